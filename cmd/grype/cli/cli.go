@@ -1,9 +1,7 @@
 package cli
 
 import (
-	"errors"
 	"os"
-	"runtime/debug"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -14,7 +12,6 @@ import (
 	"github.com/anchore/grype/cmd/grype/cli/commands"
 	grypeHandler "github.com/anchore/grype/cmd/grype/cli/ui"
 	"github.com/anchore/grype/cmd/grype/internal/ui"
-	v6 "github.com/anchore/grype/grype/db/v6"
 	"github.com/anchore/grype/grype/grypeerr"
 	"github.com/anchore/grype/internal/bus"
 	"github.com/anchore/grype/internal/log"
@@ -80,18 +77,7 @@ func SetupConfig(id clio.Identification) *clio.SetupConfig {
 		WithPostRuns(func(_ *clio.State, _ error) {
 			stereoscope.Cleanup() //nolint:staticcheck
 		}).
-		WithMapExitCode(func(err error) int {
-			// return exit code 2 to indicate when a vulnerability severity is discovered
-			// that is equal or above the given --fail-on severity value.
-			if errors.Is(err, grypeerr.ErrAboveSeverityThreshold) {
-				return 2
-			}
-			// return exit code 100 to indicate a DB upgrade is available (cmd: db check).
-			if errors.Is(err, grypeerr.ErrDBUpgradeAvailable) {
-				return 100
-			}
-			return 1
-		})
+		WithMapExitCode(grypeerr.ExitCode)
 }
 
 func create(id clio.Identification) (clio.Application, *cobra.Command) {
@@ -105,35 +91,21 @@ func create(id clio.Identification) (clio.Application, *cobra.Command) {
 	rootCmd.AddCommand(
 		commands.DB(app),
 		commands.Completion(app),
+		commands.Assert(app),
+		commands.Capabilities(app),
 		commands.Explain(app),
-		clio.VersionCommand(id, syftVersion, dbVersion),
+		commands.Merge(app),
+		commands.Triage(app),
+		commands.Baseline(app),
+		commands.VerifyResults(app),
+		commands.OfflineBundle(app),
+		clio.VersionCommand(id, versionAdditions()...),
 		clio.ConfigCommand(app, nil),
 	)
 
 	return app, rootCmd
 }
 
-func syftVersion() (string, any) {
-	buildInfo, ok := debug.ReadBuildInfo()
-	if !ok {
-		log.Debug("unable to find the buildinfo section of the binary (syft version is unknown)")
-		return "", ""
-	}
-
-	for _, d := range buildInfo.Deps {
-		if d.Path == "github.com/anchore/syft" {
-			return "Syft Version", d.Version
-		}
-	}
-
-	log.Debug("unable to find 'github.com/anchore/syft' from the buildinfo section of the binary")
-	return "", ""
-}
-
-func dbVersion() (string, any) {
-	return "Supported DB Schema", v6.ModelVersion
-}
-
 type environWithoutCI struct {
 }
 
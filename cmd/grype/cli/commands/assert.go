@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/expectations"
+	"github.com/anchore/grype/grype/grypeerr"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/bus"
+)
+
+func Assert(app clio.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "assert EXPECTATIONS_FILE [REPORT]",
+		Short: "Evaluate the results of a scan against an expectations file",
+		Long: `Evaluate the matches of a grype JSON report against the expectations of a file (such as "no critical
+vulnerabilities in these packages" or "CVE-X must be reported for package Y"), reporting which expectations passed and
+which failed. The report is read from stdin when not given (or given as "-"). Exits with code 2 when any expectation
+fails, which is useful to validate custom DB overlays and to regression test grype upgrades.`,
+		Example: `  grype alpine:3.19 -o json | grype assert expectations.yaml
+  grype assert expectations.yaml report.json`,
+		Args:    cobra.RangeArgs(1, 2),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			report := "-"
+			if len(args) > 1 {
+				report = args[1]
+			}
+			return runAssert(args[0], report)
+		},
+	}
+
+	return app.SetupCommand(cmd)
+}
+
+func runAssert(expectationsPath, reportPath string) error {
+	f, err := expectations.ReadFile(expectationsPath)
+	if err != nil {
+		return err
+	}
+
+	var doc models.Document
+	if reportPath == "-" {
+		doc, err = decodeReport(os.Stdin, "stdin")
+	} else {
+		doc, err = readReport(reportPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	results := f.Evaluate(doc)
+
+	var sb strings.Builder
+	writeAssertResults(&sb, results)
+	bus.Report(sb.String())
+
+	if len(expectations.Failed(results)) > 0 {
+		return grypeerr.ErrExpectationsNotMet
+	}
+	return nil
+}
+
+func decodeReport(r io.Reader, name string) (models.Document, error) {
+	var doc models.Document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return models.Document{}, fmt.Errorf("unable to parse report from %s (expected grype JSON): %w", name, err)
+	}
+	return doc, nil
+}
+
+func writeAssertResults(w io.Writer, results []expectations.Result) {
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+		}
+		_, _ = fmt.Fprintf(w, "%s  %s: %s\n", status, r.Expectation, r.Detail)
+	}
+	failed := len(expectations.Failed(results))
+	_, _ = fmt.Fprintf(w, "\n%d passed, %d failed\n", len(results)-failed, failed)
+}
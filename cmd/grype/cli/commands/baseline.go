@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/baseline"
+	"github.com/anchore/grype/internal/bus"
+)
+
+func Baseline(app clio.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "baseline",
+		Short: "Manage the baseline of accepted findings used with --baseline",
+		Long: `Manage a baseline file of accepted findings kept alongside the code being scanned. Scans with
+'--baseline <file>' report all findings but only fail (--fail-on, fail-on-sla) on findings that are not in the baseline.`,
+	}
+
+	cmd.AddCommand(
+		BaselineCreate(app),
+		BaselineUpdate(app),
+		BaselineShow(app),
+	)
+
+	return cmd
+}
+
+type baselineCreateOptions struct {
+	File  string `yaml:"file" json:"file" mapstructure:"file"`
+	Force bool   `yaml:"force" json:"force" mapstructure:"force"`
+}
+
+var _ clio.FlagAdder = (*baselineCreateOptions)(nil)
+
+func (o *baselineCreateOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.File, "file", "", "baseline file to create")
+	flags.BoolVarP(&o.Force, "force", "", "overwrite the baseline file if it exists")
+}
+
+func BaselineCreate(app clio.Application) *cobra.Command {
+	opts := &baselineCreateOptions{
+		File: baseline.DefaultPath,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create REPORT",
+		Short: "Create a baseline accepting all findings of a grype JSON report",
+		Long: `Create a baseline file accepting all findings of a grype JSON report (or '-' for stdin), e.g.
+
+  grype alpine:3.18 -o json | grype baseline create -`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			summary, err := runBaselineCreate(*opts, args[0], time.Now().UTC().Truncate(time.Second))
+			if err != nil {
+				return err
+			}
+			bus.Report(summary)
+			return nil
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts *baselineCreateOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+// runBaselineCreate creates the baseline file from the report, returning a summary of the baseline.
+func runBaselineCreate(opts baselineCreateOptions, reportPath string, now time.Time) (string, error) {
+	if !opts.Force {
+		if _, err := os.Stat(opts.File); err == nil {
+			return "", fmt.Errorf("baseline file %q already exists (use 'grype baseline update' or --force)", opts.File)
+		}
+	}
+
+	doc, err := readReport(reportPath)
+	if err != nil {
+		return "", err
+	}
+
+	f := baseline.New(doc, now)
+	if err := f.Write(opts.File); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s: %d findings accepted\n", opts.File, len(f.Findings)), nil
+}
+
+type baselineUpdateOptions struct {
+	File string `yaml:"file" json:"file" mapstructure:"file"`
+}
+
+var _ clio.FlagAdder = (*baselineUpdateOptions)(nil)
+
+func (o *baselineUpdateOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.File, "file", "", "baseline file to update")
+}
+
+func BaselineUpdate(app clio.Application) *cobra.Command {
+	opts := &baselineUpdateOptions{
+		File: baseline.DefaultPath,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update REPORT",
+		Short: "Update a baseline with the findings of a grype JSON report",
+		Long: `Update a baseline file from a grype JSON report (or '-' for stdin): new findings of the report are accepted and
+stale findings of the baseline that are no longer in the report are removed. Findings remaining in the baseline keep
+the time they were first accepted.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			summary, err := runBaselineUpdate(*opts, args[0], time.Now().UTC().Truncate(time.Second))
+			if err != nil {
+				return err
+			}
+			bus.Report(summary)
+			return nil
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts *baselineUpdateOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+// runBaselineUpdate updates the baseline file from the report, returning a summary of the changes.
+func runBaselineUpdate(opts baselineUpdateOptions, reportPath string, now time.Time) (string, error) {
+	f, err := baseline.ReadFile(opts.File)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("baseline file %q does not exist (use 'grype baseline create')", opts.File)
+		}
+		return "", err
+	}
+
+	doc, err := readReport(reportPath)
+	if err != nil {
+		return "", err
+	}
+
+	result := f.Update(doc, now)
+	if err := f.Write(opts.File); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s: %d findings accepted, %d stale findings removed (%d total)\n", opts.File, len(result.Added), len(result.Removed), len(f.Findings)), nil
+}
+
+type baselineShowOptions struct {
+	File   string `yaml:"file" json:"file" mapstructure:"file"`
+	Report string `yaml:"report" json:"report" mapstructure:"report"`
+	Output string `yaml:"output" json:"output" mapstructure:"output"`
+}
+
+var _ clio.FlagAdder = (*baselineShowOptions)(nil)
+
+func (o *baselineShowOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.File, "file", "", "baseline file to show")
+	flags.StringVarP(&o.Report, "report", "", "grype JSON report to detect stale findings of the baseline with (findings no longer in the report)")
+	flags.StringVarP(&o.Output, "output", "o", "format to display results (available=[table, json])")
+}
+
+func BaselineShow(app clio.Application) *cobra.Command {
+	opts := &baselineShowOptions{
+		File:   baseline.DefaultPath,
+		Output: tableOutputFormat,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "show",
+		Short:   "Show the accepted findings of a baseline",
+		Args:    cobra.NoArgs,
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runBaselineShow(*opts, os.Stdout)
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts *baselineShowOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+// baselineShowFinding is an accepted finding of a baseline, along with whether it is stale.
+type baselineShowFinding struct {
+	baseline.Finding
+	Stale bool `json:"stale"`
+}
+
+func runBaselineShow(opts baselineShowOptions, output io.Writer) error {
+	f, err := baseline.ReadFile(opts.File)
+	if err != nil {
+		return err
+	}
+
+	stale := map[string]bool{}
+	if opts.Report != "" {
+		doc, err := readReport(opts.Report)
+		if err != nil {
+			return err
+		}
+		for _, finding := range f.Stale(doc) {
+			stale[finding.ID] = true
+		}
+	}
+
+	findings := make([]baselineShowFinding, 0, len(f.Findings))
+	for _, finding := range f.Findings {
+		findings = append(findings, baselineShowFinding{Finding: finding, Stale: stale[finding.ID]})
+	}
+
+	switch opts.Output {
+	case tableOutputFormat:
+		return presentBaselineTable(opts.File, f, findings, len(stale), opts.Report != "", output)
+	case jsonOutputFormat:
+		enc := json.NewEncoder(output)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", " ")
+		return enc.Encode(findings)
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.Output)
+	}
+}
+
+func presentBaselineTable(path string, f *baseline.File, findings []baselineShowFinding, staleCount int, withStatus bool, output io.Writer) error {
+	columns := []string{"Vulnerability", "Package", "Type", "Version", "Accepted"}
+	if withStatus {
+		columns = append(columns, "Status")
+	}
+
+	rows := [][]string{}
+	for _, finding := range findings {
+		row := []string{finding.Vulnerability, finding.Package, finding.Type, finding.Version, finding.Accepted.Format(time.DateOnly)}
+		if withStatus {
+			status := "found"
+			if finding.Stale {
+				status = "stale"
+			}
+			row = append(row, status)
+		}
+		rows = append(rows, row)
+	}
+
+	sb := &strings.Builder{}
+	if len(rows) > 0 {
+		table := newTable(sb, columns)
+		if err := table.Bulk(rows); err != nil {
+			return fmt.Errorf("failed to add table rows: %w", err)
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(sb, "%s: %d findings accepted (updated %s)\n", path, len(findings), f.Updated.Format(time.DateOnly))
+	if staleCount > 0 {
+		fmt.Fprintf(sb, "%d findings are stale - run 'grype baseline update' to remove them\n", staleCount)
+	}
+
+	_, err := io.WriteString(output, sb.String())
+	return err
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/baseline"
+	"github.com/anchore/grype/grype/presenter/models"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func writeBaselineReport(t *testing.T, path string, findings ...[2]string) {
+	t.Helper()
+	var doc models.Document
+	for _, f := range findings {
+		doc.Matches = append(doc.Matches, models.Match{
+			Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: f[0]}},
+			Artifact:      models.Package{Name: f[1], Version: "1.0.0", Type: syftPkg.ApkPkg},
+		})
+	}
+	by, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, by, 0o600))
+}
+
+func Test_baselineWorkflow(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, baseline.DefaultPath)
+	report := filepath.Join(dir, "report.json")
+	created := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	writeBaselineReport(t, report, [2]string{"CVE-2023-1", "openssl"}, [2]string{"CVE-2022-9", "busybox"})
+	summary, err := runBaselineCreate(baselineCreateOptions{File: file}, report, created)
+	require.NoError(t, err)
+	assert.Equal(t, file+": 2 findings accepted\n", summary)
+
+	// an existing baseline is only overwritten with --force
+	_, err = runBaselineCreate(baselineCreateOptions{File: file}, report, created)
+	require.ErrorContains(t, err, "already exists")
+
+	// busybox was upgraded to a fixed version
+	writeBaselineReport(t, report, [2]string{"CVE-2023-1", "openssl"}, [2]string{"CVE-2024-7", "zlib"})
+
+	out := &strings.Builder{}
+	require.NoError(t, runBaselineShow(baselineShowOptions{File: file, Report: report, Output: tableOutputFormat}, out))
+	assert.Contains(t, out.String(), "2 findings accepted (updated 2025-01-02)")
+	assert.Contains(t, out.String(), "1 findings are stale")
+
+	out.Reset()
+	require.NoError(t, runBaselineShow(baselineShowOptions{File: file, Report: report, Output: jsonOutputFormat}, out))
+	var shown []baselineShowFinding
+	require.NoError(t, json.Unmarshal([]byte(out.String()), &shown))
+	require.Len(t, shown, 2)
+	assert.Equal(t, "CVE-2022-9", shown[0].Vulnerability)
+	assert.True(t, shown[0].Stale)
+	assert.False(t, shown[1].Stale)
+
+	summary, err = runBaselineUpdate(baselineUpdateOptions{File: file}, report, created.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, file+": 1 findings accepted, 1 stale findings removed (2 total)\n", summary)
+	f, err := baseline.ReadFile(file)
+	require.NoError(t, err)
+	var vulns []string
+	for _, finding := range f.Findings {
+		vulns = append(vulns, finding.Vulnerability)
+	}
+	assert.Equal(t, []string{"CVE-2023-1", "CVE-2024-7"}, vulns)
+
+	_, err = runBaselineUpdate(baselineUpdateOptions{File: filepath.Join(dir, "missing.json")}, report, created)
+	require.ErrorContains(t, err, "use 'grype baseline create'")
+}
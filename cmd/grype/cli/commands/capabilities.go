@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/internal/bus"
+)
+
+type capabilitiesOptions struct {
+	Output               string `yaml:"output" json:"output"`
+	options.MatchCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+var _ clio.FlagAdder = (*capabilitiesOptions)(nil)
+
+func (o *capabilitiesOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.Output, "output", "o", "format to display results (available=[table, json])")
+}
+
+func Capabilities(app clio.Application) *cobra.Command {
+	opts := &capabilitiesOptions{
+		Output:       tableOutputFormat,
+		MatchCommand: *options.DefaultMatchCommand(),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "capabilities",
+		Short: "List the vulnerability matchers, the package types they handle and how",
+		Long: `List the vulnerability matchers, the package types (ecosystems) they handle, the version format used to compare
+versions of each package type, and whether CPEs are searched (per the match configuration). Versions of package types
+without a specific format ("per-advisory") are compared per the format of the vulnerability records (e.g. semver),
+falling back to fuzzy comparison.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runCapabilities(opts)
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Hidden                *capabilitiesOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.MatchCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, MatchCommand: &opts.MatchCommand})
+}
+
+// capability is the reported form of a matcher.Capability.
+type capability struct {
+	Matcher      string               `json:"matcher"`
+	PackageTypes []packageTypeSupport `json:"packageTypes"`
+	Fallback     bool                 `json:"fallback"`
+	CPEs         string               `json:"cpes"`
+}
+
+type packageTypeSupport struct {
+	Type          string `json:"type"`
+	VersionFormat string `json:"versionFormat"`
+}
+
+func runCapabilities(opts *capabilitiesOptions) error {
+	mc := getMatcherConfig(&options.Grype{Match: opts.Match, ExternalSources: opts.ExternalSources})
+	capabilities := toCapabilities(matcher.Capabilities(mc))
+
+	sb := &strings.Builder{}
+	switch opts.Output {
+	case tableOutputFormat, textOutputFormat:
+		if err := displayCapabilitiesTable(capabilities, sb); err != nil {
+			return err
+		}
+	case jsonOutputFormat:
+		if err := displayCapabilitiesJSON(capabilities, sb); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.Output)
+	}
+	bus.Report(sb.String())
+
+	return nil
+}
+
+func toCapabilities(capabilities []matcher.Capability) []capability {
+	res := make([]capability, 0, len(capabilities))
+	for _, c := range capabilities {
+		types := make([]packageTypeSupport, 0, len(c.PackageTypes))
+		for _, t := range c.PackageTypes {
+			types = append(types, packageTypeSupport{
+				Type:          string(t.Type),
+				VersionFormat: versionFormatName(t.VersionFormat.String()),
+			})
+		}
+		res = append(res, capability{
+			Matcher:      string(c.Matcher),
+			PackageTypes: types,
+			Fallback:     c.Fallback,
+			CPEs:         string(c.CPEs),
+		})
+	}
+	return res
+}
+
+// perAdvisoryVersionFormat is reported for package types whose versions are compared per the format of the
+// vulnerability records matched.
+const perAdvisoryVersionFormat = "per-advisory"
+
+func versionFormatName(format string) string {
+	if format == "Unknown" {
+		return perAdvisoryVersionFormat
+	}
+	return format
+}
+
+func displayCapabilitiesTable(capabilities []capability, output io.Writer) error {
+	rows := [][]string{}
+	for _, c := range capabilities {
+		if c.Fallback {
+			rows = append(rows, []string{c.Matcher, "(any other)", perAdvisoryVersionFormat, c.CPEs})
+			continue
+		}
+		for _, t := range c.PackageTypes {
+			rows = append(rows, []string{c.Matcher, t.Type, t.VersionFormat, c.CPEs})
+		}
+	}
+
+	table := newTable(output, []string{"Matcher", "Package Type", "Version Format", "CPE Search"})
+
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to add table rows: %w", err)
+	}
+	return table.Render()
+}
+
+func displayCapabilitiesJSON(capabilities []capability, output io.Writer) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", " ")
+	if err := encoder.Encode(capabilities); err != nil {
+		return fmt.Errorf("cannot display json: %w", err)
+	}
+	return nil
+}
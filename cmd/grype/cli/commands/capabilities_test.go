@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/grype/version"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func testCapabilities() []capability {
+	return toCapabilities([]matcher.Capability{
+		{
+			Matcher: match.DpkgMatcher,
+			PackageTypes: []matcher.PackageTypeCapability{
+				{Type: syftPkg.DebPkg, VersionFormat: version.DebFormat},
+			},
+			CPEs: matcher.CPEsForEOLDistros,
+		},
+		{
+			Matcher: match.HexMatcher,
+			PackageTypes: []matcher.PackageTypeCapability{
+				{Type: syftPkg.HexPkg, VersionFormat: version.UnknownFormat},
+				{Type: syftPkg.ErlangOTPPkg, VersionFormat: version.UnknownFormat},
+			},
+			CPEs: matcher.CPEsDisabled,
+		},
+		{
+			Matcher:  match.StockMatcher,
+			Fallback: true,
+			CPEs:     matcher.CPEsEnabled,
+		},
+	})
+}
+
+func TestDisplayCapabilitiesTable(t *testing.T) {
+	expectedOutput := `MATCHER        PACKAGE TYPE  VERSION FORMAT  CPE SEARCH   
+dpkg-matcher   deb           Deb             eol-distros  
+hex-matcher    hex           per-advisory    disabled     
+hex-matcher    erlang-otp    per-advisory    disabled     
+stock-matcher  (any other)   per-advisory    enabled      
+`
+
+	var output bytes.Buffer
+	require.NoError(t, displayCapabilitiesTable(testCapabilities(), &output))
+	require.Equal(t, expectedOutput, output.String())
+}
+
+func TestDisplayCapabilitiesJSON(t *testing.T) {
+	var output bytes.Buffer
+	require.NoError(t, displayCapabilitiesJSON(testCapabilities()[2:], &output))
+	require.JSONEq(t, `[{"matcher": "stock-matcher", "packageTypes": [], "fallback": true, "cpes": "enabled"}]`, output.String())
+}
@@ -7,9 +7,12 @@ import (
 )
 
 const (
-	jsonOutputFormat  = "json"
-	tableOutputFormat = "table"
-	textOutputFormat  = "text"
+	jsonOutputFormat     = "json"
+	tableOutputFormat    = "table"
+	textOutputFormat     = "text"
+	csvOutputFormat      = "csv"
+	templateOutputFormat = "template"
+	markdownOutputFormat = "markdown"
 )
 
 func DB(app clio.Application) *cobra.Command {
@@ -21,13 +24,17 @@ func DB(app clio.Application) *cobra.Command {
 	db.AddCommand(
 		DBCheck(app),
 		DBDelete(app),
+		DBRollback(app),
 		DBImport(app),
 		DBList(app),
 		DBStatus(app),
 		DBUpdate(app),
 		DBSearch(app),
+		DBShow(app),
+		DBServe(app),
 		DBProviders(app),
 		DBDiff(app),
+		DBMigrate(app),
 	)
 
 	return db
@@ -143,7 +143,8 @@ func runDBDiff(opts dbDiffOptions) error {
 }
 
 func outputText(writer io.Writer, result *diff.Result) error {
-	columns := []string{"Ecosystem", "Package"}
+	// the withdrawn column (vulnerabilities withdrawn or rejected since the old database) is hidden when empty
+	columns := []string{"Ecosystem", "Package", "Withdrawn"}
 
 	t := newTable(writer, columns)
 
@@ -152,7 +153,11 @@ func outputText(writer io.Writer, result *diff.Result) error {
 		if pkg.CPE != "" {
 			name = pkg.CPE
 		}
-		err := t.Append(pkg.Ecosystem, name)
+		var withdrawn []string
+		for _, v := range pkg.Vulnerabilities.Withdrawn {
+			withdrawn = append(withdrawn, v.ID)
+		}
+		err := t.Append(pkg.Ecosystem, name, strings.Join(withdrawn, ", "))
 		if err != nil {
 			return err
 		}
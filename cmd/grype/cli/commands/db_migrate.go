@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/migrate"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/log"
+)
+
+type dbMigrateOptions struct {
+	Dir    string `yaml:"dir" json:"dir" mapstructure:"dir"`
+	Import bool   `yaml:"import" json:"import" mapstructure:"import"`
+}
+
+var _ clio.FlagAdder = (*dbMigrateOptions)(nil)
+
+func (o *dbMigrateOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.Dir, "dir", "d", "directory to write the v6 database to")
+	flags.BoolVarP(&o.Import, "import", "", "import the v6 database as the database used by grype")
+}
+
+func DBMigrate(app clio.Application) *cobra.Command {
+	opts := &dbMigrateOptions{}
+	dbOpts := options.DefaultDatabaseCommand(app.ID())
+
+	cmd := &cobra.Command{
+		Use:   "migrate V5_DB_FILE",
+		Short: "Convert a v5 vulnerability database into a v6 database",
+		Long: `Convert a local v5 vulnerability database (vulnerability.db), including a custom-built one, into a v6 database.
+Provider metadata (the provider-metadata.json next to the v5 database) and the build time of the v5 database are kept.
+Use --dir to write the v6 database to a directory, and/or --import to use it as the database of grype, e.g.
+
+  grype db migrate ./v5/vulnerability.db --import`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			summary, err := runDBMigrate(*opts, *dbOpts, args[0])
+			if err != nil {
+				return err
+			}
+			bus.Report(summary)
+			return nil
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts                     *dbMigrateOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts, dbOpts})
+}
+
+// runDBMigrate migrates the v5 database, returning a summary of the migration.
+func runDBMigrate(opts dbMigrateOptions, dbOpts options.DatabaseCommand, v5Path string) (string, error) {
+	if opts.Dir == "" && !opts.Import {
+		return "", fmt.Errorf("either --dir or --import is required")
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		tempDir, err := os.MkdirTemp("", "grype-db-migrate-")
+		if err != nil {
+			return "", fmt.Errorf("unable to create temp dir: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+		dir = tempDir
+	} else if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("unable to create %q: %w", dir, err)
+	}
+
+	dbPath := v6.Config{DBDirPath: dir}.DBFilePath()
+	if _, err := os.Stat(dbPath); err == nil {
+		return "", fmt.Errorf("%q already exists", dbPath)
+	}
+
+	log.WithFields("from", v5Path, "to", dbPath).Info("migrating v5 vulnerability database")
+	summary, err := migrate.FromV5(migrate.V5Config{DBFilePath: v5Path, DBDirPath: dir})
+	if err != nil {
+		return "", fmt.Errorf("unable to migrate v5 vulnerability database: %w", err)
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "migrated %d vulnerabilities (built %s) from providers: %s\n", summary.Vulnerabilities, summary.Built.Format(time.RFC3339), strings.Join(summary.Providers, ", "))
+	if summary.Dropped > 0 {
+		fmt.Fprintf(sb, "%d records could not be migrated (unsupported namespaces)\n", summary.Dropped)
+	}
+
+	if opts.Import {
+		if err := runDBImport(dbOpts, dbPath); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(sb, "imported the v6 database to %s\n", dbOpts.DB.Dir)
+	} else {
+		fmt.Fprintf(sb, "wrote %s (run 'grype db import %s' to use it)\n", dbPath, dbPath)
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+)
+
+func DBRollback(app clio.Application) *cobra.Command {
+	opts := options.DefaultDatabaseCommand(app.ID())
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Switch back to the previously installed vulnerability database build",
+		Long: `Switch back to the most recently retained vulnerability database build, discarding the current one.
+Previous builds are only retained when db.retain-previous is greater than zero.`,
+		Args:    cobra.ExactArgs(0),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDBRollback(*opts)
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+func runDBRollback(opts options.DatabaseCommand) error {
+	client, err := distribution.NewClient(opts.ToClientConfig())
+	if err != nil {
+		return fmt.Errorf("unable to create distribution client: %w", err)
+	}
+	c, err := installation.NewCurator(opts.ToCuratorConfig(), client)
+	if err != nil {
+		return fmt.Errorf("unable to create curator: %w", err)
+	}
+
+	description, err := c.Rollback()
+	if err != nil {
+		if errors.Is(err, installation.ErrNoPreviousDB) {
+			return fmt.Errorf("unable to roll back vulnerability database: %w (set db.retain-previous to keep previous builds on update)", err)
+		}
+		return fmt.Errorf("unable to roll back vulnerability database: %w", err)
+	}
+
+	return stderrPrintLnf("Vulnerability database rolled back to build %s", description.Built.UTC().Format(time.RFC3339))
+}
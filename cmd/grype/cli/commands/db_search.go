@@ -1,22 +1,31 @@
 package commands
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 
 	"github.com/anchore/clio"
+	"github.com/anchore/go-homedir"
 	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbsearch"
 	"github.com/anchore/grype/cmd/grype/cli/options"
 	v6 "github.com/anchore/grype/grype/db/v6"
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
+	presenterTemplate "github.com/anchore/grype/grype/presenter/template"
 	"github.com/anchore/grype/internal/bus"
 	"github.com/anchore/grype/internal/log"
 )
@@ -27,6 +36,8 @@ type dbSearchMatchOptions struct {
 	Package       options.DBSearchPackages        `yaml:",inline" mapstructure:",squash"`
 	OS            options.DBSearchOSs             `yaml:",inline" mapstructure:",squash"`
 	Bounds        options.DBSearchBounds          `yaml:",inline" mapstructure:",squash"`
+	Summary       options.DBSearchSummary         `yaml:",inline" mapstructure:",squash"`
+	Emit          options.DBSearchEmit            `yaml:",inline" mapstructure:",squash"`
 
 	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
 }
@@ -73,8 +84,9 @@ func hasAnyPrefix(s string, prefixes ...string) bool {
 }
 
 func DBSearch(app clio.Application) *cobra.Command {
+	id := app.ID()
 	opts := &dbSearchMatchOptions{
-		Format: options.DefaultDBSearchFormat(),
+		Format: options.DefaultDBSearchReportFormat(),
 		Vulnerability: options.DBSearchVulnerabilities{
 			UseVulnIDFlag: true,
 		},
@@ -98,6 +110,10 @@ func DBSearch(app clio.Application) *cobra.Command {
 
     $ grype db search --pkg log4j --vuln CVE-2021-44228
 
+  Search for affected packages of an ecosystem with vulnerabilities published in the last week:
+
+    $ grype db search --ecosystem npm --published-after 7d
+
   Search for affected packages by PURL (note: version is not considered):
 
     $ grype db search --pkg 'pkg:rpm/redhat/openssl' # or: '--ecosystem rpm --pkg openssl
@@ -105,7 +121,23 @@ func DBSearch(app clio.Application) *cobra.Command {
   Search for affected packages by CPE (note: version/update is not considered):
 
     $ grype db search --pkg 'cpe:2.3:a:jetty:jetty_http_server:*:*:*:*:*:*:*:*'
-    $ grype db search --pkg 'cpe:/a:jetty:jetty_http_server'`,
+    $ grype db search --pkg 'cpe:/a:jetty:jetty_http_server'
+
+  Report the affected packages as CSV, or with a Go template (given the same structure as the JSON output):
+
+    $ grype db search --pkg log4j -o csv
+    $ grype db search --pkg log4j -o template -t report.tmpl
+
+  Count the affected packages of an ecosystem, in total or per provider, severity, or ecosystem:
+
+    $ grype db search --ecosystem npm --count
+    $ grype db search --ecosystem npm --summarize-by severity
+
+  Start documenting that the vulnerabilities of a package version do not apply, as an ignore file or OpenVEX document
+  (without a reason the ignore rules are left for a reason to be filled in, and the VEX statements are under investigation):
+
+    $ grype db search --pkg pkg:npm/lodash@4.17.20 --emit-ignore-rules > .grype-ignore.yaml
+    $ grype db search --pkg pkg:npm/lodash@4.17.20 --emit-vex --justification vulnerable_code_not_in_execute_path`,
 		PreRunE: disableUI(app),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			if len(args) > 0 {
@@ -114,7 +146,7 @@ func DBSearch(app clio.Application) *cobra.Command {
 					return err
 				}
 			}
-			err = runDBSearchMatches(*opts)
+			err = runDBSearchMatches(id, *opts)
 			if err != nil {
 				if errors.Is(err, dbsearch.ErrNoSearchCriteria) {
 					_ = cmd.Usage()
@@ -127,6 +159,8 @@ func DBSearch(app clio.Application) *cobra.Command {
 
 	cmd.AddCommand(
 		DBSearchVulnerabilities(app),
+		DBSearchKnownExploited(app),
+		DBSearchEPSS(app),
 	)
 
 	// prevent from being shown in the grype config
@@ -138,7 +172,11 @@ func DBSearch(app clio.Application) *cobra.Command {
 	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, DatabaseCommand: &opts.DatabaseCommand})
 }
 
-func runDBSearchMatches(opts dbSearchMatchOptions) error {
+func runDBSearchMatches(id clio.Identification, opts dbSearchMatchOptions) error {
+	if opts.Summary.Enabled() && opts.Emit.Enabled() {
+		return errors.New("cannot count the results when emitting VEX statements or ignore rules")
+	}
+
 	client, err := distribution.NewClient(opts.ToClientConfig())
 	if err != nil {
 		return fmt.Errorf("unable to create distribution client: %w", err)
@@ -158,7 +196,7 @@ func runDBSearchMatches(opts dbSearchMatchOptions) error {
 		return err
 	}
 
-	rows, queryErr := dbsearch.FindMatches(reader, dbsearch.AffectedPackagesOptions{
+	criteria := dbsearch.AffectedPackagesOptions{
 		Vulnerability:         opts.Vulnerability.Specs,
 		Package:               opts.Package.PkgSpecs,
 		CPE:                   opts.Package.CPESpecs,
@@ -166,7 +204,18 @@ func runDBSearchMatches(opts dbSearchMatchOptions) error {
 		AllowBroadCPEMatching: opts.Package.AllowBroadCPEMatching,
 		RecordLimit:           opts.Bounds.RecordLimit,
 		FixedStates:           opts.Vulnerability.FixedState,
-	})
+		Severities:            opts.Vulnerability.Severity,
+	}
+
+	if opts.Summary.Enabled() {
+		summary, err := dbsearch.SummarizeAffectedPackages(reader, criteria, opts.Summary.SummarizeBy)
+		if err != nil {
+			return err
+		}
+		return reportDBSearchSummary(opts.Format, summary)
+	}
+
+	rows, queryErr := dbsearch.FindMatches(reader, criteria)
 	if queryErr != nil {
 		if !errors.Is(queryErr, v6.ErrLimitReached) {
 			return queryErr
@@ -174,7 +223,17 @@ func runDBSearchMatches(opts dbSearchMatchOptions) error {
 	}
 
 	sb := &strings.Builder{}
-	err = presentDBSearchMatches(opts.Format.Output, rows, sb)
+	if opts.Emit.Enabled() {
+		err = presentDBSearchSuppressions(opts.Emit, dbsearch.SuppressionOptions{
+			Versions:      opts.Package.Versions,
+			Reason:        opts.Emit.Reason,
+			Justification: opts.Emit.Justification,
+			Author:        id.Name,
+			Timestamp:     time.Now(),
+		}, rows, sb)
+	} else {
+		err = presentDBSearchMatches(opts.Format, rows, sb)
+	}
 	rep := sb.String()
 	if rep != "" {
 		bus.Report(rep)
@@ -186,8 +245,14 @@ func runDBSearchMatches(opts dbSearchMatchOptions) error {
 	return queryErr
 }
 
-func presentDBSearchMatches(outputFormat string, structuredRows dbsearch.Matches, output io.Writer) error {
-	switch outputFormat {
+func presentDBSearchMatches(format options.DBSearchFormat, structuredRows dbsearch.Matches, output io.Writer) error {
+	columns := []string{"Vulnerability", "Package", "Ecosystem", "Namespace", "Version Constraint"}
+	if structuredRows == nil {
+		// always allocate the top level collection
+		structuredRows = dbsearch.Matches{}
+	}
+
+	switch format.Output {
 	case tableOutputFormat:
 		if len(structuredRows) == 0 {
 			bus.Notify("No results found")
@@ -195,17 +260,17 @@ func presentDBSearchMatches(outputFormat string, structuredRows dbsearch.Matches
 		}
 		rows := renderDBSearchPackagesTableRows(structuredRows.Flatten())
 
-		table := newTable(output, []string{"Vulnerability", "Package", "Ecosystem", "Namespace", "Version Constraint"})
+		table := newTable(output, columns)
 
 		if err := table.Bulk(rows); err != nil {
 			return fmt.Errorf("failed to add table rows: %+v", err)
 		}
 		return table.Render()
+	case csvOutputFormat:
+		return presentDBSearchCSV(columns, renderDBSearchPackagesTableRows(structuredRows.Flatten()), output)
+	case templateOutputFormat:
+		return presentDBSearchTemplate(format.TemplateFile, structuredRows, output)
 	case jsonOutputFormat:
-		if structuredRows == nil {
-			// always allocate the top level collection
-			structuredRows = dbsearch.Matches{}
-		}
 		enc := json.NewEncoder(output)
 		enc.SetEscapeHTML(false)
 		enc.SetIndent("", " ")
@@ -213,7 +278,120 @@ func presentDBSearchMatches(outputFormat string, structuredRows dbsearch.Matches
 			return fmt.Errorf("failed to encode diff information: %+v", err)
 		}
 	default:
-		return fmt.Errorf("unsupported output format: %s", outputFormat)
+		return fmt.Errorf("unsupported output format: %s", format.Output)
+	}
+	return nil
+}
+
+// presentDBSearchSuppressions writes the starter ignore file or OpenVEX document for the affected packages found.
+func presentDBSearchSuppressions(emit options.DBSearchEmit, opts dbsearch.SuppressionOptions, rows dbsearch.Matches, output io.Writer) error {
+	if emit.IgnoreRules {
+		by, err := dbsearch.IgnoreRules(rows.Flatten(), opts)
+		if err != nil {
+			return fmt.Errorf("failed to encode ignore rules: %w", err)
+		}
+		_, err = output.Write(by)
+		return err
+	}
+
+	doc := dbsearch.VEX(rows.Flatten(), opts)
+	if err := doc.ToJSON(output); err != nil {
+		return fmt.Errorf("failed to encode VEX document: %w", err)
+	}
+	return nil
+}
+
+func reportDBSearchSummary(format options.DBSearchFormat, summary *dbsearch.Summary) error {
+	sb := &strings.Builder{}
+	err := presentDBSearchSummary(format, summary, sb)
+	rep := sb.String()
+	if rep != "" {
+		bus.Report(rep)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to present search summary: %w", err)
+	}
+	return nil
+}
+
+// presentDBSearchSummary shows the number of search results, in total or per value of the summarized attribute.
+func presentDBSearchSummary(format options.DBSearchFormat, summary *dbsearch.Summary, output io.Writer) error {
+	columns := []string{"Count"}
+	rows := [][]string{{strconv.Itoa(summary.Total)}}
+	if summary.By != "" {
+		columns = []string{cases.Title(language.English).String(summary.By), "Count"}
+		rows = nil
+		for _, g := range summary.Groups {
+			rows = append(rows, []string{g.Value, strconv.Itoa(g.Count)})
+		}
+	}
+
+	switch format.Output {
+	case tableOutputFormat:
+		if summary.By == "" {
+			_, err := fmt.Fprintln(output, summary.Total)
+			return err
+		}
+		if len(rows) == 0 {
+			bus.Notify("No results found")
+			return nil
+		}
+
+		table := newTable(output, columns)
+
+		if err := table.Bulk(rows); err != nil {
+			return fmt.Errorf("failed to add table rows: %+v", err)
+		}
+		return table.Render()
+	case csvOutputFormat:
+		return presentDBSearchCSV(columns, rows, output)
+	case templateOutputFormat:
+		return presentDBSearchTemplate(format.TemplateFile, summary, output)
+	case jsonOutputFormat:
+		enc := json.NewEncoder(output)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", " ")
+		if err := enc.Encode(summary); err != nil {
+			return fmt.Errorf("failed to encode search summary: %+v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", format.Output)
+	}
+	return nil
+}
+
+// presentDBSearchCSV writes the given table rows as CSV, with the table columns as the header.
+func presentDBSearchCSV(columns []string, rows [][]string, output io.Writer) error {
+	w := csv.NewWriter(output)
+	if err := w.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV rows: %w", err)
+	}
+	return nil
+}
+
+// presentDBSearchTemplate formats the search results (the same structure as the JSON output) with the given Go
+// template file, which has the same functions available as the templates of scan reports.
+func presentDBSearchTemplate(templateFile string, results any, output io.Writer) error {
+	expandedPath, err := homedir.Expand(templateFile)
+	if err != nil {
+		return fmt.Errorf("unable to expand path %q", templateFile)
+	}
+
+	contents, err := os.ReadFile(expandedPath)
+	if err != nil {
+		return fmt.Errorf("unable to get output template: %w", err)
+	}
+
+	tmpl, err := template.New(expandedPath).Funcs(presenterTemplate.FuncMap).Parse(string(contents))
+	if err != nil {
+		return fmt.Errorf("unable to parse template: %w", err)
+	}
+
+	if err := tmpl.Execute(output, results); err != nil {
+		return fmt.Errorf("unable to execute supplied template: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,262 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbsearch"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/internal/bus"
+)
+
+type dbSearchKnownExploitedOptions struct {
+	Format         options.DBSearchFormat         `yaml:",inline" mapstructure:",squash"`
+	KnownExploited options.DBSearchKnownExploited `yaml:",inline" mapstructure:",squash"`
+	Bounds         options.DBSearchBounds         `yaml:",inline" mapstructure:",squash"`
+
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+type dbSearchEPSSOptions struct {
+	Format options.DBSearchFormat `yaml:",inline" mapstructure:",squash"`
+	EPSS   options.DBSearchEPSS   `yaml:",inline" mapstructure:",squash"`
+	Bounds options.DBSearchBounds `yaml:",inline" mapstructure:",squash"`
+
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+func DBSearchKnownExploited(app clio.Application) *cobra.Command {
+	opts := &dbSearchKnownExploitedOptions{
+		Format:          options.DefaultDBSearchFormat(),
+		Bounds:          options.DefaultDBSearchBounds(),
+		DatabaseCommand: *options.DefaultDatabaseCommand(app.ID()),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "kev [CVE...]",
+		Aliases: []string{"known-exploited"},
+		Short:   "Search the CISA Known Exploited Vulnerabilities (KEV) entries within the DB (supports DB schema v6+ only)",
+		Example: `
+  Search for KEV entries added during 2024 that are known to be used in ransomware campaigns:
+
+    $ grype db search kev --added-after 2024-01-01 --added-before 2024-12-31 --ransomware known
+
+  Show the KEV entry for a specific CVE:
+
+    $ grype db search kev CVE-2021-44228`,
+		PreRunE: disableUI(app),
+		Args: func(_ *cobra.Command, args []string) error {
+			opts.KnownExploited.CVEs = args
+			return opts.KnownExploited.PostLoad()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDBSearchKnownExploited(*opts)
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Hidden                   *dbSearchKnownExploitedOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, DatabaseCommand: &opts.DatabaseCommand})
+}
+
+func DBSearchEPSS(app clio.Application) *cobra.Command {
+	opts := &dbSearchEPSSOptions{
+		Format:          options.DefaultDBSearchFormat(),
+		Bounds:          options.DefaultDBSearchBounds(),
+		DatabaseCommand: *options.DefaultDatabaseCommand(app.ID()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "epss [CVE...]",
+		Short: "Search the Exploit Prediction Scoring System (EPSS) scores within the DB (supports DB schema v6+ only)",
+		Example: `
+  Show the highest scoring entries (results are ordered by descending score):
+
+    $ grype db search epss --limit 20
+
+  Search for entries in the top 1% with a score of at least 0.5:
+
+    $ grype db search epss --min-percentile 0.99 --min-score 0.5
+
+  Show the EPSS score for a specific CVE:
+
+    $ grype db search epss CVE-2021-44228`,
+		PreRunE: disableUI(app),
+		Args: func(_ *cobra.Command, args []string) error {
+			opts.EPSS.CVEs = args
+			return opts.EPSS.PostLoad()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDBSearchEPSS(*opts)
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Hidden                   *dbSearchEPSSOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, DatabaseCommand: &opts.DatabaseCommand})
+}
+
+func newDBSearchReader(opts options.DatabaseCommand) (v6.Reader, error) {
+	client, err := distribution.NewClient(opts.ToClientConfig())
+	if err != nil {
+		return nil, fmt.Errorf("unable to create distribution client: %w", err)
+	}
+
+	c, err := installation.NewCurator(opts.ToCuratorConfig(), client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create curator: %w", err)
+	}
+
+	reader, err := c.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get DB reader: %w", err)
+	}
+	return reader, nil
+}
+
+func runDBSearchKnownExploited(opts dbSearchKnownExploitedOptions) error {
+	reader, err := newDBSearchReader(opts.DatabaseCommand)
+	if err != nil {
+		return err
+	}
+
+	rows, queryErr := dbsearch.FindKnownExploited(reader, dbsearch.KnownExploitedOptions{
+		KnownExploited: opts.KnownExploited.Specs,
+		RecordLimit:    opts.Bounds.RecordLimit,
+	})
+	if queryErr != nil && !errors.Is(queryErr, v6.ErrLimitReached) {
+		return queryErr
+	}
+
+	sb := &strings.Builder{}
+	err = presentDBSearchKnownExploited(opts.Format.Output, rows, sb)
+	rep := sb.String()
+	if rep != "" {
+		bus.Report(rep)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to present search results: %w", err)
+	}
+
+	return queryErr
+}
+
+func runDBSearchEPSS(opts dbSearchEPSSOptions) error {
+	reader, err := newDBSearchReader(opts.DatabaseCommand)
+	if err != nil {
+		return err
+	}
+
+	rows, queryErr := dbsearch.FindEPSS(reader, dbsearch.EPSSOptions{
+		EPSS:        opts.EPSS.Specs,
+		RecordLimit: opts.Bounds.RecordLimit,
+	})
+	if queryErr != nil && !errors.Is(queryErr, v6.ErrLimitReached) {
+		return queryErr
+	}
+
+	sb := &strings.Builder{}
+	err = presentDBSearchEPSS(opts.Format.Output, rows, sb)
+	rep := sb.String()
+	if rep != "" {
+		bus.Report(rep)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to present search results: %w", err)
+	}
+
+	return queryErr
+}
+
+func presentDBSearchKnownExploited(outputFormat string, structuredRows []dbsearch.KnownExploited, output io.Writer) error {
+	switch outputFormat {
+	case tableOutputFormat:
+		if len(structuredRows) == 0 {
+			bus.Notify("No results found")
+			return nil
+		}
+
+		var rows [][]string
+		for _, r := range structuredRows {
+			rows = append(rows, []string{r.CVE, r.VendorProject, r.Product, r.DateAdded, r.DueDate, r.KnownRansomwareCampaignUse})
+		}
+
+		table := newTable(output, []string{"CVE", "Vendor", "Product", "Added", "Due", "Ransomware"})
+
+		if err := table.Bulk(rows); err != nil {
+			return fmt.Errorf("failed to add table rows: %+v", err)
+		}
+		return table.Render()
+	case jsonOutputFormat:
+		if structuredRows == nil {
+			// always allocate the top level collection
+			structuredRows = []dbsearch.KnownExploited{}
+		}
+		return encodeDBSearchJSON(output, structuredRows)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+func presentDBSearchEPSS(outputFormat string, structuredRows []dbsearch.EPSS, output io.Writer) error {
+	switch outputFormat {
+	case tableOutputFormat:
+		if len(structuredRows) == 0 {
+			bus.Notify("No results found")
+			return nil
+		}
+
+		var rows [][]string
+		for _, r := range structuredRows {
+			rows = append(rows, []string{
+				r.CVE,
+				strconv.FormatFloat(r.EPSS, 'f', -1, 64),
+				strconv.FormatFloat(r.Percentile, 'f', -1, 64),
+				r.Date,
+			})
+		}
+
+		table := newTable(output, []string{"CVE", "Score", "Percentile", "Date"})
+
+		if err := table.Bulk(rows); err != nil {
+			return fmt.Errorf("failed to add table rows: %+v", err)
+		}
+		return table.Render()
+	case jsonOutputFormat:
+		if structuredRows == nil {
+			// always allocate the top level collection
+			structuredRows = []dbsearch.EPSS{}
+		}
+		return encodeDBSearchJSON(output, structuredRows)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+func encodeDBSearchJSON(output io.Writer, v any) error {
+	enc := json.NewEncoder(output)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode search results: %+v", err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -156,3 +157,68 @@ func TestMimicV5Namespace(t *testing.T) {
 		})
 	}
 }
+
+func TestPresentDBSearchSummary(t *testing.T) {
+	count := &dbsearch.Summary{Total: 3}
+	bySeverity := &dbsearch.Summary{
+		By:    dbsearch.SummarizeBySeverity,
+		Total: 3,
+		Groups: []dbsearch.SummaryGroup{
+			{Value: "critical", Count: 2},
+			{Value: "low", Count: 1},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  options.DBSearchFormat
+		summary *dbsearch.Summary
+		want    string
+	}{
+		{
+			name:    "count table",
+			format:  options.DBSearchFormat{Output: "table"},
+			summary: count,
+			want:    "3\n",
+		},
+		{
+			name:    "count csv",
+			format:  options.DBSearchFormat{Output: "csv"},
+			summary: count,
+			want:    "Count\n3\n",
+		},
+		{
+			name:    "summary csv",
+			format:  options.DBSearchFormat{Output: "csv"},
+			summary: bySeverity,
+			want:    "Severity,Count\ncritical,2\nlow,1\n",
+		},
+		{
+			name:    "summary json",
+			format:  options.DBSearchFormat{Output: "json"},
+			summary: bySeverity,
+			want: `{
+ "by": "severity",
+ "total": 3,
+ "groups": [
+  {
+   "value": "critical",
+   "count": 2
+  },
+  {
+   "value": "low",
+   "count": 1
+  }
+ ]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := &strings.Builder{}
+			require.NoError(t, presentDBSearchSummary(tt.format, tt.summary, sb))
+			require.Equal(t, tt.want, sb.String())
+		})
+	}
+}
@@ -25,13 +25,14 @@ type dbSearchVulnerabilityOptions struct {
 	Format        options.DBSearchFormat          `yaml:",inline" mapstructure:",squash"`
 	Vulnerability options.DBSearchVulnerabilities `yaml:",inline" mapstructure:",squash"`
 	Bounds        options.DBSearchBounds          `yaml:",inline" mapstructure:",squash"`
+	Summary       options.DBSearchSummary         `yaml:",inline" mapstructure:",squash"`
 
 	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
 }
 
 func DBSearchVulnerabilities(app clio.Application) *cobra.Command {
 	opts := &dbSearchVulnerabilityOptions{
-		Format: options.DefaultDBSearchFormat(),
+		Format: options.DefaultDBSearchReportFormat(),
 		Vulnerability: options.DBSearchVulnerabilities{
 			UseVulnIDFlag: false, // we input this through the args
 		},
@@ -84,16 +85,26 @@ func runDBSearchVulnerabilities(opts dbSearchVulnerabilityOptions) error {
 		return err
 	}
 
-	rows, err := dbsearch.FindVulnerabilities(reader, dbsearch.VulnerabilitiesOptions{
+	criteria := dbsearch.VulnerabilitiesOptions{
 		Vulnerability: opts.Vulnerability.Specs,
 		RecordLimit:   opts.Bounds.RecordLimit,
-	})
+	}
+
+	if opts.Summary.Enabled() {
+		summary, err := dbsearch.SummarizeVulnerabilities(reader, criteria, opts.Summary.SummarizeBy)
+		if err != nil {
+			return err
+		}
+		return reportDBSearchSummary(opts.Format, summary)
+	}
+
+	rows, err := dbsearch.FindVulnerabilities(reader, criteria)
 	if err != nil {
 		return err
 	}
 
 	sb := &strings.Builder{}
-	err = presentDBSearchVulnerabilities(opts.Format.Output, rows, sb)
+	err = presentDBSearchVulnerabilities(opts.Format, rows, sb)
 	rep := sb.String()
 	if rep != "" {
 		bus.Report(rep)
@@ -128,8 +139,14 @@ func validateProvidersFilter(reader v6.Reader, providers []string) error {
 	return errs
 }
 
-func presentDBSearchVulnerabilities(outputFormat string, structuredRows []dbsearch.Vulnerability, output io.Writer) error {
-	switch outputFormat {
+func presentDBSearchVulnerabilities(format options.DBSearchFormat, structuredRows []dbsearch.Vulnerability, output io.Writer) error {
+	columns := []string{"ID", "Provider", "Published", "Severity", "Reference"}
+	if structuredRows == nil {
+		// always allocate the top level collection
+		structuredRows = []dbsearch.Vulnerability{}
+	}
+
+	switch format.Output {
 	case tableOutputFormat:
 		if len(structuredRows) == 0 {
 			bus.Notify("No results found")
@@ -138,17 +155,17 @@ func presentDBSearchVulnerabilities(outputFormat string, structuredRows []dbsear
 
 		rows := renderDBSearchVulnerabilitiesTableRows(structuredRows)
 
-		table := newTable(output, []string{"ID", "Provider", "Published", "Severity", "Reference"})
+		table := newTable(output, columns)
 
 		if err := table.Bulk(rows); err != nil {
 			return fmt.Errorf("failed to add table rows: %+v", err)
 		}
 		return table.Render()
+	case csvOutputFormat:
+		return presentDBSearchCSV(columns, renderDBSearchVulnerabilitiesTableRows(structuredRows), output)
+	case templateOutputFormat:
+		return presentDBSearchTemplate(format.TemplateFile, structuredRows, output)
 	case jsonOutputFormat:
-		if structuredRows == nil {
-			// always allocate the top level collection
-			structuredRows = []dbsearch.Vulnerability{}
-		}
 		enc := json.NewEncoder(output)
 		enc.SetEscapeHTML(false)
 		enc.SetIndent("", " ")
@@ -156,7 +173,7 @@ func presentDBSearchVulnerabilities(outputFormat string, structuredRows []dbsear
 			return fmt.Errorf("failed to encode diff information: %+v", err)
 		}
 	default:
-		return fmt.Errorf("unsupported output format: %s", outputFormat)
+		return fmt.Errorf("unsupported output format: %s", format.Output)
 	}
 	return nil
 }
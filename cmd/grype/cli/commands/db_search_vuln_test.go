@@ -1,12 +1,16 @@
 package commands
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbsearch"
+	"github.com/anchore/grype/cmd/grype/cli/options"
 	v6 "github.com/anchore/grype/grype/db/v6"
 )
 
@@ -138,3 +142,48 @@ func TestGetDate(t *testing.T) {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestPresentDBSearchVulnerabilities(t *testing.T) {
+	published := time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC)
+	rows := []dbsearch.Vulnerability{
+		{
+			VulnerabilityInfo: dbsearch.VulnerabilityInfo{
+				VulnerabilityBlob: v6.VulnerabilityBlob{
+					ID:         "CVE-2021-44228",
+					References: []v6.Reference{{URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}},
+				},
+				Severity:      "critical",
+				Provider:      "nvd",
+				PublishedDate: &published,
+			},
+		},
+	}
+
+	templateFile := filepath.Join(t.TempDir(), "report.tmpl")
+	require.NoError(t, os.WriteFile(templateFile, []byte(`{{range .}}{{.ID}}={{.Severity | upper}}{{end}}`), 0o600))
+
+	tests := []struct {
+		name   string
+		format options.DBSearchFormat
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: options.DBSearchFormat{Output: "csv"},
+			want: "ID,Provider,Published,Severity,Reference\n" +
+				"CVE-2021-44228,nvd,2021-12-10,critical,https://nvd.nist.gov/vuln/detail/CVE-2021-44228\n",
+		},
+		{
+			name:   "template",
+			format: options.DBSearchFormat{Output: "template", TemplateFile: templateFile},
+			want:   "CVE-2021-44228=CRITICAL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := &strings.Builder{}
+			require.NoError(t, presentDBSearchVulnerabilities(tt.format, rows, sb))
+			require.Equal(t, tt.want, sb.String())
+		})
+	}
+}
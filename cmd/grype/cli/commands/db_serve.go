@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbserve"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/internal/log"
+)
+
+type dbServeOptions struct {
+	Serve options.DBServe `yaml:"serve" json:"serve" mapstructure:"serve"`
+
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+func DBServe(app clio.Application) *cobra.Command {
+	opts := &dbServeOptions{
+		Serve:           options.DefaultDBServe(),
+		DatabaseCommand: *options.DefaultDatabaseCommand(app.ID()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve read-only search queries against the DB over an authenticated HTTP API",
+		Example: `
+  Serve the DB on all interfaces (clients authenticate with "Authorization: Bearer <token>"):
+
+    $ GRYPE_SERVE_TOKEN=... grype db serve --listen 0.0.0.0:8080
+
+  Query affected packages, vulnerabilities, KEV and EPSS entries (parameters mirror the 'grype db search' flags):
+
+    $ curl -H "Authorization: Bearer $TOKEN" 'http://localhost:8080/v1/affected-packages?pkg=log4j&per-page=20'
+    $ curl -H "Authorization: Bearer $TOKEN" 'http://localhost:8080/v1/vulnerabilities?id=CVE-2021-44228'
+    $ curl -H "Authorization: Bearer $TOKEN" 'http://localhost:8080/v1/kev?added-after=2024-01-01&page=2'
+    $ curl -H "Authorization: Bearer $TOKEN" 'http://localhost:8080/v1/epss?min-percentile=0.99'`,
+		Args:    cobra.ExactArgs(0),
+		PreRunE: disableUI(app),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDBServe(cmd.Context(), *opts)
+		},
+	}
+
+	// prevent the DB search options from being shown in the grype config
+	type configWrapper struct {
+		Serve                    *options.DBServe `yaml:"serve" json:"serve" mapstructure:"serve"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Serve: &opts.Serve, DatabaseCommand: &opts.DatabaseCommand})
+}
+
+func runDBServe(ctx context.Context, opts dbServeOptions) error {
+	if err := opts.Serve.RequireToken(); err != nil {
+		return err
+	}
+
+	reader, err := newDBSearchReader(opts.DatabaseCommand)
+	if err != nil {
+		return err
+	}
+	defer log.CloseAndLogError(reader, "vulnerability DB")
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	server := &http.Server{
+		Addr: opts.Serve.Listen,
+		Handler: dbserve.New(reader, dbserve.Config{
+			Token:       opts.Serve.Token,
+			RecordLimit: opts.Serve.RecordLimit,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		log.WithFields("address", opts.Serve.Listen).Info("serving vulnerability DB")
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("unable to serve vulnerability DB: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("unable to shut down server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,369 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbsearch"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/bus"
+)
+
+type dbShowOptions struct {
+	Output                  string `yaml:"output" json:"output" mapstructure:"output"`
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+var _ clio.FlagAdder = (*dbShowOptions)(nil)
+
+func (d *dbShowOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&d.Output, "output", "o", "format to display results (available=[text, markdown, json])")
+}
+
+func DBShow(app clio.Application) *cobra.Command {
+	opts := &dbShowOptions{
+		Output:          textOutputFormat,
+		DatabaseCommand: *options.DefaultDatabaseCommand(app.ID()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "show ID",
+		Short: "Show everything known about a single vulnerability within the DB (supports DB schema v6+ only)",
+		Example: `
+  Show the description, severities, affected packages, KEV/EPSS information, and references for a CVE:
+
+    $ grype db show CVE-2021-44228
+
+  Render the vulnerability as markdown (e.g. to paste into a ticket):
+
+    $ grype db show GHSA-jfh8-c2jp-5v3q -o markdown`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDBShow(*opts, args[0])
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Hidden                   *dbShowOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, DatabaseCommand: &opts.DatabaseCommand})
+}
+
+func runDBShow(opts dbShowOptions, id string) error {
+	reader, err := newDBSearchReader(opts.DatabaseCommand)
+	if err != nil {
+		return err
+	}
+
+	detail, err := dbsearch.ShowVulnerability(reader, id)
+	if err != nil {
+		return err
+	}
+
+	sb := &strings.Builder{}
+	err = presentDBShow(opts.Output, detail, sb)
+	rep := sb.String()
+	if rep != "" {
+		bus.Report(rep)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to present vulnerability: %w", err)
+	}
+	return nil
+}
+
+func presentDBShow(outputFormat string, detail *dbsearch.VulnerabilityDetail, output io.Writer) error {
+	switch outputFormat {
+	case textOutputFormat:
+		return presentDBShowText(detail, output)
+	case markdownOutputFormat:
+		return presentDBShowMarkdown(detail, output)
+	case jsonOutputFormat:
+		return encodeDBSearchJSON(output, detail)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+func presentDBShowText(detail *dbsearch.VulnerabilityDetail, output io.Writer) error {
+	heading := lipgloss.NewStyle().Bold(true)
+	section := func(title string) {
+		_, _ = fmt.Fprintf(output, "\n%s\n", heading.Render(title))
+	}
+
+	_, _ = fmt.Fprintf(output, "%s (%s)\n", heading.Render(detail.ID), severityStyle(detail.Severity).Render(detail.Severity))
+	if len(detail.Aliases) > 0 {
+		_, _ = fmt.Fprintf(output, "Aliases:   %s\n", strings.Join(detail.Aliases, ", "))
+	}
+	if published := getDate(detail.PublishedDate); published != "" {
+		_, _ = fmt.Fprintf(output, "Published: %s\n", published)
+	}
+	if modified := getDate(detail.ModifiedDate); modified != "" {
+		_, _ = fmt.Fprintf(output, "Modified:  %s\n", modified)
+	}
+
+	if detail.Description != "" {
+		_, _ = fmt.Fprintf(output, "\n%s\n", strings.TrimSpace(detail.Description))
+	}
+
+	if len(detail.Severities) > 0 {
+		section("Severities")
+		if err := renderDBShowTable(output, []string{"Provider", "Vulnerability", "Source", "Severity", "Score"}, dbShowSeverityRows(detail)); err != nil {
+			return err
+		}
+	}
+
+	if len(detail.KnownExploited) > 0 {
+		section("Known exploited")
+		if err := renderDBShowTable(output, []string{"CVE", "Added", "Due", "Ransomware", "Required action"}, dbShowKnownExploitedRows(detail)); err != nil {
+			return err
+		}
+	}
+
+	if len(detail.EPSS) > 0 {
+		section("EPSS")
+		if err := renderDBShowTable(output, []string{"CVE", "Score", "Percentile", "Date"}, dbShowEPSSRows(detail)); err != nil {
+			return err
+		}
+	}
+
+	if len(detail.CWEs) > 0 {
+		section("Weaknesses")
+		for _, c := range detail.CWEs {
+			_, _ = fmt.Fprintf(output, "  %s\n", dbShowCWE(c))
+		}
+	}
+
+	for _, g := range detail.AffectedPackages {
+		section(fmt.Sprintf("Affected packages (%s)", dbShowGroupName(g)))
+		if err := renderDBShowTable(output, []string{"Name", "Versions", "Provider", "Vulnerability"}, dbShowAffectedRows(g.Packages)); err != nil {
+			return err
+		}
+	}
+
+	if len(detail.AffectedCPEs) > 0 {
+		section("Affected CPEs")
+		if err := renderDBShowTable(output, []string{"CPE", "Versions", "Provider", "Vulnerability"}, dbShowAffectedRows(detail.AffectedCPEs)); err != nil {
+			return err
+		}
+	}
+
+	if len(detail.References) > 0 {
+		section("References")
+		for _, r := range detail.References {
+			_, _ = fmt.Fprintf(output, "  %s\n", r.URL)
+		}
+	}
+
+	return nil
+}
+
+func presentDBShowMarkdown(detail *dbsearch.VulnerabilityDetail, output io.Writer) error {
+	var sb strings.Builder
+	section := func(title string) {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", title))
+	}
+
+	sb.WriteString(fmt.Sprintf("# %s\n\n", detail.ID))
+	sb.WriteString(fmt.Sprintf("- **Severity:** %s\n", detail.Severity))
+	if len(detail.Aliases) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Aliases:** %s\n", strings.Join(detail.Aliases, ", ")))
+	}
+	if published := getDate(detail.PublishedDate); published != "" {
+		sb.WriteString(fmt.Sprintf("- **Published:** %s\n", published))
+	}
+	if modified := getDate(detail.ModifiedDate); modified != "" {
+		sb.WriteString(fmt.Sprintf("- **Modified:** %s\n", modified))
+	}
+
+	if detail.Description != "" {
+		sb.WriteString(fmt.Sprintf("\n%s\n", strings.TrimSpace(detail.Description)))
+	}
+
+	if len(detail.Severities) > 0 {
+		section("Severities")
+		writeMarkdownTable(&sb, []string{"Provider", "Vulnerability", "Source", "Severity", "Score"}, dbShowSeverityRows(detail))
+	}
+
+	if len(detail.KnownExploited) > 0 {
+		section("Known exploited")
+		writeMarkdownTable(&sb, []string{"CVE", "Added", "Due", "Ransomware", "Required action"}, dbShowKnownExploitedRows(detail))
+	}
+
+	if len(detail.EPSS) > 0 {
+		section("EPSS")
+		writeMarkdownTable(&sb, []string{"CVE", "Score", "Percentile", "Date"}, dbShowEPSSRows(detail))
+	}
+
+	if len(detail.CWEs) > 0 {
+		section("Weaknesses")
+		for _, c := range detail.CWEs {
+			sb.WriteString(fmt.Sprintf("- %s\n", dbShowCWE(c)))
+		}
+	}
+
+	if len(detail.AffectedPackages) > 0 || len(detail.AffectedCPEs) > 0 {
+		section("Affected packages")
+		for i, g := range detail.AffectedPackages {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(fmt.Sprintf("### %s\n\n", dbShowGroupName(g)))
+			writeMarkdownTable(&sb, []string{"Name", "Versions", "Provider", "Vulnerability"}, dbShowAffectedRows(g.Packages))
+		}
+		if len(detail.AffectedCPEs) > 0 {
+			if len(detail.AffectedPackages) > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("### CPEs\n\n")
+			writeMarkdownTable(&sb, []string{"CPE", "Versions", "Provider", "Vulnerability"}, dbShowAffectedRows(detail.AffectedCPEs))
+		}
+	}
+
+	if len(detail.References) > 0 {
+		section("References")
+		for _, r := range detail.References {
+			sb.WriteString(fmt.Sprintf("- <%s>\n", r.URL))
+		}
+	}
+
+	_, err := io.WriteString(output, sb.String())
+	return err
+}
+
+func renderDBShowTable(output io.Writer, columns []string, rows [][]string) error {
+	table := newTable(output, columns)
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to add table rows: %+v", err)
+	}
+	return table.Render()
+}
+
+func writeMarkdownTable(sb *strings.Builder, columns []string, rows [][]string) {
+	escape := func(cell string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(cell, "|", `\|`), "\n", " ")
+	}
+
+	sb.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escape(cell)
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+}
+
+func dbShowSeverityRows(detail *dbsearch.VulnerabilityDetail) [][]string {
+	var rows [][]string
+	for _, s := range detail.Severities {
+		rows = append(rows, []string{s.Provider, s.Vulnerability, s.Source, s.Severity, dbShowSeverityScore(s)})
+	}
+	return rows
+}
+
+// dbShowSeverityScore is the score of a severity assessment (the CVSS vector with its base score, or the qualitative
+// value as given by the provider).
+func dbShowSeverityScore(s dbsearch.ProviderSeverity) string {
+	switch v := s.Value.(type) {
+	case dbsearch.CVSSSeverity:
+		return fmt.Sprintf("%s (%s)", v.Vector, strconv.FormatFloat(v.Metrics.BaseScore, 'f', 1, 64))
+	case string:
+		return v
+	}
+	return fmt.Sprintf("%v", s.Value)
+}
+
+func dbShowKnownExploitedRows(detail *dbsearch.VulnerabilityDetail) [][]string {
+	var rows [][]string
+	for _, k := range detail.KnownExploited {
+		rows = append(rows, []string{k.CVE, k.DateAdded, k.DueDate, k.KnownRansomwareCampaignUse, k.RequiredAction})
+	}
+	return rows
+}
+
+func dbShowEPSSRows(detail *dbsearch.VulnerabilityDetail) [][]string {
+	var rows [][]string
+	for _, e := range detail.EPSS {
+		rows = append(rows, []string{
+			e.CVE,
+			strconv.FormatFloat(e.EPSS, 'f', -1, 64),
+			strconv.FormatFloat(e.Percentile, 'f', -1, 64),
+			e.Date,
+		})
+	}
+	return rows
+}
+
+func dbShowCWE(c dbsearch.CWE) string {
+	var attrs []string
+	for _, a := range []string{c.Source, c.Type} {
+		if a != "" {
+			attrs = append(attrs, a)
+		}
+	}
+	if len(attrs) == 0 {
+		return c.CWE
+	}
+	return fmt.Sprintf("%s (%s)", c.CWE, strings.Join(attrs, ", "))
+}
+
+func dbShowGroupName(g dbsearch.AffectedPackageGroup) string {
+	if g.OS != nil {
+		return strings.TrimSpace(g.OS.Name + " " + g.OS.Version)
+	}
+	return g.Ecosystem
+}
+
+func dbShowAffectedRows(details []dbsearch.AffectedPackageDetail) [][]string {
+	var rows [][]string
+	for _, d := range details {
+		rows = append(rows, []string{d.Name, dbShowRanges(d.Ranges), d.Provider, d.Vulnerability})
+	}
+	return rows
+}
+
+// dbShowRanges describes the affected version ranges of a package along with their fixes (e.g. "< 1.2.3 (fixed in 1.2.3)").
+func dbShowRanges(ranges []v6.Range) string {
+	var parts []string
+	for _, r := range ranges {
+		constraint := r.Version.Constraint
+		if constraint == "" {
+			constraint = "*"
+		}
+		if r.Fix != nil && r.Fix.State != "" {
+			constraint = fmt.Sprintf("%s (%s)", constraint, r.Fix)
+		}
+		parts = append(parts, constraint)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func severityStyle(severity string) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	switch strings.ToLower(severity) {
+	case "critical":
+		return style.Foreground(lipgloss.Color("198")).Bold(true)
+	case "high":
+		return style.Foreground(lipgloss.Color("203"))
+	case "medium":
+		return style.Foreground(lipgloss.Color("178"))
+	case "low":
+		return style.Foreground(lipgloss.Color("36"))
+	case "negligible":
+		return style.Foreground(lipgloss.Color("240"))
+	}
+	return style
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbsearch"
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func testVulnerabilityDetail() *dbsearch.VulnerabilityDetail {
+	published := time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC)
+	return &dbsearch.VulnerabilityDetail{
+		ID:            "CVE-2021-44228",
+		Aliases:       []string{"GHSA-jfh8-c2jp-5v3q"},
+		Description:   "Apache Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints.",
+		Severity:      "critical",
+		PublishedDate: &published,
+		Severities: []dbsearch.ProviderSeverity{
+			{
+				Provider:      "nvd",
+				Vulnerability: "CVE-2021-44228",
+				Scheme:        "CVSS",
+				Source:        "nvd@nist.gov",
+				Severity:      "critical",
+				Value: dbsearch.CVSSSeverity{
+					Vector:  "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+					Version: "3.1",
+					Metrics: dbsearch.CvssMetrics{BaseScore: 9.8},
+				},
+			},
+			{
+				Provider:      "github",
+				Vulnerability: "GHSA-jfh8-c2jp-5v3q",
+				Scheme:        "CHMLN",
+				Severity:      "critical",
+				Value:         "critical",
+			},
+		},
+		AffectedPackages: []dbsearch.AffectedPackageGroup{
+			{
+				Ecosystem: "java-archive",
+				Packages: []dbsearch.AffectedPackageDetail{{
+					Name:          "org.apache.logging.log4j:log4j-core",
+					Provider:      "github",
+					Vulnerability: "GHSA-jfh8-c2jp-5v3q",
+					Ranges: []v6.Range{{
+						Version: v6.Version{Constraint: ">=2.0.0,<2.15.0"},
+						Fix:     &v6.Fix{Version: "2.15.0", State: v6.FixedStatus},
+					}},
+				}},
+			},
+			{
+				OS: &dbsearch.OperatingSystem{Name: "debian", Version: "12"},
+				Packages: []dbsearch.AffectedPackageDetail{{
+					Name:          "apache-log4j2",
+					Provider:      "debian",
+					Vulnerability: "CVE-2021-44228",
+					Ranges: []v6.Range{{
+						Fix: &v6.Fix{State: v6.WontFixStatus},
+					}},
+				}},
+			},
+		},
+		KnownExploited: []dbsearch.KnownExploited{{CVE: "CVE-2021-44228", DateAdded: "2021-12-10", DueDate: "2021-12-24", KnownRansomwareCampaignUse: "Known"}},
+		EPSS:           []dbsearch.EPSS{{CVE: "CVE-2021-44228", EPSS: 0.97, Percentile: 0.99, Date: "2024-06-01"}},
+		CWEs:           []dbsearch.CWE{{Cve: "CVE-2021-44228", CWE: "CWE-502", Source: "nvd@nist.gov", Type: "Primary"}},
+		References:     []v6.Reference{{URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}},
+	}
+}
+
+func TestPresentDBShowMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, presentDBShow(markdownOutputFormat, testVulnerabilityDetail(), &buf))
+
+	assert.Equal(t, `# CVE-2021-44228
+
+- **Severity:** critical
+- **Aliases:** GHSA-jfh8-c2jp-5v3q
+- **Published:** 2021-12-10
+
+Apache Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints.
+
+## Severities
+
+| Provider | Vulnerability | Source | Severity | Score |
+| --- | --- | --- | --- | --- |
+| nvd | CVE-2021-44228 | nvd@nist.gov | critical | CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H (9.8) |
+| github | GHSA-jfh8-c2jp-5v3q |  | critical | critical |
+
+## Known exploited
+
+| CVE | Added | Due | Ransomware | Required action |
+| --- | --- | --- | --- | --- |
+| CVE-2021-44228 | 2021-12-10 | 2021-12-24 | Known |  |
+
+## EPSS
+
+| CVE | Score | Percentile | Date |
+| --- | --- | --- | --- |
+| CVE-2021-44228 | 0.97 | 0.99 | 2024-06-01 |
+
+## Weaknesses
+
+- CWE-502 (nvd@nist.gov, Primary)
+
+## Affected packages
+
+### java-archive
+
+| Name | Versions | Provider | Vulnerability |
+| --- | --- | --- | --- |
+| org.apache.logging.log4j:log4j-core | >=2.0.0,<2.15.0 (fixed in 2.15.0) | github | GHSA-jfh8-c2jp-5v3q |
+
+### debian 12
+
+| Name | Versions | Provider | Vulnerability |
+| --- | --- | --- | --- |
+| apache-log4j2 | * (wont-fix) | debian | CVE-2021-44228 |
+
+## References
+
+- <https://nvd.nist.gov/vuln/detail/CVE-2021-44228>
+`, buf.String())
+}
+
+func TestPresentDBShowText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, presentDBShow(textOutputFormat, testVulnerabilityDetail(), &buf))
+
+	got := buf.String()
+	for _, want := range []string{
+		"CVE-2021-44228 (critical)",
+		"Aliases:   GHSA-jfh8-c2jp-5v3q",
+		"Published: 2021-12-10",
+		"Severities",
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H (9.8)",
+		"Affected packages (java-archive)",
+		">=2.0.0,<2.15.0 (fixed in 2.15.0)",
+		"Affected packages (debian 12)",
+		"CWE-502 (nvd@nist.gov, Primary)",
+		"https://nvd.nist.gov/vuln/detail/CVE-2021-44228",
+	} {
+		assert.Contains(t, got, want)
+	}
+}
+
+func TestPresentDBShowUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	require.ErrorContains(t, presentDBShow("table", testVulnerabilityDetail(), &buf), "unsupported output format: table")
+}
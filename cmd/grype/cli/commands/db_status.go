@@ -13,11 +13,13 @@ import (
 	"github.com/anchore/grype/cmd/grype/cli/options"
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/grype/grypeerr"
 	"github.com/anchore/grype/grype/vulnerability"
 )
 
 type dbStatusOptions struct {
 	Output                  string `yaml:"output" json:"output" mapstructure:"output"`
+	Verify                  bool   `yaml:"verify" json:"verify" mapstructure:"verify"`
 	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
 }
 
@@ -25,6 +27,7 @@ var _ clio.FlagAdder = (*dbStatusOptions)(nil)
 
 func (d *dbStatusOptions) AddFlags(flags clio.FlagSet) {
 	flags.StringVarP(&d.Output, "output", "o", "format to display results (available=[text, json])")
+	flags.BoolVarP(&d.Verify, "verify", "", "recompute the database digest and compare it against the import metadata and the latest distribution metadata, failing on drift or tampering")
 }
 
 func DBStatus(app clio.Application) *cobra.Command {
@@ -64,14 +67,31 @@ func runDBStatus(opts dbStatusOptions) error {
 
 	status := c.Status()
 
-	if err := presentDBStatus(opts.Output, os.Stdout, status); err != nil {
+	var verification *installation.Verification
+	if opts.Verify {
+		verification, err = installation.Verify(opts.ToCuratorConfig(), client)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := presentDBStatus(opts.Output, os.Stdout, status, verification); err != nil {
 		return fmt.Errorf("failed to present db status information: %+v", err)
 	}
 
+	if verification != nil && !verification.Passed() {
+		return grypeerr.ErrDBVerificationFailed
+	}
 	return status.Error
 }
 
-func presentDBStatus(format string, writer io.Writer, status vulnerability.ProviderStatus) error {
+// dbStatusVerifiedJSON is the JSON output of db status when verifying the database.
+type dbStatusVerifiedJSON struct {
+	Status       vulnerability.ProviderStatus `json:"status"`
+	Verification *installation.Verification   `json:"verification"`
+}
+
+func presentDBStatus(format string, writer io.Writer, status vulnerability.ProviderStatus, verification *installation.Verification) error {
 	switch format {
 	case textOutputFormat:
 		fmt.Fprintln(writer, "Path:     ", status.Path)
@@ -81,11 +101,18 @@ func presentDBStatus(format string, writer io.Writer, status vulnerability.Provi
 			fmt.Fprintln(writer, "From:     ", status.From)
 		}
 		fmt.Fprintln(writer, "Status:   ", renderStoreValidation(status))
+		if verification != nil {
+			presentDBVerification(writer, *verification)
+		}
 	case jsonOutputFormat:
 		enc := json.NewEncoder(writer)
 		enc.SetEscapeHTML(false)
 		enc.SetIndent("", " ")
-		if err := enc.Encode(&status); err != nil {
+		var doc any = &status
+		if verification != nil {
+			doc = dbStatusVerifiedJSON{Status: status, Verification: verification}
+		}
+		if err := enc.Encode(doc); err != nil {
 			return fmt.Errorf("failed to db status information: %+v", err)
 		}
 	default:
@@ -95,6 +122,24 @@ func presentDBStatus(format string, writer io.Writer, status vulnerability.Provi
 	return nil
 }
 
+func presentDBVerification(writer io.Writer, v installation.Verification) {
+	fmt.Fprintln(writer, "Digest:   ", v.ActualDigest)
+	if v.ArchiveChecksum != "" {
+		fmt.Fprintln(writer, "Archive:  ", v.ArchivePath, "("+v.ArchiveChecksum+")")
+	}
+	if v.Latest != nil {
+		fmt.Fprintln(writer, "Latest:   ", v.Latest.Path, "("+v.Latest.Checksum+")")
+	}
+	if v.Passed() {
+		fmt.Fprintln(writer, "Verified: ", "yes")
+		return
+	}
+	fmt.Fprintln(writer, "Verified: ", "no")
+	for _, f := range v.Findings {
+		fmt.Fprintf(writer, "  - %s: %s\n", f.Problem, f.Message)
+	}
+}
+
 func renderStoreValidation(status vulnerability.ProviderStatus) string {
 	if status.Error != nil {
 		return "invalid"
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
 	"github.com/anchore/grype/grype/vulnerability"
 )
 
@@ -30,10 +32,25 @@ func TestPresentDBStatus(t *testing.T) {
 		Error:         errors.New("checksum mismatch"),
 	}
 
+	tamperedVerification := &installation.Verification{
+		ImportDigest:    "xxh64:aaaa",
+		ActualDigest:    "xxh64:bbbb",
+		ArchivePath:     "vulnerability-db_v6.0.2_2025-03-14T01:31:06Z_1741925227.tar.zst",
+		ArchiveChecksum: "sha256:d4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8",
+		Latest: &distribution.Archive{
+			Path:     "vulnerability-db_v6.0.2_2025-03-14T01:31:06Z_1741925227.tar.zst",
+			Checksum: "sha256:d4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8",
+		},
+		Findings: []installation.VerificationFinding{
+			{Problem: installation.ProblemTampered, Message: `the database file digest "xxh64:bbbb" does not match the digest captured on import "xxh64:aaaa"`},
+		},
+	}
+
 	tests := []struct {
 		name         string
 		format       string
 		status       vulnerability.ProviderStatus
+		verification *installation.Verification
 		expectedText string
 		expectedErr  require.ErrorAssertionFunc
 	}{
@@ -87,6 +104,46 @@ Status:    invalid
  "valid": false,
  "error": "checksum mismatch"
 }
+`,
+			expectedErr: require.NoError,
+		},
+		{
+			name:         "verified status, text format",
+			format:       textOutputFormat,
+			status:       validStatus,
+			verification: tamperedVerification,
+			expectedText: `Path:      /Users/test/Library/Caches/grype/db/6/vulnerability.db
+Schema:    6.0.0
+Built:     2024-11-27T14:43:17Z
+From:      https://grype.anchore.io/databases/v6/vulnerability-db_v6.0.2_2025-03-14T01:31:06Z_1741925227.tar.zst?checksum=sha256%3Ad4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8
+Status:    valid
+Digest:    xxh64:bbbb
+Archive:   vulnerability-db_v6.0.2_2025-03-14T01:31:06Z_1741925227.tar.zst (sha256:d4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8)
+Latest:    vulnerability-db_v6.0.2_2025-03-14T01:31:06Z_1741925227.tar.zst (sha256:d4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8)
+Verified:  no
+  - tampered: the database file digest "xxh64:bbbb" does not match the digest captured on import "xxh64:aaaa"
+`,
+			expectedErr: require.NoError,
+		},
+		{
+			name:         "verified status, JSON format",
+			format:       jsonOutputFormat,
+			status:       validStatus,
+			verification: &installation.Verification{ImportDigest: "xxh64:aaaa", ActualDigest: "xxh64:aaaa", Findings: []installation.VerificationFinding{}},
+			expectedText: `{
+ "status": {
+  "schemaVersion": "6.0.0",
+  "from": "https://grype.anchore.io/databases/v6/vulnerability-db_v6.0.2_2025-03-14T01:31:06Z_1741925227.tar.zst?checksum=sha256%3Ad4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8",
+  "built": "2024-11-27T14:43:17Z",
+  "path": "/Users/test/Library/Caches/grype/db/6/vulnerability.db",
+  "valid": true
+ },
+ "verification": {
+  "importDigest": "xxh64:aaaa",
+  "actualDigest": "xxh64:aaaa",
+  "findings": []
+ }
+}
 `,
 			expectedErr: require.NoError,
 		},
@@ -105,7 +162,7 @@ Status:    invalid
 			}
 			writer := &bytes.Buffer{}
 
-			err := presentDBStatus(tt.format, writer, tt.status)
+			err := presentDBStatus(tt.format, writer, tt.status, tt.verification)
 			tt.expectedErr(t, err)
 			if err != nil {
 				return
@@ -1,20 +1,44 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/cmd/grype/cli/options"
+	db "github.com/anchore/grype/grype/db/v6"
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
 	"github.com/anchore/grype/internal/bus"
 	"github.com/anchore/grype/internal/log"
 )
 
+type dbUpdateOptions struct {
+	DryRun                  bool   `yaml:"dry-run" json:"dry-run" mapstructure:"dry-run"`
+	Output                  string `yaml:"output" json:"output" mapstructure:"output"`
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+var _ clio.FlagAdder = (*dbUpdateOptions)(nil)
+
+func (d *dbUpdateOptions) AddFlags(flags clio.FlagSet) {
+	flags.BoolVarP(&d.DryRun, "dry-run", "", "report what would be downloaded (size, build date and provider changes) without modifying the installed database")
+	flags.StringVarP(&d.Output, "output", "o", "format to display dry-run results (available=[text, json])")
+	d.Network.AddLimitBandwidthFlag(flags)
+}
+
 func DBUpdate(app clio.Application) *cobra.Command {
-	opts := options.DefaultDatabaseCommand(app.ID())
+	opts := &dbUpdateOptions{
+		Output:          textOutputFormat,
+		DatabaseCommand: *options.DefaultDatabaseCommand(app.ID()),
+	}
 
 	cmd := &cobra.Command{
 		Use:   "update",
@@ -26,27 +50,24 @@ func DBUpdate(app clio.Application) *cobra.Command {
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return runDBUpdate(*opts)
+			if opts.DryRun {
+				return runDBUpdateDryRun(*opts)
+			}
+			return runDBUpdate(opts.DatabaseCommand)
 		},
 	}
 
 	// prevent from being shown in the grype config
 	type configWrapper struct {
+		Hidden                   *dbUpdateOptions `json:"-" yaml:"-" mapstructure:"-"`
 		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
 	}
 
-	return app.SetupCommand(cmd, &configWrapper{opts})
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, DatabaseCommand: &opts.DatabaseCommand})
 }
 
 func runDBUpdate(opts options.DatabaseCommand) error {
-	cfg := opts.ToClientConfig()
-	// we need to have this set to true to force the update call to try to update
-	// regardless of what the user provided in order for update checks to fail
-	if !cfg.RequireUpdateCheck {
-		log.Warn("overriding db update check")
-		cfg.RequireUpdateCheck = true
-	}
-	client, err := distribution.NewClient(cfg)
+	client, err := distribution.NewClient(updateClientConfig(opts))
 	if err != nil {
 		return fmt.Errorf("unable to create distribution client: %w", err)
 	}
@@ -71,3 +92,222 @@ func runDBUpdate(opts options.DatabaseCommand) error {
 
 	return nil
 }
+
+func updateClientConfig(opts options.DatabaseCommand) distribution.Config {
+	cfg := opts.ToClientConfig()
+	// we need to have this set to true to force the update call to try to update
+	// regardless of what the user provided in order for update checks to fail
+	if !cfg.RequireUpdateCheck {
+		log.Warn("overriding db update check")
+		cfg.RequireUpdateCheck = true
+	}
+	return cfg
+}
+
+// runDBUpdateDryRun reports the update that 'grype db update' would install, without downloading the database or
+// modifying the installed database.
+func runDBUpdateDryRun(opts dbUpdateOptions) error {
+	client, err := distribution.NewClient(updateClientConfig(opts.DatabaseCommand))
+	if err != nil {
+		return fmt.Errorf("unable to create distribution client: %w", err)
+	}
+
+	cfg := opts.ToCuratorConfig()
+
+	current, err := db.ReadDescription(cfg.DBFilePath())
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to read current database metadata")
+		current = nil
+	}
+
+	candidate, err := client.IsUpdateAvailable(current)
+	if err != nil {
+		return fmt.Errorf("unable to check for vulnerability database update: %w", err)
+	}
+
+	plan := dbUpdatePlan{
+		CurrentDB:       current,
+		CandidateDB:     candidate,
+		UpdateAvailable: candidate != nil,
+	}
+
+	if candidate != nil {
+		if plan.DownloadSize, err = client.ArchiveSize(*candidate); err != nil {
+			log.WithFields("error", err).Warn("unable to determine the size of the vulnerability database update")
+		}
+
+		if len(candidate.Providers) > 0 {
+			installed, err := installedProviders(cfg, current)
+			if err != nil {
+				log.WithFields("error", err).Warn("unable to read the providers of the installed vulnerability database")
+			} else {
+				plan.ProviderChanges = diffDBProviders(installed, candidate.Providers)
+			}
+		}
+	}
+
+	sb := &strings.Builder{}
+	if err := presentDBUpdatePlan(opts.Output, sb, plan); err != nil {
+		return err
+	}
+	bus.Report(sb.String())
+
+	return nil
+}
+
+// installedProviders returns the providers of the installed database (none when there is no installed database). The
+// database is opened directly rather than through the curator, which may rehydrate it.
+func installedProviders(cfg installation.Config, current *db.Description) ([]db.Provider, error) {
+	if current == nil {
+		return nil, nil
+	}
+
+	reader, err := db.NewReader(db.Config{DBDirPath: cfg.DBDirectoryPath()})
+	if err != nil {
+		return nil, err
+	}
+	defer log.CloseAndLogError(reader, cfg.DBDirectoryPath())
+
+	return reader.AllProviders()
+}
+
+// dbUpdatePlan describes the update 'grype db update' would install.
+type dbUpdatePlan struct {
+	CurrentDB       *db.Description       `json:"currentDB"`
+	CandidateDB     *distribution.Archive `json:"candidateDB"`
+	UpdateAvailable bool                  `json:"updateAvailable"`
+	// DownloadSize is the size of the archive to download in bytes (0 when unknown)
+	DownloadSize int64 `json:"downloadSize,omitempty"`
+	// ProviderChanges are the providers added, removed or updated by the update (nil when unknown, since the listing
+	// does not describe the providers of the candidate database)
+	ProviderChanges *dbProviderChanges `json:"providerChanges,omitempty"`
+}
+
+type dbProviderChanges struct {
+	Added     []dbProviderChange `json:"added"`
+	Removed   []dbProviderChange `json:"removed"`
+	Updated   []dbProviderChange `json:"updated"`
+	Unchanged int                `json:"unchanged"`
+}
+
+type dbProviderChange struct {
+	Name string `json:"name"`
+	// Installed is when the data of the provider within the installed database was captured
+	Installed *time.Time `json:"installed,omitempty"`
+	// Candidate is when the data of the provider within the candidate database was captured
+	Candidate *time.Time `json:"candidate,omitempty"`
+}
+
+// diffDBProviders compares the providers of the installed database with the providers of the candidate database. A
+// provider is updated when its version, captured data or input digest differs.
+func diffDBProviders(installed []db.Provider, candidate []distribution.ArchiveProvider) *dbProviderChanges {
+	changes := &dbProviderChanges{
+		Added:   []dbProviderChange{},
+		Removed: []dbProviderChange{},
+		Updated: []dbProviderChange{},
+	}
+
+	byName := make(map[string]db.Provider)
+	for _, p := range installed {
+		byName[p.ID] = p
+	}
+
+	for _, c := range candidate {
+		i, ok := byName[c.Name]
+		if !ok {
+			changes.Added = append(changes.Added, dbProviderChange{Name: c.Name, Candidate: c.DateCaptured})
+			continue
+		}
+		delete(byName, c.Name)
+
+		if i.Version == c.Version && i.InputDigest == c.InputDigest && equalTimes(i.DateCaptured, c.DateCaptured) {
+			changes.Unchanged++
+			continue
+		}
+		changes.Updated = append(changes.Updated, dbProviderChange{Name: c.Name, Installed: i.DateCaptured, Candidate: c.DateCaptured})
+	}
+
+	for _, i := range byName {
+		changes.Removed = append(changes.Removed, dbProviderChange{Name: i.ID, Installed: i.DateCaptured})
+	}
+
+	for _, list := range [][]dbProviderChange{changes.Added, changes.Removed, changes.Updated} {
+		slices.SortFunc(list, func(a, b dbProviderChange) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	return changes
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func presentDBUpdatePlan(format string, writer io.Writer, plan dbUpdatePlan) error {
+	switch format {
+	case textOutputFormat:
+		if plan.CurrentDB != nil {
+			fmt.Fprintf(writer, "Installed DB version %s was built on %s\n", plan.CurrentDB.SchemaVersion, plan.CurrentDB.Built.String())
+		} else {
+			fmt.Fprintln(writer, "No installed DB version found")
+		}
+
+		if !plan.UpdateAvailable {
+			fmt.Fprintln(writer, "No update available")
+			return nil
+		}
+
+		size := "size unknown"
+		if plan.DownloadSize > 0 {
+			size = humanize.Bytes(uint64(plan.DownloadSize))
+		}
+		fmt.Fprintf(writer, "Would download DB version %s built on %s (%s)\n", plan.CandidateDB.SchemaVersion, plan.CandidateDB.Built.String(), size)
+
+		presentDBProviderChanges(writer, plan.ProviderChanges)
+
+		fmt.Fprintln(writer, "The installed DB was not modified (dry run)")
+	case jsonOutputFormat:
+		enc := json.NewEncoder(writer)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", " ")
+		if err := enc.Encode(&plan); err != nil {
+			return fmt.Errorf("failed to encode db update information: %+v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	return nil
+}
+
+func presentDBProviderChanges(writer io.Writer, changes *dbProviderChanges) {
+	if changes == nil {
+		fmt.Fprintln(writer, "Provider changes are unknown (the database listing does not describe providers)")
+		return
+	}
+	if len(changes.Added)+len(changes.Removed)+len(changes.Updated) == 0 {
+		fmt.Fprintf(writer, "No provider changes (%d unchanged)\n", changes.Unchanged)
+		return
+	}
+
+	fmt.Fprintln(writer, "Provider changes:")
+	for _, c := range changes.Added {
+		fmt.Fprintf(writer, "  added    %s (captured %s)\n", c.Name, formatCaptured(c.Candidate))
+	}
+	for _, c := range changes.Updated {
+		fmt.Fprintf(writer, "  updated  %s (captured %s -> %s)\n", c.Name, formatCaptured(c.Installed), formatCaptured(c.Candidate))
+	}
+	for _, c := range changes.Removed {
+		fmt.Fprintf(writer, "  removed  %s\n", c.Name)
+	}
+	fmt.Fprintf(writer, "  %d unchanged\n", changes.Unchanged)
+}
+
+func formatCaptured(t *time.Time) string {
+	if t == nil {
+		return "?"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
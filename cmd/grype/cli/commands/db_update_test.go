@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/internal/schemaver"
+)
+
+func TestDiffDBProviders(t *testing.T) {
+	day := func(d int) *time.Time {
+		t := time.Date(2023, 11, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	installed := []db.Provider{
+		{ID: "nvd", Version: "1", DateCaptured: day(25), InputDigest: "sha256:aaa"},
+		{ID: "alpine", Version: "1", DateCaptured: day(25), InputDigest: "sha256:bbb"},
+		{ID: "wolfi", Version: "1", DateCaptured: day(25), InputDigest: "sha256:ccc"},
+		{ID: "debian", Version: "1", DateCaptured: day(25), InputDigest: "sha256:ddd"},
+	}
+	candidate := []distribution.ArchiveProvider{
+		{Name: "nvd", Version: "1", DateCaptured: day(26), InputDigest: "sha256:eee"},
+		{Name: "alpine", Version: "1", DateCaptured: day(25), InputDigest: "sha256:bbb"},
+		{Name: "debian", Version: "2", DateCaptured: day(25), InputDigest: "sha256:ddd"},
+		{Name: "chainguard", Version: "1", DateCaptured: day(26), InputDigest: "sha256:fff"},
+	}
+
+	assert.Equal(t, &dbProviderChanges{
+		Added:     []dbProviderChange{{Name: "chainguard", Candidate: day(26)}},
+		Removed:   []dbProviderChange{{Name: "wolfi", Installed: day(25)}},
+		Updated:   []dbProviderChange{{Name: "debian", Installed: day(25), Candidate: day(25)}, {Name: "nvd", Installed: day(25), Candidate: day(26)}},
+		Unchanged: 1,
+	}, diffDBProviders(installed, candidate))
+
+	// without an installed database every provider is added
+	changes := diffDBProviders(nil, candidate[:1])
+	assert.Len(t, changes.Added, 1)
+	assert.Empty(t, changes.Removed)
+	assert.Empty(t, changes.Updated)
+}
+
+func TestPresentDBUpdatePlan(t *testing.T) {
+	captured := time.Date(2023, 11, 26, 6, 0, 0, 0, time.UTC)
+	previous := time.Date(2023, 11, 25, 6, 0, 0, 0, time.UTC)
+
+	currentDB := &db.Description{
+		SchemaVersion: schemaver.New(6, 0, 0),
+		Built:         db.Time{Time: time.Date(2023, 11, 25, 12, 0, 0, 0, time.UTC)},
+	}
+	candidateDB := &distribution.Archive{
+		Description: db.Description{
+			SchemaVersion: schemaver.New(6, 0, 1),
+			Built:         db.Time{Time: time.Date(2023, 11, 26, 12, 0, 0, 0, time.UTC)},
+		},
+		Path:     "vulnerability-db_6.0.1_2023-11-26T12:00:00Z_6238463.tar.zst",
+		Checksum: "sha256:1234561234567890345674561234567890345678",
+		Size:     123456789,
+	}
+	changes := &dbProviderChanges{
+		Added:     []dbProviderChange{{Name: "chainguard", Candidate: &captured}},
+		Removed:   []dbProviderChange{{Name: "wolfi", Installed: &previous}},
+		Updated:   []dbProviderChange{{Name: "nvd", Installed: &previous, Candidate: &captured}},
+		Unchanged: 12,
+	}
+
+	tests := []struct {
+		name         string
+		format       string
+		plan         dbUpdatePlan
+		expectedText string
+		expectErr    require.ErrorAssertionFunc
+	}{
+		{
+			name:   "text format with update available",
+			format: textOutputFormat,
+			plan:   dbUpdatePlan{CurrentDB: currentDB, CandidateDB: candidateDB, UpdateAvailable: true, DownloadSize: candidateDB.Size, ProviderChanges: changes},
+			expectedText: `
+Installed DB version v6.0.0 was built on 2023-11-25T12:00:00Z
+Would download DB version v6.0.1 built on 2023-11-26T12:00:00Z (124 MB)
+Provider changes:
+  added    chainguard (captured 2023-11-26T06:00:00Z)
+  updated  nvd (captured 2023-11-25T06:00:00Z -> 2023-11-26T06:00:00Z)
+  removed  wolfi
+  12 unchanged
+The installed DB was not modified (dry run)
+`,
+		},
+		{
+			name:   "text format with unknown size and providers",
+			format: textOutputFormat,
+			plan:   dbUpdatePlan{CandidateDB: candidateDB, UpdateAvailable: true},
+			expectedText: `
+No installed DB version found
+Would download DB version v6.0.1 built on 2023-11-26T12:00:00Z (size unknown)
+Provider changes are unknown (the database listing does not describe providers)
+The installed DB was not modified (dry run)
+`,
+		},
+		{
+			name:   "text format without update available",
+			format: textOutputFormat,
+			plan:   dbUpdatePlan{CurrentDB: currentDB},
+			expectedText: `
+Installed DB version v6.0.0 was built on 2023-11-25T12:00:00Z
+No update available
+`,
+		},
+		{
+			name:   "json format with update available",
+			format: jsonOutputFormat,
+			plan: dbUpdatePlan{CurrentDB: currentDB, CandidateDB: candidateDB, UpdateAvailable: true, DownloadSize: candidateDB.Size, ProviderChanges: &dbProviderChanges{
+				Added:     []dbProviderChange{},
+				Removed:   []dbProviderChange{},
+				Updated:   []dbProviderChange{{Name: "nvd", Installed: &previous, Candidate: &captured}},
+				Unchanged: 3,
+			}},
+			expectedText: `
+{
+ "currentDB": {
+  "schemaVersion": "v6.0.0",
+  "built": "2023-11-25T12:00:00Z"
+ },
+ "candidateDB": {
+  "schemaVersion": "v6.0.1",
+  "built": "2023-11-26T12:00:00Z",
+  "path": "vulnerability-db_6.0.1_2023-11-26T12:00:00Z_6238463.tar.zst",
+  "checksum": "sha256:1234561234567890345674561234567890345678",
+  "size": 123456789
+ },
+ "updateAvailable": true,
+ "downloadSize": 123456789,
+ "providerChanges": {
+  "added": [],
+  "removed": [],
+  "updated": [
+   {
+    "name": "nvd",
+    "installed": "2023-11-25T06:00:00Z",
+    "candidate": "2023-11-26T06:00:00Z"
+   }
+  ],
+  "unchanged": 3
+ }
+}
+`,
+		},
+		{
+			name:      "unsupported format",
+			format:    "xml",
+			plan:      dbUpdatePlan{CurrentDB: currentDB},
+			expectErr: requireErrorContains("unsupported output format: xml"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expectErr == nil {
+				tt.expectErr = require.NoError
+			}
+			buf := &bytes.Buffer{}
+			err := presentDBUpdatePlan(tt.format, buf, tt.plan)
+			tt.expectErr(t, err)
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, strings.TrimSpace(tt.expectedText), strings.TrimSpace(buf.String()))
+		})
+	}
+}
@@ -1,35 +1,93 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
 	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/triage"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype"
 	"github.com/anchore/grype/grype/presenter/explain"
 	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/grype/internal"
 	"github.com/anchore/grype/internal/log"
 )
 
 type explainOptions struct {
-	CVEIDs []string `yaml:"cve-ids" json:"cve-ids" mapstructure:"cve-ids"`
+	CVEIDs       []string `yaml:"cve-ids" json:"cve-ids" mapstructure:"cve-ids"`
+	All          bool     `yaml:"all" json:"all" mapstructure:"all"`
+	MinSeverity  string   `yaml:"min-severity" json:"min-severity" mapstructure:"min-severity"`
+	Output       string   `yaml:"output" json:"output" mapstructure:"output"`
+	TriageOutput string   `yaml:"triage-output" json:"triage-output" mapstructure:"triage-output"`
+
+	// the DB is used to resolve the details of findings read from SARIF or table output
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
 }
 
-var _ clio.FlagAdder = (*explainOptions)(nil)
+var _ interface {
+	clio.FlagAdder
+	clio.PostLoader
+} = (*explainOptions)(nil)
 
 func (d *explainOptions) AddFlags(flags clio.FlagSet) {
 	flags.StringArrayVarP(&d.CVEIDs, "id", "", "CVE IDs to explain")
+	flags.BoolVarP(&d.All, "all", "", "interactively browse and triage all findings (or explain them all at once with --min-severity or --output)")
+	flags.StringVarP(&d.MinSeverity, "min-severity", "", fmt.Sprintf("only explain findings with a severity >= the given severity (with --all), options=%v", vulnerability.AllSeverities()))
+	flags.StringVarP(&d.Output, "output", "o", "format to display explanations (available=[text, json])")
+	flags.StringVarP(&d.TriageOutput, "triage-output", "", "path prefix of the files written when exporting a triage session (with --all)")
+}
+
+func (d *explainOptions) PostLoad() error {
+	if d.MinSeverity != "" {
+		if !d.All {
+			return fmt.Errorf("--min-severity can only be used with --all")
+		}
+		if vulnerability.ParseSeverity(d.MinSeverity) == vulnerability.UnknownSeverity {
+			return fmt.Errorf("bad --min-severity value '%s'", d.MinSeverity)
+		}
+	}
+	switch explain.Format(d.Output) {
+	case "", explain.TextFormat, explain.JSONFormat:
+	default:
+		return fmt.Errorf("invalid output format: %s (expected one of: text, json)", d.Output)
+	}
+	return nil
+}
+
+// interactive is whether all findings are browsed in the triage browser, rather than explained all at once.
+func (d explainOptions) interactive() bool {
+	return d.All && d.MinSeverity == "" && d.Output == ""
 }
 
 func Explain(app clio.Application) *cobra.Command {
-	opts := &explainOptions{}
+	opts := &explainOptions{
+		TriageOutput:    "grype-triage",
+		DatabaseCommand: options.DatabaseCommand{DB: options.DefaultDatabase(app.ID()), Network: options.DefaultNetwork()},
+	}
 
 	cmd := &cobra.Command{
-		Use:     "explain --id [VULNERABILITY ID]",
-		Short:   "Ask grype to explain a set of findings",
+		Use:   "explain [--id VULNERABILITY ID | --all]",
+		Short: "Ask grype to explain a set of findings",
+		Example: `
+  Explain specific findings:
+
+    $ grype alpine:latest -o json | grype explain --id CVE-2023-5363
+
+  Interactively browse and triage all findings:
+
+    $ grype alpine:latest -o json | grype explain --all
+
+  Explain all high and critical findings at once (as a JSON array):
+
+    $ grype alpine:latest -o json | grype explain --all --min-severity high -o json`,
 		PreRunE: disableUI(app),
 		RunE: func(_ *cobra.Command, _ []string) error {
 			log.Warn("grype explain is a prototype feature and is subject to change")
@@ -39,26 +97,96 @@ func Explain(app clio.Application) *cobra.Command {
 				isStdinPipeOrRedirect = false
 			}
 			if isStdinPipeOrRedirect {
-				// TODO: eventually detect different types of input; for now assume grype json
-				var parseResult models.Document
-				decoder := json.NewDecoder(os.Stdin)
-				err := decoder.Decode(&parseResult)
+				parseResult, err := readExplainInput(os.Stdin, opts.DatabaseCommand)
 				if err != nil {
-					return fmt.Errorf("unable to parse piped input: %+v", err)
+					return err
 				}
-				explainer := explain.NewVulnerabilityExplainer(os.Stdout, &parseResult)
-				return explainer.ExplainByID(opts.CVEIDs)
+				if opts.interactive() {
+					return runTriage(app, *parseResult, opts.TriageOutput)
+				}
+				return runExplain(*opts, parseResult, os.Stdout)
 			}
 			// perform a scan, then explain requested CVEs
 			// TODO: implement
-			return fmt.Errorf("requires grype json on stdin, please run 'grype -o json ... | grype explain ...'")
+			return fmt.Errorf("requires grype json, sarif or table output on stdin, please run 'grype -o json ... | grype explain ...'")
 		},
 	}
 
 	// prevent from being shown in the grype config
 	type configWrapper struct {
-		Opts *explainOptions `json:"-" yaml:"-" mapstructure:"-"`
+		Opts                     *explainOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
 	}
 
-	return app.SetupCommand(cmd, &configWrapper{opts})
+	return app.SetupCommand(cmd, &configWrapper{Opts: opts, DatabaseCommand: &opts.DatabaseCommand})
+}
+
+// readExplainInput reads grype output to explain. Grype JSON carries the full details of every match, while matches
+// read from SARIF or table output are resolved against the vulnerability DB.
+func readExplainInput(r io.Reader, dbOpts options.DatabaseCommand) (*models.Document, error) {
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read piped input: %w", err)
+	}
+
+	var findings []explain.Finding
+	switch format := explain.DetectFormat(contents); format {
+	case explain.SARIFInput:
+		findings, err = explain.ParseSARIF(contents)
+	case explain.TableInput:
+		findings, err = explain.ParseTable(contents)
+	default:
+		var doc models.Document
+		if err := json.Unmarshal(contents, &doc); err != nil {
+			return nil, fmt.Errorf("unable to parse piped input: %+v", err)
+		}
+		return &doc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse piped input: %w", err)
+	}
+
+	vp, status, err := grype.LoadVulnerabilityDB(dbOpts.ToClientConfig(), dbOpts.ToCuratorConfig(), dbOpts.DB.AutoUpdate)
+	if err = validateDBLoad(err, status); err != nil {
+		return nil, err
+	}
+	defer log.CloseAndLogError(vp, status.Path)
+
+	return explain.Resolve(findings, vp)
+}
+
+func runExplain(opts explainOptions, doc *models.Document, w io.Writer) error {
+	format := explain.Format(opts.Output)
+	if format == "" {
+		format = explain.TextFormat
+	}
+	explainer := explain.NewVulnerabilityExplainerWithFormat(w, doc, format)
+	switch {
+	case opts.All && opts.MinSeverity != "":
+		return explainer.ExplainBySeverity(opts.MinSeverity)
+	case opts.All:
+		return explainer.ExplainAll()
+	}
+	return explainer.ExplainByID(opts.CVEIDs)
+}
+
+func runTriage(app clio.Application, doc models.Document, exportPrefix string) error {
+	session := triage.NewSession(doc)
+	model := triage.New(session, triage.Config{
+		Explain: func(id string) string {
+			var buf bytes.Buffer
+			if err := explain.NewVulnerabilityExplainer(&buf, &doc).ExplainByID([]string{id}); err != nil {
+				return fmt.Sprintf("unable to explain %s: %v", id, err)
+			}
+			return buf.String()
+		},
+		ExportPrefix: exportPrefix,
+		Author:       app.ID().Name,
+	})
+
+	// stdin holds the piped grype json, so keyboard input must be read from the terminal directly
+	if _, err := tea.NewProgram(model, tea.WithInputTTY(), tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("unable to run triage browser: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainOptionsPostLoad(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            explainOptions
+		wantInteractive bool
+		wantErr         string
+	}{
+		{
+			name: "explain by ID",
+			opts: explainOptions{CVEIDs: []string{"CVE-2023-5363"}},
+		},
+		{
+			name:            "browse all findings",
+			opts:            explainOptions{All: true},
+			wantInteractive: true,
+		},
+		{
+			name: "explain all findings above a severity",
+			opts: explainOptions{All: true, MinSeverity: "high"},
+		},
+		{
+			name: "explain all findings as JSON",
+			opts: explainOptions{All: true, Output: "json"},
+		},
+		{
+			name:    "min severity without all",
+			opts:    explainOptions{CVEIDs: []string{"CVE-2023-5363"}, MinSeverity: "high"},
+			wantErr: "--min-severity can only be used with --all",
+		},
+		{
+			name:    "invalid min severity",
+			opts:    explainOptions{All: true, MinSeverity: "severe"},
+			wantErr: "bad --min-severity value 'severe'",
+		},
+		{
+			name:    "invalid output",
+			opts:    explainOptions{All: true, Output: "sarif"},
+			wantErr: "invalid output format: sarif",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.PostLoad()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantInteractive, tt.opts.interactive())
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+const (
+	// DefaultPath is the path of the baseline file when none is given, relative to the working directory.
+	DefaultPath = ".grype-baseline.json"
+
+	schemaVersion = 1
+)
+
+// File is a baseline file: the accepted findings of a target kept alongside its code, such that scans with
+// --baseline only fail on new findings. Findings are identified by the hash of their vulnerability and package (see
+// match.BaselineIdentity), e.g.
+//
+//	{
+//	  "version": 1,
+//	  "source": "alpine:3.18",
+//	  "created": "2025-01-02T10:00:00Z",
+//	  "updated": "2025-01-02T10:00:00Z",
+//	  "findings": [
+//	    {
+//	      "id": "6c1d5f0e4b3a...",
+//	      "vulnerability": "CVE-2023-5678",
+//	      "package": "openssl",
+//	      "type": "apk",
+//	      "version": "3.1.4-r0",
+//	      "accepted": "2025-01-02T10:00:00Z"
+//	    }
+//	  ]
+//	}
+type File struct {
+	Version int `json:"version"`
+	// Source is the target of the report the baseline was last created or updated from
+	Source   string    `json:"source,omitempty"`
+	Created  time.Time `json:"created"`
+	Updated  time.Time `json:"updated"`
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is an accepted finding of a baseline file.
+type Finding struct {
+	// ID is the hashed identity of the finding by its vulnerability
+	ID string `json:"id"`
+	// Aliases are the hashed identities of the finding by related vulnerabilities (e.g. the CVE of a GHSA)
+	Aliases       []string `json:"aliases,omitempty"`
+	Vulnerability string   `json:"vulnerability"`
+	Package       string   `json:"package"`
+	Type          string   `json:"type"`
+	// Version is the version of the package when the finding was accepted (informational only, since findings remain
+	// accepted when the package is upgraded to another affected version)
+	Version  string    `json:"version,omitempty"`
+	Accepted time.Time `json:"accepted"`
+}
+
+// UpdateResult is the outcome of updating a baseline file from a report.
+type UpdateResult struct {
+	// Added are the findings of the report that were not in the baseline
+	Added []Finding
+	// Removed are the stale findings of the baseline that are no longer in the report
+	Removed []Finding
+}
+
+// New returns a baseline file accepting all the findings of the report.
+func New(doc models.Document, now time.Time) *File {
+	return &File{
+		Version:  schemaVersion,
+		Source:   source(doc),
+		Created:  now,
+		Updated:  now,
+		Findings: findings(doc, now),
+	}
+}
+
+// ReadFile reads the baseline file at the given path.
+func ReadFile(path string) (*File, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read baseline file: %w", err)
+	}
+	var f File
+	if err := json.Unmarshal(by, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse baseline file %q: %w", path, err)
+	}
+	if f.Version != schemaVersion {
+		return nil, fmt.Errorf("unsupported baseline file version %d in %q (expected %d)", f.Version, path, schemaVersion)
+	}
+	return &f, nil
+}
+
+// Load returns the baseline of the file at the given path, which is either a baseline file or the grype JSON report
+// of a previous scan.
+func Load(path string) (*match.Baseline, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read baseline: %w", err)
+	}
+
+	var probe struct {
+		Findings json.RawMessage `json:"findings"`
+	}
+	if err := json.Unmarshal(by, &probe); err != nil {
+		return nil, fmt.Errorf("unable to parse baseline %q (expected a baseline file or grype JSON): %w", path, err)
+	}
+	if probe.Findings != nil {
+		f, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return f.Baseline(), nil
+	}
+
+	var doc models.Document
+	if err := json.Unmarshal(by, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse baseline %q (expected a baseline file or grype JSON): %w", path, err)
+	}
+	return models.NewBaseline(doc), nil
+}
+
+// Write writes the baseline file to the given path.
+func (f *File) Write(path string) error {
+	by, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode baseline file: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(path), append(by, '\n'), 0o644); err != nil { //nolint:gosec // the baseline is meant to be committed alongside the code
+		return fmt.Errorf("unable to write baseline file: %w", err)
+	}
+	return nil
+}
+
+// Update accepts the new findings of the report and drops the stale findings that are no longer in the report. Findings
+// remaining in the baseline keep the time they were first accepted.
+func (f *File) Update(doc models.Document, now time.Time) UpdateResult {
+	existing := map[string]Finding{}
+	for _, finding := range f.Findings {
+		for _, id := range finding.identities() {
+			existing[id] = finding
+		}
+	}
+
+	var result UpdateResult
+	current := findings(doc, now)
+	for i, finding := range current {
+		if prev, ok := finding.lookup(existing); ok {
+			current[i].Accepted = prev.Accepted
+			continue
+		}
+		result.Added = append(result.Added, finding)
+	}
+	result.Removed = f.Stale(doc)
+
+	f.Findings = current
+	f.Source = source(doc)
+	f.Updated = now
+	return result
+}
+
+// Stale returns the findings of the baseline that are no longer in the report, e.g. since the vulnerable package was
+// upgraded or removed.
+func (f *File) Stale(doc models.Document) []Finding {
+	found := map[string]struct{}{}
+	for _, finding := range findings(doc, time.Time{}) {
+		found[finding.ID] = struct{}{}
+		for _, alias := range finding.Aliases {
+			found[alias] = struct{}{}
+		}
+	}
+
+	var stale []Finding
+	for _, finding := range f.Findings {
+		if !finding.foundIn(found) {
+			stale = append(stale, finding)
+		}
+	}
+	return stale
+}
+
+// Baseline returns the baseline of the accepted findings, which the matches of a scan are compared against.
+func (f *File) Baseline() *match.Baseline {
+	baseline := make([]match.BaselineFinding, 0, len(f.Findings))
+	for _, finding := range f.Findings {
+		baseline = append(baseline, match.BaselineFinding{
+			VulnerabilityIDs: []string{finding.Vulnerability},
+			PackageName:      finding.Package,
+			PackageType:      syftPkg.Type(finding.Type),
+			Identities:       finding.identities(),
+		})
+	}
+	return match.NewBaseline(baseline...)
+}
+
+// identities returns the hashed identities of the finding: its ID followed by its aliases.
+func (f Finding) identities() []string {
+	return append([]string{f.ID}, f.Aliases...)
+}
+
+func (f Finding) foundIn(identities map[string]struct{}) bool {
+	for _, id := range f.identities() {
+		if _, ok := identities[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Finding) lookup(findings map[string]Finding) (Finding, bool) {
+	for _, id := range f.identities() {
+		if finding, ok := findings[id]; ok {
+			return finding, true
+		}
+	}
+	return Finding{}, false
+}
+
+// findings returns the findings of the report, one per vulnerability and package, ordered by vulnerability and package.
+func findings(doc models.Document, now time.Time) []Finding {
+	byID := map[string]Finding{}
+	for _, m := range doc.Matches {
+		id := match.BaselineIdentity(m.Vulnerability.ID, m.Artifact.Name, m.Artifact.Type)
+		if _, ok := byID[id]; ok {
+			continue
+		}
+		var aliases []string
+		for _, related := range m.RelatedVulnerabilities {
+			if alias := match.BaselineIdentity(related.ID, m.Artifact.Name, m.Artifact.Type); alias != id {
+				aliases = append(aliases, alias)
+			}
+		}
+		byID[id] = Finding{
+			ID:            id,
+			Aliases:       aliases,
+			Vulnerability: m.Vulnerability.ID,
+			Package:       m.Artifact.Name,
+			Type:          string(m.Artifact.Type),
+			Version:       m.Artifact.Version,
+			Accepted:      now,
+		}
+	}
+
+	result := make([]Finding, 0, len(byID))
+	for _, finding := range byID {
+		result = append(result, finding)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Vulnerability != result[j].Vulnerability {
+			return result[i].Vulnerability < result[j].Vulnerability
+		}
+		if result[i].Package != result[j].Package {
+			return result[i].Package < result[j].Package
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+// source returns the target of the report, e.g. the user input of an image or the path of a directory.
+func source(doc models.Document) string {
+	if doc.Source == nil {
+		return ""
+	}
+	switch t := doc.Source.Target.(type) {
+	case string:
+		return t
+	case map[string]any:
+		for _, key := range []string{"userInput", "path"} {
+			if v, ok := t[key].(string); ok && v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+package baseline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func newReport(matches ...models.Match) models.Document {
+	return models.Document{Matches: matches}
+}
+
+func newReportMatch(id, name, version string, pkgType syftPkg.Type, related ...string) models.Match {
+	var refs []models.VulnerabilityMetadata
+	for _, r := range related {
+		refs = append(refs, models.VulnerabilityMetadata{ID: r})
+	}
+	return models.Match{
+		Vulnerability:          models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: id}},
+		RelatedVulnerabilities: refs,
+		Artifact:               models.Package{Name: name, Version: version, Type: pkgType},
+	}
+}
+
+func TestNew(t *testing.T) {
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	f := New(newReport(
+		newReportMatch("CVE-2023-5678", "openssl", "3.1.4-r0", syftPkg.ApkPkg),
+		newReportMatch("GHSA-abcd-1234-wxyz", "requests", "2.25.0", syftPkg.PythonPkg, "CVE-2023-1"),
+		// the same finding by another matcher is only accepted once
+		newReportMatch("CVE-2023-5678", "openssl", "3.1.4-r0", syftPkg.ApkPkg),
+	), now)
+
+	assert.Equal(t, schemaVersion, f.Version)
+	assert.Equal(t, now, f.Created)
+	assert.Equal(t, now, f.Updated)
+	assert.Equal(t, []Finding{
+		{
+			ID:            match.BaselineIdentity("CVE-2023-5678", "openssl", syftPkg.ApkPkg),
+			Vulnerability: "CVE-2023-5678",
+			Package:       "openssl",
+			Type:          "apk",
+			Version:       "3.1.4-r0",
+			Accepted:      now,
+		},
+		{
+			ID:            match.BaselineIdentity("GHSA-abcd-1234-wxyz", "requests", syftPkg.PythonPkg),
+			Aliases:       []string{match.BaselineIdentity("CVE-2023-1", "requests", syftPkg.PythonPkg)},
+			Vulnerability: "GHSA-abcd-1234-wxyz",
+			Package:       "requests",
+			Type:          "python",
+			Version:       "2.25.0",
+			Accepted:      now,
+		},
+	}, f.Findings)
+}
+
+func TestFile_Update(t *testing.T) {
+	created := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	updated := created.AddDate(0, 1, 0)
+
+	f := New(newReport(
+		newReportMatch("CVE-2023-5678", "openssl", "3.1.4-r0", syftPkg.ApkPkg),
+		newReportMatch("GHSA-abcd-1234-wxyz", "requests", "2.25.0", syftPkg.PythonPkg, "CVE-2023-1"),
+		newReportMatch("CVE-2022-9", "busybox", "1.36.1-r0", syftPkg.ApkPkg),
+	), created)
+
+	result := f.Update(newReport(
+		// upgraded to another affected version
+		newReportMatch("CVE-2023-5678", "openssl", "3.1.5-r0", syftPkg.ApkPkg),
+		// normalized by CVE
+		newReportMatch("CVE-2023-1", "requests", "2.25.0", syftPkg.PythonPkg, "GHSA-abcd-1234-wxyz"),
+		newReportMatch("CVE-2024-7", "zlib", "1.3-r0", syftPkg.ApkPkg),
+	), updated)
+
+	require.Len(t, result.Added, 1)
+	assert.Equal(t, "CVE-2024-7", result.Added[0].Vulnerability)
+	require.Len(t, result.Removed, 1)
+	assert.Equal(t, "CVE-2022-9", result.Removed[0].Vulnerability)
+
+	assert.Equal(t, created, f.Created)
+	assert.Equal(t, updated, f.Updated)
+	require.Len(t, f.Findings, 3)
+	accepted := map[string]time.Time{}
+	for _, finding := range f.Findings {
+		accepted[finding.Vulnerability] = finding.Accepted
+	}
+	assert.Equal(t, map[string]time.Time{
+		"CVE-2023-1":    created,
+		"CVE-2023-5678": created,
+		"CVE-2024-7":    updated,
+	}, accepted)
+}
+
+func TestFile_Stale(t *testing.T) {
+	f := New(newReport(
+		newReportMatch("CVE-2023-5678", "openssl", "3.1.4-r0", syftPkg.ApkPkg),
+		newReportMatch("CVE-2022-9", "busybox", "1.36.1-r0", syftPkg.ApkPkg),
+	), time.Now())
+
+	stale := f.Stale(newReport(newReportMatch("CVE-2023-5678", "openssl", "3.1.5-r0", syftPkg.ApkPkg)))
+	require.Len(t, stale, 1)
+	assert.Equal(t, "busybox", stale[0].Package)
+
+	assert.Empty(t, f.Stale(newReport(
+		newReportMatch("cve-2023-5678", "openssl", "3.1.4-r0", syftPkg.ApkPkg),
+		newReportMatch("CVE-2022-9", "busybox", "1.36.1-r0", syftPkg.ApkPkg),
+	)))
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	f := New(newReport(newReportMatch("CVE-2023-5678", "openssl", "3.1.4-r0", syftPkg.ApkPkg)), time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC))
+
+	path := filepath.Join(dir, DefaultPath)
+	require.NoError(t, f.Write(path))
+	got, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, f, got)
+
+	unsupported := filepath.Join(dir, "unsupported.json")
+	require.NoError(t, os.WriteFile(unsupported, []byte(`{"version": 9, "findings": []}`), 0o600))
+	_, err = ReadFile(unsupported)
+	require.ErrorContains(t, err, "unsupported baseline file version 9")
+
+	_, err = ReadFile(filepath.Join(dir, "missing.json"))
+	require.ErrorContains(t, err, "unable to read baseline file")
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	report := newReport(newReportMatch("GHSA-abcd-1234-wxyz", "requests", "2.25.0", syftPkg.PythonPkg, "CVE-2023-1"))
+	known := match.Match{
+		Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-1"}},
+		Package:       pkg.Package{Name: "requests", Version: "2.26.0", Type: syftPkg.PythonPkg},
+	}
+
+	baselinePath := filepath.Join(dir, DefaultPath)
+	require.NoError(t, New(report, time.Now()).Write(baselinePath))
+
+	reportPath := filepath.Join(dir, "report.json")
+	by, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(reportPath, by, 0o600))
+
+	for _, path := range []string{baselinePath, reportPath} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			b, err := Load(path)
+			require.NoError(t, err)
+			assert.Equal(t, 1, b.Len())
+			assert.True(t, b.Contains(known))
+		})
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`not json`), 0o600))
+	_, err = Load(invalid)
+	require.ErrorContains(t, err, "expected a baseline file or grype JSON")
+}
@@ -91,6 +91,8 @@ type AffectedPackagesOptions struct {
 	AllowBroadCPEMatching bool
 	RecordLimit           int
 	FixedStates           []string
+	// Severities are the normalized severities (e.g. "critical", "high") of the vulnerabilities to include
+	Severities []string
 }
 
 type affectedPackageWithDecorations struct {
@@ -208,34 +210,50 @@ func FindAffectedPackages(reader interface {
 		allAffectedCPEs = filterByFixedStateForCPEs(allAffectedCPEs, criteria.FixedStates)
 	}
 
+	if len(criteria.Severities) > 0 {
+		allAffectedPkgs = filterBySeverityForPackages(allAffectedPkgs, criteria.Severities)
+		allAffectedCPEs = filterBySeverityForCPEs(allAffectedCPEs, criteria.Severities)
+	}
+
 	return newAffectedPackageRows(allAffectedPkgs, allAffectedCPEs), nil
 }
 
-func findAffectedPackages(reader interface { //nolint:funlen,gocognit
+func findAffectedPackages(reader interface {
 	v6.AffectedPackageStoreReader
 	v6.AffectedCPEStoreReader
 	v6.VulnerabilityDecoratorStoreReader
 }, config AffectedPackagesOptions,
 ) ([]affectedPackageWithDecorations, []affectedCPEWithDecorations, error) {
-	var allAffectedPkgs []affectedPackageWithDecorations
-	var allAffectedCPEs []affectedCPEWithDecorations
-
-	pkgSpecs := config.Package
-	cpeSpecs := config.CPE
-	osSpecs := config.OS
-	vulnSpecs := config.Vulnerability
-
 	if config.RecordLimit == 0 {
 		log.Warn("no record limit set! For queries with large result sets this may result in performance issues")
 	}
 
-	if len(vulnSpecs) == 0 && len(pkgSpecs) == 0 && len(cpeSpecs) == 0 {
+	allAffectedPkgs, allAffectedCPEs, err := fetchAffectedPackages(reader, config)
+
+	// decorate any given results, including those found before reaching the record limit
+	for i := range allAffectedPkgs {
+		decorateVulnerabilities(reader, &allAffectedPkgs[i])
+	}
+
+	for i := range allAffectedCPEs {
+		decorateVulnerabilities(reader, &allAffectedCPEs[i])
+	}
+
+	return allAffectedPkgs, allAffectedCPEs, err
+}
+
+// resolveAffectedPackageSpecs returns the package and CPE specifiers to search by for the given criteria.
+func resolveAffectedPackageSpecs(config AffectedPackagesOptions) (v6.PackageSpecifiers, v6.PackageSpecifiers, error) {
+	pkgSpecs := config.Package
+	cpeSpecs := config.CPE
+
+	if len(config.Vulnerability) == 0 && len(pkgSpecs) == 0 && len(cpeSpecs) == 0 {
 		return nil, nil, ErrNoSearchCriteria
 	}
 
 	// don't allow for searching by any package AND any CPE AND any vulnerability AND any OS. Since these searches
 	// are oriented by primarily package, we only want to have ANY package/CPE when there is a vulnerability or OS specified.
-	if len(vulnSpecs) > 0 || !osSpecs.IsAny() {
+	if len(config.Vulnerability) > 0 || !config.OS.IsAny() {
 		if len(pkgSpecs) == 0 {
 			pkgSpecs = []*v6.PackageSpecifier{v6.AnyPackageSpecified}
 		}
@@ -245,17 +263,24 @@ func findAffectedPackages(reader interface { //nolint:funlen,gocognit
 		}
 	}
 
-	// we have multiple return points that return actual values, using a defer to decorate any given results
-	// ensures that all paths are handled the same way.
-	defer func() {
-		for i := range allAffectedPkgs {
-			decorateVulnerabilities(reader, &allAffectedPkgs[i])
-		}
+	return pkgSpecs, cpeSpecs, nil
+}
 
-		for i := range allAffectedCPEs {
-			decorateVulnerabilities(reader, &allAffectedCPEs[i])
-		}
-	}()
+func fetchAffectedPackages(reader interface {
+	v6.AffectedPackageStoreReader
+	v6.AffectedCPEStoreReader
+}, config AffectedPackagesOptions,
+) ([]affectedPackageWithDecorations, []affectedCPEWithDecorations, error) {
+	var allAffectedPkgs []affectedPackageWithDecorations
+	var allAffectedCPEs []affectedCPEWithDecorations
+
+	osSpecs := config.OS
+	vulnSpecs := config.Vulnerability
+
+	pkgSpecs, cpeSpecs, err := resolveAffectedPackageSpecs(config)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	for i := range pkgSpecs {
 		pkgSpec := pkgSpecs[i]
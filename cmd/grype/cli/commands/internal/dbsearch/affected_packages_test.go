@@ -885,6 +885,16 @@ func (m *affectedMockReader) GetAffectedCPEs(cpeSpec *cpe.Attributes, options *v
 	return args.Get(0).([]v6.AffectedCPEHandle), args.Error(1)
 }
 
+func (m *affectedMockReader) CountAffectedPackages(pkgSpec *v6.PackageSpecifier, options *v6.GetPackageOptions, groupBy v6.CountGroupBy) ([]v6.CountGroup, error) {
+	args := m.Called(pkgSpec, options, groupBy)
+	return args.Get(0).([]v6.CountGroup), args.Error(1)
+}
+
+func (m *affectedMockReader) CountAffectedCPEs(cpeSpec *cpe.Attributes, options *v6.GetCPEOptions, groupBy v6.CountGroupBy) ([]v6.CountGroup, error) {
+	args := m.Called(cpeSpec, options, groupBy)
+	return args.Get(0).([]v6.CountGroup), args.Error(1)
+}
+
 func (m *affectedMockReader) GetKnownExploitedVulnerabilities(cve string) ([]v6.KnownExploitedVulnerabilityHandle, error) {
 	args := m.Called(cve)
 	return args.Get(0).([]v6.KnownExploitedVulnerabilityHandle), args.Error(1)
@@ -895,6 +905,16 @@ func (m *affectedMockReader) GetEpss(cve string) ([]v6.EpssHandle, error) {
 	return args.Get(0).([]v6.EpssHandle), args.Error(1)
 }
 
+func (m *affectedMockReader) FindKnownExploitedVulnerabilities(spec *v6.KnownExploitedSpecifier, limit int) ([]v6.KnownExploitedVulnerabilityHandle, error) {
+	args := m.Called(spec, limit)
+	return args.Get(0).([]v6.KnownExploitedVulnerabilityHandle), args.Error(1)
+}
+
+func (m *affectedMockReader) FindEpss(spec *v6.EpssSpecifier, limit int) ([]v6.EpssHandle, error) {
+	args := m.Called(spec, limit)
+	return args.Get(0).([]v6.EpssHandle), args.Error(1)
+}
+
 func (m *affectedMockReader) GetCWEs(cve string) ([]v6.CWEHandle, error) {
 	args := m.Called(cve)
 	return args.Get(0).([]v6.CWEHandle), args.Error(1)
@@ -1116,6 +1136,73 @@ func TestFilterByFixedStateForCPEs(t *testing.T) {
 	}
 }
 
+func TestFilterBySeverityForPackages(t *testing.T) {
+	tests := []struct {
+		name        string
+		packages    []affectedPackageWithDecorations
+		severities  []string
+		expectedLen int
+	}{
+		{
+			name: "empty severities returns all packages",
+			packages: []affectedPackageWithDecorations{
+				makeAffectedPackageWithSeverity("high"),
+				makeAffectedPackageWithSeverity("low"),
+			},
+			severities:  []string{},
+			expectedLen: 2,
+		},
+		{
+			name: "filter by normalized severity",
+			packages: []affectedPackageWithDecorations{
+				makeAffectedPackageWithSeverity("High"),
+				makeAffectedPackageWithSeverity("low"),
+			},
+			severities:  []string{"high"},
+			expectedLen: 1,
+		},
+		{
+			name: "filter by multiple severities",
+			packages: []affectedPackageWithDecorations{
+				makeAffectedPackageWithSeverity("critical"),
+				makeAffectedPackageWithSeverity("high"),
+				makeAffectedPackageWithSeverity("medium"),
+			},
+			severities:  []string{"critical", "high"},
+			expectedLen: 2,
+		},
+		{
+			name: "vulnerabilities without severity are unknown",
+			packages: []affectedPackageWithDecorations{
+				{AffectedPackageHandle: v6.AffectedPackageHandle{Vulnerability: &v6.VulnerabilityHandle{BlobValue: &v6.VulnerabilityBlob{}}}},
+				{AffectedPackageHandle: v6.AffectedPackageHandle{Vulnerability: nil}},
+				makeAffectedPackageWithSeverity("high"),
+			},
+			severities:  []string{"unknown"},
+			expectedLen: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := filterBySeverityForPackages(tt.packages, tt.severities)
+			assert.Equal(t, tt.expectedLen, len(result))
+		})
+	}
+}
+
+func makeAffectedPackageWithSeverity(severity string) affectedPackageWithDecorations {
+	return affectedPackageWithDecorations{
+		AffectedPackageHandle: v6.AffectedPackageHandle{
+			Vulnerability: &v6.VulnerabilityHandle{
+				BlobValue: &v6.VulnerabilityBlob{
+					Severities: []v6.Severity{{Scheme: v6.SeveritySchemeCHMLN, Value: severity}},
+				},
+			},
+		},
+	}
+}
+
 func makeAffectedPackageWithFixState(state v6.FixStatus) affectedPackageWithDecorations {
 	return affectedPackageWithDecorations{
 		AffectedPackageHandle: v6.AffectedPackageHandle{
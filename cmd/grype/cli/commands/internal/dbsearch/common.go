@@ -1,6 +1,11 @@
 package dbsearch
 
-import v6 "github.com/anchore/grype/grype/db/v6"
+import (
+	"slices"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/vulnerability"
+)
 
 const (
 	fixStateFixed    = "fixed"
@@ -98,3 +103,46 @@ func filterByFixedStateForCPEs(cpes []affectedCPEWithDecorations, fixedStates []
 
 	return filtered
 }
+
+// getNormalizedSeverity returns the severity of the vulnerability as reported in the search results, normalized to one
+// of the known severities (e.g. "high").
+func getNormalizedSeverity(vuln *v6.VulnerabilityHandle) string {
+	if vuln == nil || vuln.BlobValue == nil {
+		return vulnerability.UnknownSeverity.String()
+	}
+	blob := *vuln.BlobValue
+	// CVSS severities are patched in place, leave the record untouched
+	blob.Severities = slices.Clone(blob.Severities)
+	patchCVSSMetrics(&blob)
+	return vulnerability.ParseSeverity(getSeverity(blob.Severities)).String()
+}
+
+func filterBySeverityForPackages(packages []affectedPackageWithDecorations, severities []string) []affectedPackageWithDecorations {
+	if len(severities) == 0 {
+		return packages
+	}
+
+	var filtered []affectedPackageWithDecorations
+	for _, pkg := range packages {
+		if slices.Contains(severities, getNormalizedSeverity(pkg.Vulnerability)) {
+			filtered = append(filtered, pkg)
+		}
+	}
+
+	return filtered
+}
+
+func filterBySeverityForCPEs(cpes []affectedCPEWithDecorations, severities []string) []affectedCPEWithDecorations {
+	if len(severities) == 0 {
+		return cpes
+	}
+
+	var filtered []affectedCPEWithDecorations
+	for _, cpe := range cpes {
+		if slices.Contains(severities, getNormalizedSeverity(cpe.Vulnerability)) {
+			filtered = append(filtered, cpe)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,370 @@
+package dbsearch
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/scylladb/go-set/strset"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+)
+
+var ErrVulnerabilityNotFound = errors.New("vulnerability not found")
+
+// VulnerabilityDetail is the JSON document for the `db show` command, which combines the records from all providers
+// for a single vulnerability.
+type VulnerabilityDetail struct {
+	// ID is the vulnerability ID that was requested (e.g. CVE-2024-12345)
+	ID string `json:"id"`
+
+	// Aliases are the other IDs the vulnerability is known by across all records
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Description of the vulnerability, preferring the record named by the ID
+	Description string `json:"description,omitempty"`
+
+	// Severity is the single string representation of the vulnerability's severity, from the first record with any
+	// severity (preferring the records named by the ID)
+	Severity string `json:"severity"`
+
+	// PublishedDate is the earliest date the vulnerability was published by any provider
+	PublishedDate *time.Time `json:"published_date,omitempty"`
+
+	// ModifiedDate is the latest date the vulnerability was modified by any provider
+	ModifiedDate *time.Time `json:"modified_date,omitempty"`
+
+	// Severities are the severity assessments from all records
+	Severities []ProviderSeverity `json:"severities,omitempty"`
+
+	// AffectedPackages are the packages affected by the vulnerability, grouped by ecosystem or operating system release
+	AffectedPackages []AffectedPackageGroup `json:"affected_packages,omitempty"`
+
+	// AffectedCPEs are the CPEs affected by the vulnerability
+	AffectedCPEs []AffectedPackageDetail `json:"affected_cpes,omitempty"`
+
+	// KnownExploited are the CISA KEV entries for the related CVEs
+	KnownExploited []KnownExploited `json:"known_exploited,omitempty"`
+
+	// EPSS are the Exploit Prediction Scoring System (EPSS) scores for the related CVEs
+	EPSS []EPSS `json:"epss,omitempty"`
+
+	// CWEs are the Common Weakness Enumeration (CWE) identifiers for the related CVEs
+	CWEs []CWE `json:"cwes,omitempty"`
+
+	// References are the external resources from all records (deduplicated by URL)
+	References []v6.Reference `json:"refs,omitempty"`
+}
+
+// ProviderSeverity is a single severity assessment from a vulnerability record.
+type ProviderSeverity struct {
+	// Provider is the upstream data processor of the record (e.g. "nvd" or "github")
+	Provider string `json:"provider"`
+
+	// Vulnerability is the ID of the record the severity is from
+	Vulnerability string `json:"vulnerability"`
+
+	// Scheme is the method used to assess the severity (e.g. "CVSS" or "CHMLN")
+	Scheme string `json:"scheme"`
+
+	// Source is the source of the severity within the record (e.g. "nvd@nist.gov")
+	Source string `json:"source,omitempty"`
+
+	// Severity is the normalized severity (e.g. "high")
+	Severity string `json:"severity"`
+
+	// Value is the severity assessment (a string or the CVSS vector with its metrics)
+	Value any `json:"value"`
+}
+
+// AffectedPackageGroup are the packages affected by the vulnerability within an ecosystem (e.g. "npm") or an
+// operating system release (e.g. "debian 12").
+type AffectedPackageGroup struct {
+	// Ecosystem is the ecosystem of the packages (for packages not released for an operating system)
+	Ecosystem string `json:"ecosystem,omitempty"`
+
+	// OS is the operating system release the packages are released for
+	OS *OperatingSystem `json:"os,omitempty"`
+
+	Packages []AffectedPackageDetail `json:"packages"`
+}
+
+// AffectedPackageDetail is a single package (or CPE) affected by a vulnerability record.
+type AffectedPackageDetail struct {
+	// Name is the name of the package (or the CPE)
+	Name string `json:"name"`
+
+	// Provider is the upstream data processor of the record
+	Provider string `json:"provider"`
+
+	// Vulnerability is the ID of the record the package is affected by
+	Vulnerability string `json:"vulnerability"`
+
+	// Ranges are the affected version ranges and fixes
+	Ranges []v6.Range `json:"ranges,omitempty"`
+}
+
+// ShowVulnerability gathers everything known about a single vulnerability: all records named by the given ID (or
+// that have it as an alias), the packages and CPEs affected by these records, and the KEV, EPSS, and CWE information
+// for the related CVEs. ErrVulnerabilityNotFound is returned when there are no records for the ID.
+func ShowVulnerability(reader interface {
+	v6.VulnerabilityStoreReader
+	v6.AffectedPackageStoreReader
+	v6.AffectedCPEStoreReader
+	v6.VulnerabilityDecoratorStoreReader
+}, id string,
+) (*VulnerabilityDetail, error) {
+	log.WithFields("id", id).Debug("fetching vulnerability details")
+
+	vulns, err := reader.GetVulnerabilities(&v6.VulnerabilitySpecifier{Name: id, IncludeAliases: true}, &v6.GetVulnerabilityOptions{
+		Preload: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get vulnerabilities: %w", err)
+	}
+	if len(vulns) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrVulnerabilityNotFound, id)
+	}
+
+	// records named by the ID come first, then ordered by provider (to keep the output stable)
+	sort.SliceStable(vulns, func(i, j int) bool {
+		iNamed, jNamed := strings.EqualFold(vulns[i].Name, id), strings.EqualFold(vulns[j].Name, id)
+		if iNamed != jNamed {
+			return iNamed
+		}
+		if vulns[i].ProviderID != vulns[j].ProviderID {
+			return vulns[i].ProviderID < vulns[j].ProviderID
+		}
+		return vulns[i].Name < vulns[j].Name
+	})
+
+	var vulnSpecs v6.VulnerabilitySpecifiers
+	for _, v := range vulns {
+		vulnSpecs = append(vulnSpecs, v6.VulnerabilitySpecifier{ID: v.ID})
+	}
+
+	affectedPkgs, affectedCPEs, err := fetchAffectedPackages(reader, AffectedPackagesOptions{
+		Vulnerability: vulnSpecs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := newVulnerabilityDetail(id, vulns)
+	detail.AffectedPackages = newAffectedPackageGroups(affectedPkgs)
+	detail.AffectedCPEs = newAffectedCPEDetails(affectedCPEs)
+	decorateVulnerabilityDetail(reader, detail, vulns)
+
+	return detail, nil
+}
+
+func newVulnerabilityDetail(id string, vulns []v6.VulnerabilityHandle) *VulnerabilityDetail {
+	detail := &VulnerabilityDetail{
+		ID:       id,
+		Severity: vulnerability.UnknownSeverity.String(),
+	}
+
+	aliases := strset.New()
+	refs := strset.New()
+	var hasSeverity bool
+	for _, v := range vulns {
+		info := newVulnerabilityInfo(v, vulnerabilityDecorations{})
+
+		for _, alias := range append([]string{v.Name, info.ID}, info.Aliases...) {
+			if alias != "" && !strings.EqualFold(alias, id) {
+				aliases.Add(alias)
+			}
+		}
+
+		if !hasSeverity && len(info.Severities) > 0 {
+			detail.Severity = info.Severity
+			hasSeverity = true
+		}
+		if detail.Description == "" {
+			detail.Description = info.Description
+		}
+
+		if info.PublishedDate != nil && (detail.PublishedDate == nil || info.PublishedDate.Before(*detail.PublishedDate)) {
+			detail.PublishedDate = info.PublishedDate
+		}
+		if info.ModifiedDate != nil && (detail.ModifiedDate == nil || info.ModifiedDate.After(*detail.ModifiedDate)) {
+			detail.ModifiedDate = info.ModifiedDate
+		}
+
+		for _, sev := range info.Severities {
+			detail.Severities = append(detail.Severities, ProviderSeverity{
+				Provider:      info.Provider,
+				Vulnerability: v.Name,
+				Scheme:        string(sev.Scheme),
+				Source:        sev.Source,
+				Severity:      vulnerability.ParseSeverity(getSeverity([]v6.Severity{sev})).String(),
+				Value:         sev.Value,
+			})
+		}
+
+		for _, ref := range info.References {
+			if ref.URL == "" || refs.Has(ref.URL) {
+				continue
+			}
+			refs.Add(ref.URL)
+			detail.References = append(detail.References, ref)
+		}
+	}
+
+	detail.Aliases = aliases.List()
+	sort.Strings(detail.Aliases)
+
+	return detail
+}
+
+func newAffectedPackageGroups(affectedPkgs []affectedPackageWithDecorations) []AffectedPackageGroup {
+	type groupKey struct {
+		ecosystem string
+		os        OperatingSystem
+	}
+
+	var keys []groupKey
+	groups := make(map[groupKey]*AffectedPackageGroup)
+	for _, a := range affectedPkgs {
+		if a.Vulnerability == nil || a.Package == nil {
+			continue
+		}
+
+		key := groupKey{ecosystem: a.Package.Ecosystem}
+		os := toOS(a.OperatingSystem)
+		if os != nil {
+			key = groupKey{os: *os}
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &AffectedPackageGroup{OS: os}
+			if os == nil {
+				g.Ecosystem = key.ecosystem
+			}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+
+		g.Packages = append(g.Packages, newAffectedPackageDetail(a.Package.Name, a.Vulnerability, a.BlobValue))
+	}
+
+	// language ecosystems first, then operating system releases
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ecosystem != keys[j].ecosystem {
+			if keys[i].ecosystem == "" || keys[j].ecosystem == "" {
+				return keys[i].ecosystem != ""
+			}
+			return keys[i].ecosystem < keys[j].ecosystem
+		}
+		if keys[i].os.Name != keys[j].os.Name {
+			return keys[i].os.Name < keys[j].os.Name
+		}
+		return keys[i].os.Version < keys[j].os.Version
+	})
+
+	var out []AffectedPackageGroup
+	for _, k := range keys {
+		g := groups[k]
+		sortAffectedPackageDetails(g.Packages)
+		out = append(out, *g)
+	}
+	return out
+}
+
+func newAffectedCPEDetails(affectedCPEs []affectedCPEWithDecorations) []AffectedPackageDetail {
+	var out []AffectedPackageDetail
+	for _, a := range affectedCPEs {
+		if a.Vulnerability == nil || a.CPE == nil {
+			continue
+		}
+		c := CPE(*a.CPE)
+		out = append(out, newAffectedPackageDetail(c.String(), a.Vulnerability, a.BlobValue))
+	}
+	sortAffectedPackageDetails(out)
+	return out
+}
+
+func newAffectedPackageDetail(name string, vuln *v6.VulnerabilityHandle, blob *v6.PackageBlob) AffectedPackageDetail {
+	d := AffectedPackageDetail{
+		Name:          name,
+		Provider:      vuln.ProviderID,
+		Vulnerability: vuln.Name,
+	}
+	if blob != nil {
+		d.Ranges = blob.Ranges
+	}
+	return d
+}
+
+func sortAffectedPackageDetails(details []AffectedPackageDetail) {
+	sort.SliceStable(details, func(i, j int) bool {
+		if details[i].Name != details[j].Name {
+			return details[i].Name < details[j].Name
+		}
+		if details[i].Provider != details[j].Provider {
+			return details[i].Provider < details[j].Provider
+		}
+		return details[i].Vulnerability < details[j].Vulnerability
+	})
+}
+
+// decorateVulnerabilityDetail adds the KEV, EPSS, and CWE information for all CVEs related to the records. These are
+// best-effort, so errors are only logged.
+func decorateVulnerabilityDetail(reader v6.VulnerabilityDecoratorStoreReader, detail *VulnerabilityDetail, vulns []v6.VulnerabilityHandle) {
+	var cves []string
+	seen := strset.New()
+	for i := range vulns {
+		for _, cve := range getCVEs(&vulns[i]) {
+			if !seen.Has(strings.ToLower(cve)) {
+				seen.Add(strings.ToLower(cve))
+				cves = append(cves, cve)
+			}
+		}
+	}
+	if len(cves) == 0 {
+		return
+	}
+
+	var err error
+	detail.KnownExploited, err = fetchKnownExploited(reader, cves)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to get known exploited vulnerabilities")
+	}
+
+	detail.EPSS, err = fetchEpss(reader, cves)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to get EPSS scores")
+	}
+
+	detail.CWEs, err = fetchCWEs(reader, cves)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to get CWEs")
+	}
+}
+
+func fetchCWEs(reader v6.VulnerabilityDecoratorStoreReader, cves []string) ([]CWE, error) {
+	var out []CWE
+	var errs error
+	for _, cve := range cves {
+		cwes, err := reader.GetCWEs(cve)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		for _, c := range cwes {
+			out = append(out, CWE{
+				Cve:    c.CVE,
+				CWE:    c.CWE,
+				Source: c.Source,
+				Type:   c.Type,
+			})
+		}
+	}
+	return out, errs
+}
@@ -0,0 +1,152 @@
+package dbsearch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestShowVulnerability(t *testing.T) {
+	published := time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC)
+	ghsaPublished := time.Date(2021, 12, 9, 0, 0, 0, 0, time.UTC)
+	modified := time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+
+	ghsa := v6.VulnerabilityHandle{
+		ID:            2,
+		Name:          "GHSA-jfh8-c2jp-5v3q",
+		ProviderID:    "github",
+		Provider:      &v6.Provider{ID: "github"},
+		PublishedDate: &ghsaPublished,
+		BlobValue: &v6.VulnerabilityBlob{
+			ID:          "GHSA-jfh8-c2jp-5v3q",
+			Description: "Remote code injection in Log4j",
+			Aliases:     []string{"CVE-2021-44228"},
+			References:  []v6.Reference{{URL: "https://logging.apache.org/log4j/2.x/security.html"}},
+			Severities:  []v6.Severity{{Scheme: v6.SeveritySchemeCHMLN, Value: "critical"}},
+		},
+	}
+	nvd := v6.VulnerabilityHandle{
+		ID:            1,
+		Name:          "CVE-2021-44228",
+		ProviderID:    "nvd",
+		Provider:      &v6.Provider{ID: "nvd"},
+		PublishedDate: &published,
+		ModifiedDate:  &modified,
+		BlobValue: &v6.VulnerabilityBlob{
+			ID:          "CVE-2021-44228",
+			Description: "Apache Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints.",
+			References: []v6.Reference{
+				{URL: "https://logging.apache.org/log4j/2.x/security.html"},
+				{URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+			},
+			Severities: []v6.Severity{{
+				Scheme: v6.SeveritySchemeCVSS,
+				Value:  v6.CVSSSeverity{Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Version: "3.1"},
+				Source: "nvd@nist.gov",
+			}},
+		},
+	}
+	debian := v6.VulnerabilityHandle{
+		ID:         3,
+		Name:       "CVE-2021-44228",
+		ProviderID: "debian",
+		Provider:   &v6.Provider{ID: "debian"},
+		BlobValue:  &v6.VulnerabilityBlob{ID: "CVE-2021-44228"},
+	}
+
+	fixed := func(constraint, version string) []v6.Range {
+		return []v6.Range{{
+			Version: v6.Version{Constraint: constraint},
+			Fix:     &v6.Fix{Version: version, State: v6.FixedStatus},
+		}}
+	}
+
+	mockReader := new(mockVulnReader)
+	mockReader.On("GetVulnerabilities", &v6.VulnerabilitySpecifier{Name: "CVE-2021-44228", IncludeAliases: true}, mock.Anything).
+		Return([]v6.VulnerabilityHandle{ghsa, debian, nvd}, nil)
+	mockReader.On("GetAffectedPackages", mock.Anything, mock.Anything).Return([]v6.AffectedPackageHandle{
+		{
+			Vulnerability: &debian,
+			OperatingSystem: &v6.OperatingSystem{
+				Name:         "debian",
+				MajorVersion: "12",
+			},
+			Package:   &v6.Package{Name: "apache-log4j2", Ecosystem: "deb"},
+			BlobValue: &v6.PackageBlob{Ranges: fixed("< 2.15.0-1", "2.15.0-1")},
+		},
+		{
+			Vulnerability: &ghsa,
+			Package:       &v6.Package{Name: "org.apache.logging.log4j:log4j-core", Ecosystem: "java-archive"},
+			BlobValue:     &v6.PackageBlob{Ranges: fixed(">= 2.0.0, < 2.15.0", "2.15.0")},
+		},
+	}, nil)
+	mockReader.On("GetAffectedCPEs", mock.Anything, mock.Anything).Return([]v6.AffectedCPEHandle{
+		{
+			Vulnerability: &nvd,
+			CPE:           &v6.Cpe{Part: "a", Vendor: "apache", Product: "log4j"},
+			BlobValue:     &v6.PackageBlob{Ranges: fixed("< 2.15.0", "2.15.0")},
+		},
+	}, nil)
+	mockReader.On("GetKnownExploitedVulnerabilities", "CVE-2021-44228").Return([]v6.KnownExploitedVulnerabilityHandle{
+		{Cve: "CVE-2021-44228", BlobValue: &v6.KnownExploitedVulnerabilityBlob{Cve: "CVE-2021-44228", KnownRansomwareCampaignUse: "Known"}},
+	}, nil)
+	mockReader.On("GetEpss", "CVE-2021-44228").Return([]v6.EpssHandle{
+		{Cve: "CVE-2021-44228", Epss: 0.97, Percentile: 0.99, Date: published},
+	}, nil)
+	mockReader.On("GetCWEs", "CVE-2021-44228").Return([]v6.CWEHandle{
+		{CVE: "CVE-2021-44228", CWE: "CWE-502", Source: "nvd@nist.gov", Type: "Primary"},
+	}, nil)
+
+	detail, err := ShowVulnerability(mockReader, "CVE-2021-44228")
+	require.NoError(t, err)
+
+	assert.Equal(t, "CVE-2021-44228", detail.ID)
+	assert.Equal(t, []string{"GHSA-jfh8-c2jp-5v3q"}, detail.Aliases)
+	// records named by the ID are preferred (the debian record has no description)
+	assert.Equal(t, "Apache Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints.", detail.Description)
+	// the debian record has no severity either, so the nvd severity is used
+	assert.Equal(t, "critical", detail.Severity)
+	assert.Equal(t, &ghsaPublished, detail.PublishedDate)
+	assert.Equal(t, &modified, detail.ModifiedDate)
+
+	require.Len(t, detail.Severities, 2)
+	assert.Equal(t, "nvd", detail.Severities[0].Provider)
+	assert.Equal(t, "critical", detail.Severities[0].Severity)
+	assert.Equal(t, "github", detail.Severities[1].Provider)
+	assert.Equal(t, "GHSA-jfh8-c2jp-5v3q", detail.Severities[1].Vulnerability)
+
+	require.Len(t, detail.AffectedPackages, 2)
+	assert.Equal(t, "java-archive", detail.AffectedPackages[0].Ecosystem)
+	assert.Equal(t, "org.apache.logging.log4j:log4j-core", detail.AffectedPackages[0].Packages[0].Name)
+	assert.Equal(t, "github", detail.AffectedPackages[0].Packages[0].Provider)
+	assert.Equal(t, &OperatingSystem{Name: "debian", Version: "12"}, detail.AffectedPackages[1].OS)
+	assert.Empty(t, detail.AffectedPackages[1].Ecosystem)
+	assert.Equal(t, fixed("< 2.15.0-1", "2.15.0-1"), detail.AffectedPackages[1].Packages[0].Ranges)
+
+	require.Len(t, detail.AffectedCPEs, 1)
+	assert.Equal(t, "cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*", detail.AffectedCPEs[0].Name)
+
+	require.Len(t, detail.KnownExploited, 1)
+	require.Len(t, detail.EPSS, 1)
+	assert.Equal(t, []CWE{{Cve: "CVE-2021-44228", CWE: "CWE-502", Source: "nvd@nist.gov", Type: "Primary"}}, detail.CWEs)
+
+	assert.Equal(t, []v6.Reference{
+		{URL: "https://logging.apache.org/log4j/2.x/security.html"},
+		{URL: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+	}, detail.References)
+}
+
+func TestShowVulnerability_NotFound(t *testing.T) {
+	mockReader := new(mockVulnReader)
+	mockReader.On("GetVulnerabilities", mock.Anything, mock.Anything).Return([]v6.VulnerabilityHandle{}, nil)
+
+	_, err := ShowVulnerability(mockReader, "CVE-0000-0000")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrVulnerabilityNotFound))
+}
@@ -0,0 +1,77 @@
+package dbsearch
+
+import (
+	"errors"
+	"fmt"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/log"
+)
+
+// KnownExploitedOptions are the filters for the `db search kev` command
+type KnownExploitedOptions struct {
+	KnownExploited []v6.KnownExploitedSpecifier
+	RecordLimit    int
+}
+
+// EPSSOptions are the filters for the `db search epss` command
+type EPSSOptions struct {
+	EPSS        []v6.EpssSpecifier
+	RecordLimit int
+}
+
+// FindKnownExploited searches the KEV table directly. If the record limit is reached then the results found so far
+// are returned along with v6.ErrLimitReached.
+func FindKnownExploited(reader v6.VulnerabilityDecoratorStoreReader, config KnownExploitedOptions) ([]KnownExploited, error) {
+	log.WithFields("specs", len(config.KnownExploited)).Debug("searching known exploited vulnerabilities")
+
+	var out []KnownExploited
+	for i := range config.KnownExploited {
+		if config.RecordLimit > 0 && len(out) >= config.RecordLimit {
+			return out, v6.ErrLimitReached
+		}
+		kevs, err := reader.FindKnownExploitedVulnerabilities(&config.KnownExploited[i], remainingLimit(config.RecordLimit, len(out)))
+		for _, kev := range kevs {
+			out = append(out, newKnownExploited(kev))
+		}
+		if err != nil {
+			if errors.Is(err, v6.ErrLimitReached) {
+				return out, err
+			}
+			return nil, fmt.Errorf("unable to get known exploited vulnerabilities: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// FindEPSS searches the EPSS table directly (ordered by descending score for each specifier). If the record limit
+// is reached then the results found so far are returned along with v6.ErrLimitReached.
+func FindEPSS(reader v6.VulnerabilityDecoratorStoreReader, config EPSSOptions) ([]EPSS, error) {
+	log.WithFields("specs", len(config.EPSS)).Debug("searching EPSS scores")
+
+	var out []EPSS
+	for i := range config.EPSS {
+		if config.RecordLimit > 0 && len(out) >= config.RecordLimit {
+			return out, v6.ErrLimitReached
+		}
+		entries, err := reader.FindEpss(&config.EPSS[i], remainingLimit(config.RecordLimit, len(out)))
+		for _, entry := range entries {
+			out = append(out, newEPSS(entry))
+		}
+		if err != nil {
+			if errors.Is(err, v6.ErrLimitReached) {
+				return out, err
+			}
+			return nil, fmt.Errorf("unable to get EPSS scores: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// remainingLimit is the limit to use for the next query given the number of records already found (0 means no limit).
+func remainingLimit(limit, found int) int {
+	if limit <= 0 {
+		return 0
+	}
+	return limit - found
+}
@@ -0,0 +1,67 @@
+package dbsearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestFindKnownExploited(t *testing.T) {
+	added := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	specs := []v6.KnownExploitedSpecifier{{CVE: "CVE-2023-0001"}, {CVE: "CVE-2023-0002"}}
+
+	mockReader := new(mockVulnReader)
+	mockReader.On("FindKnownExploitedVulnerabilities", &specs[0], 2).Return([]v6.KnownExploitedVulnerabilityHandle{
+		{
+			Cve: "CVE-2023-0001",
+			BlobValue: &v6.KnownExploitedVulnerabilityBlob{
+				Cve:                        "CVE-2023-0001",
+				VendorProject:              "vendor",
+				DateAdded:                  &added,
+				KnownRansomwareCampaignUse: "Known",
+			},
+		},
+	}, nil)
+	mockReader.On("FindKnownExploitedVulnerabilities", &specs[1], 1).Return([]v6.KnownExploitedVulnerabilityHandle{
+		{
+			// missing dates should not cause a failure
+			Cve:       "CVE-2023-0002",
+			BlobValue: &v6.KnownExploitedVulnerabilityBlob{Cve: "CVE-2023-0002"},
+		},
+	}, v6.ErrLimitReached)
+
+	results, err := FindKnownExploited(mockReader, KnownExploitedOptions{
+		KnownExploited: specs,
+		RecordLimit:    2,
+	})
+	require.ErrorIs(t, err, v6.ErrLimitReached)
+	assert.Equal(t, []KnownExploited{
+		{CVE: "CVE-2023-0001", VendorProject: "vendor", DateAdded: "2023-05-01", KnownRansomwareCampaignUse: "Known"},
+		{CVE: "CVE-2023-0002"},
+	}, results)
+	mockReader.AssertExpectations(t)
+}
+
+func TestFindEPSS(t *testing.T) {
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	minScore := 0.5
+	specs := []v6.EpssSpecifier{{MinScore: &minScore}}
+
+	mockReader := new(mockVulnReader)
+	mockReader.On("FindEpss", &specs[0], 0).Return([]v6.EpssHandle{
+		{Cve: "CVE-2023-0002", Epss: 0.9, Percentile: 0.99, Date: date},
+		{Cve: "CVE-2023-0001", Epss: 0.5, Percentile: 0.8, Date: date},
+	}, nil)
+
+	results, err := FindEPSS(mockReader, EPSSOptions{EPSS: specs})
+	require.NoError(t, err)
+	assert.Equal(t, []EPSS{
+		{CVE: "CVE-2023-0002", EPSS: 0.9, Percentile: 0.99, Date: "2025-03-01"},
+		{CVE: "CVE-2023-0001", EPSS: 0.5, Percentile: 0.8, Date: "2025-03-01"},
+	}, results)
+	mockReader.AssertExpectations(t)
+}
@@ -135,6 +135,11 @@ func FindMatches(reader interface {
 		allAffectedCPEs = filterByFixedStateForCPEs(allAffectedCPEs, criteria.FixedStates)
 	}
 
+	if len(criteria.Severities) > 0 {
+		allAffectedPkgs = filterBySeverityForPackages(allAffectedPkgs, criteria.Severities)
+		allAffectedCPEs = filterBySeverityForCPEs(allAffectedCPEs, criteria.Severities)
+	}
+
 	rows, presErr := newMatchesRows(allAffectedPkgs, allAffectedCPEs)
 	if presErr != nil {
 		return nil, presErr
@@ -0,0 +1,249 @@
+package dbsearch
+
+import (
+	"fmt"
+	"sort"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/cpe"
+)
+
+const (
+	SummarizeByProvider  = "provider"
+	SummarizeBySeverity  = "severity"
+	SummarizeByEcosystem = "ecosystem"
+)
+
+// AllSummarizeBy are the attributes search results can be summarized by.
+var AllSummarizeBy = []string{SummarizeByProvider, SummarizeBySeverity, SummarizeByEcosystem}
+
+// Summary is the number of search results, optionally broken down by an attribute of the results.
+type Summary struct {
+	// By is the attribute the results are summarized by (empty when only counting the results).
+	By string `json:"by,omitempty"`
+
+	// Total is the number of search results.
+	Total int `json:"total"`
+
+	// Groups are the number of search results per value of the summarized attribute.
+	Groups []SummaryGroup `json:"groups,omitempty"`
+}
+
+// SummaryGroup is the number of search results sharing the same value of the summarized attribute.
+type SummaryGroup struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SummarizeAffectedPackages counts the affected packages and CPEs matching the given criteria, executed as SQL
+// aggregates when possible (the record limit does not apply).
+func SummarizeAffectedPackages(reader interface {
+	v6.AffectedPackageStoreReader
+	v6.AffectedCPEStoreReader
+}, criteria AffectedPackagesOptions, by string,
+) (*Summary, error) {
+	groupBy, err := countGroupBy(by)
+	if err != nil {
+		return nil, err
+	}
+
+	// fix states and normalized severities are only found within the blobs, requiring to count the full records
+	if len(criteria.FixedStates) > 0 || len(criteria.Severities) > 0 {
+		return summarizeAffectedPackageRecords(reader, criteria, by)
+	}
+
+	pkgSpecs, cpeSpecs, err := resolveAffectedPackageSpecs(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := newSummaryCounter(by)
+	for _, pkgSpec := range pkgSpecs {
+		log.WithFields("vuln", criteria.Vulnerability, "pkg", pkgSpec, "os", criteria.OS, "by", by).Debug("counting affected packages")
+
+		groups, err := reader.CountAffectedPackages(pkgSpec, &v6.GetPackageOptions{
+			OSs:                   criteria.OS,
+			Vulnerabilities:       criteria.Vulnerability,
+			AllowBroadCPEMatching: criteria.AllowBroadCPEMatching,
+		}, groupBy)
+		if err != nil {
+			return nil, fmt.Errorf("unable to count affected packages for %s: %w", criteria.Vulnerability, err)
+		}
+		counts.addGroups(groups)
+	}
+
+	if criteria.OS.IsAny() {
+		for _, cpeSpec := range cpeSpecs {
+			var searchCPE *cpe.Attributes
+			if cpeSpec != nil {
+				searchCPE = cpeSpec.CPE
+			}
+
+			log.WithFields("vuln", criteria.Vulnerability, "cpe", cpeSpec, "by", by).Debug("counting affected packages")
+
+			groups, err := reader.CountAffectedCPEs(searchCPE, &v6.GetCPEOptions{
+				Vulnerabilities:       criteria.Vulnerability,
+				AllowBroadCPEMatching: criteria.AllowBroadCPEMatching,
+			}, groupBy)
+			if err != nil {
+				return nil, fmt.Errorf("unable to count affected cpes for %s: %w", criteria.Vulnerability, err)
+			}
+			counts.addGroups(groups)
+		}
+	}
+
+	return counts.summary(), nil
+}
+
+// summarizeAffectedPackageRecords counts the full affected package and CPE records matching the given criteria, for
+// criteria that cannot be evaluated within the query.
+func summarizeAffectedPackageRecords(reader interface {
+	v6.AffectedPackageStoreReader
+	v6.AffectedCPEStoreReader
+}, criteria AffectedPackagesOptions, by string,
+) (*Summary, error) {
+	criteria.RecordLimit = 0
+
+	allAffectedPkgs, allAffectedCPEs, err := fetchAffectedPackages(reader, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	allAffectedPkgs = filterByFixedStateForPackages(allAffectedPkgs, criteria.FixedStates)
+	allAffectedCPEs = filterByFixedStateForCPEs(allAffectedCPEs, criteria.FixedStates)
+	allAffectedPkgs = filterBySeverityForPackages(allAffectedPkgs, criteria.Severities)
+	allAffectedCPEs = filterBySeverityForCPEs(allAffectedCPEs, criteria.Severities)
+
+	counts := newSummaryCounter(by)
+	for _, a := range allAffectedPkgs {
+		var ecosystem string
+		if a.Package != nil {
+			ecosystem = a.Package.Ecosystem
+		}
+		counts.add(summaryValue(by, a.Vulnerability, ecosystem), 1)
+	}
+	for _, a := range allAffectedCPEs {
+		var ecosystem string
+		if a.CPE != nil {
+			ecosystem = a.CPE.TargetSoftware
+		}
+		counts.add(summaryValue(by, a.Vulnerability, ecosystem), 1)
+	}
+
+	return counts.summary(), nil
+}
+
+// SummarizeVulnerabilities counts the vulnerabilities matching the given criteria as SQL aggregates (the record limit
+// does not apply). When summarizing by ecosystem, a vulnerability is counted for each ecosystem of the packages it
+// affects.
+func SummarizeVulnerabilities(reader v6.VulnerabilityStoreReader, config VulnerabilitiesOptions, by string) (*Summary, error) {
+	groupBy, err := countGroupBy(by)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := newSummaryCounter(by)
+	total := 0
+	for _, vulnSpec := range config.Vulnerability {
+		groups, err := reader.CountVulnerabilities(&vulnSpec, groupBy)
+		if err != nil {
+			return nil, fmt.Errorf("unable to count vulnerabilities: %w", err)
+		}
+		counts.addGroups(groups)
+
+		if groupBy == v6.CountByEcosystem {
+			// the ecosystem groups overlap, so the total needs to be counted separately
+			totals, err := reader.CountVulnerabilities(&vulnSpec, v6.CountTotal)
+			if err != nil {
+				return nil, fmt.Errorf("unable to count vulnerabilities: %w", err)
+			}
+			for _, t := range totals {
+				total += t.Count
+			}
+		}
+	}
+
+	summary := counts.summary()
+	if groupBy == v6.CountByEcosystem {
+		summary.Total = total
+	}
+	return summary, nil
+}
+
+// countGroupBy returns how records are grouped by the store to summarize by the given attribute (where the severity
+// is derived from the vulnerability records).
+func countGroupBy(by string) (v6.CountGroupBy, error) {
+	switch by {
+	case "":
+		return v6.CountTotal, nil
+	case SummarizeByProvider:
+		return v6.CountByProvider, nil
+	case SummarizeByEcosystem:
+		return v6.CountByEcosystem, nil
+	case SummarizeBySeverity:
+		return v6.CountByVulnerability, nil
+	}
+	return "", fmt.Errorf("unsupported summary: %q", by)
+}
+
+func summaryValue(by string, vuln *v6.VulnerabilityHandle, ecosystem string) string {
+	switch by {
+	case SummarizeByProvider:
+		if vuln == nil {
+			return ""
+		}
+		return vuln.ProviderID
+	case SummarizeBySeverity:
+		return getNormalizedSeverity(vuln)
+	case SummarizeByEcosystem:
+		return ecosystem
+	}
+	return ""
+}
+
+type summaryCounter struct {
+	by     string
+	counts map[string]int
+}
+
+func newSummaryCounter(by string) *summaryCounter {
+	return &summaryCounter{
+		by:     by,
+		counts: make(map[string]int),
+	}
+}
+
+func (c *summaryCounter) add(value string, count int) {
+	c.counts[value] += count
+}
+
+func (c *summaryCounter) addGroups(groups []v6.CountGroup) {
+	for _, g := range groups {
+		value := g.Value
+		if c.by == SummarizeBySeverity {
+			value = getNormalizedSeverity(g.Vulnerability)
+		}
+		c.add(value, g.Count)
+	}
+}
+
+func (c *summaryCounter) summary() *Summary {
+	s := &Summary{By: c.by}
+	for value, count := range c.counts {
+		s.Total += count
+		if c.by != "" && count > 0 {
+			s.Groups = append(s.Groups, SummaryGroup{Value: value, Count: count})
+		}
+	}
+
+	// largest groups first
+	sort.Slice(s.Groups, func(i, j int) bool {
+		if s.Groups[i].Count != s.Groups[j].Count {
+			return s.Groups[i].Count > s.Groups[j].Count
+		}
+		return s.Groups[i].Value < s.Groups[j].Value
+	})
+
+	return s
+}
@@ -0,0 +1,140 @@
+package dbsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/syft/syft/cpe"
+)
+
+func TestSummarizeAffectedPackages(t *testing.T) {
+	vulnWithSeverity := func(severity string) *v6.VulnerabilityHandle {
+		return &v6.VulnerabilityHandle{
+			BlobValue: &v6.VulnerabilityBlob{
+				Severities: []v6.Severity{{Scheme: v6.SeveritySchemeCHMLN, Value: severity}},
+			},
+		}
+	}
+
+	t.Run("counts are aggregated by the store", func(t *testing.T) {
+		m := new(affectedMockReader)
+		m.On("CountAffectedPackages", v6.AnyPackageSpecified, mock.Anything, v6.CountByProvider).Return([]v6.CountGroup{
+			{Value: "github", Count: 2},
+			{Value: "nvd", Count: 1},
+		}, nil)
+		m.On("CountAffectedCPEs", (*cpe.Attributes)(nil), mock.Anything, v6.CountByProvider).Return([]v6.CountGroup{
+			{Value: "nvd", Count: 4},
+		}, nil)
+
+		got, err := SummarizeAffectedPackages(m, AffectedPackagesOptions{
+			Vulnerability: v6.VulnerabilitySpecifiers{{Name: "CVE-2021-44228"}},
+			RecordLimit:   1,
+		}, SummarizeByProvider)
+		require.NoError(t, err)
+		assert.Equal(t, &Summary{
+			By:    SummarizeByProvider,
+			Total: 7,
+			Groups: []SummaryGroup{
+				{Value: "nvd", Count: 5},
+				{Value: "github", Count: 2},
+			},
+		}, got)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("severities are normalized from the counted vulnerabilities", func(t *testing.T) {
+		m := new(affectedMockReader)
+		m.On("CountAffectedPackages", mock.Anything, mock.Anything, v6.CountByVulnerability).Return([]v6.CountGroup{
+			{Value: "1", Vulnerability: vulnWithSeverity("High"), Count: 2},
+			{Value: "2", Vulnerability: vulnWithSeverity("high"), Count: 1},
+			{Value: "3", Vulnerability: &v6.VulnerabilityHandle{}, Count: 1},
+		}, nil)
+
+		got, err := SummarizeAffectedPackages(m, AffectedPackagesOptions{
+			Package: v6.PackageSpecifiers{{Ecosystem: "npm"}},
+		}, SummarizeBySeverity)
+		require.NoError(t, err)
+		assert.Equal(t, &Summary{
+			By:    SummarizeBySeverity,
+			Total: 4,
+			Groups: []SummaryGroup{
+				{Value: "high", Count: 3},
+				{Value: "unknown", Count: 1},
+			},
+		}, got)
+	})
+
+	t.Run("records are counted when filtering within the blobs", func(t *testing.T) {
+		m := new(affectedMockReader)
+		m.On("GetAffectedPackages", mock.Anything, mock.MatchedBy(func(o *v6.GetPackageOptions) bool {
+			return o.Limit == 0
+		})).Return([]v6.AffectedPackageHandle{
+			{Package: &v6.Package{Ecosystem: "npm"}, Vulnerability: vulnWithSeverity("critical"), BlobValue: &v6.PackageBlob{}},
+			{Package: &v6.Package{Ecosystem: "npm"}, Vulnerability: vulnWithSeverity("low"), BlobValue: &v6.PackageBlob{}},
+			{Package: &v6.Package{Ecosystem: "python"}, Vulnerability: vulnWithSeverity("critical"), BlobValue: &v6.PackageBlob{}},
+		}, nil)
+
+		got, err := SummarizeAffectedPackages(m, AffectedPackagesOptions{
+			Package:     v6.PackageSpecifiers{{Name: "pkg"}},
+			Severities:  []string{"critical"},
+			RecordLimit: 1,
+		}, SummarizeByEcosystem)
+		require.NoError(t, err)
+		assert.Equal(t, &Summary{
+			By:    SummarizeByEcosystem,
+			Total: 2,
+			Groups: []SummaryGroup{
+				{Value: "npm", Count: 1},
+				{Value: "python", Count: 1},
+			},
+		}, got)
+		m.AssertNotCalled(t, "CountAffectedPackages", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("search criteria are required", func(t *testing.T) {
+		_, err := SummarizeAffectedPackages(new(affectedMockReader), AffectedPackagesOptions{}, "")
+		require.ErrorIs(t, err, ErrNoSearchCriteria)
+	})
+
+	t.Run("unsupported summary", func(t *testing.T) {
+		_, err := SummarizeAffectedPackages(new(affectedMockReader), AffectedPackagesOptions{}, "cwe")
+		require.ErrorContains(t, err, "unsupported summary")
+	})
+}
+
+func TestSummarizeVulnerabilities(t *testing.T) {
+	spec := v6.VulnerabilitySpecifier{Name: "CVE-2021-44228"}
+
+	t.Run("count", func(t *testing.T) {
+		m := new(mockVulnReader)
+		m.On("CountVulnerabilities", &spec, v6.CountTotal).Return([]v6.CountGroup{{Count: 2}}, nil)
+
+		got, err := SummarizeVulnerabilities(m, VulnerabilitiesOptions{Vulnerability: v6.VulnerabilitySpecifiers{spec}}, "")
+		require.NoError(t, err)
+		assert.Equal(t, &Summary{Total: 2}, got)
+	})
+
+	t.Run("ecosystems overlap", func(t *testing.T) {
+		m := new(mockVulnReader)
+		m.On("CountVulnerabilities", &spec, v6.CountByEcosystem).Return([]v6.CountGroup{
+			{Value: "maven", Count: 2},
+			{Value: "rpm", Count: 1},
+		}, nil)
+		m.On("CountVulnerabilities", &spec, v6.CountTotal).Return([]v6.CountGroup{{Count: 2}}, nil)
+
+		got, err := SummarizeVulnerabilities(m, VulnerabilitiesOptions{Vulnerability: v6.VulnerabilitySpecifiers{spec}}, SummarizeByEcosystem)
+		require.NoError(t, err)
+		assert.Equal(t, &Summary{
+			By:    SummarizeByEcosystem,
+			Total: 2,
+			Groups: []SummaryGroup{
+				{Value: "maven", Count: 2},
+				{Value: "rpm", Count: 1},
+			},
+		}, got)
+	})
+}
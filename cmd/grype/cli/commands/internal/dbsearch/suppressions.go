@@ -0,0 +1,191 @@
+package dbsearch
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/packageurl-go"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// SuppressionOptions describe the starter suppression artifacts (ignore rules and VEX statements) generated for the
+// affected packages of a search.
+type SuppressionOptions struct {
+	// Versions are the versions of the searched packages by package name, which the suppressions are scoped to (all
+	// versions of the package otherwise).
+	Versions map[string]string
+
+	// Reason documents why the vulnerabilities do not apply. Without a reason the emitted ignore rules are left for the
+	// reason to be filled in (which annotated ignore files require).
+	Reason string
+
+	// Justification is the OpenVEX justification of why the packages are not affected. Without a justification or
+	// reason the emitted VEX statements are "under_investigation", which do not suppress any findings.
+	Justification string
+
+	Author    string
+	Timestamp time.Time
+}
+
+// suppressedPackage is the package (or CPE) of an affected package record to suppress a vulnerability for.
+type suppressedPackage struct {
+	name     string
+	version  string
+	pkgType  string
+	language string
+	purl     string
+	cpe      string
+}
+
+type ignoreFileRulePackage struct {
+	Name     string `yaml:"name,omitempty"`
+	Version  string `yaml:"version,omitempty"`
+	Language string `yaml:"language,omitempty"`
+	Type     string `yaml:"type,omitempty"`
+}
+
+type ignoreFileRule struct {
+	Vulnerability  string                `yaml:"vulnerability"`
+	IncludeAliases bool                  `yaml:"include-aliases,omitempty"`
+	Package        ignoreFileRulePackage `yaml:"package"`
+	Reason         string                `yaml:"reason"`
+}
+
+// IgnoreRules renders an annotated ignore file (see match.IgnoreFile) with a rule for each vulnerability of the given
+// affected packages, which also apply to the aliases of the vulnerability.
+func IgnoreRules(rows []AffectedPackage, opts SuppressionOptions) ([]byte, error) {
+	rules := []ignoreFileRule{}
+	for _, row := range rows {
+		p := newSuppressedPackage(row, opts.Versions)
+		rule := ignoreFileRule{
+			Vulnerability:  row.Vulnerability.ID,
+			IncludeAliases: true,
+			Package: ignoreFileRulePackage{
+				Name:     p.name,
+				Version:  p.version,
+				Language: p.language,
+				Type:     p.pkgType,
+			},
+			Reason: opts.Reason,
+		}
+		if !slices.Contains(rules, rule) {
+			rules = append(rules, rule)
+		}
+	}
+	return yaml.Marshal(map[string][]ignoreFileRule{"ignore": rules})
+}
+
+// VEX renders an OpenVEX document with a statement for each vulnerability of the given affected packages, with the
+// package URLs (or CPEs) of the packages as the products.
+func VEX(rows []AffectedPackage, opts SuppressionOptions) openvex.VEX {
+	doc := openvex.New()
+	doc.Author = opts.Author
+	doc.Timestamp = &opts.Timestamp
+
+	status := openvex.StatusUnderInvestigation
+	if opts.Reason != "" || opts.Justification != "" {
+		status = openvex.StatusNotAffected
+	}
+
+	byVuln := make(map[string]*openvex.Statement)
+	var ids []string
+	for _, row := range rows {
+		p := newSuppressedPackage(row, opts.Versions)
+		product := openvex.Product{Component: openvex.Component{ID: p.purl}}
+		if p.purl == "" {
+			product.ID = p.cpe
+			product.Identifiers = map[openvex.IdentifierType]string{openvex.CPE23: p.cpe}
+		}
+		if product.ID == "" {
+			continue
+		}
+
+		stmt, ok := byVuln[row.Vulnerability.ID]
+		if !ok {
+			stmt = &openvex.Statement{
+				Vulnerability: openvex.Vulnerability{
+					Name:    openvex.VulnerabilityID(row.Vulnerability.ID),
+					Aliases: vexAliases(row.Vulnerability.Aliases),
+				},
+				Status:    status,
+				Timestamp: &opts.Timestamp,
+			}
+			if status == openvex.StatusNotAffected {
+				stmt.Justification = openvex.Justification(opts.Justification)
+				stmt.ImpactStatement = opts.Reason
+			}
+			byVuln[row.Vulnerability.ID] = stmt
+			ids = append(ids, row.Vulnerability.ID)
+		}
+		if !slices.ContainsFunc(stmt.Products, func(existing openvex.Product) bool { return existing.ID == product.ID }) {
+			stmt.Products = append(stmt.Products, product)
+		}
+	}
+
+	sort.Strings(ids)
+	for _, id := range ids {
+		doc.Statements = append(doc.Statements, *byVuln[id])
+	}
+	return doc
+}
+
+func vexAliases(aliases []string) []openvex.VulnerabilityID {
+	var out []openvex.VulnerabilityID
+	for _, a := range aliases {
+		out = append(out, openvex.VulnerabilityID(a))
+	}
+	return out
+}
+
+func newSuppressedPackage(row AffectedPackage, versions map[string]string) suppressedPackage {
+	switch {
+	case row.Package != nil:
+		p := suppressedPackage{
+			name:    row.Package.Name,
+			version: versions[row.Package.Name],
+		}
+		// the v6 store normalizes ecosystems around the syft package type, falling back to the language
+		ty := syftPkg.Type(row.Package.Ecosystem)
+		if slices.Contains(syftPkg.AllPkgs, ty) {
+			p.pkgType = string(ty)
+		} else if lang := syftPkg.LanguageByName(row.Package.Ecosystem); lang != syftPkg.UnknownLanguage {
+			p.language = string(lang)
+		}
+		p.purl = suppressedPackageURL(row, ty, p.version)
+		return p
+	case row.CPE != nil:
+		return suppressedPackage{
+			name:    row.CPE.Product,
+			version: versions[row.CPE.Product],
+			cpe:     row.CPE.String(),
+		}
+	}
+	return suppressedPackage{}
+}
+
+// suppressedPackageURL returns the package URL of the affected package, using the distro as the namespace of OS
+// packages (e.g. pkg:rpm/rhel/openssl@1.0.2).
+func suppressedPackageURL(row AffectedPackage, ty syftPkg.Type, version string) string {
+	purlType := ty.PackageURLType()
+	if purlType == "" || purlType == packageurl.TypeGeneric {
+		return ""
+	}
+
+	var namespace string
+	name := row.Package.Name
+	switch {
+	case row.OS != nil:
+		namespace = strings.ToLower(row.OS.Name)
+	case purlType == packageurl.TypeMaven:
+		if group, artifact, ok := strings.Cut(name, ":"); ok {
+			namespace, name = group, artifact
+		}
+	}
+
+	return packageurl.NewPackageURL(purlType, namespace, name, version, nil, "").ToString()
+}
@@ -0,0 +1,148 @@
+package dbsearch
+
+import (
+	"testing"
+	"time"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func suppressionTestRows() []AffectedPackage {
+	vuln := func(id string, aliases ...string) VulnerabilityInfo {
+		return VulnerabilityInfo{VulnerabilityBlob: v6.VulnerabilityBlob{ID: id, Aliases: aliases}}
+	}
+	return []AffectedPackage{
+		{
+			Vulnerability:       vuln("GHSA-jf85-cpcp-j695", "CVE-2019-10744"),
+			AffectedPackageInfo: AffectedPackageInfo{Package: &Package{Name: "lodash", Ecosystem: "npm"}},
+		},
+		{
+			// the same vulnerability from another record is suppressed once
+			Vulnerability:       vuln("GHSA-jf85-cpcp-j695", "CVE-2019-10744"),
+			AffectedPackageInfo: AffectedPackageInfo{Package: &Package{Name: "lodash", Ecosystem: "npm"}},
+		},
+		{
+			Vulnerability: vuln("CVE-2021-23337"),
+			AffectedPackageInfo: AffectedPackageInfo{
+				OS:      &OperatingSystem{Name: "rhel", Version: "9"},
+				Package: &Package{Name: "lodash", Ecosystem: "rpm"},
+			},
+		},
+		{
+			Vulnerability:       vuln("CVE-2021-23337"),
+			AffectedPackageInfo: AffectedPackageInfo{CPE: &CPE{Part: "a", Vendor: "lodash", Product: "lodash"}},
+		},
+	}
+}
+
+func TestIgnoreRules(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SuppressionOptions
+		want string
+	}{
+		{
+			name: "reason left to be filled in",
+			want: `ignore:
+    - vulnerability: GHSA-jf85-cpcp-j695
+      include-aliases: true
+      package:
+        name: lodash
+        type: npm
+      reason: ""
+    - vulnerability: CVE-2021-23337
+      include-aliases: true
+      package:
+        name: lodash
+        type: rpm
+      reason: ""
+    - vulnerability: CVE-2021-23337
+      include-aliases: true
+      package:
+        name: lodash
+      reason: ""
+`,
+		},
+		{
+			name: "scoped to the searched version",
+			opts: SuppressionOptions{
+				Versions: map[string]string{"lodash": "4.17.20"},
+				Reason:   "templates are never compiled from user input",
+			},
+			want: `ignore:
+    - vulnerability: GHSA-jf85-cpcp-j695
+      include-aliases: true
+      package:
+        name: lodash
+        version: 4.17.20
+        type: npm
+      reason: templates are never compiled from user input
+    - vulnerability: CVE-2021-23337
+      include-aliases: true
+      package:
+        name: lodash
+        version: 4.17.20
+        type: rpm
+      reason: templates are never compiled from user input
+    - vulnerability: CVE-2021-23337
+      include-aliases: true
+      package:
+        name: lodash
+        version: 4.17.20
+      reason: templates are never compiled from user input
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IgnoreRules(suppressionTestRows(), tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestVEX(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("under investigation without a justification", func(t *testing.T) {
+		doc := VEX(suppressionTestRows(), SuppressionOptions{Author: "grype", Timestamp: now})
+
+		assert.Equal(t, "grype", doc.Author)
+		require.Len(t, doc.Statements, 2)
+
+		cve := doc.Statements[0]
+		assert.Equal(t, openvex.VulnerabilityID("CVE-2021-23337"), cve.Vulnerability.Name)
+		assert.Equal(t, openvex.StatusUnderInvestigation, cve.Status)
+		require.Len(t, cve.Products, 2)
+		assert.Equal(t, "pkg:rpm/rhel/lodash", cve.Products[0].ID)
+		assert.Equal(t, "cpe:2.3:a:lodash:lodash:*:*:*:*:*:*:*:*", cve.Products[1].ID)
+		assert.NoError(t, cve.Validate())
+
+		ghsa := doc.Statements[1]
+		assert.Equal(t, openvex.VulnerabilityID("GHSA-jf85-cpcp-j695"), ghsa.Vulnerability.Name)
+		assert.Equal(t, []openvex.VulnerabilityID{"CVE-2019-10744"}, ghsa.Vulnerability.Aliases)
+		require.Len(t, ghsa.Products, 1)
+		assert.Equal(t, "pkg:npm/lodash", ghsa.Products[0].ID)
+	})
+
+	t.Run("not affected with a justification", func(t *testing.T) {
+		doc := VEX(suppressionTestRows(), SuppressionOptions{
+			Versions:      map[string]string{"lodash": "4.17.20"},
+			Justification: string(openvex.VulnerableCodeNotInExecutePath),
+			Timestamp:     now,
+		})
+
+		require.Len(t, doc.Statements, 2)
+		for _, s := range doc.Statements {
+			assert.Equal(t, openvex.StatusNotAffected, s.Status)
+			assert.Equal(t, openvex.VulnerableCodeNotInExecutePath, s.Justification)
+			assert.NoError(t, s.Validate())
+		}
+		assert.Equal(t, "pkg:npm/lodash@4.17.20", doc.Statements[1].Products[0].ID)
+	})
+}
@@ -28,4 +28,10 @@ const (
 	// 1.0.4 - Add CWE IDs to vulnerability output
 	// 1.0.5 - Add ID field to Reference (for advisory IDs like RHSA-2023:5455)
 	// 1.0.6 - Add modifications field to the vulnerability object
+
+	// VulnerabilityDetailSchemaVersion is the schema version for the `db show` command
+	VulnerabilityDetailSchemaVersion = "1.0.0"
+
+	// VulnerabilityDetailSchemaVersion
+	// 1.0.0 - Initial schema 🎉
 )
@@ -12,6 +12,7 @@ import (
 
 	v6 "github.com/anchore/grype/grype/db/v6"
 	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/cpe"
 )
 
 func TestGetSeverity(t *testing.T) {
@@ -425,6 +426,26 @@ func (m *mockVulnReader) GetAffectedPackages(pkg *v6.PackageSpecifier, config *v
 	return args.Get(0).([]v6.AffectedPackageHandle), args.Error(1)
 }
 
+func (m *mockVulnReader) GetAffectedCPEs(cpeSpec *cpe.Attributes, config *v6.GetCPEOptions) ([]v6.AffectedCPEHandle, error) {
+	args := m.Called(cpeSpec, config)
+	return args.Get(0).([]v6.AffectedCPEHandle), args.Error(1)
+}
+
+func (m *mockVulnReader) CountVulnerabilities(vuln *v6.VulnerabilitySpecifier, groupBy v6.CountGroupBy) ([]v6.CountGroup, error) {
+	args := m.Called(vuln, groupBy)
+	return args.Get(0).([]v6.CountGroup), args.Error(1)
+}
+
+func (m *mockVulnReader) CountAffectedPackages(pkg *v6.PackageSpecifier, config *v6.GetPackageOptions, groupBy v6.CountGroupBy) ([]v6.CountGroup, error) {
+	args := m.Called(pkg, config, groupBy)
+	return args.Get(0).([]v6.CountGroup), args.Error(1)
+}
+
+func (m *mockVulnReader) CountAffectedCPEs(cpeSpec *cpe.Attributes, config *v6.GetCPEOptions, groupBy v6.CountGroupBy) ([]v6.CountGroup, error) {
+	args := m.Called(cpeSpec, config, groupBy)
+	return args.Get(0).([]v6.CountGroup), args.Error(1)
+}
+
 func (m *mockVulnReader) GetKnownExploitedVulnerabilities(cve string) ([]v6.KnownExploitedVulnerabilityHandle, error) {
 	args := m.Called(cve)
 	return args.Get(0).([]v6.KnownExploitedVulnerabilityHandle), args.Error(1)
@@ -435,6 +456,16 @@ func (m *mockVulnReader) GetEpss(cve string) ([]v6.EpssHandle, error) {
 	return args.Get(0).([]v6.EpssHandle), args.Error(1)
 }
 
+func (m *mockVulnReader) FindKnownExploitedVulnerabilities(spec *v6.KnownExploitedSpecifier, limit int) ([]v6.KnownExploitedVulnerabilityHandle, error) {
+	args := m.Called(spec, limit)
+	return args.Get(0).([]v6.KnownExploitedVulnerabilityHandle), args.Error(1)
+}
+
+func (m *mockVulnReader) FindEpss(spec *v6.EpssSpecifier, limit int) ([]v6.EpssHandle, error) {
+	args := m.Called(spec, limit)
+	return args.Get(0).([]v6.EpssHandle), args.Error(1)
+}
+
 func (m *mockVulnReader) GetCWEs(cve string) ([]v6.CWEHandle, error) {
 	args := m.Called(cve)
 	return args.Get(0).([]v6.CWEHandle), args.Error(1)
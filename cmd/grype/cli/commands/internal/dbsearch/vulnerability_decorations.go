@@ -105,23 +105,47 @@ func fetchKnownExploited(reader v6.VulnerabilityDecoratorStoreReader, cves []str
 			continue
 		}
 		for _, kev := range kevs {
-			out = append(out, KnownExploited{
-				CVE:                        kev.Cve,
-				VendorProject:              kev.BlobValue.VendorProject,
-				Product:                    kev.BlobValue.Product,
-				DateAdded:                  kev.BlobValue.DateAdded.Format(time.DateOnly),
-				RequiredAction:             kev.BlobValue.RequiredAction,
-				DueDate:                    kev.BlobValue.DueDate.Format(time.DateOnly),
-				KnownRansomwareCampaignUse: kev.BlobValue.KnownRansomwareCampaignUse,
-				Notes:                      kev.BlobValue.Notes,
-				URLs:                       kev.BlobValue.URLs,
-				CWEs:                       kev.BlobValue.CWEs,
-			})
+			out = append(out, newKnownExploited(kev))
 		}
 	}
 	return out, errs
 }
 
+func newKnownExploited(kev v6.KnownExploitedVulnerabilityHandle) KnownExploited {
+	out := KnownExploited{
+		CVE: kev.Cve,
+	}
+	if kev.BlobValue == nil {
+		return out
+	}
+	out.VendorProject = kev.BlobValue.VendorProject
+	out.Product = kev.BlobValue.Product
+	out.DateAdded = formatDate(kev.BlobValue.DateAdded)
+	out.RequiredAction = kev.BlobValue.RequiredAction
+	out.DueDate = formatDate(kev.BlobValue.DueDate)
+	out.KnownRansomwareCampaignUse = kev.BlobValue.KnownRansomwareCampaignUse
+	out.Notes = kev.BlobValue.Notes
+	out.URLs = kev.BlobValue.URLs
+	out.CWEs = kev.BlobValue.CWEs
+	return out
+}
+
+func newEPSS(entry v6.EpssHandle) EPSS {
+	return EPSS{
+		CVE:        entry.Cve,
+		EPSS:       entry.Epss,
+		Percentile: entry.Percentile,
+		Date:       entry.Date.Format(time.DateOnly),
+	}
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
 func fetchEpss(reader v6.VulnerabilityDecoratorStoreReader, cves []string) ([]EPSS, error) {
 	var out []EPSS
 	var errs error
@@ -132,12 +156,7 @@ func fetchEpss(reader v6.VulnerabilityDecoratorStoreReader, cves []string) ([]EP
 			continue
 		}
 		for _, entry := range entries {
-			out = append(out, EPSS{
-				CVE:        entry.Cve,
-				EPSS:       entry.Epss,
-				Percentile: entry.Percentile,
-				Date:       entry.Date.Format(time.DateOnly),
-			})
+			out = append(out, newEPSS(entry))
 		}
 	}
 	return out, errs
@@ -0,0 +1,274 @@
+// Package dbserve exposes the vulnerability DB search capabilities (as with `grype db search`) over a read-only,
+// token-authenticated JSON API.
+package dbserve
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbsearch"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/log"
+)
+
+const (
+	defaultPerPage = 100
+	maxPerPage     = 1000
+)
+
+// Reader is the subset of the DB the API is served from.
+type Reader interface {
+	v6.DBMetadataStoreReader
+	v6.VulnerabilityStoreReader
+	v6.VulnerabilityDecoratorStoreReader
+	v6.AffectedPackageStoreReader
+	v6.AffectedCPEStoreReader
+}
+
+// Config configures the API.
+type Config struct {
+	// Token is the bearer token clients must present
+	Token string
+	// RecordLimit is the maximum number of records searched per request (0 for no limit)
+	RecordLimit int
+}
+
+// Page is a single page of results. Results are paginated after searching, so pages are consistent across requests
+// as long as the DB is unchanged.
+type Page[T any] struct {
+	Results []T `json:"results"`
+	Page    int `json:"page"`
+	PerPage int `json:"perPage"`
+	Total   int `json:"total"`
+	// Truncated indicates that the record limit was reached, so that the total (and the last pages) are incomplete
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type server struct {
+	reader Reader
+	config Config
+}
+
+// New returns a handler serving the API:
+//
+//	GET /v1/affected-packages  (vuln, pkg, ecosystem, distro, fixed-state, provider, published-after, modified-after, broad-cpe-matching)
+//	GET /v1/vulnerabilities    (id, provider, published-after, modified-after)
+//	GET /v1/kev                (cve, added-after, added-before, ransomware)
+//	GET /v1/epss               (cve, min-score, max-score, min-percentile, max-percentile)
+//	GET /healthz               (unauthenticated, reports the DB build)
+//
+// All search endpoints accept the page (1-based) and per-page query parameters.
+func New(reader Reader, cfg Config) http.Handler {
+	s := &server{reader: reader, config: cfg}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.health)
+	mux.Handle("GET /v1/affected-packages", s.authenticated(s.affectedPackages))
+	mux.Handle("GET /v1/vulnerabilities", s.authenticated(s.vulnerabilities))
+	mux.Handle("GET /v1/kev", s.authenticated(s.knownExploited))
+	mux.Handle("GET /v1/epss", s.authenticated(s.epss))
+	return mux
+}
+
+func (s *server) authenticated(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="grype"`)
+			writeError(w, http.StatusUnauthorized, errors.New("a valid bearer token is required"))
+			return
+		}
+		next(w, r)
+	})
+}
+
+func (s *server) health(w http.ResponseWriter, _ *http.Request) {
+	m, err := s.reader.GetDBMetadata()
+	if err != nil || m == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("unable to read DB metadata: %v", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, v6.DescriptionFromMetadata(m))
+}
+
+func (s *server) affectedPackages(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	vulns := vulnerabilityOptions(q, "vuln")
+	pkgs := options.DBSearchPackages{
+		Packages:              q["pkg"],
+		Ecosystem:             q.Get("ecosystem"),
+		AllowBroadCPEMatching: q.Get("broad-cpe-matching") == "true",
+	}
+	oss := options.DBSearchOSs{OSs: q["distro"]}
+	for _, o := range []interface{ PostLoad() error }{&vulns, &pkgs, &oss} {
+		if err := o.PostLoad(); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	rows, err := dbsearch.FindMatches(s.reader, dbsearch.AffectedPackagesOptions{
+		Vulnerability:         vulns.Specs,
+		Package:               pkgs.PkgSpecs,
+		CPE:                   pkgs.CPESpecs,
+		OS:                    oss.Specs,
+		AllowBroadCPEMatching: pkgs.AllowBroadCPEMatching,
+		RecordLimit:           s.config.RecordLimit,
+		FixedStates:           vulns.FixedState,
+		Severities:            vulns.Severity,
+	})
+	respond(w, r, rows, err)
+}
+
+func (s *server) vulnerabilities(w http.ResponseWriter, r *http.Request) {
+	vulns := vulnerabilityOptions(r.URL.Query(), "id")
+	if err := vulns.PostLoad(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(vulns.Specs) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("must provide at least one of id, provider, published-after or modified-after"))
+		return
+	}
+
+	rows, err := dbsearch.FindVulnerabilities(s.reader, dbsearch.VulnerabilitiesOptions{
+		Vulnerability: vulns.Specs,
+		RecordLimit:   s.config.RecordLimit,
+	})
+	respond(w, r, rows, err)
+}
+
+func (s *server) knownExploited(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	kev := options.DBSearchKnownExploited{
+		CVEs:        q["cve"],
+		AddedAfter:  q.Get("added-after"),
+		AddedBefore: q.Get("added-before"),
+		Ransomware:  q.Get("ransomware"),
+	}
+	if err := kev.PostLoad(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rows, err := dbsearch.FindKnownExploited(s.reader, dbsearch.KnownExploitedOptions{
+		KnownExploited: kev.Specs,
+		RecordLimit:    s.config.RecordLimit,
+	})
+	respond(w, r, rows, err)
+}
+
+func (s *server) epss(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	epss := options.DBSearchEPSS{
+		CVEs:          q["cve"],
+		MinScore:      q.Get("min-score"),
+		MaxScore:      q.Get("max-score"),
+		MinPercentile: q.Get("min-percentile"),
+		MaxPercentile: q.Get("max-percentile"),
+	}
+	if err := epss.PostLoad(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rows, err := dbsearch.FindEPSS(s.reader, dbsearch.EPSSOptions{
+		EPSS:        epss.Specs,
+		RecordLimit: s.config.RecordLimit,
+	})
+	respond(w, r, rows, err)
+}
+
+func vulnerabilityOptions(q map[string][]string, idParam string) options.DBSearchVulnerabilities {
+	first := func(key string) string {
+		if v := q[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	return options.DBSearchVulnerabilities{
+		VulnerabilityIDs: q[idParam],
+		PublishedAfter:   first("published-after"),
+		PublishedBefore:  first("published-before"),
+		ModifiedAfter:    first("modified-after"),
+		Providers:        q["provider"],
+		FixedState:       q["fixed-state"],
+		Severity:         q["severity"],
+	}
+}
+
+// respond writes the requested page of the search results (or the search error).
+func respond[T any](w http.ResponseWriter, r *http.Request, rows []T, err error) {
+	truncated := errors.Is(err, v6.ErrLimitReached)
+	switch {
+	case errors.Is(err, dbsearch.ErrNoSearchCriteria):
+		writeError(w, http.StatusBadRequest, err)
+		return
+	case err != nil && !truncated:
+		log.WithFields("error", err, "path", r.URL.Path).Warn("unable to search vulnerability DB")
+		writeError(w, http.StatusInternalServerError, errors.New("unable to search the vulnerability database"))
+		return
+	}
+
+	page, perPage, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	start := min((page-1)*perPage, len(rows))
+	end := min(start+perPage, len(rows))
+	results := rows[start:end]
+	if results == nil {
+		// always allocate the results collection
+		results = []T{}
+	}
+
+	writeJSON(w, http.StatusOK, Page[T]{
+		Results:   results,
+		Page:      page,
+		PerPage:   perPage,
+		Total:     len(rows),
+		Truncated: truncated,
+	})
+}
+
+func pagination(r *http.Request) (page, perPage int, err error) {
+	page, perPage = 1, defaultPerPage
+	if v := r.URL.Query().Get("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("invalid page %q: must be a positive integer", v)
+		}
+	}
+	if v := r.URL.Query().Get("per-page"); v != "" {
+		if perPage, err = strconv.Atoi(v); err != nil || perPage < 1 || perPage > maxPerPage {
+			return 0, 0, fmt.Errorf("invalid per-page %q: must be between 1 and %d", v, maxPerPage)
+		}
+	}
+	return page, perPage, nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		log.WithFields("error", err).Debug("unable to write response")
+	}
+}
@@ -0,0 +1,101 @@
+package dbserve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/dbsearch"
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func newTestReader(t *testing.T) v6.Reader {
+	t.Helper()
+	dir := t.TempDir()
+
+	w, err := v6.NewWriter(v6.Config{DBDirPath: dir})
+	require.NoError(t, err)
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, w.AddEpss(
+		&v6.EpssHandle{Cve: "CVE-2024-0001", Epss: 0.9, Percentile: 0.99, Date: date},
+		&v6.EpssHandle{Cve: "CVE-2024-0002", Epss: 0.5, Percentile: 0.8, Date: date},
+		&v6.EpssHandle{Cve: "CVE-2024-0003", Epss: 0.1, Percentile: 0.3, Date: date},
+	))
+	require.NoError(t, w.Close())
+
+	r, err := v6.NewReader(v6.Config{DBDirPath: dir})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Close() })
+	return r
+}
+
+func TestServer(t *testing.T) {
+	srv := httptest.NewServer(New(newTestReader(t), Config{Token: "secret"}))
+	t.Cleanup(srv.Close)
+
+	get := func(t *testing.T, path, token string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("health is unauthenticated", func(t *testing.T) {
+		resp := get(t, "/healthz", "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("searches require the token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, get(t, "/v1/epss", "").StatusCode)
+		assert.Equal(t, http.StatusUnauthorized, get(t, "/v1/epss", "wrong").StatusCode)
+	})
+
+	t.Run("paginates results", func(t *testing.T) {
+		resp := get(t, "/v1/epss?per-page=2&page=2", "secret")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page Page[dbsearch.EPSS]
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		assert.Equal(t, 3, page.Total)
+		assert.Equal(t, 2, page.Page)
+		require.Len(t, page.Results, 1)
+		// results are ordered by descending score
+		assert.Equal(t, "CVE-2024-0003", page.Results[0].CVE)
+	})
+
+	t.Run("filters results", func(t *testing.T) {
+		resp := get(t, "/v1/epss?min-score=0.5", "secret")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page Page[dbsearch.EPSS]
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		assert.Equal(t, 2, page.Total)
+	})
+
+	t.Run("rejects invalid parameters", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get(t, "/v1/epss?min-score=2", "secret").StatusCode)
+		assert.Equal(t, http.StatusBadRequest, get(t, "/v1/epss?page=0", "secret").StatusCode)
+		assert.Equal(t, http.StatusBadRequest, get(t, "/v1/affected-packages", "secret").StatusCode)
+		assert.Equal(t, http.StatusBadRequest, get(t, "/v1/vulnerabilities", "secret").StatusCode)
+	})
+
+	t.Run("reports no results as empty", func(t *testing.T) {
+		resp := get(t, "/v1/vulnerabilities?id=CVE-1999-0001", "secret")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		assert.Equal(t, []any{}, page["results"])
+	})
+}
@@ -0,0 +1,126 @@
+package expectations
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// Result is the outcome of an expectation evaluated against a scan.
+type Result struct {
+	Expectation Expectation
+	Passed      bool
+	// Detail explains a failure (or summarizes what was found when passed)
+	Detail string
+}
+
+// Evaluate evaluates the expectations of the file against the matches of the given scan.
+func (f File) Evaluate(doc models.Document) []Result {
+	results := make([]Result, 0, len(f.Expectations))
+	for _, e := range f.Expectations {
+		results = append(results, e.evaluate(doc.Matches))
+	}
+	return results
+}
+
+// Failed returns the results of the expectations that were not met.
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, r := range results {
+		if !r.Passed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+func (e Expectation) evaluate(matches []models.Match) Result {
+	var selected []models.Match
+	for _, m := range matches {
+		if e.selects(m) {
+			selected = append(selected, m)
+		}
+	}
+
+	r := Result{Expectation: e, Passed: true, Detail: fmt.Sprintf("%d matching", len(selected))}
+
+	if e.Reported != nil {
+		switch {
+		case *e.Reported && len(selected) == 0:
+			return e.fail("not reported")
+		case !*e.Reported && len(selected) > 0:
+			return e.fail("reported: " + describe(selected))
+		}
+	}
+
+	if e.MaxSeverity != "" {
+		limit := vulnerability.ParseSeverity(e.MaxSeverity)
+		var above []models.Match
+		for _, m := range selected {
+			if vulnerability.ParseSeverity(m.Vulnerability.EffectiveSeverity()) > limit {
+				above = append(above, m)
+			}
+		}
+		if len(above) > 0 {
+			return e.fail(fmt.Sprintf("%d above %s: %s", len(above), e.MaxSeverity, describe(above)))
+		}
+	}
+
+	if e.MaxCount != nil && len(selected) > *e.MaxCount {
+		return e.fail(fmt.Sprintf("%d matching, expected at most %d", len(selected), *e.MaxCount))
+	}
+
+	return r
+}
+
+func (e Expectation) fail(detail string) Result {
+	return Result{Expectation: e, Detail: detail}
+}
+
+func (e Expectation) selects(m models.Match) bool {
+	if e.Vulnerability != "" && !hasVulnerability(m, e.Vulnerability) {
+		return false
+	}
+	p := e.Package
+	if p.Name != "" {
+		if ok, _ := path.Match(p.Name, m.Artifact.Name); !ok {
+			return false
+		}
+	}
+	if p.Version != "" && p.Version != m.Artifact.Version {
+		return false
+	}
+	if p.Type != "" && !strings.EqualFold(p.Type, string(m.Artifact.Type)) {
+		return false
+	}
+	return true
+}
+
+func hasVulnerability(m models.Match, id string) bool {
+	if strings.EqualFold(m.Vulnerability.ID, id) {
+		return true
+	}
+	for _, r := range m.RelatedVulnerabilities {
+		if strings.EqualFold(r.ID, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe lists the given matches (up to a few) as "ID (package@version, severity)".
+func describe(matches []models.Match) string {
+	const limit = 5
+	var parts []string
+	for i, m := range matches {
+		if i == limit {
+			parts = append(parts, fmt.Sprintf("and %d more", len(matches)-limit))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s@%s, %s)", m.Vulnerability.ID, m.Artifact.Name, m.Artifact.Version, strings.ToLower(m.Vulnerability.EffectiveSeverity())))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,96 @@
+package expectations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func newMatch(id, severity, name, version string, typ syftPkg.Type, related ...string) models.Match {
+	m := models.Match{
+		Vulnerability: models.Vulnerability{
+			VulnerabilityMetadata: models.VulnerabilityMetadata{ID: id, Severity: severity},
+		},
+		Artifact: models.Package{Name: name, Version: version, Type: typ},
+	}
+	for _, r := range related {
+		m.RelatedVulnerabilities = append(m.RelatedVulnerabilities, models.VulnerabilityMetadata{ID: r})
+	}
+	return m
+}
+
+func TestFile_Evaluate(t *testing.T) {
+	doc := models.Document{Matches: []models.Match{
+		newMatch("CVE-2021-44228", "Critical", "log4j-core", "2.14.1", syftPkg.JavaPkg),
+		newMatch("GHSA-jfh8-c2jp-5v3q", "Critical", "log4j-api", "2.14.1", syftPkg.JavaPkg, "CVE-2021-44228"),
+		newMatch("CVE-2023-0286", "High", "openssl", "3.0.7", syftPkg.ApkPkg),
+		newMatch("CVE-2023-0464", "Medium", "openssl-libs", "3.0.7", syftPkg.RpmPkg),
+	}}
+	yes, no := true, false
+	zero, two := 0, 2
+
+	tests := []struct {
+		name        string
+		expectation Expectation
+		wantPassed  bool
+		wantDetail  string
+	}{
+		{
+			name:        "reported",
+			expectation: Expectation{Vulnerability: "CVE-2021-44228", Package: Package{Name: "log4j-core"}, Reported: &yes},
+			wantPassed:  true,
+			wantDetail:  "1 matching",
+		},
+		{
+			name:        "reported via related vulnerability",
+			expectation: Expectation{Vulnerability: "cve-2021-44228", Package: Package{Name: "log4j-api"}, Reported: &yes},
+			wantPassed:  true,
+		},
+		{
+			name:        "not reported",
+			expectation: Expectation{Vulnerability: "CVE-2021-44228", Package: Package{Version: "2.17.0"}, Reported: &yes},
+			wantDetail:  "not reported",
+		},
+		{
+			name:        "must not be reported",
+			expectation: Expectation{Vulnerability: "CVE-2023-0286", Reported: &no},
+			wantDetail:  "reported: CVE-2023-0286 (openssl@3.0.7, high)",
+		},
+		{
+			name:        "max severity with glob",
+			expectation: Expectation{Package: Package{Name: "openssl*"}, MaxSeverity: "high"},
+			wantPassed:  true,
+			wantDetail:  "2 matching",
+		},
+		{
+			name:        "above max severity",
+			expectation: Expectation{Package: Package{Name: "openssl*"}, MaxSeverity: "medium"},
+			wantDetail:  "1 above medium: CVE-2023-0286 (openssl@3.0.7, high)",
+		},
+		{
+			name:        "type selector",
+			expectation: Expectation{Package: Package{Name: "openssl*", Type: "rpm"}, MaxCount: &zero},
+			wantDetail:  "1 matching, expected at most 0",
+		},
+		{
+			name:        "max count",
+			expectation: Expectation{Package: Package{Name: "log4j-*"}, MaxCount: &two},
+			wantPassed:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := File{Expectations: []Expectation{tt.expectation}}.Evaluate(doc)
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.wantPassed, results[0].Passed, results[0].Detail)
+			if tt.wantDetail != "" {
+				assert.Equal(t, tt.wantDetail, results[0].Detail)
+			}
+			assert.Equal(t, !tt.wantPassed, len(Failed(results)) == 1)
+		})
+	}
+}
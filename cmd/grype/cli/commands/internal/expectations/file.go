@@ -0,0 +1,140 @@
+package expectations
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// File is an expectations file: assertions on the results of a scan, used to validate vulnerability DB overlays or to
+// regression test grype upgrades, e.g.
+//
+//	expectations:
+//	  - description: no critical vulnerabilities in openssl
+//	    package:
+//	      name: openssl*
+//	    max-severity: high
+//	  - vulnerability: CVE-2021-44228
+//	    package:
+//	      name: log4j-core
+//	      version: 2.14.1
+//	    reported: true
+//	  - vulnerability: CVE-2020-0001
+//	    reported: false
+//	  - max-count: 25
+//
+// Every expectation selects the matches of the scan (ignored matches are not considered) by vulnerability and package,
+// and asserts that they are reported (or not), do not exceed a severity, or do not exceed a number.
+type File struct {
+	Expectations []Expectation `yaml:"expectations"`
+}
+
+// Expectation is a single assertion on the results of a scan.
+type Expectation struct {
+	Description string `yaml:"description"`
+
+	// Vulnerability selects the matches of the vulnerability (by ID, or by the ID of a related vulnerability)
+	Vulnerability string `yaml:"vulnerability"`
+	// Package selects the matches of the packages matching all given fields
+	Package Package `yaml:"package"`
+
+	// Reported asserts that at least one match is selected (true), or that none is (false)
+	Reported *bool `yaml:"reported"`
+	// MaxSeverity asserts that no selected match has a severity above the given severity
+	MaxSeverity string `yaml:"max-severity"`
+	// MaxCount asserts that at most the given number of matches are selected
+	MaxCount *int `yaml:"max-count"`
+}
+
+// Package selects packages by name (a glob), version and type.
+type Package struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Type    string `yaml:"type"`
+}
+
+// ReadFile reads and validates the expectations file at the given path.
+func ReadFile(p string) (*File, error) {
+	by, err := os.ReadFile(filepath.Clean(p))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read expectations file: %w", err)
+	}
+	var f File
+	if err := yaml.Unmarshal(by, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse expectations file %q: %w", p, err)
+	}
+	if len(f.Expectations) == 0 {
+		return nil, fmt.Errorf("no expectations found in %q", p)
+	}
+	for i, e := range f.Expectations {
+		if err := e.validate(); err != nil {
+			return nil, fmt.Errorf("invalid expectation %d in %q: %w", i+1, p, err)
+		}
+	}
+	return &f, nil
+}
+
+func (e Expectation) validate() error {
+	if e.Reported == nil && e.MaxSeverity == "" && e.MaxCount == nil {
+		return fmt.Errorf("one of reported, max-severity or max-count is required")
+	}
+	if e.MaxSeverity != "" && vulnerability.ParseSeverity(e.MaxSeverity) == vulnerability.UnknownSeverity {
+		return fmt.Errorf("invalid max-severity %q: must be one of %v", e.MaxSeverity, vulnerability.AllSeverities())
+	}
+	if e.MaxCount != nil && *e.MaxCount < 0 {
+		return fmt.Errorf("invalid max-count %d: must not be negative", *e.MaxCount)
+	}
+	if _, err := path.Match(e.Package.Name, ""); err != nil {
+		return fmt.Errorf("invalid package name glob %q: %w", e.Package.Name, err)
+	}
+	return nil
+}
+
+// String describes the expectation (its description when given).
+func (e Expectation) String() string {
+	if e.Description != "" {
+		return e.Description
+	}
+
+	subject := "matches"
+	if e.Vulnerability != "" {
+		subject = e.Vulnerability
+	}
+	if p := e.Package.String(); p != "" {
+		subject += " in " + p
+	}
+
+	var assertions []string
+	if e.Reported != nil {
+		if *e.Reported {
+			assertions = append(assertions, "reported")
+		} else {
+			assertions = append(assertions, "not reported")
+		}
+	}
+	if e.MaxSeverity != "" {
+		assertions = append(assertions, "at most "+e.MaxSeverity+" severity")
+	}
+	if e.MaxCount != nil {
+		assertions = append(assertions, fmt.Sprintf("at most %d matching", *e.MaxCount))
+	}
+
+	return subject + " " + strings.Join(assertions, ", ")
+}
+
+func (p Package) String() string {
+	s := p.Name
+	if p.Version != "" {
+		s += "@" + p.Version
+	}
+	if p.Type != "" {
+		s += " (" + p.Type + ")"
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package expectations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, contents string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "expectations.yaml")
+	require.NoError(t, os.WriteFile(p, []byte(contents), 0o600))
+	return p
+}
+
+func TestReadFile(t *testing.T) {
+	f, err := ReadFile(writeFile(t, `
+expectations:
+  - description: no critical vulnerabilities in openssl
+    package:
+      name: openssl*
+    max-severity: high
+  - vulnerability: CVE-2021-44228
+    package:
+      name: log4j-core
+      version: 2.14.1
+    reported: true
+  - max-count: 25
+`))
+	require.NoError(t, err)
+	require.Len(t, f.Expectations, 3)
+
+	assert.Equal(t, "no critical vulnerabilities in openssl", f.Expectations[0].String())
+	assert.Equal(t, "CVE-2021-44228 in log4j-core@2.14.1 reported", f.Expectations[1].String())
+	assert.Equal(t, "matches at most 25 matching", f.Expectations[2].String())
+	require.NotNil(t, f.Expectations[1].Reported)
+	assert.True(t, *f.Expectations[1].Reported)
+}
+
+func TestReadFile_invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{
+			name:     "empty",
+			contents: "expectations: []\n",
+			wantErr:  "no expectations found",
+		},
+		{
+			name:     "no assertion",
+			contents: "expectations:\n  - vulnerability: CVE-2024-1234\n",
+			wantErr:  "one of reported, max-severity or max-count is required",
+		},
+		{
+			name:     "bad severity",
+			contents: "expectations:\n  - max-severity: severe\n",
+			wantErr:  `invalid max-severity "severe"`,
+		},
+		{
+			name:     "negative count",
+			contents: "expectations:\n  - max-count: -1\n",
+			wantErr:  "invalid max-count -1",
+		},
+		{
+			name:     "bad glob",
+			contents: "expectations:\n  - package:\n      name: \"lib[\"\n    reported: false\n",
+			wantErr:  "invalid package name glob",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadFile(writeFile(t, tt.contents))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -26,6 +26,7 @@ func main() {
 
 	compose(dbsearch.Matches{}, "db-search", dbsearch.MatchesSchemaVersion, comments)
 	compose(dbsearch.Vulnerabilities{}, "db-search-vuln", dbsearch.VulnerabilitiesSchemaVersion, comments)
+	compose(dbsearch.VulnerabilityDetail{}, "db-show", dbsearch.VulnerabilityDetailSchemaVersion, comments)
 }
 
 func compose(document any, component, version string, comments map[string]map[string]string) {
@@ -0,0 +1,162 @@
+// Package offlinebundle writes self-contained archives of the grype binary, configuration and vulnerability DB, which
+// can be installed on hosts without network access (e.g. ephemeral CI runners).
+package offlinebundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+const (
+	// ConfigFile is the name of the grype configuration within the bundle.
+	ConfigFile = "grype.yaml"
+	// DBFile is the name of the vulnerability DB within the bundle.
+	DBFile = "vulnerability.db"
+	// ChecksumsFile is the name of the SHA256 checksums listing within the bundle (in sha256sum format).
+	ChecksumsFile = "SHA256SUMS"
+	// InstallFile is the name of the verify-and-install entrypoint within the bundle.
+	InstallFile = "install.sh"
+)
+
+//go:embed install.sh
+var installScript string
+
+var installTemplate = template.Must(template.New("install").Parse(installScript))
+
+// Config describes the contents of an offline bundle.
+type Config struct {
+	// BinaryPath is the path to the grype binary to include.
+	BinaryPath string
+	// DBFilePath is the path to the vulnerability DB file to include.
+	DBFilePath string
+	// Config is the grype configuration to include.
+	Config []byte
+	// Timestamp is the modification time recorded for all bundle entries.
+	Timestamp time.Time
+}
+
+type entry struct {
+	name     string
+	mode     int64
+	contents func() (io.ReadCloser, int64, error)
+}
+
+// Write writes a gzip-compressed tarball of the bundle described by the given config to the writer.
+func Write(w io.Writer, cfg Config) error {
+	binaryFile := filepath.Base(cfg.BinaryPath)
+
+	var install bytes.Buffer
+	err := installTemplate.Execute(&install, map[string]string{
+		"BinaryFile":    binaryFile,
+		"ConfigFile":    ConfigFile,
+		"DBFile":        DBFile,
+		"ChecksumsFile": ChecksumsFile,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to render install script: %w", err)
+	}
+
+	entries := []entry{
+		{name: binaryFile, mode: 0o755, contents: fileContents(cfg.BinaryPath)},
+		{name: ConfigFile, mode: 0o644, contents: bytesContents(cfg.Config)},
+		{name: DBFile, mode: 0o644, contents: fileContents(cfg.DBFilePath)},
+		{name: InstallFile, mode: 0o755, contents: bytesContents(install.Bytes())},
+	}
+
+	checksums, err := checksumListing(entries)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry{name: ChecksumsFile, mode: 0o644, contents: bytesContents(checksums)})
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, e := range entries {
+		if err := writeEntry(tw, e, cfg.Timestamp); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("unable to finalize bundle: %w", err)
+	}
+	return gw.Close()
+}
+
+func writeEntry(tw *tar.Writer, e entry, timestamp time.Time) error {
+	reader, size, err := e.contents()
+	if err != nil {
+		return fmt.Errorf("unable to read %q for bundle: %w", e.name, err)
+	}
+	defer log.CloseAndLogError(reader, e.name)
+
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     e.name,
+		Mode:     e.mode,
+		Size:     size,
+		ModTime:  timestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write bundle header for %q: %w", e.name, err)
+	}
+
+	if _, err := io.Copy(tw, reader); err != nil {
+		return fmt.Errorf("unable to write %q to bundle: %w", e.name, err)
+	}
+	return nil
+}
+
+// checksumListing renders the SHA256 digests of the given entries in the format understood by "sha256sum -c".
+func checksumListing(entries []entry) ([]byte, error) {
+	var lines []string
+	for _, e := range entries {
+		reader, _, err := e.contents()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %q for bundle: %w", e.name, err)
+		}
+
+		h := sha256.New()
+		_, err = io.Copy(h, reader)
+		log.CloseAndLogError(reader, e.name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to digest %q: %w", e.name, err)
+		}
+		lines = append(lines, fmt.Sprintf("%x  %s", h.Sum(nil), e.name))
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func fileContents(path string) func() (io.ReadCloser, int64, error) {
+	return func() (io.ReadCloser, int64, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		info, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			return nil, 0, err
+		}
+		return f, info.Size(), nil
+	}
+}
+
+func bytesContents(by []byte) func() (io.ReadCloser, int64, error) {
+	return func() (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(by)), int64(len(by)), nil
+	}
+}
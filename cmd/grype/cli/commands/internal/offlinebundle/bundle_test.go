@@ -0,0 +1,75 @@
+package offlinebundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	binaryPath := filepath.Join(dir, "grype")
+	dbPath := filepath.Join(dir, "vulnerability.db")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("binary"), 0o600))
+	require.NoError(t, os.WriteFile(dbPath, []byte("database"), 0o600))
+
+	timestamp := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, Config{
+		BinaryPath: binaryPath,
+		DBFilePath: dbPath,
+		Config:     []byte("db:\n  auto-update: false\n"),
+		Timestamp:  timestamp,
+	}))
+
+	gr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	contents := make(map[string]string)
+	modes := make(map[string]int64)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		by, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		contents[hdr.Name] = string(by)
+		modes[hdr.Name] = hdr.Mode
+		assert.Equal(t, timestamp, hdr.ModTime.UTC())
+	}
+
+	require.Len(t, contents, 5)
+	assert.Equal(t, "binary", contents["grype"])
+	assert.Equal(t, "database", contents[DBFile])
+	assert.Equal(t, "db:\n  auto-update: false\n", contents[ConfigFile])
+	assert.Equal(t, int64(0o755), modes["grype"])
+	assert.Equal(t, int64(0o755), modes[InstallFile])
+
+	assert.Contains(t, contents[InstallFile], "sha256sum -c SHA256SUMS")
+	assert.Contains(t, contents[InstallFile], `db import vulnerability.db`)
+	assert.NotContains(t, contents[InstallFile], "{{")
+
+	// every other entry must be listed in the checksums file with its digest
+	checksums := strings.Split(strings.TrimSpace(contents[ChecksumsFile]), "\n")
+	require.Len(t, checksums, 4)
+	for _, line := range checksums {
+		digest, name, found := strings.Cut(line, "  ")
+		require.True(t, found, line)
+		assert.Equal(t, fmt.Sprintf("%x", sha256.Sum256([]byte(contents[name]))), digest, name)
+	}
+}
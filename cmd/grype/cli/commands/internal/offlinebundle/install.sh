@@ -0,0 +1,40 @@
+#!/bin/sh
+# verifies the contents of this grype offline bundle and installs the binary, configuration and vulnerability DB.
+#
+# environment variables:
+#   INSTALL_DIR    directory to install the grype binary to (default: /usr/local/bin)
+#   CONFIG_PATH    path to install the grype configuration to (default: $HOME/.grype.yaml, skipped if it already exists)
+#   GRYPE_DB_CACHE_DIR is honored by "grype db import" as usual
+set -eu
+
+BUNDLE_DIR=$(cd "$(dirname "$0")" && pwd)
+INSTALL_DIR=${INSTALL_DIR:-/usr/local/bin}
+CONFIG_PATH=${CONFIG_PATH:-${HOME}/.grype.yaml}
+
+cd "${BUNDLE_DIR}"
+
+echo "verifying bundle checksums"
+if command -v sha256sum >/dev/null 2>&1; then
+  sha256sum -c {{.ChecksumsFile}}
+elif command -v shasum >/dev/null 2>&1; then
+  shasum -a 256 -c {{.ChecksumsFile}}
+else
+  echo "unable to verify bundle: sha256sum or shasum is required" >&2
+  exit 1
+fi
+
+echo "installing {{.BinaryFile}} to ${INSTALL_DIR}"
+mkdir -p "${INSTALL_DIR}"
+cp {{.BinaryFile}} "${INSTALL_DIR}/{{.BinaryFile}}"
+chmod 0755 "${INSTALL_DIR}/{{.BinaryFile}}"
+
+if [ -e "${CONFIG_PATH}" ]; then
+  echo "configuration already exists at ${CONFIG_PATH}, leaving it in place"
+else
+  echo "installing configuration to ${CONFIG_PATH}"
+  mkdir -p "$(dirname "${CONFIG_PATH}")"
+  cp {{.ConfigFile}} "${CONFIG_PATH}"
+fi
+
+echo "importing vulnerability database"
+"${INSTALL_DIR}/{{.BinaryFile}}" db import {{.DBFile}}
@@ -0,0 +1,172 @@
+package triage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/grype/grype/match"
+)
+
+const (
+	ignoreConfigKey       = "ignore"
+	vexDocumentsConfigKey = "vex-documents"
+)
+
+// ConfigUpdate describes the changes to make to a grype configuration file.
+type ConfigUpdate struct {
+	// AddIgnoreRules are added unless an identical rule is already present
+	AddIgnoreRules []match.IgnoreRule
+	// RemoveIgnoreRules are removed if present
+	RemoveIgnoreRules []match.IgnoreRule
+	// AddVexDocuments are added unless already present
+	AddVexDocuments []string
+}
+
+// ConfigChanges summarizes the changes made to a grype configuration file.
+type ConfigChanges struct {
+	AddedIgnoreRules   int
+	RemovedIgnoreRules int
+	AddedVexDocuments  int
+}
+
+// UpdateConfig merges the given changes into the grype configuration file at the given path (creating it if needed),
+// preserving all other configuration and comments.
+func UpdateConfig(path string, update ConfigUpdate) (ConfigChanges, error) {
+	var changes ConfigChanges
+
+	root, err := readConfigNode(path)
+	if err != nil {
+		return changes, err
+	}
+	doc := root.Content[0]
+
+	if len(update.AddIgnoreRules) > 0 || len(update.RemoveIgnoreRules) > 0 {
+		added, removed, err := updateIgnoreRules(sequenceValue(doc, ignoreConfigKey), update)
+		if err != nil {
+			return changes, fmt.Errorf("unable to update ignore rules in %q: %w", path, err)
+		}
+		changes.AddedIgnoreRules, changes.RemovedIgnoreRules = added, removed
+	}
+
+	if len(update.AddVexDocuments) > 0 {
+		vexDocuments := sequenceValue(doc, vexDocumentsConfigKey)
+		for _, d := range update.AddVexDocuments {
+			if slices.ContainsFunc(vexDocuments.Content, func(n *yaml.Node) bool { return n.Value == d }) {
+				continue
+			}
+			vexDocuments.Content = append(vexDocuments.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: d})
+			changes.AddedVexDocuments++
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return changes, fmt.Errorf("unable to encode configuration: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(path), buf.Bytes(), 0o600); err != nil {
+		return changes, fmt.Errorf("unable to write configuration %q: %w", path, err)
+	}
+	return changes, nil
+}
+
+func updateIgnoreRules(ignores *yaml.Node, update ConfigUpdate) (added, removed int, _ error) {
+	var kept []*yaml.Node
+	var existing []match.IgnoreRule
+	for _, n := range ignores.Content {
+		var rule match.IgnoreRule
+		if err := n.Decode(&rule); err != nil {
+			return 0, 0, fmt.Errorf("unable to parse existing ignore rule: %w", err)
+		}
+		if containsRule(update.RemoveIgnoreRules, rule) {
+			removed++
+			continue
+		}
+		kept = append(kept, n)
+		existing = append(existing, rule)
+	}
+	ignores.Content = kept
+
+	for _, rule := range update.AddIgnoreRules {
+		if containsRule(existing, rule) {
+			continue
+		}
+		var n yaml.Node
+		if err := n.Encode(newIgnoreRuleEntry(rule)); err != nil {
+			return 0, 0, fmt.Errorf("unable to encode ignore rule: %w", err)
+		}
+		ignores.Content = append(ignores.Content, &n)
+		existing = append(existing, rule)
+		added++
+	}
+	return added, removed, nil
+}
+
+func readConfigNode(path string) (*yaml.Node, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to read configuration: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(by, &root); err != nil {
+		return nil, fmt.Errorf("unable to parse configuration %q: %w", path, err)
+	}
+	if root.Kind == 0 {
+		// empty or missing file
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if len(root.Content) != 1 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("unable to update configuration %q: expected a mapping at the top level", path)
+	}
+	return &root, nil
+}
+
+// sequenceValue returns the sequence node for the given key of the mapping, creating an empty sequence if the key is
+// missing or empty, and wrapping a single scalar value in a sequence.
+func sequenceValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		value := mapping.Content[i+1]
+		switch {
+		case value.Kind == yaml.SequenceNode:
+		case value.Kind == yaml.ScalarNode && value.Tag != "!!null" && value.Value != "":
+			item := *value
+			*value = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{&item}}
+		default:
+			*value = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		return value
+	}
+	value := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	return value
+}
+
+func containsRule(rules []match.IgnoreRule, rule match.IgnoreRule) bool {
+	return slices.ContainsFunc(rules, func(r match.IgnoreRule) bool { return reflect.DeepEqual(r, rule) })
+}
+
+// newIgnoreRuleEntry returns the configuration entry of an ignore rule generated from a triage decision, omitting
+// the criteria that triage decisions never set.
+func newIgnoreRuleEntry(rule match.IgnoreRule) ignoreRule {
+	return ignoreRule{
+		Vulnerability: rule.Vulnerability,
+		Reason:        rule.Reason,
+		Package: ignoreRulePackage{
+			Name:    rule.Package.Name,
+			Version: rule.Package.Version,
+			Type:    rule.Package.Type,
+		},
+	}
+}
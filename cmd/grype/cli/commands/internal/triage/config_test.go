@@ -0,0 +1,119 @@
+package triage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+)
+
+func TestUpdateConfig(t *testing.T) {
+	existing := `# project configuration
+output: table
+ignore:
+  # accepted by the security team
+  - vulnerability: CVE-2023-0001
+    fix-state: not-fixed
+  - vulnerability: CVE-2024-0003
+    reason: accepted risk
+    package:
+      name: minimist
+`
+	path := filepath.Join(t.TempDir(), ".grype.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(existing), 0o600))
+
+	update := ConfigUpdate{
+		AddIgnoreRules: []match.IgnoreRule{
+			{
+				Vulnerability: "CVE-2024-0001",
+				Reason:        "only used in tests",
+				Package:       match.IgnoreRulePackage{Name: "left-pad", Version: "1.0.0", Type: "npm"},
+			},
+		},
+		RemoveIgnoreRules: []match.IgnoreRule{
+			{
+				Vulnerability: "CVE-2024-0003",
+				Reason:        "accepted risk",
+				Package:       match.IgnoreRulePackage{Name: "minimist"},
+			},
+		},
+		AddVexDocuments: []string{"triage.vex.json"},
+	}
+
+	changes, err := UpdateConfig(path, update)
+	require.NoError(t, err)
+	assert.Equal(t, ConfigChanges{AddedIgnoreRules: 1, RemovedIgnoreRules: 1, AddedVexDocuments: 1}, changes)
+
+	want := `# project configuration
+output: table
+ignore:
+  # accepted by the security team
+  - vulnerability: CVE-2023-0001
+    fix-state: not-fixed
+  - vulnerability: CVE-2024-0001
+    reason: only used in tests
+    package:
+      name: left-pad
+      version: 1.0.0
+      type: npm
+vex-documents:
+  - triage.vex.json
+`
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	// applying the same update again is a no-op
+	changes, err = UpdateConfig(path, update)
+	require.NoError(t, err)
+	assert.Equal(t, ConfigChanges{}, changes)
+	got, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+}
+
+func TestUpdateConfig_missingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".grype.yaml")
+
+	changes, err := UpdateConfig(path, ConfigUpdate{
+		AddIgnoreRules: []match.IgnoreRule{{Vulnerability: "CVE-2024-0001", Package: match.IgnoreRulePackage{Name: "left-pad"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, changes.AddedIgnoreRules)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `ignore:
+  - vulnerability: CVE-2024-0001
+    package:
+      name: left-pad
+`, string(got))
+}
+
+func TestUpdateConfig_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".grype.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- not\n- a mapping\n"), 0o600))
+
+	_, err := UpdateConfig(path, ConfigUpdate{})
+	require.ErrorContains(t, err, "expected a mapping at the top level")
+}
+
+func TestUpdateConfig_scalarVexDocuments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".grype.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("vex-documents: existing.vex.json\n"), 0o600))
+
+	changes, err := UpdateConfig(path, ConfigUpdate{AddVexDocuments: []string{"triage.vex.json"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, changes.AddedVexDocuments)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `vex-documents:
+  - existing.vex.json
+  - triage.vex.json
+`, string(got))
+}
@@ -0,0 +1,189 @@
+package triage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/grype/grype/match"
+)
+
+// expiryLayouts are the accepted formats of the expiry of a triage entry.
+var expiryLayouts = []string{time.DateOnly, time.RFC3339}
+
+// File is a triage file: a list of triage decisions kept alongside the code being scanned, e.g.
+//
+//	triage:
+//	  - vulnerability: CVE-2024-1234
+//	    package:
+//	      name: lodash
+//	      version: 4.17.20
+//	      type: npm
+//	      purl: pkg:npm/lodash@4.17.20
+//	    decision: not_affected
+//	    reason: the vulnerable function is never called
+//	    expires: 2025-06-30
+type File struct {
+	Entries []Entry `yaml:"triage"`
+}
+
+// Entry is a single triage decision.
+type Entry struct {
+	Vulnerability string       `yaml:"vulnerability"`
+	Package       EntryPackage `yaml:"package"`
+	Decision      Decision     `yaml:"decision"`
+	Reason        string       `yaml:"reason"`
+	// Justification is the OpenVEX justification of a not_affected decision (optional when a reason is given)
+	Justification string `yaml:"justification"`
+	// Expires is the date (or RFC3339 timestamp) after which the decision no longer applies (optional)
+	Expires string `yaml:"expires"`
+}
+
+// EntryPackage identifies the package a triage decision applies to.
+type EntryPackage struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	Type    string `yaml:"type"`
+	// PURL is required for not_affected decisions, since it identifies the product of the VEX statement
+	PURL string `yaml:"purl"`
+}
+
+// ReadFile reads and validates the triage file at the given path.
+func ReadFile(path string) (*File, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read triage file: %w", err)
+	}
+	var f File
+	if err := yaml.Unmarshal(by, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse triage file %q: %w", path, err)
+	}
+	for i, e := range f.Entries {
+		if err := e.validate(); err != nil {
+			return nil, fmt.Errorf("invalid triage entry %d (%s): %w", i+1, e.Vulnerability, err)
+		}
+	}
+	return &f, nil
+}
+
+func (e Entry) validate() error {
+	if e.Vulnerability == "" {
+		return fmt.Errorf("vulnerability is required")
+	}
+	if e.Package.Name == "" && e.Package.PURL == "" {
+		return fmt.Errorf("package name or purl is required")
+	}
+	if e.Expires != "" {
+		if _, err := e.expiry(); err != nil {
+			return err
+		}
+	}
+	switch e.Decision {
+	case Ignored:
+		return nil
+	case NotAffected:
+		if e.Package.PURL == "" {
+			return fmt.Errorf("package purl is required for %q decisions", NotAffected)
+		}
+		if e.Justification != "" && !openvex.Justification(e.Justification).Valid() {
+			return fmt.Errorf("invalid justification %q", e.Justification)
+		}
+		if e.Reason == "" && e.Justification == "" {
+			return fmt.Errorf("a reason or justification is required for %q decisions", NotAffected)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid decision %q (must be %q or %q)", e.Decision, Ignored, NotAffected)
+	}
+}
+
+func (e Entry) expiry() (time.Time, error) {
+	for _, layout := range expiryLayouts {
+		if t, err := time.Parse(layout, e.Expires); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry %q (expected a date such as 2006-01-02)", e.Expires)
+}
+
+// Expired indicates if the decision no longer applies at the given time. Dates expire at the end of the day.
+func (e Entry) Expired(now time.Time) bool {
+	if e.Expires == "" {
+		return false
+	}
+	t, err := e.expiry()
+	if err != nil {
+		return false
+	}
+	if len(e.Expires) == len(time.DateOnly) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return !now.Before(t)
+}
+
+// IgnoreRule returns the grype ignore rule for an ignored finding.
+func (e Entry) IgnoreRule() match.IgnoreRule {
+	return match.IgnoreRule{
+		Vulnerability: e.Vulnerability,
+		Reason:        e.Reason,
+		Package: match.IgnoreRulePackage{
+			Name:    e.Package.Name,
+			Version: e.Package.Version,
+			Type:    e.Package.Type,
+		},
+	}
+}
+
+// Statement returns the OpenVEX statement for a finding that is not affected.
+func (e Entry) Statement(timestamp time.Time) openvex.Statement {
+	return openvex.Statement{
+		Vulnerability: openvex.Vulnerability{Name: openvex.VulnerabilityID(e.Vulnerability)},
+		Products: []openvex.Product{
+			{Component: openvex.Component{ID: e.Package.PURL}},
+		},
+		Status:          openvex.StatusNotAffected,
+		Justification:   openvex.Justification(e.Justification),
+		ImpactStatement: e.Reason,
+		Timestamp:       &timestamp,
+	}
+}
+
+// Result is the outcome of applying a triage file.
+type Result struct {
+	// IgnoreRules are the rules for the active ignore decisions
+	IgnoreRules []match.IgnoreRule
+	// ExpiredIgnoreRules are the rules for expired ignore decisions, which should be removed from the configuration
+	ExpiredIgnoreRules []match.IgnoreRule
+	// VEX holds the statements for the active not_affected decisions
+	VEX openvex.VEX
+	// Expired are the entries that no longer apply
+	Expired []Entry
+}
+
+// Apply converts the triage decisions that are active at the given time into ignore rules and VEX statements.
+func (f File) Apply(author string, now time.Time) Result {
+	r := Result{VEX: openvex.New()}
+	r.VEX.Author = author
+	r.VEX.Timestamp = &now
+
+	for _, e := range f.Entries {
+		if e.Expired(now) {
+			r.Expired = append(r.Expired, e)
+			if e.Decision == Ignored {
+				r.ExpiredIgnoreRules = append(r.ExpiredIgnoreRules, e.IgnoreRule())
+			}
+			continue
+		}
+		switch e.Decision {
+		case Ignored:
+			r.IgnoreRules = append(r.IgnoreRules, e.IgnoreRule())
+		case NotAffected:
+			r.VEX.Statements = append(r.VEX.Statements, e.Statement(now))
+		}
+	}
+	return r
+}
@@ -0,0 +1,138 @@
+package triage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+)
+
+func TestReadFile(t *testing.T) {
+	f, err := ReadFile("testdata/triage.yaml")
+	require.NoError(t, err)
+	require.Len(t, f.Entries, 3)
+	assert.Equal(t, Entry{
+		Vulnerability: "CVE-2024-0002",
+		Package:       EntryPackage{Name: "lodash", Version: "1.0.0", Type: "npm", PURL: "pkg:npm/lodash@1.0.0"},
+		Decision:      NotAffected,
+		Reason:        "the vulnerable function is never called",
+		Expires:       "2024-06-30",
+	}, f.Entries[1])
+}
+
+func TestReadFile_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   string
+		wantErr string
+	}{
+		{
+			name:    "missing vulnerability",
+			entry:   "package: {name: foo}\n    decision: ignore",
+			wantErr: "vulnerability is required",
+		},
+		{
+			name:    "missing package",
+			entry:   "vulnerability: CVE-1\n    decision: ignore",
+			wantErr: "package name or purl is required",
+		},
+		{
+			name:    "invalid decision",
+			entry:   "vulnerability: CVE-1\n    package: {name: foo}\n    decision: fixed",
+			wantErr: `invalid decision "fixed"`,
+		},
+		{
+			name:    "not affected without purl",
+			entry:   "vulnerability: CVE-1\n    package: {name: foo}\n    decision: not_affected\n    reason: unused",
+			wantErr: "package purl is required",
+		},
+		{
+			name:    "not affected without reason",
+			entry:   "vulnerability: CVE-1\n    package: {purl: pkg:npm/foo@1}\n    decision: not_affected",
+			wantErr: "a reason or justification is required",
+		},
+		{
+			name:    "invalid justification",
+			entry:   "vulnerability: CVE-1\n    package: {purl: pkg:npm/foo@1}\n    decision: not_affected\n    justification: because",
+			wantErr: `invalid justification "because"`,
+		},
+		{
+			name:    "invalid expiry",
+			entry:   "vulnerability: CVE-1\n    package: {name: foo}\n    decision: ignore\n    expires: next week",
+			wantErr: `invalid expiry "next week"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "triage.yaml")
+			require.NoError(t, os.WriteFile(path, []byte("triage:\n  - "+tt.entry+"\n"), 0o600))
+
+			_, err := ReadFile(path)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEntry_Expired(t *testing.T) {
+	tests := []struct {
+		expires string
+		now     time.Time
+		want    bool
+	}{
+		{expires: "", now: time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), want: false},
+		{expires: "2024-06-30", now: time.Date(2024, 6, 30, 23, 59, 0, 0, time.UTC), want: false},
+		{expires: "2024-06-30", now: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), want: true},
+		{expires: "2024-06-30T12:00:00Z", now: time.Date(2024, 6, 30, 11, 0, 0, 0, time.UTC), want: false},
+		{expires: "2024-06-30T12:00:00Z", now: time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expires+"@"+tt.now.Format(time.RFC3339), func(t *testing.T) {
+			assert.Equal(t, tt.want, Entry{Expires: tt.expires}.Expired(tt.now))
+		})
+	}
+}
+
+func TestFile_Apply(t *testing.T) {
+	f, err := ReadFile("testdata/triage.yaml")
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	r := f.Apply("grype", now)
+
+	assert.Equal(t, []match.IgnoreRule{
+		{
+			Vulnerability: "CVE-2024-0001",
+			Reason:        "only used in tests",
+			Package:       match.IgnoreRulePackage{Name: "left-pad", Version: "1.0.0", Type: "npm"},
+		},
+	}, r.IgnoreRules)
+	assert.Equal(t, []match.IgnoreRule{
+		{
+			Vulnerability: "CVE-2024-0003",
+			Reason:        "accepted risk",
+			Package:       match.IgnoreRulePackage{Name: "minimist"},
+		},
+	}, r.ExpiredIgnoreRules)
+	require.Len(t, r.Expired, 1)
+	assert.Equal(t, "CVE-2024-0003", r.Expired[0].Vulnerability)
+
+	assert.Equal(t, "grype", r.VEX.Author)
+	require.Len(t, r.VEX.Statements, 1)
+	st := r.VEX.Statements[0]
+	assert.Equal(t, "CVE-2024-0002", string(st.Vulnerability.Name))
+	assert.Equal(t, openvex.StatusNotAffected, st.Status)
+	assert.Equal(t, "the vulnerable function is never called", st.ImpactStatement)
+	require.Len(t, st.Products, 1)
+	assert.Equal(t, "pkg:npm/lodash@1.0.0", st.Products[0].ID)
+
+	// once the not_affected decision expires it is no longer exported
+	r = f.Apply("grype", time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))
+	assert.Empty(t, r.VEX.Statements)
+	assert.Len(t, r.Expired, 2)
+}
@@ -0,0 +1,247 @@
+package triage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+const defaultListHeight = 15
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	cursorStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	auxStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	statusStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	severityStyle = map[vulnerability.Severity]lipgloss.Style{
+		vulnerability.CriticalSeverity:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("197")),
+		vulnerability.HighSeverity:       lipgloss.NewStyle().Foreground(lipgloss.Color("197")),
+		vulnerability.MediumSeverity:     lipgloss.NewStyle().Foreground(lipgloss.Color("214")),
+		vulnerability.LowSeverity:        lipgloss.NewStyle().Foreground(lipgloss.Color("36")),
+		vulnerability.NegligibleSeverity: lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+	}
+	decisionMarker = map[Decision]string{
+		Undecided:   "[ ]",
+		Ignored:     "[i]",
+		NotAffected: "[v]",
+	}
+)
+
+// ExplainFunc renders the explanation of the given vulnerability ID.
+type ExplainFunc func(id string) string
+
+// Config configures the triage browser.
+type Config struct {
+	// Explain renders the inline explanation of a finding
+	Explain ExplainFunc
+	// ExportPrefix is the path prefix of the files written when the session is exported
+	ExportPrefix string
+	// Author is recorded in exported VEX documents
+	Author string
+}
+
+// Model is the bubbletea model of the triage browser.
+type Model struct {
+	session *Session
+	cfg     Config
+
+	// cursor is the position within the visible items
+	cursor      int
+	showDetails bool
+	filtering   bool
+	height      int
+	status      string
+
+	// now returns the current time (overridable for testing)
+	now func() time.Time
+}
+
+var _ tea.Model = (*Model)(nil)
+
+// New creates a triage browser for the given session.
+func New(session *Session, cfg Config) *Model {
+	return &Model{
+		session: session,
+		cfg:     cfg,
+		height:  defaultListHeight,
+		now:     time.Now,
+	}
+}
+
+// Session returns the triage session being browsed.
+func (m *Model) Session() *Session {
+	return m.session
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// leave room for the header, footer and the details pane
+		m.height = max(msg.Height/2-4, 3)
+	case tea.KeyMsg:
+		if m.filtering {
+			return m, m.updateFilter(msg)
+		}
+		return m, m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) updateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter, tea.KeyEsc:
+		m.filtering = false
+	case tea.KeyBackspace:
+		if f := []rune(m.session.PackageFilter); len(f) > 0 {
+			m.session.PackageFilter = string(f[:len(f)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.session.PackageFilter += string(msg.Runes)
+	case tea.KeyCtrlC:
+		return tea.Quit
+	}
+	m.clampCursor()
+	return nil
+}
+
+func (m *Model) updateBrowse(msg tea.KeyMsg) tea.Cmd {
+	m.status = ""
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.session.Visible())-1 {
+			m.cursor++
+		}
+	case "enter":
+		m.showDetails = !m.showDetails
+	case "s":
+		m.session.CycleSeverity()
+		m.clampCursor()
+	case "/":
+		m.filtering = true
+	case "i":
+		m.session.Toggle(m.selected(), Ignored)
+	case "v":
+		m.session.Toggle(m.selected(), NotAffected)
+	case "e":
+		m.export()
+	}
+	return nil
+}
+
+// selected returns the index (within the session items) of the item under the cursor, or -1 if there is none.
+func (m *Model) selected() int {
+	visible := m.session.Visible()
+	if m.cursor < 0 || m.cursor >= len(visible) {
+		return -1
+	}
+	return visible[m.cursor]
+}
+
+func (m *Model) clampCursor() {
+	visible := len(m.session.Visible())
+	if m.cursor >= visible {
+		m.cursor = visible - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *Model) export() {
+	written, err := m.session.Export(m.cfg.ExportPrefix, m.cfg.Author, m.now())
+	if err != nil {
+		m.status = fmt.Sprintf("export failed: %v", err)
+		return
+	}
+	m.status = fmt.Sprintf("exported %s", strings.Join(written, ", "))
+}
+
+func (m *Model) View() string {
+	var sb strings.Builder
+
+	visible := m.session.Visible()
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("%d of %d findings", len(visible), len(m.session.Items))))
+	sb.WriteString(auxStyle.Render(fmt.Sprintf("  severity >= %s", minSeverityLabel(m.session.MinSeverity))))
+	if m.session.PackageFilter != "" || m.filtering {
+		filter := m.session.PackageFilter
+		if m.filtering {
+			filter += "_"
+		}
+		sb.WriteString(auxStyle.Render(fmt.Sprintf("  package: %s", filter)))
+	}
+	sb.WriteString("\n\n")
+
+	start := 0
+	if m.cursor >= m.height {
+		start = m.cursor - m.height + 1
+	}
+	end := min(start+m.height, len(visible))
+	for pos := start; pos < end; pos++ {
+		sb.WriteString(m.renderRow(pos, m.session.Items[visible[pos]]))
+		sb.WriteString("\n")
+	}
+	if len(visible) == 0 {
+		sb.WriteString(auxStyle.Render("no findings match the current filters"))
+		sb.WriteString("\n")
+	}
+
+	if idx := m.selected(); m.showDetails && idx >= 0 && m.cfg.Explain != nil {
+		sb.WriteString("\n")
+		sb.WriteString(m.cfg.Explain(m.session.Items[idx].Match.Vulnerability.ID))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	if m.status != "" {
+		sb.WriteString(statusStyle.Render(m.status))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(auxStyle.Render("↑/↓ move • enter explain • s severity • / package filter • i ignore • v not affected • e export • q quit"))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func (m *Model) renderRow(pos int, item Item) string {
+	cursor := "  "
+	if pos == m.cursor {
+		cursor = cursorStyle.Render("> ")
+	}
+
+	severity := item.Match.Vulnerability.Severity
+	if style, ok := severityStyle[item.Severity]; ok {
+		severity = style.Render(fmt.Sprintf("%-10s", severity))
+	} else {
+		severity = fmt.Sprintf("%-10s", severity)
+	}
+
+	return fmt.Sprintf("%s%s %s %-20s %s %s",
+		cursor,
+		decisionMarker[item.Decision],
+		severity,
+		item.Match.Vulnerability.ID,
+		item.Match.Artifact.Name,
+		auxStyle.Render(item.Match.Artifact.Version),
+	)
+}
+
+func minSeverityLabel(s vulnerability.Severity) string {
+	if s == vulnerability.UnknownSeverity {
+		return "any"
+	}
+	return s.String()
+}
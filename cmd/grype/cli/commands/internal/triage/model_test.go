@@ -0,0 +1,101 @@
+package triage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func keys(m *Model, in ...string) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range in {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		_, cmd = m.Update(msg)
+	}
+	return cmd
+}
+
+func TestModel_navigationAndDecisions(t *testing.T) {
+	m := New(NewSession(testDocument()), Config{})
+
+	keys(m, "i", "down", "v", "down", "down", "i")
+
+	s := m.Session()
+	assert.Equal(t, Ignored, s.Items[0].Decision)
+	assert.Equal(t, NotAffected, s.Items[1].Decision)
+	// the cursor stops at the last item
+	assert.Equal(t, Ignored, s.Items[2].Decision)
+
+	assert.Contains(t, m.View(), "[i]")
+	assert.Contains(t, m.View(), "[v]")
+}
+
+func TestModel_filters(t *testing.T) {
+	m := New(NewSession(testDocument()), Config{})
+
+	keys(m, "/", "l", "e", "f", "t", "x", "backspace", "enter")
+	assert.Equal(t, "left", m.Session().PackageFilter)
+	assert.Equal(t, []int{2}, m.Session().Visible())
+
+	// keys typed while filtering are not treated as commands
+	assert.Empty(t, m.Session().Decided(Ignored))
+
+	keys(m, "/", "backspace", "backspace", "backspace", "backspace", "esc", "s", "s", "s")
+	assert.Equal(t, vulnerability.MediumSeverity, m.Session().MinSeverity)
+	assert.Equal(t, []int{0, 1}, m.Session().Visible())
+	assert.Contains(t, m.View(), "2 of 3 findings")
+}
+
+func TestModel_explain(t *testing.T) {
+	var explained []string
+	m := New(NewSession(testDocument()), Config{
+		Explain: func(id string) string {
+			explained = append(explained, id)
+			return "details for " + id
+		},
+	})
+
+	assert.NotContains(t, m.View(), "details for")
+
+	keys(m, "enter")
+	assert.Contains(t, m.View(), "details for CVE-2024-0002")
+
+	keys(m, "down")
+	assert.Contains(t, m.View(), "details for CVE-2024-0003")
+	assert.Equal(t, []string{"CVE-2024-0002", "CVE-2024-0003"}, explained)
+}
+
+func TestModel_export(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "session")
+	m := New(NewSession(testDocument()), Config{ExportPrefix: prefix, Author: "grype"})
+	m.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	keys(m, "i", "e")
+	assert.Contains(t, m.View(), "exported "+prefix+".json")
+	assert.FileExists(t, prefix+"-ignore.yaml")
+}
+
+func TestModel_quit(t *testing.T) {
+	m := New(NewSession(testDocument()), Config{})
+	cmd := keys(m, "q")
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+}
@@ -0,0 +1,245 @@
+// Package triage implements an interactive browser for grype scan results, in which findings can be filtered, explained
+// and marked as ignored or not affected, and the resulting decisions exported as ignore rules and OpenVEX statements.
+package triage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// Decision is the triage outcome recorded for a finding.
+type Decision string
+
+const (
+	// Undecided findings have not been triaged.
+	Undecided Decision = ""
+	// Ignored findings are exported as grype ignore rules.
+	Ignored Decision = "ignore"
+	// NotAffected findings are exported as OpenVEX "not_affected" statements.
+	NotAffected Decision = "not_affected"
+)
+
+const (
+	ignoreReason     = "triaged with grype explain"
+	vexJustification = openvex.VulnerableCodeNotInExecutePath
+)
+
+// Item is a single finding within a triage session.
+type Item struct {
+	Match    models.Match
+	Severity vulnerability.Severity
+	Decision Decision
+}
+
+// Session holds the findings being triaged along with the active filters.
+type Session struct {
+	Items []Item
+	// MinSeverity hides findings below this severity (UnknownSeverity shows all findings)
+	MinSeverity vulnerability.Severity
+	// PackageFilter hides findings whose package name does not contain this value (case-insensitive)
+	PackageFilter string
+}
+
+// NewSession creates a triage session for the matches of the given document, ordered by descending severity.
+func NewSession(doc models.Document) *Session {
+	s := &Session{}
+	for _, m := range doc.Matches {
+		s.Items = append(s.Items, Item{
+			Match:    m,
+			Severity: vulnerability.ParseSeverity(m.Vulnerability.Severity),
+		})
+	}
+	sort.SliceStable(s.Items, func(i, j int) bool {
+		return s.Items[i].Severity > s.Items[j].Severity
+	})
+	return s
+}
+
+// Visible returns the indexes of the items that pass the active filters.
+func (s *Session) Visible() []int {
+	filter := strings.ToLower(s.PackageFilter)
+	var out []int
+	for i, item := range s.Items {
+		if item.Severity < s.MinSeverity {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(item.Match.Artifact.Name), filter) {
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// CycleSeverity raises the minimum severity filter to the next level, wrapping around to showing all findings.
+func (s *Session) CycleSeverity() {
+	if s.MinSeverity >= vulnerability.CriticalSeverity {
+		s.MinSeverity = vulnerability.UnknownSeverity
+		return
+	}
+	s.MinSeverity++
+}
+
+// Toggle sets the decision of the given item, or clears it if the item already has that decision.
+func (s *Session) Toggle(idx int, d Decision) {
+	if idx < 0 || idx >= len(s.Items) {
+		return
+	}
+	if s.Items[idx].Decision == d {
+		s.Items[idx].Decision = Undecided
+		return
+	}
+	s.Items[idx].Decision = d
+}
+
+// Decided returns the items with the given decision.
+func (s *Session) Decided(d Decision) []Item {
+	var out []Item
+	for _, item := range s.Items {
+		if item.Decision == d {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+type ignoreRulePackage struct {
+	Name    string `yaml:"name,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	Type    string `yaml:"type,omitempty"`
+}
+
+type ignoreRule struct {
+	Vulnerability string            `yaml:"vulnerability"`
+	Reason        string            `yaml:"reason,omitempty"`
+	Package       ignoreRulePackage `yaml:"package"`
+}
+
+// IgnoreConfig renders the ignored findings as grype configuration ignore rules.
+func (s *Session) IgnoreConfig() ([]byte, error) {
+	var rules []ignoreRule
+	for _, item := range s.Decided(Ignored) {
+		rules = append(rules, ignoreRule{
+			Vulnerability: item.Match.Vulnerability.ID,
+			Reason:        ignoreReason,
+			Package: ignoreRulePackage{
+				Name:    item.Match.Artifact.Name,
+				Version: item.Match.Artifact.Version,
+				Type:    string(item.Match.Artifact.Type),
+			},
+		})
+	}
+	return yaml.Marshal(map[string][]ignoreRule{"ignore": rules})
+}
+
+// VEX renders the findings marked as not affected as an OpenVEX document, using the package URL of each finding as
+// the product. Findings for packages without a package URL cannot be expressed and are skipped.
+func (s *Session) VEX(author string, timestamp time.Time) openvex.VEX {
+	doc := openvex.New()
+	doc.Author = author
+	doc.Timestamp = &timestamp
+
+	for _, item := range s.Decided(NotAffected) {
+		if item.Match.Artifact.PURL == "" {
+			continue
+		}
+		doc.Statements = append(doc.Statements, openvex.Statement{
+			Vulnerability: openvex.Vulnerability{Name: openvex.VulnerabilityID(item.Match.Vulnerability.ID)},
+			Products: []openvex.Product{
+				{Component: openvex.Component{ID: item.Match.Artifact.PURL}},
+			},
+			Status:        openvex.StatusNotAffected,
+			Justification: vexJustification,
+			Timestamp:     &timestamp,
+		})
+	}
+	return doc
+}
+
+type sessionItem struct {
+	Vulnerability string   `json:"vulnerability"`
+	Severity      string   `json:"severity"`
+	Package       string   `json:"package"`
+	Version       string   `json:"version"`
+	Type          string   `json:"type"`
+	PURL          string   `json:"purl,omitempty"`
+	Decision      Decision `json:"decision"`
+}
+
+type sessionFile struct {
+	Timestamp     string        `json:"timestamp"`
+	MinSeverity   string        `json:"minSeverity,omitempty"`
+	PackageFilter string        `json:"packageFilter,omitempty"`
+	Items         []sessionItem `json:"items"`
+}
+
+// Export writes the triage session (all findings with their decisions), the ignore rules and (if any findings were
+// marked as not affected) the OpenVEX document to files named after the given prefix, returning the written paths.
+func (s *Session) Export(prefix, author string, timestamp time.Time) ([]string, error) {
+	var written []string
+
+	session := sessionFile{
+		Timestamp:     timestamp.Format(time.RFC3339),
+		PackageFilter: s.PackageFilter,
+	}
+	if s.MinSeverity != vulnerability.UnknownSeverity {
+		session.MinSeverity = s.MinSeverity.String()
+	}
+	for _, item := range s.Items {
+		session.Items = append(session.Items, sessionItem{
+			Vulnerability: item.Match.Vulnerability.ID,
+			Severity:      item.Match.Vulnerability.Severity,
+			Package:       item.Match.Artifact.Name,
+			Version:       item.Match.Artifact.Version,
+			Type:          string(item.Match.Artifact.Type),
+			PURL:          item.Match.Artifact.PURL,
+			Decision:      item.Decision,
+		})
+	}
+	by, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode triage session: %w", err)
+	}
+	if err := writeFile(prefix+".json", by, &written); err != nil {
+		return written, err
+	}
+
+	ignores, err := s.IgnoreConfig()
+	if err != nil {
+		return written, fmt.Errorf("unable to encode ignore rules: %w", err)
+	}
+	if err := writeFile(prefix+"-ignore.yaml", ignores, &written); err != nil {
+		return written, err
+	}
+
+	if len(s.Decided(NotAffected)) > 0 {
+		doc := s.VEX(author, timestamp)
+		var sb strings.Builder
+		if err := doc.ToJSON(&sb); err != nil {
+			return written, fmt.Errorf("unable to encode VEX document: %w", err)
+		}
+		if err := writeFile(prefix+"-vex.json", []byte(sb.String()), &written); err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+func writeFile(path string, contents []byte, written *[]string) error {
+	if err := os.WriteFile(path, contents, 0o600); err != nil {
+		return fmt.Errorf("unable to write %q: %w", path, err)
+	}
+	*written = append(*written, path)
+	return nil
+}
@@ -0,0 +1,189 @@
+package triage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func testDocument() models.Document {
+	newMatch := func(id, severity, name, purl string) models.Match {
+		return models.Match{
+			Vulnerability: models.Vulnerability{
+				VulnerabilityMetadata: models.VulnerabilityMetadata{ID: id, Severity: severity},
+			},
+			Artifact: models.Package{Name: name, Version: "1.0.0", Type: syftPkg.NpmPkg, PURL: purl},
+		}
+	}
+	return models.Document{
+		Matches: []models.Match{
+			newMatch("CVE-2024-0001", "Low", "left-pad", "pkg:npm/left-pad@1.0.0"),
+			newMatch("CVE-2024-0002", "Critical", "lodash", "pkg:npm/lodash@1.0.0"),
+			newMatch("CVE-2024-0003", "Medium", "lodash-es", ""),
+		},
+	}
+}
+
+func TestNewSession_ordersBySeverity(t *testing.T) {
+	s := NewSession(testDocument())
+	var ids []string
+	for _, item := range s.Items {
+		ids = append(ids, item.Match.Vulnerability.ID)
+	}
+	assert.Equal(t, []string{"CVE-2024-0002", "CVE-2024-0003", "CVE-2024-0001"}, ids)
+}
+
+func TestSession_Visible(t *testing.T) {
+	tests := []struct {
+		name          string
+		minSeverity   vulnerability.Severity
+		packageFilter string
+		want          []int
+	}{
+		{
+			name: "no filters",
+			want: []int{0, 1, 2},
+		},
+		{
+			name:        "severity filter",
+			minSeverity: vulnerability.MediumSeverity,
+			want:        []int{0, 1},
+		},
+		{
+			name:          "package filter is case-insensitive",
+			packageFilter: "LODASH",
+			want:          []int{0, 1},
+		},
+		{
+			name:          "combined filters",
+			minSeverity:   vulnerability.HighSeverity,
+			packageFilter: "lodash",
+			want:          []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSession(testDocument())
+			s.MinSeverity = tt.minSeverity
+			s.PackageFilter = tt.packageFilter
+			assert.Equal(t, tt.want, s.Visible())
+		})
+	}
+}
+
+func TestSession_CycleSeverity(t *testing.T) {
+	s := &Session{}
+	var seen []vulnerability.Severity
+	for i := 0; i < 7; i++ {
+		s.CycleSeverity()
+		seen = append(seen, s.MinSeverity)
+	}
+	assert.Equal(t, []vulnerability.Severity{
+		vulnerability.NegligibleSeverity,
+		vulnerability.LowSeverity,
+		vulnerability.MediumSeverity,
+		vulnerability.HighSeverity,
+		vulnerability.CriticalSeverity,
+		vulnerability.UnknownSeverity,
+		vulnerability.NegligibleSeverity,
+	}, seen)
+}
+
+func TestSession_Toggle(t *testing.T) {
+	s := NewSession(testDocument())
+
+	s.Toggle(0, Ignored)
+	assert.Equal(t, Ignored, s.Items[0].Decision)
+
+	s.Toggle(0, NotAffected)
+	assert.Equal(t, NotAffected, s.Items[0].Decision)
+
+	s.Toggle(0, NotAffected)
+	assert.Equal(t, Undecided, s.Items[0].Decision)
+
+	// out of range indexes are ignored
+	s.Toggle(-1, Ignored)
+	s.Toggle(len(s.Items), Ignored)
+	assert.Empty(t, s.Decided(Ignored))
+}
+
+func TestSession_IgnoreConfig(t *testing.T) {
+	s := NewSession(testDocument())
+	s.Toggle(0, Ignored)
+
+	got, err := s.IgnoreConfig()
+	require.NoError(t, err)
+	assert.Equal(t, `ignore:
+    - vulnerability: CVE-2024-0002
+      reason: triaged with grype explain
+      package:
+        name: lodash
+        version: 1.0.0
+        type: npm
+`, string(got))
+}
+
+func TestSession_VEX(t *testing.T) {
+	s := NewSession(testDocument())
+	s.Toggle(0, NotAffected)
+	// has no package URL, so cannot be expressed as a VEX statement
+	s.Toggle(1, NotAffected)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc := s.VEX("grype", ts)
+
+	assert.Equal(t, "grype", doc.Author)
+	require.Len(t, doc.Statements, 1)
+	st := doc.Statements[0]
+	assert.Equal(t, "CVE-2024-0002", string(st.Vulnerability.Name))
+	require.Len(t, st.Products, 1)
+	assert.Equal(t, "pkg:npm/lodash@1.0.0", st.Products[0].ID)
+	assert.Equal(t, vexJustification, st.Justification)
+}
+
+func TestSession_Export(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("without not affected findings", func(t *testing.T) {
+		prefix := filepath.Join(t.TempDir(), "triage")
+		s := NewSession(testDocument())
+		s.Toggle(0, Ignored)
+
+		written, err := s.Export(prefix, "grype", ts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{prefix + ".json", prefix + "-ignore.yaml"}, written)
+		assert.NoFileExists(t, prefix+"-vex.json")
+
+		contents, err := os.ReadFile(prefix + ".json")
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), `"decision": "ignore"`)
+	})
+
+	t.Run("with not affected findings", func(t *testing.T) {
+		prefix := filepath.Join(t.TempDir(), "triage")
+		s := NewSession(testDocument())
+		s.Toggle(0, NotAffected)
+
+		written, err := s.Export(prefix, "grype", ts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{prefix + ".json", prefix + "-ignore.yaml", prefix + "-vex.json"}, written)
+
+		contents, err := os.ReadFile(prefix + "-vex.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(contents), "pkg:npm/lodash@1.0.0")
+	})
+
+	t.Run("unwritable destination", func(t *testing.T) {
+		s := NewSession(testDocument())
+		_, err := s.Export(filepath.Join(t.TempDir(), "missing", "triage"), "grype", ts)
+		require.Error(t, err)
+	})
+}
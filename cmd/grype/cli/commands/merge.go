@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/log"
+)
+
+type mergeOptions struct {
+	File      string `yaml:"file" json:"file" mapstructure:"file"`
+	Timestamp bool   `yaml:"timestamp" json:"timestamp" mapstructure:"timestamp"`
+}
+
+var _ clio.FlagAdder = (*mergeOptions)(nil)
+
+func (o *mergeOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.File, "file", "", "file to write the merged report to (default is STDOUT)")
+	flags.BoolVarP(&o.Timestamp, "timestamp", "", "include a timestamp in the merged report descriptor")
+}
+
+func Merge(app clio.Application) *cobra.Command {
+	id := app.ID()
+	opts := &mergeOptions{
+		Timestamp: true,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "merge REPORT [REPORT...]",
+		Short: "Merge multiple grype JSON reports into a single aggregated report",
+		Long: `Merge multiple grype JSON reports (e.g. from 'grype -o json') into a single report.
+Each input report is preserved as a target; matches found in several targets are reported once,
+listing every target they were found in, and statistics are de-duplicated across targets.`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runMerge(id, *opts, args)
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts *mergeOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+func runMerge(id clio.Identification, opts mergeOptions, paths []string) error {
+	documents := make([]models.Document, 0, len(paths))
+	for _, path := range paths {
+		doc, err := readReport(path)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, doc)
+	}
+
+	merged, err := models.NewMergedDocument(id, paths, documents, opts.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if opts.File != "" {
+		f, err := os.Create(filepath.Clean(opts.File))
+		if err != nil {
+			return fmt.Errorf("unable to create merged report file: %w", err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.Warnf("unable to close merged report file: %+v", err)
+			}
+		}()
+		out = f
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	return enc.Encode(merged)
+}
+
+// readReport reads the grype JSON report at the given path (or stdin for "-").
+func readReport(path string) (models.Document, error) {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return models.Document{}, fmt.Errorf("unable to open report %q: %w", path, err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	var doc models.Document
+	if err := json.NewDecoder(reader).Decode(&doc); err != nil {
+		return models.Document{}, fmt.Errorf("unable to parse report %q (expected grype JSON): %w", path, err)
+	}
+	return doc, nil
+}
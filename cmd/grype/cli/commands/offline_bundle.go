@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/offlinebundle"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/grype/db/v6/installation"
+	"github.com/anchore/grype/internal/log"
+)
+
+// offlineBundleConfig is the configuration installed from an offline bundle: the bundled DB must be used as-is since
+// the target host is not expected to be able to reach the update services, however old the DB becomes.
+const offlineBundleConfig = `# installed from a grype offline bundle
+check-for-app-update: false
+db:
+  auto-update: false
+  require-update-check: false
+  validate-age: false
+`
+
+type offlineBundleOptions struct {
+	File                    string `yaml:"file" json:"file" mapstructure:"file"`
+	options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+}
+
+var _ clio.FlagAdder = (*offlineBundleOptions)(nil)
+
+func (o *offlineBundleOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.File, "file", "", "file to write the offline bundle to")
+}
+
+func OfflineBundle(app clio.Application) *cobra.Command {
+	opts := &offlineBundleOptions{
+		File:            "grype-offline-bundle.tar.gz",
+		DatabaseCommand: *options.DefaultDatabaseCommand(app.ID()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "offline-bundle",
+		Short: "Package grype and the current vulnerability database for installation on hosts without network access",
+		Long: `Package the grype binary, a configuration that disables update checks, and the currently installed vulnerability
+database into a tarball, along with SHA256 checksums and an install.sh entrypoint that verifies the checksums and installs
+everything (e.g. when baking grype into ephemeral CI runner images). Run 'grype db update' first to bundle the latest database.`,
+		Args:    cobra.ExactArgs(0),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runOfflineBundle(*opts)
+		},
+	}
+
+	type configWrapper struct {
+		Hidden                   *offlineBundleOptions `json:"-" yaml:"-" mapstructure:"-"`
+		*options.DatabaseCommand `yaml:",inline" mapstructure:",squash"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{Hidden: opts, DatabaseCommand: &opts.DatabaseCommand})
+}
+
+func runOfflineBundle(opts offlineBundleOptions) error {
+	client, err := distribution.NewClient(opts.ToClientConfig())
+	if err != nil {
+		return fmt.Errorf("unable to create distribution client: %w", err)
+	}
+	curatorCfg := opts.ToCuratorConfig()
+	c, err := installation.NewCurator(curatorCfg, client)
+	if err != nil {
+		return fmt.Errorf("unable to create curator: %w", err)
+	}
+
+	status := c.Status()
+	if status.Error != nil {
+		return fmt.Errorf("unable to bundle the vulnerability database (run 'grype db update' first): %w", status.Error)
+	}
+
+	binaryPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to determine grype binary path: %w", err)
+	}
+
+	f, err := os.Create(filepath.Clean(opts.File))
+	if err != nil {
+		return fmt.Errorf("unable to create offline bundle file: %w", err)
+	}
+	defer log.CloseAndLogError(f, opts.File)
+
+	err = offlinebundle.Write(f, offlinebundle.Config{
+		BinaryPath: binaryPath,
+		DBFilePath: curatorCfg.DBFilePath(),
+		Config:     []byte(offlineBundleConfig),
+		Timestamp:  time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write offline bundle: %w", err)
+	}
+
+	return stderrPrintLnf("Offline bundle written to %s (vulnerability database built %s)", opts.File, status.Built.UTC().Format(time.RFC3339))
+}
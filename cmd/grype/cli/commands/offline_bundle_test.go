@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+)
+
+func Test_offlineBundleConfig(t *testing.T) {
+	cfg := struct {
+		CheckForAppUpdate bool             `yaml:"check-for-app-update"`
+		DB                options.Database `yaml:"db"`
+	}{
+		CheckForAppUpdate: true,
+		DB:                options.DefaultDatabase(clio.Identification{Name: "grype"}),
+	}
+	require.True(t, cfg.DB.ValidateAge, "the age of the DB is validated by default")
+
+	require.NoError(t, yaml.Unmarshal([]byte(offlineBundleConfig), &cfg))
+
+	// the bundled DB is used as-is (the host cannot update it), however old it becomes
+	assert.False(t, cfg.CheckForAppUpdate)
+	assert.False(t, cfg.DB.AutoUpdate)
+	assert.False(t, cfg.DB.RequireUpdateCheck)
+	assert.False(t, cfg.DB.ValidateAge)
+}
@@ -4,6 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,14 +16,19 @@ import (
 	"github.com/wagoodman/go-partybus"
 
 	"github.com/anchore/clio"
+	baselinefile "github.com/anchore/grype/cmd/grype/cli/commands/internal/baseline"
 	"github.com/anchore/grype/cmd/grype/cli/options"
 	"github.com/anchore/grype/grype"
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/installation"
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/grype/event"
 	"github.com/anchore/grype/grype/event/parsers"
 	"github.com/anchore/grype/grype/grypeerr"
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/grype/matcher/apk"
+	"github.com/anchore/grype/grype/matcher/bitnami"
 	"github.com/anchore/grype/grype/matcher/dotnet"
 	"github.com/anchore/grype/grype/matcher/dpkg"
 	"github.com/anchore/grype/grype/matcher/golang"
@@ -29,21 +39,34 @@ import (
 	"github.com/anchore/grype/grype/matcher/rpm"
 	"github.com/anchore/grype/grype/matcher/ruby"
 	"github.com/anchore/grype/grype/matcher/stock"
+	"github.com/anchore/grype/grype/matcher/terraform"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/presenter/push"
+	"github.com/anchore/grype/grype/secret"
+	"github.com/anchore/grype/grype/sla"
 	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/grype/vex"
+	"github.com/anchore/grype/grype/vex/attestation"
+	"github.com/anchore/grype/grype/vex/openvex"
 	vexStatus "github.com/anchore/grype/grype/vex/status"
 	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/store/recording"
 	"github.com/anchore/grype/internal"
 	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/dsse"
 	"github.com/anchore/grype/internal/format"
 	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/grype/internal/proxy"
+	"github.com/anchore/grype/internal/retry"
 	"github.com/anchore/grype/internal/stringutil"
+	"github.com/anchore/grype/internal/telemetry"
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/cataloging"
+	"github.com/anchore/syft/syft/cataloging/pkgcataloging"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
 )
 
 func Root(app clio.Application) *cobra.Command {
@@ -63,6 +86,7 @@ You can also explicitly specify the scheme to use:
     {{.appName}} docker:yourrepo/yourimage:tag          explicitly use the Docker daemon
     {{.appName}} docker-archive:path/to/yourimage.tar   use a tarball from disk for archives created from "docker save"
     {{.appName}} oci-archive:path/to/yourimage.tar      use a tarball from disk for OCI archives (from Podman or otherwise)
+    {{.appName}} docker-archive:-                       read a tarball created from "docker save" from stdin, spooled to a temp file (same for oci-archive:-)
     {{.appName}} oci-dir:path/to/yourimage              read directly from a path on disk for OCI layout directories (from Skopeo or otherwise)
     {{.appName}} singularity:path/to/yourimage.sif      read directly from a Singularity Image Format (SIF) container on disk
     {{.appName}} dir:path/to/yourproject                read directly from a path on disk (any directory)
@@ -74,6 +98,8 @@ You can also explicitly specify the scheme to use:
     {{.appName}} cpes:path/to/cpes/file                 read a newline separated file of package CPEs from a path on disk
     {{.appName}} CPE                                    read a single CPE directly (e.g. cpe:2.3:a:openssl:openssl:3.0.14:*:*:*:*:*)
     {{.appName}} zarf:path/to/package.tar.zst           scan all SBOMs within a Zarf package archive
+    {{.appName}} lambda:path/to/function.zip            unpack and scan a function bundle (zip), followed by any comma separated
+                                                   layer archives (e.g. lambda:function.zip,layer.zip; same as function:)
 
 You can also pipe in Syft JSON directly:
 	syft yourimage:tag -o json | {{.appName}}
@@ -81,10 +107,13 @@ You can also pipe in Syft JSON directly:
 `, map[string]any{
 			"appName": app.ID().Name,
 		}),
-		Args:          validateRootArgs,
+		Args:          cobra.MaximumNArgs(1),
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRootArgs(cmd, opts, args); err != nil {
+				return err
+			}
 			userInput := ""
 			if len(args) > 0 {
 				userInput = args[0]
@@ -119,21 +148,86 @@ var ignoreLinuxKernelHeaders = []match.IgnoreRule{
 
 //nolint:funlen
 func runGrype(ctx context.Context, app clio.Application, opts *options.Grype, userInput string) (errs error) {
-	writer, err := format.MakeScanResultWriter(opts.Outputs, opts.File, format.PresentationConfig{
-		TemplateFilePath: opts.OutputTemplateFile,
-		ShowSuppressed:   opts.ShowSuppressed,
-		Pretty:           opts.Pretty,
-	})
+	scanStartTime := time.Now()
+
+	ignoreRules, err := setupScan(opts)
 	if err != nil {
 		return err
 	}
 
+	if opts.Targets.File != "" {
+		return runTargets(ctx, app, opts, ignoreRules)
+	}
+
+	outputs := opts.Outputs
+	if opts.StreamTable {
+		// the table written to stdout is streamed as matches are found, the other outputs are written once matching completes
+		outputs, _ = opts.StreamedOutputs()
+	}
+
+	var writer format.ScanResultWriter
+	if len(outputs) > 0 || !opts.StreamTable {
+		writer, err = makeScanResultWriter(opts, outputs, opts.File)
+		if err != nil {
+			return err
+		}
+	}
+
 	var vp vulnerability.Provider
 	var status *vulnerability.ProviderStatus
 	var packages []pkg.Package
 	var s *sbom.SBOM
 	var pkgContext pkg.Context
 
+	curatorCfg, finishTrace, err := curatorConfig(opts)
+	if err != nil {
+		return err
+	}
+	defer finishTrace()
+
+	err = parallel(
+		func() error {
+			checkForAppUpdate(app.ID(), opts)
+			return nil
+		},
+		func() (err error) {
+			vp, status, err = loadVulnerabilityDB(opts, curatorCfg)
+			return err
+		},
+		func() (err error) {
+			packages, pkgContext, s, err = catalogTarget(ctx, opts, userInput)
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	defer closeVulnerabilityDB(opts, vp, status)
+
+	_, err = scanPackages(ctx, app, opts, scanInput{
+		vp:          vp,
+		status:      status,
+		ignoreRules: ignoreRules,
+		packages:    packages,
+		context:     pkgContext,
+		sbom:        s,
+		startTime:   scanStartTime,
+	}, writer)
+	return err
+}
+
+// setupScan adds the rules of the ignore files and the ignore rules implied by the options (fix states, etc.) to the
+// ignore rules. The configured ignore rules (of the configuration and
+// ignore files) are returned, as opposed to the rules implied by the options.
+func setupScan(opts *options.Grype) ([]match.IgnoreRule, error) {
+	ignoreFileRules, err := readIgnoreFiles(opts.IgnoreFiles)
+	if err != nil {
+		return nil, err
+	}
+	opts.Ignore = append(opts.Ignore, ignoreFileRules...)
+	configured := slices.Clone(opts.Ignore)
+
 	if opts.OnlyFixed {
 		opts.Ignore = append(opts.Ignore, ignoreNonFixedMatches...)
 	}
@@ -142,6 +236,16 @@ func runGrype(ctx context.Context, app clio.Application, opts *options.Grype, us
 		opts.Ignore = append(opts.Ignore, ignoreFixedMatches...)
 	}
 
+	if opts.MinFixAge != "" {
+		if !opts.OnlyFixed {
+			opts.Ignore = append(opts.Ignore, ignoreNonFixedMatches...)
+		}
+		opts.Ignore = append(opts.Ignore, match.IgnoreRule{
+			FixAvailableWithin: opts.MinFixAge,
+			Reason:             fmt.Sprintf("fix has been available for less than %s", opts.MinFixAge),
+		})
+	}
+
 	if !opts.MatchUpstreamKernelHeaders {
 		opts.Ignore = append(opts.Ignore, ignoreLinuxKernelHeaders...)
 	}
@@ -151,66 +255,205 @@ func runGrype(ctx context.Context, app clio.Application, opts *options.Grype, us
 		case vulnerability.FixStateUnknown, vulnerability.FixStateFixed, vulnerability.FixStateNotFixed, vulnerability.FixStateWontFix:
 			opts.Ignore = append(opts.Ignore, match.IgnoreRule{FixState: ignoreState})
 		default:
-			return fmt.Errorf("unknown fix state %s was supplied for --ignore-states", ignoreState)
+			return nil, fmt.Errorf("unknown fix state %s was supplied for --ignore-states", ignoreState)
 		}
 	}
+	return configured, nil
+}
 
-	err = parallel(
-		func() error {
-			checkForAppUpdate(app.ID(), opts)
-			return nil
-		},
-		func() (err error) {
-			startTime := time.Now()
-
-			defer func() {
-				validStr := "valid"
-				if err != nil {
-					validStr = "invalid"
-				}
-				log.WithFields("time", time.Since(startTime), "status", validStr).Info("loaded DB")
-				if status != nil {
-					log.WithFields("schema", status.SchemaVersion).Debug("├──")
-					log.WithFields("built", status.Built.UTC().Format(time.RFC3339)).Debug("├──")
-					log.WithFields("from", status.From).Debug("├──")
-					log.WithFields("path", status.Path).Debug("└──")
-				}
-			}()
-			log.Debug("loading DB")
-			vp, status, err = grype.LoadVulnerabilityDB(opts.ToClientConfig(), opts.ToCuratorConfig(), opts.DB.AutoUpdate)
-
-			return validateDBLoad(err, status)
-		},
-		func() (err error) {
-			startTime := time.Now()
-
-			defer func() {
-				log.WithFields("time", time.Since(startTime), "packages", len(packages)).Info("gathered packages")
-			}()
-
-			log.Debugf("gathering packages")
-			// packages are grype.Package, not syft.Package
-			// the SBOM is returned for downstream formatting concerns
-			// grype uses the SBOM in combination with syft formatters to produce cycloneDX
-			// with vulnerability information appended
-			packages, pkgContext, s, err = pkg.Provide(userInput, getProviderConfig(opts))
-			if err != nil {
-				return fmt.Errorf("failed to catalog: %w", err)
-			}
+// makeScanResultWriter returns the writer of the reports in the given output formats, signed when configured.
+func makeScanResultWriter(opts *options.Grype, outputs []string, file string) (format.ScanResultWriter, error) {
+	var signer *dsse.Signer
+	if opts.SignResults != "" {
+		var err error
+		if signer, err = dsse.LoadSigner(opts.SignResults); err != nil {
+			return nil, fmt.Errorf("unable to load results signing key: %w", err)
+		}
+	}
 
-			return nil
-		},
-	)
+	return format.MakeScanResultWriter(outputs, file, format.PresentationConfig{
+		TemplateFilePath: opts.OutputTemplateFile,
+		ShowSuppressed:   opts.ShowSuppressed,
+		Pretty:           opts.Pretty,
+		Signer:           signer,
+	})
+}
+
+// curatorConfig returns the DB curator configuration, tracing DB queries when configured, along with a function
+// finishing the trace.
+func curatorConfig(opts *options.Grype) (installation.Config, func(), error) {
+	curatorCfg := opts.ToCuratorConfig()
+	if opts.DB.Trace == "" {
+		return curatorCfg, func() {}, nil
+	}
+	trace, finishTrace, err := startDBTrace(opts.DB.Trace, opts.DB.TraceTop)
 	if err != nil {
+		return curatorCfg, nil, err
+	}
+	curatorCfg.Tracer = trace
+	return curatorCfg, finishTrace, nil
+}
+
+// loadVulnerabilityDB loads the vulnerability DB (or the DB recording to replay), recording the interactions with it
+// when configured.
+func loadVulnerabilityDB(opts *options.Grype, curatorCfg installation.Config) (vp vulnerability.Provider, status *vulnerability.ProviderStatus, err error) {
+	startTime := time.Now()
+
+	defer func() {
+		validStr := "valid"
+		if err != nil {
+			validStr = "invalid"
+		}
+		log.WithFields("time", time.Since(startTime), "status", validStr).Info("loaded DB")
+		if status != nil {
+			log.WithFields("schema", status.SchemaVersion).Debug("├──")
+			log.WithFields("built", status.Built.UTC().Format(time.RFC3339)).Debug("├──")
+			log.WithFields("from", status.From).Debug("├──")
+			log.WithFields("path", status.Path).Debug("└──")
+		}
+	}()
+	if opts.DB.ReplayInteractions != "" {
+		log.WithFields("path", opts.DB.ReplayInteractions).Debug("loading DB recording")
+		var s vulnerability.ProviderStatus
+		vp, s, err = recording.Open(opts.DB.ReplayInteractions)
+		status = &s
+		return vp, status, err
+	}
+
+	log.Debug("loading DB")
+	vp, status, err = grype.LoadVulnerabilityDB(opts.ToClientConfig(), curatorCfg, opts.DB.AutoUpdate)
+	if err == nil && opts.DB.RecordInteractions != "" {
+		vp = recording.NewRecorder(vp, *status)
+	}
+
+	return vp, status, validateDBLoad(err, status)
+}
+
+// closeVulnerabilityDB writes the DB recording (when recording) and closes the DB.
+func closeVulnerabilityDB(opts *options.Grype, vp vulnerability.Provider, status *vulnerability.ProviderStatus) {
+	if recorder, ok := vp.(*recording.Recorder); ok {
+		if err := recorder.WriteFile(opts.DB.RecordInteractions); err != nil {
+			log.WithFields("error", err).Warn("unable to write DB recording")
+		} else {
+			log.WithFields("path", opts.DB.RecordInteractions, "searches", recorder.Count()).Info("recorded DB interactions")
+		}
+	}
+	log.CloseAndLogError(vp, status.Path)
+}
+
+// catalogTarget gathers the packages of the scan target.
+func catalogTarget(ctx context.Context, opts *options.Grype, userInput string) (packages []pkg.Package, pkgContext pkg.Context, s *sbom.SBOM, err error) {
+	startTime := time.Now()
+
+	defer func() {
+		log.WithFields("time", time.Since(startTime), "packages", len(packages)).Info("gathered packages")
+	}()
+
+	providerConfig := getProviderConfig(opts, userInput)
+	if opts.DistroAliases != "" {
+		if providerConfig.Distro.Aliases, err = distro.ReadAliasFile(opts.DistroAliases); err != nil {
+			return nil, pkgContext, nil, err
+		}
+	}
+
+	log.Debugf("gathering packages")
+	// packages are grype.Package, not syft.Package
+	// the SBOM is returned for downstream formatting concerns
+	// grype uses the SBOM in combination with syft formatters to produce cycloneDX
+	// with vulnerability information appended
+	// the registry client only retries some requests (and not per the network options), so pulls failing with
+	// a transient network error are retried as a whole
+	err = retry.Do(ctx, opts.Network.ToConfig(), "catalog "+userInput, func() (err error) {
+		packages, pkgContext, s, err = pkg.Provide(userInput, providerConfig)
 		return err
+	})
+	if err != nil {
+		return nil, pkgContext, nil, fmt.Errorf("failed to catalog: %w", err)
+	}
+
+	return packages, pkgContext, s, nil
+}
+
+// segmentProjects annotates the packages of a directory scan with the project they belong to (see
+// pkg.ProjectAnnotation), returning the detected project roots along with the packages to match: only the packages of
+// the selected project with --only-project.
+func segmentProjects(opts *options.Grype, packages []pkg.Package, pkgContext pkg.Context) ([]string, []pkg.Package, error) {
+	isDirectory := false
+	if pkgContext.Source != nil {
+		_, isDirectory = pkgContext.Source.Metadata.(source.DirectoryMetadata)
+	}
+	if !isDirectory {
+		if opts.OnlyProject != "" {
+			return nil, nil, fmt.Errorf("--only-project may only be used when scanning a directory")
+		}
+		return nil, packages, nil
 	}
 
-	defer log.CloseAndLogError(vp, status.Path)
+	roots := pkg.ProjectRoots(packages)
+	pkg.AnnotateProjects(packages, roots)
+	if len(roots) > 0 {
+		log.WithFields("count", len(roots)).Debug("detected projects")
+	}
+	if opts.OnlyProject == "" {
+		return roots, packages, nil
+	}
+
+	project := pkg.NormalizeProjectPath(opts.OnlyProject)
+	if !slices.Contains(roots, project) {
+		return nil, nil, fmt.Errorf("bad --only-project value %q: must be one of the detected projects [%s]", opts.OnlyProject, strings.Join(roots, ", "))
+	}
+	packages = slices.DeleteFunc(packages, func(p pkg.Package) bool {
+		return !slices.Contains(pkg.Projects(p), project)
+	})
+	log.WithFields("project", project, "packages", len(packages)).Info("only matching the packages of the selected project")
+	return roots, packages, nil
+}
+
+// scanInput is a cataloged scan target along with the DB its packages are matched against.
+type scanInput struct {
+	vp     vulnerability.Provider
+	status *vulnerability.ProviderStatus
+	// ignoreRules are the configured ignore rules, which are reported when unused
+	ignoreRules []match.IgnoreRule
+	packages    []pkg.Package
+	context     pkg.Context
+	sbom        *sbom.SBOM
+	startTime   time.Time
+}
+
+// scanPackages matches the packages of the scan target against the DB, writing (and pushing) the report. The report
+// document is returned when created, along with any error (including failing the configured policies).
+//
+//nolint:funlen
+func scanPackages(ctx context.Context, app clio.Application, opts *options.Grype, in scanInput, writer format.ScanResultWriter) (_ *models.Document, errs error) {
+	vp, status, packages, pkgContext, s := in.vp, in.status, in.packages, in.context, in.sbom
 
 	warnWhenDistroHintNeeded(packages, &pkgContext)
 
+	if opts.DeepJava {
+		var err error
+		packages, err = addShadedJavaPackages(packages, pkgContext, vp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	projectRoots, packages, err := segmentProjects(opts, packages, pkgContext)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Vex.Autodiscover {
+		docs, cleanup, err := discoverBaseImageVEX(ctx, opts, pkgContext)
+		defer cleanup()
+		if err != nil {
+			return nil, fmt.Errorf("discovering base image VEX attestations: %w", err)
+		}
+		opts.VexDocuments = append(opts.VexDocuments, docs...)
+	}
+
 	if err = applyVexRules(opts); err != nil {
-		return fmt.Errorf("applying vex rules: %w", err)
+		return nil, fmt.Errorf("applying vex rules: %w", err)
 	}
 
 	startTime := time.Now()
@@ -220,32 +463,116 @@ func runGrype(ctx context.Context, app clio.Application, opts *options.Grype, us
 		IgnoreRules: opts.Ignore,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create VEX processor: %w", err)
+		return nil, fmt.Errorf("failed to create VEX processor: %w", err)
+	}
+
+	slaPolicy, err := opts.FailOnSLA.ToPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	unboundedPolicy, err := match.ParseUnboundedPolicy(opts.UnboundedMatches)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliationPolicy, err := match.ParseReconciliationPolicy(opts.Match.Reconciliation)
+	if err != nil {
+		return nil, err
+	}
+
+	timeBudget, err := opts.TimeBudget.ToConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	cvssModifiers, err := opts.CVSSEnvironment.ToModifiers()
+	if err != nil {
+		return nil, err
+	}
+
+	severityPolicy, err := opts.SeverityPolicy.ToPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline *match.Baseline
+	if opts.Baseline != "" {
+		baseline, err = baselinefile.Load(opts.Baseline)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields("path", opts.Baseline, "findings", baseline.Len()).Debug("loaded baseline")
 	}
 
 	vulnMatcher := grype.VulnerabilityMatcher{
-		VulnerabilityProvider: vp,
-		IgnoreRules:           opts.Ignore,
-		NormalizeByCVE:        opts.ByCVE,
-		FailSeverity:          opts.FailOnSeverity(),
-		Matchers:              getMatchers(opts),
-		VexProcessor:          vexProcessor,
+		VulnerabilityProvider:      vp,
+		IgnoreRules:                opts.Ignore,
+		NormalizeByCVE:             opts.ByCVE,
+		FailSeverity:               opts.FailOnSeverity(),
+		FailSLA:                    slaPolicy,
+		Matchers:                   getMatchers(opts),
+		VexProcessor:               vexProcessor,
+		Unbounded:                  unboundedPolicy,
+		TimeBudget:                 timeBudget,
+		UnknownVersions:            opts.UnknownVersions.ToConfig(),
+		MinConfidence:              opts.MinConfidence,
+		CVSSModifiers:              cvssModifiers,
+		SeverityPolicy:             severityPolicy,
+		Baseline:                   baseline,
+		OnlyDirectDeps:             opts.OnlyDirectDeps,
+		ExcludeDevDependencies:     opts.ExcludeDevDependencies,
+		IncludeWithdrawn:           opts.IncludeWithdrawn,
+		SeparateIntermediateLayers: opts.SeparateIntermediateLayers,
+		DetectMaliciousPackages:    opts.MaliciousPackages.Enabled,
+		UpstreamMatching:           opts.Match.Upstreams.ToConfig(),
+		Reconciliation:             reconciliationPolicy,
+		ProviderPriority:           opts.DB.ProviderPriority.ToProviderPriority(),
 		Alerts: grype.AlertsConfig{
 			EnableEOLDistroWarnings: opts.Alerts.EnableEOLDistroWarnings,
 		},
 	}
 
+	var stream *tableStream
+	if opts.StreamTable {
+		stream = &tableStream{
+			vp:             vp,
+			assetClass:     opts.CVSSEnvironment.AssetClass,
+			cvssModifiers:  cvssModifiers,
+			severityPolicy: severityPolicy,
+			unmanaged:      opts.UnmanagedBinaries.Enabled,
+		}
+		vulnMatcher.StreamMatches = stream.publish
+	}
+
 	remainingMatches, ignoredMatches, err := vulnMatcher.FindMatchesContext(ctx, packages, pkgContext)
 	if err != nil {
-		if !errors.Is(err, grypeerr.ErrAboveSeverityThreshold) {
-			return err
+		if !errors.Is(err, grypeerr.ErrAboveSeverityThreshold) && !errors.Is(err, grypeerr.ErrSLAGracePeriodExceeded) {
+			return nil, err
 		}
 		errs = appendErrors(errs, err)
 	}
+	warnSLABreaches(vulnMatcher.SLABreaches())
+	warnUnboundedMatches(vulnMatcher.UnboundedMatches())
+	warnBaselineMatches(vulnMatcher.BaselineMatches())
+	warnStaleBaseline(baseline, remainingMatches, ignoredMatches)
+
+	unusedIgnoreRules := match.UnusedIgnoreRules(in.ignoreRules, ignoredMatches)
+	warnUnusedIgnoreRules(unusedIgnoreRules)
+	if opts.FailOnUnusedIgnores && len(unusedIgnoreRules) > 0 {
+		errs = appendErrors(errs, grypeerr.ErrUnusedIgnoreRules)
+	}
 
 	log.WithFields("time", time.Since(startTime)).Info("found vulnerability matches")
 	startTime = time.Now()
 
+	warnMaliciousPackages(vulnMatcher.MaliciousMatches())
+
+	var unmanagedBinaryMatches []match.Match
+	if opts.UnmanagedBinaries.Enabled {
+		*remainingMatches, unmanagedBinaryMatches = match.SplitUnmanagedBinaries(*remainingMatches)
+	}
+
 	// clear out the registry auth information to avoid including possibly sensitive information in the report
 	opts.Registry.Auth = nil
 
@@ -260,21 +587,133 @@ func runGrype(ctx context.Context, app clio.Application, opts *options.Grype, us
 
 	model, err := models.NewDocument(app.ID(), packages, pkgContext, *remainingMatches, ignoredMatches, vp, opts, dbInfo(status, vp), models.SortStrategy(opts.SortBy.Criteria), opts.Timestamp, distroAlertData)
 	if err != nil {
-		return fmt.Errorf("failed to create document: %w", err)
+		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
+	warnNotices(model.Notices)
+	model.UnusedIgnoreRules = models.NewUnusedIgnoreRules(unusedIgnoreRules)
 
-	if err = writer.Write(models.PresenterConfig{
+	models.AdjustCvssScores(&model, opts.CVSSEnvironment.AssetClass, cvssModifiers, models.SortStrategy(opts.SortBy.Criteria))
+	models.ApplySeverityPolicy(&model, severityPolicy, models.SortStrategy(opts.SortBy.Criteria))
+
+	if opts.ExplainSeverity {
+		if err := models.AddSeverityDerivations(model.Matches, vp); err != nil {
+			return nil, fmt.Errorf("failed to explain match severities: %w", err)
+		}
+	}
+
+	if opts.UnmanagedBinaries.Enabled {
+		model.UnmanagedBinaries, err = models.NewUnmanagedBinaries(packages, unmanagedBinaryMatches, vp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create unmanaged binary findings: %w", err)
+		}
+		warnUnmanagedBinaries(model.UnmanagedBinaries)
+	}
+
+	model.Projects = models.NewProjects(projectRoots, packages, model)
+
+	for _, skipped := range vulnMatcher.SkippedPackages() {
+		model.Skipped = append(model.Skipped, models.NewSkippedPackage(skipped.Package, skipped.Reason))
+	}
+	warnSkippedPackages(vulnMatcher.SkippedPackages())
+
+	if opts.ShowResolved {
+		model.ResolvedFindings, err = resolvedFindings(packages, vp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find resolved vulnerabilities: %w", err)
+		}
+	}
+
+	licenseViolations := opts.LicensePolicy.ToPolicy().Evaluate(packages)
+	model.LicenseViolations = models.NewLicenseViolations(licenseViolations)
+	if len(licenseViolations) > 0 {
+		bus.Notify(fmt.Sprintf("%d package licenses violate the license policy", len(licenseViolations)))
+		if opts.LicensePolicy.FailOnViolation {
+			errs = appendErrors(errs, grypeerr.ErrLicensePolicyViolation)
+		}
+	}
+
+	if opts.Secrets.Report != "" {
+		secrets, err := secret.ReadReportFile(opts.Secrets.Report)
+		if err != nil {
+			return nil, err
+		}
+		combinations := secret.Correlate(secrets, remainingMatches.Sorted())
+		model.ExploitableCombinations, err = models.NewExploitableCombinations(combinations, packages, vp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exploitable combination findings: %w", err)
+		}
+		if len(combinations) > 0 {
+			bus.Notify(fmt.Sprintf("%d exposed secrets found alongside vulnerable packages", len(combinations)))
+		}
+	}
+
+	presenterConfig := models.PresenterConfig{
 		ID:       app.ID(),
 		Document: model,
 		SBOM:     s,
 		Pretty:   opts.Pretty,
-	}); err != nil {
-		errs = appendErrors(errs, err)
+	}
+	// reports may be shared externally, so redaction applies to every output (and pushed results)
+	models.Redact(&presenterConfig, opts.Redact.ToConfig())
+
+	if stream != nil && stream.rows == 0 {
+		// the table rows have already been written as matches were discovered
+		bus.Report("No vulnerabilities found\n")
+	}
+	if writer != nil {
+		if err = writer.Write(presenterConfig); err != nil {
+			errs = appendErrors(errs, err)
+		}
+	}
+
+	if opts.Push.URL != "" {
+		if err := pushResults(ctx, app.ID(), opts.Push, opts.Proxy.PushRule(), presenterConfig.Document); err != nil {
+			errs = appendErrors(errs, err)
+		}
 	}
 
 	log.WithFields("time", time.Since(startTime)).Trace("wrote vulnerability report")
 
-	return errs
+	if opts.Telemetry.Enabled {
+		recordTelemetry(ctx, app.ID(), opts.Telemetry, telemetry.NewScan(in.startTime, app.ID().Version, model, packages, status))
+	}
+
+	return &model, errs
+}
+
+// recordTelemetry adds the scan to the local telemetry summary (and sends the summary on when configured). Telemetry
+// never fails a scan.
+func recordTelemetry(ctx context.Context, id clio.Identification, cfg options.Telemetry, scan telemetry.Scan) {
+	summary, err := telemetry.Record(cfg.File, scan)
+	if err != nil {
+		log.WithFields("error", err).Warn("unable to record scan telemetry")
+		return
+	}
+	if cfg.Endpoint == "" {
+		return
+	}
+	if err := telemetry.Send(ctx, cfg.Endpoint, fmt.Sprintf("%s %s", id.Name, id.Version), cfg.Timeout, summary); err != nil {
+		log.WithFields("error", err).Warn("unable to send scan telemetry")
+	}
+}
+
+// startDBTrace begins writing all vulnerability DB queries to the given file, returning a function that writes a
+// summary of the slowest queries and closes the file.
+func startDBTrace(path string, top int) (*v6.QueryTrace, func(), error) {
+	fh, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create DB trace file: %w", err)
+	}
+
+	trace := v6.NewQueryTrace(fh)
+
+	return trace, func() {
+		if err := trace.WriteSummary(top); err != nil {
+			log.WithFields("error", err, "path", path).Warn("unable to write DB trace")
+		}
+		log.CloseAndLogError(fh, path)
+		log.WithFields("path", path, "queries", trace.Count()).Info("wrote DB query trace")
+	}, nil
 }
 
 func warnWhenDistroHintNeeded(pkgs []pkg.Package, context *pkg.Context) {
@@ -296,6 +735,46 @@ loop:
 	}
 }
 
+// warnSkippedPackages notifies of the number of packages that were not matched per reason, broken down by ecosystem.
+// resolvedFindings returns the vulnerabilities whose fix is already included in the installed version of the packages.
+func resolvedFindings(packages []pkg.Package, vp vulnerability.Provider) ([]models.ResolvedFinding, error) {
+	var findings []match.ResolvedFinding
+	for _, p := range packages {
+		resolved, err := apk.ResolvedFindings(vp, p)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, resolved...)
+	}
+	return models.NewResolvedFindings(findings), nil
+}
+
+func warnSkippedPackages(skipped []grype.SkippedPackage) {
+	counts := make(map[string]map[string]int)
+	for _, s := range skipped {
+		if counts[s.Reason] == nil {
+			counts[s.Reason] = make(map[string]int)
+		}
+		counts[s.Reason][grype.Ecosystem(s.Package)]++
+	}
+
+	for _, reason := range slices.Sorted(maps.Keys(counts)) {
+		var total int
+		var ecosystems []string
+		for _, eco := range slices.Sorted(maps.Keys(counts[reason])) {
+			total += counts[reason][eco]
+			ecosystems = append(ecosystems, fmt.Sprintf("%s=%d", eco, counts[reason][eco]))
+		}
+		bus.Notify(fmt.Sprintf("%d packages were not matched (%s): %s", total, reason, strings.Join(ecosystems, ", ")))
+	}
+}
+
+func warnNotices(notices []models.Notice) {
+	for _, n := range notices {
+		bus.Notify(n.Message)
+	}
+}
+
 func warnDistroAlerts(data *models.DistroAlertData) {
 	if data == nil {
 		return
@@ -308,6 +787,115 @@ func warnDistroAlerts(data *models.DistroAlertData) {
 	}
 }
 
+func warnMaliciousPackages(matches []match.Match) {
+	if len(matches) == 0 {
+		return
+	}
+
+	pkgIDs := make(map[pkg.ID]struct{})
+	for _, m := range matches {
+		pkgIDs[m.Package.ID] = struct{}{}
+	}
+
+	bus.Notify(fmt.Sprintf("%d known-malicious packages found - these packages should be removed rather than upgraded", len(pkgIDs)))
+}
+
+func warnUnmanagedBinaries(binaries []models.UnmanagedBinary) {
+	vulnerable := 0
+	for _, b := range binaries {
+		if len(b.Matches) > 0 {
+			vulnerable++
+		}
+	}
+	if vulnerable == 0 {
+		return
+	}
+
+	bus.Notify(fmt.Sprintf("%d vulnerable binaries not owned by any package found - these must be replaced by hand", vulnerable))
+}
+
+func pushResults(ctx context.Context, id clio.Identification, cfg options.Push, proxyRule proxy.Rule, model models.Document) error {
+	pushCfg := cfg.ToConfig(fmt.Sprintf("%s %s", id.Name, id.Version))
+	pushCfg.Proxy = proxyRule
+	pusher, err := push.NewPusher(pushCfg)
+	if err != nil {
+		return err
+	}
+	if err := pusher.Push(ctx, model); err != nil {
+		return fmt.Errorf("failed to push results to %s: %w", cfg.URL, err)
+	}
+	bus.Notify(fmt.Sprintf("Pushed results to %s", cfg.URL))
+	return nil
+}
+
+func warnUnboundedMatches(matches []match.Match) {
+	if len(matches) == 0 {
+		return
+	}
+	bus.Notify(fmt.Sprintf("%d vulnerabilities affect all versions from a point onward with no known fix - these are not considered for failure conditions", len(matches)))
+}
+
+func warnBaselineMatches(matches []match.Match) {
+	if len(matches) == 0 {
+		return
+	}
+	bus.Notify(fmt.Sprintf("%d vulnerabilities are already in the baseline - these are not considered for failure conditions", len(matches)))
+}
+
+// warnUnusedIgnoreRules notifies of the configured ignore rules that did not ignore any vulnerability, which are likely
+// stale (e.g. the vulnerability was fixed or the package removed) and can be removed.
+func warnUnusedIgnoreRules(rules []match.IgnoreRule) {
+	if len(rules) == 0 {
+		return
+	}
+	for _, r := range rules {
+		log.WithFields("vulnerability", r.Vulnerability, "package", r.Package.Name, "reason", r.Reason, "source", r.Source).Warn("ignore rule did not ignore any vulnerability")
+	}
+	bus.Notify(fmt.Sprintf("%d configured ignore rules did not ignore any vulnerability - these may be stale and can be removed", len(rules)))
+}
+
+// warnStaleBaseline notifies of the findings of the baseline that are no longer found (including ignored matches), which
+// can be dropped with 'grype baseline update'.
+func warnStaleBaseline(baseline *match.Baseline, remaining *match.Matches, ignored []match.IgnoredMatch) {
+	if baseline == nil || remaining == nil {
+		return
+	}
+	matches := remaining.Sorted()
+	for _, m := range ignored {
+		matches = append(matches, m.Match)
+	}
+	stale := baseline.Stale(matches)
+	if len(stale) == 0 {
+		return
+	}
+	for _, f := range stale {
+		log.WithFields("vulnerability", f.VulnerabilityIDs, "package", f.PackageName, "type", f.PackageType).Debug("stale baseline finding")
+	}
+	bus.Notify(fmt.Sprintf("%d findings of the baseline are no longer found - run 'grype baseline update' to remove them", len(stale)))
+}
+
+func warnSLABreaches(breaches []sla.Breach) {
+	counts := make(map[vulnerability.Severity]int)
+	gracePeriods := make(map[vulnerability.Severity]time.Duration)
+	for _, b := range breaches {
+		counts[b.Severity]++
+		gracePeriods[b.Severity] = b.GracePeriod
+	}
+
+	severities := vulnerability.AllSeverities()
+	for i := len(severities) - 1; i >= 0; i-- {
+		sev := severities[i]
+		if counts[sev] == 0 {
+			continue
+		}
+		gracePeriod := gracePeriods[sev].String()
+		if day := 24 * time.Hour; gracePeriods[sev]%day == 0 {
+			gracePeriod = fmt.Sprintf("%dd", gracePeriods[sev]/day)
+		}
+		bus.Notify(fmt.Sprintf("%d %s vulnerabilities have been outstanding longer than the %s SLA grace period", counts[sev], sev, gracePeriod))
+	}
+}
+
 func countPackagesByDistro(packages []pkg.Package) map[string]int {
 	counts := make(map[string]int)
 	for _, p := range packages {
@@ -368,38 +956,78 @@ func checkForAppUpdate(id clio.Identification, opts *options.Grype) {
 }
 
 func getMatcherConfig(opts *options.Grype) matcher.Config {
+	javaSearch := opts.ExternalSources.ToJavaMatcherConfig()
+	javaSearch.Retry = opts.Network.ToConfig()
+	javaSearch.Bandwidth = opts.Network.Limiter()
 	return matcher.Config{
 		Java: java.MatcherConfig{
-			ExternalSearchConfig: opts.ExternalSources.ToJavaMatcherConfig(),
+			ExternalSearchConfig: javaSearch,
 			UseCPEs:              opts.Match.Java.UseCPEs,
 		},
-		Ruby:       ruby.MatcherConfig(opts.Match.Ruby),
-		Python:     python.MatcherConfig(opts.Match.Python),
-		Dotnet:     dotnet.MatcherConfig(opts.Match.Dotnet),
+		Ruby:   ruby.MatcherConfig(opts.Match.Ruby),
+		Python: python.MatcherConfig(opts.Match.Python),
+		Dotnet: dotnet.MatcherConfig{
+			UseCPEs:                opts.Match.Dotnet.UseCPEs,
+			AlwaysUseCPEForRuntime: opts.Match.Dotnet.AlwaysUseCPEForRuntime,
+		},
 		Javascript: javascript.MatcherConfig(opts.Match.Javascript),
 		Golang: golang.MatcherConfig{
 			UseCPEs:                                opts.Match.Golang.UseCPEs,
 			AlwaysUseCPEForStdlib:                  opts.Match.Golang.AlwaysUseCPEForStdlib,
 			AllowMainModulePseudoVersionComparison: opts.Match.Golang.AllowMainModulePseudoVersionComparison,
 		},
-		Hex:   hex.MatcherConfig(opts.Match.Hex),
-		Stock: stock.MatcherConfig(opts.Match.Stock),
+		Hex:       hex.MatcherConfig(opts.Match.Hex),
+		Terraform: terraform.MatcherConfig(opts.Match.Terraform),
+		Stock:     stock.MatcherConfig(opts.Match.Stock),
+		Bitnami:   bitnami.MatcherConfig(opts.Match.Bitnami),
 		Dpkg: dpkg.MatcherConfig{
-			MissingEpochStrategy: opts.Match.Dpkg.MissingEpochStrategy,
-			UseCPEsForEOL:        opts.Match.Dpkg.UseCPEsForEOL,
+			MissingEpochStrategy:  opts.Match.Dpkg.MissingEpochStrategy,
+			UseCPEsForEOL:         opts.Match.Dpkg.UseCPEsForEOL,
+			UbuntuProFixesAsFixed: opts.Match.Dpkg.UbuntuProFixesAsFixed,
 		},
 		Rpm: rpm.MatcherConfig{
-			MissingEpochStrategy: opts.Match.Rpm.MissingEpochStrategy,
-			UseCPEsForEOL:        opts.Match.Rpm.UseCPEsForEOL,
+			MissingEpochStrategy:       opts.Match.Rpm.MissingEpochStrategy,
+			BinaryMissingEpochStrategy: opts.Match.Rpm.BinaryMissingEpochStrategy,
+			UseCPEsForEOL:              opts.Match.Rpm.UseCPEsForEOL,
 		},
 	}
 }
 
+// addShadedJavaPackages adds the maven artifacts identified by the fingerprints of java class files (see --deep-java).
+func addShadedJavaPackages(packages []pkg.Package, pkgContext pkg.Context, vp vulnerability.Provider) ([]pkg.Package, error) {
+	fingerprints, ok := vp.(pkg.JavaClassFingerprintProvider)
+	if !ok {
+		log.Warn("the vulnerability provider does not support java class fingerprints, skipping deep java inspection")
+		return packages, nil
+	}
+
+	shaded, err := pkg.ShadedJavaPackages(pkgContext, packages, fingerprints)
+	if err != nil {
+		return nil, err
+	}
+	if len(shaded) > 0 {
+		log.WithFields("packages", len(shaded)).Info("identified shaded java packages from class fingerprints")
+	}
+	return append(packages, shaded...), nil
+}
+
 func getMatchers(opts *options.Grype) []match.Matcher {
 	return matcher.NewDefaultMatchers(getMatcherConfig(opts))
 }
 
-func getProviderConfig(opts *options.Grype) pkg.ProviderConfig {
+// registryTransport returns the transport of the container registry clients (nil for a clone of the default transport),
+// which has the registry proxy (if any) rather than the proxy of the environment, and the bandwidth limit (shared with
+// the database client).
+func registryTransport(opts *options.Grype) *http.Transport {
+	rule := opts.Proxy.RegistryRule()
+	limiter := opts.Network.Limiter()
+	if rule.IsZero() && limiter == nil {
+		return nil
+	}
+	return limiter.Transport(rule.Transport(http.DefaultTransport.(*http.Transport)))
+}
+
+func getProviderConfig(opts *options.Grype, userInput string) pkg.ProviderConfig {
 	cfg := syft.DefaultCreateSBOMConfig()
 	cfg.Packages.JavaArchive.IncludeIndexedArchives = opts.Search.IncludeIndexedArchives
 	cfg.Packages.JavaArchive.IncludeUnindexedArchives = opts.Search.IncludeUnindexedArchives
@@ -415,18 +1043,40 @@ func getProviderConfig(opts *options.Grype) pkg.ProviderConfig {
 	// save us the effort of ever attempting to match with these packages as early as possible.
 	cfg.Compliance.MissingVersion = cataloging.ComplianceActionDrop
 
+	// syft catalogs the providers of terraform lock files, but not the modules installed by terraform
+	cfg.WithCatalogers(pkgcataloging.NewCatalogerReference(pkg.NewTerraformModuleCataloger(),
+		[]string{pkgcataloging.DirectoryTag, pkgcataloging.DeclaredTag, "terraform"}))
+
+	// syft catalogs the version resources of PE binaries, but not the products of Windows installer (MSI) files
+	cfg.WithCatalogers(pkgcataloging.NewCatalogerReference(pkg.NewMSIPackageCataloger(),
+		[]string{pkgcataloging.DirectoryTag, pkgcataloging.InstalledTag, pkgcataloging.ImageTag, "windows", "msi"}))
+
+	// syft catalogs macOS application bundles, but not the installer packages recorded by package receipts
+	cfg.WithCatalogers(pkgcataloging.NewCatalogerReference(pkg.NewApplePackageReceiptCataloger(),
+		[]string{pkgcataloging.DirectoryTag, pkgcataloging.InstalledTag, pkgcataloging.ImageTag, "apple", "macos", "pkg-receipt"}))
+
+	// the directory scan options are validated when loading the configuration
+	directoryScan, _ := opts.DirectoryScan.ToConfig()
+
 	return pkg.ProviderConfig{
 		SyftProviderConfig: pkg.SyftProviderConfig{
-			RegistryOptions:        opts.Registry.ToOptions(),
+			RegistryOptions:        opts.RegistryOptions(userInput),
+			RegistryTransport:      registryTransport(opts),
 			Exclusions:             opts.Exclusions,
 			SBOMOptions:            cfg,
 			Platform:               opts.Platform,
 			Name:                   opts.Name,
 			DefaultImagePullSource: opts.DefaultImagePullSource,
 			Sources:                opts.From,
+			SBOMCacheDir:           opts.SBOMCacheDir,
+			DeepJava:               opts.DeepJava,
+			IgnoreEmbeddedSBOM:     opts.IgnoreEmbeddedSBOM,
+			DirectoryScan:          directoryScan,
 		},
 		SynthesisConfig: pkg.SynthesisConfig{
 			GenerateMissingCPEs: opts.GenerateMissingCPEs,
+			CPECacheDir:         opts.CPECacheDir,
+			GenerateBinaryCPEs:  opts.UnmanagedBinaries.Enabled,
 			Distro: pkg.DistroConfig{
 				Override:    applyDistroHint(opts.Distro),
 				FixChannels: getFixChannels(opts.FixChannel),
@@ -509,7 +1159,16 @@ func validateDBLoad(loadErr error, status *vulnerability.ProviderStatus) error {
 	return nil
 }
 
-func validateRootArgs(cmd *cobra.Command, args []string) error {
+// validateRootArgs validates the arguments against the loaded configuration (the targets file may be given by the
+// configuration file or the environment as well as by --targets-file).
+func validateRootArgs(cmd *cobra.Command, opts *options.Grype, args []string) error {
+	if opts.Targets.File != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("a target argument cannot be given with --targets-file")
+		}
+		return nil
+	}
+
 	isStdinPipeOrRedirect, err := internal.IsStdinPipeOrRedirect()
 	if err != nil {
 		log.Warnf("unable to determine if there is piped input: %+v", err)
@@ -532,7 +1191,72 @@ func validateRootArgs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("an image/directory argument is required")
 	}
 
-	return cobra.MaximumNArgs(1)(cmd, args)
+	return nil
+}
+
+// discoverBaseImageVEX writes the VEX documents attested for the base image of the scanned image to temporary files,
+// returning their paths and a function removing them.
+func discoverBaseImageVEX(ctx context.Context, opts *options.Grype, pkgContext pkg.Context) ([]string, func(), error) {
+	cleanup := func() {}
+
+	var verifiers []*dsse.Verifier
+	for _, key := range opts.Vex.AttestationKeys {
+		v, err := dsse.LoadVerifier(key)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		verifiers = append(verifiers, v)
+	}
+
+	baseName, _, _ := attestation.BaseImage(pkgContext.Source)
+	docs, err := attestation.Discover(ctx, pkgContext.Source, attestation.Config{
+		Registry:  opts.RegistryOptions(baseName),
+		Verifiers: verifiers,
+		Proxy:     opts.Proxy.RegistryRule(),
+		Bandwidth: opts.Network.Limiter(),
+		Retry:     opts.Network.ToConfig(),
+	})
+	if err != nil || len(docs) == 0 {
+		return nil, cleanup, err
+	}
+
+	// the VEX processor reads documents from files, which must all be of the same format
+	for _, path := range opts.VexDocuments {
+		if !openvex.IsOpenVex(path) {
+			log.WithFields("document", path).Warn("not applying base image VEX attestations, since they cannot be combined with non-OpenVEX documents")
+			return nil, cleanup, nil
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "grype-vex-")
+	if err != nil {
+		return nil, cleanup, err
+	}
+	cleanup = func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.WithFields("error", err).Debug("unable to remove base image VEX documents")
+		}
+	}
+
+	var paths []string
+	for i, doc := range docs {
+		path := filepath.Join(dir, fmt.Sprintf("base-image-%d.openvex.json", i))
+		fh, err := os.Create(path)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		err = doc.VEX.ToJSON(fh)
+		if closeErr := fh.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("unable to write base image VEX document: %w", err)
+		}
+		log.WithFields("image", doc.Image, "digest", doc.Digest, "key", doc.KeyID, "document", doc.VEX.ID, "statements", doc.Inherited, "dropped", doc.Dropped).
+			Info("applying VEX attestation of base image")
+		paths = append(paths, path)
+	}
+	return paths, cleanup, nil
 }
 
 func applyVexRules(opts *options.Grype) error {
@@ -559,3 +1283,31 @@ func applyVexRules(opts *options.Grype) error {
 
 	return nil
 }
+
+// readIgnoreFiles reads the rules of the given annotated ignore files (or of the default ignore file in the working
+// directory, when present and no files are given). Expired rules are reported and left out.
+func readIgnoreFiles(paths []string) ([]match.IgnoreRule, error) {
+	if len(paths) == 0 {
+		if _, err := os.Stat(match.DefaultIgnoreFile); err != nil {
+			return nil, nil
+		}
+		paths = []string{match.DefaultIgnoreFile}
+	}
+
+	var rules []match.IgnoreRule
+	for _, path := range paths {
+		f, err := match.ReadIgnoreFile(path)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields("path", path, "rules", len(f.Rules)).Debug("read ignore file")
+		rules = append(rules, f.Rules...)
+	}
+
+	active, expired := match.ActiveIgnoreRules(rules, time.Now())
+	for _, r := range expired {
+		log.WithFields("vulnerability", r.Vulnerability, "owner", r.Owner, "ticket", r.Ticket, "expires", r.Expires, "path", r.Source).
+			Warn("ignore rule has expired and is no longer applied")
+	}
+	return active, nil
+}
@@ -1,19 +1,25 @@
 package commands
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype"
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/grype/matcher/bitnami"
 	"github.com/anchore/grype/grype/matcher/dotnet"
 	"github.com/anchore/grype/grype/matcher/dpkg"
 	"github.com/anchore/grype/grype/matcher/golang"
@@ -24,12 +30,18 @@ import (
 	"github.com/anchore/grype/grype/matcher/rpm"
 	"github.com/anchore/grype/grype/matcher/ruby"
 	"github.com/anchore/grype/grype/matcher/stock"
+	"github.com/anchore/grype/grype/matcher/terraform"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/version"
 	vexStatus "github.com/anchore/grype/grype/vex/status"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	"github.com/anchore/grype/internal/retry"
 	"github.com/anchore/stereoscope/pkg/image"
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/cataloging"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/source"
 )
 
 func Test_getProviderConfig(t *testing.T) {
@@ -56,6 +68,7 @@ func Test_getProviderConfig(t *testing.T) {
 					RegistryOptions: &image.RegistryOptions{
 						Credentials: []image.RegistryCredentials{},
 					},
+					DirectoryScan: pkg.DirectoryScanConfig{Symlinks: pkg.SymlinksFollow},
 				},
 				SynthesisConfig: pkg.SynthesisConfig{
 					GenerateMissingCPEs: false,
@@ -89,7 +102,7 @@ func Test_getProviderConfig(t *testing.T) {
 				}, cmp.Ignore()),
 				cmpopts.IgnoreUnexported(syft.CreateSBOMConfig{}),
 			}
-			if d := cmp.Diff(tt.want, getProviderConfig(tt.opts), opts...); d != "" {
+			if d := cmp.Diff(tt.want, getProviderConfig(tt.opts, ""), opts...); d != "" {
 				t.Errorf("getProviderConfig() mismatch (-want +got):\n%s", d)
 			}
 		})
@@ -114,22 +127,26 @@ func Test_getMatcherConfig(t *testing.T) {
 						SearchMavenUpstream: false,
 						MavenBaseURL:        "https://search.maven.org/solrsearch/select",
 						MavenRateLimit:      300000000, // 300ms in nanoseconds
+						Retry:               retry.DefaultConfig(),
 					},
 					UseCPEs: false,
 				},
 				Ruby:       ruby.MatcherConfig{},
 				Python:     python.MatcherConfig{},
-				Dotnet:     dotnet.MatcherConfig{},
+				Dotnet:     dotnet.MatcherConfig{AlwaysUseCPEForRuntime: true},
 				Javascript: javascript.MatcherConfig{},
 				Golang: golang.MatcherConfig{
 					UseCPEs:                                false,
 					AlwaysUseCPEForStdlib:                  false,
 					AllowMainModulePseudoVersionComparison: false,
 				},
-				Hex:   hex.MatcherConfig{},
-				Stock: stock.MatcherConfig{UseCPEs: true},
+				Hex:       hex.MatcherConfig{},
+				Terraform: terraform.MatcherConfig{UseCPEs: true},
+				Stock:     stock.MatcherConfig{UseCPEs: true},
+				Bitnami:   bitnami.MatcherConfig{UseCPEs: true},
 				Rpm: rpm.MatcherConfig{
-					MissingEpochStrategy: "auto",
+					MissingEpochStrategy:       "auto",
+					BinaryMissingEpochStrategy: "zero",
 				},
 				Dpkg: dpkg.MatcherConfig{
 					MissingEpochStrategy: "zero",
@@ -149,22 +166,26 @@ func Test_getMatcherConfig(t *testing.T) {
 						SearchMavenUpstream: false,
 						MavenBaseURL:        "https://search.maven.org/solrsearch/select",
 						MavenRateLimit:      300000000,
+						Retry:               retry.DefaultConfig(),
 					},
 					UseCPEs: false,
 				},
 				Ruby:       ruby.MatcherConfig{},
 				Python:     python.MatcherConfig{},
-				Dotnet:     dotnet.MatcherConfig{},
+				Dotnet:     dotnet.MatcherConfig{AlwaysUseCPEForRuntime: true},
 				Javascript: javascript.MatcherConfig{},
 				Golang: golang.MatcherConfig{
 					UseCPEs:                                false,
 					AlwaysUseCPEForStdlib:                  false,
 					AllowMainModulePseudoVersionComparison: false,
 				},
-				Hex:   hex.MatcherConfig{},
-				Stock: stock.MatcherConfig{UseCPEs: true},
+				Hex:       hex.MatcherConfig{},
+				Terraform: terraform.MatcherConfig{UseCPEs: true},
+				Stock:     stock.MatcherConfig{UseCPEs: true},
+				Bitnami:   bitnami.MatcherConfig{UseCPEs: true},
 				Rpm: rpm.MatcherConfig{
-					MissingEpochStrategy: "zero",
+					MissingEpochStrategy:       "zero",
+					BinaryMissingEpochStrategy: "zero",
 				},
 				Dpkg: dpkg.MatcherConfig{
 					MissingEpochStrategy: "zero",
@@ -184,22 +205,26 @@ func Test_getMatcherConfig(t *testing.T) {
 						SearchMavenUpstream: false,
 						MavenBaseURL:        "https://search.maven.org/solrsearch/select",
 						MavenRateLimit:      300000000,
+						Retry:               retry.DefaultConfig(),
 					},
 					UseCPEs: false,
 				},
 				Ruby:       ruby.MatcherConfig{},
 				Python:     python.MatcherConfig{},
-				Dotnet:     dotnet.MatcherConfig{},
+				Dotnet:     dotnet.MatcherConfig{AlwaysUseCPEForRuntime: true},
 				Javascript: javascript.MatcherConfig{},
 				Golang: golang.MatcherConfig{
 					UseCPEs:                                false,
 					AlwaysUseCPEForStdlib:                  false,
 					AllowMainModulePseudoVersionComparison: false,
 				},
-				Hex:   hex.MatcherConfig{},
-				Stock: stock.MatcherConfig{UseCPEs: true},
+				Hex:       hex.MatcherConfig{},
+				Terraform: terraform.MatcherConfig{UseCPEs: true},
+				Stock:     stock.MatcherConfig{UseCPEs: true},
+				Bitnami:   bitnami.MatcherConfig{UseCPEs: true},
 				Rpm: rpm.MatcherConfig{
-					MissingEpochStrategy: "auto",
+					MissingEpochStrategy:       "auto",
+					BinaryMissingEpochStrategy: "zero",
 				},
 				Dpkg: dpkg.MatcherConfig{
 					MissingEpochStrategy: "auto",
@@ -330,3 +355,130 @@ func Test_applyVexRules(t *testing.T) {
 		})
 	}
 }
+
+func Test_setupScan_configuredIgnoreRules(t *testing.T) {
+	ignoreFile := filepath.Join(t.TempDir(), "ignore.yaml")
+	require.NoError(t, os.WriteFile(ignoreFile, []byte(`ignore:
+  - vulnerability: CVE-2024-1234
+    reason: the vulnerable function is never called
+`), 0o600))
+
+	configRule := match.IgnoreRule{Vulnerability: "CVE-2023-1", Reason: "accepted risk"}
+	opts := &options.Grype{
+		Ignore:       []match.IgnoreRule{configRule},
+		IgnoreFiles:  []string{ignoreFile},
+		OnlyFixed:    true,
+		IgnoreStates: "wont-fix",
+	}
+
+	configured, err := setupScan(opts)
+	require.NoError(t, err)
+
+	// only the rules of the configuration and ignore files are configured, not the rules implied by the options
+	require.Len(t, configured, 2)
+	assert.Equal(t, configRule, configured[0])
+	assert.Equal(t, "CVE-2024-1234", configured[1].Vulnerability)
+	assert.Equal(t, ignoreFile, configured[1].Source)
+	assert.Greater(t, len(opts.Ignore), len(configured))
+}
+
+func Test_validateRootArgs_targetsFile(t *testing.T) {
+	// the targets file is given by the configuration rather than by --targets-file
+	opts := &options.Grype{Targets: options.Targets{File: "targets.txt"}}
+
+	require.NoError(t, validateRootArgs(&cobra.Command{}, opts, nil))
+	require.ErrorContains(t, validateRootArgs(&cobra.Command{}, opts, []string{"alpine:3.18"}), "cannot be given with --targets-file")
+}
+
+func Test_segmentProjects(t *testing.T) {
+	at := func(name, path string) pkg.Package {
+		return pkg.Package{ID: pkg.ID(name), Name: name, Locations: file.NewLocationSet(file.NewLocation(path))}
+	}
+	catalog := func() []pkg.Package {
+		return []pkg.Package{
+			at("express", "/web/package-lock.json"),
+			at("spring-core", "/api/pom.xml"),
+		}
+	}
+	directory := pkg.Context{Source: &source.Description{Metadata: source.DirectoryMetadata{Path: "/src"}}}
+	image := pkg.Context{Source: &source.Description{Metadata: source.ImageMetadata{UserInput: "alpine"}}}
+
+	roots, packages, err := segmentProjects(&options.Grype{}, catalog(), directory)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api", "/web"}, roots)
+	require.Len(t, packages, 2)
+	assert.Equal(t, []string{"/web"}, pkg.Projects(packages[0]))
+
+	roots, packages, err = segmentProjects(&options.Grype{OnlyProject: "./api/"}, catalog(), directory)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api", "/web"}, roots)
+	require.Len(t, packages, 1)
+	assert.Equal(t, "spring-core", packages[0].Name)
+
+	_, _, err = segmentProjects(&options.Grype{OnlyProject: "mobile"}, catalog(), directory)
+	require.ErrorContains(t, err, "must be one of the detected projects [/api, /web]")
+
+	roots, packages, err = segmentProjects(&options.Grype{}, catalog(), image)
+	require.NoError(t, err)
+	assert.Nil(t, roots)
+	assert.Empty(t, pkg.Projects(packages[0]))
+
+	_, _, err = segmentProjects(&options.Grype{OnlyProject: "api"}, catalog(), image)
+	require.Error(t, err)
+}
+
+type javaClassFingerprintProvider struct {
+	vulnerability.Provider
+	artifacts []pkg.JavaClassArtifact
+}
+
+func (p javaClassFingerprintProvider) JavaClassArtifacts(digests ...string) ([]pkg.JavaClassArtifact, error) {
+	var out []pkg.JavaClassArtifact
+	for _, a := range p.artifacts {
+		if slices.Contains(digests, a.Digest) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func Test_addShadedJavaPackages(t *testing.T) {
+	vp := javaClassFingerprintProvider{
+		Provider: mock.VulnerabilityProvider(vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-jfh8-c2jp-5v3q", Namespace: "github:language:java"},
+			PackageName: "org.apache.logging.log4j:log4j-core",
+			Constraint:  version.MustGetConstraint("< 2.15.0", version.MavenFormat),
+		}),
+		artifacts: []pkg.JavaClassArtifact{
+			{
+				Digest:     "sha1:3b0f2a1ad7bb8cc6e6a7e2b4b23c1e1c6b0e4a52",
+				ClassName:  "org/apache/logging/log4j/core/lookup/JndiLookup.class",
+				GroupID:    "org.apache.logging.log4j",
+				ArtifactID: "log4j-core",
+				Version:    "2.14.1",
+			},
+		},
+	}
+	// the application jar shades log4j-core without its maven metadata
+	pkgContext := pkg.Context{
+		JavaClassDigests: map[string][]string{
+			"/app/app.jar": {"sha1:3b0f2a1ad7bb8cc6e6a7e2b4b23c1e1c6b0e4a52"},
+		},
+	}
+
+	packages, err := addShadedJavaPackages(nil, pkgContext, vp)
+	require.NoError(t, err)
+	require.Len(t, packages, 1)
+	assert.Equal(t, "log4j-core", packages[0].Name)
+
+	matcher := grype.VulnerabilityMatcher{
+		VulnerabilityProvider: vp,
+		Matchers:              []match.Matcher{java.NewJavaMatcher(java.MatcherConfig{})},
+	}
+	matches, _, err := matcher.FindMatches(packages, pkgContext)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, matches.Count())
+	assert.Equal(t, "GHSA-jfh8-c2jp-5v3q", matches.Sorted()[0].Vulnerability.ID)
+	assert.Equal(t, "/app/app.jar", matches.Sorted()[0].Package.Locations.ToSlice()[0].RealPath)
+}
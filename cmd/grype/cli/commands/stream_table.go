@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/anchore/grype/grype/event"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/cvss"
+	"github.com/anchore/grype/internal/log"
+)
+
+// tableStream publishes the table rows of the matches streamed by the vulnerability matcher (see --stream-table),
+// adjusting the matches as the final report does.
+type tableStream struct {
+	vp             vulnerability.Provider
+	assetClass     string
+	cvssModifiers  cvss.Modifiers
+	severityPolicy match.SeverityPolicy
+	// unmanaged indicates that matches on unmanaged binaries are reported in a separate section of the report
+	unmanaged bool
+	// rows is the number of matches published so far
+	rows int
+}
+
+// publish is called by the vulnerability matcher with the final matches of a package.
+func (s *tableStream) publish(matches []match.Match) {
+	if s.unmanaged {
+		remaining, _ := match.SplitUnmanagedBinaries(match.NewMatches(matches...))
+		matches = remaining.Sorted()
+	}
+
+	var doc models.Document
+	for _, m := range matches {
+		matchModel, err := models.NewMatch(m, s.vp)
+		if err != nil {
+			log.WithFields("error", err, "vulnerability", m.Vulnerability.ID).Warn("unable to render streamed match")
+			continue
+		}
+		doc.Matches = append(doc.Matches, *matchModel)
+	}
+	if len(doc.Matches) == 0 {
+		return
+	}
+
+	models.AdjustCvssScores(&doc, s.assetClass, s.cvssModifiers, models.SortByPackage)
+	models.ApplySeverityPolicy(&doc, s.severityPolicy, models.SortByPackage)
+
+	s.rows += len(doc.Matches)
+	bus.Publish(partybus.Event{
+		Type:  event.VulnerabilityMatchesDiscovered,
+		Value: doc.Matches,
+	})
+}
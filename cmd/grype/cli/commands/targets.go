@@ -0,0 +1,296 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/options"
+	"github.com/anchore/grype/grype/grypeerr"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/format"
+	"github.com/anchore/grype/internal/log"
+)
+
+const (
+	targetPassed = "passed"
+	targetFailed = "failed"
+	targetError  = "error"
+
+	targetsSummaryFile = "summary.json"
+)
+
+// unsafeFileNameChars matches the characters of a target replaced when naming its report files
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// targetResult is the outcome of scanning one of the targets of a targets file.
+type targetResult struct {
+	Target   string   `json:"target"`
+	Status   string   `json:"status"`
+	ExitCode int      `json:"exitCode"`
+	Matches  int      `json:"matches"`
+	Packages int      `json:"packages"`
+	Reports  []string `json:"reports,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Duration string   `json:"duration"`
+}
+
+// targetsSummary is the aggregate outcome of scanning the targets of a targets file.
+type targetsSummary struct {
+	Passed  int            `json:"passed"`
+	Failed  int            `json:"failed"`
+	Errored int            `json:"errored"`
+	Targets []targetResult `json:"targets"`
+}
+
+// runTargets scans each target of the targets file against a single load of the vulnerability DB, writing the
+// reports of each target to the output directory along with a summary. Scanning fails when any target could not be
+// scanned, otherwise with the policy failures of the targets (e.g. --fail-on).
+func runTargets(ctx context.Context, app clio.Application, opts *options.Grype, ignoreRules []match.IgnoreRule) error {
+	targets, err := readTargets(opts.Targets.File)
+	if err != nil {
+		return err
+	}
+
+	curatorCfg, finishTrace, err := curatorConfig(opts)
+	if err != nil {
+		return err
+	}
+	defer finishTrace()
+
+	var vp vulnerability.Provider
+	var status *vulnerability.ProviderStatus
+	err = parallel(
+		func() error {
+			checkForAppUpdate(app.ID(), opts)
+			return nil
+		},
+		func() (err error) {
+			vp, status, err = loadVulnerabilityDB(opts, curatorCfg)
+			return err
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer closeVulnerabilityDB(opts, vp, status)
+
+	reports := targetReportOutputs(targets, opts.Outputs, opts.Targets.OutputDir)
+
+	results := make([]targetResult, len(targets))
+	errs := make([]error, len(targets))
+	sem := make(chan struct{}, opts.Targets.Parallelism)
+	wg := &sync.WaitGroup{}
+	for i, target := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = scanListedTarget(ctx, app, opts, scanInput{vp: vp, status: status, ignoreRules: ignoreRules}, target, reports[i])
+		}()
+	}
+	wg.Wait()
+
+	summary := newTargetsSummary(results)
+	if err := writeTargetsSummary(summary, filepath.Join(opts.Targets.OutputDir, targetsSummaryFile)); err != nil {
+		return err
+	}
+
+	sb := &strings.Builder{}
+	if err := displayTargetsSummaryTable(summary, sb); err != nil {
+		return err
+	}
+	bus.Report(sb.String())
+
+	if summary.Errored > 0 {
+		return fmt.Errorf("%d of %d targets could not be scanned", summary.Errored, len(targets))
+	}
+
+	// the policy failures of all targets are reported, such that the exit code reflects them
+	var policyErrs error
+	for _, err := range errs {
+		if err != nil && !errors.Is(policyErrs, err) {
+			policyErrs = appendErrors(policyErrs, err)
+		}
+	}
+	return policyErrs
+}
+
+// scanListedTarget catalogs and scans a single target of a targets file against the DB of the given input, writing its
+// reports with the given outputs.
+func scanListedTarget(ctx context.Context, app clio.Application, opts *options.Grype, in scanInput, target string, outputs []string) (result targetResult, err error) {
+	startTime := time.Now()
+	result = targetResult{Target: target}
+
+	defer func() {
+		result.Duration = time.Since(startTime).Round(time.Millisecond).String()
+		result.ExitCode = grypeerr.ExitCode(err)
+		switch result.ExitCode {
+		case 0:
+			result.Status = targetPassed
+		case 2:
+			result.Status = targetFailed
+		default:
+			result.Status = targetError
+		}
+		if err != nil {
+			result.Error = err.Error()
+			log.WithFields("target", target, "error", err).Warn("unable to scan target")
+		}
+	}()
+
+	// the options are updated while scanning (e.g. with discovered VEX documents), so each target gets a copy
+	targetOpts := *opts
+	targetOpts.Ignore = slices.Clone(opts.Ignore)
+	targetOpts.VexDocuments = slices.Clone(opts.VexDocuments)
+
+	packages, pkgContext, s, err := catalogTarget(ctx, &targetOpts, target)
+	if err != nil {
+		return result, err
+	}
+	result.Packages = len(packages)
+
+	// the report files are only created for targets that could be cataloged
+	writer, err := makeScanResultWriter(&targetOpts, outputs, "")
+	if err != nil {
+		return result, err
+	}
+	for _, output := range outputs {
+		_, path, _ := strings.Cut(output, "=")
+		result.Reports = append(result.Reports, path)
+	}
+
+	in.packages, in.context, in.sbom, in.startTime = packages, pkgContext, s, startTime
+	doc, err := scanPackages(ctx, app, &targetOpts, in, writer)
+	if doc != nil {
+		result.Matches = len(doc.Matches)
+	}
+	return result, err
+}
+
+// readTargets reads the targets listed in the file (or stdin for "-"), one per line, ignoring blank lines and lines
+// starting with "#".
+func readTargets(path string) ([]string, error) {
+	var reader io.Reader
+	if path == "-" {
+		reader = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read targets file: %w", err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	var targets []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read targets file: %w", err)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets found in %s", path)
+	}
+	return targets, nil
+}
+
+// targetReportOutputs returns the outputs of each target (e.g. "json=<dir>/alpine_3.18.json"), with the report files
+// named after the target and output format. Targets with the same name get distinct files.
+func targetReportOutputs(targets []string, outputs []string, dir string) [][]string {
+	if len(outputs) == 0 {
+		outputs = []string{format.TableFormat.String()}
+	}
+
+	used := map[string]bool{}
+	result := make([][]string, len(targets))
+	for i, target := range targets {
+		base := strings.Trim(unsafeFileNameChars.ReplaceAllString(target, "_"), "_.")
+		if base == "" {
+			base = "target"
+		}
+		name := base
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[name] = true
+
+		for _, output := range outputs {
+			f := format.Parse(strings.TrimSpace(output))
+			result[i] = append(result[i], fmt.Sprintf("%s=%s", f, filepath.Join(dir, name+"."+f.String())))
+		}
+	}
+	return result
+}
+
+func newTargetsSummary(results []targetResult) targetsSummary {
+	summary := targetsSummary{Targets: results}
+	for _, r := range results {
+		switch r.Status {
+		case targetPassed:
+			summary.Passed++
+		case targetFailed:
+			summary.Failed++
+		default:
+			summary.Errored++
+		}
+	}
+	return summary
+}
+
+func writeTargetsSummary(summary targetsSummary, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("unable to create targets output directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to write targets summary: %w", err)
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", " ")
+	if err := encoder.Encode(summary); err != nil {
+		return fmt.Errorf("unable to write targets summary: %w", err)
+	}
+	return nil
+}
+
+func displayTargetsSummaryTable(summary targetsSummary, output io.Writer) error {
+	rows := [][]string{}
+	for _, r := range summary.Targets {
+		rows = append(rows, []string{r.Target, r.Status, fmt.Sprintf("%d", r.ExitCode), fmt.Sprintf("%d", r.Packages), fmt.Sprintf("%d", r.Matches), r.Duration})
+	}
+
+	table := newTable(output, []string{"Target", "Status", "Exit Code", "Packages", "Matches", "Duration"})
+	if err := table.Bulk(rows); err != nil {
+		return fmt.Errorf("failed to add table rows: %w", err)
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(output, "%d passed, %d failed, %d errored\n", summary.Passed, summary.Failed, summary.Errored)
+	return err
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readTargets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	require.NoError(t, os.WriteFile(path, []byte(`# production images
+alpine:3.18
+
+  registry:ghcr.io/org/app:v1
+sbom:./app.spdx.json
+`), 0o600))
+
+	targets, err := readTargets(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alpine:3.18", "registry:ghcr.io/org/app:v1", "sbom:./app.spdx.json"}, targets)
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	require.NoError(t, os.WriteFile(empty, []byte("# nothing to scan\n"), 0o600))
+	_, err = readTargets(empty)
+	require.ErrorContains(t, err, "no targets found")
+
+	_, err = readTargets(filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorContains(t, err, "unable to read targets file")
+}
+
+func Test_targetReportOutputs(t *testing.T) {
+	got := targetReportOutputs(
+		[]string{"alpine:3.18", "registry:alpine:3.18", "alpine:3.18", "dir:/"},
+		[]string{"json", "embedded-cyclonedx-vex-json"},
+		"out",
+	)
+	assert.Equal(t, [][]string{
+		{"json=" + filepath.Join("out", "alpine_3.18.json"), "cyclonedx-json=" + filepath.Join("out", "alpine_3.18.cyclonedx-json")},
+		{"json=" + filepath.Join("out", "registry_alpine_3.18.json"), "cyclonedx-json=" + filepath.Join("out", "registry_alpine_3.18.cyclonedx-json")},
+		{"json=" + filepath.Join("out", "alpine_3.18-2.json"), "cyclonedx-json=" + filepath.Join("out", "alpine_3.18-2.cyclonedx-json")},
+		{"json=" + filepath.Join("out", "dir.json"), "cyclonedx-json=" + filepath.Join("out", "dir.cyclonedx-json")},
+	}, got)
+
+	// the table format is the default
+	assert.Equal(t, [][]string{{"table=" + filepath.Join("out", "target.table")}}, targetReportOutputs([]string{"/"}, nil, "out"))
+}
+
+func Test_targetsSummary(t *testing.T) {
+	summary := newTargetsSummary([]targetResult{
+		{Target: "alpine:3.18", Status: targetPassed, Packages: 15, Duration: "1.2s"},
+		{Target: "debian:9", Status: targetFailed, ExitCode: 2, Packages: 90, Matches: 312, Duration: "3.4s"},
+		{Target: "missing:latest", Status: targetError, ExitCode: 1, Error: "failed to catalog", Duration: "0.5s"},
+	})
+	assert.Equal(t, 1, summary.Passed)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 1, summary.Errored)
+
+	sb := &strings.Builder{}
+	require.NoError(t, displayTargetsSummaryTable(summary, sb))
+	assert.Contains(t, sb.String(), "debian:9")
+	assert.Contains(t, sb.String(), "1 passed, 1 failed, 1 errored")
+
+	path := filepath.Join(t.TempDir(), "results", targetsSummaryFile)
+	require.NoError(t, writeTargetsSummary(summary, path))
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var got targetsSummary
+	require.NoError(t, json.Unmarshal(contents, &got))
+	assert.Equal(t, summary, got)
+}
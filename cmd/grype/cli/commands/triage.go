@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/cmd/grype/cli/commands/internal/triage"
+	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/log"
+)
+
+func Triage(app clio.Application) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "triage",
+		Short: "Manage triage decisions for vulnerability findings",
+	}
+
+	cmd.AddCommand(
+		TriageApply(app),
+	)
+
+	return cmd
+}
+
+type triageApplyOptions struct {
+	ConfigFile string `yaml:"config-file" json:"config-file" mapstructure:"config-file"`
+	VexFile    string `yaml:"vex-file" json:"vex-file" mapstructure:"vex-file"`
+}
+
+var _ clio.FlagAdder = (*triageApplyOptions)(nil)
+
+func (o *triageApplyOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.ConfigFile, "config-file", "", "grype configuration file to merge the ignore rules into (created if missing)")
+	flags.StringVarP(&o.VexFile, "vex-file", "", "file to write the OpenVEX statements for not_affected decisions to")
+}
+
+func TriageApply(app clio.Application) *cobra.Command {
+	id := app.ID()
+	opts := &triageApplyOptions{
+		ConfigFile: ".grype.yaml",
+		VexFile:    "triage.vex.json",
+	}
+
+	cmd := &cobra.Command{
+		Use:   "apply TRIAGE_FILE",
+		Short: "Convert a triage file into ignore rules and VEX statements",
+		Long: `Convert the decisions of a triage file into grype configuration and OpenVEX statements, so that triage decisions
+can be kept and reviewed in-repo. Decisions of "ignore" become ignore rules merged into the grype configuration file, while
+decisions of "not_affected" become OpenVEX statements written to the VEX file, which is added to the 'vex-documents' of
+the configuration. Expired decisions are skipped and their ignore rules removed from the configuration.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runTriageApply(id, *opts, args[0], time.Now())
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts *triageApplyOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+func runTriageApply(id clio.Identification, opts triageApplyOptions, path string, now time.Time) error {
+	f, err := triage.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	result := f.Apply(id.Name, now)
+	for _, e := range result.Expired {
+		log.WithFields("vulnerability", e.Vulnerability, "package", e.Package.Name, "expires", e.Expires).Warn("triage decision has expired")
+	}
+
+	update := triage.ConfigUpdate{
+		AddIgnoreRules:    result.IgnoreRules,
+		RemoveIgnoreRules: result.ExpiredIgnoreRules,
+	}
+	// the VEX file is rewritten even when every not_affected decision has expired, so it never holds stale statements
+	writeVEX := slices.ContainsFunc(f.Entries, func(e triage.Entry) bool { return e.Decision == triage.NotAffected })
+	if writeVEX {
+		if err := writeTriageVEX(opts.VexFile, result); err != nil {
+			return err
+		}
+		update.AddVexDocuments = []string{opts.VexFile}
+	}
+
+	changes, err := triage.UpdateConfig(opts.ConfigFile, update)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "applied %d triage decisions from %s (%d expired)\n", len(f.Entries)-len(result.Expired), path, len(result.Expired))
+	fmt.Fprintf(&sb, "%s: %d ignore rules added, %d removed\n", opts.ConfigFile, changes.AddedIgnoreRules, changes.RemovedIgnoreRules)
+	if writeVEX {
+		fmt.Fprintf(&sb, "%s: %d VEX statements written\n", opts.VexFile, len(result.VEX.Statements))
+	}
+	bus.Report(sb.String())
+	return nil
+}
+
+func writeTriageVEX(path string, result triage.Result) error {
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("unable to create VEX file: %w", err)
+	}
+	defer log.CloseAndLogError(f, path)
+
+	if err := result.VEX.ToJSON(f); err != nil {
+		return fmt.Errorf("unable to write VEX file: %w", err)
+	}
+	return nil
+}
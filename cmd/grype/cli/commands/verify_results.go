@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/internal/dsse"
+	"github.com/anchore/grype/internal/format"
+)
+
+type verifyResultsOptions struct {
+	Key       string `yaml:"key" json:"key" mapstructure:"key"`
+	Signature string `yaml:"signature" json:"signature" mapstructure:"signature"`
+}
+
+var _ clio.FlagAdder = (*verifyResultsOptions)(nil)
+
+func (o *verifyResultsOptions) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.Key, "key", "", "PEM public key to verify the signature with")
+	flags.StringVarP(&o.Signature, "signature", "", "DSSE envelope of the report (default is <report>.dsse.json)")
+}
+
+func VerifyResults(app clio.Application) *cobra.Command {
+	opts := &verifyResultsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify-results REPORT --key PUBLIC_KEY",
+		Short: "Verify the signature of a report produced with --sign-results",
+		Long: `Verify that a report file was signed (with 'grype --sign-results') by the private key matching the given public key
+and that it has not been modified since. Exits with a non-zero status if the signature is not valid.`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: disableUI(app),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runVerifyResults(*opts, args[0])
+		},
+	}
+
+	// prevent from being shown in the grype config
+	type configWrapper struct {
+		Opts *verifyResultsOptions `json:"-" yaml:"-" mapstructure:"-"`
+	}
+
+	return app.SetupCommand(cmd, &configWrapper{opts})
+}
+
+func runVerifyResults(opts verifyResultsOptions, reportPath string) error {
+	if opts.Key == "" {
+		return fmt.Errorf("a public key is required (--key)")
+	}
+	sigPath := opts.Signature
+	if sigPath == "" {
+		sigPath = format.SignaturePath(reportPath)
+	}
+
+	verifier, err := dsse.LoadVerifier(opts.Key)
+	if err != nil {
+		return err
+	}
+	env, err := dsse.ReadEnvelope(sigPath)
+	if err != nil {
+		return err
+	}
+	report, err := os.ReadFile(filepath.Clean(reportPath))
+	if err != nil {
+		return fmt.Errorf("unable to read report: %w", err)
+	}
+
+	payload, err := verifier.Verify(*env)
+	if err != nil {
+		return fmt.Errorf("report signature verification failed: %w", err)
+	}
+	if !bytes.Equal(payload, report) {
+		return fmt.Errorf("report signature verification failed: %s does not match the signed report", reportPath)
+	}
+
+	return stderrPrintLnf("Verified signature of %s (%s)", reportPath, env.PayloadType)
+}
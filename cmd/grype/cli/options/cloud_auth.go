@@ -0,0 +1,79 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/internal/cloudauth"
+)
+
+// cloudAuth configures getting short-lived registry credentials from the cloud environment grype is running in.
+type cloudAuth struct {
+	ECR ecrAuth `yaml:"ecr" json:"ecr" mapstructure:"ecr"`
+	GCR gcrAuth `yaml:"gcr" json:"gcr" mapstructure:"gcr"`
+	ACR acrAuth `yaml:"acr" json:"acr" mapstructure:"acr"`
+}
+
+type ecrAuth struct {
+	Enabled     bool   `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	RoleARN     string `yaml:"role-arn" json:"role-arn" mapstructure:"role-arn"`
+	ExternalID  string `yaml:"external-id" json:"external-id" mapstructure:"external-id"`
+	SessionName string `yaml:"session-name" json:"session-name" mapstructure:"session-name"`
+}
+
+type gcrAuth struct {
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+}
+
+type acrAuth struct {
+	Enabled  bool   `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	ClientID string `yaml:"client-id" json:"client-id" mapstructure:"client-id"`
+	TenantID string `yaml:"tenant-id" json:"tenant-id" mapstructure:"tenant-id"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*cloudAuth)(nil)
+
+func (cfg *cloudAuth) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.ECR.Enabled, `get authorization tokens for Amazon ECR registries (<account>.dkr.ecr.<region>.amazonaws.com) with the
+default AWS credential chain (environment, shared config, web identity, container and instance roles)`)
+	descriptions.Add(&cfg.ECR.RoleARN, `role assumed to get ECR authorization tokens (e.g. "arn:aws:iam::123456789012:role/scanner")`)
+	descriptions.Add(&cfg.ECR.ExternalID, `external ID passed when assuming the role`)
+	descriptions.Add(&cfg.ECR.SessionName, `session name of the assumed role`)
+	descriptions.Add(&cfg.GCR.Enabled, `get access tokens for Google Container Registry (gcr.io) and Artifact Registry (*-docker.pkg.dev) with
+application default credentials (including GKE workload identity and the compute metadata server)`)
+	descriptions.Add(&cfg.ACR.Enabled, `get refresh tokens for Azure Container Registry (*.azurecr.io) with workload identity (AZURE_FEDERATED_TOKEN_FILE)
+or, barring that, the managed identity of the host`)
+	descriptions.Add(&cfg.ACR.ClientID, `client ID of the workload or user-assigned managed identity (default: AZURE_CLIENT_ID)`)
+	descriptions.Add(&cfg.ACR.TenantID, `tenant ID of the workload identity (default: AZURE_TENANT_ID)`)
+}
+
+func (cfg *cloudAuth) PostLoad() error {
+	if cfg.ECR.RoleARN != "" && !strings.HasPrefix(cfg.ECR.RoleARN, "arn:") {
+		return fmt.Errorf("bad registry.cloud-auth.ecr.role-arn value: %q is not an ARN", cfg.ECR.RoleARN)
+	}
+	return nil
+}
+
+// ToConfig returns the cloud registry authentication flows to use.
+func (cfg cloudAuth) ToConfig() cloudauth.Config {
+	return cloudauth.Config{
+		ECR: cloudauth.ECRConfig{
+			Enabled:     cfg.ECR.Enabled,
+			RoleARN:     cfg.ECR.RoleARN,
+			ExternalID:  cfg.ECR.ExternalID,
+			SessionName: cfg.ECR.SessionName,
+		},
+		GCR: cloudauth.GCRConfig{
+			Enabled: cfg.GCR.Enabled,
+		},
+		ACR: cloudauth.ACRConfig{
+			Enabled:  cfg.ACR.Enabled,
+			ClientID: cfg.ACR.ClientID,
+			TenantID: cfg.ACR.TenantID,
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/internal/cloudauth"
+)
+
+func TestCloudAuth_PostLoad(t *testing.T) {
+	cfg := cloudAuth{ECR: ecrAuth{Enabled: true, RoleARN: "arn:aws:iam::123456789012:role/scanner"}}
+	require.NoError(t, cfg.PostLoad())
+
+	cfg.ECR.RoleARN = "scanner"
+	require.EqualError(t, cfg.PostLoad(), `bad registry.cloud-auth.ecr.role-arn value: "scanner" is not an ARN`)
+}
+
+func TestCloudAuth_ToConfig(t *testing.T) {
+	cfg := cloudAuth{
+		ECR: ecrAuth{Enabled: true, RoleARN: "arn:aws:iam::123456789012:role/scanner", ExternalID: "external", SessionName: "grype"},
+		ACR: acrAuth{Enabled: true, ClientID: "client-id", TenantID: "tenant-id"},
+	}
+	assert.Equal(t, cloudauth.Config{
+		ECR: cloudauth.ECRConfig{Enabled: true, RoleARN: "arn:aws:iam::123456789012:role/scanner", ExternalID: "external", SessionName: "grype"},
+		ACR: cloudauth.ACRConfig{Enabled: true, ClientID: "client-id", TenantID: "tenant-id"},
+	}, cfg.ToConfig())
+}
+
+func Test_registry_ToOptions_cloudAuth(t *testing.T) {
+	cfg := registry{CloudAuth: cloudAuth{GCR: gcrAuth{Enabled: true}}}
+	assert.NotNil(t, cfg.ToOptions(TLS{}, "").Keychain)
+}
@@ -0,0 +1,63 @@
+package options
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/internal/cvss"
+)
+
+// CVSSEnvironment configures the CVSS temporal and environmental modifiers applied per asset class.
+type CVSSEnvironment struct {
+	AssetClass string            `yaml:"asset-class" json:"asset-class" mapstructure:"asset-class"` // --asset-class, the class of the asset being scanned
+	Classes    map[string]string `yaml:"classes" json:"classes" mapstructure:"classes"`             // CVSS modifiers by asset class
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*CVSSEnvironment)(nil)
+
+func defaultCVSSEnvironment() CVSSEnvironment {
+	return CVSSEnvironment{}
+}
+
+func (c *CVSSEnvironment) PostLoad() error {
+	for class, modifiers := range c.Classes {
+		if _, err := cvss.ParseModifiers(modifiers); err != nil {
+			return fmt.Errorf("bad cvss-environment.classes value for %q: %w", class, err)
+		}
+	}
+	_, err := c.ToModifiers()
+	return err
+}
+
+func (c *CVSSEnvironment) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&c.AssetClass, `the class of the asset being scanned (one of the configured classes), selecting the CVSS modifiers used to
+compute adjusted scores (scores are not adjusted when empty, same as --asset-class)`)
+	descriptions.Add(&c.Classes, `CVSS temporal/threat and environmental modifiers by asset class, in vector notation, for example:
+  production: "CR:H/IR:H/AR:H/E:P"
+  internal: "MAV:A/CR:L/IR:L/AR:L"
+Modifiers of different CVSS versions may be mixed: only the metrics defined by the version of a vulnerability vector
+are applied to it. The adjusted score and severity are reported next to the base score, and are used for sorting by
+severity and for --fail-on`)
+}
+
+// ToModifiers returns the CVSS modifiers of the selected asset class (empty when no class is selected).
+func (c CVSSEnvironment) ToModifiers() (cvss.Modifiers, error) {
+	if c.AssetClass == "" {
+		return cvss.Modifiers{}, nil
+	}
+	modifiers, ok := c.Classes[c.AssetClass]
+	if !ok {
+		classes := make([]string, 0, len(c.Classes))
+		for class := range c.Classes {
+			classes = append(classes, class)
+		}
+		slices.Sort(classes)
+		return cvss.Modifiers{}, fmt.Errorf("bad --asset-class value %q: must be one of the configured cvss-environment classes [%s]", c.AssetClass, strings.Join(classes, ", "))
+	}
+	return cvss.ParseModifiers(modifiers)
+}
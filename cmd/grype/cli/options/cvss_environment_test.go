@@ -0,0 +1,58 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCVSSEnvironment_PostLoad(t *testing.T) {
+	classes := map[string]string{
+		"production": "CR:H/IR:H/AR:H/E:P",
+		"internal":   "MAV:A/CR:L",
+	}
+
+	tests := []struct {
+		name          string
+		cfg           CVSSEnvironment
+		wantModifiers string
+		wantErr       require.ErrorAssertionFunc
+	}{
+		{
+			name: "no asset class",
+			cfg:  CVSSEnvironment{Classes: classes},
+		},
+		{
+			name:          "configured asset class",
+			cfg:           CVSSEnvironment{AssetClass: "internal", Classes: classes},
+			wantModifiers: "MAV:A/CR:L",
+		},
+		{
+			name:    "unknown asset class",
+			cfg:     CVSSEnvironment{AssetClass: "staging", Classes: classes},
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid modifiers",
+			cfg:     CVSSEnvironment{Classes: map[string]string{"production": "AV:L"}},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			err := tt.cfg.PostLoad()
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			modifiers, err := tt.cfg.ToModifiers()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantModifiers, modifiers.String())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package options
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/anchore/clio"
@@ -9,19 +10,35 @@ import (
 	"github.com/anchore/grype/grype/db/v6/installation"
 )
 
+const (
+	sqliteBackend   = "sqlite"
+	postgresBackend = "postgres"
+)
+
 type Database struct {
-	ID                      clio.Identification `yaml:"-" json:"-" mapstructure:"-"`
-	Dir                     string              `yaml:"cache-dir" json:"cache-dir" mapstructure:"cache-dir"`
-	UpdateURL               string              `yaml:"update-url" json:"update-url" mapstructure:"update-url"`
-	CACert                  string              `yaml:"ca-cert" json:"ca-cert" mapstructure:"ca-cert"`
-	AutoUpdate              bool                `yaml:"auto-update" json:"auto-update" mapstructure:"auto-update"`
-	ValidateByHashOnStart   bool                `yaml:"validate-by-hash-on-start" json:"validate-by-hash-on-start" mapstructure:"validate-by-hash-on-start"`
-	ValidateAge             bool                `yaml:"validate-age" json:"validate-age" mapstructure:"validate-age"`
-	MaxAllowedBuiltAge      time.Duration       `yaml:"max-allowed-built-age" json:"max-allowed-built-age" mapstructure:"max-allowed-built-age"`
-	RequireUpdateCheck      bool                `yaml:"require-update-check" json:"require-update-check" mapstructure:"require-update-check"`
-	UpdateAvailableTimeout  time.Duration       `yaml:"update-available-timeout" json:"update-available-timeout" mapstructure:"update-available-timeout"`
-	UpdateDownloadTimeout   time.Duration       `yaml:"update-download-timeout" json:"update-download-timeout" mapstructure:"update-download-timeout"`
-	MaxUpdateCheckFrequency time.Duration       `yaml:"max-update-check-frequency" json:"max-update-check-frequency" mapstructure:"max-update-check-frequency"`
+	ID                      clio.Identification      `yaml:"-" json:"-" mapstructure:"-"`
+	Dir                     string                   `yaml:"cache-dir" json:"cache-dir" mapstructure:"cache-dir"`
+	UpdateURL               string                   `yaml:"update-url" json:"update-url" mapstructure:"update-url"`
+	FallbackUpdateURLs      []string                 `yaml:"fallback-update-urls" json:"fallback-update-urls" mapstructure:"fallback-update-urls"`
+	CACert                  string                   `yaml:"ca-cert" json:"ca-cert" mapstructure:"ca-cert"`
+	AutoUpdate              bool                     `yaml:"auto-update" json:"auto-update" mapstructure:"auto-update"`
+	ValidateByHashOnStart   bool                     `yaml:"validate-by-hash-on-start" json:"validate-by-hash-on-start" mapstructure:"validate-by-hash-on-start"`
+	ValidateAge             bool                     `yaml:"validate-age" json:"validate-age" mapstructure:"validate-age"`
+	MaxAllowedBuiltAge      time.Duration            `yaml:"max-allowed-built-age" json:"max-allowed-built-age" mapstructure:"max-allowed-built-age"`
+	RequireUpdateCheck      bool                     `yaml:"require-update-check" json:"require-update-check" mapstructure:"require-update-check"`
+	UpdateAvailableTimeout  time.Duration            `yaml:"update-available-timeout" json:"update-available-timeout" mapstructure:"update-available-timeout"`
+	UpdateDownloadTimeout   time.Duration            `yaml:"update-download-timeout" json:"update-download-timeout" mapstructure:"update-download-timeout"`
+	MaxUpdateCheckFrequency time.Duration            `yaml:"max-update-check-frequency" json:"max-update-check-frequency" mapstructure:"max-update-check-frequency"`
+	RetainPrevious          int                      `yaml:"retain-previous" json:"retain-previous" mapstructure:"retain-previous"`
+	Trace                   string                   `yaml:"trace" json:"trace" mapstructure:"trace"`
+	TraceTop                int                      `yaml:"trace-top" json:"trace-top" mapstructure:"trace-top"`
+	RecordInteractions      string                   `yaml:"record-interactions" json:"record-interactions" mapstructure:"record-interactions"`
+	ReplayInteractions      string                   `yaml:"replay-interactions" json:"replay-interactions" mapstructure:"replay-interactions"`
+	Backend                 string                   `yaml:"backend" json:"backend" mapstructure:"backend"`
+	SQLite                  databaseSQLite           `yaml:"sqlite" json:"sqlite" mapstructure:"sqlite"`
+	Postgres                databasePostgres         `yaml:"postgres" json:"postgres" mapstructure:"postgres"`
+	Hooks                   databaseHooks            `yaml:"hooks" json:"hooks" mapstructure:"hooks"`
+	ProviderPriority        databaseProviderPriority `yaml:"provider-priority" json:"provider-priority" mapstructure:"provider-priority"`
 }
 
 var _ interface {
@@ -46,13 +63,17 @@ func DefaultDatabase(id clio.Identification) Database {
 		UpdateDownloadTimeout:   distConfig.UpdateTimeout,
 		MaxUpdateCheckFrequency: installConfig.UpdateCheckMaxFrequency,
 		CACert:                  distConfig.CACert,
+		TraceTop:                10,
+		Backend:                 sqliteBackend,
 	}
 }
 
 func (cfg *Database) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	descriptions.Add(&cfg.Dir, `location to write the vulnerability database cache`)
 	descriptions.Add(&cfg.UpdateURL, `URL of the vulnerability database`)
-	descriptions.Add(&cfg.CACert, `certificate to trust download the database and listing file`)
+	descriptions.Add(&cfg.FallbackUpdateURLs, `mirrors of update-url, tried in order when the database listing or archive cannot be downloaded
+(mirrors that failed recently are tried last, for up to an hour)`)
+	descriptions.Add(&cfg.CACert, `certificate to trust download the database and listing file (deprecated: use tls.ca-cert)`)
 	descriptions.Add(&cfg.AutoUpdate, `check for database updates on execution`)
 	descriptions.Add(&cfg.ValidateAge, `ensure db build is no older than the max-allowed-built-age`)
 	descriptions.Add(&cfg.ValidateByHashOnStart, `validate the database matches the known hash each execution`)
@@ -65,10 +86,57 @@ This file is ~156KiB as of 2024-04-17 so the download should be quick; adjust as
 	descriptions.Add(&cfg.UpdateDownloadTimeout, `Timeout for downloading actual vulnerability DB
 The DB is ~156MB as of 2024-04-17 so slower connections may exceed the default timeout; adjust as needed`)
 	descriptions.Add(&cfg.MaxUpdateCheckFrequency, `Maximum frequency to check for vulnerability database updates`)
+	descriptions.Add(&cfg.RetainPrevious, `number of previously installed vulnerability database builds to keep on disk after an update,
+allowing 'grype db rollback' to switch back to an earlier build without downloading it again (0 disables retention).
+Note: a rolled back database may be replaced again on the next update check, consider disabling auto-update while investigating`)
+	descriptions.Add(&cfg.Trace, `write every SQL query issued against the vulnerability database (with duration and row count) to the given file,
+followed by a summary of the slowest queries (same as --db-trace)`)
+	descriptions.Add(&cfg.TraceTop, `number of the slowest queries to summarize at the end of the DB trace`)
+	descriptions.Add(&cfg.RecordInteractions, `write every vulnerability database search made during the scan (with the records it returned) to the given file,
+which can be replayed with replay-interactions without the database (same as --record-db-interactions)`)
+	descriptions.Add(&cfg.ReplayInteractions, `scan using a file written by record-interactions instead of the vulnerability database,
+failing on any search that was not recorded (same as --replay-db-interactions)`)
+	descriptions.Add(&cfg.Backend, `where the vulnerability database is read from: "sqlite" (the database installed within cache-dir) or "postgres"
+(a shared database at db.postgres.dsn, published to by running 'grype db update' with this backend; scans never update it)`)
+}
+
+// postgresDSN returns the DSN of the shared Postgres database, if configured as the backend.
+func (cfg Database) postgresDSN() string {
+	if cfg.Backend != postgresBackend {
+		return ""
+	}
+	return cfg.Postgres.DSN
 }
 
 func (cfg *Database) PostLoad() error {
 	var err error
 	cfg.Dir, err = homedir.Expand(cfg.Dir)
-	return err
+	if err != nil {
+		return err
+	}
+	switch cfg.Backend {
+	case "", sqliteBackend:
+		cfg.Backend = sqliteBackend
+	case postgresBackend:
+		if cfg.Postgres.DSN == "" {
+			return fmt.Errorf("db.postgres.dsn must be set when using the postgres backend")
+		}
+	default:
+		return fmt.Errorf("unsupported db.backend %q (supported: %s, %s)", cfg.Backend, sqliteBackend, postgresBackend)
+	}
+	if cfg.RetainPrevious < 0 {
+		return fmt.Errorf("db.retain-previous must not be negative")
+	}
+	if cfg.RecordInteractions != "" && cfg.ReplayInteractions != "" {
+		return fmt.Errorf("db.record-interactions and db.replay-interactions cannot be used together")
+	}
+	for _, path := range []*string{&cfg.Trace, &cfg.RecordInteractions, &cfg.ReplayInteractions} {
+		if *path == "" {
+			continue
+		}
+		if *path, err = homedir.Expand(*path); err != nil {
+			return err
+		}
+	}
+	return nil
 }
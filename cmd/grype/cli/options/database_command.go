@@ -1,6 +1,8 @@
 package options
 
 import (
+	"path/filepath"
+
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/grype/db/v6/distribution"
 	"github.com/anchore/grype/grype/db/v6/installation"
@@ -10,6 +12,9 @@ type DatabaseCommand struct {
 	DB           Database     `yaml:"db" json:"db" mapstructure:"db"`
 	Experimental Experimental `yaml:"exp" json:"exp" mapstructure:"exp"`
 	Developer    developer    `yaml:"dev" json:"dev" mapstructure:"dev"`
+	TLS          TLS          `yaml:"tls" json:"tls" mapstructure:"tls"`
+	Proxy        Proxy        `yaml:"proxy" json:"proxy" mapstructure:"proxy"`
+	Network      Network      `yaml:"network" json:"network" mapstructure:"network"`
 }
 
 func DefaultDatabaseCommand(id clio.Identification) *DatabaseCommand {
@@ -19,7 +24,8 @@ func DefaultDatabaseCommand(id clio.Identification) *DatabaseCommand {
 	// we want to validate by hash during Status checks
 	dbDefaults.ValidateByHashOnStart = true
 	return &DatabaseCommand{
-		DB: dbDefaults,
+		DB:      dbDefaults,
+		Network: DefaultNetwork(),
 	}
 }
 
@@ -31,6 +37,10 @@ func (cfg DatabaseCommand) ToCuratorConfig() installation.Config {
 		MaxAllowedBuiltAge:      cfg.DB.MaxAllowedBuiltAge,
 		UpdateCheckMaxFrequency: cfg.DB.MaxUpdateCheckFrequency,
 		Debug:                   cfg.Developer.DB.Debug,
+		Pragmas:                 cfg.DB.SQLite.Pragmas,
+		Hooks:                   cfg.DB.Hooks.toUpdateHooks(),
+		RetainPrevious:          cfg.DB.RetainPrevious,
+		PostgresDSN:             cfg.DB.postgresDSN(),
 	}
 }
 
@@ -38,9 +48,23 @@ func (cfg DatabaseCommand) ToClientConfig() distribution.Config {
 	return distribution.Config{
 		ID:                 cfg.DB.ID,
 		LatestURL:          cfg.DB.UpdateURL,
+		FallbackURLs:       cfg.DB.FallbackUpdateURLs,
+		MirrorHealthFile:   cfg.mirrorHealthFile(),
+		TLS:                cfg.TLS.ToConfig(),
 		CACert:             cfg.DB.CACert,
+		Proxy:              cfg.Proxy.DBRule(),
+		Retry:              cfg.Network.ToConfig(),
+		Bandwidth:          cfg.Network.Limiter(),
 		RequireUpdateCheck: cfg.DB.RequireUpdateCheck,
 		CheckTimeout:       cfg.DB.UpdateAvailableTimeout,
 		UpdateTimeout:      cfg.DB.UpdateDownloadTimeout,
 	}
 }
+
+// mirrorHealthFile is where failures of the database mirrors are kept between runs (within the DB cache dir).
+func (cfg DatabaseCommand) mirrorHealthFile() string {
+	if cfg.DB.Dir == "" || len(cfg.DB.FallbackUpdateURLs) == 0 {
+		return ""
+	}
+	return filepath.Join(cfg.DB.Dir, distribution.MirrorHealthFileName)
+}
@@ -0,0 +1,30 @@
+package options
+
+import (
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/db/v6/installation"
+)
+
+type databaseHooks struct {
+	PreUpdate  []string `yaml:"pre-update" json:"pre-update" mapstructure:"pre-update"`
+	PostUpdate []string `yaml:"post-update" json:"post-update" mapstructure:"post-update"`
+}
+
+var _ clio.FieldDescriber = (*databaseHooks)(nil)
+
+func (cfg *databaseHooks) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.PreUpdate, `shell commands to run after a new vulnerability database is found but before it replaces the current one
+(e.g. to snapshot the old database); a failing command aborts the update.
+Details of the update are provided in the GRYPE_HOOK_EVENT, GRYPE_HOOK_DB_DIR, GRYPE_HOOK_DB_FILE, GRYPE_HOOK_URL,
+GRYPE_HOOK_CURRENT_BUILT, GRYPE_HOOK_CURRENT_SCHEMA_VERSION, GRYPE_HOOK_UPDATE_BUILT, GRYPE_HOOK_UPDATE_SCHEMA_VERSION
+and GRYPE_HOOK_UPDATE_CHECKSUM environment variables`)
+	descriptions.Add(&cfg.PostUpdate, `shell commands to run after a new vulnerability database has been installed (e.g. to notify a channel or
+trigger re-scans); failures are logged but do not fail the update. The same environment variables as pre-update are provided`)
+}
+
+func (cfg databaseHooks) toUpdateHooks() installation.UpdateHooks {
+	return installation.UpdateHooks{
+		PreUpdate:  cfg.PreUpdate,
+		PostUpdate: cfg.PostUpdate,
+	}
+}
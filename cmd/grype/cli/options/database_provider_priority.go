@@ -0,0 +1,48 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/match"
+)
+
+type databaseProviderPriority struct {
+	Order           []string `yaml:"order" json:"order" mapstructure:"order"`
+	AllowDuplicates bool     `yaml:"allow-duplicates" json:"allow-duplicates" mapstructure:"allow-duplicates"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*databaseProviderPriority)(nil)
+
+func (cfg *databaseProviderPriority) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.Order, `vulnerability data providers in order of precedence (e.g. ["rhel", "nvd"]); providers not listed have the
+lowest priority. Matches of a lower priority provider for a vulnerability (or its CVE) and package already reported
+by a higher priority provider are moved to the ignored matches (empty keeps all matches)`)
+	descriptions.Add(&cfg.AllowDuplicates, `keep the matches of lower priority providers that are already reported by a higher priority provider`)
+}
+
+func (cfg *databaseProviderPriority) PostLoad() error {
+	seen := make(map[string]bool)
+	for i, provider := range cfg.Order {
+		normalized := match.NormalizeProvider(provider)
+		if normalized == "" {
+			return fmt.Errorf("db.provider-priority.order must not contain empty providers")
+		}
+		if seen[normalized] {
+			return fmt.Errorf("db.provider-priority.order lists provider %q more than once", provider)
+		}
+		seen[normalized] = true
+		cfg.Order[i] = normalized
+	}
+	return nil
+}
+
+func (cfg databaseProviderPriority) ToProviderPriority() match.ProviderPriority {
+	return match.ProviderPriority{
+		Providers:       cfg.Order,
+		AllowDuplicates: cfg.AllowDuplicates,
+	}
+}
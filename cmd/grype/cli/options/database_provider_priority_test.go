@@ -0,0 +1,51 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseProviderPriority_PostLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   []string
+		want    []string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "no order",
+		},
+		{
+			name:  "normalizes providers",
+			order: []string{" RHEL ", "Ubuntu", "nvd"},
+			want:  []string{"redhat", "ubuntu", "nvd"},
+		},
+		{
+			name:    "empty provider",
+			order:   []string{"nvd", " "},
+			wantErr: require.Error,
+		},
+		{
+			name:    "duplicate provider",
+			order:   []string{"rhel", "redhat"},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			cfg := databaseProviderPriority{Order: tt.order}
+			err := cfg.PostLoad()
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, cfg.Order)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	openvex "github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/clio"
+)
+
+// DBSearchEmit configures emitting the results of a search as starter suppression artifacts (ignore rules or VEX
+// statements) instead of reporting the results.
+type DBSearchEmit struct {
+	VEX           bool   `yaml:"emit-vex" json:"emit-vex" mapstructure:"emit-vex"`
+	IgnoreRules   bool   `yaml:"emit-ignore-rules" json:"emit-ignore-rules" mapstructure:"emit-ignore-rules"`
+	Reason        string `yaml:"reason" json:"reason" mapstructure:"reason"`
+	Justification string `yaml:"justification" json:"justification" mapstructure:"justification"`
+}
+
+func (c *DBSearchEmit) AddFlags(flags clio.FlagSet) {
+	flags.BoolVarP(&c.VEX, "emit-vex", "", "emit an OpenVEX document with a statement for each vulnerability found instead of the results")
+	flags.BoolVarP(&c.IgnoreRules, "emit-ignore-rules", "", "emit an ignore file with a rule for each vulnerability found instead of the results")
+	flags.StringVarP(&c.Reason, "reason", "", "why the vulnerabilities found do not apply (the reason of the emitted ignore rules, or the impact statement of the emitted VEX statements)")
+	flags.StringVarP(&c.Justification, "justification", "", fmt.Sprintf("OpenVEX justification of why the emitted VEX statements are not affected (%s)", strings.Join(openvex.Justifications(), ", ")))
+}
+
+func (c *DBSearchEmit) PostLoad() error {
+	if c.VEX && c.IgnoreRules {
+		return errors.New("cannot emit both VEX statements and ignore rules")
+	}
+	if c.Justification != "" {
+		if !c.VEX {
+			return errors.New("a justification can only be given when emitting VEX statements (use --emit-vex)")
+		}
+		if !openvex.Justification(c.Justification).Valid() {
+			return fmt.Errorf("invalid justification %q (valid values: %s)", c.Justification, strings.Join(openvex.Justifications(), ", "))
+		}
+	}
+	if c.Reason != "" && !c.Enabled() {
+		return errors.New("a reason can only be given when emitting VEX statements or ignore rules (use --emit-vex or --emit-ignore-rules)")
+	}
+	return nil
+}
+
+// Enabled indicates if suppression artifacts are emitted instead of the results of the search.
+func (c DBSearchEmit) Enabled() bool {
+	return c.VEX || c.IgnoreRules
+}
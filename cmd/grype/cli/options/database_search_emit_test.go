@@ -0,0 +1,58 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBSearchEmitPostLoad(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          DBSearchEmit
+		expectedErrMsg string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:  "ignore rules with a reason",
+			input: DBSearchEmit{IgnoreRules: true, Reason: "not reachable"},
+		},
+		{
+			name:  "VEX with a justification",
+			input: DBSearchEmit{VEX: true, Justification: "vulnerable_code_not_present"},
+		},
+		{
+			name:           "both VEX and ignore rules",
+			input:          DBSearchEmit{VEX: true, IgnoreRules: true},
+			expectedErrMsg: "cannot emit both",
+		},
+		{
+			name:           "invalid justification",
+			input:          DBSearchEmit{VEX: true, Justification: "not_my_problem"},
+			expectedErrMsg: "invalid justification \"not_my_problem\"",
+		},
+		{
+			name:           "justification without VEX",
+			input:          DBSearchEmit{IgnoreRules: true, Justification: "vulnerable_code_not_present"},
+			expectedErrMsg: "use --emit-vex",
+		},
+		{
+			name:           "reason without emitting",
+			input:          DBSearchEmit{Reason: "not reachable"},
+			expectedErrMsg: "a reason can only be given",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.PostLoad()
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package options
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+
+	"github.com/anchore/clio"
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+type DBSearchKnownExploited struct {
+	CVEs []string `yaml:"cves" json:"cves" mapstructure:"cves"`
+
+	AddedAfter  string `yaml:"added-after" json:"added-after" mapstructure:"added-after"`
+	AddedBefore string `yaml:"added-before" json:"added-before" mapstructure:"added-before"`
+	Ransomware  string `yaml:"ransomware" json:"ransomware" mapstructure:"ransomware"`
+
+	Specs []v6.KnownExploitedSpecifier `yaml:"-" json:"-" mapstructure:"-"`
+}
+
+func (c *DBSearchKnownExploited) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&c.AddedAfter, "added-after", "", "only show entries added to the KEV catalog on or after the given date (format: YYYY-MM-DD)")
+	flags.StringVarP(&c.AddedBefore, "added-before", "", "only show entries added to the KEV catalog on or before the given date (format: YYYY-MM-DD)")
+	flags.StringVarP(&c.Ransomware, "ransomware", "", "only show entries with the given known ransomware campaign use (known, unknown)")
+}
+
+func (c *DBSearchKnownExploited) PostLoad() error {
+	// note: this may be called multiple times, so we need to reset the specs each time
+	c.Specs = nil
+
+	addedAfter, err := parseDBSearchDate(c.AddedAfter, "added-after")
+	if err != nil {
+		return err
+	}
+	addedBefore, err := parseDBSearchDate(c.AddedBefore, "added-before")
+	if err != nil {
+		return err
+	}
+	if addedAfter != nil && addedBefore != nil && addedAfter.After(*addedBefore) {
+		return fmt.Errorf("added-after (%s) must not be later than added-before (%s)", c.AddedAfter, c.AddedBefore)
+	}
+
+	switch strings.ToLower(c.Ransomware) {
+	case "", "known", "unknown":
+	default:
+		return fmt.Errorf("invalid ransomware value: %q (valid values: known, unknown)", c.Ransomware)
+	}
+
+	base := v6.KnownExploitedSpecifier{
+		AddedAfter:    addedAfter,
+		AddedBefore:   addedBefore,
+		RansomwareUse: c.Ransomware,
+	}
+
+	if len(c.CVEs) == 0 {
+		c.Specs = append(c.Specs, base)
+		return nil
+	}
+
+	for _, cve := range c.CVEs {
+		spec := base
+		spec.CVE = cve
+		c.Specs = append(c.Specs, spec)
+	}
+	return nil
+}
+
+type DBSearchEPSS struct {
+	CVEs []string `yaml:"cves" json:"cves" mapstructure:"cves"`
+
+	MinScore      string `yaml:"min-score" json:"min-score" mapstructure:"min-score"`
+	MaxScore      string `yaml:"max-score" json:"max-score" mapstructure:"max-score"`
+	MinPercentile string `yaml:"min-percentile" json:"min-percentile" mapstructure:"min-percentile"`
+	MaxPercentile string `yaml:"max-percentile" json:"max-percentile" mapstructure:"max-percentile"`
+
+	Specs []v6.EpssSpecifier `yaml:"-" json:"-" mapstructure:"-"`
+}
+
+func (c *DBSearchEPSS) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&c.MinScore, "min-score", "", "only show entries with an EPSS score greater than or equal to the given value (0.0-1.0)")
+	flags.StringVarP(&c.MaxScore, "max-score", "", "only show entries with an EPSS score less than or equal to the given value (0.0-1.0)")
+	flags.StringVarP(&c.MinPercentile, "min-percentile", "", "only show entries with an EPSS percentile greater than or equal to the given value (0.0-1.0)")
+	flags.StringVarP(&c.MaxPercentile, "max-percentile", "", "only show entries with an EPSS percentile less than or equal to the given value (0.0-1.0)")
+}
+
+func (c *DBSearchEPSS) PostLoad() error {
+	// note: this may be called multiple times, so we need to reset the specs each time
+	c.Specs = nil
+
+	var base v6.EpssSpecifier
+	var err error
+	if base.MinScore, err = parseDBSearchRatio(c.MinScore, "min-score"); err != nil {
+		return err
+	}
+	if base.MaxScore, err = parseDBSearchRatio(c.MaxScore, "max-score"); err != nil {
+		return err
+	}
+	if base.MinPercentile, err = parseDBSearchRatio(c.MinPercentile, "min-percentile"); err != nil {
+		return err
+	}
+	if base.MaxPercentile, err = parseDBSearchRatio(c.MaxPercentile, "max-percentile"); err != nil {
+		return err
+	}
+
+	if base.MinScore != nil && base.MaxScore != nil && *base.MinScore > *base.MaxScore {
+		return fmt.Errorf("min-score (%s) must not be greater than max-score (%s)", c.MinScore, c.MaxScore)
+	}
+	if base.MinPercentile != nil && base.MaxPercentile != nil && *base.MinPercentile > *base.MaxPercentile {
+		return fmt.Errorf("min-percentile (%s) must not be greater than max-percentile (%s)", c.MinPercentile, c.MaxPercentile)
+	}
+
+	if len(c.CVEs) == 0 {
+		c.Specs = append(c.Specs, base)
+		return nil
+	}
+
+	for _, cve := range c.CVEs {
+		spec := base
+		spec.CVE = cve
+		c.Specs = append(c.Specs, spec)
+	}
+	return nil
+}
+
+func parseDBSearchDate(val, flag string) (*time.Time, error) {
+	if val == "" {
+		return nil, nil
+	}
+	parsed, err := dateparse.ParseIn(val, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format for %s=%q: %w", flag, val, err)
+	}
+	return &parsed, nil
+}
+
+func parseDBSearchRatio(val, flag string) (*float64, error) {
+	if val == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s=%q: %w", flag, val, err)
+	}
+	if parsed < 0 || parsed > 1 {
+		return nil, fmt.Errorf("invalid value for %s=%q: must be between 0 and 1", flag, val)
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,142 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestDBSearchKnownExploitedPostLoad(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          DBSearchKnownExploited
+		expectedSpecs  []v6.KnownExploitedSpecifier
+		expectedErrMsg string
+	}{
+		{
+			name:          "no filters",
+			input:         DBSearchKnownExploited{},
+			expectedSpecs: []v6.KnownExploitedSpecifier{{}},
+		},
+		{
+			name: "filters apply to each CVE",
+			input: DBSearchKnownExploited{
+				CVEs:        []string{"CVE-2023-0001", "CVE-2023-0002"},
+				AddedAfter:  "2023-01-01",
+				AddedBefore: "2023-12-31",
+				Ransomware:  "Known",
+			},
+			expectedSpecs: []v6.KnownExploitedSpecifier{
+				{CVE: "CVE-2023-0001", AddedAfter: parseTime("2023-01-01"), AddedBefore: parseTime("2023-12-31"), RansomwareUse: "Known"},
+				{CVE: "CVE-2023-0002", AddedAfter: parseTime("2023-01-01"), AddedBefore: parseTime("2023-12-31"), RansomwareUse: "Known"},
+			},
+		},
+		{
+			name: "invalid date",
+			input: DBSearchKnownExploited{
+				AddedAfter: "invalid-date",
+			},
+			expectedErrMsg: "invalid date format for added-after",
+		},
+		{
+			name: "inverted date range",
+			input: DBSearchKnownExploited{
+				AddedAfter:  "2024-01-01",
+				AddedBefore: "2023-01-01",
+			},
+			expectedErrMsg: "must not be later than added-before",
+		},
+		{
+			name: "invalid ransomware value",
+			input: DBSearchKnownExploited{
+				Ransomware: "maybe",
+			},
+			expectedErrMsg: "invalid ransomware value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.PostLoad()
+
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if d := cmp.Diff(tc.expectedSpecs, tc.input.Specs); d != "" {
+				t.Errorf("unexpected KEV specifiers (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestDBSearchEPSSPostLoad(t *testing.T) {
+	ratio := func(v float64) *float64 { return &v }
+
+	testCases := []struct {
+		name           string
+		input          DBSearchEPSS
+		expectedSpecs  []v6.EpssSpecifier
+		expectedErrMsg string
+	}{
+		{
+			name:          "no filters",
+			input:         DBSearchEPSS{},
+			expectedSpecs: []v6.EpssSpecifier{{}},
+		},
+		{
+			name: "score and percentile ranges",
+			input: DBSearchEPSS{
+				CVEs:          []string{"CVE-2023-0001"},
+				MinScore:      "0.1",
+				MaxScore:      "0.9",
+				MinPercentile: "0.5",
+				MaxPercentile: "1",
+			},
+			expectedSpecs: []v6.EpssSpecifier{
+				{CVE: "CVE-2023-0001", MinScore: ratio(0.1), MaxScore: ratio(0.9), MinPercentile: ratio(0.5), MaxPercentile: ratio(1)},
+			},
+		},
+		{
+			name: "not a number",
+			input: DBSearchEPSS{
+				MinScore: "high",
+			},
+			expectedErrMsg: "invalid value for min-score",
+		},
+		{
+			name: "out of range",
+			input: DBSearchEPSS{
+				MaxPercentile: "1.5",
+			},
+			expectedErrMsg: "must be between 0 and 1",
+		},
+		{
+			name: "inverted score range",
+			input: DBSearchEPSS{
+				MinScore: "0.9",
+				MaxScore: "0.1",
+			},
+			expectedErrMsg: "must not be greater than max-score",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.PostLoad()
+
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			if d := cmp.Diff(tc.expectedSpecs, tc.input.Specs); d != "" {
+				t.Errorf("unexpected EPSS specifiers (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package options
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/scylladb/go-set/strset"
@@ -10,8 +11,9 @@ import (
 )
 
 type DBSearchFormat struct {
-	Output    string   `yaml:"output" json:"output" mapstructure:"output"`
-	Allowable []string `yaml:"-" json:"-" mapstructure:"-"`
+	Output       string   `yaml:"output" json:"output" mapstructure:"output"`
+	TemplateFile string   `yaml:"template" json:"template" mapstructure:"template"`
+	Allowable    []string `yaml:"-" json:"-" mapstructure:"-"`
 }
 
 func DefaultDBSearchFormat() DBSearchFormat {
@@ -21,9 +23,21 @@ func DefaultDBSearchFormat() DBSearchFormat {
 	}
 }
 
+// DefaultDBSearchReportFormat is the output format of searches that can additionally be reported as CSV or formatted
+// with a Go template (e.g. to feed the results into spreadsheets and custom reports).
+func DefaultDBSearchReportFormat() DBSearchFormat {
+	return DBSearchFormat{
+		Output:    "table",
+		Allowable: []string{"table", "json", "csv", "template"},
+	}
+}
+
 func (c *DBSearchFormat) AddFlags(flags clio.FlagSet) {
 	available := strings.Join(c.Allowable, ", ")
 	flags.StringVarP(&c.Output, "output", "o", fmt.Sprintf("format to display results (available=[%s])", available))
+	if c.allowsTemplate() {
+		flags.StringVarP(&c.TemplateFile, "template", "t", "specify the path to a Go template file (requires 'template' output to be selected)")
+	}
 }
 
 func (c *DBSearchFormat) PostLoad() error {
@@ -32,5 +46,12 @@ func (c *DBSearchFormat) PostLoad() error {
 			return fmt.Errorf("invalid output format: %s (expected one of: %s)", c.Output, strings.Join(c.Allowable, ", "))
 		}
 	}
+	if c.Output == "template" && c.TemplateFile == "" {
+		return fmt.Errorf("a template file is required with the template output format (use --template)")
+	}
 	return nil
 }
+
+func (c DBSearchFormat) allowsTemplate() bool {
+	return slices.Contains(c.Allowable, "template")
+}
@@ -0,0 +1,53 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBSearchFormatPostLoad(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          DBSearchFormat
+		expectedErrMsg string
+	}{
+		{
+			name:  "default format",
+			input: DefaultDBSearchFormat(),
+		},
+		{
+			name:  "csv report",
+			input: DBSearchFormat{Output: "csv", Allowable: DefaultDBSearchReportFormat().Allowable},
+		},
+		{
+			name: "template report",
+			input: DBSearchFormat{
+				Output:       "template",
+				TemplateFile: "report.tmpl",
+				Allowable:    DefaultDBSearchReportFormat().Allowable,
+			},
+		},
+		{
+			name:           "template report without template file",
+			input:          DBSearchFormat{Output: "template", Allowable: DefaultDBSearchReportFormat().Allowable},
+			expectedErrMsg: "a template file is required",
+		},
+		{
+			name:           "csv not allowed",
+			input:          DBSearchFormat{Output: "csv", Allowable: DefaultDBSearchFormat().Allowable},
+			expectedErrMsg: "invalid output format: csv",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.PostLoad()
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -18,6 +18,9 @@ type DBSearchPackages struct {
 	Ecosystem             string               `yaml:"ecosystem" json:"ecosystem" mapstructure:"ecosystem"`
 	PkgSpecs              v6.PackageSpecifiers `yaml:"-" json:"-" mapstructure:"-"`
 	CPESpecs              v6.PackageSpecifiers `yaml:"-" json:"-" mapstructure:"-"`
+	// Versions are the versions given within package URLs by package name (not considered when searching, but kept
+	// for describing the searched package, e.g. when emitting VEX statements)
+	Versions map[string]string `yaml:"-" json:"-" mapstructure:"-"`
 }
 
 func (o *DBSearchPackages) AddFlags(flags clio.FlagSet) {
@@ -30,6 +33,7 @@ func (o *DBSearchPackages) PostLoad() error {
 	// note: this may be called multiple times, so we need to reset the specs each time
 	o.PkgSpecs = nil
 	o.CPESpecs = nil
+	o.Versions = nil
 
 	for _, p := range o.Packages {
 		switch {
@@ -62,6 +66,13 @@ func (o *DBSearchPackages) PostLoad() error {
 				log.Warnf("ignoring version and qualifiers for package URL %q", purl)
 			}
 
+			if purl.Version != "" {
+				if o.Versions == nil {
+					o.Versions = make(map[string]string)
+				}
+				o.Versions[purl.Name] = purl.Version
+			}
+
 			o.PkgSpecs = append(o.PkgSpecs, &v6.PackageSpecifier{Name: purl.Name, Ecosystem: purl.Type})
 			o.CPESpecs = append(o.CPESpecs, &v6.PackageSpecifier{CPE: &cpe.Attributes{Part: "a", Product: purl.Name, TargetSW: purl.Type}})
 
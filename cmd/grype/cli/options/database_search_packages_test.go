@@ -16,6 +16,7 @@ func TestDBSearchPackagesPostLoad(t *testing.T) {
 		input          DBSearchPackages
 		expectedPkg    v6.PackageSpecifiers
 		expectedCPE    v6.PackageSpecifiers
+		expectedVers   map[string]string
 		expectedErrMsg string
 	}{
 		{
@@ -41,6 +42,7 @@ func TestDBSearchPackagesPostLoad(t *testing.T) {
 			expectedCPE: v6.PackageSpecifiers{
 				{CPE: &cpe.Attributes{Part: "a", Product: "package-name", TargetSW: "npm"}},
 			},
+			expectedVers: map[string]string{"package-name": "1.0.0"},
 		},
 		{
 			name: "plain package name",
@@ -106,6 +108,7 @@ func TestDBSearchPackagesPostLoad(t *testing.T) {
 			if d := cmp.Diff(tc.expectedCPE, tc.input.CPESpecs); d != "" {
 				t.Errorf("unexpected CPE specifiers (-want +got):\n%s", d)
 			}
+			require.Equal(t, tc.expectedVers, tc.input.Versions)
 
 		})
 	}
@@ -0,0 +1,35 @@
+package options
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/anchore/clio"
+)
+
+// summarizeByValues are the attributes the results of a search can be summarized by.
+var summarizeByValues = []string{"provider", "severity", "ecosystem"}
+
+type DBSearchSummary struct {
+	Count       bool   `yaml:"count" json:"count" mapstructure:"count"`
+	SummarizeBy string `yaml:"summarize-by" json:"summarize-by" mapstructure:"summarize-by"`
+}
+
+func (c *DBSearchSummary) AddFlags(flags clio.FlagSet) {
+	flags.BoolVarP(&c.Count, "count", "", "only show the number of results instead of the results themselves")
+	flags.StringVarP(&c.SummarizeBy, "summarize-by", "", fmt.Sprintf("only show the number of results per value of the given attribute (%s)", strings.Join(summarizeByValues, ", ")))
+}
+
+func (c *DBSearchSummary) PostLoad() error {
+	c.SummarizeBy = strings.ToLower(strings.TrimSpace(c.SummarizeBy))
+	if c.SummarizeBy != "" && !slices.Contains(summarizeByValues, c.SummarizeBy) {
+		return fmt.Errorf("invalid summarize-by value: %q (valid values: %s)", c.SummarizeBy, strings.Join(summarizeByValues, ", "))
+	}
+	return nil
+}
+
+// Enabled indicates if the number of results is shown instead of the results themselves.
+func (c DBSearchSummary) Enabled() bool {
+	return c.Count || c.SummarizeBy != ""
+}
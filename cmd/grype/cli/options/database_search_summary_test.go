@@ -0,0 +1,49 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBSearchSummaryPostLoad(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          DBSearchSummary
+		expected       DBSearchSummary
+		expectedErrMsg string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:     "count",
+			input:    DBSearchSummary{Count: true},
+			expected: DBSearchSummary{Count: true},
+		},
+		{
+			name:     "summarize by severity",
+			input:    DBSearchSummary{SummarizeBy: " Severity"},
+			expected: DBSearchSummary{SummarizeBy: "severity"},
+		},
+		{
+			name:           "invalid summarize-by",
+			input:          DBSearchSummary{SummarizeBy: "cwe"},
+			expectedErrMsg: "invalid summarize-by value: \"cwe\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.PostLoad()
+			if tc.expectedErrMsg != "" {
+				require.ErrorContains(t, err, tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, tc.input)
+			assert.Equal(t, tc.expected.Count || tc.expected.SummarizeBy != "", tc.input.Enabled())
+		})
+	}
+}
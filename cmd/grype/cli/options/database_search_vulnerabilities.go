@@ -2,23 +2,28 @@ package options
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/araddon/dateparse"
 
 	"github.com/anchore/clio"
 	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/vulnerability"
 )
 
 type DBSearchVulnerabilities struct {
 	VulnerabilityIDs []string `yaml:"vulnerability-ids" json:"vulnerability-ids" mapstructure:"vulnerability-ids"`
 	UseVulnIDFlag    bool     `yaml:"-" json:"-" mapstructure:"-"`
 
-	PublishedAfter string `yaml:"published-after" json:"published-after" mapstructure:"published-after"`
-	ModifiedAfter  string `yaml:"modified-after" json:"modified-after" mapstructure:"modified-after"`
+	PublishedAfter  string `yaml:"published-after" json:"published-after" mapstructure:"published-after"`
+	PublishedBefore string `yaml:"published-before" json:"published-before" mapstructure:"published-before"`
+	ModifiedAfter   string `yaml:"modified-after" json:"modified-after" mapstructure:"modified-after"`
 
 	Providers  []string `yaml:"providers" json:"providers" mapstructure:"providers"`
 	FixedState []string `yaml:"fixed-state" json:"fixed-state" mapstructure:"fixed-state"`
+	Severity   []string `yaml:"severity" json:"severity" mapstructure:"severity"`
 
 	Specs v6.VulnerabilitySpecifiers `yaml:"-" json:"-" mapstructure:"-"`
 }
@@ -27,10 +32,12 @@ func (c *DBSearchVulnerabilities) AddFlags(flags clio.FlagSet) {
 	if c.UseVulnIDFlag {
 		flags.StringArrayVarP(&c.VulnerabilityIDs, "vuln", "", "only show results for the given vulnerability ID")
 	}
-	flags.StringVarP(&c.PublishedAfter, "published-after", "", "only show vulnerabilities originally published after the given date (format: YYYY-MM-DD)")
-	flags.StringVarP(&c.ModifiedAfter, "modified-after", "", "only show vulnerabilities originally published or modified since the given date (format: YYYY-MM-DD)")
+	flags.StringVarP(&c.PublishedAfter, "published-after", "", "only show vulnerabilities originally published after the given date (format: YYYY-MM-DD, or a relative age such as 7d, 2w or 12h)")
+	flags.StringVarP(&c.PublishedBefore, "published-before", "", "only show vulnerabilities originally published before the given date (format: YYYY-MM-DD, or a relative age such as 7d, 2w or 12h)")
+	flags.StringVarP(&c.ModifiedAfter, "modified-after", "", "only show vulnerabilities originally published or modified since the given date (format: YYYY-MM-DD, or a relative age such as 7d, 2w or 12h)")
 	flags.StringArrayVarP(&c.Providers, "provider", "", "only show vulnerabilities from the given provider")
 	flags.StringArrayVarP(&c.FixedState, "fixed-state", "", "only show vulnerabilities with the given fix state (fixed, not-fixed, unknown, wont-fix)")
+	flags.StringArrayVarP(&c.Severity, "severity", "", "only show vulnerabilities with the given severity, comma separated (negligible, low, medium, high, critical, unknown)")
 }
 
 func (c *DBSearchVulnerabilities) PostLoad() error {
@@ -41,6 +48,11 @@ func (c *DBSearchVulnerabilities) PostLoad() error {
 		if val == "" {
 			return nil, nil
 		}
+		// relative ages are spelled as for the other age options (e.g. 7d, 2w or 12h)
+		if age, err := match.ParseAge(val); err == nil {
+			since := now().UTC().Add(-age)
+			return &since, nil
+		}
 		parsed, err := dateparse.ParseIn(val, time.UTC)
 		if err != nil {
 			return nil, fmt.Errorf("invalid date format for %s=%q: %w", flag, val, err)
@@ -64,12 +76,28 @@ func (c *DBSearchVulnerabilities) PostLoad() error {
 		}
 	}
 
-	var publishedAfter, modifiedAfter *time.Time
+	c.Severity = flatten(c.Severity)
+	for i, sev := range c.Severity {
+		sev = strings.ToLower(sev)
+		if vulnerability.ParseSeverity(sev).String() != sev {
+			return fmt.Errorf("invalid severity value: %q (valid values: negligible, low, medium, high, critical, unknown)", c.Severity[i])
+		}
+		c.Severity[i] = sev
+	}
+
+	var publishedAfter, publishedBefore, modifiedAfter *time.Time
 	var err error
 	publishedAfter, err = handleTimeOption(c.PublishedAfter, "published-after")
 	if err != nil {
 		return fmt.Errorf("invalid date format for published-after field: %w", err)
 	}
+	publishedBefore, err = handleTimeOption(c.PublishedBefore, "published-before")
+	if err != nil {
+		return fmt.Errorf("invalid date format for published-before field: %w", err)
+	}
+	if publishedAfter != nil && publishedBefore != nil && !publishedAfter.Before(*publishedBefore) {
+		return fmt.Errorf("--published-after (%s) must be before --published-before (%s)", c.PublishedAfter, c.PublishedBefore)
+	}
 	modifiedAfter, err = handleTimeOption(c.ModifiedAfter, "modified-after")
 	if err != nil {
 		return fmt.Errorf("invalid date format for modified-after field: %w", err)
@@ -78,19 +106,21 @@ func (c *DBSearchVulnerabilities) PostLoad() error {
 	var specs []v6.VulnerabilitySpecifier
 	for _, vulnID := range c.VulnerabilityIDs {
 		specs = append(specs, v6.VulnerabilitySpecifier{
-			Name:           vulnID,
-			PublishedAfter: publishedAfter,
-			ModifiedAfter:  modifiedAfter,
-			Providers:      c.Providers,
+			Name:            vulnID,
+			PublishedAfter:  publishedAfter,
+			PublishedBefore: publishedBefore,
+			ModifiedAfter:   modifiedAfter,
+			Providers:       c.Providers,
 		})
 	}
 
 	if len(specs) == 0 {
-		if c.PublishedAfter != "" || c.ModifiedAfter != "" || len(c.Providers) > 0 {
+		if c.PublishedAfter != "" || c.PublishedBefore != "" || c.ModifiedAfter != "" || len(c.Providers) > 0 {
 			specs = append(specs, v6.VulnerabilitySpecifier{
-				PublishedAfter: publishedAfter,
-				ModifiedAfter:  modifiedAfter,
-				Providers:      c.Providers,
+				PublishedAfter:  publishedAfter,
+				PublishedBefore: publishedBefore,
+				ModifiedAfter:   modifiedAfter,
+				Providers:       c.Providers,
 			})
 		}
 	}
@@ -99,3 +129,6 @@ func (c *DBSearchVulnerabilities) PostLoad() error {
 
 	return nil
 }
+
+// now is the reference time of relative ages (replaceable in tests)
+var now = time.Now
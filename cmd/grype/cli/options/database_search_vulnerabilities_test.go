@@ -11,6 +11,9 @@ import (
 )
 
 func TestDBSearchVulnerabilitiesPostLoad(t *testing.T) {
+	now = func() time.Time { return *parseTime("2023-03-15") }
+	t.Cleanup(func() { now = time.Now })
+
 	testCases := []struct {
 		name           string
 		input          DBSearchVulnerabilities
@@ -54,6 +57,58 @@ func TestDBSearchVulnerabilitiesPostLoad(t *testing.T) {
 				{ModifiedAfter: parseTime("2023-02-01")},
 			},
 		},
+		{
+			name: "published date range",
+			input: DBSearchVulnerabilities{
+				PublishedAfter:  "2023-01-01",
+				PublishedBefore: "2023-02-01",
+			},
+			expectedSpecs: v6.VulnerabilitySpecifiers{
+				{PublishedAfter: parseTime("2023-01-01"), PublishedBefore: parseTime("2023-02-01")},
+			},
+		},
+		{
+			name: "published in the last week",
+			input: DBSearchVulnerabilities{
+				PublishedAfter: "7d",
+			},
+			expectedSpecs: v6.VulnerabilitySpecifiers{
+				{PublishedAfter: parseTime("2023-03-08")},
+			},
+		},
+		{
+			name: "published more than 2 weeks ago",
+			input: DBSearchVulnerabilities{
+				PublishedBefore: "2w",
+			},
+			expectedSpecs: v6.VulnerabilitySpecifiers{
+				{PublishedBefore: parseTime("2023-03-01")},
+			},
+		},
+		{
+			name: "modified in the last 24 hours",
+			input: DBSearchVulnerabilities{
+				ModifiedAfter: "24h",
+			},
+			expectedSpecs: v6.VulnerabilitySpecifiers{
+				{ModifiedAfter: parseTime("2023-03-14")},
+			},
+		},
+		{
+			name: "published-after not before published-before",
+			input: DBSearchVulnerabilities{
+				PublishedAfter:  "2023-02-01",
+				PublishedBefore: "2023-01-01",
+			},
+			expectedErrMsg: "--published-after (2023-02-01) must be before --published-before (2023-01-01)",
+		},
+		{
+			name: "invalid date for published-before",
+			input: DBSearchVulnerabilities{
+				PublishedBefore: "invalid-date",
+			},
+			expectedErrMsg: "invalid date format for published-before",
+		},
 		{
 			name: "both published-after and modified-after set",
 			input: DBSearchVulnerabilities{
@@ -123,6 +178,20 @@ func TestDBSearchVulnerabilitiesPostLoad(t *testing.T) {
 			},
 			expectedErrMsg: "invalid fixed-state value: \"bad-state\"",
 		},
+		{
+			name: "valid severity: multiple values",
+			input: DBSearchVulnerabilities{
+				Severity: []string{"Critical,high"},
+			},
+			expectedSpecs: nil,
+		},
+		{
+			name: "invalid severity",
+			input: DBSearchVulnerabilities{
+				Severity: []string{"high", "severe"},
+			},
+			expectedErrMsg: "invalid severity value: \"severe\"",
+		},
 	}
 
 	for _, tc := range testCases {
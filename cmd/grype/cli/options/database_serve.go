@@ -0,0 +1,58 @@
+package options
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/anchore/clio"
+)
+
+type DBServe struct {
+	Listen      string `yaml:"listen" json:"listen" mapstructure:"listen"`
+	Token       string `yaml:"token" json:"-" mapstructure:"token"`
+	RecordLimit int    `yaml:"limit" json:"limit" mapstructure:"limit"`
+}
+
+var _ interface {
+	clio.FlagAdder
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*DBServe)(nil)
+
+func DefaultDBServe() DBServe {
+	return DBServe{
+		Listen:      "127.0.0.1:8080",
+		RecordLimit: DefaultDBSearchBounds().RecordLimit,
+	}
+}
+
+func (o *DBServe) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&o.Listen, "listen", "", "address to serve the API on")
+	flags.IntVarP(&o.RecordLimit, "limit", "", "maximum number of records searched per request, results beyond are reported as truncated (0 for no limit)")
+}
+
+func (o *DBServe) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&o.Listen, `address to serve the vulnerability database API on`)
+	descriptions.Add(&o.Token, `bearer token clients must present to query the API (consider setting GRYPE_SERVE_TOKEN instead of writing the token to a file)`)
+	descriptions.Add(&o.RecordLimit, `maximum number of records searched per request; results beyond the limit are reported as truncated (0 for no limit)`)
+}
+
+// PostLoad validates the serve options. Note that the token is only required when serving (see RequireToken), since
+// the options are loaded for every command (e.g. to show the application configuration).
+func (o *DBServe) PostLoad() error {
+	if _, _, err := net.SplitHostPort(o.Listen); err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", o.Listen, err)
+	}
+	if o.RecordLimit < 0 {
+		return fmt.Errorf("limit must be a positive integer")
+	}
+	return nil
+}
+
+// RequireToken returns an error when no token is configured, since the API is never served without authentication.
+func (o DBServe) RequireToken() error {
+	if o.Token == "" {
+		return fmt.Errorf("a token is required to serve the vulnerability database (set serve.token or GRYPE_SERVE_TOKEN)")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBServe_PostLoad(t *testing.T) {
+	tests := []struct {
+		name             string
+		modify           func(o *DBServe)
+		wantErr          require.ErrorAssertionFunc
+		wantRequireToken require.ErrorAssertionFunc
+	}{
+		{
+			name:             "defaults load without a token",
+			wantErr:          require.NoError,
+			wantRequireToken: require.Error,
+		},
+		{
+			name:             "token",
+			modify:           func(o *DBServe) { o.Token = "secret" },
+			wantErr:          require.NoError,
+			wantRequireToken: require.NoError,
+		},
+		{
+			name:             "invalid listen address",
+			modify:           func(o *DBServe) { o.Listen = "localhost" },
+			wantErr:          require.Error,
+			wantRequireToken: require.Error,
+		},
+		{
+			name:             "negative limit",
+			modify:           func(o *DBServe) { o.RecordLimit = -1 },
+			wantErr:          require.Error,
+			wantRequireToken: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := DefaultDBServe()
+			if tt.modify != nil {
+				tt.modify(&o)
+			}
+			tt.wantErr(t, o.PostLoad())
+			tt.wantRequireToken(t, o.RequireToken())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package options
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anchore/clio"
+)
+
+// supportedSQLitePragmas are the PRAGMA names that may be tuned via configuration
+var supportedSQLitePragmas = []string{"cache_size", "journal_mode", "mmap_size"}
+
+type databaseSQLite struct {
+	Pragmas map[string]string `yaml:"pragmas" json:"pragmas" mapstructure:"pragmas"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*databaseSQLite)(nil)
+
+func (cfg *databaseSQLite) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.Pragmas, fmt.Sprintf(`SQLite PRAGMA values to set when opening the vulnerability database, overriding the defaults tuned for matching
+(mmap_size = 268435456, cache_size = -65536). Note: journal_mode is only applied when the database is opened for writing.
+supported pragmas: %s`, strings.Join(supportedSQLitePragmas, ", ")))
+}
+
+func (cfg *databaseSQLite) PostLoad() error {
+	pragmas := make(map[string]string)
+	for name, value := range cfg.Pragmas {
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(value)
+
+		idx := sort.SearchStrings(supportedSQLitePragmas, name)
+		if idx >= len(supportedSQLitePragmas) || supportedSQLitePragmas[idx] != name {
+			return fmt.Errorf("unsupported db.sqlite.pragmas entry %q (supported: %s)", name, strings.Join(supportedSQLitePragmas, ", "))
+		}
+		if value == "" || strings.ContainsAny(value, "; ") {
+			return fmt.Errorf("invalid value for db.sqlite.pragmas entry %q: %q", name, value)
+		}
+		pragmas[name] = value
+	}
+	cfg.Pragmas = pragmas
+	return nil
+}
@@ -0,0 +1,52 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseSQLite_PostLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		pragmas map[string]string
+		want    map[string]string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "no pragmas",
+			want: map[string]string{},
+		},
+		{
+			name:    "normalizes names and values",
+			pragmas: map[string]string{" MMAP_SIZE ": " 0 ", "journal_mode": "WAL"},
+			want:    map[string]string{"mmap_size": "0", "journal_mode": "WAL"},
+		},
+		{
+			name:    "unsupported pragma",
+			pragmas: map[string]string{"foreign_keys": "OFF"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "value with multiple statements",
+			pragmas: map[string]string{"cache_size": "1; DROP TABLE x"},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			cfg := databaseSQLite{Pragmas: tt.pragmas}
+			err := cfg.PostLoad()
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, cfg.Pragmas)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabase_PostLoad_backend(t *testing.T) {
+	tests := []struct {
+		name            string
+		backend         string
+		dsn             string
+		wantBackend     string
+		wantPostgresDSN string
+		wantErr         require.ErrorAssertionFunc
+	}{
+		{
+			name:        "defaults to sqlite",
+			wantBackend: sqliteBackend,
+		},
+		{
+			name:        "sqlite ignores the dsn",
+			backend:     sqliteBackend,
+			dsn:         "postgres://localhost/grype",
+			wantBackend: sqliteBackend,
+		},
+		{
+			name:            "postgres",
+			backend:         postgresBackend,
+			dsn:             "postgres://localhost/grype",
+			wantBackend:     postgresBackend,
+			wantPostgresDSN: "postgres://localhost/grype",
+		},
+		{
+			name:    "postgres without dsn",
+			backend: postgresBackend,
+			wantErr: require.Error,
+		},
+		{
+			name:    "unsupported backend",
+			backend: "mysql",
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			cfg := Database{Backend: tt.backend, Postgres: databasePostgres{DSN: tt.dsn}}
+			err := cfg.PostLoad()
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantBackend, cfg.Backend)
+			assert.Equal(t, tt.wantPostgresDSN, cfg.postgresDSN())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// DirectoryScan configures the symbolic links and mount points followed when scanning a directory.
+type DirectoryScan struct {
+	Symlinks   string `yaml:"symlinks" json:"symlinks" mapstructure:"symlinks"`          // --symlinks, the symbolic links followed
+	SkipMounts bool   `yaml:"skip-mounts" json:"skip-mounts" mapstructure:"skip-mounts"` // --skip-mounts, skip directories on other filesystems
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*DirectoryScan)(nil)
+
+func defaultDirectoryScan() DirectoryScan {
+	return DirectoryScan{
+		Symlinks: string(pkg.SymlinksFollow),
+	}
+}
+
+func (d *DirectoryScan) PostLoad() error {
+	_, err := d.ToConfig()
+	return err
+}
+
+func (d *DirectoryScan) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&d.Symlinks, `the symbolic links followed when scanning a directory: "follow" (all links), "within-root" (only links
+resolving within the scanned directory) or "none" (same as --symlinks)`)
+	descriptions.Add(&d.SkipMounts, `skip the directories on another filesystem than the scanned directory, such as mounted volumes (same as
+--skip-mounts, not supported on windows)`)
+}
+
+// ToConfig validates the configuration and returns the directory scan config.
+func (d DirectoryScan) ToConfig() (pkg.DirectoryScanConfig, error) {
+	policy, err := pkg.ParseSymlinkPolicy(d.Symlinks)
+	if err != nil {
+		return pkg.DirectoryScanConfig{}, fmt.Errorf("bad --symlinks value: %w", err)
+	}
+	return pkg.DirectoryScanConfig{
+		Symlinks:   policy,
+		SkipMounts: d.SkipMounts,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+)
+
+func TestDirectoryScan_PostLoad(t *testing.T) {
+	cfg := defaultDirectoryScan()
+	require.NoError(t, cfg.PostLoad())
+
+	cfg = DirectoryScan{Symlinks: "none", SkipMounts: true}
+	require.NoError(t, cfg.PostLoad())
+	got, err := cfg.ToConfig()
+	require.NoError(t, err)
+	assert.Equal(t, pkg.DirectoryScanConfig{Symlinks: pkg.SymlinksNone, SkipMounts: true}, got)
+
+	cfg = DirectoryScan{Symlinks: "sometimes"}
+	require.Error(t, cfg.PostLoad())
+}
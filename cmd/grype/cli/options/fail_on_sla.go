@@ -0,0 +1,89 @@
+package options
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/sla"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// FailOnSLA configures the grace period per severity that findings may remain before failing the scan.
+type FailOnSLA struct {
+	Basis        string            `yaml:"basis" json:"basis" mapstructure:"basis"`
+	GracePeriods map[string]string `yaml:"grace-periods" json:"grace-periods" mapstructure:"grace-periods"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*FailOnSLA)(nil)
+
+func defaultFailOnSLA() FailOnSLA {
+	return FailOnSLA{
+		Basis: string(sla.PublishedBasis),
+	}
+}
+
+func (f *FailOnSLA) PostLoad() error {
+	_, err := f.ToPolicy()
+	return err
+}
+
+func (f *FailOnSLA) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&f.Basis, fmt.Sprintf(`the point in time the age of a finding is measured from (options: %s)
+with "fix-available", findings without a fix never exceed the grace period`, joinBases(sla.AllBases())))
+	descriptions.Add(&f.GracePeriods, `the grace period per severity (e.g. 30d or 72h) before a finding sets the return code to 2, for example:
+  critical: 7d
+  high: 30d
+severities without a grace period and findings without a known date (published or fix available) never fail the scan;
+when configured, the grace periods replace the --fail-on severity threshold (fresh findings do not fail the scan)`)
+}
+
+// ToPolicy validates the configuration and returns the SLA policy to evaluate matches against (nil when no grace
+// periods are configured).
+func (f FailOnSLA) ToPolicy() (*sla.Policy, error) {
+	basis := sla.Basis(strings.ToLower(strings.TrimSpace(f.Basis)))
+	switch basis {
+	case "":
+		basis = sla.PublishedBasis
+	case sla.PublishedBasis, sla.FixAvailableBasis:
+	default:
+		return nil, fmt.Errorf("invalid fail-on-sla basis %q (options: %s)", f.Basis, joinBases(sla.AllBases()))
+	}
+
+	if len(f.GracePeriods) == 0 {
+		return nil, nil
+	}
+
+	gracePeriods := make(map[vulnerability.Severity]time.Duration)
+	for severity, age := range f.GracePeriods {
+		sev := vulnerability.ParseSeverity(severity)
+		if sev == vulnerability.UnknownSeverity {
+			return nil, fmt.Errorf("invalid fail-on-sla grace period severity %q (options: %v)", severity, vulnerability.AllSeverities())
+		}
+		d, err := match.ParseAge(age)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fail-on-sla grace period for %s: %w", severity, err)
+		}
+		gracePeriods[sev] = d
+	}
+
+	return &sla.Policy{
+		Basis:        basis,
+		GracePeriods: gracePeriods,
+	}, nil
+}
+
+func joinBases(bases []sla.Basis) string {
+	var out []string
+	for _, b := range bases {
+		out = append(out, string(b))
+	}
+	sort.Strings(out)
+	return strings.Join(out, ", ")
+}
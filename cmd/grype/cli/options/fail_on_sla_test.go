@@ -0,0 +1,73 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/sla"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestFailOnSLA_ToPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    FailOnSLA
+		expected *sla.Policy
+		wantErr  string
+	}{
+		{
+			name:  "no grace periods",
+			input: defaultFailOnSLA(),
+		},
+		{
+			name: "grace periods per severity",
+			input: FailOnSLA{
+				Basis:        "Fix-Available",
+				GracePeriods: map[string]string{"critical": "7d", "High": "720h"},
+			},
+			expected: &sla.Policy{
+				Basis: sla.FixAvailableBasis,
+				GracePeriods: map[vulnerability.Severity]time.Duration{
+					vulnerability.CriticalSeverity: 7 * 24 * time.Hour,
+					vulnerability.HighSeverity:     720 * time.Hour,
+				},
+			},
+		},
+		{
+			name: "invalid basis",
+			input: FailOnSLA{
+				Basis: "modified",
+			},
+			wantErr: "invalid fail-on-sla basis",
+		},
+		{
+			name: "invalid severity",
+			input: FailOnSLA{
+				GracePeriods: map[string]string{"urgent": "7d"},
+			},
+			wantErr: "invalid fail-on-sla grace period severity",
+		},
+		{
+			name: "invalid age",
+			input: FailOnSLA{
+				GracePeriods: map[string]string{"high": "a month"},
+			},
+			wantErr: "invalid fail-on-sla grace period for high",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.input.ToPolicy()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -2,12 +2,17 @@ package options
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/anchore/clio"
+	"github.com/anchore/go-homedir"
 	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/grype/internal/format"
+	"github.com/anchore/stereoscope/pkg/image"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -15,33 +20,68 @@ type Grype struct {
 	Outputs                    []string           `yaml:"output" json:"output" mapstructure:"output"` // -o, <presenter>=<file> the Presenter hint string to use for report formatting and the output file
 	File                       string             `yaml:"file" json:"file" mapstructure:"file"`       // --file, the file to write report output to
 	Pretty                     bool               `yaml:"pretty" json:"pretty" mapstructure:"pretty"`
+	SignResults                string             `yaml:"sign-results" json:"sign-results" mapstructure:"sign-results"`                         // --sign-results, private key to sign the report files with
+	StreamTable                bool               `yaml:"stream-table" json:"stream-table" mapstructure:"stream-table"`                         // --stream-table, render table rows as matches are found
 	Distro                     string             `yaml:"distro" json:"distro" mapstructure:"distro"`                                           // --distro, specify a distro to explicitly use
+	DistroAliases              string             `yaml:"distro-aliases" json:"distro-aliases" mapstructure:"distro-aliases"`                   // --distro-aliases, file mapping custom distros to the distros they are matched as
 	GenerateMissingCPEs        bool               `yaml:"add-cpes-if-none" json:"add-cpes-if-none" mapstructure:"add-cpes-if-none"`             // --add-cpes-if-none, automatically generate CPEs if they are not present in import (e.g. from a 3rd party SPDX document)
 	OutputTemplateFile         string             `yaml:"output-template-file" json:"output-template-file" mapstructure:"output-template-file"` // -t, the template file to use for formatting the final report
 	CheckForAppUpdate          bool               `yaml:"check-for-app-update" json:"check-for-app-update" mapstructure:"check-for-app-update"` // whether to check for an application update on start up or not
 	OnlyFixed                  bool               `yaml:"only-fixed" json:"only-fixed" mapstructure:"only-fixed"`                               // only fail if detected vulns have a fix
 	OnlyNotFixed               bool               `yaml:"only-notfixed" json:"only-notfixed" mapstructure:"only-notfixed"`                      // only fail if detected vulns don't have a fix
 	IgnoreStates               string             `yaml:"ignore-states" json:"ignore-wontfix" mapstructure:"ignore-wontfix"`                    // ignore detections for vulnerabilities matching these comma-separated fix states
-	Platform                   string             `yaml:"platform" json:"platform" mapstructure:"platform"`                                     // --platform, override the target platform for a container image
+	MinFixAge                  string             `yaml:"min-fix-age" json:"min-fix-age" mapstructure:"min-fix-age"`                            // --min-fix-age, only show vulns whose fix has been available for at least this long
+	UnboundedMatches           string             `yaml:"unbounded-matches" json:"unbounded-matches" mapstructure:"unbounded-matches"`          // --unbounded-matches, how to handle advisories with no upper bound and no known fix
+	UnknownVersions            UnknownVersions    `yaml:"unknown-versions" json:"unknown-versions" mapstructure:"unknown-versions"`
+	MinConfidence              float64            `yaml:"min-confidence" json:"min-confidence" mapstructure:"min-confidence"`                                           // --min-confidence, ignore matches below this confidence
+	OnlyDirectDeps             bool               `yaml:"only-direct-deps" json:"only-direct-deps" mapstructure:"only-direct-deps"`                                     // --only-direct-deps, ignore matches on transitive dependencies
+	ExcludeDevDependencies     bool               `yaml:"exclude-dev-dependencies" json:"exclude-dev-dependencies" mapstructure:"exclude-dev-dependencies"`             // --exclude-dev-dependencies, ignore matches on development or test dependencies
+	IncludeWithdrawn           bool               `yaml:"include-withdrawn" json:"include-withdrawn" mapstructure:"include-withdrawn"`                                  // --include-withdrawn, report matches against withdrawn or rejected vulnerability records
+	OnlyProject                string             `yaml:"only-project" json:"only-project" mapstructure:"only-project"`                                                 // --only-project, when scanning a directory, only match the packages of this project
+	SeparateIntermediateLayers bool               `yaml:"separate-intermediate-layers" json:"separate-intermediate-layers" mapstructure:"separate-intermediate-layers"` // --separate-intermediate-layers, with all-layers scope, report matches only present in intermediate layers separately
+	ShowResolved               bool               `yaml:"show-resolved" json:"show-resolved" mapstructure:"show-resolved"`                                              // --show-resolved, report vulnerabilities whose fix is already installed
+	TimeBudget                 TimeBudget         `yaml:"time-budget" json:"time-budget" mapstructure:"time-budget"`
+	Platform                   string             `yaml:"platform" json:"platform" mapstructure:"platform"` // --platform, override the target platform for a container image
 	Search                     search             `yaml:"search" json:"search" mapstructure:"search"`
+	DeepJava                   bool               `yaml:"deep-java" json:"deep-java" mapstructure:"deep-java"`                                  // --deep-java, fingerprint java class files to find shaded artifacts
+	IgnoreEmbeddedSBOM         bool               `yaml:"ignore-embedded-sbom" json:"ignore-embedded-sbom" mapstructure:"ignore-embedded-sbom"` // --ignore-embedded-sbom, catalog OCI artifacts even when they carry an SBOM attestation
+	SBOMCacheDir               string             `yaml:"sbom-cache-dir" json:"sbom-cache-dir" mapstructure:"sbom-cache-dir"`                   // directory to cache container image layer cataloging results in (disabled when empty)
+	CPECacheDir                string             `yaml:"cpe-cache-dir" json:"cpe-cache-dir" mapstructure:"cpe-cache-dir"`                      // directory to persist CPEs generated with add-cpes-if-none in (disabled when empty)
 	Ignore                     []match.IgnoreRule `yaml:"ignore" json:"ignore" mapstructure:"ignore"`
+	IgnoreFiles                []string           `yaml:"ignore-files" json:"ignore-files" mapstructure:"ignore-files"`                               // --ignore-file, annotated ignore files applied in addition to the ignore rules
+	FailOnUnusedIgnores        bool               `yaml:"fail-on-unused-ignores" json:"fail-on-unused-ignores" mapstructure:"fail-on-unused-ignores"` // --fail-on-unused-ignores, fail when configured ignore rules did not ignore any vulnerability
 	Exclusions                 []string           `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
+	DirectoryScan              DirectoryScan      `yaml:"directory-scan" json:"directory-scan" mapstructure:"directory-scan"`
 	ExternalSources            externalSources    `yaml:"external-sources" json:"externalSources" mapstructure:"external-sources"`
 	Match                      matchConfig        `yaml:"match" json:"match" mapstructure:"match"`
 	FailOn                     string             `yaml:"fail-on-severity" json:"fail-on-severity" mapstructure:"fail-on-severity"`
+	Baseline                   string             `yaml:"baseline" json:"baseline" mapstructure:"baseline"` // --baseline, baseline file or grype JSON report of a previous scan: only new findings are evaluated against the fail-on policies
+	FailOnSLA                  FailOnSLA          `yaml:"fail-on-sla" json:"fail-on-sla" mapstructure:"fail-on-sla"`
 	Registry                   registry           `yaml:"registry" json:"registry" mapstructure:"registry"`
 	ShowSuppressed             bool               `yaml:"show-suppressed" json:"show-suppressed" mapstructure:"show-suppressed"`
-	ByCVE                      bool               `yaml:"by-cve" json:"by-cve" mapstructure:"by-cve"` // --by-cve, indicates if the original match vulnerability IDs should be preserved or the CVE should be used instead
+	ExplainSeverity            bool               `yaml:"explain-severity" json:"explain-severity" mapstructure:"explain-severity"` // --explain-severity, report how the severity of each match was derived
+	ByCVE                      bool               `yaml:"by-cve" json:"by-cve" mapstructure:"by-cve"`                               // --by-cve, indicates if the original match vulnerability IDs should be preserved or the CVE should be used instead
 	SortBy                     SortBy             `yaml:",inline" json:",inline" mapstructure:",squash"`
 	Name                       string             `yaml:"name" json:"name" mapstructure:"name"`
 	DefaultImagePullSource     string             `yaml:"default-image-pull-source" json:"default-image-pull-source" mapstructure:"default-image-pull-source"`
 	From                       []string           `yaml:"from" json:"from" mapstructure:"from"`
+	Vex                        Vex                `yaml:"vex" json:"vex" mapstructure:"vex"`
 	VexDocuments               []string           `yaml:"vex-documents" json:"vex-documents" mapstructure:"vex-documents"`
 	VexAdd                     []string           `yaml:"vex-add" json:"vex-add" mapstructure:"vex-add"`                                                                   // GRYPE_VEX_ADD
 	MatchUpstreamKernelHeaders bool               `yaml:"match-upstream-kernel-headers" json:"match-upstream-kernel-headers" mapstructure:"match-upstream-kernel-headers"` // Show matches on kernel-headers packages where the match is on kernel upstream instead of marking them as ignored, default=false
 	FixChannel                 FixChannels        `yaml:"fix-channel" json:"fix-channel" mapstructure:"fix-channel"`                                                       // the fix channels to apply to the distro when matching
 	Timestamp                  bool               `yaml:"timestamp" json:"timestamp" mapstructure:"timestamp"`
 	Alerts                     Alerts             `yaml:"alerts" json:"alerts" mapstructure:"alerts"`
+	MaliciousPackages          MaliciousPackages  `yaml:"malicious-packages" json:"malicious-packages" mapstructure:"malicious-packages"`
+	UnmanagedBinaries          UnmanagedBinaries  `yaml:"unmanaged-binaries" json:"unmanaged-binaries" mapstructure:"unmanaged-binaries"`
+	LicensePolicy              LicensePolicy      `yaml:"license-policy" json:"license-policy" mapstructure:"license-policy"`
+	Secrets                    Secrets            `yaml:"secrets" json:"secrets" mapstructure:"secrets"`
+	Push                       Push               `yaml:"push" json:"push" mapstructure:"push"`
+	Redact                     Redaction          `yaml:"redact" json:"redact" mapstructure:"redact"`
+	Telemetry                  Telemetry          `yaml:"telemetry" json:"telemetry" mapstructure:"telemetry"`
+	CVSSEnvironment            CVSSEnvironment    `yaml:"cvss-environment" json:"cvss-environment" mapstructure:"cvss-environment"`
+	SeverityPolicy             SeverityPolicy     `yaml:"severity-policy" json:"severity-policy" mapstructure:"severity-policy"`
+	Targets                    Targets            `yaml:"targets" json:"targets" mapstructure:"targets"`
 	DatabaseCommand            `yaml:",inline" json:",inline" mapstructure:",squash"`
 }
 
@@ -64,16 +104,33 @@ func DefaultGrype(id clio.Identification) *Grype {
 		Search:     defaultSearch(source.SquashedScope),
 		FixChannel: DefaultFixChannels(),
 		DatabaseCommand: DatabaseCommand{
-			DB: DefaultDatabase(id),
+			DB:      DefaultDatabase(id),
+			Network: DefaultNetwork(),
 		},
 		Match:                      defaultMatchConfig(),
 		ExternalSources:            defaultExternalSources(),
+		UnboundedMatches:           string(match.UnboundedAsMatch),
+		UnknownVersions:            defaultUnknownVersions(),
+		TimeBudget:                 defaultTimeBudget(),
+		DirectoryScan:              defaultDirectoryScan(),
 		CheckForAppUpdate:          true,
 		VexAdd:                     []string{},
+		Vex:                        defaultVex(),
 		MatchUpstreamKernelHeaders: false,
 		SortBy:                     defaultSortBy(),
 		Timestamp:                  true,
 		Alerts:                     defaultAlerts(),
+		MaliciousPackages:          defaultMaliciousPackages(),
+		UnmanagedBinaries:          defaultUnmanagedBinaries(),
+		FailOnSLA:                  defaultFailOnSLA(),
+		LicensePolicy:              defaultLicensePolicy(),
+		Secrets:                    defaultSecrets(),
+		Push:                       defaultPush(),
+		Redact:                     defaultRedaction(),
+		Telemetry:                  defaultTelemetry(id),
+		CVSSEnvironment:            defaultCVSSEnvironment(),
+		SeverityPolicy:             defaultSeverityPolicy(),
+		Targets:                    defaultTargets(),
 	}
 }
 
@@ -94,6 +151,26 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 		"file to write the default report output to (default is STDOUT)",
 	)
 
+	flags.StringVarP(&o.SignResults,
+		"sign-results", "",
+		"sign the report files with the given PEM private key, writing a DSSE envelope next to each report (<file>.dsse.json)",
+	)
+
+	flags.StringVarP(&o.Push.URL,
+		"push", "",
+		"also send the results to the given URL of a central ingest service (see the push configuration for auth, batching, and retries)",
+	)
+
+	flags.StringVarP(&o.Redact.Mode,
+		"redact", "",
+		"redact file paths, registry hostnames and environment details from the report, options=[strip hash] (see the redact configuration)",
+	)
+
+	flags.BoolVarP(&o.StreamTable,
+		"stream-table", "",
+		"render table rows as vulnerability matches are found instead of after all packages have been matched (requires a table output to stdout)",
+	)
+
 	flags.StringVarP(&o.Name,
 		"name", "",
 		"set the name of the target being analyzed",
@@ -104,6 +181,13 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 		"distro to match against in the format: <distro>[-:@]<version>",
 	)
 
+	o.Network.AddLimitBandwidthFlag(flags)
+
+	flags.StringVarP(&o.DistroAliases,
+		"distro-aliases", "",
+		"file mapping the IDs of custom distros to the distros they are matched as",
+	)
+
 	flags.BoolVarP(&o.GenerateMissingCPEs,
 		"add-cpes-if-none", "",
 		"generate CPEs for packages with no CPE data",
@@ -115,7 +199,17 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 
 	flags.StringVarP(&o.FailOn,
 		"fail-on", "f",
-		fmt.Sprintf("set the return code to 2 if a vulnerability is found with a severity >= the given severity, options=%v", vulnerability.AllSeverities()),
+		fmt.Sprintf("set the return code to 2 if a vulnerability is found with a severity >= the given severity (unless fail-on-sla grace periods are configured, which replace the threshold), options=%v", vulnerability.AllSeverities()),
+	)
+
+	flags.StringVarP(&o.Baseline,
+		"baseline", "",
+		"baseline file (see 'grype baseline') or grype JSON report of a previous scan: findings already in the baseline are reported but do not fail the scan (--fail-on, fail-on-sla)",
+	)
+
+	flags.BoolVarP(&o.FailOnUnusedIgnores,
+		"fail-on-unused-ignores", "",
+		"set the return code to 2 if any configured ignore rule (including ignore files) did not ignore a vulnerability",
 	)
 
 	flags.BoolVarP(&o.OnlyFixed,
@@ -133,6 +227,76 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 		fmt.Sprintf("ignore matches for vulnerabilities with specified comma separated fix states, options=%v", vulnerability.AllFixStates()),
 	)
 
+	flags.StringVarP(&o.MinFixAge,
+		"min-fix-age", "",
+		"ignore matches for vulnerabilities without a fix that has been available for at least the given age (e.g. 30d)",
+	)
+
+	flags.StringVarP(&o.UnboundedMatches,
+		"unbounded-matches", "",
+		fmt.Sprintf("how to handle matches against advisories with no upper bound and no known fix, options=%v", match.AllUnboundedPolicies()),
+	)
+
+	flags.Float64VarP(&o.MinConfidence,
+		"min-confidence", "",
+		"ignore matches with a confidence below this ratio (exact matches: 1.0, source package matches: 0.8, CPE matches: 0.6)",
+	)
+
+	flags.BoolVarP(&o.OnlyDirectDeps,
+		"only-direct-deps", "",
+		"ignore matches on transitive dependencies (requires dependency relationships, e.g. from an SPDX or syft SBOM)",
+	)
+
+	flags.BoolVarP(&o.ExcludeDevDependencies,
+		"exclude-dev-dependencies", "",
+		"ignore matches on development or test dependencies (e.g. maven test scope)",
+	)
+
+	flags.StringVarP(&o.OnlyProject,
+		"only-project", "",
+		"when scanning a directory, only match the packages of the project with this root directory (e.g. services/api)",
+	)
+
+	flags.BoolVarP(&o.IncludeWithdrawn,
+		"include-withdrawn", "",
+		"report matches against vulnerability records that were withdrawn or rejected by their provider",
+	)
+
+	flags.BoolVarP(&o.SeparateIntermediateLayers,
+		"separate-intermediate-layers", "",
+		"with --scope all-layers, report matches on packages removed from the final image separately (as ignored matches)",
+	)
+
+	flags.BoolVarP(&o.ShowResolved,
+		"show-resolved", "",
+		"report vulnerabilities whose fix is already included in the installed package version (alpine packages only) as resolved findings",
+	)
+
+	flags.BoolVarP(&o.DeepJava,
+		"deep-java", "",
+		"fingerprint the class files of java archives to find artifacts shaded without their maven metadata (slow)",
+	)
+
+	flags.BoolVarP(&o.IgnoreEmbeddedSBOM,
+		"ignore-embedded-sbom", "",
+		"catalog OCI artifacts (oci-dir, oci-archive or registry) even when the image carries an SBOM attestation (e.g. built by docker buildx with --sbom)",
+	)
+
+	flags.StringVarP(&o.TimeBudget.Limit,
+		"time-budget", "",
+		"the time allowed for matching (e.g. 5m), after which packages outside of the priority ecosystems are reported as skipped",
+	)
+
+	flags.StringArrayVarP(&o.TimeBudget.PriorityEcosystems,
+		"priority-ecosystems", "",
+		"ecosystems always matched first regardless of the time budget (e.g. os,java,python)",
+	)
+
+	flags.StringVarP(&o.CVSSEnvironment.AssetClass,
+		"asset-class", "",
+		"the class of the scanned asset, selecting the configured CVSS environmental modifiers used to adjust scores",
+	)
+
 	flags.BoolVarP(&o.ByCVE,
 		"by-cve", "",
 		"orient results by CVE instead of the original vulnerability ID when possible",
@@ -143,11 +307,26 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 		"show suppressed/ignored vulnerabilities in the output (only supported with table output format)",
 	)
 
+	flags.BoolVarP(&o.ExplainSeverity,
+		"explain-severity", "",
+		"report how the severity of each match was derived from the severities reported by each source",
+	)
+
 	flags.StringArrayVarP(&o.Exclusions,
 		"exclude", "",
 		"exclude paths from being scanned using a glob expression",
 	)
 
+	flags.StringVarP(&o.DirectoryScan.Symlinks,
+		"symlinks", "",
+		fmt.Sprintf("the symbolic links followed when scanning a directory %v", pkg.AllSymlinkPolicies),
+	)
+
+	flags.BoolVarP(&o.DirectoryScan.SkipMounts,
+		"skip-mounts", "",
+		"skip the directories on another filesystem than the scanned directory (e.g. mounted volumes)",
+	)
+
 	flags.StringVarP(&o.Platform,
 		"platform", "",
 		"an optional platform specifier for container image sources (e.g. 'linux/arm64', 'linux/arm64/v8', 'arm64', 'linux')",
@@ -158,15 +337,123 @@ func (o *Grype) AddFlags(flags clio.FlagSet) {
 		"specify the source behavior to use (e.g. docker, registry, podman, oci-dir, ...)",
 	)
 
+	flags.StringVarP(&o.DB.Trace,
+		"db-trace", "",
+		"write every vulnerability database query (with duration and row count) and a summary of the slowest queries to the given file",
+	)
+
+	flags.StringVarP(&o.DB.RecordInteractions,
+		"record-db-interactions", "",
+		"write every vulnerability database search and its results to the given file (for replaying with --replay-db-interactions)",
+	)
+
+	flags.StringVarP(&o.DB.ReplayInteractions,
+		"replay-db-interactions", "",
+		"scan using the vulnerability database searches recorded in the given file instead of the database",
+	)
+
 	flags.StringArrayVarP(&o.VexDocuments,
 		"vex", "",
 		"a list of VEX documents to consider when producing scanning results",
 	)
+
+	flags.StringArrayVarP(&o.IgnoreFiles,
+		"ignore-file", "",
+		fmt.Sprintf("an annotated ignore file to apply in addition to the configured ignore rules (default: %s when present)", match.DefaultIgnoreFile),
+	)
 }
 
 func (o *Grype) PostLoad() error {
 	o.From = flatten(o.From)
 
+	if o.SBOMCacheDir != "" {
+		dir, err := homedir.Expand(o.SBOMCacheDir)
+		if err != nil {
+			return fmt.Errorf("bad sbom-cache-dir value: %w", err)
+		}
+		o.SBOMCacheDir = dir
+	}
+
+	if o.CPECacheDir != "" {
+		dir, err := homedir.Expand(o.CPECacheDir)
+		if err != nil {
+			return fmt.Errorf("bad cpe-cache-dir value: %w", err)
+		}
+		o.CPECacheDir = dir
+	}
+
+	if o.Baseline != "" {
+		baseline, err := homedir.Expand(o.Baseline)
+		if err != nil {
+			return fmt.Errorf("bad baseline value: %w", err)
+		}
+		if _, err := os.Stat(baseline); err != nil {
+			return fmt.Errorf("invalid baseline report %q: %w", baseline, err)
+		}
+		o.Baseline = baseline
+	}
+
+	if o.SignResults != "" {
+		key, err := homedir.Expand(o.SignResults)
+		if err != nil {
+			return fmt.Errorf("bad sign-results value: %w", err)
+		}
+		o.SignResults = key
+	}
+
+	if err := match.ValidateIgnoreRules(o.Ignore); err != nil {
+		return fmt.Errorf("bad ignore value: %w", err)
+	}
+
+	if o.DistroAliases != "" {
+		path, err := homedir.Expand(o.DistroAliases)
+		if err != nil {
+			return fmt.Errorf("bad distro-aliases value: %w", err)
+		}
+		o.DistroAliases = path
+	}
+
+	for i, f := range o.IgnoreFiles {
+		path, err := homedir.Expand(f)
+		if err != nil {
+			return fmt.Errorf("bad ignore-files value: %w", err)
+		}
+		o.IgnoreFiles[i] = path
+	}
+
+	if o.SeparateIntermediateLayers && o.Search.GetScope() != source.AllLayersScope {
+		return fmt.Errorf("--separate-intermediate-layers may only be used with --scope %s", source.AllLayersScope)
+	}
+
+	if _, ok := o.StreamedOutputs(); o.StreamTable && !ok {
+		return fmt.Errorf("--stream-table requires a table output written to stdout")
+	}
+
+	if o.StreamTable && o.Redact.Mode != "" {
+		// streamed rows are written before the report could be redacted
+		return fmt.Errorf("--stream-table cannot be used with --redact")
+	}
+
+	if o.Targets.File != "" {
+		if err := o.validateTargetsScan(); err != nil {
+			return err
+		}
+	}
+
+	if o.MinFixAge != "" {
+		if _, err := match.ParseAge(o.MinFixAge); err != nil {
+			return fmt.Errorf("bad --min-fix-age value: %w", err)
+		}
+	}
+
+	if _, err := match.ParseUnboundedPolicy(o.UnboundedMatches); err != nil {
+		return fmt.Errorf("bad --unbounded-matches value: %w", err)
+	}
+
+	if o.MinConfidence < 0 || o.MinConfidence > 1 {
+		return fmt.Errorf("bad --min-confidence value '%v': must be between 0 and 1", o.MinConfidence)
+	}
+
 	if o.FailOn != "" {
 		failOnSeverity := *o.FailOnSeverity()
 		if failOnSeverity == vulnerability.UnknownSeverity {
@@ -176,6 +463,21 @@ func (o *Grype) PostLoad() error {
 	return nil
 }
 
+// validateTargetsScan returns an error when options that only apply to scanning a single target are combined with a
+// targets file.
+func (o *Grype) validateTargetsScan() error {
+	if o.StreamTable {
+		return fmt.Errorf("--stream-table cannot be used with --targets-file")
+	}
+	if o.File != "" || slices.ContainsFunc(o.Outputs, func(output string) bool { return strings.Contains(output, "=") }) {
+		return fmt.Errorf("report files cannot be given with --targets-file: the reports of each target are written to --targets-output-dir")
+	}
+	if o.Targets.Parallelism > 1 && o.DB.RecordInteractions != "" {
+		return fmt.Errorf("DB interactions cannot be recorded when scanning targets in parallel")
+	}
+	return nil
+}
+
 func (o *Grype) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	descriptions.Add(&o.CheckForAppUpdate, `enable/disable checking for application updates on startup`)
 	descriptions.Add(&o.DefaultImagePullSource, `allows users to specify which image source should be used to generate the sbom
@@ -191,11 +493,33 @@ the default path to the template file is the current working directory
 output-template-file: .grype/html.tmpl
 
 write output report to a file (default is to write to stdout)`)
-	descriptions.Add(&o.Outputs, `the output format of the vulnerability report (options: table, template, json, cyclonedx)
+	descriptions.Add(&o.Outputs, `the output format of the vulnerability report (options: table, template, json, cyclonedx, spdx3-json, badge, badge-json)
 when using template as the output type, you must also provide a value for 'output-template-file'`)
 	descriptions.Add(&o.Pretty, `pretty-print output`)
+	descriptions.Add(&o.DistroAliases, `path to a file mapping the IDs of custom distros (e.g. in-house derivatives) to the distro their packages are
+matched as, applied to the detected distro, to --distro and to the distro qualifiers of package URLs, for example:
+  aliases:
+    - id: acmeos
+      as: rhel
+      versions:
+        "3": "9"
+versions are mapped by full or major version (unmapped versions are kept unless 'version' is given) (same as --distro-aliases)`)
+	descriptions.Add(&o.SignResults, `path to a PEM-encoded ECDSA, Ed25519 or RSA private key used to sign every report written to a file;
+a DSSE envelope is written next to each report (<file>.dsse.json), which 'grype verify-results' validates
+(same as --sign-results)`)
+	descriptions.Add(&o.StreamTable, `render the rows of the table written to stdout as vulnerability matches are found (in discovery order) rather
+than after all packages have been matched. Rows are only written once the matches of a package are final (after ignore
+rules, VEX documents and the other match policies are applied), and other outputs are still written once matching
+completes (same as --stream-table)`)
 	descriptions.Add(&o.FailOn, `upon scanning, if a severity is found at or above the given severity then the return code will be 1
 default is unset which will skip this validation (options: negligible, low, medium, high, critical)`)
+	descriptions.Add(&o.Baseline, `path to a baseline file (managed with 'grype baseline') or the grype JSON report of a previous scan (e.g. of the
+main branch): findings already in the baseline (by vulnerability and package) are still reported, but only new findings
+are evaluated against fail-on-severity and fail-on-sla, so existing findings do not fail the scan while regressions do.
+Findings of the baseline that are no longer found are reported as stale (same as --baseline)`)
+	descriptions.Add(&o.FailOnUnusedIgnores, `set the return code to 2 if any of the configured ignore rules (of the configuration and ignore files) did not
+ignore a vulnerability, so that stale suppressions do not accumulate. Unused ignore rules are always reported as
+warnings and listed as the unusedIgnoreRules of the JSON report (same as --fail-on-unused-ignores)`)
 	descriptions.Add(&o.Ignore, `A list of vulnerability ignore rules, one or more property may be specified and all matching vulnerabilities will be ignored.
 This is the full set of supported rule fields:
   - vulnerability: CVE-2008-4318
@@ -205,15 +529,106 @@ This is the full set of supported rule fields:
       version: 1.5.1
       type: npm
       location: "/usr/local/lib/node_modules/**"
+    # ignore fixed vulnerabilities whose earliest fix became available within the given age (e.g. 30d or 72h)
+    fix-available-within: 30d
+    # globs of where the package was found (its primary evidence), e.g. vendored test fixtures or documentation trees
+    locations:
+      - "**/test/**"
+      - "/usr/share/doc/**"
 
 VEX fields apply when Grype reads vex data:
   - vex-status: not_affected
     vex-justification: vulnerable_code_not_present
 `)
+	descriptions.Add(&o.IgnoreFiles, fmt.Sprintf(`annotated ignore files, whose rules are applied in addition to the ignore rules above. Rules support the same
+fields, must state a reason, and may record an owner, a ticket and an expiry (a date or RFC3339 timestamp) after
+which the rule no longer applies:
+  ignore:
+    - vulnerability: CVE-2024-1234
+      package:
+        name: lodash
+      reason: the vulnerable function is never called
+      owner: team-web
+      ticket: SEC-123
+      expires: 2025-06-30
+%s is read from the working directory when no files are given (same as --ignore-file)`, match.DefaultIgnoreFile))
+	descriptions.Add(&o.MinFixAge, `only show vulnerabilities whose earliest fix has been available for at least the given age (e.g. 30d or 72h),
+ignoring vulnerabilities without a fix. Fixed vulnerabilities with no known fix date are still shown
+(same as --min-fix-age)`)
+	descriptions.Add(&o.UnboundedMatches, `how to handle matches against "affected, fix unknown" advisories, whose affected range has no upper bound
+and no fix version (options: match, warn, skip). "match" reports them as regular matches, "warn" reports them but
+does not consider them for --fail-on and SLA failures, and "skip" moves them to the ignored matches
+(same as --unbounded-matches)`)
+	descriptions.Add(&o.MinConfidence, `ignore matches with a confidence below this ratio: exact package matches have a confidence of 1.0, matches
+inherited from a source or upstream package 0.8, and CPE matches 0.6 (0 keeps all matches, same as --min-confidence)`)
+	descriptions.Add(&o.OnlyDirectDeps, `ignore matches on transitive dependencies, that is, packages that are only brought in by another dependency
+of a root package. This relies on the dependency relationships of the scanned packages (e.g. DEPENDS_ON and CONTAINED_BY
+relationships of SPDX SBOMs); OS packages and packages without dependency information are always considered (same as
+--only-direct-deps)`)
+	descriptions.Add(&o.ExcludeDevDependencies, `ignore matches on packages only needed for development or testing, as indicated by the dependency scope of
+the cataloged packages where available (e.g. maven dependencies of the test scope). Such packages carry the
+"dev-dependency" annotation, and their matches are not considered for --fail-on (same as --exclude-dev-dependencies)`)
+	descriptions.Add(&o.OnlyProject, `when scanning a directory, only match the packages of the project with this root directory (relative to the
+scanned directory, e.g. services/api). Projects are detected by their manifests (package.json, go.mod or pom.xml, and
+their lock files), each package belongs to the innermost project containing it, and matches are summarized per project
+in the "projects" section of the JSON output (same as --only-project)`)
+	descriptions.Add(&o.IncludeWithdrawn, `report matches against vulnerability records that were withdrawn or rejected by their provider (e.g. a
+withdrawn GitHub advisory or a rejected CVE), which are otherwise excluded from matching (same as --include-withdrawn)`)
+	descriptions.Add(&o.SeparateIntermediateLayers, `when scanning all layers of an image (--scope all-layers), report matches on packages only present in intermediate
+layers (e.g. removed by a later layer, like a cleaned up package cache) separately from the matches on the final image:
+they are moved to the ignored matches (shown with --show-suppressed) and do not count towards --fail-on. The layers
+such packages were found in are listed in their "intermediate-layer" annotation (same as --separate-intermediate-layers)`)
+	descriptions.Add(&o.ShowResolved, `report the vulnerabilities whose fix is already included in the installed version of a package in the
+"resolvedFindings" section of the JSON output (vulnerability, fixed in versions and current version), to demonstrate
+remediation without comparing against older scans. Currently only the alpine secdb is considered (same as --show-resolved)`)
+	descriptions.Add(&o.DeepJava, `fingerprint the class files of java archives (including nested archives) against the class fingerprints of the
+vulnerability DB, to find maven artifacts that were shaded or repackaged without their metadata (e.g. a vulnerable
+log4j-core bundled into an application jar). Every java archive is read in full, so this is considerably slower
+(same as --deep-java)`)
+	descriptions.Add(&o.IgnoreEmbeddedSBOM, `catalog images given as OCI artifacts (oci-dir, oci-archive or registry sources) even when the image carries
+SBOM attestations. By default the SBOMs attested within the image (e.g. by docker buildx with --sbom) are used instead of
+cataloging the image, and the JSON descriptor records which was done under "cataloging" (same as --ignore-embedded-sbom)`)
+	descriptions.Add(&o.SBOMCacheDir, `directory to cache the cataloging results of container image layers in, keyed by the ordered chain of layer
+digests up to each layer, the syft version and the cataloger configuration. Each layer is cataloged on its own and the
+results are merged per image, so layers shared by several images (e.g. a base image) are only cataloged once. Packages
+identified from files of several layers at once (e.g. file ownership overlap between packages) may differ from
+cataloging the image as a whole (disabled when empty)`)
+	descriptions.Add(&o.CPECacheDir, `directory to persist CPEs generated for packages without CPEs (see add-cpes-if-none) in, so that later scans
+of the same packages do not generate them again (disabled when empty)`)
 	descriptions.Add(&o.VexAdd, `VEX statuses to consider as ignored rules`)
 	descriptions.Add(&o.MatchUpstreamKernelHeaders, `match kernel-header packages with upstream kernel as kernel vulnerabilities`)
 }
 
+// StreamedOutputs returns the outputs written once all packages have been matched when the table rows are streamed:
+// all outputs but the table written to stdout (false when there is no such table output).
+func (o Grype) StreamedOutputs() ([]string, bool) {
+	outputs := o.Outputs
+	if len(outputs) == 0 {
+		outputs = []string{format.TableFormat.String()}
+	}
+
+	var rest []string
+	streamed := false
+	for _, output := range outputs {
+		name, file, hasFile := strings.Cut(strings.TrimSpace(output), "=")
+		if !hasFile {
+			file = o.File
+		}
+		if !streamed && file == "" && format.Parse(name) == format.TableFormat {
+			streamed = true
+			continue
+		}
+		rest = append(rest, output)
+	}
+	return rest, streamed
+}
+
+// RegistryOptions returns the registry options for pulling the given image reference, with the TLS settings for its
+// registry host applied.
+func (o Grype) RegistryOptions(imageRef string) *image.RegistryOptions {
+	return o.Registry.ToOptions(o.TLS, registryHost(imageRef))
+}
+
 func (o Grype) FailOnSeverity() *vulnerability.Severity {
 	severity := vulnerability.ParseSeverity(o.FailOn)
 	return &severity
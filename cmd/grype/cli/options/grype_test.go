@@ -1,9 +1,14 @@
 package options
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/match"
 )
 
 func Test_flatten(t *testing.T) {
@@ -46,3 +51,158 @@ func Test_flatten(t *testing.T) {
 		})
 	}
 }
+
+func TestGrype_PostLoad_streamTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		outputs  []string
+		file     string
+		wantRest []string
+		wantErr  bool
+	}{
+		{
+			name: "default output",
+		},
+		{
+			name:    "explicit table output",
+			outputs: []string{"table"},
+		},
+		{
+			name:    "non-table output",
+			outputs: []string{"json"},
+			wantErr: true,
+		},
+		{
+			name:     "other outputs are written once matching completes",
+			outputs:  []string{"json=report.json", "table", "sarif=report.sarif"},
+			wantRest: []string{"json=report.json", "sarif=report.sarif"},
+		},
+		{
+			name:     "only one table is streamed",
+			outputs:  []string{"table", "table=report.txt"},
+			wantRest: []string{"table=report.txt"},
+		},
+		{
+			name:    "table written to a file",
+			outputs: []string{"table=report.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "default output written to a file",
+			file:    "report.txt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := Grype{
+				StreamTable: true,
+				Outputs:     tt.outputs,
+				File:        tt.file,
+			}
+			err := o.PostLoad()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			rest, ok := o.StreamedOutputs()
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
+
+func TestGrype_PostLoad_minFixAge(t *testing.T) {
+	tests := []struct {
+		minFixAge string
+		wantErr   bool
+	}{
+		{minFixAge: ""},
+		{minFixAge: "30d"},
+		{minFixAge: "720h"},
+		{minFixAge: "30 days", wantErr: true},
+		{minFixAge: "-1d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.minFixAge, func(t *testing.T) {
+			o := Grype{MinFixAge: tt.minFixAge}
+			err := o.PostLoad()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGrype_PostLoad_unboundedMatches(t *testing.T) {
+	tests := []struct {
+		unboundedMatches string
+		wantErr          bool
+	}{
+		{unboundedMatches: ""},
+		{unboundedMatches: "match"},
+		{unboundedMatches: "warn"},
+		{unboundedMatches: "skip"},
+		{unboundedMatches: "ignore", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unboundedMatches, func(t *testing.T) {
+			o := Grype{UnboundedMatches: tt.unboundedMatches}
+			err := o.PostLoad()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGrype_PostLoad_minConfidence(t *testing.T) {
+	tests := []struct {
+		minConfidence float64
+		wantErr       bool
+	}{
+		{minConfidence: 0},
+		{minConfidence: 0.6},
+		{minConfidence: 1},
+		{minConfidence: -0.1, wantErr: true},
+		{minConfidence: 1.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.FormatFloat(tt.minConfidence, 'f', -1, 64), func(t *testing.T) {
+			o := Grype{MinConfidence: tt.minConfidence}
+			err := o.PostLoad()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGrype_PostLoad_ignoreLocations(t *testing.T) {
+	o := Grype{Ignore: []match.IgnoreRule{{Locations: []string{"**/test/**"}}}}
+	require.NoError(t, o.PostLoad())
+
+	o = Grype{Ignore: []match.IgnoreRule{{Locations: []string{"/usr/{share"}}}}
+	assert.ErrorContains(t, o.PostLoad(), "invalid location glob")
+}
+
+func TestGrype_PostLoad_separateIntermediateLayers(t *testing.T) {
+	o := DefaultGrype(clio.Identification{Name: "grype"})
+	o.SeparateIntermediateLayers = true
+	assert.ErrorContains(t, o.PostLoad(), "--scope all-layers")
+
+	o.Search.Scope = "all-layers"
+	require.NoError(t, o.PostLoad())
+}
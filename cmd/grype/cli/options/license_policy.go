@@ -0,0 +1,41 @@
+package options
+
+import (
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/license"
+)
+
+// LicensePolicy configures the license policy that package licenses are evaluated against.
+type LicensePolicy struct {
+	Allow           []string `yaml:"allow" json:"allow" mapstructure:"allow"`
+	Deny            []string `yaml:"deny" json:"deny" mapstructure:"deny"`
+	FailOnViolation bool     `yaml:"fail-on-violation" json:"fail-on-violation" mapstructure:"fail-on-violation"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*LicensePolicy)(nil)
+
+func defaultLicensePolicy() LicensePolicy {
+	return LicensePolicy{}
+}
+
+func (l *LicensePolicy) PostLoad() error {
+	l.Allow = flatten(l.Allow)
+	l.Deny = flatten(l.Deny)
+	return nil
+}
+
+func (l *LicensePolicy) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&l.Allow, `SPDX license IDs that packages may use; when set, any package license (or license expression) that cannot be satisfied by these licenses is reported as a violation`)
+	descriptions.Add(&l.Deny, `SPDX license IDs that packages may not use; license expressions are only reported when they cannot be satisfied without a denied license`)
+	descriptions.Add(&l.FailOnViolation, `set the return code to 2 if any package license violates the license policy`)
+}
+
+func (l LicensePolicy) ToPolicy() license.Policy {
+	return license.Policy{
+		Allow: l.Allow,
+		Deny:  l.Deny,
+	}
+}
@@ -0,0 +1,22 @@
+package options
+
+import "github.com/anchore/clio"
+
+// MaliciousPackages configures how findings for known-malicious packages are reported.
+type MaliciousPackages struct {
+	// Enabled looks up package URLs and artifact digests against known-malicious package records and fails the scan on
+	// any malicious package
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+}
+
+var _ clio.FieldDescriber = (*MaliciousPackages)(nil)
+
+func defaultMaliciousPackages() MaliciousPackages {
+	return MaliciousPackages{
+		Enabled: false,
+	}
+}
+
+func (m *MaliciousPackages) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&m.Enabled, `detect known-malicious packages (e.g. from the OSV and OpenSSF malicious-packages datasets) by looking up the package URL and artifact digests of every package, in addition to matching by name and version; malicious package matches are flagged in every output format, and any such match fails the scan when --fail-on is set`)
+}
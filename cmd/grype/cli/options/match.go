@@ -2,25 +2,34 @@ package options
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/version"
 )
 
 // matchConfig contains all matching-related configuration options available to the user via the application config.
 type matchConfig struct {
-	Java       matcherConfig `yaml:"java" json:"java" mapstructure:"java"`                   // settings for the java matcher
-	JVM        matcherConfig `yaml:"jvm" json:"jvm" mapstructure:"jvm"`                      // settings for the jvm matcher
-	Dotnet     matcherConfig `yaml:"dotnet" json:"dotnet" mapstructure:"dotnet"`             // settings for the dotnet matcher
-	Golang     golangConfig  `yaml:"golang" json:"golang" mapstructure:"golang"`             // settings for the golang matcher
-	Javascript matcherConfig `yaml:"javascript" json:"javascript" mapstructure:"javascript"` // settings for the javascript matcher
-	Python     matcherConfig `yaml:"python" json:"python" mapstructure:"python"`             // settings for the python matcher
-	Ruby       matcherConfig `yaml:"ruby" json:"ruby" mapstructure:"ruby"`                   // settings for the ruby matcher
-	Rust       matcherConfig `yaml:"rust" json:"rust" mapstructure:"rust"`                   // settings for the rust matcher
-	Hex        matcherConfig `yaml:"hex" json:"hex" mapstructure:"hex"`                      // settings for the hex matcher (Elixir/Erlang)
-	Stock      matcherConfig `yaml:"stock" json:"stock" mapstructure:"stock"`                // settings for the default/stock matcher
-	Dpkg       dpkgConfig    `yaml:"dpkg" json:"dpkg" mapstructure:"dpkg"`                   // settings for the dpkg matcher
-	Rpm        rpmConfig     `yaml:"rpm" json:"rpm" mapstructure:"rpm"`                      // settings for the rpm matcher
+	Java       matcherConfig   `yaml:"java" json:"java" mapstructure:"java"`                   // settings for the java matcher
+	JVM        matcherConfig   `yaml:"jvm" json:"jvm" mapstructure:"jvm"`                      // settings for the jvm matcher
+	Dotnet     dotnetConfig    `yaml:"dotnet" json:"dotnet" mapstructure:"dotnet"`             // settings for the dotnet matcher
+	Golang     golangConfig    `yaml:"golang" json:"golang" mapstructure:"golang"`             // settings for the golang matcher
+	Javascript matcherConfig   `yaml:"javascript" json:"javascript" mapstructure:"javascript"` // settings for the javascript matcher
+	Python     matcherConfig   `yaml:"python" json:"python" mapstructure:"python"`             // settings for the python matcher
+	Ruby       matcherConfig   `yaml:"ruby" json:"ruby" mapstructure:"ruby"`                   // settings for the ruby matcher
+	Rust       matcherConfig   `yaml:"rust" json:"rust" mapstructure:"rust"`                   // settings for the rust matcher
+	Hex        matcherConfig   `yaml:"hex" json:"hex" mapstructure:"hex"`                      // settings for the hex matcher (Elixir/Erlang)
+	Terraform  matcherConfig   `yaml:"terraform" json:"terraform" mapstructure:"terraform"`    // settings for the terraform matcher (providers and modules)
+	Stock      matcherConfig   `yaml:"stock" json:"stock" mapstructure:"stock"`                // settings for the default/stock matcher
+	Bitnami    matcherConfig   `yaml:"bitnami" json:"bitnami" mapstructure:"bitnami"`          // settings for the bitnami matcher
+	Dpkg       dpkgConfig      `yaml:"dpkg" json:"dpkg" mapstructure:"dpkg"`                   // settings for the dpkg matcher
+	Rpm        rpmConfig       `yaml:"rpm" json:"rpm" mapstructure:"rpm"`                      // settings for the rpm matcher
+	Upstreams  upstreamsConfig `yaml:"upstreams" json:"upstreams" mapstructure:"upstreams"`    // settings for matching distro packages via their source packages
+	// Reconciliation is how disagreements between a distro and NVD about the same vulnerability are resolved
+	Reconciliation string `yaml:"reconciliation" json:"reconciliation" mapstructure:"reconciliation"`
 }
 
 var _ interface {
@@ -38,6 +47,11 @@ type golangConfig struct {
 	AllowMainModulePseudoVersionComparison bool `yaml:"allow-main-module-pseudo-version-comparison" json:"allow-main-module-pseudo-version-comparison" mapstructure:"allow-main-module-pseudo-version-comparison"` // if pseudo versions should be compared
 }
 
+type dotnetConfig struct {
+	matcherConfig          `yaml:",inline" mapstructure:",squash"`
+	AlwaysUseCPEForRuntime bool `yaml:"always-use-cpe-for-runtime" json:"always-use-cpe-for-runtime" mapstructure:"always-use-cpe-for-runtime"` // if CPEs should be used for the .NET runtime
+}
+
 // dpkgConfig contains configuration for the dpkg matcher.
 type dpkgConfig struct {
 	matcherConfig `yaml:",inline" mapstructure:",squash"`
@@ -59,8 +73,9 @@ type dpkgConfig struct {
 	//
 	//   With "zero": Treat package as 0:2.0.0 → MATCH (0 < 1)
 	//   With "auto": Treat package as 1:2.0.0 → NO MATCH (2.0.0 > 1.5.0)
-	MissingEpochStrategy version.MissingEpochStrategy `yaml:"missing-epoch-strategy" json:"missing-epoch-strategy" mapstructure:"missing-epoch-strategy"`
-	UseCPEsForEOL        bool                         `yaml:"use-cpes-for-eol" json:"use-cpes-for-eol" mapstructure:"use-cpes-for-eol"` // if CPEs should be used for EOL distro packages
+	MissingEpochStrategy  version.MissingEpochStrategy `yaml:"missing-epoch-strategy" json:"missing-epoch-strategy" mapstructure:"missing-epoch-strategy"`
+	UseCPEsForEOL         bool                         `yaml:"use-cpes-for-eol" json:"use-cpes-for-eol" mapstructure:"use-cpes-for-eol"`                            // if CPEs should be used for EOL distro packages
+	UbuntuProFixesAsFixed bool                         `yaml:"ubuntu-pro-fixes-as-fixed" json:"ubuntu-pro-fixes-as-fixed" mapstructure:"ubuntu-pro-fixes-as-fixed"` // if fixes only available with Ubuntu Pro are considered fixed
 }
 
 // rpmConfig contains configuration for the RPM matcher.
@@ -85,7 +100,22 @@ type rpmConfig struct {
 	//   With "zero": Treat package as 0:2.0.0 → MATCH (0 < 1)
 	//   With "auto": Treat package as 1:2.0.0 → NO MATCH (2.0.0 > 1.5.0)
 	MissingEpochStrategy version.MissingEpochStrategy `yaml:"missing-epoch-strategy" json:"missing-epoch-strategy" mapstructure:"missing-epoch-strategy"`
-	UseCPEsForEOL        bool                         `yaml:"use-cpes-for-eol" json:"use-cpes-for-eol" mapstructure:"use-cpes-for-eol"` // if CPEs should be used for EOL distro packages
+	// BinaryMissingEpochStrategy controls how binary packages without an epoch (in neither
+	// the version nor the RPM metadata, e.g. from an SBOM) are handled during matching.
+	//
+	// Valid values:
+	//   - "zero" (default): Treat missing epochs as 0
+	//   - "auto": Assume missing epoch matches the constraint's epoch
+	//
+	// When the assumed epoch decides that a package is vulnerable, the match details
+	// explain the assumption (see "epochAssumption" in the JSON output).
+	BinaryMissingEpochStrategy version.MissingEpochStrategy `yaml:"binary-missing-epoch-strategy" json:"binary-missing-epoch-strategy" mapstructure:"binary-missing-epoch-strategy"`
+	UseCPEsForEOL              bool                         `yaml:"use-cpes-for-eol" json:"use-cpes-for-eol" mapstructure:"use-cpes-for-eol"` // if CPEs should be used for EOL distro packages
+}
+
+// upstreamsConfig contains configuration for matching distro packages via their source (upstream) packages.
+type upstreamsConfig struct {
+	DisabledDistros []string `yaml:"disabled-distros" json:"disabled-distros" mapstructure:"disabled-distros"` // distros for which packages are not matched via their source packages
 }
 
 func defaultGolangConfig() golangConfig {
@@ -100,9 +130,19 @@ func defaultGolangConfig() golangConfig {
 
 func defaultRpmConfig() rpmConfig {
 	return rpmConfig{
-		matcherConfig:        matcherConfig{UseCPEs: false},
-		MissingEpochStrategy: version.MissingEpochStrategyAuto,
-		UseCPEsForEOL:        false,
+		matcherConfig:              matcherConfig{UseCPEs: false},
+		MissingEpochStrategy:       version.MissingEpochStrategyAuto,
+		BinaryMissingEpochStrategy: version.MissingEpochStrategyZero,
+		UseCPEsForEOL:              false,
+	}
+}
+
+func defaultDotnetConfig() dotnetConfig {
+	return dotnetConfig{
+		matcherConfig: matcherConfig{
+			UseCPEs: false,
+		},
+		AlwaysUseCPEForRuntime: true,
 	}
 }
 
@@ -120,16 +160,20 @@ func defaultMatchConfig() matchConfig {
 	return matchConfig{
 		Java:       dontUseCpe,
 		JVM:        useCpe,
-		Dotnet:     dontUseCpe,
+		Dotnet:     defaultDotnetConfig(),
 		Golang:     defaultGolangConfig(),
 		Javascript: dontUseCpe,
 		Python:     dontUseCpe,
 		Ruby:       dontUseCpe,
 		Rust:       dontUseCpe,
 		Hex:        dontUseCpe,
+		Terraform:  useCpe,
 		Stock:      useCpe,
+		Bitnami:    useCpe,
 		Dpkg:       defaultDpkgConfig(),
 		Rpm:        defaultRpmConfig(),
+
+		Reconciliation: string(match.ReconcilePreferDistro),
 	}
 }
 
@@ -140,15 +184,45 @@ func (cfg *matchConfig) PostLoad() error {
 	if err := cfg.Dpkg.PostLoad(); err != nil {
 		return err
 	}
+	if err := cfg.Upstreams.PostLoad(); err != nil {
+		return err
+	}
+	if _, err := match.ParseReconciliationPolicy(cfg.Reconciliation); err != nil {
+		return fmt.Errorf("invalid match.reconciliation: %w", err)
+	}
+	return nil
+}
+
+// PostLoad validates the upstreams configuration.
+func (cfg *upstreamsConfig) PostLoad() error {
+	cfg.DisabledDistros = flatten(cfg.DisabledDistros)
+	for _, d := range cfg.DisabledDistros {
+		if _, ok := distro.IDMapping[strings.ToLower(d)]; !ok {
+			return fmt.Errorf("invalid upstreams.disabled-distros entry: %q", d)
+		}
+	}
 	return nil
 }
 
+// ToConfig returns the upstream matching configuration for the vulnerability matcher.
+func (cfg upstreamsConfig) ToConfig() grype.UpstreamMatchingConfig {
+	var disabled []distro.Type
+	for _, d := range cfg.DisabledDistros {
+		disabled = append(disabled, distro.IDMapping[strings.ToLower(d)])
+	}
+	return grype.UpstreamMatchingConfig{DisabledDistros: disabled}
+}
+
 // PostLoad validates the RPM configuration.
 func (cfg *rpmConfig) PostLoad() error {
 	if cfg.MissingEpochStrategy != version.MissingEpochStrategyZero && cfg.MissingEpochStrategy != version.MissingEpochStrategyAuto {
 		return fmt.Errorf("invalid rpm.missing-epoch-strategy: %q (allowable: %s, %s)",
 			cfg.MissingEpochStrategy, version.MissingEpochStrategyZero, version.MissingEpochStrategyAuto)
 	}
+	if cfg.BinaryMissingEpochStrategy != version.MissingEpochStrategyZero && cfg.BinaryMissingEpochStrategy != version.MissingEpochStrategyAuto {
+		return fmt.Errorf("invalid rpm.binary-missing-epoch-strategy: %q (allowable: %s, %s)",
+			cfg.BinaryMissingEpochStrategy, version.MissingEpochStrategyZero, version.MissingEpochStrategyAuto)
+	}
 	return nil
 }
 
@@ -165,6 +239,7 @@ func (cfg *matchConfig) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	usingCpeDescription := `use CPE matching to find vulnerabilities`
 	descriptions.Add(&cfg.Java.UseCPEs, usingCpeDescription)
 	descriptions.Add(&cfg.Dotnet.UseCPEs, usingCpeDescription)
+	descriptions.Add(&cfg.Dotnet.AlwaysUseCPEForRuntime, usingCpeDescription+" for the .NET runtime (advisories for the runtime are largely published against the .NET / ASP.NET Core products)")
 	descriptions.Add(&cfg.Golang.UseCPEs, usingCpeDescription)
 	descriptions.Add(&cfg.Golang.AlwaysUseCPEForStdlib, usingCpeDescription+" for the Go standard library")
 	descriptions.Add(&cfg.Golang.AllowMainModulePseudoVersionComparison, `allow comparison between main module pseudo-versions (e.g. v0.0.0-20240413-2b432cf643...)`)
@@ -173,13 +248,31 @@ func (cfg *matchConfig) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	descriptions.Add(&cfg.Ruby.UseCPEs, usingCpeDescription)
 	descriptions.Add(&cfg.Rust.UseCPEs, usingCpeDescription)
 	descriptions.Add(&cfg.Hex.UseCPEs, usingCpeDescription)
+	descriptions.Add(&cfg.Terraform.UseCPEs, usingCpeDescription)
 	descriptions.Add(&cfg.Stock.UseCPEs, usingCpeDescription)
+	descriptions.Add(&cfg.Bitnami.UseCPEs, usingCpeDescription+` to find upstream product advisories for Bitnami-packaged components`)
 	descriptions.Add(&cfg.Dpkg.MissingEpochStrategy,
 		`strategy for handling missing epochs in dpkg package versions during matching (options: zero, auto)`)
+	descriptions.Add(&cfg.Dpkg.UbuntuProFixesAsFixed,
+		`consider vulnerabilities whose fixes are only available with Ubuntu Pro (published in the ESM channel) as fixed, even
+when the distro does not have the ESM channel enabled (e.g. for --only-fixed and --fail-on gating). Either way such fixes
+are reported with the "ubuntu-pro" entitlement`)
 	descriptions.Add(&cfg.Rpm.MissingEpochStrategy,
 		`strategy for handling missing epochs in RPM package versions during matching (options: zero, auto)`)
+	descriptions.Add(&cfg.Rpm.BinaryMissingEpochStrategy,
+		`strategy for handling binary RPM packages without any epoch information (e.g. from an SBOM) during matching: assume
+epoch 0 (zero) or the epoch of the vulnerable version range (auto). Matches decided by the assumed epoch are explained
+in the match details (options: zero, auto)`)
 
 	eolCpeDescription := `use CPE matching for packages from end-of-life distributions`
 	descriptions.Add(&cfg.Dpkg.UseCPEsForEOL, eolCpeDescription)
 	descriptions.Add(&cfg.Rpm.UseCPEsForEOL, eolCpeDescription)
+
+	descriptions.Add(&cfg.Upstreams.DisabledDistros, `distro IDs (e.g. "debian", "rhel") whose packages are only matched by their own name, not via their source
+(upstream) packages; matches made via a source package are annotated with "matchedVia: upstream"`)
+
+	descriptions.Add(&cfg.Reconciliation, `how to resolve disagreements between a distro and NVD about the same vulnerability, when one reports a package
+(or the distro package owning its files) as affected and the other as not affected or fixed: follow the distro
+(prefer-distro), follow NVD (prefer-nvd) or report the matches of both (report-both). Disputed matches record the
+decision (options: prefer-distro, prefer-nvd, report-both)`)
 }
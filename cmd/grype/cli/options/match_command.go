@@ -0,0 +1,15 @@
+package options
+
+// MatchCommand is the configuration of commands that describe (rather than perform) vulnerability matching, sharing the
+// match configuration of scans.
+type MatchCommand struct {
+	Match           matchConfig     `yaml:"match" json:"match" mapstructure:"match"`
+	ExternalSources externalSources `yaml:"external-sources" json:"externalSources" mapstructure:"external-sources"`
+}
+
+func DefaultMatchCommand() *MatchCommand {
+	return &MatchCommand{
+		Match:           defaultMatchConfig(),
+		ExternalSources: defaultExternalSources(),
+	}
+}
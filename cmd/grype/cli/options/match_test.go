@@ -6,6 +6,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/grype/version"
 )
 
@@ -18,12 +20,12 @@ func TestRpmConfig_PostLoad(t *testing.T) {
 	}{
 		{
 			name:    "valid zero strategy",
-			cfg:     rpmConfig{MissingEpochStrategy: "zero"},
+			cfg:     rpmConfig{MissingEpochStrategy: "zero", BinaryMissingEpochStrategy: "zero"},
 			wantErr: false,
 		},
 		{
 			name:    "valid auto strategy",
-			cfg:     rpmConfig{MissingEpochStrategy: "auto"},
+			cfg:     rpmConfig{MissingEpochStrategy: "auto", BinaryMissingEpochStrategy: "zero"},
 			wantErr: false,
 		},
 		{
@@ -48,6 +50,23 @@ func TestRpmConfig_PostLoad(t *testing.T) {
 			cfg:     rpmConfig{MissingEpochStrategy: "AUTO"},
 			wantErr: true,
 		},
+		{
+			name:    "valid auto binary strategy",
+			cfg:     rpmConfig{MissingEpochStrategy: "auto", BinaryMissingEpochStrategy: "auto"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid binary strategy",
+			cfg:     rpmConfig{MissingEpochStrategy: "auto", BinaryMissingEpochStrategy: "garbage"},
+			wantErr: true,
+			errMsg:  `invalid rpm.binary-missing-epoch-strategy: "garbage" (allowable: zero, auto)`,
+		},
+		{
+			name:    "empty binary strategy fails validation",
+			cfg:     rpmConfig{MissingEpochStrategy: "auto"},
+			wantErr: true,
+			errMsg:  `invalid rpm.binary-missing-epoch-strategy: "" (allowable: zero, auto)`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,7 +145,7 @@ func TestMatchConfig_PostLoad(t *testing.T) {
 		{
 			name: "valid rpm and dpkg configs",
 			cfg: matchConfig{
-				Rpm:  rpmConfig{MissingEpochStrategy: "zero"},
+				Rpm:  rpmConfig{MissingEpochStrategy: "zero", BinaryMissingEpochStrategy: "zero"},
 				Dpkg: dpkgConfig{MissingEpochStrategy: "auto"},
 			},
 			wantErr: false,
@@ -143,7 +162,7 @@ func TestMatchConfig_PostLoad(t *testing.T) {
 		{
 			name: "invalid dpkg config",
 			cfg: matchConfig{
-				Rpm:  rpmConfig{MissingEpochStrategy: "zero"},
+				Rpm:  rpmConfig{MissingEpochStrategy: "zero", BinaryMissingEpochStrategy: "zero"},
 				Dpkg: dpkgConfig{MissingEpochStrategy: "bad"},
 			},
 			wantErr: true,
@@ -209,3 +228,12 @@ func TestDefaultMatchConfig(t *testing.T) {
 	err := cfg.PostLoad()
 	require.NoError(t, err, "default match config should be valid")
 }
+
+func TestUpstreamsConfig_PostLoad(t *testing.T) {
+	cfg := upstreamsConfig{DisabledDistros: []string{"debian,RHEL"}}
+	require.NoError(t, cfg.PostLoad())
+	assert.Equal(t, grype.UpstreamMatchingConfig{DisabledDistros: []distro.Type{distro.Debian, distro.RedHat}}, cfg.ToConfig())
+
+	cfg = upstreamsConfig{DisabledDistros: []string{"not-a-distro"}}
+	require.ErrorContains(t, cfg.PostLoad(), `invalid upstreams.disabled-distros entry: "not-a-distro"`)
+}
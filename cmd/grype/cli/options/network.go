@@ -0,0 +1,89 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/internal/bandwidth"
+	"github.com/anchore/grype/internal/retry"
+)
+
+// Network configures how network operations failing with transient errors are retried, and the bandwidth downloads
+// may use.
+type Network struct {
+	MaxRetries     int           `yaml:"max-retries" json:"max-retries" mapstructure:"max-retries"`
+	Backoff        time.Duration `yaml:"backoff" json:"backoff" mapstructure:"backoff"`
+	MaxBackoff     time.Duration `yaml:"max-backoff" json:"max-backoff" mapstructure:"max-backoff"`
+	Timeout        time.Duration `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+	LimitBandwidth string        `yaml:"limit-bandwidth" json:"limit-bandwidth" mapstructure:"limit-bandwidth"` // --limit-bandwidth, maximum download rate (e.g. 10MB/s)
+
+	// limiter is shared by every client configured from these options, so that the limit applies to all downloads
+	limiter *bandwidth.Limiter
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Network)(nil)
+
+func DefaultNetwork() Network {
+	cfg := retry.DefaultConfig()
+	return Network{
+		MaxRetries: cfg.MaxRetries,
+		Backoff:    cfg.Backoff,
+		MaxBackoff: cfg.MaxBackoff,
+		Timeout:    cfg.Timeout,
+	}
+}
+
+// AddLimitBandwidthFlag adds the --limit-bandwidth flag (commands share the network options with the nested
+// database options, so the flag is added by each command downloading data).
+func (cfg *Network) AddLimitBandwidthFlag(flags clio.FlagSet) {
+	flags.StringVarP(&cfg.LimitBandwidth,
+		"limit-bandwidth", "",
+		"maximum rate of database downloads and image pulls (e.g. 10MB/s)",
+	)
+}
+
+func (cfg *Network) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.MaxRetries, `number of times a network operation is retried after a transient error (connection resets, timeouts,
+429 and 5xx responses), applied to database downloads, registry pulls and maven searches (0 disables retries).
+Pushing results is retried with the push options instead`)
+	descriptions.Add(&cfg.Backoff, `wait before the first retry, doubled on every following retry`)
+	descriptions.Add(&cfg.MaxBackoff, `maximum wait between retries, including waits requested by the server with Retry-After (0 for no limit)`)
+	descriptions.Add(&cfg.Timeout, `maximum total time of a network operation across all attempts, after which it is not retried (0 for no limit)`)
+	descriptions.Add(&cfg.LimitBandwidth, `maximum rate at which data is downloaded (e.g. 10MB/s or 512KiB/s), shared by database downloads, registry
+pulls and maven searches so that scheduled scans do not saturate constrained links. Images pulled by a container
+daemon (docker, podman) are not limited (same as --limit-bandwidth)`)
+}
+
+func (cfg *Network) PostLoad() error {
+	if cfg.MaxRetries < 0 {
+		return fmt.Errorf("network.max-retries must not be negative")
+	}
+	if cfg.Backoff < 0 || cfg.MaxBackoff < 0 || cfg.Timeout < 0 {
+		return fmt.Errorf("network.backoff, network.max-backoff and network.timeout must not be negative")
+	}
+	limit, err := bandwidth.ParseLimit(cfg.LimitBandwidth)
+	if err != nil {
+		return fmt.Errorf("bad network.limit-bandwidth value: %w", err)
+	}
+	cfg.limiter = bandwidth.NewLimiter(limit)
+	return nil
+}
+
+// Limiter returns the bandwidth limiter of downloads (nil when the bandwidth is not limited).
+func (cfg Network) Limiter() *bandwidth.Limiter {
+	return cfg.limiter
+}
+
+// ToConfig returns the retry configuration applied to network operations.
+func (cfg Network) ToConfig() retry.Config {
+	return retry.Config{
+		MaxRetries: cfg.MaxRetries,
+		Backoff:    cfg.Backoff,
+		MaxBackoff: cfg.MaxBackoff,
+		Timeout:    cfg.Timeout,
+	}
+}
@@ -0,0 +1,77 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/internal/retry"
+)
+
+func TestNetwork_PostLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Network
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			cfg:  DefaultNetwork(),
+		},
+		{
+			name: "retries disabled",
+			cfg:  Network{},
+		},
+		{
+			name:    "negative retries",
+			cfg:     Network{MaxRetries: -1},
+			wantErr: "network.max-retries must not be negative",
+		},
+		{
+			name:    "negative timeout",
+			cfg:     Network{MaxRetries: 3, Timeout: -time.Second},
+			wantErr: "must not be negative",
+		},
+		{
+			name: "bandwidth limit",
+			cfg:  Network{LimitBandwidth: "10MB/s"},
+		},
+		{
+			name:    "invalid bandwidth limit",
+			cfg:     Network{LimitBandwidth: "fast"},
+			wantErr: "bad network.limit-bandwidth value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.PostLoad()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNetwork_ToConfig(t *testing.T) {
+	assert.Equal(t, retry.DefaultConfig(), DefaultNetwork().ToConfig())
+
+	cfg := DatabaseCommand{Network: Network{MaxRetries: 5, Backoff: 2 * time.Second, Timeout: time.Minute}}
+	assert.Equal(t, retry.Config{MaxRetries: 5, Backoff: 2 * time.Second, Timeout: time.Minute}, cfg.ToClientConfig().Retry)
+}
+
+func TestNetwork_Limiter(t *testing.T) {
+	cfg := DatabaseCommand{Network: DefaultNetwork()}
+	require.NoError(t, cfg.Network.PostLoad())
+	assert.Nil(t, cfg.Network.Limiter())
+	assert.Nil(t, cfg.ToClientConfig().Bandwidth)
+
+	cfg.Network.LimitBandwidth = "10MB/s"
+	require.NoError(t, cfg.Network.PostLoad())
+	require.NotNil(t, cfg.Network.Limiter())
+	// the database client shares the limiter with the other downloads
+	assert.Same(t, cfg.Network.Limiter(), cfg.ToClientConfig().Bandwidth)
+}
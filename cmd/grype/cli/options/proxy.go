@@ -0,0 +1,79 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/internal/proxy"
+)
+
+// Proxy configures the proxy used for each destination, overriding the proxy configured by the environment
+// (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+type Proxy struct {
+	NoProxy  string    `yaml:"no-proxy" json:"no-proxy" mapstructure:"no-proxy"`
+	Registry proxyRule `yaml:"registry" json:"registry" mapstructure:"registry"`
+	DB       proxyRule `yaml:"db" json:"db" mapstructure:"db"`
+	Push     proxyRule `yaml:"push" json:"push" mapstructure:"push"`
+}
+
+type proxyRule struct {
+	URL     string `yaml:"url" json:"url" mapstructure:"url"`
+	NoProxy string `yaml:"no-proxy" json:"no-proxy" mapstructure:"no-proxy"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Proxy)(nil)
+
+func (cfg *Proxy) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.NoProxy, `hosts connected to directly for all destinations, in addition to NO_PROXY (comma-separated, same format as NO_PROXY)`)
+	descriptions.Add(&cfg.Registry, `proxy for container registry requests (image pulls, embedded SBOMs and VEX attestations)`)
+	descriptions.Add(&cfg.DB, `proxy for downloading the vulnerability database listing and archives`)
+	descriptions.Add(&cfg.Push, `proxy for pushing scan results`)
+	for _, r := range []*proxyRule{&cfg.Registry, &cfg.DB, &cfg.Push} {
+		descriptions.Add(&r.URL, `URL of the proxy (http, https or socks5), or "direct" to ignore HTTP_PROXY and HTTPS_PROXY
+(the proxy configured by the environment is used when empty)`)
+		descriptions.Add(&r.NoProxy, `hosts connected to directly, in addition to NO_PROXY and no-proxy above (comma-separated)`)
+	}
+}
+
+func (cfg *Proxy) PostLoad() error {
+	rules := []struct {
+		name string
+		rule proxyRule
+	}{
+		{"registry", cfg.Registry},
+		{"db", cfg.DB},
+		{"push", cfg.Push},
+	}
+	for _, r := range rules {
+		if err := cfg.rule(r.rule).Validate(); err != nil {
+			return fmt.Errorf("bad proxy.%s.url value: %w", r.name, err)
+		}
+	}
+	return nil
+}
+
+// RegistryRule returns the proxy rule for container registry requests.
+func (cfg Proxy) RegistryRule() proxy.Rule {
+	return cfg.rule(cfg.Registry)
+}
+
+// DBRule returns the proxy rule for vulnerability database downloads.
+func (cfg Proxy) DBRule() proxy.Rule {
+	return cfg.rule(cfg.DB)
+}
+
+// PushRule returns the proxy rule for pushing scan results.
+func (cfg Proxy) PushRule() proxy.Rule {
+	return cfg.rule(cfg.Push)
+}
+
+func (cfg Proxy) rule(r proxyRule) proxy.Rule {
+	return proxy.Rule{
+		URL:     r.URL,
+		NoProxy: strings.Trim(cfg.NoProxy+","+r.NoProxy, ","),
+	}
+}
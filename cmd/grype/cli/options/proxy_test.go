@@ -0,0 +1,58 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/internal/proxy"
+)
+
+func TestProxy_PostLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Proxy
+		wantErr string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid rules",
+			cfg: Proxy{
+				Registry: proxyRule{URL: "http://registry-proxy:3128"},
+				DB:       proxyRule{URL: proxy.Direct},
+				Push:     proxyRule{URL: "socks5://push-proxy:1080"},
+			},
+		},
+		{
+			name:    "invalid db proxy",
+			cfg:     Proxy{DB: proxyRule{URL: "ftp://db-proxy"}},
+			wantErr: "bad proxy.db.url value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.PostLoad()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProxy_Rules(t *testing.T) {
+	cfg := Proxy{
+		NoProxy:  ".internal.example.com",
+		Registry: proxyRule{URL: "http://registry-proxy:3128", NoProxy: "localhost:5000"},
+		DB:       proxyRule{URL: proxy.Direct},
+	}
+
+	assert.Equal(t, proxy.Rule{URL: "http://registry-proxy:3128", NoProxy: ".internal.example.com,localhost:5000"}, cfg.RegistryRule())
+	assert.Equal(t, proxy.Rule{URL: proxy.Direct, NoProxy: ".internal.example.com"}, cfg.DBRule())
+	assert.Equal(t, proxy.Rule{NoProxy: ".internal.example.com"}, cfg.PushRule())
+	assert.True(t, Proxy{}.PushRule().IsZero())
+}
@@ -0,0 +1,79 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/presenter/push"
+)
+
+// Push configures sending scan results to a central ingest service.
+type Push struct {
+	URL string `yaml:"url" json:"url" mapstructure:"url"`
+	// IMPORTANT: do not show the password or token in any output (sensitive information)
+	Token      secret            `yaml:"token" json:"token" mapstructure:"token"`
+	Username   string            `yaml:"username" json:"username" mapstructure:"username"`
+	Password   secret            `yaml:"password" json:"password" mapstructure:"password"`
+	Headers    map[string]string `yaml:"headers" json:"headers" mapstructure:"headers"`
+	BatchSize  int               `yaml:"batch-size" json:"batch-size" mapstructure:"batch-size"`
+	MaxRetries int               `yaml:"max-retries" json:"max-retries" mapstructure:"max-retries"`
+	RetryWait  time.Duration     `yaml:"retry-wait" json:"retry-wait" mapstructure:"retry-wait"`
+	Timeout    time.Duration     `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Push)(nil)
+
+func defaultPush() Push {
+	return Push{
+		BatchSize:  500,
+		MaxRetries: 3,
+		RetryWait:  time.Second,
+		Timeout:    30 * time.Second,
+	}
+}
+
+func (p *Push) PostLoad() error {
+	if p.URL == "" {
+		return nil
+	}
+	if _, err := push.NewPusher(p.ToConfig("")); err != nil {
+		return err
+	}
+	if p.BatchSize < 0 {
+		return fmt.Errorf("push batch-size must not be negative")
+	}
+	return nil
+}
+
+func (p *Push) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&p.URL, `URL to POST scan results to, in addition to the configured outputs (same as --push)
+results are sent as JSON documents following the grype ingest payload schema`)
+	descriptions.Add(&p.Token, `bearer token to authenticate with the ingest service (env: GRYPE_PUSH_TOKEN)`)
+	descriptions.Add(&p.Username, `username for basic authentication with the ingest service (ignored when a token is set)`)
+	descriptions.Add(&p.Password, `password for basic authentication with the ingest service (env: GRYPE_PUSH_PASSWORD)`)
+	descriptions.Add(&p.Headers, `additional HTTP headers to send with every request`)
+	descriptions.Add(&p.BatchSize, `maximum number of matches sent per request`)
+	descriptions.Add(&p.MaxRetries, `number of times a request is retried on network errors, 429, or 5xx responses`)
+	descriptions.Add(&p.RetryWait, `initial wait between retries (doubled on every retry)`)
+	descriptions.Add(&p.Timeout, `timeout for each request`)
+}
+
+// ToConfig returns the configuration for a pusher of scan results.
+func (p Push) ToConfig(userAgent string) push.Config {
+	return push.Config{
+		URL:        p.URL,
+		Token:      string(p.Token),
+		Username:   p.Username,
+		Password:   string(p.Password),
+		Headers:    p.Headers,
+		BatchSize:  p.BatchSize,
+		MaxRetries: p.MaxRetries,
+		RetryWait:  p.RetryWait,
+		Timeout:    p.Timeout,
+		UserAgent:  userAgent,
+	}
+}
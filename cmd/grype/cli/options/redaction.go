@@ -0,0 +1,72 @@
+package options
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+// Redaction configures what is redacted from reports, so that they can be shared externally without revealing
+// internal topology (e.g. directory layouts and registry hostnames).
+type Redaction struct {
+	// Mode is how values are redacted: "strip" or "hash" (redaction is disabled when empty)
+	Mode        string   `yaml:"mode" json:"mode" mapstructure:"mode"`
+	Paths       bool     `yaml:"paths" json:"paths" mapstructure:"paths"`
+	Registries  bool     `yaml:"registries" json:"registries" mapstructure:"registries"`
+	Environment bool     `yaml:"environment" json:"environment" mapstructure:"environment"`
+	Patterns    []string `yaml:"patterns" json:"patterns" mapstructure:"patterns"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Redaction)(nil)
+
+func defaultRedaction() Redaction {
+	return Redaction{
+		Paths:       true,
+		Registries:  true,
+		Environment: true,
+	}
+}
+
+func (r *Redaction) PostLoad() error {
+	switch models.RedactionMode(r.Mode) {
+	case "", models.RedactStrip, models.RedactHash:
+	default:
+		return fmt.Errorf("invalid redact.mode: %q (allowable: %s, %s)", r.Mode, models.RedactStrip, models.RedactHash)
+	}
+	for _, p := range r.Patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("invalid redact.patterns entry %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+func (r *Redaction) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&r.Mode, `how to redact reports so they can be shared externally: "strip" removes redacted values, "hash" replaces them
+with a stable hash so they can still be correlated across reports (disabled when empty, same as --redact)`)
+	descriptions.Add(&r.Paths, `redact the directories of file paths (e.g. package locations and the scanned directory), keeping file names`)
+	descriptions.Add(&r.Registries, `redact the registry hostnames of image references`)
+	descriptions.Add(&r.Environment, `redact environment-identifying metadata: the application configuration and where the vulnerability DB was loaded from`)
+	descriptions.Add(&r.Patterns, `regular expressions of additional values to redact anywhere in the report (e.g. internal hostnames or user names)`)
+}
+
+// ToConfig returns the redaction configuration for presenting reports.
+func (r Redaction) ToConfig() models.RedactionConfig {
+	var patterns []*regexp.Regexp
+	for _, p := range r.Patterns {
+		// patterns are validated on load
+		patterns = append(patterns, regexp.MustCompile(p))
+	}
+	return models.RedactionConfig{
+		Mode:        models.RedactionMode(r.Mode),
+		Paths:       r.Paths,
+		Registries:  r.Registries,
+		Environment: r.Environment,
+		Patterns:    patterns,
+	}
+}
@@ -0,0 +1,64 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+func TestRedaction_PostLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		redaction   Redaction
+		wantEnabled bool
+		wantErr     require.ErrorAssertionFunc
+	}{
+		{
+			name:      "disabled by default",
+			redaction: defaultRedaction(),
+		},
+		{
+			name:        "strip",
+			redaction:   Redaction{Mode: "strip", Paths: true},
+			wantEnabled: true,
+		},
+		{
+			name:        "hash patterns",
+			redaction:   Redaction{Mode: "hash", Patterns: []string{`ci-host-\d+`}},
+			wantEnabled: true,
+		},
+		{
+			name:      "nothing selected",
+			redaction: Redaction{Mode: "strip"},
+		},
+		{
+			name:      "unknown mode",
+			redaction: Redaction{Mode: "mask", Paths: true},
+			wantErr:   require.Error,
+		},
+		{
+			name:      "invalid pattern",
+			redaction: Redaction{Mode: "strip", Patterns: []string{"ci-host-("}},
+			wantErr:   require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			err := tt.redaction.PostLoad()
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			cfg := tt.redaction.ToConfig()
+			assert.Equal(t, models.RedactionMode(tt.redaction.Mode), cfg.Mode)
+			assert.Len(t, cfg.Patterns, len(tt.redaction.Patterns))
+			assert.Equal(t, tt.wantEnabled, cfg.Enabled())
+		})
+	}
+}
@@ -1,12 +1,23 @@
 package options
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+
 	"github.com/anchore/clio"
+	"github.com/anchore/go-collections"
+	"github.com/anchore/grype/internal/cloudauth"
+	"github.com/anchore/grype/internal/credhelper"
 	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/stereoscope"
 	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/syft/source"
+	"github.com/anchore/syft/syft/source/sourceproviders"
 )
 
 type RegistryCredentials struct {
@@ -25,6 +36,13 @@ type registry struct {
 	InsecureUseHTTP       bool                  `yaml:"insecure-use-http" json:"insecure-use-http" mapstructure:"insecure-use-http"`
 	Auth                  []RegistryCredentials `yaml:"auth" json:"auth,omitempty" mapstructure:"auth"`
 	CACert                string                `yaml:"ca-cert" json:"ca-cert" mapstructure:"ca-cert"`
+	CredentialHelpers     []credentialHelper    `yaml:"credential-helpers" json:"credential-helpers,omitempty" mapstructure:"credential-helpers"`
+	CloudAuth             cloudAuth             `yaml:"cloud-auth" json:"cloud-auth" mapstructure:"cloud-auth"`
+}
+
+type credentialHelper struct {
+	Authority string `yaml:"authority" json:"authority" mapstructure:"authority"`
+	Helper    string `yaml:"helper" json:"helper" mapstructure:"helper"`
 }
 
 var _ interface {
@@ -56,6 +74,17 @@ func (cfg *registry) PostLoad() error {
 		}, cfg.Auth...)
 	}
 
+	for i, h := range cfg.CredentialHelpers {
+		rule := credhelper.Rule{Authority: h.Authority, Helper: h.Helper}
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("bad registry.credential-helpers[%d] value: %w", i, err)
+		}
+		// the helper may only be installed where images are pulled from the matching registries
+		if _, err := exec.LookPath(rule.Executable()); err != nil {
+			log.Warnf("credential helper %q is not installed: %s not found on the PATH", h.Helper, rule.Executable())
+		}
+	}
+
 	// these flags may be picked up from a config file or environment variable without the
 	// user realizing, so surfacing them makes unprotected registry traffic visible in CI/CD output
 	var insecureFlags []string
@@ -73,9 +102,9 @@ func (cfg *registry) PostLoad() error {
 }
 
 func (cfg *registry) DescribeFields(descriptions clio.FieldDescriptionSet) {
-	descriptions.Add(&cfg.InsecureSkipTLSVerify, "skip TLS verification when communicating with the registry")
+	descriptions.Add(&cfg.InsecureSkipTLSVerify, "skip TLS verification when communicating with the registry (deprecated: use tls.insecure-skip-verify)")
 	descriptions.Add(&cfg.InsecureUseHTTP, "use http instead of https when connecting to the registry")
-	descriptions.Add(&cfg.CACert, "filepath to a CA certificate (or directory containing *.crt, *.cert, *.pem) used to generate the client certificate")
+	descriptions.Add(&cfg.CACert, "filepath to a CA certificate (or directory containing *.crt, *.cert, *.pem) used to generate the client certificate (deprecated: use tls.ca-cert)")
 	descriptions.Add(&cfg.Auth, `Authentication credentials for specific registries. Each entry describes authentication for a specific authority:
 -	authority: the registry authority URL the URL to the registry (e.g. "docker.io", "localhost:5000", etc.) (env: SYFT_REGISTRY_AUTH_AUTHORITY)
 	username: a username if using basic credentials (env: SYFT_REGISTRY_AUTH_USERNAME)
@@ -83,6 +112,12 @@ func (cfg *registry) DescribeFields(descriptions clio.FieldDescriptionSet) {
 	token: a token if using token-based authentication, mutually exclusive with username/password (env: SYFT_REGISTRY_AUTH_TOKEN)
 	tls-cert: filepath to the client certificate used for TLS authentication to the registry (env: SYFT_REGISTRY_AUTH_TLS_CERT)
 	tls-key: filepath to the client key used for TLS authentication to the registry (env: SYFT_REGISTRY_AUTH_TLS_KEY)
+`)
+	descriptions.Add(&cfg.CredentialHelpers, `docker credential helpers (docker-credential-<helper> executables) used to get credentials for registries without
+credentials configured above, taking precedence over the docker config. The first entry matching a registry is used:
+-	authority: the registry host or a glob matching registry hosts (e.g. "*.dkr.ecr.*.amazonaws.com"), all registries when empty
+	helper: the helper name, such as an OS keychain (osxkeychain, wincred, secretservice, pass) or a cloud provider
+	        token exchange (ecr-login, gcr, acr-env)
 `)
 }
 
@@ -93,7 +128,10 @@ func hasNonEmptyCredentials(username, password, token, tlsCert, tlsKey string) b
 	return hasUserPass || hasToken || hasTLSMaterial
 }
 
-func (cfg *registry) ToOptions() *image.RegistryOptions {
+// ToOptions returns the registry options for pulling images from the given registry host (empty when not known), with
+// the TLS settings for the host applied. The registry CA certificate and insecure-skip-tls-verify options are still
+// honored, though are superseded by the TLS settings.
+func (cfg *registry) ToOptions(tls TLS, host string) *image.RegistryOptions {
 	var auth = make([]image.RegistryCredentials, len(cfg.Auth))
 	for i, a := range cfg.Auth {
 		auth[i] = image.RegistryCredentials{
@@ -106,10 +144,70 @@ func (cfg *registry) ToOptions() *image.RegistryOptions {
 		}
 	}
 
+	tlsCfg := tls.ToConfig()
+	settings := tlsCfg.ForHost(host)
+
+	// client certificates are selected by stereoscope for each registry, which the configured credentials take
+	// precedence over
+	if host != "" && settings.ClientCert != "" {
+		auth = append(auth, image.RegistryCredentials{Authority: host, ClientCert: settings.ClientCert, ClientKey: settings.ClientKey})
+	}
+	for _, h := range tlsCfg.Hosts {
+		if h.ClientCert != "" {
+			auth = append(auth, image.RegistryCredentials{Authority: h.Host, ClientCert: h.ClientCert, ClientKey: h.ClientKey})
+		}
+	}
+	if tlsCfg.ClientCert != "" {
+		auth = append(auth, image.RegistryCredentials{ClientCert: tlsCfg.ClientCert, ClientKey: tlsCfg.ClientKey})
+	}
+
+	caCert := settings.CACert
+	if caCert == "" {
+		caCert = cfg.CACert
+	}
+
 	return &image.RegistryOptions{
-		InsecureSkipTLSVerify: cfg.InsecureSkipTLSVerify,
+		InsecureSkipTLSVerify: cfg.InsecureSkipTLSVerify || settings.InsecureSkipVerify,
 		InsecureUseHTTP:       cfg.InsecureUseHTTP,
 		Credentials:           auth,
-		CAFileOrDir:           cfg.CACert,
+		Keychain:              cfg.keychain(),
+		CAFileOrDir:           caCert,
+	}
+}
+
+// keychain returns the keychain consulted for registries without configured credentials: credential helpers, then
+// cloud provider credentials, then the docker config. This is left to stereoscope (the docker config) when neither
+// credential helpers nor cloud provider credentials are configured.
+func (cfg *registry) keychain() authn.Keychain {
+	cloud := cfg.CloudAuth.ToConfig()
+	if len(cfg.CredentialHelpers) == 0 && cloud.IsZero() {
+		return nil
+	}
+
+	var fallback []authn.Keychain
+	if !cloud.IsZero() {
+		fallback = append(fallback, cloudauth.Keychain(cloud))
+	}
+	fallback = append(fallback, authn.DefaultKeychain)
+
+	rules := make([]credhelper.Rule, len(cfg.CredentialHelpers))
+	for i, h := range cfg.CredentialHelpers {
+		rules[i] = credhelper.Rule{Authority: h.Authority, Helper: h.Helper}
+	}
+	return credhelper.Keychain(rules, fallback...)
+}
+
+// registryHost returns the registry host of the given image reference (e.g. "registry:localhost:5000/app:latest"),
+// or an empty string when it is not an image reference (e.g. "dir:/path").
+func registryHost(ref string) string {
+	tags := collections.TaggedValueSet[source.Provider]{}.Join(sourceproviders.All("", nil)...).Tags()
+	scheme, ref := stereoscope.ExtractSchemeSource(ref, tags...)
+	if scheme != "" && scheme != "registry" {
+		return ""
+	}
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return ""
 	}
+	return r.Context().RegistryStr()
 }
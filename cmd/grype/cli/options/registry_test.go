@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/stereoscope/pkg/image"
 )
@@ -87,6 +88,8 @@ func Test_registry_ToOptions(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    registry
+		tls      TLS
+		host     string
 		expected image.RegistryOptions
 	}{
 		{
@@ -151,11 +154,142 @@ func Test_registry_ToOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "shared tls configuration supersedes registry tls options",
+			input: registry{
+				CACert: "registry-ca.crt",
+			},
+			tls: TLS{
+				CACert:     "ca.crt",
+				ClientCert: "client.crt",
+				ClientKey:  "client.key",
+			},
+			expected: image.RegistryOptions{
+				CAFileOrDir: "ca.crt",
+				Credentials: []image.RegistryCredentials{
+					{
+						ClientCert: "client.crt",
+						ClientKey:  "client.key",
+					},
+				},
+			},
+		},
+		{
+			name: "tls settings of the registry host are applied",
+			tls: TLS{
+				CACert: "ca.crt",
+				Hosts: []tlsHost{
+					{
+						Host:               "registry.example.com",
+						CACert:             "example-ca.crt",
+						InsecureSkipVerify: true,
+						ClientCert:         "example.crt",
+						ClientKey:          "example.key",
+					},
+					{
+						Host:   "other.example.com",
+						CACert: "other-ca.crt",
+					},
+				},
+			},
+			host: "registry.example.com:5000",
+			expected: image.RegistryOptions{
+				CAFileOrDir:           "example-ca.crt",
+				InsecureSkipTLSVerify: true,
+				Credentials: []image.RegistryCredentials{
+					{
+						Authority:  "registry.example.com:5000",
+						ClientCert: "example.crt",
+						ClientKey:  "example.key",
+					},
+					{
+						Authority:  "registry.example.com",
+						ClientCert: "example.crt",
+						ClientKey:  "example.key",
+					},
+				},
+			},
+		},
+		{
+			name: "tls settings of other hosts are not applied",
+			tls: TLS{
+				Hosts: []tlsHost{
+					{
+						Host:               "registry.example.com",
+						CACert:             "example-ca.crt",
+						InsecureSkipVerify: true,
+					},
+				},
+			},
+			host: "index.docker.io",
+			expected: image.RegistryOptions{
+				Credentials: []image.RegistryCredentials{},
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			assert.Equal(t, &test.expected, test.input.ToOptions())
+			assert.Equal(t, &test.expected, test.input.ToOptions(test.tls, test.host))
+		})
+	}
+}
+
+func Test_registry_PostLoad_credentialHelpers(t *testing.T) {
+	tests := []struct {
+		name    string
+		helpers []credentialHelper
+		wantErr string
+	}{
+		{
+			name:    "helpers not installed",
+			helpers: []credentialHelper{{Authority: "*.dkr.ecr.*.amazonaws.com", Helper: "ecr-login"}, {Helper: "osxkeychain"}},
+		},
+		{
+			name:    "missing helper",
+			helpers: []credentialHelper{{Helper: "pass"}, {Authority: "ghcr.io"}},
+			wantErr: "bad registry.credential-helpers[1] value: a credential helper name is required",
+		},
+		{
+			name:    "executable instead of helper name",
+			helpers: []credentialHelper{{Helper: "docker-credential-pass"}},
+			wantErr: "bad registry.credential-helpers[0] value: invalid credential helper",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := registry{CredentialHelpers: tt.helpers}
+			err := cfg.PostLoad()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_registry_ToOptions_credentialHelpers(t *testing.T) {
+	assert.Nil(t, (&registry{}).ToOptions(TLS{}, "").Keychain)
+
+	cfg := registry{CredentialHelpers: []credentialHelper{{Helper: "osxkeychain"}}}
+	assert.NotNil(t, cfg.ToOptions(TLS{}, "").Keychain)
+}
+
+func Test_registryHost(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "alpine:3.18", want: "index.docker.io"},
+		{ref: "registry:localhost:5000/app:latest", want: "localhost:5000"},
+		{ref: "registry.example.com/team/app@sha256:0000000000000000000000000000000000000000000000000000000000000000", want: "registry.example.com"},
+		{ref: "", want: ""},
+		{ref: "dir:/tmp/project", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.want, registryHost(tt.ref))
 		})
 	}
 }
@@ -0,0 +1,46 @@
+package options
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/anchore/clio"
+)
+
+// Secrets configures correlation of an externally-produced secrets report with vulnerability matches.
+type Secrets struct {
+	Report string `yaml:"report" json:"report" mapstructure:"report"`
+}
+
+var _ interface {
+	clio.FlagAdder
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Secrets)(nil)
+
+func defaultSecrets() Secrets {
+	return Secrets{}
+}
+
+func (s *Secrets) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&s.Report,
+		"secrets-report", "",
+		"a secrets report (syft JSON with a secrets section, or a JSON list of findings) to correlate with vulnerable packages",
+	)
+}
+
+func (s *Secrets) PostLoad() error {
+	if s.Report == "" {
+		return nil
+	}
+	if _, err := os.Stat(s.Report); err != nil {
+		return fmt.Errorf("invalid secrets report %q: %w", s.Report, err)
+	}
+	return nil
+}
+
+func (s *Secrets) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&s.Report, `path to a secrets report produced by another tool (a syft JSON document with a "secrets" section, or a JSON list of
+findings with "classification", "path", and "lineNumber" fields). Exposed credentials that reside alongside vulnerable
+packages are reported as "exploitableCombinations" (same as --secrets-report)`)
+}
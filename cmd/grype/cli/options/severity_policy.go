@@ -0,0 +1,98 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// SeverityPolicy configures rules raising or capping the severity of findings by ecosystem.
+type SeverityPolicy struct {
+	Rules []SeverityPolicyRule `yaml:"rules" json:"rules" mapstructure:"rules"`
+}
+
+// SeverityPolicyRule bounds the severity of the findings of the packages it applies to.
+type SeverityPolicyRule struct {
+	Name        string `yaml:"name" json:"name" mapstructure:"name"`
+	Ecosystem   string `yaml:"ecosystem" json:"ecosystem" mapstructure:"ecosystem"`
+	DevOnly     bool   `yaml:"dev-only" json:"dev-only" mapstructure:"dev-only"`
+	UnknownOnly bool   `yaml:"unknown-only" json:"unknown-only" mapstructure:"unknown-only"`
+	Floor       string `yaml:"floor" json:"floor" mapstructure:"floor"`
+	Ceiling     string `yaml:"ceiling" json:"ceiling" mapstructure:"ceiling"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*SeverityPolicy)(nil)
+
+func defaultSeverityPolicy() SeverityPolicy {
+	return SeverityPolicy{}
+}
+
+func (s *SeverityPolicy) PostLoad() error {
+	_, err := s.ToPolicy()
+	return err
+}
+
+func (s *SeverityPolicy) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&s.Rules, `rules raising (floor) or capping (ceiling) the severity of findings, for example:
+  - name: os-unrated
+    ecosystem: os        # "os" for OS packages, a language (e.g. java) or a package type (e.g. npm); all when empty
+    unknown-only: true   # only findings without a (vendor) severity
+    floor: medium
+  - name: dev-dependencies
+    dev-only: true       # only packages needed for development or testing (e.g. maven test scope)
+    ceiling: low
+Only the first rule applying to a finding is used. The resulting severity is reported as the policy severity of the
+finding (along with the rule that set it) and is used for sorting by severity and for --fail-on`)
+}
+
+// ToPolicy validates the rules and returns the severity policy.
+func (s SeverityPolicy) ToPolicy() (match.SeverityPolicy, error) {
+	var policy match.SeverityPolicy
+	for i, r := range s.Rules {
+		if r.Ecosystem != "" && !pkg.IsEcosystem(r.Ecosystem) {
+			return policy, fmt.Errorf("bad severity-policy.rules[%d].ecosystem value %q: must be %q, a language (e.g. java) or a package type (e.g. npm)", i, r.Ecosystem, pkg.OSEcosystem)
+		}
+		floor, err := parsePolicySeverity(r.Floor)
+		if err != nil {
+			return policy, fmt.Errorf("bad severity-policy.rules[%d].floor value: %w", i, err)
+		}
+		ceiling, err := parsePolicySeverity(r.Ceiling)
+		if err != nil {
+			return policy, fmt.Errorf("bad severity-policy.rules[%d].ceiling value: %w", i, err)
+		}
+		if floor == vulnerability.UnknownSeverity && ceiling == vulnerability.UnknownSeverity {
+			return policy, fmt.Errorf("bad severity-policy.rules[%d]: a floor or a ceiling is required", i)
+		}
+		if ceiling != vulnerability.UnknownSeverity && floor > ceiling {
+			return policy, fmt.Errorf("bad severity-policy.rules[%d]: floor %q is above ceiling %q", i, r.Floor, r.Ceiling)
+		}
+		policy.Rules = append(policy.Rules, match.SeverityPolicyRule{
+			Name:        r.Name,
+			Ecosystem:   r.Ecosystem,
+			DevOnly:     r.DevOnly,
+			UnknownOnly: r.UnknownOnly,
+			Floor:       floor,
+			Ceiling:     ceiling,
+		})
+	}
+	return policy, nil
+}
+
+// parsePolicySeverity parses a severity of a severity policy rule, where empty is no bound.
+func parsePolicySeverity(value string) (vulnerability.Severity, error) {
+	if strings.TrimSpace(value) == "" {
+		return vulnerability.UnknownSeverity, nil
+	}
+	severity := vulnerability.ParseSeverity(strings.TrimSpace(value))
+	if severity == vulnerability.UnknownSeverity {
+		return severity, fmt.Errorf("%q is not a valid severity (negligible, low, medium, high or critical)", value)
+	}
+	return severity, nil
+}
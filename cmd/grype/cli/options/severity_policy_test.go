@@ -0,0 +1,71 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestSeverityPolicy_PostLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []SeverityPolicyRule
+		want    []match.SeverityPolicyRule
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name: "valid rules",
+			rules: []SeverityPolicyRule{
+				{Name: "os-unrated", Ecosystem: "os", UnknownOnly: true, Floor: "medium"},
+				{DevOnly: true, Ceiling: " Low "},
+			},
+			want: []match.SeverityPolicyRule{
+				{Name: "os-unrated", Ecosystem: "os", UnknownOnly: true, Floor: vulnerability.MediumSeverity},
+				{DevOnly: true, Ceiling: vulnerability.LowSeverity},
+			},
+		},
+		{
+			name:    "unknown ecosystem",
+			rules:   []SeverityPolicyRule{{Ecosystem: "cobol-packages", Floor: "low"}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid severity",
+			rules:   []SeverityPolicyRule{{Ceiling: "severe"}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "no bounds",
+			rules:   []SeverityPolicyRule{{Ecosystem: "os"}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "floor above ceiling",
+			rules:   []SeverityPolicyRule{{Floor: "high", Ceiling: "low"}},
+			wantErr: require.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			cfg := SeverityPolicy{Rules: tt.rules}
+			tt.wantErr(t, cfg.PostLoad())
+
+			policy, err := cfg.ToPolicy()
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, policy.Rules)
+		})
+	}
+}
@@ -14,10 +14,12 @@ import (
 var _ interface {
 	fangs.FlagAdder
 	fangs.PostLoader
+	fangs.FieldDescriber
 } = (*SortBy)(nil)
 
 type SortBy struct {
 	Criteria         string   `yaml:"sort-by" json:"sort-by" mapstructure:"sort-by"`
+	Sort             string   `yaml:"-" json:"-" mapstructure:"-"` // --sort, shorthand for --sort-by
 	AllowableOptions []string `yaml:"-" json:"-" mapstructure:"-"`
 }
 
@@ -37,11 +39,26 @@ func (o *SortBy) AddFlags(flags clio.FlagSet) {
 		"sort-by", "",
 		fmt.Sprintf("sort the match results with the given strategy, options=%v", o.AllowableOptions),
 	)
+
+	flags.StringVarP(&o.Sort,
+		"sort", "",
+		"same as --sort-by (matches equal under the strategy are always ordered by vulnerability, package, and location so that reports are stable across runs)",
+	)
 }
 
 func (o *SortBy) PostLoad() error {
+	if o.Sort != "" {
+		o.Criteria = o.Sort
+	}
 	if !strset.New(o.AllowableOptions...).Has(strings.ToLower(o.Criteria)) {
 		return fmt.Errorf("invalid sort-by criteria: %q (allowable: %s)", o.Criteria, strings.Join(o.AllowableOptions, ", "))
 	}
 	return nil
 }
+
+func (o *SortBy) DescribeFields(descriptions fangs.FieldDescriptionSet) {
+	descriptions.Add(&o.Criteria, fmt.Sprintf(`the strategy used to order matches in the report (options: %s)
+the order is deterministic: matches that are equal according to the strategy are further ordered by vulnerability ID,
+namespace, package PURL, package locations, and package ID so that reports for the same input can be diffed without noise
+(same as --sort-by or --sort)`, strings.Join(o.AllowableOptions, ", ")))
+}
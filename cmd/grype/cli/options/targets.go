@@ -0,0 +1,75 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/go-homedir"
+)
+
+// Targets configures scanning a list of targets (images, directories, SBOMs, ...) with a shared vulnerability DB.
+type Targets struct {
+	File        string `yaml:"file" json:"file" mapstructure:"file"`
+	OutputDir   string `yaml:"output-dir" json:"output-dir" mapstructure:"output-dir"`
+	Parallelism int    `yaml:"parallelism" json:"parallelism" mapstructure:"parallelism"`
+}
+
+var _ interface {
+	clio.FlagAdder
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Targets)(nil)
+
+func defaultTargets() Targets {
+	return Targets{
+		OutputDir:   "grype-results",
+		Parallelism: 1,
+	}
+}
+
+func (t *Targets) AddFlags(flags clio.FlagSet) {
+	flags.StringVarP(&t.File,
+		"targets-file", "",
+		"scan the targets listed in a file (one per line, '-' for stdin) instead of a single target",
+	)
+	flags.StringVarP(&t.OutputDir,
+		"targets-output-dir", "",
+		"directory the per-target reports and the summary of a --targets-file scan are written to",
+	)
+	flags.IntVarP(&t.Parallelism,
+		"targets-parallelism", "",
+		"number of targets of a --targets-file scan cataloged and matched at once",
+	)
+}
+
+func (t *Targets) PostLoad() error {
+	if t.File == "" {
+		return nil
+	}
+	if t.Parallelism < 1 {
+		return fmt.Errorf("targets.parallelism must be at least 1")
+	}
+	if t.OutputDir == "" {
+		return fmt.Errorf("targets.output-dir must be set to scan a targets file")
+	}
+	for _, path := range []*string{&t.File, &t.OutputDir} {
+		if *path == "-" {
+			continue
+		}
+		expanded, err := homedir.Expand(*path)
+		if err != nil {
+			return err
+		}
+		*path = expanded
+	}
+	return nil
+}
+
+func (t *Targets) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&t.File, `file listing the targets to scan (one per line in the same forms as the target argument, with blank lines and
+lines starting with '#' ignored), or '-' to read the list from stdin (same as --targets-file). The vulnerability DB is
+loaded once and shared by all targets`)
+	descriptions.Add(&t.OutputDir, `directory the report of each target is written to, as <target>.<format> for each output format, along with a
+summary.json of the status of each target (same as --targets-output-dir)`)
+	descriptions.Add(&t.Parallelism, `number of targets cataloged and matched at once (same as --targets-parallelism)`)
+}
@@ -0,0 +1,92 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargets_PostLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Targets
+		wantErr string
+	}{
+		{
+			name: "no targets file",
+			cfg:  Targets{Parallelism: 0},
+		},
+		{
+			name: "targets from stdin",
+			cfg:  Targets{File: "-", OutputDir: "results", Parallelism: 4},
+		},
+		{
+			name:    "no parallelism",
+			cfg:     Targets{File: "targets.txt", OutputDir: "results"},
+			wantErr: "targets.parallelism must be at least 1",
+		},
+		{
+			name:    "no output directory",
+			cfg:     Targets{File: "targets.txt", Parallelism: 1},
+			wantErr: "targets.output-dir must be set to scan a targets file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.PostLoad()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGrype_validateTargetsScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Grype)
+		wantErr string
+	}{
+		{
+			name:   "output formats",
+			modify: func(g *Grype) { g.Outputs = []string{"json", "sarif"} },
+		},
+		{
+			name:    "stream table",
+			modify:  func(g *Grype) { g.StreamTable = true },
+			wantErr: "--stream-table cannot be used with --targets-file",
+		},
+		{
+			name:    "report file",
+			modify:  func(g *Grype) { g.File = "report.json" },
+			wantErr: "report files cannot be given with --targets-file",
+		},
+		{
+			name:    "output report file",
+			modify:  func(g *Grype) { g.Outputs = []string{"json=report.json"} },
+			wantErr: "report files cannot be given with --targets-file",
+		},
+		{
+			name: "recording in parallel",
+			modify: func(g *Grype) {
+				g.Targets.Parallelism = 2
+				g.DB.RecordInteractions = "db.recording"
+			},
+			wantErr: "DB interactions cannot be recorded when scanning targets in parallel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Grype{Targets: Targets{File: "targets.txt", OutputDir: "results", Parallelism: 1}}
+			tt.modify(&g)
+			err := g.validateTargetsScan()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package options
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/go-homedir"
+	"github.com/anchore/grype/internal/telemetry"
+)
+
+// Telemetry configures the (opt-in) aggregation of anonymous scan statistics.
+type Telemetry struct {
+	Enabled  bool          `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	File     string        `yaml:"file" json:"file" mapstructure:"file"`
+	Endpoint string        `yaml:"endpoint" json:"endpoint" mapstructure:"endpoint"`
+	Timeout  time.Duration `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Telemetry)(nil)
+
+func defaultTelemetry(id clio.Identification) Telemetry {
+	return Telemetry{
+		File:    filepath.Join(xdg.StateHome, id.Name, "telemetry.json"),
+		Timeout: 10 * time.Second,
+	}
+}
+
+func (t *Telemetry) PostLoad() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.File == "" {
+		return fmt.Errorf("telemetry.file must be set when telemetry is enabled")
+	}
+	file, err := homedir.Expand(t.File)
+	if err != nil {
+		return fmt.Errorf("unable to expand telemetry.file: %w", err)
+	}
+	t.File = file
+	if t.Endpoint != "" {
+		return telemetry.ValidateEndpoint(t.Endpoint)
+	}
+	return nil
+}
+
+func (t *Telemetry) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&t.Enabled, `aggregate anonymous statistics of every scan (counts, ecosystems, durations, and DB age) into a local summary file
+no names of sources, packages, vulnerabilities, or paths are ever recorded (disabled by default)`)
+	descriptions.Add(&t.File, `the JSON file to keep the telemetry summary in`)
+	descriptions.Add(&t.Endpoint, `URL to POST the telemetry summary to after every scan (the summary is only kept locally when empty)`)
+	descriptions.Add(&t.Timeout, `timeout for sending the telemetry summary`)
+}
@@ -0,0 +1,58 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype"
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// TimeBudget configures the time allowed for matching, with ecosystems that are always matched regardless.
+type TimeBudget struct {
+	Limit              string   `yaml:"limit" json:"limit" mapstructure:"limit"`                                           // --time-budget, the time allowed for matching
+	PriorityEcosystems []string `yaml:"priority-ecosystems" json:"priority-ecosystems" mapstructure:"priority-ecosystems"` // --priority-ecosystems, ecosystems always matched first
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*TimeBudget)(nil)
+
+func defaultTimeBudget() TimeBudget {
+	return TimeBudget{}
+}
+
+func (t *TimeBudget) PostLoad() error {
+	t.PriorityEcosystems = flatten(t.PriorityEcosystems)
+	for _, e := range t.PriorityEcosystems {
+		if !pkg.IsEcosystem(e) {
+			return fmt.Errorf("bad --priority-ecosystems value %q: must be %q, a language (e.g. java) or a package type (e.g. npm)", e, grype.OSEcosystem)
+		}
+	}
+	_, err := t.ToConfig()
+	return err
+}
+
+func (t *TimeBudget) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&t.Limit, `the time allowed for matching packages (e.g. 90s or 5m), after which packages outside of the priority ecosystems
+are not matched and are instead reported in the "skipped" section of the output (no limit when empty)`)
+	descriptions.Add(&t.PriorityEcosystems, `ecosystems matched first (in the given order) and always in full, regardless of the time budget:
+"os" for OS packages, a language (e.g. java, python or go) or a package type (e.g. npm or binary)`)
+}
+
+// ToConfig validates the configuration and returns the time budget for matching.
+func (t TimeBudget) ToConfig() (grype.TimeBudgetConfig, error) {
+	cfg := grype.TimeBudgetConfig{
+		PriorityEcosystems: t.PriorityEcosystems,
+	}
+	if t.Limit != "" {
+		limit, err := time.ParseDuration(t.Limit)
+		if err != nil || limit <= 0 {
+			return cfg, fmt.Errorf("bad --time-budget value %q: must be a positive duration (e.g. 90s or 5m)", t.Limit)
+		}
+		cfg.Limit = limit
+	}
+	return cfg, nil
+}
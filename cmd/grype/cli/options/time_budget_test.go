@@ -0,0 +1,65 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeBudget_PostLoad(t *testing.T) {
+	tests := []struct {
+		name           string
+		budget         TimeBudget
+		wantLimit      time.Duration
+		wantEcosystems []string
+		wantErr        require.ErrorAssertionFunc
+	}{
+		{
+			name: "no budget",
+		},
+		{
+			name:           "comma separated ecosystems",
+			budget:         TimeBudget{Limit: "5m", PriorityEcosystems: []string{"os,java", "python"}},
+			wantLimit:      5 * time.Minute,
+			wantEcosystems: []string{"os", "java", "python"},
+		},
+		{
+			name:           "package types",
+			budget:         TimeBudget{PriorityEcosystems: []string{"npm", "binary"}},
+			wantEcosystems: []string{"npm", "binary"},
+		},
+		{
+			name:    "unknown ecosystem",
+			budget:  TimeBudget{PriorityEcosystems: []string{"os", "cobol"}},
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid limit",
+			budget:  TimeBudget{Limit: "5 minutes"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "negative limit",
+			budget:  TimeBudget{Limit: "-5m"},
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			err := tt.budget.PostLoad()
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			cfg, err := tt.budget.ToConfig()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, cfg.Limit)
+			assert.Equal(t, tt.wantEcosystems, cfg.PriorityEcosystems)
+		})
+	}
+}
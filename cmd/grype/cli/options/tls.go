@@ -0,0 +1,123 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/go-homedir"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/grype/internal/tlsconfig"
+)
+
+// TLS configures how TLS connections are established, shared by the container registry client and the
+// vulnerability database client.
+type TLS struct {
+	CACert             string    `yaml:"ca-cert" json:"ca-cert" mapstructure:"ca-cert"`
+	InsecureSkipVerify bool      `yaml:"insecure-skip-verify" json:"insecure-skip-verify" mapstructure:"insecure-skip-verify"`
+	ClientCert         string    `yaml:"client-cert" json:"client-cert" mapstructure:"client-cert"`
+	ClientKey          string    `yaml:"client-key" json:"client-key" mapstructure:"client-key"`
+	Hosts              []tlsHost `yaml:"hosts" json:"hosts" mapstructure:"hosts"`
+}
+
+type tlsHost struct {
+	Host               string `yaml:"host" json:"host" mapstructure:"host"`
+	CACert             string `yaml:"ca-cert" json:"ca-cert" mapstructure:"ca-cert"`
+	InsecureSkipVerify bool   `yaml:"insecure-skip-verify" json:"insecure-skip-verify" mapstructure:"insecure-skip-verify"`
+	ClientCert         string `yaml:"client-cert" json:"client-cert" mapstructure:"client-cert"`
+	ClientKey          string `yaml:"client-key" json:"client-key" mapstructure:"client-key"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*TLS)(nil)
+
+func (cfg *TLS) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&cfg.CACert, `filepath to a CA certificate (or directory containing *.crt, *.cert, *.pem) trusted in addition to the system
+certificates, when communicating with container registries and downloading the vulnerability database`)
+	descriptions.Add(&cfg.InsecureSkipVerify, `skip TLS certificate verification for all hosts`)
+	descriptions.Add(&cfg.ClientCert, `filepath to the client certificate presented for mutual TLS (requires client-key)`)
+	descriptions.Add(&cfg.ClientKey, `filepath to the key of the client certificate`)
+	descriptions.Add(&cfg.Hosts, `TLS settings for specific hosts, overriding the settings above. Each entry describes a host:
+-	host: the host name, optionally with a port (e.g. "registry.example.com:5000"); without a port all ports match
+	ca-cert: filepath to a CA certificate (or directory) trusted for the host
+	insecure-skip-verify: skip TLS certificate verification for the host
+	client-cert: filepath to the client certificate presented to the host
+	client-key: filepath to the key of the client certificate
+`)
+}
+
+func (cfg *TLS) PostLoad() error {
+	var insecureHosts []string
+	if cfg.InsecureSkipVerify {
+		insecureHosts = append(insecureHosts, "all hosts")
+	}
+
+	if err := expandTLSPaths(&cfg.CACert, &cfg.ClientCert, &cfg.ClientKey); err != nil {
+		return err
+	}
+	if (cfg.ClientCert == "") != (cfg.ClientKey == "") {
+		return fmt.Errorf("tls.client-cert and tls.client-key must be provided together")
+	}
+
+	for i := range cfg.Hosts {
+		h := &cfg.Hosts[i]
+		if h.Host == "" {
+			return fmt.Errorf("tls.hosts[%d].host must be set", i)
+		}
+		if err := expandTLSPaths(&h.CACert, &h.ClientCert, &h.ClientKey); err != nil {
+			return err
+		}
+		if (h.ClientCert == "") != (h.ClientKey == "") {
+			return fmt.Errorf("tls.hosts[%d] (%s): client-cert and client-key must be provided together", i, h.Host)
+		}
+		if h.InsecureSkipVerify && !cfg.InsecureSkipVerify {
+			insecureHosts = append(insecureHosts, h.Host)
+		}
+	}
+
+	// these settings may be picked up from a config file or environment variable without the user realizing
+	if len(insecureHosts) > 0 {
+		log.Warnf("TLS certificate verification is disabled for %s", strings.Join(insecureHosts, ", "))
+	}
+	return nil
+}
+
+func expandTLSPaths(paths ...*string) error {
+	for _, path := range paths {
+		if *path == "" {
+			continue
+		}
+		expanded, err := homedir.Expand(*path)
+		if err != nil {
+			return err
+		}
+		*path = expanded
+	}
+	return nil
+}
+
+// ToConfig returns the TLS configuration applied to the registry and database clients.
+func (cfg TLS) ToConfig() tlsconfig.Config {
+	c := tlsconfig.Config{
+		Settings: tlsconfig.Settings{
+			CACert:             cfg.CACert,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+			ClientCert:         cfg.ClientCert,
+			ClientKey:          cfg.ClientKey,
+		},
+	}
+	for _, h := range cfg.Hosts {
+		c.Hosts = append(c.Hosts, tlsconfig.Host{
+			Host: h.Host,
+			Settings: tlsconfig.Settings{
+				CACert:             h.CACert,
+				InsecureSkipVerify: h.InsecureSkipVerify,
+				ClientCert:         h.ClientCert,
+				ClientKey:          h.ClientKey,
+			},
+		})
+	}
+	return c
+}
@@ -0,0 +1,84 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/internal/tlsconfig"
+)
+
+func TestTLS_PostLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     TLS
+		wantErr string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "global and host settings",
+			cfg: TLS{
+				CACert:     "ca.crt",
+				ClientCert: "client.crt",
+				ClientKey:  "client.key",
+				Hosts:      []tlsHost{{Host: "registry.example.com", InsecureSkipVerify: true}},
+			},
+		},
+		{
+			name:    "client certificate without key",
+			cfg:     TLS{ClientCert: "client.crt"},
+			wantErr: "tls.client-cert and tls.client-key must be provided together",
+		},
+		{
+			name:    "host without a name",
+			cfg:     TLS{Hosts: []tlsHost{{CACert: "ca.crt"}}},
+			wantErr: "tls.hosts[0].host must be set",
+		},
+		{
+			name:    "host client key without certificate",
+			cfg:     TLS{Hosts: []tlsHost{{Host: "registry.example.com", ClientKey: "client.key"}}},
+			wantErr: "tls.hosts[0] (registry.example.com): client-cert and client-key must be provided together",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.PostLoad()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTLS_ToConfig(t *testing.T) {
+	cfg := TLS{
+		CACert: "ca.crt",
+		Hosts: []tlsHost{
+			{Host: "registry.example.com", InsecureSkipVerify: true, ClientCert: "client.crt", ClientKey: "client.key"},
+		},
+	}
+	assert.Equal(t, tlsconfig.Config{
+		Settings: tlsconfig.Settings{CACert: "ca.crt"},
+		Hosts: []tlsconfig.Host{
+			{
+				Host:     "registry.example.com",
+				Settings: tlsconfig.Settings{InsecureSkipVerify: true, ClientCert: "client.crt", ClientKey: "client.key"},
+			},
+		},
+	}, cfg.ToConfig())
+}
+
+func TestDatabaseCommand_ToClientConfig_TLS(t *testing.T) {
+	cfg := DatabaseCommand{
+		DB:  Database{CACert: "db-ca.crt"},
+		TLS: TLS{Hosts: []tlsHost{{Host: "grype.anchore.io", CACert: "ca.crt"}}},
+	}
+	got := cfg.ToClientConfig()
+	assert.Equal(t, "db-ca.crt", got.CACert)
+	assert.Equal(t, cfg.TLS.ToConfig(), got.TLS)
+}
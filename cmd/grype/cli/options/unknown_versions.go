@@ -0,0 +1,41 @@
+package options
+
+import (
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype"
+)
+
+// UnknownVersions configures how packages with an unknown version are handled during matching.
+type UnknownVersions struct {
+	Sentinels           []string `yaml:"sentinels" json:"sentinels" mapstructure:"sentinels"`
+	ReportSkipped       bool     `yaml:"report-skipped" json:"report-skipped" mapstructure:"report-skipped"`
+	MatchAnyVersionCPEs bool     `yaml:"match-any-version-cpes" json:"match-any-version-cpes" mapstructure:"match-any-version-cpes"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*UnknownVersions)(nil)
+
+func defaultUnknownVersions() UnknownVersions {
+	return UnknownVersions{}
+}
+
+func (u *UnknownVersions) PostLoad() error {
+	u.Sentinels = flatten(u.Sentinels)
+	return nil
+}
+
+func (u *UnknownVersions) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&u.Sentinels, `additional package version values (beyond "unknown", compared case-insensitively) that indicate the version is unknown, e.g. "0.0.0-unknown" or "n/a"`)
+	descriptions.Add(&u.ReportSkipped, `report packages with an unknown version that could not be matched in a "skipped" section of the output`)
+	descriptions.Add(&u.MatchAnyVersionCPEs, `match packages with an unknown version against every CPE advisory for the product regardless of the affected versions (matches are given a low confidence and are likely to include false positives)`)
+}
+
+func (u UnknownVersions) ToConfig() grype.UnknownVersionConfig {
+	return grype.UnknownVersionConfig{
+		Sentinels:           u.Sentinels,
+		ReportSkipped:       u.ReportSkipped,
+		MatchAnyVersionCPEs: u.MatchAnyVersionCPEs,
+	}
+}
@@ -0,0 +1,22 @@
+package options
+
+import "github.com/anchore/clio"
+
+// UnmanagedBinaries configures how findings for binaries not owned by any package are reported.
+type UnmanagedBinaries struct {
+	// Enabled reports matches against binaries not owned by any package as a distinct class of finding
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+}
+
+var _ clio.FieldDescriber = (*UnmanagedBinaries)(nil)
+
+func defaultUnmanagedBinaries() UnmanagedBinaries {
+	return UnmanagedBinaries{
+		Enabled: false,
+	}
+}
+
+func (u *UnmanagedBinaries) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&u.Enabled, `report binaries not owned by any package (e.g. dropped into an image by hand) in a dedicated "unmanagedBinaries" section,
+along with the matches against the versions embedded in them (CPEs are generated for these binaries if missing)`)
+}
@@ -0,0 +1,45 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/go-homedir"
+)
+
+// Vex configures the discovery of VEX documents beyond those given explicitly.
+type Vex struct {
+	Autodiscover    bool     `yaml:"autodiscover" json:"autodiscover" mapstructure:"autodiscover"`
+	AttestationKeys []string `yaml:"attestation-keys" json:"attestation-keys" mapstructure:"attestation-keys"`
+}
+
+var _ interface {
+	clio.FieldDescriber
+	clio.PostLoader
+} = (*Vex)(nil)
+
+func defaultVex() Vex {
+	return Vex{}
+}
+
+func (v *Vex) DescribeFields(descriptions clio.FieldDescriptionSet) {
+	descriptions.Add(&v.Autodiscover, `when scanning a container image declaring its base image (with the org.opencontainers.image.base.name annotation or label),
+apply the OpenVEX attestations published for the base image in its registry, signed by one of the attestation-keys.
+Only statements about specific packages of the base image are inherited`)
+	descriptions.Add(&v.AttestationKeys, `PEM public keys that base image VEX attestations must be signed with to be applied`)
+}
+
+func (v *Vex) PostLoad() error {
+	v.AttestationKeys = flatten(v.AttestationKeys)
+	if v.Autodiscover && len(v.AttestationKeys) == 0 {
+		return fmt.Errorf("vex.autodiscover requires vex.attestation-keys to verify base image attestations with")
+	}
+	for i, key := range v.AttestationKeys {
+		expanded, err := homedir.Expand(key)
+		if err != nil {
+			return err
+		}
+		v.AttestationKeys[i] = expanded
+	}
+	return nil
+}
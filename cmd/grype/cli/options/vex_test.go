@@ -0,0 +1,45 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVex_PostLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		vex      Vex
+		wantKeys []string
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name: "disabled by default",
+			vex:  defaultVex(),
+		},
+		{
+			name:     "autodiscover with keys",
+			vex:      Vex{Autodiscover: true, AttestationKeys: []string{"a.pub,b.pub"}},
+			wantKeys: []string{"a.pub", "b.pub"},
+		},
+		{
+			name:    "autodiscover requires keys",
+			vex:     Vex{Autodiscover: true},
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			err := tt.vex.PostLoad()
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantKeys, tt.vex.AttestationKeys)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/anchore/grype/grype/event/parsers"
+	"github.com/anchore/grype/grype/presenter/table"
+	"github.com/anchore/grype/internal/log"
+)
+
+func (m *Handler) handleVulnerabilityMatchesDiscovered(e partybus.Event) ([]tea.Model, tea.Cmd) {
+	matches, err := parsers.ParseVulnerabilityMatchesDiscovered(e)
+	if err != nil {
+		log.WithFields("error", err).Warn("unable to parse event")
+		return nil, nil
+	}
+
+	if m.stream == nil {
+		m.streamBuffer = &bytes.Buffer{}
+		m.stream = table.NewStreamPresenter(m.streamBuffer)
+	}
+
+	if err := m.stream.Write(matches...); err != nil {
+		log.WithFields("error", err).Warn("unable to render discovered matches")
+		return nil, nil
+	}
+
+	rows := strings.TrimRight(m.streamBuffer.String(), "\n")
+	m.streamBuffer.Reset()
+	if rows == "" {
+		return nil, nil
+	}
+
+	// print the rows above the progress frame so that they remain on the screen after the UI is torn down
+	return nil, tea.Println(rows)
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"bytes"
 	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -9,6 +10,7 @@ import (
 	"github.com/anchore/bubbly"
 	"github.com/anchore/bubbly/bubbles/taskprogress"
 	"github.com/anchore/grype/grype/event"
+	"github.com/anchore/grype/grype/presenter/table"
 )
 
 var _ interface {
@@ -28,6 +30,10 @@ type Handler struct {
 	Config     HandlerConfig
 
 	bubbly.EventHandler
+
+	// stream renders table rows for matches as they are discovered (only used when matches are streamed)
+	stream       *table.StreamPresenter
+	streamBuffer *bytes.Buffer
 }
 
 func DefaultHandlerConfig() HandlerConfig {
@@ -47,9 +53,10 @@ func New(cfg HandlerConfig) *Handler {
 
 	// register all supported event types with the respective handler functions
 	d.AddHandlers(map[partybus.EventType]bubbly.EventHandlerFn{
-		event.UpdateVulnerabilityDatabase:  h.handleUpdateVulnerabilityDatabase,
-		event.VulnerabilityScanningStarted: h.handleVulnerabilityScanningStarted,
-		event.DatabaseDiffingStarted:       h.handleDatabaseDiffStarted,
+		event.UpdateVulnerabilityDatabase:    h.handleUpdateVulnerabilityDatabase,
+		event.VulnerabilityScanningStarted:   h.handleVulnerabilityScanningStarted,
+		event.DatabaseDiffingStarted:         h.handleDatabaseDiffStarted,
+		event.VulnerabilityMatchesDiscovered: h.handleVulnerabilityMatchesDiscovered,
 	})
 
 	return h
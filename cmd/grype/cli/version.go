@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/matcher"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/grype/internal/schemaver"
+)
+
+// sqliteDriverModule is the (pure Go) SQLite implementation used to read the vulnerability DB.
+const sqliteDriverModule = "modernc.org/sqlite"
+
+// versionAdditions are the details reported by the version command in addition to the application version and runtime
+// (as "name: value" lines in text output, or as lower camel case keys in JSON output).
+func versionAdditions() []func() (string, any) {
+	return []func() (string, any){
+		syftVersion,
+		dbVersion,
+		dbSchemaVersion,
+		supportedEcosystems,
+		matchers,
+		cgoEnabled,
+		buildTags,
+		sqliteDriver,
+	}
+}
+
+// stringList is shown as a comma separated list in text output, and as an array in JSON output.
+type stringList []string
+
+func (l stringList) String() string {
+	return strings.Join(l, ", ")
+}
+
+// matcherInfo describes a vulnerability matcher and the package types it matches.
+type matcherInfo struct {
+	Name         string     `json:"name"`
+	PackageTypes stringList `json:"packageTypes,omitempty"`
+}
+
+type matcherList []matcherInfo
+
+func (l matcherList) String() string {
+	names := make([]string, len(l))
+	for i, m := range l {
+		names[i] = m.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// sqliteDriverInfo describes the SQLite implementation the vulnerability DB is read with.
+type sqliteDriverInfo struct {
+	Module      string `json:"module"`
+	Version     string `json:"version,omitempty"`
+	RequiresCGO bool   `json:"requiresCgo"`
+}
+
+func (d sqliteDriverInfo) String() string {
+	s := d.Module
+	if d.Version != "" {
+		s += "@" + d.Version
+	}
+	if !d.RequiresCGO {
+		s += " (pure Go)"
+	}
+	return s
+}
+
+func buildInfo() *debug.BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		log.Debug("unable to find the buildinfo section of the binary")
+		return nil
+	}
+	return info
+}
+
+func dependencyVersion(info *debug.BuildInfo, path string) string {
+	if info == nil {
+		return ""
+	}
+	for _, d := range info.Deps {
+		if d.Path == path {
+			return d.Version
+		}
+	}
+	return ""
+}
+
+func buildSetting(info *debug.BuildInfo, key string) string {
+	if info == nil {
+		return ""
+	}
+	for _, s := range info.Settings {
+		if s.Key == key {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+func syftVersion() (string, any) {
+	version := dependencyVersion(buildInfo(), "github.com/anchore/syft")
+	if version == "" {
+		log.Debug("unable to find 'github.com/anchore/syft' from the buildinfo section of the binary")
+		return "", ""
+	}
+	return "Syft Version", version
+}
+
+func dbVersion() (string, any) {
+	return "Supported DB Schema", v6.ModelVersion
+}
+
+func dbSchemaVersion() (string, any) {
+	return "DB Schema Version", schemaver.New(v6.ModelVersion, v6.Revision, v6.Addition).String()
+}
+
+func defaultMatcherInfo() matcherList {
+	var list matcherList
+	for _, m := range matcher.NewDefaultMatchers(matcher.Config{}) {
+		info := matcherInfo{Name: string(m.Type())}
+		for _, t := range m.PackageTypes() {
+			info.PackageTypes = append(info.PackageTypes, string(t))
+		}
+		list = append(list, info)
+	}
+	slices.SortFunc(list, func(a, b matcherInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return list
+}
+
+func supportedEcosystems() (string, any) {
+	var types stringList
+	for _, m := range defaultMatcherInfo() {
+		for _, t := range m.PackageTypes {
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	slices.Sort(types)
+	return "Supported Ecosystems", types
+}
+
+func matchers() (string, any) {
+	return "Matchers", defaultMatcherInfo()
+}
+
+func cgoEnabled() (string, any) {
+	enabled, err := strconv.ParseBool(buildSetting(buildInfo(), "CGO_ENABLED"))
+	if err != nil {
+		// not shown in text output
+		return "CGO Enabled", ""
+	}
+	return "CGO Enabled", enabled
+}
+
+func buildTags() (string, any) {
+	tags := stringList{}
+	if s := buildSetting(buildInfo(), "-tags"); s != "" {
+		tags = strings.Split(s, ",")
+	}
+	return "Build Tags", tags
+}
+
+func sqliteDriver() (string, any) {
+	return "SQLite Driver", sqliteDriverInfo{
+		Module:  sqliteDriverModule,
+		Version: dependencyVersion(buildInfo(), sqliteDriverModule),
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/clio"
+)
+
+func Test_versionJSON(t *testing.T) {
+	cmd := clio.VersionCommand(clio.Identification{Name: "grype", Version: "test-version"}, versionAdditions()...)
+	cmd.SetArgs([]string{"-o", "json"})
+
+	// the version command prints to stdout directly
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	err = cmd.Execute()
+	os.Stdout = stdout
+	require.NoError(t, w.Close())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err)
+
+	var got struct {
+		Version             string        `json:"version"`
+		SupportedDBSchema   int           `json:"supportedDbSchema"`
+		DBSchemaVersion     string        `json:"dbSchemaVersion"`
+		SupportedEcosystems []string      `json:"supportedEcosystems"`
+		Matchers            []matcherInfo `json:"matchers"`
+		BuildTags           []string      `json:"buildTags"`
+		SQLiteDriver        struct {
+			Module      string `json:"module"`
+			RequiresCGO bool   `json:"requiresCgo"`
+		} `json:"sqliteDriver"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got), buf.String())
+
+	assert.Equal(t, "test-version", got.Version)
+	assert.Equal(t, 6, got.SupportedDBSchema)
+	assert.Regexp(t, `^v6\.\d+\.\d+$`, got.DBSchemaVersion)
+	assert.Contains(t, got.SupportedEcosystems, "deb")
+	assert.Contains(t, got.SupportedEcosystems, "npm")
+	assert.Contains(t, got.Matchers, matcherInfo{Name: "dpkg-matcher", PackageTypes: stringList{"deb"}})
+	assert.NotNil(t, got.BuildTags)
+	assert.Equal(t, sqliteDriverModule, got.SQLiteDriver.Module)
+	assert.False(t, got.SQLiteDriver.RequiresCGO)
+}
+
+func Test_versionText(t *testing.T) {
+	name, value := matchers()
+	assert.Equal(t, "Matchers", name)
+	assert.Contains(t, value.(matcherList).String(), "dpkg-matcher, ")
+
+	assert.Equal(t, "a, b", stringList{"a", "b"}.String())
+	assert.Empty(t, stringList{}.String())
+	assert.Equal(t, "modernc.org/sqlite@v1.0.0 (pure Go)", sqliteDriverInfo{Module: sqliteDriverModule, Version: "v1.0.0"}.String())
+}
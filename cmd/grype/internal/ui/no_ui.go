@@ -7,6 +7,9 @@ import (
 
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/grype/event"
+	"github.com/anchore/grype/grype/event/parsers"
+	"github.com/anchore/grype/grype/presenter/table"
+	"github.com/anchore/grype/internal/log"
 )
 
 var _ clio.UI = (*NoUI)(nil)
@@ -15,6 +18,7 @@ type NoUI struct {
 	finalizeEvents []partybus.Event
 	subscription   partybus.Unsubscribable
 	quiet          bool
+	stream         *table.StreamPresenter
 }
 
 func None(quiet bool) *NoUI {
@@ -33,6 +37,17 @@ func (n *NoUI) Handle(e partybus.Event) error {
 	case event.CLIReport, event.CLINotification:
 		// keep these for when the UI is terminated to show to the screen (or perform other events)
 		n.finalizeEvents = append(n.finalizeEvents, e)
+	case event.VulnerabilityMatchesDiscovered:
+		// there is no UI to contend with, so rows can be written to stdout as soon as they are available
+		matches, err := parsers.ParseVulnerabilityMatchesDiscovered(e)
+		if err != nil {
+			log.WithFields("error", err).Warn("unable to parse event")
+			return nil
+		}
+		if n.stream == nil {
+			n.stream = table.NewStreamPresenter(os.Stdout)
+		}
+		return n.stream.Write(matches...)
 	}
 	return nil
 }
@@ -17,6 +17,12 @@ type logAdapter struct {
 	debug         bool
 	slowThreshold time.Duration
 	level         logger.LogLevel
+	tracer        Tracer
+}
+
+// Tracer receives every SQL statement executed, regardless of the log level.
+type Tracer interface {
+	TraceQuery(sql string, rowsAffected int64, elapsed time.Duration, err error)
 }
 
 // LogMode sets the log level for the logger and returns a new instance
@@ -47,7 +53,12 @@ func (l logAdapter) Error(_ context.Context, fmt string, v ...any) {
 }
 
 // Trace logs the SQL statement and the duration it took to run the statement
-func (l logAdapter) Trace(_ context.Context, t time.Time, fn func() (sql string, rowsAffected int64), _ error) {
+func (l logAdapter) Trace(_ context.Context, t time.Time, fn func() (sql string, rowsAffected int64), err error) {
+	if l.tracer != nil {
+		sql, rowsAffected := fn()
+		l.tracer.TraceQuery(sql, rowsAffected, time.Since(t), err)
+	}
+
 	if l.level <= logger.Silent {
 		return
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,6 +30,13 @@ var heavyWriteStatements = []string{
 	`PRAGMA defer_foreign_keys = ON`,  // defer enforcement of foreign key constraints until the end of the transaction (to avoid the overhead of checking constraints for each row)
 }
 
+// readerStatements are applied to read-only connections (used while matching), where many small indexed lookups are made
+// across the entire DB. These favor keeping as much of the DB in memory as possible.
+var readerStatements = []string{
+	`PRAGMA mmap_size = 268435456`, // ~256 MB; read pages directly from the memory-mapped file instead of copying them into the page cache
+	`PRAGMA cache_size = -65536`,   // ~64 MB (negative means KiB not page count); the default (~2 MB) results in frequent page cache evictions
+}
+
 var readConnectionOptions = []string{
 	"immutable=1",  // indicates that the database file is guaranteed not to change during the connection’s lifetime (slight performance benefit for read-only cases)
 	"mode=ro",      // opens the database in as read-only (an enforcement mechanism to allow immutable=1 to be effective)
@@ -45,6 +53,8 @@ type config struct {
 	initialData               []any
 	memory                    bool
 	statements                []string
+	pragmas                   map[string]string
+	tracer                    Tracer
 }
 
 type Option func(*config)
@@ -55,6 +65,13 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithTracer reports every SQL statement executed against the DB to the given tracer.
+func WithTracer(tracer Tracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
 func WithTruncate(truncate bool, models []any, initialData []any) Option {
 	return func(c *config) {
 		c.truncate = truncate
@@ -73,6 +90,19 @@ func WithStatements(statements ...string) Option {
 	}
 }
 
+// WithPragmas sets the given PRAGMA values (by name) on the DB connection, overriding any defaults. Note that the
+// journal mode only applies to writable DBs, since read-only connections never write a journal.
+func WithPragmas(pragmas map[string]string) Option {
+	return func(c *config) {
+		if c.pragmas == nil {
+			c.pragmas = make(map[string]string)
+		}
+		for name, value := range pragmas {
+			c.pragmas[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+		}
+	}
+}
+
 func WithModels(models []any) Option {
 	return func(c *config) {
 		c.models = append(c.models, models...)
@@ -142,6 +172,7 @@ func Open(path string, options ...Option) (*gorm.DB, error) {
 	dbObj, err := gorm.Open(sqlite.Open(cfg.connectionString()), &gorm.Config{Logger: &logAdapter{
 		debug:         cfg.debug,
 		slowThreshold: 400 * time.Millisecond,
+		tracer:        cfg.tracer,
 	}})
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to DB: %w", err)
@@ -165,12 +196,25 @@ func (c config) prepareDB(dbObj *gorm.DB) (*gorm.DB, error) {
 		}
 	}
 
+	if !c.writable && !c.memory {
+		log.WithFields("path", c.path).Trace("using read-only DB statements")
+		if err := c.applyStatements(dbObj, readerStatements); err != nil {
+			return nil, fmt.Errorf("unable to apply DB reader statements: %w", err)
+		}
+	}
+
 	if len(commonStatements) > 0 {
 		if err := c.applyStatements(dbObj, commonStatements); err != nil {
 			return nil, fmt.Errorf("unable to apply DB common statements: %w", err)
 		}
 	}
 
+	if pragmas := c.pragmaStatements(); len(pragmas) > 0 {
+		if err := c.applyStatements(dbObj, pragmas); err != nil {
+			return nil, fmt.Errorf("unable to apply DB pragmas: %w", err)
+		}
+	}
+
 	if len(c.statements) > 0 {
 		if err := c.applyStatements(dbObj, c.statements); err != nil {
 			return nil, fmt.Errorf("unable to apply DB custom statements: %w", err)
@@ -204,6 +248,24 @@ func (c config) prepareDB(dbObj *gorm.DB) (*gorm.DB, error) {
 	return dbObj, nil
 }
 
+func (c config) pragmaStatements() []string {
+	var names []string
+	for name := range c.pragmas {
+		if name == "journal_mode" && !c.writable {
+			log.WithFields("path", c.path).Trace("skipping journal_mode pragma for read-only DB")
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var statements []string
+	for _, name := range names {
+		statements = append(statements, fmt.Sprintf("PRAGMA %s = %s", name, c.pragmas[name]))
+	}
+	return statements
+}
+
 func (c config) applyStatements(db *gorm.DB, statements []string) error {
 	for _, sqlStmt := range statements {
 		if err := db.Exec(sqlStmt).Error; err != nil {
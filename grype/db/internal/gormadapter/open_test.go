@@ -1,6 +1,7 @@
 package gormadapter
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -222,3 +223,98 @@ func TestPragmaNameValue(t *testing.T) {
 		})
 	}
 }
+
+func TestPragmaStatements(t *testing.T) {
+	pragmas := map[string]string{" MMAP_SIZE ": "0", "cache_size": "-2000", "journal_mode": "WAL"}
+
+	t.Run("writable DB", func(t *testing.T) {
+		c := newConfig("test.db", []Option{WithWritable(true, nil), WithPragmas(pragmas)})
+		require.Equal(t, []string{
+			"PRAGMA cache_size = -2000",
+			"PRAGMA journal_mode = WAL",
+			"PRAGMA mmap_size = 0",
+		}, c.pragmaStatements())
+	})
+
+	t.Run("read-only DB skips the journal mode", func(t *testing.T) {
+		c := newConfig("test.db", []Option{WithPragmas(pragmas)})
+		require.Equal(t, []string{
+			"PRAGMA cache_size = -2000",
+			"PRAGMA mmap_size = 0",
+		}, c.pragmaStatements())
+	})
+}
+
+func TestOpen_readOnlyPragmas(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	createBenchmarkDB(t, dbPath, 10)
+
+	pragma := func(t *testing.T, options ...Option) string {
+		db, err := Open(dbPath, options...)
+		require.NoError(t, err)
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, sqlDB.Close()) })
+
+		var value string
+		require.NoError(t, db.Raw("PRAGMA cache_size").Scan(&value).Error)
+		return value
+	}
+
+	require.Equal(t, "-65536", pragma(t))
+	require.Equal(t, "-2000", pragma(t, WithPragmas(map[string]string{"cache_size": "-2000"})))
+}
+
+// BenchmarkReadOnlyPragmas compares indexed lookups against a read-only DB using the sqlite defaults vs the
+// read-only tuned defaults (e.g. go test -run none -bench BenchmarkReadOnlyPragmas -benchtime 200000x).
+func BenchmarkReadOnlyPragmas(b *testing.B) {
+	const rows = 1_000_000
+	dbPath := filepath.Join(b.TempDir(), "bench.db")
+	createBenchmarkDB(b, dbPath, rows)
+
+	cases := []struct {
+		name    string
+		options []Option
+	}{
+		{
+			name:    "sqlite defaults",
+			options: []Option{WithPragmas(map[string]string{"mmap_size": "0", "cache_size": "-2000"})},
+		},
+		{
+			name: "read-only defaults",
+		},
+	}
+
+	for _, bc := range cases {
+		b.Run(bc.name, func(b *testing.B) {
+			db, err := Open(dbPath, bc.options...)
+			require.NoError(b, err)
+			sqlDB, err := db.DB()
+			require.NoError(b, err)
+			b.Cleanup(func() { require.NoError(b, sqlDB.Close()) })
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var payload string
+				name := fmt.Sprintf("name-%d", (i*7919)%rows)
+				require.NoError(b, db.Raw("SELECT payload FROM records WHERE name = ?", name).Scan(&payload).Error)
+			}
+		})
+	}
+}
+
+func createBenchmarkDB(t testing.TB, dbPath string, rows int) {
+	t.Helper()
+
+	db, err := Open(dbPath, WithTruncate(true, nil, nil))
+	require.NoError(t, err)
+
+	require.NoError(t, db.Exec("CREATE TABLE records (id INTEGER PRIMARY KEY, name TEXT, payload TEXT)").Error)
+	require.NoError(t, db.Exec("CREATE INDEX records_name ON records (name)").Error)
+	require.NoError(t, db.Exec(`WITH RECURSIVE seq(n) AS (SELECT 0 UNION ALL SELECT n + 1 FROM seq WHERE n < ?)
+		INSERT INTO records (name, payload) SELECT 'name-' || n, printf('%0200d', n) FROM seq`, rows-1).Error)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+}
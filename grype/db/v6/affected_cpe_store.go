@@ -12,6 +12,7 @@ type AffectedCPEStoreWriter interface {
 
 type AffectedCPEStoreReader interface {
 	GetAffectedCPEs(cpe *cpe.Attributes, config *GetCPEOptions) ([]AffectedCPEHandle, error)
+	CountAffectedCPEs(cpe *cpe.Attributes, config *GetCPEOptions, groupBy CountGroupBy) ([]CountGroup, error)
 }
 
 type affectedCPEStore struct {
@@ -49,3 +50,7 @@ func (s *affectedCPEStore) GetAffectedCPEs(cpe *cpe.Attributes, config *GetCPEOp
 	}
 	return models, nil
 }
+
+func (s *affectedCPEStore) CountAffectedCPEs(cpe *cpe.Attributes, config *GetCPEOptions, groupBy CountGroupBy) ([]CountGroup, error) {
+	return countCPEHandles(s.cpeStore, cpe, config, "affected_cpe_handles", groupBy)
+}
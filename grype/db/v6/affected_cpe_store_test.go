@@ -210,6 +210,30 @@ func TestAffectedCPEStore_PreventDuplicateCPEs(t *testing.T) {
 	}
 }
 
+func TestAffectedCPEStore_CountAffectedCPEs(t *testing.T) {
+	db := setupTestStore(t).db
+	bw := newBlobStore(db)
+	s := newAffectedCPEStore(db, bw)
+
+	c1 := testAffectedCPEHandle()
+	c2 := testAffectedCPEHandle()
+	c2.Vulnerability = &VulnerabilityHandle{Name: "CVE-2024-1234", Provider: &Provider{ID: "nvd"}}
+	c2.CPE = &Cpe{Part: "a", Vendor: "vendor", Product: "other-product"}
+	require.NoError(t, s.AddAffectedCPEs(c1, c2))
+
+	got, err := s.CountAffectedCPEs(nil, nil, CountTotal)
+	require.NoError(t, err)
+	assert.Equal(t, []CountGroup{{Count: 2}}, got)
+
+	got, err = s.CountAffectedCPEs(cpeFromProduct(c1.CPE.Product), nil, CountByProvider)
+	require.NoError(t, err)
+	assert.Equal(t, []CountGroup{{Value: "nvd", Count: 1}}, got)
+
+	got, err = s.CountAffectedCPEs(nil, nil, CountByEcosystem)
+	require.NoError(t, err)
+	assert.Equal(t, []CountGroup{{Value: "", Count: 1}, {Value: "target_software", Count: 1}}, got)
+}
+
 func cpeFromProduct(product string) *cpe.Attributes {
 	return &cpe.Attributes{
 		Product: product,
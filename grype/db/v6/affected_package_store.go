@@ -8,6 +8,7 @@ type AffectedPackageStoreWriter interface {
 
 type AffectedPackageStoreReader interface {
 	GetAffectedPackages(pkg *PackageSpecifier, config *GetPackageOptions) ([]AffectedPackageHandle, error)
+	CountAffectedPackages(pkg *PackageSpecifier, config *GetPackageOptions, groupBy CountGroupBy) ([]CountGroup, error)
 }
 
 type affectedPackageStore struct {
@@ -45,3 +46,7 @@ func (s *affectedPackageStore) GetAffectedPackages(pkg *PackageSpecifier, config
 	}
 	return models, nil
 }
+
+func (s *affectedPackageStore) CountAffectedPackages(pkg *PackageSpecifier, config *GetPackageOptions, groupBy CountGroupBy) ([]CountGroup, error) {
+	return countPackages(s.pkgStore, pkg, config, "affected_package_handles", groupBy)
+}
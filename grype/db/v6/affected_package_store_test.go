@@ -887,6 +887,23 @@ func TestAffectedPackageStore_GetAffectedPackages(t *testing.T) {
 			},
 			expected: []AffectedPackageHandle{*pkg2d1, *pkg2d2},
 		},
+		{
+			name: "any CVE published within a date range",
+			pkg:  pkgFromName(pkg2d1.Package.Name),
+			options: &GetPackageOptions{
+				Vulnerabilities: []VulnerabilitySpecifier{{
+					PublishedAfter: func() *time.Time {
+						after := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+						return &after
+					}(),
+					PublishedBefore: func() *time.Time {
+						before := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+						return &before
+					}(),
+				}},
+			},
+			expected: []AffectedPackageHandle{*pkg2d2},
+		},
 		{
 			name: "any CVE modified after a date",
 			pkg:  pkgFromName(pkg2d1.Package.Name),
@@ -945,6 +962,93 @@ func TestAffectedPackageStore_GetAffectedPackages(t *testing.T) {
 	}
 }
 
+func TestAffectedPackageStore_CountAffectedPackages(t *testing.T) {
+	db := setupTestStore(t).db
+	bs := newBlobStore(db)
+	oss := newOperatingSystemStore(db, bs)
+	s := newAffectedPackageStore(db, bs, oss)
+
+	pkg2d1 := testDistro1AffectedPackage2Handle()
+	pkg2d2 := testDistro2AffectedPackage2Handle()
+	pkg2 := testNonDistroAffectedPackage2Handle()
+	err := s.AddAffectedPackages(pkg2d1, pkg2d2, pkg2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		pkg     *PackageSpecifier
+		options *GetPackageOptions
+		groupBy CountGroupBy
+		want    []CountGroup
+	}{
+		{
+			name: "total",
+			pkg:  pkgFromName("pkg2"),
+			want: []CountGroup{{Count: 3}},
+		},
+		{
+			name:    "total without matches",
+			pkg:     pkgFromName("does not exist"),
+			groupBy: CountTotal,
+			want:    []CountGroup{{Count: 0}},
+		},
+		{
+			name:    "by provider",
+			groupBy: CountByProvider,
+			want: []CountGroup{
+				{Value: "ubuntu", Count: 2},
+				{Value: "wolfi", Count: 1},
+			},
+		},
+		{
+			name:    "by ecosystem",
+			pkg:     pkgFromName("pkg2"),
+			groupBy: CountByEcosystem,
+			want: []CountGroup{
+				{Value: "type2", Count: 1},
+				{Value: "type2d", Count: 2},
+			},
+		},
+		{
+			name: "by provider with vulnerability and OS criteria",
+			options: &GetPackageOptions{
+				OSs:             []*OSSpecifier{{Name: "ubuntu"}},
+				Vulnerabilities: []VulnerabilitySpecifier{{Name: "CVE-2023-4567"}},
+			},
+			groupBy: CountByProvider,
+			want:    []CountGroup{{Value: "ubuntu", Count: 1}},
+		},
+		{
+			name: "limit does not apply",
+			pkg:  pkgFromName("pkg2"),
+			options: &GetPackageOptions{
+				Limit: 1,
+			},
+			want: []CountGroup{{Count: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.CountAffectedPackages(tt.pkg, tt.options, tt.groupBy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("by vulnerability", func(t *testing.T) {
+		got, err := s.CountAffectedPackages(pkgFromName("pkg2"), nil, CountByVulnerability)
+		require.NoError(t, err)
+
+		counts := make(map[string]int)
+		for _, g := range got {
+			require.NotNil(t, g.Vulnerability)
+			counts[g.Vulnerability.Name] += g.Count
+		}
+		assert.Equal(t, map[string]int{"CVE-2023-1234": 1, "CVE-2023-4567": 2}, counts)
+	})
+}
+
 func TestAffectedPackageStore_ApplyPackageAlias(t *testing.T) {
 	db := setupTestStore(t).db
 	bs := newBlobStore(db)
@@ -78,7 +78,7 @@ func CreateArchive(dbDir, overrideArchiveExtension string, compressorCommands ma
 
 	log.WithFields("path", tarPath).Info("created database archive")
 
-	return writeLatestDocument(tarPath, *metadata)
+	return writeLatestDocument(tarPath, *metadata, providerModels)
 }
 
 func toProviders(states []v6.Provider) provider.States {
@@ -92,6 +92,19 @@ func toProviders(states []v6.Provider) provider.States {
 	return result
 }
 
+func toArchiveProviders(providers []v6.Provider) []v6Distribution.ArchiveProvider {
+	var result []v6Distribution.ArchiveProvider
+	for _, p := range providers {
+		result = append(result, v6Distribution.ArchiveProvider{
+			Name:         p.ID,
+			Version:      p.Version,
+			DateCaptured: p.DateCaptured,
+			InputDigest:  p.InputDigest,
+		})
+	}
+	return result
+}
+
 func resolveExtension(overrideArchiveExtension string) (string, error) {
 	var extension = "tar.zst"
 
@@ -142,11 +155,13 @@ func populateTar(dbDir, tarName string, compressorCommands map[string]string, fi
 	return nil
 }
 
-func writeLatestDocument(tarPath string, metadata v6.DBMetadata) error {
+func writeLatestDocument(tarPath string, metadata v6.DBMetadata, providers []v6.Provider) error {
 	archive, err := v6Distribution.NewArchive(tarPath, *metadata.BuildTimestamp, metadata.Model, metadata.Revision, metadata.Addition)
 	if err != nil || archive == nil {
 		return fmt.Errorf("unable to create archive: %w", err)
 	}
+	// the providers allow clients to summarize what an update changes before downloading it
+	archive.Providers = toArchiveProviders(providers)
 
 	doc := v6Distribution.NewLatestDocument(*archive)
 	if doc == nil {
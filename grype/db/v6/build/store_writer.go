@@ -0,0 +1,191 @@
+package v6
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anchore/grype/grype/db/data"
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/build/transformers"
+)
+
+// Record is a vulnerability along with the packages and CPEs it is known to affect (or not affect), the unit of data
+// written with a StoreWriter. The database IDs of all handles (e.g. ID, BlobID and VulnerabilityID) are assigned when
+// written and must be left unset.
+type Record struct {
+	// Vulnerability is the vulnerability, which must have a provider (see NewProvider)
+	Vulnerability db.VulnerabilityHandle
+
+	// AffectedPackages are the packages affected by the vulnerability (matched by ecosystem and name, optionally scoped to an OS)
+	AffectedPackages []db.AffectedPackageHandle
+
+	// UnaffectedPackages are the packages known not to be affected by the vulnerability
+	UnaffectedPackages []db.UnaffectedPackageHandle
+
+	// AffectedCPEs are the CPEs affected by the vulnerability
+	AffectedCPEs []db.AffectedCPEHandle
+
+	// UnaffectedCPEs are the CPEs known not to be affected by the vulnerability
+	UnaffectedCPEs []db.UnaffectedCPEHandle
+
+	// MaliciousArtifacts are the package artifacts (by package URL or digest) a known-malicious package record applies to
+	MaliciousArtifacts []db.MaliciousArtifactHandle
+}
+
+// StoreWriterOption configures a StoreWriter.
+type StoreWriterOption func(*storeWriterConfig)
+
+type storeWriterConfig struct {
+	batchSize            int
+	failOnMissingFixDate bool
+}
+
+// WithBatchSize sets the number of records buffered before being written to the database (the default is
+// DefaultBatchSize).
+func WithBatchSize(size int) StoreWriterOption {
+	return func(cfg *storeWriterConfig) {
+		cfg.batchSize = size
+	}
+}
+
+// WithFailOnMissingFixDate fails writes of affected packages with fixed versions that have no fix availability date.
+func WithFailOnMissingFixDate(fail bool) StoreWriterOption {
+	return func(cfg *storeWriterConfig) {
+		cfg.failOnMissingFixDate = fail
+	}
+}
+
+// DefaultBatchSize is the default number of records buffered before being written to the database.
+const DefaultBatchSize = 2000
+
+// StoreWriter writes vulnerability records to a new v6 database, for building databases outside of grype (e.g. from a
+// custom provider) that are compatible with grype. It is the same writer used to build the published databases:
+//
+//   - records are buffered and written in batches
+//   - blobs (e.g. descriptions and affected ranges) shared by records are stored once
+//   - providers, packages, CPEs and operating systems shared by records are stored once
+//   - missing severities of vulnerabilities are filled in from the NVD record of the same CVE (or of a CVE alias),
+//     given NVD records are written first
+//
+// The database is complete once the writer is closed, and can then be used with 'grype db import <dir>/vulnerability.db'.
+type StoreWriter struct {
+	writer data.Writer
+	closed bool
+}
+
+// NewStoreWriter creates a new v6 database in the given directory, replacing any existing database.
+func NewStoreWriter(dir string, opts ...StoreWriterOption) (*StoreWriter, error) {
+	cfg := storeWriterConfig{
+		batchSize: DefaultBatchSize,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	w, err := NewWriter(dir, nil, cfg.failOnMissingFixDate, cfg.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoreWriter{writer: w}, nil
+}
+
+// Write adds the records to the database.
+func (w *StoreWriter) Write(records ...Record) error {
+	if w.closed {
+		return errors.New("unable to write records: writer is closed")
+	}
+
+	for i := range records {
+		r := records[i]
+		if err := validateRecord(r); err != nil {
+			return err
+		}
+
+		if r.Vulnerability.ProviderID == "" {
+			r.Vulnerability.ProviderID = r.Vulnerability.Provider.ID
+		}
+
+		models := []any{r.Vulnerability}
+		for _, p := range r.AffectedPackages {
+			models = append(models, p)
+		}
+		for _, p := range r.UnaffectedPackages {
+			models = append(models, p)
+		}
+		for _, c := range r.AffectedCPEs {
+			models = append(models, c)
+		}
+		for _, c := range r.UnaffectedCPEs {
+			models = append(models, c)
+		}
+		for _, a := range r.MaliciousArtifacts {
+			models = append(models, a)
+		}
+
+		if err := w.writer.Write(transformers.NewEntries(models...)...); err != nil {
+			return fmt.Errorf("unable to write %q: %w", r.Vulnerability.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Close writes all buffered records and closes the database.
+func (w *StoreWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.writer.Close()
+}
+
+func validateRecord(r Record) error {
+	v := r.Vulnerability
+	if v.Name == "" {
+		return errors.New("invalid record: vulnerability has no name")
+	}
+	if v.Provider == nil || v.Provider.ID == "" {
+		return fmt.Errorf("invalid record %q: vulnerability has no provider", v.Name)
+	}
+	if v.ProviderID != "" && v.ProviderID != v.Provider.ID {
+		return fmt.Errorf("invalid record %q: provider ID %q does not match the provider %q", v.Name, v.ProviderID, v.Provider.ID)
+	}
+	for _, p := range r.AffectedPackages {
+		if p.Package == nil {
+			return fmt.Errorf("invalid record %q: affected package has no package", v.Name)
+		}
+	}
+	for _, p := range r.UnaffectedPackages {
+		if p.Package == nil {
+			return fmt.Errorf("invalid record %q: unaffected package has no package", v.Name)
+		}
+	}
+	for _, c := range r.AffectedCPEs {
+		if c.CPE == nil {
+			return fmt.Errorf("invalid record %q: affected CPE has no CPE", v.Name)
+		}
+	}
+	for _, c := range r.UnaffectedCPEs {
+		if c.CPE == nil {
+			return fmt.Errorf("invalid record %q: unaffected CPE has no CPE", v.Name)
+		}
+	}
+	for _, a := range r.MaliciousArtifacts {
+		if a.PURL == "" && a.Digest == "" {
+			return fmt.Errorf("invalid record %q: malicious artifact has no package URL or digest", v.Name)
+		}
+	}
+	return nil
+}
+
+// NewProvider returns the provider of vulnerability records, with the time the provider data was captured.
+func NewProvider(id, version string, captured time.Time) *db.Provider {
+	captured = captured.UTC()
+	return &db.Provider{
+		ID:           id,
+		Version:      version,
+		DateCaptured: &captured,
+	}
+}
@@ -0,0 +1,165 @@
+package v6
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestStoreWriter(t *testing.T) {
+	dir := t.TempDir()
+	captured := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	provider := NewProvider("custom", "1", captured)
+
+	w, err := NewStoreWriter(dir, WithBatchSize(1))
+	require.NoError(t, err)
+
+	ranges := []db.Range{{
+		Version: db.Version{Type: "semver", Constraint: "< 1.2.0"},
+		Fix:     &db.Fix{Version: "1.2.0", State: db.FixedStatus},
+	}}
+	records := []Record{
+		{
+			Vulnerability: db.VulnerabilityHandle{
+				Name:     "CUSTOM-2025-0001",
+				Provider: provider,
+				BlobValue: &db.VulnerabilityBlob{
+					ID:          "CUSTOM-2025-0001",
+					Description: "a flaw in libfoo",
+					Aliases:     []string{"CVE-2025-0001"},
+				},
+			},
+			AffectedPackages: []db.AffectedPackageHandle{
+				{Package: &db.Package{Ecosystem: "npm", Name: "libfoo"}, BlobValue: &db.PackageBlob{Ranges: ranges}},
+			},
+		},
+		{
+			Vulnerability: db.VulnerabilityHandle{
+				Name:     "CUSTOM-2025-0002",
+				Provider: provider,
+				BlobValue: &db.VulnerabilityBlob{
+					ID:          "CUSTOM-2025-0002",
+					Description: "another flaw in libfoo",
+				},
+			},
+			AffectedPackages: []db.AffectedPackageHandle{
+				// the same package and ranges are stored once
+				{Package: &db.Package{Ecosystem: "npm", Name: "libfoo"}, BlobValue: &db.PackageBlob{Ranges: ranges}},
+			},
+			AffectedCPEs: []db.AffectedCPEHandle{
+				{CPE: &db.Cpe{Part: "a", Vendor: "foo", Product: "libfoo"}, BlobValue: &db.PackageBlob{Ranges: ranges}},
+			},
+		},
+		{
+			Vulnerability: db.VulnerabilityHandle{
+				Name:     "MAL-2025-0001",
+				Provider: provider,
+				BlobValue: &db.VulnerabilityBlob{
+					ID:          "MAL-2025-0001",
+					Description: "malicious code in libbar",
+				},
+			},
+			MaliciousArtifacts: []db.MaliciousArtifactHandle{
+				{PURL: "pkg:npm/libbar@1.0.0"},
+				{Digest: "sha256:aaaa"},
+			},
+		},
+	}
+	require.NoError(t, w.Write(records...))
+	require.NoError(t, w.Close())
+	require.ErrorContains(t, w.Write(records...), "writer is closed")
+	require.NoError(t, w.Close())
+
+	reader, err := db.NewReader(db.Config{DBDirPath: dir})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	p, err := reader.GetProvider("custom")
+	require.NoError(t, err)
+	assert.Equal(t, "1", p.Version)
+	assert.Equal(t, captured, p.DateCaptured.UTC())
+
+	pkgs, err := reader.GetAffectedPackages(&db.PackageSpecifier{Ecosystem: "npm", Name: "libfoo"}, &db.GetPackageOptions{PreloadPackage: true, PreloadVulnerability: true, PreloadBlob: true})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+	assert.Equal(t, pkgs[0].PackageID, pkgs[1].PackageID)
+	assert.Equal(t, pkgs[0].BlobID, pkgs[1].BlobID)
+	assert.Equal(t, "custom", pkgs[0].Vulnerability.ProviderID)
+	assert.Equal(t, ranges, pkgs[0].BlobValue.Ranges)
+
+	vulns, err := reader.GetVulnerabilities(&db.VulnerabilitySpecifier{Name: "CUSTOM-2025-0001"}, &db.GetVulnerabilityOptions{Preload: true})
+	require.NoError(t, err)
+	require.Len(t, vulns, 1)
+	assert.Equal(t, "a flaw in libfoo", vulns[0].BlobValue.Description)
+
+	artifacts, err := reader.GetMaliciousArtifacts([]string{"pkg:npm/libbar@1.0.0"}, []string{"sha256:aaaa"})
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	for _, a := range artifacts {
+		require.NotNil(t, a.Vulnerability)
+		assert.Equal(t, "MAL-2025-0001", a.Vulnerability.Name)
+	}
+}
+
+func TestStoreWriter_invalidRecords(t *testing.T) {
+	provider := NewProvider("custom", "1", time.Now())
+
+	tests := []struct {
+		name    string
+		record  Record
+		wantErr string
+	}{
+		{
+			name:    "no name",
+			record:  Record{Vulnerability: db.VulnerabilityHandle{Provider: provider}},
+			wantErr: "vulnerability has no name",
+		},
+		{
+			name:    "no provider",
+			record:  Record{Vulnerability: db.VulnerabilityHandle{Name: "CUSTOM-1"}},
+			wantErr: "vulnerability has no provider",
+		},
+		{
+			name:    "mismatched provider ID",
+			record:  Record{Vulnerability: db.VulnerabilityHandle{Name: "CUSTOM-1", ProviderID: "other", Provider: provider}},
+			wantErr: `provider ID "other" does not match`,
+		},
+		{
+			name: "package without package",
+			record: Record{
+				Vulnerability:    db.VulnerabilityHandle{Name: "CUSTOM-1", Provider: provider},
+				AffectedPackages: []db.AffectedPackageHandle{{}},
+			},
+			wantErr: "affected package has no package",
+		},
+		{
+			name: "CPE without CPE",
+			record: Record{
+				Vulnerability:  db.VulnerabilityHandle{Name: "CUSTOM-1", Provider: provider},
+				UnaffectedCPEs: []db.UnaffectedCPEHandle{{}},
+			},
+			wantErr: "unaffected CPE has no CPE",
+		},
+		{
+			name: "malicious artifact without package URL or digest",
+			record: Record{
+				Vulnerability:      db.VulnerabilityHandle{Name: "MAL-1", Provider: provider},
+				MaliciousArtifacts: []db.MaliciousArtifactHandle{{}},
+			},
+			wantErr: "malicious artifact has no package URL or digest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewStoreWriter(t.TempDir())
+			require.NoError(t, err)
+			defer w.Close()
+
+			require.ErrorContains(t, w.Write(tt.record), tt.wantErr)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/anchore/grype/grype/db/v6/build/transformers/internal"
 	"github.com/anchore/grype/grype/db/v6/name"
 	"github.com/anchore/grype/grype/distro"
+	grypePkg "github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/internal/log"
 	"github.com/anchore/syft/syft/pkg"
 )
@@ -76,7 +77,7 @@ func getPackages(vuln unmarshal.OSVulnerability) ([]db.AffectedPackageHandle, []
 	for group, fixedIns := range groups {
 		// APK providers already handle not-affected signaling in their own matching layer,
 		// so skip emitting unaffected package handles for them.
-		pkgType := getPackageType(group.osName)
+		pkgType := PackageType(group.osName)
 		if pkgType != pkg.ApkPkg && isNotAffectedGroup(fixedIns) {
 			// A not-affected handle SUPPRESSES an affected match for the same package+OS.
 			// Because affected handles are expanded per-minor (below), the suppressing
@@ -123,7 +124,7 @@ func getPackages(vuln unmarshal.OSVulnerability) ([]db.AffectedPackageHandle, []
 		}
 
 		aph := db.AffectedPackageHandle{
-			OperatingSystem: getOperatingSystem(group.osName, group.id, group.osVersion, group.osChannel),
+			OperatingSystem: OperatingSystem(group.osName, group.id, group.osVersion, group.osChannel),
 			Package:         getPackage(group),
 			BlobValue: &db.PackageBlob{
 				CVEs:       getAliases(vuln),
@@ -289,7 +290,7 @@ func expandUnaffectedHandles(vuln unmarshal.OSVulnerability, group groupIndex, f
 
 	minors := rhelGAExpansionMinors(group)
 	if minors == nil {
-		return []db.UnaffectedPackageHandle{mk(getOperatingSystem(group.osName, group.id, group.osVersion, group.osChannel))}
+		return []db.UnaffectedPackageHandle{mk(OperatingSystem(group.osName, group.id, group.osVersion, group.osChannel))}
 	}
 	out := make([]db.UnaffectedPackageHandle, 0, len(minors))
 	for _, minor := range minors {
@@ -521,10 +522,18 @@ func groupFixedIns(vuln unmarshal.OSVulnerability) map[groupIndex][]unmarshal.OS
 	return grouped
 }
 
-func getPackageType(osName string) pkg.Type {
+// PackageType returns the package type of the packages of the given (normalized) OS name, or an empty type if the OS
+// is not known.
+func PackageType(osName string) pkg.Type {
 	switch osName {
 	case "arch", "archlinux":
 		return pkg.AlpmPkg
+	case "gentoo":
+		return pkg.PortagePkg
+	case "freebsd":
+		return grypePkg.FreeBSDPkg
+	case "openbsd":
+		return grypePkg.OpenBSDPkg
 	case "redhat", "amazonlinux", "oraclelinux", "sles", "mariner", "azurelinux", "photon", "fedora", "rocky", "rockylinux", "almalinux", "centos", "hummingbird":
 		return pkg.RpmPkg
 	case "ubuntu", "debian", "echo":
@@ -539,7 +548,7 @@ func getPackageType(osName string) pkg.Type {
 }
 
 func getPackage(group groupIndex) *db.Package {
-	t := getPackageType(group.osName)
+	t := PackageType(group.osName)
 	return &db.Package{
 		Ecosystem: string(t),
 		Name:      name.Normalize(group.name, t),
@@ -595,7 +604,9 @@ func normalizeOsName(id string) string {
 	return d.String()
 }
 
-func getOperatingSystem(osName, osID, osVersion, channel string) *db.OperatingSystem {
+// OperatingSystem returns the OS of the given (normalized) name, release ID and version (e.g. "20.04" or "edge"), or nil
+// when either the name or version is missing.
+func OperatingSystem(osName, osID, osVersion, channel string) *db.OperatingSystem {
 	if osName == "" || osVersion == "" {
 		return nil
 	}
@@ -625,13 +636,13 @@ func getOperatingSystem(osName, osID, osVersion, channel string) *db.OperatingSy
 	}
 }
 
-// getOperatingSystemWithMinor builds an OS row reusing getOperatingSystem but forces
+// getOperatingSystemWithMinor builds an OS row reusing OperatingSystem but forces
 // the minor version to the supplied value (empty string means a major-only row). This
 // lets the stream-affinity expansion materialize one OS row per minor from a group
 // whose osVersion only carries the major (e.g. RHEL "9"). Codename is recomputed for
 // the overridden minor.
 func getOperatingSystemWithMinor(osName, osID, osVersion, minor, channel string) *db.OperatingSystem {
-	os := getOperatingSystem(osName, osID, osVersion, channel)
+	os := OperatingSystem(osName, osID, osVersion, channel)
 	if os == nil {
 		return nil
 	}
@@ -90,6 +90,16 @@ func TestTransform(t *testing.T) {
 		ReleaseID:    "arch",
 		LabelVersion: "rolling",
 	}
+	gentoo := &db.OperatingSystem{
+		Name:         "gentoo",
+		ReleaseID:    "gentoo",
+		LabelVersion: "rolling",
+	}
+	freebsd := &db.OperatingSystem{
+		Name:         "freebsd",
+		ReleaseID:    "freebsd",
+		LabelVersion: "rolling",
+	}
 	tests := []struct {
 		name     string
 		provider string
@@ -1014,6 +1024,102 @@ func TestTransform(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "testdata/gentoo.json",
+			provider: "gentoo",
+			want: []transformers.RelatedEntries{
+				{
+					VulnerabilityHandle: &db.VulnerabilityHandle{
+						Name:       "GLSA-202402-08",
+						Status:     "active",
+						ProviderID: "gentoo",
+						Provider:   expectedProvider("gentoo"),
+						BlobValue: &db.VulnerabilityBlob{
+							ID:          "GLSA-202402-08",
+							Description: "Multiple vulnerabilities have been discovered in OpenSSL, the worst of which could result in denial of service.",
+							Aliases:     []string{"CVE-2023-5678"},
+							References: []db.Reference{
+								{URL: "https://security.gentoo.org/glsa/202402-08"},
+								{URL: "https://nvd.nist.gov/vuln/detail/CVE-2023-5678"},
+							},
+							Severities: []db.Severity{
+								{
+									Scheme: db.SeveritySchemeCHMLN,
+									Value:  "medium",
+									Rank:   1,
+								},
+							},
+						},
+					},
+					Related: affectedPkgSlice(
+						db.AffectedPackageHandle{
+							OperatingSystem: gentoo,
+							Package:         &db.Package{Ecosystem: "portage", Name: "dev-libs/openssl"},
+							BlobValue: &db.PackageBlob{
+								CVEs: []string{"CVE-2023-5678"},
+								Ranges: []db.Range{
+									{
+										Version: db.Version{Type: "portage", Constraint: "< 3.0.13"},
+										Fix: &db.Fix{
+											Version: "3.0.13",
+											State:   db.FixedStatus,
+										},
+									},
+								},
+							},
+						},
+					),
+				},
+			},
+		},
+		{
+			name:     "testdata/freebsd.json",
+			provider: "freebsd",
+			want: []transformers.RelatedEntries{
+				{
+					VulnerabilityHandle: &db.VulnerabilityHandle{
+						Name:       "VUXML-d1f9a3c6-0b4e-11ef-8a0f-a8a1599412c6",
+						Status:     "active",
+						ProviderID: "freebsd",
+						Provider:   expectedProvider("freebsd"),
+						BlobValue: &db.VulnerabilityBlob{
+							ID:          "VUXML-d1f9a3c6-0b4e-11ef-8a0f-a8a1599412c6",
+							Description: "Apache httpd -- multiple vulnerabilities",
+							Aliases:     []string{"CVE-2024-27316"},
+							References: []db.Reference{
+								{URL: "https://vuxml.freebsd.org/freebsd/d1f9a3c6-0b4e-11ef-8a0f-a8a1599412c6.html"},
+								{URL: "https://nvd.nist.gov/vuln/detail/CVE-2024-27316"},
+							},
+							Severities: []db.Severity{
+								{
+									Scheme: db.SeveritySchemeCHMLN,
+									Value:  "high",
+									Rank:   1,
+								},
+							},
+						},
+					},
+					Related: affectedPkgSlice(
+						db.AffectedPackageHandle{
+							OperatingSystem: freebsd,
+							Package:         &db.Package{Ecosystem: "freebsd-pkg", Name: "apache24"},
+							BlobValue: &db.PackageBlob{
+								CVEs: []string{"CVE-2024-27316"},
+								Ranges: []db.Range{
+									{
+										Version: db.Version{Type: "freebsd", Constraint: "< 2.4.59,1"},
+										Fix: &db.Fix{
+											Version: "2.4.59,1",
+											State:   db.FixedStatus,
+										},
+									},
+								},
+							},
+						},
+					),
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1122,7 +1228,7 @@ func TestGetOperatingSystem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getOperatingSystem(tt.osName, tt.osID, tt.osVersion, tt.channel)
+			result := OperatingSystem(tt.osName, tt.osID, tt.osVersion, tt.channel)
 			require.Equal(t, tt.expected, result)
 		})
 	}
@@ -0,0 +1,231 @@
+package osv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anchore/grype/grype/db/data"
+	"github.com/anchore/grype/grype/db/internal/provider/unmarshal"
+	"github.com/anchore/grype/grype/db/internal/provider/unmarshal/osvmodel"
+	"github.com/anchore/grype/grype/db/provider"
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/build/transformers"
+	"github.com/anchore/grype/grype/db/v6/build/transformers/internal"
+	"github.com/anchore/grype/grype/db/v6/name"
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// maliciousPackagesOrigins is the database_specific field of the OpenSSF malicious-packages records listing the
+// reports a record was built from, which may carry the sha256 digest of the reported artifact.
+const maliciousPackagesOrigins = "malicious-packages-origins"
+
+// maliciousStrategy handles MAL-* records from the OSV malicious-packages datasets (e.g. the OpenSSF
+// malicious-packages repository). These records describe packages that are malicious by design rather than
+// vulnerable, so there is never a fix.
+//
+// Malicious-package-specific decisions:
+//   - Package type comes from the OSV ecosystem (records rarely carry PURLs).
+//   - Explicit `versions` are emitted as exact version ranges when the record has no `ranges`; an
+//     "introduced: 0" range with no upper bound affects every version of the package.
+//   - Besides the affected packages (matched by name and version), every listed version is emitted as a
+//     versioned package URL, and every sha256 digest of `database_specific.malicious-packages-origins` as an
+//     artifact digest: these malicious artifacts are looked up directly at match time.
+//   - Withdrawn records (typically false positives) are rejected and emit no malicious artifacts, since the
+//     artifact lookup does not consider the record status.
+type maliciousStrategy struct{}
+
+func (maliciousStrategy) Matches(id string) bool {
+	return strings.HasPrefix(id, "MAL-")
+}
+
+func (maliciousStrategy) Transform(vuln unmarshal.OSVVulnerability, state provider.State) ([]data.Entry, error) {
+	severities, err := getSeverities(vuln)
+	if err != nil {
+		return nil, fmt.Errorf("unable to obtain severities: %w", err)
+	}
+
+	status := db.VulnerabilityActive
+	var withdrawnDate *time.Time
+	if !vuln.Withdrawn.IsZero() {
+		status = db.VulnerabilityRejected
+		withdrawnDate = &vuln.Withdrawn
+	}
+
+	description := vuln.Details
+	if description == "" {
+		description = vuln.Summary
+	}
+
+	in := []any{
+		db.VulnerabilityHandle{
+			Name:          vuln.ID,
+			ProviderID:    state.Provider,
+			Provider:      provider.Model(state),
+			Status:        status,
+			ModifiedDate:  &vuln.Modified,
+			PublishedDate: &vuln.Published,
+			WithdrawnDate: withdrawnDate,
+			BlobValue: &db.VulnerabilityBlob{
+				ID:          vuln.ID,
+				Description: description,
+				References:  maliciousReferences(vuln),
+				Aliases:     vuln.Aliases,
+				Severities:  severities,
+			},
+		},
+	}
+
+	for _, aph := range maliciousAffectedPackages(vuln) {
+		in = append(in, aph)
+	}
+	if withdrawnDate == nil {
+		for _, a := range maliciousArtifacts(vuln) {
+			in = append(in, a)
+		}
+	}
+	return transformers.NewEntries(in...), nil
+}
+
+func maliciousReferences(vuln unmarshal.OSVVulnerability) []db.Reference {
+	var refs []db.Reference
+	for _, ref := range vuln.References {
+		refs = append(refs, db.Reference{
+			URL:  ref.URL,
+			Tags: []string{string(ref.Type)},
+		})
+	}
+	return refs
+}
+
+func maliciousAffectedPackages(vuln unmarshal.OSVVulnerability) []db.AffectedPackageHandle {
+	var aphs []db.AffectedPackageHandle
+	for _, affected := range vuln.Affected {
+		pkgType := maliciousPackageType(affected.Package)
+		if pkgType == "" {
+			continue
+		}
+
+		var ranges []db.Range
+		for _, r := range affected.Ranges {
+			ranges = append(ranges, getGrypeRangesFromRange(r, defaultRangeType(r.Type))...)
+		}
+		if len(affected.Ranges) == 0 {
+			for _, v := range affected.Versions {
+				ranges = append(ranges, db.Range{
+					Version: db.Version{Type: defaultRangeType(osvmodel.RangeEcosystem), Constraint: fmt.Sprintf("= %s", v)},
+				})
+			}
+		}
+
+		aphs = append(aphs, db.AffectedPackageHandle{
+			Package: &db.Package{
+				Ecosystem: pkgType.String(),
+				Name:      name.Normalize(affected.Package.Name, pkgType),
+			},
+			BlobValue: &db.PackageBlob{
+				CVEs:   vuln.Aliases,
+				Ranges: ranges,
+			},
+		})
+	}
+	sort.Sort(internal.ByAffectedPackage(aphs))
+	return aphs
+}
+
+// maliciousArtifacts returns the versioned package URLs of the listed versions of the affected packages, along with
+// the sha256 digests of the reported artifacts.
+func maliciousArtifacts(vuln unmarshal.OSVVulnerability) []db.MaliciousArtifactHandle {
+	var out []db.MaliciousArtifactHandle
+	seen := make(map[db.MaliciousArtifactHandle]struct{})
+	add := func(a db.MaliciousArtifactHandle) {
+		if _, ok := seen[a]; ok {
+			return
+		}
+		seen[a] = struct{}{}
+		out = append(out, a)
+	}
+
+	for _, affected := range vuln.Affected {
+		for _, v := range affected.Versions {
+			if purl := maliciousPackageURL(affected.Package, v); purl != "" {
+				add(db.MaliciousArtifactHandle{PURL: purl})
+			}
+		}
+	}
+
+	origins, _ := vuln.DatabaseSpecific[maliciousPackagesOrigins].([]any)
+	for _, origin := range origins {
+		fields, _ := origin.(map[string]any)
+		if digest, _ := fields["sha256"].(string); digest != "" {
+			add(db.MaliciousArtifactHandle{Digest: "sha256:" + strings.ToLower(digest)})
+		}
+	}
+	return out
+}
+
+// maliciousPackageURL returns the versioned package URL of the given package, without qualifiers or subpath (empty
+// when the package type is not known).
+func maliciousPackageURL(p osvmodel.Package, version string) string {
+	if p.Purl != "" {
+		purl, err := packageurl.FromString(p.Purl)
+		if err == nil {
+			purl.Version = version
+			purl.Qualifiers = nil
+			purl.Subpath = ""
+			return purl.ToString()
+		}
+	}
+
+	pkgType := maliciousPackageType(p)
+	if pkgType == "" {
+		return ""
+	}
+
+	var namespace string
+	pkgName := p.Name
+	switch pkgType {
+	case pkg.JavaPkg:
+		// maven packages are named "group:artifact"
+		namespace, pkgName, _ = strings.Cut(p.Name, ":")
+	case pkg.NpmPkg, pkg.PhpComposerPkg, pkg.GoModulePkg:
+		if i := strings.LastIndex(p.Name, "/"); i > 0 {
+			namespace, pkgName = p.Name[:i], p.Name[i+1:]
+		}
+	}
+	return packageurl.NewPackageURL(pkgType.PackageURLType(), namespace, pkgName, version, nil, "").ToString()
+}
+
+// maliciousPackageType resolves the grype package type from the PURL of the package, or else its OSV ecosystem.
+func maliciousPackageType(p osvmodel.Package) pkg.Type {
+	if p.Purl != "" {
+		if t := pkg.TypeFromPURL(p.Purl); t != pkg.UnknownPkg {
+			return t
+		}
+	}
+	switch strings.ToLower(p.Ecosystem) {
+	case "npm":
+		return pkg.NpmPkg
+	case "pypi":
+		return pkg.PythonPkg
+	case "crates.io":
+		return pkg.RustPkg
+	case "go":
+		return pkg.GoModulePkg
+	case "maven":
+		return pkg.JavaPkg
+	case "nuget":
+		return pkg.DotnetPkg
+	case "rubygems":
+		return pkg.GemPkg
+	case "packagist":
+		return pkg.PhpComposerPkg
+	case "hex":
+		return pkg.HexPkg
+	case "pub":
+		return pkg.DartPubPkg
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package osv
+
+import (
+	"testing"
+	"time"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/build/transformers"
+)
+
+// TestMaliciousTransform exercises the malicious strategy end-to-end against OpenSSF malicious-packages fixtures.
+//
+//   - MAL-2022-7426: scoped npm package with explicit versions and no ranges; the versions become exact
+//     constraints and versioned package URLs, and the origin sha256 becomes a (lowercased) artifact digest.
+//   - MAL-2024-2139: withdrawn PyPI record with an "introduced: 0" range (every version affected); the record is
+//     rejected and no malicious artifacts are emitted.
+func TestMaliciousTransform(t *testing.T) {
+	tests := []transformCase{
+		{
+			name:        "npm package with explicit versions",
+			fixturePath: "testdata/MAL-2022-7426.json",
+			want: []transformers.RelatedEntries{{
+				VulnerabilityHandle: &db.VulnerabilityHandle{
+					Name:          "MAL-2022-7426",
+					Status:        db.VulnerabilityActive,
+					ProviderID:    "osv",
+					Provider:      expectedProvider(),
+					ModifiedDate:  timeRef(time.Date(2022, time.November, 16, 21, 13, 20, 0, time.UTC)),
+					PublishedDate: timeRef(time.Date(2022, time.November, 16, 11, 39, 36, 0, time.UTC)),
+					BlobValue: &db.VulnerabilityBlob{
+						ID:          "MAL-2022-7426",
+						Description: "\n---\n_-= Per source details. Do not edit below this line.=-_\n\n## Source: ossf-package-analysis\nThe package exfiltrates environment variables to a remote host during installation.\n",
+						References: []db.Reference{{
+							URL:  "https://www.npmjs.com/package/@evil-corp/colors-lite",
+							Tags: []string{"WEB"},
+						}},
+					},
+				},
+				Related: []any{
+					db.AffectedPackageHandle{
+						Package: &db.Package{
+							Name:      "@evil-corp/colors-lite",
+							Ecosystem: "npm",
+						},
+						BlobValue: &db.PackageBlob{
+							Ranges: []db.Range{{
+								Version: db.Version{Type: "ecosystem", Constraint: "= 1.0.0"},
+							}, {
+								Version: db.Version{Type: "ecosystem", Constraint: "= 1.0.1"},
+							}},
+						},
+					},
+					db.MaliciousArtifactHandle{PURL: "pkg:npm/%40evil-corp/colors-lite@1.0.0"},
+					db.MaliciousArtifactHandle{PURL: "pkg:npm/%40evil-corp/colors-lite@1.0.1"},
+					db.MaliciousArtifactHandle{Digest: "sha256:8c5c3dc2b2d4dcb1d4d0d8e5e7c0a6d9b3c1b7e2f4a9c6e1d8f0b2a4c6e8d0f2"},
+				},
+			}},
+		},
+		{
+			name:        "withdrawn PyPI package affecting every version",
+			fixturePath: "testdata/MAL-2024-2139.json",
+			want: []transformers.RelatedEntries{{
+				VulnerabilityHandle: &db.VulnerabilityHandle{
+					Name:          "MAL-2024-2139",
+					Status:        db.VulnerabilityRejected,
+					ProviderID:    "osv",
+					Provider:      expectedProvider(),
+					ModifiedDate:  timeRef(time.Date(2024, time.March, 5, 10, 12, 0, 0, time.UTC)),
+					PublishedDate: timeRef(time.Date(2024, time.March, 1, 19, 44, 2, 0, time.UTC)),
+					WithdrawnDate: timeRef(time.Date(2024, time.March, 5, 10, 12, 0, 0, time.UTC)),
+					BlobValue: &db.VulnerabilityBlob{
+						ID:          "MAL-2024-2139",
+						Description: "Any computer that has this package installed or running should be considered fully compromised.",
+					},
+				},
+				Related: affectedPkgSlice(
+					db.AffectedPackageHandle{
+						Package: &db.Package{
+							Name:      "Request-Helpers",
+							Ecosystem: "python",
+						},
+						BlobValue: &db.PackageBlob{},
+					},
+				),
+			}},
+		},
+	}
+	runTransformCases(t, tests)
+}
@@ -189,6 +189,8 @@ func (w *writer) writeRelatedEntry(vulnHandle *db.VulnerabilityHandle, related a
 			handleCopy := cweHandle
 			return w.store.AddCWE(&handleCopy)
 		})
+	case db.MaliciousArtifactHandle:
+		return w.writeMaliciousArtifact(vulnHandle, row)
 	case db.OperatingSystemEOLHandle:
 		// Add OS EOL to child batch - copy to avoid pointer reuse
 		eolHandle := row
@@ -277,6 +279,20 @@ func (w *writer) writeUnaffectedCPE(vulnHandle *db.VulnerabilityHandle, row db.U
 	})
 }
 
+func (w *writer) writeMaliciousArtifact(vulnHandle *db.VulnerabilityHandle, row db.MaliciousArtifactHandle) error {
+	// Add malicious artifact to child batch - defer VulnerabilityID assignment until flush
+	artifactHandle := row
+	return w.addToChildBatch(func() error {
+		handleCopy := artifactHandle
+		if vulnHandle != nil {
+			handleCopy.VulnerabilityID = vulnHandle.ID
+		} else {
+			log.WithFields("artifact", handleCopy.String()).Warn("malicious artifact entry does not have a vulnerability ID")
+		}
+		return w.store.AddMaliciousArtifacts(&handleCopy)
+	})
+}
+
 // fillInMissingSeverity will add a severity entry to the vulnerability record if it is missing, empty, or "unknown".
 // The upstream NVD record is used to fill in these missing values. Note that the NVD provider is always guaranteed
 // to be processed first before other providers.
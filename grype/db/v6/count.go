@@ -0,0 +1,161 @@
+package v6
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+// CountGroupBy is the attribute that counted records are grouped by.
+type CountGroupBy string
+
+const (
+	// CountTotal does not group records, resulting in a single group with the total number of records.
+	CountTotal CountGroupBy = ""
+	// CountByProvider groups records by the provider of the vulnerability.
+	CountByProvider CountGroupBy = "provider"
+	// CountByEcosystem groups records by the ecosystem of the package (or the target software of the CPE).
+	CountByEcosystem CountGroupBy = "ecosystem"
+	// CountByVulnerability groups records by vulnerability, allowing for further aggregating by attributes only found
+	// within the vulnerability blob (e.g. the severity).
+	CountByVulnerability CountGroupBy = "vulnerability"
+)
+
+// CountGroup is the number of records sharing the same value of the grouped attribute.
+type CountGroup struct {
+	// Value is the value of the grouped attribute (empty when not grouping).
+	Value string
+	// Vulnerability is the vulnerability record (with its blob attached) when grouping by vulnerability.
+	Vulnerability *VulnerabilityHandle
+	Count         int
+}
+
+// countColumns describes how records of a table are counted and how each attribute they can be grouped by is resolved.
+type countColumns struct {
+	table string
+	count string
+	group map[CountGroupBy]string
+}
+
+func packageCountColumns(table string) countColumns {
+	return countColumns{
+		table: table,
+		count: "COUNT(*)",
+		group: map[CountGroupBy]string{
+			CountByProvider:      fmt.Sprintf("COALESCE((SELECT v.provider_id FROM vulnerability_handles v WHERE v.id = %s.vulnerability_id), '')", table),
+			CountByEcosystem:     fmt.Sprintf("COALESCE((SELECT p.ecosystem FROM packages p WHERE p.id = %s.package_id), '')", table),
+			CountByVulnerability: fmt.Sprintf("%s.vulnerability_id", table),
+		},
+	}
+}
+
+func cpeCountColumns(table string) countColumns {
+	return countColumns{
+		table: table,
+		count: "COUNT(*)",
+		group: map[CountGroupBy]string{
+			CountByProvider:      fmt.Sprintf("COALESCE((SELECT v.provider_id FROM vulnerability_handles v WHERE v.id = %s.vulnerability_id), '')", table),
+			CountByEcosystem:     fmt.Sprintf("COALESCE((SELECT c.target_software FROM cpes c WHERE c.id = %s.cpe_id), '')", table),
+			CountByVulnerability: fmt.Sprintf("%s.vulnerability_id", table),
+		},
+	}
+}
+
+// vulnerabilityCountColumns counts distinct vulnerabilities, where grouping by ecosystem expects the packages affected
+// by the vulnerability to be joined (as "p"), so that a vulnerability is counted once for each ecosystem it affects.
+func vulnerabilityCountColumns() countColumns {
+	return countColumns{
+		table: "vulnerability_handles",
+		count: "COUNT(DISTINCT vulnerability_handles.id)",
+		group: map[CountGroupBy]string{
+			CountByProvider:      "vulnerability_handles.provider_id",
+			CountByEcosystem:     "COALESCE(p.ecosystem, '')",
+			CountByVulnerability: "vulnerability_handles.id",
+		},
+	}
+}
+
+// countRecords executes the given query as a SQL aggregate, counting the matching records per group.
+func countRecords(db, query *gorm.DB, bs *blobStore, columns countColumns, groupBy CountGroupBy) ([]CountGroup, error) {
+	expr := "''"
+	if groupBy != CountTotal {
+		var ok bool
+		expr, ok = columns.group[groupBy]
+		if !ok {
+			return nil, fmt.Errorf("unable to count %s records by %q", columns.table, groupBy)
+		}
+	}
+
+	start := time.Now()
+	defer func() {
+		log.WithFields("table", columns.table, "by", groupBy, "duration", time.Since(start)).Trace("counted records")
+	}()
+
+	query = query.Select(fmt.Sprintf("%s AS value, %s AS count", expr, columns.count))
+	if groupBy != CountTotal {
+		query = query.Group(expr).Order("value")
+	}
+
+	var rows []struct {
+		Value string
+		Count int
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("unable to count %s records: %w", columns.table, err)
+	}
+
+	groups := make([]CountGroup, len(rows))
+	for i, r := range rows {
+		groups[i] = CountGroup{Value: r.Value, Count: r.Count}
+	}
+
+	if groupBy == CountByVulnerability {
+		if err := attachCountedVulnerabilities(db, bs, groups); err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+// attachCountedVulnerabilities sets the vulnerability record (with its blob) on each group of a count by vulnerability.
+func attachCountedVulnerabilities(db *gorm.DB, bs *blobStore, groups []CountGroup) error {
+	byID := make(map[ID]*CountGroup, len(groups))
+	var ids []ID
+	for i := range groups {
+		id, err := strconv.ParseInt(groups[i].Value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse vulnerability ID %q: %w", groups[i].Value, err)
+		}
+		byID[ID(id)] = &groups[i]
+		ids = append(ids, ID(id))
+	}
+
+	for len(ids) > 0 {
+		n := min(batchSize, len(ids))
+		var vulns []*VulnerabilityHandle
+		if err := db.Where("id IN ?", ids[:n]).Find(&vulns).Error; err != nil {
+			return fmt.Errorf("unable to fetch counted vulnerability records: %w", err)
+		}
+		ids = ids[n:]
+
+		var blobs []blobable
+		for _, v := range vulns {
+			blobs = append(blobs, v)
+		}
+		if err := bs.attachBlobValue(blobs...); err != nil {
+			return fmt.Errorf("unable to attach vulnerability blobs: %w", err)
+		}
+
+		for _, v := range vulns {
+			if g, ok := byID[v.ID]; ok {
+				g.Vulnerability = v
+			}
+		}
+	}
+	return nil
+}
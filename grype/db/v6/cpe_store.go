@@ -139,10 +139,7 @@ func getCPEHandles[T cpeHandleStore]( // nolint:funlen
 		log.WithFields(fields).Trace("fetched CPE record")
 	}()
 
-	query := s.handleCPE(s.db.Table(tableName), cpe, config.AllowBroadCPEMatching, tableName)
-
-	var err error
-	query, err = s.handleVulnerabilityOptions(query, config.Vulnerabilities, tableName)
+	query, err := s.newCPEsQuery(cpe, config, tableName)
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +189,27 @@ func getCPEHandles[T cpeHandleStore]( // nolint:funlen
 	return models, nil
 }
 
+// countCPEHandles counts the CPE records matching the given criteria as a SQL aggregate (preload options and the
+// record limit do not apply).
+func countCPEHandles(s *cpeStore, cpe *cpe.Attributes, config *GetCPEOptions, tableName string, groupBy CountGroupBy) ([]CountGroup, error) {
+	if config == nil {
+		config = &GetCPEOptions{}
+	}
+
+	query, err := s.newCPEsQuery(cpe, config, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return countRecords(s.db, query, s.blobStore, cpeCountColumns(tableName), groupBy)
+}
+
+func (s *cpeStore) newCPEsQuery(cpe *cpe.Attributes, config *GetCPEOptions, tableName string) (*gorm.DB, error) {
+	query := s.handleCPE(s.db.Table(tableName), cpe, config.AllowBroadCPEMatching, tableName)
+
+	return s.handleVulnerabilityOptions(query, config.Vulnerabilities, tableName)
+}
+
 func (s *cpeStore) handleCPE(query *gorm.DB, c *cpe.Attributes, allowBroad bool, tableName string) *gorm.DB {
 	if c == nil {
 		return query
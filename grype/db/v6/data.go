@@ -22,7 +22,6 @@ func KnownOperatingSystemSpecifierOverrides() []OperatingSystemSpecifierOverride
 		{Alias: "almalinux", ReplacementName: strRef("rhel")}, // non-standard, but common (dockerhub uses "almalinux")
 		{Alias: "scientific", ReplacementName: strRef("rhel")},
 		{Alias: "sl", ReplacementName: strRef("rhel")}, // non-standard, but common (dockerhub uses "sl")
-		{Alias: "gentoo", ReplacementName: strRef("rhel")},
 
 		// Alternaitve distros that should match against the debian vulnerability data
 		{Alias: "raspbian", ReplacementName: strRef("debian")},
@@ -48,6 +47,8 @@ func KnownOperatingSystemSpecifierOverrides() []OperatingSystemSpecifierOverride
 
 		// others
 		{Alias: "archlinux", Rolling: true},
+		{Alias: "gentoo", Rolling: true},
+		{Alias: "freebsd", Rolling: true}, // VuXML entries apply to every supported FreeBSD release
 		{Alias: "minimos", Rolling: true},
 		{Alias: "arch", ReplacementName: strRef("archlinux"), Rolling: true}, // os-release ID=arch, but namespace uses archlinux
 		{Alias: "oracle", ReplacementName: strRef("ol")},                     // non-standard, but common
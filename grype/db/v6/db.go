@@ -24,7 +24,7 @@ const (
 	Revision = 1
 
 	// Addition indicates how many changes have been introduced that are compatible with all historical data
-	Addition = 9
+	Addition = 11
 
 	// v6 model changelog:
 	// 6.0.0: Initial version 🎉
@@ -57,6 +57,14 @@ const (
 	//        runtime qualifier in pkg/qualifier/gosymbols matches captured Go binary symbols
 	//        so stdlib and golang.org/x/* advisories don't FP-match binaries that don't use
 	//        vulnerable symbols)
+	// 6.1.10: Add JavaClassFingerprint table (java_class_fingerprints). The deep java inspection
+	//         identifies shaded or repackaged maven artifacts by the digests of their class files.
+	//         Older clients ignore the table; clients reading a DB built before it existed find
+	//         no fingerprints.
+	// 6.1.11: Add MaliciousArtifactHandle table (malicious_artifact_handles). Known-malicious package records
+	//         list the package URLs and digests of the artifacts they apply to, so that malicious packages are
+	//         found independent of name and version matching. Older clients ignore the table; clients reading a
+	//         DB built before it existed find no malicious artifacts.
 )
 
 const (
@@ -87,6 +95,8 @@ type Reader interface {
 	AffectedCPEStoreReader
 	UnaffectedCPEStoreReader
 	ArchitectureAliasStoreReader
+	JavaClassFingerprintStoreReader
+	MaliciousArtifactStoreReader
 	io.Closer
 	attachBlobValue(...blobable) error
 }
@@ -101,6 +111,8 @@ type Writer interface {
 	UnaffectedPackageStoreWriter
 	AffectedCPEStoreWriter
 	UnaffectedCPEStoreWriter
+	JavaClassFingerprintStoreWriter
+	MaliciousArtifactStoreWriter
 	io.Closer
 }
 
@@ -110,11 +122,20 @@ type Curator interface {
 	Delete() error
 	Update() (bool, error)
 	Import(dbArchivePath string) error
+	// Rollback replaces the current DB with the most recently retained previous DB build
+	Rollback() (*Description, error)
 }
 
 type Config struct {
 	DBDirPath string
 	Debug     bool
+	// Tracer (optional) receives every SQL query issued against the DB
+	Tracer QueryTracer
+	// Pragmas (optional) are SQLite PRAGMA values (by name) to set on the DB connection, overriding any defaults
+	Pragmas map[string]string
+	// PostgresDSN (optional) is the connection string of a shared Postgres DB (published with CopyToPostgres) to read
+	// instead of the DB file within DBDirPath
+	PostgresDSN string
 }
 
 func (c Config) DBFilePath() string {
@@ -145,9 +166,13 @@ func Hydrater() func(string) error {
 // NewLowLevelDB creates a new empty DB for writing or opens an existing one for reading from the given path. This is
 // not recommended for typical interactions with the vulnerability DB, use NewReader and NewWriter instead.
 func NewLowLevelDB(dbFilePath string, empty, writable, debug bool) (*gorm.DB, error) {
-	opts := []gormadapter.Option{
+	return newLowLevelDB(dbFilePath, empty, writable, debug)
+}
+
+func newLowLevelDB(dbFilePath string, empty, writable, debug bool, extraOpts ...gormadapter.Option) (*gorm.DB, error) {
+	opts := append([]gormadapter.Option{
 		gormadapter.WithDebug(debug),
-	}
+	}, extraOpts...)
 
 	if empty && !writable {
 		return nil, fmt.Errorf("cannot open an empty database for reading only")
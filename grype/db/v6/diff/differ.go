@@ -174,6 +174,12 @@ func (d *DBDiffer) diffPackages() ([]PackageDiff, error) {
 		log.Infof("%s found %v records; took %s", v.name, count, time.Since(startTime))
 	}
 
+	withdrawn, err := d.findWithdrawn()
+	if err != nil {
+		return nil, fmt.Errorf("\"withdrawn package vulnerabilities\" failed: %w", err)
+	}
+	flagWithdrawn(diffs, withdrawn)
+
 	log.Infof("package diff completed in %s", time.Since(startTime))
 
 	packages := make([]PackageDiff, 0, len(diffs))
@@ -475,6 +481,22 @@ func vulnerabilityRemoved(diffs map[pkgKey]*PackageDiff, pkgEcosystem, pkgName,
 	})
 }
 
+// flagWithdrawn records the changes of each package against a withdrawn or rejected vulnerability as withdrawn.
+func flagWithdrawn(diffs map[pkgKey]*PackageDiff, withdrawn map[VulnerabilityID]struct{}) {
+	if len(withdrawn) == 0 {
+		return
+	}
+	for _, pd := range diffs {
+		for _, entries := range [][]VulnerabilityID{pd.Vulnerabilities.Added, pd.Vulnerabilities.Removed, pd.Vulnerabilities.Modified} {
+			for _, entry := range entries {
+				if _, ok := withdrawn[entry]; ok {
+					pd.Vulnerabilities.Withdrawn = appendUniqueEntry(pd.Vulnerabilities.Withdrawn, entry)
+				}
+			}
+		}
+	}
+}
+
 func appendUniqueEntry(entries []VulnerabilityID, entry VulnerabilityID) []VulnerabilityID {
 	for _, e := range entries {
 		if e.ID == entry.ID && e.Provider == entry.Provider {
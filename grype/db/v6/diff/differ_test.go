@@ -336,6 +336,7 @@ func Test_packageDiff(t *testing.T) {
 	type added []VulnerabilityID
 	type modified []VulnerabilityID
 	type removed []VulnerabilityID
+	type withdrawn []VulnerabilityID
 	v := func(provider, id string) VulnerabilityID {
 		return VulnerabilityID{Provider: provider, ID: id}
 	}
@@ -423,6 +424,18 @@ func Test_packageDiff(t *testing.T) {
 				},
 			},
 		},
+		// rejecting an NVD CPE vuln no longer matches the CPE, flagged as withdrawn
+		{
+			name:  "nvd-reject-cpe-vuln",
+			oldDB: []string{"cve-2025-24456"},
+			newDB: []string{"cve-2025-24456-rejected"},
+			expected: map[pkg]changes{
+				p("cpe", "hub"): {
+					removed{v("nvd", "CVE-2025-24456")},
+					withdrawn{v("nvd", "CVE-2025-24456")},
+				},
+			},
+		},
 		// add multiple OS vulns to same package (ghostscript via echo=deb + oracle=rpm)
 		{
 			name:  "os-add-multiple-vulns-same-package",
@@ -905,6 +918,7 @@ func Test_packageDiff(t *testing.T) {
 				hasAdded := false
 				hasModified := false
 				hasRemoved := false
+				hasWithdrawn := false
 				for _, expected := range expected {
 					switch expected := expected.(type) {
 					case added:
@@ -916,6 +930,9 @@ func Test_packageDiff(t *testing.T) {
 					case removed:
 						hasRemoved = true
 						requireVulnIDs(t, "removed", vulnChanges.Removed, expected...)
+					case withdrawn:
+						hasWithdrawn = true
+						requireVulnIDs(t, "withdrawn", vulnChanges.Withdrawn, expected...)
 					}
 				}
 				if !hasAdded {
@@ -927,6 +944,9 @@ func Test_packageDiff(t *testing.T) {
 				if !hasRemoved {
 					require.Emptyf(t, vulnChanges.Removed, "expected no removed vulns for %+v; got: %+v", expectedPkg, vulnChanges.Removed)
 				}
+				if !hasWithdrawn {
+					require.Emptyf(t, vulnChanges.Withdrawn, "expected no withdrawn vulns for %+v; got: %+v", expectedPkg, vulnChanges.Withdrawn)
+				}
 			}
 
 			// make sure we don't have unexpected changes
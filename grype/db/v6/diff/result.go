@@ -62,6 +62,9 @@ type VulnerabilityChanges struct {
 	Removed []VulnerabilityID `json:"removed,omitempty"`
 	// Modified results are results that have been modified which will match the same package
 	Modified []VulnerabilityID `json:"modified,omitempty"`
+	// Withdrawn results are results whose vulnerability record was withdrawn or rejected since the old database, which
+	// will no longer match a specific package (also reported as removed)
+	Withdrawn []VulnerabilityID `json:"withdrawn,omitempty"`
 }
 
 // VulnerabilityID is a minimal vulnerability reference in diff output.
@@ -80,6 +83,9 @@ type VulnerabilityDiff struct {
 	Modified []VulnerabilityID `json:"modified,omitempty"`
 	// Removed is the list of vulnerabilities removed from a provider between databases
 	Removed []VulnerabilityID `json:"removed,omitempty"`
+	// Withdrawn is the list of vulnerabilities withdrawn or rejected by a provider between databases (also reported
+	// as modified)
+	Withdrawn []VulnerabilityID `json:"withdrawn,omitempty"`
 }
 
 // newDatabaseInfo constructs a DatabaseInfo from a DB directory path by reading metadata.
@@ -55,6 +55,7 @@ func (d *DBDiffer) diffVulns() (*VulnerabilityDiff, error) {
 		{"added vulnerabilities", d.findVulnsAdded},
 		{"removed vulnerabilities", d.findVulnsRemoved},
 		{"modified vulnerabilities", d.findVulnsModified},
+		{"withdrawn vulnerabilities", d.findVulnsWithdrawn},
 	}
 
 	for _, v := range changeTypes {
@@ -68,7 +69,7 @@ func (d *DBDiffer) diffVulns() (*VulnerabilityDiff, error) {
 
 	log.Infof("vulnerability diff completed in %s", time.Since(startTime))
 
-	for _, vulns := range []*[]VulnerabilityID{&diffs.Added, &diffs.Modified, &diffs.Removed} {
+	for _, vulns := range []*[]VulnerabilityID{&diffs.Added, &diffs.Modified, &diffs.Removed, &diffs.Withdrawn} {
 		slices.SortFunc(*vulns, func(a, b VulnerabilityID) int {
 			if a.Provider != b.Provider {
 				return strings.Compare(a.Provider, b.Provider)
@@ -129,3 +130,35 @@ func (d *DBDiffer) findVulnsModified(diff *VulnerabilityDiff) (int, error) {
 	diff.Modified = append(diff.Modified, maps.Keys(out)...)
 	return len(out), nil
 }
+
+// findVulnsWithdrawn gets vulnerabilities withdrawn or rejected in the new database
+func (d *DBDiffer) findVulnsWithdrawn(diff *VulnerabilityDiff) (int, error) {
+	withdrawn, err := d.findWithdrawn()
+	if err != nil {
+		return 0, err
+	}
+	diff.Withdrawn = append(diff.Withdrawn, maps.Keys(withdrawn)...)
+	return len(withdrawn), nil
+}
+
+// findWithdrawn gets the vulnerabilities of both databases that are withdrawn or rejected in the new database but not
+// in the old database
+func (d *DBDiffer) findWithdrawn() (map[VulnerabilityID]struct{}, error) {
+	out := map[VulnerabilityID]struct{}{}
+	var rows []vulnRow
+	err := d.db.Raw(`
+		SELECT DISTINCT n.provider_id, n.name FROM new_db.vulnerability_handles n
+		JOIN main.vulnerability_handles o
+			ON o.provider_id = n.provider_id
+			AND o.name = n.name
+		WHERE LOWER(COALESCE(n.status, '')) IN ('withdrawn', 'rejected')
+		AND LOWER(COALESCE(o.status, '')) NOT IN ('withdrawn', 'rejected')
+	`).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		out[VulnerabilityID{r.ProviderID, r.VulnName}] = struct{}{}
+	}
+	return out, nil
+}
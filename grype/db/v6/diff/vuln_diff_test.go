@@ -19,6 +19,7 @@ func Test_vulnDiff(t *testing.T) {
 	type added []VulnerabilityID
 	type modified []VulnerabilityID
 	type removed []VulnerabilityID
+	type withdrawn []VulnerabilityID
 
 	v := func(provider, id string) VulnerabilityID {
 		return VulnerabilityID{Provider: provider, ID: id}
@@ -131,6 +132,16 @@ func Test_vulnDiff(t *testing.T) {
 				removed{v("nvd", "CVE-2010-1724")},
 			},
 		},
+		{
+			name:    "vuln-rejected",
+			oldDB:   []string{"cve-2025-21916", "cve-2025-24456"},
+			newDB:   []string{"cve-2025-21916", "cve-2025-24456-rejected"},
+			include: Includes{Vulns: true},
+			expected: changes{
+				modified{v("nvd", "CVE-2025-24456")},
+				withdrawn{v("nvd", "CVE-2025-24456")},
+			},
+		},
 
 		// ── KEV-only diffs (CVEs with only KEV entries, no NVD) ────────────
 		{
@@ -374,6 +385,7 @@ func Test_vulnDiff(t *testing.T) {
 					assert.Empty(t, vulnDiff.Added, "expected no added vulns")
 					assert.Empty(t, vulnDiff.Modified, "expected no modified vulns")
 					assert.Empty(t, vulnDiff.Removed, "expected no removed vulns")
+					assert.Empty(t, vulnDiff.Withdrawn, "expected no withdrawn vulns")
 				}
 				return
 			}
@@ -383,6 +395,7 @@ func Test_vulnDiff(t *testing.T) {
 			hasAdded := false
 			hasModified := false
 			hasRemoved := false
+			hasWithdrawn := false
 			for _, exp := range tt.expected {
 				switch exp := exp.(type) {
 				case added:
@@ -394,6 +407,9 @@ func Test_vulnDiff(t *testing.T) {
 				case removed:
 					hasRemoved = true
 					requireVulnIDs(t, "removed", vulnDiff.Removed, exp...)
+				case withdrawn:
+					hasWithdrawn = true
+					requireVulnIDs(t, "withdrawn", vulnDiff.Withdrawn, exp...)
 				}
 			}
 			if !hasAdded {
@@ -405,6 +421,9 @@ func Test_vulnDiff(t *testing.T) {
 			if !hasRemoved {
 				assert.Empty(t, vulnDiff.Removed, "expected no removed vulns")
 			}
+			if !hasWithdrawn {
+				assert.Empty(t, vulnDiff.Withdrawn, "expected no withdrawn vulns")
+			}
 		})
 	}
 }
@@ -1,14 +1,12 @@
 package distribution
 
 import (
-	"crypto/tls"
-	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
@@ -17,9 +15,13 @@ import (
 
 	"github.com/anchore/clio"
 	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/bandwidth"
 	"github.com/anchore/grype/internal/bus"
 	"github.com/anchore/grype/internal/file"
 	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/grype/internal/proxy"
+	"github.com/anchore/grype/internal/retry"
+	"github.com/anchore/grype/internal/tlsconfig"
 )
 
 type Config struct {
@@ -27,7 +29,20 @@ type Config struct {
 
 	// check/fetch parameters
 	LatestURL string
-	CACert    string
+	// FallbackURLs are mirrors of LatestURL, tried in order when the listing or archive cannot be downloaded
+	FallbackURLs []string
+	// MirrorHealthFile (optional) persists mirror failures across runs, so that recently failed mirrors are tried last
+	MirrorHealthFile string
+	// TLS configures the CAs trusted, certificate verification and client certificates when downloading (per host)
+	TLS tlsconfig.Config
+	// CACert is trusted when TLS.CACert is not set (deprecated: use TLS.CACert)
+	CACert string
+	// Proxy selects the proxy for downloads (the proxy configured by the environment when empty)
+	Proxy proxy.Rule
+	// Retry configures how requests failing with transient network errors are retried
+	Retry retry.Config
+	// Bandwidth limits the rate of downloads (unlimited when nil)
+	Bandwidth *bandwidth.Limiter
 
 	// validations
 	RequireUpdateCheck bool
@@ -41,6 +56,7 @@ type Client interface {
 	Latest() (*LatestDocument, error)
 	IsUpdateAvailable(current *v6.Description) (*Archive, error)
 	ResolveArchiveURL(archive Archive) (string, error)
+	ArchiveSize(archive Archive) (int64, error)
 	Download(url, dest string, downloadProgress *progress.Manual) (string, error)
 }
 
@@ -48,7 +64,9 @@ type client struct {
 	fs                afero.Fs
 	dbDownloader      file.Getter
 	listingDownloader file.Getter
+	dbHTTPClient      *http.Client
 	config            Config
+	mirrors           *mirrors
 }
 
 func DefaultConfig() Config {
@@ -57,17 +75,18 @@ func DefaultConfig() Config {
 		RequireUpdateCheck: false,
 		CheckTimeout:       30 * time.Second,
 		UpdateTimeout:      300 * time.Second,
+		Retry:              retry.DefaultConfig(),
 	}
 }
 
 func NewClient(cfg Config) (Client, error) {
 	fs := afero.NewOsFs()
-	latestClient, err := defaultHTTPClient(fs, cfg.CACert, withClientTimeout(cfg.CheckTimeout), withUserAgent(cfg.ID))
+	latestClient, err := defaultHTTPClient(fs, cfg, withClientTimeout(cfg.CheckTimeout), withUserAgent(cfg.ID))
 	if err != nil {
 		return client{}, err
 	}
 
-	dbClient, err := defaultHTTPClient(fs, cfg.CACert, withClientTimeout(cfg.UpdateTimeout), withUserAgent(cfg.ID))
+	dbClient, err := defaultHTTPClient(fs, cfg, withClientTimeout(cfg.UpdateTimeout), withUserAgent(cfg.ID))
 	if err != nil {
 		return client{}, err
 	}
@@ -76,7 +95,9 @@ func NewClient(cfg Config) (Client, error) {
 		fs:                fs,
 		listingDownloader: file.NewGetter(cfg.ID, latestClient),
 		dbDownloader:      file.NewGetter(cfg.ID, dbClient),
+		dbHTTPClient:      dbClient,
 		config:            cfg,
+		mirrors:           newMirrors(fs, cfg),
 	}, nil
 }
 
@@ -147,6 +168,40 @@ func (c client) ResolveArchiveURL(archive Archive) (string, error) {
 	return u.String(), nil
 }
 
+// ArchiveSize returns the size of the given archive in bytes: the size within the listing, otherwise the size reported
+// by the server for the archive URL (0 when the server does not report it).
+func (c client) ArchiveSize(archive Archive) (int64, error) {
+	if archive.Size > 0 {
+		return archive.Size, nil
+	}
+
+	archiveURL, err := c.ResolveArchiveURL(archive)
+	if err != nil {
+		return 0, err
+	}
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse db URL %q: %w", archiveURL, err)
+	}
+	// the checksum is only meaningful to the downloader
+	u.RawQuery = ""
+
+	httpClient := c.dbHTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Head(u.String())
+	if err != nil {
+		return 0, fmt.Errorf("unable to determine db archive size: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unable to determine db archive size: unexpected status %q", resp.Status)
+	}
+	return max(resp.ContentLength, 0), nil
+}
+
 func (c client) Download(archiveURL, dest string, downloadProgress *progress.Manual) (string, error) {
 	defer downloadProgress.SetCompleted()
 
@@ -154,6 +209,33 @@ func (c client) Download(archiveURL, dest string, downloadProgress *progress.Man
 		return "", fmt.Errorf("unable to create db download root dir: %w", err)
 	}
 
+	urls := []string{archiveURL}
+	if c.mirrors != nil {
+		urls = c.mirrors.archiveURLs(archiveURL)
+	}
+
+	var errs error
+	for _, u := range urls {
+		tempDir, err := c.downloadFrom(u, dest, downloadProgress)
+		if err == nil {
+			if c.mirrors != nil {
+				if l := c.mirrors.listingFor(u); l != "" {
+					c.mirrors.succeeded(l, false)
+				}
+			}
+			return tempDir, nil
+		}
+		errs = errors.Join(errs, err)
+		if c.mirrors != nil {
+			if l := c.mirrors.listingFor(u); l != "" {
+				c.mirrors.failed(l, err)
+			}
+		}
+	}
+	return "", fmt.Errorf("unable to download db: %w", errs)
+}
+
+func (c client) downloadFrom(archiveURL, dest string, downloadProgress *progress.Manual) (string, error) {
 	// note: as much as I'd like to use the afero FS abstraction here, the go-getter library does not support it
 	tempDir, err := os.MkdirTemp(dest, "grype-db-download")
 	if err != nil {
@@ -164,14 +246,37 @@ func (c client) Download(archiveURL, dest string, downloadProgress *progress.Man
 	err = c.dbDownloader.GetToDir(tempDir, archiveURL, downloadProgress)
 	if err != nil {
 		removeAllOrLog(afero.NewOsFs(), tempDir)
-		return "", fmt.Errorf("unable to download db: %w", err)
+		return "", err
 	}
 
 	return tempDir, nil
 }
 
-// Latest loads a LatestDocument from the configured URL.
+// Latest loads a LatestDocument from the configured URL, falling back to the configured mirrors in order.
 func (c client) Latest() (*LatestDocument, error) {
+	listings := []string{c.latestURL()}
+	if c.mirrors != nil {
+		listings = c.mirrors.ordered()
+	}
+
+	var errs error
+	for _, listing := range listings {
+		doc, err := c.latestFrom(listing)
+		if err == nil {
+			if c.mirrors != nil {
+				c.mirrors.succeeded(listing, true)
+			}
+			return doc, nil
+		}
+		errs = errors.Join(errs, err)
+		if c.mirrors != nil {
+			c.mirrors.failed(listing, err)
+		}
+	}
+	return nil, errs
+}
+
+func (c client) latestFrom(listing string) (*LatestDocument, error) {
 	tempFile, err := afero.TempFile(c.fs, "", "grype-db-listing")
 	if err != nil {
 		return nil, fmt.Errorf("unable to create listing temp file: %w", err)
@@ -184,7 +289,7 @@ func (c client) Latest() (*LatestDocument, error) {
 		}
 	}()
 
-	err = c.listingDownloader.GetFile(tempFile.Name(), c.latestURL())
+	err = c.listingDownloader.GetFile(tempFile.Name(), listing)
 	if err != nil {
 		return nil, fmt.Errorf("unable to download listing: %w", err)
 	}
@@ -192,14 +297,15 @@ func (c client) Latest() (*LatestDocument, error) {
 	return NewLatestFromFile(c.fs, tempFile.Name())
 }
 
+// latestURL returns the listing URL of the mirror that served the latest listing (the update URL otherwise), which
+// archive paths are relative to.
 func (c client) latestURL() string {
-	u := c.config.LatestURL
-	// allow path to be specified directly to a json file, or the path without version information
-	if !strings.HasSuffix(u, ".json") {
-		u = strings.TrimRight(u, "/")
-		u = fmt.Sprintf("%s/v%d/%s", u, v6.ModelVersion, LatestFileName)
+	if c.mirrors != nil {
+		if u := c.mirrors.activeListing(); u != "" {
+			return u
+		}
 	}
-	return u
+	return listingURL(c.config.LatestURL)
 }
 
 func withClientTimeout(timeout time.Duration) func(*http.Client) {
@@ -214,23 +320,25 @@ func withUserAgent(id clio.Identification) func(*http.Client) {
 	}
 }
 
-func defaultHTTPClient(fs afero.Fs, caCertPath string, postProcessor ...func(*http.Client)) (*http.Client, error) {
+// tlsConfig returns the TLS configuration of the client, falling back to the deprecated CACert option.
+func (cfg Config) tlsConfig() tlsconfig.Config {
+	tlsCfg := cfg.TLS
+	if tlsCfg.CACert == "" {
+		tlsCfg.CACert = cfg.CACert
+	}
+	return tlsCfg
+}
+
+func defaultHTTPClient(fs afero.Fs, cfg Config, postProcessor ...func(*http.Client)) (*http.Client, error) {
 	httpClient := cleanhttp.DefaultClient()
 	httpClient.Timeout = 30 * time.Second
-	if caCertPath != "" {
-		rootCAs := x509.NewCertPool()
 
-		pemBytes, err := afero.ReadFile(fs, caCertPath)
-		if err != nil {
-			return nil, fmt.Errorf("unable to configure root CAs for curator: %w", err)
-		}
-		rootCAs.AppendCertsFromPEM(pemBytes)
-
-		httpClient.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
-			MinVersion: tls.VersionTLS12,
-			RootCAs:    rootCAs,
-		}
+	base := cfg.Bandwidth.Transport(cfg.Proxy.Transport(httpClient.Transport.(*http.Transport)))
+	transport, err := cfg.tlsConfig().Transport(fs, base)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure TLS for the database client: %w", err)
 	}
+	httpClient.Transport = retry.Transport(transport, cfg.Retry)
 
 	for _, pp := range postProcessor {
 		pp(httpClient)
@@ -3,6 +3,8 @@ package distribution
 import (
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"testing"
 	"time"
@@ -14,7 +16,9 @@ import (
 	"github.com/wagoodman/go-progress"
 
 	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/proxy"
 	"github.com/anchore/grype/internal/schemaver"
+	"github.com/anchore/grype/internal/tlsconfig"
 )
 
 type mockGetter struct {
@@ -185,6 +189,43 @@ func TestClient_Download(t *testing.T) {
 	})
 }
 
+func TestClient_ArchiveSize(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.Method+" "+r.URL.String())
+		if r.URL.Path != "/db/archive.tar.zst" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", "1234")
+	}))
+	defer server.Close()
+
+	c, err := NewClient(Config{LatestURL: server.URL + "/db/latest.json"})
+	require.NoError(t, err)
+
+	t.Run("size within the listing", func(t *testing.T) {
+		requested = nil
+		size, err := c.ArchiveSize(Archive{Path: "archive.tar.zst", Size: 42})
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), size)
+		assert.Empty(t, requested)
+	})
+
+	t.Run("size reported by the server", func(t *testing.T) {
+		requested = nil
+		size, err := c.ArchiveSize(Archive{Path: "archive.tar.zst", Checksum: "sha256:abc"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1234), size)
+		assert.Equal(t, []string{"HEAD /db/archive.tar.zst"}, requested)
+	})
+
+	t.Run("archive not found", func(t *testing.T) {
+		_, err := c.ArchiveSize(Archive{Path: "missing.tar.zst"})
+		assert.ErrorContains(t, err, "404")
+	})
+}
+
 func TestClient_IsUpdateAvailable(t *testing.T) {
 	current := &db.Description{
 		SchemaVersion: schemaver.New(1, 0, 0),
@@ -416,3 +457,37 @@ func Test_latestURL(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_tlsConfig(t *testing.T) {
+	t.Run("deprecated CA certificate is used as a fallback", func(t *testing.T) {
+		cfg := Config{CACert: "db-ca.crt", TLS: tlsconfig.Config{Settings: tlsconfig.Settings{InsecureSkipVerify: true}}}
+		assert.Equal(t, tlsconfig.Config{Settings: tlsconfig.Settings{CACert: "db-ca.crt", InsecureSkipVerify: true}}, cfg.tlsConfig())
+	})
+
+	t.Run("TLS CA certificate takes precedence", func(t *testing.T) {
+		cfg := Config{CACert: "db-ca.crt", TLS: tlsconfig.Config{Settings: tlsconfig.Settings{CACert: "ca.crt"}}}
+		assert.Equal(t, "ca.crt", cfg.tlsConfig().CACert)
+	})
+}
+
+func TestNewClient_InvalidTLS(t *testing.T) {
+	_, err := NewClient(Config{TLS: tlsconfig.Config{Settings: tlsconfig.Settings{CACert: "/does/not/exist.pem"}}})
+	require.ErrorContains(t, err, "unable to configure TLS for the database client")
+}
+
+func TestDefaultHTTPClient_Proxy(t *testing.T) {
+	var proxiedHost string
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.Host
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxyServer.Close()
+
+	c, err := defaultHTTPClient(afero.NewMemMapFs(), Config{Proxy: proxy.Rule{URL: proxyServer.URL}})
+	require.NoError(t, err)
+
+	resp, err := c.Get("http://db.example.invalid/databases")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, "db.example.invalid", proxiedHost)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"sort"
 	"time"
@@ -36,6 +37,27 @@ type Archive struct {
 
 	// Checksum is the self describing digest of the database archive referenced in path
 	Checksum string `json:"checksum"`
+
+	// Size is the size of the database archive in bytes (absent from listings of older builds)
+	Size int64 `json:"size,omitempty"`
+
+	// Providers describes the data of each provider within the database (absent from listings of older builds)
+	Providers []ArchiveProvider `json:"providers,omitempty"`
+}
+
+// ArchiveProvider describes the data of a provider within a database archive.
+type ArchiveProvider struct {
+	// Name is the name of the provider (e.g. "nvd")
+	Name string `json:"name"`
+
+	// Version is the version of the provider
+	Version string `json:"version,omitempty"`
+
+	// DateCaptured is when the provider data was captured
+	DateCaptured *time.Time `json:"dateCaptured,omitempty"`
+
+	// InputDigest is the digest of the provider input data
+	InputDigest string `json:"inputDigest,omitempty"`
 }
 
 func NewLatestDocument(entries ...Archive) *LatestDocument {
@@ -67,13 +89,18 @@ func NewLatestFromReader(reader io.Reader) (*LatestDocument, error) {
 		return nil, fmt.Errorf("unable to parse DB latest.json: %w", err)
 	}
 
-	if l == (LatestDocument{}) {
+	if l.isEmpty() {
 		return nil, nil
 	}
 
 	return &l, nil
 }
 
+// isEmpty indicates whether none of the fields of the document were given.
+func (l LatestDocument) isEmpty() bool {
+	return l.Status == "" && l.Description == (db.Description{}) && l.Path == "" && l.Checksum == "" && l.Size == 0 && len(l.Providers) == 0
+}
+
 func NewLatestFromFile(fs afero.Fs, path string) (*LatestDocument, error) {
 	fh, err := fs.Open(path)
 	if err != nil {
@@ -89,6 +116,11 @@ func NewArchive(path string, t time.Time, model, revision, addition int) (*Archi
 		return nil, fmt.Errorf("failed to calculate archive checksum: %w", err)
 	}
 
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat archive: %w", err)
+	}
+
 	return &Archive{
 		Description: db.Description{
 			SchemaVersion: schemaver.New(model, revision, addition),
@@ -97,6 +129,7 @@ func NewArchive(path string, t time.Time, model, revision, addition int) (*Archi
 		// this is not the path on disk, this is the path relative to the latest.json file when hosted
 		Path:     filepath.Base(path),
 		Checksum: checksum,
+		Size:     info.Size(),
 	}, nil
 }
 
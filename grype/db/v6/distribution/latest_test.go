@@ -260,6 +260,7 @@ func TestNewArchive(t *testing.T) {
 				},
 				Path:     "archive.tar.gz",
 				Checksum: "sha256:2a11c11d2c3803697c458a1f5f03c2b73235c101f93c88193cc8810003c40d87",
+				Size:     20,
 			},
 		},
 	}
@@ -0,0 +1,228 @@
+package distribution
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	v6 "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/log"
+)
+
+// MirrorHealthFileName is the conventional name of the file mirror failures are persisted to (see Config.MirrorHealthFile).
+const MirrorHealthFileName = "mirror-health.json"
+
+const (
+	// mirrorCooldown is how long a mirror is tried last after failing, doubled for every consecutive failure
+	mirrorCooldown = 5 * time.Minute
+
+	// maxMirrorCooldown bounds how long a failing mirror is tried last
+	maxMirrorCooldown = time.Hour
+)
+
+// mirrorFailure records the consecutive failures of a mirror.
+type mirrorFailure struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+// mirrors selects between the distribution mirrors configured (the update URL followed by the fallback URLs). Mirrors
+// are tried in the configured order, except that mirrors that failed recently are tried last. Failures are optionally
+// persisted so that a mirror failing in one run is not tried first by the next.
+type mirrors struct {
+	fs         afero.Fs
+	healthFile string
+	listings   []string
+
+	lock     sync.Mutex
+	active   string
+	failures map[string]mirrorFailure
+}
+
+func newMirrors(fs afero.Fs, cfg Config) *mirrors {
+	m := &mirrors{
+		fs:       fs,
+		failures: make(map[string]mirrorFailure),
+	}
+
+	seen := make(map[string]bool)
+	for _, u := range append([]string{cfg.LatestURL}, cfg.FallbackURLs...) {
+		if u == "" {
+			continue
+		}
+		listing := listingURL(u)
+		if seen[listing] {
+			continue
+		}
+		seen[listing] = true
+		m.listings = append(m.listings, listing)
+	}
+
+	// health only matters when there is a choice of mirrors
+	if len(m.listings) > 1 {
+		m.healthFile = cfg.MirrorHealthFile
+		m.load()
+	}
+	return m
+}
+
+// listingURL returns the URL of the listing file for the given update URL, which may point directly to a json file
+// or to the path without version information.
+func listingURL(u string) string {
+	if !strings.HasSuffix(u, ".json") {
+		u = strings.TrimRight(u, "/")
+		u = fmt.Sprintf("%s/v%d/%s", u, v6.ModelVersion, LatestFileName)
+	}
+	return u
+}
+
+// listingBase returns the URL archive paths of the given listing are relative to (with a trailing slash).
+func listingBase(listing string) string {
+	return listing[:strings.LastIndex(listing, "/")+1]
+}
+
+// ordered returns the listing URLs to try, healthy mirrors first (each group in the configured order).
+func (m *mirrors) ordered() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var healthy, unhealthy []string
+	now := time.Now()
+	for _, l := range m.listings {
+		if m.coolingDown(l, now) {
+			unhealthy = append(unhealthy, l)
+			continue
+		}
+		healthy = append(healthy, l)
+	}
+	if len(unhealthy) > 0 {
+		log.WithFields("mirrors", unhealthy).Debug("trying recently failed database mirrors last")
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (m *mirrors) coolingDown(listing string, now time.Time) bool {
+	f, ok := m.failures[listing]
+	if !ok || f.Count == 0 {
+		return false
+	}
+	cooldown := mirrorCooldown
+	for i := 1; i < f.Count && cooldown < maxMirrorCooldown; i++ {
+		cooldown *= 2
+	}
+	return now.Sub(f.Last) < min(cooldown, maxMirrorCooldown)
+}
+
+// activeListing returns the listing URL of the mirror that last served the listing (the first mirror otherwise).
+func (m *mirrors) activeListing() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.active != "" {
+		return m.active
+	}
+	if len(m.listings) > 0 {
+		return m.listings[0]
+	}
+	return ""
+}
+
+func (m *mirrors) succeeded(listing string, active bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if active {
+		m.active = listing
+	}
+	if _, ok := m.failures[listing]; !ok {
+		return
+	}
+	delete(m.failures, listing)
+	m.save()
+}
+
+func (m *mirrors) failed(listing string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(m.listings) > 1 {
+		log.WithFields("mirror", listing, "error", err).Warn("database mirror failed")
+	}
+	f := m.failures[listing]
+	f.Count++
+	f.Last = time.Now()
+	m.failures[listing] = f
+	m.save()
+}
+
+// archiveURLs returns the given archive URL as served by every mirror, starting with the mirror it was resolved
+// against. Archive URLs are relative to the listing file, so the same archive is expected at the same relative path on
+// every mirror. URLs not served by any configured mirror (e.g. a URL given to 'db import') have no alternatives.
+func (m *mirrors) archiveURLs(archiveURL string) []string {
+	var source, rest string
+	for _, l := range m.listings {
+		base := listingBase(l)
+		if strings.HasPrefix(archiveURL, base) {
+			source, rest = l, strings.TrimPrefix(archiveURL, base)
+			break
+		}
+	}
+	if source == "" {
+		return []string{archiveURL}
+	}
+
+	urls := []string{archiveURL}
+	for _, l := range m.ordered() {
+		if l == source {
+			continue
+		}
+		urls = append(urls, listingBase(l)+rest)
+	}
+	return urls
+}
+
+// listingFor returns the listing URL of the mirror serving the given archive URL.
+func (m *mirrors) listingFor(archiveURL string) string {
+	for _, l := range m.listings {
+		if strings.HasPrefix(archiveURL, listingBase(l)) {
+			return l
+		}
+	}
+	return ""
+}
+
+func (m *mirrors) load() {
+	if m.healthFile == "" {
+		return
+	}
+	contents, err := afero.ReadFile(m.fs, m.healthFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithFields("path", m.healthFile, "error", err).Debug("unable to read database mirror health")
+		}
+		return
+	}
+	if err := json.Unmarshal(contents, &m.failures); err != nil {
+		log.WithFields("path", m.healthFile, "error", err).Debug("unable to parse database mirror health")
+		m.failures = make(map[string]mirrorFailure)
+	}
+}
+
+// save persists the mirror failures (the lock must be held).
+func (m *mirrors) save() {
+	if m.healthFile == "" {
+		return
+	}
+	contents, err := json.MarshalIndent(m.failures, "", " ")
+	if err == nil {
+		if err = m.fs.MkdirAll(filepath.Dir(m.healthFile), 0o700); err == nil {
+			err = afero.WriteFile(m.fs, m.healthFile, contents, 0o600)
+		}
+	}
+	if err != nil {
+		log.WithFields("path", m.healthFile, "error", err).Debug("unable to write database mirror health")
+	}
+}
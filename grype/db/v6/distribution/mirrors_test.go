@@ -0,0 +1,155 @@
+package distribution
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/schemaver"
+)
+
+const (
+	primaryListing  = "https://cdn.example.com/databases/v6/latest.json"
+	fallbackListing = "https://mirror.example.com/grype/v6/latest.json"
+	mirrorHealth    = "/cache/grype/db/mirror-health.json"
+)
+
+func newMirroredClient(t *testing.T, fs afero.Fs) (client, *mockGetter, *mockGetter) {
+	t.Helper()
+	c, err := NewClient(Config{
+		LatestURL:        "https://cdn.example.com/databases",
+		FallbackURLs:     []string{"https://mirror.example.com/grype/", "https://cdn.example.com/databases/"},
+		MirrorHealthFile: mirrorHealth,
+	})
+	require.NoError(t, err)
+
+	cl := c.(client)
+	cl.fs = fs
+	cl.mirrors.fs = fs
+	cl.mirrors.load()
+
+	listings := new(mockGetter)
+	archives := new(mockGetter)
+	cl.listingDownloader = listings
+	cl.dbDownloader = archives
+	return cl, listings, archives
+}
+
+func serveListing(t *testing.T, fs afero.Fs, listings *mockGetter, listing string) {
+	doc := LatestDocument{
+		Status: LifecycleStatus,
+		Archive: Archive{
+			Description: db.Description{
+				SchemaVersion: schemaver.New(6, 0, 0),
+				Built:         db.Time{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			Path:     "vulnerability-db_v6.0.0.tar.zst",
+			Checksum: "sha256:abc",
+		},
+	}
+	contents, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	listings.On("GetFile", mock.Anything, listing, mock.Anything).Run(func(args mock.Arguments) {
+		require.NoError(t, afero.WriteFile(fs, args.String(0), contents, 0o644))
+	}).Return(nil)
+}
+
+func TestClient_Latest_FallsBackToMirrors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	c, listings, _ := newMirroredClient(t, fs)
+	// duplicate mirrors are only tried once
+	assert.Equal(t, []string{primaryListing, fallbackListing}, c.mirrors.listings)
+
+	listings.On("GetFile", mock.Anything, primaryListing, mock.Anything).Return(errors.New("503 service unavailable")).Once()
+	serveListing(t, fs, listings, fallbackListing)
+
+	doc, err := c.Latest()
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+
+	// archives are resolved against the mirror that served the listing
+	u, err := c.ResolveArchiveURL(doc.Archive)
+	require.NoError(t, err)
+	assert.Equal(t, "https://mirror.example.com/grype/v6/vulnerability-db_v6.0.0.tar.zst?checksum=sha256%3Aabc", u)
+
+	// the failure is persisted, so the next run tries the healthy mirror first
+	next, nextListings, _ := newMirroredClient(t, fs)
+	assert.Equal(t, []string{fallbackListing, primaryListing}, next.mirrors.ordered())
+	serveListing(t, fs, nextListings, fallbackListing)
+	_, err = next.Latest()
+	require.NoError(t, err)
+	nextListings.AssertNotCalled(t, "GetFile", mock.Anything, primaryListing, mock.Anything)
+
+	listings.AssertExpectations(t)
+}
+
+func TestClient_Latest_AllMirrorsFail(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	c, listings, _ := newMirroredClient(t, fs)
+
+	listings.On("GetFile", mock.Anything, primaryListing, mock.Anything).Return(errors.New("cdn down"))
+	listings.On("GetFile", mock.Anything, fallbackListing, mock.Anything).Return(errors.New("mirror down"))
+
+	_, err := c.Latest()
+	require.ErrorContains(t, err, "cdn down")
+	require.ErrorContains(t, err, "mirror down")
+}
+
+func TestClient_Download_FallsBackToMirrors(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	c, _, archives := newMirroredClient(t, fs)
+
+	primaryArchive := "https://cdn.example.com/databases/v6/vulnerability-db_v6.0.0.tar.zst?checksum=sha256%3Aabc"
+	fallbackArchive := "https://mirror.example.com/grype/v6/vulnerability-db_v6.0.0.tar.zst?checksum=sha256%3Aabc"
+	archives.On("GetToDir", mock.Anything, primaryArchive, mock.Anything).Return(errors.New("connection reset"))
+	archives.On("GetToDir", mock.Anything, fallbackArchive, mock.Anything).Return(nil)
+
+	dir, err := c.Download(primaryArchive, t.TempDir(), &progress.Manual{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, dir)
+	archives.AssertExpectations(t)
+
+	// the primary mirror is now tried last
+	assert.Equal(t, []string{fallbackListing, primaryListing}, c.mirrors.ordered())
+}
+
+func TestClient_Download_UnknownURLHasNoFallback(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	c, _, archives := newMirroredClient(t, fs)
+
+	archive := "https://elsewhere.example.com/db.tar.zst"
+	archives.On("GetToDir", mock.Anything, archive, mock.Anything).Return(errors.New("not found")).Once()
+
+	_, err := c.Download(archive, t.TempDir(), &progress.Manual{})
+	require.ErrorContains(t, err, "unable to download db")
+	archives.AssertExpectations(t)
+}
+
+func TestMirrors_coolingDown(t *testing.T) {
+	m := &mirrors{failures: map[string]mirrorFailure{}}
+	now := time.Now()
+
+	assert.False(t, m.coolingDown(primaryListing, now))
+
+	m.failures[primaryListing] = mirrorFailure{Count: 1, Last: now.Add(-time.Minute)}
+	assert.True(t, m.coolingDown(primaryListing, now))
+
+	m.failures[primaryListing] = mirrorFailure{Count: 1, Last: now.Add(-6 * time.Minute)}
+	assert.False(t, m.coolingDown(primaryListing, now))
+
+	// consecutive failures back off, up to an hour
+	m.failures[primaryListing] = mirrorFailure{Count: 3, Last: now.Add(-15 * time.Minute)}
+	assert.True(t, m.coolingDown(primaryListing, now))
+
+	m.failures[primaryListing] = mirrorFailure{Count: 50, Last: now.Add(-61 * time.Minute)}
+	assert.False(t, m.coolingDown(primaryListing, now))
+}
@@ -42,6 +42,17 @@ type monitor struct {
 type Config struct {
 	DBRootDir string
 	Debug     bool
+	// Tracer (optional) receives every SQL query issued against the DB by readers
+	Tracer db.QueryTracer
+	// Pragmas (optional) are SQLite PRAGMA values (by name) to set when reading the DB
+	Pragmas map[string]string
+	// Hooks (optional) are commands executed before and after the DB is replaced by an update
+	Hooks UpdateHooks
+	// RetainPrevious is the number of previously installed DB builds to keep for rollback (0 disables retention)
+	RetainPrevious int
+	// PostgresDSN (optional) is the connection string of a shared Postgres DB which is read instead of the installed DB.
+	// Updates install the DB as usual and then publish it to the Postgres DB.
+	PostgresDSN string
 
 	// validations
 	ValidateAge             bool
@@ -85,6 +96,10 @@ func NewCurator(cfg Config, downloader distribution.Client) (db.Curator, error)
 }
 
 func (c curator) Reader() (db.Reader, error) {
+	if c.config.PostgresDSN != "" {
+		return c.postgresReader()
+	}
+
 	status := c.Status()
 	if err := status.Error; err != nil && errors.Is(err, db.ErrDBDoesNotExist) {
 		return nil, fmt.Errorf("vulnerability database error: %w. Try 'grype db update'", err)
@@ -94,6 +109,8 @@ func (c curator) Reader() (db.Reader, error) {
 		db.Config{
 			DBDirPath: c.config.DBDirectoryPath(),
 			Debug:     c.config.Debug,
+			Tracer:    c.config.Tracer,
+			Pragmas:   c.config.Pragmas,
 		},
 	)
 	if err != nil {
@@ -147,6 +164,8 @@ func (c curator) Reader() (db.Reader, error) {
 			db.Config{
 				DBDirPath: c.config.DBDirectoryPath(),
 				Debug:     c.config.Debug,
+				Tracer:    c.config.Tracer,
+				Pragmas:   c.config.Pragmas,
 			},
 		)
 		if err != nil {
@@ -158,6 +177,10 @@ func (c curator) Reader() (db.Reader, error) {
 }
 
 func (c curator) Status() vulnerability.ProviderStatus {
+	if c.config.PostgresDSN != "" {
+		return c.postgresStatus()
+	}
+
 	dbFile := c.config.DBFilePath()
 	d, validateErr := db.ReadDescription(dbFile)
 	if validateErr != nil {
@@ -206,6 +229,16 @@ func (c curator) Delete() error {
 
 // Update the existing DB, returning an indication if any action was taken.
 func (c curator) Update() (bool, error) {
+	updated, err := c.updateInstalled()
+	if err != nil || c.config.PostgresDSN == "" {
+		return updated, err
+	}
+
+	published, err := c.publishToPostgres()
+	return updated || published, err
+}
+
+func (c curator) updateInstalled() (bool, error) {
 	current, err := db.ReadDescription(c.config.DBFilePath())
 	if err != nil {
 		// we should not warn if the DB does not exist, as this is a common first-run case... but other cases we
@@ -306,6 +339,10 @@ func (c curator) update(current *db.Description) (*distribution.Archive, error)
 		return nil, fmt.Errorf("unable to resolve vulnerability DB URL: %w", err)
 	}
 
+	if err := c.runHooks(preUpdateHook, c.config.Hooks.PreUpdate, current, update, url); err != nil {
+		return nil, fmt.Errorf("unable to update vulnerability database: %w", err)
+	}
+
 	// Ensure parent of DBRootDir exists for the download client to create a temp dir within DBRootDir
 	// This might be redundant if DBRootDir must already exist, but good for safety.
 	if err := os.MkdirAll(c.config.DBRootDir, 0o700); err != nil {
@@ -331,6 +368,11 @@ func (c curator) update(current *db.Description) (*distribution.Archive, error)
 	// only set the last successful update check if the update was successful
 	c.setLastSuccessfulUpdateCheck()
 
+	if err := c.runHooks(postUpdateHook, c.config.Hooks.PostUpdate, current, update, url); err != nil {
+		// the new DB is already in place, so this should not be reported as a failed update
+		log.WithFields("error", err).Warn("vulnerability DB update hook failed")
+	}
+
 	return update, nil
 }
 
@@ -534,10 +576,18 @@ func (c curator) replaceDB(dbDirPath string) error {
 	dbDir := c.config.DBDirectoryPath()
 	_, err := c.fs.Stat(dbDir)
 	if !os.IsNotExist(err) {
-		// remove any previous databases
-		err = c.Delete()
-		if err != nil {
-			return fmt.Errorf("failed to purge existing database: %w", err)
+		if c.config.RetainPrevious > 0 {
+			// keep the existing database around so that it can be rolled back to
+			err = c.retainCurrent()
+			if err != nil {
+				return err
+			}
+		} else {
+			// remove any previous databases
+			err = c.Delete()
+			if err != nil {
+				return fmt.Errorf("failed to purge existing database: %w", err)
+			}
 		}
 	}
 
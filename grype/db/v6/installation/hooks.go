@@ -0,0 +1,99 @@
+package installation
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+	"github.com/anchore/grype/internal/log"
+)
+
+// hookEnvPrefix is the prefix of the environment variables describing the update to hook commands. Note: this is
+// deliberately not GRYPE_DB_ since those variables would be interpreted as configuration by a nested grype invocation.
+const hookEnvPrefix = "GRYPE_HOOK_"
+
+// UpdateHooks are shell commands executed around a vulnerability database update. Each command is provided with
+// the details of the update in GRYPE_HOOK_* environment variables.
+type UpdateHooks struct {
+	// PreUpdate commands are executed after a new database has been found but before it is downloaded and
+	// installed. Any failing command aborts the update.
+	PreUpdate []string
+	// PostUpdate commands are executed after a new database has been installed. Failures are logged but do not
+	// affect the (already completed) update.
+	PostUpdate []string
+}
+
+type hookEvent string
+
+const (
+	preUpdateHook  hookEvent = "pre-update"
+	postUpdateHook hookEvent = "post-update"
+)
+
+// runHooks executes the given commands in order, stopping at the first failure.
+func (c curator) runHooks(event hookEvent, commands []string, current *db.Description, update *distribution.Archive, url string) error {
+	if len(commands) == 0 {
+		return nil
+	}
+
+	env := append(os.Environ(), c.hookEnv(event, current, update, url)...)
+	for _, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			continue
+		}
+
+		startTime := time.Now()
+		output, err := hookCommand(command, env).CombinedOutput()
+		fields := []any{"hook", event, "command", command, "time", time.Since(startTime)}
+		if len(output) > 0 {
+			fields = append(fields, "output", string(bytes.TrimSpace(output)))
+		}
+		if err != nil {
+			log.WithFields(append(fields, "error", err)...).Debug("DB update hook failed")
+			return fmt.Errorf("%s hook %q failed: %w", event, command, err)
+		}
+		log.WithFields(fields...).Debug("ran DB update hook")
+	}
+	return nil
+}
+
+func (c curator) hookEnv(event hookEvent, current *db.Description, update *distribution.Archive, url string) []string {
+	vars := map[string]string{
+		"EVENT":   string(event),
+		"DB_DIR":  c.config.DBDirectoryPath(),
+		"DB_FILE": c.config.DBFilePath(),
+		"URL":     url,
+	}
+	if current != nil {
+		vars["CURRENT_SCHEMA_VERSION"] = current.SchemaVersion.String()
+		vars["CURRENT_BUILT"] = current.Built.UTC().Format(time.RFC3339)
+	}
+	if update != nil {
+		vars["UPDATE_SCHEMA_VERSION"] = update.SchemaVersion.String()
+		vars["UPDATE_BUILT"] = update.Built.UTC().Format(time.RFC3339)
+		vars["UPDATE_CHECKSUM"] = update.Checksum
+	}
+
+	var env []string
+	for name, value := range vars {
+		env = append(env, hookEnvPrefix+name+"="+value)
+	}
+	return env
+}
+
+func hookCommand(command string, env []string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Env = env
+	return cmd
+}
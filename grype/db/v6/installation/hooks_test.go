@@ -0,0 +1,90 @@
+package installation
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/db/v6/distribution"
+)
+
+func TestCurator_Update_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use POSIX shell commands")
+	}
+
+	readEnv := func(t *testing.T, path string) map[string]string {
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		env := make(map[string]string)
+		for _, line := range strings.Split(string(contents), "\n") {
+			name, value, found := strings.Cut(line, "=")
+			if found && strings.HasPrefix(name, hookEnvPrefix) {
+				env[strings.TrimPrefix(name, hookEnvPrefix)] = value
+			}
+		}
+		return env
+	}
+
+	t.Run("hooks run with update details", func(t *testing.T) {
+		c := setupCuratorForUpdate(t, withWorkingUpdateIntegrations())
+		c.hydrator = nil
+		outDir := t.TempDir()
+		preFile := filepath.Join(outDir, "pre.env")
+		postFile := filepath.Join(outDir, "post.env")
+		c.config.Hooks = UpdateHooks{
+			PreUpdate:  []string{"env > " + preFile},
+			PostUpdate: []string{"env > " + postFile},
+		}
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+
+		pre := readEnv(t, preFile)
+		assert.Equal(t, "pre-update", pre["EVENT"])
+		assert.Equal(t, c.config.DBFilePath(), pre["DB_FILE"])
+		assert.Equal(t, "http://localhost/archive.tar.zst", pre["URL"])
+		assert.NotEmpty(t, pre["CURRENT_BUILT"])
+		assert.Contains(t, pre, "UPDATE_BUILT")
+
+		post := readEnv(t, postFile)
+		assert.Equal(t, "post-update", post["EVENT"])
+		assert.Equal(t, pre["CURRENT_BUILT"], post["CURRENT_BUILT"])
+	})
+
+	t.Run("failing pre-update hook aborts the update", func(t *testing.T) {
+		c := setupCuratorForUpdate(t)
+		mc := c.client.(*mockClient)
+		mc.On("IsUpdateAvailable", mock.Anything).Return(&distribution.Archive{}, nil)
+		c.config.Hooks = UpdateHooks{
+			PreUpdate: []string{"exit 3"},
+		}
+
+		updated, err := c.Update()
+		require.ErrorContains(t, err, `pre-update hook "exit 3" failed`)
+		require.False(t, updated)
+		require.NoFileExists(t, filepath.Join(c.config.DBDirectoryPath(), lastUpdateCheckFileName))
+
+		mc.AssertExpectations(t)
+		mc.AssertNotCalled(t, "Download", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failing post-update hook does not fail the update", func(t *testing.T) {
+		c := setupCuratorForUpdate(t, withWorkingUpdateIntegrations())
+		c.hydrator = nil
+		c.config.Hooks = UpdateHooks{
+			PostUpdate: []string{"exit 1"},
+		}
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+	})
+}
@@ -0,0 +1,70 @@
+package installation
+
+import (
+	"fmt"
+	"net/url"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+)
+
+// postgresReader opens the shared Postgres DB. Unlike the DB file, the Postgres DB is never rehydrated by readers:
+// the schema is migrated when a DB is published to it with 'grype db update'.
+func (c curator) postgresReader() (db.Reader, error) {
+	return db.NewReader(
+		db.Config{
+			Debug:       c.config.Debug,
+			Tracer:      c.config.Tracer,
+			PostgresDSN: c.config.PostgresDSN,
+		},
+	)
+}
+
+func (c curator) postgresStatus() vulnerability.ProviderStatus {
+	path := redactDSN(c.config.PostgresDSN)
+
+	rdr, err := c.postgresReader()
+	if err != nil {
+		return vulnerability.ProviderStatus{
+			Path:  path,
+			Error: fmt.Errorf("unable to open postgres DB: %w. Try 'grype db update' to publish a DB", err),
+		}
+	}
+	defer log.CloseAndLogError(rdr, path)
+
+	m, err := rdr.GetDBMetadata()
+	if err != nil || m == nil {
+		return vulnerability.ProviderStatus{
+			Path:  path,
+			Error: fmt.Errorf("no DB has been published to postgres (%v). Try 'grype db update'", err),
+		}
+	}
+
+	d := db.DescriptionFromMetadata(m)
+	return vulnerability.ProviderStatus{
+		Built:         d.Built.Time,
+		SchemaVersion: d.SchemaVersion.String(),
+		Path:          path,
+		Error:         c.validateAge(d),
+	}
+}
+
+// publishToPostgres copies the installed DB to the shared Postgres DB, unless it already holds the same build.
+func (c curator) publishToPostgres() (bool, error) {
+	published, err := db.CopyToPostgres(db.Config{DBDirPath: c.config.DBDirectoryPath(), Debug: c.config.Debug}, c.config.PostgresDSN)
+	if err != nil {
+		return false, fmt.Errorf("unable to publish vulnerability DB to postgres: %w", err)
+	}
+	return published, nil
+}
+
+// redactDSN returns a description of the Postgres DB which is safe to show (without credentials).
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		// key=value connection strings may hold a password anywhere
+		return "postgres"
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,142 @@
+package installation
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/afero"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/internal/log"
+)
+
+// previousDBsDirName is the directory (under the DB root dir) where previously installed DB builds are retained.
+const previousDBsDirName = "previous"
+
+// retainedDBTimeFormat names retained DB directories by build time, which keeps them ordered lexically.
+const retainedDBTimeFormat = "20060102T150405Z"
+
+// ErrNoPreviousDB is returned when a rollback is requested but there are no retained DB builds to roll back to.
+var ErrNoPreviousDB = errors.New("no previous vulnerability database builds are available")
+
+// PreviousDBsDirectoryPath is the directory where previously installed DB builds for the current schema are retained.
+func (c Config) PreviousDBsDirectoryPath() string {
+	return filepath.Join(c.DBRootDir, previousDBsDirName, strconv.Itoa(db.ModelVersion))
+}
+
+// Rollback replaces the current DB with the most recently retained previous DB build, discarding the current DB.
+func (c curator) Rollback() (*db.Description, error) {
+	retained, err := c.retainedDBs()
+	if err != nil {
+		return nil, err
+	}
+	if len(retained) == 0 {
+		return nil, ErrNoPreviousDB
+	}
+
+	previousDir := filepath.Join(c.config.PreviousDBsDirectoryPath(), retained[len(retained)-1])
+	dbDir := c.config.DBDirectoryPath()
+
+	// move the current DB out of the way first so that a failure while activating the previous build can be undone
+	discardDir := dbDir + "-rollback"
+	removeAllOrLog(c.fs, discardDir)
+
+	_, err = c.fs.Stat(dbDir)
+	hasCurrent := err == nil
+	if hasCurrent {
+		if err := c.fs.Rename(dbDir, discardDir); err != nil {
+			return nil, fmt.Errorf("unable to move current database aside: %w", err)
+		}
+	}
+
+	if err := c.fs.Rename(previousDir, dbDir); err != nil {
+		if hasCurrent {
+			if restoreErr := c.fs.Rename(discardDir, dbDir); restoreErr != nil {
+				log.WithFields("error", restoreErr).Error("unable to restore current database after failed rollback")
+			}
+		}
+		return nil, fmt.Errorf("unable to activate previous database: %w", err)
+	}
+
+	if hasCurrent {
+		removeAllOrLog(c.fs, discardDir)
+	}
+
+	description, err := db.ReadDescription(c.config.DBFilePath())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read rolled back database description: %w", err)
+	}
+
+	log.WithFields("built", description.Built.String(), "version", description.SchemaVersion).Info("rolled back vulnerability DB")
+
+	return description, nil
+}
+
+// retainCurrent moves the current DB into the retained previous builds and prunes the oldest builds beyond the
+// configured limit.
+func (c curator) retainCurrent() error {
+	dbDir := c.config.DBDirectoryPath()
+
+	name := time.Now().UTC().Format(retainedDBTimeFormat)
+	if current, err := db.ReadDescription(c.config.DBFilePath()); err == nil && current != nil {
+		name = current.Built.UTC().Format(retainedDBTimeFormat)
+	}
+
+	previousDir := c.config.PreviousDBsDirectoryPath()
+	if err := c.fs.MkdirAll(previousDir, 0o700); err != nil {
+		return fmt.Errorf("unable to create previous database directory: %w", err)
+	}
+
+	dest := filepath.Join(previousDir, name)
+	if _, err := c.fs.Stat(dest); err == nil {
+		// the same build is already retained
+		removeAllOrLog(c.fs, dest)
+	}
+
+	if err := c.fs.Rename(dbDir, dest); err != nil {
+		return fmt.Errorf("unable to retain previous database: %w", err)
+	}
+	log.WithFields("from", dbDir, "to", dest).Debug("retained previous database")
+
+	return c.pruneRetainedDBs()
+}
+
+// pruneRetainedDBs removes the oldest retained DB builds beyond the configured limit.
+func (c curator) pruneRetainedDBs() error {
+	retained, err := c.retainedDBs()
+	if err != nil {
+		return err
+	}
+
+	limit := max(c.config.RetainPrevious, 0)
+	for len(retained) > limit {
+		removeAllOrLog(c.fs, filepath.Join(c.config.PreviousDBsDirectoryPath(), retained[0]))
+		retained = retained[1:]
+	}
+	return nil
+}
+
+// retainedDBs returns the names of the retained DB build directories, oldest first.
+func (c curator) retainedDBs() ([]string, error) {
+	entries, err := afero.ReadDir(c.fs, c.config.PreviousDBsDirectoryPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read previous database directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,76 @@
+package installation
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/anchore/grype/grype/db/v6"
+)
+
+func TestCurator_Rollback(t *testing.T) {
+	t.Run("update retains the previous build and rollback restores it", func(t *testing.T) {
+		c := setupCuratorForUpdate(t, withWorkingUpdateIntegrations())
+		c.hydrator = nil
+		c.config.RetainPrevious = 2
+
+		// mark the installed build so that it can be identified after the rollback
+		marker := filepath.Join(c.config.DBDirectoryPath(), "marker")
+		require.NoError(t, afero.WriteFile(c.fs, marker, []byte("previous"), 0o600))
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+		require.NoFileExists(t, marker)
+
+		retained, err := c.retainedDBs()
+		require.NoError(t, err)
+		require.Len(t, retained, 1)
+
+		rolledBack, err := c.Rollback()
+		require.NoError(t, err)
+		require.NotNil(t, rolledBack)
+		assert.FileExists(t, marker)
+
+		current, err := db.ReadDescription(c.config.DBFilePath())
+		require.NoError(t, err)
+		assert.Equal(t, rolledBack, current)
+
+		// the discarded build is not retained
+		retained, err = c.retainedDBs()
+		require.NoError(t, err)
+		assert.Empty(t, retained)
+		assert.NoDirExists(t, c.config.DBDirectoryPath()+"-rollback")
+	})
+
+	t.Run("update without retention discards the previous build", func(t *testing.T) {
+		c := setupCuratorForUpdate(t, withWorkingUpdateIntegrations())
+		c.hydrator = nil
+
+		updated, err := c.Update()
+		require.NoError(t, err)
+		require.True(t, updated)
+
+		_, err = c.Rollback()
+		require.ErrorIs(t, err, ErrNoPreviousDB)
+	})
+
+	t.Run("prune keeps only the newest builds", func(t *testing.T) {
+		c := newTestCurator(t)
+		c.config.RetainPrevious = 2
+
+		previousDir := c.config.PreviousDBsDirectoryPath()
+		for _, name := range []string{"20240101T000000Z", "20240301T000000Z", "20240201T000000Z"} {
+			require.NoError(t, c.fs.MkdirAll(filepath.Join(previousDir, name), 0o700))
+		}
+
+		require.NoError(t, c.pruneRetainedDBs())
+
+		retained, err := c.retainedDBs()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"20240201T000000Z", "20240301T000000Z"}, retained)
+	})
+}
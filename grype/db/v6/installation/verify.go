@@ -0,0 +1,145 @@
+package installation
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/spf13/afero"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+)
+
+// VerificationProblem classifies a discrepancy found while verifying the installed DB.
+type VerificationProblem string
+
+const (
+	// ProblemTampered indicates the installed DB does not match what was imported or distributed (modified on disk,
+	// or imported from an archive that is not the one being distributed under the same name).
+	ProblemTampered VerificationProblem = "tampered"
+
+	// ProblemDrift indicates the installed DB is intact, but is not the DB currently being distributed.
+	ProblemDrift VerificationProblem = "drift"
+)
+
+// VerificationFinding is a single discrepancy found while verifying the installed DB.
+type VerificationFinding struct {
+	Problem VerificationProblem `json:"problem"`
+	Message string              `json:"message"`
+}
+
+// Verification is the result of deeply checking the installed DB against the digest captured when it was imported and
+// against the latest distribution metadata.
+type Verification struct {
+	// ImportDigest is the digest of the DB file captured when the DB was imported
+	ImportDigest string `json:"importDigest"`
+	// ActualDigest is the recomputed digest of the DB file
+	ActualDigest string `json:"actualDigest"`
+	// ArchivePath is the name of the distribution archive the DB was imported from (when imported from a URL)
+	ArchivePath string `json:"archivePath,omitempty"`
+	// ArchiveChecksum is the checksum of the distribution archive the DB was imported from (when known)
+	ArchiveChecksum string `json:"archiveChecksum,omitempty"`
+	// Latest is the archive currently being distributed
+	Latest   *distribution.Archive `json:"latest,omitempty"`
+	Findings []VerificationFinding `json:"findings"`
+}
+
+// Passed indicates that no discrepancies were found.
+func (v Verification) Passed() bool {
+	return len(v.Findings) == 0
+}
+
+func (v *Verification) add(problem VerificationProblem, format string, args ...any) {
+	v.Findings = append(v.Findings, VerificationFinding{Problem: problem, Message: fmt.Sprintf(format, args...)})
+}
+
+// Verify recomputes the digest of the installed DB file and compares it to the digest captured on import, then
+// compares the imported archive to the latest distribution metadata. Discrepancies are reported as findings; an error
+// is only returned when the verification itself could not be done (e.g. no DB is installed or the distribution
+// metadata could not be fetched).
+func Verify(cfg Config, client distribution.Client) (*Verification, error) {
+	c := curator{
+		fs:     afero.NewOsFs(),
+		client: client,
+		config: cfg,
+	}
+	return c.verify()
+}
+
+func (c curator) verify() (*Verification, error) {
+	if c.config.PostgresDSN != "" {
+		return nil, fmt.Errorf("verification is not supported for postgres-backed databases")
+	}
+
+	dbFile := c.config.DBFilePath()
+	description, err := db.ReadDescription(dbFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read database metadata: %w", err)
+	}
+	if description == nil {
+		return nil, fmt.Errorf("database not found at %q", dbFile)
+	}
+
+	im, err := db.ReadImportMetadata(c.fs, c.config.DBDirectoryPath())
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify database: %w", err)
+	}
+
+	v := &Verification{ImportDigest: im.Digest, Findings: []VerificationFinding{}}
+
+	v.ActualDigest, err = db.CalculateDBDigest(c.fs, dbFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify database: %w", err)
+	}
+	if v.ActualDigest != v.ImportDigest {
+		v.add(ProblemTampered, "the database file digest %q does not match the digest captured on import %q", v.ActualDigest, v.ImportDigest)
+	}
+
+	v.ArchivePath, v.ArchiveChecksum = importedArchive(im.Source)
+
+	latest, err := c.client.Latest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the latest distribution metadata: %w", err)
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no databases are being distributed")
+	}
+	v.Latest = &latest.Archive
+
+	switch {
+	case v.ArchivePath != "" && v.ArchivePath == latest.Path:
+		// the installed DB claims to be the distributed DB, so everything about it must match
+		if v.ArchiveChecksum != "" && v.ArchiveChecksum != latest.Checksum {
+			v.add(ProblemTampered, "the archive checksum captured on import %q does not match the distributed checksum %q for %s", v.ArchiveChecksum, latest.Checksum, latest.Path)
+		}
+		if !sameBuild(*description, latest.Description) {
+			v.add(ProblemTampered, "the database (schema %s, built %s) does not match the distributed database (schema %s, built %s) for %s",
+				description.SchemaVersion, description.Built, latest.SchemaVersion, latest.Built, latest.Path)
+		}
+	case sameBuild(*description, latest.Description):
+		// imported from a file (or a mirror) with the same build as the one distributed: nothing more to compare
+	case description.Built.Before(latest.Built.Time):
+		v.add(ProblemDrift, "the database (built %s) is older than the distributed database (built %s)", description.Built, latest.Built)
+	default:
+		v.add(ProblemDrift, "the database (schema %s, built %s) is not the distributed database (schema %s, built %s)",
+			description.SchemaVersion, description.Built, latest.SchemaVersion, latest.Built)
+	}
+
+	return v, nil
+}
+
+// sameBuild compares build times at the (second) precision of distribution metadata.
+func sameBuild(a, b db.Description) bool {
+	return a.SchemaVersion == b.SchemaVersion && a.Built.String() == b.Built.String()
+}
+
+// importedArchive returns the name and checksum of the archive the DB was imported from, as recorded in the import
+// metadata (URLs carry the checksum as a query parameter, see distribution.Client.ResolveArchiveURL).
+func importedArchive(source string) (string, string) {
+	u, err := url.Parse(source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", ""
+	}
+	return path.Base(u.Path), u.Query().Get("checksum")
+}
@@ -0,0 +1,140 @@
+package installation
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/grype/grype/db/v6/distribution"
+)
+
+func TestCurator_verify(t *testing.T) {
+	const (
+		archivePath     = "vulnerability-db_v6.1.0_2025-03-14T01:31:06Z_1741925227.tar.zst"
+		archiveChecksum = "sha256:d4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8"
+		archiveURL      = "https://grype.anchore.io/databases/v6/" + archivePath + "?checksum=sha256%3Ad4654e3b212f1d8a1aaab979599691099af541568d687c4a7c4e7c1da079b9b8"
+	)
+
+	setup := func(t *testing.T, source string) (curator, db.Description) {
+		c := newTestCurator(t)
+		dir := c.config.DBDirectoryPath()
+		writeTestDB(t, c.fs, dir)
+		_, err := db.WriteImportMetadata(c.fs, dir, source)
+		require.NoError(t, err)
+
+		d, err := db.ReadDescription(c.config.DBFilePath())
+		require.NoError(t, err)
+		require.NotNil(t, d)
+		return c, *d
+	}
+
+	withLatest := func(c *curator, latest *distribution.LatestDocument, err error) {
+		client := new(mockClient)
+		client.On("Latest").Return(latest, err)
+		c.client = client
+	}
+
+	latestFor := func(d db.Description, path, checksum string) *distribution.LatestDocument {
+		return &distribution.LatestDocument{
+			Status:  distribution.LifecycleStatus,
+			Archive: distribution.Archive{Description: d, Path: path, Checksum: checksum},
+		}
+	}
+
+	t.Run("installed DB is the distributed DB", func(t *testing.T) {
+		c, d := setup(t, archiveURL)
+		withLatest(&c, latestFor(d, archivePath, archiveChecksum), nil)
+
+		v, err := c.verify()
+		require.NoError(t, err)
+		assert.True(t, v.Passed(), "findings: %+v", v.Findings)
+		assert.Equal(t, v.ImportDigest, v.ActualDigest)
+		assert.Equal(t, archivePath, v.ArchivePath)
+		assert.Equal(t, archiveChecksum, v.ArchiveChecksum)
+	})
+
+	t.Run("imported from a file with the distributed build", func(t *testing.T) {
+		c, d := setup(t, "/tmp/db.tar.zst")
+		withLatest(&c, latestFor(d, archivePath, archiveChecksum), nil)
+
+		v, err := c.verify()
+		require.NoError(t, err)
+		assert.True(t, v.Passed(), "findings: %+v", v.Findings)
+		assert.Empty(t, v.ArchivePath)
+	})
+
+	t.Run("DB file modified after import", func(t *testing.T) {
+		c, d := setup(t, archiveURL)
+		withLatest(&c, latestFor(d, archivePath, archiveChecksum), nil)
+
+		f, err := os.OpenFile(c.config.DBFilePath(), os.O_APPEND|os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = f.WriteString("tampered")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		v, err := c.verify()
+		require.NoError(t, err)
+		require.Len(t, v.Findings, 1)
+		assert.Equal(t, ProblemTampered, v.Findings[0].Problem)
+		assert.NotEqual(t, v.ImportDigest, v.ActualDigest)
+	})
+
+	t.Run("archive checksum differs from the distributed archive", func(t *testing.T) {
+		c, d := setup(t, archiveURL)
+		withLatest(&c, latestFor(d, archivePath, "sha256:0000"), nil)
+
+		v, err := c.verify()
+		require.NoError(t, err)
+		require.Len(t, v.Findings, 1)
+		assert.Equal(t, ProblemTampered, v.Findings[0].Problem)
+		assert.Contains(t, v.Findings[0].Message, "archive checksum")
+	})
+
+	t.Run("archive contents differ from the distributed archive", func(t *testing.T) {
+		c, d := setup(t, archiveURL)
+		distributed := d
+		distributed.Built = db.Time{Time: d.Built.Add(-time.Hour)}
+		withLatest(&c, latestFor(distributed, archivePath, archiveChecksum), nil)
+
+		v, err := c.verify()
+		require.NoError(t, err)
+		require.Len(t, v.Findings, 1)
+		assert.Equal(t, ProblemTampered, v.Findings[0].Problem)
+	})
+
+	t.Run("newer DB is distributed", func(t *testing.T) {
+		c, d := setup(t, archiveURL)
+		distributed := d
+		distributed.Built = db.Time{Time: d.Built.Add(24 * time.Hour)}
+		withLatest(&c, latestFor(distributed, "vulnerability-db_v6.1.0_newer.tar.zst", "sha256:1111"), nil)
+
+		v, err := c.verify()
+		require.NoError(t, err)
+		require.Len(t, v.Findings, 1)
+		assert.Equal(t, ProblemDrift, v.Findings[0].Problem)
+		assert.Contains(t, v.Findings[0].Message, "older than the distributed database")
+	})
+
+	t.Run("distribution metadata unavailable", func(t *testing.T) {
+		c, _ := setup(t, archiveURL)
+		withLatest(&c, nil, errors.New("offline"))
+
+		_, err := c.verify()
+		require.ErrorContains(t, err, "unable to fetch the latest distribution metadata")
+	})
+
+	t.Run("no DB installed", func(t *testing.T) {
+		c := newTestCurator(t)
+		c.fs = afero.NewOsFs()
+
+		_, err := c.verify()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package v6
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type JavaClassFingerprintStoreWriter interface {
+	AddJavaClassFingerprints(...*JavaClassFingerprint) error
+}
+
+type JavaClassFingerprintStoreReader interface {
+	// GetJavaClassFingerprints returns the fingerprints matching any of the given class file digests.
+	GetJavaClassFingerprints(digests ...string) ([]JavaClassFingerprint, error)
+}
+
+type javaClassFingerprintStore struct {
+	db *gorm.DB
+}
+
+func newJavaClassFingerprintStore(db *gorm.DB) *javaClassFingerprintStore {
+	return &javaClassFingerprintStore{db: db}
+}
+
+func (s *javaClassFingerprintStore) AddJavaClassFingerprints(fingerprints ...*JavaClassFingerprint) error {
+	for _, f := range fingerprints {
+		if err := s.db.Create(f).Error; err != nil {
+			return fmt.Errorf("unable to create java class fingerprint: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetJavaClassFingerprints returns the fingerprints matching any of the given class file digests. A database built
+// before this table existed has no such table; that is not an error — no fingerprints are returned.
+func (s *javaClassFingerprintStore) GetJavaClassFingerprints(digests ...string) ([]JavaClassFingerprint, error) {
+	if len(digests) == 0 || !s.db.Migrator().HasTable(&JavaClassFingerprint{}) {
+		return nil, nil
+	}
+
+	var out []JavaClassFingerprint
+	// keep the number of query parameters well below the sqlite limit
+	for start := 0; start < len(digests); start += batchSize {
+		end := min(start+batchSize, len(digests))
+
+		var rows []JavaClassFingerprint
+		if err := s.db.Where("digest IN ?", digests[start:end]).Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("unable to fetch java class fingerprints: %w", err)
+		}
+		out = append(out, rows...)
+	}
+	return out, nil
+}
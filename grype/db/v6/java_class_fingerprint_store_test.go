@@ -0,0 +1,54 @@
+package v6
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJavaClassFingerprintStore_GetJavaClassFingerprints(t *testing.T) {
+	s := setupTestStore(t)
+
+	jndiLookup := &JavaClassFingerprint{
+		Digest:     "sha256:aaaa",
+		ClassName:  "org/apache/logging/log4j/core/lookup/JndiLookup.class",
+		GroupID:    "org.apache.logging.log4j",
+		ArtifactID: "log4j-core",
+		Version:    "2.14.1",
+	}
+	other := &JavaClassFingerprint{
+		Digest:     "sha256:bbbb",
+		ClassName:  "org/example/Other.class",
+		GroupID:    "org.example",
+		ArtifactID: "other",
+		Version:    "1.0.0",
+	}
+	require.NoError(t, s.AddJavaClassFingerprints(jndiLookup, other))
+
+	// query with more digests than fit in a single batch
+	digests := []string{"sha256:aaaa"}
+	for i := 0; i < batchSize*2; i++ {
+		digests = append(digests, fmt.Sprintf("sha256:%d", i))
+	}
+
+	got, err := s.GetJavaClassFingerprints(digests...)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, *jndiLookup, got[0])
+
+	got, err = s.GetJavaClassFingerprints()
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestJavaClassFingerprintStore_MissingTableIsEmptyNotError(t *testing.T) {
+	// a database built before the java_class_fingerprints table existed has no such table
+	s := setupTestStore(t)
+	require.NoError(t, s.db.Migrator().DropTable(&JavaClassFingerprint{}))
+
+	got, err := s.GetJavaClassFingerprints("sha256:aaaa")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,67 @@
+package v6
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type MaliciousArtifactStoreWriter interface {
+	AddMaliciousArtifacts(...*MaliciousArtifactHandle) error
+}
+
+type MaliciousArtifactStoreReader interface {
+	// GetMaliciousArtifacts returns the malicious artifacts matching any of the given package URLs or digests.
+	GetMaliciousArtifacts(purls, digests []string) ([]MaliciousArtifactHandle, error)
+}
+
+type maliciousArtifactStore struct {
+	db *gorm.DB
+}
+
+func newMaliciousArtifactStore(db *gorm.DB) *maliciousArtifactStore {
+	return &maliciousArtifactStore{db: db}
+}
+
+func (s *maliciousArtifactStore) AddMaliciousArtifacts(artifacts ...*MaliciousArtifactHandle) error {
+	for _, a := range artifacts {
+		if err := s.db.Omit("Vulnerability").Create(a).Error; err != nil {
+			return fmt.Errorf("unable to create malicious artifact: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetMaliciousArtifacts returns the malicious artifacts matching any of the given package URLs or digests (with the
+// vulnerability record of each artifact). A database built before this table existed has no such table; that is not
+// an error — no artifacts are returned.
+func (s *maliciousArtifactStore) GetMaliciousArtifacts(purls, digests []string) ([]MaliciousArtifactHandle, error) {
+	if len(purls)+len(digests) == 0 || !s.db.Migrator().HasTable(&MaliciousArtifactHandle{}) {
+		return nil, nil
+	}
+
+	byPURL, err := s.getMaliciousArtifacts("purl", purls)
+	if err != nil {
+		return nil, err
+	}
+	byDigest, err := s.getMaliciousArtifacts("digest", digests)
+	if err != nil {
+		return nil, err
+	}
+	return append(byPURL, byDigest...), nil
+}
+
+func (s *maliciousArtifactStore) getMaliciousArtifacts(column string, values []string) ([]MaliciousArtifactHandle, error) {
+	var out []MaliciousArtifactHandle
+	// keep the number of query parameters well below the sqlite limit
+	for start := 0; start < len(values); start += batchSize {
+		end := min(start+batchSize, len(values))
+
+		var rows []MaliciousArtifactHandle
+		if err := s.db.Preload("Vulnerability").Where(column+" IN ?", values[start:end]).Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("unable to fetch malicious artifacts by %s: %w", column, err)
+		}
+		out = append(out, rows...)
+	}
+	return out, nil
+}
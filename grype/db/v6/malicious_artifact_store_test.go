@@ -0,0 +1,58 @@
+package v6
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaliciousArtifactStore_GetMaliciousArtifacts(t *testing.T) {
+	s := setupTestStore(t)
+
+	vuln := &VulnerabilityHandle{
+		Name:       "MAL-2024-1234",
+		ProviderID: "openssf-malicious-packages",
+		Provider:   &Provider{ID: "openssf-malicious-packages"},
+	}
+	require.NoError(t, s.AddVulnerabilities(vuln))
+
+	byPURL := &MaliciousArtifactHandle{VulnerabilityID: vuln.ID, PURL: "pkg:npm/evil@1.0.0"}
+	byDigest := &MaliciousArtifactHandle{VulnerabilityID: vuln.ID, Digest: "sha256:aaaa"}
+	require.NoError(t, s.AddMaliciousArtifacts(byPURL, byDigest))
+
+	// query with more digests than fit in a single batch
+	digests := []string{"sha256:aaaa"}
+	for i := 0; i < batchSize*2; i++ {
+		digests = append(digests, fmt.Sprintf("sha256:%d", i))
+	}
+
+	got, err := s.GetMaliciousArtifacts([]string{"pkg:npm/evil@1.0.0", "pkg:npm/benign@1.0.0"}, digests)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "pkg:npm/evil@1.0.0", got[0].PURL)
+	assert.Equal(t, "sha256:aaaa", got[1].Digest)
+	for _, a := range got {
+		require.NotNil(t, a.Vulnerability)
+		assert.Equal(t, "MAL-2024-1234", a.Vulnerability.Name)
+	}
+
+	got, err = s.GetMaliciousArtifacts([]string{"pkg:npm/evil@2.0.0"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = s.GetMaliciousArtifacts(nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestMaliciousArtifactStore_MissingTableIsEmptyNotError(t *testing.T) {
+	// a database built before the malicious_artifact_handles table existed has no such table
+	s := setupTestStore(t)
+	require.NoError(t, s.db.Migrator().DropTable(&MaliciousArtifactHandle{}))
+
+	got, err := s.GetMaliciousArtifacts([]string{"pkg:npm/evil@1.0.0"}, []string{"sha256:aaaa"})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,530 @@
+// Package migrate converts vulnerability stores of previous schema versions into v6 stores.
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scylladb/go-set/strset"
+
+	v5 "github.com/anchore/grype/grype/db/v5"
+	v5build "github.com/anchore/grype/grype/db/v5/build"
+	"github.com/anchore/grype/grype/db/v5/namespace"
+	"github.com/anchore/grype/grype/db/v5/namespace/cpe"
+	"github.com/anchore/grype/grype/db/v5/namespace/distro"
+	"github.com/anchore/grype/grype/db/v5/namespace/language"
+	"github.com/anchore/grype/grype/db/v5/pkg/qualifier/platformcpe"
+	"github.com/anchore/grype/grype/db/v5/pkg/qualifier/rpmmodularity"
+	v5store "github.com/anchore/grype/grype/db/v5/store"
+	db "github.com/anchore/grype/grype/db/v6"
+	v6build "github.com/anchore/grype/grype/db/v6/build"
+	ostransformer "github.com/anchore/grype/grype/db/v6/build/transformers/os"
+	"github.com/anchore/grype/grype/db/v6/name"
+	"github.com/anchore/grype/internal/log"
+	syftCPE "github.com/anchore/syft/syft/cpe"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// providerMetadataFileName is the file written next to a v5 store by the v5 build, describing when each provider last
+// ran successfully.
+const providerMetadataFileName = "provider-metadata.json"
+
+// V5Config configures the migration of a v5 store.
+type V5Config struct {
+	// DBFilePath is the path to the v5 sqlite store to migrate
+	DBFilePath string
+
+	// DBDirPath is the directory to write the v6 store to
+	DBDirPath string
+}
+
+// Summary describes the result of a migration.
+type Summary struct {
+	// Built is the build time of the v5 store, which is kept as the build time of the v6 store
+	Built time.Time
+
+	// Providers are the providers of the migrated vulnerability records
+	Providers []string
+
+	// Vulnerabilities is the number of vulnerability records written to the v6 store
+	Vulnerabilities int
+
+	// Dropped is the number of vulnerability records of the v5 store that could not be migrated
+	Dropped int
+}
+
+// v5Reader is a v5 store that can be read in full.
+type v5Reader interface {
+	v5.StoreReader
+	GetAllVulnerabilities() (*[]v5.Vulnerability, error)
+	GetAllVulnerabilityMetadata() (*[]v5.VulnerabilityMetadata, error)
+}
+
+// FromV5 converts the v5 store (either a published store or a custom-built one) into a v6 store. Each vulnerability
+// of a v5 namespace becomes a v6 vulnerability record of the namespace provider, with the affected packages (or CPEs)
+// of the namespace. Provider metadata is taken from the provider-metadata.json next to the v5 store when present,
+// otherwise the build time of the v5 store is used as the capture date of each provider. Note that v5 match exclusions
+// are not migrated, since v6 has no equivalent.
+func FromV5(cfg V5Config) (*Summary, error) {
+	if _, err := os.Stat(cfg.DBFilePath); err != nil {
+		return nil, fmt.Errorf("unable to read v5 store: %w", err)
+	}
+
+	s, err := v5store.New(cfg.DBFilePath, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open v5 store: %w", err)
+	}
+	defer log.CloseAndLogError(s, cfg.DBFilePath)
+
+	reader, ok := s.(v5Reader)
+	if !ok {
+		return nil, fmt.Errorf("unable to read all records of the v5 store")
+	}
+
+	id, err := reader.GetID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read v5 store ID: %w", err)
+	}
+	if id == nil || id.SchemaVersion != v5.SchemaVersion {
+		return nil, fmt.Errorf("%q is not a v5 store", cfg.DBFilePath)
+	}
+
+	providers, err := readProviders(filepath.Dir(cfg.DBFilePath), id.BuildTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := readRecords(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := v6build.NewStoreWriter(cfg.DBDirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{
+		Built: id.BuildTimestamp.UTC().Round(time.Second),
+	}
+	seen := strset.New()
+	for _, r := range records {
+		rec, err := r.migrate(providers)
+		if err != nil {
+			log.WithFields("id", r.id, "namespace", r.namespace, "error", err).Debug("dropping v5 record")
+			summary.Dropped += len(r.vulnerabilities)
+			continue
+		}
+		if err := writer.Write(*rec); err != nil {
+			return nil, fmt.Errorf("unable to write %s (%s): %w", r.id, r.namespace, err)
+		}
+		summary.Vulnerabilities++
+		seen.Add(rec.Vulnerability.ProviderID)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := setBuildTimestamp(cfg.DBDirPath, summary.Built); err != nil {
+		return nil, err
+	}
+
+	summary.Providers = seen.List()
+	sort.Strings(summary.Providers)
+
+	return summary, nil
+}
+
+// readProviders returns the v6 provider models by provider name.
+func readProviders(dir string, built time.Time) (map[string]db.Provider, error) {
+	// the default for providers missing from the metadata
+	built = built.UTC()
+	providers := map[string]db.Provider{
+		"": {DateCaptured: &built},
+	}
+
+	by, err := os.ReadFile(filepath.Join(dir, providerMetadataFileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return providers, nil
+		}
+		return nil, fmt.Errorf("unable to read provider metadata: %w", err)
+	}
+
+	var metadata v5build.ProviderMetadata
+	if err := json.Unmarshal(by, &metadata); err != nil {
+		return nil, fmt.Errorf("unable to parse provider metadata: %w", err)
+	}
+
+	for _, p := range metadata.Providers {
+		captured := p.LastSuccessfulRun
+		if captured.IsZero() {
+			captured = built
+		}
+		providers[p.Name] = *v6build.NewProvider(p.Name, "", captured)
+	}
+
+	return providers, nil
+}
+
+// record is all v5 data of a vulnerability within a namespace.
+type record struct {
+	id              string
+	namespace       string
+	metadata        *v5.VulnerabilityMetadata
+	vulnerabilities []v5.Vulnerability
+}
+
+// readRecords reads all v5 vulnerabilities and metadata, grouped by vulnerability and namespace. NVD records are
+// ordered first, such that the v6 writer can fill in missing severities from NVD.
+func readRecords(reader v5Reader) ([]*record, error) {
+	vulnerabilities, err := reader.GetAllVulnerabilities()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read v5 vulnerabilities: %w", err)
+	}
+	metadata, err := reader.GetAllVulnerabilityMetadata()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read v5 vulnerability metadata: %w", err)
+	}
+
+	byKey := map[[2]string]*record{}
+	get := func(id, ns string) *record {
+		key := [2]string{ns, id}
+		r, ok := byKey[key]
+		if !ok {
+			r = &record{id: id, namespace: ns}
+			byKey[key] = r
+		}
+		return r
+	}
+	for i := range *metadata {
+		m := (*metadata)[i]
+		get(m.ID, m.Namespace).metadata = &m
+	}
+	for _, v := range *vulnerabilities {
+		r := get(v.ID, v.Namespace)
+		r.vulnerabilities = append(r.vulnerabilities, v)
+	}
+
+	records := make([]*record, 0, len(byKey))
+	for _, r := range byKey {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if aNVD, bNVD := isNVD(a.namespace), isNVD(b.namespace); aNVD != bNVD {
+			return aNVD
+		}
+		if a.namespace != b.namespace {
+			return a.namespace < b.namespace
+		}
+		return a.id < b.id
+	})
+	return records, nil
+}
+
+func isNVD(ns string) bool {
+	return strings.HasPrefix(ns, "nvd:")
+}
+
+// migrate returns the v6 record of the v5 vulnerability and its affected packages and CPEs.
+func (r record) migrate(providers map[string]db.Provider) (*v6build.Record, error) {
+	ns, err := namespace.FromString(r.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vuln := r.vulnerability(providerID(ns.Provider()), providers)
+	rec := &v6build.Record{Vulnerability: vuln}
+	cves := cveAliases(vuln.BlobValue.Aliases, r.id)
+
+	for _, v := range r.vulnerabilities {
+		blob := &db.PackageBlob{
+			CVEs:       cves,
+			Qualifiers: qualifiers(v),
+			Ranges:     ranges(v),
+		}
+
+		switch n := ns.(type) {
+		case *cpe.Namespace:
+			for _, c := range v.CPEs {
+				attrs, err := syftCPE.NewAttributes(c)
+				if err != nil {
+					log.WithFields("cpe", c, "id", r.id, "error", err).Debug("dropping unparsable CPE")
+					continue
+				}
+				rec.AffectedCPEs = append(rec.AffectedCPEs, db.AffectedCPEHandle{
+					CPE:       cpeModel(attrs),
+					BlobValue: blob,
+				})
+			}
+		case *distro.Namespace:
+			rec.AffectedPackages = append(rec.AffectedPackages, distroPackage(n, v.PackageName, blob))
+		case *language.Namespace:
+			t := languagePackageType(n)
+			rec.AffectedPackages = append(rec.AffectedPackages, db.AffectedPackageHandle{
+				Package: &db.Package{
+					Ecosystem: string(t),
+					Name:      name.Normalize(v.PackageName, t),
+				},
+				BlobValue: blob,
+			})
+		default:
+			return nil, fmt.Errorf("unsupported namespace type %T", ns)
+		}
+	}
+
+	return rec, nil
+}
+
+// providerID returns the v6 provider ID of a v5 namespace provider.
+func providerID(p string) string {
+	if p == "redhat" {
+		return "rhel"
+	}
+	return p
+}
+
+func (r record) vulnerability(providerID string, providers map[string]db.Provider) db.VulnerabilityHandle {
+	p, ok := providers[providerID]
+	if !ok {
+		p = providers[""]
+	}
+	p.ID = providerID
+
+	blob := &db.VulnerabilityBlob{
+		ID: r.id,
+	}
+
+	aliases := strset.New()
+	for _, v := range r.vulnerabilities {
+		for _, related := range v.RelatedVulnerabilities {
+			if related.ID != r.id {
+				aliases.Add(related.ID)
+			}
+		}
+	}
+	blob.Aliases = aliases.List()
+	sort.Strings(blob.Aliases)
+
+	if m := r.metadata; m != nil {
+		blob.Description = strings.TrimSpace(m.Description)
+		blob.References = references(*m)
+		blob.Severities = severities(*m)
+	}
+
+	return db.VulnerabilityHandle{
+		Name:       r.id,
+		ProviderID: providerID,
+		Provider:   &p,
+		Status:     db.VulnerabilityActive,
+		BlobValue:  blob,
+	}
+}
+
+func references(m v5.VulnerabilityMetadata) []db.Reference {
+	var refs []db.Reference
+	seen := strset.New()
+	for _, u := range append([]string{m.DataSource}, m.URLs...) {
+		u = strings.TrimSpace(u)
+		if u == "" || seen.Has(u) {
+			continue
+		}
+		seen.Add(u)
+		refs = append(refs, db.Reference{URL: u})
+	}
+	return refs
+}
+
+func severities(m v5.VulnerabilityMetadata) []db.Severity {
+	var sevs []db.Severity
+	if s := strings.ToLower(strings.TrimSpace(m.Severity)); s != "" && s != "unknown" {
+		sevs = append(sevs, db.Severity{
+			Scheme: db.SeveritySchemeCHMLN,
+			Value:  s,
+			Rank:   1,
+		})
+	}
+	for _, c := range m.Cvss {
+		sevs = append(sevs, db.Severity{
+			Scheme: db.SeveritySchemeCVSS,
+			Value: db.CVSSSeverity{
+				Vector:  c.Vector,
+				Version: c.Version,
+			},
+			Source: c.Source,
+			Rank:   2,
+		})
+	}
+	return sevs
+}
+
+func cveAliases(aliases []string, id string) []string {
+	var cves []string
+	for _, a := range append([]string{id}, aliases...) {
+		if strings.HasPrefix(strings.ToLower(a), "cve-") {
+			cves = append(cves, a)
+		}
+	}
+	return cves
+}
+
+func qualifiers(v v5.Vulnerability) *db.PackageQualifiers {
+	var q db.PackageQualifiers
+	for _, pq := range v.PackageQualifiers {
+		switch pq := pq.(type) {
+		case rpmmodularity.Qualifier:
+			module := pq.Module
+			q.RpmModularity = &module
+		case platformcpe.Qualifier:
+			q.PlatformCPEs = append(q.PlatformCPEs, pq.CPE)
+		}
+	}
+	if q.RpmModularity == nil && len(q.PlatformCPEs) == 0 {
+		return nil
+	}
+	return &q
+}
+
+// ranges returns the affected ranges of the vulnerability. v5 records may have several fix versions for a single
+// constraint, which are kept as one range per fix version with the same constraint.
+func ranges(v v5.Vulnerability) []db.Range {
+	affected := db.Version{
+		Type:       v.VersionFormat,
+		Constraint: v.VersionConstraint,
+	}
+
+	var refs []db.Reference
+	for _, a := range v.Advisories {
+		refs = append(refs, db.Reference{ID: a.ID, URL: a.Link})
+	}
+	var detail *db.FixDetail
+	if len(refs) > 0 {
+		detail = &db.FixDetail{References: refs}
+	}
+
+	state := fixStatus(v.Fix.State)
+	if state == db.UnknownFixStatus {
+		return []db.Range{{Version: affected}}
+	}
+	if len(v.Fix.Versions) == 0 {
+		return []db.Range{{Version: affected, Fix: &db.Fix{State: state, Detail: detail}}}
+	}
+
+	var rs []db.Range
+	for _, fixed := range v.Fix.Versions {
+		rs = append(rs, db.Range{
+			Version: affected,
+			Fix:     &db.Fix{Version: fixed, State: state, Detail: detail},
+		})
+	}
+	return rs
+}
+
+func fixStatus(state v5.FixState) db.FixStatus {
+	switch state {
+	case v5.FixedState:
+		return db.FixedStatus
+	case v5.NotFixedState:
+		return db.NotFixedStatus
+	case v5.WontFixState:
+		return db.WontFixStatus
+	default:
+		return db.UnknownFixStatus
+	}
+}
+
+func cpeModel(attrs syftCPE.Attributes) *db.Cpe {
+	return &db.Cpe{
+		Part:            attrs.Part,
+		Vendor:          attrs.Vendor,
+		Product:         attrs.Product,
+		Edition:         attrs.Edition,
+		Language:        attrs.Language,
+		SoftwareEdition: attrs.SWEdition,
+		TargetHardware:  attrs.TargetHW,
+		TargetSoftware:  attrs.TargetSW,
+		Other:           attrs.Other,
+	}
+}
+
+func distroPackage(n *distro.Namespace, packageName string, blob *db.PackageBlob) db.AffectedPackageHandle {
+	osName := string(n.DistroType())
+	if osName == "windows" {
+		// msrc products were modelled as a windows distro version in v5
+		return db.AffectedPackageHandle{
+			Package: &db.Package{
+				Ecosystem: string(pkg.KbPkg),
+				Name:      name.Normalize(packageName, pkg.KbPkg),
+			},
+			BlobValue: blob,
+		}
+	}
+
+	t := ostransformer.PackageType(osName)
+	return db.AffectedPackageHandle{
+		OperatingSystem: ostransformer.OperatingSystem(osName, osName, n.Version(), ""),
+		Package: &db.Package{
+			Ecosystem: string(t),
+			Name:      name.Normalize(packageName, t),
+		},
+		BlobValue: blob,
+	}
+}
+
+// languagePackageType returns the package type of a v5 language namespace, the inverse of the v5 namespace of v6
+// language packages (see db.MimicV5Namespace).
+func languagePackageType(n *language.Namespace) pkg.Type {
+	if n.PackageType() != "" {
+		return n.PackageType()
+	}
+	switch n.Language() {
+	case pkg.Go:
+		return pkg.GoModulePkg
+	case pkg.PHP:
+		return pkg.PhpComposerPkg
+	case pkg.Rust:
+		return pkg.RustPkg
+	case pkg.Dart:
+		return pkg.DartPubPkg
+	case pkg.Dotnet:
+		return pkg.DotnetPkg
+	case pkg.Java:
+		return pkg.JavaPkg
+	case pkg.Swift:
+		return pkg.SwiftPkg
+	case pkg.JavaScript:
+		return pkg.NpmPkg
+	case pkg.Python:
+		return pkg.PythonPkg
+	case pkg.Ruby:
+		return pkg.GemPkg
+	case pkg.Erlang, pkg.Elixir:
+		return pkg.HexPkg
+	}
+	return pkg.Type(n.Language())
+}
+
+// setBuildTimestamp replaces the build time of the v6 store, which the v6 writer sets to the current time.
+func setBuildTimestamp(dir string, built time.Time) error {
+	gdb, err := db.NewLowLevelDB(db.Config{DBDirPath: dir}.DBFilePath(), false, true, false)
+	if err != nil {
+		return fmt.Errorf("unable to open v6 store: %w", err)
+	}
+	if err := gdb.Model(&db.DBMetadata{}).Where("true").Update("build_timestamp", built).Error; err != nil {
+		return fmt.Errorf("unable to set v6 store build time: %w", err)
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -0,0 +1,169 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v5 "github.com/anchore/grype/grype/db/v5"
+	v5build "github.com/anchore/grype/grype/db/v5/build"
+	"github.com/anchore/grype/grype/db/v5/pkg/qualifier"
+	"github.com/anchore/grype/grype/db/v5/pkg/qualifier/rpmmodularity"
+	v5store "github.com/anchore/grype/grype/db/v5/store"
+	db "github.com/anchore/grype/grype/db/v6"
+	"github.com/anchore/syft/syft/cpe"
+)
+
+func writeV5Store(t *testing.T, dir string, built time.Time) {
+	t.Helper()
+	s, err := v5store.New(filepath.Join(dir, v5.VulnerabilityStoreFileName), true)
+	require.NoError(t, err)
+
+	require.NoError(t, s.SetID(v5.NewID(built)))
+	require.NoError(t, s.AddVulnerability(
+		v5.Vulnerability{
+			ID:                "CVE-2023-1234",
+			PackageName:       "libfoo",
+			Namespace:         "nvd:cpe",
+			VersionConstraint: "< 1.2.0",
+			VersionFormat:     "unknown",
+			CPEs:              []string{"cpe:2.3:a:foo:libfoo:*:*:*:*:*:*:*:*"},
+			Fix:               v5.Fix{State: v5.UnknownFixState},
+		},
+		v5.Vulnerability{
+			ID:                     "GHSA-aaaa-bbbb-cccc",
+			PackageName:            "Django",
+			Namespace:              "github:language:python",
+			VersionConstraint:      ">=4.0,<4.1.3",
+			VersionFormat:          "python",
+			RelatedVulnerabilities: []v5.VulnerabilityReference{{ID: "CVE-2023-1234", Namespace: "nvd:cpe"}},
+			Fix:                    v5.Fix{State: v5.FixedState, Versions: []string{"4.1.3"}},
+		},
+		v5.Vulnerability{
+			ID:                "CVE-2023-1234",
+			PackageName:       "libfoo",
+			Namespace:         "redhat:distro:redhat:8",
+			VersionConstraint: "< 0:1.2.0-1.el8",
+			VersionFormat:     "rpm",
+			PackageQualifiers: []qualifier.Qualifier{rpmmodularity.Qualifier{Kind: "rpm-modularity", Module: "foo:1"}},
+			Fix:               v5.Fix{State: v5.FixedState, Versions: []string{"0:1.2.0-1.el8"}},
+			Advisories:        []v5.Advisory{{ID: "RHSA-2023:0001", Link: "https://access.redhat.com/errata/RHSA-2023:0001"}},
+		},
+		v5.Vulnerability{
+			ID:                "CVE-2023-1234",
+			PackageName:       "libfoo",
+			Namespace:         "unsupported",
+			VersionConstraint: "< 1.2.0",
+		},
+	))
+	require.NoError(t, s.AddVulnerabilityMetadata(
+		v5.VulnerabilityMetadata{
+			ID:          "CVE-2023-1234",
+			Namespace:   "nvd:cpe",
+			DataSource:  "https://nvd.nist.gov/vuln/detail/CVE-2023-1234",
+			Severity:    "High",
+			Description: "a flaw in libfoo",
+			Cvss:        []v5.Cvss{{Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:N/A:N", Version: "3.1", Source: "nvd@nist.gov"}},
+		},
+		v5.VulnerabilityMetadata{
+			ID:        "GHSA-aaaa-bbbb-cccc",
+			Namespace: "github:language:python",
+			Severity:  "Medium",
+			URLs:      []string{"https://github.com/advisories/GHSA-aaaa-bbbb-cccc"},
+		},
+	))
+	require.NoError(t, s.Close())
+
+	require.NoError(t, v5build.ProviderMetadata{Providers: []v5build.Provider{
+		{Name: "nvd", LastSuccessfulRun: built.Add(-time.Hour)},
+	}}.Write(filepath.Join(dir, providerMetadataFileName)))
+}
+
+func TestFromV5(t *testing.T) {
+	v5Dir, v6Dir := t.TempDir(), t.TempDir()
+	built := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	writeV5Store(t, v5Dir, built)
+
+	summary, err := FromV5(V5Config{DBFilePath: filepath.Join(v5Dir, v5.VulnerabilityStoreFileName), DBDirPath: v6Dir})
+	require.NoError(t, err)
+	assert.Equal(t, &Summary{
+		Built:           built,
+		Providers:       []string{"github", "nvd", "rhel"},
+		Vulnerabilities: 3,
+		Dropped:         1,
+	}, summary)
+
+	reader, err := db.NewReader(db.Config{DBDirPath: v6Dir})
+	require.NoError(t, err)
+	defer reader.Close()
+
+	metadata, err := reader.GetDBMetadata()
+	require.NoError(t, err)
+	assert.Equal(t, built, metadata.BuildTimestamp.UTC())
+
+	// provider metadata is kept, falling back to the build time of the v5 store
+	nvd, err := reader.GetProvider("nvd")
+	require.NoError(t, err)
+	assert.Equal(t, built.Add(-time.Hour), nvd.DateCaptured.UTC())
+	rhel, err := reader.GetProvider("rhel")
+	require.NoError(t, err)
+	assert.Equal(t, built, rhel.DateCaptured.UTC())
+
+	cpes, err := reader.GetAffectedCPEs(&cpe.Attributes{Part: "a", Vendor: "foo", Product: "libfoo"}, &db.GetCPEOptions{PreloadVulnerability: true, PreloadBlob: true})
+	require.NoError(t, err)
+	require.Len(t, cpes, 1)
+	vuln := cpes[0].Vulnerability
+	assert.Equal(t, "CVE-2023-1234", vuln.Name)
+	assert.Equal(t, "nvd:cpe", db.MimicV5Namespace(vuln, nil))
+	assert.Equal(t, "a flaw in libfoo", vuln.BlobValue.Description)
+	assert.Equal(t, []db.Reference{{URL: "https://nvd.nist.gov/vuln/detail/CVE-2023-1234"}}, vuln.BlobValue.References)
+	require.Len(t, vuln.BlobValue.Severities, 2)
+	assert.Equal(t, "high", vuln.BlobValue.Severities[0].Value)
+	assert.Equal(t, "< 1.2.0", cpes[0].BlobValue.Ranges[0].Version.Constraint)
+
+	pkgs, err := reader.GetAffectedPackages(&db.PackageSpecifier{Name: "django"}, &db.GetPackageOptions{PreloadPackage: true, PreloadVulnerability: true, PreloadBlob: true})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	assert.Equal(t, "github:language:python", db.MimicV5Namespace(pkgs[0].Vulnerability, &pkgs[0]))
+	assert.Equal(t, []string{"CVE-2023-1234"}, pkgs[0].Vulnerability.BlobValue.Aliases)
+	assert.Equal(t, []string{"CVE-2023-1234"}, pkgs[0].BlobValue.CVEs)
+	assert.Equal(t, &db.Fix{Version: "4.1.3", State: db.FixedStatus}, pkgs[0].BlobValue.Ranges[0].Fix)
+
+	pkgs, err = reader.GetAffectedPackages(&db.PackageSpecifier{Name: "libfoo"}, &db.GetPackageOptions{PreloadOS: true, PreloadPackage: true, PreloadVulnerability: true, PreloadBlob: true})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	assert.Equal(t, "redhat:distro:redhat:8", db.MimicV5Namespace(pkgs[0].Vulnerability, &pkgs[0]))
+	assert.Equal(t, "rpm", pkgs[0].Package.Ecosystem)
+	require.NotNil(t, pkgs[0].BlobValue.Qualifiers)
+	assert.Equal(t, "foo:1", *pkgs[0].BlobValue.Qualifiers.RpmModularity)
+	assert.Equal(t, []db.Reference{{ID: "RHSA-2023:0001", URL: "https://access.redhat.com/errata/RHSA-2023:0001"}}, pkgs[0].BlobValue.Ranges[0].Fix.Detail.References)
+}
+
+func TestFromV5_notAV5Store(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, v5.VulnerabilityStoreFileName)
+
+	_, err := FromV5(V5Config{DBFilePath: path, DBDirPath: t.TempDir()})
+	require.ErrorContains(t, err, "unable to read v5 store")
+
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	_, err = FromV5(V5Config{DBFilePath: path, DBDirPath: t.TempDir()})
+	require.Error(t, err)
+}
+
+func TestRanges(t *testing.T) {
+	ranges := ranges(v5.Vulnerability{
+		VersionConstraint: "< 2.0.0",
+		VersionFormat:     "semver",
+		Fix:               v5.Fix{State: v5.FixedState, Versions: []string{"1.5.3", "2.0.0"}},
+	})
+	affected := db.Version{Type: "semver", Constraint: "< 2.0.0"}
+	assert.Equal(t, []db.Range{
+		{Version: affected, Fix: &db.Fix{Version: "1.5.3", State: db.FixedStatus}},
+		{Version: affected, Fix: &db.Fix{Version: "2.0.0", State: db.FixedStatus}},
+	}, ranges)
+}
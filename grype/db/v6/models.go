@@ -64,6 +64,12 @@ func Models() []any {
 		&EpssHandle{},
 		&EpssMetadata{},
 		&CWEHandle{},
+
+		// java class fingerprints (for identifying shaded or repackaged artifacts)
+		&JavaClassFingerprint{},
+
+		// known-malicious package artifacts (for identifying malicious packages by package URL or digest)
+		&MaliciousArtifactHandle{},
 	}
 }
 
@@ -983,6 +989,58 @@ func (c CWEHandle) String() string {
 	return fmt.Sprintf("CWE(%s: %s, source=%s, type=%s)", c.CVE, c.CWE, c.Source, c.Type)
 }
 
+// java class fingerprints //////////////////////////////////////////////
+
+// JavaClassFingerprint identifies the maven artifact a java class file was released in by the digest of the class
+// file. This allows for finding artifacts that were shaded or repackaged into other archives without their maven
+// metadata (e.g. an application jar bundling the JndiLookup class of a vulnerable log4j-core release). Only classes
+// that are distinctive of an artifact version are expected to be stored.
+type JavaClassFingerprint struct {
+	ID int64 `gorm:"primaryKey"`
+
+	// Digest is the digest of the class file contents (e.g. "sha256:...")
+	Digest string `gorm:"column:digest;not null;index:java_class_fingerprints_digest_idx"`
+
+	// ClassName is the path of the class file within the artifact (e.g. "org/apache/logging/log4j/core/lookup/JndiLookup.class")
+	ClassName string `gorm:"column:class_name;not null"`
+
+	GroupID    string `gorm:"column:group_id;not null"`
+	ArtifactID string `gorm:"column:artifact_id;not null"`
+	Version    string `gorm:"column:version;not null"`
+}
+
+func (f JavaClassFingerprint) String() string {
+	return fmt.Sprintf("JavaClassFingerprint(%s: %s:%s@%s, class=%s)", f.Digest, f.GroupID, f.ArtifactID, f.Version, f.ClassName)
+}
+
+// malicious artifacts //////////////////////////////////////////////////
+
+// MaliciousArtifactHandle identifies a package artifact that a known-malicious package record (e.g. a MAL- record of
+// the OpenSSF malicious-packages dataset) applies to, either by the package URL of the artifact or by the digest of the
+// artifact itself. This allows for finding malicious artifacts independent of the name and version matching of the
+// affected packages of the record (e.g. a malicious tarball republished under another name or version).
+type MaliciousArtifactHandle struct {
+	ID              ID                   `gorm:"column:id;primaryKey"`
+	VulnerabilityID ID                   `gorm:"column:vulnerability_id;not null"`
+	Vulnerability   *VulnerabilityHandle `gorm:"foreignKey:VulnerabilityID"`
+
+	// PURL is the versioned package URL of the artifact, without qualifiers or subpath (e.g. "pkg:npm/evil@1.0.0"),
+	// empty when the artifact is only known by digest
+	PURL string `gorm:"column:purl;index:malicious_artifact_handles_purl_idx"`
+
+	// Digest is the self describing digest of the artifact (e.g. "sha256:..."), empty when the artifact is only known
+	// by package URL
+	Digest string `gorm:"column:digest;index:malicious_artifact_handles_digest_idx"`
+}
+
+func (h MaliciousArtifactHandle) String() string {
+	var vuln string
+	if h.Vulnerability != nil {
+		vuln = h.Vulnerability.Name
+	}
+	return fmt.Sprintf("MaliciousArtifact(%s: purl=%q digest=%q)", vuln, h.PURL, h.Digest)
+}
+
 // OperatingSystemEOLHandle carries end-of-life data for an operating system.
 // This is not a GORM model - it's used to update existing OperatingSystem records.
 type OperatingSystemEOLHandle struct {
@@ -230,15 +230,7 @@ func getPackages[T packageHandleStore]( //nolint:funlen
 			Trace("fetched package record")
 	}()
 
-	query := s.handlePackage(s.db.Table(tableName), pkg, config.AllowBroadCPEMatching)
-
-	var err error
-	query, err = s.handleVulnerabilityOptions(query, config.Vulnerabilities, tableName)
-	if err != nil {
-		return nil, err
-	}
-
-	query, err = s.handleOSOptions(query, config.OSs, tableName)
+	query, err := s.newPackagesQuery(pkg, config, tableName)
 	if err != nil {
 		return nil, err
 	}
@@ -288,6 +280,32 @@ func getPackages[T packageHandleStore]( //nolint:funlen
 	return models, nil
 }
 
+// countPackages counts the package records matching the given criteria as a SQL aggregate (preload options and the
+// record limit do not apply).
+func countPackages(s *packageStore, pkg *PackageSpecifier, config *GetPackageOptions, tableName string, groupBy CountGroupBy) ([]CountGroup, error) {
+	if config == nil {
+		config = &GetPackageOptions{}
+	}
+
+	query, err := s.newPackagesQuery(pkg, config, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return countRecords(s.db, query, s.blobStore, packageCountColumns(tableName), groupBy)
+}
+
+func (s *packageStore) newPackagesQuery(pkg *PackageSpecifier, config *GetPackageOptions, tableName string) (*gorm.DB, error) {
+	query := s.handlePackage(s.db.Table(tableName), pkg, config.AllowBroadCPEMatching)
+
+	query, err := s.handleVulnerabilityOptions(query, config.Vulnerabilities, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.handleOSOptions(query, config.OSs, tableName)
+}
+
 func (s *packageStore) handlePackage(query *gorm.DB, p *PackageSpecifier, allowBroad bool) *gorm.DB {
 	if p == nil {
 		return query
@@ -0,0 +1,152 @@
+package v6
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/anchore/grype/grype/db/internal/gormadapter"
+	"github.com/anchore/grype/internal/log"
+)
+
+// copyOrder lists the models in the order rows must be copied between databases, such that rows are always written
+// after the rows they reference.
+func copyOrder() []any {
+	return []any{
+		&Blob{},
+		&DBMetadata{},
+		&Provider{},
+		&VulnerabilityHandle{},
+		&VulnerabilityAlias{},
+		&OperatingSystem{},
+		&OperatingSystemSpecifierOverride{},
+		&Package{},
+		&PackageSpecifierOverride{},
+		&ArchitectureAlias{},
+		&Cpe{},
+		&PackageCpe{},
+		&AffectedPackageHandle{},
+		&UnaffectedPackageHandle{},
+		&AffectedCPEHandle{},
+		&UnaffectedCPEHandle{},
+		&KnownExploitedVulnerabilityHandle{},
+		&EpssHandle{},
+		&EpssMetadata{},
+		&CWEHandle{},
+		&JavaClassFingerprint{},
+		&MaliciousArtifactHandle{},
+	}
+}
+
+func newPostgresDB(dsn string, writable, debug bool, extraOpts ...gormadapter.Option) (*gorm.DB, error) {
+	opts := append([]gormadapter.Option{
+		gormadapter.WithDebug(debug),
+	}, extraOpts...)
+
+	if writable {
+		opts = append(opts, gormadapter.WithWritable(true, Models()))
+	}
+
+	return gormadapter.OpenPostgres(dsn, opts...)
+}
+
+// CopyToPostgres publishes the DB at the given (sqlite) location to the Postgres database with the given DSN, replacing
+// any previously published DB. The copy is made in a single transaction, so scanners reading from the Postgres database
+// never observe a partial DB. Returns false (without copying) when the Postgres database already holds the same build.
+func CopyToPostgres(cfg Config, dsn string) (bool, error) {
+	src, err := NewReader(Config{DBDirPath: cfg.DBDirPath, Debug: cfg.Debug})
+	if err != nil {
+		return false, fmt.Errorf("unable to open source DB: %w", err)
+	}
+	defer log.CloseAndLogError(src, cfg.DBFilePath())
+
+	srcMeta, err := src.GetDBMetadata()
+	if err != nil {
+		return false, fmt.Errorf("unable to get source DB metadata: %w", err)
+	}
+
+	dst, err := newPostgresDB(dsn, true, cfg.Debug)
+	if err != nil {
+		return false, fmt.Errorf("unable to open postgres DB: %w", err)
+	}
+	if d, err := dst.DB(); err == nil {
+		defer d.Close()
+	}
+
+	dstMeta, err := newDBMetadataStore(dst).GetDBMetadata()
+	if err == nil && sameBuild(srcMeta, dstMeta) {
+		log.WithFields("built", srcMeta.BuildTimestamp).Debug("postgres DB is up to date")
+		return false, nil
+	}
+
+	srcDB := src.(lowLevelReader).GetDB()
+	err = dst.Transaction(func(tx *gorm.DB) error {
+		models := copyOrder()
+		// rows reference each other, so existing rows are deleted in reverse order
+		for i := len(models) - 1; i >= 0; i-- {
+			if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true, SkipHooks: true}).Delete(models[i]).Error; err != nil {
+				return fmt.Errorf("unable to clear %T rows: %w", models[i], err)
+			}
+		}
+		for _, model := range models {
+			if err := copyRows(srcDB, tx, model); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("unable to copy DB to postgres: %w", err)
+	}
+
+	log.WithFields("built", srcMeta.BuildTimestamp).Info("published vulnerability DB to postgres")
+	return true, nil
+}
+
+// copyRows copies all rows of the given model as-is (keeping IDs, without running create hooks or writing associations).
+func copyRows(src, dst *gorm.DB, model any) error {
+	if !src.Migrator().HasTable(model) {
+		// the source DB was built before the table existed
+		return nil
+	}
+
+	rows := reflect.New(reflect.SliceOf(reflect.TypeOf(model).Elem()))
+
+	stmt := &gorm.Statement{DB: src}
+	if err := stmt.Parse(model); err != nil {
+		return fmt.Errorf("unable to parse %T model: %w", model, err)
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		// batches are ordered by primary key, so tables without one (e.g. the DB metadata) are copied at once
+		if err := src.Model(model).Find(rows.Interface()).Error; err != nil {
+			return fmt.Errorf("unable to read %T rows: %w", model, err)
+		}
+		if rows.Elem().Len() == 0 {
+			return nil
+		}
+		if err := dst.Session(&gorm.Session{SkipHooks: true}).Omit(clause.Associations).Create(rows.Interface()).Error; err != nil {
+			return fmt.Errorf("unable to copy %T rows: %w", model, err)
+		}
+		return nil
+	}
+
+	result := src.Model(model).FindInBatches(rows.Interface(), batchSize, func(_ *gorm.DB, _ int) error {
+		if rows.Elem().Len() == 0 {
+			return nil
+		}
+		return dst.Session(&gorm.Session{SkipHooks: true}).Omit(clause.Associations).Create(rows.Interface()).Error
+	})
+	if result.Error != nil {
+		return fmt.Errorf("unable to copy %T rows: %w", model, result.Error)
+	}
+	return nil
+}
+
+func sameBuild(a, b *DBMetadata) bool {
+	if a == nil || b == nil || a.BuildTimestamp == nil || b.BuildTimestamp == nil {
+		return false
+	}
+	return a.BuildTimestamp.Equal(*b.BuildTimestamp) && newSchemaVerFromDBMetadata(*a) == newSchemaVerFromDBMetadata(*b)
+}
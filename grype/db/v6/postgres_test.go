@@ -0,0 +1,54 @@
+package v6
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyOrder_coversAllModels(t *testing.T) {
+	var want, got []string
+	for _, m := range Models() {
+		want = append(want, reflect.TypeOf(m).Elem().Name())
+	}
+	for _, m := range copyOrder() {
+		got = append(got, reflect.TypeOf(m).Elem().Name())
+	}
+	// the join table for package CPEs is not a model of its own, but its rows must be copied as well
+	want = append(want, "PackageCpe")
+	assert.ElementsMatch(t, want, got)
+}
+
+func TestCopyRows(t *testing.T) {
+	s := setupTestStore(t)
+	require.NoError(t, s.AddAffectedPackages(testDistro1AffectedPackage2Handle(), testNonDistroAffectedPackage2Handle()))
+	require.NoError(t, s.AddUnaffectedPackages(testDistro2UnaffectedPackage2Handle()))
+	require.NoError(t, s.AddAffectedCPEs(&AffectedCPEHandle{
+		Vulnerability: &VulnerabilityHandle{Name: "CVE-2024-0001", Provider: &Provider{ID: "nvd"}},
+		CPE:           &Cpe{Part: "a", Vendor: "vendor", Product: "product"},
+		BlobValue:     &PackageBlob{CVEs: []string{"CVE-2024-0001"}},
+	}))
+
+	// rows are written with foreign keys enforced, so the order of the copy is verified as well
+	dst, err := newLowLevelDB(filepath.Join(t.TempDir(), VulnerabilityDBFileName), false, true, false)
+	require.NoError(t, err)
+
+	for _, model := range copyOrder() {
+		require.NoError(t, copyRows(s.db, dst, model))
+	}
+
+	for _, model := range copyOrder() {
+		var want, got int64
+		require.NoError(t, s.db.Model(model).Count(&want).Error)
+		require.NoError(t, dst.Model(model).Count(&got).Error)
+		assert.Equal(t, want, got, "row count of %T", model)
+	}
+
+	var src, copied []AffectedPackageHandle
+	require.NoError(t, s.db.Order("id").Find(&src).Error)
+	require.NoError(t, dst.Order("id").Find(&copied).Error)
+	assert.Equal(t, src, copied)
+}
@@ -0,0 +1,123 @@
+package v6
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueryTracer receives every SQL query issued against the DB.
+type QueryTracer interface {
+	TraceQuery(sql string, rowsAffected int64, elapsed time.Duration, err error)
+}
+
+var _ QueryTracer = (*QueryTrace)(nil)
+
+// QueryTrace writes each SQL query issued against the DB (with duration and row count) to a writer as it is executed
+// and keeps track of the queries in order to summarize the slowest queries at the end of a session.
+type QueryTrace struct {
+	lock    sync.Mutex
+	writer  io.Writer
+	queries []TracedQuery
+	total   time.Duration
+	err     error
+}
+
+// TracedQuery is a single SQL query issued against the DB.
+type TracedQuery struct {
+	SQL      string
+	Rows     int64
+	Duration time.Duration
+	Err      error
+}
+
+// NewQueryTrace creates a QueryTrace that writes every query to the given writer.
+func NewQueryTrace(writer io.Writer) *QueryTrace {
+	return &QueryTrace{
+		writer: writer,
+	}
+}
+
+func (t *QueryTrace) TraceQuery(sql string, rowsAffected int64, elapsed time.Duration, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	q := TracedQuery{
+		SQL:      singleLine(sql),
+		Rows:     rowsAffected,
+		Duration: elapsed,
+		Err:      err,
+	}
+	t.queries = append(t.queries, q)
+	t.total += elapsed
+
+	if t.err != nil {
+		// don't continue to attempt writing after the first failure
+		return
+	}
+	_, t.err = fmt.Fprintln(t.writer, q.String())
+}
+
+// Count is the number of queries traced.
+func (t *QueryTrace) Count() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.queries)
+}
+
+// Slowest returns up to n of the slowest queries traced, slowest first.
+func (t *QueryTrace) Slowest(n int) []TracedQuery {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	sorted := make([]TracedQuery, len(t.queries))
+	copy(sorted, t.queries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+
+	if n >= 0 && len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// WriteSummary writes the total query count and time along with the n slowest queries to the trace writer, returning
+// the first error encountered while writing the trace.
+func (t *QueryTrace) WriteSummary(n int) error {
+	slowest := t.Slowest(n)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.err != nil {
+		return fmt.Errorf("unable to write DB trace: %w", t.err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\n# %d queries took %s\n", len(t.queries), t.total)
+	fmt.Fprintf(&sb, "# top %d slowest queries:\n", len(slowest))
+	for _, q := range slowest {
+		fmt.Fprintf(&sb, "%s\n", q.String())
+	}
+
+	if _, err := io.WriteString(t.writer, sb.String()); err != nil {
+		return fmt.Errorf("unable to write DB trace summary: %w", err)
+	}
+	return nil
+}
+
+func (q TracedQuery) String() string {
+	s := fmt.Sprintf("%12s  rows=%-6d  %s", q.Duration, q.Rows, q.SQL)
+	if q.Err != nil {
+		s += fmt.Sprintf("  error=%q", q.Err.Error())
+	}
+	return s
+}
+
+func singleLine(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
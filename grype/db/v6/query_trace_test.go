@@ -0,0 +1,56 @@
+package v6
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTrace_Slowest(t *testing.T) {
+	var buf bytes.Buffer
+	trace := NewQueryTrace(&buf)
+
+	trace.TraceQuery("SELECT * FROM a", 1, 2*time.Millisecond, nil)
+	trace.TraceQuery("SELECT *\n  FROM b", 5, 10*time.Millisecond, nil)
+	trace.TraceQuery("SELECT * FROM c", 0, time.Millisecond, errors.New("boom"))
+
+	assert.Equal(t, 3, trace.Count())
+
+	slowest := trace.Slowest(2)
+	require.Len(t, slowest, 2)
+	assert.Equal(t, "SELECT * FROM b", slowest[0].SQL)
+	assert.Equal(t, "SELECT * FROM a", slowest[1].SQL)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[1], "rows=5")
+	assert.Contains(t, lines[2], `error="boom"`)
+
+	require.NoError(t, trace.WriteSummary(1))
+	assert.Contains(t, buf.String(), "# 3 queries took 13ms")
+	assert.Contains(t, buf.String(), "# top 1 slowest queries:")
+}
+
+func TestQueryTrace_Store(t *testing.T) {
+	var buf bytes.Buffer
+	trace := NewQueryTrace(&buf)
+
+	dir := t.TempDir()
+	s := setupTestStore(t, dir)
+	require.NoError(t, s.Close())
+
+	s, err := newStore(Config{DBDirPath: dir, Tracer: trace}, false, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+
+	_, err = s.GetDBMetadata()
+	require.NoError(t, err)
+
+	assert.NotZero(t, trace.Count())
+	assert.Contains(t, buf.String(), "db_metadata")
+}
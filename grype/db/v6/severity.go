@@ -94,6 +94,26 @@ func interpretCVSSv3Plus(score float64) vulnerability.Severity {
 	return vulnerability.UnknownSeverity
 }
 
+// toSeverityRecords returns every severity of the vulnerability (in rank order) along with how each value is interpreted.
+func toSeverityRecords(severities ...Severity) []vulnerability.SeverityRecord {
+	//nolint:prealloc
+	var out []vulnerability.SeverityRecord
+	for _, sev := range severities {
+		interpreted, err := extractSeverity(sev.Value)
+		if err != nil {
+			log.WithFields("value", sev.Value, "error", err).Trace("unable to interpret severity")
+		}
+		out = append(out, vulnerability.SeverityRecord{
+			Source:   sev.Source,
+			Scheme:   string(sev.Scheme),
+			Value:    fmt.Sprint(sev.Value),
+			Severity: interpreted,
+			Rank:     sev.Rank,
+		})
+	}
+	return out
+}
+
 func toCvss(severities ...Severity) []vulnerability.Cvss {
 	//nolint:prealloc
 	var out []vulnerability.Cvss
@@ -6,6 +6,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/anchore/grype/grype/db/internal/gormadapter"
 	"github.com/anchore/grype/internal/log"
 )
 
@@ -20,6 +21,8 @@ type store struct {
 	*unaffectedCPEStore
 	*vulnerabilityDecoratorStore
 	*architectureAliasStore
+	*javaClassFingerprintStore
+	*maliciousArtifactStore
 	blobStore *blobStore
 	db        *gorm.DB
 	config    Config
@@ -60,7 +63,24 @@ func newStore(cfg Config, empty, writable bool) (*store, error) {
 		path = cfg.DBFilePath()
 	}
 
-	db, err := NewLowLevelDB(path, empty, writable, cfg.Debug)
+	var opts []gormadapter.Option
+	if cfg.Tracer != nil {
+		opts = append(opts, gormadapter.WithTracer(cfg.Tracer))
+	}
+	if len(cfg.Pragmas) > 0 && cfg.PostgresDSN == "" {
+		opts = append(opts, gormadapter.WithPragmas(cfg.Pragmas))
+	}
+
+	var db *gorm.DB
+	var err error
+	if cfg.PostgresDSN != "" {
+		if empty {
+			return nil, fmt.Errorf("cannot build a postgres database, use CopyToPostgres to publish a DB instead")
+		}
+		db, err = newPostgresDB(cfg.PostgresDSN, writable, cfg.Debug, opts...)
+	} else {
+		db, err = newLowLevelDB(path, empty, writable, cfg.Debug, opts...)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db: %w", err)
 	}
@@ -103,6 +123,8 @@ func newStore(cfg Config, empty, writable bool) (*store, error) {
 		vulnerabilityDecoratorStore: newVulnerabilityDecoratorStore(db, bs, dbVersion),
 		unaffectedCPEStore:          newUnaffectedCPEStore(db, bs),
 		architectureAliasStore:      newArchitectureAliasStore(db),
+		javaClassFingerprintStore:   newJavaClassFingerprintStore(db),
+		maliciousArtifactStore:      newMaliciousArtifactStore(db),
 		blobStore:                   bs,
 		db:                          db,
 		config:                      cfg,
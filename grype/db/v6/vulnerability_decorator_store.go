@@ -1,7 +1,9 @@
 package v6
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -21,6 +23,58 @@ type VulnerabilityDecoratorStoreReader interface {
 	GetKnownExploitedVulnerabilities(cve string) ([]KnownExploitedVulnerabilityHandle, error)
 	GetEpss(cve string) ([]EpssHandle, error)
 	GetCWEs(cve string) ([]CWEHandle, error)
+	FindKnownExploitedVulnerabilities(spec *KnownExploitedSpecifier, limit int) ([]KnownExploitedVulnerabilityHandle, error)
+	FindEpss(spec *EpssSpecifier, limit int) ([]EpssHandle, error)
+}
+
+// KnownExploitedSpecifier is used to filter KEV records when searching the table directly (all fields are optional).
+type KnownExploitedSpecifier struct {
+	// CVE is the vulnerability ID to filter on (e.g. CVE-2021-44228)
+	CVE string
+
+	// AddedAfter is a filter to only return records added to the KEV catalog on or after the given time
+	AddedAfter *time.Time
+
+	// AddedBefore is a filter to only return records added to the KEV catalog on or before the given time
+	AddedBefore *time.Time
+
+	// RansomwareUse is a filter on the known ransomware campaign use value (e.g. "Known" or "Unknown", case-insensitive)
+	RansomwareUse string
+}
+
+func (s *KnownExploitedSpecifier) matches(blob *KnownExploitedVulnerabilityBlob) bool {
+	if s == nil {
+		return true
+	}
+	if s.AddedAfter != nil || s.AddedBefore != nil {
+		if blob == nil || blob.DateAdded == nil {
+			return false
+		}
+		if s.AddedAfter != nil && blob.DateAdded.Before(*s.AddedAfter) {
+			return false
+		}
+		if s.AddedBefore != nil && blob.DateAdded.After(*s.AddedBefore) {
+			return false
+		}
+	}
+	if s.RansomwareUse != "" {
+		if blob == nil || !strings.EqualFold(blob.KnownRansomwareCampaignUse, s.RansomwareUse) {
+			return false
+		}
+	}
+	return true
+}
+
+// EpssSpecifier is used to filter EPSS records when searching the table directly (all fields are optional and
+// ranges are inclusive).
+type EpssSpecifier struct {
+	// CVE is the vulnerability ID to filter on (e.g. CVE-2021-44228)
+	CVE string
+
+	MinScore      *float64
+	MaxScore      *float64
+	MinPercentile *float64
+	MaxPercentile *float64
 }
 
 type vulnerabilityDecoratorStore struct {
@@ -269,3 +323,116 @@ func (s *vulnerabilityDecoratorStore) GetKnownExploitedVulnerabilities(cve strin
 
 	return models, nil
 }
+
+// FindKnownExploitedVulnerabilities returns all KEV records matching the given specifier (nil matches all records).
+// Since the filterable fields live within the blob, filtering is done after the blob values are attached. If the
+// limit is reached then the records found so far are returned along with ErrLimitReached.
+func (s *vulnerabilityDecoratorStore) FindKnownExploitedVulnerabilities(spec *KnownExploitedSpecifier, limit int) ([]KnownExploitedVulnerabilityHandle, error) {
+	if !s.kevEnabled {
+		// capability incompatibilities should gracefully degrade, returning no data or errors
+		return nil, nil
+	}
+
+	start := time.Now()
+	var models []KnownExploitedVulnerabilityHandle
+	defer func() {
+		log.WithFields("duration", time.Since(start), "records", len(models)).Trace("searched KEV records")
+	}()
+
+	query := s.db
+	if spec != nil && spec.CVE != "" {
+		query = query.Where("cve = ? collate nocase", spec.CVE)
+	}
+
+	var results []*KnownExploitedVulnerabilityHandle
+	err := query.FindInBatches(&results, batchSize, func(_ *gorm.DB, _ int) error {
+		var blobs []blobable
+		for _, r := range results {
+			blobs = append(blobs, r)
+		}
+		if err := s.blobStore.attachBlobValue(blobs...); err != nil {
+			return fmt.Errorf("unable to attach KEV blobs: %w", err)
+		}
+
+		for _, r := range results {
+			if !spec.matches(r.BlobValue) {
+				continue
+			}
+			// the limit is only reached when there is one more matching record than the limit
+			if limit > 0 && len(models) >= limit {
+				return ErrLimitReached
+			}
+			models = append(models, *r)
+		}
+		return nil
+	}).Error
+
+	if err != nil && !errors.Is(err, ErrLimitReached) {
+		return models, fmt.Errorf("unable to fetch KEV records: %w", err)
+	}
+	return models, err
+}
+
+// FindEpss returns all EPSS records matching the given specifier (nil matches all records), ordered by descending
+// score. If the limit is reached then the records found so far are returned along with ErrLimitReached.
+func (s *vulnerabilityDecoratorStore) FindEpss(spec *EpssSpecifier, limit int) ([]EpssHandle, error) {
+	if !s.epssEnabled {
+		// capability incompatibilities should gracefully degrade, returning no data or errors
+		return nil, nil
+	}
+
+	start := time.Now()
+	var models []EpssHandle
+	defer func() {
+		log.WithFields("duration", time.Since(start), "records", len(models)).Trace("searched EPSS records")
+	}()
+
+	if s.epssDate == nil {
+		// fetch and cache the EPSS metadata
+		metadata, err := s.getEPSSMetadata()
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch EPSS metadata: %w", err)
+		}
+		s.epssDate = &metadata.Date
+	}
+
+	query := s.db.Model(&EpssHandle{})
+	if spec != nil {
+		if spec.CVE != "" {
+			query = query.Where("cve = ? collate nocase", spec.CVE)
+		}
+		if spec.MinScore != nil {
+			query = query.Where("epss >= ?", *spec.MinScore)
+		}
+		if spec.MaxScore != nil {
+			query = query.Where("epss <= ?", *spec.MaxScore)
+		}
+		if spec.MinPercentile != nil {
+			query = query.Where("percentile >= ?", *spec.MinPercentile)
+		}
+		if spec.MaxPercentile != nil {
+			query = query.Where("percentile <= ?", *spec.MaxPercentile)
+		}
+	}
+	query = query.Order("epss desc").Order("cve")
+
+	// fetch one more record than the limit so we can tell if the limit was reached
+	if limit > 0 {
+		query = query.Limit(limit + 1)
+	}
+
+	var results []EpssHandle
+	if err := query.Find(&results).Error; err != nil {
+		return nil, fmt.Errorf("unable to fetch EPSS records: %w", err)
+	}
+
+	for _, r := range results {
+		if limit > 0 && len(models) >= limit {
+			return models, ErrLimitReached
+		}
+		r.Date = *s.epssDate
+		models = append(models, r)
+	}
+
+	return models, nil
+}
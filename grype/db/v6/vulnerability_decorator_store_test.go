@@ -380,3 +380,160 @@ func TestVulnerabilityDecoratorCapabilities_AllCapabilitiesCovered(t *testing.T)
 func timeRef(t time.Time) *time.Time {
 	return &t
 }
+
+func TestVulnerabilityDecoratorStore_FindKnownExploitedVulnerabilities(t *testing.T) {
+	db := setupTestStore(t).db
+	s := &vulnerabilityDecoratorStore{
+		db:        db,
+		blobStore: newBlobStore(db),
+		vulnerabilityDecoratorCapabilities: vulnerabilityDecoratorCapabilities{
+			kevEnabled: true,
+		},
+	}
+
+	day := func(d string) *time.Time {
+		v, err := time.Parse(time.DateOnly, d)
+		require.NoError(t, err)
+		return &v
+	}
+
+	require.NoError(t, s.AddKnownExploitedVulnerabilities(
+		&KnownExploitedVulnerabilityHandle{Cve: "CVE-2023-0001", BlobValue: &KnownExploitedVulnerabilityBlob{Cve: "CVE-2023-0001", DateAdded: day("2023-01-10"), KnownRansomwareCampaignUse: "Known"}},
+		&KnownExploitedVulnerabilityHandle{Cve: "CVE-2023-0002", BlobValue: &KnownExploitedVulnerabilityBlob{Cve: "CVE-2023-0002", DateAdded: day("2023-06-01"), KnownRansomwareCampaignUse: "Unknown"}},
+		&KnownExploitedVulnerabilityHandle{Cve: "CVE-2024-0003", BlobValue: &KnownExploitedVulnerabilityBlob{Cve: "CVE-2024-0003", DateAdded: day("2024-02-20"), KnownRansomwareCampaignUse: "Known"}},
+	))
+
+	tests := []struct {
+		name        string
+		spec        *KnownExploitedSpecifier
+		limit       int
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:     "no filters",
+			expected: []string{"CVE-2023-0001", "CVE-2023-0002", "CVE-2024-0003"},
+		},
+		{
+			name:     "by CVE",
+			spec:     &KnownExploitedSpecifier{CVE: "cve-2023-0002"},
+			expected: []string{"CVE-2023-0002"},
+		},
+		{
+			name:     "date added range (inclusive)",
+			spec:     &KnownExploitedSpecifier{AddedAfter: day("2023-01-10"), AddedBefore: day("2023-12-31")},
+			expected: []string{"CVE-2023-0001", "CVE-2023-0002"},
+		},
+		{
+			name:     "ransomware use",
+			spec:     &KnownExploitedSpecifier{RansomwareUse: "known"},
+			expected: []string{"CVE-2023-0001", "CVE-2024-0003"},
+		},
+		{
+			name:        "limit reached",
+			limit:       2,
+			expected:    []string{"CVE-2023-0001", "CVE-2023-0002"},
+			expectedErr: ErrLimitReached,
+		},
+		{
+			name:     "exactly the limit",
+			limit:    3,
+			expected: []string{"CVE-2023-0001", "CVE-2023-0002", "CVE-2024-0003"},
+		},
+		{
+			name:     "filtered records at the limit",
+			spec:     &KnownExploitedSpecifier{RansomwareUse: "known"},
+			limit:    2,
+			expected: []string{"CVE-2023-0001", "CVE-2024-0003"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := s.FindKnownExploitedVulnerabilities(tt.spec, tt.limit)
+			require.ErrorIs(t, err, tt.expectedErr)
+
+			var actual []string
+			for _, r := range results {
+				require.NotNil(t, r.BlobValue)
+				actual = append(actual, r.Cve)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestVulnerabilityDecoratorStore_FindEpss(t *testing.T) {
+	db := setupTestStore(t).db
+	s := &vulnerabilityDecoratorStore{
+		db:        db,
+		blobStore: newBlobStore(db),
+		vulnerabilityDecoratorCapabilities: vulnerabilityDecoratorCapabilities{
+			epssEnabled: true,
+		},
+	}
+
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, s.AddEpss(
+		&EpssHandle{Cve: "CVE-2023-0001", Epss: 0.1, Percentile: 0.5, Date: date},
+		&EpssHandle{Cve: "CVE-2023-0002", Epss: 0.9, Percentile: 0.99, Date: date},
+		&EpssHandle{Cve: "CVE-2023-0003", Epss: 0.5, Percentile: 0.8, Date: date},
+	))
+	// force the date to be read from the metadata table
+	s.epssDate = nil
+
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name        string
+		spec        *EpssSpecifier
+		limit       int
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:     "no filters orders by descending score",
+			expected: []string{"CVE-2023-0002", "CVE-2023-0003", "CVE-2023-0001"},
+		},
+		{
+			name:     "score range (inclusive)",
+			spec:     &EpssSpecifier{MinScore: score(0.1), MaxScore: score(0.5)},
+			expected: []string{"CVE-2023-0003", "CVE-2023-0001"},
+		},
+		{
+			name:     "percentile range",
+			spec:     &EpssSpecifier{MinPercentile: score(0.9)},
+			expected: []string{"CVE-2023-0002"},
+		},
+		{
+			name:     "by CVE",
+			spec:     &EpssSpecifier{CVE: "cve-2023-0001"},
+			expected: []string{"CVE-2023-0001"},
+		},
+		{
+			name:        "limit reached",
+			limit:       1,
+			expected:    []string{"CVE-2023-0002"},
+			expectedErr: ErrLimitReached,
+		},
+		{
+			name:     "limit not reached",
+			limit:    3,
+			expected: []string{"CVE-2023-0002", "CVE-2023-0003", "CVE-2023-0001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := s.FindEpss(tt.spec, tt.limit)
+			require.ErrorIs(t, err, tt.expectedErr)
+
+			var actual []string
+			for _, r := range results {
+				assert.True(t, date.Equal(r.Date))
+				actual = append(actual, r.Cve)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -23,9 +23,11 @@ import (
 )
 
 var (
-	_ vulnerability.Provider              = (*vulnerabilityProvider)(nil)
-	_ vulnerability.StoreMetadataProvider = (*vulnerabilityProvider)(nil)
-	_ vulnerability.EOLChecker            = (*vulnerabilityProvider)(nil)
+	_ vulnerability.Provider                  = (*vulnerabilityProvider)(nil)
+	_ vulnerability.StoreMetadataProvider     = (*vulnerabilityProvider)(nil)
+	_ vulnerability.EOLChecker                = (*vulnerabilityProvider)(nil)
+	_ pkg.JavaClassFingerprintProvider        = (*vulnerabilityProvider)(nil)
+	_ vulnerability.MaliciousArtifactProvider = (*vulnerabilityProvider)(nil)
 )
 
 func NewVulnerabilityProvider(rdr Reader) vulnerability.Provider {
@@ -124,6 +126,11 @@ func newVulnerabilityMetadata(vuln *VulnerabilityHandle, namespace string, kevs
 		log.WithFields("id", vuln.Name, "vulnerability", vuln.String()).Debug("unable to extract severity from vulnerability")
 	}
 
+	var severities []vulnerability.SeverityRecord
+	if vuln.BlobValue != nil {
+		severities = toSeverityRecords(vuln.BlobValue.Severities...)
+	}
+
 	return &vulnerability.Metadata{
 		ID:             vuln.Name,
 		DataSource:     firstReferenceURL(vuln),
@@ -132,9 +139,12 @@ func newVulnerabilityMetadata(vuln *VulnerabilityHandle, namespace string, kevs
 		URLs:           lastReferenceURLs(vuln),
 		Description:    vuln.BlobValue.Description,
 		Cvss:           cvss,
+		Severities:     severities,
 		KnownExploited: kevs,
 		EPSS:           epss,
 		CWEs:           cwes,
+		PublishedDate:  vuln.PublishedDate,
+		ModifiedDate:   vuln.ModifiedDate,
 	}, nil
 }
 
@@ -222,6 +232,46 @@ func (vp vulnerabilityProvider) PackageSearchNames(p pkg.Package) []string {
 	return name.PackageNames(p)
 }
 
+// JavaClassArtifacts returns the maven artifacts released with class files of the given digests.
+func (vp vulnerabilityProvider) JavaClassArtifacts(digests ...string) ([]pkg.JavaClassArtifact, error) {
+	fingerprints, err := vp.reader.GetJavaClassFingerprints(digests...)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]pkg.JavaClassArtifact, 0, len(fingerprints))
+	for _, f := range fingerprints {
+		out = append(out, pkg.JavaClassArtifact{
+			Digest:     f.Digest,
+			ClassName:  f.ClassName,
+			GroupID:    f.GroupID,
+			ArtifactID: f.ArtifactID,
+			Version:    f.Version,
+		})
+	}
+	return out, nil
+}
+
+// MaliciousArtifacts returns the known-malicious package artifacts matching any of the given package URLs or digests.
+func (vp vulnerabilityProvider) MaliciousArtifacts(purls, digests []string) ([]vulnerability.MaliciousArtifact, error) {
+	handles, err := vp.reader.GetMaliciousArtifacts(purls, digests)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]vulnerability.MaliciousArtifact, 0, len(handles))
+	for _, h := range handles {
+		if h.Vulnerability == nil {
+			log.WithFields("artifact", h.String()).Debug("malicious artifact has no vulnerability record")
+			continue
+		}
+		out = append(out, vulnerability.MaliciousArtifact{
+			ID:     h.Vulnerability.Name,
+			PURL:   h.PURL,
+			Digest: h.Digest,
+		})
+	}
+	return out, nil
+}
+
 func (vp vulnerabilityProvider) Close() error {
 	return vp.reader.(io.Closer).Close()
 }
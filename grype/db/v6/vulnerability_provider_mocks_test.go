@@ -17,6 +17,12 @@ import (
 )
 
 func testVulnerabilityProvider(t *testing.T) vulnerability.Provider {
+	t.Helper()
+	return NewVulnerabilityProvider(setupReadOnlyTestStore(t, testVulnerabilityDB(t)))
+}
+
+// testVulnerabilityDB writes the DB of the vulnerability provider tests, returning the directory it is written to.
+func testVulnerabilityDB(t *testing.T) string {
 	t.Helper()
 	tmp := t.TempDir()
 	w, err := NewWriter(Config{
@@ -413,5 +419,5 @@ func testVulnerabilityProvider(t *testing.T) vulnerability.Provider {
 	err = w.AddUnaffectedPackages(neutronUnaffectedHandle)
 	require.NoError(t, err)
 
-	return NewVulnerabilityProvider(setupReadOnlyTestStore(t, tmp))
+	return tmp
 }
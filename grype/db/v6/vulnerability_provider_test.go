@@ -52,6 +52,7 @@ func Test_FindVulnerabilitiesByDistro(t *testing.T) {
 				DataSource:  "http://somewhere/CVE-2014-fake-1",
 				Namespace:   "debian:distro:debian:8",
 				Severity:    "High",
+				Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 				URLs:        nil,
 				Description: "CVE-2014-fake-1-description",
 			},
@@ -72,6 +73,7 @@ func Test_FindVulnerabilitiesByDistro(t *testing.T) {
 				DataSource:  "http://somewhere/CVE-2013-fake-2",
 				Namespace:   "debian:distro:debian:8",
 				Severity:    "High",
+				Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 				URLs:        nil,
 				Description: "CVE-2013-fake-2-description",
 			},
@@ -131,6 +133,7 @@ func Test_FindVulnerabilitiesByCPE(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2014-fake-4",
 						Namespace:   "nvd:cpe",
 						Severity:    "High",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 						URLs:        nil,
 						Description: "CVE-2014-fake-4-description",
 					},
@@ -158,6 +161,7 @@ func Test_FindVulnerabilitiesByCPE(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2014-fake-4",
 						Namespace:   "nvd:cpe",
 						Severity:    "High",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 						URLs:        nil,
 						Description: "CVE-2014-fake-4-description",
 					},
@@ -185,6 +189,7 @@ func Test_FindVulnerabilitiesByCPE(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2014-fake-3",
 						Namespace:   "nvd:cpe",
 						Severity:    "High",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 						URLs:        nil,
 						Description: "CVE-2014-fake-3-description",
 					},
@@ -206,6 +211,7 @@ func Test_FindVulnerabilitiesByCPE(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2014-fake-4",
 						Namespace:   "nvd:cpe",
 						Severity:    "High",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 						URLs:        nil,
 						Description: "CVE-2014-fake-4-description",
 					},
@@ -227,6 +233,7 @@ func Test_FindVulnerabilitiesByCPE(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2014-fake-7",
 						Namespace:   "nvd:cpe",
 						Severity:    "High",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 						URLs:        nil,
 						Description: "CVE-2014-fake-7-description",
 					},
@@ -298,6 +305,7 @@ func Test_FindVulnerabilitiesByID(t *testing.T) {
 				DataSource:  "http://somewhere/CVE-2014-fake-1",
 				Namespace:   "debian:distro:debian:8",
 				Severity:    "High",
+				Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "high", Severity: vulnerability.HighSeverity}},
 				URLs:        nil,
 				Description: "CVE-2014-fake-1-description",
 			},
@@ -311,6 +319,10 @@ func Test_FindVulnerabilitiesByID(t *testing.T) {
 		if d := cmp.Diff(expected[idx], vuln, cmpOpts()...); d != "" {
 			t.Errorf("diff: %+v", d)
 		}
+		// the record dates are carried through to the metadata
+		require.NotNil(t, vuln.Metadata.PublishedDate)
+		require.NotNil(t, vuln.Metadata.ModifiedDate)
+		require.True(t, vuln.Metadata.PublishedDate.Before(*vuln.Metadata.ModifiedDate))
 	}
 
 	// without distro
@@ -590,6 +602,7 @@ func Test_FindVulnerabilitiesByUnaffectedCriteria(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2024-unaffected-test",
 						Namespace:   "nvd:language:deb",
 						Severity:    "Medium",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "medium", Severity: vulnerability.MediumSeverity}},
 						URLs:        nil,
 						Description: "CVE-2024-unaffected-test-description",
 					},
@@ -621,6 +634,7 @@ func Test_FindVulnerabilitiesByUnaffectedCriteria(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2024-unaffected-test",
 						Namespace:   "nvd:cpe",
 						Severity:    "Medium",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "medium", Severity: vulnerability.MediumSeverity}},
 						URLs:        nil,
 						Description: "CVE-2024-unaffected-test-description",
 					},
@@ -653,6 +667,7 @@ func Test_FindVulnerabilitiesByUnaffectedCriteria(t *testing.T) {
 						DataSource:  "http://somewhere/CVE-2024-unaffected-test",
 						Namespace:   "nvd:distro:debian:8",
 						Severity:    "Medium",
+						Severities:  []vulnerability.SeverityRecord{{Scheme: "CVSS", Value: "medium", Severity: vulnerability.MediumSeverity}},
 						URLs:        nil,
 						Description: "CVE-2024-unaffected-test-description",
 					},
@@ -954,5 +969,7 @@ func cmpOpts() []cmp.Option {
 		}, cmp.Ignore()),
 		cmpopts.EquateEmpty(),
 		cmpopts.IgnoreFields(vulnerability.Reference{}, "Internal"),
+		// fixture dates are relative to the current time, see Test_FindVulnerabilitiesByID for coverage
+		cmpopts.IgnoreFields(vulnerability.Metadata{}, "PublishedDate", "ModifiedDate"),
 	}
 }
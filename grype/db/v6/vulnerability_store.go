@@ -20,6 +20,7 @@ type VulnerabilityStoreWriter interface {
 
 type VulnerabilityStoreReader interface {
 	GetVulnerabilities(vuln *VulnerabilitySpecifier, config *GetVulnerabilityOptions) ([]VulnerabilityHandle, error)
+	CountVulnerabilities(vuln *VulnerabilitySpecifier, groupBy CountGroupBy) ([]CountGroup, error)
 }
 
 type GetVulnerabilityOptions struct {
@@ -42,6 +43,9 @@ type VulnerabilitySpecifier struct {
 	// PublishedAfter is a filter to only return vulnerabilities published after the given time
 	PublishedAfter *time.Time
 
+	// PublishedBefore is a filter to only return vulnerabilities published before the given time
+	PublishedBefore *time.Time
+
 	// ModifiedAfter is a filter to only return vulnerabilities modified after the given time
 	ModifiedAfter *time.Time
 
@@ -70,6 +74,10 @@ func (v *VulnerabilitySpecifier) String() string {
 		parts = append(parts, fmt.Sprintf("publishedAfter=%s", v.PublishedAfter.String()))
 	}
 
+	if v.PublishedBefore != nil {
+		parts = append(parts, fmt.Sprintf("publishedBefore=%s", v.PublishedBefore.String()))
+	}
+
 	if v.ModifiedAfter != nil {
 		parts = append(parts, fmt.Sprintf("modifiedAfter=%s", v.ModifiedAfter.String()))
 	}
@@ -330,6 +338,27 @@ func (s *vulnerabilityStore) GetVulnerabilities(vuln *VulnerabilitySpecifier, co
 	return models, err
 }
 
+// CountVulnerabilities counts the vulnerability records matching the given specifier as a SQL aggregate. When grouping
+// by ecosystem, a vulnerability is counted for each ecosystem of the packages it affects.
+func (s *vulnerabilityStore) CountVulnerabilities(vuln *VulnerabilitySpecifier, groupBy CountGroupBy) ([]CountGroup, error) {
+	query := s.db.Model(&VulnerabilityHandle{})
+	if vuln != nil {
+		var err error
+		query, err = handleVulnerabilityOptions(s.db, query, *vuln)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if groupBy == CountByEcosystem {
+		query = query.
+			Joins("JOIN affected_package_handles aph ON aph.vulnerability_id = vulnerability_handles.id").
+			Joins("JOIN packages p ON p.id = aph.package_id")
+	}
+
+	return countRecords(s.db, query, s.blobStore, vulnerabilityCountColumns(), groupBy)
+}
+
 func (s *vulnerabilityStore) handlePreload(query *gorm.DB, config GetVulnerabilityOptions) *gorm.DB {
 	var limitArgs []any
 	if config.Limit > 0 {
@@ -370,6 +399,10 @@ func handleVulnerabilityOptions(base, parentQuery *gorm.DB, configs ...Vulnerabi
 			query = query.Where("vulnerability_handles.published_date > ?", *config.PublishedAfter)
 		}
 
+		if config.PublishedBefore != nil {
+			query = query.Where("vulnerability_handles.published_date < ?", *config.PublishedBefore)
+		}
+
 		if config.ModifiedAfter != nil {
 			query = query.Where("vulnerability_handles.modified_date > ?", *config.ModifiedAfter)
 		}
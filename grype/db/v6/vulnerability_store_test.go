@@ -394,3 +394,50 @@ func TestVulnerabilityStore_GetVulnerabilities_FilterByMultipleFactors(t *testin
 	require.Len(t, results, 1)
 	assert.Equal(t, vuln1.Name, results[0].Name)
 }
+
+func TestVulnerabilityStore_CountVulnerabilities(t *testing.T) {
+	db := setupTestStore(t).db
+	bs := newBlobStore(db)
+	s := newVulnerabilityStore(db, bs)
+	aps := newAffectedPackageStore(db, bs, newOperatingSystemStore(db, bs))
+
+	vuln1 := &VulnerabilityHandle{
+		Name:      "CVE-1234-5678",
+		Provider:  &Provider{ID: "provider1"},
+		BlobValue: &VulnerabilityBlob{ID: "CVE-1234-5678", Severities: []Severity{{Scheme: SeveritySchemeCHMLN, Value: "high"}}},
+	}
+	vuln2 := &VulnerabilityHandle{
+		Name:      "CVE-2345-6789",
+		Provider:  &Provider{ID: "provider2"},
+		BlobValue: &VulnerabilityBlob{ID: "CVE-2345-6789"},
+	}
+	require.NoError(t, s.AddVulnerabilities(vuln1, vuln2))
+
+	require.NoError(t, aps.AddAffectedPackages(
+		&AffectedPackageHandle{Vulnerability: vuln1, Package: &Package{Name: "pkg1", Ecosystem: "npm"}, BlobValue: &PackageBlob{}},
+		&AffectedPackageHandle{Vulnerability: vuln1, Package: &Package{Name: "pkg2", Ecosystem: "npm"}, BlobValue: &PackageBlob{}},
+		&AffectedPackageHandle{Vulnerability: vuln1, Package: &Package{Name: "pkg3", Ecosystem: "python"}, BlobValue: &PackageBlob{}},
+		&AffectedPackageHandle{Vulnerability: vuln2, Package: &Package{Name: "pkg4", Ecosystem: "python"}, BlobValue: &PackageBlob{}},
+	))
+
+	got, err := s.CountVulnerabilities(nil, CountTotal)
+	require.NoError(t, err)
+	assert.Equal(t, []CountGroup{{Count: 2}}, got)
+
+	got, err = s.CountVulnerabilities(&VulnerabilitySpecifier{Providers: []string{"provider1"}}, CountByProvider)
+	require.NoError(t, err)
+	assert.Equal(t, []CountGroup{{Value: "provider1", Count: 1}}, got)
+
+	// vulnerabilities are counted once for each ecosystem they affect
+	got, err = s.CountVulnerabilities(nil, CountByEcosystem)
+	require.NoError(t, err)
+	assert.Equal(t, []CountGroup{{Value: "npm", Count: 1}, {Value: "python", Count: 2}}, got)
+
+	got, err = s.CountVulnerabilities(&VulnerabilitySpecifier{Name: vuln1.Name}, CountByVulnerability)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, 1, got[0].Count)
+	require.NotNil(t, got[0].Vulnerability)
+	assert.Equal(t, vuln1.Name, got[0].Vulnerability.Name)
+	assert.Equal(t, vuln1.BlobValue, got[0].Vulnerability.BlobValue)
+}
@@ -411,6 +411,9 @@ func Test_NewDistroFromRelease_Coverage(t *testing.T) {
 	// possible to comply with this test unless it is added manually to the "observed distros"
 	definedDistros.Remove(string(Windows))
 
+	// OpenBSD does not ship an os-release file, so it can only be provided by an SBOM or the --distro flag
+	definedDistros.Remove(string(OpenBSD))
+
 	tests := []struct {
 		Name         string
 		Type         Type
@@ -597,6 +600,11 @@ func Test_NewDistroFromRelease_Coverage(t *testing.T) {
 			Type:    Hummingbird,
 			Version: "20251124",
 		},
+		{
+			Name:    "testdata/os/freebsd",
+			Type:    FreeBSD,
+			Version: "14.1",
+		},
 	}
 
 	for _, tt := range tests {
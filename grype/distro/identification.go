@@ -0,0 +1,256 @@
+package distro
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/linux"
+)
+
+// IdentificationSource is where the distro of the scanned artifact was identified from.
+type IdentificationSource string
+
+const (
+	// IdentifiedFromRelease is the release information of the scanned artifact (e.g. /etc/os-release, or the distro
+	// reported within an SBOM)
+	IdentifiedFromRelease IdentificationSource = "os-release"
+	// IdentifiedFromOverride is the distro given by the user (--distro)
+	IdentifiedFromOverride IdentificationSource = "override"
+	// IdentifiedFromPackages is the distro shared by all packages (e.g. from the distro qualifiers of package URLs)
+	IdentifiedFromPackages IdentificationSource = "packages"
+)
+
+// Identification records how the distro of the scanned artifact was identified: the values detected (before any
+// alias is applied) and the evidence the distro was determined by.
+type Identification struct {
+	// Source is where the distro was identified from
+	Source IdentificationSource
+	// ID is the detected distro ID (e.g. the ID field of /etc/os-release)
+	ID string
+	// Version is the detected distro version (e.g. the VERSION_ID field of /etc/os-release)
+	Version string
+	// IDLike is the detected chain of distros the distro derives from (the ID_LIKE field of /etc/os-release)
+	IDLike []string
+	// Field is the release field the distro type was determined by ("ID", "ID_LIKE" or "NAME"), only set for releases
+	Field string
+	// Alias is the user alias the distro is matched as (nil when none applies)
+	Alias *Alias
+	// Distro is the distro matched as (nil when it could not be determined)
+	Distro *Distro
+}
+
+// AliasFile maps the IDs of custom distros (e.g. in-house derivatives of a supported distro) to the distro their
+// packages are matched as, e.g.
+//
+//	aliases:
+//	  - id: acmeos
+//	    as: rhel
+//	    versions:
+//	      "3": "9"
+type AliasFile struct {
+	Aliases Aliases `yaml:"aliases"`
+}
+
+// Aliases are the user aliases of custom distros.
+type Aliases []Alias
+
+// Alias maps a custom distro to the distro its packages are matched as.
+type Alias struct {
+	// ID is the ID of the custom distro (e.g. the ID field of /etc/os-release)
+	ID string `yaml:"id"`
+	// As is the ID of the distro matched as (e.g. "rhel")
+	As string `yaml:"as"`
+	// Versions maps versions of the custom distro (full or major versions) to versions of the distro matched as
+	Versions map[string]string `yaml:"versions,omitempty"`
+	// Version is the version matched as when the version is not within Versions (the detected version when empty)
+	Version string `yaml:"version,omitempty"`
+}
+
+// ReadAliasFile reads and validates the distro alias file at the given path.
+func ReadAliasFile(path string) (Aliases, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read distro alias file: %w", err)
+	}
+	var f AliasFile
+	if err := yaml.Unmarshal(by, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse distro alias file %q: %w", path, err)
+	}
+	if err := f.Aliases.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid distro alias file %q: %w", path, err)
+	}
+	return f.Aliases, nil
+}
+
+// Validate ensures each alias maps a distinct ID to a supported distro.
+func (a Aliases) Validate() error {
+	var seen []string
+	for i, alias := range a {
+		id := strings.ToLower(strings.TrimSpace(alias.ID))
+		if id == "" {
+			return fmt.Errorf("alias %d: id is required", i+1)
+		}
+		if slices.Contains(seen, id) {
+			return fmt.Errorf("alias %d: duplicate id %q", i+1, alias.ID)
+		}
+		seen = append(seen, id)
+		if typeOfID(alias.As) == "" {
+			return fmt.Errorf("alias %d: unsupported distro %q to match %q as", i+1, alias.As, alias.ID)
+		}
+	}
+	return nil
+}
+
+// find returns the alias of the given distro ID (nil when none).
+func (a Aliases) find(id string) *Alias {
+	for i := range a {
+		if strings.EqualFold(strings.TrimSpace(a[i].ID), strings.TrimSpace(id)) {
+			return &a[i]
+		}
+	}
+	return nil
+}
+
+// version returns the version matched as for the given version of the custom distro.
+func (a Alias) version(v string) string {
+	if mapped, ok := a.Versions[v]; ok {
+		return mapped
+	}
+	if major, _, _ := strings.Cut(v, "."); major != v {
+		if mapped, ok := a.Versions[major]; ok {
+			return mapped
+		}
+	}
+	if a.Version != "" {
+		return a.Version
+	}
+	return v
+}
+
+// Identify returns the distro of the given release (after applying the aliases), along with how it was identified.
+// Both are nil without a release; errors are only logged (see FromRelease).
+func Identify(release *linux.Release, aliases Aliases, channels []FixChannel) (*Distro, *Identification) {
+	if release == nil {
+		return nil, nil
+	}
+	id := &Identification{
+		Source:  IdentifiedFromRelease,
+		ID:      release.ID,
+		Version: release.VersionID,
+	}
+	for _, like := range release.IDLike {
+		if like = strings.TrimSpace(like); like != "" {
+			id.IDLike = append(id.IDLike, like)
+		}
+	}
+	if id.ID == "" {
+		// releases without an ID (e.g. of older SBOMs) are identified by name
+		id.ID = release.Name
+	}
+	if id.Version == "" {
+		id.Version = release.Version
+	}
+
+	r := *release
+	if alias := aliases.find(release.ID); alias != nil {
+		id.Alias = alias
+		r.ID = idOf(alias.As)
+		if v := alias.version(id.Version); v != id.Version {
+			// the version (and codename) of the custom distro do not apply to the distro matched as
+			r.VersionID, r.Version, r.VersionCodename = v, "", ""
+		}
+	}
+	_, id.Field = typeFromRelease(r)
+
+	id.Distro = FromRelease(&r, channels)
+	return id.Distro, id
+}
+
+// IdentifyOverride returns the given user distro (after applying the aliases), along with its identification.
+func IdentifyOverride(d *Distro, aliases Aliases) (*Distro, *Identification) {
+	if d == nil {
+		return nil, nil
+	}
+	id := &Identification{
+		Source:  IdentifiedFromOverride,
+		ID:      idOrName(*d),
+		Version: d.VersionString(),
+		IDLike:  d.IDLike,
+		Distro:  d,
+	}
+	id.Distro, id.Alias = aliases.apply(d)
+	return id.Distro, id
+}
+
+// Apply returns the distro the given distro is matched as: the distro of its alias, otherwise the distro itself.
+func (a Aliases) Apply(d *Distro) *Distro {
+	d, _ = a.apply(d)
+	return d
+}
+
+func (a Aliases) apply(d *Distro) (*Distro, *Alias) {
+	if d == nil {
+		return nil, nil
+	}
+	alias := a.find(idOrName(*d))
+	if alias == nil {
+		return d, nil
+	}
+	version := d.Version
+	if version == "" {
+		version = d.Codename
+	}
+	aliased := NewFromNameVersion(idOf(alias.As), alias.version(version))
+	aliased.Channels = d.Channels
+	log.WithFields("distro", d.String(), "as", aliased.String()).Debug("applying distro alias")
+	return aliased, alias
+}
+
+// IdentifyFromPackages returns the identification of the distro shared by all packages.
+func IdentifyFromPackages(d *Distro) *Identification {
+	if d == nil {
+		return nil
+	}
+	return &Identification{
+		Source:  IdentifiedFromPackages,
+		ID:      idOrName(*d),
+		Version: d.VersionString(),
+		IDLike:  d.IDLike,
+		Distro:  d,
+	}
+}
+
+// typeOfID returns the distro type of the given distro ID or type name (e.g. "rhel" or "redhat").
+func typeOfID(id string) Type {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if t, ok := IDMapping[id]; ok {
+		return t
+	}
+	if slices.Contains(All, Type(id)) {
+		return Type(id)
+	}
+	return ""
+}
+
+// idOf returns the distro ID of the given distro ID or type name (e.g. "rhel" for "redhat").
+func idOf(name string) string {
+	t := typeOfID(name)
+	if id, ok := typeToIDMapping[t]; ok {
+		return id
+	}
+	return string(t)
+}
+
+// idOrName returns the ID of the distro, or its name for distros without a known ID (e.g. custom distros).
+func idOrName(d Distro) string {
+	if id := d.ID(); id != "" {
+		return id
+	}
+	return d.Name()
+}
@@ -0,0 +1,154 @@
+package distro
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/linux"
+)
+
+func TestReadAliasFile(t *testing.T) {
+	aliases, err := ReadAliasFile("testdata/aliases/valid.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, Aliases{
+		{ID: "acmeos", As: "rhel", Versions: map[string]string{"3": "9"}},
+		{ID: "widgetlinux", As: "debian", Version: "12"},
+	}, aliases)
+
+	_, err = ReadAliasFile("testdata/aliases/unsupported.yaml")
+	assert.ErrorContains(t, err, `unsupported distro "acmebsd"`)
+
+	_, err = ReadAliasFile("testdata/aliases/missing.yaml")
+	assert.ErrorContains(t, err, "unable to read distro alias file")
+}
+
+func TestAliases_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases Aliases
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			aliases: Aliases{{ID: "acmeos", As: "rhel"}, {ID: "widgetlinux", As: "redhat"}},
+		},
+		{
+			name:    "missing id",
+			aliases: Aliases{{As: "rhel"}},
+			wantErr: "alias 1: id is required",
+		},
+		{
+			name:    "duplicate id",
+			aliases: Aliases{{ID: "acmeos", As: "rhel"}, {ID: "AcmeOS", As: "debian"}},
+			wantErr: `alias 2: duplicate id "AcmeOS"`,
+		},
+		{
+			name:    "unsupported distro",
+			aliases: Aliases{{ID: "acmeos", As: "acmebsd"}},
+			wantErr: `alias 1: unsupported distro "acmebsd"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.aliases.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	aliases := Aliases{
+		{ID: "acmeos", As: "rhel", Versions: map[string]string{"3": "9"}},
+		{ID: "widgetlinux", As: "debian", Version: "12"},
+	}
+	tests := []struct {
+		name        string
+		release     *linux.Release
+		wantType    Type
+		wantVersion string
+		wantField   string
+		wantAlias   string
+	}{
+		{
+			name:        "supported distro",
+			release:     &linux.Release{ID: "ubuntu", VersionID: "22.04", IDLike: []string{"debian"}},
+			wantType:    Ubuntu,
+			wantVersion: "22.04",
+			wantField:   "ID",
+		},
+		{
+			name:        "derivative matched by ID_LIKE",
+			release:     &linux.Release{ID: "somederivative", VersionID: "9.2", IDLike: []string{"rhel"}},
+			wantType:    RedHat,
+			wantVersion: "9.2",
+			wantField:   "ID_LIKE",
+		},
+		{
+			name:        "alias with version mapped by major version",
+			release:     &linux.Release{ID: "acmeos", VersionID: "3.1", VersionCodename: "roadrunner"},
+			wantType:    RedHat,
+			wantVersion: "9",
+			wantField:   "ID",
+			wantAlias:   "acmeos",
+		},
+		{
+			name:        "alias with default version",
+			release:     &linux.Release{ID: "widgetlinux", VersionID: "1"},
+			wantType:    Debian,
+			wantVersion: "12",
+			wantField:   "ID",
+			wantAlias:   "widgetlinux",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, id := Identify(tt.release, aliases, nil)
+			require.NotNil(t, d)
+			require.NotNil(t, id)
+			assert.Same(t, d, id.Distro)
+			assert.Equal(t, tt.wantType, d.Type)
+			assert.Equal(t, tt.wantVersion, d.Version)
+
+			assert.Equal(t, IdentifiedFromRelease, id.Source)
+			assert.Equal(t, tt.release.ID, id.ID)
+			assert.Equal(t, tt.release.VersionID, id.Version)
+			assert.Equal(t, tt.wantField, id.Field)
+			if tt.wantAlias == "" {
+				assert.Nil(t, id.Alias)
+			} else {
+				require.NotNil(t, id.Alias)
+				assert.Equal(t, tt.wantAlias, id.Alias.ID)
+			}
+		})
+	}
+
+	d, id := Identify(nil, aliases, nil)
+	assert.Nil(t, d)
+	assert.Nil(t, id)
+}
+
+func TestIdentifyOverride(t *testing.T) {
+	aliases := Aliases{{ID: "acmeos", As: "rhel", Versions: map[string]string{"3": "9"}}}
+
+	d, id := IdentifyOverride(NewFromNameVersion("acmeos", "3"), aliases)
+	require.NotNil(t, d)
+	assert.Equal(t, RedHat, d.Type)
+	assert.Equal(t, "9", d.Version)
+	assert.Equal(t, IdentifiedFromOverride, id.Source)
+	assert.Equal(t, "acmeos", id.ID)
+	assert.Equal(t, "3", id.Version)
+	require.NotNil(t, id.Alias)
+	assert.Equal(t, "rhel", id.Alias.As)
+
+	override := NewFromNameVersion("alpine", "3.19")
+	d, id = IdentifyOverride(override, aliases)
+	assert.Same(t, override, d)
+	assert.Equal(t, "alpine", id.ID)
+	assert.Nil(t, id.Alias)
+}
@@ -38,6 +38,8 @@ const (
 	SecureOS     Type = "secureos"
 	PostmarketOS Type = "postmarketos"
 	Hummingbird  Type = "hummingbird"
+	FreeBSD      Type = "freebsd"
+	OpenBSD      Type = "openbsd"
 )
 
 // All contains all Linux distribution options
@@ -70,6 +72,8 @@ var All = []Type{
 	SecureOS,
 	PostmarketOS,
 	Hummingbird,
+	FreeBSD,
+	OpenBSD,
 }
 
 // IDMapping maps a distro ID from the /etc/os-release (e.g. like "ubuntu") to a Distro type.
@@ -101,6 +105,8 @@ var IDMapping = map[string]Type{
 	"secureos":      SecureOS,
 	"postmarketos":  PostmarketOS,
 	"hummingbird":   Hummingbird,
+	"freebsd":       FreeBSD,
+	"openbsd":       OpenBSD,
 }
 
 // aliasTypes maps common aliases to their corresponding Type.
@@ -122,35 +128,41 @@ func init() {
 }
 
 func TypeFromRelease(release linux.Release) Type {
+	t, _ := typeFromRelease(release)
+	return t
+}
+
+// typeFromRelease returns the distro type of the release along with the release field it was determined by.
+func typeFromRelease(release linux.Release) (Type, string) {
 	// first try the release ID
 	if t, ok := IDMapping[release.ID]; ok {
-		return t
+		return t, "ID"
 	}
 
 	if t, ok := aliasTypes[release.ID]; ok {
-		return t
+		return t, "ID"
 	}
 
 	// use ID_LIKE as a backup
 	for _, l := range release.IDLike {
 		if t, ok := IDMapping[l]; ok {
-			return t
+			return t, "ID_LIKE"
 		}
 		if t, ok := aliasTypes[l]; ok {
-			return t
+			return t, "ID_LIKE"
 		}
 	}
 
 	// then try the release name as a fallback
 	if t, ok := IDMapping[release.Name]; ok {
-		return t
+		return t, "NAME"
 	}
 
 	if t, ok := aliasTypes[release.Name]; ok {
-		return t
+		return t, "NAME"
 	}
 
-	return ""
+	return "", ""
 }
 
 // String returns the string representation of the given Linux distribution.
@@ -14,6 +14,10 @@ const (
 	VulnerabilityScanningStarted partybus.EventType = typePrefix + "-vulnerability-scanning-started"
 	DatabaseDiffingStarted       partybus.EventType = typePrefix + "-database-diffing-started"
 
+	// VulnerabilityMatchesDiscovered is a partybus event that occurs when the final matches for a package are ready to
+	// be presented (only published when the table rows are streamed)
+	VulnerabilityMatchesDiscovered partybus.EventType = typePrefix + "-vulnerability-matches-discovered"
+
 	// Events exclusively for the CLI
 
 	// CLIAppUpdateAvailable is a partybus event that occurs when an application update is available
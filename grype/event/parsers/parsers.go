@@ -8,6 +8,7 @@ import (
 
 	"github.com/anchore/grype/grype/event"
 	"github.com/anchore/grype/grype/event/monitor"
+	"github.com/anchore/grype/grype/presenter/models"
 )
 
 type ErrBadPayload struct {
@@ -74,6 +75,19 @@ func ParseDatabaseDiffingStarted(e partybus.Event) (*monitor.DBDiff, error) {
 	return &mon, nil
 }
 
+func ParseVulnerabilityMatchesDiscovered(e partybus.Event) ([]models.Match, error) {
+	if err := checkEventType(e.Type, event.VulnerabilityMatchesDiscovered); err != nil {
+		return nil, err
+	}
+
+	matches, ok := e.Value.([]models.Match)
+	if !ok {
+		return nil, newPayloadErr(e.Type, "Value", e.Value)
+	}
+
+	return matches, nil
+}
+
 type UpdateCheck struct {
 	New     string
 	Current string
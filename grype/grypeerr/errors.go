@@ -5,6 +5,26 @@ var (
 	// or above the given --fail-on severity value.
 	ErrAboveSeverityThreshold = NewExpectedErr("discovered vulnerabilities at or above the severity threshold")
 
+	// ErrLicensePolicyViolation indicates when a package license is discovered that does not conform to the
+	// configured license policy (and the policy is configured to fail on violations).
+	ErrLicensePolicyViolation = NewExpectedErr("discovered package licenses that violate the license policy")
+
+	// ErrSLAGracePeriodExceeded indicates when a vulnerability has been public (or fixable) for longer than the
+	// grace period configured for its severity.
+	ErrSLAGracePeriodExceeded = NewExpectedErr("discovered vulnerabilities that exceed the SLA grace period for their severity")
+
+	// ErrUnusedIgnoreRules indicates when configured ignore rules did not ignore any vulnerability (and unused ignore
+	// rules are configured to fail the scan).
+	ErrUnusedIgnoreRules = NewExpectedErr("configured ignore rules did not ignore any vulnerability")
+
+	// ErrDBVerificationFailed indicates that the installed DB does not match the digest captured on import or the
+	// latest distribution metadata (cmd: db status --verify).
+	ErrDBVerificationFailed = NewExpectedErr("db verification failed")
+
+	// ErrExpectationsNotMet indicates that the results of a scan do not meet the expectations of an expectations file
+	// (cmd: assert).
+	ErrExpectationsNotMet = NewExpectedErr("scan results do not meet the expectations")
+
 	// ErrDBUpgradeAvailable indicates that a DB upgrade is available.
 	ErrDBUpgradeAvailable = NewExpectedErr("db upgrade available")
 )
@@ -0,0 +1,36 @@
+package grypeerr
+
+import "errors"
+
+// ExitCode returns the process exit code for the error returned by a command.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	// return exit code 2 to indicate when a vulnerability severity is discovered
+	// that is equal or above the given --fail-on severity value.
+	if errors.Is(err, ErrAboveSeverityThreshold) {
+		return 2
+	}
+	// return exit code 2 to indicate when a vulnerability exceeds the SLA grace period for its severity.
+	if errors.Is(err, ErrSLAGracePeriodExceeded) {
+		return 2
+	}
+	// return exit code 2 to indicate when a package license violates the license policy (when configured to fail).
+	if errors.Is(err, ErrLicensePolicyViolation) {
+		return 2
+	}
+	// return exit code 2 to indicate when configured ignore rules did not ignore any vulnerability (when configured to fail).
+	if errors.Is(err, ErrUnusedIgnoreRules) {
+		return 2
+	}
+	// return exit code 2 to indicate when scan results do not meet the expectations (cmd: assert).
+	if errors.Is(err, ErrExpectationsNotMet) {
+		return 2
+	}
+	// return exit code 100 to indicate a DB upgrade is available (cmd: db check).
+	if errors.Is(err, ErrDBUpgradeAvailable) {
+		return 100
+	}
+	return 1
+}
@@ -0,0 +1,31 @@
+package grypeerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no error", err: nil, want: 0},
+		{name: "unexpected error", err: errors.New("failed to catalog"), want: 1},
+		{name: "severity threshold", err: ErrAboveSeverityThreshold, want: 2},
+		{name: "wrapped SLA breach", err: fmt.Errorf("scan: %w", ErrSLAGracePeriodExceeded), want: 2},
+		{name: "multiple errors", err: multierror.Append(nil, errors.New("unable to push results"), ErrLicensePolicyViolation), want: 2},
+		{name: "unused ignore rules", err: ErrUnusedIgnoreRules, want: 2},
+		{name: "db upgrade", err: ErrDBUpgradeAvailable, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
@@ -21,6 +21,7 @@ var metadataExceptions = strset.New(
 	"PURLLiteralMetadata",
 	"CPELiteralMetadata",
 	"ZarfPackageMetadata",
+	"FunctionArchiveMetadata",
 )
 
 func DiscoverTypeNames() ([]string, error) {
@@ -18,6 +18,7 @@ var jsonNameFromType = map[reflect.Type][]string{
 	reflect.TypeFor[pkg.GolangModMetadata]():          nameList("GolangModMetadata"),
 	reflect.TypeFor[pkg.GolangSourceMetadata]():       nameList("GolangSourceMetadata"),
 	reflect.TypeFor[pkg.JavaMetadata]():               nameList("JavaMetadata"),
+	reflect.TypeFor[pkg.PythonMetadata]():             nameList("PythonMetadata"),
 	reflect.TypeFor[pkg.RpmMetadata]():                nameList("RpmMetadata"),
 	reflect.TypeFor[pkg.JavaVMInstallationMetadata](): nameList("JavaVMInstallationMetadata"),
 }
@@ -0,0 +1,121 @@
+package license
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/github/go-spdx/v2/spdxexp"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/internal/log"
+)
+
+// Policy describes which licenses are acceptable for packages. Entries are SPDX license IDs (e.g. "MIT", "GPL-3.0-only")
+// and license expressions found on packages are evaluated against the policy, so a package licensed "MIT OR GPL-3.0-only"
+// is acceptable when only "GPL-3.0-only" is denied.
+type Policy struct {
+	// Allow is the set of licenses that packages may use. When empty all licenses not explicitly denied are allowed.
+	Allow []string
+	// Deny is the set of licenses that packages may not use.
+	Deny []string
+}
+
+// Violation describes a single package license that does not conform to the Policy.
+type Violation struct {
+	Package pkg.Package
+	License string
+	Reason  string
+}
+
+// IsEmpty indicates if the policy has no rules to evaluate.
+func (p Policy) IsEmpty() bool {
+	return len(p.Allow) == 0 && len(p.Deny) == 0
+}
+
+// Evaluate checks every license on each package against the policy, returning all violations found.
+func (p Policy) Evaluate(pkgs []pkg.Package) []Violation {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	var violations []Violation
+	for _, pk := range pkgs {
+		for _, l := range pk.Licenses {
+			reason := p.evaluate(l)
+			if reason == "" {
+				continue
+			}
+			violations = append(violations, Violation{
+				Package: pk,
+				License: l,
+				Reason:  reason,
+			})
+		}
+	}
+	return violations
+}
+
+// evaluate returns the reason the given license (or license expression) violates the policy, or an empty string if it does not.
+func (p Policy) evaluate(license string) string {
+	license = strings.TrimSpace(license)
+	if license == "" {
+		return ""
+	}
+
+	ids, err := spdxexp.ExtractLicenses(license)
+	if err != nil {
+		// this is not a valid SPDX expression, so the best we can do is compare the raw value
+		log.WithFields("license", license, "error", err).Trace("unable to parse license as SPDX expression")
+		return p.evaluateRaw(license)
+	}
+
+	if len(p.Deny) > 0 {
+		// the expression is acceptable if it can be satisfied without using any denied license
+		var permitted []string
+		for _, id := range ids {
+			if !containsFold(p.Deny, id) {
+				permitted = append(permitted, id)
+			}
+		}
+		if !satisfies(license, permitted) {
+			return fmt.Sprintf("license requires a denied license (denied: %s)", strings.Join(p.Deny, ", "))
+		}
+	}
+
+	if len(p.Allow) > 0 && !satisfies(license, p.Allow) {
+		return "license is not satisfied by the allowed licenses"
+	}
+
+	return ""
+}
+
+func (p Policy) evaluateRaw(license string) string {
+	if containsFold(p.Deny, license) {
+		return "license is denied"
+	}
+	if len(p.Allow) > 0 && !containsFold(p.Allow, license) {
+		return "license is not in the allowed licenses"
+	}
+	return ""
+}
+
+func satisfies(expression string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return false
+	}
+	ok, err := spdxexp.Satisfies(expression, allowed)
+	if err != nil {
+		log.WithFields("license", expression, "error", err).Trace("unable to evaluate license expression")
+		return false
+	}
+	return ok
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
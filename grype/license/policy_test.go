@@ -0,0 +1,67 @@
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/pkg"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   Policy
+		licenses []string
+		want     []string
+	}{
+		{
+			name:     "empty policy allows everything",
+			policy:   Policy{},
+			licenses: []string{"GPL-3.0-only"},
+		},
+		{
+			name:     "denied license",
+			policy:   Policy{Deny: []string{"GPL-3.0-only"}},
+			licenses: []string{"MIT", "GPL-3.0-only"},
+			want:     []string{"GPL-3.0-only"},
+		},
+		{
+			name:     "denied license with an alternative in the expression",
+			policy:   Policy{Deny: []string{"GPL-3.0-only"}},
+			licenses: []string{"MIT OR GPL-3.0-only"},
+		},
+		{
+			name:     "denied license required by the expression",
+			policy:   Policy{Deny: []string{"GPL-3.0-only"}},
+			licenses: []string{"MIT AND GPL-3.0-only"},
+			want:     []string{"MIT AND GPL-3.0-only"},
+		},
+		{
+			name:     "allowed licenses",
+			policy:   Policy{Allow: []string{"MIT", "Apache-2.0"}},
+			licenses: []string{"MIT", "Apache-2.0 OR GPL-2.0-only", "BSD-3-Clause"},
+			want:     []string{"BSD-3-Clause"},
+		},
+		{
+			name:     "non-SPDX license values are compared directly",
+			policy:   Policy{Allow: []string{"MIT"}, Deny: []string{"Proprietary License"}},
+			licenses: []string{"proprietary license", "Some Custom License"},
+			want:     []string{"proprietary license", "Some Custom License"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := pkg.Package{Name: "test", Licenses: tt.licenses}
+
+			var got []string
+			for _, v := range tt.policy.Evaluate([]pkg.Package{p}) {
+				assert.NotEmpty(t, v.Reason)
+				got = append(got, v.License)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
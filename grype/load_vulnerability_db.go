@@ -20,7 +20,10 @@ func LoadVulnerabilityDB(distCfg v6dist.Config, installCfg v6inst.Config, update
 		return nil, nil, fmt.Errorf("unable to create curator: %w", err)
 	}
 
-	if update {
+	if update && installCfg.PostgresDSN != "" {
+		// the shared DB is published once (by 'grype db update') instead of by every scanner reading it
+		log.Debug("skipping db update for postgres backend")
+	} else if update {
 		updated, err := c.Update()
 		if err != nil {
 			if distCfg.RequireUpdateCheck {
@@ -0,0 +1,133 @@
+package grype
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/packageurl-go"
+)
+
+// findMaliciousArtifactMatches looks up the package URLs and artifact digests of the given packages against the
+// known-malicious package artifacts of the vulnerability provider (when it supports this, see
+// vulnerability.MaliciousArtifactProvider), returning the resulting matches keyed by package. This finds malicious
+// packages regardless of their name and version, e.g. a renamed artifact or a package with an unknown version.
+func (m *VulnerabilityMatcher) findMaliciousArtifactMatches(packages []pkg.Package, pkgContext pkg.Context) map[pkg.ID][]match.Match {
+	if !m.DetectMaliciousPackages {
+		return nil
+	}
+	provider, ok := m.VulnerabilityProvider.(vulnerability.MaliciousArtifactProvider)
+	if !ok {
+		log.Debug("vulnerability provider does not support looking up malicious artifacts")
+		return nil
+	}
+
+	byPURL := make(map[string][]pkg.Package)
+	byDigest := make(map[string][]pkg.Package)
+	for _, p := range packages {
+		if purl := artifactPURL(p); purl != "" {
+			byPURL[purl] = append(byPURL[purl], p)
+		}
+		for _, d := range pkgContext.ArtifactDigests[p.ID] {
+			byDigest[d] = append(byDigest[d], p)
+		}
+	}
+	if len(byPURL)+len(byDigest) == 0 {
+		return nil
+	}
+
+	artifacts, err := provider.MaliciousArtifacts(sortedKeys(byPURL), sortedKeys(byDigest))
+	if err != nil {
+		log.WithFields("error", err).Warn("unable to look up malicious artifacts")
+		return nil
+	}
+
+	records := make(map[string][]vulnerability.Vulnerability)
+	out := make(map[pkg.ID][]match.Match)
+	for _, a := range artifacts {
+		for _, p := range slices.Concat(byPURL[a.PURL], byDigest[a.Digest]) {
+			if hasMaliciousArtifactMatch(out[p.ID], a.ID) {
+				continue
+			}
+			v, ok := m.maliciousPackageRecord(records, a.ID, p)
+			if !ok {
+				continue
+			}
+			out[p.ID] = append(out[p.ID], match.NewMaliciousArtifactMatch(v, p, a))
+		}
+	}
+	return out
+}
+
+// maliciousPackageRecord returns the vulnerability record of the malicious package record with the given ID (for the
+// given package when the record lists several packages), caching the records by ID.
+func (m *VulnerabilityMatcher) maliciousPackageRecord(records map[string][]vulnerability.Vulnerability, id string, p pkg.Package) (vulnerability.Vulnerability, bool) {
+	vulns, ok := records[id]
+	if !ok {
+		var err error
+		vulns, err = m.VulnerabilityProvider.FindVulnerabilities(search.ByID(id))
+		if err != nil {
+			log.WithFields("error", err, "vulnerability", id).Debug("unable to fetch malicious package record")
+		}
+		records[id] = vulns
+	}
+	if len(vulns) == 0 {
+		log.WithFields("vulnerability", id, "package", displayPackage(p)).Debug("malicious package record not found")
+		return vulnerability.Vulnerability{}, false
+	}
+	for _, v := range vulns {
+		if v.PackageName == p.Name {
+			return v, true
+		}
+	}
+	return vulns[0], true
+}
+
+// newMaliciousArtifactMatches returns the malicious artifact matches of a package against the records the package was
+// not already matched against (by name and version).
+func newMaliciousArtifactMatches(packageMatches, artifactMatches []match.Match) []match.Match {
+	var out []match.Match
+	for _, am := range artifactMatches {
+		if !hasMaliciousArtifactMatch(packageMatches, am.Vulnerability.ID) {
+			out = append(out, am)
+		}
+	}
+	return out
+}
+
+func hasMaliciousArtifactMatch(matches []match.Match, id string) bool {
+	for _, mt := range matches {
+		if mt.Vulnerability.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactPURL returns the versioned package URL of the given package without qualifiers or subpath, which is how
+// malicious artifacts are identified (empty when the package has no versioned package URL).
+func artifactPURL(p pkg.Package) string {
+	if p.PURL == "" {
+		return ""
+	}
+	purl, err := packageurl.FromString(p.PURL)
+	if err != nil || purl.Version == "" {
+		return ""
+	}
+	purl.Qualifiers = nil
+	purl.Subpath = ""
+	return purl.ToString()
+}
+
+func sortedKeys(m map[string][]pkg.Package) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
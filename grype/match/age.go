@@ -0,0 +1,46 @@
+package match
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// now is the current time, overridable for testing age-based ignore rules
+var now = time.Now
+
+// ageUnits are the units of ages expressed as a whole number of days (e.g. "30d") or weeks (e.g. "2w").
+var ageUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{suffix: "d", unit: 24 * time.Hour},
+	{suffix: "w", unit: 7 * 24 * time.Hour},
+}
+
+// ParseAge parses an age expressed either as a whole number of days (e.g. "30d") or weeks (e.g. "2w"), or as a Go
+// duration (e.g. "72h").
+func ParseAge(age string) (time.Duration, error) {
+	age = strings.TrimSpace(age)
+	for _, u := range ageUnits {
+		count, ok := strings.CutSuffix(age, u.suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q: expected a non-negative number of days (e.g. 30d) or weeks (e.g. 2w)", age)
+		}
+		return time.Duration(n) * u.unit, nil
+	}
+
+	d, err := time.ParseDuration(age)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q: expected a number of days (e.g. 30d), weeks (e.g. 2w) or a duration (e.g. 72h)", age)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid age %q: must not be negative", age)
+	}
+	return d, nil
+}
@@ -0,0 +1,110 @@
+package match
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		age      string
+		expected time.Duration
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{age: "30d", expected: 30 * 24 * time.Hour},
+		{age: " 0d ", expected: 0},
+		{age: "2w", expected: 14 * 24 * time.Hour},
+		{age: "72h", expected: 72 * time.Hour},
+		{age: "1h30m", expected: 90 * time.Minute},
+		{age: "-1d", wantErr: require.Error},
+		{age: "1.5w", wantErr: require.Error},
+		{age: "-5h", wantErr: require.Error},
+		{age: "thirty days", wantErr: require.Error},
+		{age: "", wantErr: require.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			actual, err := ParseAge(tt.age)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIgnoreRule_FixAvailableWithin(t *testing.T) {
+	current := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	original := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = original })
+
+	withFix := func(state vulnerability.FixState, dates ...time.Time) Match {
+		m := Match{}
+		m.Vulnerability.Fix.State = state
+		for _, d := range dates {
+			m.Vulnerability.Fix.Available = append(m.Vulnerability.Fix.Available, vulnerability.FixAvailable{Version: "1.0", Date: d})
+		}
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		match    Match
+		age      string
+		expected bool
+	}{
+		{
+			name:     "fix is newer than the age",
+			match:    withFix(vulnerability.FixStateFixed, current.AddDate(0, 0, -10)),
+			age:      "30d",
+			expected: true,
+		},
+		{
+			name:     "fix is older than the age",
+			match:    withFix(vulnerability.FixStateFixed, current.AddDate(0, 0, -45)),
+			age:      "30d",
+			expected: false,
+		},
+		{
+			name:     "earliest fix date is used",
+			match:    withFix(vulnerability.FixStateFixed, current.AddDate(0, 0, -5), current.AddDate(0, 0, -45)),
+			age:      "30d",
+			expected: false,
+		},
+		{
+			name:     "fixed without a known date",
+			match:    withFix(vulnerability.FixStateFixed),
+			age:      "30d",
+			expected: false,
+		},
+		{
+			name:     "not fixed",
+			match:    withFix(vulnerability.FixStateNotFixed, current.AddDate(0, 0, -1)),
+			age:      "30d",
+			expected: false,
+		},
+		{
+			name:     "invalid age never applies",
+			match:    withFix(vulnerability.FixStateFixed, current.AddDate(0, 0, -1)),
+			age:      "soon",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := IgnoreRule{FixAvailableWithin: tt.age}
+			assert.Equal(t, tt.expected, len(rule.IgnoreMatch(tt.match)) > 0)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package match
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// BaselineFinding is a finding of a previous scan.
+type BaselineFinding struct {
+	// VulnerabilityIDs are the ID of the vulnerability along with the IDs of related vulnerabilities (e.g. the CVE of a
+	// GHSA), such that findings are recognized regardless of whether they were normalized by CVE.
+	VulnerabilityIDs []string
+	PackageName      string
+	PackageType      syftPkg.Type
+	// Identities are the hashed identities of the finding (see BaselineIdentity). When set they are used instead of the
+	// identities derived from the vulnerability IDs, e.g. for findings read from a baseline file.
+	Identities []string
+}
+
+// identities returns the hashed identities the finding is recognized by.
+func (f BaselineFinding) identities() []string {
+	if len(f.Identities) > 0 {
+		return f.Identities
+	}
+	var ids []string
+	for _, id := range f.VulnerabilityIDs {
+		ids = append(ids, BaselineIdentity(id, f.PackageName, f.PackageType))
+	}
+	return ids
+}
+
+// Baseline is the set of findings of a previous scan, distinguishing new findings from findings that were already
+// known. Findings are keyed by vulnerability and package (not package version), so a finding remains known when the
+// package is upgraded to another affected version.
+type Baseline struct {
+	findings   []BaselineFinding
+	identities map[string]struct{}
+}
+
+// NewBaseline returns a baseline of the given findings.
+func NewBaseline(findings ...BaselineFinding) *Baseline {
+	b := &Baseline{findings: findings, identities: map[string]struct{}{}}
+	for _, f := range findings {
+		for _, id := range f.identities() {
+			b.identities[id] = struct{}{}
+		}
+	}
+	return b
+}
+
+// BaselineIdentity returns the hashed identity of the finding of a vulnerability in a package. The identity does not
+// depend on the package version nor on the case of the vulnerability ID.
+func BaselineIdentity(vulnerabilityID, packageName string, packageType syftPkg.Type) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{strings.ToLower(vulnerabilityID), packageName, string(packageType)}, "\x00")))
+	return hex.EncodeToString(h[:16])
+}
+
+// BaselineIdentities returns the hashed identities of the match: the identity by the vulnerability followed by the
+// identities by related vulnerabilities.
+func BaselineIdentities(m Match) []string {
+	ids := []string{BaselineIdentity(m.Vulnerability.ID, m.Package.Name, m.Package.Type)}
+	for _, related := range m.Vulnerability.RelatedVulnerabilities {
+		ids = append(ids, BaselineIdentity(related.ID, m.Package.Name, m.Package.Type))
+	}
+	return ids
+}
+
+// Len returns the number of findings in the baseline.
+func (b *Baseline) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.findings)
+}
+
+// Contains indicates if the match is a finding of the baseline.
+func (b *Baseline) Contains(m Match) bool {
+	if b == nil {
+		return false
+	}
+	for _, id := range BaselineIdentities(m) {
+		if _, ok := b.identities[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Stale returns the findings of the baseline that none of the given matches are a finding of, e.g. since the
+// vulnerable package was upgraded or removed. Stale findings can be dropped from the baseline.
+func (b *Baseline) Stale(matches []Match) []BaselineFinding {
+	if b == nil {
+		return nil
+	}
+	found := map[string]struct{}{}
+	for _, m := range matches {
+		for _, id := range BaselineIdentities(m) {
+			found[id] = struct{}{}
+		}
+	}
+
+	var stale []BaselineFinding
+	for _, f := range b.findings {
+		seen := false
+		for _, id := range f.identities() {
+			if _, ok := found[id]; ok {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			stale = append(stale, f)
+		}
+	}
+	return stale
+}
+
+// SplitBaseline partitions the given matches into the matches not in the baseline (new findings) and the matches in
+// the baseline.
+func SplitBaseline(matches Matches, baseline *Baseline) (Matches, []Match) {
+	added := NewMatches()
+	var known []Match
+	for _, m := range matches.Sorted() {
+		if baseline.Contains(m) {
+			known = append(known, m)
+			continue
+		}
+		added.Add(m)
+	}
+	return added, known
+}
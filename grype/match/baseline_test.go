@@ -0,0 +1,142 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestBaseline_Contains(t *testing.T) {
+	baseline := NewBaseline(
+		BaselineFinding{VulnerabilityIDs: []string{"GHSA-abcd-1234-wxyz", "CVE-2023-1"}, PackageName: "requests", PackageType: syftPkg.PythonPkg},
+		BaselineFinding{VulnerabilityIDs: []string{"CVE-2023-2"}, PackageName: "openssl", PackageType: syftPkg.ApkPkg},
+	)
+
+	newMatch := func(id, name string, pkgType syftPkg.Type, related ...string) Match {
+		var refs []vulnerability.Reference
+		for _, r := range related {
+			refs = append(refs, vulnerability.Reference{ID: r})
+		}
+		return Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference:              vulnerability.Reference{ID: id},
+				RelatedVulnerabilities: refs,
+			},
+			Package: pkg.Package{ID: pkg.ID(uuid.NewString()), Name: name, Type: pkgType},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		match Match
+		want  bool
+	}{
+		{
+			name:  "same finding",
+			match: newMatch("CVE-2023-2", "openssl", syftPkg.ApkPkg),
+			want:  true,
+		},
+		{
+			name:  "vulnerability ID case is ignored",
+			match: newMatch("ghsa-ABCD-1234-wxyz", "requests", syftPkg.PythonPkg),
+			want:  true,
+		},
+		{
+			name:  "normalized by CVE",
+			match: newMatch("CVE-2023-1", "requests", syftPkg.PythonPkg),
+			want:  true,
+		},
+		{
+			name:  "known by related vulnerability",
+			match: newMatch("GHSA-0000-0000-0000", "openssl", syftPkg.ApkPkg, "CVE-2023-2"),
+			want:  true,
+		},
+		{
+			name:  "new vulnerability",
+			match: newMatch("CVE-2024-1", "openssl", syftPkg.ApkPkg),
+		},
+		{
+			name:  "other package",
+			match: newMatch("CVE-2023-2", "libcrypto3", syftPkg.ApkPkg),
+		},
+		{
+			name:  "other package type",
+			match: newMatch("CVE-2023-2", "openssl", syftPkg.DebPkg),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, baseline.Contains(tt.match))
+		})
+	}
+
+	assert.Equal(t, 2, baseline.Len())
+
+	var none *Baseline
+	assert.False(t, none.Contains(newMatch("CVE-2023-2", "openssl", syftPkg.ApkPkg)))
+	assert.Equal(t, 0, none.Len())
+}
+
+func TestSplitBaseline(t *testing.T) {
+	p := pkg.Package{ID: pkg.ID(uuid.NewString()), Name: "openssl", Type: syftPkg.ApkPkg}
+	known := Match{Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-2"}}, Package: p}
+	added := Match{Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2024-1"}}, Package: p}
+
+	baseline := NewBaseline(BaselineFinding{VulnerabilityIDs: []string{"CVE-2023-2"}, PackageName: "openssl", PackageType: syftPkg.ApkPkg})
+
+	gotAdded, gotKnown := SplitBaseline(NewMatches(known, added), baseline)
+	assert.Equal(t, []Match{added}, gotAdded.Sorted())
+	assert.Equal(t, []Match{known}, gotKnown)
+
+	// without a baseline all matches are new
+	gotAdded, gotKnown = SplitBaseline(NewMatches(known, added), nil)
+	assert.Equal(t, 2, gotAdded.Count())
+	assert.Empty(t, gotKnown)
+}
+
+func TestBaselineIdentity(t *testing.T) {
+	id := BaselineIdentity("CVE-2023-2", "openssl", syftPkg.ApkPkg)
+	assert.Len(t, id, 32)
+	assert.Equal(t, id, BaselineIdentity("cve-2023-2", "openssl", syftPkg.ApkPkg))
+	assert.NotEqual(t, id, BaselineIdentity("CVE-2023-2", "openssl", syftPkg.DebPkg))
+	assert.NotEqual(t, id, BaselineIdentity("CVE-2023-2", "libssl", syftPkg.ApkPkg))
+
+	// findings given by their hashed identities are recognized the same as findings given by vulnerability IDs
+	baseline := NewBaseline(BaselineFinding{Identities: []string{id}})
+	assert.True(t, baseline.Contains(Match{
+		Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-2"}},
+		Package:       pkg.Package{Name: "openssl", Type: syftPkg.ApkPkg},
+	}))
+}
+
+func TestBaseline_Stale(t *testing.T) {
+	current := BaselineFinding{VulnerabilityIDs: []string{"CVE-2023-2"}, PackageName: "openssl", PackageType: syftPkg.ApkPkg}
+	related := BaselineFinding{VulnerabilityIDs: []string{"CVE-2023-1"}, PackageName: "requests", PackageType: syftPkg.PythonPkg}
+	removed := BaselineFinding{VulnerabilityIDs: []string{"CVE-2022-9"}, PackageName: "busybox", PackageType: syftPkg.ApkPkg}
+	baseline := NewBaseline(current, related, removed)
+
+	matches := []Match{
+		{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-2"}},
+			Package:       pkg.Package{Name: "openssl", Version: "3.1.5", Type: syftPkg.ApkPkg},
+		},
+		{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference:              vulnerability.Reference{ID: "GHSA-abcd-1234-wxyz"},
+				RelatedVulnerabilities: []vulnerability.Reference{{ID: "CVE-2023-1"}},
+			},
+			Package: pkg.Package{Name: "requests", Type: syftPkg.PythonPkg},
+		},
+	}
+
+	assert.Equal(t, []BaselineFinding{removed}, baseline.Stale(matches))
+	assert.Equal(t, []BaselineFinding{current, related, removed}, baseline.Stale(nil))
+
+	var none *Baseline
+	assert.Empty(t, none.Stale(matches))
+}
@@ -0,0 +1,61 @@
+package match
+
+const (
+	// ExactDirectConfidence is the confidence of a match made on the package's own name (or purl) and version within
+	// its ecosystem or distro.
+	ExactDirectConfidence = 1.0
+	// ExactIndirectConfidence is the confidence of a match inherited from a related (e.g. source or upstream) package.
+	ExactIndirectConfidence = 0.8
+	// CPEConfidence is the confidence of a match made by fuzzy CPE attribute and version range comparison.
+	CPEConfidence = 0.6
+
+	// LowConfidenceThreshold is the confidence below which a match is considered low confidence.
+	LowConfidenceThreshold = 0.5
+
+	lowConfidenceIgnoreReason = "match confidence is below the configured minimum"
+)
+
+var typeConfidence = map[Type]float64{
+	ExactDirectMatch:   ExactDirectConfidence,
+	ExactIndirectMatch: ExactIndirectConfidence,
+	CPEMatch:           CPEConfidence,
+}
+
+// Confidence returns the confidence of a match of this type, or 0 for unknown types.
+func (t Type) Confidence() float64 {
+	return typeConfidence[t]
+}
+
+// Confidence returns the highest confidence of all details of the match.
+func (m Match) Confidence() float64 {
+	var c float64
+	for _, d := range m.Details {
+		if d.Confidence > c {
+			c = d.Confidence
+		}
+	}
+	return c
+}
+
+// SplitByConfidence splits the given matches into those at or above the given minimum confidence and those below it.
+// Matches without any confidence information (e.g. from VEX documents) are always kept.
+func SplitByConfidence(matches Matches, minConfidence float64) (Matches, []Match) {
+	kept := NewMatches()
+	var dropped []Match
+	for _, m := range matches.Sorted() {
+		if c := m.Confidence(); c > 0 && c < minConfidence {
+			dropped = append(dropped, m)
+			continue
+		}
+		kept.Add(m)
+	}
+	return kept, dropped
+}
+
+// NewLowConfidenceIgnoredMatch returns an ignored match for a match below the configured minimum confidence.
+func NewLowConfidenceIgnoredMatch(m Match) IgnoredMatch {
+	return IgnoredMatch{
+		Match:              m,
+		AppliedIgnoreRules: []IgnoreRule{{Reason: lowConfidenceIgnoreReason}},
+	}
+}
@@ -0,0 +1,51 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestType_Confidence(t *testing.T) {
+	assert.Greater(t, ExactDirectMatch.Confidence(), ExactIndirectMatch.Confidence())
+	assert.Greater(t, ExactIndirectMatch.Confidence(), CPEMatch.Confidence())
+	assert.Zero(t, Type("unknown").Confidence())
+}
+
+func TestSplitByConfidence(t *testing.T) {
+	newMatch := func(id string, confidences ...float64) Match {
+		m := Match{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id, Namespace: "nvd:cpe"}},
+			Package:       pkg.Package{ID: pkg.ID(id), Name: "libfoo", Version: "1.0"},
+		}
+		for _, c := range confidences {
+			m.Details = append(m.Details, Detail{Type: CPEMatch, Confidence: c, SearchedBy: c})
+		}
+		return m
+	}
+
+	exact := newMatch("CVE-2024-0001", ExactDirectConfidence)
+	cpe := newMatch("CVE-2024-0002", CPEConfidence)
+	mixed := newMatch("CVE-2024-0003", 0.1, ExactIndirectConfidence)
+	anyVersion := newMatch("CVE-2024-0004", 0.1)
+	noConfidence := newMatch("CVE-2024-0005")
+
+	assert.Equal(t, ExactIndirectConfidence, mixed.Confidence(), "the most confident detail should win")
+
+	kept, dropped := SplitByConfidence(NewMatches(exact, cpe, mixed, anyVersion, noConfidence), ExactIndirectConfidence)
+
+	var keptIDs []string
+	for _, m := range kept.Sorted() {
+		keptIDs = append(keptIDs, m.Vulnerability.ID)
+	}
+	assert.ElementsMatch(t, []string{"CVE-2024-0001", "CVE-2024-0003", "CVE-2024-0005"}, keptIDs)
+
+	require.Len(t, dropped, 2)
+	ignored := NewLowConfidenceIgnoredMatch(dropped[0])
+	require.Len(t, ignored.AppliedIgnoreRules, 1)
+	assert.Equal(t, lowConfidenceIgnoreReason, ignored.AppliedIgnoreRules[0].Reason)
+}
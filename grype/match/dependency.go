@@ -0,0 +1,31 @@
+package match
+
+import (
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// transitiveIgnoreReason is recorded on the ignore rule of matches dropped for only considering direct dependencies.
+const transitiveIgnoreReason = "package is a transitive dependency"
+
+// SplitTransitive partitions the given matches into the matches on root packages or direct dependencies and the matches
+// on transitive dependencies. Matches on packages without dependency information are considered direct.
+func SplitTransitive(matches Matches) (Matches, []Match) {
+	direct := NewMatches()
+	var transitive []Match
+	for _, m := range matches.Sorted() {
+		if pkg.IsTransitiveDependency(m.Package) {
+			transitive = append(transitive, m)
+			continue
+		}
+		direct.Add(m)
+	}
+	return direct, transitive
+}
+
+// NewTransitiveIgnoredMatch wraps the given match as ignored for being on a transitive dependency.
+func NewTransitiveIgnoredMatch(m Match) IgnoredMatch {
+	return IgnoredMatch{
+		Match:              m,
+		AppliedIgnoreRules: []IgnoreRule{{Reason: transitiveIgnoreReason}},
+	}
+}
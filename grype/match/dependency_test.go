@@ -0,0 +1,44 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestSplitTransitive(t *testing.T) {
+	root := &pkg.Package{ID: "root", Name: "app", Language: syftPkg.JavaScript}
+	direct := &pkg.Package{ID: "direct", Name: "express", Language: syftPkg.JavaScript, RelatedPackages: map[artifact.RelationshipType][]*pkg.Package{
+		artifact.DependencyOfRelationship: {root},
+	}}
+	transitive := &pkg.Package{ID: "transitive", Name: "qs", Language: syftPkg.JavaScript, RelatedPackages: map[artifact.RelationshipType][]*pkg.Package{
+		artifact.DependencyOfRelationship: {direct},
+	}}
+
+	newMatch := func(id string, p *pkg.Package) Match {
+		return Match{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id, Namespace: "github:language:javascript"}},
+			Package:       *p,
+		}
+	}
+
+	kept, dropped := SplitTransitive(NewMatches(newMatch("CVE-2024-0001", root), newMatch("CVE-2024-0002", direct), newMatch("CVE-2024-0003", transitive)))
+
+	var keptIDs []string
+	for _, m := range kept.Sorted() {
+		keptIDs = append(keptIDs, m.Vulnerability.ID)
+	}
+	assert.ElementsMatch(t, []string{"CVE-2024-0001", "CVE-2024-0002"}, keptIDs)
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "CVE-2024-0003", dropped[0].Vulnerability.ID)
+
+	ignored := NewTransitiveIgnoredMatch(dropped[0])
+	require.Len(t, ignored.AppliedIgnoreRules, 1)
+	assert.Equal(t, transitiveIgnoreReason, ignored.AppliedIgnoreRules[0].Reason)
+}
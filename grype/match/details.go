@@ -14,7 +14,7 @@ type Detail struct {
 	SearchedBy any         // The specific attributes that were used to search (other than package name and version) --this indicates "how" the match was made.
 	Found      any         // The specific attributes on the vulnerability object that were matched with --this indicates "what" was matched on / within.
 	Matcher    MatcherType // The matcher object that discovered the match.
-	Confidence float64     // The certainty of the match as a ratio (currently unused, reserved for future use).
+	Confidence float64     // The certainty of the match as a ratio (see Type.Confidence).
 }
 
 // String is the string representation of select match fields.
@@ -0,0 +1,32 @@
+package match
+
+import (
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// devDependencyIgnoreReason is recorded on the ignore rule of matches dropped for being on a development or test
+// dependency.
+const devDependencyIgnoreReason = "package is a development or test dependency"
+
+// SplitDevDependencies partitions the given matches into the matches on packages that are shipped and the matches on
+// packages only needed for development or testing (see pkg.IsDevDependency).
+func SplitDevDependencies(matches Matches) (Matches, []Match) {
+	kept := NewMatches()
+	var dev []Match
+	for _, m := range matches.Sorted() {
+		if pkg.IsDevDependency(m.Package) {
+			dev = append(dev, m)
+			continue
+		}
+		kept.Add(m)
+	}
+	return kept, dev
+}
+
+// NewDevDependencyIgnoredMatch wraps the given match as ignored for being on a development or test dependency.
+func NewDevDependencyIgnoredMatch(m Match) IgnoredMatch {
+	return IgnoredMatch{
+		Match:              m,
+		AppliedIgnoreRules: []IgnoreRule{{Reason: devDependencyIgnoreReason}},
+	}
+}
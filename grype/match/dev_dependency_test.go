@@ -0,0 +1,38 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestSplitDevDependencies(t *testing.T) {
+	spring := pkg.Package{ID: "spring", Name: "spring-core"}
+	junit := pkg.Package{ID: "junit", Name: "junit"}
+	junit.AddAnnotation(pkg.DevDependencyAnnotation, "maven-scope:test")
+
+	newMatch := func(id string, p pkg.Package) Match {
+		return Match{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id, Namespace: "github:language:java"}},
+			Package:       p,
+		}
+	}
+
+	kept, dropped := SplitDevDependencies(NewMatches(newMatch("CVE-2024-0001", spring), newMatch("CVE-2024-0002", junit)))
+
+	var keptIDs []string
+	for _, m := range kept.Sorted() {
+		keptIDs = append(keptIDs, m.Vulnerability.ID)
+	}
+	assert.Equal(t, []string{"CVE-2024-0001"}, keptIDs)
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "CVE-2024-0002", dropped[0].Vulnerability.ID)
+
+	ignored := NewDevDependencyIgnoredMatch(dropped[0])
+	require.Len(t, ignored.AppliedIgnoreRules, 1)
+	assert.Equal(t, devDependencyIgnoreReason, ignored.AppliedIgnoreRules[0].Reason)
+}
@@ -0,0 +1,168 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// SBOMMatcher is the matcher recorded on matches reported within the input SBOM rather than found by grype.
+const SBOMMatcher MatcherType = "sbom-matcher"
+
+// externalNamespace is the vulnerability namespace of matches reported within the input SBOM.
+const externalNamespace = "sbom"
+
+// ExternalSearchedBy describes the SBOM vulnerability entry an external match originates from.
+type ExternalSearchedBy struct {
+	VulnerabilityID string `json:"vulnerabilityID"`
+	BOMRef          string `json:"bomRef"`
+}
+
+// ApplyExternalVulnerabilities merges the vulnerabilities reported within the input SBOM into the results. Vulnerabilities
+// with a resolved, false positive or not affected analysis are treated as suppressions: matching results are moved to
+// the ignored matches. The remaining vulnerabilities are added as matches when grype did not already find them.
+func ApplyExternalVulnerabilities(external []pkg.ExternalVulnerability, pkgs []pkg.Package, matches Matches, ignored []IgnoredMatch) (Matches, []IgnoredMatch) {
+	if len(external) == 0 {
+		return matches, ignored
+	}
+
+	pkgsByID := make(map[pkg.ID]pkg.Package, len(pkgs))
+	for _, p := range pkgs {
+		pkgsByID[p.ID] = p
+	}
+
+	suppressed := make(map[pkg.ID]map[string]pkg.ExternalVulnerability)
+	var additions []Match
+	for _, ev := range external {
+		for _, id := range ev.Packages {
+			p, ok := pkgsByID[id]
+			if !ok {
+				continue
+			}
+			if ev.Analysis.Suppresses() {
+				if suppressed[id] == nil {
+					suppressed[id] = make(map[string]pkg.ExternalVulnerability)
+				}
+				suppressed[id][strings.ToLower(ev.ID)] = ev
+			}
+			if !hasMatchForVulnerability(matches.GetByPkgID(id), ev.ID) {
+				additions = append(additions, newExternalMatch(ev, p))
+			}
+		}
+	}
+
+	remaining := NewMatches()
+	for _, m := range append(matches.Sorted(), additions...) {
+		if ev, ok := suppressingVulnerability(suppressed[m.Package.ID], m); ok {
+			ignored = append(ignored, newExternallySuppressedMatch(m, ev))
+			continue
+		}
+		remaining.Add(m)
+	}
+	return remaining, ignored
+}
+
+func hasMatchForVulnerability(matches []Match, id string) bool {
+	for _, m := range matches {
+		if matchesVulnerabilityID(m, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func suppressingVulnerability(suppressed map[string]pkg.ExternalVulnerability, m Match) (pkg.ExternalVulnerability, bool) {
+	if len(suppressed) == 0 {
+		return pkg.ExternalVulnerability{}, false
+	}
+	if ev, ok := suppressed[strings.ToLower(m.Vulnerability.ID)]; ok {
+		return ev, true
+	}
+	for _, r := range m.Vulnerability.RelatedVulnerabilities {
+		if ev, ok := suppressed[strings.ToLower(r.ID)]; ok {
+			return ev, true
+		}
+	}
+	return pkg.ExternalVulnerability{}, false
+}
+
+func matchesVulnerabilityID(m Match, id string) bool {
+	if strings.EqualFold(m.Vulnerability.ID, id) {
+		return true
+	}
+	for _, r := range m.Vulnerability.RelatedVulnerabilities {
+		if strings.EqualFold(r.ID, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func newExternalMatch(ev pkg.ExternalVulnerability, p pkg.Package) Match {
+	namespace := externalNamespace
+	if ev.Source != "" {
+		namespace = fmt.Sprintf("%s:%s", externalNamespace, strings.ToLower(ev.Source))
+	}
+
+	var urls []string
+	if ev.URL != "" {
+		urls = append(urls, ev.URL)
+	}
+
+	return Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{
+				ID:        ev.ID,
+				Namespace: namespace,
+			},
+			PackageName: p.Name,
+			Metadata: &vulnerability.Metadata{
+				ID:          ev.ID,
+				DataSource:  ev.URL,
+				Namespace:   namespace,
+				Severity:    externalSeverity(ev.Severity),
+				URLs:        urls,
+				Description: ev.Description,
+			},
+		},
+		Package: p,
+		Details: Details{
+			{
+				Type:    ExactDirectMatch,
+				Matcher: SBOMMatcher,
+				SearchedBy: ExternalSearchedBy{
+					VulnerabilityID: ev.ID,
+					BOMRef:          string(p.ID),
+				},
+				Found: ev.Analysis,
+			},
+		},
+	}
+}
+
+func newExternallySuppressedMatch(m Match, ev pkg.ExternalVulnerability) IgnoredMatch {
+	reason := fmt.Sprintf("SBOM analysis state is %s", ev.Analysis.State)
+	if ev.Analysis.Justification != "" {
+		reason = fmt.Sprintf("%s (%s)", reason, ev.Analysis.Justification)
+	}
+	return IgnoredMatch{
+		Match: m,
+		AppliedIgnoreRules: []IgnoreRule{{
+			Vulnerability: ev.ID,
+			Reason:        reason,
+		}},
+	}
+}
+
+// externalSeverity maps CycloneDX severities onto the severities used by grype.
+func externalSeverity(severity string) string {
+	sev := vulnerability.ParseSeverity(severity)
+	if strings.EqualFold(severity, "info") {
+		sev = vulnerability.NegligibleSeverity
+	}
+	return strcase.ToCamel(sev.String())
+}
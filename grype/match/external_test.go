@@ -0,0 +1,98 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestApplyExternalVulnerabilities(t *testing.T) {
+	lodash := pkg.Package{ID: "pkg:npm/lodash@4.17.20", Name: "lodash", Version: "4.17.20"}
+	minimist := pkg.Package{ID: "pkg:npm/minimist@1.2.5", Name: "minimist", Version: "1.2.5"}
+
+	newMatch := func(id string, p pkg.Package, related ...string) Match {
+		m := Match{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id, Namespace: "github:language:javascript"}},
+			Package:       p,
+			Details:       Details{{Type: ExactDirectMatch, Matcher: JavascriptMatcher, SearchedBy: id, Found: id}},
+		}
+		for _, r := range related {
+			m.Vulnerability.RelatedVulnerabilities = append(m.Vulnerability.RelatedVulnerabilities, vulnerability.Reference{ID: r, Namespace: "nvd:cpe"})
+		}
+		return m
+	}
+
+	found := newMatch("GHSA-35jh-r3h4-6jhm", lodash, "CVE-2021-23337")
+	suppressed := newMatch("GHSA-xvch-5gv4-984h", minimist, "CVE-2021-44906")
+	unrelated := newMatch("GHSA-p6mc-m468-83gw", lodash, "CVE-2020-8203")
+
+	external := []pkg.ExternalVulnerability{
+		{
+			// already found by grype (via the related CVE)
+			ID:       "cve-2021-23337",
+			Packages: []pkg.ID{lodash.ID},
+			Analysis: pkg.ExternalAnalysis{State: "exploitable"},
+		},
+		{
+			ID:       "CVE-2021-44906",
+			Packages: []pkg.ID{minimist.ID},
+			Analysis: pkg.ExternalAnalysis{State: "false_positive", Justification: "code_not_reachable"},
+		},
+		{
+			// only known to the SBOM producer
+			ID:       "CVE-2024-0001",
+			Source:   "NVD",
+			Severity: "critical",
+			Packages: []pkg.ID{lodash.ID, "not-in-sbom"},
+			Analysis: pkg.ExternalAnalysis{State: "in_triage"},
+		},
+		{
+			ID:       "CVE-2024-0002",
+			Packages: []pkg.ID{minimist.ID},
+			Analysis: pkg.ExternalAnalysis{State: "not_affected"},
+		},
+	}
+
+	remaining, ignored := ApplyExternalVulnerabilities(external, []pkg.Package{lodash, minimist}, NewMatches(found, suppressed, unrelated), nil)
+
+	var remainingIDs []string
+	for _, m := range remaining.Sorted() {
+		remainingIDs = append(remainingIDs, m.Vulnerability.ID)
+	}
+	assert.ElementsMatch(t, []string{"GHSA-35jh-r3h4-6jhm", "GHSA-p6mc-m468-83gw", "CVE-2024-0001"}, remainingIDs)
+
+	added := remaining.GetByPkgID(lodash.ID)
+	var external0001 *Match
+	for i := range added {
+		if added[i].Vulnerability.ID == "CVE-2024-0001" {
+			external0001 = &added[i]
+		}
+	}
+	require.NotNil(t, external0001)
+	assert.Equal(t, "sbom:nvd", external0001.Vulnerability.Namespace)
+	require.NotNil(t, external0001.Vulnerability.Metadata)
+	assert.Equal(t, "Critical", external0001.Vulnerability.Metadata.Severity)
+	require.Len(t, external0001.Details, 1)
+	assert.Equal(t, SBOMMatcher, external0001.Details[0].Matcher)
+	assert.Equal(t, pkg.ExternalAnalysis{State: "in_triage"}, external0001.Details[0].Found)
+
+	require.Len(t, ignored, 2)
+	var ignoredIDs []string
+	for _, i := range ignored {
+		ignoredIDs = append(ignoredIDs, i.Vulnerability.ID)
+		require.Len(t, i.AppliedIgnoreRules, 1)
+	}
+	assert.ElementsMatch(t, []string{"GHSA-xvch-5gv4-984h", "CVE-2024-0002"}, ignoredIDs)
+	assert.Equal(t, "SBOM analysis state is false_positive (code_not_reachable)", ignored[0].AppliedIgnoreRules[0].Reason)
+}
+
+func TestApplyExternalVulnerabilities_NoExternal(t *testing.T) {
+	matches := NewMatches()
+	remaining, ignored := ApplyExternalVulnerabilities(nil, nil, matches, nil)
+	assert.Equal(t, matches, remaining)
+	assert.Empty(t, ignored)
+}
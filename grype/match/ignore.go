@@ -1,6 +1,7 @@
 package match
 
 import (
+	"reflect"
 	"regexp"
 	"slices"
 
@@ -31,15 +32,24 @@ type IgnoredMatch struct {
 // specified criteria must be met by the vulnerability match in order for the
 // rule to apply.
 type IgnoreRule struct {
-	Vulnerability    string            `yaml:"vulnerability" json:"vulnerability" mapstructure:"vulnerability"`
-	IncludeAliases   bool              `yaml:"include-aliases" json:"include-aliases" mapstructure:"include-aliases"`
-	Reason           string            `yaml:"reason" json:"reason" mapstructure:"reason"`
-	Namespace        string            `yaml:"namespace" json:"namespace" mapstructure:"namespace"`
-	FixState         string            `yaml:"fix-state" json:"fix-state" mapstructure:"fix-state"`
-	Package          IgnoreRulePackage `yaml:"package" json:"package" mapstructure:"package"`
-	VexStatus        string            `yaml:"vex-status" json:"vex-status" mapstructure:"vex-status"`
-	VexJustification string            `yaml:"vex-justification" json:"vex-justification" mapstructure:"vex-justification"`
-	MatchType        Type              `yaml:"match-type" json:"match-type" mapstructure:"match-type"`
+	Vulnerability      string            `yaml:"vulnerability" json:"vulnerability" mapstructure:"vulnerability"`
+	IncludeAliases     bool              `yaml:"include-aliases" json:"include-aliases" mapstructure:"include-aliases"`
+	Reason             string            `yaml:"reason" json:"reason" mapstructure:"reason"`
+	Namespace          string            `yaml:"namespace" json:"namespace" mapstructure:"namespace"`
+	FixState           string            `yaml:"fix-state" json:"fix-state" mapstructure:"fix-state"`
+	Package            IgnoreRulePackage `yaml:"package" json:"package" mapstructure:"package"`
+	VexStatus          string            `yaml:"vex-status" json:"vex-status" mapstructure:"vex-status"`
+	VexJustification   string            `yaml:"vex-justification" json:"vex-justification" mapstructure:"vex-justification"`
+	MatchType          Type              `yaml:"match-type" json:"match-type" mapstructure:"match-type"`
+	FixAvailableWithin string            `yaml:"fix-available-within" json:"fix-available-within" mapstructure:"fix-available-within"`
+	// Locations are globs (e.g. "**/test/**") of where the package was found: the rule applies when any matches
+	Locations []string `yaml:"locations" json:"locations" mapstructure:"locations"`
+	// Owner, Ticket and Expires annotate the rule (see IgnoreFile): rules no longer apply once expired
+	Owner   string `yaml:"owner" json:"owner" mapstructure:"owner"`
+	Ticket  string `yaml:"ticket" json:"ticket" mapstructure:"ticket"`
+	Expires string `yaml:"expires" json:"expires" mapstructure:"expires"`
+	// Source is the ignore file the rule was read from (empty for rules of the grype configuration)
+	Source string `yaml:"-" json:"-" mapstructure:"-"`
 }
 
 // IgnoreRulePackage describes the Package-specific fields that comprise the IgnoreRule.
@@ -59,9 +69,26 @@ type IgnoreRelatedPackage struct {
 	RelationshipType artifact.RelationshipType `yaml:"relationship-type" json:"relationship-type" mapstructure:"relationship-type"`
 	VulnerabilityID  string                    `yaml:"vulnerability" json:"vulnerability" mapstructure:"vulnerability"`
 	RelatedPackageID pkg.ID                    `yaml:"related-package" json:"related-package" mapstructure:"related-package"`
+	// Namespace is the namespace of the vulnerability record reporting the related package as not affected
+	Namespace string `yaml:"namespace" json:"namespace" mapstructure:"namespace"`
 }
 
 func (i IgnoreRelatedPackage) IgnoreMatch(m Match) []IgnoreRule {
+	// the match was reported by the reconciliation policy, despite this verdict of another source
+	if m.Reconciliation != nil && m.Reconciliation.Reported && i.disputes(m) {
+		return nil
+	}
+	return i.ignoreMatch(m)
+}
+
+// disputes indicates if the not-affected verdict comes from a different source (a distro or NVD) than the match.
+func (i IgnoreRelatedPackage) disputes(m Match) bool {
+	verdictSource := namespaceSource(i.Namespace)
+	matchSource := namespaceSource(m.Vulnerability.Namespace)
+	return verdictSource != "" && matchSource != "" && verdictSource != matchSource
+}
+
+func (i IgnoreRelatedPackage) ignoreMatch(m Match) []IgnoreRule {
 	if m.Vulnerability.ID != i.VulnerabilityID {
 		matches := false
 		for _, related := range m.Vulnerability.RelatedVulnerabilities {
@@ -138,6 +165,27 @@ func ApplyIgnoreFilters[T IgnoreFilter](matches []Match, filters ...T) ([]Match,
 	return out, ignoredMatches
 }
 
+// UnusedIgnoreRules returns the rules that were not applied to any of the ignored matches, e.g. since the vulnerability
+// was fixed or the package removed. Rules of VEX statuses that augment matches (affected and under_investigation) never
+// ignore a match, so are never reported as unused.
+func UnusedIgnoreRules(rules []IgnoreRule, ignored []IgnoredMatch) []IgnoreRule {
+	var unused []IgnoreRule
+	for _, rule := range rules {
+		if rule.VexStatus == "affected" || rule.VexStatus == "under_investigation" {
+			continue
+		}
+		used := slices.ContainsFunc(ignored, func(m IgnoredMatch) bool {
+			return slices.ContainsFunc(m.AppliedIgnoreRules, func(applied IgnoreRule) bool {
+				return reflect.DeepEqual(applied, rule)
+			})
+		})
+		if !used {
+			unused = append(unused, rule)
+		}
+	}
+	return unused
+}
+
 func (r IgnoreRule) IgnoreMatch(match Match) []IgnoreRule {
 	// VEX rules are handled by the vex processor
 	if r.VexStatus != "" {
@@ -217,6 +265,10 @@ func getIgnoreConditionsForRule(rule IgnoreRule) []ignoreCondition {
 		ignoreConditions = append(ignoreConditions, ifPackageLocationApplies(l))
 	}
 
+	if len(rule.Locations) > 0 {
+		ignoreConditions = append(ignoreConditions, ifLocationsApply(rule.Locations))
+	}
+
 	if fs := rule.FixState; fs != "" {
 		ignoreConditions = append(ignoreConditions, ifFixStateApplies(fs))
 	}
@@ -228,9 +280,31 @@ func getIgnoreConditionsForRule(rule IgnoreRule) []ignoreCondition {
 	if matchType := rule.MatchType; matchType != "" {
 		ignoreConditions = append(ignoreConditions, ifMatchTypeApplies(matchType))
 	}
+
+	if age := rule.FixAvailableWithin; age != "" {
+		ignoreConditions = append(ignoreConditions, ifFixAvailableWithinApplies(age))
+	}
 	return ignoreConditions
 }
 
+func ifFixAvailableWithinApplies(age string) ignoreCondition {
+	maxAge, err := ParseAge(age)
+	if err != nil {
+		log.WithFields("age", age, "error", err).Debug("unable to parse fix-available-within age")
+		return func(Match) bool { return false }
+	}
+	return func(match Match) bool {
+		if match.Vulnerability.Fix.State != vulnerability.FixStateFixed {
+			return false
+		}
+		available := match.Vulnerability.Fix.EarliestAvailable()
+		if available == nil {
+			return false
+		}
+		return now().Sub(*available) < maxAge
+	}
+}
+
 func ifFixStateApplies(fs string) ignoreCondition {
 	return func(match Match) bool {
 		if fs == string(vulnerability.FixStateUnknown) &&
@@ -0,0 +1,112 @@
+package match
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultIgnoreFile is the ignore file read from the working directory when no ignore files are configured.
+const DefaultIgnoreFile = ".grype-ignore.yaml"
+
+// ignoreExpiryLayouts are the accepted formats of the expiry of an ignore rule.
+var ignoreExpiryLayouts = []string{time.DateOnly, time.RFC3339}
+
+// IgnoreFile is an annotated ignore file: ignore rules kept apart from the grype configuration, each recording why
+// the match is ignored, who owns the decision, the ticket tracking it, and when it expires, e.g.
+//
+//	ignore:
+//	  - vulnerability: CVE-2024-1234
+//	    package:
+//	      name: lodash
+//	      type: npm
+//	    reason: the vulnerable function is never called
+//	    owner: team-web
+//	    ticket: SEC-123
+//	    expires: 2025-06-30
+//
+// The rules support the same criteria as the ignore rules of the grype configuration.
+type IgnoreFile struct {
+	Rules []IgnoreRule `yaml:"ignore"`
+}
+
+// ReadIgnoreFile reads and validates the ignore file at the given path. The returned rules record the path as their
+// source.
+func ReadIgnoreFile(path string) (*IgnoreFile, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ignore file: %w", err)
+	}
+	var f IgnoreFile
+	if err := yaml.Unmarshal(by, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse ignore file %q: %w", path, err)
+	}
+	for i := range f.Rules {
+		r := &f.Rules[i]
+		if err := r.validateAnnotated(); err != nil {
+			return nil, fmt.Errorf("invalid rule %d in ignore file %q: %w", i+1, path, err)
+		}
+		r.Source = path
+	}
+	return &f, nil
+}
+
+func (r IgnoreRule) validateAnnotated() error {
+	if r.Vulnerability == "" && r.Package == (IgnoreRulePackage{}) && len(r.Locations) == 0 && r.Namespace == "" && r.FixState == "" && r.MatchType == "" {
+		return fmt.Errorf("at least a vulnerability, package, locations, namespace, fix-state or match-type is required")
+	}
+	if err := r.validateLocations(); err != nil {
+		return err
+	}
+	if r.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if r.Expires != "" {
+		if _, err := parseIgnoreExpiry(r.Expires); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Expired indicates whether the rule has an expiry that has passed at the given time (a date expires at the end of
+// the day).
+func (r IgnoreRule) Expired(now time.Time) bool {
+	if r.Expires == "" {
+		return false
+	}
+	expires, err := parseIgnoreExpiry(r.Expires)
+	if err != nil {
+		return false
+	}
+	return now.After(expires)
+}
+
+// ActiveIgnoreRules splits the rules into those that apply and those that have expired at the given time.
+func ActiveIgnoreRules(rules []IgnoreRule, now time.Time) (active, expired []IgnoreRule) {
+	for _, r := range rules {
+		if r.Expired(now) {
+			expired = append(expired, r)
+			continue
+		}
+		active = append(active, r)
+	}
+	return active, expired
+}
+
+func parseIgnoreExpiry(expires string) (time.Time, error) {
+	for _, layout := range ignoreExpiryLayouts {
+		t, err := time.Parse(layout, expires)
+		if err != nil {
+			continue
+		}
+		if layout == time.DateOnly {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid expires value %q: must be a date (YYYY-MM-DD) or an RFC3339 timestamp", expires)
+}
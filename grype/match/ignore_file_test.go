@@ -0,0 +1,128 @@
+package match
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeIgnoreFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultIgnoreFile)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestReadIgnoreFile(t *testing.T) {
+	path := writeIgnoreFile(t, `
+ignore:
+  - vulnerability: CVE-2024-1234
+    package:
+      name: lodash
+      type: npm
+    reason: the vulnerable function is never called
+    owner: team-web
+    ticket: SEC-123
+    expires: 2025-06-30
+  - package:
+      name: openssl
+    reason: patched in the base image
+`)
+
+	f, err := ReadIgnoreFile(path)
+	require.NoError(t, err)
+	require.Len(t, f.Rules, 2)
+
+	assert.Equal(t, IgnoreRule{
+		Vulnerability: "CVE-2024-1234",
+		Package:       IgnoreRulePackage{Name: "lodash", Type: "npm"},
+		Reason:        "the vulnerable function is never called",
+		Owner:         "team-web",
+		Ticket:        "SEC-123",
+		Expires:       "2025-06-30",
+		Source:        path,
+	}, f.Rules[0])
+	assert.Equal(t, "openssl", f.Rules[1].Package.Name)
+	assert.Equal(t, path, f.Rules[1].Source)
+}
+
+func TestReadIgnoreFile_invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{
+			name:     "missing reason",
+			contents: "ignore:\n  - vulnerability: CVE-2024-1234\n",
+			wantErr:  "reason is required",
+		},
+		{
+			name:     "no criteria",
+			contents: "ignore:\n  - reason: everything\n    owner: team-web\n",
+			wantErr:  "at least a vulnerability",
+		},
+		{
+			name:     "bad expiry",
+			contents: "ignore:\n  - vulnerability: CVE-2024-1234\n    reason: accepted\n    expires: next week\n",
+			wantErr:  "invalid expires value",
+		},
+		{
+			name:     "bad yaml",
+			contents: "ignore: [",
+			wantErr:  "unable to parse ignore file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadIgnoreFile(writeIgnoreFile(t, tt.contents))
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := ReadIgnoreFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "unable to read ignore file")
+}
+
+func TestActiveIgnoreRules(t *testing.T) {
+	noExpiry := IgnoreRule{Vulnerability: "CVE-1"}
+	dateExpiry := IgnoreRule{Vulnerability: "CVE-2", Expires: "2025-06-30"}
+	timestampExpiry := IgnoreRule{Vulnerability: "CVE-3", Expires: "2025-06-30T12:00:00Z"}
+	rules := []IgnoreRule{noExpiry, dateExpiry, timestampExpiry}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		wantActive  []IgnoreRule
+		wantExpired []IgnoreRule
+	}{
+		{
+			name:       "before expiry",
+			now:        time.Date(2025, 6, 30, 11, 0, 0, 0, time.UTC),
+			wantActive: rules,
+		},
+		{
+			name:        "dates expire at the end of the day",
+			now:         time.Date(2025, 6, 30, 13, 0, 0, 0, time.UTC),
+			wantActive:  []IgnoreRule{noExpiry, dateExpiry},
+			wantExpired: []IgnoreRule{timestampExpiry},
+		},
+		{
+			name:        "after expiry",
+			now:         time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantActive:  []IgnoreRule{noExpiry},
+			wantExpired: []IgnoreRule{dateExpiry, timestampExpiry},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, expired := ActiveIgnoreRules(rules, tt.now)
+			assert.Equal(t, tt.wantActive, active)
+			assert.Equal(t, tt.wantExpired, expired)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package match
+
+import (
+	"fmt"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// validateLocations checks that the location globs of the rule are valid patterns (a bad pattern would otherwise
+// never match, silently).
+func (r IgnoreRule) validateLocations() error {
+	for _, glob := range r.Locations {
+		if !doublestar.ValidatePattern(glob) {
+			return fmt.Errorf("invalid location glob %q", glob)
+		}
+	}
+	return nil
+}
+
+// ValidateIgnoreRules checks the location globs of the given rules.
+func ValidateIgnoreRules(rules []IgnoreRule) error {
+	for i, r := range rules {
+		if err := r.validateLocations(); err != nil {
+			return fmt.Errorf("invalid ignore rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func ifLocationsApply(globs []string) ignoreCondition {
+	return func(match Match) bool {
+		for _, l := range pkg.EvidenceLocations(match.Package) {
+			for _, glob := range globs {
+				if locationGlobMatches(glob, l.RealPath) || locationGlobMatches(glob, l.AccessPath) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func locationGlobMatches(glob, path string) bool {
+	if path == "" {
+		return false
+	}
+	return doublestar.MatchUnvalidated(glob, path)
+}
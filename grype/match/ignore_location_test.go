@@ -0,0 +1,94 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func locationMatch(locations ...file.Location) Match {
+	return Match{
+		Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2024-1234"}},
+		Package: pkg.Package{
+			ID:        pkg.ID("pkg"),
+			Name:      "lodash",
+			Locations: file.NewLocationSet(locations...),
+		},
+	}
+}
+
+func TestIgnoreRule_Locations(t *testing.T) {
+	primary := func(path string) file.Location {
+		return file.NewLocation(path).WithAnnotation(syftPkg.EvidenceAnnotationKey, syftPkg.PrimaryEvidenceAnnotation)
+	}
+	supporting := func(path string) file.Location {
+		return file.NewLocation(path).WithAnnotation(syftPkg.EvidenceAnnotationKey, syftPkg.SupportingEvidenceAnnotation)
+	}
+
+	tests := []struct {
+		name     string
+		match    Match
+		rule     IgnoreRule
+		expected bool
+	}{
+		{
+			name:     "glob matches nested test directory",
+			match:    locationMatch(file.NewLocation("/app/test/fixtures/node_modules/lodash/package.json")),
+			rule:     IgnoreRule{Locations: []string{"**/test/**"}},
+			expected: true,
+		},
+		{
+			name:     "glob does not match other locations",
+			match:    locationMatch(file.NewLocation("/app/node_modules/lodash/package.json")),
+			rule:     IgnoreRule{Locations: []string{"**/test/**"}},
+			expected: false,
+		},
+		{
+			name:     "any of the globs may match",
+			match:    locationMatch(file.NewLocation("/usr/share/doc/lodash/package.json")),
+			rule:     IgnoreRule{Locations: []string{"**/test/**", "/usr/share/doc/**"}},
+			expected: true,
+		},
+		{
+			name:     "access path is considered",
+			match:    locationMatch(file.NewVirtualLocation("/layer/real/package.json", "/app/test/package.json")),
+			rule:     IgnoreRule{Locations: []string{"**/test/**"}},
+			expected: true,
+		},
+		{
+			name:     "supporting evidence is not where the package was found",
+			match:    locationMatch(primary("/var/lib/dpkg/status"), supporting("/usr/share/doc/libc6/copyright")),
+			rule:     IgnoreRule{Locations: []string{"/usr/share/doc/**"}},
+			expected: false,
+		},
+		{
+			name:     "primary evidence is matched",
+			match:    locationMatch(primary("/var/lib/dpkg/status"), supporting("/usr/share/doc/libc6/copyright")),
+			rule:     IgnoreRule{Locations: []string{"/var/lib/dpkg/**"}},
+			expected: true,
+		},
+		{
+			name:     "combined with other criteria",
+			match:    locationMatch(file.NewLocation("/app/test/package.json")),
+			rule:     IgnoreRule{Vulnerability: "CVE-2024-9999", Locations: []string{"**/test/**"}},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ignored := ApplyIgnoreFilters([]Match{tt.match}, tt.rule)
+			assert.Equal(t, tt.expected, len(ignored) == 1)
+		})
+	}
+}
+
+func TestValidateIgnoreRules(t *testing.T) {
+	require.NoError(t, ValidateIgnoreRules([]IgnoreRule{{Locations: []string{"**/test/**", "/usr/share/doc/**"}}}))
+	require.ErrorContains(t, ValidateIgnoreRules([]IgnoreRule{{Vulnerability: "CVE-1"}, {Locations: []string{"/usr/{a,b"}}}), "invalid ignore rule 2")
+}
@@ -1361,3 +1361,18 @@ func TestShouldIgnore(t *testing.T) {
 		})
 	}
 }
+
+func TestUnusedIgnoreRules(t *testing.T) {
+	usedByVulnerability := IgnoreRule{Vulnerability: "CVE-123", Reason: "not reachable"}
+	usedByLocations := IgnoreRule{Package: IgnoreRulePackage{Type: "gem"}, Locations: []string{"/real/**"}}
+	fixed := IgnoreRule{Vulnerability: "CVE-789", Reason: "fixed upstream"}
+	removed := IgnoreRule{Package: IgnoreRulePackage{Name: "bashful"}}
+	augmenting := IgnoreRule{VexStatus: "affected"}
+
+	rules := []IgnoreRule{usedByVulnerability, usedByLocations, fixed, removed, augmenting}
+	_, ignored := ApplyIgnoreRules(NewMatches(allMatches...), rules)
+
+	assert.Equal(t, []IgnoreRule{fixed, removed}, UnusedIgnoreRules(rules, ignored))
+	assert.Equal(t, []IgnoreRule{usedByVulnerability, fixed}, UnusedIgnoreRules([]IgnoreRule{usedByVulnerability, fixed}, nil))
+	assert.Empty(t, UnusedIgnoreRules(nil, ignored))
+}
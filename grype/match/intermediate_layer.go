@@ -0,0 +1,36 @@
+package match
+
+import (
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// intermediateLayerIgnoreReason is recorded on the ignore rule of matches reported separately for being on packages
+// only present in intermediate layers of an image.
+const intermediateLayerIgnoreReason = "package is only present in intermediate image layers"
+
+// SplitIntermediateLayer partitions the given matches into the matches on packages present in the final image and the
+// matches on packages only present in intermediate layers (see pkg.IntermediateLayerAnnotation).
+func SplitIntermediateLayer(matches Matches) (Matches, []Match) {
+	final := NewMatches()
+	var intermediate []Match
+	for _, m := range matches.Sorted() {
+		if pkg.IsIntermediateLayerPackage(m.Package) {
+			intermediate = append(intermediate, m)
+			continue
+		}
+		final.Add(m)
+	}
+	return final, intermediate
+}
+
+// NewIntermediateLayerIgnoredMatch wraps the given match as ignored for being on a package only present in
+// intermediate layers.
+func NewIntermediateLayerIgnoredMatch(m Match) IgnoredMatch {
+	return IgnoredMatch{
+		Match: m,
+		AppliedIgnoreRules: []IgnoreRule{{
+			Reason:    intermediateLayerIgnoreReason,
+			Namespace: "intermediate-layer",
+		}},
+	}
+}
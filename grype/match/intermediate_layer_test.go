@@ -0,0 +1,36 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestSplitIntermediateLayer(t *testing.T) {
+	final := pkg.Package{ID: "final", Name: "libc6"}
+	intermediate := pkg.Package{ID: "intermediate", Name: "curl"}
+	intermediate.AddAnnotation(pkg.IntermediateLayerAnnotation, "sha256:layer2")
+
+	newMatch := func(id string, p pkg.Package) Match {
+		return Match{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id, Namespace: "debian:distro:debian:12"}},
+			Package:       p,
+		}
+	}
+
+	kept, dropped := SplitIntermediateLayer(NewMatches(newMatch("CVE-2024-0001", final), newMatch("CVE-2024-0002", intermediate)))
+
+	require.Equal(t, 1, kept.Count())
+	assert.Equal(t, "CVE-2024-0001", kept.Sorted()[0].Vulnerability.ID)
+	require.Len(t, dropped, 1)
+	assert.Equal(t, "CVE-2024-0002", dropped[0].Vulnerability.ID)
+
+	ignored := NewIntermediateLayerIgnoredMatch(dropped[0])
+	require.Len(t, ignored.AppliedIgnoreRules, 1)
+	assert.Equal(t, intermediateLayerIgnoreReason, ignored.AppliedIgnoreRules[0].Reason)
+	assert.Equal(t, "intermediate-layer", ignored.AppliedIgnoreRules[0].Namespace)
+}
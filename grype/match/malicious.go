@@ -0,0 +1,59 @@
+package match
+
+import (
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// MaliciousArtifactMatcher is the matcher recorded on matches found by looking up the package URL or artifact digest of
+// a package against the known-malicious package artifacts (see vulnerability.MaliciousArtifactProvider).
+const MaliciousArtifactMatcher MatcherType = "malicious-artifact-matcher"
+
+// MaliciousArtifactSearchedBy describes the package URL or artifact digest a malicious artifact match was found by.
+type MaliciousArtifactSearchedBy struct {
+	PURL   string `json:"purl,omitempty"`
+	Digest string `json:"digest,omitempty"`
+}
+
+// MaliciousArtifactResult describes the malicious package record a malicious artifact match was found in.
+type MaliciousArtifactResult struct {
+	VulnerabilityID string `json:"vulnerabilityID"`
+}
+
+// NewMaliciousArtifactMatch creates the match of the given package against the given malicious package record, found
+// from the given malicious artifact.
+func NewMaliciousArtifactMatch(v vulnerability.Vulnerability, p pkg.Package, artifact vulnerability.MaliciousArtifact) Match {
+	return Match{
+		Vulnerability: v,
+		Package:       p,
+		Details: Details{
+			{
+				Type:       ExactDirectMatch,
+				Matcher:    MaliciousArtifactMatcher,
+				Confidence: ExactDirectMatch.Confidence(),
+				SearchedBy: MaliciousArtifactSearchedBy{
+					PURL:   artifact.PURL,
+					Digest: artifact.Digest,
+				},
+				Found: MaliciousArtifactResult{
+					VulnerabilityID: artifact.ID,
+				},
+			},
+		},
+	}
+}
+
+// IsMaliciousPackage indicates if the given match is against a known-malicious package record (e.g. records from the
+// OSV malicious-packages datasets) rather than a vulnerability. Malicious package findings are a distinct class of
+// finding from CVEs: there is no fixed version to upgrade to, the package should be removed entirely.
+func IsMaliciousPackage(m Match) bool {
+	if vulnerability.IsMaliciousPackage(m.Vulnerability.Reference) {
+		return true
+	}
+	for _, related := range m.Vulnerability.RelatedVulnerabilities {
+		if vulnerability.IsMaliciousPackage(related) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestIsMaliciousPackage(t *testing.T) {
+	p := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "event-stream",
+		Version: "3.3.6",
+		Type:    syftPkg.NpmPkg,
+	}
+
+	tests := []struct {
+		name  string
+		match Match
+		want  bool
+	}{
+		{
+			name: "vulnerability",
+			match: Match{
+				Vulnerability: vulnerability.Vulnerability{
+					Reference: vulnerability.Reference{ID: "CVE-2018-1000620", Namespace: "github:language:javascript"},
+				},
+				Package: p,
+			},
+		},
+		{
+			name: "malicious package record",
+			match: Match{
+				Vulnerability: vulnerability.Vulnerability{
+					Reference: vulnerability.Reference{ID: "MAL-2018-1", Namespace: "osv:language:javascript"},
+				},
+				Package: p,
+			},
+			want: true,
+		},
+		{
+			name: "malicious package record alias",
+			match: Match{
+				Vulnerability: vulnerability.Vulnerability{
+					Reference: vulnerability.Reference{ID: "GHSA-mh6f-8j2x-4483", Namespace: "github:language:javascript"},
+					RelatedVulnerabilities: []vulnerability.Reference{
+						{ID: "mal-2018-2", Namespace: "osv:language:javascript"},
+					},
+				},
+				Package: p,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsMaliciousPackage(tt.match))
+		})
+	}
+}
+
+func TestNewMaliciousArtifactMatch(t *testing.T) {
+	p := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "event-stream",
+		Version: "3.3.6",
+		Type:    syftPkg.NpmPkg,
+	}
+	v := vulnerability.Vulnerability{
+		Reference:   vulnerability.Reference{ID: "MAL-2018-1", Namespace: "osv:language:javascript"},
+		PackageName: "event-stream",
+	}
+
+	m := NewMaliciousArtifactMatch(v, p, vulnerability.MaliciousArtifact{ID: "MAL-2018-1", Digest: "sha256:aaaa"})
+
+	assert.True(t, IsMaliciousPackage(m))
+	assert.Equal(t, p, m.Package)
+	assert.Equal(t, Details{{
+		Type:       ExactDirectMatch,
+		Matcher:    MaliciousArtifactMatcher,
+		Confidence: 1,
+		SearchedBy: MaliciousArtifactSearchedBy{Digest: "sha256:aaaa"},
+		Found:      MaliciousArtifactResult{VulnerabilityID: "MAL-2018-1"},
+	}}, m.Details)
+}
@@ -19,6 +19,8 @@ type Match struct {
 	Vulnerability vulnerability.Vulnerability // The vulnerability details of the match.
 	Package       pkg.Package                 // The package used to search for a match.
 	Details       Details                     // all the ways this particular match was made.
+	// Reconciliation records how a disagreement with another source about the vulnerability was resolved, if any.
+	Reconciliation *Reconciliation
 }
 
 // String is the string representation of select match fields.
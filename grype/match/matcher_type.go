@@ -20,6 +20,8 @@ const (
 	BitnamiMatcher     MatcherType = "bitnami-matcher"
 	PacmanMatcher      MatcherType = "pacman-matcher"
 	HexMatcher         MatcherType = "hex-matcher"
+	BSDMatcher         MatcherType = "bsd-matcher"
+	TerraformMatcher   MatcherType = "terraform-matcher"
 )
 
 var AllMatcherTypes = []MatcherType{
@@ -40,6 +42,8 @@ var AllMatcherTypes = []MatcherType{
 	BitnamiMatcher,
 	PacmanMatcher,
 	HexMatcher,
+	BSDMatcher,
+	TerraformMatcher,
 }
 
 type MatcherType string
@@ -0,0 +1,111 @@
+package match
+
+import (
+	"sort"
+	"strings"
+)
+
+// providerPriorityIgnoreReason is recorded on the ignore rule of matches dropped for already being reported by a
+// higher priority provider.
+const providerPriorityIgnoreReason = "vulnerability is already reported by a higher priority provider"
+
+// ProviderPriority orders the providers of vulnerability records (e.g. "rhel", "ubuntu", "github" or "nvd"), the
+// first component of a vulnerability namespace.
+type ProviderPriority struct {
+	// Providers are the providers in order of precedence; providers not listed have the lowest priority
+	Providers []string
+	// AllowDuplicates keeps matches of lower priority providers for a vulnerability and package already reported by a
+	// higher priority provider
+	AllowDuplicates bool
+}
+
+// providerAliases maps provider names to the name used within vulnerability namespaces.
+var providerAliases = map[string]string{
+	"rhel": "redhat",
+}
+
+// NormalizeProvider returns the name of the given provider as it appears within vulnerability namespaces.
+func NormalizeProvider(provider string) string {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if alias, ok := providerAliases[provider]; ok {
+		return alias
+	}
+	return provider
+}
+
+// Enabled indicates whether matches of lower priority providers may be dropped.
+func (p ProviderPriority) Enabled() bool {
+	return len(p.Providers) > 0 && !p.AllowDuplicates
+}
+
+// rank returns the position of the provider of the given namespace, where a lower rank has a higher priority.
+func (p ProviderPriority) rank(namespace string) int {
+	provider, _, _ := strings.Cut(namespace, ":")
+	provider = NormalizeProvider(provider)
+	for i, candidate := range p.Providers {
+		if NormalizeProvider(candidate) == provider {
+			return i
+		}
+	}
+	return len(p.Providers)
+}
+
+// SplitByProviderPriority partitions the given matches into the matches to report and the matches of lower priority
+// providers for a vulnerability (by ID or related vulnerability, e.g. a GHSA and its CVE) and package already reported
+// by a higher priority provider. Providers of equal priority never displace each other's matches.
+func SplitByProviderPriority(matches Matches, p ProviderPriority) (Matches, []Match) {
+	if !p.Enabled() {
+		return matches, nil
+	}
+
+	sorted := matches.Sorted()
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return p.rank(sorted[i].Vulnerability.Namespace) < p.rank(sorted[j].Vulnerability.Namespace)
+	})
+
+	type reportedKey struct {
+		pkgID  string
+		vulnID string
+	}
+	// the rank of the highest priority provider that reported each vulnerability and package
+	reported := make(map[reportedKey]int)
+
+	kept := NewMatches()
+	var dropped []Match
+	for _, m := range sorted {
+		rank := p.rank(m.Vulnerability.Namespace)
+		keys := []reportedKey{{pkgID: string(m.Package.ID), vulnID: strings.ToLower(m.Vulnerability.ID)}}
+		for _, related := range m.Vulnerability.RelatedVulnerabilities {
+			keys = append(keys, reportedKey{pkgID: string(m.Package.ID), vulnID: strings.ToLower(related.ID)})
+		}
+
+		duplicate := false
+		for _, k := range keys {
+			if r, ok := reported[k]; ok && r < rank {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			dropped = append(dropped, m)
+			continue
+		}
+
+		for _, k := range keys {
+			if _, ok := reported[k]; !ok {
+				reported[k] = rank
+			}
+		}
+		kept.Add(m)
+	}
+	return kept, dropped
+}
+
+// NewProviderPriorityIgnoredMatch wraps the given match as ignored for already being reported by a higher priority
+// provider.
+func NewProviderPriorityIgnoredMatch(m Match) IgnoredMatch {
+	return IgnoredMatch{
+		Match:              m,
+		AppliedIgnoreRules: []IgnoreRule{{Reason: providerPriorityIgnoreReason}},
+	}
+}
@@ -0,0 +1,76 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestSplitByProviderPriority(t *testing.T) {
+	openssl := pkg.Package{ID: "openssl", Name: "openssl"}
+	django := pkg.Package{ID: "django", Name: "Django"}
+
+	newMatch := func(p pkg.Package, id, namespace string, related ...string) Match {
+		v := vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id, Namespace: namespace}}
+		for _, r := range related {
+			v.RelatedVulnerabilities = append(v.RelatedVulnerabilities, vulnerability.Reference{ID: r, Namespace: "nvd:cpe"})
+		}
+		return Match{Vulnerability: v, Package: p}
+	}
+
+	matches := NewMatches(
+		newMatch(openssl, "CVE-2024-0001", "redhat:distro:redhat:9"),
+		newMatch(openssl, "CVE-2024-0001", "nvd:cpe"),
+		newMatch(openssl, "CVE-2024-0002", "nvd:cpe"),
+		newMatch(django, "GHSA-aaaa-bbbb-cccc", "github:language:python", "CVE-2024-0003"),
+		newMatch(django, "CVE-2024-0003", "nvd:cpe"),
+		newMatch(django, "CVE-2024-0004", "nvd:cpe"),
+	)
+
+	tests := []struct {
+		name        string
+		priority    ProviderPriority
+		wantDropped []string
+	}{
+		{
+			name: "disabled without providers",
+		},
+		{
+			name:     "duplicates allowed",
+			priority: ProviderPriority{Providers: []string{"rhel", "github", "nvd"}, AllowDuplicates: true},
+		},
+		{
+			name:        "lower priority duplicates are dropped",
+			priority:    ProviderPriority{Providers: []string{"rhel", "github", "nvd"}},
+			wantDropped: []string{"CVE-2024-0001 nvd:cpe", "CVE-2024-0003 nvd:cpe"},
+		},
+		{
+			name:        "unlisted providers have the lowest priority",
+			priority:    ProviderPriority{Providers: []string{"NVD"}},
+			wantDropped: []string{"CVE-2024-0001 redhat:distro:redhat:9", "GHSA-aaaa-bbbb-cccc github:language:python"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, dropped := SplitByProviderPriority(matches, tt.priority)
+
+			var droppedIDs []string
+			for _, m := range dropped {
+				droppedIDs = append(droppedIDs, m.Vulnerability.ID+" "+m.Vulnerability.Namespace)
+			}
+			assert.ElementsMatch(t, tt.wantDropped, droppedIDs)
+			assert.Equal(t, matches.Count()-len(tt.wantDropped), kept.Count())
+		})
+	}
+
+	_, dropped := SplitByProviderPriority(matches, ProviderPriority{Providers: []string{"nvd"}})
+	require.NotEmpty(t, dropped)
+	ignored := NewProviderPriorityIgnoredMatch(dropped[0])
+	require.Len(t, ignored.AppliedIgnoreRules, 1)
+	assert.Equal(t, providerPriorityIgnoreReason, ignored.AppliedIgnoreRules[0].Reason)
+}
@@ -0,0 +1,115 @@
+package match
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ReconciliationPolicy describes how disagreements between a distro and NVD about the same vulnerability are resolved:
+// one source reporting a package as affected while the other reports it (or the package owning its files) as not
+// affected or fixed.
+type ReconciliationPolicy string
+
+const (
+	// ReconcilePreferDistro follows the verdict of the distro (the default): NVD matches the distro reports as not
+	// affected are ignored, while distro matches are reported regardless of NVD.
+	ReconcilePreferDistro ReconciliationPolicy = "prefer-distro"
+	// ReconcilePreferNVD follows the verdict of NVD: distro matches NVD reports as not affected are ignored, while
+	// NVD matches are reported regardless of the distro.
+	ReconcilePreferNVD ReconciliationPolicy = "prefer-nvd"
+	// ReconcileReportBoth reports the matches of both sources, regardless of the verdict of the other source.
+	ReconcileReportBoth ReconciliationPolicy = "report-both"
+)
+
+// the sources of vulnerability records whose disagreements are reconciled
+const (
+	distroSource = "distro"
+	nvdSource    = "nvd"
+)
+
+func AllReconciliationPolicies() []ReconciliationPolicy {
+	return []ReconciliationPolicy{
+		ReconcilePreferDistro,
+		ReconcilePreferNVD,
+		ReconcileReportBoth,
+	}
+}
+
+// ParseReconciliationPolicy parses the given policy name, where an empty value selects ReconcilePreferDistro.
+func ParseReconciliationPolicy(policy string) (ReconciliationPolicy, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" {
+		return ReconcilePreferDistro, nil
+	}
+	for _, p := range AllReconciliationPolicies() {
+		if string(p) == policy {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown reconciliation policy %q (allowed: %v)", policy, AllReconciliationPolicies())
+}
+
+// Reconciliation records how a disagreement about a match was resolved.
+type Reconciliation struct {
+	// Policy is the reconciliation policy that decided the outcome.
+	Policy ReconciliationPolicy
+	// Reported indicates if the match was kept, otherwise it was ignored in favor of the other source.
+	Reported bool
+	// DisputedBy are the namespaces of the vulnerability records reporting the package as not affected.
+	DisputedBy []string
+}
+
+// Reconcile records on the given match how the policy resolves its disagreements with the not-affected verdicts of
+// the given filters (of related packages reported not affected by another source). When the match is reported, these
+// verdicts no longer ignore the match.
+func (p ReconciliationPolicy) Reconcile(m Match, filters []IgnoreFilter) Match {
+	if p == "" {
+		p = ReconcilePreferDistro
+	}
+
+	var disputedBy []string
+	for _, f := range filters {
+		verdict, ok := f.(IgnoreRelatedPackage)
+		if !ok || !verdict.disputes(m) || verdict.ignoreMatch(m) == nil {
+			continue
+		}
+		if !slices.Contains(disputedBy, verdict.Namespace) {
+			disputedBy = append(disputedBy, verdict.Namespace)
+		}
+	}
+	if len(disputedBy) == 0 {
+		return m
+	}
+
+	m.Reconciliation = &Reconciliation{
+		Policy:     p,
+		Reported:   p.reports(namespaceSource(m.Vulnerability.Namespace)),
+		DisputedBy: disputedBy,
+	}
+	return m
+}
+
+// reports indicates if matches from the given source are kept when another source disagrees.
+func (p ReconciliationPolicy) reports(source string) bool {
+	switch p {
+	case ReconcilePreferNVD:
+		return source == nvdSource
+	case ReconcileReportBoth:
+		return true
+	default:
+		return source == distroSource
+	}
+}
+
+// namespaceSource returns the source of the vulnerability records of the given namespace whose disagreements are
+// reconciled (a distro or NVD), or an empty string for any other source.
+func namespaceSource(namespace string) string {
+	switch {
+	case strings.HasPrefix(namespace, "nvd:"):
+		return nvdSource
+	case strings.Contains(namespace, ":distro:"):
+		return distroSource
+	}
+	return ""
+}
@@ -0,0 +1,165 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestParseReconciliationPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		want    ReconciliationPolicy
+		wantErr require.ErrorAssertionFunc
+	}{
+		{policy: "", want: ReconcilePreferDistro},
+		{policy: "prefer-distro", want: ReconcilePreferDistro},
+		{policy: "PREFER-NVD", want: ReconcilePreferNVD},
+		{policy: " report-both ", want: ReconcileReportBoth},
+		{policy: "prefer-ghsa", wantErr: require.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := ParseReconciliationPolicy(tt.policy)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconciliationPolicy_Reconcile(t *testing.T) {
+	owner := pkg.Package{ID: "apk-python", Name: "python3", Type: syftPkg.ApkPkg}
+	owned := pkg.Package{
+		ID:   "binary-python",
+		Name: "python",
+		Type: syftPkg.BinaryPkg,
+		RelatedPackages: map[artifact.RelationshipType][]*pkg.Package{
+			artifact.OwnershipByFileOverlapRelationship: {&owner},
+		},
+	}
+
+	matchOn := func(p pkg.Package, namespace string) Match {
+		return Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference: vulnerability.Reference{ID: "CVE-2024-1234", Namespace: namespace},
+			},
+			Package: p,
+		}
+	}
+	verdictOf := func(p pkg.Package, namespace string) IgnoreRelatedPackage {
+		return IgnoreRelatedPackage{
+			Reason:           "Distro Not Vulnerable",
+			RelationshipType: artifact.OwnershipByFileOverlapRelationship,
+			VulnerabilityID:  "CVE-2024-1234",
+			RelatedPackageID: p.ID,
+			Namespace:        namespace,
+		}
+	}
+
+	nvdMatch := matchOn(owned, "nvd:cpe")
+	distroMatch := matchOn(owned, "alpine:distro:alpine:3.18")
+	distroVerdict := verdictOf(owner, "alpine:distro:alpine:3.18")
+	nvdVerdict := verdictOf(owner, "nvd:cpe")
+
+	tests := []struct {
+		name    string
+		policy  ReconciliationPolicy
+		match   Match
+		verdict IgnoreRelatedPackage
+		want    *Reconciliation
+		ignored bool
+	}{
+		{
+			name:    "distro verdict ignores NVD match by default",
+			match:   nvdMatch,
+			verdict: distroVerdict,
+			want: &Reconciliation{
+				Policy:     ReconcilePreferDistro,
+				DisputedBy: []string{"alpine:distro:alpine:3.18"},
+			},
+			ignored: true,
+		},
+		{
+			name:    "NVD match reported when preferring NVD",
+			policy:  ReconcilePreferNVD,
+			match:   nvdMatch,
+			verdict: distroVerdict,
+			want: &Reconciliation{
+				Policy:     ReconcilePreferNVD,
+				Reported:   true,
+				DisputedBy: []string{"alpine:distro:alpine:3.18"},
+			},
+		},
+		{
+			name:    "distro match reported when preferring the distro",
+			policy:  ReconcilePreferDistro,
+			match:   distroMatch,
+			verdict: nvdVerdict,
+			want: &Reconciliation{
+				Policy:     ReconcilePreferDistro,
+				Reported:   true,
+				DisputedBy: []string{"nvd:cpe"},
+			},
+		},
+		{
+			name:    "NVD verdict ignores distro match when preferring NVD",
+			policy:  ReconcilePreferNVD,
+			match:   distroMatch,
+			verdict: nvdVerdict,
+			want: &Reconciliation{
+				Policy:     ReconcilePreferNVD,
+				DisputedBy: []string{"nvd:cpe"},
+			},
+			ignored: true,
+		},
+		{
+			name:    "both reported",
+			policy:  ReconcileReportBoth,
+			match:   nvdMatch,
+			verdict: distroVerdict,
+			want: &Reconciliation{
+				Policy:     ReconcileReportBoth,
+				Reported:   true,
+				DisputedBy: []string{"alpine:distro:alpine:3.18"},
+			},
+		},
+		{
+			name:    "verdict of the same source is not a disagreement",
+			policy:  ReconcileReportBoth,
+			match:   distroMatch,
+			verdict: distroVerdict,
+			ignored: true,
+		},
+		{
+			name:    "other sources are not reconciled",
+			policy:  ReconcileReportBoth,
+			match:   matchOn(owned, "github:language:python"),
+			verdict: distroVerdict,
+			ignored: true,
+		},
+		{
+			name:    "verdict not applying to the package",
+			policy:  ReconcileReportBoth,
+			match:   matchOn(owner, "nvd:cpe"),
+			verdict: distroVerdict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Reconcile(tt.match, []IgnoreFilter{tt.verdict})
+			assert.Equal(t, tt.want, got.Reconciliation)
+
+			_, ignored := ApplyIgnoreFilters([]Match{got}, tt.verdict)
+			assert.Equal(t, tt.ignored, len(ignored) > 0)
+		})
+	}
+}
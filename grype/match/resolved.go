@@ -0,0 +1,15 @@
+package match
+
+import (
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// ResolvedFinding is a vulnerability the package was affected by, whose fix is already included in the installed
+// version of the package (so it is not a match). Reporting these allows demonstrating remediation without comparing
+// against the results of older scans.
+type ResolvedFinding struct {
+	// Vulnerability is the (distro) record of the vulnerability, including the versions the fix is available in
+	Vulnerability vulnerability.Vulnerability
+	Package       pkg.Package
+}
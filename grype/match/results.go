@@ -5,6 +5,8 @@ import (
 	"sort"
 
 	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/grype/grype/version"
 )
 
 type CPEParameters struct {
@@ -35,6 +37,20 @@ type CPEResult struct {
 	VulnerabilityID   string   `json:"vulnerabilityID"`
 	VersionConstraint string   `json:"versionConstraint"`
 	CPEs              []string `json:"cpes"`
+	// Qualifiers describes how the package qualifiers of the vulnerability entry (e.g. architecture) were evaluated
+	// against the package.
+	Qualifiers []string `json:"qualifiers,omitempty"`
+	// Evidence describes which package CPE matched which vulnerability CPE criteria, which is essential for triaging
+	// false positives from CPE-based advisories (e.g. NVD).
+	Evidence []CPEEvidence `json:"evidence,omitempty"`
+}
+
+// CPEEvidence records a single package CPE, the vulnerability CPE criteria it matched, and the affected version
+// range(s) of the vulnerability that the package version falls within.
+type CPEEvidence struct {
+	PackageCPE    string           `json:"packageCPE"`
+	Criteria      []string         `json:"criteria"`
+	VersionBounds []version.Bounds `json:"versionBounds,omitempty"`
 }
 
 func (h CPEResult) Equals(other CPEResult) bool {
@@ -88,6 +104,12 @@ func (d *DistroParameters) Merge(other DistroParameters) error {
 type DistroResult struct {
 	VulnerabilityID   string `json:"vulnerabilityID"`
 	VersionConstraint string `json:"versionConstraint"`
+	// Qualifiers describes how the package qualifiers of the vulnerability entry (e.g. architecture) were evaluated
+	// against the package.
+	Qualifiers []string `json:"qualifiers,omitempty"`
+	// EpochAssumption explains the epoch assumed for a package version without one, when that assumption decided
+	// that the package is vulnerable.
+	EpochAssumption string `json:"epochAssumption,omitempty"`
 }
 
 func (d DistroResult) Equals(other DistroResult) bool {
@@ -126,4 +148,7 @@ type EcosystemResult struct {
 	// in Detail.Found (never compared or used as a map key), so no comparability constraint applies —
 	// the sibling CPEResult.CPEs is likewise a slice.
 	MatchedSymbols []string `json:"matchedSymbols,omitempty"`
+	// Qualifiers describes how the package qualifiers of the vulnerability entry (e.g. architecture) were evaluated
+	// against the package.
+	Qualifiers []string `json:"qualifiers,omitempty"`
 }
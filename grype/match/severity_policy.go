@@ -0,0 +1,91 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// SeverityPolicy raises or caps the severity of findings by the ecosystem of the matched package (e.g. "treat all OS
+// package findings without a vendor severity as medium" or "cap the severity of dev-only dependencies at low").
+type SeverityPolicy struct {
+	// Rules are evaluated in order; only the first rule applying to a finding is used
+	Rules []SeverityPolicyRule
+}
+
+// SeverityPolicyRule bounds the severity of the findings of the packages it applies to.
+type SeverityPolicyRule struct {
+	// Name identifies the rule as the provenance of the severities it changes (defaults to a description of the rule)
+	Name string
+	// Ecosystem is the ecosystem of the packages the rule applies to: "os" for OS packages, a language (e.g. "java") or
+	// a package type (e.g. "npm"); empty for all packages
+	Ecosystem string
+	// DevOnly restricts the rule to packages only needed for development or testing (see pkg.IsDevDependency)
+	DevOnly bool
+	// UnknownOnly restricts the rule to findings without a severity (e.g. not rated by the vendor)
+	UnknownOnly bool
+	// Floor is the lowest severity of the findings (UnknownSeverity for no floor)
+	Floor vulnerability.Severity
+	// Ceiling is the highest severity of the findings (UnknownSeverity for no ceiling)
+	Ceiling vulnerability.Severity
+}
+
+// Enabled indicates whether any rule is configured.
+func (p SeverityPolicy) Enabled() bool {
+	return len(p.Rules) > 0
+}
+
+// Apply returns the severity of a finding of the given package after applying the first rule that applies to it,
+// along with that rule (nil when no rule applies).
+func (p SeverityPolicy) Apply(pk pkg.Package, severity vulnerability.Severity) (vulnerability.Severity, *SeverityPolicyRule) {
+	for i := range p.Rules {
+		r := &p.Rules[i]
+		if !r.appliesTo(pk, severity) {
+			continue
+		}
+		if r.Floor != vulnerability.UnknownSeverity && severity < r.Floor {
+			severity = r.Floor
+		}
+		if r.Ceiling != vulnerability.UnknownSeverity && severity > r.Ceiling {
+			severity = r.Ceiling
+		}
+		return severity, r
+	}
+	return severity, nil
+}
+
+func (r SeverityPolicyRule) appliesTo(p pkg.Package, severity vulnerability.Severity) bool {
+	if r.Ecosystem != "" && !pkg.InEcosystem(p, r.Ecosystem) {
+		return false
+	}
+	if r.DevOnly && !pkg.IsDevDependency(p) {
+		return false
+	}
+	return !r.UnknownOnly || severity == vulnerability.UnknownSeverity
+}
+
+// String describes the rule, using its name when set.
+func (r SeverityPolicyRule) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	var parts []string
+	if r.Ecosystem != "" {
+		parts = append(parts, "ecosystem="+strings.ToLower(r.Ecosystem))
+	}
+	if r.DevOnly {
+		parts = append(parts, "dev-only")
+	}
+	if r.UnknownOnly {
+		parts = append(parts, "unknown-only")
+	}
+	if r.Floor != vulnerability.UnknownSeverity {
+		parts = append(parts, fmt.Sprintf("floor=%s", r.Floor))
+	}
+	if r.Ceiling != vulnerability.UnknownSeverity {
+		parts = append(parts, fmt.Sprintf("ceiling=%s", r.Ceiling))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,80 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestSeverityPolicy_Apply(t *testing.T) {
+	deb := pkg.Package{Name: "libc6", Type: syftPkg.DebPkg}
+	junit := pkg.Package{Name: "junit", Type: syftPkg.JavaPkg, Language: syftPkg.Java}
+	junit.AddAnnotation(pkg.DevDependencyAnnotation, "maven-scope:test")
+	spring := pkg.Package{Name: "spring-core", Type: syftPkg.JavaPkg, Language: syftPkg.Java}
+
+	policy := SeverityPolicy{Rules: []SeverityPolicyRule{
+		{Name: "os-unrated", Ecosystem: "os", UnknownOnly: true, Floor: vulnerability.MediumSeverity},
+		{Ecosystem: "maven", DevOnly: true, Ceiling: vulnerability.LowSeverity},
+		{Ecosystem: "java", Floor: vulnerability.NegligibleSeverity, Ceiling: vulnerability.HighSeverity},
+	}}
+
+	tests := []struct {
+		name     string
+		p        pkg.Package
+		severity vulnerability.Severity
+		want     vulnerability.Severity
+		wantRule string
+	}{
+		{
+			name:     "unrated os finding is raised",
+			p:        deb,
+			severity: vulnerability.UnknownSeverity,
+			want:     vulnerability.MediumSeverity,
+			wantRule: "os-unrated",
+		},
+		{
+			name:     "rated os finding is kept",
+			p:        deb,
+			severity: vulnerability.LowSeverity,
+			want:     vulnerability.LowSeverity,
+		},
+		{
+			name:     "dev dependency finding is capped",
+			p:        junit,
+			severity: vulnerability.CriticalSeverity,
+			want:     vulnerability.LowSeverity,
+			wantRule: "ecosystem=maven,dev-only,ceiling=low",
+		},
+		{
+			name:     "only the first applying rule is used",
+			p:        junit,
+			severity: vulnerability.UnknownSeverity,
+			want:     vulnerability.UnknownSeverity,
+			wantRule: "ecosystem=maven,dev-only,ceiling=low",
+		},
+		{
+			name:     "later rule applies to other packages",
+			p:        spring,
+			severity: vulnerability.CriticalSeverity,
+			want:     vulnerability.HighSeverity,
+			wantRule: "ecosystem=java,floor=negligible,ceiling=high",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rule := policy.Apply(tt.p, tt.severity)
+			assert.Equal(t, tt.want, got)
+			if tt.wantRule == "" {
+				assert.Nil(t, rule)
+				return
+			}
+			if assert.NotNil(t, rule) {
+				assert.Equal(t, tt.wantRule, rule.String())
+			}
+		})
+	}
+}
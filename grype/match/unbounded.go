@@ -0,0 +1,75 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// UnboundedPolicy describes how matches against "affected, fix unknown" advisories (affected ranges without an upper
+// bound and without a known fix) are handled.
+type UnboundedPolicy string
+
+const (
+	// UnboundedAsMatch reports unbounded advisories as regular matches (the default).
+	UnboundedAsMatch UnboundedPolicy = "match"
+	// UnboundedAsWarning reports unbounded advisories but does not consider them when evaluating fail-on
+	// severity or SLA policies.
+	UnboundedAsWarning UnboundedPolicy = "warn"
+	// UnboundedSkip moves unbounded advisories to the ignored matches.
+	UnboundedSkip UnboundedPolicy = "skip"
+)
+
+// unboundedIgnoreReason is recorded on the ignore rule of matches dropped by the UnboundedSkip policy.
+const unboundedIgnoreReason = "advisory has no upper bound and no known fix"
+
+func AllUnboundedPolicies() []UnboundedPolicy {
+	return []UnboundedPolicy{
+		UnboundedAsMatch,
+		UnboundedAsWarning,
+		UnboundedSkip,
+	}
+}
+
+// ParseUnboundedPolicy parses the given policy name, where an empty value selects UnboundedAsMatch.
+func ParseUnboundedPolicy(policy string) (UnboundedPolicy, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" {
+		return UnboundedAsMatch, nil
+	}
+	for _, p := range AllUnboundedPolicies() {
+		if string(p) == policy {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown unbounded match policy %q (allowed: %v)", policy, AllUnboundedPolicies())
+}
+
+// IsUnbounded indicates if the given match is against an "affected, fix unknown" advisory.
+func IsUnbounded(m Match) bool {
+	return vulnerability.IsUnbounded(m.Vulnerability)
+}
+
+// SplitUnbounded partitions the given matches into the matches against bounded advisories and the matches against
+// unbounded advisories.
+func SplitUnbounded(matches Matches) (Matches, []Match) {
+	bounded := NewMatches()
+	var unbounded []Match
+	for _, m := range matches.Sorted() {
+		if IsUnbounded(m) {
+			unbounded = append(unbounded, m)
+			continue
+		}
+		bounded.Add(m)
+	}
+	return bounded, unbounded
+}
+
+// NewUnboundedIgnoredMatch wraps the given match as ignored by the UnboundedSkip policy.
+func NewUnboundedIgnoredMatch(m Match) IgnoredMatch {
+	return IgnoredMatch{
+		Match:              m,
+		AppliedIgnoreRules: []IgnoreRule{{Reason: unboundedIgnoreReason}},
+	}
+}
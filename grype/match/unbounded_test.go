@@ -0,0 +1,93 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestParseUnboundedPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		want    UnboundedPolicy
+		wantErr require.ErrorAssertionFunc
+	}{
+		{policy: "", want: UnboundedAsMatch},
+		{policy: "match", want: UnboundedAsMatch},
+		{policy: "WARN", want: UnboundedAsWarning},
+		{policy: " skip ", want: UnboundedSkip},
+		{policy: "drop", wantErr: require.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := ParseUnboundedPolicy(tt.policy)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSplitUnbounded(t *testing.T) {
+	p := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "libxml2",
+		Version: "2.9.14",
+		Type:    syftPkg.DebPkg,
+	}
+
+	fixed := Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference:  vulnerability.Reference{ID: "CVE-2022-1", Namespace: "debian:distro:debian:12"},
+			Constraint: version.MustGetConstraint("< 2.9.15", version.DebFormat),
+			Fix:        vulnerability.Fix{Versions: []string{"2.9.15"}, State: vulnerability.FixStateFixed},
+		},
+		Package: p,
+	}
+	boundedNotFixed := Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference:  vulnerability.Reference{ID: "CVE-2022-2", Namespace: "debian:distro:debian:12"},
+			Constraint: version.MustGetConstraint(">= 2.9.0, <= 2.9.14", version.DebFormat),
+			Fix:        vulnerability.Fix{State: vulnerability.FixStateNotFixed},
+		},
+		Package: p,
+	}
+	wontFix := Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference:  vulnerability.Reference{ID: "CVE-2022-3", Namespace: "debian:distro:debian:12"},
+			Constraint: version.MustGetConstraint("", version.DebFormat),
+			Fix:        vulnerability.Fix{State: vulnerability.FixStateWontFix},
+		},
+		Package: p,
+	}
+	notFixed := Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference:  vulnerability.Reference{ID: "CVE-2022-4", Namespace: "debian:distro:debian:12"},
+			Constraint: version.MustGetConstraint("", version.DebFormat),
+			Fix:        vulnerability.Fix{State: vulnerability.FixStateNotFixed},
+		},
+		Package: p,
+	}
+	unknown := Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference:  vulnerability.Reference{ID: "GHSA-xxxx", Namespace: "github:language:python"},
+			Constraint: version.MustGetConstraint(">= 2.0", version.DebFormat),
+			Fix:        vulnerability.Fix{State: vulnerability.FixStateUnknown},
+		},
+		Package: p,
+	}
+
+	bounded, unbounded := SplitUnbounded(NewMatches(fixed, boundedNotFixed, wontFix, notFixed, unknown))
+
+	assert.ElementsMatch(t, []Match{fixed, boundedNotFixed, wontFix}, bounded.Sorted())
+	assert.ElementsMatch(t, []Match{notFixed, unknown}, unbounded)
+}
@@ -0,0 +1,21 @@
+package match
+
+import (
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// SplitUnmanagedBinaries partitions the given matches into the matches against packages and the matches against
+// binaries not owned by any package (see pkg.IsUnmanagedBinary). Unmanaged binary findings are a distinct class of
+// finding: the binary was identified from embedded version strings only and must be replaced by hand.
+func SplitUnmanagedBinaries(matches Matches) (Matches, []Match) {
+	remaining := NewMatches()
+	var unmanaged []Match
+	for _, m := range matches.Sorted() {
+		if pkg.IsUnmanagedBinary(m.Package) {
+			unmanaged = append(unmanaged, m)
+			continue
+		}
+		remaining.Add(m)
+	}
+	return remaining, unmanaged
+}
@@ -0,0 +1,54 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestSplitUnmanagedBinaries(t *testing.T) {
+	owner := &pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "python3.11",
+		Version: "3.11.2-6",
+		Type:    syftPkg.DebPkg,
+	}
+	owned := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "python",
+		Version: "3.11.1",
+		Type:    syftPkg.BinaryPkg,
+		RelatedPackages: map[artifact.RelationshipType][]*pkg.Package{
+			artifact.OwnershipByFileOverlapRelationship: {owner},
+		},
+	}
+	unowned := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "curl",
+		Version: "7.88.0",
+		Type:    syftPkg.BinaryPkg,
+	}
+
+	newMatch := func(id string, p pkg.Package) Match {
+		return Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference: vulnerability.Reference{ID: id, Namespace: "nvd:cpe"},
+			},
+			Package: p,
+		}
+	}
+	ownerMatch := newMatch("CVE-2024-0001", *owner)
+	ownedMatch := newMatch("CVE-2024-0002", owned)
+	unownedMatch := newMatch("CVE-2024-0003", unowned)
+
+	remaining, unmanaged := SplitUnmanagedBinaries(NewMatches(ownerMatch, ownedMatch, unownedMatch))
+
+	assert.ElementsMatch(t, []Match{ownerMatch, ownedMatch}, remaining.Sorted())
+	assert.Equal(t, []Match{unownedMatch}, unmanaged)
+}
@@ -0,0 +1,33 @@
+package match
+
+import (
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// withdrawnIgnoreReason is recorded on the ignore rule of matches dropped for being against a withdrawn or rejected
+// vulnerability record.
+const withdrawnIgnoreReason = "vulnerability is withdrawn or rejected"
+
+// SplitWithdrawn partitions the given matches into the matches against active vulnerability records and the matches
+// against withdrawn or rejected records (see vulnerability.IsWithdrawn).
+func SplitWithdrawn(matches Matches) (Matches, []Match) {
+	active := NewMatches()
+	var withdrawn []Match
+	for _, m := range matches.Sorted() {
+		if vulnerability.IsWithdrawn(m.Vulnerability) {
+			withdrawn = append(withdrawn, m)
+			continue
+		}
+		active.Add(m)
+	}
+	return active, withdrawn
+}
+
+// NewWithdrawnIgnoredMatch wraps the given match as ignored for being against a withdrawn or rejected vulnerability
+// record.
+func NewWithdrawnIgnoredMatch(m Match) IgnoredMatch {
+	return IgnoredMatch{
+		Match:              m,
+		AppliedIgnoreRules: []IgnoreRule{{Reason: withdrawnIgnoreReason}},
+	}
+}
@@ -0,0 +1,44 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestSplitWithdrawn(t *testing.T) {
+	p := pkg.Package{ID: "libfoo", Name: "libfoo"}
+	newMatch := func(id, status string) Match {
+		return Match{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id, Namespace: "nvd:cpe"}, Status: status},
+			Package:       p,
+		}
+	}
+
+	kept, dropped := SplitWithdrawn(NewMatches(
+		newMatch("CVE-2024-0001", ""),
+		newMatch("CVE-2024-0002", "active"),
+		newMatch("CVE-2024-0003", "rejected"),
+		newMatch("GHSA-aaaa-bbbb-cccc", "Withdrawn"),
+	))
+
+	var keptIDs []string
+	for _, m := range kept.Sorted() {
+		keptIDs = append(keptIDs, m.Vulnerability.ID)
+	}
+	assert.ElementsMatch(t, []string{"CVE-2024-0001", "CVE-2024-0002"}, keptIDs)
+
+	var droppedIDs []string
+	for _, m := range dropped {
+		droppedIDs = append(droppedIDs, m.Vulnerability.ID)
+	}
+	assert.ElementsMatch(t, []string{"CVE-2024-0003", "GHSA-aaaa-bbbb-cccc"}, droppedIDs)
+
+	ignored := NewWithdrawnIgnoredMatch(dropped[0])
+	require.Len(t, ignored.AppliedIgnoreRules, 1)
+	assert.Equal(t, withdrawnIgnoreReason, ignored.AppliedIgnoreRules[0].Reason)
+}
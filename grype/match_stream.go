@@ -0,0 +1,157 @@
+package grype
+
+import (
+	"slices"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// matchStream hands the matches of each package to VulnerabilityMatcher.StreamMatches once they are final. Matchers
+// may return ignore filters for the packages owned by the matched package (by file overlap), so the matches of an owned
+// package are held back until all of its owners have been matched.
+type matchStream struct {
+	matcher    *VulnerabilityMatcher
+	pkgContext pkg.Context
+	// scanned are the IDs of all packages to match (only these can be waited on)
+	scanned map[pkg.ID]struct{}
+	// matched are the IDs of the packages matched so far
+	matched map[pkg.ID]struct{}
+	// held are the packages waiting on owners to be matched, by package ID
+	held map[pkg.ID]*heldPackage
+	// waiters are the IDs of the held packages waiting on each owner, by owner ID
+	waiters  map[pkg.ID][]pkg.ID
+	ignorers []match.IgnoreFilter
+	filter   *ignoreRulesByIndex
+}
+
+type heldPackage struct {
+	pkg     pkg.Package
+	matches []match.Match
+	owners  int
+}
+
+// newMatchStream returns the stream of matches for the given packages (nil when matches are not streamed).
+func newMatchStream(m *VulnerabilityMatcher, pkgs []pkg.Package, pkgContext pkg.Context) *matchStream {
+	if m.StreamMatches == nil {
+		return nil
+	}
+
+	scanned := make(map[pkg.ID]struct{}, len(pkgs))
+	for _, p := range pkgs {
+		scanned[p.ID] = struct{}{}
+	}
+	return &matchStream{
+		matcher:    m,
+		pkgContext: pkgContext,
+		scanned:    scanned,
+		matched:    make(map[pkg.ID]struct{}, len(pkgs)),
+		held:       make(map[pkg.ID]*heldPackage),
+		waiters:    make(map[pkg.ID][]pkg.ID),
+	}
+}
+
+// add records the matches of a package (and the ignore filters returned by its matchers), streaming the matches of
+// the package and of the held packages it was the last owner to be matched of.
+func (s *matchStream) add(p pkg.Package, matches []match.Match, ignorers []match.IgnoreFilter) {
+	s.matched[p.ID] = struct{}{}
+	if len(ignorers) > 0 {
+		s.ignorers = append(s.ignorers, ignorers...)
+		s.filter = nil
+	}
+
+	if owners := s.pendingOwners(p); len(owners) > 0 {
+		s.held[p.ID] = &heldPackage{pkg: p, matches: matches, owners: len(owners)}
+		for _, owner := range owners {
+			s.waiters[owner] = append(s.waiters[owner], p.ID)
+		}
+	} else {
+		s.publish(p, matches)
+	}
+
+	for _, id := range s.waiters[p.ID] {
+		held := s.held[id]
+		held.owners--
+		if held.owners == 0 {
+			delete(s.held, id)
+			s.publish(held.pkg, held.matches)
+		}
+	}
+	delete(s.waiters, p.ID)
+}
+
+// flush streams the matches of all held packages.
+func (s *matchStream) flush() {
+	ids := make([]pkg.ID, 0, len(s.held))
+	for id := range s.held {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		s.publish(s.held[id].pkg, s.held[id].matches)
+	}
+	clear(s.held)
+	clear(s.waiters)
+}
+
+// pendingOwners returns the IDs of the packages owning files of the given package that are yet to be matched.
+func (s *matchStream) pendingOwners(p pkg.Package) []pkg.ID {
+	var owners []pkg.ID
+	for _, owner := range p.RelatedPackages[artifact.OwnershipByFileOverlapRelationship] {
+		if owner == nil || owner.ID == p.ID || slices.Contains(owners, owner.ID) {
+			continue
+		}
+		if _, ok := s.scanned[owner.ID]; !ok {
+			continue
+		}
+		if _, ok := s.matched[owner.ID]; ok {
+			continue
+		}
+		owners = append(owners, owner.ID)
+	}
+	return owners
+}
+
+// publish post-processes the matches of a package as FindMatches does, streaming the remaining matches.
+func (s *matchStream) publish(p pkg.Package, matches []match.Match) {
+	m := s.matcher
+	if s.filter == nil {
+		// building the index removes indexed filters from the given slice, which is kept for later packages
+		filter := ignoredMatchFilter(slices.Clone(s.ignorers))
+		s.filter = &filter
+	}
+
+	filtered := match.NewMatches()
+	for _, mt := range matches {
+		mt = m.Reconciliation.Reconcile(mt, s.filter.vulnerabilityFilters(mt))
+		kept, _ := match.ApplyIgnoreFilters([]match.Match{mt}, *s.filter)
+		filtered.Add(kept...)
+	}
+
+	remaining, ignored := m.applyIgnoreRulesByCVE(filtered, nil)
+	remainingMatches := &remaining
+
+	if m.VexProcessor != nil {
+		var err error
+		remainingMatches, ignored, err = m.VexProcessor.ApplyVEX(&s.pkgContext, remainingMatches, ignored)
+		if err != nil {
+			// the error is reported once all packages have been matched
+			log.WithFields("error", err, "package", displayPackage(p)).Debug("unable to apply VEX documents to streamed matches")
+			return
+		}
+	}
+
+	remaining, ignored = match.ApplyExternalVulnerabilities(s.pkgContext.ExternalVulnerabilities, []pkg.Package{p}, *remainingMatches, ignored)
+	remainingMatches, _ = m.applyPolicies(&remaining, ignored)
+
+	if m.Unbounded == match.UnboundedSkip {
+		*remainingMatches, _ = match.SplitUnbounded(*remainingMatches)
+	}
+
+	if remainingMatches.Count() == 0 {
+		return
+	}
+	m.StreamMatches(remainingMatches.Sorted())
+}
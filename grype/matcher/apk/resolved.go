@@ -0,0 +1,85 @@
+package apk
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// ResolvedFindings returns the vulnerabilities recorded in the secdb for the given apk package (or its origin package)
+// whose fix is already included in the installed version. These are the vulnerabilities that are not matched (and
+// whose CPE matches are dropped) because the package has been remediated. NAK entries are not included, since the
+// package was never affected.
+func ResolvedFindings(provider vulnerability.Provider, p pkg.Package) ([]match.ResolvedFinding, error) {
+	if p.Type != syftPkg.ApkPkg || p.Distro == nil {
+		return nil, nil
+	}
+
+	vulns, err := provider.FindVulnerabilities(
+		search.ByPackageName(p.Name),
+		search.ByDistro(*p.Distro))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, upstreamPkg := range pkg.UpstreamPackages(p) {
+		upstreamVulns, err := provider.FindVulnerabilities(
+			search.ByPackageName(upstreamPkg.Name),
+			search.ByDistro(*upstreamPkg.Distro))
+		if err != nil {
+			return nil, err
+		}
+		vulns = append(vulns, upstreamVulns...)
+	}
+
+	verObj := version.New(p.Version, pkg.VersionFormat(p))
+
+	var findings []match.ResolvedFinding
+	for _, vulnsForID := range vulnerabilitiesByID(vulns) {
+		resolved, err := resolvedVulnerability(vulnsForID, verObj)
+		if err != nil {
+			return nil, err
+		}
+		if resolved == nil {
+			continue
+		}
+		findings = append(findings, match.ResolvedFinding{
+			Vulnerability: *resolved,
+			Package:       p,
+		})
+	}
+
+	slices.SortFunc(findings, func(a, b match.ResolvedFinding) int {
+		return cmp.Compare(a.Vulnerability.ID, b.Vulnerability.ID)
+	})
+
+	return findings, nil
+}
+
+// resolvedVulnerability returns the fixed secdb record of the vulnerability when the given version is not affected by
+// any of the records (nil otherwise, including when the vulnerability was NAKed).
+func resolvedVulnerability(vulns []vulnerability.Vulnerability, v *version.Version) (*vulnerability.Vulnerability, error) {
+	var resolved *vulnerability.Vulnerability
+	for i, vuln := range vulns {
+		if vuln.Constraint == nil || vuln.Constraint.String() == nakVersionString {
+			return nil, nil
+		}
+		vulnerable, err := vuln.Constraint.Satisfied(v)
+		if err != nil {
+			return nil, err
+		}
+		if vulnerable {
+			return nil, nil
+		}
+		if resolved == nil && vuln.Fix.State == vulnerability.FixStateFixed && len(vuln.Fix.Versions) > 0 {
+			resolved = &vulns[i]
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,67 @@
+package apk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/internal/dbtest"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestResolvedFindings(t *testing.T) {
+	dbtest.DBs(t, "alpine318").
+		SelectOnly("3.18/CVE-2024-0727", "3.18/CVE-2019-6470").
+		Run(func(t *testing.T, db *dbtest.DB) {
+			tests := []struct {
+				name    string
+				builder *dbtest.PackageBuilder
+				wantIDs []string
+			}{
+				{
+					name: "installed version includes the fix",
+					// alpine 3.18 fix: openssl 3.1.4-r5
+					builder: dbtest.NewPackage("openssl", "3.1.4-r6", syftPkg.ApkPkg).
+						WithDistro(dbtest.Alpine318),
+					wantIDs: []string{"CVE-2024-0727"},
+				},
+				{
+					name: "fix of the origin package",
+					builder: dbtest.NewPackage("libssl3", "3.1.4-r5", syftPkg.ApkPkg).
+						WithDistro(dbtest.Alpine318).
+						WithUpstream("openssl", ""),
+					wantIDs: []string{"CVE-2024-0727"},
+				},
+				{
+					name: "still vulnerable",
+					builder: dbtest.NewPackage("openssl", "3.1.4-r0", syftPkg.ApkPkg).
+						WithDistro(dbtest.Alpine318),
+				},
+				{
+					name: "NAKed vulnerabilities are not resolved",
+					builder: dbtest.NewPackage("bind", "9.16.0-r0", syftPkg.ApkPkg).
+						WithDistro(dbtest.Alpine318),
+				},
+				{
+					name:    "packages without a distro are not considered",
+					builder: dbtest.NewPackage("openssl", "3.1.4-r6", syftPkg.ApkPkg),
+				},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					p := tt.builder.Build()
+					findings, err := ResolvedFindings(db, p)
+					require.NoError(t, err)
+
+					var ids []string
+					for _, f := range findings {
+						ids = append(ids, f.Vulnerability.ID)
+						assert.Equal(t, []string{"3.1.4-r5"}, f.Vulnerability.Fix.Versions)
+						assert.Equal(t, p.ID, f.Package.ID)
+					}
+					assert.Equal(t, tt.wantIDs, ids)
+				})
+			}
+		})
+}
@@ -1,14 +1,29 @@
 package bitnami
 
 import (
+	"errors"
+
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher/internal"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
 
-type Matcher struct{}
+type Matcher struct {
+	cfg MatcherConfig
+}
+
+type MatcherConfig struct {
+	UseCPEs bool
+}
+
+func NewBitnamiMatcher(cfg MatcherConfig) *Matcher {
+	return &Matcher{
+		cfg: cfg,
+	}
+}
 
 func (m *Matcher) PackageTypes() []syftPkg.Type {
 	return []syftPkg.Type{syftPkg.BitnamiPkg}
@@ -23,5 +38,44 @@ func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Ma
 	// info such as the package name, version, revision, distro or architecture.
 	// ref: https://github.com/anchore/syft/blob/main/syft/pkg/bitnami.go#L3-L13
 	// ref: https://github.com/anchore/syft/blob/main/syft/pkg/cataloger/bitnami/package.go#L18-L45
-	return internal.MatchPackageByEcosystemPackageName(store, p, p.Name, m.Type())
+	matches, ignores, err := internal.MatchPackageByEcosystemPackageName(store, p, p.Name, m.Type())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !m.cfg.UseCPEs {
+		return matches, ignores, nil
+	}
+
+	// Bitnami re-packages upstream software, so the upstream product advisories (by CPE) apply as well.
+	cpeMatches, cpeIgnores, err := internal.MatchPackageByCPEs(store, p, m.Type())
+	switch {
+	case errors.Is(err, internal.ErrEmptyCPEMatch):
+		log.WithFields("package", p.Name).Debug("bitnami package has no CPEs, skipping upstream advisory search")
+	case err != nil:
+		log.WithFields("package", p.Name, "error", err).Debug("could not match bitnami package by CPE")
+	}
+
+	return append(matches, withoutBitnamiAliases(cpeMatches, matches)...), append(ignores, cpeIgnores...), nil
+}
+
+// withoutBitnamiAliases removes upstream matches for vulnerabilities that are already covered by a Bitnami advisory
+// (BIT-* records list the upstream CVE as an alias). The Bitnami advisory is preferred since its affected ranges are
+// expressed in terms of the Bitnami package versions.
+func withoutBitnamiAliases(upstream, bitnami []match.Match) []match.Match {
+	aliases := make(map[string]struct{})
+	for _, m := range bitnami {
+		for _, ref := range m.Vulnerability.RelatedVulnerabilities {
+			aliases[ref.ID] = struct{}{}
+		}
+	}
+
+	var out []match.Match
+	for _, m := range upstream {
+		if _, ok := aliases[m.Vulnerability.ID]; ok {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
 }
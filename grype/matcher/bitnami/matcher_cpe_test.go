@@ -0,0 +1,94 @@
+package bitnami
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestMatcher_UpstreamCPEs(t *testing.T) {
+	store := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "CVE-2023-22946", Namespace: "nvd:cpe"},
+			PackageName: "spark",
+			Constraint:  version.MustGetConstraint("< 3.4.0", version.UnknownFormat),
+			CPEs:        []cpe.CPE{cpe.Must("cpe:2.3:a:apache:spark:*:*:*:*:*:*:*:*", "")},
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "CVE-2022-31777", Namespace: "nvd:cpe"},
+			PackageName: "spark",
+			Constraint:  version.MustGetConstraint("< 3.2.2", version.UnknownFormat),
+			CPEs:        []cpe.CPE{cpe.Must("cpe:2.3:a:apache:spark:*:*:*:*:*:*:*:*", "")},
+		},
+	)
+
+	p := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "spark",
+		Version: "3.2.4-8",
+		Type:    syftPkg.BitnamiPkg,
+		PURL:    "pkg:bitnami/spark@3.2.4-8",
+		CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:apache:spark:3.2.4-debian-11-r8:*:*:*:*:*:*:*", "")},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      MatcherConfig
+		expected []string
+	}{
+		{
+			name:     "upstream advisories are matched by CPE with the vendor revision removed",
+			cfg:      MatcherConfig{UseCPEs: true},
+			expected: []string{"CVE-2023-22946"},
+		},
+		{
+			name: "CPE matching disabled",
+			cfg:  MatcherConfig{UseCPEs: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, _, err := NewBitnamiMatcher(tt.cfg).Match(store, p)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, m := range matches {
+				ids = append(ids, m.Vulnerability.ID)
+				for _, d := range m.Details {
+					assert.Equal(t, match.CPEMatch, d.Type)
+				}
+			}
+			assert.ElementsMatch(t, tt.expected, ids)
+		})
+	}
+}
+
+func Test_withoutBitnamiAliases(t *testing.T) {
+	bitnamiMatches := []match.Match{
+		{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference:              vulnerability.Reference{ID: "BIT-spark-2023-22946"},
+				RelatedVulnerabilities: []vulnerability.Reference{{ID: "CVE-2023-22946"}},
+			},
+		},
+	}
+	upstream := []match.Match{
+		{Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-22946"}}},
+		{Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2024-00001"}}},
+	}
+
+	got := withoutBitnamiAliases(upstream, bitnamiMatches)
+	require.Len(t, got, 1)
+	assert.Equal(t, "CVE-2024-00001", got[0].Vulnerability.ID)
+}
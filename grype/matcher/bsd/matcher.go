@@ -0,0 +1,32 @@
+package bsd
+
+import (
+	"fmt"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher/internal"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// Matcher matches FreeBSD packages (against VuXML) and OpenBSD packages (against the errata for the release)
+// using the ports version comparison rules of each system.
+type Matcher struct{}
+
+func (m *Matcher) PackageTypes() []syftPkg.Type {
+	return []syftPkg.Type{pkg.FreeBSDPkg, pkg.OpenBSDPkg}
+}
+
+func (m *Matcher) Type() match.MatcherType {
+	return match.BSDMatcher
+}
+
+func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+	matches, ignoreFilters, err := internal.MatchPackageByDistro(store, p, nil, m.Type(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to match %s package: %w", p.Type, err)
+	}
+
+	return matches, ignoreFilters, nil
+}
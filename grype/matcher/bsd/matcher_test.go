@@ -0,0 +1,135 @@
+package bsd
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+)
+
+func TestMatcherType(t *testing.T) {
+	m := Matcher{}
+	assert.Equal(t, match.BSDMatcher, m.Type())
+}
+
+func TestMatch(t *testing.T) {
+	freebsdVuln := vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{
+			ID:        "d1f9a3c6-0b4e-11ef-8a0f-a8a1599412c6",
+			Namespace: "vuxml:distro:freebsd:rolling",
+		},
+		PackageName: "apache24",
+		Constraint:  version.MustGetConstraint("< 2.4.59,1", version.FreeBSDFormat),
+	}
+
+	openbsdVuln := vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{
+			ID:        "OpenBSD-7.5-012",
+			Namespace: "openbsd:distro:openbsd:7.5",
+		},
+		PackageName: "curl",
+		Constraint:  version.MustGetConstraint("< 8.7.1p1", version.OpenBSDFormat),
+	}
+
+	vp := mock.VulnerabilityProvider(freebsdVuln, openbsdVuln)
+
+	tests := []struct {
+		name    string
+		pkg     pkg.Package
+		wantIDs []string
+	}{
+		{
+			name: "freebsd package before fixed port epoch version",
+			pkg: pkg.Package{
+				Name:    "apache24",
+				Version: "2.4.58_1,1",
+				Type:    pkg.FreeBSDPkg,
+				Distro:  distro.New(distro.FreeBSD, "", ""),
+			},
+			wantIDs: []string{"d1f9a3c6-0b4e-11ef-8a0f-a8a1599412c6"},
+		},
+		{
+			name: "freebsd package with fixed version",
+			pkg: pkg.Package{
+				Name:    "apache24",
+				Version: "2.4.59,1",
+				Type:    pkg.FreeBSDPkg,
+				Distro:  distro.New(distro.FreeBSD, "", ""),
+			},
+		},
+		{
+			name: "freebsd package missing the port epoch is older",
+			pkg: pkg.Package{
+				Name:    "apache24",
+				Version: "2.4.60",
+				Type:    pkg.FreeBSDPkg,
+				Distro:  distro.New(distro.FreeBSD, "", ""),
+			},
+			wantIDs: []string{"d1f9a3c6-0b4e-11ef-8a0f-a8a1599412c6"},
+		},
+		{
+			name: "openbsd package before fixed package patch level",
+			pkg: pkg.Package{
+				Name:    "curl",
+				Version: "8.7.1",
+				Type:    pkg.OpenBSDPkg,
+				Distro:  distro.New(distro.OpenBSD, "7.5", ""),
+			},
+			wantIDs: []string{"OpenBSD-7.5-012"},
+		},
+		{
+			name: "openbsd package with fixed package patch level",
+			pkg: pkg.Package{
+				Name:    "curl",
+				Version: "8.7.1p1",
+				Type:    pkg.OpenBSDPkg,
+				Distro:  distro.New(distro.OpenBSD, "7.5", ""),
+			},
+		},
+		{
+			name: "openbsd package on another release",
+			pkg: pkg.Package{
+				Name:    "curl",
+				Version: "8.7.1",
+				Type:    pkg.OpenBSDPkg,
+				Distro:  distro.New(distro.OpenBSD, "7.4", ""),
+			},
+		},
+		{
+			name: "package without a distro",
+			pkg: pkg.Package{
+				Name:    "curl",
+				Version: "8.7.1",
+				Type:    pkg.OpenBSDPkg,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := Matcher{}
+			test.pkg.ID = pkg.ID(uuid.NewString())
+
+			actual, _, err := m.Match(vp, test.pkg)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, a := range actual {
+				assert.Equal(t, test.pkg.ID, a.Package.ID)
+				require.NotEmpty(t, a.Details)
+				assert.Equal(t, match.ExactDirectMatch, a.Details[0].Type)
+				assert.Equal(t, match.BSDMatcher, a.Details[0].Matcher)
+				ids = append(ids, a.Vulnerability.ID)
+			}
+			assert.Equal(t, test.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package matcher
+
+import (
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// CPEUsage describes when a matcher searches for vulnerabilities by CPE (in addition to the ecosystem or distro
+// specific data).
+type CPEUsage string
+
+const (
+	// CPEsNever indicates the matcher never searches by CPE
+	CPEsNever CPEUsage = "never"
+	// CPEsAlways indicates the matcher always searches by CPE
+	CPEsAlways CPEUsage = "always"
+	// CPEsEnabled indicates the matcher searches by CPE, as configured
+	CPEsEnabled CPEUsage = "enabled"
+	// CPEsDisabled indicates the matcher does not search by CPE, as configured
+	CPEsDisabled CPEUsage = "disabled"
+	// CPEsForEOLDistros indicates the matcher only searches by CPE for packages of end-of-life distros, as configured
+	CPEsForEOLDistros CPEUsage = "eol-distros"
+	// CPEsForGoStdlib indicates the matcher only searches by CPE for the go standard library, as configured
+	CPEsForGoStdlib CPEUsage = "stdlib"
+	// CPEsForDotnetRuntime indicates the matcher only searches by CPE for the .NET runtime, as configured
+	CPEsForDotnetRuntime CPEUsage = "runtime"
+)
+
+// Capability describes what a matcher handles and how.
+type Capability struct {
+	Matcher match.MatcherType
+	// PackageTypes are the package types handled by the matcher (none for the fallback matcher)
+	PackageTypes []PackageTypeCapability
+	// Fallback indicates the matcher handles the packages of types that no other matcher handles
+	Fallback bool
+	CPEs     CPEUsage
+}
+
+// PackageTypeCapability is a package type handled by a matcher, along with the version format its versions are
+// compared with.
+type PackageTypeCapability struct {
+	Type          syftPkg.Type
+	VersionFormat version.Format
+}
+
+// Capabilities describes the matchers created by NewDefaultMatchers with the given configuration.
+func Capabilities(mc Config) []Capability {
+	var capabilities []Capability
+	for _, m := range NewDefaultMatchers(mc) {
+		c := Capability{
+			Matcher:  m.Type(),
+			Fallback: len(m.PackageTypes()) == 0,
+			CPEs:     cpeUsage(m.Type(), mc),
+		}
+		for _, t := range m.PackageTypes() {
+			c.PackageTypes = append(c.PackageTypes, PackageTypeCapability{
+				Type:          t,
+				VersionFormat: pkg.VersionFormat(pkg.Package{Type: t}),
+			})
+		}
+		capabilities = append(capabilities, c)
+	}
+	return capabilities
+}
+
+func cpeUsage(t match.MatcherType, mc Config) CPEUsage {
+	switch t {
+	case match.ApkMatcher:
+		// CPE matches are filtered by the fixes of the alpine secdb
+		return CPEsAlways
+	case match.DpkgMatcher:
+		return eolCPEUsage(mc.Dpkg.UseCPEsForEOL)
+	case match.RpmMatcher:
+		return eolCPEUsage(mc.Rpm.UseCPEsForEOL)
+	case match.GoModuleMatcher:
+		if !mc.Golang.UseCPEs && mc.Golang.AlwaysUseCPEForStdlib {
+			return CPEsForGoStdlib
+		}
+		return configuredCPEUsage(mc.Golang.UseCPEs)
+	case match.JavaMatcher:
+		return configuredCPEUsage(mc.Java.UseCPEs)
+	case match.RubyGemMatcher:
+		return configuredCPEUsage(mc.Ruby.UseCPEs)
+	case match.PythonMatcher:
+		return configuredCPEUsage(mc.Python.UseCPEs)
+	case match.DotnetMatcher:
+		if !mc.Dotnet.UseCPEs && mc.Dotnet.AlwaysUseCPEForRuntime {
+			return CPEsForDotnetRuntime
+		}
+		return configuredCPEUsage(mc.Dotnet.UseCPEs)
+	case match.JavascriptMatcher:
+		return configuredCPEUsage(mc.Javascript.UseCPEs)
+	case match.RustMatcher:
+		return configuredCPEUsage(mc.Rust.UseCPEs)
+	case match.HexMatcher:
+		return configuredCPEUsage(mc.Hex.UseCPEs)
+	case match.TerraformMatcher:
+		return configuredCPEUsage(mc.Terraform.UseCPEs)
+	case match.StockMatcher:
+		return configuredCPEUsage(mc.Stock.UseCPEs)
+	case match.BitnamiMatcher:
+		return configuredCPEUsage(mc.Bitnami.UseCPEs)
+	}
+	return CPEsNever
+}
+
+func configuredCPEUsage(enabled bool) CPEUsage {
+	if enabled {
+		return CPEsEnabled
+	}
+	return CPEsDisabled
+}
+
+func eolCPEUsage(enabled bool) CPEUsage {
+	if enabled {
+		return CPEsForEOLDistros
+	}
+	return CPEsDisabled
+}
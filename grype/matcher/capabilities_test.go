@@ -0,0 +1,47 @@
+package matcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher/dotnet"
+	"github.com/anchore/grype/grype/matcher/dpkg"
+	"github.com/anchore/grype/grype/matcher/golang"
+	"github.com/anchore/grype/grype/matcher/javascript"
+	"github.com/anchore/grype/grype/version"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestCapabilities(t *testing.T) {
+	capabilities := Capabilities(Config{
+		Javascript: javascript.MatcherConfig{UseCPEs: true},
+		Golang:     golang.MatcherConfig{AlwaysUseCPEForStdlib: true},
+		Dpkg:       dpkg.MatcherConfig{UseCPEsForEOL: true},
+		Dotnet:     dotnet.MatcherConfig{AlwaysUseCPEForRuntime: true},
+	})
+
+	byMatcher := make(map[match.MatcherType]Capability)
+	for _, c := range capabilities {
+		byMatcher[c.Matcher] = c
+	}
+	require.Len(t, byMatcher, len(NewDefaultMatchers(Config{})))
+
+	assert.Equal(t, CPEsEnabled, byMatcher[match.JavascriptMatcher].CPEs)
+	assert.Equal(t, CPEsDisabled, byMatcher[match.PythonMatcher].CPEs)
+	assert.Equal(t, CPEsForGoStdlib, byMatcher[match.GoModuleMatcher].CPEs)
+	assert.Equal(t, CPEsForDotnetRuntime, byMatcher[match.DotnetMatcher].CPEs)
+	assert.Equal(t, CPEsForEOLDistros, byMatcher[match.DpkgMatcher].CPEs)
+	assert.Equal(t, CPEsDisabled, byMatcher[match.RpmMatcher].CPEs)
+	assert.Equal(t, CPEsAlways, byMatcher[match.ApkMatcher].CPEs)
+	assert.Equal(t, CPEsNever, byMatcher[match.MsrcMatcher].CPEs)
+
+	assert.Equal(t, []PackageTypeCapability{{Type: syftPkg.DebPkg, VersionFormat: version.DebFormat}}, byMatcher[match.DpkgMatcher].PackageTypes)
+	assert.Equal(t, []PackageTypeCapability{{Type: syftPkg.NpmPkg, VersionFormat: version.UnknownFormat}}, byMatcher[match.JavascriptMatcher].PackageTypes)
+
+	assert.True(t, byMatcher[match.StockMatcher].Fallback)
+	assert.Empty(t, byMatcher[match.StockMatcher].PackageTypes)
+	assert.False(t, byMatcher[match.DpkgMatcher].Fallback)
+}
@@ -1,10 +1,13 @@
 package dotnet
 
 import (
+	"strings"
+
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher/internal"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
 
@@ -14,6 +17,10 @@ type Matcher struct {
 
 type MatcherConfig struct {
 	UseCPEs bool
+	// AlwaysUseCPEForRuntime searches the .NET runtime packages by CPE even when UseCPEs is disabled, since Microsoft
+	// advisories for the runtime are largely published against the .NET / ASP.NET Core products rather than a package
+	// name
+	AlwaysUseCPEForRuntime bool
 }
 
 func NewDotnetMatcher(cfg MatcherConfig) *Matcher {
@@ -31,5 +38,28 @@ func (m *Matcher) Type() match.MatcherType {
 }
 
 func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
-	return internal.MatchPackageByEcosystemAndCPEs(store, p, m.Type(), m.cfg.UseCPEs)
+	r := runtimeOf(p.Name)
+	if r == nil {
+		return internal.MatchPackageByEcosystemAndCPEs(store, p, m.Type(), m.cfg.UseCPEs)
+	}
+
+	matches, ignored, err := internal.MatchPackageByEcosystemAndCPEs(store, p, m.Type(), m.cfg.UseCPEs || m.cfg.AlwaysUseCPEForRuntime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range r.advisoryNames(p.Name) {
+		if strings.EqualFold(name, p.Name) {
+			continue
+		}
+		nameMatches, nameIgnores, err := internal.MatchPackageByEcosystemPackageName(store, p, name, m.Type())
+		if err != nil {
+			log.WithFields("package", p.Name, "name", name, "error", err).Debug("could not match .NET runtime by runtime pack name")
+			continue
+		}
+		matches = append(matches, nameMatches...)
+		ignored = append(ignored, nameIgnores...)
+	}
+
+	return matches, ignored, nil
 }
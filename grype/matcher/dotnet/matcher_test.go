@@ -0,0 +1,135 @@
+package dotnet
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestMatcher_Runtime(t *testing.T) {
+	store := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-runtime-linux", Namespace: "github:language:dotnet"},
+			PackageName: "Microsoft.NETCore.App.Runtime.linux-x64",
+			Constraint:  version.MustGetConstraint(">= 8.0.0, < 8.0.5", version.NuGetFormat),
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-runtime-win", Namespace: "github:language:dotnet"},
+			PackageName: "Microsoft.NETCore.App.Runtime.win-x64",
+			Constraint:  version.MustGetConstraint(">= 8.0.0, < 8.0.3", version.NuGetFormat),
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-aspnetcore", Namespace: "github:language:dotnet"},
+			PackageName: "Microsoft.AspNetCore.App.Runtime.linux-x64",
+			Constraint:  version.MustGetConstraint(">= 8.0.0, < 8.0.5", version.NuGetFormat),
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "CVE-2024-21392", Namespace: "nvd:cpe"},
+			PackageName: ".net",
+			Constraint:  version.MustGetConstraint(">= 8.0.0, < 8.0.3", version.UnknownFormat),
+			CPEs:        []cpe.CPE{cpe.Must("cpe:2.3:a:microsoft:.net:*:*:*:*:*:*:*:*", "")},
+		},
+	)
+
+	tests := []struct {
+		name     string
+		cfg      MatcherConfig
+		pkg      pkg.Package
+		expected []string
+	}{
+		{
+			name: "shared framework matches advisories of all runtime packs and by CPE",
+			cfg:  MatcherConfig{AlwaysUseCPEForRuntime: true},
+			pkg: pkg.Package{
+				Name:    "Microsoft.NETCore.App",
+				Version: "8.0.2",
+				CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:microsoft:.net:8.0.2:*:*:*:*:*:*:*", "")},
+			},
+			expected: []string{"GHSA-runtime-linux", "GHSA-runtime-win", "CVE-2024-21392"},
+		},
+		{
+			name: "runtime matched by CPE when CPE matching is enabled",
+			cfg:  MatcherConfig{UseCPEs: true},
+			pkg: pkg.Package{
+				Name:    "Microsoft.NETCore.App",
+				Version: "8.0.2",
+				CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:microsoft:.net:8.0.2:*:*:*:*:*:*:*", "")},
+			},
+			expected: []string{"GHSA-runtime-linux", "GHSA-runtime-win", "CVE-2024-21392"},
+		},
+		{
+			name: "runtime not matched by CPE with CPE matching disabled",
+			pkg: pkg.Package{
+				Name:    "Microsoft.NETCore.App",
+				Version: "8.0.2",
+				CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:microsoft:.net:8.0.2:*:*:*:*:*:*:*", "")},
+			},
+			expected: []string{"GHSA-runtime-linux", "GHSA-runtime-win"},
+		},
+		{
+			name: "runtime pack only matches advisories for its runtime identifier",
+			pkg: pkg.Package{
+				Name:    "runtimepack.Microsoft.NETCore.App.Runtime.linux-x64",
+				Version: "8.0.4",
+			},
+			expected: []string{"GHSA-runtime-linux"},
+		},
+		{
+			name: "prerelease runtime is not in range",
+			pkg: pkg.Package{
+				Name:    "Microsoft.NETCore.App",
+				Version: "8.0.0-rc.2.23479.6",
+			},
+		},
+		{
+			name: "fixed runtime",
+			pkg: pkg.Package{
+				Name:    "Microsoft.NETCore.App",
+				Version: "8.0.5",
+			},
+		},
+		{
+			name: "ASP.NET Core runtime",
+			pkg: pkg.Package{
+				Name:    "Microsoft.AspNetCore.App",
+				Version: "8.0.4",
+			},
+			expected: []string{"GHSA-aspnetcore"},
+		},
+		{
+			name: "packages other than the runtime are not matched by CPE with CPE matching disabled",
+			cfg:  MatcherConfig{AlwaysUseCPEForRuntime: true},
+			pkg: pkg.Package{
+				Name:    "Microsoft.Extensions.Logging",
+				Version: "8.0.2",
+				CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:microsoft:.net:8.0.2:*:*:*:*:*:*:*", "")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.pkg.ID = pkg.ID(uuid.NewString())
+			tt.pkg.Type = syftPkg.DotnetPkg
+			tt.pkg.Language = syftPkg.Dotnet
+
+			matches, _, err := NewDotnetMatcher(tt.cfg).Match(store, tt.pkg)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, m := range matches {
+				ids = append(ids, m.Vulnerability.ID)
+			}
+			assert.ElementsMatch(t, tt.expected, ids)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package dotnet
+
+import (
+	"strings"
+)
+
+// runtimeIdentifiers are the runtime identifiers (RIDs) runtime packs are commonly published for, see
+// https://learn.microsoft.com/en-us/dotnet/core/rid-catalog. Advisories for the runtime itself are published against
+// the runtime pack of each RID (e.g. "Microsoft.NETCore.App.Runtime.linux-x64").
+var runtimeIdentifiers = []string{
+	"linux-x64",
+	"linux-arm",
+	"linux-arm64",
+	"linux-musl-x64",
+	"linux-musl-arm",
+	"linux-musl-arm64",
+	"win-x64",
+	"win-x86",
+	"win-arm",
+	"win-arm64",
+	"osx-x64",
+	"osx-arm64",
+}
+
+// runtime is a .NET shared framework (e.g. the .NET runtime or the ASP.NET Core runtime) which may be found as an
+// installed runtime or as a runtime pack of a self-contained application.
+type runtime struct {
+	// framework is the name of the shared framework, e.g. "Microsoft.NETCore.App"
+	framework string
+}
+
+var runtimes = []runtime{
+	{framework: "Microsoft.NETCore.App"},
+	{framework: "Microsoft.AspNetCore.App"},
+	{framework: "Microsoft.WindowsDesktop.App"},
+}
+
+// runtimeOf returns the .NET runtime the given package name represents, or nil if the package is not a runtime.
+// Accepted names are the shared framework name ("Microsoft.NETCore.App"), a runtime pack name
+// ("Microsoft.NETCore.App.Runtime.linux-x64") or a runtime pack as named in a deps.json ("runtimepack.Microsoft.NETCore.App.Runtime.linux-x64").
+func runtimeOf(name string) *runtime {
+	name = strings.TrimPrefix(strings.ToLower(name), "runtimepack.")
+	for i, r := range runtimes {
+		framework := strings.ToLower(r.framework)
+		if name == framework || strings.HasPrefix(name, framework+".runtime.") {
+			return &runtimes[i]
+		}
+	}
+	return nil
+}
+
+func (r runtime) runtimePack(rid string) string {
+	return r.framework + ".Runtime." + rid
+}
+
+// advisoryNames returns the package names advisories for the runtime may be published under: the shared framework
+// itself and its runtime packs. When the package is a runtime pack for a specific RID, only that runtime pack is
+// considered.
+func (r runtime) advisoryNames(name string) []string {
+	name = strings.TrimPrefix(strings.ToLower(name), "runtimepack.")
+	if rid, ok := strings.CutPrefix(name, strings.ToLower(r.framework)+".runtime."); ok && rid != "" {
+		return []string{r.framework, r.runtimePack(rid)}
+	}
+
+	names := []string{r.framework}
+	for _, rid := range runtimeIdentifiers {
+		names = append(names, r.runtimePack(rid))
+	}
+	return names
+}
@@ -21,6 +21,9 @@ type Matcher struct {
 type MatcherConfig struct {
 	MissingEpochStrategy version.MissingEpochStrategy
 	UseCPEsForEOL        bool
+	// UbuntuProFixesAsFixed considers fixes only available with Ubuntu Pro (from the ESM channel) as fixed, even when
+	// the distro does not have the ESM channel
+	UbuntuProFixesAsFixed bool
 }
 
 func NewDpkgMatcher(cfg MatcherConfig) *Matcher {
@@ -69,6 +72,10 @@ func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Ma
 		ignores = append(ignores, exactIgnores...)
 	}
 
+	if err := markUbuntuProFixes(store, p, matches, m.cfg.UbuntuProFixesAsFixed); err != nil {
+		return nil, nil, fmt.Errorf("failed to find Ubuntu Pro fixes: %w", err)
+	}
+
 	// if configured, also search by CPEs for packages from EOL distros
 	if m.cfg.UseCPEsForEOL && internal.IsDistroEOL(store, p.Distro) {
 		log.WithFields("package", p.Name, "distro", p.Distro).Debug("distro is EOL, searching by CPEs")
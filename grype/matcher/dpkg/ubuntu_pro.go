@@ -0,0 +1,96 @@
+package dpkg
+
+import (
+	"slices"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher/internal"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// UbuntuProEntitlement is the entitlement required to install fixes only published in the Ubuntu ESM channel
+// (esm-infra / esm-apps, available with an Ubuntu Pro subscription).
+const UbuntuProEntitlement = "ubuntu-pro"
+
+// markUbuntuProFixes marks the fixes of the given matches that are only available from the Ubuntu ESM channel with
+// the Ubuntu Pro entitlement. When the distro has the ESM channel (Ubuntu Pro is attached) these fixes can be installed,
+// so they are always considered fixed; otherwise the fix versions are reported but the match keeps the fix state of
+// the standard pocket (e.g. won't fix), unless proFixesAsFixed is set.
+func markUbuntuProFixes(store vulnerability.Provider, p pkg.Package, matches []match.Match, proFixesAsFixed bool) error {
+	if p.Distro == nil || p.Distro.Type != distro.Ubuntu || len(matches) == 0 {
+		return nil
+	}
+
+	proFixes, err := ubuntuProFixes(store, p)
+	if err != nil {
+		return err
+	}
+	if len(proFixes) == 0 {
+		return nil
+	}
+
+	esmAttached := shouldUseUbuntuESMMatching(p.Distro)
+	for i := range matches {
+		fix := &matches[i].Vulnerability.Fix
+		needed := proFixes[matches[i].Vulnerability.ID]
+		if len(needed) == 0 || hasStandardFix(*fix, needed) {
+			continue
+		}
+
+		fix.Entitlement = UbuntuProEntitlement
+		fix.Versions = needed
+		if esmAttached || proFixesAsFixed {
+			fix.State = vulnerability.FixStateFixed
+		}
+	}
+	return nil
+}
+
+// ubuntuProFixes returns the fix versions published in the ESM channel that are newer than the installed version of
+// the package (or of its source packages), by vulnerability ID.
+func ubuntuProFixes(store vulnerability.Provider, p pkg.Package) (map[string][]string, error) {
+	esmDistro := *p.Distro
+	esmDistro.Channels = []string{"esm"}
+
+	fixes := map[string][]string{}
+	for _, searchPkg := range append([]pkg.Package{p}, pkg.UpstreamPackages(p)...) {
+		vulns, err := store.FindVulnerabilities(
+			search.ByPackageName(searchPkg.Name),
+			search.ByDistro(esmDistro),
+			internal.OnlyQualifiedPackages(searchPkg),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		v := version.New(searchPkg.Version, pkg.VersionFormat(searchPkg))
+		for _, vuln := range vulns {
+			if vuln.Fix.State != vulnerability.FixStateFixed || vuln.Constraint == nil {
+				continue
+			}
+			for _, needed := range neededFixes(v, vuln.Fix.Versions, vuln.Constraint.Format(), vuln.ID) {
+				if !slices.Contains(fixes[vuln.ID], needed.Raw) {
+					fixes[vuln.ID] = append(fixes[vuln.ID], needed.Raw)
+				}
+			}
+		}
+	}
+	return fixes, nil
+}
+
+// hasStandardFix indicates if the fix includes a version from the standard pocket (that is, not only ESM versions).
+func hasStandardFix(fix vulnerability.Fix, proVersions []string) bool {
+	if fix.State != vulnerability.FixStateFixed {
+		return false
+	}
+	for _, v := range fix.Versions {
+		if !slices.Contains(proVersions, v) {
+			return true
+		}
+	}
+	return false
+}
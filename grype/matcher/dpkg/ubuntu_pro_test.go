@@ -0,0 +1,89 @@
+package dpkg
+
+import (
+	"testing"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/dbtest"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestUbuntuProFixes(t *testing.T) {
+	tests := []struct {
+		name              string
+		pkgName           string
+		pkgVersion        string
+		d                 *distro.Distro
+		proFixesAsFixed   bool
+		expectCVE         string
+		expectState       vulnerability.FixState
+		expectFixes       []string
+		expectEntitlement string
+	}{
+		{
+			// the standard pocket won't fix it, so without the ESM channel the Pro fix is reported but not fixed
+			name:              "pro-only fix without esm channel is not fixed",
+			pkgName:           pkgWayland,
+			pkgVersion:        verWaylandXenial,
+			d:                 distro.New(distro.Ubuntu, "16.04", ""),
+			expectCVE:         cveWayland,
+			expectState:       vulnerability.FixStateNotFixed,
+			expectFixes:       []string{esmFixWaylandXenial},
+			expectEntitlement: UbuntuProEntitlement,
+		},
+		{
+			name:              "pro-only fix without esm channel considered fixed when configured",
+			pkgName:           pkgWayland,
+			pkgVersion:        verWaylandXenial,
+			d:                 distro.New(distro.Ubuntu, "16.04", ""),
+			proFixesAsFixed:   true,
+			expectCVE:         cveWayland,
+			expectState:       vulnerability.FixStateFixed,
+			expectFixes:       []string{esmFixWaylandXenial},
+			expectEntitlement: UbuntuProEntitlement,
+		},
+		{
+			// with the ESM channel the Pro fix can be installed, so it is fixed (but still marked)
+			name:              "pro-only fix with esm channel is fixed",
+			pkgName:           pkgWayland,
+			pkgVersion:        verWaylandXenial,
+			d:                 newESMDistro("16.04"),
+			expectCVE:         cveWayland,
+			expectState:       vulnerability.FixStateFixed,
+			expectFixes:       []string{esmFixWaylandXenial},
+			expectEntitlement: UbuntuProEntitlement,
+		},
+		{
+			name:        "standard pocket fix requires no entitlement",
+			pkgName:     pkgWayland,
+			pkgVersion:  "1.17.0-1ubuntu1",
+			d:           newESMDistro("20.04"),
+			expectCVE:   cveWayland,
+			expectState: vulnerability.FixStateFixed,
+			expectFixes: []string{"1.18.0-1ubuntu0.1"},
+		},
+		{
+			name:        "no esm fix requires no entitlement",
+			pkgName:     "curl",
+			pkgVersion:  "7.47.0-1ubuntu2",
+			d:           newESMDistro("16.04"),
+			expectCVE:   "CVE-2016-9586",
+			expectState: vulnerability.FixStateNotFixed,
+		},
+	}
+
+	dbtest.DBs(t, "ubuntu-esm").Run(func(t *testing.T, db *dbtest.DB) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				matcher := NewDpkgMatcher(MatcherConfig{UbuntuProFixesAsFixed: tt.proFixesAsFixed})
+				p := dbtest.NewPackage(tt.pkgName, tt.pkgVersion, syftPkg.DebPkg).WithDistro(tt.d).Build()
+
+				db.Match(t, matcher, p).SkipCompleteness().
+					SelectMatch(tt.expectCVE).
+					HasFix(tt.expectState, tt.expectFixes...).
+					HasFixEntitlement(tt.expectEntitlement)
+			})
+		}
+	})
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -11,10 +13,12 @@ import (
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher/internal/result"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/pkg/qualifier"
 	"github.com/anchore/grype/grype/search"
 	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/cpe"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
@@ -35,6 +39,50 @@ func alpineCPEComparableVersion(version string) string {
 	return cpeComparableVersion
 }
 
+var (
+	// vendorRepackagingSuffixPattern matches version suffixes added by vendors that re-package upstream software without
+	// changing the upstream source, e.g. "-debian-12-r4" (Bitnami image tags) or "+vmware.1" (VMware Tanzu builds).
+	vendorRepackagingSuffixPattern = regexp.MustCompile(`(?:-(?:debian|photon|ubuntu|rhel|ol|alpine)-\d+(?:\.\d+)?-r\d+|\+vmware\.\d+)$`)
+
+	// bitnamiRevisionPattern matches the Bitnami package revision (e.g. the "-4" in "1.2.3-4")
+	bitnamiRevisionPattern = regexp.MustCompile(`^(\d+(?:\.\d+)*)-\d+$`)
+)
+
+// vendorCPEComparableVersion strips vendor re-packaging conventions from the version of a re-packaged package so that
+// it compares correctly against upstream (e.g. NVD) version constraints. Re-packaged builds use the same upstream source
+// as the version they were built from, so 1.2.3-debian-12-r4 is equivalent to 1.2.3 for purposes of CPE-based matching.
+// The versions of other packages are left as-is, since such suffixes may be meaningful for them.
+func vendorCPEComparableVersion(p pkg.Package, version string) string {
+	if !isVendorRepackaged(p) {
+		return version
+	}
+	version = vendorRepackagingSuffixPattern.ReplaceAllString(version, "")
+	if p.Type == syftPkg.BitnamiPkg {
+		version = bitnamiRevisionPattern.ReplaceAllString(version, "$1")
+	}
+	return version
+}
+
+// repackagingVendors are the PURL namespaces of vendors known to re-package upstream software (see
+// vendorRepackagingSuffixPattern).
+var repackagingVendors = []string{"bitnami", "vmware"}
+
+// isVendorRepackaged indicates if the package is a vendor re-packaging of upstream software: a Bitnami package, or a
+// package whose PURL is of the bitnami type or of a re-packaging vendor namespace (e.g. pkg:generic/vmware/...).
+func isVendorRepackaged(p pkg.Package) bool {
+	if p.Type == syftPkg.BitnamiPkg {
+		return true
+	}
+	if p.PURL == "" {
+		return false
+	}
+	purl, err := packageurl.FromString(p.PURL)
+	if err != nil {
+		return false
+	}
+	return purl.Type == "bitnami" || slices.Contains(repackagingVendors, strings.ToLower(purl.Namespace))
+}
+
 var ErrEmptyCPEMatch = errors.New("attempted CPE match against package with no CPEs")
 
 // MatchPackageByCPEs retrieves all vulnerabilities that match any of the provided package's CPEs
@@ -65,6 +113,8 @@ func MatchPackageByCPEs(vulnProvider vulnerability.Provider, p pkg.Package, upst
 			searchVersion = p.Version
 		}
 
+		searchVersion = vendorCPEComparableVersion(p, searchVersion)
+
 		if isUnknownVersion(searchVersion) {
 			log.WithFields("package", p.Name).Trace("skipping package with unknown version")
 			continue
@@ -151,9 +201,10 @@ func addNewMatch(matchesByFingerprint map[match.Fingerprint]match.Match, vuln vu
 }
 
 func CPEMatchDetails(matcherType match.MatcherType, vuln vulnerability.Vulnerability, searchedByCPE cpe.CPE, p pkg.Package, searchVersion *version.Version) match.Detail {
+	matchedCPEs := filterCPEsByVersion(searchVersion, vuln.CPEs)
 	return match.Detail{
 		Type:       match.CPEMatch,
-		Confidence: 0.9, // TODO: this is hard coded for now
+		Confidence: match.CPEMatch.Confidence(),
 		Matcher:    matcherType,
 		SearchedBy: match.CPEParameters{
 			Namespace: vuln.Namespace,
@@ -169,11 +220,38 @@ func CPEMatchDetails(matcherType match.MatcherType, vuln vulnerability.Vulnerabi
 		Found: match.CPEResult{
 			VulnerabilityID:   vuln.ID,
 			VersionConstraint: vuln.Constraint.String(),
-			CPEs:              cpesToString(filterCPEsByVersion(searchVersion, vuln.CPEs)),
+			CPEs:              cpesToString(matchedCPEs),
+			Qualifiers:        qualifier.Describe(vuln.PackageQualifiers, p),
+			Evidence: []match.CPEEvidence{
+				{
+					PackageCPE:    searchedByCPE.Attributes.String(),
+					Criteria:      cpesToString(cpeCriteriaFor(searchedByCPE, matchedCPEs)),
+					VersionBounds: version.SatisfiedBounds(vuln.Constraint, searchVersion),
+				},
+			},
 		},
 	}
 }
 
+// cpeCriteriaFor returns the vulnerability CPEs that describe the same product as the given package CPE. If none do
+// (e.g. the product was matched through an alias) all vulnerability CPEs are returned.
+func cpeCriteriaFor(packageCPE cpe.CPE, vulnCPEs []cpe.CPE) []cpe.CPE {
+	var criteria []cpe.CPE
+	for _, c := range vulnCPEs {
+		if !strings.EqualFold(c.Attributes.Product, packageCPE.Attributes.Product) {
+			continue
+		}
+		if c.Attributes.Vendor != wfn.Any && packageCPE.Attributes.Vendor != wfn.Any && !strings.EqualFold(c.Attributes.Vendor, packageCPE.Attributes.Vendor) {
+			continue
+		}
+		criteria = append(criteria, c)
+	}
+	if len(criteria) == 0 {
+		return vulnCPEs
+	}
+	return criteria
+}
+
 func addMatchDetails(existingDetails []match.Detail, newDetails match.Detail) []match.Detail {
 	newFound, ok := newDetails.Found.(match.CPEResult)
 	if !ok {
@@ -204,7 +282,10 @@ func addMatchDetails(existingDetails []match.Detail, newDetails match.Detail) []
 			continue
 		}
 
+		found.Evidence = mergeCPEEvidence(found.Evidence, newFound.Evidence)
+
 		existingDetails[idx].SearchedBy = searchedBy
+		existingDetails[idx].Found = found
 		return existingDetails
 	}
 
@@ -213,6 +294,19 @@ func addMatchDetails(existingDetails []match.Detail, newDetails match.Detail) []
 	return existingDetails
 }
 
+// mergeCPEEvidence appends the new evidence that is not already present for the same package CPE.
+func mergeCPEEvidence(existing, additional []match.CPEEvidence) []match.CPEEvidence {
+	for _, e := range additional {
+		if !slices.ContainsFunc(existing, func(o match.CPEEvidence) bool { return o.PackageCPE == e.PackageCPE }) {
+			existing = append(existing, e)
+		}
+	}
+	sort.Slice(existing, func(i, j int) bool {
+		return existing[i].PackageCPE < existing[j].PackageCPE
+	})
+	return existing
+}
+
 func filterCPEsByVersion(pkgVersion *version.Version, allCPEs []cpe.CPE) (matchedCPEs []cpe.CPE) {
 	if pkgVersion == nil {
 		// all CPEs are valid in the case when a version is not specified
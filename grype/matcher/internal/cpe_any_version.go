@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher/internal/result"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/cpe"
+)
+
+// AnyVersionCPEConfidence is the confidence assigned to CPE matches made without comparing versions.
+const AnyVersionCPEConfidence = 0.1
+
+// MatchPackageByCPEsAnyVersion matches the given package against every vulnerability recorded for any of its CPEs,
+// regardless of the affected version ranges. This is only meaningful for packages whose version is unknown, and the
+// resulting matches are given a low confidence since every version of the product is considered vulnerable.
+func MatchPackageByCPEsAnyVersion(vulnProvider vulnerability.Provider, p pkg.Package, upstreamMatcher match.MatcherType) ([]match.Match, error) {
+	if len(p.CPEs) == 0 {
+		return nil, nil
+	}
+
+	provider := result.NewProvider(vulnProvider, p, upstreamMatcher)
+
+	matchesByFingerprint := make(map[match.Fingerprint]match.Match)
+	for _, c := range p.CPEs {
+		// the package version is meaningless here, so search by (and report) the product alone
+		searchCPE := cpe.CPE{Attributes: c.Attributes, Source: c.Source}
+		searchCPE.Attributes.Version = ""
+
+		vulns, err := provider.FindResults(
+			search.ByCPE(searchCPE),
+			OnlyVulnerableTargets(p),
+			OnlyQualifiedPackages(p),
+			OnlyNonWithdrawnVulnerabilities(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("matcher failed to fetch by CPE pkg=%q: %w", p.Name, err)
+		}
+
+		for _, vuln := range vulns.Vulnerabilities() {
+			addNewMatch(matchesByFingerprint, vuln, p, nil, upstreamMatcher, searchCPE)
+		}
+	}
+
+	matches := toMatches(matchesByFingerprint)
+	for i := range matches {
+		for j := range matches[i].Details {
+			matches[i].Details[j].Confidence = AnyVersionCPEConfidence
+		}
+	}
+	return matches, nil
+}
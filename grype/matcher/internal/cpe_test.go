@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -145,7 +146,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								Namespace: "nvd:cpe",
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:3.7.5:rando4:*:re:*:rails:*:*"},
@@ -196,7 +197,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								Namespace: "nvd:cpe",
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:3.7.5:rando4:*:re:*:rails:*:*"},
@@ -248,7 +249,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs: []string{
 									"cpe:2.3:*:activerecord:activerecord:*:rando4:*:re:*:rails:*:*", //important!
@@ -287,7 +288,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:*:rando1:*:ra:*:ruby:*:*"}, //important!
 								Namespace: "nvd:cpe",
@@ -323,7 +324,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs: []string{
 									"cpe:2.3:*:activerecord:activerecord:*:rando1:*:ra:*:ruby:*:*",  //important!
@@ -392,7 +393,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs: []string{
 									"cpe:2.3:*:activerecord:activerecord:3.7.3:rando4:*:re:*:rails:*:*",
@@ -431,7 +432,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:activerecord:activerecord:3.7.3:rando1:*:ra:*:ruby:*:*"},
 								Namespace: "nvd:cpe",
@@ -480,7 +481,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:*:activerecord:4.0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -540,7 +541,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:awesome:awesome:98SE1:rando1:*:ra:*:dunno:*:*"},
 								Namespace: "nvd:cpe",
@@ -590,7 +591,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:multiple:multiple:1.0:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -654,7 +655,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:sw:sw:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -710,7 +711,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:*:funfun:funfun:5.2.1:*:*:*:*:python:*:*"},
 								Namespace: "nvd:cpe",
@@ -761,7 +762,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -811,7 +812,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -861,7 +862,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -911,7 +912,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -974,7 +975,7 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 					Details: []match.Detail{
 						{
 							Type:       match.CPEMatch,
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 							SearchedBy: match.CPEParameters{
 								CPEs:      []string{"cpe:2.3:a:handlebarsjs:handlebars:0.1:*:*:*:*:*:*:*"},
 								Namespace: "nvd:cpe",
@@ -1008,7 +1009,8 @@ func TestFindMatchesByPackageCPE(t *testing.T) {
 			assertMatchesUsingIDsForVulnerabilities(t, test.expected, actual)
 			for idx, e := range test.expected {
 				if idx < len(actual) {
-					if d := cmp.Diff(e.Details, actual[idx].Details); d != "" {
+					// CPE evidence is asserted separately (see TestCPEMatchDetails_Evidence)
+					if d := cmp.Diff(e.Details, actual[idx].Details, cmpopts.IgnoreFields(match.CPEResult{}, "Evidence")); d != "" {
 						t.Errorf("unexpected match details (-want +got):\n%s", d)
 					}
 				} else {
@@ -1488,11 +1490,13 @@ func TestMatchPackageByCPEs_IgnoreFilters(t *testing.T) {
 					Reason:           "CPE not vulnerable",
 					RelationshipType: artifact.OwnershipByFileOverlapRelationship,
 					VulnerabilityID:  "CVE-NO-MATCH",
+					Namespace:        "nvd:cpe",
 				},
 				match.IgnoreRelatedPackage{
 					Reason:           "CPE not vulnerable",
 					RelationshipType: artifact.OwnershipByFileOverlapRelationship,
 					VulnerabilityID:  "CVE-UNAFFECTED",
+					Namespace:        "nvd:cpe",
 				},
 			},
 		},
@@ -1522,6 +1526,7 @@ func TestMatchPackageByCPEs_IgnoreFilters(t *testing.T) {
 					Reason:           "CPE not vulnerable",
 					RelationshipType: artifact.OwnershipByFileOverlapRelationship,
 					VulnerabilityID:  "CVE-UNAFFECTED",
+					Namespace:        "nvd:cpe",
 				},
 			},
 		},
@@ -1536,3 +1541,110 @@ func TestMatchPackageByCPEs_IgnoreFilters(t *testing.T) {
 		})
 	}
 }
+
+func Test_vendorCPEComparableVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkg      pkg.Package
+		version  string
+		expected string
+	}{
+		{name: "bitnami", pkg: pkg.Package{Type: syftPkg.BitnamiPkg}, version: "1.2.3", expected: "1.2.3"},
+		{name: "bitnami revision", pkg: pkg.Package{Type: syftPkg.BitnamiPkg}, version: "1.2.3-4", expected: "1.2.3"},
+		{name: "bitnami debian tag", pkg: pkg.Package{Type: syftPkg.BitnamiPkg}, version: "3.2.4-debian-11-r8", expected: "3.2.4"},
+		{name: "bitnami photon tag", pkg: pkg.Package{Type: syftPkg.BitnamiPkg}, version: "3.2.4-photon-5-r1", expected: "3.2.4"},
+		{name: "bitnami pre-release", pkg: pkg.Package{Type: syftPkg.BitnamiPkg}, version: "1.2.3-rc1", expected: "1.2.3-rc1"},
+		{
+			name:     "vmware build",
+			pkg:      pkg.Package{Type: syftPkg.BinaryPkg, PURL: "pkg:generic/vmware/kubernetes@1.26.1%2Bvmware.1"},
+			version:  "1.26.1+vmware.1",
+			expected: "1.26.1",
+		},
+		{
+			name:     "bitnami purl",
+			pkg:      pkg.Package{Type: syftPkg.BinaryPkg, PURL: "pkg:bitnami/apache@2.4.58-debian-12-r3"},
+			version:  "2.4.58-debian-12-r3",
+			expected: "2.4.58",
+		},
+		// revisions are only a Bitnami convention; for other packages this may be a meaningful pre-release
+		{
+			name:     "bitnami purl revision",
+			pkg:      pkg.Package{Type: syftPkg.BinaryPkg, PURL: "pkg:bitnami/apache@2.4.58-4"},
+			version:  "1.2.3-4",
+			expected: "1.2.3-4",
+		},
+		// vendor suffixes are left intact for packages that are not vendor re-packagings
+		{name: "non-vendor debian tag", pkg: pkg.Package{Type: syftPkg.BinaryPkg}, version: "2.4.58-debian-12-r3", expected: "2.4.58-debian-12-r3"},
+		{
+			name:     "non-vendor vmware suffix",
+			pkg:      pkg.Package{Type: syftPkg.GoModulePkg, PURL: "pkg:golang/github.com/example/tool@v1.26.1%2Bvmware.1"},
+			version:  "1.26.1+vmware.1",
+			expected: "1.26.1+vmware.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, vendorCPEComparableVersion(tt.pkg, tt.version))
+		})
+	}
+}
+
+func TestCPEMatchDetails_Evidence(t *testing.T) {
+	p := pkg.Package{
+		ID: pkg.ID(uuid.NewString()),
+		CPEs: []cpe.CPE{
+			cpe.Must("cpe:2.3:*:activerecord:activerecord:3.7.5:*:*:*:*:rails:*:*", ""),
+		},
+		Name:     "activerecord",
+		Version:  "3.7.5",
+		Language: syftPkg.Ruby,
+		Type:     syftPkg.GemPkg,
+	}
+	vuln := vulnerability.Vulnerability{
+		Reference:   vulnerability.Reference{ID: "CVE-2017-fake-1", Namespace: "nvd:cpe"},
+		PackageName: "activerecord",
+		Constraint:  version.MustGetConstraint(">= 2.0.0, < 2.4.0 || >= 3.0.0, < 3.7.6", version.GemFormat),
+		CPEs: []cpe.CPE{
+			cpe.Must("cpe:2.3:*:activerecord:activerecord:*:*:*:*:*:rails:*:*", ""),
+			cpe.Must("cpe:2.3:*:rubyonrails:rails:*:*:*:*:*:*:*:*", ""),
+		},
+	}
+
+	detail := CPEMatchDetails(match.RubyGemMatcher, vuln, p.CPEs[0], p, version.New("3.7.5", version.GemFormat))
+
+	found, ok := detail.Found.(match.CPEResult)
+	require.True(t, ok)
+	assert.Equal(t, []match.CPEEvidence{
+		{
+			PackageCPE: "cpe:2.3:*:activerecord:activerecord:3.7.5:*:*:*:*:rails:*:*",
+			Criteria:   []string{"cpe:2.3:*:activerecord:activerecord:*:*:*:*:*:rails:*:*"},
+			VersionBounds: []version.Bounds{
+				{VersionStartIncluding: "3.0.0", VersionEndExcluding: "3.7.6"},
+			},
+		},
+	}, found.Evidence)
+}
+
+func TestAddMatchDetails_mergesEvidence(t *testing.T) {
+	newDetail := func(packageCPE string) match.Detail {
+		return match.Detail{
+			SearchedBy: match.CPEParameters{CPEs: []string{packageCPE}},
+			Found: match.CPEResult{
+				VulnerabilityID:   "CVE-2017-fake-1",
+				VersionConstraint: "< 3.7.6 (unknown)",
+				CPEs:              []string{"cpe:2.3:*:activerecord:activerecord:*:*:*:*:*:*:*:*"},
+				Evidence:          []match.CPEEvidence{{PackageCPE: packageCPE, Criteria: []string{"cpe:2.3:*:activerecord:activerecord:*:*:*:*:*:*:*:*"}}},
+			},
+		}
+	}
+
+	details := addMatchDetails(nil, newDetail("cpe:2.3:a:b:activerecord:3.7.5:*:*:*:*:*:*:*"))
+	details = addMatchDetails(details, newDetail("cpe:2.3:a:a:activerecord:3.7.5:*:*:*:*:*:*:*"))
+	details = addMatchDetails(details, newDetail("cpe:2.3:a:a:activerecord:3.7.5:*:*:*:*:*:*:*"))
+
+	require.Len(t, details, 1)
+	found := details[0].Found.(match.CPEResult)
+	require.Len(t, found.Evidence, 2)
+	assert.Equal(t, "cpe:2.3:a:a:activerecord:3.7.5:*:*:*:*:*:*:*", found.Evidence[0].PackageCPE)
+	assert.Equal(t, "cpe:2.3:a:b:activerecord:3.7.5:*:*:*:*:*:*:*", found.Evidence[1].PackageCPE)
+}
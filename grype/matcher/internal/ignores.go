@@ -19,6 +19,7 @@ func OwnershipIgnores(p pkg.Package, reason string, ignoredVulnerabilities ...vu
 				RelationshipType: artifact.OwnershipByFileOverlapRelationship,
 				VulnerabilityID:  ignoreVulnID,
 				RelatedPackageID: p.ID,
+				Namespace:        ignoredVulnerability.Namespace,
 			})
 		}
 	}
@@ -3,6 +3,7 @@ package result
 import (
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/pkg/qualifier"
 	"github.com/anchore/grype/grype/pkg/qualifier/gosymbols"
 	"github.com/anchore/grype/grype/search"
 	"github.com/anchore/grype/grype/vulnerability"
@@ -63,8 +64,9 @@ func detailProvider(matcher match.MatcherType, catalogedPkg pkg.Package, criteri
 	// the vulnerable Go symbols the package was found to use; empty for every non-Go match and for
 	// module-granularity Go matches where no specific symbol intersection decided the match.
 	matchedSymbols := gosymbols.MatchedSymbols(vuln.PackageQualifiers, catalogedPkg)
+	qualifiers := qualifier.Describe(vuln.PackageQualifiers, catalogedPkg)
 
-	return buildMatchDetails(matcher, distroMatchType, constraintStr, vuln, cpeParams, distroParams, ecosystemParams, matchedSymbols)
+	return buildMatchDetails(matcher, distroMatchType, constraintStr, vuln, cpeParams, distroParams, ecosystemParams, matchedSymbols, qualifiers)
 }
 
 // extractSearchParameters processes criteria set and extracts search parameters for different match types
@@ -157,7 +159,7 @@ func getConstraintString(vuln vulnerability.Vulnerability) string {
 }
 
 // buildMatchDetails creates the final match details from all parameters
-func buildMatchDetails(matcher match.MatcherType, distroMatchType match.Type, constraintStr string, vuln vulnerability.Vulnerability, cpeParams []match.CPEParameters, distroParams []match.DistroParameters, ecosystemParams []match.EcosystemParameters, matchedSymbols []string) match.Details {
+func buildMatchDetails(matcher match.MatcherType, distroMatchType match.Type, constraintStr string, vuln vulnerability.Vulnerability, cpeParams []match.CPEParameters, distroParams []match.DistroParameters, ecosystemParams []match.EcosystemParameters, matchedSymbols, qualifiers []string) match.Details {
 	var details match.Details
 
 	// add CPE match details
@@ -169,8 +171,9 @@ func buildMatchDetails(matcher match.MatcherType, distroMatchType match.Type, co
 			Found: match.CPEResult{
 				VulnerabilityID:   vuln.ID,
 				VersionConstraint: constraintStr,
+				Qualifiers:        qualifiers,
 			},
-			Confidence: 0.9, // TODO: this is hard coded for now
+			Confidence: match.CPEMatch.Confidence(),
 		})
 	}
 
@@ -183,8 +186,9 @@ func buildMatchDetails(matcher match.MatcherType, distroMatchType match.Type, co
 			Found: match.DistroResult{
 				VulnerabilityID:   vuln.ID,
 				VersionConstraint: constraintStr,
+				Qualifiers:        qualifiers,
 			},
-			Confidence: 1.0, // TODO: this is hard coded for now
+			Confidence: distroMatchType.Confidence(),
 		})
 	}
 
@@ -198,8 +202,9 @@ func buildMatchDetails(matcher match.MatcherType, distroMatchType match.Type, co
 				VulnerabilityID:   vuln.ID,
 				VersionConstraint: constraintStr,
 				MatchedSymbols:    matchedSymbols,
+				Qualifiers:        qualifiers,
 			},
-			Confidence: 1.0, // TODO: this is hard coded for now
+			Confidence: match.ExactDirectMatch.Confidence(),
 		})
 	}
 
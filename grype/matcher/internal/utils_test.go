@@ -16,8 +16,23 @@ func assertMatchesUsingIDsForVulnerabilities(t testing.TB, expected, actual []ma
 	for idx, a := range actual {
 		// only compare the vulnerability ID, nothing else
 		a.Vulnerability = vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: a.Vulnerability.ID}}
+		a.Details = withoutCPEEvidence(a.Details)
 		for _, d := range deep.Equal(expected[idx], a) {
 			t.Errorf("diff idx=%d: %+v", idx, d)
 		}
 	}
 }
+
+// withoutCPEEvidence strips CPE evidence from the given details (evidence is asserted separately, see
+// TestCPEMatchDetails_Evidence).
+func withoutCPEEvidence(details match.Details) match.Details {
+	stripped := make(match.Details, len(details))
+	for i, d := range details {
+		if found, ok := d.Found.(match.CPEResult); ok {
+			found.Evidence = nil
+			d.Found = found
+		}
+		stripped[i] = d
+	}
+	return stripped
+}
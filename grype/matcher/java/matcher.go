@@ -11,7 +11,9 @@ import (
 	"github.com/anchore/grype/grype/matcher/internal"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/bandwidth"
 	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/grype/internal/retry"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
 
@@ -28,6 +30,10 @@ type ExternalSearchConfig struct {
 	SearchMavenUpstream bool
 	MavenBaseURL        string
 	MavenRateLimit      time.Duration
+	// Retry configures how maven searches failing with transient network errors are retried
+	Retry retry.Config
+	// Bandwidth limits the rate of maven search downloads (unlimited when nil)
+	Bandwidth *bandwidth.Limiter
 }
 
 type MatcherConfig struct {
@@ -38,10 +44,21 @@ type MatcherConfig struct {
 func NewJavaMatcher(cfg MatcherConfig) *Matcher {
 	return &Matcher{
 		cfg:           cfg,
-		MavenSearcher: newMavenSearch(http.DefaultClient, cfg.MavenBaseURL, cfg.MavenRateLimit),
+		MavenSearcher: newMavenSearch(mavenSearchClient(cfg.ExternalSearchConfig), cfg.MavenBaseURL, cfg.MavenRateLimit),
 	}
 }
 
+func mavenSearchClient(cfg ExternalSearchConfig) *http.Client {
+	if cfg.Retry.MaxRetries <= 0 && cfg.Bandwidth == nil {
+		return http.DefaultClient
+	}
+	var transport http.RoundTripper = cfg.Bandwidth.Transport(http.DefaultTransport.(*http.Transport))
+	if cfg.Retry.MaxRetries > 0 {
+		transport = retry.Transport(transport, cfg.Retry)
+	}
+	return &http.Client{Transport: transport}
+}
+
 func (m *Matcher) PackageTypes() []syftPkg.Type {
 	return []syftPkg.Type{syftPkg.JavaPkg, syftPkg.JenkinsPluginPkg}
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher/apk"
 	"github.com/anchore/grype/grype/matcher/bitnami"
+	"github.com/anchore/grype/grype/matcher/bsd"
 	"github.com/anchore/grype/grype/matcher/dotnet"
 	"github.com/anchore/grype/grype/matcher/dpkg"
 	"github.com/anchore/grype/grype/matcher/golang"
@@ -18,6 +19,7 @@ import (
 	"github.com/anchore/grype/grype/matcher/ruby"
 	"github.com/anchore/grype/grype/matcher/rust"
 	"github.com/anchore/grype/grype/matcher/stock"
+	"github.com/anchore/grype/grype/matcher/terraform"
 )
 
 // Config contains values used by individual matcher structs for advanced configuration
@@ -30,7 +32,9 @@ type Config struct {
 	Golang     golang.MatcherConfig
 	Rust       rust.MatcherConfig
 	Hex        hex.MatcherConfig
+	Terraform  terraform.MatcherConfig
 	Stock      stock.MatcherConfig
+	Bitnami    bitnami.MatcherConfig
 	Dpkg       dpkg.MatcherConfig
 	Rpm        rpm.MatcherConfig
 }
@@ -50,8 +54,10 @@ func NewDefaultMatchers(mc Config) []match.Matcher {
 		&portage.Matcher{},
 		rust.NewRustMatcher(mc.Rust),
 		hex.NewHexMatcher(mc.Hex),
+		terraform.NewTerraformMatcher(mc.Terraform),
 		stock.NewStockMatcher(mc.Stock),
-		&bitnami.Matcher{},
+		bitnami.NewBitnamiMatcher(mc.Bitnami),
 		&pacman.Matcher{},
+		&bsd.Matcher{},
 	}
 }
@@ -25,5 +25,32 @@ func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Ma
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to match pacman package: %w", err)
 	}
-	return matches, ignoreFilters, nil
+
+	upstreamMatches, upstreamIgnores, err := m.matchUpstreamPackages(store, p)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to match pacman package by base package: %w", err)
+	}
+
+	return append(matches, upstreamMatches...), append(ignoreFilters, upstreamIgnores...), nil
+}
+
+// matchUpstreamPackages searches by the base package (pkgbase) of split packages, since the Arch Linux security
+// tracker reports vulnerabilities against the base package (e.g. "lib32-curl" and "curl" are both built from "curl").
+func (m *Matcher) matchUpstreamPackages(store vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+	var matches []match.Match
+	var ignores []match.IgnoreFilter
+
+	for _, indirectPackage := range pkg.UpstreamPackages(p) {
+		indirectMatches, ignored, err := internal.MatchPackageByDistro(store, indirectPackage, &p, m.Type(), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		matches = append(matches, indirectMatches...)
+		ignores = append(ignores, ignored...)
+	}
+
+	// track the match based on the package from the SBOM (not the indirect package)
+	match.ConvertToIndirectMatches(matches, p)
+
+	return matches, ignores, nil
 }
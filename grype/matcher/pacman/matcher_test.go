@@ -144,6 +144,40 @@ func TestMatchWithEpoch(t *testing.T) {
 	assert.Equal(t, "AVG-5678", actual[0].Vulnerability.ID)
 }
 
+func TestMatchByBasePackage(t *testing.T) {
+	archVuln := vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{
+			ID:        "AVG-1234",
+			Namespace: "arch:distro:archlinux:rolling",
+		},
+		PackageName: "curl",
+		Constraint:  version.MustGetConstraint("< 8.5.0-1", version.PacmanFormat),
+	}
+
+	vp := mock.VulnerabilityProvider(archVuln)
+
+	m := Matcher{}
+	d := distro.New(distro.ArchLinux, "", "rolling")
+
+	// split package built from the vulnerable base package
+	p := pkg.Package{
+		ID:        pkg.ID(uuid.NewString()),
+		Name:      "lib32-curl",
+		Version:   "8.4.0-1",
+		Type:      syftPkg.AlpmPkg,
+		Distro:    d,
+		Upstreams: []pkg.UpstreamPackage{{Name: "curl", Version: "8.4.0-1"}},
+	}
+
+	actual, _, err := m.Match(vp, p)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	assert.Equal(t, "AVG-1234", actual[0].Vulnerability.ID)
+	assert.Equal(t, p.ID, actual[0].Package.ID)
+	require.Len(t, actual[0].Details, 1)
+	assert.Equal(t, match.ExactIndirectMatch, actual[0].Details[0].Type)
+}
+
 func TestMatchNilDistro(t *testing.T) {
 	m := Matcher{}
 
@@ -5,6 +5,7 @@ import (
 	"github.com/anchore/grype/grype/matcher/internal"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
 
@@ -31,5 +32,23 @@ func (m *Matcher) Type() match.MatcherType {
 }
 
 func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
-	return internal.MatchPackageByEcosystemAndCPEs(store, p, m.Type(), m.cfg.UseCPEs)
+	matches, ignored, err := internal.MatchPackageByEcosystemAndCPEs(store, p, m.Type(), m.cfg.UseCPEs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// advisories affecting only an optional feature of a package are published against the package name with the
+	// extra (e.g. "requests[socks]"), and only apply when that extra is installed
+	for _, extra := range pkg.PythonExtras(p) {
+		name := p.Name + "[" + extra + "]"
+		extraMatches, extraIgnores, err := internal.MatchPackageByEcosystemPackageName(store, p, name, m.Type())
+		if err != nil {
+			log.WithFields("package", p.Name, "extra", extra, "error", err).Debug("could not match python package extra")
+			continue
+		}
+		matches = append(matches, extraMatches...)
+		ignored = append(ignored, extraIgnores...)
+	}
+
+	return matches, ignored, nil
 }
@@ -3,7 +3,15 @@ package python
 import (
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
 	"github.com/anchore/grype/internal/dbtest"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
@@ -184,3 +192,77 @@ func TestMatcher_ChainguardLibrariesSuppressesUpstreamGhsa(t *testing.T) {
 			})
 		})
 }
+
+func TestMatcher_Extras(t *testing.T) {
+	store := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-requests", Namespace: "github:language:python"},
+			PackageName: "requests",
+			Constraint:  version.MustGetConstraint("< 2.20.0", version.PythonFormat),
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-requests-socks", Namespace: "github:language:python"},
+			PackageName: "requests[socks]",
+			Constraint:  version.MustGetConstraint(">= 2.10.0, != 2.12.*, < 2.31.0", version.PythonFormat),
+		},
+	)
+
+	tests := []struct {
+		name     string
+		version  string
+		metadata any
+		expected []string
+	}{
+		{
+			name:     "extra not installed",
+			version:  "2.25.0",
+			expected: nil,
+		},
+		{
+			name:     "vulnerable extra installed",
+			version:  "2.25.0",
+			metadata: pkg.PythonMetadata{Extras: []string{"socks"}},
+			expected: []string{"GHSA-requests-socks"},
+		},
+		{
+			name:     "other extra installed",
+			version:  "2.25.0",
+			metadata: pkg.PythonMetadata{Extras: []string{"security"}},
+			expected: nil,
+		},
+		{
+			name:     "excluded version of the extra",
+			version:  "2.12.1",
+			metadata: pkg.PythonMetadata{Extras: []string{"socks"}},
+			expected: []string{"GHSA-requests"},
+		},
+		{
+			name:     "package and extra both vulnerable",
+			version:  "2.19.1",
+			metadata: pkg.PythonMetadata{Extras: []string{"socks"}},
+			expected: []string{"GHSA-requests", "GHSA-requests-socks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := pkg.Package{
+				ID:       pkg.ID(uuid.NewString()),
+				Name:     "requests",
+				Version:  tt.version,
+				Language: syftPkg.Python,
+				Type:     syftPkg.PythonPkg,
+				Metadata: tt.metadata,
+			}
+
+			matches, _, err := NewPythonMatcher(MatcherConfig{}).Match(store, p)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, m := range matches {
+				ids = append(ids, m.Vulnerability.ID)
+			}
+			assert.ElementsMatch(t, tt.expected, ids)
+		})
+	}
+}
@@ -34,6 +34,7 @@ func markAsIndirectMatches(results result.Set) result.Set {
 			for i, detail := range r.Details {
 				updatedDetails[i] = detail
 				updatedDetails[i].Type = match.ExactIndirectMatch
+				updatedDetails[i].Confidence = match.ExactIndirectMatch.Confidence()
 			}
 			r.Details = updatedDetails
 			updatedResults = append(updatedResults, r)
@@ -0,0 +1,82 @@
+package rpm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+)
+
+// the settings controlling missing epochs, as named in match diagnostics
+const (
+	missingEpochStrategySetting       = "rpm.missing-epoch-strategy"
+	binaryMissingEpochStrategySetting = "rpm.binary-missing-epoch-strategy"
+)
+
+// hasEpoch indicates if the epoch of the package is known, either from the version string or the RPM metadata.
+func hasEpoch(p pkg.Package) bool {
+	if strings.Contains(p.Version, ":") {
+		return true
+	}
+	meta, ok := p.Metadata.(pkg.RpmMetadata)
+	return ok && meta.Epoch != nil
+}
+
+// binaryMissingEpochStrategy returns how a binary package without a known epoch is compared (zero, by default).
+func (c MatcherConfig) binaryMissingEpochStrategy() version.MissingEpochStrategy {
+	if c.BinaryMissingEpochStrategy == version.MissingEpochStrategyAuto {
+		return version.MissingEpochStrategyAuto
+	}
+	return version.MissingEpochStrategyZero
+}
+
+// describeEpochAssumptions records, in the distro match details, when the epoch assumed for the given version (which
+// has no epoch) decided the outcome: that is, when the package would not be vulnerable with the other strategy. These
+// are the confusing cases, e.g. a package without epoch information compared against a fix with a non-zero epoch.
+func describeEpochAssumptions(matches []match.Match, ver string, strategy version.MissingEpochStrategy, setting string) {
+	alternative := alternativeEpochStrategy(strategy)
+	if alternative == "" {
+		return
+	}
+
+	for i := range matches {
+		constraint := matches[i].Vulnerability.Constraint
+		if constraint == nil {
+			continue
+		}
+		vulnerable, err := constraint.Satisfied(version.NewWithConfig(ver, version.RpmFormat, version.ComparisonConfig{
+			MissingEpochStrategy: alternative,
+		}))
+		if err != nil || vulnerable {
+			continue
+		}
+
+		note := fmt.Sprintf("package version %q has no epoch and was compared assuming %s (%s: %s); assuming %s it would not be vulnerable",
+			ver, describeEpochStrategy(strategy), setting, strategy, describeEpochStrategy(alternative))
+		for j, d := range matches[i].Details {
+			if r, ok := d.Found.(match.DistroResult); ok {
+				r.EpochAssumption = note
+				matches[i].Details[j].Found = r
+			}
+		}
+	}
+}
+
+func alternativeEpochStrategy(strategy version.MissingEpochStrategy) version.MissingEpochStrategy {
+	switch strategy {
+	case version.MissingEpochStrategyZero:
+		return version.MissingEpochStrategyAuto
+	case version.MissingEpochStrategyAuto:
+		return version.MissingEpochStrategyZero
+	}
+	return ""
+}
+
+func describeEpochStrategy(strategy version.MissingEpochStrategy) string {
+	if strategy == version.MissingEpochStrategyAuto {
+		return "the epoch of the vulnerable version range"
+	}
+	return "epoch 0"
+}
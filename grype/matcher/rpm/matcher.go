@@ -44,8 +44,12 @@ type Matcher struct {
 }
 
 type MatcherConfig struct {
+	// MissingEpochStrategy is how versions without an epoch are compared, such as those of source packages
 	MissingEpochStrategy version.MissingEpochStrategy
-	UseCPEsForEOL        bool
+	// BinaryMissingEpochStrategy is how binary packages without an epoch (in neither the version nor the RPM metadata)
+	// are compared: assumed to be epoch 0 ("zero", the default) or the epoch of the vulnerable version range ("auto")
+	BinaryMissingEpochStrategy version.MissingEpochStrategy
+	UseCPEsForEOL              bool
 }
 
 func NewRpmMatcher(cfg MatcherConfig) *Matcher {
@@ -129,7 +133,9 @@ func (m *Matcher) matchAlmaLinux(vp vulnerability.Provider, p pkg.Package) ([]ma
 
 	// Add epoch if applicable for the binary package
 	binaryPkg := p
-	addEpochIfApplicable(&binaryPkg)
+	if hasEpoch(p) || m.cfg.binaryMissingEpochStrategy() != version.MissingEpochStrategyAuto {
+		addEpochIfApplicable(&binaryPkg)
+	}
 
 	// Call almaLinuxMatches with both the binary package and its upstreams
 	return almaLinuxMatchesWithUpstreams(provider, binaryPkg)
@@ -149,20 +155,34 @@ func (m *Matcher) matchAlmaLinux(vp vulnerability.Provider, p pkg.Package) ([]ma
 // epoch (since downstream version comparison logic will strip the epoch during
 // comparison for the above-mentioned reasons --essentially for the source RPM
 // case). To do this, we fill in missing epoch values in the package versions with
-// an explicit 0.
+// an explicit 0 (unless configured to assume the epoch of the vulnerable version range instead, see
+// MatcherConfig.BinaryMissingEpochStrategy).
 func (m *Matcher) matchPackage(vp vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
 	provider := result.NewProvider(vp, p, m.Type())
 
+	epochAssumed := !hasEpoch(p) && !isUnknownVersion(p.Version)
+	epochlessVersion := p.Version
+	assumedStrategy := m.cfg.binaryMissingEpochStrategy()
+
 	// we want to ensure that the version ALWAYS has an epoch specified... but at the same time we do not want to modify the
 	// original package that was passed in when making matches. This is why we create the provider with the original package
 	// then patch the epoch into the version of the package that we are searching with.
-	addEpochIfApplicable(&p)
+	searchStrategy := m.cfg.MissingEpochStrategy
+	if epochAssumed && assumedStrategy == version.MissingEpochStrategyAuto {
+		searchStrategy = version.MissingEpochStrategyAuto
+	} else {
+		addEpochIfApplicable(&p)
+	}
 
-	matches, ignores, err := m.findMatches(provider, p)
+	matches, ignores, err := m.findMatches(provider, p, searchStrategy)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to find vulnerabilities by dpkg source indirection: %w", err)
 	}
 
+	if epochAssumed {
+		describeEpochAssumptions(matches, epochlessVersion, assumedStrategy, binaryMissingEpochStrategySetting)
+	}
+
 	return matches, ignores, nil
 }
 
@@ -219,10 +239,14 @@ func (m *Matcher) matchUpstreamPackages(vp vulnerability.Provider, p pkg.Package
 		// architecture qualifier then matches it only against src (and unspecified) records
 		// and rejects binary-arch records — which is how we avoid matching a binary's
 		// upstream against a sibling binary's vulnerability.
-		indirectMatches, ignores, err := m.findMatches(provider, indirectPackage)
+		indirectMatches, ignores, err := m.findMatches(provider, indirectPackage, m.cfg.MissingEpochStrategy)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to find vulnerabilities for rpm upstream source package: %w", err)
 		}
+		// the metadata is that of the binary package, so only the (source package) version tells if there is an epoch
+		if !strings.Contains(indirectPackage.Version, ":") && !isUnknownVersion(indirectPackage.Version) {
+			describeEpochAssumptions(indirectMatches, indirectPackage.Version, m.cfg.MissingEpochStrategy, missingEpochStrategySetting)
+		}
 		matches = append(matches, indirectMatches...)
 		ignored = append(ignored, ignores...)
 	}
@@ -230,7 +254,7 @@ func (m *Matcher) matchUpstreamPackages(vp vulnerability.Provider, p pkg.Package
 	return matches, ignored, nil
 }
 
-func (m *Matcher) findMatches(provider result.Provider, searchPkg pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+func (m *Matcher) findMatches(provider result.Provider, searchPkg pkg.Package, missingEpochStrategy version.MissingEpochStrategy) ([]match.Match, []match.IgnoreFilter, error) {
 	if searchPkg.Distro == nil {
 		return nil, nil, nil
 	}
@@ -241,19 +265,19 @@ func (m *Matcher) findMatches(provider result.Provider, searchPkg pkg.Package) (
 
 	switch {
 	case shouldUseRedhatEUSMatching(searchPkg.Distro):
-		return redhatEUSMatches(provider, searchPkg, m.cfg.MissingEpochStrategy)
+		return redhatEUSMatches(provider, searchPkg, missingEpochStrategy)
 	default:
-		return m.standardMatches(provider, searchPkg)
+		return m.standardMatches(provider, searchPkg, missingEpochStrategy)
 	}
 }
 
-func (m *Matcher) standardMatches(provider result.Provider, searchPkg pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+func (m *Matcher) standardMatches(provider result.Provider, searchPkg pkg.Package, missingEpochStrategy version.MissingEpochStrategy) ([]match.Match, []match.IgnoreFilter, error) {
 	// Create version with config embedded
 	pkgVersion := version.NewWithConfig(
 		searchPkg.Version,
 		pkg.VersionFormat(searchPkg),
 		version.ComparisonConfig{
-			MissingEpochStrategy: m.cfg.MissingEpochStrategy,
+			MissingEpochStrategy: missingEpochStrategy,
 		},
 	)
 
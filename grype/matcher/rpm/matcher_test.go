@@ -8,6 +8,7 @@ import (
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/internal/dbtest"
 	"github.com/anchore/syft/syft/artifact"
 	syftPkg "github.com/anchore/syft/syft/pkg"
@@ -207,6 +208,90 @@ func TestMatcherRpm_PackageHigherEpochNoMatch(t *testing.T) {
 		})
 }
 
+// TestMatcherRpm_PackageWithoutEpochExplainsAssumption verifies that when the
+// epoch assumed for a package without any epoch information decides the match
+// (0:1.1.1z-99.el8 < 1:1.1.1c-2.el8, whereas 1:1.1.1z-99.el8 is past the fix),
+// the distro detail explains the assumption.
+func TestMatcherRpm_PackageWithoutEpochExplainsAssumption(t *testing.T) {
+	dbtest.DBs(t, "rhel8").
+		SelectOnly("rhel:8/cve-2018-0735").
+		Run(func(t *testing.T, db *dbtest.DB) {
+			matcher := Matcher{}
+			p := dbtest.NewPackage("openssl", "1.1.1z-99.el8", syftPkg.RpmPkg).
+				WithArchitecture("aarch64").
+				WithDistro(dbtest.RHEL8).
+				Build()
+
+			db.Match(t, &matcher, p).
+				SelectMatch("CVE-2018-0735").
+				SelectDetailByType(match.ExactDirectMatch).
+				AsDistroSearch().
+				HasEpochAssumption(`package version "1.1.1z-99.el8" has no epoch and was compared assuming epoch 0 (rpm.binary-missing-epoch-strategy: zero)`)
+		})
+}
+
+// TestMatcherRpm_PackageWithoutEpochAssumptionNotDeciding verifies that no
+// explanation is recorded when the package is vulnerable regardless of the
+// assumed epoch.
+func TestMatcherRpm_PackageWithoutEpochAssumptionNotDeciding(t *testing.T) {
+	dbtest.DBs(t, "rhel8").
+		SelectOnly("rhel:8/cve-2018-0735").
+		Run(func(t *testing.T, db *dbtest.DB) {
+			matcher := Matcher{}
+			p := dbtest.NewPackage("openssl", "1.1.1b-1.el8", syftPkg.RpmPkg).
+				WithArchitecture("aarch64").
+				WithDistro(dbtest.RHEL8).
+				Build()
+
+			db.Match(t, &matcher, p).
+				SelectMatch("CVE-2018-0735").
+				SelectDetailByType(match.ExactDirectMatch).
+				AsDistroSearch().
+				HasEpochAssumption("")
+		})
+}
+
+// TestMatcherRpm_PackageWithoutEpochAutoStrategy verifies that with the auto
+// binary strategy a package without any epoch information assumes the epoch of
+// the fix (1:1.1.1z-99.el8 is past 1:1.1.1c-2.el8), so it is not vulnerable.
+func TestMatcherRpm_PackageWithoutEpochAutoStrategy(t *testing.T) {
+	dbtest.DBs(t, "rhel8").
+		SelectOnly("rhel:8/cve-2018-0735").
+		Run(func(t *testing.T, db *dbtest.DB) {
+			matcher := NewRpmMatcher(MatcherConfig{BinaryMissingEpochStrategy: version.MissingEpochStrategyAuto})
+			p := dbtest.NewPackage("openssl", "1.1.1z-99.el8", syftPkg.RpmPkg).
+				WithArchitecture("aarch64").
+				WithDistro(dbtest.RHEL8).
+				Build()
+
+			db.Match(t, matcher, p).Ignores().
+				SelectRelatedPackageIgnore(IgnoreReasonDistroNotVulnerable, "CVE-2018-0735")
+		})
+}
+
+// TestMatcherRpm_UpstreamWithoutEpochExplainsAssumption verifies that the
+// explanation is also recorded for source packages, whose versions usually have
+// no epoch, when matching with the zero strategy.
+func TestMatcherRpm_UpstreamWithoutEpochExplainsAssumption(t *testing.T) {
+	dbtest.DBs(t, "rhel8").
+		SelectOnly("rhel:8/cve-2018-0735").
+		Run(func(t *testing.T, db *dbtest.DB) {
+			matcher := NewRpmMatcher(MatcherConfig{MissingEpochStrategy: version.MissingEpochStrategyZero})
+			p := dbtest.NewPackage("openssl-libs", "1:1.1.1z-99.el8", syftPkg.RpmPkg).
+				WithArchitecture("x86_64").
+				WithDistro(dbtest.RHEL8).
+				WithUpstream("openssl", "1.1.1z-99.el8").
+				WithMetadata(pkg.RpmMetadata{Epoch: intPtr(1)}).
+				Build()
+
+			db.Match(t, matcher, p).
+				SelectMatch("CVE-2018-0735").
+				SelectDetailByType(match.ExactIndirectMatch).
+				AsDistroSearch().
+				HasEpochAssumption(`assuming epoch 0 (rpm.missing-epoch-strategy: zero); assuming the epoch of the vulnerable version range it would not be vulnerable`)
+		})
+}
+
 // TestMatcherRpm_DistroNotVulnerableIgnore exercises the rpm standard-matcher
 // ignore path: when a RHEL CVE record exists for a package but the package
 // version is at or past the fix, the matcher emits a "Distro Not Vulnerable"
@@ -0,0 +1,77 @@
+package ruby
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// TestMatcher_PlatformGems verifies that platform-specific gem builds (as reported by bundler and syft, e.g.
+// "1.16.4-x86_64-linux") are evaluated against GHSA ranges the same way as the ruby platform gem, including
+// prerelease builds.
+func TestMatcher_PlatformGems(t *testing.T) {
+	vp := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{
+				ID:        "GHSA-r95h-9x8f-r3f7",
+				Namespace: "github:language:ruby",
+			},
+			PackageName: "nokogiri",
+			Constraint:  version.MustGetConstraint("< 1.16.5", version.GemFormat),
+		},
+		vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{
+				ID:        "GHSA-xc9x-jj77-9p9j",
+				Namespace: "github:language:ruby",
+			},
+			PackageName: "nokogiri",
+			Constraint:  version.MustGetConstraint(">= 1.16.0.rc1, < 1.16.0", version.GemFormat),
+		},
+	)
+
+	tests := []struct {
+		version string
+		wantIDs []string
+	}{
+		{version: "1.16.4-x86_64-linux", wantIDs: []string{"GHSA-r95h-9x8f-r3f7"}},
+		{version: "1.16.4-aarch64-linux-gnu", wantIDs: []string{"GHSA-r95h-9x8f-r3f7"}},
+		{version: "1.16.4-java", wantIDs: []string{"GHSA-r95h-9x8f-r3f7"}},
+		{version: "1.16.5-x86_64-linux"},
+		{version: "1.16.5-i686-linux"},
+		{version: "1.16.5-x64-mingw-ucrt"},
+		{version: "1.16.5-arm64-darwin"},
+		{version: "1.16.0.rc1-x86_64-linux", wantIDs: []string{"GHSA-r95h-9x8f-r3f7", "GHSA-xc9x-jj77-9p9j"}},
+		{version: "1.16.0-x86_64-linux", wantIDs: []string{"GHSA-r95h-9x8f-r3f7"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			matcher := NewRubyMatcher(MatcherConfig{})
+			p := pkg.Package{
+				ID:       pkg.ID(uuid.NewString()),
+				Name:     "nokogiri",
+				Version:  test.version,
+				Language: syftPkg.Ruby,
+				Type:     syftPkg.GemPkg,
+			}
+
+			matches, _, err := matcher.Match(vp, p)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, m := range matches {
+				assert.Equal(t, test.version, m.Package.Version)
+				ids = append(ids, m.Vulnerability.ID)
+			}
+			assert.ElementsMatch(t, test.wantIDs, ids)
+		})
+	}
+}
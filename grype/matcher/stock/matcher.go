@@ -33,3 +33,12 @@ func (m *Matcher) Type() match.MatcherType {
 func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
 	return internal.MatchPackageByEcosystemAndCPEs(store, p, m.Type(), m.cfg.UseCPEs)
 }
+
+// AnyVersionCPEConfidence is the confidence assigned to matches found by MatchAnyVersionByCPEs.
+const AnyVersionCPEConfidence = internal.AnyVersionCPEConfidence
+
+// MatchAnyVersionByCPEs matches a package with an unknown version against every vulnerability recorded for its CPEs,
+// regardless of affected version ranges (with a low confidence).
+func MatchAnyVersionByCPEs(store vulnerability.Provider, p pkg.Package) ([]match.Match, error) {
+	return internal.MatchPackageByCPEsAnyVersion(store, p, match.StockMatcher)
+}
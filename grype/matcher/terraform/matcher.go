@@ -0,0 +1,105 @@
+package terraform
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher/internal"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// publicRegistries are the registry hosts whose providers and modules follow the GitHub repository naming conventions.
+var publicRegistries = []string{"registry.terraform.io", "registry.opentofu.org"}
+
+type Matcher struct {
+	cfg MatcherConfig
+}
+
+type MatcherConfig struct {
+	UseCPEs bool
+}
+
+func NewTerraformMatcher(cfg MatcherConfig) *Matcher {
+	return &Matcher{
+		cfg: cfg,
+	}
+}
+
+func (m *Matcher) PackageTypes() []syftPkg.Type {
+	return []syftPkg.Type{syftPkg.TerraformPkg}
+}
+
+func (m *Matcher) Type() match.MatcherType {
+	return match.TerraformMatcher
+}
+
+// Match finds vulnerabilities for terraform providers and modules. Providers are Go modules and advisories for
+// them (e.g. GHSA) are published against their source repository, so both the registry address and the repository
+// are searched within the Go ecosystem.
+func (m *Matcher) Match(store vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+	goPkg := p
+	goPkg.Language = syftPkg.Go
+
+	var matches []match.Match
+	var ignored []match.IgnoreFilter
+	for _, name := range goModuleNames(p.Name) {
+		nameMatches, nameIgnores, err := internal.MatchPackageByEcosystemPackageName(store, goPkg, name, m.Type())
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range nameMatches {
+			nameMatches[i].Package = p
+		}
+		matches = append(matches, nameMatches...)
+		ignored = append(ignored, nameIgnores...)
+	}
+
+	if m.cfg.UseCPEs {
+		cpeMatches, cpeIgnores, err := internal.MatchPackageByCPEs(store, p, m.Type())
+		switch {
+		case errors.Is(err, internal.ErrEmptyCPEMatch):
+			log.Debugf("attempted CPE search on %s, which has no CPEs. Consider re-running with --add-cpes-if-none", p.Name)
+		case err != nil:
+			log.Debugf("could not match by package CPE (package=%+v): %v", p, err)
+		}
+		matches = append(matches, cpeMatches...)
+		ignored = append(ignored, cpeIgnores...)
+	}
+
+	return matches, ignored, nil
+}
+
+// goModuleNames returns the names advisories for the given provider or module address may be published under:
+// the address itself and, for the public registries, the GitHub repository it is released from by convention
+// (e.g. "registry.terraform.io/hashicorp/aws" is released from "github.com/hashicorp/terraform-provider-aws", and
+// "registry.terraform.io/terraform-aws-modules/vpc/aws" from "github.com/terraform-aws-modules/terraform-aws-vpc").
+func goModuleNames(address string) []string {
+	names := []string{address}
+
+	parts := strings.Split(address, "/")
+	if !isPublicRegistry(parts[0]) {
+		return names
+	}
+	switch len(parts) {
+	case 3:
+		// provider: host/namespace/type
+		names = append(names, "github.com/"+parts[1]+"/terraform-provider-"+parts[2])
+	case 4:
+		// module: host/namespace/name/provider
+		names = append(names, "github.com/"+parts[1]+"/terraform-"+parts[3]+"-"+parts[2])
+	}
+	return names
+}
+
+func isPublicRegistry(host string) bool {
+	for _, r := range publicRegistries {
+		if strings.EqualFold(host, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestMatcher(t *testing.T) {
+	store := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-provider", Namespace: "github:language:go"},
+			PackageName: "github.com/hashicorp/terraform-provider-aws",
+			Constraint:  version.MustGetConstraint("< 5.31.0", version.GolangFormat),
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-module", Namespace: "github:language:go"},
+			PackageName: "github.com/terraform-aws-modules/terraform-aws-vpc",
+			Constraint:  version.MustGetConstraint("< 5.1.0", version.GolangFormat),
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "GHSA-git-module", Namespace: "github:language:go"},
+			PackageName: "github.com/example/terraform-modules",
+			Constraint:  version.MustGetConstraint("< 1.2.0", version.GolangFormat),
+		},
+	)
+
+	tests := []struct {
+		name     string
+		pkg      pkg.Package
+		expected []string
+	}{
+		{
+			name:     "vulnerable provider is matched by its repository",
+			pkg:      pkg.Package{Name: "registry.terraform.io/hashicorp/aws", Version: "5.30.0", Language: syftPkg.Go},
+			expected: []string{"GHSA-provider"},
+		},
+		{
+			name: "fixed provider",
+			pkg:  pkg.Package{Name: "registry.terraform.io/hashicorp/aws", Version: "5.31.0", Language: syftPkg.Go},
+		},
+		{
+			name:     "vulnerable registry module is matched by its repository",
+			pkg:      pkg.Package{Name: "registry.terraform.io/terraform-aws-modules/vpc/aws", Version: "5.0.0"},
+			expected: []string{"GHSA-module"},
+		},
+		{
+			name:     "vulnerable git module is matched by its address",
+			pkg:      pkg.Package{Name: "github.com/example/terraform-modules", Version: "v1.1.0"},
+			expected: []string{"GHSA-git-module"},
+		},
+		{
+			name: "providers of private registries are not matched by repository",
+			pkg:  pkg.Package{Name: "tf.example.com/hashicorp/aws", Version: "5.30.0", Language: syftPkg.Go},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.pkg.ID = pkg.ID(uuid.NewString())
+			tt.pkg.Type = syftPkg.TerraformPkg
+
+			matches, _, err := NewTerraformMatcher(MatcherConfig{UseCPEs: false}).Match(store, tt.pkg)
+			require.NoError(t, err)
+
+			var ids []string
+			for _, m := range matches {
+				ids = append(ids, m.Vulnerability.ID)
+				assert.Equal(t, tt.pkg, m.Package)
+			}
+			assert.ElementsMatch(t, tt.expected, ids)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"howett.net/plist"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/cpe"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+// applePackageReceipt is the receipt macOS records for each installer package (.pkg) installed
+// (/var/db/receipts/<identifier>.plist).
+type applePackageReceipt struct {
+	PackageIdentifier string `plist:"PackageIdentifier"`
+	PackageVersion    string `plist:"PackageVersion"`
+}
+
+// appleIdentifierNoise are the components of bundle and package identifiers that do not name the product (e.g.
+// "com.microsoft.package.Microsoft_Word.app")
+var appleIdentifierNoise = map[string]bool{
+	"app":       true,
+	"pkg":       true,
+	"mpkg":      true,
+	"package":   true,
+	"installer": true,
+	"component": true,
+}
+
+// appleBundleCPEs returns the CPEs of the given macOS application bundle package that are not within the existing
+// CPEs. The CPEs syft generates for application bundles are named after the application alone, while NVD names the
+// vendor after the publisher, which is the second component of the reverse-DNS bundle identifier (e.g. "google" of
+// "com.google.Chrome").
+func appleBundleCPEs(p syftPkg.Package, existing []cpe.CPE) []cpe.CPE {
+	metadata, ok := p.Metadata.(syftPkg.AppleAppBundleEntry)
+	if !ok {
+		return nil
+	}
+	vendor, names := appleIdentifierProduct(metadata.BundleIdentifier)
+	if vendor == "" {
+		return nil
+	}
+	product := installedProduct{Vendor: vendor, Name: p.Name, Version: p.Version, Aliases: names}
+	return additionalCPEs(product.cpes(), existing)
+}
+
+// appleIdentifierProduct returns the vendor and the product names of the given reverse-DNS identifier (e.g. "mozilla"
+// and ["firefox"] for "org.mozilla.firefox").
+func appleIdentifierProduct(identifier string) (vendor string, names []string) {
+	components := strings.Split(strings.TrimSpace(identifier), ".")
+	if len(components) < 2 {
+		return "", nil
+	}
+	for _, c := range components[2:] {
+		if c != "" && !appleIdentifierNoise[strings.ToLower(c)] {
+			names = append(names, c)
+		}
+	}
+	return components[1], names
+}
+
+// NewApplePackageReceiptCataloger returns a cataloger for the installer packages (.pkg) recorded as installed by the
+// receipts of macOS. Packages are named after their identifier (e.g. "org.python.Python.PythonFramework-3.12") and come
+// with the CPEs derived from it, since there is no other ecosystem to match them by. The receipts of Apple's own
+// packages are skipped: these are components of macOS versioned by build.
+func NewApplePackageReceiptCataloger() syftPkg.Cataloger {
+	return generic.NewCataloger("apple-package-receipt-cataloger").
+		WithParserByGlobs(parseApplePackageReceipt, "**/var/db/receipts/*.plist")
+}
+
+func parseApplePackageReceipt(_ context.Context, _ file.Resolver, _ *generic.Environment, reader file.LocationReadCloser) ([]syftPkg.Package, []artifact.Relationship, error) {
+	// receipts are small, the limit guards against unrelated files matching the glob
+	data, err := io.ReadAll(io.LimitReader(reader, 5*1024*1024))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read package receipt %q: %w", reader.RealPath, err)
+	}
+	var receipt applePackageReceipt
+	if _, err := plist.Unmarshal(data, &receipt); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse package receipt %q: %w", reader.RealPath, err)
+	}
+
+	identifier := strings.TrimSpace(receipt.PackageIdentifier)
+	version := strings.TrimSpace(receipt.PackageVersion)
+	if identifier == "" || version == "" || strings.HasPrefix(identifier, "com.apple.pkg.") {
+		return nil, nil, nil
+	}
+
+	var cpes []cpe.CPE
+	if vendor, names := appleIdentifierProduct(identifier); vendor != "" && len(names) > 0 {
+		cpes = installedProduct{Vendor: vendor, Name: names[0], Version: version, Aliases: names[1:]}.cpes()
+	}
+
+	p := syftPkg.Package{
+		Name:      identifier,
+		Version:   version,
+		Locations: file.NewLocationSet(reader.WithAnnotation(syftPkg.EvidenceAnnotationKey, syftPkg.PrimaryEvidenceAnnotation)),
+		Licenses:  syftPkg.NewLicenseSet(),
+		Type:      syftPkg.BinaryPkg,
+		CPEs:      cpes,
+	}
+	p.SetID()
+	return []syftPkg.Package{p}, nil, nil
+}
@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/cpe"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestParseApplePackageReceipt(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		version string
+		cpes    []string
+	}{
+		{
+			name:    "third party package",
+			path:    "testdata/apple/var/db/receipts/com.microsoft.package.Microsoft_Word.app.plist",
+			version: "16.86.24060916",
+			cpes: []string{
+				"cpe:2.3:a:microsoft:microsoft_word:16.86.24060916:*:*:*:*:*:*:*",
+				"cpe:2.3:a:microsoft:word:16.86.24060916:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name: "apple package",
+			path: "testdata/apple/var/db/receipts/com.apple.pkg.XProtectPayloads_10_15.16U4211.plist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.path)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = f.Close() })
+
+			pkgs, relationships, err := parseApplePackageReceipt(context.Background(), nil, nil, file.NewLocationReadCloser(file.NewLocation(tt.path), f))
+			require.NoError(t, err)
+			assert.Empty(t, relationships)
+			if tt.version == "" {
+				assert.Empty(t, pkgs)
+				return
+			}
+			require.Len(t, pkgs, 1)
+			assert.Equal(t, "com.microsoft.package.Microsoft_Word.app", pkgs[0].Name)
+			assert.Equal(t, tt.version, pkgs[0].Version)
+			assert.Equal(t, syftPkg.BinaryPkg, pkgs[0].Type)
+
+			var cpes []string
+			for _, c := range pkgs[0].CPEs {
+				cpes = append(cpes, c.Attributes.String())
+			}
+			assert.Equal(t, tt.cpes, cpes)
+		})
+	}
+}
+
+func TestAppleBundleCPEs(t *testing.T) {
+	p := syftPkg.Package{
+		Name:    "Firefox",
+		Version: "128.0.3",
+		Type:    syftPkg.AppleAppBundlePkg,
+		Metadata: syftPkg.AppleAppBundleEntry{
+			BundleIdentifier: "org.mozilla.firefox",
+			Name:             "Firefox",
+			ShortVersion:     "128.0.3",
+		},
+	}
+	existing := []cpe.CPE{cpe.Must("cpe:2.3:a:firefox:firefox:128.0.3:*:*:*:*:*:*:*", cpe.GeneratedSource)}
+
+	var got []string
+	for _, c := range appleBundleCPEs(p, existing) {
+		got = append(got, c.Attributes.String())
+	}
+	assert.Equal(t, []string{"cpe:2.3:a:mozilla:firefox:128.0.3:*:*:*:*:*:*:*"}, got)
+
+	p.Metadata = syftPkg.AppleAppBundleEntry{Name: "Firefox"}
+	assert.Empty(t, appleBundleCPEs(p, nil), "no bundle identifier")
+}
+
+func TestAppleIdentifierProduct(t *testing.T) {
+	tests := []struct {
+		identifier string
+		vendor     string
+		names      []string
+	}{
+		{identifier: "com.google.Chrome", vendor: "google", names: []string{"Chrome"}},
+		{identifier: "com.microsoft.package.Microsoft_Word.app", vendor: "microsoft", names: []string{"Microsoft_Word"}},
+		{identifier: "org.videolan.vlc", vendor: "videolan", names: []string{"vlc"}},
+		{identifier: "com.docker", vendor: "docker"},
+		{identifier: "Safari"},
+		{identifier: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			vendor, names := appleIdentifierProduct(tt.identifier)
+			assert.Equal(t, tt.vendor, vendor)
+			assert.Equal(t, tt.names, names)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// setArtifactDigests records the digests of the artifacts the packages were found in within the context: the archive
+// digests of java packages and the SBOM file digests of the package locations (when the SBOM has them). These are the
+// digests known-malicious artifacts are looked up by.
+func setArtifactDigests(packages []*Package, ctx *Context, s *sbom.SBOM) {
+	digests := make(map[ID][]string)
+	for _, p := range packages {
+		if d := artifactDigests(*p, s); len(d) > 0 {
+			digests[p.ID] = d
+		}
+	}
+	if len(digests) > 0 {
+		ctx.ArtifactDigests = digests
+	}
+}
+
+// artifactDigests returns the self describing digests (e.g. "sha256:...") of the artifacts the given package was
+// found in.
+func artifactDigests(p Package, s *sbom.SBOM) []string {
+	set := strset.New()
+	add := func(algorithm, value string) {
+		if algorithm == "" || value == "" {
+			return
+		}
+		set.Add(fmt.Sprintf("%s:%s", strings.ToLower(algorithm), strings.ToLower(value)))
+	}
+
+	if m, ok := p.Metadata.(JavaMetadata); ok {
+		for _, d := range m.ArchiveDigests {
+			add(d.Algorithm, d.Value)
+		}
+	}
+	if s != nil {
+		for _, l := range p.Locations.ToSlice() {
+			for _, d := range s.Artifacts.FileDigests[l.Coordinates] {
+				add(d.Algorithm, d.Value)
+			}
+		}
+	}
+
+	if set.Size() == 0 {
+		return nil
+	}
+	out := set.List()
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,54 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func TestSetArtifactDigests(t *testing.T) {
+	binLocation := file.NewLocation("/usr/bin/evil")
+	jar := &Package{
+		ID:   "jar",
+		Name: "evil",
+		Metadata: JavaMetadata{ArchiveDigests: []Digest{
+			{Algorithm: "sha1", Value: "ABCD"},
+			{Algorithm: "sha256", Value: "ef01"},
+		}},
+	}
+	binary := &Package{
+		ID:        "binary",
+		Name:      "evil-bin",
+		Locations: file.NewLocationSet(binLocation),
+	}
+	other := &Package{
+		ID:        "other",
+		Name:      "benign",
+		Locations: file.NewLocationSet(file.NewLocation("/usr/bin/benign")),
+	}
+
+	s := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			FileDigests: map[file.Coordinates][]file.Digest{
+				binLocation.Coordinates: {
+					{Algorithm: "sha256", Value: "1234"},
+					{Algorithm: "sha1", Value: "5678"},
+				},
+			},
+		},
+	}
+
+	var ctx Context
+	setArtifactDigests([]*Package{jar, binary, other}, &ctx, s)
+	assert.Equal(t, map[ID][]string{
+		"jar":    {"sha1:abcd", "sha256:ef01"},
+		"binary": {"sha1:5678", "sha256:1234"},
+	}, ctx.ArtifactDigests)
+
+	ctx = Context{}
+	setArtifactDigests([]*Package{other}, &ctx, nil)
+	assert.Nil(t, ctx.ArtifactDigests)
+}
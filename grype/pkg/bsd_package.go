@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"github.com/anchore/packageurl-go"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// Syft does not catalog BSD packages, so packages from SBOMs describing FreeBSD or OpenBSD systems (e.g. jails or
+// images) are identified by the type of their package URL.
+const (
+	FreeBSDPkg syftPkg.Type = "freebsd-pkg"
+	OpenBSDPkg syftPkg.Type = "openbsd-pkg"
+)
+
+var bsdPURLTypes = map[string]syftPkg.Type{
+	"freebsd": FreeBSDPkg,
+	"openbsd": OpenBSDPkg,
+}
+
+// packageType returns the type of the given syft package, resolving packages syft could not classify to a BSD
+// package type when the package URL indicates one.
+func packageType(p syftPkg.Package) syftPkg.Type {
+	if p.Type != "" && p.Type != syftPkg.UnknownPkg {
+		return p.Type
+	}
+	if p.PURL == "" {
+		return p.Type
+	}
+	purl, err := packageurl.FromString(p.PURL)
+	if err != nil {
+		return p.Type
+	}
+	if t, ok := bsdPURLTypes[purl.Type]; ok {
+		return t
+	}
+	return p.Type
+}
@@ -8,7 +8,49 @@ import (
 type Context struct {
 	Source *source.Description
 	Distro *distro.Distro
+	// OSIdentification records how the distro was identified (nil when no distro was identified)
+	OSIdentification *distro.Identification
 	// DistroDetectionFailed is true when linux release info was present but
 	// the distro type could not be determined (e.g., unknown distro ID)
 	DistroDetectionFailed bool
+	// Distroless is true when the packages were cataloged from a container image without an OS package DB (such as
+	// "distroless" or "static" images), in which case OS level vulnerabilities are only found from binary evidence
+	Distroless bool
+	// BinaryEvidence holds the IDs of the packages identified from binary evidence (only set for distroless images)
+	BinaryEvidence []ID
+	// ExternalVulnerabilities are the vulnerabilities already reported within the input SBOM (with their analysis)
+	ExternalVulnerabilities []ExternalVulnerability
+	// JavaClassDigests are the digests of the class files within each java archive, keyed by the archive path (only set
+	// with deep java inspection, see ShadedJavaPackages)
+	JavaClassDigests map[string][]string
+	// ArtifactDigests are the digests (e.g. "sha256:...") of the artifacts each package was found in, keyed by package
+	// ID (only for packages with known digests, such as java archives)
+	ArtifactDigests map[ID][]string
+	// Cataloging records how the packages of an OCI artifact were obtained (nil for other inputs)
+	Cataloging *Cataloging
+}
+
+// CatalogingMethod is how the packages of an OCI artifact were obtained.
+type CatalogingMethod string
+
+const (
+	// CatalogedMethod is when the packages were cataloged from the contents of the image
+	CatalogedMethod CatalogingMethod = "cataloged"
+	// EmbeddedSBOMMethod is when the packages were read from the SBOM attestations of the image (e.g. as attached by
+	// docker buildx with --sbom)
+	EmbeddedSBOMMethod CatalogingMethod = "embedded-sbom"
+)
+
+// Cataloging records how the packages of an OCI artifact (an OCI layout directory or archive, or a registry reference)
+// were obtained, along with the attestations the image carries.
+type Cataloging struct {
+	Method CatalogingMethod
+	// Attestation is the digest of the attestation manifest of the image (empty when the image has no attestations)
+	Attestation string
+	// PredicateTypes are the predicate types of the SBOM attestations of the image
+	PredicateTypes []string
+	// Provenance is true when the image carries a provenance attestation
+	Provenance bool
+	// Reason is why the image was cataloged rather than its embedded SBOM used (cataloged only)
+	Reason string
 }
@@ -0,0 +1,233 @@
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	cpes "github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+)
+
+// maxCPECacheEntries bounds the size of the persisted CPE cache; entries not used by the current scan are dropped
+// first when the bound is exceeded.
+const maxCPECacheEntries = 100_000
+
+// cpeCache memoizes CPE generation for packages without CPEs (e.g. from SBOMs which do not carry CPEs). Generation
+// only depends on the attributes of the package (not where it was found), so identical packages found at several
+// locations are only generated once, and results may be persisted to a directory to be reused by later scans. Keys
+// include the syft version so that changes to the generation logic never serve stale results.
+type cpeCache struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string][]cachedCPE
+	used    map[string]struct{}
+	dirty   bool
+}
+
+type cachedCPE struct {
+	Value  string `json:"cpe"`
+	Source string `json:"source,omitempty"`
+}
+
+// newCPECache creates a CPE cache, loading any entries persisted to the given directory (which may be empty to only
+// memoize in memory).
+func newCPECache(dir string) *cpeCache {
+	c := &cpeCache{
+		dir:     dir,
+		entries: map[string][]cachedCPE{},
+		used:    map[string]struct{}{},
+	}
+	if dir == "" {
+		return c
+	}
+
+	by, err := os.ReadFile(c.path())
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithFields("error", err).Debug("unable to read CPE cache")
+		}
+		return c
+	}
+	if err := json.Unmarshal(by, &c.entries); err != nil {
+		log.WithFields("error", err).Debug("unable to decode CPE cache")
+		c.entries = map[string][]cachedCPE{}
+	}
+	return c
+}
+
+// path is the file the cache is persisted to; it is specific to the syft version so that a syft upgrade starts from
+// an empty cache instead of carrying stale entries along.
+func (c *cpeCache) path() string {
+	version := sha256.Sum256([]byte(syftVersion()))
+	return filepath.Join(c.dir, fmt.Sprintf("cpes-%x.json", version[:6]))
+}
+
+// cpeCacheKey returns the identity of a package with respect to CPE generation.
+func cpeCacheKey(p syftPkg.Package) (string, error) {
+	by, err := json.Marshal(struct {
+		Name     string
+		Version  string
+		Type     syftPkg.Type
+		Language syftPkg.Language
+		PURL     string
+		Metadata any
+	}{p.Name, p.Version, p.Type, p.Language, p.PURL, p.Metadata})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(by)), nil
+}
+
+// Generate returns the generated CPEs for each of the given packages (nil for packages which already have CPEs).
+// Packages are generated concurrently, each distinct package only once.
+func (c *cpeCache) Generate(pkgs []syftPkg.Package) [][]cpe.CPE {
+	out := make([][]cpe.CPE, len(pkgs))
+
+	// group the packages needing generation by identity
+	byKey := map[string][]int{}
+	var order []string
+	for i, p := range pkgs {
+		if len(p.CPEs) > 0 {
+			continue
+		}
+		key, err := cpeCacheKey(p)
+		if err != nil {
+			log.WithFields("package", p.Name, "error", err).Trace("unable to determine CPE cache key")
+			out[i] = cpes.Generate(p)
+			continue
+		}
+		if _, ok := byKey[key]; !ok {
+			order = append(order, key)
+		}
+		byKey[key] = append(byKey[key], i)
+	}
+
+	keys := make(chan string)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), max(len(order), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				indexes := byKey[key]
+				generated := c.get(key, pkgs[indexes[0]])
+				for _, i := range indexes {
+					// packages must not share a backing array, as CPEs may be appended to afterward
+					out[i] = slices.Clone(generated)
+				}
+			}
+		}()
+	}
+	for _, key := range order {
+		keys <- key
+	}
+	close(keys)
+	wg.Wait()
+
+	return out
+}
+
+// get returns the cached CPEs for the given key, generating (and caching) them for the package on a miss.
+func (c *cpeCache) get(key string, p syftPkg.Package) []cpe.CPE {
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.used[key] = struct{}{}
+	c.mu.Unlock()
+
+	if ok {
+		if values, err := fromCachedCPEs(cached); err == nil {
+			return values
+		}
+		log.WithFields("package", p.Name).Trace("invalid CPE cache entry, regenerating")
+	}
+
+	values := cpes.Generate(p)
+
+	c.mu.Lock()
+	c.entries[key] = toCachedCPEs(values)
+	c.dirty = true
+	c.mu.Unlock()
+
+	return values
+}
+
+// Save persists the cache (if a directory was given and new entries were generated). Failures are logged but never
+// fail the scan.
+func (c *cpeCache) Save() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dir == "" || !c.dirty {
+		return
+	}
+
+	if len(c.entries) > maxCPECacheEntries {
+		for key := range c.entries {
+			if len(c.entries) <= maxCPECacheEntries {
+				break
+			}
+			if _, ok := c.used[key]; !ok {
+				delete(c.entries, key)
+			}
+		}
+	}
+
+	by, err := json.Marshal(c.entries)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to encode CPE cache")
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		log.WithFields("error", err).Debug("unable to create CPE cache directory")
+		return
+	}
+
+	// write to a temp file and rename so that concurrent scans never observe a partial cache
+	tmp, err := os.CreateTemp(c.dir, "tmp-cpes-*")
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to create CPE cache file")
+		return
+	}
+	_, err = tmp.Write(by)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path())
+	}
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to write CPE cache")
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	c.dirty = false
+}
+
+func toCachedCPEs(values []cpe.CPE) []cachedCPE {
+	out := make([]cachedCPE, 0, len(values))
+	for _, v := range values {
+		out = append(out, cachedCPE{Value: v.Attributes.BindToFmtString(), Source: string(v.Source)})
+	}
+	return out
+}
+
+func fromCachedCPEs(cached []cachedCPE) ([]cpe.CPE, error) {
+	var out []cpe.CPE
+	for _, c := range cached {
+		v, err := cpe.New(c.Value, cpe.Source(c.Source))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
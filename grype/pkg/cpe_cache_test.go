@@ -0,0 +1,125 @@
+package pkg
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	cpes "github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+)
+
+func cpeCacheTestPackages(n, duplicates int) []syftPkg.Package {
+	var pkgs []syftPkg.Package
+	for i := range n {
+		for range duplicates {
+			pkgs = append(pkgs, syftPkg.Package{
+				Name:     fmt.Sprintf("package-%d", i),
+				Version:  "1.2.3",
+				Type:     syftPkg.JavaPkg,
+				Language: syftPkg.Java,
+				PURL:     fmt.Sprintf("pkg:maven/org.example/package-%d@1.2.3", i),
+				Metadata: syftPkg.JavaArchive{
+					Manifest: &syftPkg.JavaManifest{Main: syftPkg.KeyValues{{Key: "Implementation-Vendor", Value: "Example"}}},
+				},
+			})
+		}
+	}
+	return pkgs
+}
+
+func TestCPECache_Generate(t *testing.T) {
+	pkgs := cpeCacheTestPackages(3, 2)
+	pkgs = append(pkgs, syftPkg.Package{
+		Name: "has-cpes",
+		CPEs: []cpe.CPE{cpe.Must("cpe:2.3:a:example:has-cpes:1.0:*:*:*:*:*:*:*", cpe.DeclaredSource)},
+	})
+
+	c := newCPECache("")
+	got := c.Generate(pkgs)
+	require.Len(t, got, len(pkgs))
+
+	for i, p := range pkgs[:6] {
+		assert.Equal(t, cpes.Generate(p), got[i], "package %d", i)
+	}
+	// identical packages are only generated once, but do not share a backing array
+	assert.Len(t, c.entries, 3)
+	require.NotEmpty(t, got[0])
+	assert.NotSame(t, &got[0][0], &got[1][0])
+
+	assert.Nil(t, got[6], "packages with CPEs are not generated")
+}
+
+func TestCPECache_Persisted(t *testing.T) {
+	dir := t.TempDir()
+	pkgs := cpeCacheTestPackages(2, 1)
+
+	c := newCPECache(dir)
+	expected := c.Generate(pkgs)
+	c.Save()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "only the cache file should remain")
+
+	// a later scan is served from the persisted cache
+	loaded := newCPECache(dir)
+	assert.Len(t, loaded.entries, 2)
+	key, err := cpeCacheKey(pkgs[0])
+	require.NoError(t, err)
+	sentinel := cpe.Must("cpe:2.3:a:cached:cached:1.2.3:*:*:*:*:*:*:*", cpe.GeneratedSource)
+	loaded.entries[key] = toCachedCPEs([]cpe.CPE{sentinel})
+
+	got := loaded.Generate(pkgs)
+	assert.Equal(t, []cpe.CPE{sentinel}, got[0])
+	assert.Equal(t, expected[1], got[1])
+	assert.False(t, loaded.dirty, "nothing new was generated")
+}
+
+func TestCPECache_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	c := newCPECache(dir)
+	require.NoError(t, os.WriteFile(c.path(), []byte("not json"), 0o600))
+
+	c = newCPECache(dir)
+	assert.Empty(t, c.entries)
+
+	pkgs := cpeCacheTestPackages(1, 1)
+	assert.Equal(t, cpes.Generate(pkgs[0]), c.Generate(pkgs)[0])
+}
+
+// BenchmarkCPEGeneration compares generating CPEs for every package with memoized generation (identical packages
+// generated once, distinct packages generated concurrently) and generation served from a persisted cache.
+func BenchmarkCPEGeneration(b *testing.B) {
+	pkgs := cpeCacheTestPackages(500, 4)
+
+	b.Run("uncached", func(b *testing.B) {
+		for range b.N {
+			for _, p := range pkgs {
+				_ = cpes.Generate(p)
+			}
+		}
+	})
+
+	b.Run("memoized", func(b *testing.B) {
+		for range b.N {
+			newCPECache("").Generate(pkgs)
+		}
+	})
+
+	b.Run("persisted", func(b *testing.B) {
+		dir := b.TempDir()
+		warm := newCPECache(dir)
+		warm.Generate(pkgs)
+		warm.Save()
+
+		b.ResetTimer()
+		for range b.N {
+			newCPECache(dir).Generate(pkgs)
+		}
+	})
+}
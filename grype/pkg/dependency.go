@@ -0,0 +1,71 @@
+package pkg
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// parentRelationships are the relationships from a package to the packages it is brought in by: the packages
+// depending on it and the packages containing it.
+var parentRelationships = []artifact.RelationshipType{
+	artifact.DependencyOfRelationship,
+	artifact.ContainsRelationship,
+}
+
+// DependencyPath returns the shortest path from a root package (one that no other package depends on or contains) to
+// the given package, starting with the root and ending with the package itself. A package without dependency
+// information is its own root. Nil is returned when no root can be reached (e.g. for dependency cycles).
+func DependencyPath(p Package) []Package {
+	if !hasParents(&p) {
+		return []Package{p}
+	}
+
+	// breadth-first search towards the roots, tracking where each package was reached from
+	start := &p
+	next := map[ID]*Package{}
+	visited := map[ID]bool{p.ID: true}
+	queue := []*Package{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if !hasParents(current) {
+			path := []Package{*current}
+			for child := next[current.ID]; child != nil; child = next[child.ID] {
+				path = append(path, *child)
+			}
+			return path
+		}
+
+		for _, rel := range parentRelationships {
+			for _, parent := range current.RelatedPackages[rel] {
+				if parent == nil || visited[parent.ID] {
+					continue
+				}
+				visited[parent.ID] = true
+				next[parent.ID] = current
+				queue = append(queue, parent)
+			}
+		}
+	}
+	return nil
+}
+
+// IsTransitiveDependency indicates if the given language ecosystem package is only brought in through another
+// dependency, that is, it is neither a root package nor a direct dependency (or content) of one. OS packages are never
+// transitive dependencies: the dependencies between them do not make them any less installed.
+func IsTransitiveDependency(p Package) bool {
+	if p.Language == syftPkg.UnknownLanguage {
+		return false
+	}
+	return len(DependencyPath(p)) > 2
+}
+
+func hasParents(p *Package) bool {
+	for _, rel := range parentRelationships {
+		if len(p.RelatedPackages[rel]) > 0 {
+			return true
+		}
+	}
+	return false
+}
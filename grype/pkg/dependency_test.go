@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestDependencyPath(t *testing.T) {
+	newPkg := func(id string) *Package {
+		return &Package{ID: ID(id), Name: id, Language: syftPkg.Python}
+	}
+	relate := func(child *Package, rel artifact.RelationshipType, parents ...*Package) {
+		if child.RelatedPackages == nil {
+			child.RelatedPackages = map[artifact.RelationshipType][]*Package{}
+		}
+		child.RelatedPackages[rel] = append(child.RelatedPackages[rel], parents...)
+	}
+
+	root := newPkg("root")
+	direct := newPkg("direct")
+	other := newPkg("other")
+	transitive := newPkg("transitive")
+	nested := newPkg("nested")
+	relate(direct, artifact.DependencyOfRelationship, root)
+	relate(other, artifact.DependencyOfRelationship, direct)
+	// reachable through both "other" (3 hops) and "direct" (2 hops), the shortest path wins
+	relate(transitive, artifact.DependencyOfRelationship, other, direct)
+	relate(nested, artifact.ContainsRelationship, transitive)
+
+	// OS packages depending on each other
+	libc := &Package{ID: "libc6", Name: "libc6", Type: syftPkg.DebPkg}
+	libssl := &Package{ID: "libssl3", Name: "libssl3", Type: syftPkg.DebPkg}
+	openssl := &Package{ID: "openssl", Name: "openssl", Type: syftPkg.DebPkg}
+	relate(libssl, artifact.DependencyOfRelationship, openssl)
+	relate(libc, artifact.DependencyOfRelationship, libssl)
+
+	cycleA := newPkg("cycle-a")
+	cycleB := newPkg("cycle-b")
+	relate(cycleA, artifact.DependencyOfRelationship, cycleB)
+	relate(cycleB, artifact.DependencyOfRelationship, cycleA)
+
+	names := func(path []Package) []string {
+		var out []string
+		for _, p := range path {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		pkg        *Package
+		want       []string
+		transitive bool
+	}{
+		{name: "root", pkg: root, want: []string{"root"}},
+		{name: "no dependency information", pkg: newPkg("isolated"), want: []string{"isolated"}},
+		{name: "direct dependency", pkg: direct, want: []string{"root", "direct"}},
+		{name: "shortest path", pkg: transitive, want: []string{"root", "direct", "transitive"}, transitive: true},
+		{name: "contained package", pkg: nested, want: []string{"root", "direct", "transitive", "nested"}, transitive: true},
+		{name: "cycle without a root", pkg: cycleA, want: nil},
+		{name: "os package", pkg: libc, want: []string{"openssl", "libssl3", "libc6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(DependencyPath(*tt.pkg)))
+			assert.Equal(t, tt.transitive, IsTransitiveDependency(*tt.pkg))
+		})
+	}
+}
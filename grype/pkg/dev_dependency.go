@@ -0,0 +1,25 @@
+package pkg
+
+import (
+	"strings"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// DevDependencyAnnotation lists the signals indicating a package is only needed for development or testing (e.g.
+// "maven-scope:test") and is not part of what is shipped.
+const DevDependencyAnnotation = "dev-dependency"
+
+// IsDevDependency indicates if the package is only needed for development or testing.
+func IsDevDependency(p Package) bool {
+	return len(p.Annotations[DevDependencyAnnotation]) > 0
+}
+
+// annotateDevDependency annotates the package when the cataloged metadata shows it is a development-only dependency.
+func annotateDevDependency(out *Package, p syftPkg.Package) {
+	if m, ok := p.Metadata.(syftPkg.JavaArchive); ok && m.PomProperties != nil {
+		if scope := strings.ToLower(strings.TrimSpace(m.PomProperties.Scope)); scope == "test" {
+			out.AddAnnotation(DevDependencyAnnotation, "maven-scope:"+scope)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestAnnotateDevDependency(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope string
+		want  bool
+	}{
+		{name: "test scope", scope: "test", want: true},
+		{name: "test scope with different case", scope: " Test ", want: true},
+		{name: "compile scope", scope: "compile"},
+		{name: "no scope"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(syftPkg.Package{
+				Name:    "junit",
+				Version: "4.13.2",
+				Type:    syftPkg.JavaPkg,
+				Metadata: syftPkg.JavaArchive{
+					PomProperties: &syftPkg.JavaPomProperties{GroupID: "junit", ArtifactID: "junit", Scope: tt.scope},
+				},
+			})
+			assert.Equal(t, tt.want, IsDevDependency(p))
+		})
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package pkg
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// deviceOf returns the ID of the device holding the given file.
+func deviceOf(info fs.FileInfo) (uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(stat.Dev), true //nolint:unconvert // the type of Dev differs across platforms
+}
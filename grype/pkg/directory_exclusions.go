@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+// SymlinkPolicy controls the symbolic links followed when scanning a directory.
+type SymlinkPolicy string
+
+const (
+	// SymlinksFollow follows all symbolic links (the default)
+	SymlinksFollow SymlinkPolicy = "follow"
+	// SymlinksWithinRoot only follows symbolic links resolving to a path within the scanned directory
+	SymlinksWithinRoot SymlinkPolicy = "within-root"
+	// SymlinksNone does not follow any symbolic link
+	SymlinksNone SymlinkPolicy = "none"
+)
+
+// AllSymlinkPolicies lists the supported symlink policies.
+var AllSymlinkPolicies = []SymlinkPolicy{SymlinksFollow, SymlinksWithinRoot, SymlinksNone}
+
+// ParseSymlinkPolicy parses the given symlink policy, where empty is SymlinksFollow.
+func ParseSymlinkPolicy(s string) (SymlinkPolicy, error) {
+	if s == "" {
+		return SymlinksFollow, nil
+	}
+	for _, p := range AllSymlinkPolicies {
+		if strings.EqualFold(s, string(p)) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown symlink policy %q (must be one of %v)", s, AllSymlinkPolicies)
+}
+
+// DirectoryScanConfig controls what is reached when scanning a directory, to avoid accidentally scanning mounted volumes
+// or directories linked to from within the scanned directory.
+type DirectoryScanConfig struct {
+	// Symlinks is the policy for the symbolic links found within the directory (empty follows all links)
+	Symlinks SymlinkPolicy
+	// SkipMounts skips directories on another filesystem than the scanned directory (e.g. mounted volumes)
+	SkipMounts bool
+}
+
+func (c DirectoryScanConfig) isDefault() bool {
+	return (c.Symlinks == "" || c.Symlinks == SymlinksFollow) && !c.SkipMounts
+}
+
+// globEscaper escapes the glob meta characters of paths used as exclusion patterns.
+var globEscaper = strings.NewReplacer("*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`, "{", `\{`, "}", `\}`)
+
+// directoryExclusions returns the exclusion patterns (relative to the given directory, e.g. "./data/volume") of the
+// symbolic links and mount points within the directory that should not be scanned per the given config.
+func directoryExclusions(root string, cfg DirectoryScanConfig) ([]string, error) {
+	if cfg.isDefault() {
+		return nil, nil
+	}
+
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, err
+	}
+	rootInfo, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	rootDevice, hasDevice := deviceOf(rootInfo)
+
+	var exclusions []string
+	exclude := func(path, reason string) {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return
+		}
+		log.WithFields("path", path, "reason", reason).Debug("excluding path from directory scan")
+		exclusions = append(exclusions, "./"+globEscaper.Replace(filepath.ToSlash(rel)))
+	}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			// unreadable paths are reported by the scan itself
+			return nil
+		}
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			if !followSymlink(path, realRoot, cfg.Symlinks) {
+				exclude(path, "symlink")
+			}
+		case d.IsDir() && cfg.SkipMounts && hasDevice:
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if device, ok := deviceOf(info); ok && device != rootDevice {
+				exclude(path, "mount point")
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	return exclusions, err
+}
+
+// followSymlink indicates whether the given symbolic link is followed per the given policy.
+func followSymlink(path, realRoot string, policy SymlinkPolicy) bool {
+	switch policy {
+	case SymlinksNone:
+		return false
+	case SymlinksWithinRoot:
+		target, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			// dangling links lead nowhere
+			return true
+		}
+		rel, err := filepath.Rel(realRoot, target)
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	default:
+		return true
+	}
+}
+
+// AccessPathAnnotation lists the paths a package was found through when they differ from the real paths of its
+// locations, such as a symbolic link within the scanned directory, attributing the package to the path that produced it.
+const AccessPathAnnotation = "access-path"
+
+// annotateAccessPaths annotates the packages found through a symbolic link with the path they were accessed by.
+func annotateAccessPaths(packages []*Package) {
+	for _, p := range packages {
+		for _, l := range p.Locations.ToSlice() {
+			if l.AccessPath != "" && l.AccessPath != l.RealPath {
+				p.AddAnnotation(AccessPathAnnotation, l.AccessPath)
+			}
+		}
+	}
+}
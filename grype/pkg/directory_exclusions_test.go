@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/file"
+)
+
+func TestDirectoryExclusions(t *testing.T) {
+	outside := t.TempDir()
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "app", "lib"), 0o755))
+	require.NoError(t, os.Symlink(filepath.Join(root, "app", "lib"), filepath.Join(root, "lib-link")))
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "app", "volume[1]")))
+
+	tests := []struct {
+		name string
+		cfg  DirectoryScanConfig
+		want []string
+	}{
+		{
+			name: "follow all symlinks",
+			cfg:  DirectoryScanConfig{Symlinks: SymlinksFollow},
+		},
+		{
+			name: "only symlinks within the root",
+			cfg:  DirectoryScanConfig{Symlinks: SymlinksWithinRoot},
+			want: []string{`./app/volume\[1\]`},
+		},
+		{
+			name: "no symlinks",
+			cfg:  DirectoryScanConfig{Symlinks: SymlinksNone},
+			want: []string{`./app/volume\[1\]`, "./lib-link"},
+		},
+		{
+			name: "skip mounts without mounts",
+			cfg:  DirectoryScanConfig{SkipMounts: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := directoryExclusions(root, tt.cfg)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSymlinkPolicy(t *testing.T) {
+	policy, err := ParseSymlinkPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, SymlinksFollow, policy)
+
+	policy, err = ParseSymlinkPolicy("Within-Root")
+	require.NoError(t, err)
+	assert.Equal(t, SymlinksWithinRoot, policy)
+
+	_, err = ParseSymlinkPolicy("sometimes")
+	require.Error(t, err)
+}
+
+func TestAnnotateAccessPaths(t *testing.T) {
+	linked := &Package{Name: "express", Locations: file.NewLocationSet(
+		file.NewVirtualLocation("/shared/package-lock.json", "/app/shared-link/package-lock.json"),
+	)}
+	direct := &Package{Name: "lodash", Locations: file.NewLocationSet(file.NewLocation("/app/package-lock.json"))}
+
+	annotateAccessPaths([]*Package{linked, direct})
+	assert.Equal(t, []string{"/app/shared-link/package-lock.json"}, linked.Annotations[AccessPathAnnotation])
+	assert.Empty(t, direct.Annotations)
+}
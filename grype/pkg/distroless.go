@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	cpes "github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// isDistroless indicates whether the SBOM describes a container image without an OS package DB, such as "distroless"
+// or "static" images (or any image built FROM scratch). No OS packages are found for these images, so any OS level
+// vulnerabilities can only be found from binary evidence (version strings and ELF package notes).
+func isDistroless(s *sbom.SBOM, ctx Context) bool {
+	if s == nil || ctx.Source == nil {
+		return false
+	}
+	if _, ok := ctx.Source.Metadata.(source.ImageMetadata); !ok {
+		return false
+	}
+	for _, p := range s.Artifacts.Packages.Sorted() {
+		if isOSPackageType(p.Type) && !hasBinaryEvidence(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// useBinaryEvidence generates CPEs for the packages found from binary evidence which have none, so that they are
+// matched regardless of the CPE generation configuration. Returns the IDs of all packages found from binary evidence.
+func useBinaryEvidence(packages []*Package, s *sbom.SBOM) []ID {
+	var ids []ID
+	for _, p := range packages {
+		sp := s.Artifacts.Packages.Package(artifact.ID(p.ID))
+		if sp == nil || !hasBinaryEvidence(*sp) {
+			continue
+		}
+		ids = append(ids, p.ID)
+		if len(p.CPEs) == 0 {
+			p.CPEs = cpes.Generate(*sp)
+		}
+	}
+	return ids
+}
+
+func hasBinaryEvidence(p syftPkg.Package) bool {
+	if p.Type == syftPkg.BinaryPkg {
+		return true
+	}
+	_, ok := p.Metadata.(syftPkg.ELFBinaryPackageNoteJSONPayload)
+	return ok
+}
+
+func isOSPackageType(t syftPkg.Type) bool {
+	switch t {
+	case syftPkg.AlpmPkg, syftPkg.ApkPkg, syftPkg.DebPkg, syftPkg.KbPkg, syftPkg.PortagePkg, syftPkg.RpmPkg:
+		return true
+	}
+	return false
+}
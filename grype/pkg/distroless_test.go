@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestDetectDistroless(t *testing.T) {
+	image := &source.Description{Name: "app:1", Metadata: source.ImageMetadata{UserInput: "app:1"}}
+	directory := &source.Description{Name: "app", Metadata: source.DirectoryMetadata{Path: "/app"}}
+
+	newPkg := func(name string, t syftPkg.Type, metadata any) syftPkg.Package {
+		p := syftPkg.Package{Name: name, Version: "1.0.0", Type: t, Metadata: metadata}
+		p.SetID()
+		return p
+	}
+	curl := newPkg("curl", syftPkg.BinaryPkg, syftPkg.BinarySignature{})
+	openssl := newPkg("openssl", syftPkg.RpmPkg, syftPkg.ELFBinaryPackageNoteJSONPayload{Type: "rpm", OS: "fedora"})
+	flask := newPkg("flask", syftPkg.PythonPkg, nil)
+	libc := newPkg("libc6", syftPkg.DebPkg, nil)
+
+	tests := []struct {
+		name           string
+		source         *source.Description
+		pkgs           []syftPkg.Package
+		wantDistroless bool
+		wantEvidence   []ID
+	}{
+		{
+			name:           "image without OS packages",
+			source:         image,
+			pkgs:           []syftPkg.Package{curl, flask},
+			wantDistroless: true,
+			wantEvidence:   []ID{ID(curl.ID())},
+		},
+		{
+			name:           "OS packages from ELF package notes are binary evidence",
+			source:         image,
+			pkgs:           []syftPkg.Package{openssl, curl},
+			wantDistroless: true,
+			wantEvidence:   []ID{ID(openssl.ID()), ID(curl.ID())},
+		},
+		{
+			name:   "image with an OS package DB",
+			source: image,
+			pkgs:   []syftPkg.Package{curl, libc},
+		},
+		{
+			name:   "not an image",
+			source: directory,
+			pkgs:   []syftPkg.Package{curl, flask},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &sbom.SBOM{Artifacts: sbom.Artifacts{Packages: syftPkg.NewCollection(tt.pkgs...)}}
+			packages := FromCollection(s.Artifacts.Packages, nil, SynthesisConfig{})
+			ctx := Context{Source: tt.source}
+
+			detectDistroless(packages, &ctx, s)
+
+			assert.Equal(t, tt.wantDistroless, ctx.Distroless)
+			assert.ElementsMatch(t, tt.wantEvidence, ctx.BinaryEvidence)
+			for _, p := range packages {
+				if p.Type == syftPkg.BinaryPkg && tt.wantDistroless {
+					require.NotEmpty(t, p.CPEs, "binary evidence should always be matched by CPE")
+				}
+			}
+		})
+	}
+}
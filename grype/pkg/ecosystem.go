@@ -0,0 +1,43 @@
+package pkg
+
+import (
+	"slices"
+	"strings"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// OSEcosystem is the ecosystem name given to all OS packages (e.g. deb, rpm and apk packages).
+const OSEcosystem = "os"
+
+// Ecosystem returns the ecosystem of the package: "os" for OS packages, otherwise the package language (or the
+// package type when the language is not known).
+func Ecosystem(p Package) string {
+	switch p.Type {
+	case syftPkg.AlpmPkg, syftPkg.ApkPkg, syftPkg.DebPkg, syftPkg.KbPkg, syftPkg.PortagePkg, syftPkg.RpmPkg:
+		return OSEcosystem
+	}
+	if p.Language != "" && p.Language != syftPkg.UnknownLanguage {
+		return string(p.Language)
+	}
+	return string(p.Type)
+}
+
+// InEcosystem indicates whether the package belongs to the given ecosystem: "os" for OS packages, a language
+// (including aliases, e.g. "maven" for java or "node.js" for javascript) or a package type (e.g. "binary" or "npm").
+func InEcosystem(p Package, ecosystem string) bool {
+	ecosystem = strings.ToLower(strings.TrimSpace(ecosystem))
+	if ecosystem == Ecosystem(p) || ecosystem == string(p.Type) {
+		return true
+	}
+	lang := syftPkg.LanguageByName(ecosystem)
+	return lang != syftPkg.UnknownLanguage && lang == p.Language
+}
+
+// IsEcosystem indicates whether the given name refers to an ecosystem understood by InEcosystem.
+func IsEcosystem(ecosystem string) bool {
+	ecosystem = strings.ToLower(strings.TrimSpace(ecosystem))
+	return ecosystem == OSEcosystem ||
+		syftPkg.LanguageByName(ecosystem) != syftPkg.UnknownLanguage ||
+		slices.Contains(syftPkg.AllPkgs, syftPkg.Type(ecosystem))
+}
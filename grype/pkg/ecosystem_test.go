@@ -0,0 +1,36 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestInEcosystem(t *testing.T) {
+	deb := Package{Type: syftPkg.DebPkg}
+	npm := Package{Type: syftPkg.NpmPkg, Language: syftPkg.JavaScript}
+	binary := Package{Type: syftPkg.BinaryPkg}
+
+	tests := []struct {
+		name      string
+		p         Package
+		ecosystem string
+		want      bool
+	}{
+		{name: "os package", p: deb, ecosystem: "os", want: true},
+		{name: "os package by type", p: deb, ecosystem: "deb", want: true},
+		{name: "language", p: npm, ecosystem: "javascript", want: true},
+		{name: "language alias", p: npm, ecosystem: "Node.js", want: true},
+		{name: "package type", p: npm, ecosystem: "npm", want: true},
+		{name: "package type without language", p: binary, ecosystem: "binary", want: true},
+		{name: "other language", p: npm, ecosystem: "python"},
+		{name: "language package is not os", p: npm, ecosystem: "os"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, InEcosystem(tt.p, tt.ecosystem))
+		})
+	}
+}
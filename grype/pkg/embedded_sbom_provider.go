@@ -0,0 +1,663 @@
+package pkg
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/stereoscope"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// the sources of the OCI artifacts which may carry attestations (as produced by docker buildx)
+const (
+	ociDirectoryInput = "oci-dir"
+	ociArchiveInput   = "oci-archive"
+	ociRegistryInput  = "registry"
+)
+
+// the annotations of the attestations attached to images by docker buildx (buildkit), see
+// https://docs.docker.com/build/metadata/attestations/attestation-storage/
+const (
+	referenceTypeAnnotation      = "vnd.docker.reference.type"
+	referenceDigestAnnotation    = "vnd.docker.reference.digest"
+	attestationManifestReference = "attestation-manifest"
+	predicateTypeAnnotation      = "in-toto.io/predicate-type"
+	inTotoMediaType              = "application/vnd.in-toto+json"
+)
+
+// the predicate types of the SBOM attestations (any version of each) and of the provenance attestations
+var (
+	sbomPredicateTypes      = []string{"https://spdx.dev/Document", "https://cyclonedx.org/bom", "https://syft.dev/bom"}
+	provenancePredicateType = "https://slsa.dev/provenance/"
+)
+
+// maxManifestBytes is the maximum size of an index, manifest or image config read from an OCI artifact.
+const maxManifestBytes = 4 << 20 // 4 MB
+
+// maxIndexDepth is the maximum nesting of indexes followed within an OCI artifact.
+const maxIndexDepth = 4
+
+// embeddedSBOMProvider reads the packages of an OCI artifact (an OCI layout directory or archive, or a registry
+// reference) from the SBOM attestations attached to the image (as done by docker buildx with --sbom), rather than
+// cataloging the image. The image is cataloged when it carries no SBOM attestation, or when embedded SBOMs are
+// ignored. Either way the context records which path was taken.
+func embeddedSBOMProvider(userInput string, config ProviderConfig, applyChannel func(*distro.Distro) bool) ([]*Package, Context, *sbom.SBOM, error) {
+	kind, ref, ok := ociArtifactInput(userInput, config)
+	if !ok {
+		return nil, Context{}, nil, errDoesNotProvide
+	}
+
+	cataloging := &Cataloging{Method: CatalogedMethod, Reason: "embedded SBOMs are ignored by configuration"}
+	if !config.IgnoreEmbeddedSBOM {
+		packages, ctx, s, err := readEmbeddedSBOM(kind, ref, userInput, config, applyChannel)
+		if err == nil {
+			log.WithFields("input", userInput, "attestation", ctx.Cataloging.Attestation).Info("using the SBOM embedded in the image")
+			return packages, ctx, s, nil
+		}
+		if ctx.Cataloging != nil {
+			cataloging = ctx.Cataloging
+		}
+		cataloging.Reason = err.Error()
+		log.WithFields("input", userInput, "reason", cataloging.Reason).Debug("cataloging the image instead of using an embedded SBOM")
+	}
+
+	packages, ctx, s, err := syftProvider(userInput, config, applyChannel)
+	if err != nil {
+		return nil, Context{}, nil, err
+	}
+	ctx.Cataloging = cataloging
+	return packages, ctx, s, nil
+}
+
+// ociArtifactInput returns the source and the path (or registry reference) of an OCI artifact given by scheme (e.g.
+// "oci-dir:path") or by a single --from source.
+func ociArtifactInput(userInput string, config ProviderConfig) (kind, ref string, ok bool) {
+	supported := []string{ociDirectoryInput, ociArchiveInput, ociRegistryInput}
+	if len(config.Sources) == 0 {
+		kind, ref = stereoscope.ExtractSchemeSource(userInput, supported...)
+		return kind, ref, kind != ""
+	}
+	if len(config.Sources) == 1 && slices.Contains(supported, config.Sources[0]) {
+		return config.Sources[0], userInput, true
+	}
+	return "", "", false
+}
+
+// readEmbeddedSBOM reads the packages from the SBOM attestations of the image within the given OCI artifact. When
+// there are none, the returned context (if any) still describes the attestations found.
+func readEmbeddedSBOM(kind, ref, userInput string, config ProviderConfig, applyChannel func(*distro.Distro) bool) ([]*Package, Context, *sbom.SBOM, error) {
+	store, err := openOCIStore(kind, ref, config)
+	if err != nil {
+		return nil, Context{}, nil, err
+	}
+	defer log.CloseAndLogError(store, ref)
+
+	att, err := resolveImageAttestations(store, config.Platform)
+	if err != nil {
+		return nil, Context{}, nil, err
+	}
+
+	ctx := Context{Cataloging: att.cataloging()}
+	if len(att.sboms) == 0 {
+		if att.attestation == nil {
+			return nil, ctx, nil, errors.New("the image has no attestations")
+		}
+		return nil, ctx, nil, errors.New("the image has no SBOM attestation")
+	}
+
+	var allPackages []*Package
+	pkgIndex := map[ID]*Package{}
+	var merged *sbom.SBOM
+	for _, desc := range att.sboms {
+		s, fmtID, err := readSBOMAttestation(store, desc, att.image.Digest)
+		if err != nil {
+			return nil, ctx, nil, fmt.Errorf("unable to read SBOM attestation %s: %w", desc.Digest, err)
+		}
+
+		d, osIdentification, distroDetectionFailed := distroFromSBOM(s, config, applyChannel)
+		if ctx.Distro == nil && d != nil {
+			ctx.Distro, ctx.OSIdentification = d, osIdentification
+		}
+		ctx.DistroDetectionFailed = ctx.DistroDetectionFailed || distroDetectionFailed
+
+		enhancers := sbomEnhancers(fmtID, applyChannel, config.Distro.Aliases)
+
+		for _, p := range FromCollection(s.Artifacts.Packages, s.Relationships, config.SynthesisConfig, enhancers...) {
+			if p.Distro == nil && d != nil {
+				p.Distro = d
+			}
+			if _, ok := pkgIndex[p.ID]; ok {
+				continue
+			}
+			pkgIndex[p.ID] = p
+			allPackages = append(allPackages, p)
+		}
+
+		if merged == nil {
+			merged = s
+		} else {
+			mergeSBOM(merged, s)
+		}
+	}
+	if ctx.Distro != nil {
+		ctx.DistroDetectionFailed = false
+	}
+
+	src := att.describe(kind, ref, userInput, config.Name)
+	merged.Source = src
+	ctx.Source = &src
+	ctx.Cataloging.Method = EmbeddedSBOMMethod
+
+	return allPackages, ctx, merged, nil
+}
+
+// imageAttestations is the image selected within an OCI artifact along with its attestations.
+type imageAttestations struct {
+	image       v1.Descriptor
+	rawManifest []byte
+	manifest    *v1.Manifest
+	rawConfig   []byte
+	config      *v1.ConfigFile
+	// attestation is the descriptor of the attestation manifest of the image (nil when there is none)
+	attestation *v1.Descriptor
+	// sboms are the descriptors of the SBOM attestation layers
+	sboms []v1.Descriptor
+	// predicateTypes are the predicate types of the SBOM attestations
+	predicateTypes []string
+	provenance     bool
+}
+
+func (a imageAttestations) cataloging() *Cataloging {
+	c := &Cataloging{
+		Method:         CatalogedMethod,
+		PredicateTypes: a.predicateTypes,
+		Provenance:     a.provenance,
+	}
+	if a.attestation != nil {
+		c.Attestation = a.attestation.Digest.String()
+	}
+	return c
+}
+
+// describe returns the source description of the image, as if it was cataloged.
+func (a imageAttestations) describe(kind, ref, userInput, alias string) source.Description {
+	metadata := source.ImageMetadata{
+		UserInput:      userInput,
+		ManifestDigest: a.image.Digest.String(),
+		MediaType:      string(a.image.MediaType),
+		RawManifest:    a.rawManifest,
+		RawConfig:      a.rawConfig,
+		Annotations:    a.manifest.Annotations,
+	}
+	if a.image.Platform != nil {
+		metadata.OS, metadata.Architecture, metadata.Variant = a.image.Platform.OS, a.image.Platform.Architecture, a.image.Platform.Variant
+	}
+	if a.config != nil {
+		metadata.OS, metadata.Architecture, metadata.Variant = a.config.OS, a.config.Architecture, a.config.Variant
+		metadata.Labels = a.config.Config.Labels
+	}
+	metadata.ID = a.manifest.Config.Digest.String()
+	for _, l := range a.manifest.Layers {
+		metadata.Layers = append(metadata.Layers, source.LayerMetadata{MediaType: string(l.MediaType), Digest: l.Digest.String(), Size: l.Size})
+		metadata.Size += l.Size
+	}
+
+	srcName := ref
+	if kind == ociRegistryInput {
+		if r, err := name.ParseReference(ref); err == nil {
+			srcName = r.Context().Name()
+			if tag, ok := r.(name.Tag); ok {
+				metadata.Tags = []string{tag.Name()}
+			}
+			metadata.RepoDigests = []string{r.Context().Digest(a.image.Digest.String()).String()}
+		}
+	}
+	if alias != "" {
+		srcName = alias
+	}
+
+	return source.Description{
+		ID:       a.image.Digest.String(),
+		Name:     srcName,
+		Version:  a.image.Digest.String(),
+		Metadata: metadata,
+	}
+}
+
+// resolveImageAttestations selects the image of the given platform (or the only image, or the image for the host
+// architecture) within the OCI artifact, and finds its attestations.
+func resolveImageAttestations(store ociStore, platform string) (*imageAttestations, error) {
+	raw, mediaType, err := store.root()
+	if err != nil {
+		return nil, err
+	}
+	if !mediaType.IsIndex() {
+		return nil, errors.New("the image has no attestations (it is not an index)")
+	}
+
+	manifests, err := collectManifests(store, raw, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := selectImage(manifests, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	att := &imageAttestations{image: image}
+	if att.rawManifest, err = store.fetch(image); err != nil {
+		return nil, err
+	}
+	if att.manifest, err = v1.ParseManifest(bytes.NewReader(att.rawManifest)); err != nil {
+		return nil, fmt.Errorf("unable to parse image manifest %s: %w", image.Digest, err)
+	}
+	if att.rawConfig, err = store.fetch(att.manifest.Config); err == nil {
+		att.config, err = v1.ParseConfigFile(bytes.NewReader(att.rawConfig))
+	}
+	if err != nil {
+		log.WithFields("digest", att.manifest.Config.Digest, "error", err).Debug("unable to read image config")
+		att.rawConfig, att.config = nil, nil
+	}
+
+	for i, m := range manifests {
+		if m.Annotations[referenceTypeAnnotation] == attestationManifestReference && m.Annotations[referenceDigestAnnotation] == image.Digest.String() {
+			att.attestation = &manifests[i]
+			break
+		}
+	}
+	if att.attestation == nil {
+		return att, nil
+	}
+
+	raw, err = store.fetch(*att.attestation)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse attestation manifest %s: %w", att.attestation.Digest, err)
+	}
+	for _, l := range manifest.Layers {
+		predicateType := l.Annotations[predicateTypeAnnotation]
+		switch {
+		case strings.HasPrefix(predicateType, provenancePredicateType):
+			att.provenance = true
+		case isSBOMPredicateType(predicateType):
+			if l.MediaType != inTotoMediaType {
+				log.WithFields("digest", l.Digest, "mediaType", l.MediaType).Debug("skipping SBOM attestation of an unsupported media type")
+				continue
+			}
+			att.sboms = append(att.sboms, l)
+			if !slices.Contains(att.predicateTypes, predicateType) {
+				att.predicateTypes = append(att.predicateTypes, predicateType)
+			}
+		}
+	}
+	return att, nil
+}
+
+func isSBOMPredicateType(predicateType string) bool {
+	for _, t := range sbomPredicateTypes {
+		if strings.HasPrefix(predicateType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectManifests returns the descriptors of the manifests within the given index, following nested indexes.
+func collectManifests(store ociStore, raw []byte, depth int) ([]v1.Descriptor, error) {
+	if depth > maxIndexDepth {
+		return nil, fmt.Errorf("indexes are nested more than %d levels deep", maxIndexDepth)
+	}
+	index, err := v1.ParseIndexManifest(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse index: %w", err)
+	}
+
+	var out []v1.Descriptor
+	for _, desc := range index.Manifests {
+		if !desc.MediaType.IsIndex() {
+			out = append(out, desc)
+			continue
+		}
+		nested, err := store.fetch(desc)
+		if err != nil {
+			return nil, err
+		}
+		manifests, err := collectManifests(store, nested, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, manifests...)
+	}
+	return out, nil
+}
+
+// selectImage returns the image manifest of the given platform, or else the only image manifest, or else the image
+// manifest for the host architecture (as when pulling an image).
+func selectImage(manifests []v1.Descriptor, platform string) (v1.Descriptor, error) {
+	var images []v1.Descriptor
+	for _, m := range manifests {
+		if m.MediaType.IsImage() && m.Annotations[referenceTypeAnnotation] == "" {
+			images = append(images, m)
+		}
+	}
+	if len(images) == 0 {
+		return v1.Descriptor{}, errors.New("no image manifest found")
+	}
+
+	want := v1.Platform{OS: "linux", Architecture: runtime.GOARCH}
+	if platform != "" {
+		p, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return v1.Descriptor{}, fmt.Errorf("invalid platform %q: %w", platform, err)
+		}
+		want = *p
+	} else if len(images) == 1 {
+		return images[0], nil
+	}
+
+	for _, m := range images {
+		if m.Platform != nil && m.Platform.Satisfies(want) {
+			return m, nil
+		}
+	}
+	return v1.Descriptor{}, fmt.Errorf("no image manifest found for platform %s", want.String())
+}
+
+// inTotoStatement is an in-toto attestation statement, see https://github.com/in-toto/attestation
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	Predicate json.RawMessage `json:"predicate"`
+}
+
+// readSBOMAttestation decodes the SBOM predicate of the given attestation layer, which must describe the given image.
+func readSBOMAttestation(store ociStore, desc v1.Descriptor, image v1.Hash) (*sbom.SBOM, sbom.FormatID, error) {
+	raw, err := store.fetch(desc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var statement inTotoStatement
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return nil, "", fmt.Errorf("unable to parse in-toto statement: %w", err)
+	}
+
+	describesImage := len(statement.Subject) == 0
+	for _, s := range statement.Subject {
+		if s.Digest[image.Algorithm] == image.Hex {
+			describesImage = true
+			break
+		}
+	}
+	if !describesImage {
+		return nil, "", fmt.Errorf("the statement does not describe image %s", image)
+	}
+
+	// SBOMs encoded as a string (e.g. syft-json) are unquoted first
+	predicate := []byte(statement.Predicate)
+	var encoded string
+	if json.Unmarshal(predicate, &encoded) == nil {
+		predicate = []byte(encoded)
+	}
+
+	s, fmtID, err := readSBOM(bytes.NewReader(predicate))
+	if err != nil {
+		if errors.Is(err, errDoesNotProvide) {
+			return nil, "", fmt.Errorf("unsupported SBOM format (predicate type %s)", statement.PredicateType)
+		}
+		return nil, "", err
+	}
+	return s, fmtID, nil
+}
+
+// ociStore reads the index and the content of an OCI artifact.
+type ociStore interface {
+	io.Closer
+	// root returns the top level index (or manifest) of the artifact
+	root() ([]byte, types.MediaType, error)
+	// fetch returns the (verified) content of the given descriptor
+	fetch(desc v1.Descriptor) ([]byte, error)
+}
+
+func openOCIStore(kind, ref string, config ProviderConfig) (ociStore, error) {
+	switch kind {
+	case ociDirectoryInput:
+		return ociDirectoryStore{dir: ref}, nil
+	case ociArchiveInput:
+		return openOCIArchiveStore(ref)
+	case ociRegistryInput:
+		return newOCIRegistryStore(ref, config)
+	}
+	return nil, fmt.Errorf("unsupported OCI artifact source: %s", kind)
+}
+
+// readVerified reads the content of the given descriptor (within the size limits) and verifies its digest.
+func readVerified(desc v1.Descriptor, r io.Reader) ([]byte, error) {
+	limit := int64(maxManifestBytes)
+	if !desc.MediaType.IsIndex() && !desc.MediaType.IsImage() && !desc.MediaType.IsConfig() {
+		limit = maxSBOMEntryBytes
+	}
+	if desc.Size > limit {
+		return nil, fmt.Errorf("%s exceeds the maximum size (%d > %d bytes)", desc.Digest, desc.Size, limit)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", desc.Digest, err)
+	}
+	if int64(len(raw)) > limit {
+		return nil, fmt.Errorf("%s exceeds the maximum size (%d bytes)", desc.Digest, limit)
+	}
+	if desc.Digest.Algorithm == "sha256" {
+		h, _, err := v1.SHA256(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+		if h != desc.Digest {
+			return nil, fmt.Errorf("digest mismatch for %s (got %s)", desc.Digest, h)
+		}
+	}
+	return raw, nil
+}
+
+// ociDirectoryStore reads an OCI image layout directory.
+type ociDirectoryStore struct {
+	dir string
+}
+
+func (s ociDirectoryStore) root() ([]byte, types.MediaType, error) {
+	f, err := os.Open(filepath.Join(s.dir, "index.json"))
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read OCI layout: %w", err)
+	}
+	defer f.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(f, maxManifestBytes))
+	return raw, types.OCIImageIndex, err
+}
+
+func (s ociDirectoryStore) fetch(desc v1.Descriptor) ([]byte, error) {
+	f, err := os.Open(filepath.Join(s.dir, "blobs", desc.Digest.Algorithm, desc.Digest.Hex))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blob %s: %w", desc.Digest, err)
+	}
+	defer f.Close()
+
+	return readVerified(desc, f)
+}
+
+func (s ociDirectoryStore) Close() error {
+	return nil
+}
+
+// ociArchiveStore reads an OCI image layout archive (an uncompressed tar), where the location of each entry is
+// indexed upfront so that the (possibly large) archive is only read once.
+type ociArchiveStore struct {
+	f       *os.File
+	entries map[string]*io.SectionReader
+}
+
+func openOCIArchiveStore(archivePath string) (*ociArchiveStore, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open OCI archive: %w", err)
+	}
+
+	s := &ociArchiveStore{f: f, entries: map[string]*io.SectionReader{}}
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("unable to read OCI archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		// the tar reader does not buffer, so the content of the entry starts at the current offset of the file
+		offset, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		s.entries[path.Clean(strings.TrimPrefix(hdr.Name, "./"))] = io.NewSectionReader(f, offset, hdr.Size)
+	}
+	return s, nil
+}
+
+func (s *ociArchiveStore) root() ([]byte, types.MediaType, error) {
+	r, ok := s.entries["index.json"]
+	if !ok {
+		return nil, "", errors.New("unable to read OCI layout: index.json not found in archive")
+	}
+	raw, err := io.ReadAll(io.LimitReader(r, maxManifestBytes))
+	return raw, types.OCIImageIndex, err
+}
+
+func (s *ociArchiveStore) fetch(desc v1.Descriptor) ([]byte, error) {
+	r, ok := s.entries[path.Join("blobs", desc.Digest.Algorithm, desc.Digest.Hex)]
+	if !ok {
+		return nil, fmt.Errorf("blob %s not found in archive", desc.Digest)
+	}
+	return readVerified(desc, io.NewSectionReader(r, 0, r.Size()))
+}
+
+func (s *ociArchiveStore) Close() error {
+	return s.f.Close()
+}
+
+// ociRegistryStore reads an image from a registry.
+type ociRegistryStore struct {
+	ref     name.Reference
+	options []remote.Option
+}
+
+func newOCIRegistryStore(ref string, config ProviderConfig) (*ociRegistryStore, error) {
+	var nameOptions []name.Option
+	if config.RegistryOptions != nil && config.RegistryOptions.InsecureUseHTTP {
+		nameOptions = append(nameOptions, name.Insecure)
+	}
+	r, err := name.ParseReference(ref, nameOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image reference %q: %w", ref, err)
+	}
+	options, err := registryOptions(r.Context().RegistryStr(), config)
+	if err != nil {
+		return nil, err
+	}
+	return &ociRegistryStore{ref: r, options: options}, nil
+}
+
+// registryOptions returns the options of the registry client, using a clone of the configured registry transport (or
+// of the default transport when not configured).
+func registryOptions(registry string, config ProviderConfig) ([]remote.Option, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if config.RegistryTransport != nil {
+		t = config.RegistryTransport.Clone()
+	}
+	opts := config.RegistryOptions
+	if opts == nil {
+		return []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithTransport(t)}, nil
+	}
+
+	tlsConfig, err := opts.TLSConfig(registry)
+	if err != nil {
+		return nil, err
+	}
+	t.TLSClientConfig = tlsConfig
+	out := []remote.Option{remote.WithTransport(t)}
+
+	switch auth := opts.Authenticator(registry); {
+	case auth != nil:
+		out = append(out, remote.WithAuth(auth))
+	case opts.Keychain != nil:
+		out = append(out, remote.WithAuthFromKeychain(opts.Keychain))
+	default:
+		out = append(out, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+	return out, nil
+}
+
+func (s *ociRegistryStore) root() ([]byte, types.MediaType, error) {
+	desc, err := remote.Get(s.ref, s.options...)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get image %s: %w", s.ref, err)
+	}
+	return desc.Manifest, desc.MediaType, nil
+}
+
+func (s *ociRegistryStore) fetch(desc v1.Descriptor) ([]byte, error) {
+	ref := s.ref.Context().Digest(desc.Digest.String())
+	if desc.MediaType.IsIndex() || desc.MediaType.IsImage() {
+		d, err := remote.Get(ref, s.options...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get manifest %s: %w", desc.Digest, err)
+		}
+		return readVerified(desc, bytes.NewReader(d.Manifest))
+	}
+
+	layer, err := remote.Layer(ref, s.options...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get blob %s: %w", desc.Digest, err)
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get blob %s: %w", desc.Digest, err)
+	}
+	defer rc.Close()
+	return readVerified(desc, rc)
+}
+
+func (s *ociRegistryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,372 @@
+package pkg
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	spdxPredicateType       = "https://spdx.dev/Document"
+	slsaProvenancePredicate = "https://slsa.dev/provenance/v0.2"
+)
+
+type testBlob struct {
+	desc    v1.Descriptor
+	content []byte
+}
+
+// testArtifact is an image (with an empty layer) along with its attestations, laid out as built by docker buildx.
+type testArtifact struct {
+	// blobs are ordered so that the content referenced by a manifest comes before the manifest
+	blobs       []testBlob
+	image       v1.Descriptor
+	attestation *v1.Descriptor
+	imageIndex  testBlob
+}
+
+// newTestArtifact returns an image with the given attestations (predicate type to predicate).
+func newTestArtifact(t *testing.T, attestations map[string]any) *testArtifact {
+	t.Helper()
+	a := &testArtifact{}
+
+	var layerTar bytes.Buffer
+	require.NoError(t, tar.NewWriter(&layerTar).Close())
+	diffID, _, err := v1.SHA256(bytes.NewReader(layerTar.Bytes()))
+	require.NoError(t, err)
+	var layerGz bytes.Buffer
+	gw := gzip.NewWriter(&layerGz)
+	_, err = gw.Write(layerTar.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	platform := &v1.Platform{OS: "linux", Architecture: runtime.GOARCH}
+	config := a.add(t, types.OCIConfigJSON, v1.ConfigFile{
+		OS:           platform.OS,
+		Architecture: platform.Architecture,
+		Config:       v1.Config{Labels: map[string]string{"org.opencontainers.image.title": "app"}},
+		RootFS:       v1.RootFS{Type: "layers", DiffIDs: []v1.Hash{diffID}},
+	})
+	layer := a.addRaw(t, types.OCILayer, layerGz.Bytes())
+	a.image = a.add(t, types.OCIManifestSchema1, v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config:        config,
+		Layers:        []v1.Descriptor{layer},
+	})
+	a.image.Platform = platform
+
+	manifests := []v1.Descriptor{a.image}
+	if len(attestations) > 0 {
+		var layers []v1.Descriptor
+		for predicateType, predicate := range attestations {
+			l := a.add(t, inTotoMediaType, map[string]any{
+				"_type":         "https://in-toto.io/Statement/v0.1",
+				"predicateType": predicateType,
+				"subject": []map[string]any{{
+					"name":   "pkg:docker/app@latest?platform=linux%2F" + runtime.GOARCH,
+					"digest": map[string]string{a.image.Digest.Algorithm: a.image.Digest.Hex},
+				}},
+				"predicate": predicate,
+			})
+			l.Annotations = map[string]string{predicateTypeAnnotation: predicateType}
+			layers = append(layers, l)
+		}
+		attestationConfig := a.add(t, types.OCIConfigJSON, v1.ConfigFile{OS: "unknown", Architecture: "unknown"})
+		attestation := a.add(t, types.OCIManifestSchema1, v1.Manifest{
+			SchemaVersion: 2,
+			MediaType:     types.OCIManifestSchema1,
+			Config:        attestationConfig,
+			Layers:        layers,
+		})
+		attestation.Platform = &v1.Platform{OS: "unknown", Architecture: "unknown"}
+		attestation.Annotations = map[string]string{
+			referenceTypeAnnotation:   attestationManifestReference,
+			referenceDigestAnnotation: a.image.Digest.String(),
+		}
+		a.attestation = &attestation
+		manifests = append(manifests, attestation)
+	}
+
+	a.add(t, types.OCIImageIndex, v1.IndexManifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIImageIndex,
+		Manifests:     manifests,
+	})
+	a.imageIndex = a.blobs[len(a.blobs)-1]
+	return a
+}
+
+func (a *testArtifact) add(t *testing.T, mediaType types.MediaType, v any) v1.Descriptor {
+	t.Helper()
+	content, err := json.Marshal(v)
+	require.NoError(t, err)
+	return a.addRaw(t, mediaType, content)
+}
+
+func (a *testArtifact) addRaw(t *testing.T, mediaType types.MediaType, content []byte) v1.Descriptor {
+	t.Helper()
+	h, size, err := v1.SHA256(bytes.NewReader(content))
+	require.NoError(t, err)
+	desc := v1.Descriptor{MediaType: mediaType, Digest: h, Size: size}
+	a.blobs = append(a.blobs, testBlob{desc: desc, content: content})
+	return desc
+}
+
+// layoutFiles returns the files of the OCI image layout of the artifact.
+func (a *testArtifact) layoutFiles(t *testing.T) map[string][]byte {
+	t.Helper()
+	index := a.imageIndex.desc
+	index.Annotations = map[string]string{"org.opencontainers.image.ref.name": "latest"}
+	indexJSON, err := json.Marshal(v1.IndexManifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIImageIndex,
+		Manifests:     []v1.Descriptor{index},
+	})
+	require.NoError(t, err)
+
+	files := map[string][]byte{
+		"oci-layout": []byte(`{"imageLayoutVersion":"1.0.0"}`),
+		"index.json": indexJSON,
+	}
+	for _, b := range a.blobs {
+		files[filepath.Join("blobs", b.desc.Digest.Algorithm, b.desc.Digest.Hex)] = b.content
+	}
+	return files
+}
+
+func (a *testArtifact) writeDirectory(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for p, content := range a.layoutFiles(t) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, p)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, p), content, 0o600))
+	}
+	return dir
+}
+
+func (a *testArtifact) writeArchive(t *testing.T) string {
+	t.Helper()
+	archivePath := filepath.Join(t.TempDir(), "image.tar")
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	for p, content := range a.layoutFiles(t) {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./" + p, Size: int64(len(content)), Mode: 0o600, Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return archivePath
+}
+
+type testManifest testBlob
+
+func (m testManifest) RawManifest() ([]byte, error)        { return m.content, nil }
+func (m testManifest) MediaType() (types.MediaType, error) { return m.desc.MediaType, nil }
+
+// push pushes the artifact to the given repository and returns its tagged reference.
+func (a *testArtifact) push(t *testing.T, repo string) string {
+	t.Helper()
+	r, err := name.NewRepository(repo)
+	require.NoError(t, err)
+	for _, b := range a.blobs {
+		if b.desc.MediaType.IsImage() || b.desc.MediaType.IsIndex() {
+			continue
+		}
+		require.NoError(t, remote.WriteLayer(r, static.NewLayer(b.content, b.desc.MediaType)))
+	}
+	for _, b := range a.blobs {
+		if b.desc.MediaType.IsImage() {
+			require.NoError(t, remote.Put(r.Digest(b.desc.Digest.String()), testManifest(b)))
+		}
+	}
+	require.NoError(t, remote.Put(r.Tag("latest"), testManifest(a.imageIndex)))
+	return r.Tag("latest").String()
+}
+
+func readTestSPDX(t *testing.T) map[string]any {
+	t.Helper()
+	content, err := os.ReadFile("testdata/buildx/sbom.spdx.json")
+	require.NoError(t, err)
+	var predicate map[string]any
+	require.NoError(t, json.Unmarshal(content, &predicate))
+	return predicate
+}
+
+func testEmbeddedSBOMConfig() ProviderConfig {
+	return ProviderConfig{
+		SyftProviderConfig: SyftProviderConfig{
+			SBOMOptions: syft.DefaultCreateSBOMConfig(),
+		},
+	}
+}
+
+func TestEmbeddedSBOMProvider(t *testing.T) {
+	artifact := newTestArtifact(t, map[string]any{
+		spdxPredicateType:       readTestSPDX(t),
+		slsaProvenancePredicate: map[string]any{"builder": map[string]any{"id": "https://github.com/docker/buildx"}},
+	})
+
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	ref := artifact.push(t, strings.TrimPrefix(server.URL, "http://")+"/app")
+
+	tests := []struct {
+		name      string
+		userInput string
+	}{
+		{name: "oci directory", userInput: "oci-dir:" + artifact.writeDirectory(t)},
+		{name: "oci archive", userInput: "oci-archive:" + artifact.writeArchive(t)},
+		{name: "registry", userInput: "registry:" + ref},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packages, ctx, s, err := Provide(tt.userInput, testEmbeddedSBOMConfig())
+			require.NoError(t, err)
+
+			var names []string
+			for _, p := range packages {
+				names = append(names, p.Name)
+			}
+			assert.ElementsMatch(t, []string{"busybox", "express"}, names)
+
+			require.NotNil(t, ctx.Distro)
+			assert.Equal(t, "alpine", ctx.Distro.ID())
+			assert.Equal(t, "3.18.4", ctx.Distro.Version)
+
+			assert.Equal(t, &Cataloging{
+				Method:         EmbeddedSBOMMethod,
+				Attestation:    artifact.attestation.Digest.String(),
+				PredicateTypes: []string{spdxPredicateType},
+				Provenance:     true,
+			}, ctx.Cataloging)
+
+			require.NotNil(t, ctx.Source)
+			metadata, ok := ctx.Source.Metadata.(source.ImageMetadata)
+			require.True(t, ok)
+			assert.Equal(t, tt.userInput, metadata.UserInput)
+			assert.Equal(t, artifact.image.Digest.String(), metadata.ManifestDigest)
+			assert.Equal(t, runtime.GOARCH, metadata.Architecture)
+			assert.Equal(t, "app", metadata.Labels["org.opencontainers.image.title"])
+			assert.Len(t, metadata.Layers, 1)
+			assert.Equal(t, *ctx.Source, s.Source)
+		})
+	}
+}
+
+func TestEmbeddedSBOMProvider_Cataloged(t *testing.T) {
+	tests := []struct {
+		name         string
+		attestations map[string]any
+		ignore       bool
+		expected     *Cataloging
+	}{
+		{
+			name:     "no attestations",
+			expected: &Cataloging{Method: CatalogedMethod, Reason: "the image has no attestations"},
+		},
+		{
+			name:         "provenance only",
+			attestations: map[string]any{slsaProvenancePredicate: map[string]any{}},
+			expected:     &Cataloging{Method: CatalogedMethod, Provenance: true, Reason: "the image has no SBOM attestation"},
+		},
+		{
+			name:         "embedded SBOM ignored",
+			attestations: map[string]any{spdxPredicateType: readTestSPDX(t)},
+			ignore:       true,
+			expected:     &Cataloging{Method: CatalogedMethod, Reason: "embedded SBOMs are ignored by configuration"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifact := newTestArtifact(t, tt.attestations)
+			if artifact.attestation != nil && !tt.ignore {
+				tt.expected.Attestation = artifact.attestation.Digest.String()
+			}
+
+			cfg := testEmbeddedSBOMConfig()
+			cfg.IgnoreEmbeddedSBOM = tt.ignore
+			packages, ctx, _, err := embeddedSBOMProvider("oci-dir:"+artifact.writeDirectory(t), cfg, getDistroChannelApplier(nil))
+			require.NoError(t, err)
+
+			// the (empty) image is cataloged
+			assert.Empty(t, packages)
+			assert.Equal(t, tt.expected, ctx.Cataloging)
+			metadata, ok := ctx.Source.Metadata.(source.ImageMetadata)
+			require.True(t, ok)
+			assert.Equal(t, artifact.image.Digest.String(), metadata.ManifestDigest)
+		})
+	}
+}
+
+func TestOCIArtifactInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		userInput string
+		sources   []string
+		wantKind  string
+		wantRef   string
+	}{
+		{name: "oci directory scheme", userInput: "oci-dir:./image", wantKind: ociDirectoryInput, wantRef: "./image"},
+		{name: "oci archive scheme", userInput: "oci-archive:image.tar", wantKind: ociArchiveInput, wantRef: "image.tar"},
+		{name: "registry scheme", userInput: "registry:alpine:3.18", wantKind: ociRegistryInput, wantRef: "alpine:3.18"},
+		{name: "registry source", userInput: "alpine:3.18", sources: []string{"registry"}, wantKind: ociRegistryInput, wantRef: "alpine:3.18"},
+		{name: "docker scheme", userInput: "docker:alpine:3.18"},
+		{name: "no scheme", userInput: "alpine:3.18"},
+		{name: "several sources", userInput: "alpine:3.18", sources: []string{"docker", "registry"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, ref, ok := ociArtifactInput(tt.userInput, ProviderConfig{SyftProviderConfig: SyftProviderConfig{Sources: tt.sources}})
+			assert.Equal(t, tt.wantKind != "", ok)
+			assert.Equal(t, tt.wantKind, kind)
+			if ok {
+				assert.Equal(t, tt.wantRef, ref)
+			}
+		})
+	}
+}
+
+func TestSelectImage(t *testing.T) {
+	image := func(arch string) v1.Descriptor {
+		return v1.Descriptor{MediaType: types.OCIManifestSchema1, Platform: &v1.Platform{OS: "linux", Architecture: arch}, Digest: v1.Hash{Algorithm: "sha256", Hex: arch}}
+	}
+	attestation := v1.Descriptor{MediaType: types.OCIManifestSchema1, Annotations: map[string]string{referenceTypeAnnotation: attestationManifestReference}}
+
+	got, err := selectImage([]v1.Descriptor{image("amd64"), image("arm64"), attestation}, "linux/arm64")
+	require.NoError(t, err)
+	assert.Equal(t, image("arm64"), got)
+
+	// a single image is selected regardless of the host architecture
+	got, err = selectImage([]v1.Descriptor{attestation, image("s390x")}, "")
+	require.NoError(t, err)
+	assert.Equal(t, image("s390x"), got)
+
+	_, err = selectImage([]v1.Descriptor{image("amd64"), image("arm64")}, "linux/ppc64le")
+	assert.ErrorContains(t, err, "no image manifest found for platform linux/ppc64le")
+
+	_, err = selectImage([]v1.Descriptor{attestation}, "")
+	assert.ErrorContains(t, err, "no image manifest found")
+}
@@ -0,0 +1,148 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/format/cyclonedxjson"
+	"github.com/anchore/syft/syft/format/cyclonedxxml"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// ExternalVulnerability is a vulnerability already reported within the input SBOM (the vulnerabilities of a CycloneDX
+// document), along with the analysis of the SBOM producer.
+type ExternalVulnerability struct {
+	ID          string
+	Source      string
+	URL         string
+	Description string
+	// Severity is the highest severity rated for the vulnerability (e.g. "critical"), if any
+	Severity string
+	// Packages are the IDs of the affected packages
+	Packages []ID
+	Analysis ExternalAnalysis
+}
+
+// ExternalAnalysis is the impact analysis of an ExternalVulnerability, in CycloneDX terms.
+type ExternalAnalysis struct {
+	State         string   `json:"state,omitempty"`
+	Justification string   `json:"justification,omitempty"`
+	Response      []string `json:"response,omitempty"`
+	Detail        string   `json:"detail,omitempty"`
+}
+
+// Suppresses is true when the analysis concludes the packages are not (or no longer) affected.
+func (a ExternalAnalysis) Suppresses() bool {
+	switch cyclonedx.ImpactAnalysisState(a.State) {
+	case cyclonedx.IASResolved, cyclonedx.IASResolvedWithPedigree, cyclonedx.IASFalsePositive, cyclonedx.IASNotAffected:
+		return true
+	}
+	return false
+}
+
+// severityOrder ranks CycloneDX severities, higher being more severe.
+var severityOrder = map[cyclonedx.Severity]int{
+	cyclonedx.SeverityInfo:     1,
+	cyclonedx.SeverityLow:      2,
+	cyclonedx.SeverityMedium:   3,
+	cyclonedx.SeverityHigh:     4,
+	cyclonedx.SeverityCritical: 5,
+}
+
+// readExternalVulnerabilities returns the vulnerabilities reported within a CycloneDX SBOM (none for other formats).
+func readExternalVulnerabilities(reader io.ReadSeeker, fmtID sbom.FormatID) ([]ExternalVulnerability, error) {
+	var fileFormat cyclonedx.BOMFileFormat
+	switch fmtID {
+	case cyclonedxjson.ID:
+		fileFormat = cyclonedx.BOMFileFormatJSON
+	case cyclonedxxml.ID:
+		fileFormat = cyclonedx.BOMFileFormatXML
+	default:
+		return nil, nil
+	}
+
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to read SBOM vulnerabilities: %w", err)
+	}
+	var bom cyclonedx.BOM
+	if err := cyclonedx.NewBOMDecoder(reader, fileFormat).Decode(&bom); err != nil {
+		return nil, fmt.Errorf("unable to read SBOM vulnerabilities: %w", err)
+	}
+	if bom.Vulnerabilities == nil {
+		return nil, nil
+	}
+
+	var out []ExternalVulnerability
+	for _, v := range *bom.Vulnerabilities {
+		ev := newExternalVulnerability(v)
+		if ev.ID == "" || len(ev.Packages) == 0 {
+			log.WithFields("vulnerability", v.ID).Debug("skipping SBOM vulnerability without an ID or affected components")
+			continue
+		}
+		out = append(out, ev)
+	}
+	return out, nil
+}
+
+func newExternalVulnerability(v cyclonedx.Vulnerability) ExternalVulnerability {
+	ev := ExternalVulnerability{
+		ID:          v.ID,
+		Description: v.Description,
+	}
+	if v.Source != nil {
+		ev.Source = v.Source.Name
+		ev.URL = v.Source.URL
+	}
+
+	if v.Ratings != nil {
+		var worst cyclonedx.Severity
+		for _, r := range *v.Ratings {
+			if severityOrder[r.Severity] > severityOrder[worst] {
+				worst = r.Severity
+			}
+		}
+		ev.Severity = string(worst)
+	}
+
+	if v.Affects != nil {
+		for _, a := range *v.Affects {
+			if ref := bomRef(a.Ref); ref != "" {
+				ev.Packages = append(ev.Packages, ID(ref))
+			}
+		}
+	}
+
+	if a := v.Analysis; a != nil {
+		ev.Analysis = ExternalAnalysis{
+			State:         string(a.State),
+			Justification: string(a.Justification),
+			Detail:        a.Detail,
+		}
+		if a.Response != nil {
+			for _, r := range *a.Response {
+				ev.Analysis.Response = append(ev.Analysis.Response, string(r))
+			}
+		}
+	}
+	return ev
+}
+
+// bomRef returns the bom-ref of a component referenced either directly or with a BOM-Link (urn:cdx:...#<bom-ref>).
+func bomRef(ref string) string {
+	if strings.HasPrefix(ref, "urn:cdx:") {
+		_, fragment, found := strings.Cut(ref, "#")
+		if !found {
+			return ""
+		}
+		if unescaped, err := url.PathUnescape(fragment); err == nil {
+			return unescaped
+		}
+		return fragment
+	}
+	return ref
+}
@@ -0,0 +1,233 @@
+package pkg
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// functionInputPrefixes select the function archive provider, e.g. "lambda:function.zip,layer.zip".
+var functionInputPrefixes = []string{"lambda:", "function:"}
+
+const (
+	// functionCodeDir and functionLayersDir are where the function code and its layers are extracted to, mirroring the
+	// layout of the function runtime environment (so that reported locations match the deployed function).
+	functionCodeDir   = "var/task"
+	functionLayersDir = "opt"
+
+	// maxFunctionArchiveBytes bounds the total uncompressed size of the function code and layers. Deployed functions
+	// are far smaller (e.g. 250 MB for AWS Lambda); this only guards against decompression bombs.
+	maxFunctionArchiveBytes = 4 << 30 // 4 GB
+
+	// maxFunctionArchiveEntries bounds the number of entries extracted from the function code and layers.
+	maxFunctionArchiveEntries = 500000
+)
+
+// FunctionArchiveMetadata describes a serverless function bundle (e.g. an AWS Lambda deployment package) that was
+// unpacked and cataloged, along with its layers.
+type FunctionArchiveMetadata struct {
+	Path   string   `json:"path"`
+	Layers []string `json:"layers,omitempty"`
+	// Runtimes are the language runtimes the bundle has dependencies for (e.g. "nodejs", "python")
+	Runtimes []string `json:"runtimes,omitempty"`
+}
+
+func functionArchiveProvider(userInput string, config ProviderConfig, applyChannel func(*distro.Distro) bool) ([]*Package, Context, *sbom.SBOM, error) {
+	archives, ok := functionArchives(userInput)
+	if !ok {
+		return nil, Context{}, nil, errDoesNotProvide
+	}
+
+	dir, err := os.MkdirTemp("", "grype-function-")
+	if err != nil {
+		return nil, Context{}, nil, fmt.Errorf("unable to create directory for function archive: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.WithFields("error", err, "path", dir).Warn("unable to remove unpacked function archive")
+		}
+	}()
+
+	limits := &extractLimits{bytes: maxFunctionArchiveBytes, entries: maxFunctionArchiveEntries}
+	if err := extractZip(archives[0], filepath.Join(dir, functionCodeDir), limits); err != nil {
+		return nil, Context{}, nil, fmt.Errorf("unable to unpack function archive: %w", err)
+	}
+	for _, layer := range archives[1:] {
+		// later layers take precedence over earlier ones, as with the function runtime
+		if err := extractZip(layer, filepath.Join(dir, functionLayersDir), limits); err != nil {
+			return nil, Context{}, nil, fmt.Errorf("unable to unpack function layer: %w", err)
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(archives[0]), filepath.Ext(archives[0]))
+	}
+
+	dirConfig := config
+	dirConfig.Sources = []string{"dir"}
+	dirConfig.Name = name
+	// the SBOM cache is keyed by image layers, so it does not apply to unpacked archives
+	dirConfig.SBOMCacheDir = ""
+
+	packages, ctx, s, err := syftProvider(dir, dirConfig, applyChannel)
+	if err != nil {
+		return nil, Context{}, nil, err
+	}
+
+	metadata := FunctionArchiveMetadata{
+		Path:     archives[0],
+		Layers:   archives[1:],
+		Runtimes: functionRuntimes(dir),
+	}
+	log.WithFields("function", metadata.Path, "layers", len(metadata.Layers), "runtimes", metadata.Runtimes).Debug("cataloged function archive")
+
+	src := *ctx.Source
+	src.Name = name
+	src.Metadata = metadata
+	ctx.Source = &src
+	s.Source = src
+
+	return packages, ctx, s, nil
+}
+
+// functionArchives returns the function archive followed by its layer archives for inputs such as
+// "lambda:function.zip,layer-1.zip,layer-2.zip".
+func functionArchives(userInput string) ([]string, bool) {
+	for _, prefix := range functionInputPrefixes {
+		if !strings.HasPrefix(userInput, prefix) {
+			continue
+		}
+		var archives []string
+		for _, path := range strings.Split(strings.TrimPrefix(userInput, prefix), ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				archives = append(archives, path)
+			}
+		}
+		return archives, len(archives) > 0
+	}
+	return nil, false
+}
+
+// extractLimits tracks the remaining budget while extracting archives.
+type extractLimits struct {
+	bytes   int64
+	entries int
+}
+
+// extractZip extracts the given zip archive into the destination directory, refusing entries escaping it.
+func extractZip(path, dest string, limits *extractLimits) error {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", path, err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		limits.entries--
+		if limits.entries < 0 {
+			return fmt.Errorf("%s exceeds the maximum of %d entries", path, maxFunctionArchiveEntries)
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(f.Name))
+		if target != dest && !strings.HasPrefix(target, dest+string(os.PathSeparator)) {
+			return fmt.Errorf("%s contains an entry outside of the archive root: %q", path, f.Name)
+		}
+
+		mode := f.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		case !mode.IsRegular():
+			// symlinks and other special files are not followed, avoiding links out of the extraction directory
+			log.WithFields("archive", path, "entry", f.Name).Trace("skipping non-regular file in function archive")
+			continue
+		}
+
+		if err := extractZipFile(f, target, limits); err != nil {
+			return fmt.Errorf("unable to extract %q from %s: %w", f.Name, path, err)
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string, limits *extractLimits) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode().Perm()|0o600)
+	if err != nil {
+		return err
+	}
+
+	n, err := io.Copy(out, io.LimitReader(rc, limits.bytes+1))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	limits.bytes -= n
+	if limits.bytes < 0 {
+		return errors.New("the function archive exceeds the maximum uncompressed size")
+	}
+	return nil
+}
+
+// functionRuntimeIndicators are files (matched by name or extension) indicating the dependencies of a runtime.
+var functionRuntimeIndicators = map[string][]string{
+	"nodejs":   {"package.json", "package-lock.json", "yarn.lock", "node_modules", ".js", ".mjs", ".cjs"},
+	"python":   {"requirements.txt", "pyproject.toml", "Pipfile.lock", "poetry.lock", ".py", ".dist-info"},
+	"java":     {"pom.xml", ".jar", ".class"},
+	"dotnet":   {".deps.json", ".dll"},
+	"ruby":     {"Gemfile", "Gemfile.lock", ".rb", ".gemspec"},
+	"provided": {"bootstrap"},
+}
+
+// functionRuntimes returns the runtimes the unpacked function has code or dependencies for.
+func functionRuntimes(dir string) []string {
+	found := strset.New()
+	_ = filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		for runtime, indicators := range functionRuntimeIndicators {
+			for _, indicator := range indicators {
+				if name == indicator || (strings.HasPrefix(indicator, ".") && strings.HasSuffix(name, indicator)) {
+					found.Add(runtime)
+				}
+			}
+		}
+		if name == "node_modules" && d.IsDir() {
+			// the contents of dependency directories say nothing more about the runtime
+			return filepath.SkipDir
+		}
+		return nil
+	})
+
+	runtimes := found.List()
+	sort.Strings(runtimes)
+	return runtimes
+}
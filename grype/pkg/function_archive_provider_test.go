@@ -0,0 +1,108 @@
+package pkg
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/glebarez/sqlite" // required by the RPM DB cataloger run by default
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+)
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, contents := range files {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func TestFunctionArchiveProvider(t *testing.T) {
+	dir := t.TempDir()
+	function := filepath.Join(dir, "my-function.zip")
+	layer := filepath.Join(dir, "layer.zip")
+	writeZip(t, function, map[string]string{
+		"index.js":     "exports.handler = async () => {}",
+		"package.json": `{"name": "my-function", "version": "1.0.0"}`,
+		"package-lock.json": `{
+  "name": "my-function",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "my-function", "version": "1.0.0", "dependencies": {"lodash": "4.17.20"}},
+    "node_modules/lodash": {"version": "4.17.20", "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.20.tgz"}
+  }
+}`,
+	})
+	writeZip(t, layer, map[string]string{
+		"python/requirements.txt": "requests==2.31.0\n",
+	})
+
+	cfg := ProviderConfig{
+		SyftProviderConfig: SyftProviderConfig{
+			SBOMOptions: syft.DefaultCreateSBOMConfig(),
+		},
+	}
+	packages, ctx, s, err := functionArchiveProvider("lambda:"+function+","+layer, cfg, getDistroChannelApplier(testFixChannels()))
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	locations := map[string]string{}
+	for _, p := range packages {
+		for _, l := range p.Locations.ToSlice() {
+			locations[p.Name] = l.RealPath
+		}
+	}
+	assert.Equal(t, "/var/task/package-lock.json", locations["lodash"])
+	assert.Equal(t, "/opt/python/requirements.txt", locations["requests"])
+
+	require.NotNil(t, ctx.Source)
+	assert.Equal(t, "my-function", ctx.Source.Name)
+	assert.Equal(t, FunctionArchiveMetadata{
+		Path:     function,
+		Layers:   []string{layer},
+		Runtimes: []string{"nodejs", "python"},
+	}, ctx.Source.Metadata)
+	assert.Equal(t, *ctx.Source, s.Source)
+}
+
+func TestFunctionArchiveProvider_DoesNotProvide(t *testing.T) {
+	for _, input := range []string{"function.zip", "dir:function", "lambda:", "function: , "} {
+		_, _, _, err := functionArchiveProvider(input, ProviderConfig{}, nil)
+		assert.ErrorIs(t, err, errDoesNotProvide, input)
+	}
+}
+
+func TestExtractZip_RejectsEscapingEntries(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "evil.zip")
+	writeZip(t, archive, map[string]string{"../../escaped.txt": "nope"})
+
+	dest := filepath.Join(dir, "out")
+	err := extractZip(archive, dest, &extractLimits{bytes: maxFunctionArchiveBytes, entries: maxFunctionArchiveEntries})
+	require.ErrorContains(t, err, "outside of the archive root")
+	assert.NoFileExists(t, filepath.Join(dir, "escaped.txt"))
+}
+
+func TestExtractZip_Limits(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "big.zip")
+	writeZip(t, archive, map[string]string{"a.txt": "0123456789", "b.txt": "0123456789"})
+
+	err := extractZip(archive, filepath.Join(dir, "bytes"), &extractLimits{bytes: 15, entries: 10})
+	assert.ErrorContains(t, err, "maximum uncompressed size")
+
+	err = extractZip(archive, filepath.Join(dir, "entries"), &extractLimits{bytes: 100, entries: 1})
+	assert.ErrorContains(t, err, "entries")
+}
@@ -0,0 +1,233 @@
+package pkg
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/format/syftjson"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// imageSBOMCache stores syft cataloging results of container image layers on disk, so that scanning many images
+// sharing base layers only catalogs the layers they do not share. Each layer is cataloged on its own (see layerSource)
+// and the layer results are merged per image (see mergeLayerSBOMs). The files a layer provides depend on the layers
+// below it (a layer may modify or remove files of earlier layers, and packages may reference files of earlier layers),
+// so a layer entry is keyed by the ordered chain of layer digests up to and including that layer (much like an OCI
+// chain ID): images built on the same base image share the entries of the base layers. Keys also include the syft
+// version and a hash of the cataloger configuration so that a syft upgrade or configuration change never serves stale
+// results.
+type imageSBOMCache struct {
+	dir string
+}
+
+func newImageSBOMCache(dir string) *imageSBOMCache {
+	if dir == "" {
+		return nil
+	}
+	return &imageSBOMCache{dir: dir}
+}
+
+// layerKeys returns the cache keys for cataloging each layer of the given source with the given configuration (in
+// layer order), or false if the source is not cacheable (only container images with known layer digests are).
+func (c *imageSBOMCache) layerKeys(src source.Description, config ProviderConfig) ([]string, bool) {
+	metadata, ok := src.Metadata.(source.ImageMetadata)
+	if !ok || len(metadata.Layers) == 0 {
+		return nil, false
+	}
+
+	cfg, err := json.Marshal(config.SBOMOptions)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to hash cataloger configuration, not caching SBOM")
+		return nil, false
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "syft=%s\n", syftVersion())
+	fmt.Fprintf(h, "config=%x\n", sha256.Sum256(cfg))
+	fmt.Fprintf(h, "exclusions=%q\n", config.Exclusions)
+	var keys []string
+	for _, layer := range metadata.Layers {
+		if layer.Digest == "" {
+			return nil, false
+		}
+		fmt.Fprintf(h, "layer=%s\n", layer.Digest)
+		// the hash of the chain so far (hashing does not reset the running state)
+		keys = append(keys, fmt.Sprintf("%x", h.Sum(nil)))
+	}
+	return keys, true
+}
+
+func (c *imageSBOMCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// get returns the cached SBOM for the given key, or nil if there is no (readable) entry.
+func (c *imageSBOMCache) get(key string) *sbom.SBOM {
+	by, err := os.ReadFile(c.path(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithFields("error", err, "key", key).Debug("unable to read cached SBOM")
+		}
+		return nil
+	}
+
+	s, _, _, err := syftjson.NewFormatDecoder().Decode(bytes.NewReader(by))
+	if err != nil {
+		log.WithFields("error", err, "key", key).Debug("unable to decode cached SBOM")
+		return nil
+	}
+	return s
+}
+
+// put stores the given SBOM under the given key. Failures are logged but never fail the scan.
+func (c *imageSBOMCache) put(key string, s *sbom.SBOM) {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		log.WithFields("error", err).Debug("unable to create SBOM cache directory")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := syftjson.NewFormatEncoder().Encode(&buf, *s); err != nil {
+		log.WithFields("error", err).Debug("unable to encode SBOM for cache")
+		return
+	}
+
+	// write to a temp file and rename so that concurrent scans never observe a partial entry
+	tmp, err := os.CreateTemp(c.dir, "tmp-sbom-*")
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to create SBOM cache entry")
+		return
+	}
+	_, err = tmp.Write(buf.Bytes())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to write SBOM cache entry")
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// mergeLayerSBOMs merges the cataloging results of the layers of an image (in layer order) into the SBOM of the image.
+// Unless a squashed image resolver is given (i.e. when cataloging all layers), every package, file and relationship is
+// kept. Otherwise only what is present in the final image is: packages whose (primary evidence) locations are the
+// files visible in the squashed image, and files visible in the squashed image. The distribution is that of the
+// topmost layer providing a release file.
+//
+// Since each package is found by cataloging a single layer, results derived from several layers at once (such as
+// packages owning files of packages found in other layers, see artifact.OwnershipByFileOverlapRelationship) are not
+// identified across layers, so may differ from cataloging the squashed image as a whole.
+func mergeLayerSBOMs(layers []*sbom.SBOM, squashed file.Resolver) *sbom.SBOM {
+	out := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			Packages:     syftPkg.NewCollection(),
+			FileMetadata: make(map[file.Coordinates]file.Metadata),
+			FileDigests:  make(map[file.Coordinates][]file.Digest),
+			FileContents: make(map[file.Coordinates]string),
+			FileLicenses: make(map[file.Coordinates][]file.License),
+			Executables:  make(map[file.Coordinates]file.Executable),
+			Unknowns:     make(map[file.Coordinates][]string),
+		},
+	}
+
+	visible := make(map[file.Coordinates]bool)
+	fileVisible := func(c file.Coordinates) bool {
+		if squashed == nil {
+			return true
+		}
+		v, ok := visible[c]
+		if !ok {
+			v = visibleInSquashedImage([]file.Location{file.NewLocationFromCoordinates(c)}, squashed)
+			visible[c] = v
+		}
+		return v
+	}
+
+	kept := make(map[artifact.ID]struct{})
+	for _, s := range layers {
+		if s == nil {
+			continue
+		}
+		out.Descriptor = s.Descriptor
+		if s.Artifacts.LinuxDistribution != nil {
+			out.Artifacts.LinuxDistribution = s.Artifacts.LinuxDistribution
+		}
+
+		if s.Artifacts.Packages != nil {
+			for p := range s.Artifacts.Packages.Enumerate() {
+				locations := EvidenceLocations(Package{Locations: p.Locations})
+				if squashed != nil && len(locations) > 0 && !visibleInSquashedImage(locations, squashed) {
+					continue
+				}
+				kept[p.ID()] = struct{}{}
+				out.Artifacts.Packages.Add(p)
+			}
+		}
+
+		mergeVisible(out.Artifacts.FileMetadata, s.Artifacts.FileMetadata, fileVisible)
+		mergeVisible(out.Artifacts.FileDigests, s.Artifacts.FileDigests, fileVisible)
+		mergeVisible(out.Artifacts.FileContents, s.Artifacts.FileContents, fileVisible)
+		mergeVisible(out.Artifacts.FileLicenses, s.Artifacts.FileLicenses, fileVisible)
+		mergeVisible(out.Artifacts.Executables, s.Artifacts.Executables, fileVisible)
+		mergeVisible(out.Artifacts.Unknowns, s.Artifacts.Unknowns, fileVisible)
+	}
+
+	keep := func(a artifact.Identifiable) bool {
+		switch v := a.(type) {
+		case syftPkg.Package:
+			_, ok := kept[v.ID()]
+			return ok
+		case file.Coordinates:
+			return fileVisible(v)
+		}
+		return true
+	}
+	for _, s := range layers {
+		if s == nil {
+			continue
+		}
+		for _, r := range s.Relationships {
+			if keep(r.From) && keep(r.To) {
+				out.Relationships = append(out.Relationships, r)
+			}
+		}
+	}
+	return out
+}
+
+func mergeVisible[T any](dst, src map[file.Coordinates]T, visible func(file.Coordinates) bool) {
+	for c, v := range src {
+		if visible(c) {
+			dst[c] = v
+		}
+	}
+}
+
+// syftVersion returns the version of the syft library this binary is built with.
+func syftVersion() string {
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, d := range buildInfo.Deps {
+			if d.Path == "github.com/anchore/syft" {
+				if d.Replace != nil {
+					return d.Replace.Version
+				}
+				return d.Version
+			}
+		}
+	}
+	return syft.DefaultCreateSBOMConfig().ToolVersion
+}
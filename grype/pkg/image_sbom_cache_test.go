@@ -0,0 +1,165 @@
+package pkg
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/linux"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestImageSBOMCache_layerKeys(t *testing.T) {
+	cache := newImageSBOMCache(t.TempDir())
+	config := ProviderConfig{SyftProviderConfig: SyftProviderConfig{SBOMOptions: syft.DefaultCreateSBOMConfig()}}
+
+	image := func(tag string, layers ...string) source.Description {
+		metadata := source.ImageMetadata{UserInput: tag, Tags: []string{tag}}
+		for _, l := range layers {
+			metadata.Layers = append(metadata.Layers, source.LayerMetadata{Digest: l})
+		}
+		return source.Description{Name: tag, Metadata: metadata}
+	}
+
+	keys, ok := cache.layerKeys(image("app:1", "sha256:base", "sha256:app"), config)
+	require.True(t, ok)
+	require.Len(t, keys, 2)
+	assert.NotEqual(t, keys[0], keys[1])
+
+	retagged, ok := cache.layerKeys(image("app:latest", "sha256:base", "sha256:app"), config)
+	require.True(t, ok)
+	assert.Equal(t, keys, retagged, "the same layers should share cache entries")
+
+	other, ok := cache.layerKeys(image("app:2", "sha256:base", "sha256:app2"), config)
+	require.True(t, ok)
+	assert.Equal(t, keys[0], other[0], "images sharing a base layer should share its cache entry")
+	assert.NotEqual(t, keys[1], other[1])
+
+	base, ok := cache.layerKeys(image("base:1", "sha256:base"), config)
+	require.True(t, ok)
+	assert.Equal(t, keys[:1], base)
+
+	reordered, ok := cache.layerKeys(image("app:1", "sha256:app", "sha256:base"), config)
+	require.True(t, ok)
+	assert.NotEqual(t, keys[1], reordered[1], "a layer entry depends on the layers below it")
+
+	changedConfig := ProviderConfig{SyftProviderConfig: SyftProviderConfig{SBOMOptions: syft.DefaultCreateSBOMConfig()}}
+	changedConfig.SBOMOptions.Packages.JavaArchive.IncludeUnindexedArchives = true
+	reconfigured, ok := cache.layerKeys(image("app:1", "sha256:base", "sha256:app"), changedConfig)
+	require.True(t, ok)
+	assert.NotEqual(t, keys[0], reconfigured[0], "cataloger configuration changes should invalidate the cache")
+
+	_, ok = cache.layerKeys(source.Description{Metadata: source.DirectoryMetadata{Path: "/src"}}, config)
+	assert.False(t, ok, "only images are cacheable")
+
+	_, ok = cache.layerKeys(image("app:1"), config)
+	assert.False(t, ok, "images without layer digests are not cacheable")
+
+	_, ok = cache.layerKeys(image("app:1", "sha256:base", ""), config)
+	assert.False(t, ok, "images with unknown layer digests are not cacheable")
+}
+
+func TestMergeLayerSBOMs(t *testing.T) {
+	inLayer := func(path, layer string) file.Location {
+		return file.NewLocationFromCoordinates(file.Coordinates{RealPath: path, FileSystemID: layer}).
+			WithAnnotation(syftPkg.EvidenceAnnotationKey, syftPkg.PrimaryEvidenceAnnotation)
+	}
+	newPackage := func(name string, locations ...file.Location) syftPkg.Package {
+		p := syftPkg.Package{Name: name, Version: "1.0", Type: syftPkg.DebPkg, Locations: file.NewLocationSet(locations...)}
+		p.SetID()
+		return p
+	}
+
+	// the base layer installs curl and libc6, the app layer removes curl (rewriting the dpkg status file)
+	baseStatus := file.Coordinates{RealPath: "/var/lib/dpkg/status", FileSystemID: "sha256:base"}
+	appStatus := file.Coordinates{RealPath: "/var/lib/dpkg/status", FileSystemID: "sha256:app"}
+	curlBinary := file.Coordinates{RealPath: "/usr/bin/curl", FileSystemID: "sha256:base"}
+
+	baseLibc := newPackage("libc6", inLayer(baseStatus.RealPath, baseStatus.FileSystemID))
+	curl := newPackage("curl", inLayer(baseStatus.RealPath, baseStatus.FileSystemID))
+	appLibc := newPackage("libc6", inLayer(appStatus.RealPath, appStatus.FileSystemID))
+	app := newPackage("app", inLayer("/app/package.json", "sha256:app"))
+
+	base := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			Packages:          syftPkg.NewCollection(baseLibc, curl),
+			FileDigests:       map[file.Coordinates][]file.Digest{baseStatus: {{Algorithm: "sha256", Value: "aaaa"}}, curlBinary: {{Algorithm: "sha256", Value: "bbbb"}}},
+			LinuxDistribution: &linux.Release{ID: "debian", VersionID: "12"},
+		},
+		Relationships: []artifact.Relationship{
+			{From: curl, To: curlBinary, Type: artifact.ContainsRelationship},
+			{From: baseLibc, To: baseStatus, Type: artifact.EvidentByRelationship},
+		},
+	}
+	top := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			Packages:    syftPkg.NewCollection(appLibc, app),
+			FileDigests: map[file.Coordinates][]file.Digest{appStatus: {{Algorithm: "sha256", Value: "cccc"}}},
+		},
+		Relationships: []artifact.Relationship{
+			{From: appLibc, To: appStatus, Type: artifact.EvidentByRelationship},
+		},
+	}
+
+	resolver := squashedResolver{layers: map[string]string{
+		"/var/lib/dpkg/status": "sha256:app",
+		"/app/package.json":    "sha256:app",
+	}}
+
+	names := func(s *sbom.SBOM) []string {
+		var out []string
+		for _, p := range s.Artifacts.Packages.Sorted() {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+
+	t.Run("squashed", func(t *testing.T) {
+		merged := mergeLayerSBOMs([]*sbom.SBOM{base, top}, resolver)
+		assert.Equal(t, []string{"app", "libc6"}, names(merged))
+		assert.Equal(t, []file.Coordinates{appStatus}, slices.Collect(maps.Keys(merged.Artifacts.FileDigests)))
+		require.Len(t, merged.Relationships, 1)
+		assert.Equal(t, appStatus, merged.Relationships[0].To)
+		require.NotNil(t, merged.Artifacts.LinuxDistribution)
+		assert.Equal(t, "debian", merged.Artifacts.LinuxDistribution.ID)
+	})
+
+	t.Run("all layers", func(t *testing.T) {
+		merged := mergeLayerSBOMs([]*sbom.SBOM{base, top}, nil)
+		// the same package found in several layers is merged (as when cataloging all layers at once)
+		assert.Equal(t, []string{"app", "curl", "libc6"}, names(merged))
+		assert.Len(t, merged.Artifacts.FileDigests, 3)
+		assert.Len(t, merged.Relationships, 3)
+	})
+}
+
+func TestImageSBOMCache_getPut(t *testing.T) {
+	assert.Nil(t, newImageSBOMCache(""))
+
+	cache := newImageSBOMCache(t.TempDir())
+	assert.Nil(t, cache.get("missing"))
+
+	p := syftPkg.Package{Name: "musl", Version: "1.2.4-r2", Type: syftPkg.ApkPkg}
+	p.SetID()
+	s := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{Packages: syftPkg.NewCollection(p)},
+		Source:    source.Description{Name: "app:1", Metadata: source.ImageMetadata{UserInput: "app:1"}},
+	}
+
+	cache.put("key", s)
+
+	cached := cache.get("key")
+	require.NotNil(t, cached)
+	pkgs := cached.Artifacts.Packages.Sorted()
+	require.Len(t, pkgs, 1)
+	assert.Equal(t, "musl", pkgs[0].Name)
+	assert.Equal(t, "1.2.4-r2", pkgs[0].Version)
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/syft/syft/cpe"
+)
+
+var (
+	// productTrademarks are the marks commonly embedded in product and company names (e.g. "Microsoft® Windows®")
+	productTrademarks = strings.NewReplacer("®", " ", "™", " ", "©", " ", "(r)", " ", "(tm)", " ", "(c)", " ")
+	// productCompanySuffix matches the legal entity suffix of company names (e.g. "Mozilla Corporation" or "Oracle, Inc.")
+	productCompanySuffix = regexp.MustCompile(`[\s,]+(inc|incorporated|corp|corporation|co|company|ltd|limited|llc|gmbh|ag|s\.?a|b\.?v|pty|plc|srl)\.?$`)
+	// productDecoration matches what installers append to product names: architectures and versions (e.g.
+	// "7-Zip 23.01 (x64)" or "Python 3.11.4 (64-bit)")
+	productDecoration = regexp.MustCompile(`\s*\([^)]*\)|\s+v?\d+(\.\d+)*\s*$|\s+(x64|x86|amd64|arm64|32-bit|64-bit)\s*$`)
+	// productCPEInvalidChars matches the runs of characters not kept in CPE vendor and product values
+	productCPEInvalidChars = regexp.MustCompile(`[^a-z0-9.+\-]+`)
+	// productVersion matches the leading dotted version of version strings (e.g. "3.11.4150.0" of "3.11.4150.0 (main)")
+	productVersion = regexp.MustCompile(`^v?(\d+(\.\d+)*)`)
+)
+
+// installedProduct identifies a product installed on a workstation or server by the vendor, name and version declared
+// by its installer (e.g. MSI properties or macOS package receipts) or its binaries (e.g. PE version resources or
+// application bundles). These products are not part of a package ecosystem, so they are matched by CPEs only.
+type installedProduct struct {
+	Vendor  string
+	Name    string
+	Version string
+	// Aliases are other names of the product (e.g. the last component of a bundle identifier)
+	Aliases []string
+}
+
+// cpes returns the CPEs of the product, derived from the vendor and product names the way NVD names them: lowercase,
+// without legal entity suffixes and with spaces as underscores (e.g. vendor "Mozilla Corporation" and product
+// "Mozilla Firefox" give "mozilla" and both "mozilla_firefox" and "firefox").
+func (p installedProduct) cpes() []cpe.CPE {
+	version := strings.TrimSpace(p.Version)
+	if version == "" {
+		return nil
+	}
+
+	var names []string
+	for _, name := range append([]string{p.Name}, p.Aliases...) {
+		if n := normalizeProductName(name); n != "" {
+			names = append(names, n)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	vendor := normalizeProductVendor(p.Vendor)
+	if vendor == "" {
+		vendor = names[0]
+	}
+
+	products := strset.New()
+	var out []cpe.CPE
+	add := func(product string) {
+		if product == "" || products.Has(product) {
+			return
+		}
+		products.Add(product)
+		out = append(out, cpe.CPE{
+			Attributes: cpe.Attributes{Part: "a", Vendor: vendor, Product: product, Version: version},
+			Source:     cpe.GeneratedSource,
+		})
+	}
+	for _, name := range names {
+		add(name)
+		add(strings.TrimPrefix(name, vendor+"_"))
+	}
+	return out
+}
+
+// additionalCPEs returns the given candidate CPEs that are not within the existing CPEs.
+func additionalCPEs(candidates, existing []cpe.CPE) []cpe.CPE {
+	seen := strset.New()
+	for _, c := range existing {
+		seen.Add(c.Attributes.BindToFmtString())
+	}
+	var additions []cpe.CPE
+	for _, c := range candidates {
+		if key := c.Attributes.BindToFmtString(); !seen.Has(key) {
+			seen.Add(key)
+			additions = append(additions, c)
+		}
+	}
+	return additions
+}
+
+func normalizeProductVendor(vendor string) string {
+	vendor = strings.TrimSpace(productTrademarks.Replace(strings.ToLower(vendor)))
+	for {
+		trimmed := productCompanySuffix.ReplaceAllString(vendor, "")
+		if trimmed == vendor {
+			break
+		}
+		vendor = trimmed
+	}
+	return normalizeProductCPEValue(vendor)
+}
+
+func normalizeProductName(product string) string {
+	product = productTrademarks.Replace(strings.ToLower(product))
+	for {
+		trimmed := strings.TrimSpace(productDecoration.ReplaceAllString(product, ""))
+		if trimmed == product {
+			break
+		}
+		product = trimmed
+	}
+	return normalizeProductCPEValue(product)
+}
+
+func normalizeProductCPEValue(value string) string {
+	return strings.Trim(productCPEInvalidChars.ReplaceAllString(value, "_"), "_.")
+}
@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/cpe"
+)
+
+func TestInstalledProduct_CPEs(t *testing.T) {
+	tests := []struct {
+		name    string
+		product installedProduct
+		want    []string
+	}{
+		{
+			name:    "vendor prefix of the product",
+			product: installedProduct{Vendor: "Mozilla Corporation", Name: "Mozilla Firefox (x64 en-US)", Version: "128.0.3"},
+			want: []string{
+				"cpe:2.3:a:mozilla:mozilla_firefox:128.0.3:*:*:*:*:*:*:*",
+				"cpe:2.3:a:mozilla:firefox:128.0.3:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name:    "version and architecture of the product",
+			product: installedProduct{Vendor: "Python Software Foundation", Name: "Python 3.11.4 (64-bit)", Version: "3.11.4150.0"},
+			want:    []string{"cpe:2.3:a:python_software_foundation:python:3.11.4150.0:*:*:*:*:*:*:*"},
+		},
+		{
+			name:    "legal entity suffixes and trademarks",
+			product: installedProduct{Vendor: "Oracle, Inc.", Name: "Oracle® VirtualBox™", Version: "7.0.10"},
+			want: []string{
+				"cpe:2.3:a:oracle:oracle_virtualbox:7.0.10:*:*:*:*:*:*:*",
+				"cpe:2.3:a:oracle:virtualbox:7.0.10:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name:    "product as vendor",
+			product: installedProduct{Name: "Notepad++", Version: "8.6.2"},
+			want:    []string{"cpe:2.3:a:notepad\\+\\+:notepad\\+\\+:8.6.2:*:*:*:*:*:*:*"},
+		},
+		{
+			name:    "aliases",
+			product: installedProduct{Vendor: "google", Name: "Google Chrome", Version: "126.0.6478.127", Aliases: []string{"chrome"}},
+			want: []string{
+				"cpe:2.3:a:google:google_chrome:126.0.6478.127:*:*:*:*:*:*:*",
+				"cpe:2.3:a:google:chrome:126.0.6478.127:*:*:*:*:*:*:*",
+			},
+		},
+		{
+			name:    "no version",
+			product: installedProduct{Vendor: "Igor Pavlov", Name: "7-Zip"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range tt.product.cpes() {
+				assert.Equal(t, cpe.GeneratedSource, c.Source)
+				got = append(got, c.Attributes.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
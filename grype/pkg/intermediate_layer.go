@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/file"
+)
+
+// IntermediateLayerAnnotation lists the layers a package was found in when it is not present in the final (squashed)
+// image, such as packages removed (or whose package DB entries were removed) by a later layer. It is only set when
+// cataloging all layers of a container image.
+const IntermediateLayerAnnotation = "intermediate-layer"
+
+// IsIntermediateLayerPackage indicates if the package was only found in intermediate layers of the image.
+func IsIntermediateLayerPackage(p Package) bool {
+	return len(p.Annotations[IntermediateLayerAnnotation]) > 0
+}
+
+// annotateIntermediateLayerPackages annotates the packages cataloged from all layers of an image that are not present
+// in the final image: those none of whose (primary evidence) locations are the file visible at that path in the
+// squashed image (as resolved by the given squashed scope resolver).
+func annotateIntermediateLayerPackages(packages []*Package, resolver file.Resolver) {
+	count := 0
+	for _, p := range packages {
+		locations := EvidenceLocations(*p)
+		if len(locations) == 0 || visibleInSquashedImage(locations, resolver) {
+			continue
+		}
+		for _, l := range locations {
+			p.AddAnnotation(IntermediateLayerAnnotation, l.FileSystemID)
+		}
+		count++
+	}
+	if count > 0 {
+		log.WithFields("count", count).Debug("found packages only present in intermediate layers")
+	}
+}
+
+func visibleInSquashedImage(locations []file.Location, resolver file.Resolver) bool {
+	for _, l := range locations {
+		squashed, err := resolver.FilesByPath(l.RealPath)
+		if err != nil {
+			// when in doubt, the package is considered present
+			return true
+		}
+		for _, s := range squashed {
+			if s.FileSystemID == l.FileSystemID {
+				return true
+			}
+		}
+	}
+	return false
+}
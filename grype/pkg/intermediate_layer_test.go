@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// squashedResolver resolves paths to the layer that provides them in the squashed image.
+type squashedResolver struct {
+	file.Resolver
+	layers map[string]string
+}
+
+func (r squashedResolver) FilesByPath(paths ...string) ([]file.Location, error) {
+	var locations []file.Location
+	for _, p := range paths {
+		if layer, ok := r.layers[p]; ok {
+			locations = append(locations, file.NewLocationFromCoordinates(file.Coordinates{RealPath: p, FileSystemID: layer}))
+		}
+	}
+	return locations, nil
+}
+
+func TestAnnotateIntermediateLayerPackages(t *testing.T) {
+	inLayer := func(path, layer string) file.Location {
+		return file.NewLocationFromCoordinates(file.Coordinates{RealPath: path, FileSystemID: layer}).
+			WithAnnotation(syftPkg.EvidenceAnnotationKey, syftPkg.PrimaryEvidenceAnnotation)
+	}
+
+	// the squashed image: the dpkg status file was last written by layer 3, the apt cache was removed
+	resolver := squashedResolver{layers: map[string]string{
+		"/var/lib/dpkg/status": "sha256:layer3",
+		"/app/package.json":    "sha256:layer1",
+	}}
+
+	kept := &Package{ID: "kept", Name: "libc6", Locations: file.NewLocationSet(
+		inLayer("/var/lib/dpkg/status", "sha256:layer2"),
+		inLayer("/var/lib/dpkg/status", "sha256:layer3"),
+	)}
+	removed := &Package{ID: "removed", Name: "curl", Locations: file.NewLocationSet(
+		inLayer("/var/lib/dpkg/status", "sha256:layer2"),
+	)}
+	deleted := &Package{ID: "deleted", Name: "cached", Locations: file.NewLocationSet(
+		inLayer("/var/cache/apt/archives/cached.deb", "sha256:layer2"),
+	)}
+	unchanged := &Package{ID: "unchanged", Name: "app", Locations: file.NewLocationSet(
+		file.NewLocationFromCoordinates(file.Coordinates{RealPath: "/app/package.json", FileSystemID: "sha256:layer1"}),
+	)}
+	noLocations := &Package{ID: "none", Name: "synthetic"}
+
+	annotateIntermediateLayerPackages([]*Package{kept, removed, deleted, unchanged, noLocations}, resolver)
+
+	assert.False(t, IsIntermediateLayerPackage(*kept))
+	assert.False(t, IsIntermediateLayerPackage(*unchanged))
+	assert.False(t, IsIntermediateLayerPackage(*noLocations))
+	assert.True(t, IsIntermediateLayerPackage(*removed))
+	assert.True(t, IsIntermediateLayerPackage(*deleted))
+	assert.Equal(t, []string{"sha256:layer2"}, removed.Annotations[IntermediateLayerAnnotation])
+}
@@ -0,0 +1,277 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+// javaClassFingerprintAnnotation lists the class files a package was identified by (for packages found by deep java
+// inspection). Surfaces in JSON output as `artifact.annotations["java-class-fingerprint"]`.
+const javaClassFingerprintAnnotation = "java-class-fingerprint"
+
+const (
+	// maxNestedJavaArchiveDepth bounds how deeply archives nested within java archives are inspected (e.g. a jar
+	// within the WEB-INF/lib directory of a war within an ear)
+	maxNestedJavaArchiveDepth = 3
+
+	// maxNestedJavaArchiveBytes bounds the size of a nested archive, which is inspected in memory
+	maxNestedJavaArchiveBytes = 256 << 20 // 256 MB
+
+	// maxJavaClassBytes bounds the size of a class file to fingerprint
+	maxJavaClassBytes = 8 << 20 // 8 MB
+)
+
+var javaArchiveExtensions = []string{".jar", ".war", ".ear", ".par", ".sar", ".nar", ".jpi", ".hpi", ".kar", ".lpkg"}
+
+// JavaClassArtifact is a maven artifact identified by the digest of one of its class files.
+type JavaClassArtifact struct {
+	Digest     string
+	ClassName  string
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// JavaClassFingerprintProvider identifies the maven artifacts java class files were released in by their digests.
+type JavaClassFingerprintProvider interface {
+	JavaClassArtifacts(digests ...string) ([]JavaClassArtifact, error)
+}
+
+// javaClassDigests collects the digests of the class files within each java archive, keyed by the archive path.
+type javaClassDigests struct {
+	lock    sync.Mutex
+	digests map[string][]string
+}
+
+func newJavaClassDigests() *javaClassDigests {
+	return &javaClassDigests{digests: make(map[string][]string)}
+}
+
+func (d *javaClassDigests) add(archivePath string, digests []string) {
+	if len(digests) == 0 {
+		return
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.digests[archivePath] = append(d.digests[archivePath], digests...)
+}
+
+func (d *javaClassDigests) all() map[string][]string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.digests
+}
+
+// newJavaClassCataloger returns a cataloger fingerprinting the class files of java archives (including archives
+// nested within them). It finds no packages by itself: the digests are looked up in the vulnerability DB once loaded
+// (see ShadedJavaPackages).
+func newJavaClassCataloger(digests *javaClassDigests) syftPkg.Cataloger {
+	globs := make([]string, 0, len(javaArchiveExtensions))
+	for _, ext := range javaArchiveExtensions {
+		globs = append(globs, "**/*"+ext)
+	}
+	return generic.NewCataloger("java-class-fingerprint-cataloger").
+		WithParserByGlobs(func(_ context.Context, _ file.Resolver, _ *generic.Environment, reader file.LocationReadCloser) ([]syftPkg.Package, []artifact.Relationship, error) {
+			found, err := fingerprintJavaArchive(reader)
+			if err != nil {
+				log.WithFields("path", reader.RealPath, "error", err).Debug("unable to fingerprint java archive classes")
+				return nil, nil, nil
+			}
+			digests.add(reader.RealPath, found)
+			return nil, nil, nil
+		}, globs...)
+}
+
+func fingerprintJavaArchive(reader io.Reader) ([]string, error) {
+	// the zip format requires random access, so the archive is spooled to disk
+	f, err := os.CreateTemp("", "grype-java-archive-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	size, err := io.Copy(f, reader)
+	if err != nil {
+		return nil, err
+	}
+	r, err := zip.NewReader(f, size)
+	if err != nil {
+		return nil, err
+	}
+	return fingerprintJavaClasses(r, 0), nil
+}
+
+// fingerprintJavaClasses returns the digests of all class files within the archive and the archives nested within it.
+func fingerprintJavaClasses(r *zip.Reader, depth int) []string {
+	var digests []string
+	for _, f := range r.File {
+		name := strings.ToLower(f.Name)
+		switch {
+		case strings.HasSuffix(name, ".class"):
+			if f.UncompressedSize64 > maxJavaClassBytes {
+				continue
+			}
+			digest, err := digestZipEntry(f)
+			if err != nil {
+				log.WithFields("class", f.Name, "error", err).Trace("unable to fingerprint java class")
+				continue
+			}
+			digests = append(digests, digest)
+		case depth < maxNestedJavaArchiveDepth && isJavaArchive(name):
+			if f.UncompressedSize64 > maxNestedJavaArchiveBytes {
+				continue
+			}
+			nested, err := readNestedZip(f)
+			if err != nil {
+				log.WithFields("archive", f.Name, "error", err).Trace("unable to read nested java archive")
+				continue
+			}
+			digests = append(digests, fingerprintJavaClasses(nested, depth+1)...)
+		}
+	}
+	return digests
+}
+
+func isJavaArchive(name string) bool {
+	for _, ext := range javaArchiveExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func digestZipEntry(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, io.LimitReader(rc, maxJavaClassBytes)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+func readNestedZip(f *zip.File) (*zip.Reader, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(io.LimitReader(rc, maxNestedJavaArchiveBytes))
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+}
+
+// ShadedJavaPackages returns packages for the maven artifacts identified by fingerprinting the class files within the
+// java archives of the given context, which were not already cataloged from their maven metadata (e.g. artifacts that
+// were shaded or repackaged into an application jar with their metadata stripped).
+func ShadedJavaPackages(ctx Context, packages []Package, provider JavaClassFingerprintProvider) ([]Package, error) {
+	if len(ctx.JavaClassDigests) == 0 {
+		return nil, nil
+	}
+
+	archivesByDigest := make(map[string][]string)
+	for archive, digests := range ctx.JavaClassDigests {
+		for _, d := range digests {
+			archivesByDigest[d] = append(archivesByDigest[d], archive)
+		}
+	}
+	digests := make([]string, 0, len(archivesByDigest))
+	for d := range archivesByDigest {
+		digests = append(digests, d)
+	}
+	sort.Strings(digests)
+
+	artifacts, err := provider.JavaClassArtifacts(digests...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to identify java classes: %w", err)
+	}
+
+	cataloged := strset.New()
+	for _, p := range packages {
+		for _, l := range p.Locations.ToSlice() {
+			cataloged.Add(shadedJavaPackageKey(l.RealPath, p.Name, p.Version))
+		}
+	}
+
+	byKey := make(map[string]*Package)
+	var keys []string
+	for _, a := range artifacts {
+		for _, archive := range archivesByDigest[a.Digest] {
+			key := shadedJavaPackageKey(archive, a.ArtifactID, a.Version)
+			if cataloged.Has(key) {
+				continue
+			}
+			p, ok := byKey[key]
+			if !ok {
+				p = newShadedJavaPackage(archive, a)
+				byKey[key] = p
+				keys = append(keys, key)
+			}
+			p.AddAnnotation(javaClassFingerprintAnnotation, a.ClassName)
+		}
+	}
+
+	sort.Strings(keys)
+	out := make([]Package, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, *byKey[key])
+	}
+	log.WithFields("classes", len(digests), "packages", len(out)).Debug("identified shaded java packages")
+	return out, nil
+}
+
+func shadedJavaPackageKey(archive, artifactID, version string) string {
+	return fmt.Sprintf("%s|%s@%s", archive, artifactID, version)
+}
+
+func newShadedJavaPackage(archive string, a JavaClassArtifact) *Package {
+	sp := syftPkg.Package{
+		Name:      a.ArtifactID,
+		Version:   a.Version,
+		FoundBy:   "java-class-fingerprint-cataloger",
+		Locations: file.NewLocationSet(file.NewLocation(archive)),
+		Licenses:  syftPkg.NewLicenseSet(),
+		Language:  syftPkg.Java,
+		Type:      syftPkg.JavaPkg,
+		PURL:      fmt.Sprintf("pkg:maven/%s/%s@%s", a.GroupID, a.ArtifactID, a.Version),
+		Metadata: syftPkg.JavaArchive{
+			VirtualPath: archive,
+			PomProperties: &syftPkg.JavaPomProperties{
+				GroupID:    a.GroupID,
+				ArtifactID: a.ArtifactID,
+				Version:    a.Version,
+			},
+		},
+	}
+	sp.SetID()
+
+	p := New(sp)
+	return &p
+}
@@ -0,0 +1,135 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+const jndiLookupClass = "org/apache/logging/log4j/core/lookup/JndiLookup.class"
+
+func classDigest(contents string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(contents)))
+}
+
+func zipBytes(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, contents := range files {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.String()
+}
+
+type fakeJavaClassFingerprints []JavaClassArtifact
+
+func (f fakeJavaClassFingerprints) JavaClassArtifacts(digests ...string) ([]JavaClassArtifact, error) {
+	var out []JavaClassArtifact
+	for _, d := range digests {
+		for _, a := range f {
+			if a.Digest == d {
+				out = append(out, a)
+			}
+		}
+	}
+	return out, nil
+}
+
+func TestSyftProvider_DeepJava(t *testing.T) {
+	dir := t.TempDir()
+	writeZip(t, filepath.Join(dir, "app.war"), map[string]string{
+		"WEB-INF/classes/com/example/App.class": "app",
+		// a fat jar with log4j shaded into it, without its maven metadata
+		"WEB-INF/lib/fat.jar": zipBytes(t, map[string]string{
+			jndiLookupClass:           "jndi-lookup-2.14.1",
+			"com/example/Other.class": "other",
+		}),
+		"README.txt": "not a class",
+	})
+
+	cfg := ProviderConfig{
+		SyftProviderConfig: SyftProviderConfig{
+			SBOMOptions: syft.DefaultCreateSBOMConfig(),
+			DeepJava:    true,
+		},
+	}
+	_, ctx, _, err := syftProvider("dir:"+dir, cfg, getDistroChannelApplier(testFixChannels()))
+	require.NoError(t, err)
+
+	require.Len(t, ctx.JavaClassDigests, 1)
+	for archive, digests := range ctx.JavaClassDigests {
+		assert.Equal(t, "/app.war", archive)
+		assert.ElementsMatch(t, []string{classDigest("app"), classDigest("jndi-lookup-2.14.1"), classDigest("other")}, digests)
+	}
+
+	// without deep java inspection no class files are fingerprinted
+	cfg.DeepJava = false
+	_, ctx, _, err = syftProvider("dir:"+dir, cfg, getDistroChannelApplier(testFixChannels()))
+	require.NoError(t, err)
+	assert.Empty(t, ctx.JavaClassDigests)
+}
+
+func TestShadedJavaPackages(t *testing.T) {
+	log4j := JavaClassArtifact{
+		Digest:     classDigest("jndi-lookup-2.14.1"),
+		ClassName:  jndiLookupClass,
+		GroupID:    "org.apache.logging.log4j",
+		ArtifactID: "log4j-core",
+		Version:    "2.14.1",
+	}
+	commonsText := JavaClassArtifact{
+		Digest:     classDigest("string-substitutor-1.9"),
+		ClassName:  "org/apache/commons/text/StringSubstitutor.class",
+		GroupID:    "org.apache.commons",
+		ArtifactID: "commons-text",
+		Version:    "1.9",
+	}
+
+	ctx := Context{
+		JavaClassDigests: map[string][]string{
+			"/app/fat.jar":  {log4j.Digest, classDigest("unknown")},
+			"/app/text.jar": {commonsText.Digest},
+		},
+	}
+	// commons-text was already cataloged from its maven metadata
+	cataloged := []Package{{
+		Name:      "commons-text",
+		Version:   "1.9",
+		Locations: file.NewLocationSet(file.NewLocation("/app/text.jar")),
+	}}
+
+	got, err := ShadedJavaPackages(ctx, cataloged, fakeJavaClassFingerprints{log4j, commonsText})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	p := got[0]
+	assert.NotEmpty(t, p.ID)
+	assert.Equal(t, "log4j-core", p.Name)
+	assert.Equal(t, "2.14.1", p.Version)
+	assert.Equal(t, syftPkg.JavaPkg, p.Type)
+	assert.Equal(t, syftPkg.Java, p.Language)
+	assert.Equal(t, "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", p.PURL)
+	assert.Equal(t, []string{"/app/fat.jar"}, p.Locations.CoordinateSet().Paths())
+	require.IsType(t, JavaMetadata{}, p.Metadata)
+	assert.Equal(t, "org.apache.logging.log4j", p.Metadata.(JavaMetadata).PomGroupID)
+	assert.Equal(t, map[string][]string{javaClassFingerprintAnnotation: {jndiLookupClass}}, p.Annotations)
+
+	got, err = ShadedJavaPackages(Context{}, nil, fakeJavaClassFingerprints{log4j})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,80 @@
+package pkg
+
+import (
+	"context"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/source"
+)
+
+// layerSource is a container image source restricted to the files added or modified by a single layer, so that the
+// layer can be cataloged (and its cataloging result cached) on its own. Files of lower layers are still resolvable
+// relative to a file of the layer (e.g. a package DB referencing files installed earlier), but they are never
+// searched for, so each package is found by cataloging the layer that provides its evidence.
+type layerSource struct {
+	source.Source
+	digest string
+}
+
+// FileResolver returns a resolver of the files of the layer, regardless of the given scope.
+func (s layerSource) FileResolver(_ source.Scope) (file.Resolver, error) {
+	resolver, err := s.Source.FileResolver(source.AllLayersScope)
+	if err != nil {
+		return nil, err
+	}
+	return layerResolver{Resolver: resolver, digest: s.digest}, nil
+}
+
+// Close is a no-op: the underlying image is shared between all layers and is closed by the caller.
+func (s layerSource) Close() error {
+	return nil
+}
+
+// layerResolver is an all-layers resolver that only searches the files of a single layer.
+type layerResolver struct {
+	file.Resolver
+	digest string
+}
+
+func (r layerResolver) FilesByPath(paths ...string) ([]file.Location, error) {
+	locations, err := r.Resolver.FilesByPath(paths...)
+	return r.filter(locations), err
+}
+
+func (r layerResolver) FilesByGlob(patterns ...string) ([]file.Location, error) {
+	locations, err := r.Resolver.FilesByGlob(patterns...)
+	return r.filter(locations), err
+}
+
+func (r layerResolver) FilesByMIMEType(types ...string) ([]file.Location, error) {
+	locations, err := r.Resolver.FilesByMIMEType(types...)
+	return r.filter(locations), err
+}
+
+func (r layerResolver) AllLocations(ctx context.Context) <-chan file.Location {
+	out := make(chan file.Location)
+	go func() {
+		defer close(out)
+		for l := range r.Resolver.AllLocations(ctx) {
+			if l.FileSystemID != r.digest {
+				continue
+			}
+			select {
+			case out <- l:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (r layerResolver) filter(locations []file.Location) []file.Location {
+	var out []file.Location
+	for _, l := range locations {
+		if l.FileSystemID == r.digest {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/file"
+)
+
+// allLayersResolver returns every given location for any search.
+type allLayersResolver struct {
+	file.Resolver
+	locations []file.Location
+}
+
+func (r allLayersResolver) FilesByGlob(...string) ([]file.Location, error) {
+	return r.locations, nil
+}
+
+func (r allLayersResolver) AllLocations(context.Context) <-chan file.Location {
+	out := make(chan file.Location, len(r.locations))
+	for _, l := range r.locations {
+		out <- l
+	}
+	close(out)
+	return out
+}
+
+func TestLayerResolver(t *testing.T) {
+	inLayer := func(path, layer string) file.Location {
+		return file.NewLocationFromCoordinates(file.Coordinates{RealPath: path, FileSystemID: layer})
+	}
+	resolver := layerResolver{
+		Resolver: allLayersResolver{locations: []file.Location{
+			inLayer("/var/lib/dpkg/status", "sha256:base"),
+			inLayer("/var/lib/dpkg/status", "sha256:app"),
+			inLayer("/usr/bin/curl", "sha256:base"),
+		}},
+		digest: "sha256:app",
+	}
+
+	locations, err := resolver.FilesByGlob("**/*")
+	require.NoError(t, err)
+	assert.Equal(t, []file.Location{inLayer("/var/lib/dpkg/status", "sha256:app")}, locations)
+
+	var all []file.Location
+	for l := range resolver.AllLocations(context.Background()) {
+		all = append(all, l)
+	}
+	assert.Equal(t, locations, all)
+}
@@ -0,0 +1,224 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/anchore/grype/internal/cfb"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const (
+	// msiStreamNameChars are the characters of the table and stream names encoded in the stream names of MSI files
+	msiStreamNameChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz._"
+	// msiLongStringRefs flags the string pools of MSI files referencing strings with 3 bytes instead of 2
+	msiLongStringRefs = 0x80000000
+	// msiUTF8CodePage is the code page of MSI files holding UTF-8 strings (the others are read as Windows-1252)
+	msiUTF8CodePage = 65001
+	// msiMaxReadSize bounds the size of the MSI files read in memory
+	msiMaxReadSize = 512 << 20
+)
+
+// NewMSIPackageCataloger returns a cataloger for the products of Windows installer (MSI) files, as found on Windows
+// hosts where the installers of the installed products are cached (C:\Windows\Installer). Products are named after the
+// ProductName property and come with the CPEs of their Manufacturer, since there is no other ecosystem to match them by.
+func NewMSIPackageCataloger() syftPkg.Cataloger {
+	return generic.NewCataloger("msi-package-cataloger").
+		WithParserByGlobs(parseMSI, "**/*.msi", "**/*.MSI")
+}
+
+func parseMSI(_ context.Context, _ file.Resolver, _ *generic.Environment, reader file.LocationReadCloser) ([]syftPkg.Package, []artifact.Relationship, error) {
+	r, size, err := msiReaderAt(reader.ReadCloser)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read MSI file %q: %w", reader.RealPath, err)
+	}
+	properties, err := msiProperties(r, size)
+	if errors.Is(err, cfb.ErrNotCompoundFile) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse MSI file %q: %w", reader.RealPath, err)
+	}
+
+	product := installedProduct{
+		Vendor:  properties["Manufacturer"],
+		Name:    strings.TrimSpace(properties["ProductName"]),
+		Version: strings.TrimSpace(properties["ProductVersion"]),
+	}
+	if product.Name == "" || product.Version == "" {
+		return nil, nil, nil
+	}
+
+	p := syftPkg.Package{
+		Name:      product.Name,
+		Version:   product.Version,
+		Locations: file.NewLocationSet(reader.WithAnnotation(syftPkg.EvidenceAnnotationKey, syftPkg.PrimaryEvidenceAnnotation)),
+		Licenses:  syftPkg.NewLicenseSet(),
+		Type:      syftPkg.BinaryPkg,
+		CPEs:      product.cpes(),
+	}
+	p.SetID()
+	return []syftPkg.Package{p}, nil, nil
+}
+
+// msiReaderAt returns random access to the content of an MSI file along with its size, reading it in memory (up to a
+// limit) unless the reader is a file allowing random access (e.g. of directory sources).
+func msiReaderAt(r io.Reader) (io.ReaderAt, int64, error) {
+	if f, ok := r.(interface {
+		io.ReaderAt
+		Stat() (os.FileInfo, error)
+	}); ok {
+		if info, err := f.Stat(); err == nil {
+			return f, info.Size(), nil
+		}
+	}
+	content, err := io.ReadAll(io.LimitReader(r, msiMaxReadSize+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(content) > msiMaxReadSize {
+		return nil, 0, fmt.Errorf("file is larger than %d bytes", msiMaxReadSize)
+	}
+	return bytes.NewReader(content), int64(len(content)), nil
+}
+
+// msiProperties returns the rows of the Property table of the given MSI file of the given size.
+func msiProperties(r io.ReaderAt, size int64) (map[string]string, error) {
+	f, err := cfb.Open(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	streams := make(map[string]string)
+	for _, name := range f.Streams() {
+		streams[decodeMSIStreamName(name)] = name
+	}
+	read := func(name string) ([]byte, error) {
+		raw, ok := streams[name]
+		if !ok {
+			return nil, fmt.Errorf("no %s table", strings.TrimPrefix(name, "!"))
+		}
+		return f.ReadStream(raw)
+	}
+
+	poolData, err := read("!_StringPool")
+	if err != nil {
+		return nil, err
+	}
+	stringData, err := read("!_StringData")
+	if err != nil {
+		return nil, err
+	}
+	strs, refSize, err := msiStrings(poolData, stringData)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := read("!Property")
+	if err != nil {
+		return nil, err
+	}
+	// tables are stored by column: the Property column of all rows then the Value column of all rows
+	rows := len(table) / (2 * refSize)
+	properties := make(map[string]string, rows)
+	for i := 0; i < rows; i++ {
+		key := msiStringRef(table[i*refSize:], refSize)
+		value := msiStringRef(table[(rows+i)*refSize:], refSize)
+		if key < len(strs) && value < len(strs) && strs[key] != "" {
+			properties[strs[key]] = strs[value]
+		}
+	}
+	return properties, nil
+}
+
+// msiStrings returns the strings of the string pool of an MSI file (by string ID, starting at 1) along with the size of
+// the references to these strings.
+func msiStrings(pool, data []byte) ([]string, int, error) {
+	if len(pool) < 4 {
+		return nil, 0, errors.New("invalid string pool")
+	}
+	header := binary.LittleEndian.Uint32(pool)
+	refSize := 2
+	if header&msiLongStringRefs != 0 {
+		refSize = 3
+	}
+	decode := decodeWindows1252
+	if header&^msiLongStringRefs == msiUTF8CodePage {
+		decode = func(b []byte) string { return string(b) }
+	}
+
+	// each entry holds the length and the reference count of a string; strings of 64KB and more take two entries,
+	// the first with a zero length and the second holding the low and high words of the length
+	entries := pool[4:]
+	strs := []string{""}
+	var offset uint32
+	for i := 0; i+4 <= len(entries); i += 4 {
+		length := uint32(binary.LittleEndian.Uint16(entries[i:]))
+		refs := binary.LittleEndian.Uint16(entries[i+2:])
+		if length == 0 && refs != 0 {
+			if i+8 > len(entries) {
+				break
+			}
+			length = uint32(binary.LittleEndian.Uint16(entries[i+6:]))<<16 | uint32(binary.LittleEndian.Uint16(entries[i+4:]))
+			i += 4
+		}
+		if uint64(offset)+uint64(length) > uint64(len(data)) {
+			return nil, 0, errors.New("string pool exceeds the string data")
+		}
+		strs = append(strs, decode(data[offset:offset+length]))
+		offset += length
+	}
+	return strs, refSize, nil
+}
+
+func msiStringRef(b []byte, size int) int {
+	ref := int(b[0]) | int(b[1])<<8
+	if size == 3 {
+		ref |= int(b[2]) << 16
+	}
+	return ref
+}
+
+// decodeMSIStreamName decodes the names of the streams of an MSI file, which pack two characters of the table and
+// stream names in each character (and mark table streams with a leading "!").
+func decodeMSIStreamName(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 0x3800 && r < 0x4800:
+			c := r - 0x3800
+			sb.WriteByte(msiStreamNameChars[c&0x3f])
+			sb.WriteByte(msiStreamNameChars[(c>>6)&0x3f])
+		case r >= 0x4800 && r < 0x4840:
+			sb.WriteByte(msiStreamNameChars[r-0x4800])
+		case r == 0x4840:
+			sb.WriteByte('!')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func decodeWindows1252(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	s, err := charmap.Windows1252.NewDecoder().Bytes(b)
+	if err != nil {
+		return string(b)
+	}
+	return string(s)
+}
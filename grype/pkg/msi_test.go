@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestParseMSI(t *testing.T) {
+	path := "testdata/msi/7zip.msi"
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	pkgs, relationships, err := parseMSI(context.Background(), nil, nil, file.NewLocationReadCloser(file.NewLocation(path), f))
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+	require.Len(t, pkgs, 1)
+
+	p := pkgs[0]
+	assert.Equal(t, "7-Zip 23.01 (x64 edition)", p.Name)
+	assert.Equal(t, "23.01.00.0", p.Version)
+	assert.Equal(t, syftPkg.BinaryPkg, p.Type)
+
+	var cpes []string
+	for _, c := range p.CPEs {
+		cpes = append(cpes, c.Attributes.String())
+	}
+	assert.Equal(t, []string{"cpe:2.3:a:igor_pavlov:7-zip:23.01.00.0:*:*:*:*:*:*:*"}, cpes)
+}
+
+func TestParseMSI_NotCompoundFile(t *testing.T) {
+	reader := file.NewLocationReadCloser(file.NewLocation("setup.msi"), io.NopCloser(strings.NewReader(strings.Repeat("x", 1024))))
+
+	pkgs, _, err := parseMSI(context.Background(), nil, nil, reader)
+	require.NoError(t, err)
+	assert.Empty(t, pkgs)
+}
+
+func TestMSIProperties(t *testing.T) {
+	f, err := os.Open("testdata/msi/7zip.msi")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	info, err := f.Stat()
+	require.NoError(t, err)
+
+	properties, err := msiProperties(f, info.Size())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"ProductName":    "7-Zip 23.01 (x64 edition)",
+		"Manufacturer":   "Igor Pavlov",
+		"ProductVersion": "23.01.00.0",
+		"ARPCOMMENTS":    "café", // from Windows-1252
+		"UpgradeCode":    "",
+	}, properties)
+}
+
+func TestDecodeMSIStreamName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "\u4840\u3f3f\u4577\u446c\u3e6a\u44b2\u482f", want: "!_StringPool"},
+		{name: "\u4840\u4559\u44f2\u4568\u4737", want: "!Property"},
+		{name: "\u0005SummaryInformation", want: "\u0005SummaryInformation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, decodeMSIStreamName(tt.name))
+		})
+	}
+}
@@ -86,12 +86,13 @@ func New(p syftPkg.Package, enhancers ...Enhancer) Package {
 		Locations: p.Locations,
 		Licenses:  licenses,
 		Language:  p.Language,
-		Type:      p.Type,
+		Type:      packageType(p),
 		CPEs:      p.CPEs,
 		PURL:      p.PURL,
 		Upstreams: upstreams,
 		Metadata:  metadata,
 	}
+	annotateDevDependency(&out, p)
 
 	if len(enhancers) > 0 {
 		purl, err := packageurl.FromString(p.PURL)
@@ -122,12 +123,22 @@ func FromPackages(syftPkgs []syftPkg.Package, relationships []artifact.Relations
 	pkgByID := make(map[ID]*Package)
 	ownedLocations := map[string][]*Package{}
 
-	for _, p := range syftPkgs {
+	var generatedCPEs [][]cpe.CPE
+	if config.GenerateMissingCPEs {
+		cache := newCPECache(config.CPECacheDir)
+		generatedCPEs = cache.Generate(syftPkgs)
+		cache.Save()
+	}
+
+	for i, p := range syftPkgs {
 		if len(p.CPEs) == 0 {
 			// for SPDX (or any format, really) we may have no CPEs
-			if config.GenerateMissingCPEs {
+			switch {
+			case config.GenerateMissingCPEs:
+				p.CPEs = generatedCPEs[i]
+			case config.GenerateBinaryCPEs && p.Type == syftPkg.BinaryPkg:
 				p.CPEs = cpes.Generate(p)
-			} else {
+			default:
 				log.Debugf("no CPEs for package: %s", p)
 			}
 		}
@@ -141,6 +152,8 @@ func FromPackages(syftPkgs []syftPkg.Package, relationships []artifact.Relations
 		// rootio-prefixed CPEs never align with NVD's openbsd:openssh-style
 		// records and the rootio NAK has nothing to suppress.
 		grypePkg.CPEs = append(grypePkg.CPEs, rootio.EquivalentCPEs(p, rootioJavaGroupID(grypePkg), grypePkg.CPEs)...)
+		grypePkg.CPEs = append(grypePkg.CPEs, windowsProductCPEs(p, grypePkg.CPEs)...)
+		grypePkg.CPEs = append(grypePkg.CPEs, appleBundleCPEs(p, grypePkg.CPEs)...)
 
 		pkgByID[grypePkg.ID] = &grypePkg
 		pkgs = append(pkgs, &grypePkg)
@@ -153,6 +166,8 @@ func FromPackages(syftPkgs []syftPkg.Package, relationships []artifact.Relations
 		}
 	}
 
+	addInstalledPythonExtras(syftPkgs, pkgByID)
+
 	hasOverlapRelationships := false
 	for _, r := range relationships {
 		if !retainRelationshipType(r.Type) {
@@ -227,6 +242,23 @@ func (p Package) String() string {
 	return fmt.Sprintf("Pkg(type=%s, name=%s, version=%s%s%s)", p.Type, p.Name, p.Version, u, d)
 }
 
+// EvidenceLocations returns the locations a package was found at: its primary evidence when annotated, otherwise all
+// of its locations. Supporting evidence (such as the copyright files of debian packages under /usr/share/doc) does not
+// describe where the package is.
+func EvidenceLocations(p Package) []file.Location {
+	all := p.Locations.ToSlice()
+	var primary []file.Location
+	for _, l := range all {
+		if l.Annotations[syftPkg.EvidenceAnnotationKey] == syftPkg.PrimaryEvidenceAnnotation {
+			primary = append(primary, l)
+		}
+	}
+	if len(primary) > 0 {
+		return primary
+	}
+	return all
+}
+
 func removePackagesByOverlap(pkgs []*Package) []*Package {
 	return slices.DeleteFunc(pkgs, func(p *Package) bool {
 		if p.RelatedPackages == nil {
@@ -331,6 +363,8 @@ func dataFromPkg(p syftPkg.Package) (any, []UpstreamPackage) {
 	switch p.Metadata.(type) {
 	case syftPkg.GolangModuleEntry, syftPkg.GolangBinaryBuildinfoEntry, syftPkg.GolangSourceEntry:
 		metadata = golangMetadataFromPkg(p)
+	case syftPkg.AlpmDBEntry:
+		upstreams = alpmDataFromPkg(p)
 	case syftPkg.DpkgDBEntry:
 		upstreams = dpkgDataFromPkg(p)
 	case syftPkg.DpkgArchiveEntry:
@@ -350,13 +384,32 @@ func dataFromPkg(p syftPkg.Package) (any, []UpstreamPackage) {
 		upstreams = apkDataFromPkg(p)
 	case syftPkg.JavaVMInstallation:
 		metadata = javaVMDataFromPkg(p)
+	case syftPkg.PythonRequirementsEntry, syftPkg.PythonPdmLockEntry:
+		if m := pythonMetadataFromPkg(p); m != nil {
+			metadata = *m
+		}
 	}
 
 	// there are still cases where we could still fill the metadata from other info (such as the PURL)
-	if metadata == nil {
-		if p.Type == syftPkg.JavaPkg {
+	switch p.Type {
+	case syftPkg.JavaPkg:
+		if metadata == nil {
 			metadata = javaDataFromPkgData(p)
 		}
+	case syftPkg.RpmPkg:
+		var existing *RpmMetadata
+		if m, ok := metadata.(RpmMetadata); ok {
+			existing = &m
+		}
+		if m := rpmMetadataFromPURL(p.PURL, existing); m != nil {
+			metadata = *m
+		}
+	case syftPkg.ApkPkg:
+		if metadata == nil {
+			if arch := purlQualifier(p.PURL, syftPkg.PURLQualifierArch); arch != "" {
+				metadata = ApkMetadata{Arch: arch}
+			}
+		}
 	}
 
 	return metadata, upstreams
@@ -431,6 +484,20 @@ func golangMetadataFromPkg(p syftPkg.Package) any {
 	return nil
 }
 
+// alpmDataFromPkg returns the base package for split packages (e.g. "python-pip" built from pkgbase "python-pip" vs.
+// "lib32-openssl" built from "openssl"), which is what the Arch Linux security tracker keys advisories by.
+func alpmDataFromPkg(p syftPkg.Package) (upstreams []UpstreamPackage) {
+	if value, ok := p.Metadata.(syftPkg.AlpmDBEntry); ok {
+		if value.BasePackage != "" && value.BasePackage != p.Name {
+			upstreams = append(upstreams, UpstreamPackage{
+				Name:    value.BasePackage,
+				Version: p.Version,
+			})
+		}
+	}
+	return upstreams
+}
+
 func dpkgDataFromPkg(p syftPkg.Package) (upstreams []UpstreamPackage) {
 	switch value := p.Metadata.(type) {
 	case syftPkg.DpkgDBEntry:
@@ -658,6 +725,7 @@ func grypeID(identifiable artifact.Identifiable) ID {
 func retainRelationshipType(relationshipType artifact.RelationshipType) bool {
 	switch relationshipType {
 	case artifact.ContainsRelationship,
+		artifact.DependencyOfRelationship,
 		artifact.OwnershipByFileOverlapRelationship:
 		return true
 	default:
@@ -29,6 +29,8 @@ func TestNew(t *testing.T) {
 		{
 			name: "alpm package with source info",
 			syftPkg: syftPkg.Package{
+				Name:    "pkg-info",
+				Version: "version-info",
 				Metadata: syftPkg.AlpmDBEntry{
 					BasePackage:  "base-pkg-info",
 					Package:      "pkg-info",
@@ -39,6 +41,12 @@ func TestNew(t *testing.T) {
 					}},
 				},
 			},
+			upstreams: []UpstreamPackage{
+				{
+					Name:    "base-pkg-info",
+					Version: "version-info",
+				},
+			},
 		},
 		{
 			name: "dpkg with source info",
@@ -590,6 +598,7 @@ func TestNew(t *testing.T) {
 					Markers:           "a",
 				},
 			},
+			metadata: PythonMetadata{Extras: []string{"a"}},
 		},
 		{
 			name: "binary-metadata",
@@ -1089,6 +1098,114 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNew_metadataFromPURLQualifiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		syftPkg  syftPkg.Package
+		metadata any
+	}{
+		{
+			name: "rpm without metadata",
+			syftPkg: syftPkg.Package{
+				Type: syftPkg.RpmPkg,
+				PURL: "pkg:rpm/redhat/openssl@3.0.7-27.el9?arch=aarch64&epoch=1&distro=rhel-9.4",
+			},
+			metadata: RpmMetadata{
+				Epoch: intRef(1),
+				Arch:  "aarch64",
+			},
+		},
+		{
+			name: "rpm metadata takes precedence over qualifiers",
+			syftPkg: syftPkg.Package{
+				Type: syftPkg.RpmPkg,
+				PURL: "pkg:rpm/redhat/openssl@3.0.7-27.el9?arch=aarch64&epoch=1",
+				Metadata: syftPkg.RpmDBEntry{
+					Arch: "x86_64",
+				},
+			},
+			metadata: RpmMetadata{
+				Epoch: intRef(1),
+				Arch:  "x86_64",
+			},
+		},
+		{
+			name: "rpm without qualifiers",
+			syftPkg: syftPkg.Package{
+				Type: syftPkg.RpmPkg,
+				PURL: "pkg:rpm/redhat/openssl@3.0.7-27.el9",
+			},
+		},
+		{
+			name: "apk without metadata",
+			syftPkg: syftPkg.Package{
+				Type: syftPkg.ApkPkg,
+				PURL: "pkg:apk/alpine/openssl@3.1.4-r5?arch=x86_64&distro=alpine-3.19.1",
+			},
+			metadata: ApkMetadata{
+				Arch: "x86_64",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := New(test.syftPkg)
+			assert.Equal(t, test.metadata, p.Metadata)
+		})
+	}
+}
+
+func TestNew_bsdPackageType(t *testing.T) {
+	tests := []struct {
+		name     string
+		syftPkg  syftPkg.Package
+		expected syftPkg.Type
+	}{
+		{
+			name: "freebsd purl",
+			syftPkg: syftPkg.Package{
+				Name: "openssl",
+				PURL: "pkg:freebsd/openssl@3.0.13_1,1",
+			},
+			expected: FreeBSDPkg,
+		},
+		{
+			name: "openbsd purl",
+			syftPkg: syftPkg.Package{
+				Type: syftPkg.UnknownPkg,
+				Name: "curl",
+				PURL: "pkg:openbsd/curl@8.6.0p0",
+			},
+			expected: OpenBSDPkg,
+		},
+		{
+			name: "cataloged type takes precedence",
+			syftPkg: syftPkg.Package{
+				Type: syftPkg.BinaryPkg,
+				Name: "curl",
+				PURL: "pkg:freebsd/curl@8.6.0",
+			},
+			expected: syftPkg.BinaryPkg,
+		},
+		{
+			name: "other purl types are untouched",
+			syftPkg: syftPkg.Package{
+				Type: syftPkg.UnknownPkg,
+				Name: "curl",
+				PURL: "pkg:generic/curl@8.6.0",
+			},
+			expected: syftPkg.UnknownPkg,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, New(test.syftPkg).Type)
+		})
+	}
+}
+
 func TestFromCollection_DoesNotPanic(t *testing.T) {
 	collection := syftPkg.NewCollection()
 
@@ -1645,7 +1762,7 @@ func Test_ExcludeRetainsCorrectRelationships(t *testing.T) {
 
 	d := distro.FromRelease(s.Artifacts.LinuxDistribution, nil)
 	pkgs := FromCollection(s.Artifacts.Packages, s.Relationships, SynthesisConfig{},
-		setDistroFromPURL(func(d *distro.Distro) bool { return true }),
+		setDistroFromPURL(func(d *distro.Distro) bool { return true }, nil),
 		func(out *Package, _ packageurl.PackageURL, _ syftPkg.Package) {
 			if out.Type == syftPkg.ApkPkg {
 				out.Distro = d
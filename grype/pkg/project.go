@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// ProjectAnnotation is the root directory of the project a package belongs to (e.g. "/services/api") when scanning a
+// directory holding several projects, such as a monorepo.
+const ProjectAnnotation = "project"
+
+// projectManifests are the files marking the root directory of a project. Lock files are included since they are
+// cataloged instead of the manifest next to them (e.g. package-lock.json next to package.json).
+var projectManifests = map[string]bool{
+	"package.json":      true,
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"go.mod":            true,
+	"pom.xml":           true,
+}
+
+// ProjectRoots returns the sorted root directories of the projects found in the locations of the given packages.
+// Manifests of installed dependencies (within node_modules) do not mark a project.
+func ProjectRoots(packages []Package) []string {
+	var roots []string
+	for _, p := range packages {
+		for _, l := range p.Locations.ToSlice() {
+			if !projectManifests[path.Base(l.RealPath)] || slices.Contains(strings.Split(l.RealPath, "/"), "node_modules") {
+				continue
+			}
+			roots = append(roots, path.Dir(NormalizeProjectPath(l.RealPath)))
+		}
+	}
+	slices.Sort(roots)
+	return slices.Compact(roots)
+}
+
+// AnnotateProjects annotates each of the given packages with the project it belongs to: the deepest of the given
+// project roots containing a location of the package. Packages outside of all projects are not annotated.
+func AnnotateProjects(packages []Package, roots []string) {
+	for i := range packages {
+		for _, l := range packages[i].Locations.ToSlice() {
+			if root := projectOf(l.RealPath, roots); root != "" {
+				packages[i].AddAnnotation(ProjectAnnotation, root)
+			}
+		}
+	}
+}
+
+// Projects returns the projects the package belongs to (see ProjectAnnotation).
+func Projects(p Package) []string {
+	return p.Annotations[ProjectAnnotation]
+}
+
+// NormalizeProjectPath returns the given path (relative to the scanned directory) as an absolute, clean path, as used
+// for project roots (e.g. "./services/api/" becomes "/services/api").
+func NormalizeProjectPath(p string) string {
+	return path.Clean("/" + strings.ReplaceAll(strings.TrimSpace(p), "\\", "/"))
+}
+
+func projectOf(location string, roots []string) string {
+	location = NormalizeProjectPath(location)
+	var deepest string
+	for _, root := range roots {
+		if root != "/" && location != root && !strings.HasPrefix(location, root+"/") {
+			continue
+		}
+		if len(root) > len(deepest) {
+			deepest = root
+		}
+	}
+	return deepest
+}
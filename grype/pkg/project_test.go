@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/file"
+)
+
+func TestProjects(t *testing.T) {
+	at := func(name string, paths ...string) Package {
+		var locations []file.Location
+		for _, p := range paths {
+			locations = append(locations, file.NewLocation(p))
+		}
+		return Package{ID: ID(name), Name: name, Locations: file.NewLocationSet(locations...)}
+	}
+
+	packages := []Package{
+		at("tool", "/go.mod"),
+		at("express", "/services/web/package-lock.json"),
+		at("lodash", "/services/web/node_modules/lodash/package.json"),
+		at("spring-core", "/services/api/pom.xml"),
+		at("netty", "/services/api/lib/netty.jar"),
+		at("shared", "/services/web/package-lock.json", "/services/api/pom.xml"),
+		at("readme", "/services/README.md"),
+	}
+
+	roots := ProjectRoots(packages)
+	assert.Equal(t, []string{"/", "/services/api", "/services/web"}, roots)
+
+	AnnotateProjects(packages, roots)
+	want := map[string][]string{
+		"tool":        {"/"},
+		"express":     {"/services/web"},
+		"lodash":      {"/services/web"},
+		"spring-core": {"/services/api"},
+		"netty":       {"/services/api"},
+		"shared":      {"/services/api", "/services/web"},
+		"readme":      {"/"},
+	}
+	for _, p := range packages {
+		assert.Equal(t, want[p.Name], Projects(p), p.Name)
+	}
+}
+
+func TestProjects_noRootManifest(t *testing.T) {
+	packages := []Package{
+		{ID: "express", Name: "express", Locations: file.NewLocationSet(file.NewLocation("/web/yarn.lock"))},
+		{ID: "curl", Name: "curl", Locations: file.NewLocationSet(file.NewLocation("/usr/bin/curl"))},
+	}
+	AnnotateProjects(packages, ProjectRoots(packages))
+	assert.Equal(t, []string{"/web"}, Projects(packages[0]))
+	assert.Empty(t, Projects(packages[1]))
+}
+
+func TestNormalizeProjectPath(t *testing.T) {
+	tests := map[string]string{
+		"services/api":    "/services/api",
+		"./services/api/": "/services/api",
+		"/services/api":   "/services/api",
+		`services\api`:    "/services/api",
+		".":               "/",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, NormalizeProjectPath(in), in)
+	}
+}
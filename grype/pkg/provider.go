@@ -21,7 +21,9 @@ var errDoesNotProvide = fmt.Errorf("cannot provide packages from the given sourc
 // Provide a set of packages and context metadata describing where they were sourced from.
 func Provide(userInput string, config ProviderConfig) ([]Package, Context, *sbom.SBOM, error) {
 	applyChannel := getDistroChannelApplier(config.Distro.FixChannels)
+	var overrideIdentification *distro.Identification
 	if config.Distro.Override != nil {
+		config.Distro.Override, overrideIdentification = distro.IdentifyOverride(config.Distro.Override, config.Distro.Aliases)
 		applyChannel(config.Distro.Override)
 		log.Infof("using distro: %s", config.Distro.Override.String())
 	}
@@ -30,6 +32,9 @@ func Provide(userInput string, config ProviderConfig) ([]Package, Context, *sbom
 	if err != nil {
 		return nil, Context{}, nil, err
 	}
+	if overrideIdentification != nil {
+		ctx.OSIdentification = overrideIdentification
+	}
 	setContextDistro(packages, &ctx)
 
 	// set the distro on each package if there is not already one set
@@ -46,6 +51,8 @@ func Provide(userInput string, config ProviderConfig) ([]Package, Context, *sbom
 	}
 
 	packages = removePackagesByOverlap(packages)
+	detectDistroless(packages, &ctx, s)
+	setArtifactDigests(packages, &ctx, s)
 
 	out := FromPtrs(packages)
 	warnMissingGoSymbols(out)
@@ -56,7 +63,9 @@ func Provide(userInput string, config ProviderConfig) ([]Package, Context, *sbom
 // instead of resolving a user input string to a file path.
 func ProvideFromReader(reader io.ReadSeeker, config ProviderConfig) ([]Package, Context, *sbom.SBOM, error) {
 	applyChannel := getDistroChannelApplier(config.Distro.FixChannels)
+	var overrideIdentification *distro.Identification
 	if config.Distro.Override != nil {
+		config.Distro.Override, overrideIdentification = distro.IdentifyOverride(config.Distro.Override, config.Distro.Aliases)
 		applyChannel(config.Distro.Override)
 		log.Infof("using distro: %s", config.Distro.Override.String())
 	}
@@ -65,6 +74,9 @@ func ProvideFromReader(reader io.ReadSeeker, config ProviderConfig) ([]Package,
 	if err != nil {
 		return nil, Context{}, nil, err
 	}
+	if overrideIdentification != nil {
+		ctx.OSIdentification = overrideIdentification
+	}
 	setContextDistro(packages, &ctx)
 
 	if ctx.Distro != nil {
@@ -88,12 +100,25 @@ func ProvideFromReader(reader io.ReadSeeker, config ProviderConfig) ([]Package,
 			return nil, ctx, s, exclusionsErr
 		}
 	}
+	detectDistroless(packages, &ctx, s)
+	setArtifactDigests(packages, &ctx, s)
 
 	out := FromPtrs(packages)
 	warnMissingGoSymbols(out)
 	return out, ctx, s, nil
 }
 
+// detectDistroless flags images without an OS package DB in the context, relying on binary evidence for these images
+// instead (see isDistroless).
+func detectDistroless(packages []*Package, ctx *Context, s *sbom.SBOM) {
+	if !isDistroless(s, *ctx) {
+		return
+	}
+	ctx.Distroless = true
+	ctx.BinaryEvidence = useBinaryEvidence(packages, s)
+	log.WithFields("binary packages", len(ctx.BinaryEvidence)).Debug("no OS package DB found in image, relying on binary evidence")
+}
+
 // warnMissingGoSymbols emits a single warning when the scan produced Go binary packages but not one
 // of them carries function symbols. See shouldWarnMissingGoSymbols for when that holds.
 func warnMissingGoSymbols(packages []Package) {
@@ -277,6 +302,25 @@ func provide(userInput string, config ProviderConfig, applyChannel func(d *distr
 		return packages, ctx, s, err
 	}
 
+	packages, ctx, s, err = embeddedSBOMProvider(userInput, config, applyChannel)
+	if !errors.Is(err, errDoesNotProvide) {
+		if len(config.Exclusions) > 0 && err == nil && ctx.Cataloging.Method == EmbeddedSBOMMethod {
+			var exclusionsErr error
+			packages, exclusionsErr = filterPackageExclusions(packages, config.Exclusions)
+			if exclusionsErr != nil {
+				return nil, ctx, s, exclusionsErr
+			}
+		}
+		log.WithFields("input", userInput).Trace("interpreting input as an OCI artifact")
+		return packages, ctx, s, err
+	}
+
+	packages, ctx, s, err = functionArchiveProvider(userInput, config, applyChannel)
+	if !errors.Is(err, errDoesNotProvide) {
+		log.WithFields("input", userInput).Trace("interpreting input as a function archive")
+		return packages, ctx, s, err
+	}
+
 	log.WithFields("input", userInput).Trace("passing input to syft for interpretation")
 	return syftProvider(userInput, config, applyChannel)
 }
@@ -355,5 +399,6 @@ func setContextDistro(packages []*Package, ctx *Context) {
 	// if there is one distro (with one version) represented, use that
 	if singleDistro != nil {
 		ctx.Distro = singleDistro
+		ctx.OSIdentification = distro.IdentifyFromPackages(singleDistro)
 	}
 }
@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"net/http"
+
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/stereoscope/pkg/image"
 	"github.com/anchore/syft/syft"
@@ -12,21 +14,42 @@ type ProviderConfig struct {
 }
 
 type SyftProviderConfig struct {
-	SBOMOptions            *syft.CreateSBOMConfig
-	RegistryOptions        *image.RegistryOptions
+	SBOMOptions     *syft.CreateSBOMConfig
+	RegistryOptions *image.RegistryOptions
+	// RegistryTransport (optional) is the transport of container registry requests (e.g. through a dedicated proxy),
+	// a clone of http.DefaultTransport is used when not set
+	RegistryTransport      *http.Transport
 	Platform               string
 	Exclusions             []string
 	Name                   string
 	DefaultImagePullSource string
 	Sources                []string
+	// SBOMCacheDir (optional) is the directory used to cache the cataloging results of container image layers between
+	// scans (keyed by the layer chain, so layers shared by different images such as base layers are cataloged once)
+	SBOMCacheDir string
+	// DeepJava fingerprints the class files of java archives, so that artifacts shaded or repackaged without their maven
+	// metadata can be identified (see ShadedJavaPackages). This reads every java archive in full, so is costly.
+	DeepJava bool
+	// IgnoreEmbeddedSBOM catalogs OCI artifacts even when the image carries SBOM attestations (e.g. built by docker
+	// buildx with --sbom), rather than using the embedded SBOM
+	IgnoreEmbeddedSBOM bool
+	// DirectoryScan controls the symbolic links and mount points followed when scanning a directory
+	DirectoryScan DirectoryScanConfig
 }
 
 type SynthesisConfig struct {
 	GenerateMissingCPEs bool
-	Distro              DistroConfig
+	// CPECacheDir (optional) is the directory used to persist generated CPEs between scans
+	CPECacheDir string
+	// GenerateBinaryCPEs generates CPEs for binary packages without any (regardless of GenerateMissingCPEs), so that
+	// binaries identified by embedded version strings can be matched
+	GenerateBinaryCPEs bool
+	Distro             DistroConfig
 }
 
 type DistroConfig struct {
 	Override    *distro.Distro
 	FixChannels []distro.FixChannel
+	// Aliases map the IDs of custom distros to the distro their packages are matched as (applied to the override too)
+	Aliases distro.Aliases
 }
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
@@ -16,6 +17,10 @@ import (
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/cataloging"
 	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/format/syftjson"
+	"github.com/anchore/syft/syft/linux"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
 )
 
 func TestProviderLocationExcludes(t *testing.T) {
@@ -133,6 +138,34 @@ func TestProvideFromReader(t *testing.T) {
 	}
 }
 
+func TestProvideFromReader_distroQualifier(t *testing.T) {
+	libc := syftPkg.Package{Name: "libc6", Version: "2.36-9", Type: syftPkg.DebPkg, PURL: "pkg:deb/debian/libc6@2.36-9?distro=debian-12"}
+	openssl := syftPkg.Package{Name: "openssl", Version: "1.1.1n-0", Type: syftPkg.DebPkg, PURL: "pkg:deb/debian/openssl@1.1.1n-0?distro=debian-11"}
+	libc.SetID()
+	openssl.SetID()
+
+	// a merged SBOM of debian 12, holding a package of debian 11
+	var buf bytes.Buffer
+	require.NoError(t, syftjson.NewFormatEncoder().Encode(&buf, sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			Packages:          syftPkg.NewCollection(libc, openssl),
+			LinuxDistribution: &linux.Release{ID: "debian", VersionID: "12", VersionCodename: "bookworm"},
+		},
+	}))
+
+	pkgs, ctx, _, err := ProvideFromReader(bytes.NewReader(buf.Bytes()), ProviderConfig{})
+	require.NoError(t, err)
+	require.NotNil(t, ctx.Distro)
+	assert.Equal(t, "12", ctx.Distro.Version)
+
+	distros := make(map[string]string)
+	for _, p := range pkgs {
+		require.NotNil(t, p.Distro, p.Name)
+		distros[p.Name] = p.Distro.String()
+	}
+	assert.Equal(t, map[string]string{"libc6": "debian 12", "openssl": "debian 11"}, distros)
+}
+
 func TestSyftLocationExcludes(t *testing.T) {
 	tests := []struct {
 		name     string
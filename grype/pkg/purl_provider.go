@@ -9,6 +9,7 @@ import (
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/format"
+	"github.com/anchore/syft/syft/format/syftjson"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
@@ -23,8 +24,19 @@ type PURLLiteralMetadata struct {
 	PURL string
 }
 
-func purlEnhancers(applyChannel func(*distro.Distro) bool) []Enhancer {
-	return []Enhancer{setUpstreamsFromPURL, setDistroFromPURL(applyChannel)}
+func purlEnhancers(applyChannel func(*distro.Distro) bool, aliases distro.Aliases) []Enhancer {
+	return []Enhancer{setUpstreamsFromPURL, setDistroFromPURL(applyChannel, aliases)}
+}
+
+// sbomEnhancers returns the enhancers for the packages of an SBOM of the given format. Syft JSON retains the upstream
+// packages in the package metadata, but not the distro of each package: the distro PURL qualifier is used for all
+// formats, so that the packages of another distro than the SBOM (e.g. in merged SBOMs) are not matched against the
+// advisories of the SBOM distro.
+func sbomEnhancers(fmtID sbom.FormatID, applyChannel func(*distro.Distro) bool, aliases distro.Aliases) []Enhancer {
+	if fmtID == syftjson.ID {
+		return []Enhancer{setDistroFromPURL(applyChannel, aliases)}
+	}
+	return purlEnhancers(applyChannel, aliases)
 }
 
 func purlProvider(userInput string, config ProviderConfig, applyChannel func(*distro.Distro) bool) ([]*Package, Context, *sbom.SBOM, error) {
@@ -38,7 +50,7 @@ func purlProvider(userInput string, config ProviderConfig, applyChannel func(*di
 		return nil, Context{}, nil, fmt.Errorf("unable to decode purl: %w", err)
 	}
 
-	return FromCollection(s.Artifacts.Packages, s.Relationships, config.SynthesisConfig, purlEnhancers(applyChannel)...), ctx, s, nil
+	return FromCollection(s.Artifacts.Packages, s.Relationships, config.SynthesisConfig, purlEnhancers(applyChannel, config.Distro.Aliases)...), ctx, s, nil
 }
 
 func getPurlReader(userInput string) (r io.Reader, ctx Context, err error) {
@@ -74,10 +86,10 @@ func upstreamsFromPURL(purl packageurl.PackageURL, pkgType syftPkg.Type) (upstre
 	return upstreams
 }
 
-func setDistroFromPURL(applyChannel func(*distro.Distro) bool) func(out *Package, purl packageurl.PackageURL, _ syftPkg.Package) {
+func setDistroFromPURL(applyChannel func(*distro.Distro) bool, aliases distro.Aliases) func(out *Package, purl packageurl.PackageURL, _ syftPkg.Package) {
 	return func(out *Package, purl packageurl.PackageURL, _ syftPkg.Package) {
 		if out.Distro == nil {
-			out.Distro = distroFromPURL(purl)
+			out.Distro = aliases.Apply(distroFromPURL(purl))
 			applyChannel(out.Distro)
 		}
 	}
@@ -198,7 +198,8 @@ func Test_PurlProvider(t *testing.T) {
 					PURL:    "pkg:rpm/redhat/dbus-common@1.12.8-26.el8?arch=noarch&distro=rhel-8.10&epoch=1&upstream=dbus-1.12.8-26.el8.src.rpm",
 					Distro:  &distro.Distro{Type: distro.RedHat, Version: "8.10", Codename: "", IDLike: []string{"redhat"}},
 					Metadata: RpmMetadata{
-						Arch: "noarch",
+						Epoch: intRef(1),
+						Arch:  "noarch",
 					},
 					Upstreams: []UpstreamPackage{
 						{
@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"strconv"
+
+	"github.com/anchore/packageurl-go"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// PURLQualifier returns the value of the given qualifier from the package URL (e.g. syftPkg.PURLQualifierArch),
+// or an empty string if the package has no (valid) PURL or the qualifier is not present.
+func PURLQualifier(p Package, key string) string {
+	return purlQualifier(p.PURL, key)
+}
+
+func purlQualifier(purl, key string) string {
+	if purl == "" {
+		return ""
+	}
+	parsed, err := packageurl.FromString(purl)
+	if err != nil {
+		return ""
+	}
+	return parsed.Qualifiers.Map()[key]
+}
+
+// rpmMetadataFromPURL fills in RPM metadata that is missing from the cataloged package (e.g. a package read from an
+// SBOM that only has a PURL) from the arch and epoch PURL qualifiers.
+func rpmMetadataFromPURL(purl string, existing *RpmMetadata) *RpmMetadata {
+	arch := purlQualifier(purl, syftPkg.PURLQualifierArch)
+	var epoch *int
+	if e := purlQualifier(purl, syftPkg.PURLQualifierEpoch); e != "" {
+		if v, err := strconv.Atoi(e); err == nil {
+			epoch = &v
+		}
+	}
+
+	if existing == nil {
+		if arch == "" && epoch == nil {
+			return nil
+		}
+		return &RpmMetadata{Arch: arch, Epoch: epoch}
+	}
+
+	out := *existing
+	if out.Arch == "" {
+		out.Arch = arch
+	}
+	if out.Epoch == nil {
+		out.Epoch = epoch
+	}
+	return &out
+}
@@ -0,0 +1,124 @@
+package pkg
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+type PythonMetadata struct {
+	// Extras are the optional features of the package (e.g. "socks" for "requests[socks]") known to be installed.
+	Extras []string `json:"extras,omitempty"`
+}
+
+var (
+	// pythonNormalizePattern is used to normalize python package names and extras (see https://peps.python.org/pep-0503/#normalized-names)
+	pythonNormalizePattern = regexp.MustCompile(`[-_.]+`)
+
+	// pythonRequirementNamePattern captures the distribution name of a requirement (e.g. "PySocks" from "PySocks!=1.5.7,>=1.5.6; extra == 'socks'")
+	pythonRequirementNamePattern = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+	// pythonExtraMarkerPattern captures the extra a requirement belongs to from its environment marker
+	pythonExtraMarkerPattern = regexp.MustCompile(`extra\s*==\s*["']([^"']+)["']`)
+)
+
+func normalizePythonName(name string) string {
+	return pythonNormalizePattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
+
+// pythonMetadataFromPkg returns the extras explicitly requested for a python package, as found in requirements files
+// (e.g. "requests[socks]==2.31.0") and PDM lock files.
+func pythonMetadataFromPkg(p syftPkg.Package) *PythonMetadata {
+	var extras []string
+	switch m := p.Metadata.(type) {
+	case syftPkg.PythonRequirementsEntry:
+		extras = m.Extras
+	case syftPkg.PythonPdmLockEntry:
+		for _, variant := range m.Extras {
+			extras = append(extras, variant.Extras...)
+		}
+	}
+	if len(extras) == 0 {
+		return nil
+	}
+	return &PythonMetadata{Extras: normalizePythonExtras(extras)}
+}
+
+// addInstalledPythonExtras records the extras of installed python packages which are satisfied by the other
+// cataloged python packages. Installed package metadata does not record which extras were requested at install time,
+// so an extra is considered installed when every distribution it requires is present.
+func addInstalledPythonExtras(syftPkgs []syftPkg.Package, pkgByID map[ID]*Package) {
+	installed := strset.New()
+	for _, p := range syftPkgs {
+		if p.Type == syftPkg.PythonPkg {
+			installed.Add(normalizePythonName(p.Name))
+		}
+	}
+
+	for _, p := range syftPkgs {
+		m, ok := p.Metadata.(syftPkg.PythonPackage)
+		if !ok || len(m.ProvidesExtra) == 0 {
+			continue
+		}
+		extras := installedPythonExtras(m, installed)
+		if len(extras) == 0 {
+			continue
+		}
+		grypePkg, ok := pkgByID[grypeID(p)]
+		if !ok || grypePkg.Metadata != nil {
+			continue
+		}
+		grypePkg.Metadata = PythonMetadata{Extras: extras}
+	}
+}
+
+func installedPythonExtras(m syftPkg.PythonPackage, installed *strset.Set) []string {
+	requires := map[string][]string{}
+	for _, req := range m.RequiresDist {
+		spec, marker, ok := strings.Cut(req, ";")
+		if !ok {
+			continue
+		}
+		extra := pythonExtraMarkerPattern.FindStringSubmatch(marker)
+		name := pythonRequirementNamePattern.FindStringSubmatch(spec)
+		if extra == nil || name == nil {
+			continue
+		}
+		key := normalizePythonName(extra[1])
+		requires[key] = append(requires[key], normalizePythonName(name[1]))
+	}
+
+	var extras []string
+	for _, extra := range normalizePythonExtras(m.ProvidesExtra) {
+		names := requires[extra]
+		if len(names) > 0 && installed.Has(names...) {
+			extras = append(extras, extra)
+		}
+	}
+	return extras
+}
+
+func normalizePythonExtras(extras []string) []string {
+	s := strset.New()
+	for _, e := range extras {
+		if e = normalizePythonName(e); e != "" {
+			s.Add(e)
+		}
+	}
+	out := s.List()
+	sort.Strings(out)
+	return out
+}
+
+// PythonExtras returns the extras known to be installed for the given python package.
+func PythonExtras(p Package) []string {
+	if m, ok := p.Metadata.(PythonMetadata); ok {
+		return slices.Clone(m.Extras)
+	}
+	return nil
+}
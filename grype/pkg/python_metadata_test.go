@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestFromPackages_PythonExtras(t *testing.T) {
+	requests := syftPkg.Package{
+		Name:    "requests",
+		Version: "2.25.0",
+		Type:    syftPkg.PythonPkg,
+		Metadata: syftPkg.PythonPackage{
+			Name:          "requests",
+			ProvidesExtra: []string{"security", "socks", "use_chardet_on_py3"},
+			RequiresDist: []string{
+				"idna<3,>=2.5",
+				`PySocks!=1.5.7,>=1.5.6; extra == "socks"`,
+				`chardet<5,>=3.0.2; extra == "use_chardet_on_py3"`,
+			},
+		},
+	}
+	pysocks := syftPkg.Package{
+		Name:     "PySocks",
+		Version:  "1.7.1",
+		Type:     syftPkg.PythonPkg,
+		Metadata: syftPkg.PythonPackage{Name: "PySocks"},
+	}
+	urllib3 := syftPkg.Package{
+		Name:    "urllib3",
+		Version: "1.26.0",
+		Type:    syftPkg.PythonPkg,
+		Metadata: syftPkg.PythonRequirementsEntry{
+			Name:   "urllib3",
+			Extras: []string{"SOCKS", "brotli"},
+		},
+	}
+	for _, p := range []*syftPkg.Package{&requests, &pysocks, &urllib3} {
+		p.SetID()
+	}
+
+	pkgs := FromPackages([]syftPkg.Package{requests, pysocks, urllib3}, nil, SynthesisConfig{})
+
+	extras := map[string][]string{}
+	for _, p := range pkgs {
+		extras[p.Name] = PythonExtras(*p)
+	}
+
+	assert.Equal(t, map[string][]string{
+		// only the extra with all of its requirements installed ("security" requires nothing, so is unknown)
+		"requests": {"socks"},
+		"PySocks":  nil,
+		// extras requested explicitly are taken as-is
+		"urllib3": {"brotli", "socks"},
+	}, extras)
+}
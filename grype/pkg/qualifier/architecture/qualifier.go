@@ -1,8 +1,11 @@
 package architecture
 
 import (
+	"fmt"
+
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/pkg/qualifier"
+	syftPkg "github.com/anchore/syft/syft/pkg"
 )
 
 // Arch values stored on a vulnerability entry. The literal "src" matches the CSAF wire
@@ -140,18 +143,22 @@ func isArchIndependent(a string) bool {
 	return a == archNoarch || a == archAll
 }
 
-// packageArch reads the architecture off the package's metadata contract. Only RPM metadata
-// carries an arch today; any other (or absent) metadata reports "", which Satisfied treats as
-// inert. The architecture qualifier is only emitted on rpm/CSAF entries, so it is only ever
-// evaluated against rpm packages in practice.
+// packageArch reads the architecture off the package's metadata contract (RPM and APK metadata
+// carry an arch), falling back to the "arch" PURL qualifier for packages whose metadata does not
+// (e.g. deb packages, or any package read from an SBOM that only has a PURL). A package with
+// neither reports "", which Satisfied treats as inert.
 func packageArch(p pkg.Package) string {
 	switch m := p.Metadata.(type) {
 	case pkg.RpmMetadata:
-		return m.Arch
+		if m.Arch != "" {
+			return m.Arch
+		}
 	case pkg.ApkMetadata:
-		return m.Arch
+		if m.Arch != "" {
+			return m.Arch
+		}
 	}
-	return ""
+	return pkg.PURLQualifier(p, syftPkg.PURLQualifierArch)
 }
 
 // canonicalArch folds an architecture string onto one token per CPU architecture so matching
@@ -203,3 +210,24 @@ func DefaultAliases() map[string]string {
 	}
 	return out
 }
+
+// Describe reports the entry's arch and the package arch it was evaluated against (e.g. "arch=x86_64 (package: amd64)").
+func (r architectureQualifier) Describe(p pkg.Package) string {
+	return describe(r.arch, p)
+}
+
+// Describe reports the entry's arch and the package arch it was evaluated against.
+func (r unaffectedArchitectureQualifier) Describe(p pkg.Package) string {
+	return describe(r.arch, p)
+}
+
+func describe(recordArch string, p pkg.Package) string {
+	if recordArch == "" {
+		return ""
+	}
+	pkgArch := packageArch(p)
+	if pkgArch == "" {
+		pkgArch = "unknown"
+	}
+	return fmt.Sprintf("arch=%s (package: %s)", recordArch, pkgArch)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/pkg/qualifier"
 )
 
 // TestArchitecture_Satisfied pins down the per-package gating logic for the AFFECTED qualifier:
@@ -327,6 +328,53 @@ func TestArchitecture_Satisfied_NonRpmMetadataInert(t *testing.T) {
 	}
 }
 
+// TestArchitecture_Satisfied_PURLArch pins that a package without an arch in its metadata (e.g. a
+// deb package, or any package read from an SBOM with only a PURL) is gated on the "arch" PURL
+// qualifier, so arch-scoped entries no longer match other architectures.
+func TestArchitecture_Satisfied_PURLArch(t *testing.T) {
+	tests := []struct {
+		name string
+		p    pkg.Package
+		want bool
+	}{
+		{
+			name: "deb package with matching purl arch (cross-dialect)",
+			p:    pkg.Package{Name: "openssl", PURL: "pkg:deb/debian/openssl@3.0.11-1?arch=amd64&distro=debian-12"},
+			want: true,
+		},
+		{
+			name: "deb package with other purl arch",
+			p:    pkg.Package{Name: "openssl", PURL: "pkg:deb/debian/openssl@3.0.11-1?arch=arm64&distro=debian-12"},
+			want: false,
+		},
+		{
+			name: "metadata arch takes precedence over purl arch",
+			p:    pkg.Package{Name: "openssl", Metadata: pkg.RpmMetadata{Arch: "x86_64"}, PURL: "pkg:rpm/redhat/openssl@3.0.7?arch=aarch64"},
+			want: true,
+		},
+		{
+			name: "purl without arch is inert",
+			p:    pkg.Package{Name: "openssl", PURL: "pkg:deb/debian/openssl@3.0.11-1"},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New("x86_64", nil).Satisfied(tt.p)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestArchitecture_Describe(t *testing.T) {
+	q, ok := New("x86_64", nil).(qualifier.Describer)
+	require.True(t, ok)
+	require.Equal(t, "arch=x86_64 (package: aarch64)", q.Describe(pkg.Package{Metadata: pkg.RpmMetadata{Arch: "aarch64"}}))
+	require.Equal(t, "arch=x86_64 (package: unknown)", q.Describe(pkg.Package{}))
+	require.Empty(t, New("", nil).(qualifier.Describer).Describe(pkg.Package{}))
+}
+
 // TestArchitecture_Satisfied_DataDrivenAliases pins that the DB-supplied alias table — not the
 // built-in defaults — drives canonicalization when present. A non-empty table is trusted
 // exclusively, so a default fold absent from it no longer applies.
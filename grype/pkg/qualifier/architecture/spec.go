@@ -0,0 +1,43 @@
+package architecture
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anchore/grype/grype/pkg/qualifier"
+)
+
+const (
+	// Kind identifies the architecture qualifier of AFFECTED entries in a qualifier.Spec
+	Kind = "architecture"
+	// UnaffectedKind identifies the architecture qualifier of UNAFFECTED entries in a qualifier.Spec
+	UnaffectedKind = "unaffected-architecture"
+)
+
+type spec struct {
+	Arch    string            `json:"arch"`
+	Aliases map[string]string `json:"aliases,omitempty"`
+}
+
+func (r architectureQualifier) Spec() (qualifier.Spec, error) {
+	return qualifier.NewSpec(Kind, spec{Arch: r.arch, Aliases: r.aliases})
+}
+
+func (r unaffectedArchitectureQualifier) Spec() (qualifier.Spec, error) {
+	return qualifier.NewSpec(UnaffectedKind, spec{Arch: r.arch, Aliases: r.aliases})
+}
+
+// FromSpec restores an architecture qualifier from its qualifier.Spec.
+func FromSpec(s qualifier.Spec) (qualifier.Qualifier, error) {
+	var data spec
+	if err := json.Unmarshal(s.Data, &data); err != nil {
+		return nil, fmt.Errorf("invalid %s qualifier: %w", s.Kind, err)
+	}
+	switch s.Kind {
+	case Kind:
+		return New(data.Arch, data.Aliases), nil
+	case UnaffectedKind:
+		return NewUnaffected(data.Arch, data.Aliases), nil
+	}
+	return nil, fmt.Errorf("not an architecture qualifier: %q", s.Kind)
+}
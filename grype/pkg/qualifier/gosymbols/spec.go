@@ -0,0 +1,24 @@
+package gosymbols
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anchore/grype/grype/pkg/qualifier"
+)
+
+// Kind identifies the go symbols qualifier in a qualifier.Spec
+const Kind = "go-symbols"
+
+func (q *gosymbolsQualifier) Spec() (qualifier.Spec, error) {
+	return qualifier.NewSpec(Kind, q.imports)
+}
+
+// FromSpec restores a go symbols qualifier from its qualifier.Spec.
+func FromSpec(s qualifier.Spec) (qualifier.Qualifier, error) {
+	var imports []Import
+	if err := json.Unmarshal(s.Data, &imports); err != nil {
+		return nil, fmt.Errorf("invalid %s qualifier: %w", s.Kind, err)
+	}
+	return New(imports), nil
+}
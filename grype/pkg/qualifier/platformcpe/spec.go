@@ -0,0 +1,24 @@
+package platformcpe
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anchore/grype/grype/pkg/qualifier"
+)
+
+// Kind identifies the platform CPE qualifier in a qualifier.Spec
+const Kind = "platform-cpe"
+
+func (p platformCPE) Spec() (qualifier.Spec, error) {
+	return qualifier.NewSpec(Kind, p.cpe)
+}
+
+// FromSpec restores a platform CPE qualifier from its qualifier.Spec.
+func FromSpec(s qualifier.Spec) (qualifier.Qualifier, error) {
+	var c string
+	if err := json.Unmarshal(s.Data, &c); err != nil {
+		return nil, fmt.Errorf("invalid %s qualifier: %w", s.Kind, err)
+	}
+	return New(c), nil
+}
@@ -1,9 +1,57 @@
 package qualifier
 
 import (
+	"encoding/json"
+
 	"github.com/anchore/grype/grype/pkg"
 )
 
 type Qualifier interface {
 	Satisfied(p pkg.Package) (bool, error)
 }
+
+// Describer is implemented by qualifiers that can describe how they were evaluated against a package. The
+// description is recorded in the match details so that it is clear why a qualified vulnerability applies.
+type Describer interface {
+	Describe(p pkg.Package) string
+}
+
+// Describe returns the descriptions of the given qualifiers (that support it) evaluated against the package.
+func Describe(qualifiers []Qualifier, p pkg.Package) []string {
+	var out []string
+	for _, q := range qualifiers {
+		d, ok := q.(Describer)
+		if !ok {
+			continue
+		}
+		if desc := d.Describe(p); desc != "" {
+			out = append(out, desc)
+		}
+	}
+	return out
+}
+
+// Spec is the serializable form of a qualifier, allowing qualifiers to be persisted outside of the vulnerability DB
+// (e.g. when recording DB interactions). Kind identifies the qualifier implementation and Data holds its parameters;
+// each implementation package provides a FromSpec function to restore the qualifier.
+type Spec struct {
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// Specifier is implemented by qualifiers that can be serialized (see Spec).
+type Specifier interface {
+	Spec() (Spec, error)
+}
+
+// NewSpec creates the Spec of the given kind, holding the given parameters (if any).
+func NewSpec(kind string, data any) (Spec, error) {
+	if data == nil {
+		return Spec{Kind: kind}, nil
+	}
+	by, err := json.Marshal(data)
+	if err != nil {
+		return Spec{}, err
+	}
+	return Spec{Kind: kind, Data: by}, nil
+}
@@ -0,0 +1,17 @@
+package rootio
+
+import (
+	"github.com/anchore/grype/grype/pkg/qualifier"
+)
+
+// Kind identifies the rootio qualifier in a qualifier.Spec
+const Kind = "rootio"
+
+func (rootIO) Spec() (qualifier.Spec, error) {
+	return qualifier.NewSpec(Kind, nil)
+}
+
+// FromSpec restores the rootio qualifier from its qualifier.Spec (which has no parameters).
+func FromSpec(qualifier.Spec) (qualifier.Qualifier, error) {
+	return New(), nil
+}
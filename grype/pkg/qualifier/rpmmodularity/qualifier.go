@@ -1,6 +1,7 @@
 package rpmmodularity
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/anchore/grype/grype/distro"
@@ -60,3 +61,12 @@ func (r rpmModularity) Satisfied(p pkg.Package) (bool, error) {
 
 	return strings.HasPrefix(*m.ModularityLabel, r.module), nil
 }
+
+// Describe reports the entry's module and the package modularity label it was evaluated against.
+func (r rpmModularity) Describe(p pkg.Package) string {
+	label := "unknown"
+	if m, ok := p.Metadata.(pkg.RpmMetadata); ok && m.ModularityLabel != nil {
+		label = *m.ModularityLabel
+	}
+	return fmt.Sprintf("rpm-modularity=%s (package: %s)", r.module, label)
+}
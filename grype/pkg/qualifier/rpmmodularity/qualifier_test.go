@@ -154,6 +154,13 @@ func TestRpmModularity_Satisfied(t *testing.T) {
 	}
 }
 
+func TestRpmModularity_Describe(t *testing.T) {
+	q := New("nodejs:18").(qualifier.Describer)
+	assert.Equal(t, "rpm-modularity=nodejs:18 (package: nodejs:20:9040020240131162437:rhel9)",
+		q.Describe(pkg.Package{Metadata: pkg.RpmMetadata{ModularityLabel: strRef("nodejs:20:9040020240131162437:rhel9")}}))
+	assert.Equal(t, "rpm-modularity=nodejs:18 (package: unknown)", q.Describe(pkg.Package{}))
+}
+
 func strRef(s string) *string {
 	return &s
 }
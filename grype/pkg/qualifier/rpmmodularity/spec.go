@@ -0,0 +1,24 @@
+package rpmmodularity
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anchore/grype/grype/pkg/qualifier"
+)
+
+// Kind identifies the rpm modularity qualifier in a qualifier.Spec
+const Kind = "rpm-modularity"
+
+func (r rpmModularity) Spec() (qualifier.Spec, error) {
+	return qualifier.NewSpec(Kind, r.module)
+}
+
+// FromSpec restores an rpm modularity qualifier from its qualifier.Spec.
+func FromSpec(s qualifier.Spec) (qualifier.Qualifier, error) {
+	var module string
+	if err := json.Unmarshal(s.Data, &module); err != nil {
+		return nil, fmt.Errorf("invalid %s qualifier: %w", s.Kind, err)
+	}
+	return New(module), nil
+}
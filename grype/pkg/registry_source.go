@@ -0,0 +1,184 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/anchore/go-collections"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/stereoscope/pkg/file"
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/stereoscope/pkg/image/oci"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/source"
+	"github.com/anchore/syft/syft/source/sourceproviders"
+	"github.com/anchore/syft/syft/source/stereoscopesource"
+)
+
+// getSourceWithRegistryTransport resolves the user input as syft.GetSource does, pulling images from registries with
+// the given transport rather than the default transport (which the registry provider of stereoscope always uses).
+func getSourceWithRegistryTransport(ctx context.Context, userInput string, cfg *syft.GetSourceConfig, transport *http.Transport) (source.Source, error) {
+	providers, err := sourceProviders(userInput, cfg, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, p := range providers {
+		src, err := p.Provide(ctx)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+			}
+			continue
+		}
+		if src != nil {
+			return src, nil
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil, fmt.Errorf("no source providers were able to resolve the input %q", userInput)
+	case 1:
+		return nil, fmt.Errorf("an error occurred attempting to resolve '%s': %w", userInput, errs[0])
+	}
+	return nil, fmt.Errorf("errors occurred attempting to resolve '%s': %w", userInput, errors.Join(errs...))
+}
+
+// sourceProviders returns the source providers of syft.GetSource (in the same order), with the registry image provider
+// replaced by one using the given transport.
+func sourceProviders(userInput string, cfg *syft.GetSourceConfig, transport *http.Transport) ([]source.Provider, error) {
+	providerCfg := cfg.SourceProviderConfig
+	var providers collections.TaggedValueSet[source.Provider]
+	for _, p := range sourceproviders.All(userInput, providerCfg) {
+		if p.Value.Name() == string(oci.Registry) && p.HasTag(sourceproviders.PullTag) {
+			p.Value = registrySourceProvider{
+				reference: userInput,
+				platform:  providerCfg.Platform,
+				registry:  providerCfg.RegistryOptions,
+				transport: transport,
+				exclude:   providerCfg.Exclude,
+				alias:     providerCfg.Alias,
+			}
+		}
+		providers = append(providers, p)
+	}
+
+	if cfg.DefaultImagePullSource != "" {
+		base := providers.Remove(sourceproviders.PullTag)
+		pull := providers.Select(sourceproviders.PullTag)
+		def := pull.Select(cfg.DefaultImagePullSource)
+		if len(def) == 0 {
+			return nil, fmt.Errorf("invalid DefaultImagePullSource: %s; available values are: %v", cfg.DefaultImagePullSource, pull.Tags())
+		}
+		providers = base.Join(def...).Join(pull...)
+	}
+
+	if len(cfg.Sources) > 0 {
+		providers = providers.Select(cfg.Sources...)
+	}
+	return providers.Values(), nil
+}
+
+// registrySourceProvider pulls container images from a registry with a given transport.
+type registrySourceProvider struct {
+	reference string
+	platform  *image.Platform
+	registry  *image.RegistryOptions
+	transport *http.Transport
+	exclude   source.ExcludeConfig
+	alias     source.Alias
+}
+
+func (p registrySourceProvider) Name() string {
+	return string(oci.Registry)
+}
+
+func (p registrySourceProvider) Provide(ctx context.Context) (source.Source, error) {
+	config := ProviderConfig{SyftProviderConfig: SyftProviderConfig{RegistryOptions: p.registry, RegistryTransport: p.transport}}
+
+	var nameOptions []name.Option
+	if p.registry != nil && p.registry.InsecureUseHTTP {
+		nameOptions = append(nameOptions, name.Insecure)
+	}
+	ref, err := name.ParseReference(p.reference, nameOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse registry reference=%q: %w", p.reference, err)
+	}
+
+	options, err := registryOptions(ref.Context().RegistryStr(), config)
+	if err != nil {
+		return nil, err
+	}
+	// as stereoscope does, multi-platform images default to the platform of the host (rather than linux/amd64)
+	platform := v1.Platform{OS: "linux", Architecture: runtime.GOARCH}
+	if p.platform != nil {
+		platform = v1.Platform{OS: p.platform.OS, Architecture: p.platform.Architecture, Variant: p.platform.Variant}
+	}
+	options = append(options, remote.WithContext(ctx), remote.WithUserAgent(os.Args[0]), remote.WithPlatform(platform))
+
+	log.WithFields("image", p.reference).Debug("pulling image directly from registry")
+	descriptor, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image descriptor from registry: %w", err)
+	}
+
+	img, err := descriptor.Image()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image from registry: %w", err)
+	}
+	metadata, err := registryImageMetadata(ref, descriptor, img, p.platform)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDirGen := file.NewTempDirGenerator("grype-registry")
+	contentDir, err := tmpDirGen.NewDirectory("oci-registry-image")
+	if err != nil {
+		return nil, errors.Join(err, tmpDirGen.Cleanup())
+	}
+	out := image.New(img, tmpDirGen, contentDir, metadata...)
+	if err := out.Read(); err != nil {
+		return nil, errors.Join(err, out.Cleanup())
+	}
+
+	return stereoscopesource.New(out, stereoscopesource.ImageConfig{
+		Reference:       p.reference,
+		Platform:        p.platform,
+		RegistryOptions: p.registry,
+		Exclude:         p.exclude,
+		Alias:           p.alias,
+	}), nil
+}
+
+// registryImageMetadata returns the metadata of the pulled image, verifying that the image is of the requested
+// platform (if any).
+func registryImageMetadata(ref name.Reference, descriptor *remote.Descriptor, img v1.Image, platform *image.Platform) ([]image.AdditionalMetadata, error) {
+	c, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image config from registry: %w", err)
+	}
+	if platform != nil && (c.OS != platform.OS || c.Architecture != platform.Architecture || (platform.Variant != "" && c.Variant != platform.Variant)) {
+		return nil, fmt.Errorf("image platform=\"%s/%s\" does not match user specified platform=%q", c.OS, c.Architecture, platform.String())
+	}
+
+	metadata := []image.AdditionalMetadata{
+		image.WithRepoDigests(fmt.Sprintf("%s@%s", ref.Context().Name(), descriptor.Digest)),
+		image.WithArchitecture(c.Architecture, c.Variant),
+		image.WithOS(c.OS),
+	}
+	// best effort, the manifest is not required to catalog the image
+	if manifest, err := img.RawManifest(); err == nil {
+		metadata = append(metadata, image.WithManifest(manifest))
+	}
+	return metadata, nil
+}
@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestSyftProvider_RegistryTransport(t *testing.T) {
+	artifact := newTestArtifact(t, nil)
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	ref := artifact.push(t, strings.TrimPrefix(server.URL, "http://")+"/app")
+
+	// the proxy function of the transport sees every registry request (connecting directly)
+	var mu sync.Mutex
+	var paths []string
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, req.URL.Path)
+		return nil, nil
+	}
+
+	cfg := testEmbeddedSBOMConfig()
+	cfg.RegistryTransport = transport
+	cfg.IgnoreEmbeddedSBOM = true
+	packages, ctx, _, err := Provide("registry:"+ref, cfg)
+	require.NoError(t, err)
+
+	assert.Empty(t, packages)
+	metadata, ok := ctx.Source.Metadata.(source.ImageMetadata)
+	require.True(t, ok)
+	assert.Equal(t, artifact.image.Digest.String(), metadata.ManifestDigest)
+	assert.True(t, slices.ContainsFunc(paths, func(p string) bool {
+		return strings.HasPrefix(p, "/v2/app/blobs/")
+	}), "the image was not pulled with the registry transport: %v", paths)
+}
@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"io"
+
+	spdxjsonreader "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/spdx/tools-golang/tagvalue"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/format/spdxjson"
+	"github.com/anchore/syft/syft/format/spdxtagvalue"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// readSPDXRelationships returns the package relationships of an SPDX SBOM that are not decoded by syft (none for other
+// formats). Syft already decodes DEPENDS_ON, DEPENDENCY_OF and CONTAINS relationships, but not CONTAINED_BY ones, which
+// are needed to know which packages are nested within other packages.
+func readSPDXRelationships(reader io.ReadSeeker, fmtID sbom.FormatID, s *sbom.SBOM) []artifact.Relationship {
+	var read func(io.Reader) (*spdx.Document, error)
+	switch fmtID {
+	case spdxjson.ID:
+		read = spdxjsonreader.Read
+	case spdxtagvalue.ID:
+		read = tagvalue.Read
+	default:
+		return nil
+	}
+
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		log.WithFields("error", err).Debug("unable to read SPDX relationships")
+		return nil
+	}
+	doc, err := read(reader)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to read SPDX relationships")
+		return nil
+	}
+
+	var out []artifact.Relationship
+	for _, r := range doc.Relationships {
+		if r == nil || r.Relationship != common.TypeRelationshipContainedBy {
+			continue
+		}
+		if r.RefA.DocumentRefID != "" || r.RefB.DocumentRefID != "" {
+			// relationships to elements of external documents cannot be resolved
+			continue
+		}
+		contained := s.Artifacts.Packages.Package(artifact.ID(r.RefA.ElementRefID))
+		container := s.Artifacts.Packages.Package(artifact.ID(r.RefB.ElementRefID))
+		if contained == nil || container == nil {
+			continue
+		}
+		out = append(out, artifact.Relationship{
+			From: *container,
+			To:   *contained,
+			Type: artifact.ContainsRelationship,
+		})
+	}
+	return out
+}
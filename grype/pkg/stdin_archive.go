@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/source"
+)
+
+// stdinInput is the user input (e.g. "docker-archive:-") indicating that an image archive is read from stdin.
+const stdinInput = "-"
+
+// stdinArchiveName is the name given to images read from stdin when no --name is provided.
+const stdinArchiveName = "stdin"
+
+// stdinArchiveSources are the image sources that may be read from stdin.
+var stdinArchiveSources = []string{"docker-archive", "oci-archive"}
+
+// isStdinArchive indicates if the user input (with any scheme already extracted into the sources) is an image archive
+// to read from stdin, e.g. "docker-archive:-" or "--from oci-archive -". Such archives are not streamed: the whole
+// archive is first spooled to disk (see spoolStdinArchive), so scanning needs as much free temp space as the archive.
+func isStdinArchive(userInput string, sources []string) bool {
+	return userInput == stdinInput && len(sources) == 1 && slices.Contains(stdinArchiveSources, sources[0])
+}
+
+// spoolStdinArchive copies an image archive read from the given reader into a private temporary directory, returning
+// the archive path and a function removing it. Image archives must be indexed and read out of order (e.g. the
+// manifest of a "docker save" archive is written after the layers it references), so they cannot be cataloged
+// straight from a stream.
+func spoolStdinArchive(r io.Reader) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "grype-stdin-archive-")
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create directory for image archive read from stdin: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.WithFields("error", err, "path", dir).Warn("unable to remove image archive read from stdin")
+		}
+	}
+
+	path := filepath.Join(dir, "image.tar")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("unable to create image archive read from stdin: %w", err)
+	}
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("unable to read image archive from stdin: %w", err)
+	}
+	if n == 0 {
+		cleanup()
+		return "", nil, fmt.Errorf("no image archive was provided via stdin")
+	}
+
+	log.WithFields("bytes", n).Debug("read image archive from stdin")
+	return path, cleanup, nil
+}
+
+// cleanupSource is a source that runs a cleanup function once closed.
+type cleanupSource struct {
+	source.Source
+	cleanup func()
+}
+
+func (s cleanupSource) Close() error {
+	defer s.cleanup()
+	return s.Source.Close()
+}
@@ -0,0 +1,119 @@
+package pkg
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Test_isStdinArchive(t *testing.T) {
+	tests := []struct {
+		input   string
+		sources []string
+		want    bool
+	}{
+		{input: "-", sources: []string{"docker-archive"}, want: true},
+		{input: "-", sources: []string{"oci-archive"}, want: true},
+		{input: "-", sources: []string{"oci-dir"}, want: false},
+		{input: "-", sources: []string{"docker-archive", "oci-archive"}, want: false},
+		{input: "-", want: false},
+		{input: "image.tar", sources: []string{"docker-archive"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input+"/"+strings.Join(tt.sources, ","), func(t *testing.T) {
+			assert.Equal(t, tt.want, isStdinArchive(tt.input, tt.sources))
+		})
+	}
+}
+
+func Test_spoolStdinArchive(t *testing.T) {
+	path, cleanup, err := spoolStdinArchive(strings.NewReader("archive contents"))
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "archive contents", string(contents))
+
+	cleanup()
+	assert.NoFileExists(t, path)
+
+	_, _, err = spoolStdinArchive(strings.NewReader(""))
+	require.ErrorContains(t, err, "no image archive was provided via stdin")
+}
+
+type closeRecorder struct {
+	source.Source
+	closed bool
+	err    error
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func Test_cleanupSource_Close(t *testing.T) {
+	src := &closeRecorder{err: errors.New("close failed")}
+	var cleaned bool
+	err := cleanupSource{Source: src, cleanup: func() { cleaned = true }}.Close()
+
+	require.ErrorContains(t, err, "close failed")
+	assert.True(t, src.closed)
+	assert.True(t, cleaned, "cleanup should run even when closing the source fails")
+}
+
+func Test_getSource_stdinArchive(t *testing.T) {
+	img, err := random.Image(256, 2)
+	require.NoError(t, err)
+	tag, err := name.NewTag("example.com/stdin-test:latest")
+	require.NoError(t, err)
+	var archive bytes.Buffer
+	require.NoError(t, tarball.Write(tag, img, &archive))
+
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = stdin })
+	go func() {
+		_, _ = w.Write(archive.Bytes())
+		_ = w.Close()
+	}()
+
+	cfg := ProviderConfig{
+		SyftProviderConfig: SyftProviderConfig{
+			SBOMOptions: syft.DefaultCreateSBOMConfig(),
+		},
+	}
+	src, err := getSource("docker-archive:-", cfg)
+	require.NoError(t, err)
+
+	spooled, ok := src.(cleanupSource)
+	require.True(t, ok, "expected the source to remove the spooled archive on close")
+	assert.Equal(t, stdinArchiveName, src.Describe().Name)
+
+	entries, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries, "expected the archive to be spooled to the temp dir")
+
+	require.NoError(t, spooled.Close())
+	entries, err = os.ReadDir(tmp)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasPrefix(e.Name(), "grype-stdin-archive-"), "spooled archive %q was not removed", e.Name())
+	}
+}
@@ -3,6 +3,9 @@ package pkg
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"slices"
 
 	"github.com/anchore/go-collections"
 	"github.com/anchore/grype/grype/distro"
@@ -10,6 +13,8 @@ import (
 	"github.com/anchore/stereoscope"
 	"github.com/anchore/stereoscope/pkg/image"
 	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/cataloging/pkgcataloging"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 	"github.com/anchore/syft/syft/source/sourceproviders"
@@ -22,7 +27,15 @@ func syftProvider(userInput string, config ProviderConfig, applyChannel func(*di
 	}
 	defer log.CloseAndLogError(src, "syft source")
 
-	s, err := syft.CreateSBOM(context.Background(), src, config.SBOMOptions)
+	srcDescription := src.Describe()
+
+	var classDigests *javaClassDigests
+	if config.DeepJava {
+		classDigests = newJavaClassDigests()
+		config = withJavaClassCataloger(config, classDigests)
+	}
+
+	s, err := createSBOM(src, srcDescription, config)
 	if err != nil {
 		return nil, Context{}, nil, err
 	}
@@ -31,30 +44,103 @@ func syftProvider(userInput string, config ProviderConfig, applyChannel func(*di
 		return nil, Context{}, nil, errors.New("no SBOM provided")
 	}
 
-	srcDescription := src.Describe()
-
-	d, distroDetectionFailed := distroFromSBOM(s, config, applyChannel)
+	d, osIdentification, distroDetectionFailed := distroFromSBOM(s, config, applyChannel)
 
 	packages := FromCollection(s.Artifacts.Packages, s.Relationships, config.SynthesisConfig)
+	if _, ok := srcDescription.Metadata.(source.ImageMetadata); ok && source.ParseScope(string(config.SBOMOptions.Search.Scope)) == source.AllLayersScope {
+		if resolver, err := src.FileResolver(source.SquashedScope); err == nil {
+			annotateIntermediateLayerPackages(packages, resolver)
+		} else {
+			log.WithFields("error", err).Debug("unable to resolve the squashed image, not identifying packages only in intermediate layers")
+		}
+	}
+	if _, ok := srcDescription.Metadata.(source.DirectoryMetadata); ok {
+		annotateAccessPaths(packages)
+	}
 	pkgCtx := Context{
 		Source:                &srcDescription,
 		Distro:                d,
+		OSIdentification:      osIdentification,
 		DistroDetectionFailed: distroDetectionFailed,
 	}
+	if classDigests != nil {
+		pkgCtx.JavaClassDigests = classDigests.all()
+	}
 
 	return packages, pkgCtx, s, nil
 }
 
-func distroFromSBOM(s *sbom.SBOM, config ProviderConfig, applyChannel func(*distro.Distro) bool) (d *distro.Distro, detectionFailed bool) {
+// withJavaClassCataloger returns the config with the java class fingerprinting cataloger added.
+func withJavaClassCataloger(config ProviderConfig, digests *javaClassDigests) ProviderConfig {
+	sbomOptions := *config.SBOMOptions
+	sbomOptions.WithCatalogers(pkgcataloging.NewAlwaysEnabledCatalogerReference(newJavaClassCataloger(digests)))
+	config.SBOMOptions = &sbomOptions
+	// cached SBOMs do not carry the class fingerprints
+	config.SBOMCacheDir = ""
+	return config
+}
+
+// createSBOM catalogs the given source. When the image SBOM cache is configured and the source is a container image,
+// each layer is cataloged on its own, reusing the cataloging result of any layer (chain) already cataloged for another
+// image, and the layer results are merged into the SBOM of the image.
+func createSBOM(src source.Source, srcDescription source.Description, config ProviderConfig) (*sbom.SBOM, error) {
+	cache := newImageSBOMCache(config.SBOMCacheDir)
+	if cache == nil {
+		return syft.CreateSBOM(context.Background(), src, config.SBOMOptions)
+	}
+
+	keys, cacheable := cache.layerKeys(srcDescription, config)
+	if !cacheable {
+		return syft.CreateSBOM(context.Background(), src, config.SBOMOptions)
+	}
+
+	var squashed file.Resolver
+	if source.ParseScope(string(config.SBOMOptions.Search.Scope)) != source.AllLayersScope {
+		var err error
+		squashed, err = src.FileResolver(source.SquashedScope)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve the squashed image: %w", err)
+		}
+	}
+
+	layers := srcDescription.Metadata.(source.ImageMetadata).Layers
+	var layerSBOMs []*sbom.SBOM
+	for i, layer := range layers {
+		s := cache.get(keys[i])
+		if s != nil {
+			log.WithFields("layer", layer.Digest, "key", keys[i]).Trace("using cached layer SBOM")
+		} else {
+			var err error
+			s, err = syft.CreateSBOM(context.Background(), layerSource{Source: src, digest: layer.Digest}, config.SBOMOptions)
+			if err != nil {
+				return nil, fmt.Errorf("unable to catalog layer %s: %w", layer.Digest, err)
+			}
+			if s == nil {
+				continue
+			}
+			cache.put(keys[i], s)
+		}
+		layerSBOMs = append(layerSBOMs, s)
+	}
+
+	s := mergeLayerSBOMs(layerSBOMs, squashed)
+	// cached layer SBOMs may have been created for another image sharing the layer
+	s.Source = srcDescription
+	return s, nil
+}
+
+// distroFromSBOM returns the distro of the SBOM (the override when given) along with how it was identified (nil for
+// the override, which is identified by the caller).
+func distroFromSBOM(s *sbom.SBOM, config ProviderConfig, applyChannel func(*distro.Distro) bool) (d *distro.Distro, id *distro.Identification, detectionFailed bool) {
 	if config.Distro.Override != nil {
 		d = config.Distro.Override
 	} else {
-		d = distro.FromRelease(s.Artifacts.LinuxDistribution, config.Distro.FixChannels)
+		d, id = distro.Identify(s.Artifacts.LinuxDistribution, config.Distro.Aliases, config.Distro.FixChannels)
 		applyChannel(d)
 		// detection failed if we had linux release info but couldn't determine distro type
 		detectionFailed = s.Artifacts.LinuxDistribution != nil && d == nil
 	}
-	return d, detectionFailed
+	return d, id, detectionFailed
 }
 
 func getSource(userInput string, config ProviderConfig) (source.Source, error) {
@@ -82,13 +168,59 @@ func getSource(userInput string, config ProviderConfig) (source.Source, error) {
 		}
 	}
 
-	return syft.GetSource(context.Background(), userInput, syft.DefaultGetSourceConfig().
+	exclusions, err := withDirectoryExclusions(userInput, config)
+	if err != nil {
+		return nil, err
+	}
+
+	name := config.Name
+	var cleanup func()
+	if isStdinArchive(userInput, sources) {
+		userInput, cleanup, err = spoolStdinArchive(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = stdinArchiveName
+		}
+	}
+
+	sourceConfig := syft.DefaultGetSourceConfig().
 		WithSources(sources...).
 		WithDefaultImagePullSource(config.DefaultImagePullSource).
-		WithAlias(source.Alias{Name: config.Name}).
+		WithAlias(source.Alias{Name: name}).
 		WithRegistryOptions(config.RegistryOptions).
 		WithPlatform(platform).
-		WithExcludeConfig(source.ExcludeConfig{Paths: config.Exclusions}))
+		WithExcludeConfig(source.ExcludeConfig{Paths: exclusions})
+
+	var src source.Source
+	if config.RegistryTransport != nil {
+		src, err = getSourceWithRegistryTransport(context.Background(), userInput, sourceConfig, config.RegistryTransport)
+	} else {
+		src, err = syft.GetSource(context.Background(), userInput, sourceConfig)
+	}
+	if cleanup == nil {
+		return src, err
+	}
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	return cleanupSource{Source: src, cleanup: cleanup}, nil
+}
+
+// withDirectoryExclusions returns the configured exclusions along with the exclusions of the symbolic links and mount
+// points not to scan when the user input is a directory (see DirectoryScanConfig).
+func withDirectoryExclusions(userInput string, config ProviderConfig) ([]string, error) {
+	info, err := os.Stat(userInput)
+	if err != nil || !info.IsDir() {
+		return config.Exclusions, nil
+	}
+	exclusions, err := directoryExclusions(userInput, config.DirectoryScan)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply the directory scan policies: %w", err)
+	}
+	return append(slices.Clone(config.Exclusions), exclusions...), nil
 }
 
 func allSourceTags() []string {
@@ -15,7 +15,6 @@ import (
 	"github.com/anchore/grype/internal"
 	"github.com/anchore/grype/internal/log"
 	"github.com/anchore/syft/syft/format"
-	"github.com/anchore/syft/syft/format/syftjson"
 	"github.com/anchore/syft/syft/sbom"
 )
 
@@ -24,7 +23,7 @@ type SBOMFileMetadata struct {
 }
 
 func syftSBOMProvider(userInput string, config ProviderConfig, applyChannel func(*distro.Distro) bool) ([]*Package, Context, *sbom.SBOM, error) {
-	s, fmtID, path, err := getSBOM(userInput)
+	s, fmtID, external, path, err := getSBOM(userInput)
 	if err != nil {
 		return nil, Context{}, nil, err
 	}
@@ -36,17 +35,16 @@ func syftSBOMProvider(userInput string, config ProviderConfig, applyChannel func
 		}
 	}
 
-	d, distroDetectionFailed := distroFromSBOM(s, config, applyChannel)
+	d, osIdentification, distroDetectionFailed := distroFromSBOM(s, config, applyChannel)
 
-	var enhancers []Enhancer
-	if fmtID != syftjson.ID {
-		enhancers = purlEnhancers(applyChannel)
-	}
+	enhancers := sbomEnhancers(fmtID, applyChannel, config.Distro.Aliases)
 
 	return FromCollection(s.Artifacts.Packages, s.Relationships, config.SynthesisConfig, enhancers...), Context{
-		Source:                &src,
-		Distro:                d,
-		DistroDetectionFailed: distroDetectionFailed,
+		Source:                  &src,
+		Distro:                  d,
+		OSIdentification:        osIdentification,
+		DistroDetectionFailed:   distroDetectionFailed,
+		ExternalVulnerabilities: external,
 	}, s, nil
 }
 
@@ -56,30 +54,39 @@ func syftSBOMProviderFromReader(reader io.ReadSeeker, config ProviderConfig, app
 		return nil, Context{}, nil, err
 	}
 
-	d, distroDetectionFailed := distroFromSBOM(s, config, applyChannel)
-
-	var enhancers []Enhancer
-	if fmtID != syftjson.ID {
-		enhancers = purlEnhancers(applyChannel)
+	external, err := readExternalVulnerabilities(reader, fmtID)
+	if err != nil {
+		return nil, Context{}, nil, err
 	}
 
+	d, osIdentification, distroDetectionFailed := distroFromSBOM(s, config, applyChannel)
+
+	enhancers := sbomEnhancers(fmtID, applyChannel, config.Distro.Aliases)
+
 	src := s.Source
 
 	return FromCollection(s.Artifacts.Packages, s.Relationships, config.SynthesisConfig, enhancers...), Context{
-		Source:                &src,
-		Distro:                d,
-		DistroDetectionFailed: distroDetectionFailed,
+		Source:                  &src,
+		Distro:                  d,
+		OSIdentification:        osIdentification,
+		DistroDetectionFailed:   distroDetectionFailed,
+		ExternalVulnerabilities: external,
 	}, s, nil
 }
 
-func getSBOM(userInput string) (*sbom.SBOM, sbom.FormatID, string, error) {
+func getSBOM(userInput string) (*sbom.SBOM, sbom.FormatID, []ExternalVulnerability, string, error) {
 	reader, path, err := getSBOMReader(userInput)
 	if err != nil {
-		return nil, "", path, err
+		return nil, "", nil, path, err
 	}
 
 	s, fmtID, err := readSBOM(reader)
-	return s, fmtID, path, err
+	if err != nil {
+		return nil, "", nil, path, err
+	}
+
+	external, err := readExternalVulnerabilities(reader, fmtID)
+	return s, fmtID, external, path, err
 }
 
 func readSBOM(reader io.ReadSeeker) (*sbom.SBOM, sbom.FormatID, error) {
@@ -92,6 +99,8 @@ func readSBOM(reader io.ReadSeeker) (*sbom.SBOM, sbom.FormatID, error) {
 		return nil, "", errDoesNotProvide
 	}
 
+	s.Relationships = append(s.Relationships, readSPDXRelationships(reader, fmtID, s)...)
+
 	return s, fmtID, nil
 }
 
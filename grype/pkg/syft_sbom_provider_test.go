@@ -230,6 +230,13 @@ func TestParseSyftJSON(t *testing.T) {
 					Type:    "alpine",
 					Version: "3.12.0",
 				},
+				OSIdentification: &distro.Identification{
+					Source:  distro.IdentifiedFromRelease,
+					ID:      "alpine",
+					Version: "3.12.0",
+					Field:   "NAME",
+					Distro:  &distro.Distro{Type: "alpine", Version: "3.12.0"},
+				},
 			},
 		},
 		springImageTestCase,
@@ -349,6 +356,13 @@ var springImageTestCase = struct {
 			Type:    "debian",
 			Version: "9",
 		},
+		OSIdentification: &distro.Identification{
+			Source:  distro.IdentifiedFromRelease,
+			ID:      "debian",
+			Version: "9",
+			Field:   "NAME",
+			Distro:  &distro.Distro{Type: "debian", Version: "9"},
+		},
 	},
 }
 
@@ -370,6 +384,13 @@ func Test_PurlList(t *testing.T) {
 					IDLike:  []string{"debian"},
 					Version: "8",
 				},
+				OSIdentification: &distro.Identification{
+					Source:  distro.IdentifiedFromPackages,
+					ID:      "debian",
+					Version: "8",
+					IDLike:  []string{"debian"},
+					Distro:  &distro.Distro{Type: "debian", IDLike: []string{"debian"}, Version: "8"},
+				},
 				Source: &source.Description{
 					Metadata: SBOMFileMetadata{
 						Path: "testdata/purl/valid-purl.txt",
@@ -422,6 +443,13 @@ func Test_PurlList(t *testing.T) {
 					IDLike:  []string{"alpine"},
 					Version: "3.20.3",
 				},
+				OSIdentification: &distro.Identification{
+					Source:  distro.IdentifiedFromPackages,
+					ID:      "alpine",
+					Version: "3.20.3",
+					IDLike:  []string{"alpine"},
+					Distro:  &distro.Distro{Type: "alpine", IDLike: []string{"alpine"}, Version: "3.20.3"},
+				},
 				Source: &source.Description{
 					Metadata: SBOMFileMetadata{
 						Path: "testdata/purl/homogeneous-os.txt",
@@ -518,6 +546,13 @@ func Test_PurlList(t *testing.T) {
 					IDLike:  []string{"redhat"},
 					Version: "9.4",
 				},
+				OSIdentification: &distro.Identification{
+					Source:  distro.IdentifiedFromPackages,
+					ID:      "rhel",
+					Version: "9.4",
+					IDLike:  []string{"redhat"},
+					Distro:  &distro.Distro{Type: distro.RedHat, IDLike: []string{"redhat"}, Version: "9.4"},
+				},
 			},
 			wantPkgs: []Package{
 				{
@@ -551,6 +586,13 @@ func Test_PurlList(t *testing.T) {
 					Channels: names("eus"), // important!
 					Version:  "9.4",
 				},
+				OSIdentification: &distro.Identification{
+					Source:  distro.IdentifiedFromPackages,
+					ID:      "rhel",
+					Version: "9.4+eus",
+					IDLike:  []string{"redhat"},
+					Distro:  &distro.Distro{Type: distro.RedHat, IDLike: []string{"redhat"}, Channels: names("eus"), Version: "9.4"},
+				},
 			},
 			wantPkgs: []Package{
 				{
@@ -602,3 +644,63 @@ func Test_PurlList(t *testing.T) {
 func testFixChannels() []distro.FixChannel {
 	return distro.DefaultFixChannels()
 }
+
+func TestParseCycloneDX_ExternalVulnerabilities(t *testing.T) {
+	pkgs, ctx, _, err := syftSBOMProvider("testdata/cdx-with-vulnerabilities.json", ProviderConfig{}, getDistroChannelApplier(testFixChannels()))
+	require.NoError(t, err)
+
+	expected := []ExternalVulnerability{
+		{
+			ID:          "CVE-2021-23337",
+			Source:      "NVD",
+			URL:         "https://nvd.nist.gov/vuln/detail/CVE-2021-23337",
+			Description: "command injection via template",
+			Severity:    "high",
+			Packages:    []ID{"pkg:npm/lodash@4.17.20"},
+			Analysis: ExternalAnalysis{
+				State:    "exploitable",
+				Response: []string{"update"},
+			},
+		},
+		{
+			ID:       "CVE-2021-44906",
+			Packages: []ID{"pkg:npm/minimist@1.2.5"},
+			Analysis: ExternalAnalysis{
+				State:         "false_positive",
+				Justification: "code_not_reachable",
+				Detail:        "only used in tests",
+			},
+		},
+	}
+	assert.Equal(t, expected, ctx.ExternalVulnerabilities)
+	assert.False(t, ctx.ExternalVulnerabilities[0].Analysis.Suppresses())
+	assert.True(t, ctx.ExternalVulnerabilities[1].Analysis.Suppresses())
+
+	// the affected packages are referenced by the package IDs (the bom-refs of the components)
+	var ids []ID
+	for _, p := range pkgs {
+		ids = append(ids, p.ID)
+	}
+	assert.Subset(t, ids, []ID{"pkg:npm/lodash@4.17.20", "pkg:npm/minimist@1.2.5"})
+}
+
+func TestParseSPDX_Relationships(t *testing.T) {
+	pkgs, _, _, err := syftSBOMProvider("testdata/spdx-with-relationships.json", ProviderConfig{}, getDistroChannelApplier(testFixChannels()))
+	require.NoError(t, err)
+
+	paths := map[string][]string{}
+	for _, p := range FromPtrs(pkgs) {
+		var names []string
+		for _, dp := range DependencyPath(p) {
+			names = append(names, dp.Name)
+		}
+		paths[p.Name] = names
+	}
+
+	assert.Equal(t, map[string][]string{
+		"app":     {"app"},
+		"express": {"app", "express"},
+		"qs":      {"app", "express", "qs"},
+		"bundled": {"app", "bundled"},
+	}, paths)
+}
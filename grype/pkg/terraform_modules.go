@@ -0,0 +1,105 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+// terraformRegistryHost is the host assumed for registry module sources without an explicit host.
+const terraformRegistryHost = "registry.terraform.io"
+
+// terraformModulesManifest is the manifest terraform writes when installing the modules of a configuration
+// (.terraform/modules/modules.json).
+type terraformModulesManifest struct {
+	Modules []struct {
+		Key     string `json:"Key"`
+		Source  string `json:"Source"`
+		Version string `json:"Version"`
+		Dir     string `json:"Dir"`
+	} `json:"Modules"`
+}
+
+// NewTerraformModuleCataloger returns a cataloger for the modules installed by terraform, complementing the syft
+// cataloger for the providers of the lock file. Module packages are named after their source (e.g.
+// "registry.terraform.io/terraform-aws-modules/vpc/aws" or "github.com/org/repo") so they can be matched like providers.
+func NewTerraformModuleCataloger() syftPkg.Cataloger {
+	return generic.NewCataloger("terraform-module-cataloger").
+		WithParserByGlobs(parseTerraformModules, "**/.terraform/modules/modules.json")
+}
+
+func parseTerraformModules(_ context.Context, _ file.Resolver, _ *generic.Environment, reader file.LocationReadCloser) ([]syftPkg.Package, []artifact.Relationship, error) {
+	var manifest terraformModulesManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode terraform modules manifest: %w", err)
+	}
+
+	var pkgs []syftPkg.Package
+	for _, m := range manifest.Modules {
+		name, ref := terraformModuleSource(m.Source)
+		if name == "" {
+			// the root module and local modules are part of the scanned configuration
+			continue
+		}
+		version := m.Version
+		if version == "" {
+			version = ref
+		}
+
+		p := syftPkg.Package{
+			Name:      name,
+			Version:   version,
+			Locations: file.NewLocationSet(reader.WithAnnotation(syftPkg.EvidenceAnnotationKey, syftPkg.PrimaryEvidenceAnnotation)),
+			Licenses:  syftPkg.NewLicenseSet(),
+			Type:      syftPkg.TerraformPkg,
+		}
+		p.SetID()
+		pkgs = append(pkgs, p)
+	}
+	return pkgs, nil, nil
+}
+
+// terraformModuleSource returns the package name and the git ref (if any) of a module source address. Local paths
+// yield no name.
+func terraformModuleSource(source string) (name, ref string) {
+	if source == "" || strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+		return "", ""
+	}
+
+	// drop the forced getter (e.g. "git::") and the module subdirectory (e.g. "//modules/iam")
+	if _, after, ok := strings.Cut(source, "::"); ok {
+		source = after
+	}
+	rest, query, _ := strings.Cut(source, "?")
+	if values, err := url.ParseQuery(query); err == nil {
+		ref = values.Get("ref")
+	}
+	if scheme, after, ok := strings.Cut(rest, "://"); ok {
+		rest = after
+		if scheme == "file" {
+			return "", ""
+		}
+	}
+	if before, _, ok := strings.Cut(rest, "//"); ok {
+		rest = before
+	}
+	// scp-like git addresses (e.g. "git@github.com:org/repo.git")
+	if user, after, ok := strings.Cut(rest, "@"); ok && !strings.Contains(user, "/") {
+		rest = strings.Replace(after, ":", "/", 1)
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, "/"), ".git")
+
+	parts := strings.Split(rest, "/")
+	if len(parts) == 3 && !strings.Contains(parts[0], ".") {
+		// registry modules are addressed as "namespace/name/provider" on the default registry
+		rest = terraformRegistryHost + "/" + rest
+	}
+	return rest, ref
+}
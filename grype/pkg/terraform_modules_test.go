@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestParseTerraformModules(t *testing.T) {
+	path := "testdata/terraform/.terraform/modules/modules.json"
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	pkgs, relationships, err := parseTerraformModules(context.Background(), nil, nil, file.NewLocationReadCloser(file.NewLocation(path), f))
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+
+	got := map[string]string{}
+	for _, p := range pkgs {
+		assert.Equal(t, syftPkg.TerraformPkg, p.Type)
+		got[p.Name] = p.Version
+	}
+	assert.Equal(t, map[string]string{
+		"registry.terraform.io/terraform-aws-modules/vpc/aws": "5.0.0",
+		"github.com/example/terraform-modules":                "v1.1.0",
+	}, got)
+}
+
+func TestTerraformModuleSource(t *testing.T) {
+	tests := []struct {
+		source string
+		name   string
+		ref    string
+	}{
+		{source: "terraform-aws-modules/vpc/aws", name: "registry.terraform.io/terraform-aws-modules/vpc/aws"},
+		{source: "app.terraform.io/example/vpc/aws", name: "app.terraform.io/example/vpc/aws"},
+		{source: "github.com/example/modules//vpc?ref=v1.2.0", name: "github.com/example/modules", ref: "v1.2.0"},
+		{source: "git::https://github.com/example/modules.git?ref=v1.2.0", name: "github.com/example/modules", ref: "v1.2.0"},
+		{source: "git::ssh://git@github.com/example/modules.git", name: "github.com/example/modules"},
+		{source: "git@github.com:example/modules.git?ref=v2.0.0", name: "github.com/example/modules", ref: "v2.0.0"},
+		{source: "./modules/vpc"},
+		{source: "../shared"},
+		{source: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			name, ref := terraformModuleSource(tt.source)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.ref, ref)
+		})
+	}
+}
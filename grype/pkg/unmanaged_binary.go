@@ -0,0 +1,17 @@
+package pkg
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// IsUnmanagedBinary indicates if the package is a binary which is not owned by any other package (e.g. a binary
+// dropped into an image instead of being installed by the OS package manager). These are only identified by the
+// version strings embedded in the binary, so findings against them are less certain and cannot be remediated by
+// upgrading OS packages.
+func IsUnmanagedBinary(p Package) bool {
+	if p.Type != syftPkg.BinaryPkg {
+		return false
+	}
+	return len(p.RelatedPackages[artifact.OwnershipByFileOverlapRelationship]) == 0
+}
@@ -25,10 +25,17 @@ func VersionFormat(p Package) version.Format {
 		return version.KBFormat
 	case syftPkg.PortagePkg:
 		return version.PortageFormat
-	case syftPkg.GoModulePkg:
+	case syftPkg.GoModulePkg, syftPkg.TerraformPkg:
+		// terraform providers are Go modules, and modules are versioned alike
 		return version.GolangFormat
 	case syftPkg.AlpmPkg:
 		return version.PacmanFormat
+	case syftPkg.DotnetPkg:
+		return version.NuGetFormat
+	case FreeBSDPkg:
+		return version.FreeBSDFormat
+	case OpenBSDPkg:
+		return version.OpenBSDFormat
 	}
 
 	if isJvmPackage(p) {
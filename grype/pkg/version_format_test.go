@@ -49,6 +49,27 @@ func TestVersionFormat(t *testing.T) {
 			},
 			format: version.PacmanFormat,
 		},
+		{
+			name: "terraform provider",
+			p: Package{
+				Type: syftPkg.TerraformPkg,
+			},
+			format: version.GolangFormat,
+		},
+		{
+			name: "freebsd pkg",
+			p: Package{
+				Type: FreeBSDPkg,
+			},
+			format: version.FreeBSDFormat,
+		},
+		{
+			name: "openbsd pkg",
+			p: Package{
+				Type: OpenBSDPkg,
+			},
+			format: version.OpenBSDFormat,
+		},
 		{
 			name: "jvm by metadata",
 			p: Package{
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// windowsProductCPEs returns the CPEs of the Windows product declared by the version resources of the given PE binary
+// package that are not within the existing CPEs. The CPEs syft generates for PE binaries are named after the product
+// alone, while NVD names the vendor after the company publishing the product.
+func windowsProductCPEs(p syftPkg.Package, existing []cpe.CPE) []cpe.CPE {
+	product, ok := windowsProductFromPE(p)
+	if !ok || isWindowsComponent(product) {
+		return nil
+	}
+	return additionalCPEs(product.cpes(), existing)
+}
+
+// windowsProductFromPE returns the product declared by the version resources of a PE binary.
+func windowsProductFromPE(p syftPkg.Package) (installedProduct, bool) {
+	metadata, ok := p.Metadata.(syftPkg.PEBinary)
+	if !ok {
+		return installedProduct{}, false
+	}
+	product := installedProduct{Version: p.Version}
+	for _, kv := range metadata.VersionResources {
+		switch strings.ToLower(kv.Key) {
+		case "companyname":
+			product.Vendor = kv.Value
+		case "productname":
+			product.Name = kv.Value
+		case "productversion":
+			if v := productVersion.FindStringSubmatch(strings.TrimSpace(kv.Value)); v != nil {
+				product.Version = v[1]
+			}
+		}
+	}
+	return product, product.Name != ""
+}
+
+// isWindowsComponent indicates whether the product is a component of Windows itself, whose vulnerabilities are
+// matched by the KB updates installed rather than by the versions of its binaries.
+func isWindowsComponent(p installedProduct) bool {
+	return normalizeProductName(p.Name) == "microsoft_windows_operating_system"
+}
@@ -0,0 +1,40 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestWindowsProductCPEs(t *testing.T) {
+	p := syftPkg.Package{
+		Name:    "Mozilla Firefox",
+		Version: "128.0.3",
+		Type:    syftPkg.BinaryPkg,
+		Metadata: syftPkg.PEBinary{VersionResources: syftPkg.KeyValues{
+			{Key: "CompanyName", Value: "Mozilla Corporation"},
+			{Key: "ProductName", Value: "Mozilla Firefox"},
+			{Key: "ProductVersion", Value: "128.0.3 (build 20240730)"},
+		}},
+	}
+	existing := []cpe.CPE{cpe.Must("cpe:2.3:a:mozilla:firefox:128.0.3:*:*:*:*:*:*:*", cpe.GeneratedSource)}
+
+	var got []string
+	for _, c := range windowsProductCPEs(p, existing) {
+		got = append(got, c.Attributes.String())
+	}
+	assert.Equal(t, []string{"cpe:2.3:a:mozilla:mozilla_firefox:128.0.3:*:*:*:*:*:*:*"}, got)
+
+	p.Metadata = syftPkg.PEBinary{VersionResources: syftPkg.KeyValues{
+		{Key: "CompanyName", Value: "Microsoft Corporation"},
+		{Key: "ProductName", Value: "Microsoft® Windows® Operating System"},
+		{Key: "ProductVersion", Value: "10.0.19041.1"},
+	}}
+	assert.Empty(t, windowsProductCPEs(p, nil), "windows components are matched by KB")
+
+	p.Metadata = nil
+	assert.Empty(t, windowsProductCPEs(p, nil))
+}
@@ -13,7 +13,6 @@ import (
 
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/internal/log"
-	"github.com/anchore/syft/syft/format/syftjson"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
@@ -138,12 +137,9 @@ func readSBOMsFromTar(r io.Reader, config ProviderConfig, applyChannel func(*dis
 		}
 		decodedCount++
 
-		d, _ := distroFromSBOM(s, config, applyChannel)
+		d, _, _ := distroFromSBOM(s, config, applyChannel)
 
-		var enhancers []Enhancer
-		if fmtID != syftjson.ID {
-			enhancers = purlEnhancers(applyChannel)
-		}
+		enhancers := sbomEnhancers(fmtID, applyChannel, config.Distro.Aliases)
 
 		packages := FromCollection(s.Artifacts.Packages, s.Relationships, config.SynthesisConfig, enhancers...)
 
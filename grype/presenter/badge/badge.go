@@ -0,0 +1,75 @@
+package badge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+const label = "vulnerabilities"
+
+// grades from best to worst, each given when the most severe vulnerability found is of the corresponding severity
+var grades = []struct {
+	grade string
+	color string
+	worst vulnerability.Severity
+}{
+	{grade: "A", color: "brightgreen", worst: vulnerability.UnknownSeverity},
+	{grade: "B", color: "green", worst: vulnerability.LowSeverity},
+	{grade: "C", color: "yellow", worst: vulnerability.MediumSeverity},
+	{grade: "D", color: "orange", worst: vulnerability.HighSeverity},
+	{grade: "F", color: "red", worst: vulnerability.CriticalSeverity},
+}
+
+// severities lists the severities counted in the badge message, most severe first
+var severities = []vulnerability.Severity{
+	vulnerability.CriticalSeverity,
+	vulnerability.HighSeverity,
+	vulnerability.MediumSeverity,
+	vulnerability.LowSeverity,
+}
+
+// badge summarizes the scan: the grade is based on the most severe vulnerability found (negligible and unknown
+// severities do not affect the grade) and the message holds the counts of vulnerabilities per severity.
+type badge struct {
+	Grade   string
+	Label   string
+	Message string
+	Color   string
+}
+
+func newBadge(doc models.Document) badge {
+	counts := make(map[vulnerability.Severity]int)
+	worst := vulnerability.UnknownSeverity
+	for _, m := range doc.Matches {
+		sev := vulnerability.ParseSeverity(m.Vulnerability.Severity)
+		if sev < vulnerability.LowSeverity {
+			continue
+		}
+		counts[sev]++
+		worst = max(worst, sev)
+	}
+
+	b := badge{Label: label}
+	for _, g := range grades {
+		if worst <= g.worst {
+			b.Grade, b.Color = g.grade, g.color
+			break
+		}
+	}
+
+	var parts []string
+	for _, sev := range severities {
+		if counts[sev] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[sev], strings.ToLower(sev.String())))
+		}
+	}
+	if len(parts) == 0 {
+		parts = []string{"none"}
+	}
+	b.Message = fmt.Sprintf("%s | %s", b.Grade, strings.Join(parts, ", "))
+
+	return b
+}
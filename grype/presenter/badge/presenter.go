@@ -0,0 +1,124 @@
+// Package badge presents a summary of the scan as a status badge, either as an SVG image or as a shields.io endpoint
+// document (see https://shields.io/badges/endpoint-badge), for embedding live security badges in repositories.
+package badge
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+// colors maps the shields.io named colors used by the badges to their hex values
+var colors = map[string]string{
+	"brightgreen": "#4c1",
+	"green":       "#97ca00",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+}
+
+// SVGPresenter writes the badge as a flat SVG image
+type SVGPresenter struct {
+	document models.Document
+}
+
+// JSONPresenter writes the badge as a shields.io endpoint document
+type JSONPresenter struct {
+	document models.Document
+}
+
+// NewSVGPresenter is a SVGPresenter constructor
+func NewSVGPresenter(pb models.PresenterConfig) *SVGPresenter {
+	return &SVGPresenter{
+		document: pb.Document,
+	}
+}
+
+// NewJSONPresenter is a JSONPresenter constructor
+func NewJSONPresenter(pb models.PresenterConfig) *JSONPresenter {
+	return &JSONPresenter{
+		document: pb.Document,
+	}
+}
+
+type endpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// Present writes the shields.io endpoint document
+func (p *JSONPresenter) Present(output io.Writer) error {
+	b := newBadge(p.document)
+	enc := json.NewEncoder(output)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	return enc.Encode(endpoint{
+		SchemaVersion: 1,
+		Label:         b.Label,
+		Message:       b.Message,
+		Color:         b.Color,
+	})
+}
+
+// Present writes the SVG badge
+func (p *SVGPresenter) Present(output io.Writer) error {
+	b := newBadge(p.document)
+
+	// text is rendered at 10x scale (as shields.io does) for more precise positioning
+	labelWidth := textWidth(b.Label) + 10
+	messageWidth := textWidth(b.Message) + 10
+	width := labelWidth + messageWidth
+	title := html.EscapeString(fmt.Sprintf("%s: %s", b.Label, b.Message))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s">`+"\n", width, title)
+	fmt.Fprintf(&sb, "<title>%s</title>\n", title)
+	sb.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&sb, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", width)
+	sb.WriteString(`<g clip-path="url(#r)">` + "\n")
+	fmt.Fprintf(&sb, `<rect width="%d" height="20" fill="#555"/>`+"\n", labelWidth)
+	fmt.Fprintf(&sb, `<rect x="%d" width="%d" height="20" fill="%s"/>`+"\n", labelWidth, messageWidth, colors[b.Color])
+	fmt.Fprintf(&sb, `<rect width="%d" height="20" fill="url(#s)"/>`+"\n", width)
+	sb.WriteString("</g>\n")
+	sb.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" text-rendering="geometricPrecision" font-size="110">` + "\n")
+	writeText(&sb, b.Label, labelWidth*5, textWidth(b.Label))
+	writeText(&sb, b.Message, labelWidth*10+messageWidth*5, textWidth(b.Message))
+	sb.WriteString("</g>\n</svg>\n")
+
+	_, err := io.WriteString(output, sb.String())
+	return err
+}
+
+// writeText writes the text (with a shadow) centered at x, given at 10x scale.
+func writeText(sb *strings.Builder, text string, x, width int) {
+	text = html.EscapeString(text)
+	fmt.Fprintf(sb, `<text aria-hidden="true" x="%d" y="150" fill="#010101" fill-opacity=".3" transform="scale(.1)" textLength="%d">%s</text>`+"\n", x, width*10, text)
+	fmt.Fprintf(sb, `<text x="%d" y="140" transform="scale(.1)" fill="#fff" textLength="%d">%s</text>`+"\n", x, width*10, text)
+}
+
+// textWidth approximates the width (in pixels) of the text rendered in 11px Verdana. The width only needs to be
+// approximate, since the text is stretched to fit (with textLength).
+func textWidth(text string) int {
+	var width float64
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("ijlI|.,:;!' ", r):
+			width += 3.8
+		case strings.ContainsRune("frt()", r):
+			width += 4.8
+		case r == 'm' || r == 'w' || r == 'W' || r == 'M':
+			width += 10.5
+		case r >= 'A' && r <= 'Z':
+			width += 7.6
+		default:
+			width += 6.9
+		}
+	}
+	return int(width + 0.5)
+}
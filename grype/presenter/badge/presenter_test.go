@@ -0,0 +1,105 @@
+package badge
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/internal"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/testutils"
+)
+
+var update = flag.Bool("update", false, "update the *.golden files for badge presenters")
+
+func TestSVGPresenter(t *testing.T) {
+	var buffer bytes.Buffer
+	pb := internal.GeneratePresenterConfig(t, internal.ImageSource)
+
+	require.NoError(t, NewSVGPresenter(pb).Present(&buffer))
+	actual := buffer.Bytes()
+
+	if *update {
+		testutils.UpdateGoldenFileContents(t, actual)
+	}
+
+	expected := testutils.GetGoldenFileContents(t)
+	if d := cmp.Diff(string(expected), string(actual)); d != "" {
+		t.Fatalf("diff: %s", d)
+	}
+}
+
+func TestJSONPresenter(t *testing.T) {
+	var buffer bytes.Buffer
+	pb := internal.GeneratePresenterConfig(t, internal.ImageSource)
+
+	require.NoError(t, NewJSONPresenter(pb).Present(&buffer))
+	actual := buffer.Bytes()
+
+	if *update {
+		testutils.UpdateGoldenFileContents(t, actual)
+	}
+
+	expected := testutils.GetGoldenFileContents(t)
+	if d := cmp.Diff(string(expected), string(actual)); d != "" {
+		t.Fatalf("diff: %s", d)
+	}
+}
+
+func TestNewBadge(t *testing.T) {
+	matches := func(severities ...string) models.Document {
+		var doc models.Document
+		for _, s := range severities {
+			var m models.Match
+			m.Vulnerability.Severity = s
+			doc.Matches = append(doc.Matches, m)
+		}
+		return doc
+	}
+
+	tests := []struct {
+		name string
+		doc  models.Document
+		want badge
+	}{
+		{
+			name: "no vulnerabilities",
+			doc:  matches(),
+			want: badge{Grade: "A", Label: label, Message: "A | none", Color: "brightgreen"},
+		},
+		{
+			name: "negligible and unknown severities do not affect the grade",
+			doc:  matches("Negligible", "Unknown", ""),
+			want: badge{Grade: "A", Label: label, Message: "A | none", Color: "brightgreen"},
+		},
+		{
+			name: "low",
+			doc:  matches("Low", "Low"),
+			want: badge{Grade: "B", Label: label, Message: "B | 2 low", Color: "green"},
+		},
+		{
+			name: "medium",
+			doc:  matches("Low", "Medium"),
+			want: badge{Grade: "C", Label: label, Message: "C | 1 medium, 1 low", Color: "yellow"},
+		},
+		{
+			name: "high",
+			doc:  matches("Medium", "High", "High"),
+			want: badge{Grade: "D", Label: label, Message: "D | 2 high, 1 medium", Color: "orange"},
+		},
+		{
+			name: "critical",
+			doc:  matches("Low", "Critical", "High"),
+			want: badge{Grade: "F", Label: label, Message: "F | 1 critical, 1 high, 1 low", Color: "red"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newBadge(tt.doc))
+		})
+	}
+}
@@ -71,10 +71,37 @@ func NewVulnerability(m models.Match) (v cyclonedx.Vulnerability, err error) {
 		Tools: nil,
 		// TODO:  we do not leverage the following fields in our model
 		Analysis:   nil,
-		Properties: nil,
+		Properties: matchProperties(m),
 	}, nil
 }
 
+// matchProperties returns the fingerprint of the match and the match confidence (if recorded) as CycloneDX properties.
+func matchProperties(m models.Match) *[]cyclonedx.Property {
+	var properties []cyclonedx.Property
+	if m.Fingerprint != "" {
+		properties = append(properties, cyclonedx.Property{
+			Name:  "grype:fingerprint",
+			Value: m.Fingerprint,
+		})
+	}
+	if c := m.Confidence(); c > 0 {
+		properties = append(properties, cyclonedx.Property{
+			Name:  "grype:confidence",
+			Value: strconv.FormatFloat(c, 'f', -1, 64),
+		})
+	}
+	if m.Malicious {
+		properties = append(properties, cyclonedx.Property{
+			Name:  "grype:malicious",
+			Value: "true",
+		})
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return &properties
+}
+
 func generateCDXRatings(metadata models.VulnerabilityMetadata) []cyclonedx.VulnerabilityRating {
 	severity := cdxSeverityFromGrypeSeverity(metadata.Severity)
 
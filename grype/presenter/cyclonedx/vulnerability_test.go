@@ -191,3 +191,23 @@ func TestNewVulnerability_IncludesEPSSAndKEV(t *testing.T) {
 	assert.True(t, foundEPSS, "should include EPSS rating")
 	assert.True(t, foundKEV, "should include KEV rating")
 }
+
+func TestNewVulnerability_confidence(t *testing.T) {
+	m := models.Match{
+		Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2024-0001"}},
+		MatchDetails: []models.MatchDetails{
+			{Type: "cpe-match", Confidence: 0.6},
+			{Type: "exact-indirect-match", Confidence: 0.8},
+		},
+	}
+
+	v, err := NewVulnerability(m)
+	require.NoError(t, err)
+	require.NotNil(t, v.Properties)
+	assert.Equal(t, []cyclonedx.Property{{Name: "grype:confidence", Value: "0.8"}}, *v.Properties)
+
+	m.MatchDetails = nil
+	v, err = NewVulnerability(m)
+	require.NoError(t, err)
+	assert.Nil(t, v.Properties)
+}
@@ -2,6 +2,7 @@ package explain
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"io"
 	"slices"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/syft/syft/file"
 )
 
@@ -23,11 +26,19 @@ type VulnerabilityExplainer interface {
 	ExplainAll() error
 }
 
+// Format is the format explanations are rendered in.
+type Format string
+
+const (
+	TextFormat Format = "text"
+	JSONFormat Format = "json"
+)
+
 type ViewModel struct {
-	PrimaryVulnerability   models.VulnerabilityMetadata
-	RelatedVulnerabilities []models.VulnerabilityMetadata
-	MatchedPackages        []*explainedPackage // I think this needs a map of artifacts to explained evidence
-	URLs                   []string
+	PrimaryVulnerability   models.VulnerabilityMetadata   `json:"primaryVulnerability"`
+	RelatedVulnerabilities []models.VulnerabilityMetadata `json:"relatedVulnerabilities"`
+	MatchedPackages        []*explainedPackage            `json:"matchedPackages"` // I think this needs a map of artifacts to explained evidence
+	URLs                   []string                       `json:"urls"`
 }
 
 type viewModelBuilder struct {
@@ -39,34 +50,44 @@ type viewModelBuilder struct {
 type Findings map[string]ViewModel
 
 type explainedPackage struct {
-	PURL                string
-	Name                string
-	Version             string
-	MatchedOnID         string
-	MatchedOnNamespace  string
-	IndirectExplanation string
-	DirectExplanation   string
-	CPEExplanation      string
-	Locations           []explainedEvidence
-	displayPriority     int // shows how early it should be displayed; direct matches first
+	PURL                string              `json:"purl,omitempty"`
+	Name                string              `json:"name"`
+	Version             string              `json:"version"`
+	MatchedOnID         string              `json:"matchedOnID"`
+	MatchedOnNamespace  string              `json:"matchedOnNamespace"`
+	IndirectExplanation string              `json:"indirectExplanation,omitempty"`
+	DirectExplanation   string              `json:"directExplanation,omitempty"`
+	CPEExplanation      string              `json:"cpeExplanation,omitempty"`
+	DependencyPath      string              `json:"dependencyPath,omitempty"`
+	DirectDependency    string              `json:"directDependency,omitempty"`
+	Locations           []explainedEvidence `json:"locations"`
+	displayPriority     int                 // shows how early it should be displayed; direct matches first
 }
 
 type explainedEvidence struct {
-	Location     string
-	ArtifactID   string
-	ViaVulnID    string
-	ViaNamespace string
+	Location     string `json:"location"`
+	ArtifactID   string `json:"artifactID"`
+	ViaVulnID    string `json:"viaVulnID"`
+	ViaNamespace string `json:"viaNamespace"`
 }
 
 type vulnerabilityExplainer struct {
-	w   io.Writer
-	doc *models.Document
+	w      io.Writer
+	doc    *models.Document
+	format Format
 }
 
 func NewVulnerabilityExplainer(w io.Writer, doc *models.Document) VulnerabilityExplainer {
+	return NewVulnerabilityExplainerWithFormat(w, doc, TextFormat)
+}
+
+// NewVulnerabilityExplainerWithFormat returns an explainer rendering explanations in the given format, where JSON
+// explanations are rendered as a single array (regardless of how many vulnerabilities are explained).
+func NewVulnerabilityExplainerWithFormat(w io.Writer, doc *models.Document, format Format) VulnerabilityExplainer {
 	return &vulnerabilityExplainer{
-		w:   w,
-		doc: doc,
+		w:      w,
+		doc:    doc,
+		format: format,
 	}
 }
 
@@ -79,31 +100,91 @@ func (e *vulnerabilityExplainer) ExplainByID(ids []string) error {
 	if err != nil {
 		return err
 	}
-	t := template.Must(template.New("explanation").Funcs(funcs).Parse(explainTemplate))
+	var explanations []ViewModel
 	for _, id := range ids {
 		finding, ok := findings[id]
 		if !ok {
 			continue
 		}
-		if err := t.Execute(e.w, finding); err != nil {
-			return fmt.Errorf("unable to execute template: %w", err)
-		}
+		explanations = append(explanations, finding)
 	}
-	return nil
+	return e.render(explanations)
 }
 
-func (e *vulnerabilityExplainer) ExplainBySeverity(_ string) error {
-	return fmt.Errorf("not implemented")
+// ExplainBySeverity explains every matched vulnerability with a severity at or above the given severity, the most
+// severe vulnerabilities first.
+func (e *vulnerabilityExplainer) ExplainBySeverity(severity string) error {
+	minSeverity := vulnerability.ParseSeverity(severity)
+	if minSeverity == vulnerability.UnknownSeverity {
+		return fmt.Errorf("invalid severity %q (options: %v)", severity, vulnerability.AllSeverities())
+	}
+	return e.ExplainByID(matchedIDs(e.doc, minSeverity))
 }
 
+// ExplainAll explains every matched vulnerability, the most severe vulnerabilities first.
 func (e *vulnerabilityExplainer) ExplainAll() error {
-	findings, err := Doc(e.doc, nil)
-	if err != nil {
-		return err
+	return e.ExplainByID(matchedIDs(e.doc, vulnerability.UnknownSeverity))
+}
+
+func (e *vulnerabilityExplainer) render(explanations []ViewModel) error {
+	switch e.format {
+	case JSONFormat:
+		if explanations == nil {
+			// always allocate the top level collection
+			explanations = []ViewModel{}
+		}
+		enc := json.NewEncoder(e.w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", " ")
+		if err := enc.Encode(explanations); err != nil {
+			return fmt.Errorf("unable to encode explanations: %w", err)
+		}
+		return nil
+	case TextFormat, "":
+		t := template.Must(template.New("explanation").Funcs(funcs).Parse(explainTemplate))
+		for i, explanation := range explanations {
+			if i > 0 {
+				// separate each explanation when explaining several vulnerabilities at once
+				if _, err := io.WriteString(e.w, "\n"); err != nil {
+					return err
+				}
+			}
+			if err := t.Execute(e.w, explanation); err != nil {
+				return fmt.Errorf("unable to execute template: %w", err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported explanation format: %s", e.format)
+}
+
+// matchedIDs returns the IDs of the matched vulnerabilities with a severity at or above the given severity, ordered
+// by descending severity (then by ID).
+func matchedIDs(doc *models.Document, minSeverity vulnerability.Severity) []string {
+	severities := make(map[string]vulnerability.Severity)
+	var ids []string
+	for _, m := range doc.Matches {
+		id := m.Vulnerability.ID
+		severity := vulnerability.ParseSeverity(m.Vulnerability.Severity)
+		if severity < minSeverity {
+			continue
+		}
+		existing, ok := severities[id]
+		if !ok {
+			ids = append(ids, id)
+		}
+		if !ok || severity > existing {
+			severities[id] = severity
+		}
 	}
-	t := template.Must(template.New("explanation").Funcs(funcs).Parse(explainTemplate))
 
-	return t.Execute(e.w, findings)
+	sort.SliceStable(ids, func(i, j int) bool {
+		if severities[ids[i]] != severities[ids[j]] {
+			return severities[ids[i]] > severities[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
 }
 
 func Doc(doc *models.Document, requestedIDs []string) (Findings, error) {
@@ -279,6 +360,8 @@ func groupAndSortEvidence(matches []models.Match) []*explainedPackage {
 				DirectExplanation:   directExplanation,
 				IndirectExplanation: indirectExplanation,
 				CPEExplanation:      cpeExplanation,
+				DependencyPath:      formatDependencyPath(m.Artifact.DependencyPath),
+				DirectDependency:    formatDependencyPackage(m.Artifact.DirectDependency),
 				Locations:           newLocations,
 				displayPriority:     matchTypePriority,
 			}
@@ -324,6 +407,25 @@ func groupAndSortEvidence(matches []models.Match) []*explainedPackage {
 	return explainedPackages
 }
 
+// formatDependencyPath renders the path from a root package to the matched package (e.g. "app@1.0 -> lib@2.0").
+func formatDependencyPath(path []models.DependencyPathPackage) string {
+	var parts []string
+	for i := range path {
+		parts = append(parts, formatDependencyPackage(&path[i]))
+	}
+	return strings.Join(parts, " -> ")
+}
+
+func formatDependencyPackage(p *models.DependencyPathPackage) string {
+	switch {
+	case p == nil:
+		return ""
+	case p.Version == "":
+		return p.Name
+	}
+	return fmt.Sprintf("%s@%s", p.Name, p.Version)
+}
+
 func explainedPackageIsLess(i, j *explainedPackage) bool {
 	if i.displayPriority != j.displayPriority {
 		return i.displayPriority > j.displayPriority
@@ -418,7 +520,7 @@ func formatCPEExplanation(m models.Match) string {
 		if cpes, ok := mapResult["cpes"]; ok {
 			if cpeSlice, ok := cpes.([]any); ok {
 				if len(cpeSlice) > 0 {
-					return fmt.Sprintf("CPE match on `%s`.", cpeSlice[0])
+					return fmt.Sprintf("CPE match on `%s`.", cpeSlice[0]) + formatCPEEvidence(m.MatchDetails[0].Found)
 				}
 			}
 		}
@@ -426,6 +528,75 @@ func formatCPEExplanation(m models.Match) string {
 	return ""
 }
 
+// formatCPEEvidence describes which NVD criteria (and version bounds) each package CPE matched, if recorded.
+func formatCPEEvidence(found any) string {
+	mapResult, ok := found.(map[string]any)
+	if !ok {
+		return ""
+	}
+	evidence, ok := mapResult["evidence"].([]any)
+	if !ok {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, e := range evidence {
+		evidenceMap, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		packageCPE, _ := evidenceMap["packageCPE"].(string)
+		criteria := quotedStrings(evidenceMap["criteria"])
+		if packageCPE == "" || len(criteria) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, " `%s` matched criteria %s", packageCPE, strings.Join(criteria, ", "))
+		if bounds := formatVersionBounds(evidenceMap["versionBounds"]); bounds != "" {
+			fmt.Fprintf(&sb, " (%s)", bounds)
+		}
+		sb.WriteString(".")
+	}
+	return sb.String()
+}
+
+func quotedStrings(value any) []string {
+	values, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	var result []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, fmt.Sprintf("`%s`", s))
+		}
+	}
+	return result
+}
+
+func formatVersionBounds(value any) string {
+	bounds, ok := value.([]any)
+	if !ok {
+		return ""
+	}
+	var parts []string
+	for _, b := range bounds {
+		boundsMap, ok := b.(map[string]any)
+		if !ok {
+			continue
+		}
+		var v version.Bounds
+		v.Version, _ = boundsMap["version"].(string)
+		v.VersionStartIncluding, _ = boundsMap["versionStartIncluding"].(string)
+		v.VersionStartExcluding, _ = boundsMap["versionStartExcluding"].(string)
+		v.VersionEndIncluding, _ = boundsMap["versionEndIncluding"].(string)
+		v.VersionEndExcluding, _ = boundsMap["versionEndExcluding"].(string)
+		if s := v.String(); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
 func sourcePackageNameAndVersion(md models.MatchDetails) (string, string) {
 	var name string
 	var version string
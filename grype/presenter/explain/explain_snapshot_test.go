@@ -47,6 +47,11 @@ func TestExplainSnapshot(t *testing.T) {
 			fixture:          "testdata/ghsa-test.json",
 			vulnerabilityIDs: []string{"CVE-2014-3577"},
 		},
+		{
+			name:             "transitive dependency with a dependency path",
+			fixture:          "testdata/dependency-path-test.json",
+			vulnerabilityIDs: []string{"GHSA-hrpp-h998-j3pp"},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
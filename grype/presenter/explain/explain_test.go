@@ -0,0 +1,113 @@
+package explain_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/explain"
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+func severityTestDocument() *models.Document {
+	newMatch := func(id, severity, pkg string) models.Match {
+		return models.Match{
+			Vulnerability: models.Vulnerability{
+				VulnerabilityMetadata: models.VulnerabilityMetadata{
+					ID:        id,
+					Namespace: "github:language:javascript",
+					Severity:  severity,
+				},
+			},
+			Artifact: models.Package{ID: pkg, Name: pkg, Version: "1.0.0"},
+		}
+	}
+	return &models.Document{
+		Matches: []models.Match{
+			newMatch("GHSA-low", "Low", "left-pad"),
+			newMatch("GHSA-high-b", "High", "lodash"),
+			newMatch("GHSA-critical", "Critical", "minimist"),
+			newMatch("GHSA-high-a", "High", "lodash"),
+			newMatch("GHSA-unknown", "", "qs"),
+		},
+	}
+}
+
+func TestExplainBySeverity(t *testing.T) {
+	tests := []struct {
+		name        string
+		minSeverity string
+		wantIDs     []string
+		wantErr     string
+	}{
+		{
+			name:        "high and above, most severe first",
+			minSeverity: "high",
+			wantIDs:     []string{"GHSA-critical", "GHSA-high-a", "GHSA-high-b"},
+		},
+		{
+			name:        "case insensitive",
+			minSeverity: "Critical",
+			wantIDs:     []string{"GHSA-critical"},
+		},
+		{
+			name:        "everything with a known severity",
+			minSeverity: "negligible",
+			wantIDs:     []string{"GHSA-critical", "GHSA-high-a", "GHSA-high-b", "GHSA-low"},
+		},
+		{
+			name:        "invalid severity",
+			minSeverity: "severe",
+			wantErr:     `invalid severity "severe"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := explain.NewVulnerabilityExplainerWithFormat(&buf, severityTestDocument(), explain.JSONFormat).ExplainBySeverity(tt.minSeverity)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var explanations []explain.ViewModel
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &explanations))
+
+			var ids []string
+			for _, e := range explanations {
+				ids = append(ids, e.PrimaryVulnerability.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestExplainAll(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, explain.NewVulnerabilityExplainer(&buf, severityTestDocument()).ExplainAll())
+
+	var headings []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasPrefix(line, "GHSA-") {
+			headings = append(headings, line)
+		}
+	}
+	assert.Equal(t, []string{
+		"GHSA-critical from github:language:javascript (Critical)",
+		"GHSA-high-a from github:language:javascript (High)",
+		"GHSA-high-b from github:language:javascript (High)",
+		"GHSA-low from github:language:javascript (Low)",
+		"GHSA-unknown from github:language:javascript ()",
+	}, headings)
+}
+
+func TestExplainNoMatchesJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, explain.NewVulnerabilityExplainerWithFormat(&buf, &models.Document{}, explain.JSONFormat).ExplainBySeverity("high"))
+	assert.Equal(t, "[]\n", buf.String())
+}
@@ -0,0 +1,289 @@
+package explain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/owenrumney/go-sarif/sarif"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// InputFormat is a grype report format that can be explained.
+type InputFormat string
+
+const (
+	JSONInput  InputFormat = "json"
+	SARIFInput InputFormat = "sarif"
+	TableInput InputFormat = "table"
+)
+
+// Finding is a match recovered from a report that does not carry the full details of matches (the SARIF and table
+// outputs), to be resolved against the vulnerability DB.
+type Finding struct {
+	VulnerabilityID string
+	PackageName     string
+	PackageVersion  string
+	PackageType     string
+	PURL            string
+	Severity        string
+	Locations       []string
+	// Matcher and MatchType describe how the match was made, when known
+	Matcher   match.MatcherType
+	MatchType match.Type
+}
+
+// sarifMessagePattern matches the result messages of the SARIF output (see the sarif presenter).
+var sarifMessagePattern = regexp.MustCompile(`^A (\S+) vulnerability in (\S*) package: (.+), version (\S*) was found`)
+
+// ansiPattern matches the terminal escape sequences used to style the table output.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// tableColumns are the column headers of the table output.
+var tableColumns = []string{"NAME", "INSTALLED", "FIXED IN", "TYPE", "VULNERABILITY", "SEVERITY"}
+
+// DetectFormat determines the format of a grype report.
+func DetectFormat(contents []byte) InputFormat {
+	trimmed := bytes.TrimSpace(contents)
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		return TableInput
+	}
+	var probe struct {
+		Runs    json.RawMessage `json:"runs"`
+		Matches json.RawMessage `json:"matches"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err == nil && probe.Runs != nil && probe.Matches == nil {
+		return SARIFInput
+	}
+	return JSONInput
+}
+
+// ParseSARIF recovers the findings reported in grype SARIF output.
+func ParseSARIF(contents []byte) ([]Finding, error) {
+	report, err := sarif.FromBytes(contents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse SARIF: %w", err)
+	}
+
+	var findings []Finding
+	for _, run := range report.Runs {
+		rules := make(map[string]*sarif.ReportingDescriptor)
+		for _, r := range run.Tool.Driver.Rules {
+			rules[r.ID] = r
+		}
+
+		for _, result := range run.Results {
+			if result.RuleID == nil || result.Message.Text == nil {
+				continue
+			}
+			fields := sarifMessagePattern.FindStringSubmatch(*result.Message.Text)
+			if fields == nil {
+				log.WithFields("rule", *result.RuleID).Debug("unable to recover the package of SARIF result")
+				continue
+			}
+
+			f := Finding{
+				Severity:       fields[1],
+				PackageType:    fields[2],
+				PackageName:    fields[3],
+				PackageVersion: fields[4],
+				// rule IDs are the vulnerability ID suffixed by the package name
+				VulnerabilityID: strings.TrimSuffix(*result.RuleID, "-"+fields[3]),
+				Locations:       sarifLocations(result),
+			}
+			if rule, ok := rules[*result.RuleID]; ok {
+				if purls, ok := rule.Properties["purls"].([]any); ok && len(purls) > 0 {
+					f.PURL, _ = purls[0].(string)
+				}
+				if rule.Name != nil {
+					f.Matcher, f.MatchType = parseRuleName(*rule.Name)
+				}
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+func sarifLocations(result *sarif.Result) []string {
+	var locations []string
+	for _, l := range result.Locations {
+		for _, logical := range l.LogicalLocations {
+			if logical.Name != nil {
+				locations = append(locations, *logical.Name)
+			}
+		}
+		if len(locations) == 0 && l.PhysicalLocation != nil && l.PhysicalLocation.ArtifactLocation != nil && l.PhysicalLocation.ArtifactLocation.URI != nil {
+			locations = append(locations, *l.PhysicalLocation.ArtifactLocation.URI)
+		}
+	}
+	return locations
+}
+
+// parseRuleName recovers the matcher and match type from a SARIF rule name (e.g. "StockMatcherCpeMatch").
+func parseRuleName(name string) (match.MatcherType, match.Type) {
+	for _, matcher := range append([]match.MatcherType{match.StockMatcher}, match.AllMatcherTypes...) {
+		rest, ok := strings.CutPrefix(name, pascalCase(string(matcher)))
+		if !ok {
+			continue
+		}
+		for _, t := range []match.Type{match.ExactDirectMatch, match.ExactIndirectMatch, match.CPEMatch} {
+			if rest == pascalCase(string(t)) {
+				return matcher, t
+			}
+		}
+	}
+	return "", ""
+}
+
+func pascalCase(s string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(s, "-") {
+		if part == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]))
+		sb.WriteString(part[1:])
+	}
+	return sb.String()
+}
+
+// ParseTable recovers the findings reported in grype table output (with or without color).
+func ParseTable(contents []byte) ([]Finding, error) {
+	lines := strings.Split(ansiPattern.ReplaceAllString(string(contents), ""), "\n")
+
+	var starts []int
+	var findings []Finding
+	for _, line := range lines {
+		runes := []rune(strings.TrimRight(line, "\r"))
+		if starts == nil {
+			starts = tableColumnStarts(string(runes))
+			continue
+		}
+		if strings.TrimSpace(string(runes)) == "" {
+			// the table is followed by other sections (e.g. severity derivations)
+			break
+		}
+
+		cells := make([]string, len(starts))
+		for i, start := range starts {
+			end := len(runes)
+			if i+1 < len(starts) {
+				end = min(starts[i+1], len(runes))
+			}
+			if start < end {
+				cells[i] = strings.TrimSpace(string(runes[start:end]))
+			}
+		}
+		if cells[0] == "" || cells[4] == "" {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			PackageName:     cells[0],
+			PackageVersion:  cells[1],
+			PackageType:     cells[3],
+			VulnerabilityID: cells[4],
+			// adjusted severities are shown as "<severity> → <adjusted severity>"
+			Severity: strings.Fields(cells[5] + " ")[0],
+		})
+	}
+
+	if starts == nil {
+		return nil, fmt.Errorf("unable to find the table header (%s)", strings.Join(tableColumns, ", "))
+	}
+	return findings, nil
+}
+
+// tableColumnStarts returns the (rune) offsets of the columns when the line is the table header, nil otherwise.
+func tableColumnStarts(line string) []int {
+	if !strings.HasPrefix(line, tableColumns[0]) {
+		return nil
+	}
+	starts := make([]int, 0, len(tableColumns)+1)
+	for _, column := range tableColumns {
+		idx := strings.Index(line, column)
+		if idx < 0 {
+			return nil
+		}
+		starts = append(starts, len([]rune(line[:idx])))
+	}
+	// the severity column ends where the next column (EPSS) starts
+	if idx := strings.Index(line, "EPSS"); idx >= 0 {
+		starts = append(starts, len([]rune(line[:idx])))
+	}
+	return starts
+}
+
+// Resolve builds a document from findings, using the vulnerability provider for the details not carried by the
+// report they were recovered from (such as descriptions, CVSS scores, fixes and related vulnerabilities).
+func Resolve(findings []Finding, provider vulnerability.Provider) (*models.Document, error) {
+	doc := &models.Document{Matches: make([]models.Match, 0, len(findings))}
+	for _, f := range findings {
+		m := match.Match{
+			Vulnerability: resolveVulnerability(f, provider),
+			Package:       findingPackage(f),
+		}
+		if f.MatchType != "" {
+			m.Details = match.Details{{Type: f.MatchType, Matcher: f.Matcher}}
+		}
+
+		model, err := models.NewMatch(m, provider)
+		if err != nil {
+			return nil, err
+		}
+		doc.Matches = append(doc.Matches, *model)
+	}
+	return doc, nil
+}
+
+// resolveVulnerability finds the vulnerability record of the finding, preferring the record for the same package.
+func resolveVulnerability(f Finding, provider vulnerability.Provider) vulnerability.Vulnerability {
+	vulns, err := provider.FindVulnerabilities(search.ByID(f.VulnerabilityID))
+	if err != nil {
+		log.WithFields("vulnerability", f.VulnerabilityID, "error", err).Debug("unable to find vulnerability")
+	}
+	for _, v := range vulns {
+		if strings.EqualFold(v.PackageName, f.PackageName) {
+			return v
+		}
+	}
+	if len(vulns) > 0 {
+		return vulns[0]
+	}
+
+	log.WithFields("vulnerability", f.VulnerabilityID).Warn("vulnerability not found in the DB, only the reported details are explained")
+	return vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{ID: f.VulnerabilityID},
+		Metadata: &vulnerability.Metadata{
+			ID:       f.VulnerabilityID,
+			Severity: f.Severity,
+		},
+	}
+}
+
+func findingPackage(f Finding) pkg.Package {
+	var locations []file.Location
+	for _, l := range f.Locations {
+		locations = append(locations, file.NewLocation(l))
+	}
+	return pkg.Package{
+		// findings of the same package are grouped by ID when explained
+		ID:        pkg.ID(fmt.Sprintf("%s@%s:%s:%s", f.PackageName, f.PackageVersion, f.PackageType, strings.Join(f.Locations, ","))),
+		Name:      f.PackageName,
+		Version:   f.PackageVersion,
+		Type:      syftPkg.Type(f.PackageType),
+		PURL:      f.PURL,
+		Locations: file.NewLocationSet(locations...),
+	}
+}
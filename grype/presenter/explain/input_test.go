@@ -0,0 +1,122 @@
+package explain_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/presenter/explain"
+	"github.com/anchore/grype/grype/presenter/internal"
+	"github.com/anchore/grype/grype/presenter/sarif"
+	"github.com/anchore/grype/grype/presenter/table"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+)
+
+func sarifOutput(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, sarif.NewPresenter(internal.GeneratePresenterConfig(t, internal.ImageSource)).Present(&buf))
+	return buf.Bytes()
+}
+
+func tableOutput(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, table.NewPresenter(internal.GeneratePresenterConfig(t, internal.ImageSource), false).Present(&buf))
+	return buf.Bytes()
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, explain.SARIFInput, explain.DetectFormat(sarifOutput(t)))
+	assert.Equal(t, explain.TableInput, explain.DetectFormat(tableOutput(t)))
+	assert.Equal(t, explain.JSONInput, explain.DetectFormat([]byte(`{"matches": [], "source": null}`)))
+}
+
+func TestParseSARIF(t *testing.T) {
+	findings, err := explain.ParseSARIF(sarifOutput(t))
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, "CVE-1999-0001", findings[0].VulnerabilityID)
+	assert.Equal(t, "package-1", findings[0].PackageName)
+	assert.Equal(t, "1.1.1", findings[0].PackageVersion)
+	assert.Equal(t, "rpm", findings[0].PackageType)
+	assert.Equal(t, match.DpkgMatcher, findings[0].Matcher)
+	assert.Equal(t, match.ExactDirectMatch, findings[0].MatchType)
+	assert.NotEmpty(t, findings[0].Locations)
+
+	assert.Equal(t, "CVE-1999-0002", findings[1].VulnerabilityID)
+	assert.Equal(t, "package-2", findings[1].PackageName)
+	assert.Equal(t, match.ExactIndirectMatch, findings[1].MatchType)
+}
+
+func TestParseTable(t *testing.T) {
+	colored := "NAME       INSTALLED  FIXED IN             TYPE  VULNERABILITY  SEVERITY  EPSS         RISK         \n" +
+		"package-1  1.1.1      1.2.1\x1b[38;5;240m, \x1b[0m\x1b[38;5;240m2.1.3\x1b[0m\x1b[38;5;240m, \x1b[0m\x1b[38;5;240m3.4.0\x1b[0m  \x1b[0mrpm   CVE-1999-0001  \x1b[38;5;36mLow\x1b[0m       \x1b[0m3.0% (42nd)  1.7          \n" +
+		"package-2  2.2.2      (fix unknown)        deb   CVE-1999-0002  \x1b[1;38;5;198mCritical\x1b[0m  \x1b[0m8.0% (53rd)  96.3  \x1b[1;7;38;5;198m KEV \x1b[0m  \x1b[0m\n"
+
+	for _, output := range [][]byte{tableOutput(t), []byte(colored)} {
+		findings, err := explain.ParseTable(output)
+		require.NoError(t, err)
+		require.Len(t, findings, 2)
+
+		assert.Equal(t, explain.Finding{
+			VulnerabilityID: "CVE-1999-0001",
+			PackageName:     "package-1",
+			PackageVersion:  "1.1.1",
+			PackageType:     "rpm",
+			Severity:        "Low",
+		}, findings[0])
+		assert.Equal(t, "CVE-1999-0002", findings[1].VulnerabilityID)
+		assert.Equal(t, "Critical", findings[1].Severity)
+	}
+
+	_, err := explain.ParseTable([]byte("No vulnerabilities found\n"))
+	require.Error(t, err)
+}
+
+func TestResolve(t *testing.T) {
+	provider := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "CVE-1999-0001", Namespace: "other"},
+			PackageName: "other-package",
+		},
+		vulnerability.Vulnerability{
+			Reference:   vulnerability.Reference{ID: "CVE-1999-0001", Namespace: "redhat:distro:redhat:8"},
+			PackageName: "package-1",
+			Fix:         vulnerability.Fix{Versions: []string{"1.2.1"}, State: vulnerability.FixStateFixed},
+			Metadata: &vulnerability.Metadata{
+				ID:          "CVE-1999-0001",
+				Namespace:   "redhat:distro:redhat:8",
+				Severity:    "Low",
+				Description: "1999-01 description",
+			},
+		},
+	)
+
+	doc, err := explain.Resolve([]explain.Finding{
+		{VulnerabilityID: "CVE-1999-0001", PackageName: "package-1", PackageVersion: "1.1.1", PackageType: "rpm", MatchType: match.ExactDirectMatch, Matcher: match.RpmMatcher},
+		{VulnerabilityID: "CVE-1999-0404", PackageName: "package-2", PackageVersion: "2.2.2", PackageType: "deb", Severity: "High"},
+	}, provider)
+	require.NoError(t, err)
+	require.Len(t, doc.Matches, 2)
+
+	resolved := doc.Matches[0]
+	assert.Equal(t, "redhat:distro:redhat:8", resolved.Vulnerability.Namespace)
+	assert.Equal(t, "1999-01 description", resolved.Vulnerability.Description)
+	assert.Equal(t, []string{"1.2.1"}, resolved.Vulnerability.Fix.Versions)
+	require.Len(t, resolved.MatchDetails, 1)
+	assert.Equal(t, string(match.RpmMatcher), resolved.MatchDetails[0].Matcher)
+
+	// vulnerabilities missing from the DB are explained with the reported details
+	unresolved := doc.Matches[1]
+	assert.Equal(t, "CVE-1999-0404", unresolved.Vulnerability.ID)
+	assert.Equal(t, "High", unresolved.Vulnerability.Severity)
+	assert.Empty(t, unresolved.MatchDetails)
+
+	var out bytes.Buffer
+	require.NoError(t, explain.NewVulnerabilityExplainer(&out, doc).ExplainByID([]string{"CVE-1999-0001"}))
+	assert.Contains(t, out.String(), "1999-01 description")
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/anchore/grype/grype/match"
+)
+
+// NewBaseline returns the baseline of the matches of a previous report, which the matches of a scan are compared
+// against to find new findings.
+func NewBaseline(doc Document) *match.Baseline {
+	findings := make([]match.BaselineFinding, 0, len(doc.Matches))
+	for _, m := range doc.Matches {
+		ids := []string{m.Vulnerability.ID}
+		for _, related := range m.RelatedVulnerabilities {
+			ids = append(ids, related.ID)
+		}
+		findings = append(findings, match.BaselineFinding{
+			VulnerabilityIDs: ids,
+			PackageName:      m.Artifact.Name,
+			PackageType:      m.Artifact.Type,
+		})
+	}
+	return match.NewBaseline(findings...)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestNewBaseline(t *testing.T) {
+	doc := Document{
+		Matches: []Match{
+			{
+				Vulnerability:          Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "GHSA-abcd-1234-wxyz"}},
+				RelatedVulnerabilities: []VulnerabilityMetadata{{ID: "CVE-2023-1"}},
+				Artifact:               Package{Name: "requests", Version: "2.25.0", Type: syftPkg.PythonPkg},
+			},
+		},
+	}
+
+	baseline := NewBaseline(doc)
+	assert.Equal(t, 1, baseline.Len())
+
+	// the finding is known regardless of the package version and whether it was normalized by CVE
+	assert.True(t, baseline.Contains(match.Match{
+		Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-1"}},
+		Package:       pkg.Package{Name: "requests", Version: "2.26.0", Type: syftPkg.PythonPkg},
+	}))
+	assert.False(t, baseline.Contains(match.Match{
+		Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-1"}},
+		Package:       pkg.Package{Name: "urllib3", Version: "2.26.0", Type: syftPkg.PythonPkg},
+	}))
+}
@@ -1,6 +1,12 @@
 package models
 
-import "github.com/anchore/grype/grype/vulnerability"
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/cvss"
+)
 
 type Cvss struct {
 	Source         string      `json:"source,omitempty"`
@@ -39,3 +45,61 @@ func toCVSS(metadata *vulnerability.Metadata) []Cvss {
 	}
 	return cvss
 }
+
+// AdjustedCvss is a CVSS score recomputed with the temporal and environmental modifiers configured for the asset class
+// of the scanned asset.
+type AdjustedCvss struct {
+	AssetClass string  `json:"assetClass"`
+	Version    string  `json:"version"`
+	Vector     string  `json:"vector"`    // the vector with the modifiers applied
+	BaseScore  float64 `json:"baseScore"` // the score of the vector as published
+	Score      float64 `json:"score"`     // the adjusted score
+	Severity   string  `json:"severity"`  // the severity rating of the adjusted score
+}
+
+// EffectiveSeverity returns the severity set by the severity policy, otherwise the adjusted severity when the CVSS
+// score was adjusted, the severity otherwise.
+func (v Vulnerability) EffectiveSeverity() string {
+	if v.PolicySeverity != nil {
+		return v.PolicySeverity.Severity
+	}
+	if v.AdjustedCvss != nil {
+		return v.AdjustedCvss.Severity
+	}
+	return v.Severity
+}
+
+// AdjustCvssScores adjusts the CVSS scores of the (ignored) matches of the document with the modifiers of the given
+// asset class, then re-sorts the matches since adjusted severities take precedence when sorting.
+func AdjustCvssScores(doc *Document, assetClass string, modifiers cvss.Modifiers, strategy SortStrategy) {
+	if modifiers.IsEmpty() {
+		return
+	}
+	for i := range doc.Matches {
+		adjustCvss(&doc.Matches[i].Vulnerability, assetClass, modifiers)
+	}
+	for i := range doc.IgnoredMatches {
+		adjustCvss(&doc.IgnoredMatches[i].Vulnerability, assetClass, modifiers)
+	}
+	SortMatches(doc.Matches, strategy)
+	SortIgnoredMatches(doc.IgnoredMatches, strategy)
+}
+
+func adjustCvss(v *Vulnerability, assetClass string, modifiers cvss.Modifiers) {
+	scores := make([]vulnerability.Cvss, len(v.Cvss))
+	for i, c := range v.Cvss {
+		scores[i] = vulnerability.Cvss{Source: c.Source, Type: c.Type, Version: c.Version, Vector: c.Vector}
+	}
+	adjusted := modifiers.AdjustScores(scores)
+	if adjusted == nil {
+		return
+	}
+	v.AdjustedCvss = &AdjustedCvss{
+		AssetClass: assetClass,
+		Version:    adjusted.Version,
+		Vector:     adjusted.Vector,
+		BaseScore:  adjusted.BaseScore,
+		Score:      adjusted.Score,
+		Severity:   cases.Title(language.English).String(adjusted.Severity.String()),
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/internal/cvss"
+)
+
+func TestAdjustCvssScores(t *testing.T) {
+	vulnWith := func(id, severity, vector string) Match {
+		m := Match{Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: id, Severity: severity}}}
+		if vector != "" {
+			m.Vulnerability.Cvss = []Cvss{{Type: "Primary", Version: "3.1", Vector: vector}}
+		}
+		return m
+	}
+
+	doc := Document{
+		Matches: []Match{
+			// critical base score, but lowered to medium for the asset class
+			vulnWith("CVE-2024-0001", "Critical", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"),
+			vulnWith("CVE-2024-0002", "High", ""),
+		},
+		IgnoredMatches: []IgnoredMatch{
+			{Match: vulnWith("CVE-2024-0003", "Critical", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H")},
+		},
+	}
+
+	modifiers, err := cvss.ParseModifiers("CR:L/IR:L/AR:L/MAV:L/E:U")
+	require.NoError(t, err)
+	AdjustCvssScores(&doc, "internal", modifiers, SortBySeverity)
+
+	require.Len(t, doc.Matches, 2)
+	assert.Equal(t, "CVE-2024-0002", doc.Matches[0].Vulnerability.ID)
+	assert.Nil(t, doc.Matches[0].Vulnerability.AdjustedCvss)
+	assert.Equal(t, "High", doc.Matches[0].Vulnerability.EffectiveSeverity())
+
+	adjusted := doc.Matches[1].Vulnerability
+	assert.Equal(t, "Critical", adjusted.Severity)
+	assert.Equal(t, &AdjustedCvss{
+		AssetClass: "internal",
+		Version:    "3.1",
+		Vector:     "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:U/CR:L/IR:L/AR:L/MAV:L",
+		BaseScore:  9.8,
+		Score:      6.1,
+		Severity:   "Medium",
+	}, adjusted.AdjustedCvss)
+	assert.Equal(t, "Medium", adjusted.EffectiveSeverity())
+
+	require.NotNil(t, doc.IgnoredMatches[0].Vulnerability.AdjustedCvss)
+}
+
+func TestAdjustCvssScores_noModifiers(t *testing.T) {
+	doc := Document{
+		Matches: []Match{{Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{
+			ID:   "CVE-2024-0001",
+			Cvss: []Cvss{{Version: "3.1", Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}},
+		}}}},
+	}
+	AdjustCvssScores(&doc, "", cvss.Modifiers{}, SortBySeverity)
+	assert.Nil(t, doc.Matches[0].Vulnerability.AdjustedCvss)
+}
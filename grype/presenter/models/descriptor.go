@@ -1,10 +1,36 @@
 package models
 
+import "github.com/anchore/grype/grype/pkg"
+
 // descriptor describes what created the document as well as surrounding metadata
 type descriptor struct {
-	Name          string `json:"name"`
-	Version       string `json:"version"`
-	Configuration any    `json:"configuration,omitempty"`
-	DB            any    `json:"db,omitempty"`
-	Timestamp     string `json:"timestamp,omitempty"`
+	Name          string      `json:"name"`
+	Version       string      `json:"version"`
+	Configuration any         `json:"configuration,omitempty"`
+	DB            any         `json:"db,omitempty"`
+	Timestamp     string      `json:"timestamp,omitempty"`
+	Cataloging    *Cataloging `json:"cataloging,omitempty"`
+}
+
+// Cataloging describes how the packages of an OCI artifact were obtained: read from the SBOM attested within the image
+// (e.g. by docker buildx), or cataloged from the contents of the image.
+type Cataloging struct {
+	Method         string   `json:"method"`                   // "embedded-sbom" or "cataloged"
+	Attestation    string   `json:"attestation,omitempty"`    // digest of the attestation manifest of the image
+	PredicateTypes []string `json:"predicateTypes,omitempty"` // predicate types of the SBOM attestations of the image
+	Provenance     bool     `json:"provenance,omitempty"`     // whether the image carries a provenance attestation
+	Reason         string   `json:"reason,omitempty"`         // why the image was cataloged rather than its embedded SBOM used
+}
+
+func newCataloging(c *pkg.Cataloging) *Cataloging {
+	if c == nil {
+		return nil
+	}
+	return &Cataloging{
+		Method:         string(c.Method),
+		Attestation:    c.Attestation,
+		PredicateTypes: c.PredicateTypes,
+		Provenance:     c.Provenance,
+		Reason:         c.Reason,
+	}
 }
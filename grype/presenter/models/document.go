@@ -15,12 +15,21 @@ import (
 
 // Document represents the JSON document to be presented
 type Document struct {
-	Matches         []Match         `json:"matches"`
-	IgnoredMatches  []IgnoredMatch  `json:"ignoredMatches,omitempty"`
-	AlertsByPackage []PackageAlerts `json:"alertsByPackage,omitempty"`
-	Source          *source         `json:"source"`
-	Distro          distribution    `json:"distro"`
-	Descriptor      descriptor      `json:"descriptor"`
+	Matches                 []Match                  `json:"matches"`
+	IgnoredMatches          []IgnoredMatch           `json:"ignoredMatches,omitempty"`
+	UnusedIgnoreRules       []IgnoreRule             `json:"unusedIgnoreRules,omitempty"`
+	UnmanagedBinaries       []UnmanagedBinary        `json:"unmanagedBinaries,omitempty"`
+	LicenseViolations       []LicenseViolation       `json:"licenseViolations,omitempty"`
+	ExploitableCombinations []ExploitableCombination `json:"exploitableCombinations,omitempty"`
+	Skipped                 []SkippedPackage         `json:"skipped,omitempty"`
+	Projects                []Project                `json:"projects,omitempty"`
+	ResolvedFindings        []ResolvedFinding        `json:"resolvedFindings,omitempty"`
+	AlertsByPackage         []PackageAlerts          `json:"alertsByPackage,omitempty"`
+	Notices                 []Notice                 `json:"notices,omitempty"`
+	Source                  *source                  `json:"source"`
+	Distro                  distribution             `json:"distro"`
+	OSIdentification        *OSIdentification        `json:"osIdentification,omitempty"`
+	Descriptor              descriptor               `json:"descriptor"`
 }
 
 // NewDocument creates and populates a new Document struct, representing the populated JSON document.
@@ -78,18 +87,23 @@ func NewDocument(id clio.Identification, packages []pkg.Package, context pkg.Con
 		ignoredMatchModels = append(ignoredMatchModels, ignoredMatch)
 	}
 
+	SortIgnoredMatches(ignoredMatchModels, strategy)
+
 	return Document{
-		Matches:         findings,
-		IgnoredMatches:  ignoredMatchModels,
-		AlertsByPackage: buildPackageAlerts(distroAlerts),
-		Source:          src,
-		Distro:          newDistribution(context, selectMostCommonDistro(packages)),
+		Matches:          findings,
+		IgnoredMatches:   ignoredMatchModels,
+		AlertsByPackage:  buildPackageAlerts(distroAlerts),
+		Notices:          NewNotices(context, matches),
+		Source:           src,
+		Distro:           newDistribution(context, selectMostCommonDistro(packages)),
+		OSIdentification: newOSIdentification(context.OSIdentification),
 		Descriptor: descriptor{
 			Name:          id.Name,
 			Version:       id.Version,
 			Configuration: appConfig,
 			DB:            dbInfo,
 			Timestamp:     timestamp,
+			Cataloging:    newCataloging(context.Cataloging),
 		},
 	}, nil
 }
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/clio"
 	"github.com/anchore/grype/grype/distro"
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/grype/vulnerability"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 	syftSource "github.com/anchore/syft/syft/source"
@@ -142,6 +144,71 @@ func TestFixSuggestedVersion(t *testing.T) {
 	assert.Equal(t, "1.1.2", actualSuggestedFixedVersion)
 }
 
+func TestNearestAndLatestFixedVersions(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		format      version.Format
+		fixes       []string
+		wantNearest string
+		wantLatest  string
+	}{
+		{
+			name:        "prefers the current minor stream",
+			version:     "2.2.10",
+			format:      version.SemanticFormat,
+			fixes:       []string{"3.0.1", "2.2.34", "1.9.8"},
+			wantNearest: "2.2.34",
+			wantLatest:  "3.0.1",
+		},
+		{
+			name:        "falls back to the current major stream",
+			version:     "2.2.10",
+			format:      version.SemanticFormat,
+			fixes:       []string{"3.0.1", "2.4.0", "2.3.5"},
+			wantNearest: "2.3.5",
+			wantLatest:  "3.0.1",
+		},
+		{
+			name:        "falls back to any later stream",
+			version:     "2.2.10",
+			format:      version.SemanticFormat,
+			fixes:       []string{"4.0.0", "3.1.0"},
+			wantNearest: "3.1.0",
+			wantLatest:  "4.0.0",
+		},
+		{
+			name:        "ignores fixes older than the package version",
+			version:     "2.2.10",
+			format:      version.SemanticFormat,
+			fixes:       []string{"2.2.9", "2.1.40"},
+			wantNearest: "",
+			wantLatest:  "2.2.9",
+		},
+		{
+			name:        "distro versions",
+			version:     "1.1.1f-1ubuntu2.16",
+			format:      version.DebFormat,
+			fixes:       []string{"3.0.2-0ubuntu1.10", "1.1.1f-1ubuntu2.20"},
+			wantNearest: "1.1.1f-1ubuntu2.20",
+			wantLatest:  "3.0.2-0ubuntu1.10",
+		},
+		{
+			name:    "invalid package version",
+			version: "not a version",
+			format:  version.SemanticFormat,
+			fixes:   []string{"1.0.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nearest, latest := nearestAndLatestFixedVersions(pkg.Package{Name: "foo", Version: tt.version}, tt.fixes, tt.format)
+			assert.Equal(t, tt.wantNearest, nearest)
+			assert.Equal(t, tt.wantLatest, latest)
+		})
+	}
+}
+
 func TestTimestampValidFormat(t *testing.T) {
 
 	matches := match.NewMatches()
@@ -181,6 +248,31 @@ func TestConfigurableTimestamp(t *testing.T) {
 
 }
 
+func TestDescriptorCataloging(t *testing.T) {
+	ctx := pkg.Context{
+		Cataloging: &pkg.Cataloging{
+			Method:         pkg.EmbeddedSBOMMethod,
+			Attestation:    "sha256:aaaa",
+			PredicateTypes: []string{"https://spdx.dev/Document"},
+			Provenance:     true,
+		},
+	}
+
+	doc, err := NewDocument(clio.Identification{}, nil, ctx, match.NewMatches(), nil, nil, nil, nil, SortByPackage, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &Cataloging{
+		Method:         "embedded-sbom",
+		Attestation:    "sha256:aaaa",
+		PredicateTypes: []string{"https://spdx.dev/Document"},
+		Provenance:     true,
+	}, doc.Descriptor.Cataloging)
+
+	// the cataloging is only recorded for OCI artifacts
+	doc, err = NewDocument(clio.Identification{}, nil, pkg.Context{}, match.NewMatches(), nil, nil, nil, nil, SortByPackage, false, nil)
+	require.NoError(t, err)
+	assert.Nil(t, doc.Descriptor.Cataloging)
+}
+
 func TestBuildPackageAlerts(t *testing.T) {
 	ubuntu := &distro.Distro{Type: distro.Ubuntu, Version: "18.04"}
 
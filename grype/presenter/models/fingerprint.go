@@ -0,0 +1,46 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// fingerprintVersion is the version of the fingerprint algorithm. It is hashed along with the finding, such that
+// fingerprints of different versions of the algorithm never collide, and is only incremented when the hashed fields change.
+const fingerprintVersion = "1"
+
+// NewFingerprint returns the stable fingerprint of the finding of a vulnerability in a package, which identifies the
+// finding across scans (e.g. to track the finding in an external system). The fingerprint is the hex encoded SHA-256
+// of the following fields, each followed by a NUL byte:
+//
+//   - the version of the fingerprint algorithm ("1")
+//   - the vulnerability ID, lowercased
+//   - the package name, version and type
+//   - the real paths of the package locations, sorted and de-duplicated
+//
+// The fingerprint does not depend on how the vulnerability was matched, on the vulnerability metadata (e.g. severity
+// or fixes) nor on the layer of an image the package was found in, such that it remains the same between scans of
+// rebuilt images and updated vulnerability DBs. Fingerprints of the same vulnerability ID in the same package and
+// locations are equal, even when reported by different namespaces.
+func NewFingerprint(vulnerabilityID string, p Package) string {
+	paths := make([]string, 0, len(p.Locations))
+	seen := map[string]struct{}{}
+	for _, l := range p.Locations {
+		if _, ok := seen[l.RealPath]; ok {
+			continue
+		}
+		seen[l.RealPath] = struct{}{}
+		paths = append(paths, l.RealPath)
+	}
+	sort.Strings(paths)
+
+	hasher := sha256.New()
+	fields := append([]string{fingerprintVersion, strings.ToLower(vulnerabilityID), p.Name, p.Version, string(p.Type)}, paths...)
+	for _, field := range fields {
+		_, _ = hasher.Write([]byte(field))
+		_, _ = hasher.Write([]byte{0})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestNewFingerprint(t *testing.T) {
+	p := Package{
+		ID:      "a2a8ac0d3d4d5cd2",
+		Name:    "openssl",
+		Version: "3.1.4-r0",
+		Type:    syftPkg.ApkPkg,
+		Locations: []file.Location{
+			file.NewLocationFromCoordinates(file.Coordinates{RealPath: "/lib/apk/db/installed", FileSystemID: "sha256:layer-1"}),
+			file.NewLocationFromCoordinates(file.Coordinates{RealPath: "/etc/ssl/openssl.cnf", FileSystemID: "sha256:layer-1"}),
+		},
+	}
+	fingerprint := NewFingerprint("CVE-2023-5678", p)
+
+	// the fingerprint is a hex encoded SHA-256, which is part of the documented format and must not change
+	assert.Equal(t, "6d7e477650ca04a78c4ccdc7e5e17b671eba079b9ade506746719e14894310b1", fingerprint)
+
+	tests := []struct {
+		name   string
+		vulnID string
+		modify func(p *Package)
+		same   bool
+	}{
+		{
+			name:   "vulnerability ID case",
+			vulnID: "cve-2023-5678",
+			same:   true,
+		},
+		{
+			name:   "package ID and layers",
+			vulnID: "CVE-2023-5678",
+			modify: func(p *Package) {
+				p.ID = "b3b9bd1e4e5e6de3"
+				p.Locations = []file.Location{
+					file.NewLocationFromCoordinates(file.Coordinates{RealPath: "/etc/ssl/openssl.cnf", FileSystemID: "sha256:layer-2"}),
+					file.NewLocationFromCoordinates(file.Coordinates{RealPath: "/lib/apk/db/installed", FileSystemID: "sha256:layer-3"}),
+					file.NewLocationFromCoordinates(file.Coordinates{RealPath: "/lib/apk/db/installed", FileSystemID: "sha256:layer-2"}),
+				}
+			},
+			same: true,
+		},
+		{
+			name:   "vulnerability",
+			vulnID: "CVE-2023-5679",
+		},
+		{
+			name:   "package version",
+			vulnID: "CVE-2023-5678",
+			modify: func(p *Package) { p.Version = "3.1.5-r0" },
+		},
+		{
+			name:   "package type",
+			vulnID: "CVE-2023-5678",
+			modify: func(p *Package) { p.Type = syftPkg.DebPkg },
+		},
+		{
+			name:   "locations",
+			vulnID: "CVE-2023-5678",
+			modify: func(p *Package) {
+				p.Locations = []file.Location{file.NewLocationFromCoordinates(file.Coordinates{RealPath: "/lib/apk/db/installed"})}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := p
+			other.Locations = append([]file.Location{}, p.Locations...)
+			if tt.modify != nil {
+				tt.modify(&other)
+			}
+			got := NewFingerprint(tt.vulnID, other)
+			if tt.same {
+				assert.Equal(t, fingerprint, got)
+			} else {
+				assert.NotEqual(t, fingerprint, got)
+			}
+		})
+	}
+}
@@ -8,14 +8,20 @@ type IgnoredMatch struct {
 }
 
 type IgnoreRule struct {
-	Vulnerability    string             `json:"vulnerability,omitempty"`
-	Reason           string             `json:"reason,omitempty"`
-	Namespace        string             `json:"namespace"`
-	FixState         string             `json:"fix-state,omitempty"`
-	Package          *IgnoreRulePackage `json:"package,omitempty"`
-	VexStatus        string             `json:"vex-status,omitempty"`
-	VexJustification string             `json:"vex-justification,omitempty"`
-	MatchType        string             `json:"match-type,omitempty"`
+	Vulnerability      string             `json:"vulnerability,omitempty"`
+	Reason             string             `json:"reason,omitempty"`
+	Namespace          string             `json:"namespace"`
+	FixState           string             `json:"fix-state,omitempty"`
+	Package            *IgnoreRulePackage `json:"package,omitempty"`
+	Locations          []string           `json:"locations,omitempty"`
+	VexStatus          string             `json:"vex-status,omitempty"`
+	VexJustification   string             `json:"vex-justification,omitempty"`
+	MatchType          string             `json:"match-type,omitempty"`
+	FixAvailableWithin string             `json:"fix-available-within,omitempty"`
+	Owner              string             `json:"owner,omitempty"`
+	Ticket             string             `json:"ticket,omitempty"`
+	Expires            string             `json:"expires,omitempty"`
+	Source             string             `json:"source,omitempty"`
 }
 
 type IgnoreRulePackage struct {
@@ -43,17 +49,28 @@ func newIgnoreRule(r match.IgnoreRule) IgnoreRule {
 	}
 
 	return IgnoreRule{
-		Vulnerability:    r.Vulnerability,
-		Reason:           r.Reason,
-		Namespace:        r.Namespace,
-		FixState:         r.FixState,
-		Package:          ignoreRulePackage,
-		VexStatus:        r.VexStatus,
-		VexJustification: r.VexJustification,
-		MatchType:        string(r.MatchType),
+		Vulnerability:      r.Vulnerability,
+		Reason:             r.Reason,
+		Namespace:          r.Namespace,
+		FixState:           r.FixState,
+		Package:            ignoreRulePackage,
+		Locations:          r.Locations,
+		VexStatus:          r.VexStatus,
+		VexJustification:   r.VexJustification,
+		MatchType:          string(r.MatchType),
+		FixAvailableWithin: r.FixAvailableWithin,
+		Owner:              r.Owner,
+		Ticket:             r.Ticket,
+		Expires:            r.Expires,
+		Source:             r.Source,
 	}
 }
 
+// NewUnusedIgnoreRules creates the presentable form of the configured ignore rules that did not ignore any vulnerability.
+func NewUnusedIgnoreRules(rules []match.IgnoreRule) []IgnoreRule {
+	return mapIgnoreRules(rules)
+}
+
 func mapIgnoreRules(rules []match.IgnoreRule) []IgnoreRule {
 	var result []IgnoreRule
 
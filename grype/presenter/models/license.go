@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/anchore/grype/grype/license"
+)
+
+// LicenseViolation is a single package license that does not conform to the configured license policy.
+type LicenseViolation struct {
+	Package Package `json:"package"`
+	License string  `json:"license"`
+	Reason  string  `json:"reason"`
+}
+
+// NewLicenseViolations creates the presentable form of the given license policy violations.
+func NewLicenseViolations(violations []license.Violation) []LicenseViolation {
+	var out []LicenseViolation
+	for _, v := range violations {
+		out = append(out, LicenseViolation{
+			Package: newPackage(v.Package),
+			License: v.License,
+			Reason:  v.Reason,
+		})
+	}
+	return out
+}
@@ -17,26 +17,56 @@ type Match struct {
 	RelatedVulnerabilities []VulnerabilityMetadata `json:"relatedVulnerabilities"`
 	MatchDetails           []MatchDetails          `json:"matchDetails"`
 	Artifact               Package                 `json:"artifact"`
+	SeverityDerivation     *SeverityDerivation     `json:"severityDerivation,omitempty"` // How the severity was derived (only with --explain-severity).
+	Reconciliation         *Reconciliation         `json:"reconciliation,omitempty"`     // How a disagreement between a distro and NVD about the vulnerability was resolved.
+	Fingerprint            string                  `json:"fingerprint"`                  // The stable identity of the finding across scans (see NewFingerprint).
+	Malicious              bool                    `json:"malicious,omitempty"`          // Whether the match is against a known-malicious package record: the package should be removed rather than upgraded.
+}
+
+// Reconciliation records how a disagreement between a distro and NVD about the vulnerability of a match was resolved.
+type Reconciliation struct {
+	Policy     string   `json:"policy"`
+	Reported   bool     `json:"reported"`   // Whether the match was kept, otherwise it was ignored in favor of the other source.
+	DisputedBy []string `json:"disputedBy"` // The namespaces of the vulnerability records reporting the package as not affected.
 }
 
 // MatchDetails contains all data that indicates how the result match was found
 type MatchDetails struct {
-	Type       string      `json:"type"`
-	Matcher    string      `json:"matcher"`
-	SearchedBy any         `json:"searchedBy"` // The specific attributes that were used to search (other than package name and version) --this indicates "how" the match was made.
-	Found      any         `json:"found"`      // The specific attributes on the vulnerability object that were matched with --this indicates "what" was matched on / within.
-	Fix        *FixDetails `json:"fix,omitempty"`
+	Type       string           `json:"type"`
+	Matcher    string           `json:"matcher"`
+	SearchedBy any              `json:"searchedBy"` // The specific attributes that were used to search (other than package name and version) --this indicates "how" the match was made.
+	Found      any              `json:"found"`      // The specific attributes on the vulnerability object that were matched with --this indicates "what" was matched on / within.
+	Fix        *FixDetails      `json:"fix,omitempty"`
+	Confidence float64          `json:"confidence,omitempty"` // The certainty of the match as a ratio (exact matches are more certain than CPE matches).
+	MatchedVia string           `json:"matchedVia,omitempty"` // How the match was inherited, if not made on the package itself (e.g. "upstream").
+	Upstream   *UpstreamPackage `json:"upstream,omitempty"`   // The upstream (source) package the match was made on, when matched via an upstream.
 }
 
+// MatchedViaUpstream indicates a match that was made on one of the package's upstream (source) packages.
+const MatchedViaUpstream = "upstream"
+
 // FixDetails contains any data that is relevant to fixing the vulnerability specific to the package searched with
 type FixDetails struct {
 	SuggestedVersion string `json:"suggestedVersion"`
+	NearestVersion   string `json:"nearestVersion,omitempty"` // The lowest fixed version above the package version, preferring the package's own release stream.
+	LatestVersion    string `json:"latestVersion,omitempty"`  // The highest fixed version.
+}
+
+// NewMatch creates the presentable form of a single match for the package within the match. The metadata provider is
+// optional; when nil only the metadata already attached to the match is used and related vulnerabilities are omitted.
+//
+//nolint:staticcheck // MetadataProvider is deprecated but still used internally
+func NewMatch(m match.Match, metadataProvider vulnerability.MetadataProvider) (*Match, error) {
+	return newMatch(m, m.Package, metadataProvider)
 }
 
 //nolint:staticcheck // MetadataProvider is deprecated but still used internally
 func newMatch(m match.Match, p pkg.Package, metadataProvider vulnerability.MetadataProvider) (*Match, error) {
 	relatedVulnerabilities := make([]VulnerabilityMetadata, 0)
 	for _, r := range m.Vulnerability.RelatedVulnerabilities {
+		if metadataProvider == nil {
+			break
+		}
 		relatedMetadata, err := metadataProvider.VulnerabilityMetadata(r) //nolint:staticcheck // deprecated API still used internally
 		if err != nil {
 			return nil, fmt.Errorf("unable to fetch related vuln=%q metadata: %+v", r, err)
@@ -49,7 +79,7 @@ func newMatch(m match.Match, p pkg.Package, metadataProvider vulnerability.Metad
 	// vulnerability.Vulnerability should always have vulnerability.Metadata populated, however, in the case of test mocks
 	// and other edge cases, it may not be populated. In these cases, we should fetch the metadata from the provider.
 	metadata := m.Vulnerability.Metadata
-	if metadata == nil {
+	if metadata == nil && metadataProvider != nil {
 		var err error
 		metadata, err = metadataProvider.VulnerabilityMetadata(m.Vulnerability.Reference) //nolint:staticcheck // deprecated API still used internally
 		if err != nil {
@@ -67,25 +97,177 @@ func newMatch(m match.Match, p pkg.Package, metadataProvider vulnerability.Metad
 			SearchedBy: d.SearchedBy,
 			Found:      d.Found,
 			Fix:        getFix(m, p, format),
+			Confidence: d.Confidence,
+		}
+		if upstream := matchedUpstream(d, p); upstream != nil {
+			details[idx].MatchedVia = MatchedViaUpstream
+			details[idx].Upstream = upstream
 		}
 	}
 
+	artifact := newPackage(p)
 	return &Match{
 		Vulnerability:          NewVulnerability(m.Vulnerability, metadata, format),
-		Artifact:               newPackage(p),
+		Artifact:               artifact,
 		RelatedVulnerabilities: relatedVulnerabilities,
 		MatchDetails:           details,
+		Reconciliation:         newReconciliation(m.Reconciliation),
+		Fingerprint:            NewFingerprint(m.Vulnerability.ID, artifact),
+		Malicious:              match.IsMaliciousPackage(m),
 	}, nil
 }
 
+func newReconciliation(r *match.Reconciliation) *Reconciliation {
+	if r == nil {
+		return nil
+	}
+	return &Reconciliation{
+		Policy:     string(r.Policy),
+		Reported:   r.Reported,
+		DisputedBy: r.DisputedBy,
+	}
+}
+
+// InheritedFrom returns the upstream package the match was inherited from, or nil if any detail matched the package
+// itself.
+func (m Match) InheritedFrom() *UpstreamPackage {
+	var upstream *UpstreamPackage
+	for _, d := range m.MatchDetails {
+		if d.Upstream == nil {
+			return nil
+		}
+		if upstream == nil {
+			upstream = d.Upstream
+		}
+	}
+	return upstream
+}
+
+// matchedUpstream returns the upstream package of p that the given indirect match detail searched by, if any.
+func matchedUpstream(d match.Detail, p pkg.Package) *UpstreamPackage {
+	if d.Type != match.ExactIndirectMatch {
+		return nil
+	}
+
+	var searched match.PackageParameter
+	switch params := d.SearchedBy.(type) {
+	case match.DistroParameters:
+		searched = params.Package
+	case match.EcosystemParameters:
+		searched = params.Package
+	case match.CPEParameters:
+		searched = params.Package
+	default:
+		return nil
+	}
+
+	for _, u := range p.Upstreams {
+		if u.Name == searched.Name {
+			return &UpstreamPackage{
+				Name:    searched.Name,
+				Version: searched.Version,
+			}
+		}
+	}
+	return nil
+}
+
+// Confidence returns the highest confidence of all match details (0 when no confidence was recorded).
+func (m Match) Confidence() float64 {
+	var c float64
+	for _, d := range m.MatchDetails {
+		if d.Confidence > c {
+			c = d.Confidence
+		}
+	}
+	return c
+}
+
 func getFix(m match.Match, p pkg.Package, format version.Format) *FixDetails {
-	suggested := calculateSuggestedFixedVersion(p, m.Vulnerability.Fix.Versions, format)
+	nearest, latest := nearestAndLatestFixedVersions(p, m.Vulnerability.Fix.Versions, format)
+	suggested := nearest
+	if suggested == "" {
+		suggested = calculateSuggestedFixedVersion(p, m.Vulnerability.Fix.Versions, format)
+	}
 	if suggested == "" {
 		return nil
 	}
 	return &FixDetails{
 		SuggestedVersion: suggested,
+		NearestVersion:   nearest,
+		LatestVersion:    latest,
+	}
+}
+
+// streamSegments are the number of leading version segments (major.minor, then major) that define the release
+// streams preferred when suggesting a fixed version.
+var streamSegments = []int{2, 1}
+
+// nearestAndLatestFixedVersions returns the nearest fixed version (the lowest fixed version above the package version
+// within the package's major.minor stream, falling back to its major stream and then to any stream) and the latest
+// (highest) fixed version. Versions that cannot be compared are not considered.
+func nearestAndLatestFixedVersions(p pkg.Package, fixedVersions []string, format version.Format) (nearest, latest string) {
+	current := version.New(p.Version, format)
+	if current.Validate() != nil {
+		return "", ""
+	}
+
+	var candidates []*version.Version
+	var highest *version.Version
+	for _, f := range fixedVersions {
+		v := version.New(f, format)
+		if v.Validate() != nil {
+			continue
+		}
+		if highest == nil || compareVersions(v, highest) > 0 {
+			highest = v
+		}
+		if compareVersions(v, current) > 0 {
+			candidates = append(candidates, v)
+		}
+	}
+	if highest != nil {
+		latest = highest.Raw
+	}
+
+	lowest := func(vs []*version.Version) string {
+		var out *version.Version
+		for _, v := range vs {
+			if out == nil || compareVersions(v, out) < 0 {
+				out = v
+			}
+		}
+		if out == nil {
+			return ""
+		}
+		return out.Raw
+	}
+
+	for _, segments := range streamSegments {
+		stream := version.Stream(p.Version, format, segments)
+		if stream == "" {
+			continue
+		}
+		var inStream []*version.Version
+		for _, v := range candidates {
+			if version.Stream(v.Raw, format, segments) == stream {
+				inStream = append(inStream, v)
+			}
+		}
+		if len(inStream) > 0 {
+			return lowest(inStream), latest
+		}
+	}
+	return lowest(candidates), latest
+}
+
+// compareVersions compares two versions, treating versions that cannot be compared as equal.
+func compareVersions(a, b *version.Version) int {
+	c, err := a.Compare(b)
+	if err != nil {
+		return 0
 	}
+	return c
 }
 
 func calculateSuggestedFixedVersion(p pkg.Package, fixedVersions []string, format version.Format) string {
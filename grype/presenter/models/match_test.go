@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestNewMatch_matchedViaUpstream(t *testing.T) {
+	p := pkg.Package{
+		ID:        pkg.ID("libssl3-id"),
+		Name:      "libssl3",
+		Version:   "3.0.11-1",
+		Upstreams: []pkg.UpstreamPackage{{Name: "openssl", Version: "3.0.11-1"}},
+	}
+
+	distroDetail := func(t match.Type, name string) match.Detail {
+		return match.Detail{
+			Type:    t,
+			Matcher: match.DpkgMatcher,
+			SearchedBy: match.DistroParameters{
+				Distro:  match.DistroIdentification{Type: "debian", Version: "12"},
+				Package: match.PackageParameter{Name: name, Version: "3.0.11-1"},
+			},
+		}
+	}
+
+	m := match.Match{
+		Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2024-0001", Namespace: "debian:distro:debian:12"}},
+		Package:       p,
+		Details:       match.Details{distroDetail(match.ExactIndirectMatch, "openssl")},
+	}
+
+	model, err := NewMatch(m, nil)
+	require.NoError(t, err)
+	require.Len(t, model.MatchDetails, 1)
+	assert.Equal(t, MatchedViaUpstream, model.MatchDetails[0].MatchedVia)
+	assert.Equal(t, &UpstreamPackage{Name: "openssl", Version: "3.0.11-1"}, model.MatchDetails[0].Upstream)
+	assert.Equal(t, &UpstreamPackage{Name: "openssl", Version: "3.0.11-1"}, model.InheritedFrom())
+
+	// a direct match on the package itself means the finding is not (only) inherited
+	m.Details = append(m.Details, distroDetail(match.ExactDirectMatch, "libssl3"))
+	model, err = NewMatch(m, nil)
+	require.NoError(t, err)
+	require.Len(t, model.MatchDetails, 2)
+	assert.Empty(t, model.MatchDetails[1].MatchedVia)
+	assert.Nil(t, model.MatchDetails[1].Upstream)
+	assert.Nil(t, model.InheritedFrom())
+}
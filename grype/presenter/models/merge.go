@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/anchore/clio"
+)
+
+// MergedDocument combines the results from several scan documents (e.g. per-repository CI artifacts) into a single
+// fleet-level report. Matches for the same vulnerability and package found in multiple targets are reported once,
+// listing every target the match was found in.
+type MergedDocument struct {
+	Targets    []MergedTarget   `json:"targets"`
+	Matches    []MergedMatch    `json:"matches"`
+	Statistics MergedStatistics `json:"statistics"`
+	Descriptor descriptor       `json:"descriptor"`
+}
+
+// MergedTarget preserves the provenance of a single document that was merged.
+type MergedTarget struct {
+	// Name identifies the target within the merged document (e.g. the path of the result file)
+	Name       string       `json:"name"`
+	Source     *source      `json:"source"`
+	Distro     distribution `json:"distro"`
+	Descriptor descriptor   `json:"descriptor"`
+	Matches    int          `json:"matches"`
+}
+
+// MergedMatch is a match found in one or more targets.
+type MergedMatch struct {
+	Match
+	Targets []string `json:"targets"`
+}
+
+// MergedStatistics summarizes the merged matches. Counts other than TotalMatches are de-duplicated across targets.
+type MergedStatistics struct {
+	Targets                   int            `json:"targets"`
+	TotalMatches              int            `json:"totalMatches"`
+	UniqueMatches             int            `json:"uniqueMatches"`
+	UniqueVulnerabilities     int            `json:"uniqueVulnerabilities"`
+	UniquePackages            int            `json:"uniquePackages"`
+	VulnerabilitiesBySeverity map[string]int `json:"vulnerabilitiesBySeverity"`
+}
+
+// NewMergedDocument merges the given documents, where each document is identified by the name at the same index.
+func NewMergedDocument(id clio.Identification, names []string, documents []Document, outputTimestamp bool) (MergedDocument, error) {
+	if len(names) != len(documents) {
+		return MergedDocument{}, fmt.Errorf("expected a name for each document (names=%d documents=%d)", len(names), len(documents))
+	}
+
+	timestamp, err := createTimestamp(outputTimestamp)
+	if err != nil {
+		return MergedDocument{}, err
+	}
+
+	merged := MergedDocument{
+		Targets: make([]MergedTarget, 0, len(documents)),
+		Matches: make([]MergedMatch, 0),
+		Statistics: MergedStatistics{
+			Targets:                   len(documents),
+			VulnerabilitiesBySeverity: make(map[string]int),
+		},
+		Descriptor: descriptor{
+			Name:      id.Name,
+			Version:   id.Version,
+			Timestamp: timestamp,
+		},
+	}
+
+	byKey := make(map[string]int)
+	for idx, doc := range documents {
+		name := names[idx]
+		merged.Targets = append(merged.Targets, MergedTarget{
+			Name:       name,
+			Source:     doc.Source,
+			Distro:     doc.Distro,
+			Descriptor: doc.Descriptor,
+			Matches:    len(doc.Matches),
+		})
+
+		for _, m := range doc.Matches {
+			merged.Statistics.TotalMatches++
+
+			key := mergeKey(m)
+			if i, ok := byKey[key]; ok {
+				if !slices.Contains(merged.Matches[i].Targets, name) {
+					merged.Matches[i].Targets = append(merged.Matches[i].Targets, name)
+				}
+				continue
+			}
+			byKey[key] = len(merged.Matches)
+			merged.Matches = append(merged.Matches, MergedMatch{
+				Match:   m,
+				Targets: []string{name},
+			})
+		}
+	}
+
+	strategy := getSortStrategy(DefaultSortStrategy)
+	sort.SliceStable(merged.Matches, func(i, j int) bool {
+		return strategy.less(merged.Matches[i].Match, merged.Matches[j].Match)
+	})
+
+	merged.Statistics.UniqueMatches = len(merged.Matches)
+
+	vulnerabilities := make(map[string]struct{})
+	packages := make(map[string]struct{})
+	for _, m := range merged.Matches {
+		packages[packageKey(m.Artifact)] = struct{}{}
+
+		if _, ok := vulnerabilities[m.Vulnerability.ID]; ok {
+			continue
+		}
+		vulnerabilities[m.Vulnerability.ID] = struct{}{}
+
+		severity := m.Vulnerability.Severity
+		if severity == "" {
+			severity = "Unknown"
+		}
+		merged.Statistics.VulnerabilitiesBySeverity[severity]++
+	}
+	merged.Statistics.UniqueVulnerabilities = len(vulnerabilities)
+	merged.Statistics.UniquePackages = len(packages)
+
+	return merged, nil
+}
+
+// mergeKey identifies the same finding across documents, where package IDs and locations are specific to each scan.
+func mergeKey(m Match) string {
+	return fmt.Sprintf("%s|%s|%s", m.Vulnerability.ID, m.Vulnerability.Namespace, packageKey(m.Artifact))
+}
+
+func packageKey(p Package) string {
+	if p.PURL != "" {
+		return p.PURL
+	}
+	return fmt.Sprintf("%s:%s@%s", p.Type, p.Name, p.Version)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/clio"
+)
+
+func TestNewMergedDocument(t *testing.T) {
+	newMatch := func(vulnID, severity, pkgName, pkgID string) Match {
+		return Match{
+			Vulnerability: Vulnerability{
+				VulnerabilityMetadata: VulnerabilityMetadata{
+					ID:        vulnID,
+					Namespace: "github:language:go",
+					Severity:  severity,
+				},
+			},
+			Artifact: Package{
+				ID:      pkgID,
+				Name:    pkgName,
+				Version: "1.0.0",
+				Type:    "go-module",
+				PURL:    "pkg:golang/" + pkgName + "@1.0.0",
+			},
+		}
+	}
+
+	first := Document{
+		Matches: []Match{
+			newMatch("CVE-2024-0001", "High", "a", "id-1"),
+			newMatch("CVE-2024-0002", "Low", "b", "id-2"),
+		},
+		Descriptor: descriptor{Name: "grype", Version: "1.0.0"},
+	}
+	second := Document{
+		Matches: []Match{
+			// same finding as in the first document, but with a scan-specific package ID
+			newMatch("CVE-2024-0001", "High", "a", "id-3"),
+			// same vulnerability in a different package
+			newMatch("CVE-2024-0001", "High", "c", "id-4"),
+		},
+		Descriptor: descriptor{Name: "grype", Version: "1.1.0"},
+	}
+
+	merged, err := NewMergedDocument(clio.Identification{Name: "grype", Version: "2.0.0"}, []string{"first.json", "second.json"}, []Document{first, second}, false)
+	require.NoError(t, err)
+
+	require.Len(t, merged.Targets, 2)
+	assert.Equal(t, "first.json", merged.Targets[0].Name)
+	assert.Equal(t, "1.0.0", merged.Targets[0].Descriptor.Version)
+	assert.Equal(t, 2, merged.Targets[1].Matches)
+
+	require.Len(t, merged.Matches, 3)
+	var targets [][]string
+	for _, m := range merged.Matches {
+		targets = append(targets, m.Targets)
+	}
+	assert.Equal(t, [][]string{
+		{"first.json", "second.json"},
+		{"second.json"},
+		{"first.json"},
+	}, targets)
+
+	assert.Equal(t, MergedStatistics{
+		Targets:               2,
+		TotalMatches:          4,
+		UniqueMatches:         3,
+		UniqueVulnerabilities: 2,
+		UniquePackages:        3,
+		VulnerabilitiesBySeverity: map[string]int{
+			"High": 1,
+			"Low":  1,
+		},
+	}, merged.Statistics)
+	assert.Equal(t, "2.0.0", merged.Descriptor.Version)
+	assert.Empty(t, merged.Descriptor.Timestamp)
+}
+
+func TestNewMergedDocument_mismatchedNames(t *testing.T) {
+	_, err := NewMergedDocument(clio.Identification{}, []string{"first.json"}, nil, false)
+	require.Error(t, err)
+}
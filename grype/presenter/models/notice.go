@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// NoticeType represents categories of concerns about the scan as a whole (as opposed to alerts, which concern a
+// single package)
+type NoticeType string
+
+const (
+	// NoticeTypeDistrolessImage indicates the image has no OS package DB, so OS packages could not be scanned
+	NoticeTypeDistrolessImage NoticeType = "distroless-image"
+)
+
+// Notice represents a concern about the coverage or reliability of the scan
+type Notice struct {
+	Type     NoticeType `json:"type"`
+	Message  string     `json:"message"`
+	Metadata any        `json:"metadata,omitempty"`
+}
+
+// DistrolessNoticeMetadata contains machine-readable details for the distroless image notice
+type DistrolessNoticeMetadata struct {
+	// Distro is the distro described by the image release info (if any), for which no packages were found
+	Distro string `json:"distro,omitempty"`
+	// BinaryPackages is the number of packages identified from binary evidence (version strings and ELF package notes)
+	BinaryPackages int `json:"binaryPackages"`
+	// BinaryPackageMatches is the number of matches for packages identified from binary evidence
+	BinaryPackageMatches int `json:"binaryPackageMatches"`
+}
+
+// NewNotices creates the notices for the given scan context.
+func NewNotices(context pkg.Context, matches match.Matches) []Notice {
+	var notices []Notice
+	if context.Distroless {
+		notices = append(notices, newDistrolessNotice(context, matches))
+	}
+	return notices
+}
+
+func newDistrolessNotice(context pkg.Context, matches match.Matches) Notice {
+	binaryPkgs := strset.New()
+	for _, id := range context.BinaryEvidence {
+		binaryPkgs.Add(string(id))
+	}
+
+	var binaryMatches int
+	for m := range matches.Enumerate() {
+		if binaryPkgs.Has(string(m.Package.ID)) {
+			binaryMatches++
+		}
+	}
+
+	metadata := DistrolessNoticeMetadata{
+		BinaryPackages:       binaryPkgs.Size(),
+		BinaryPackageMatches: binaryMatches,
+	}
+	if context.Distro != nil {
+		metadata.Distro = context.Distro.String()
+	}
+
+	return Notice{
+		Type: NoticeTypeDistrolessImage,
+		Message: fmt.Sprintf("No OS package DB found in the image (e.g. a distroless or static image): OS packages could not be scanned, "+
+			"so coverage is reduced to %d packages identified from binary evidence", binaryPkgs.Size()),
+		Metadata: metadata,
+	}
+}
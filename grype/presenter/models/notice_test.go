@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestNewNotices(t *testing.T) {
+	curl := pkg.Package{ID: "curl-id", Name: "curl", Version: "7.88.0", Type: syftPkg.BinaryPkg}
+	flask := pkg.Package{ID: "flask-id", Name: "flask", Version: "2.0.0", Type: syftPkg.PythonPkg}
+	matches := match.NewMatches(
+		match.Match{Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-38545"}}, Package: curl},
+		match.Match{Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2023-30861"}}, Package: flask},
+	)
+
+	assert.Empty(t, NewNotices(pkg.Context{}, matches))
+
+	notices := NewNotices(pkg.Context{
+		Distro:         distro.New(distro.Debian, "12", ""),
+		Distroless:     true,
+		BinaryEvidence: []pkg.ID{curl.ID},
+	}, matches)
+	require.Len(t, notices, 1)
+	assert.Equal(t, NoticeTypeDistrolessImage, notices[0].Type)
+	assert.Contains(t, notices[0].Message, "reduced to 1 packages")
+	assert.Equal(t, DistrolessNoticeMetadata{
+		Distro:               "debian 12",
+		BinaryPackages:       1,
+		BinaryPackageMatches: 1,
+	}, notices[0].Metadata)
+}
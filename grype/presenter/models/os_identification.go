@@ -0,0 +1,47 @@
+package models
+
+import (
+	"github.com/anchore/grype/grype/distro"
+)
+
+// OSIdentification describes how the OS of the scanned artifact was identified and the distro it is matched as.
+type OSIdentification struct {
+	ID        string        `json:"id"`                  // detected distro ID (e.g. the ID field of /etc/os-release)
+	Version   string        `json:"version,omitempty"`   // detected distro version (e.g. the VERSION_ID field of /etc/os-release)
+	IDLike    []string      `json:"idLike,omitempty"`    // detected chain of distros the distro derives from (the ID_LIKE field)
+	Source    string        `json:"source"`              // where the distro was identified from: "os-release", "override" or "packages"
+	Evidence  string        `json:"evidence,omitempty"`  // the release field the distro was determined by: "ID", "ID_LIKE" or "NAME"
+	Alias     *DistroAlias  `json:"alias,omitempty"`     // the user alias the distro is matched as, if any
+	MatchedAs *distribution `json:"matchedAs,omitempty"` // the normalized distro matched as (absent when not supported)
+}
+
+// DistroAlias is a user alias mapping a custom distro to the distro it is matched as.
+type DistroAlias struct {
+	ID string `json:"id"`
+	As string `json:"as"`
+}
+
+func newOSIdentification(id *distro.Identification) *OSIdentification {
+	if id == nil {
+		return nil
+	}
+	out := &OSIdentification{
+		ID:       id.ID,
+		Version:  id.Version,
+		IDLike:   id.IDLike,
+		Source:   string(id.Source),
+		Evidence: id.Field,
+	}
+	if id.Alias != nil {
+		out.Alias = &DistroAlias{ID: id.Alias.ID, As: id.Alias.As}
+	}
+	if id.Distro != nil {
+		out.MatchedAs = &distribution{
+			Name:     id.Distro.Name(),
+			Version:  id.Distro.Version,
+			IDLike:   cleanIDLike(id.Distro.IDLike),
+			Channels: id.Distro.Channels,
+		}
+	}
+	return out
+}
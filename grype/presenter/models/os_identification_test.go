@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/syft/syft/linux"
+)
+
+func TestNewOSIdentification(t *testing.T) {
+	assert.Nil(t, newOSIdentification(nil))
+
+	aliases := distro.Aliases{{ID: "acmeos", As: "rhel", Versions: map[string]string{"3": "9"}}}
+	_, id := distro.Identify(&linux.Release{ID: "acmeos", VersionID: "3.1", IDLike: []string{"fedora"}}, aliases, nil)
+	require.NotNil(t, id)
+
+	assert.Equal(t, &OSIdentification{
+		ID:       "acmeos",
+		Version:  "3.1",
+		IDLike:   []string{"fedora"},
+		Source:   "os-release",
+		Evidence: "ID",
+		Alias:    &DistroAlias{ID: "acmeos", As: "rhel"},
+		MatchedAs: &distribution{
+			Name:    "redhat",
+			Version: "9",
+			IDLike:  []string{"fedora"},
+		},
+	}, newOSIdentification(id))
+}
@@ -7,6 +7,9 @@ import (
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
 
+// DevScope is the scope of packages only needed for development or testing.
+const DevScope = "dev"
+
 // Package is meant to be only the fields that are needed when displaying a single pkg.Package object for the JSON presenter.
 type Package struct {
 	ID           string              `json:"id"`
@@ -22,6 +25,19 @@ type Package struct {
 	MetadataType string              `json:"metadataType,omitempty"`
 	Metadata     any                 `json:"metadata,omitempty"`
 	Annotations  map[string][]string `json:"annotations,omitempty"`
+	// Scope is "dev" for packages only needed for development or testing (see pkg.DevDependencyAnnotation)
+	Scope string `json:"scope,omitempty"`
+	// DependencyPath is the shortest path from a root package to this package (itself included), when known
+	DependencyPath []DependencyPathPackage `json:"dependencyPath,omitempty"`
+	// DirectDependency is the dependency of the root package that brings in this (transitive) package, which is the
+	// package to upgrade to pick up a fix
+	DirectDependency *DependencyPathPackage `json:"directDependency,omitempty"`
+}
+
+type DependencyPathPackage struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
 }
 
 type UpstreamPackage struct {
@@ -49,7 +65,7 @@ func newPackage(p pkg.Package) Package {
 		})
 	}
 
-	return Package{
+	out := Package{
 		ID:           string(p.ID),
 		Name:         p.Name,
 		Version:      p.Version,
@@ -64,4 +80,42 @@ func newPackage(p pkg.Package) Package {
 		Metadata:     p.Metadata,
 		Annotations:  p.Annotations,
 	}
+	if pkg.IsDevDependency(p) {
+		out.Scope = DevScope
+	}
+	out.DependencyPath, out.DirectDependency = newDependencyPath(p)
+	return out
+}
+
+// newDependencyPath returns the dependency path of language ecosystem packages, along with the direct dependency
+// bringing in transitive packages. OS packages are left out, since their dependencies are not upgraded individually.
+func newDependencyPath(p pkg.Package) ([]DependencyPathPackage, *DependencyPathPackage) {
+	if p.Language == syftPkg.UnknownLanguage {
+		return nil, nil
+	}
+
+	path := pkg.DependencyPath(p)
+	if len(path) < 2 {
+		// the package is a root package, or its dependency path is unknown
+		return nil, nil
+	}
+
+	out := make([]DependencyPathPackage, 0, len(path))
+	for _, dp := range path {
+		out = append(out, newDependencyPathPackage(dp))
+	}
+
+	var direct *DependencyPathPackage
+	if len(out) > 2 {
+		direct = &out[1]
+	}
+	return out, direct
+}
+
+func newDependencyPathPackage(p pkg.Package) DependencyPathPackage {
+	return DependencyPathPackage{
+		ID:      string(p.ID),
+		Name:    p.Name,
+		Version: p.Version,
+	}
 }
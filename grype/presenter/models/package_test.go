@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/syft/syft/artifact"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestNewPackage_DependencyPath(t *testing.T) {
+	dependsOn := func(p *pkg.Package) map[artifact.RelationshipType][]*pkg.Package {
+		return map[artifact.RelationshipType][]*pkg.Package{artifact.DependencyOfRelationship: {p}}
+	}
+
+	app := &pkg.Package{ID: "app", Name: "app", Version: "1.0.0", Language: syftPkg.JavaScript}
+	express := &pkg.Package{ID: "express", Name: "express", Version: "4.17.1", Language: syftPkg.JavaScript, RelatedPackages: dependsOn(app)}
+	qs := pkg.Package{ID: "qs", Name: "qs", Version: "6.7.0", Language: syftPkg.JavaScript, RelatedPackages: dependsOn(express)}
+	libc := &pkg.Package{ID: "libc", Name: "libc6", Version: "2.36", Type: syftPkg.DebPkg}
+	curl := pkg.Package{ID: "curl", Name: "curl", Version: "7.88", Type: syftPkg.DebPkg, RelatedPackages: dependsOn(libc)}
+
+	transitive := newPackage(qs)
+	assert.Equal(t, []DependencyPathPackage{
+		{ID: "app", Name: "app", Version: "1.0.0"},
+		{ID: "express", Name: "express", Version: "4.17.1"},
+		{ID: "qs", Name: "qs", Version: "6.7.0"},
+	}, transitive.DependencyPath)
+	assert.Equal(t, &DependencyPathPackage{ID: "express", Name: "express", Version: "4.17.1"}, transitive.DirectDependency)
+
+	direct := newPackage(*express)
+	assert.Len(t, direct.DependencyPath, 2)
+	assert.Nil(t, direct.DirectDependency, "direct dependencies are upgraded themselves")
+
+	root := newPackage(*app)
+	assert.Nil(t, root.DependencyPath)
+
+	osPackage := newPackage(curl)
+	assert.Nil(t, osPackage.DependencyPath, "OS packages are left out")
+}
+
+func TestNewPackage_Scope(t *testing.T) {
+	junit := pkg.Package{ID: "junit", Name: "junit", Version: "4.13.2", Language: syftPkg.Java}
+	junit.AddAnnotation(pkg.DevDependencyAnnotation, "maven-scope:test")
+	assert.Equal(t, DevScope, newPackage(junit).Scope)
+
+	spring := pkg.Package{ID: "spring", Name: "spring-core", Version: "5.3.0", Language: syftPkg.Java}
+	assert.Empty(t, newPackage(spring).Scope)
+}
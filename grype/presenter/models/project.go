@@ -0,0 +1,57 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// Project summarizes the findings of a project detected within a scanned directory (see pkg.ProjectAnnotation).
+type Project struct {
+	Path       string         `json:"path"`                 // the root directory of the project
+	Packages   int            `json:"packages"`             // the number of packages of the project
+	Matches    int            `json:"matches"`              // the number of matches on packages of the project
+	Severities map[string]int `json:"severities,omitempty"` // the number of matches by (effective) severity
+}
+
+// NewProjects summarizes the matches of the given document by the project of the matched packages, for each of the
+// given project roots.
+func NewProjects(roots []string, packages []pkg.Package, doc Document) []Project {
+	if len(roots) == 0 {
+		return nil
+	}
+
+	byPath := make(map[string]*Project, len(roots))
+	projects := make([]Project, len(roots))
+	for i, root := range roots {
+		projects[i] = Project{Path: root}
+		byPath[root] = &projects[i]
+	}
+
+	for _, p := range packages {
+		for _, root := range pkg.Projects(p) {
+			if project, ok := byPath[root]; ok {
+				project.Packages++
+			}
+		}
+	}
+
+	for _, m := range doc.Matches {
+		for _, root := range m.Artifact.Annotations[pkg.ProjectAnnotation] {
+			project, ok := byPath[root]
+			if !ok {
+				continue
+			}
+			project.Matches++
+			if project.Severities == nil {
+				project.Severities = make(map[string]int)
+			}
+			project.Severities[m.Vulnerability.EffectiveSeverity()]++
+		}
+	}
+
+	// projects without packages (e.g. filtered out with --only-project) are left out
+	return slices.DeleteFunc(projects, func(p Project) bool {
+		return p.Packages == 0
+	})
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/pkg"
+)
+
+func TestNewProjects(t *testing.T) {
+	inProject := func(name string, projects ...string) pkg.Package {
+		return pkg.Package{ID: pkg.ID(name), Name: name, Annotations: map[string][]string{pkg.ProjectAnnotation: projects}}
+	}
+	express := inProject("express", "/web")
+	lodash := inProject("lodash", "/web")
+	spring := inProject("spring-core", "/api")
+	curl := pkg.Package{ID: "curl", Name: "curl"}
+
+	matchOn := func(p pkg.Package, severity string) Match {
+		return Match{
+			Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{Severity: severity}},
+			Artifact:      newPackage(p),
+		}
+	}
+	doc := Document{Matches: []Match{
+		matchOn(express, "High"),
+		matchOn(lodash, "High"),
+		matchOn(lodash, "Low"),
+		matchOn(curl, "Critical"),
+	}}
+
+	projects := NewProjects([]string{"/api", "/mobile", "/web"}, []pkg.Package{express, lodash, spring, curl}, doc)
+	assert.Equal(t, []Project{
+		{Path: "/api", Packages: 1},
+		{Path: "/web", Packages: 2, Matches: 3, Severities: map[string]int{"High": 2, "Low": 1}},
+	}, projects)
+
+	assert.Nil(t, NewProjects(nil, []pkg.Package{curl}, doc))
+}
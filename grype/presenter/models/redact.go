@@ -0,0 +1,303 @@
+package models
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	syftSource "github.com/anchore/syft/syft/source"
+)
+
+// RedactionMode is how redacted values are replaced.
+type RedactionMode string
+
+const (
+	// RedactStrip removes redacted values (keeping only the file name of paths)
+	RedactStrip RedactionMode = "strip"
+	// RedactHash replaces redacted values with a stable hash, so that values can still be correlated across reports
+	RedactHash RedactionMode = "hash"
+)
+
+// redactedValue replaces values matching a redaction pattern in RedactStrip mode.
+const redactedValue = "[REDACTED]"
+
+// RedactionConfig selects what is redacted from reports, so that they can be shared without revealing details of the
+// environment they were created in.
+type RedactionConfig struct {
+	Mode RedactionMode
+	// Paths redacts the directories of file paths (e.g. package locations and the scanned directory)
+	Paths bool
+	// Registries redacts the registry hostnames of image references
+	Registries bool
+	// Environment redacts the application configuration and the location the vulnerability DB was loaded from
+	Environment bool
+	// Patterns redacts matches of these expressions in any value of the report
+	Patterns []*regexp.Regexp
+}
+
+// Enabled indicates if anything is to be redacted.
+func (c RedactionConfig) Enabled() bool {
+	return c.Mode != "" && (c.Paths || c.Registries || c.Environment || len(c.Patterns) > 0)
+}
+
+// Redact applies the redaction config to the document and SBOM of the presenter config. The document is modified in
+// place; the SBOM is replaced by a redacted copy.
+func Redact(pc *PresenterConfig, cfg RedactionConfig) {
+	if !cfg.Enabled() {
+		return
+	}
+	r := redactor{cfg: cfg}
+	r.document(&pc.Document)
+	if pc.SBOM != nil {
+		pc.SBOM = r.sbom(*pc.SBOM)
+	}
+}
+
+type redactor struct {
+	cfg RedactionConfig
+}
+
+func (r redactor) document(d *Document) {
+	if r.cfg.Environment {
+		d.Descriptor.Configuration = nil
+	}
+	if d.Source != nil {
+		d.Source.Target = r.sourceTarget(d.Source.Target)
+	}
+	r.walk(reflect.ValueOf(d).Elem())
+}
+
+func (r redactor) sbom(s sbom.SBOM) *sbom.SBOM {
+	if r.cfg.Environment {
+		s.Descriptor.Configuration = nil
+	}
+
+	s.Source.Name = r.sourceName(s.Source)
+	s.Source.Metadata = r.sourceTarget(s.Source.Metadata)
+	r.walk(reflect.ValueOf(&s.Source.Metadata).Elem())
+
+	if s.Artifacts.Packages != nil {
+		var pkgs []syftPkg.Package
+		for p := range s.Artifacts.Packages.Enumerate() {
+			locations := p.Locations.ToSlice()
+			for i := range locations {
+				r.location(&locations[i])
+			}
+			r.walk(reflect.ValueOf(&p).Elem())
+			p.Locations = file.NewLocationSet(locations...)
+			pkgs = append(pkgs, p)
+		}
+		s.Artifacts.Packages = syftPkg.NewCollection(pkgs...)
+	}
+
+	if r.cfg.Paths {
+		// file artifacts are keyed by their location
+		s.Artifacts.FileMetadata = nil
+		s.Artifacts.FileDigests = nil
+		s.Artifacts.FileContents = nil
+		s.Artifacts.FileLicenses = nil
+		s.Artifacts.Executables = nil
+		s.Artifacts.Unknowns = nil
+	}
+	return &s
+}
+
+func (r redactor) sourceName(src syftSource.Description) string {
+	switch src.Metadata.(type) {
+	case syftSource.ImageMetadata, syftSource.OCIModelMetadata:
+		return r.imageReference(src.Name)
+	case syftSource.DirectoryMetadata, syftSource.FileMetadata:
+		return r.path(src.Name)
+	}
+	return src.Name
+}
+
+// sourceTarget redacts the paths and image references describing the scanned source.
+func (r redactor) sourceTarget(target any) any {
+	switch t := target.(type) {
+	case string:
+		// the path of an SBOM file or zarf package, or a package URL / CPE literal
+		if strings.HasPrefix(t, "pkg:") || strings.HasPrefix(t, "cpe:") {
+			return t
+		}
+		return r.path(t)
+	case syftSource.DirectoryMetadata:
+		t.Path = r.path(t.Path)
+		t.Base = r.path(t.Base)
+		return t
+	case syftSource.FileMetadata:
+		t.Path = r.path(t.Path)
+		return t
+	case syftSource.ImageMetadata:
+		t.UserInput = r.imageReference(t.UserInput)
+		t.Tags = r.imageReferences(t.Tags)
+		t.RepoDigests = r.imageReferences(t.RepoDigests)
+		return t
+	case syftSource.OCIModelMetadata:
+		t.UserInput = r.imageReference(t.UserInput)
+		t.Tags = r.imageReferences(t.Tags)
+		t.RepoDigests = r.imageReferences(t.RepoDigests)
+		return t
+	case pkg.SBOMFileMetadata:
+		t.Path = r.path(t.Path)
+		return t
+	case pkg.ZarfPackageMetadata:
+		t.Path = r.path(t.Path)
+		return t
+	case pkg.FunctionArchiveMetadata:
+		t.Path = r.path(t.Path)
+		layers := make([]string, 0, len(t.Layers))
+		for _, l := range t.Layers {
+			layers = append(layers, r.path(l))
+		}
+		t.Layers = layers
+		return t
+	}
+	return target
+}
+
+// walk redacts the locations, paths and pattern matches of all (settable) values reachable from v.
+func (r redactor) walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			r.walk(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		// values held by interfaces are not addressable, so a copy is redacted in their place
+		elem := v.Elem()
+		cp := reflect.New(elem.Type()).Elem()
+		cp.Set(elem)
+		r.walk(cp)
+		v.Set(cp)
+	case reflect.Struct:
+		if !v.CanAddr() {
+			return
+		}
+		switch val := v.Addr().Interface().(type) {
+		case *file.Location:
+			r.location(val)
+		case *SecretFinding:
+			val.Path = r.path(val.Path)
+		case *pkg.JavaMetadata:
+			val.VirtualPath = r.path(val.VirtualPath)
+		case *syftPkg.JavaArchive:
+			val.VirtualPath = r.path(val.VirtualPath)
+		case *vulnerability.ProviderStatus:
+			if r.cfg.Environment {
+				val.Path = r.value(val.Path)
+				val.From = r.value(val.From)
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				r.walk(f)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.walk(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			cp := reflect.New(iter.Value().Type()).Elem()
+			cp.Set(iter.Value())
+			r.walk(cp)
+			v.SetMapIndex(iter.Key(), cp)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(r.patterns(v.String()))
+		}
+	}
+}
+
+func (r redactor) location(l *file.Location) {
+	l.RealPath = r.path(l.RealPath)
+	l.AccessPath = r.path(l.AccessPath)
+}
+
+// path redacts the directory of the given path (when redacting paths), keeping the file name. Nested archive paths
+// (e.g. "/app/lib.jar:nested.jar") are redacted by the outermost path.
+func (r redactor) path(p string) string {
+	if !r.cfg.Paths || p == "" {
+		return p
+	}
+	outer, nested, isNested := strings.Cut(p, ":")
+	dir, base := path.Split(outer)
+	if dir == "" {
+		return p
+	}
+	if r.cfg.Mode == RedactHash {
+		base = r.hash(strings.TrimSuffix(dir, "/")) + "/" + base
+	}
+	if isNested {
+		base += ":" + nested
+	}
+	return base
+}
+
+func (r redactor) imageReferences(refs []string) []string {
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		out = append(out, r.imageReference(ref))
+	}
+	return out
+}
+
+// imageReference redacts the registry hostname of the given image reference (when redacting registries).
+func (r redactor) imageReference(ref string) string {
+	if !r.cfg.Registries {
+		return ref
+	}
+	host, rest, ok := strings.Cut(ref, "/")
+	// as with docker, the first component is a hostname if it looks like one
+	if !ok || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return ref
+	}
+	if r.cfg.Mode == RedactHash {
+		return r.hash(host) + "/" + rest
+	}
+	return rest
+}
+
+func (r redactor) value(v string) string {
+	if v == "" {
+		return v
+	}
+	if r.cfg.Mode == RedactHash {
+		return r.hash(v)
+	}
+	return ""
+}
+
+func (r redactor) patterns(v string) string {
+	for _, p := range r.cfg.Patterns {
+		v = p.ReplaceAllStringFunc(v, func(match string) string {
+			if r.cfg.Mode == RedactHash {
+				return r.hash(match)
+			}
+			return redactedValue
+		})
+	}
+	return v
+}
+
+func (r redactor) hash(v string) string {
+	return fmt.Sprintf("redacted-%x", sha256.Sum256([]byte(v)))[:len("redacted-")+12]
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/file"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	syftSource "github.com/anchore/syft/syft/source"
+)
+
+func redactionTestDocument() Document {
+	return Document{
+		Matches: []Match{{
+			Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-2021-44228"}},
+			Artifact: Package{
+				Name:      "log4j-core",
+				Version:   "2.14.1",
+				Locations: file.Locations{file.NewVirtualLocation("/home/ci/build/app/lib/log4j-core.jar", "/home/ci/build/app/lib/log4j-core.jar")},
+				Metadata:  pkg.JavaMetadata{VirtualPath: "/home/ci/build/app/lib/log4j-core.jar:log4j-core", PomGroupID: "org.apache.logging.log4j"},
+				Annotations: map[string][]string{
+					"origin": {"built by ci-host-42.corp.example.com"},
+				},
+			},
+		}},
+		ExploitableCombinations: []ExploitableCombination{{
+			Secret: SecretFinding{Classification: "aws-access-key", Path: "/home/ci/build/app/.env"},
+		}},
+		Source: &source{
+			Type: "image",
+			Target: syftSource.ImageMetadata{
+				UserInput:   "registry.corp.example.com:5000/team/app:1.0",
+				Tags:        []string{"registry.corp.example.com:5000/team/app:1.0"},
+				RepoDigests: []string{"registry.corp.example.com:5000/team/app@sha256:abc"},
+				ID:          "sha256:def",
+			},
+		},
+		Descriptor: descriptor{
+			Name:          "grype",
+			Configuration: map[string]string{"db.cache-dir": "/home/ci/.cache/grype"},
+			DB: struct {
+				Status *vulnerability.ProviderStatus `json:"status"`
+			}{
+				Status: &vulnerability.ProviderStatus{SchemaVersion: "v6.1.10", Path: "/home/ci/.cache/grype/db/6", From: "https://mirror.corp.example.com/db.tar.zst"},
+			},
+		},
+	}
+}
+
+func TestRedact_Strip(t *testing.T) {
+	pc := PresenterConfig{Document: redactionTestDocument()}
+	Redact(&pc, RedactionConfig{
+		Mode:        RedactStrip,
+		Paths:       true,
+		Registries:  true,
+		Environment: true,
+		Patterns:    []*regexp.Regexp{regexp.MustCompile(`ci-host-\d+\.corp\.example\.com`)},
+	})
+	d := pc.Document
+
+	artifact := d.Matches[0].Artifact
+	assert.Equal(t, "log4j-core.jar", artifact.Locations[0].RealPath)
+	assert.Equal(t, "log4j-core.jar", artifact.Locations[0].AccessPath)
+	assert.Equal(t, "log4j-core.jar:log4j-core", artifact.Metadata.(pkg.JavaMetadata).VirtualPath)
+	assert.Equal(t, "org.apache.logging.log4j", artifact.Metadata.(pkg.JavaMetadata).PomGroupID)
+	assert.Equal(t, []string{"built by [REDACTED]"}, artifact.Annotations["origin"])
+	assert.Equal(t, "CVE-2021-44228", d.Matches[0].Vulnerability.ID)
+
+	assert.Equal(t, ".env", d.ExploitableCombinations[0].Secret.Path)
+
+	target := d.Source.Target.(syftSource.ImageMetadata)
+	assert.Equal(t, "team/app:1.0", target.UserInput)
+	assert.Equal(t, []string{"team/app:1.0"}, target.Tags)
+	assert.Equal(t, []string{"team/app@sha256:abc"}, target.RepoDigests)
+	assert.Equal(t, "sha256:def", target.ID)
+
+	assert.Nil(t, d.Descriptor.Configuration)
+	assert.Equal(t, "grype", d.Descriptor.Name)
+	status := d.Descriptor.DB.(struct {
+		Status *vulnerability.ProviderStatus `json:"status"`
+	}).Status
+	assert.Empty(t, status.Path)
+	assert.Empty(t, status.From)
+	assert.Equal(t, "v6.1.10", status.SchemaVersion)
+}
+
+func TestRedact_Hash(t *testing.T) {
+	pc := PresenterConfig{Document: redactionTestDocument()}
+	Redact(&pc, RedactionConfig{Mode: RedactHash, Paths: true, Registries: true})
+	d := pc.Document
+
+	location := d.Matches[0].Artifact.Locations[0].RealPath
+	assert.Regexp(t, `^redacted-[0-9a-f]{12}/log4j-core\.jar$`, location)
+	// hashes are stable, so the same directory is redacted alike everywhere
+	virtualPath := d.Matches[0].Artifact.Metadata.(pkg.JavaMetadata).VirtualPath
+	assert.Equal(t, location+":log4j-core", virtualPath)
+	assert.Regexp(t, `^redacted-[0-9a-f]{12}/\.env$`, d.ExploitableCombinations[0].Secret.Path)
+
+	target := d.Source.Target.(syftSource.ImageMetadata)
+	assert.Regexp(t, `^redacted-[0-9a-f]{12}/team/app:1\.0$`, target.UserInput)
+
+	// the environment is not redacted unless configured
+	assert.NotNil(t, d.Descriptor.Configuration)
+}
+
+func TestRedact_Disabled(t *testing.T) {
+	pc := PresenterConfig{Document: redactionTestDocument()}
+	Redact(&pc, RedactionConfig{Paths: true, Registries: true, Environment: true})
+	assert.Equal(t, redactionTestDocument(), pc.Document)
+}
+
+func TestRedact_SBOM(t *testing.T) {
+	p := syftPkg.Package{
+		Name:      "log4j-core",
+		Version:   "2.14.1",
+		Locations: file.NewLocationSet(file.NewLocation("/srv/app/lib/log4j-core.jar")),
+		Metadata:  syftPkg.JavaArchive{VirtualPath: "/srv/app/lib/log4j-core.jar"},
+	}
+	p.SetID()
+
+	s := &sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			Packages:     syftPkg.NewCollection(p),
+			FileDigests:  map[file.Coordinates][]file.Digest{{RealPath: "/srv/app/lib/log4j-core.jar"}: {}},
+			FileMetadata: map[file.Coordinates]file.Metadata{{RealPath: "/srv/app/lib/log4j-core.jar"}: {}},
+		},
+		Source: syftSource.Description{
+			Name:     "/srv/app",
+			Metadata: syftSource.DirectoryMetadata{Path: "/srv/app"},
+		},
+	}
+
+	pc := PresenterConfig{SBOM: s}
+	Redact(&pc, RedactionConfig{Mode: RedactStrip, Paths: true})
+
+	require.NotSame(t, s, pc.SBOM)
+	assert.Equal(t, "app", pc.SBOM.Source.Name)
+	assert.Equal(t, "app", pc.SBOM.Source.Metadata.(syftSource.DirectoryMetadata).Path)
+	assert.Empty(t, pc.SBOM.Artifacts.FileDigests)
+	assert.Empty(t, pc.SBOM.Artifacts.FileMetadata)
+
+	redacted := pc.SBOM.Artifacts.Packages.Package(p.ID())
+	require.NotNil(t, redacted)
+	assert.Equal(t, []string{"log4j-core.jar"}, redacted.Locations.CoordinateSet().Paths())
+	assert.Equal(t, "log4j-core.jar", redacted.Metadata.(syftPkg.JavaArchive).VirtualPath)
+
+	// the original SBOM is left as is
+	assert.Equal(t, "/srv/app", s.Source.Name)
+	assert.Equal(t, []string{"/srv/app/lib/log4j-core.jar"}, s.Artifacts.Packages.Package(p.ID()).Locations.CoordinateSet().Paths())
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"github.com/anchore/grype/grype/match"
+)
+
+// ResolvedFinding is a vulnerability whose fix is already included in the installed version of a package.
+type ResolvedFinding struct {
+	Vulnerability   ResolvedVulnerability `json:"vulnerability"`
+	FixedInVersions []string              `json:"fixedInVersions"`
+	CurrentVersion  string                `json:"currentVersion"`
+	Artifact        Package               `json:"artifact"`
+}
+
+// ResolvedVulnerability identifies the vulnerability record a finding was resolved against.
+type ResolvedVulnerability struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NewResolvedFindings creates the presentable form of the given resolved findings.
+func NewResolvedFindings(findings []match.ResolvedFinding) []ResolvedFinding {
+	var out []ResolvedFinding
+	for _, f := range findings {
+		fixedIn := f.Vulnerability.Fix.Versions
+		if fixedIn == nil {
+			fixedIn = []string{}
+		}
+		out = append(out, ResolvedFinding{
+			Vulnerability: ResolvedVulnerability{
+				ID:        f.Vulnerability.ID,
+				Namespace: f.Vulnerability.Namespace,
+			},
+			FixedInVersions: fixedIn,
+			CurrentVersion:  f.Package.Version,
+			Artifact:        newPackage(f.Package),
+		})
+	}
+	return out
+}
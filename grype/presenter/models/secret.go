@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/secret"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// ExploitableCombination is an exposed credential found alongside a vulnerable package.
+type ExploitableCombination struct {
+	Secret SecretFinding `json:"secret"`
+	Match  Match         `json:"match"`
+}
+
+// SecretFinding is the location and kind of an exposed credential (the secret value is never included).
+type SecretFinding struct {
+	Classification string `json:"classification"`
+	Path           string `json:"path"`
+	LineNumber     int64  `json:"lineNumber,omitempty"`
+}
+
+// NewExploitableCombinations creates the presentable form of secrets correlated with vulnerability matches.
+//
+//nolint:staticcheck // MetadataProvider is deprecated but still used internally
+func NewExploitableCombinations(combinations []secret.Combination, packages []pkg.Package, metadataProvider vulnerability.MetadataProvider) ([]ExploitableCombination, error) {
+	var out []ExploitableCombination
+	for _, c := range combinations {
+		p := pkg.ByID(c.Match.Package.ID, packages)
+		if p == nil {
+			return nil, fmt.Errorf("unable to find package in collection: %+v", c.Match.Package.ID)
+		}
+
+		matchModel, err := newMatch(c.Match, *p, metadataProvider)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, ExploitableCombination{
+			Secret: SecretFinding{
+				Classification: c.Secret.Classification,
+				Path:           c.Secret.Path,
+				LineNumber:     c.Secret.LineNumber,
+			},
+			Match: *matchModel,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/cvss"
+)
+
+// SeverityDerivation explains how the severity shown for a match was derived from the severities reported for the
+// vulnerability by each source.
+type SeverityDerivation struct {
+	Severity string           `json:"severity"` // the severity shown for the match
+	Reason   string           `json:"reason"`   // why the severity was selected
+	Sources  []SeveritySource `json:"sources"`  // every severity considered, in order of precedence
+}
+
+// SeveritySource is a single severity reported for the vulnerability (or one of its related vulnerabilities).
+type SeveritySource struct {
+	Vulnerability string  `json:"vulnerability"`
+	Source        string  `json:"source,omitempty"` // who reported the severity (e.g. "nvd@nist.gov")
+	Scheme        string  `json:"scheme,omitempty"` // how the value is expressed (e.g. "CVSS" or "CHMLN")
+	Value         string  `json:"value"`            // the value as reported (e.g. a CVSS vector or "high")
+	Score         float64 `json:"score,omitempty"`  // the CVSS base score, for CVSS values
+	Severity      string  `json:"severity"`         // the value interpreted as a severity
+	Rank          int     `json:"rank"`             // the precedence of the severity among those of the same vulnerability (lowest first)
+	Selected      bool    `json:"selected,omitempty"`
+}
+
+// NewSeverityDerivation explains the severity of the vulnerability with the given metadata. The severities of related
+// vulnerabilities are listed for reference, but never override the severity of the vulnerability itself.
+func NewSeverityDerivation(metadata *vulnerability.Metadata, related ...*vulnerability.Metadata) *SeverityDerivation {
+	if metadata == nil {
+		return nil
+	}
+
+	d := &SeverityDerivation{
+		Severity: metadata.Severity,
+		Sources:  toSeveritySources(metadata),
+	}
+	for _, r := range related {
+		if r != nil {
+			d.Sources = append(d.Sources, toSeveritySources(r)...)
+		}
+	}
+
+	switch {
+	case len(metadata.Severities) > 0:
+		selected := &d.Sources[0]
+		selected.Selected = true
+		d.Reason = fmt.Sprintf("highest ranked of %d severities reported for %s", len(metadata.Severities), metadata.ID)
+		if selected.Source != "" {
+			d.Reason += fmt.Sprintf(", from %s", selected.Source)
+		}
+		switch {
+		case selected.Severity == vulnerability.UnknownSeverity.String():
+			d.Reason += fmt.Sprintf(" (the value %q could not be interpreted as a severity)", selected.Value)
+		case selected.Score > 0:
+			d.Reason += fmt.Sprintf(" (CVSS base score %.1f)", selected.Score)
+		}
+	case vulnerability.ParseSeverity(metadata.Severity) != vulnerability.UnknownSeverity:
+		d.Reason = fmt.Sprintf("reported for %s without source details", metadata.ID)
+	default:
+		d.Reason = fmt.Sprintf("no severity is reported for %s", metadata.ID)
+		if len(d.Sources) > 0 {
+			d.Reason += " (severities of related vulnerabilities are not used)"
+		}
+	}
+
+	return d
+}
+
+func toSeveritySources(metadata *vulnerability.Metadata) []SeveritySource {
+	var out []SeveritySource
+	for _, s := range metadata.Severities {
+		source := SeveritySource{
+			Vulnerability: metadata.ID,
+			Source:        s.Source,
+			Scheme:        s.Scheme,
+			Value:         s.Value,
+			Severity:      s.Severity.String(),
+			Rank:          s.Rank,
+		}
+		if strings.HasPrefix(strings.ToUpper(s.Value), "CVSS:") {
+			if metrics, err := cvss.ParseMetricsFromVector(s.Value); err == nil && metrics != nil {
+				source.Score = metrics.BaseScore
+			}
+		}
+		out = append(out, source)
+	}
+	return out
+}
+
+// AddSeverityDerivations explains the severity of each match with the severities reported for its vulnerability and
+// related vulnerabilities.
+//
+//nolint:staticcheck // MetadataProvider is deprecated but still used internally
+func AddSeverityDerivations(matches []Match, metadataProvider vulnerability.MetadataProvider) error {
+	for i := range matches {
+		m := &matches[i]
+		metadata, err := metadataProvider.VulnerabilityMetadata(vulnerability.Reference{ID: m.Vulnerability.ID, Namespace: m.Vulnerability.Namespace}) //nolint:staticcheck // deprecated API still used internally
+		if err != nil {
+			return fmt.Errorf("unable to fetch vuln=%q metadata: %w", m.Vulnerability.ID, err)
+		}
+		if metadata == nil {
+			continue
+		}
+
+		var related []*vulnerability.Metadata
+		for _, r := range m.RelatedVulnerabilities {
+			relatedMetadata, err := metadataProvider.VulnerabilityMetadata(vulnerability.Reference{ID: r.ID, Namespace: r.Namespace}) //nolint:staticcheck // deprecated API still used internally
+			if err != nil {
+				return fmt.Errorf("unable to fetch related vuln=%q metadata: %w", r.ID, err)
+			}
+			related = append(related, relatedMetadata)
+		}
+
+		m.SeverityDerivation = NewSeverityDerivation(metadata, related...)
+	}
+	return nil
+}
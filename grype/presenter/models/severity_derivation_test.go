@@ -0,0 +1,107 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestNewSeverityDerivation(t *testing.T) {
+	nvd := &vulnerability.Metadata{
+		ID:       "CVE-2024-1234",
+		Severity: "Critical",
+		Severities: []vulnerability.SeverityRecord{
+			{Source: "nvd@nist.gov", Scheme: "CVSS", Value: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Severity: vulnerability.CriticalSeverity, Rank: 1},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		metadata *vulnerability.Metadata
+		related  []*vulnerability.Metadata
+		want     *SeverityDerivation
+	}{
+		{
+			name:     "no metadata",
+			metadata: nil,
+			want:     nil,
+		},
+		{
+			name: "highest ranked severity is selected",
+			metadata: &vulnerability.Metadata{
+				ID:       "GHSA-xxxx-yyyy-zzzz",
+				Severity: "High",
+				Severities: []vulnerability.SeverityRecord{
+					{Source: "security-advisories@github.com", Scheme: "CHMLN", Value: "high", Severity: vulnerability.HighSeverity, Rank: 1},
+					{Source: "nvd@nist.gov", Scheme: "CVSS", Value: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Severity: vulnerability.CriticalSeverity, Rank: 2},
+				},
+			},
+			related: []*vulnerability.Metadata{nvd},
+			want: &SeverityDerivation{
+				Severity: "High",
+				Reason:   "highest ranked of 2 severities reported for GHSA-xxxx-yyyy-zzzz, from security-advisories@github.com",
+				Sources: []SeveritySource{
+					{Vulnerability: "GHSA-xxxx-yyyy-zzzz", Source: "security-advisories@github.com", Scheme: "CHMLN", Value: "high", Severity: "high", Rank: 1, Selected: true},
+					{Vulnerability: "GHSA-xxxx-yyyy-zzzz", Source: "nvd@nist.gov", Scheme: "CVSS", Value: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Score: 9.8, Severity: "critical", Rank: 2},
+					{Vulnerability: "CVE-2024-1234", Source: "nvd@nist.gov", Scheme: "CVSS", Value: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Score: 9.8, Severity: "critical", Rank: 1},
+				},
+			},
+		},
+		{
+			name:     "CVSS base score is explained",
+			metadata: nvd,
+			want: &SeverityDerivation{
+				Severity: "Critical",
+				Reason:   "highest ranked of 1 severities reported for CVE-2024-1234, from nvd@nist.gov (CVSS base score 9.8)",
+				Sources: []SeveritySource{
+					{Vulnerability: "CVE-2024-1234", Source: "nvd@nist.gov", Scheme: "CVSS", Value: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Score: 9.8, Severity: "critical", Rank: 1, Selected: true},
+				},
+			},
+		},
+		{
+			name: "uninterpretable severity",
+			metadata: &vulnerability.Metadata{
+				ID:       "ELSA-2024-0001",
+				Severity: "Unknown",
+				Severities: []vulnerability.SeverityRecord{
+					{Scheme: "HML", Value: "moderate-ish", Severity: vulnerability.UnknownSeverity},
+				},
+			},
+			want: &SeverityDerivation{
+				Severity: "Unknown",
+				Reason:   `highest ranked of 1 severities reported for ELSA-2024-0001 (the value "moderate-ish" could not be interpreted as a severity)`,
+				Sources: []SeveritySource{
+					{Vulnerability: "ELSA-2024-0001", Scheme: "HML", Value: "moderate-ish", Severity: "unknown", Selected: true},
+				},
+			},
+		},
+		{
+			name:     "no severities with related severities",
+			metadata: &vulnerability.Metadata{ID: "ALAS-2024-0001", Severity: "Unknown"},
+			related:  []*vulnerability.Metadata{nvd},
+			want: &SeverityDerivation{
+				Severity: "Unknown",
+				Reason:   "no severity is reported for ALAS-2024-0001 (severities of related vulnerabilities are not used)",
+				Sources: []SeveritySource{
+					{Vulnerability: "CVE-2024-1234", Source: "nvd@nist.gov", Scheme: "CVSS", Value: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Score: 9.8, Severity: "critical", Rank: 1},
+				},
+			},
+		},
+		{
+			name:     "severity without records",
+			metadata: &vulnerability.Metadata{ID: "CVE-2024-5678", Severity: "Medium"},
+			want: &SeverityDerivation{
+				Severity: "Medium",
+				Reason:   "reported for CVE-2024-5678 without source details",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewSeverityDerivation(tt.metadata, tt.related...))
+		})
+	}
+}
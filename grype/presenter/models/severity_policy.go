@@ -0,0 +1,52 @@
+package models
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// PolicySeverity is the severity of a finding as raised or capped by a rule of the configured severity policy.
+type PolicySeverity struct {
+	Severity string `json:"severity"` // the severity after applying the rule
+	Original string `json:"original"` // the severity before applying the rule (the adjusted CVSS severity, if any)
+	Rule     string `json:"rule"`     // the rule that changed the severity
+}
+
+// ApplySeverityPolicy applies the given severity policy to the (ignored) matches of the document, recording the
+// severities changed by a rule as their policy severity, then re-sorts the matches since policy severities take
+// precedence when sorting.
+func ApplySeverityPolicy(doc *Document, policy match.SeverityPolicy, strategy SortStrategy) {
+	if !policy.Enabled() {
+		return
+	}
+	for i := range doc.Matches {
+		applySeverityPolicy(&doc.Matches[i], policy)
+	}
+	for i := range doc.IgnoredMatches {
+		applySeverityPolicy(&doc.IgnoredMatches[i].Match, policy)
+	}
+	SortMatches(doc.Matches, strategy)
+	SortIgnoredMatches(doc.IgnoredMatches, strategy)
+}
+
+func applySeverityPolicy(m *Match, policy match.SeverityPolicy) {
+	p := pkg.Package{
+		Type:        m.Artifact.Type,
+		Language:    m.Artifact.Language,
+		Annotations: m.Artifact.Annotations,
+	}
+	original := m.Vulnerability.EffectiveSeverity()
+	severity, rule := policy.Apply(p, vulnerability.ParseSeverity(original))
+	if rule == nil || severity == vulnerability.ParseSeverity(original) {
+		return
+	}
+	m.Vulnerability.PolicySeverity = &PolicySeverity{
+		Severity: cases.Title(language.English).String(severity.String()),
+		Original: original,
+		Rule:     rule.String(),
+	}
+}
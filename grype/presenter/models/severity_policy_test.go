@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestApplySeverityPolicy(t *testing.T) {
+	matchWith := func(id, severity string, p Package) Match {
+		return Match{
+			Vulnerability: Vulnerability{VulnerabilityMetadata: VulnerabilityMetadata{ID: id, Severity: severity}},
+			Artifact:      p,
+		}
+	}
+	deb := Package{Name: "libc6", Type: syftPkg.DebPkg}
+	junit := Package{Name: "junit", Type: syftPkg.JavaPkg, Language: syftPkg.Java,
+		Annotations: map[string][]string{pkg.DevDependencyAnnotation: {"maven-scope:test"}}}
+
+	doc := Document{
+		Matches: []Match{
+			matchWith("CVE-2024-0001", "Critical", junit),
+			matchWith("CVE-2024-0002", "Unknown", deb),
+			matchWith("CVE-2024-0003", "High", deb),
+		},
+		IgnoredMatches: []IgnoredMatch{
+			{Match: matchWith("CVE-2024-0004", "High", junit)},
+		},
+	}
+
+	policy := match.SeverityPolicy{Rules: []match.SeverityPolicyRule{
+		{Name: "os-unrated", Ecosystem: "os", UnknownOnly: true, Floor: vulnerability.MediumSeverity},
+		{Name: "dev-dependencies", DevOnly: true, Ceiling: vulnerability.LowSeverity},
+	}}
+	ApplySeverityPolicy(&doc, policy, SortBySeverity)
+
+	require.Len(t, doc.Matches, 3)
+	assert.Equal(t, "CVE-2024-0003", doc.Matches[0].Vulnerability.ID)
+	assert.Nil(t, doc.Matches[0].Vulnerability.PolicySeverity)
+
+	assert.Equal(t, "CVE-2024-0002", doc.Matches[1].Vulnerability.ID)
+	assert.Equal(t, &PolicySeverity{Severity: "Medium", Original: "Unknown", Rule: "os-unrated"}, doc.Matches[1].Vulnerability.PolicySeverity)
+	assert.Equal(t, "Medium", doc.Matches[1].Vulnerability.EffectiveSeverity())
+
+	assert.Equal(t, "CVE-2024-0001", doc.Matches[2].Vulnerability.ID)
+	assert.Equal(t, &PolicySeverity{Severity: "Low", Original: "Critical", Rule: "dev-dependencies"}, doc.Matches[2].Vulnerability.PolicySeverity)
+
+	require.NotNil(t, doc.IgnoredMatches[0].Vulnerability.PolicySeverity)
+	assert.Equal(t, "Low", doc.IgnoredMatches[0].Vulnerability.EffectiveSeverity())
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// SkippedPackage is a package that was not matched against the vulnerability database (e.g. because its version is
+// unknown), so the absence of matches for it says nothing about whether it is vulnerable.
+type SkippedPackage struct {
+	Package Package `json:"package"`
+	Reason  string  `json:"reason"`
+}
+
+// NewSkippedPackage creates the presentable form of a package that was skipped during matching.
+func NewSkippedPackage(p pkg.Package, reason string) SkippedPackage {
+	return SkippedPackage{
+		Package: newPackage(p),
+		Reason:  reason,
+	}
+}
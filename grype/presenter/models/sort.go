@@ -1,10 +1,12 @@
 package models
 
 import (
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/syft/syft/file"
 )
 
 type SortStrategy string
@@ -121,11 +123,21 @@ func combine(impls ...compareFunc) compareFunc {
 	}
 }
 
-// SortMatches sorts matches based on a strategy name
+// SortMatches sorts matches based on a strategy name. The resulting order is deterministic: matches that are equal
+// according to the strategy are further ordered by vulnerability namespace, package PURL, package locations, package
+// ID, and match details, so the same set of matches always results in the same order regardless of input order.
 func SortMatches(matches []Match, strategyName SortStrategy) {
 	sortWithStrategy(matches, getSortStrategy(strategyName))
 }
 
+// SortIgnoredMatches sorts ignored matches with the same (deterministic) ordering as SortMatches.
+func SortIgnoredMatches(matches []IgnoredMatch, strategyName SortStrategy) {
+	strategy := getSortStrategy(strategyName)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return strategy.less(matches[i].Match, matches[j].Match)
+	})
+}
+
 func getSortStrategy(strategyName SortStrategy) sortStrategyImpl {
 	strategy, exists := matchSortStrategy[strategyName]
 	if !exists {
@@ -136,18 +148,35 @@ func getSortStrategy(strategyName SortStrategy) sortStrategyImpl {
 }
 
 func sortWithStrategy(matches []Match, strategy sortStrategyImpl) {
-	sort.Slice(matches, func(i, j int) bool {
-		for _, compare := range strategy {
-			result := compare(matches[i], matches[j])
-			if result != 0 {
-				// we are implementing a "less" function, so we want to return true if the result is negative
-				return result < 0
-			}
-		}
-		return false // all comparisons are equal
+	sort.SliceStable(matches, func(i, j int) bool {
+		return strategy.less(matches[i], matches[j])
 	})
 }
 
+func (s sortStrategyImpl) less(a, b Match) bool {
+	for _, compare := range s {
+		result := compare(a, b)
+		if result != 0 {
+			// we are implementing a "less" function, so we want to return true if the result is negative
+			return result < 0
+		}
+	}
+	// all strategy comparisons are equal, fall back to attributes that make the order deterministic
+	return compareForDeterminism(a, b) < 0
+}
+
+// compareForDeterminism orders matches that are otherwise equal by the remaining identifying attributes
+func compareForDeterminism(a, b Match) int {
+	return combine(
+		compareByVulnerabilityID,
+		compareByVulnerabilityNamespace,
+		compareByPackagePURL,
+		compareByPackageLocations,
+		compareByPackageID,
+		compareByMatchDetails,
+	)(a, b)
+}
+
 func compareByVulnerabilityID(a, b Match) int {
 	aID := a.Vulnerability.ID
 	bID := b.Vulnerability.ID
@@ -162,9 +191,33 @@ func compareByVulnerabilityID(a, b Match) int {
 	}
 }
 
+func compareByVulnerabilityNamespace(a, b Match) int {
+	return strings.Compare(a.Vulnerability.Namespace, b.Vulnerability.Namespace)
+}
+
+func compareByPackagePURL(a, b Match) int {
+	return strings.Compare(a.Artifact.PURL, b.Artifact.PURL)
+}
+
+func compareByPackageLocations(a, b Match) int {
+	return slices.CompareFunc(a.Artifact.Locations, b.Artifact.Locations, func(x, y file.Location) int {
+		return strings.Compare(x.RealPath+"@"+x.FileSystemID, y.RealPath+"@"+y.FileSystemID)
+	})
+}
+
+func compareByPackageID(a, b Match) int {
+	return strings.Compare(a.Artifact.ID, b.Artifact.ID)
+}
+
+func compareByMatchDetails(a, b Match) int {
+	return slices.CompareFunc(a.MatchDetails, b.MatchDetails, func(x, y MatchDetails) int {
+		return strings.Compare(x.Type+"@"+x.Matcher, y.Type+"@"+y.Matcher)
+	})
+}
+
 func compareBySeverity(a, b Match) int {
-	aScore := severityPriority(a.Vulnerability.Severity)
-	bScore := severityPriority(b.Vulnerability.Severity)
+	aScore := severityPriority(a.Vulnerability.EffectiveSeverity())
+	bScore := severityPriority(b.Vulnerability.EffectiveSeverity())
 
 	switch {
 	case aScore < bScore: // higher severity first
@@ -6,6 +6,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/file"
 )
 
 func TestSortStrategies(t *testing.T) {
@@ -497,6 +499,45 @@ func TestSortWithStrategy(t *testing.T) {
 	}
 }
 
+func TestSortMatches_deterministic(t *testing.T) {
+	newMatch := func(namespace, purl, id string, paths ...string) Match {
+		var locations file.Locations
+		for _, p := range paths {
+			locations = append(locations, file.NewLocation(p))
+		}
+		return Match{
+			Vulnerability: Vulnerability{
+				VulnerabilityMetadata: VulnerabilityMetadata{ID: "CVE-2023-1111", Namespace: namespace, Severity: "high"},
+			},
+			Artifact: Package{ID: id, Name: "pkg", Version: "1.0.0", Type: "npm", PURL: purl, Locations: locations},
+		}
+	}
+
+	// all matches are equal for every sort strategy and differ only in identifying attributes
+	expected := []Match{
+		newMatch("github:language:javascript", "pkg:npm/pkg@1.0.0", "id-1", "/a/package.json"),
+		newMatch("github:language:javascript", "pkg:npm/pkg@1.0.0", "id-2", "/b/package.json"),
+		newMatch("github:language:javascript", "pkg:npm/pkg@1.0.0", "id-0", "/c/package.json"),
+		newMatch("github:language:javascript", "pkg:npm/pkg@1.0.0?arch=x", "id-3", "/a/package.json"),
+		newMatch("nvd:cpe", "pkg:npm/pkg@1.0.0", "id-1", "/a/package.json"),
+	}
+
+	for _, strategy := range SortStrategies() {
+		t.Run(strategy.String(), func(t *testing.T) {
+			for _, order := range [][]int{{0, 1, 2, 3, 4}, {4, 3, 2, 1, 0}, {2, 4, 0, 3, 1}} {
+				var matches []Match
+				for _, idx := range order {
+					matches = append(matches, expected[idx])
+				}
+
+				SortMatches(matches, strategy)
+
+				assert.Equal(t, expected, matches, "order %v resulted in a different sort", order)
+			}
+		})
+	}
+}
+
 func deepCopyMatches(matches []Match) []Match {
 	result := make([]Match, len(matches))
 	copy(result, matches)
@@ -35,6 +35,11 @@ func newSource(src syftSource.Description) (source, error) {
 			Type:   "zarf-package",
 			Target: m.Path,
 		}, nil
+	case pkg.FunctionArchiveMetadata:
+		return source{
+			Type:   "function-archive",
+			Target: m,
+		}, nil
 	case syftSource.ImageMetadata:
 		// ensure that empty collections are not shown as null
 		if m.RepoDigests == nil {
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// UnmanagedBinary is a binary not owned by any package (e.g. dropped into an image by hand), along with the
+// vulnerabilities matched against the version embedded in it.
+type UnmanagedBinary struct {
+	Artifact Package `json:"artifact"`
+	Matches  []Match `json:"matches"`
+}
+
+// NewUnmanagedBinaries creates the findings for every unmanaged binary found, including binaries without any matches
+// so that all dropped-in binaries are accounted for.
+//
+//nolint:staticcheck // MetadataProvider is deprecated but still used internally
+func NewUnmanagedBinaries(packages []pkg.Package, matches []match.Match, metadataProvider vulnerability.MetadataProvider) ([]UnmanagedBinary, error) {
+	var binaries []pkg.Package
+	for _, p := range packages {
+		if pkg.IsUnmanagedBinary(p) {
+			binaries = append(binaries, p)
+		}
+	}
+	slices.SortFunc(binaries, func(a, b pkg.Package) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version), cmp.Compare(a.ID, b.ID))
+	})
+
+	var out []UnmanagedBinary
+	index := map[pkg.ID]int{}
+	for _, p := range binaries {
+		index[p.ID] = len(out)
+		out = append(out, UnmanagedBinary{
+			Artifact: newPackage(p),
+			Matches:  make([]Match, 0),
+		})
+	}
+
+	for _, m := range matches {
+		i, ok := index[m.Package.ID]
+		if !ok {
+			return nil, fmt.Errorf("unable to find unmanaged binary in collection: %+v", m.Package.ID)
+		}
+		p := pkg.ByID(m.Package.ID, packages)
+
+		matchModel, err := newMatch(m, *p, metadataProvider)
+		if err != nil {
+			return nil, err
+		}
+		out[i].Matches = append(out[i].Matches, *matchModel)
+	}
+	return out, nil
+}
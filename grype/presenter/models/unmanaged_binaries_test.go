@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestNewUnmanagedBinaries(t *testing.T) {
+	curl := pkg.Package{ID: "curl-id", Name: "curl", Version: "7.88.0", Type: syftPkg.BinaryPkg}
+	busybox := pkg.Package{ID: "busybox-id", Name: "busybox", Version: "1.36.1", Type: syftPkg.BinaryPkg}
+	openssl := pkg.Package{ID: "openssl-id", Name: "openssl", Version: "3.0.11-1", Type: syftPkg.DebPkg}
+
+	vuln := vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{ID: "CVE-2023-38545", Namespace: "nvd:cpe"},
+	}
+	matches := []match.Match{{Vulnerability: vuln, Package: curl}}
+
+	got, err := NewUnmanagedBinaries([]pkg.Package{openssl, curl, busybox}, matches, mock.VulnerabilityProvider(vuln))
+	require.NoError(t, err)
+
+	// every unmanaged binary is reported (sorted by name), including those without matches
+	require.Len(t, got, 2)
+	assert.Equal(t, "busybox", got[0].Artifact.Name)
+	assert.Empty(t, got[0].Matches)
+	assert.Equal(t, "curl", got[1].Artifact.Name)
+	require.Len(t, got[1].Matches, 1)
+	assert.Equal(t, "CVE-2023-38545", got[1].Matches[0].Vulnerability.ID)
+
+	_, err = NewUnmanagedBinaries([]pkg.Package{openssl}, []match.Match{{Vulnerability: vuln, Package: openssl}}, mock.VulnerabilityProvider(vuln))
+	assert.Error(t, err)
+}
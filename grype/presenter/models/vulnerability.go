@@ -2,6 +2,7 @@ package models
 
 import (
 	"sort"
+	"time"
 
 	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/grype/vulnerability"
@@ -13,12 +14,29 @@ type Vulnerability struct {
 	Fix        Fix        `json:"fix"`
 	Advisories []Advisory `json:"advisories"`
 	Risk       float64    `json:"risk"`
+	Timeline   *Timeline  `json:"timeline,omitempty"`
+	// AdjustedCvss is the CVSS score adjusted for the environment of the scanned asset (only with --asset-class)
+	AdjustedCvss *AdjustedCvss `json:"adjustedCvss,omitempty"`
+	// PolicySeverity is the severity raised or capped by the severity policy (only when a rule changed it)
+	PolicySeverity *PolicySeverity `json:"policySeverity,omitempty"`
+}
+
+// Timeline collects the known dates in the lifecycle of a vulnerability (formatted as YYYY-MM-DD), which is useful
+// for SLA tracking (e.g. how long a fix has been available).
+type Timeline struct {
+	Published    string `json:"published,omitempty"`
+	Modified     string `json:"modified,omitempty"`
+	KEVDateAdded string `json:"kevDateAdded,omitempty"`
+	FixAvailable string `json:"fixAvailable,omitempty"` // the earliest date any fix became available
 }
 
 type Fix struct {
 	Versions  []string       `json:"versions"`
 	State     string         `json:"state"`
 	Available []FixAvailable `json:"available,omitempty"`
+	Unbounded bool           `json:"unbounded,omitempty"` // the affected range has no upper bound and no fix is known
+	// Entitlement is the subscription required to install the fix versions (e.g. "ubuntu-pro")
+	Entitlement string `json:"entitlement,omitempty"`
 }
 
 type FixAvailable struct {
@@ -56,13 +74,37 @@ func NewVulnerability(vuln vulnerability.Vulnerability, metadata *vulnerability.
 	return Vulnerability{
 		VulnerabilityMetadata: NewVulnerabilityMetadata(vuln.ID, vuln.Namespace, metadata),
 		Fix: Fix{
-			Versions:  sortVersions(fixedInVersions, versionFormat),
-			State:     string(vuln.Fix.State),
-			Available: getFixAvailable(vuln.Fix.Available),
+			Versions:    sortVersions(fixedInVersions, versionFormat),
+			State:       string(vuln.Fix.State),
+			Available:   getFixAvailable(vuln.Fix.Available),
+			Unbounded:   vulnerability.IsUnbounded(vuln),
+			Entitlement: vuln.Fix.Entitlement,
 		},
 		Advisories: advisories,
 		Risk:       metadata.RiskScore(),
+		Timeline:   newTimeline(vuln.Fix, metadata),
+	}
+}
+
+func newTimeline(fix vulnerability.Fix, metadata *vulnerability.Metadata) *Timeline {
+	t := Timeline{
+		Published:    formatDate(metadata.PublishedDate),
+		Modified:     formatDate(metadata.ModifiedDate),
+		FixAvailable: formatDate(fix.EarliestAvailable()),
+	}
+
+	var kevDateAdded *time.Time
+	for _, kev := range metadata.KnownExploited {
+		if kev.DateAdded != nil && (kevDateAdded == nil || kev.DateAdded.Before(*kevDateAdded)) {
+			kevDateAdded = kev.DateAdded
+		}
+	}
+	t.KEVDateAdded = formatDate(kevDateAdded)
+
+	if t == (Timeline{}) {
+		return nil
 	}
+	return &t
 }
 
 func getFixAvailable(fixesAvailable []vulnerability.FixAvailable) []FixAvailable {
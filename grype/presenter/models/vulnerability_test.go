@@ -155,3 +155,53 @@ func Test_getFixAvailable(t *testing.T) {
 		})
 	}
 }
+
+func Test_newTimeline(t *testing.T) {
+	published := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	modified := time.Date(2023, 2, 3, 0, 0, 0, 0, time.UTC)
+	kevAdded := time.Date(2023, 4, 5, 0, 0, 0, 0, time.UTC)
+	kevAddedLater := time.Date(2023, 6, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		fix      vulnerability.Fix
+		metadata vulnerability.Metadata
+		expected *Timeline
+	}{
+		{
+			name:     "no dates",
+			expected: nil,
+		},
+		{
+			name: "all dates",
+			fix: vulnerability.Fix{
+				Available: []vulnerability.FixAvailable{
+					{Version: "2.0.0", Date: time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)},
+					{Version: "1.2.3", Date: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			metadata: vulnerability.Metadata{
+				PublishedDate: &published,
+				ModifiedDate:  &modified,
+				KnownExploited: []vulnerability.KnownExploited{
+					{CVE: "CVE-2023-0001", DateAdded: &kevAddedLater},
+					{CVE: "CVE-2023-0001", DateAdded: &kevAdded},
+				},
+			},
+			expected: &Timeline{
+				Published:    "2023-01-02",
+				Modified:     "2023-02-03",
+				KEVDateAdded: "2023-04-05",
+				FixAvailable: "2023-03-01",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := cmp.Diff(tt.expected, newTimeline(tt.fix, &tt.metadata)); d != "" {
+				t.Errorf("unexpected timeline (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Package push sends scan results to a central ingest service over HTTP.
+
+Results are sent as one or more JSON POST requests to the configured URL. Each request body is a Payload:
+
+	{
+	  "schema":   {"version": "1.0.0"},
+	  "batch":    {"id": "<shared by all requests of one scan>", "index": 0, "total": 2},
+	  "document": { ...grype JSON document... }
+	}
+
+Matches are split across batches (see Config.BatchSize). All batches carry the source, distro, and descriptor of the
+document; the remaining sections (ignored matches, unmanaged binaries, license violations, etc.) are only sent with
+the first batch. A receiver can reassemble the full document by concatenating the matches of all batches with the same
+batch ID, in index order.
+
+Requests that fail with a network error, a 429 response, or a 5xx response are retried; any other non-2xx response
+fails the push immediately.
+*/
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/grype/internal/proxy"
+)
+
+// SchemaVersion is the version of the ingest payload schema. The major version is incremented on breaking changes.
+const SchemaVersion = "1.0.0"
+
+const (
+	defaultBatchSize  = 500
+	defaultRetryWait  = time.Second
+	defaultTimeout    = 30 * time.Second
+	contentTypeHeader = "application/json"
+)
+
+// Payload is the body of a single ingest request.
+type Payload struct {
+	Schema   Schema          `json:"schema"`
+	Batch    Batch           `json:"batch"`
+	Document models.Document `json:"document"`
+}
+
+// Schema describes the version of the payload schema.
+type Schema struct {
+	Version string `json:"version"`
+}
+
+// Batch identifies a single request within the set of requests for one scan.
+type Batch struct {
+	ID    string `json:"id"`
+	Index int    `json:"index"`
+	Total int    `json:"total"`
+}
+
+// Config describes where and how to push results.
+type Config struct {
+	URL string
+	// Token is sent as a bearer token (takes precedence over Username/Password)
+	Token    string
+	Username string
+	Password string
+	Headers  map[string]string
+	// BatchSize is the maximum number of matches per request (defaults to 500)
+	BatchSize int
+	// MaxRetries is the number of times a failed request is retried
+	MaxRetries int
+	// RetryWait is the initial wait before retrying, doubled on every attempt (defaults to 1s)
+	RetryWait time.Duration
+	// Timeout applies to each individual request (defaults to 30s)
+	Timeout   time.Duration
+	UserAgent string
+	// Proxy selects the proxy for requests (the proxy configured by the environment when empty)
+	Proxy proxy.Rule
+}
+
+// Pusher sends documents to an ingest endpoint.
+type Pusher struct {
+	config Config
+	client *http.Client
+}
+
+// NewPusher validates the configuration and returns a Pusher for it.
+func NewPusher(cfg Config) (*Pusher, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid push URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid push URL %q: must be an http or https URL", cfg.URL)
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryWait <= 0 {
+		cfg.RetryWait = defaultRetryWait
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if err := cfg.Proxy.Validate(); err != nil {
+		return nil, err
+	}
+	return &Pusher{
+		config: cfg,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: cfg.Proxy.Transport(cleanhttp.DefaultTransport()),
+		},
+	}, nil
+}
+
+// Push sends the document to the ingest endpoint in one or more batches.
+func (p *Pusher) Push(ctx context.Context, doc models.Document) error {
+	batchID, err := newBatchID()
+	if err != nil {
+		return err
+	}
+
+	payloads := p.batches(batchID, doc)
+	for _, payload := range payloads {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("unable to encode push payload: %w", err)
+		}
+		if err := p.send(ctx, body); err != nil {
+			return fmt.Errorf("unable to push batch %d of %d: %w", payload.Batch.Index+1, payload.Batch.Total, err)
+		}
+	}
+
+	log.WithFields("url", p.config.URL, "batches", len(payloads), "matches", len(doc.Matches)).Debug("pushed scan results")
+	return nil
+}
+
+func (p *Pusher) batches(batchID string, doc models.Document) []Payload {
+	total := (len(doc.Matches) + p.config.BatchSize - 1) / p.config.BatchSize
+	if total == 0 {
+		// always send at least one request so the receiver learns about the scan (and the other document sections)
+		total = 1
+	}
+
+	payloads := make([]Payload, 0, total)
+	for i := 0; i < total; i++ {
+		start := i * p.config.BatchSize
+		end := min(start+p.config.BatchSize, len(doc.Matches))
+
+		// the first batch carries every section of the document, the others only identify the scan
+		batchDoc := models.Document{
+			Source:           doc.Source,
+			Distro:           doc.Distro,
+			OSIdentification: doc.OSIdentification,
+			Descriptor:       doc.Descriptor,
+		}
+		if i == 0 {
+			batchDoc = doc
+		}
+		batchDoc.Matches = make([]models.Match, 0, end-start)
+		if start < end {
+			batchDoc.Matches = append(batchDoc.Matches, doc.Matches[start:end]...)
+		}
+
+		payloads = append(payloads, Payload{
+			Schema:   Schema{Version: SchemaVersion},
+			Batch:    Batch{ID: batchID, Index: i, Total: total},
+			Document: batchDoc,
+		})
+	}
+	return payloads
+}
+
+func (p *Pusher) send(ctx context.Context, body []byte) error {
+	wait := p.config.RetryWait
+	var lastErr error
+	for attempt := 0; attempt <= p.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			log.WithFields("attempt", attempt, "wait", wait, "error", lastErr).Debug("retrying push")
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			wait *= 2
+		}
+
+		retryable, err := p.sendOnce(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable {
+			return err
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", p.config.MaxRetries+1, lastErr)
+}
+
+func (p *Pusher) sendOnce(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentTypeHeader)
+	if p.config.UserAgent != "" {
+		req.Header.Set("User-Agent", p.config.UserAgent)
+	}
+	for k, v := range p.config.Headers {
+		req.Header.Set(k, v)
+	}
+	switch {
+	case p.config.Token != "":
+		req.Header.Set("Authorization", "Bearer "+p.config.Token)
+	case p.config.Username != "":
+		req.SetBasicAuth(p.config.Username, p.config.Password)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	// drain (a bounded amount of) the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("unexpected response status: %s", resp.Status)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+func newBatchID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("unable to generate push batch ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,115 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+func TestPusher_Push(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads []Payload
+		calls    int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			// the first request fails and should be retried
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "Bearer the-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "value", r.Header.Get("X-Custom"))
+
+		var p Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		payloads = append(payloads, p)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pusher, err := NewPusher(Config{
+		URL:        server.URL,
+		Token:      "the-token",
+		Headers:    map[string]string{"X-Custom": "value"},
+		BatchSize:  2,
+		MaxRetries: 1,
+		RetryWait:  time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	doc := models.Document{
+		Matches: []models.Match{
+			{Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-1"}}},
+			{Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2"}}},
+			{Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-3"}}},
+		},
+		IgnoredMatches: []models.IgnoredMatch{
+			{Match: models.Match{Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-4"}}}},
+		},
+		UnusedIgnoreRules: []models.IgnoreRule{{Vulnerability: "CVE-5"}},
+		Skipped:           []models.SkippedPackage{{Reason: "time budget exceeded"}},
+	}
+	require.NoError(t, pusher.Push(context.Background(), doc))
+
+	require.Len(t, payloads, 2)
+	assert.Equal(t, SchemaVersion, payloads[0].Schema.Version)
+	assert.NotEmpty(t, payloads[0].Batch.ID)
+	assert.Equal(t, payloads[0].Batch.ID, payloads[1].Batch.ID)
+	assert.Equal(t, Batch{ID: payloads[0].Batch.ID, Index: 1, Total: 2}, payloads[1].Batch)
+	assert.Len(t, payloads[0].Document.Matches, 2)
+	assert.Len(t, payloads[1].Document.Matches, 1)
+	// the other sections of the document are all sent with the first batch
+	first := payloads[0].Document
+	first.Matches = doc.Matches
+	assert.Equal(t, doc, first)
+	assert.Empty(t, payloads[1].Document.IgnoredMatches)
+	assert.Empty(t, payloads[1].Document.UnusedIgnoreRules)
+	assert.Empty(t, payloads[1].Document.Skipped)
+}
+
+func TestPusher_Push_noRetryOnClientError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	pusher, err := NewPusher(Config{
+		URL:        server.URL,
+		Username:   "user",
+		Password:   "pass",
+		MaxRetries: 3,
+		RetryWait:  time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	err = pusher.Push(context.Background(), models.Document{})
+	require.ErrorContains(t, err, "401")
+	assert.Equal(t, 1, calls)
+}
+
+func TestNewPusher_invalidURL(t *testing.T) {
+	for _, u := range []string{"", "ftp://example.com", "://bad"} {
+		_, err := NewPusher(Config{URL: u})
+		assert.Error(t, err, u)
+	}
+}
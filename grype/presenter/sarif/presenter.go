@@ -342,6 +342,11 @@ func securitySeverityValue(m models.Match) string {
 }
 
 func levelValue(m models.Match) string {
+	// a known-malicious package is an error regardless of the severity of the record
+	if m.Malicious {
+		return "error"
+	}
+
 	severity := vulnerability.ParseSeverity(m.Vulnerability.Severity)
 	switch severity {
 	case vulnerability.CriticalSeverity:
@@ -398,7 +403,7 @@ func shortDescription(m models.Match) string {
 func (p Presenter) sarifResults() []*sarif.Result {
 	out := make([]*sarif.Result, 0) // make sure we have at least an empty array
 	for _, m := range p.document.Matches {
-		out = append(out, &sarif.Result{
+		result := &sarif.Result{
 			RuleID:  sp(p.ruleID(m)),
 			Level:   sp(levelValue(m)),
 			Message: p.resultMessage(m),
@@ -407,8 +412,22 @@ func (p Presenter) sarifResults() []*sarif.Result {
 			// GitHub requires partialFingerprints to upload to the API; these are automatically filled in
 			// when using the CodeQL upload action. See: https://docs.github.com/en/code-security/code-scanning/integrating-with-code-scanning/sarif-support-for-code-scanning#providing-data-to-track-code-scanning-alerts-across-runs
 			PartialFingerprints: p.partialFingerprints(m),
-			Locations:           p.locations(m),
-		})
+			// the stable identity of the finding across runs (see models.NewFingerprint)
+			Fingerprints: map[string]any{
+				"grypeFingerprint/v1": m.Fingerprint,
+			},
+			Locations: p.locations(m),
+		}
+		if c := m.Confidence(); c > 0 {
+			result.Properties = sarif.Properties{"confidence": c}
+		}
+		if m.Malicious {
+			if result.Properties == nil {
+				result.Properties = sarif.Properties{}
+			}
+			result.Properties["malicious"] = true
+		}
+		out = append(out, result)
 	}
 	return out
 }
@@ -438,6 +457,10 @@ func (p Presenter) resultMessage(m models.Match) sarif.Message {
 	}
 	message := fmt.Sprintf("A %s vulnerability in %s package: %s, version %s was found %s",
 		severityText(m), m.Artifact.Type, m.Artifact.Name, m.Artifact.Version, src)
+	if m.Malicious {
+		message = fmt.Sprintf("A known-malicious %s package: %s, version %s was found %s (the package should be removed)",
+			m.Artifact.Type, m.Artifact.Name, m.Artifact.Version, src)
+	}
 
 	return sarif.Message{
 		Text: &message,
@@ -0,0 +1,252 @@
+package spdx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spdx/tools-golang/convert"
+	"github.com/spdx/tools-golang/spdx/v3/v3_0"
+
+	"github.com/anchore/clio"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/syft/syft/format/common/spdxhelpers"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// Presenter writes an SPDX 3.0 JSON-LD report, describing matches with the security profile
+type Presenter struct {
+	id       clio.Identification
+	document models.Document
+	sbom     *sbom.SBOM
+}
+
+// NewJSONPresenter is a *Presenter constructor
+func NewJSONPresenter(pb models.PresenterConfig) *Presenter {
+	return &Presenter{
+		id:       pb.ID,
+		document: pb.Document,
+		sbom:     pb.SBOM,
+	}
+}
+
+// Present creates an SPDX 3.0 JSON-LD report
+func (p *Presenter) Present(output io.Writer) error {
+	doc, err := p.toSPDXDocument()
+	if err != nil {
+		return err
+	}
+	return doc.Write(output)
+}
+
+func (p *Presenter) toSPDXDocument() (*v3_0.Document, error) {
+	if p.sbom == nil {
+		return nil, fmt.Errorf("no SBOM provided to SPDX presenter")
+	}
+
+	// note: this uses the syft SPDX helpers to create a consistent SPDX document across syft and grype,
+	// then upgrades the document to SPDX 3.0 so that we can attach security profile elements
+	latestDoc := spdxhelpers.ToFormatModel(*p.sbom)
+	if latestDoc == nil {
+		return nil, fmt.Errorf("unable to convert SBOM to SPDX document")
+	}
+
+	doc := &v3_0.Document{}
+	if err := convert.Document(latestDoc, doc); err != nil {
+		return nil, fmt.Errorf("unable to convert SBOM to SPDX 3.0 document: %w", err)
+	}
+
+	if !hasProfile(doc.ProfileConformances, v3_0.ProfileIdentifierType_Security) {
+		doc.ProfileConformances = append(doc.ProfileConformances, v3_0.ProfileIdentifierType_Security)
+	}
+
+	root := rootSBOM(doc)
+	if root == nil {
+		return nil, fmt.Errorf("unable to find SBOM root element in SPDX document")
+	}
+
+	elements := newVulnerabilityElements(packageIndex(root.GetElements()))
+	for _, m := range p.document.Matches {
+		elements.add(m)
+	}
+
+	root.SetElements(append(root.GetElements(), elements.all()...))
+
+	return doc, nil
+}
+
+func hasProfile(profiles []v3_0.ProfileIdentifierType, profile v3_0.ProfileIdentifierType) bool {
+	for _, existing := range profiles {
+		if existing == profile {
+			return true
+		}
+	}
+	return false
+}
+
+func rootSBOM(doc *v3_0.Document) v3_0.AnySBOM {
+	for _, e := range doc.RootElements {
+		if s, ok := e.(v3_0.AnySBOM); ok {
+			return s
+		}
+	}
+	return nil
+}
+
+// packageIndex creates a lookup of SPDX packages keyed by the same attributes found on match artifacts
+func packageIndex(elements v3_0.ElementList) map[string]v3_0.AnyPackage {
+	out := make(map[string]v3_0.AnyPackage)
+	for _, p := range elements.Packages() {
+		out[packageKey(p.GetName(), p.GetVersion(), string(p.GetPackageURL()))] = p
+	}
+	return out
+}
+
+func packageKey(name, version, purl string) string {
+	return strings.Join([]string{name, version, purl}, "|")
+}
+
+// vulnerabilityElements collects the security profile elements for all matches, ensuring that each
+// vulnerability is represented by a single element regardless of how many packages it affects
+type vulnerabilityElements struct {
+	packages        map[string]v3_0.AnyPackage
+	vulnerabilities map[string]*v3_0.Vulnerability
+	relationships   v3_0.ElementList
+	order           []string
+}
+
+func newVulnerabilityElements(packages map[string]v3_0.AnyPackage) *vulnerabilityElements {
+	return &vulnerabilityElements{
+		packages:        packages,
+		vulnerabilities: make(map[string]*v3_0.Vulnerability),
+	}
+}
+
+func (v *vulnerabilityElements) add(m models.Match) {
+	p, ok := v.packages[packageKey(m.Artifact.Name, m.Artifact.Version, m.Artifact.PURL)]
+	if !ok {
+		// the package is not represented in the SBOM, so there is nothing to relate the vulnerability to
+		return
+	}
+
+	vuln := v.vulnerability(m.Vulnerability)
+
+	v.relationships = append(v.relationships,
+		&v3_0.Relationship{
+			From: p,
+			To:   v3_0.ElementList{vuln},
+			Type: v3_0.RelationshipType_HasAssociatedVulnerability,
+		},
+		&v3_0.VexAffectedVulnAssessmentRelationship{
+			From:                vuln,
+			To:                  v3_0.ElementList{p},
+			Type:                v3_0.RelationshipType_Affects,
+			ActionStatement:     actionStatement(m),
+			ExternalIdentifiers: fingerprintIdentifiers(m),
+		},
+	)
+
+	for _, score := range m.Vulnerability.Cvss {
+		if !strings.HasPrefix(score.Version, "3") {
+			continue
+		}
+		v.relationships = append(v.relationships, &v3_0.CvssV3VulnAssessmentRelationship{
+			From:         vuln,
+			To:           v3_0.ElementList{p},
+			Type:         v3_0.RelationshipType_HasAssessmentFor,
+			Score:        score.Metrics.BaseScore,
+			Severity:     cvssSeverity(score.Metrics.BaseScore),
+			VectorString: score.Vector,
+			SuppliedBy:   supplier(score.Source),
+		})
+	}
+}
+
+func (v *vulnerabilityElements) vulnerability(m models.Vulnerability) *v3_0.Vulnerability {
+	if existing, ok := v.vulnerabilities[m.ID]; ok {
+		return existing
+	}
+
+	identifierType := v3_0.ExternalIdentifierType_SecurityOther
+	if strings.HasPrefix(strings.ToLower(m.ID), "cve-") {
+		identifierType = v3_0.ExternalIdentifierType_Cve
+	}
+
+	var refs v3_0.ExternalRefList
+	for _, url := range m.URLs {
+		refs = append(refs, &v3_0.ExternalRef{
+			Type:     v3_0.ExternalRefType_SecurityAdvisory,
+			Locators: []string{url},
+		})
+	}
+
+	vuln := &v3_0.Vulnerability{
+		Name:        m.ID,
+		Description: m.Description,
+		ExternalIdentifiers: v3_0.ExternalIdentifierList{
+			&v3_0.ExternalIdentifier{
+				Type:       identifierType,
+				Identifier: m.ID,
+			},
+		},
+		ExternalRefs: refs,
+	}
+
+	v.vulnerabilities[m.ID] = vuln
+	v.order = append(v.order, m.ID)
+	return vuln
+}
+
+func (v *vulnerabilityElements) all() v3_0.ElementList {
+	var out v3_0.ElementList
+	for _, id := range v.order {
+		out = append(out, v.vulnerabilities[id])
+	}
+	return append(out, v.relationships...)
+}
+
+// fingerprintIdentifiers returns the stable identity of the finding across scans (see models.NewFingerprint) as an
+// external identifier of the assessment of the finding.
+func fingerprintIdentifiers(m models.Match) v3_0.ExternalIdentifierList {
+	if m.Fingerprint == "" {
+		return nil
+	}
+	return v3_0.ExternalIdentifierList{
+		&v3_0.ExternalIdentifier{
+			Type:             v3_0.ExternalIdentifierType_Other,
+			Identifier:       m.Fingerprint,
+			IssuingAuthority: "grype",
+			Comment:          "grype fingerprint",
+		},
+	}
+}
+
+func actionStatement(m models.Match) string {
+	if len(m.Vulnerability.Fix.Versions) > 0 {
+		return fmt.Sprintf("Upgrade %s to version %s", m.Artifact.Name, strings.Join(m.Vulnerability.Fix.Versions, ", "))
+	}
+	return "No fix is currently available"
+}
+
+func supplier(source string) v3_0.AnyAgent {
+	if source == "" {
+		return nil
+	}
+	return &v3_0.Organization{Name: source}
+}
+
+// cvssSeverity maps a CVSS v3 base score to the qualitative severity rating defined by the CVSS specification
+func cvssSeverity(score float64) v3_0.CvssSeverityType {
+	switch {
+	case score >= 9.0:
+		return v3_0.CvssSeverityType_Critical
+	case score >= 7.0:
+		return v3_0.CvssSeverityType_High
+	case score >= 4.0:
+		return v3_0.CvssSeverityType_Medium
+	case score > 0:
+		return v3_0.CvssSeverityType_Low
+	default:
+		return v3_0.CvssSeverityType_None
+	}
+}
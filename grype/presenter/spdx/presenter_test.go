@@ -0,0 +1,80 @@
+package spdx
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/internal"
+)
+
+func TestSPDX3JSONPresenter(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme internal.SyftSource
+	}{
+		{
+			name:   "image",
+			scheme: internal.ImageSource,
+		},
+		{
+			name:   "directory",
+			scheme: internal.DirectorySource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pb := internal.GeneratePresenterConfig(t, tt.scheme)
+
+			var buffer bytes.Buffer
+			require.NoError(t, NewJSONPresenter(pb).Present(&buffer))
+
+			var doc struct {
+				Context string           `json:"@context"`
+				Graph   []map[string]any `json:"@graph"`
+			}
+			require.NoError(t, json.Unmarshal(buffer.Bytes(), &doc))
+			assert.Contains(t, doc.Context, "spdx.org/rdf/3.0")
+
+			var vulnNames []string
+			relationshipTypes := map[string]int{}
+			var fingerprints []string
+			for _, element := range doc.Graph {
+				switch element["type"] {
+				case "security_Vulnerability":
+					vulnNames = append(vulnNames, element["name"].(string))
+				case "Relationship", "security_VexAffectedVulnAssessmentRelationship", "security_CvssV3VulnAssessmentRelationship":
+					relationshipTypes[element["relationshipType"].(string)]++
+				}
+				if ids, ok := element["externalIdentifier"].([]any); ok && element["type"] == "security_VexAffectedVulnAssessmentRelationship" {
+					for _, id := range ids {
+						fingerprints = append(fingerprints, id.(map[string]any)["identifier"].(string))
+					}
+				}
+			}
+
+			assert.ElementsMatch(t, []string{"CVE-1999-0001", "CVE-1999-0002"}, vulnNames)
+			assert.Equal(t, len(pb.Document.Matches), relationshipTypes["hasAssociatedVulnerability"])
+			assert.Equal(t, len(pb.Document.Matches), relationshipTypes["affects"])
+
+			// the affects assessments carry the fingerprint of their match
+			var want []string
+			for _, m := range pb.Document.Matches {
+				want = append(want, m.Fingerprint)
+			}
+			assert.ElementsMatch(t, want, fingerprints)
+		})
+	}
+}
+
+func TestPresent_requiresSBOM(t *testing.T) {
+	pb := internal.GeneratePresenterConfig(t, internal.ImageSource)
+	pb.SBOM = nil
+
+	var buffer bytes.Buffer
+	require.Error(t, NewJSONPresenter(pb).Present(&buffer))
+}
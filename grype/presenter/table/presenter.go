@@ -12,13 +12,19 @@ import (
 	"github.com/scylladb/go-set/strset"
 
 	"github.com/anchore/grype/grype/db/v5/namespace/distro"
+	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/presenter/models"
 	"github.com/anchore/grype/grype/vulnerability"
 )
 
+var columns = []string{"Name", "Installed", "Fixed In", "Type", "Vulnerability", "Severity", "EPSS", "Risk"}
+
 const (
 	appendSuppressed    = "suppressed"
 	appendSuppressedVEX = "suppressed by VEX"
+	appendIntermediate  = "intermediate layer"
+	appendLowConfidence = "low confidence"
+	appendMalicious     = "malicious package"
 )
 
 // Presenter is a generic struct for holding fields needed for reporting
@@ -95,13 +101,17 @@ func formatPercentileWithSuffix(percentile float64) string {
 
 // NewPresenter is a *Presenter constructor
 func NewPresenter(pb models.PresenterConfig, showSuppressed bool) *Presenter {
+	return newPresenter(pb.Document, showSuppressed)
+}
+
+func newPresenter(document models.Document, showSuppressed bool) *Presenter {
 	withColor := supportsColor()
 	fixStyle := lipgloss.NewStyle().Border(lipgloss.Border{Left: "*"}, false, false, false, true)
 	if withColor {
 		fixStyle = lipgloss.NewStyle()
 	}
 	return &Presenter{
-		document:            pb.Document,
+		document:            document,
 		showSuppressed:      showSuppressed,
 		withColor:           withColor,
 		recommendedFixStyle: fixStyle,
@@ -126,13 +136,59 @@ func (p *Presenter) Present(output io.Writer) error {
 		return err
 	}
 
-	table := newTable(output, []string{"Name", "Installed", "Fixed In", "Type", "Vulnerability", "Severity", "EPSS", "Risk"})
+	table := newTable(output, columns)
 
 	if err := table.Bulk(rs.Render()); err != nil {
 		return fmt.Errorf("failed to add table rows: %w", err)
 	}
 
-	return table.Render()
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	return presentSeverityDerivations(output, p.document.Matches)
+}
+
+// presentSeverityDerivations writes how the severity of each match was derived (when requested with --explain-severity).
+func presentSeverityDerivations(output io.Writer, matches []models.Match) error {
+	var sb strings.Builder
+	for _, m := range matches {
+		d := m.SeverityDerivation
+		if d == nil {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("\nSeverity derivation:\n")
+		}
+		fmt.Fprintf(&sb, "  %s (%s %s): %s, %s\n", m.Vulnerability.ID, m.Artifact.Name, m.Artifact.Version, severityOrUnknown(d.Severity), d.Reason)
+		for _, s := range d.Sources {
+			marker := " "
+			if s.Selected {
+				marker = "*"
+			}
+			source := s.Source
+			if source == "" {
+				source = "unknown source"
+			}
+			fmt.Fprintf(&sb, "    %s %s rank %d  %s  %s: %s", marker, s.Vulnerability, s.Rank, source, s.Scheme, s.Value)
+			if s.Score > 0 {
+				fmt.Fprintf(&sb, " (%.1f)", s.Score)
+			}
+			fmt.Fprintf(&sb, " => %s\n", s.Severity)
+		}
+	}
+	if sb.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(output, sb.String())
+	return err
+}
+
+func severityOrUnknown(severity string) string {
+	if severity == "" {
+		return "Unknown"
+	}
+	return severity
 }
 
 func newTable(output io.Writer, columns []string) *tablewriter.Table {
@@ -197,8 +253,15 @@ func (p *Presenter) getRows(doc models.Document, showSuppressed bool) rows {
 			msg := appendSuppressed
 			if m.AppliedIgnoreRules != nil {
 				for i := range m.AppliedIgnoreRules {
-					if m.AppliedIgnoreRules[i].Namespace == "vex" {
+					switch m.AppliedIgnoreRules[i].Namespace {
+					case "vex":
 						msg = appendSuppressedVEX
+					case "intermediate-layer":
+						msg = appendIntermediate
+					}
+					// rules of annotated ignore files reference the ticket tracking them
+					if m.AppliedIgnoreRules[i].Ticket != "" && msg == appendSuppressed {
+						msg = fmt.Sprintf("%s (%s)", appendSuppressed, m.AppliedIgnoreRules[i].Ticket)
 					}
 				}
 			}
@@ -225,6 +288,18 @@ func (p *Presenter) newRow(m models.Match, extraAnnotation string, showDistro bo
 		annotations = append(annotations, p.auxiliaryStyle.Render(extraAnnotation))
 	}
 
+	if upstream := m.InheritedFrom(); upstream != nil {
+		annotations = append(annotations, p.auxiliaryStyle.Render(fmt.Sprintf("via upstream %s", upstream.Name)))
+	}
+
+	if m.Malicious {
+		annotations = append(annotations, p.auxiliaryStyle.Render(appendMalicious))
+	}
+
+	if c := m.Confidence(); c > 0 && c < match.LowConfidenceThreshold {
+		annotations = append(annotations, p.auxiliaryStyle.Render(appendLowConfidence))
+	}
+
 	var kev, annotation string
 	if len(m.Vulnerability.KnownExploited) > 0 {
 		if p.withColor {
@@ -248,7 +323,7 @@ func (p *Presenter) newRow(m models.Match, extraAnnotation string, showDistro bo
 		Fix:             p.formatFix(m),
 		PackageType:     string(m.Artifact.Type),
 		VulnerabilityID: m.Vulnerability.ID,
-		Severity:        p.formatSeverity(m.Vulnerability.Severity),
+		Severity:        p.formatVulnerabilitySeverity(m.Vulnerability),
 		EPSS:            newEPSS(m.Vulnerability.EPSS),
 		Risk:            p.formatRisk(m.Vulnerability.Risk),
 		Annotation:      annotation,
@@ -265,6 +340,16 @@ func newEPSS(es []models.EPSS) epss {
 	}
 }
 
+// formatVulnerabilitySeverity shows the severity, followed by the effective severity when the CVSS environmental
+// modifiers of the asset class or the severity policy changed it.
+func (p *Presenter) formatVulnerabilitySeverity(v models.Vulnerability) string {
+	severity := p.formatSeverity(v.Severity)
+	if adjusted := v.EffectiveSeverity(); !strings.EqualFold(adjusted, v.Severity) {
+		severity += p.auxiliaryStyle.Render(" → ") + p.formatSeverity(adjusted)
+	}
+	return severity
+}
+
 func (p *Presenter) formatSeverity(severity string) string {
 	var severityStyle *lipgloss.Style
 	switch strings.ToLower(severity) {
@@ -299,6 +384,12 @@ func (p *Presenter) formatRisk(risk float64) string {
 }
 
 func (p *Presenter) formatFix(m models.Match) string {
+	if e := m.Vulnerability.Fix.Entitlement; e != "" && len(m.Vulnerability.Fix.Versions) > 0 {
+		// the fix is only available with a subscription, so show it regardless of the fix state
+		versions := p.applyTruncation(p.formatVersionsToDisplay(m, getRecommendedVersions(m)), m.Vulnerability.Fix.Versions)
+		return fmt.Sprintf("%s (%s)", versions, e)
+	}
+
 	// adjust the model fix state values for better presentation
 	switch m.Vulnerability.Fix.State {
 	case vulnerability.FixStateWontFix.String():
@@ -307,6 +398,10 @@ func (p *Presenter) formatFix(m models.Match) string {
 		return ""
 	}
 
+	if m.Vulnerability.Fix.Unbounded {
+		return "(fix unknown)"
+	}
+
 	// do our best to summarize the fixed versions, de-epmhasize non-recommended versions
 	// also, since there is not a lot of screen real estate, we will truncate the list of fixed versions
 	// to ~30 characters (or so) to avoid wrapping.
@@ -466,3 +466,39 @@ func mustRow(t *testing.T, name, version, fix, pkgType, vulnID, severity string,
 	}
 	return r
 }
+
+func TestPresentSeverityDerivations(t *testing.T) {
+	matches := []models.Match{
+		{
+			Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2024-1234", Severity: "Critical"}},
+			Artifact:      models.Package{Name: "openssl", Version: "3.0.1"},
+			SeverityDerivation: &models.SeverityDerivation{
+				Severity: "Critical",
+				Reason:   "highest ranked of 2 severities reported for CVE-2024-1234, from nvd@nist.gov (CVSS base score 9.8)",
+				Sources: []models.SeveritySource{
+					{Vulnerability: "CVE-2024-1234", Source: "nvd@nist.gov", Scheme: "CVSS", Value: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", Score: 9.8, Severity: "critical", Rank: 1, Selected: true},
+					{Vulnerability: "CVE-2024-1234", Scheme: "CHMLN", Value: "high", Severity: "high", Rank: 2},
+				},
+			},
+		},
+		{
+			Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2024-5678"}},
+			Artifact:      models.Package{Name: "zlib", Version: "1.2.11"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, presentSeverityDerivations(&buffer, matches))
+
+	expected := `
+Severity derivation:
+  CVE-2024-1234 (openssl 3.0.1): Critical, highest ranked of 2 severities reported for CVE-2024-1234, from nvd@nist.gov (CVSS base score 9.8)
+    * CVE-2024-1234 rank 1  nvd@nist.gov  CVSS: CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H (9.8) => critical
+      CVE-2024-1234 rank 2  unknown source  CHMLN: high => high
+`
+	assert.Equal(t, expected, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, presentSeverityDerivations(&buffer, matches[1:]))
+	assert.Empty(t, buffer.String())
+}
@@ -0,0 +1,88 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/anchore/grype/grype/presenter/models"
+)
+
+// streamColumnWidths are the fixed widths used when streaming rows, since the widths cannot be derived from the
+// full set of rows ahead of time (values wider than the column are not truncated).
+var streamColumnWidths = []int{30, 20, 20, 10, 20, 10, 16, 5}
+
+// StreamPresenter writes table rows incrementally as matches are discovered. Rows are written in the order they are
+// received (not sorted) with fixed column widths.
+type StreamPresenter struct {
+	presenter *Presenter
+	output    io.Writer
+	seen      map[string]struct{}
+}
+
+// NewStreamPresenter is a *StreamPresenter constructor
+func NewStreamPresenter(output io.Writer) *StreamPresenter {
+	return &StreamPresenter{
+		presenter: newPresenter(models.Document{}, false),
+		output:    output,
+		seen:      make(map[string]struct{}),
+	}
+}
+
+// Count is the number of rows written so far.
+func (s *StreamPresenter) Count() int {
+	return len(s.seen)
+}
+
+// Write renders the given matches as table rows, writing the header before the first row.
+func (s *StreamPresenter) Write(matches ...models.Match) error {
+	for _, m := range matches {
+		r := s.presenter.newRow(m, "", false)
+		key := r.String()
+		if _, ok := s.seen[key]; ok {
+			continue
+		}
+
+		if len(s.seen) == 0 {
+			if err := s.writeLine(header()); err != nil {
+				return err
+			}
+		}
+		s.seen[key] = struct{}{}
+
+		if err := s.writeLine(r.Columns()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func header() []string {
+	out := make([]string, len(columns))
+	for idx, c := range columns {
+		out[idx] = strings.ToUpper(c)
+	}
+	return out
+}
+
+func (s *StreamPresenter) writeLine(cells []string) error {
+	var line strings.Builder
+	for idx, cell := range cells {
+		if idx > 0 {
+			line.WriteString("  ")
+		}
+		line.WriteString(cell)
+		if idx < len(streamColumnWidths) && idx < len(cells)-1 {
+			if pad := streamColumnWidths[idx] - lipgloss.Width(cell); pad > 0 {
+				line.WriteString(strings.Repeat(" ", pad))
+			}
+		}
+	}
+
+	if _, err := fmt.Fprintln(s.output, strings.TrimRight(line.String(), " ")); err != nil {
+		return fmt.Errorf("failed to write table row: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/internal"
+)
+
+func TestStreamPresenter(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	pb := internal.GeneratePresenterConfig(t, internal.ImageSource)
+
+	var buffer bytes.Buffer
+	pres := NewStreamPresenter(&buffer)
+
+	require.NoError(t, pres.Write(pb.Document.Matches[0]))
+	// the header is only written once and duplicate rows are dropped
+	require.NoError(t, pres.Write(pb.Document.Matches...))
+
+	lines := strings.Split(strings.TrimRight(buffer.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, 2, pres.Count())
+
+	assert.True(t, strings.HasPrefix(lines[0], "NAME                            INSTALLED"), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "package-1                       1.1.1"), lines[1])
+	assert.Contains(t, lines[1], "CVE-1999-0001")
+	assert.True(t, strings.HasPrefix(lines[2], "package-2                       2.2.2"), lines[2])
+	assert.Contains(t, lines[2], "(kev)")
+}
+
+func TestStreamPresenter_noMatches(t *testing.T) {
+	var buffer bytes.Buffer
+	pres := NewStreamPresenter(&buffer)
+
+	require.NoError(t, pres.Write())
+	assert.Empty(t, buffer.String())
+	assert.Zero(t, pres.Count())
+}
@@ -0,0 +1,23 @@
+package search
+
+import (
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+var _ vulnerability.Criteria = (*NonWithdrawnCriteria)(nil)
+
+// ByNonWithdrawn returns criteria which will exclude vulnerabilities whose records were withdrawn or rejected.
+func ByNonWithdrawn() vulnerability.Criteria {
+	return &NonWithdrawnCriteria{}
+}
+
+// NonWithdrawnCriteria matches vulnerabilities whose records are not withdrawn or rejected (see
+// vulnerability.IsWithdrawn).
+type NonWithdrawnCriteria struct{}
+
+func (c *NonWithdrawnCriteria) MatchesVulnerability(v vulnerability.Vulnerability) (bool, string, error) {
+	if vulnerability.IsWithdrawn(v) {
+		return false, "vulnerability is withdrawn or rejected", nil
+	}
+	return true, "", nil
+}
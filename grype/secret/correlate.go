@@ -0,0 +1,53 @@
+package secret
+
+import (
+	"path"
+	"strings"
+
+	"github.com/anchore/grype/grype/match"
+)
+
+// Combination is an "exploitable combination": an exposed credential that resides alongside a package with a known
+// vulnerability, where the credential may allow an attacker to reach or escalate through the vulnerable service.
+type Combination struct {
+	Secret Finding
+	Match  match.Match
+}
+
+// Correlate pairs each secret with the vulnerability matches for packages discovered within the same directory tree
+// as the secret (e.g. a credential in /app/.env and a vulnerable dependency declared in /app/package-lock.json).
+func Correlate(findings []Finding, matches []match.Match) []Combination {
+	if len(findings) == 0 || len(matches) == 0 {
+		return nil
+	}
+
+	var combinations []Combination
+	for _, f := range findings {
+		secretPath := path.Clean(f.Path)
+		for _, m := range matches {
+			if !colocated(secretPath, m) {
+				continue
+			}
+			combinations = append(combinations, Combination{
+				Secret: f,
+				Match:  m,
+			})
+		}
+	}
+	return combinations
+}
+
+// colocated indicates if the secret path is within the directory of any location the matched package was discovered at
+func colocated(secretPath string, m match.Match) bool {
+	for _, l := range m.Package.Locations.ToSlice() {
+		dir := path.Dir(path.Clean(l.RealPath))
+		if dir == "/" || dir == "." {
+			// everything is within the root directory, which does not indicate any relationship
+			continue
+		}
+		if secretPath == dir || strings.HasPrefix(secretPath, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
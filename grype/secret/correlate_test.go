@@ -0,0 +1,79 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/file"
+)
+
+func TestCorrelate(t *testing.T) {
+	matchAt := func(id string, paths ...string) match.Match {
+		var locations []file.Location
+		for _, p := range paths {
+			locations = append(locations, file.NewLocation(p))
+		}
+		return match.Match{
+			Vulnerability: vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: id}},
+			Package: pkg.Package{
+				ID:        pkg.ID(id),
+				Name:      "pkg-" + id,
+				Locations: file.NewLocationSet(locations...),
+			},
+		}
+	}
+
+	appMatch := matchAt("CVE-2024-0001", "/app/package-lock.json")
+	nestedMatch := matchAt("CVE-2024-0002", "/app/node_modules/lodash/package.json")
+	rootMatch := matchAt("CVE-2024-0003", "/Gemfile.lock")
+	osMatch := matchAt("CVE-2024-0004", "/var/lib/dpkg/status")
+
+	tests := []struct {
+		name     string
+		findings []Finding
+		matches  []match.Match
+		want     []Combination
+	}{
+		{
+			name:     "no findings",
+			findings: nil,
+			matches:  []match.Match{appMatch},
+		},
+		{
+			name:     "secret within package directory",
+			findings: []Finding{{Classification: "aws-access-key", Path: "/app/.env"}},
+			matches:  []match.Match{appMatch, nestedMatch, osMatch},
+			want: []Combination{
+				{Secret: Finding{Classification: "aws-access-key", Path: "/app/.env"}, Match: appMatch},
+			},
+		},
+		{
+			name:     "secret nested below package directory",
+			findings: []Finding{{Classification: "generic-api-key", Path: "/app/config/keys.json"}},
+			matches:  []match.Match{appMatch},
+			want: []Combination{
+				{Secret: Finding{Classification: "generic-api-key", Path: "/app/config/keys.json"}, Match: appMatch},
+			},
+		},
+		{
+			name:     "sibling directory with shared prefix is not correlated",
+			findings: []Finding{{Classification: "generic-api-key", Path: "/application/.env"}},
+			matches:  []match.Match{appMatch},
+		},
+		{
+			name:     "packages found at the root are not correlated",
+			findings: []Finding{{Classification: "generic-api-key", Path: "/app/.env"}},
+			matches:  []match.Match{rootMatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Correlate(tt.findings, tt.matches))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+// Finding is a single exposed credential reported by an external secrets scanner. Note that the secret value itself
+// is intentionally never retained.
+type Finding struct {
+	Classification string `json:"classification"`
+	Path           string `json:"path"`
+	LineNumber     int64  `json:"lineNumber,omitempty"`
+}
+
+// syftDocument is the subset of the (legacy) syft JSON document describing secrets found by the syft secrets cataloger
+type syftDocument struct {
+	Secrets []struct {
+		Location struct {
+			Path string `json:"path"`
+		} `json:"location"`
+		Secrets []struct {
+			Classification string `json:"classification"`
+			LineNumber     int64  `json:"lineNumber"`
+		} `json:"secrets"`
+	} `json:"secrets"`
+}
+
+// ReadReportFile reads secret findings from the report at the given path.
+func ReadReportFile(path string) ([]Finding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open secrets report: %w", err)
+	}
+	defer log.CloseAndLogError(f, path)
+
+	return ReadReport(f)
+}
+
+// ReadReport reads secret findings from either a syft JSON document containing a "secrets" section or a JSON list of
+// findings (each with "classification", "path", and optionally "lineNumber" fields).
+func ReadReport(reader io.Reader) ([]Finding, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read secrets report: %w", err)
+	}
+
+	trimmed := strings.TrimSpace(string(contents))
+	if strings.HasPrefix(trimmed, "[") {
+		var findings []Finding
+		if err := json.Unmarshal(contents, &findings); err != nil {
+			return nil, fmt.Errorf("unable to decode secrets report: %w", err)
+		}
+		return findings, nil
+	}
+
+	var doc syftDocument
+	if err := json.Unmarshal(contents, &doc); err != nil {
+		return nil, fmt.Errorf("unable to decode secrets report: %w", err)
+	}
+
+	var findings []Finding
+	for _, s := range doc.Secrets {
+		for _, f := range s.Secrets {
+			findings = append(findings, Finding{
+				Classification: f.Classification,
+				Path:           s.Location.Path,
+				LineNumber:     f.LineNumber,
+			})
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,45 @@
+package secret
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadReportFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    []Finding
+	}{
+		{
+			name:    "syft document",
+			fixture: "testdata/syft-secrets.json",
+			want: []Finding{
+				{Classification: "aws-access-key", Path: "/app/.env", LineNumber: 2},
+			},
+		},
+		{
+			name:    "list of findings",
+			fixture: "testdata/findings.json",
+			want: []Finding{
+				{Classification: "generic-api-key", Path: "/srv/config/settings.yaml", LineNumber: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadReportFile(tt.fixture)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadReport_invalid(t *testing.T) {
+	_, err := ReadReport(strings.NewReader("not json"))
+	require.Error(t, err)
+}
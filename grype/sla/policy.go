@@ -0,0 +1,110 @@
+package sla
+
+import (
+	"time"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// Basis is the point in time a finding's age is measured from.
+type Basis string
+
+const (
+	// PublishedBasis measures age from when the vulnerability was first published.
+	PublishedBasis Basis = "published"
+	// FixAvailableBasis measures age from when a fix first became available (unfixed findings never breach).
+	FixAvailableBasis Basis = "fix-available"
+)
+
+// AllBases returns all supported bases.
+func AllBases() []Basis {
+	return []Basis{PublishedBasis, FixAvailableBasis}
+}
+
+// Policy describes how long findings of each severity may remain unaddressed (the grace period) before they breach
+// the SLA. Findings are only evaluated when the date for the configured Basis is known.
+type Policy struct {
+	// Basis is the point in time a finding's age is measured from (defaults to PublishedBasis).
+	Basis Basis
+	// GracePeriods is the grace period for each severity. Severities without a grace period never breach.
+	GracePeriods map[vulnerability.Severity]time.Duration
+	// Now returns the current time (defaults to time.Now).
+	Now func() time.Time
+}
+
+// Breach describes a single finding that has exceeded the grace period for its severity.
+type Breach struct {
+	Match       match.Match
+	Severity    vulnerability.Severity
+	Since       time.Time
+	GracePeriod time.Duration
+	Age         time.Duration
+}
+
+// IsEmpty indicates if the policy has no grace periods to evaluate.
+func (p Policy) IsEmpty() bool {
+	return len(p.GracePeriods) == 0
+}
+
+// Evaluate returns all matches that have exceeded the grace period for their severity.
+//
+//nolint:staticcheck // MetadataProvider is deprecated but still used internally
+func (p Policy) Evaluate(metadataProvider vulnerability.MetadataProvider, matches []match.Match) []Breach {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	current := now()
+
+	var breaches []Breach
+	for _, m := range matches {
+		metadata := m.Vulnerability.Metadata
+		if metadataProvider != nil {
+			if md, err := metadataProvider.VulnerabilityMetadata(m.Vulnerability.Reference); err == nil && md != nil {
+				metadata = md
+			}
+		}
+		if metadata == nil {
+			continue
+		}
+
+		severity := vulnerability.ParseSeverity(metadata.Severity)
+		gracePeriod, ok := p.GracePeriods[severity]
+		if !ok {
+			continue
+		}
+
+		since := p.since(m, metadata)
+		if since == nil {
+			continue
+		}
+
+		if age := current.Sub(*since); age > gracePeriod {
+			breaches = append(breaches, Breach{
+				Match:       m,
+				Severity:    severity,
+				Since:       *since,
+				GracePeriod: gracePeriod,
+				Age:         age,
+			})
+		}
+	}
+	return breaches
+}
+
+func (p Policy) since(m match.Match, metadata *vulnerability.Metadata) *time.Time {
+	switch p.Basis {
+	case FixAvailableBasis:
+		if m.Vulnerability.Fix.State != vulnerability.FixStateFixed {
+			return nil
+		}
+		return m.Vulnerability.Fix.EarliestAvailable()
+	default:
+		return metadata.PublishedDate
+	}
+}
@@ -0,0 +1,82 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	current := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) *time.Time {
+		d := current.AddDate(0, 0, -n)
+		return &d
+	}
+
+	newMatch := func(id, severity string, published *time.Time, fixState vulnerability.FixState, fixDate *time.Time) match.Match {
+		m := match.Match{}
+		m.Vulnerability.ID = id
+		m.Vulnerability.Metadata = &vulnerability.Metadata{ID: id, Severity: severity, PublishedDate: published}
+		m.Vulnerability.Fix.State = fixState
+		if fixDate != nil {
+			m.Vulnerability.Fix.Available = []vulnerability.FixAvailable{{Version: "1.0", Date: *fixDate}}
+		}
+		return m
+	}
+
+	matches := []match.Match{
+		newMatch("CVE-critical-old", "Critical", daysAgo(10), vulnerability.FixStateFixed, daysAgo(2)),
+		newMatch("CVE-critical-new", "Critical", daysAgo(3), vulnerability.FixStateFixed, daysAgo(3)),
+		newMatch("CVE-high-old", "High", daysAgo(60), vulnerability.FixStateFixed, daysAgo(45)),
+		newMatch("CVE-high-not-fixed", "High", daysAgo(90), vulnerability.FixStateNotFixed, nil),
+		newMatch("CVE-high-no-dates", "High", nil, vulnerability.FixStateFixed, nil),
+		newMatch("CVE-low-old", "Low", daysAgo(365), vulnerability.FixStateFixed, daysAgo(365)),
+	}
+
+	gracePeriods := map[vulnerability.Severity]time.Duration{
+		vulnerability.CriticalSeverity: 7 * 24 * time.Hour,
+		vulnerability.HighSeverity:     30 * 24 * time.Hour,
+	}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		expected []string
+	}{
+		{
+			name:   "empty policy",
+			policy: Policy{Now: func() time.Time { return current }},
+		},
+		{
+			name:     "published basis",
+			policy:   Policy{Basis: PublishedBasis, GracePeriods: gracePeriods, Now: func() time.Time { return current }},
+			expected: []string{"CVE-critical-old", "CVE-high-old", "CVE-high-not-fixed"},
+		},
+		{
+			name:     "defaults to published basis",
+			policy:   Policy{GracePeriods: gracePeriods, Now: func() time.Time { return current }},
+			expected: []string{"CVE-critical-old", "CVE-high-old", "CVE-high-not-fixed"},
+		},
+		{
+			name:     "fix available basis",
+			policy:   Policy{Basis: FixAvailableBasis, GracePeriods: gracePeriods, Now: func() time.Time { return current }},
+			expected: []string{"CVE-high-old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual []string
+			for _, b := range tt.policy.Evaluate(nil, matches) {
+				assert.Equal(t, gracePeriods[b.Severity], b.GracePeriod)
+				assert.Greater(t, b.Age, b.GracePeriod)
+				actual = append(actual, b.Match.Vulnerability.ID)
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package grype
+
+import (
+	"slices"
+	"time"
+
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// OSEcosystem is the ecosystem name given to all OS packages (e.g. deb, rpm and apk packages) when prioritizing.
+const OSEcosystem = pkg.OSEcosystem
+
+// skippedTimeBudgetReason is the reason recorded for packages skipped because the time budget was exhausted.
+const skippedTimeBudgetReason = "time budget exceeded"
+
+// TimeBudgetConfig bounds the time spent matching packages, for constrained environments (such as CI) scanning very
+// large images. Packages of the priority ecosystems are matched first and always in full; the remaining packages are
+// matched until the budget is exhausted, after which they are reported as skipped (see
+// VulnerabilityMatcher.SkippedPackages).
+type TimeBudgetConfig struct {
+	// Limit is the time allowed for matching (0 for no limit)
+	Limit time.Duration
+	// PriorityEcosystems are the ecosystems always matched, in order of priority: "os" for OS packages, a language
+	// (e.g. "java" or "python") or a package type (e.g. "binary" or "npm")
+	PriorityEcosystems []string
+}
+
+// Ecosystem returns the ecosystem of the package as referred to by TimeBudgetConfig.PriorityEcosystems: "os" for OS
+// packages, otherwise the package language (or the package type when the language is not known).
+func Ecosystem(p pkg.Package) string {
+	return pkg.Ecosystem(p)
+}
+
+// priority returns the rank of the package within the priority ecosystems (or -1 when not prioritized).
+func (c TimeBudgetConfig) priority(p pkg.Package) int {
+	for i, e := range c.PriorityEcosystems {
+		if pkg.InEcosystem(p, e) {
+			return i
+		}
+	}
+	return -1
+}
+
+// order returns the packages in the order they should be matched (packages of the priority ecosystems first, by rank)
+// along with the number of prioritized packages.
+func (c TimeBudgetConfig) order(packages []pkg.Package) ([]pkg.Package, int) {
+	if len(c.PriorityEcosystems) == 0 {
+		return packages, 0
+	}
+
+	var prioritized int
+	ordered := slices.Clone(packages)
+	rank := func(p pkg.Package) int {
+		if r := c.priority(p); r >= 0 {
+			return r
+		}
+		return len(c.PriorityEcosystems)
+	}
+	slices.SortStableFunc(ordered, func(a, b pkg.Package) int {
+		return rank(a) - rank(b)
+	})
+	for _, p := range ordered {
+		if c.priority(p) < 0 {
+			break
+		}
+		prioritized++
+	}
+	return ordered, prioritized
+}
+
+// exhausted indicates whether the budget has run out for matching the next package.
+func (c TimeBudgetConfig) exhausted(start time.Time) bool {
+	return c.Limit > 0 && time.Since(start) > c.Limit
+}
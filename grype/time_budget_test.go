@@ -0,0 +1,139 @@
+package grype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	matcherMock "github.com/anchore/grype/grype/matcher/mock"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestEcosystem(t *testing.T) {
+	tests := []struct {
+		p    pkg.Package
+		want string
+	}{
+		{p: pkg.Package{Type: syftPkg.DebPkg}, want: "os"},
+		{p: pkg.Package{Type: syftPkg.ApkPkg}, want: "os"},
+		{p: pkg.Package{Type: syftPkg.JavaPkg, Language: syftPkg.Java}, want: "java"},
+		{p: pkg.Package{Type: syftPkg.NpmPkg, Language: syftPkg.JavaScript}, want: "javascript"},
+		{p: pkg.Package{Type: syftPkg.BinaryPkg}, want: "binary"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, Ecosystem(tt.p))
+		})
+	}
+}
+
+func TestTimeBudgetConfig_order(t *testing.T) {
+	npm := pkg.Package{Name: "left-pad", Type: syftPkg.NpmPkg, Language: syftPkg.JavaScript}
+	deb := pkg.Package{Name: "libc6", Type: syftPkg.DebPkg}
+	jar := pkg.Package{Name: "log4j", Type: syftPkg.JavaPkg, Language: syftPkg.Java}
+	bin := pkg.Package{Name: "curl", Type: syftPkg.BinaryPkg}
+	whl := pkg.Package{Name: "flask", Type: syftPkg.PythonPkg, Language: syftPkg.Python}
+	packages := []pkg.Package{npm, deb, jar, bin, whl}
+
+	tests := []struct {
+		name            string
+		ecosystems      []string
+		want            []pkg.Package
+		wantPrioritized int
+	}{
+		{
+			name: "no priority ecosystems",
+			want: packages,
+		},
+		{
+			name:            "ecosystems are matched in the given order",
+			ecosystems:      []string{"os", "java", "python"},
+			want:            []pkg.Package{deb, jar, whl, npm, bin},
+			wantPrioritized: 3,
+		},
+		{
+			name:            "package types and language aliases",
+			ecosystems:      []string{"binary", "Maven"},
+			want:            []pkg.Package{bin, jar, npm, deb, whl},
+			wantPrioritized: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, prioritized := TimeBudgetConfig{PriorityEcosystems: tt.ecosystems}.order(packages)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPrioritized, prioritized)
+		})
+	}
+}
+
+func TestVulnerabilityMatcher_timeBudget(t *testing.T) {
+	packages := []pkg.Package{
+		{ID: "left-pad", Name: "left-pad", Type: syftPkg.NpmPkg, Language: syftPkg.JavaScript},
+		{ID: "libc6", Name: "libc6", Type: syftPkg.DebPkg},
+		{ID: "log4j", Name: "log4j", Type: syftPkg.JavaPkg, Language: syftPkg.Java},
+		{ID: "lodash", Name: "lodash", Type: syftPkg.NpmPkg, Language: syftPkg.JavaScript},
+	}
+
+	var matched []string
+	recorder := func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		matched = append(matched, p.Name)
+		return nil, nil, nil
+	}
+	matchers := []match.Matcher{
+		matcherMock.New(syftPkg.NpmPkg, recorder),
+		matcherMock.New(syftPkg.DebPkg, recorder),
+		matcherMock.New(syftPkg.JavaPkg, recorder),
+	}
+
+	tests := []struct {
+		name        string
+		budget      TimeBudgetConfig
+		wantMatched []string
+		wantSkipped []string
+	}{
+		{
+			name:        "no limit",
+			budget:      TimeBudgetConfig{PriorityEcosystems: []string{"os"}},
+			wantMatched: []string{"libc6", "left-pad", "log4j", "lodash"},
+		},
+		{
+			name:        "priority ecosystems are always matched",
+			budget:      TimeBudgetConfig{Limit: time.Nanosecond, PriorityEcosystems: []string{"os", "java"}},
+			wantMatched: []string{"libc6", "log4j"},
+			wantSkipped: []string{"left-pad", "lodash"},
+		},
+		{
+			name:        "without priority ecosystems",
+			budget:      TimeBudgetConfig{Limit: time.Nanosecond},
+			wantSkipped: []string{"left-pad", "libc6", "log4j", "lodash"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched = nil
+			m := VulnerabilityMatcher{
+				VulnerabilityProvider: mock.VulnerabilityProvider(),
+				Matchers:              matchers,
+				TimeBudget:            tt.budget,
+			}
+
+			_, _, err := m.FindMatches(packages, pkg.Context{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMatched, matched)
+
+			var skipped []string
+			for _, s := range m.SkippedPackages() {
+				assert.Equal(t, skippedTimeBudgetReason, s.Reason)
+				skipped = append(skipped, s.Package.Name)
+			}
+			assert.Equal(t, tt.wantSkipped, skipped)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package grype
+
+import (
+	"strings"
+
+	"github.com/anchore/grype/grype/match"
+	"github.com/anchore/grype/grype/matcher/stock"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/internal/log"
+)
+
+// unknownVersion is the version value that matchers always treat as unknown (and skip).
+const unknownVersion = "unknown"
+
+// UnknownVersionConfig controls how packages with an unknown version are handled during matching.
+type UnknownVersionConfig struct {
+	// Sentinels are additional version values (beyond "unknown", compared case-insensitively) that indicate the
+	// package version is unknown, e.g. "0.0.0-unknown" or "n/a"
+	Sentinels []string
+	// ReportSkipped records packages with an unknown version that could not be matched (see
+	// VulnerabilityMatcher.SkippedPackages)
+	ReportSkipped bool
+	// MatchAnyVersionCPEs matches packages with an unknown version against every advisory for their CPEs regardless
+	// of the affected version range, with a low confidence
+	MatchAnyVersionCPEs bool
+}
+
+// skippedUnknownVersionReason is the reason recorded for packages skipped because their version is unknown.
+const skippedUnknownVersionReason = "unknown version"
+
+// SkippedPackage is a package that was not matched against the vulnerability database.
+type SkippedPackage struct {
+	Package pkg.Package
+	Reason  string
+}
+
+// isUnknown indicates if the given version is "unknown" or one of the configured sentinel versions.
+func (c UnknownVersionConfig) isUnknown(v string) bool {
+	if strings.EqualFold(v, unknownVersion) {
+		return true
+	}
+	for _, s := range c.Sentinels {
+		if s != "" && strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchPackage returns the package to hand to matchers, normalizing sentinel versions to "unknown" so that every
+// matcher handles them the same way, along with whether the package version is unknown.
+func (c UnknownVersionConfig) searchPackage(p pkg.Package) (pkg.Package, bool) {
+	if !c.isUnknown(p.Version) {
+		return p, false
+	}
+	if p.Version != unknownVersion {
+		p.Version = unknownVersion
+	}
+	return p, true
+}
+
+// matchUnknownVersion handles a package with an unknown version that the matchers produced no matches for, returning
+// any low-confidence matches and whether the package should be reported as skipped.
+func (m *VulnerabilityMatcher) matchUnknownVersion(p pkg.Package) ([]match.Match, bool) {
+	if m.UnknownVersions.MatchAnyVersionCPEs && len(p.CPEs) > 0 {
+		matches, err := stock.MatchAnyVersionByCPEs(m.VulnerabilityProvider, p)
+		if err != nil {
+			log.WithFields("error", err, "package", displayPackage(p)).Warn("unable to match package with unknown version by CPE")
+		}
+		if len(matches) > 0 {
+			return matches, false
+		}
+	}
+	return nil, m.UnknownVersions.ReportSkipped
+}
@@ -0,0 +1,118 @@
+package grype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/match"
+	matcherMock "github.com/anchore/grype/grype/matcher/mock"
+	"github.com/anchore/grype/grype/matcher/stock"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestUnknownVersionConfig_searchPackage(t *testing.T) {
+	cfg := UnknownVersionConfig{Sentinels: []string{"0.0.0-unknown", "N/A"}}
+
+	tests := []struct {
+		version     string
+		wantVersion string
+		wantUnknown bool
+	}{
+		{version: "1.2.3", wantVersion: "1.2.3"},
+		{version: "", wantVersion: ""},
+		{version: "unknown", wantVersion: "unknown", wantUnknown: true},
+		{version: "UNKNOWN", wantVersion: "unknown", wantUnknown: true},
+		{version: "0.0.0-unknown", wantVersion: "unknown", wantUnknown: true},
+		{version: "n/a", wantVersion: "unknown", wantUnknown: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, unknown := cfg.searchPackage(pkg.Package{Name: "foo", Version: tt.version})
+			assert.Equal(t, tt.wantUnknown, unknown)
+			assert.Equal(t, tt.wantVersion, got.Version)
+		})
+	}
+}
+
+func TestVulnerabilityMatcher_unknownVersions(t *testing.T) {
+	vuln := vulnerability.Vulnerability{
+		Reference:   vulnerability.Reference{ID: "CVE-2024-fake-1", Namespace: "nvd:cpe"},
+		PackageName: "libfoo",
+		Constraint:  version.MustGetConstraint("< 2.0", version.UnknownFormat),
+		CPEs:        []cpe.CPE{cpe.Must("cpe:2.3:a:foo:libfoo:*:*:*:*:*:*:*:*", "")},
+	}
+
+	p := pkg.Package{
+		ID:      pkg.ID("libfoo-id"),
+		Name:    "libfoo",
+		Version: "0.0.0-unknown",
+		Type:    syftPkg.BinaryPkg,
+		CPEs:    []cpe.CPE{cpe.Must("cpe:2.3:a:foo:libfoo:0.0.0-unknown:*:*:*:*:*:*:*", "")},
+	}
+
+	var matcherVersions []string
+	versionRecorder := matcherMock.New(syftPkg.BinaryPkg, func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		matcherVersions = append(matcherVersions, p.Version)
+		return nil, nil, nil
+	})
+
+	tests := []struct {
+		name         string
+		cfg          UnknownVersionConfig
+		wantMatches  int
+		wantSkipped  int
+		wantVersions []string
+	}{
+		{
+			name:         "sentinels are not configured",
+			wantVersions: []string{"0.0.0-unknown"},
+		},
+		{
+			name:         "sentinel is normalized for matchers",
+			cfg:          UnknownVersionConfig{Sentinels: []string{"0.0.0-unknown"}},
+			wantVersions: []string{"unknown"},
+		},
+		{
+			name:         "skipped packages are reported",
+			cfg:          UnknownVersionConfig{Sentinels: []string{"0.0.0-unknown"}, ReportSkipped: true},
+			wantSkipped:  1,
+			wantVersions: []string{"unknown"},
+		},
+		{
+			name:         "any-version CPE matching",
+			cfg:          UnknownVersionConfig{Sentinels: []string{"0.0.0-unknown"}, ReportSkipped: true, MatchAnyVersionCPEs: true},
+			wantMatches:  1,
+			wantVersions: []string{"unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcherVersions = nil
+			m := VulnerabilityMatcher{
+				VulnerabilityProvider: mock.VulnerabilityProvider(vuln),
+				Matchers:              []match.Matcher{versionRecorder},
+				UnknownVersions:       tt.cfg,
+			}
+
+			matches, _, err := m.FindMatches([]pkg.Package{p}, pkg.Context{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersions, matcherVersions)
+			assert.Len(t, m.SkippedPackages(), tt.wantSkipped)
+
+			sorted := matches.Sorted()
+			require.Len(t, sorted, tt.wantMatches)
+			for _, mt := range sorted {
+				assert.Equal(t, "0.0.0-unknown", mt.Package.Version, "matches should report the original package version")
+				require.NotEmpty(t, mt.Details)
+				assert.Equal(t, stock.AnyVersionCPEConfidence, mt.Details[0].Confidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package grype
+
+import (
+	"slices"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/pkg"
+)
+
+// UpstreamMatchingConfig controls whether packages are matched via their source (upstream) packages, e.g. the source
+// package of a Debian binary package or the source RPM of an RPM.
+type UpstreamMatchingConfig struct {
+	// DisabledDistros are the distros for which packages are only matched by their own name and version, ignoring
+	// any upstream packages
+	DisabledDistros []distro.Type
+}
+
+// enabled indicates if the given package should be matched via its upstream packages.
+func (c UpstreamMatchingConfig) enabled(p pkg.Package) bool {
+	if p.Distro == nil {
+		return true
+	}
+	return !slices.Contains(c.DisabledDistros, p.Distro.Type)
+}
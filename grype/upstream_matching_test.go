@@ -0,0 +1,77 @@
+package grype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/match"
+	matcherMock "github.com/anchore/grype/grype/matcher/mock"
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestVulnerabilityMatcher_upstreamMatching(t *testing.T) {
+	newPackage := func(d *distro.Distro) pkg.Package {
+		return pkg.Package{
+			ID:        pkg.ID("libssl3-id"),
+			Name:      "libssl3",
+			Version:   "3.0.11-1",
+			Type:      syftPkg.DebPkg,
+			Distro:    d,
+			Upstreams: []pkg.UpstreamPackage{{Name: "openssl"}},
+		}
+	}
+
+	var searchedUpstreams [][]pkg.UpstreamPackage
+	recorder := matcherMock.New(syftPkg.DebPkg, func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		searchedUpstreams = append(searchedUpstreams, p.Upstreams)
+		return nil, nil, nil
+	})
+
+	cfg := UpstreamMatchingConfig{DisabledDistros: []distro.Type{distro.Debian}}
+
+	tests := []struct {
+		name          string
+		p             pkg.Package
+		wantUpstreams bool
+	}{
+		{
+			name: "disabled distro",
+			p:    newPackage(distro.New(distro.Debian, "12", "")),
+		},
+		{
+			name:          "enabled distro",
+			p:             newPackage(distro.New(distro.Ubuntu, "22.04", "")),
+			wantUpstreams: true,
+		},
+		{
+			name:          "no distro",
+			p:             newPackage(nil),
+			wantUpstreams: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchedUpstreams = nil
+			m := VulnerabilityMatcher{
+				VulnerabilityProvider: mock.VulnerabilityProvider(),
+				Matchers:              []match.Matcher{recorder},
+				UpstreamMatching:      cfg,
+			}
+
+			_, _, err := m.FindMatches([]pkg.Package{tt.p}, pkg.Context{})
+			require.NoError(t, err)
+			require.Len(t, searchedUpstreams, 1)
+			if tt.wantUpstreams {
+				assert.Equal(t, tt.p.Upstreams, searchedUpstreams[0])
+			} else {
+				assert.Empty(t, searchedUpstreams[0])
+			}
+		})
+	}
+}
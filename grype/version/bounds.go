@@ -0,0 +1,109 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bounds describes a single range of a version constraint in the terms used by NVD CPE match criteria.
+type Bounds struct {
+	Version               string `json:"version,omitempty"`
+	VersionStartIncluding string `json:"versionStartIncluding,omitempty"`
+	VersionStartExcluding string `json:"versionStartExcluding,omitempty"`
+	VersionEndIncluding   string `json:"versionEndIncluding,omitempty"`
+	VersionEndExcluding   string `json:"versionEndExcluding,omitempty"`
+}
+
+func (b Bounds) String() string {
+	var parts []string
+	for _, p := range []struct{ name, value string }{
+		{"version", b.Version},
+		{"versionStartIncluding", b.VersionStartIncluding},
+		{"versionStartExcluding", b.VersionStartExcluding},
+		{"versionEndIncluding", b.VersionEndIncluding},
+		{"versionEndExcluding", b.VersionEndExcluding},
+	} {
+		if p.value != "" {
+			parts = append(parts, fmt.Sprintf("%s: %s", p.name, p.value))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SatisfiedBounds returns the bounds of each range of the given constraint that the given version falls within. If
+// the version is not given (or no single range can be evaluated) the bounds of every range are returned.
+func SatisfiedBounds(c Constraint, v *Version) []Bounds {
+	if c == nil {
+		return nil
+	}
+
+	if combined, ok := c.(combinedConstraint); ok {
+		var bounds []Bounds
+		for _, op := range combined.OrOperands {
+			if satisfied, err := op.Satisfied(v); v != nil && (err != nil || !satisfied) {
+				continue
+			}
+			bounds = append(bounds, SatisfiedBounds(op, v)...)
+		}
+		return bounds
+	}
+
+	var expression simpleRangeExpression
+	switch con := c.(type) {
+	case genericConstraint:
+		expression = con.Expression
+	case fuzzyConstraint:
+		expression = con.Constraints
+	default:
+		return nil
+	}
+
+	var all, satisfied []Bounds
+	for _, group := range expression.Units {
+		b := newBounds(group)
+		all = append(all, b)
+		if v == nil {
+			continue
+		}
+
+		groupConstraint, err := GetConstraint(unitsString(group), c.Format())
+		if err != nil {
+			continue
+		}
+		if ok, err := groupConstraint.Satisfied(v); err == nil && ok {
+			satisfied = append(satisfied, b)
+		}
+	}
+
+	if len(satisfied) == 0 {
+		return all
+	}
+	return satisfied
+}
+
+func newBounds(group []rangeUnit) Bounds {
+	var b Bounds
+	for _, unit := range group {
+		switch unit.Operator {
+		case EQ:
+			b.Version = unit.Version
+		case GTE:
+			b.VersionStartIncluding = unit.Version
+		case GT:
+			b.VersionStartExcluding = unit.Version
+		case LTE:
+			b.VersionEndIncluding = unit.Version
+		case LT:
+			b.VersionEndExcluding = unit.Version
+		}
+	}
+	return b
+}
+
+func unitsString(group []rangeUnit) string {
+	parts := make([]string, len(group))
+	for i, unit := range group {
+		parts[i] = fmt.Sprintf("%s %s", unit.Operator, unit.Version)
+	}
+	return strings.Join(parts, ", ")
+}
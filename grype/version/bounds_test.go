@@ -0,0 +1,61 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSatisfiedBounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint Constraint
+		version    *Version
+		want       []Bounds
+	}{
+		{
+			name:       "nil constraint",
+			constraint: nil,
+			want:       nil,
+		},
+		{
+			name:       "single range",
+			constraint: MustGetConstraint(">= 1.0.0, < 1.2.3", SemanticFormat),
+			version:    New("1.1.0", SemanticFormat),
+			want:       []Bounds{{VersionStartIncluding: "1.0.0", VersionEndExcluding: "1.2.3"}},
+		},
+		{
+			name:       "only the satisfied range is reported",
+			constraint: MustGetConstraint("> 1.0, <= 1.5 || >= 2.0, < 2.4", UnknownFormat),
+			version:    New("2.1", UnknownFormat),
+			want:       []Bounds{{VersionStartIncluding: "2.0", VersionEndExcluding: "2.4"}},
+		},
+		{
+			name:       "all ranges without a version",
+			constraint: MustGetConstraint("> 1.0, <= 1.5 || = 2.0", UnknownFormat),
+			want: []Bounds{
+				{VersionStartExcluding: "1.0", VersionEndIncluding: "1.5"},
+				{Version: "2.0"},
+			},
+		},
+		{
+			name: "combined constraint",
+			constraint: CombineConstraints(
+				MustGetConstraint("< 1.0", SemanticFormat),
+				MustGetConstraint(">= 3.0, < 3.1", SemanticFormat),
+			),
+			version: New("3.0.5", SemanticFormat),
+			want:    []Bounds{{VersionStartIncluding: "3.0", VersionEndExcluding: "3.1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SatisfiedBounds(tt.constraint, tt.version))
+		})
+	}
+}
+
+func TestBounds_String(t *testing.T) {
+	b := Bounds{VersionStartIncluding: "1.0", VersionEndExcluding: "2.0"}
+	assert.Equal(t, "versionStartIncluding: 1.0, versionEndExcluding: 2.0", b.String())
+}
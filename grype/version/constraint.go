@@ -31,15 +31,21 @@ func GetConstraint(constStr string, format Format) (Constraint, error) {
 	case RpmFormat:
 		c, err = newGenericConstraint(RpmFormat, constStr)
 	case PythonFormat:
-		c, err = newGenericConstraint(PythonFormat, constStr)
+		c, err = newPep440Constraint(constStr)
 	case KBFormat:
 		c, err = newKBConstraint(constStr)
 	case PortageFormat:
 		c, err = newGenericConstraint(PortageFormat, constStr)
 	case PacmanFormat:
 		c, err = newGenericConstraint(PacmanFormat, constStr)
+	case FreeBSDFormat:
+		c, err = newFreeBSDConstraint(constStr)
+	case OpenBSDFormat:
+		c, err = newGenericConstraint(OpenBSDFormat, constStr)
 	case JVMFormat:
 		c, err = newGenericConstraint(JVMFormat, constStr)
+	case NuGetFormat:
+		c, err = newGenericConstraint(NuGetFormat, constStr)
 	case UnknownFormat:
 		c, err = newFuzzyConstraint(constStr, "unknown")
 	default:
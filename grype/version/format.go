@@ -22,6 +22,9 @@ const (
 	JVMFormat
 	BitnamiFormat
 	PacmanFormat
+	FreeBSDFormat
+	OpenBSDFormat
+	NuGetFormat
 )
 
 type Format int
@@ -41,6 +44,9 @@ var formatStr = []string{
 	"JVM",
 	"Bitnami",
 	"Pacman",
+	"FreeBSD",
+	"OpenBSD",
+	"NuGet",
 }
 
 var Formats = []Format{
@@ -57,12 +63,15 @@ var Formats = []Format{
 	JVMFormat,
 	BitnamiFormat,
 	PacmanFormat,
+	FreeBSDFormat,
+	OpenBSDFormat,
+	NuGetFormat,
 }
 
 func ParseFormat(userStr string) Format {
 	switch strings.ToLower(userStr) {
 	// sever includes known ecosystem types that use semver or a very semver-like schemes
-	case strings.ToLower(SemanticFormat.String()), "semver", packageurl.TypeNPM, packageurl.TypeNuget, packageurl.TypeComposer, packageurl.TypeHex, packageurl.TypePub, packageurl.TypeSwift, packageurl.TypeConan, packageurl.TypeCocoapods, packageurl.TypeHackage:
+	case strings.ToLower(SemanticFormat.String()), "semver", packageurl.TypeNPM, packageurl.TypeComposer, packageurl.TypeHex, packageurl.TypePub, packageurl.TypeSwift, packageurl.TypeConan, packageurl.TypeCocoapods, packageurl.TypeHackage:
 		return SemanticFormat
 	case strings.ToLower(ApkFormat.String()), "apk", pkg.ApkPkg.String():
 		return ApkFormat
@@ -88,6 +97,12 @@ func ParseFormat(userStr string) Format {
 		return JVMFormat
 	case strings.ToLower(PacmanFormat.String()), "pacman", pkg.AlpmPkg.String():
 		return PacmanFormat
+	case strings.ToLower(FreeBSDFormat.String()), "freebsd-pkg":
+		return FreeBSDFormat
+	case strings.ToLower(OpenBSDFormat.String()), "openbsd-pkg":
+		return OpenBSDFormat
+	case strings.ToLower(NuGetFormat.String()), packageurl.TypeNuget, "dotnet", pkg.DotnetPkg.String():
+		return NuGetFormat
 	}
 	return UnknownFormat
 }
@@ -25,7 +25,7 @@ func TestParseFormat(t *testing.T) {
 		},
 		{
 			input:  "nuget",
-			format: SemanticFormat,
+			format: NuGetFormat,
 		},
 		{
 			input:  "composer",
@@ -167,6 +167,24 @@ func TestParseFormat(t *testing.T) {
 			input:  "alpm",
 			format: PacmanFormat,
 		},
+		// FreeBSDFormat cases
+		{
+			input:  "freebsd",
+			format: FreeBSDFormat,
+		},
+		{
+			input:  "freebsd-pkg",
+			format: FreeBSDFormat,
+		},
+		// OpenBSDFormat cases
+		{
+			input:  "openbsd",
+			format: OpenBSDFormat,
+		},
+		{
+			input:  "openbsd-pkg",
+			format: OpenBSDFormat,
+		},
 		// UnknownFormat case
 		{
 			input:  "unknown",
@@ -0,0 +1,19 @@
+package version
+
+import "regexp"
+
+// freebsdEpochPattern matches versions carrying a port epoch (e.g. "1.2_3,1"). The comma would otherwise be read as
+// the separator between two and'ed constraints, so these versions are quoted before the expression is parsed.
+var freebsdEpochPattern = regexp.MustCompile(`([^\s,<>=|"']+),(\d+)(\s|,|\||$)`)
+
+func newFreeBSDConstraint(raw string) (genericConstraint, error) {
+	constraints, err := parseRangeExpression(freebsdEpochPattern.ReplaceAllString(raw, `"$1,$2"$3`))
+	if err != nil {
+		return genericConstraint{}, invalidFormatError(FreeBSDFormat, raw, err)
+	}
+	return genericConstraint{
+		Expression: constraints,
+		Raw:        raw,
+		Fmt:        FreeBSDFormat,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeBSDVersion_Constraint(t *testing.T) {
+	tests := []testCase{
+		{name: "less than", version: "3.0.12", constraint: "< 3.0.13", satisfied: true},
+		{name: "less than with revision", version: "3.0.13", constraint: "< 3.0.13_1", satisfied: true},
+		{name: "fixed by revision", version: "3.0.13_1", constraint: "< 3.0.13_1", satisfied: false},
+		{name: "epoch in constraint", version: "2.4.58,1", constraint: "< 2.4.59,1", satisfied: true},
+		{name: "epoch beats version", version: "1.0,1", constraint: "< 2.0", satisfied: false},
+		{name: "missing epoch is lower", version: "5.0", constraint: "< 1.0,1", satisfied: true},
+		{name: "epoch with range", version: "2.4.58_1,1", constraint: ">= 2.4.0,1, < 2.4.59,1", satisfied: true},
+		{name: "epoch with range outside", version: "2.3.9,1", constraint: ">= 2.4.0,1, < 2.4.59,1", satisfied: false},
+		{name: "range without spaces", version: "1.5", constraint: ">=1.0,<2.0", satisfied: true},
+		{name: "or'ed epochs", version: "1.1,2", constraint: "< 1.0,1 || < 1.2,2", satisfied: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			constraint, err := GetConstraint(test.constraint, FreeBSDFormat)
+			require.NoError(t, err)
+			assert.Equal(t, test.constraint, constraint.Value())
+
+			test.assertVersionConstraint(t, FreeBSDFormat, constraint)
+		})
+	}
+}
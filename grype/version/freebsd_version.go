@@ -0,0 +1,216 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var _ Comparator = (*freebsdVersion)(nil)
+
+// freebsdVersion represents a FreeBSD ports/pkg version of the form version[_portrevision][,portepoch].
+// See https://docs.freebsd.org/en/books/porters-handbook/makefiles/#makefile-versions for details.
+type freebsdVersion struct {
+	version  string
+	revision int
+	epoch    int
+}
+
+func newFreeBSDVersion(raw string) (freebsdVersion, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return freebsdVersion{}, fmt.Errorf("empty FreeBSD version")
+	}
+
+	remaining := raw
+	var epoch, revision int
+	var err error
+
+	if idx := strings.LastIndex(remaining, ","); idx >= 0 {
+		epoch, err = strconv.Atoi(remaining[idx+1:])
+		if err != nil {
+			return freebsdVersion{}, fmt.Errorf("invalid FreeBSD port epoch in %q: %w", raw, err)
+		}
+		remaining = remaining[:idx]
+	}
+
+	if idx := strings.LastIndex(remaining, "_"); idx >= 0 {
+		revision, err = strconv.Atoi(remaining[idx+1:])
+		if err != nil {
+			return freebsdVersion{}, fmt.Errorf("invalid FreeBSD port revision in %q: %w", raw, err)
+		}
+		remaining = remaining[:idx]
+	}
+
+	if remaining == "" {
+		return freebsdVersion{}, fmt.Errorf("invalid FreeBSD version %q", raw)
+	}
+
+	return freebsdVersion{
+		version:  remaining,
+		revision: revision,
+		epoch:    epoch,
+	}, nil
+}
+
+func (v freebsdVersion) Compare(other *Version) (int, error) {
+	if other == nil {
+		return -1, ErrNoVersionProvided
+	}
+
+	o, err := newFreeBSDVersion(other.Raw)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.compare(o), nil
+}
+
+// compare returns 0 if v == v2, -1 if v < v2, and +1 if v > v2. The port epoch always takes precedence, followed
+// by the upstream version and finally the port revision (mirroring "pkg version -t").
+func (v freebsdVersion) compare(v2 freebsdVersion) int {
+	if c := compareInts(v.epoch, v2.epoch); c != 0 {
+		return c
+	}
+
+	if c := compareBSDVersions(v.version, v2.version); c != 0 {
+		return c
+	}
+
+	return compareInts(v.revision, v2.revision)
+}
+
+func (v freebsdVersion) String() string {
+	version := v.version
+	if v.revision != 0 {
+		version += fmt.Sprintf("_%d", v.revision)
+	}
+	if v.epoch != 0 {
+		version += fmt.Sprintf(",%d", v.epoch)
+	}
+	return version
+}
+
+// bsdVersionStages are the special version strings understood by the BSD package tools. The prerelease stages sort
+// before a release of the same version, while "pl" (patch level) sorts after it.
+var bsdVersionStages = map[string]int{
+	"alpha": -4,
+	"beta":  -3,
+	"pre":   -2,
+	"rc":    -1,
+	"pl":    0,
+}
+
+// bsdVersionComponent is a single component of a BSD package version: a number, an optional letter (or stage)
+// and an optional trailing number, so "1", "2a" and "0rc1" are all single components.
+type bsdVersionComponent struct {
+	number  int
+	letter  int
+	trailer int
+}
+
+func (c bsdVersionComponent) compare(o bsdVersionComponent) int {
+	if r := compareInts(c.number, o.number); r != 0 {
+		return r
+	}
+	if r := compareInts(c.letter, o.letter); r != 0 {
+		return r
+	}
+	return compareInts(c.trailer, o.trailer)
+}
+
+// compareBSDVersions compares the upstream portion of two BSD package versions component by component, where
+// a missing component is treated as zero (so 1.0 == 1.0.0), prerelease stages sort before the release
+// (1.0rc1 < 1.0) and letters or patch levels sort after it (1.0 < 1.0a, 1.0 < 1.0pl1).
+func compareBSDVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	componentsA := bsdVersionComponents(a)
+	componentsB := bsdVersionComponents(b)
+
+	for i := range max(len(componentsA), len(componentsB)) {
+		var ca, cb bsdVersionComponent
+		if i < len(componentsA) {
+			ca = componentsA[i]
+		}
+		if i < len(componentsB) {
+			cb = componentsB[i]
+		}
+		if r := ca.compare(cb); r != 0 {
+			return r
+		}
+	}
+	return 0
+}
+
+func bsdVersionComponents(raw string) []bsdVersionComponent {
+	var components []bsdVersionComponent
+	runes := []rune(strings.ToLower(raw))
+	i := 0
+	for i < len(runes) {
+		// skip separators (e.g. '.', '-', '+')
+		if !unicode.IsDigit(runes[i]) && !unicode.IsLetter(runes[i]) {
+			i++
+			continue
+		}
+
+		var c bsdVersionComponent
+		c.number, i = readBSDNumber(runes, i)
+
+		if i < len(runes) && unicode.IsLetter(runes[i]) {
+			start := i
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			if stage, ok := bsdVersionStages[word]; ok {
+				c.letter = stage
+				if word == "pl" {
+					// a patch level always sorts after the release it patches, even without a number
+					c.trailer = 1
+				}
+			} else {
+				c.letter = int(runes[start]-'a') + 1
+			}
+
+			if i < len(runes) && unicode.IsDigit(runes[i]) {
+				var trailer int
+				trailer, i = readBSDNumber(runes, i)
+				c.trailer += trailer
+			}
+		}
+
+		components = append(components, c)
+	}
+	return components
+}
+
+func readBSDNumber(runes []rune, i int) (int, int) {
+	start := i
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+	}
+	if start == i {
+		return 0, i
+	}
+	n, err := strconv.Atoi(string(runes[start:i]))
+	if err != nil {
+		// the number overflows, which is only possible with date-like or hash-like components
+		return int(^uint(0) >> 1), i
+	}
+	return n, i
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	default:
+		return 0
+	}
+}
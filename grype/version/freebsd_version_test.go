@@ -0,0 +1,86 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeBSDVersionCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{name: "equal versions", v1: "1.2.3", v2: "1.2.3", want: 0},
+		{name: "first greater", v1: "1.2.4", v2: "1.2.3", want: 1},
+		{name: "second greater", v1: "1.2.3", v2: "1.10.0", want: -1},
+		{name: "missing components are zero", v1: "1.0", v2: "1.0.0", want: 0},
+		{name: "port revision", v1: "3.0.13_1", v2: "3.0.13", want: 1},
+		{name: "port revision ordering", v1: "3.0.13_1", v2: "3.0.13_2", want: -1},
+		{name: "port epoch takes precedence", v1: "1.0,1", v2: "2.0", want: 1},
+		{name: "port epoch with revision", v1: "2.0_3,1", v2: "2.0_1,1", want: 1},
+		{name: "release candidate before release", v1: "1.0rc1", v2: "1.0", want: -1},
+		{name: "alpha before beta", v1: "1.0.alpha2", v2: "1.0.beta1", want: -1},
+		{name: "pre before rc", v1: "2.0pre3", v2: "2.0rc1", want: -1},
+		{name: "letter after release", v1: "1.1.1w", v2: "1.1.1", want: 1},
+		{name: "letter ordering", v1: "1.1.1v", v2: "1.1.1w", want: -1},
+		{name: "patch level after release", v1: "2.4pl1", v2: "2.4", want: 1},
+		{name: "patch level ordering", v1: "2.4pl1", v2: "2.4pl2", want: -1},
+		{name: "numeric components", v1: "9.18.24", v2: "9.18.3", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := newFreeBSDVersion(tt.v1)
+			require.NoError(t, err)
+
+			result, err := v1.Compare(New(tt.v2, FreeBSDFormat))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+
+			// the comparison must be symmetric
+			v2, err := newFreeBSDVersion(tt.v2)
+			require.NoError(t, err)
+
+			result, err = v2.Compare(New(tt.v1, FreeBSDFormat))
+			require.NoError(t, err)
+			assert.Equal(t, -tt.want, result)
+		})
+	}
+}
+
+func TestFreeBSDVersionInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"1.0_abc",
+		"1.0,x",
+		"_1",
+	}
+
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			_, err := newFreeBSDVersion(raw)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFreeBSDVersionString(t *testing.T) {
+	tests := []string{
+		"1.2.3",
+		"1.2.3_1",
+		"1.2.3,2",
+		"1.2.3_4,1",
+	}
+
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			v, err := newFreeBSDVersion(raw)
+			require.NoError(t, err)
+			assert.Equal(t, raw, v.String())
+		})
+	}
+}
@@ -282,15 +282,30 @@ func compareLengths(left, right []any, commonResult int) int {
 	return 0
 }
 
+// gemPlatforms are the leading components of rubygems platform strings (e.g. "x86_64-linux", "arm64-darwin",
+// "x64-mingw-ucrt" or "java") that are appended to the version of platform-specific gem builds.
+var gemPlatforms = []string{
+	// CPUs
+	"x86", "x64", "i386", "i486", "i586", "i686", "universal", "arm", "aarch64", "powerpc", "ppc", "sparc", "s390",
+	"riscv", "loongarch", "mips",
+	// runtimes and operating systems that may appear without a CPU
+	"java", "dalvik", "mswin", "mingw", "cygwin", "darwin", "linux", "freebsd", "openbsd", "netbsd", "solaris", "aix",
+}
+
+// cleanArchFromVersion removes the platform from the version of a platform-specific gem (e.g. "1.16.5-x86_64-linux"
+// becomes "1.16.5"), so that native builds compare the same as the pure-ruby gem. Without this the platform would be
+// read as a prerelease segment, making "1.16.5-i686-linux" sort before "1.16.5". The earliest platform component
+// wins, so any prerelease before the platform is retained ("1.16.0.rc1-arm64-darwin" becomes "1.16.0.rc1").
 func cleanArchFromVersion(raw string) string {
-	platforms := []string{"x86", "universal", "arm", "aarch64", "java", "dalvik", "x64", "powerpc", "sparc", "mswin"}
-	dash := "-"
-	for _, p := range platforms {
-		vals := strings.SplitN(raw, dash+p, 2)
-		if len(vals) == 2 {
-			return vals[0]
+	cut := -1
+	for _, p := range gemPlatforms {
+		if idx := strings.Index(raw, "-"+p); idx >= 0 && (cut == -1 || idx < cut) {
+			cut = idx
 		}
 	}
 
-	return raw
+	if cut == -1 {
+		return raw
+	}
+	return raw[:cut]
 }
@@ -33,6 +33,22 @@ func TestGemVersion_Constraint(t *testing.T) {
 		{version: "1.19.3-aarch64-linux-gnu", constraint: "< 1.19.3", satisfied: false},
 		{version: "1.19.3-aarch64-linux-musl", constraint: "= 1.19.3", satisfied: true},
 		{version: "1.19.3-aarch64-linux-musl", constraint: "< 1.19.3", satisfied: false},
+		// nokogiri-style native platform builds must compare the same as the ruby platform gem
+		{version: "1.16.5-i686-linux", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.5-x86-linux-gnu", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.5-ppc64le-linux", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.5-s390x-linux", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.5-riscv64-linux", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.5-x86-mingw32", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.5-x64-mingw-ucrt", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.5-arm64-darwin", constraint: "< 1.16.5", satisfied: false},
+		{version: "1.16.4-arm64-darwin", constraint: "< 1.16.5", satisfied: true},
+		{version: "1.16.4-java", constraint: "< 1.16.5", satisfied: true},
+		// prereleases are retained when the platform is removed
+		{version: "1.16.0.rc1-x86_64-linux", constraint: "< 1.16.0", satisfied: true},
+		{version: "1.16.0.rc1-arm64-darwin", constraint: ">= 1.16.0.rc1, < 1.16.0", satisfied: true},
+		{version: "1.16.0.rc2-java", constraint: "< 1.16.0.rc2", satisfied: false},
+		{version: "1.16.0-x86_64-linux", constraint: "< 1.16.0.rc1", satisfied: false},
 		// https://semver.org/#spec-item-11
 		{version: "1.2.0-alpha-x86-linux", constraint: "<1.2.0", satisfied: true},
 		{version: "1.2.0-alpha-1-x86-linux", constraint: "<1.2.0", satisfied: true},
@@ -79,6 +95,16 @@ func Test_cleanPlatformMakesEqualVersions(t *testing.T) {
 		{input: "1.13.1-x86-freebsd", trimmed: "1.13.1"},
 		{input: "1.13.1-x86-mswin32-80", trimmed: "1.13.1"},
 		{input: "1.13.1-universal-darwin-8", trimmed: "1.13.1"},
+		{input: "1.13.1-i686-linux", trimmed: "1.13.1"},
+		{input: "1.13.1-i386-mingw32", trimmed: "1.13.1"},
+		{input: "1.13.1-x64-mingw-ucrt", trimmed: "1.13.1"},
+		{input: "1.13.1-arm64-darwin", trimmed: "1.13.1"},
+		{input: "1.13.1-ppc64le-linux", trimmed: "1.13.1"},
+		{input: "1.13.1-s390x-linux", trimmed: "1.13.1"},
+		{input: "1.13.1-riscv64-linux-gnu", trimmed: "1.13.1"},
+		{input: "1.13.1-loongarch64-linux", trimmed: "1.13.1"},
+		{input: "1.13.1-universal-java-17", trimmed: "1.13.1"},
+		{input: "1.13.1.rc1-x86_64-linux", trimmed: "1.13.1.rc1"},
 		// ruby versions get the canonical segment "pre" if there are any segments that are all
 		// alphabetic characters.
 		{input: "1.13.1-beta-universal-darwin-8", trimmed: "1.13.1.pre.beta"},
@@ -0,0 +1,115 @@
+package version
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var _ Comparator = (*nugetVersion)(nil)
+
+// nugetVersion is a NuGet package version (https://learn.microsoft.com/en-us/nuget/concepts/package-versioning):
+// up to four numeric segments (major.minor.patch.revision, where missing segments are zero, so 1.0 == 1.0.0.0),
+// an optional release label compared with SemVer 2.0 precedence (case-insensitively, per NuGet), and build metadata
+// which is ignored for comparison.
+type nugetVersion struct {
+	segments [4]*big.Int
+	release  []string
+}
+
+func newNugetVersion(raw string) (nugetVersion, error) {
+	clean := trimLeadingV(strings.TrimSpace(raw))
+
+	// build metadata does not participate in precedence
+	if i := strings.Index(clean, "+"); i >= 0 {
+		clean = clean[:i]
+	}
+
+	numeric, release, hasRelease := strings.Cut(clean, "-")
+	if hasRelease && release == "" {
+		return nugetVersion{}, invalidFormatError(NuGetFormat, raw, fmt.Errorf("empty release label"))
+	}
+
+	parts := strings.Split(numeric, ".")
+	if len(parts) > len(nugetVersion{}.segments) {
+		return nugetVersion{}, invalidFormatError(NuGetFormat, raw, fmt.Errorf("too many version segments"))
+	}
+
+	var v nugetVersion
+	for i := range v.segments {
+		v.segments[i] = big.NewInt(0)
+	}
+	for i, p := range parts {
+		n, ok := new(big.Int).SetString(p, 10)
+		if !ok || n.Sign() < 0 {
+			return nugetVersion{}, invalidFormatError(NuGetFormat, raw, fmt.Errorf("invalid version segment %q", p))
+		}
+		v.segments[i] = n
+	}
+
+	if hasRelease {
+		v.release = strings.Split(release, ".")
+		for _, label := range v.release {
+			if label == "" {
+				return nugetVersion{}, invalidFormatError(NuGetFormat, raw, fmt.Errorf("empty release label identifier"))
+			}
+		}
+	}
+
+	return v, nil
+}
+
+func (v nugetVersion) Compare(other *Version) (int, error) {
+	if other == nil {
+		return -1, ErrNoVersionProvided
+	}
+
+	o, err := newNugetVersion(other.Raw)
+	if err != nil {
+		return 0, err
+	}
+	return v.compare(o), nil
+}
+
+func (v nugetVersion) compare(o nugetVersion) int {
+	for i := range v.segments {
+		if c := v.segments[i].Cmp(o.segments[i]); c != 0 {
+			return c
+		}
+	}
+
+	// a release (no label) has higher precedence than any prerelease of the same version
+	switch {
+	case len(v.release) == 0 && len(o.release) == 0:
+		return 0
+	case len(v.release) == 0:
+		return 1
+	case len(o.release) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.release) && i < len(o.release); i++ {
+		if c := compareReleaseLabel(v.release[i], o.release[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.release), len(o.release))
+}
+
+// compareReleaseLabel compares release label identifiers: numeric identifiers compare numerically and have lower
+// precedence than alphanumeric identifiers, which compare case-insensitively.
+func compareReleaseLabel(a, b string) int {
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(aNum, bNum)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
@@ -0,0 +1,77 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNugetVersion_Constraint(t *testing.T) {
+	tests := []testCase{
+		// empty values
+		{version: "2.3.1", constraint: "", satisfied: true},
+		// missing segments are zero
+		{version: "1.0", constraint: "= 1.0.0.0", satisfied: true},
+		{version: "1.0.0.0", constraint: "= 1.0", satisfied: true},
+		// four-part versions
+		{version: "4.5.0.1", constraint: "> 4.5.0", satisfied: true},
+		{version: "4.5.0.1", constraint: "< 4.5.0.2", satisfied: true},
+		{version: "4.5.0.10", constraint: "< 4.5.0.9", satisfied: false},
+		// typical .NET runtime ranges
+		{version: "8.0.4", constraint: ">= 8.0.0, < 8.0.5", satisfied: true},
+		{version: "8.0.5", constraint: ">= 8.0.0, < 8.0.5", satisfied: false},
+		{version: "6.0.29", constraint: ">= 8.0.0, < 8.0.5 || >= 6.0.0, < 6.0.30", satisfied: true},
+		// prerelease semantics
+		{version: "8.0.0-rc.2.23479.6", constraint: "< 8.0.0", satisfied: true},
+		{version: "8.0.0-preview.7.23375.6", constraint: "< 8.0.0-rc.1.23419.4", satisfied: true},
+		{version: "1.0.0-Beta", constraint: "= 1.0.0-beta", satisfied: true},
+		{version: "1.0.0-beta.2", constraint: "< 1.0.0-beta.11", satisfied: true},
+		{version: "1.0.0-beta.11", constraint: "< 1.0.0-beta.2", satisfied: false},
+		{version: "1.0.0-alpha", constraint: "< 1.0.0-alpha.1", satisfied: true},
+		{version: "1.0.0-1", constraint: "< 1.0.0-alpha", satisfied: true},
+		// build metadata is ignored
+		{version: "1.0.0+abc123", constraint: "= 1.0.0", satisfied: true},
+		{version: "1.0.0-rc.1+build.5", constraint: "= 1.0.0-rc.1", satisfied: true},
+		// v-prefix
+		{version: "v2.1.0", constraint: "< 2.1.1", satisfied: true},
+		// invalid
+		{version: "1.2.3.4.5", constraint: "< 2.0", wantError: require.Error},
+		{version: "not-a-version", constraint: "< 2.0", wantError: require.Error},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tName(), func(t *testing.T) {
+			constraint, err := GetConstraint(test.constraint, NuGetFormat)
+			require.NoError(t, err)
+
+			test.assertVersionConstraint(t, NuGetFormat, constraint)
+		})
+	}
+}
+
+func TestNugetVersion_Compare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0.0", b: "1.0.0.0", want: 0},
+		{a: "1.0.0.1", b: "1.0.0", want: 1},
+		{a: "1.0.0-alpha", b: "1.0.0", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-alpha.beta", want: -1},
+		{a: "1.0.0-alpha.beta", b: "1.0.0-beta", want: -1},
+		{a: "1.0.0-beta.2", b: "1.0.0-beta.11", want: -1},
+		{a: "1.0.0-rc.1", b: "1.0.0-RC.1", want: 0},
+		{a: "18446744073709551616.0", b: "1.0", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, err := newNugetVersion(tt.a)
+			require.NoError(t, err)
+
+			got, err := a.Compare(New(tt.b, NuGetFormat))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
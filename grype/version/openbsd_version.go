@@ -0,0 +1,95 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var _ Comparator = (*openbsdVersion)(nil)
+
+// openbsdVersionPattern splits an OpenBSD package version into the upstream version, the package patch level
+// (pN, REVISION in the ports tree) and the package epoch (vN). See packages-specs(7) for details.
+var openbsdVersionPattern = regexp.MustCompile(`^(.+?)(?:p(\d+))?(?:v(\d+))?$`)
+
+// openbsdVersion represents an OpenBSD package version of the form version[pN][vN][-flavor].
+type openbsdVersion struct {
+	version  string
+	revision int
+	epoch    int
+}
+
+func newOpenBSDVersion(raw string) (openbsdVersion, error) {
+	raw = strings.TrimSpace(raw)
+
+	// flavors (e.g. "-no_x11") do not take part in the version comparison
+	if idx := strings.Index(raw, "-"); idx >= 0 {
+		raw = raw[:idx]
+	}
+
+	if raw == "" {
+		return openbsdVersion{}, fmt.Errorf("empty OpenBSD version")
+	}
+
+	match := openbsdVersionPattern.FindStringSubmatch(raw)
+	if match == nil {
+		return openbsdVersion{}, fmt.Errorf("invalid OpenBSD version %q", raw)
+	}
+
+	v := openbsdVersion{version: match[1]}
+
+	var err error
+	if match[2] != "" {
+		v.revision, err = strconv.Atoi(match[2])
+		if err != nil {
+			return openbsdVersion{}, fmt.Errorf("invalid OpenBSD package patch level in %q: %w", raw, err)
+		}
+	}
+	if match[3] != "" {
+		v.epoch, err = strconv.Atoi(match[3])
+		if err != nil {
+			return openbsdVersion{}, fmt.Errorf("invalid OpenBSD package epoch in %q: %w", raw, err)
+		}
+	}
+
+	return v, nil
+}
+
+func (v openbsdVersion) Compare(other *Version) (int, error) {
+	if other == nil {
+		return -1, ErrNoVersionProvided
+	}
+
+	o, err := newOpenBSDVersion(other.Raw)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.compare(o), nil
+}
+
+// compare returns 0 if v == v2, -1 if v < v2, and +1 if v > v2. The package epoch always takes precedence,
+// followed by the upstream version and finally the package patch level.
+func (v openbsdVersion) compare(v2 openbsdVersion) int {
+	if c := compareInts(v.epoch, v2.epoch); c != 0 {
+		return c
+	}
+
+	if c := compareBSDVersions(v.version, v2.version); c != 0 {
+		return c
+	}
+
+	return compareInts(v.revision, v2.revision)
+}
+
+func (v openbsdVersion) String() string {
+	version := v.version
+	if v.revision != 0 {
+		version += fmt.Sprintf("p%d", v.revision)
+	}
+	if v.epoch != 0 {
+		version += fmt.Sprintf("v%d", v.epoch)
+	}
+	return version
+}
@@ -0,0 +1,66 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenBSDVersionCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		v1   string
+		v2   string
+		want int
+	}{
+		{name: "equal versions", v1: "3.1.5", v2: "3.1.5", want: 0},
+		{name: "first greater", v1: "3.1.6", v2: "3.1.5", want: 1},
+		{name: "second greater", v1: "3.1.5", v2: "3.10.0", want: -1},
+		{name: "package patch level", v1: "3.1.5p1", v2: "3.1.5", want: 1},
+		{name: "package patch level ordering", v1: "3.1.5p1", v2: "3.1.5p2", want: -1},
+		{name: "zero package epoch", v1: "1.0v0", v2: "2.0", want: -1},
+		{name: "package epoch greater", v1: "1.0v1", v2: "2.0", want: 1},
+		{name: "epoch with patch level", v1: "1.0p2v1", v2: "1.0p1v1", want: 1},
+		{name: "flavor is ignored", v1: "9.0.2100-no_x11", v2: "9.0.2100", want: 0},
+		{name: "release candidate before release", v1: "2.0rc1", v2: "2.0", want: -1},
+		{name: "letter after release", v1: "1.1.1w", v2: "1.1.1", want: 1},
+		{name: "upstream patch level", v1: "2.4pl1", v2: "2.4", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1, err := newOpenBSDVersion(tt.v1)
+			require.NoError(t, err)
+
+			result, err := v1.Compare(New(tt.v2, OpenBSDFormat))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+
+			// the comparison must be symmetric
+			v2, err := newOpenBSDVersion(tt.v2)
+			require.NoError(t, err)
+
+			result, err = v2.Compare(New(tt.v1, OpenBSDFormat))
+			require.NoError(t, err)
+			assert.Equal(t, -tt.want, result)
+		})
+	}
+}
+
+func TestOpenBSDVersionString(t *testing.T) {
+	tests := []string{
+		"3.1.5",
+		"3.1.5p1",
+		"3.1.5v2",
+		"3.1.5p4v1",
+	}
+
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			v, err := newOpenBSDVersion(raw)
+			require.NoError(t, err)
+			assert.Equal(t, raw, v.String())
+		})
+	}
+}
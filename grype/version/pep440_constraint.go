@@ -0,0 +1,134 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440SpecifierPattern splits a PEP 440 version specifier (https://peps.python.org/pep-0440/#version-specifiers)
+// into its comparison operator and version.
+var pep440SpecifierPattern = regexp.MustCompile(`^\s*(~=|===|==|!=|<=|>=|<|>|=)?\s*(.+?)\s*$`)
+
+// pep440ReleasePattern captures the epoch and release segments of a PEP 440 version.
+var pep440ReleasePattern = regexp.MustCompile(`^[vV]?((?:\d+!)?)(\d+(?:\.\d+)*)`)
+
+// newPep440Constraint creates a constraint from PEP 440 version specifiers. Specifiers without an equivalent range
+// operator are rewritten into ranges before the expression is parsed:
+//   - compatible release "~= 2.2.1" becomes ">= 2.2.1, < 2.3.dev0"
+//   - prefix matching "== 2.2.*" becomes ">= 2.2.dev0, < 2.3.dev0"
+//   - exclusion "!= 2.2" splits the and'ed group into "< 2.2" or'ed with "> 2.2"
+//   - equality "== 2.2" and arbitrary equality "=== 2.2" become "= 2.2"
+func newPep440Constraint(raw string) (genericConstraint, error) {
+	orParts, err := scanExpression(raw)
+	if err != nil {
+		return genericConstraint{}, invalidFormatError(PythonFormat, raw, err)
+	}
+
+	var expression simpleRangeExpression
+	for _, andParts := range orParts {
+		groups := [][]rangeUnit{nil}
+		for _, part := range andParts {
+			alternatives, err := pep440RangeUnits(part)
+			if err != nil {
+				return genericConstraint{}, invalidFormatError(PythonFormat, raw, err)
+			}
+			// each alternative of the specifier is and'ed with every group collected so far
+			var next [][]rangeUnit
+			for _, group := range groups {
+				for _, units := range alternatives {
+					next = append(next, append(append([]rangeUnit{}, group...), units...))
+				}
+			}
+			groups = next
+		}
+		expression.Units = append(expression.Units, groups...)
+	}
+
+	return genericConstraint{
+		Expression: expression,
+		Raw:        raw,
+		Fmt:        PythonFormat,
+	}, nil
+}
+
+// pep440RangeUnits returns the range units equivalent to a single specifier as alternatives to be or'ed together,
+// each of which is a group of units to be and'ed together.
+func pep440RangeUnits(specifier string) ([][]rangeUnit, error) {
+	match := pep440SpecifierPattern.FindStringSubmatch(specifier)
+	if match == nil {
+		return nil, fmt.Errorf("unable to parse specifier %q", specifier)
+	}
+	op, ver := match[1], match[2]
+
+	if prefix, ok := strings.CutSuffix(ver, ".*"); ok {
+		lower, upper, err := pep440PrefixRange(prefix)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "==", "=", "":
+			return pep440Units([]string{">=", lower, "<", upper})
+		case "!=":
+			return pep440Units([]string{"<", lower}, []string{">=", upper})
+		}
+		return nil, fmt.Errorf("wildcard versions are not allowed with the %q operator", op)
+	}
+
+	switch op {
+	case "~=":
+		release := pep440ReleasePattern.FindStringSubmatch(ver)
+		if release == nil || !strings.Contains(release[2], ".") {
+			return nil, fmt.Errorf("compatible release specifier %q must have at least two release segments", specifier)
+		}
+		_, upper, err := pep440PrefixRange(release[1] + release[2][:strings.LastIndex(release[2], ".")])
+		if err != nil {
+			return nil, err
+		}
+		return pep440Units([]string{">=", ver, "<", upper})
+	case "!=":
+		return pep440Units([]string{"<", ver}, []string{">", ver})
+	case "==", "===":
+		op = "="
+	}
+	return pep440Units([]string{op, ver})
+}
+
+// pep440PrefixRange returns the lowest version having the given release prefix (e.g. "2.2" gives "2.2.dev0") and the
+// lowest version above all versions having that prefix (e.g. "2.3.dev0").
+func pep440PrefixRange(prefix string) (string, string, error) {
+	release := pep440ReleasePattern.FindStringSubmatch(prefix)
+	if release == nil || release[0] != prefix {
+		return "", "", fmt.Errorf("invalid version prefix %q", prefix)
+	}
+	epoch, segments := release[1], strings.Split(release[2], ".")
+
+	last, err := strconv.Atoi(segments[len(segments)-1])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid version prefix %q: %w", prefix, err)
+	}
+	segments[len(segments)-1] = strconv.Itoa(last + 1)
+
+	return prefix + ".dev0", epoch + strings.Join(segments, ".") + ".dev0", nil
+}
+
+// pep440Units parses alternatives given as flattened operator and version pairs.
+func pep440Units(alternatives ...[]string) ([][]rangeUnit, error) {
+	var out [][]rangeUnit
+	for _, pairs := range alternatives {
+		var units []rangeUnit
+		for i := 0; i+1 < len(pairs); i += 2 {
+			unit, err := parseRange(pairs[i] + " " + pairs[i+1])
+			if err != nil {
+				return nil, err
+			}
+			if unit == nil {
+				return nil, fmt.Errorf("unable to parse unit: %q", pairs[i]+" "+pairs[i+1])
+			}
+			units = append(units, *unit)
+		}
+		out = append(out, units)
+	}
+	return out, nil
+}
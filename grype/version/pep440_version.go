@@ -17,7 +17,7 @@ func newPep440Version(raw string) (pep440Version, error) {
 	// lets ensure this is a valid PEP 440 version
 	parsed, err := goPepVersion.Parse(raw)
 	if err != nil {
-		return pep440Version{}, invalidFormatError(SemanticFormat, raw, err)
+		return pep440Version{}, invalidFormatError(PythonFormat, raw, err)
 	}
 
 	// we want to use the "public" portion of the version for comparison purposes (for specifier matching, not local versions).
@@ -30,7 +30,7 @@ func newPep440Version(raw string) (pep440Version, error) {
 	// This means that for a version like "1.0.0+abc.1", we only want to consider "1.0.0" for comparison purposes.
 	public, err := goPepVersion.Parse(parsed.Public())
 	if err != nil {
-		return pep440Version{}, invalidFormatError(SemanticFormat, raw, err)
+		return pep440Version{}, invalidFormatError(PythonFormat, raw, err)
 	}
 	return pep440Version{
 		public: public,
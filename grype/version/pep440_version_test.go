@@ -1,6 +1,8 @@
 package version
 
 import (
+	"bufio"
+	"os"
 	"strings"
 	"testing"
 
@@ -386,3 +388,67 @@ func TestPep440Version_Compare_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestPep440Version_OrderingCorpus checks version ordering against a corpus of PEP 440 cases covering
+// pre, post and dev releases, alternate spellings, epochs and local versions.
+func TestPep440Version_OrderingCorpus(t *testing.T) {
+	for _, fields := range readPep440Corpus(t, "testdata/pep440-ordering.txt", " ") {
+		require.Len(t, fields, 3)
+		left, op, right := fields[0], fields[1], fields[2]
+
+		t.Run(strings.Join(fields, " "), func(t *testing.T) {
+			v, err := newPep440Version(left)
+			require.NoError(t, err)
+
+			got, err := v.Compare(New(right, PythonFormat))
+			require.NoError(t, err)
+
+			want := map[string]int{"<": -1, "==": 0, ">": 1}[op]
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+// TestPep440Version_ConstraintCorpus checks constraint satisfaction against a corpus of PEP 440 cases at the
+// boundaries of vulnerable ranges.
+func TestPep440Version_ConstraintCorpus(t *testing.T) {
+	for _, fields := range readPep440Corpus(t, "testdata/pep440-constraints.txt", " | ") {
+		require.Len(t, fields, 3)
+
+		test := testCase{
+			version:    fields[0],
+			constraint: fields[1],
+			satisfied:  fields[2] == "true",
+		}
+		t.Run(test.tName(), func(t *testing.T) {
+			constraint, err := GetConstraint(test.constraint, PythonFormat)
+			require.NoError(t, err)
+			test.assertVersionConstraint(t, PythonFormat, constraint)
+		})
+	}
+}
+
+func readPep440Corpus(t *testing.T, path, sep string) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var cases [][]string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var fields []string
+		for _, field := range strings.Split(line, sep) {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+		cases = append(cases, fields)
+	}
+	require.NoError(t, scanner.Err())
+	return cases
+}
@@ -0,0 +1,30 @@
+package version
+
+import (
+	"regexp"
+	"strings"
+)
+
+// streamPattern captures the optional epoch (e.g. "1:" for deb/rpm versions) and leading numeric release segments of
+// a version, ignoring any "v" prefix (e.g. Go module versions).
+var streamPattern = regexp.MustCompile(`^(\d+:)?[vV]?(\d+(?:\.\d+)*)`)
+
+// Stream returns the release stream of the given version made of (up to) the given number of leading numeric
+// segments, e.g. "2.2" for version "2.2.34-r1" with 2 segments. Versions of different epochs are always on different
+// streams. An empty string is returned when the format has no notion of release streams (e.g. KB article numbers) or
+// the version does not start with a numeric segment.
+func Stream(raw string, format Format, segments int) string {
+	if format == KBFormat || segments <= 0 {
+		return ""
+	}
+	m := streamPattern.FindStringSubmatch(strings.TrimSpace(raw))
+	if m == nil {
+		return ""
+	}
+	parts := strings.Split(m[2], ".")
+	// a version without a minor segment (e.g. "2") is on the "2.0" stream
+	for len(parts) < segments {
+		parts = append(parts, "0")
+	}
+	return m[1] + strings.Join(parts[:segments], ".")
+}
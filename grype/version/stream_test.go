@@ -0,0 +1,32 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		raw      string
+		format   Format
+		segments int
+		want     string
+	}{
+		{raw: "2.2.34", format: SemanticFormat, segments: 2, want: "2.2"},
+		{raw: "2.2.34", format: SemanticFormat, segments: 1, want: "2"},
+		{raw: "v1.21.3", format: GolangFormat, segments: 2, want: "1.21"},
+		{raw: "2", format: SemanticFormat, segments: 2, want: "2.0"},
+		{raw: "1:2.2.34-1ubuntu1", format: DebFormat, segments: 2, want: "1:2.2"},
+		{raw: "2.2.34-r1", format: ApkFormat, segments: 2, want: "2.2"},
+		{raw: "1.8.0_292", format: JVMFormat, segments: 2, want: "1.8"},
+		{raw: "2.2.34", format: SemanticFormat, segments: 0, want: ""},
+		{raw: "5001234", format: KBFormat, segments: 1, want: ""},
+		{raw: "latest", format: UnknownFormat, segments: 1, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			assert.Equal(t, tt.want, Stream(tt.raw, tt.format, tt.segments))
+		})
+	}
+}
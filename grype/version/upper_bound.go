@@ -0,0 +1,54 @@
+package version
+
+// HasUpperBound indicates whether the given constraint limits the affected versions from above. Constraints such as
+// ">= 1.0" (or an empty constraint) describe every version from some point onward as affected, which is how
+// providers typically express "affected, fix unknown".
+func HasUpperBound(c Constraint) bool {
+	if c == nil || c.Value() == "" {
+		return false
+	}
+
+	switch con := c.(type) {
+	case genericConstraint:
+		return con.Expression.hasUpperBound()
+	case kbConstraint:
+		return con.Expression.hasUpperBound()
+	case fuzzyConstraint:
+		return con.Constraints.hasUpperBound()
+	case combinedConstraint:
+		for _, op := range con.OrOperands {
+			if !HasUpperBound(op) {
+				return false
+			}
+		}
+		return true
+	}
+
+	expression, err := parseRangeExpression(c.Value())
+	if err != nil {
+		// we cannot reason about the constraint, so assume it is bounded rather than flag it
+		return true
+	}
+	return expression.hasUpperBound()
+}
+
+// hasUpperBound returns true when every or'd group of the expression is capped by a "<", "<=" or "=" unit.
+func (c simpleRangeExpression) hasUpperBound() bool {
+	if len(c.Units) == 0 {
+		return false
+	}
+
+	for _, andOperand := range c.Units {
+		bounded := false
+		for _, unit := range andOperand {
+			switch unit.Operator {
+			case LT, LTE, EQ:
+				bounded = true
+			}
+		}
+		if !bounded {
+			return false
+		}
+	}
+	return true
+}
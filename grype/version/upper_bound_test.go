@@ -0,0 +1,82 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasUpperBound(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint Constraint
+		want       bool
+	}{
+		{
+			name:       "nil constraint",
+			constraint: nil,
+			want:       false,
+		},
+		{
+			name:       "empty constraint",
+			constraint: MustGetConstraint("", SemanticFormat),
+			want:       false,
+		},
+		{
+			name:       "lower bound only",
+			constraint: MustGetConstraint(">= 1.0.0", SemanticFormat),
+			want:       false,
+		},
+		{
+			name:       "closed range",
+			constraint: MustGetConstraint(">= 1.0.0, < 2.0.0", SemanticFormat),
+			want:       true,
+		},
+		{
+			name:       "less than",
+			constraint: MustGetConstraint("< 2.0.0", DebFormat),
+			want:       true,
+		},
+		{
+			name:       "exact version",
+			constraint: MustGetConstraint("= 1.2.3", PythonFormat),
+			want:       true,
+		},
+		{
+			name:       "one or'd group without an upper bound",
+			constraint: MustGetConstraint("< 1.0.0 || >= 2.0.0", SemanticFormat),
+			want:       false,
+		},
+		{
+			name:       "fuzzy constraint",
+			constraint: MustGetConstraint("> 1.0", UnknownFormat),
+			want:       false,
+		},
+		{
+			name:       "kb constraint",
+			constraint: MustGetConstraint("878787 || 2", KBFormat),
+			want:       true,
+		},
+		{
+			name: "combined constraint with an unbounded operand",
+			constraint: CombineConstraints(
+				MustGetConstraint("< 2.0.0", SemanticFormat),
+				MustGetConstraint(">= 3.0.0", SemanticFormat),
+			),
+			want: false,
+		},
+		{
+			name: "combined constraint with bounded operands",
+			constraint: CombineConstraints(
+				MustGetConstraint("< 2.0.0", SemanticFormat),
+				MustGetConstraint(">= 3.0.0, < 3.1.0", SemanticFormat),
+			),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HasUpperBound(tt.constraint))
+		})
+	}
+}
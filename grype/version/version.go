@@ -76,6 +76,12 @@ func (v *Version) getComparator(format Format) (Comparator, error) {
 		comparator, err = newJvmVersion(v.Raw)
 	case PacmanFormat:
 		comparator, err = newPacmanVersion(v.Raw)
+	case FreeBSDFormat:
+		comparator, err = newFreeBSDVersion(v.Raw)
+	case OpenBSDFormat:
+		comparator, err = newOpenBSDVersion(v.Raw)
+	case NuGetFormat:
+		comparator, err = newNugetVersion(v.Raw)
 	case UnknownFormat:
 		comparator, err = newFuzzyVersion(v.Raw)
 	default:
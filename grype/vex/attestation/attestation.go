@@ -0,0 +1,340 @@
+// Package attestation discovers VEX documents published as signed attestations of the base image of a container
+// image, so that the suppressions of the base image maintainers carry over to images derived from it.
+//
+// The base image is identified by the standard OCI annotations (or labels) of the scanned image, and attestations are
+// looked up using the cosign tag convention (<repository>:sha256-<digest>.att). Only DSSE envelopes holding an in-toto
+// statement about the base image with an OpenVEX predicate, signed by one of the configured keys, are used.
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	openvex "github.com/openvex/go-vex/pkg/vex"
+
+	"github.com/anchore/grype/internal/bandwidth"
+	"github.com/anchore/grype/internal/dsse"
+	"github.com/anchore/grype/internal/log"
+	"github.com/anchore/grype/internal/proxy"
+	"github.com/anchore/grype/internal/retry"
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	// BaseNameAnnotation is the OCI annotation (or label) holding the reference of the base image of an image.
+	BaseNameAnnotation = "org.opencontainers.image.base.name"
+
+	// BaseDigestAnnotation is the OCI annotation (or label) holding the digest of the base image of an image.
+	BaseDigestAnnotation = "org.opencontainers.image.base.digest"
+
+	inTotoPayloadType      = "application/vnd.in-toto+json"
+	openVEXPredicatePrefix = "https://openvex.dev/ns"
+	dsseMediaType          = types.MediaType("application/vnd.dsse.envelope.v1+json")
+)
+
+// Config configures how attestations are fetched and which are trusted.
+type Config struct {
+	// Registry holds the registry credentials and TLS options (the default keychain is used when nil).
+	Registry *image.RegistryOptions
+
+	// Verifiers are the keys an attestation must be signed with to be used.
+	Verifiers []*dsse.Verifier
+
+	// Proxy selects the proxy for registry requests (the proxy configured by the environment when empty).
+	Proxy proxy.Rule
+
+	// Bandwidth limits the rate of registry downloads (unlimited when nil).
+	Bandwidth *bandwidth.Limiter
+
+	// Retry configures how registry requests failing with transient network errors are retried (the registry client
+	// defaults are used when retries are disabled).
+	Retry retry.Config
+}
+
+// Document is a VEX document inherited from the base image, along with its provenance.
+type Document struct {
+	// Image is the reference of the base image the attestation was found for.
+	Image string
+
+	// Digest is the digest of the base image.
+	Digest string
+
+	// KeyID identifies the key the attestation was signed with.
+	KeyID string
+
+	// Inherited is the number of statements carried over to the scanned image.
+	Inherited int
+
+	// Dropped is the number of statements about the base image as a whole, which are not carried over since the
+	// derived image may have added vulnerable content.
+	Dropped int
+
+	VEX *openvex.VEX
+}
+
+// statement is an in-toto statement (v0.1 or v1).
+type statement struct {
+	PredicateType string          `json:"predicateType"`
+	Subject       []subject       `json:"subject"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+type subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// BaseImage returns the reference and digest (which may be empty) of the base image of the given source, as declared
+// by its OCI annotations or labels. Returns false when the source is not an image or does not declare a base image.
+func BaseImage(src *source.Description) (ref, digest string, ok bool) {
+	if src == nil {
+		return "", "", false
+	}
+	m, isImage := src.Metadata.(source.ImageMetadata)
+	if !isImage {
+		return "", "", false
+	}
+	for _, values := range []map[string]string{m.Annotations, m.Labels} {
+		if values[BaseNameAnnotation] != "" {
+			return values[BaseNameAnnotation], values[BaseDigestAnnotation], true
+		}
+	}
+	return "", "", false
+}
+
+// Discover returns the VEX documents attested for the base image of the given source. No documents (and no error)
+// are returned when the source does not declare a base image, or the base image has no attestations.
+func Discover(ctx context.Context, src *source.Description, cfg Config) ([]Document, error) {
+	baseName, baseDigest, ok := BaseImage(src)
+	if !ok {
+		return nil, nil
+	}
+	if len(cfg.Verifiers) == 0 {
+		return nil, fmt.Errorf("no keys configured to verify VEX attestations with")
+	}
+
+	var nameOpts []name.Option
+	if cfg.Registry != nil && cfg.Registry.InsecureUseHTTP {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	ref, err := name.ParseReference(baseName, nameOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base image reference %q: %w", baseName, err)
+	}
+
+	opts, err := remoteOptions(ctx, ref.Context().RegistryStr(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseDigest == "" {
+		if d, isDigest := ref.(name.Digest); isDigest {
+			baseDigest = d.DigestStr()
+		} else {
+			desc, err := remote.Head(ref, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve base image %q: %w", baseName, err)
+			}
+			baseDigest = desc.Digest.String()
+			log.WithFields("image", baseName, "digest", baseDigest).Debug("resolved base image without a declared digest")
+		}
+	}
+
+	algorithm, hex, found := strings.Cut(baseDigest, ":")
+	if !found {
+		return nil, fmt.Errorf("invalid base image digest %q", baseDigest)
+	}
+	attRef := ref.Context().Tag(fmt.Sprintf("%s-%s.att", algorithm, hex))
+
+	img, err := remote.Image(attRef, opts...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			log.WithFields("image", baseName, "digest", baseDigest).Debug("base image has no attestations")
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to fetch attestations of base image %q: %w", baseName, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read attestations of base image %q: %w", baseName, err)
+	}
+
+	var docs []Document
+	for _, layer := range layers {
+		mediaType, err := layer.MediaType()
+		if err != nil || mediaType != dsseMediaType {
+			continue
+		}
+		env, err := readEnvelope(layer.Uncompressed)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read attestation of base image %q: %w", baseName, err)
+		}
+		doc, err := fromEnvelope(*env, algorithm, hex, cfg.Verifiers)
+		if err != nil {
+			log.WithFields("image", baseName, "digest", baseDigest, "reason", err).Warn("ignoring attestation of base image")
+			continue
+		}
+		if doc == nil {
+			continue
+		}
+		doc.Image = baseName
+		doc.Digest = baseDigest
+		docs = append(docs, *doc)
+	}
+	return docs, nil
+}
+
+// fromEnvelope returns the VEX document held by a DSSE envelope (nil if the envelope holds another kind of
+// attestation), verifying it is signed by one of the given keys and is about the base image with the given digest.
+func fromEnvelope(env dsse.Envelope, algorithm, hex string, verifiers []*dsse.Verifier) (*Document, error) {
+	if env.PayloadType != inTotoPayloadType {
+		return nil, nil
+	}
+
+	// filter on the predicate type before verifying, since the base image may be attested by other parties (e.g. SLSA
+	// provenance by the build system) with keys that are not configured
+	raw, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode payload: %w", err)
+	}
+	var unverified statement
+	if err := json.Unmarshal(raw, &unverified); err != nil {
+		return nil, fmt.Errorf("unable to parse in-toto statement: %w", err)
+	}
+	if !strings.HasPrefix(unverified.PredicateType, openVEXPredicatePrefix) {
+		return nil, nil
+	}
+
+	var payload []byte
+	var keyID string
+	for _, v := range verifiers {
+		if payload, err = v.Verify(env); err == nil {
+			keyID = v.KeyID()
+			break
+		}
+	}
+	if payload == nil {
+		return nil, fmt.Errorf("VEX attestation is not signed by any of the configured keys")
+	}
+
+	var st statement
+	if err := json.Unmarshal(payload, &st); err != nil {
+		return nil, fmt.Errorf("unable to parse in-toto statement: %w", err)
+	}
+	if !hasSubject(st.Subject, algorithm, hex) {
+		return nil, fmt.Errorf("VEX attestation is not about the base image (%s:%s)", algorithm, hex)
+	}
+
+	vex, err := openvex.Parse(st.Predicate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse OpenVEX predicate: %w", err)
+	}
+
+	inherited, dropped := Inherit(vex)
+	return &Document{
+		KeyID:     keyID,
+		Inherited: inherited,
+		Dropped:   dropped,
+		VEX:       vex,
+	}, nil
+}
+
+// Inherit rewrites the statements of a VEX document about the base image into statements about the packages
+// (subcomponents) they name, which apply to the same packages found in a derived image. Statements about the base
+// image as a whole are removed. Returns the number of statements kept and removed.
+func Inherit(doc *openvex.VEX) (inherited, dropped int) {
+	var statements []openvex.Statement
+	for _, s := range doc.Statements {
+		var products []openvex.Product
+		for _, p := range s.Products {
+			for _, sub := range p.Subcomponents {
+				products = append(products, openvex.Product{Component: sub.Component})
+			}
+		}
+		if len(products) == 0 {
+			dropped++
+			continue
+		}
+		s.Products = products
+		statements = append(statements, s)
+	}
+	doc.Statements = statements
+	return len(statements), dropped
+}
+
+func hasSubject(subjects []subject, algorithm, hex string) bool {
+	for _, s := range subjects {
+		if strings.EqualFold(s.Digest[algorithm], hex) {
+			return true
+		}
+	}
+	return false
+}
+
+func readEnvelope(open func() (io.ReadCloser, error)) (*dsse.Envelope, error) {
+	rc, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var env dsse.Envelope
+	if err := json.NewDecoder(rc).Decode(&env); err != nil {
+		return nil, fmt.Errorf("unable to parse DSSE envelope: %w", err)
+	}
+	return &env, nil
+}
+
+func remoteOptions(ctx context.Context, registry string, cfg Config) ([]remote.Option, error) {
+	out := []remote.Option{remote.WithContext(ctx)}
+	if cfg.Retry.MaxRetries > 0 {
+		out = append(out, remote.WithRetryBackoff(remote.Backoff{
+			Duration: cfg.Retry.Backoff,
+			Factor:   2,
+			Steps:    cfg.Retry.MaxRetries + 1,
+			Cap:      cfg.Retry.MaxBackoff,
+		}))
+	}
+	opts := cfg.Registry
+	if opts == nil {
+		out = append(out, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+		if !cfg.Proxy.IsZero() || cfg.Bandwidth != nil {
+			out = append(out, remote.WithTransport(cfg.Bandwidth.Transport(cfg.Proxy.Transport(remote.DefaultTransport.(*http.Transport)))))
+		}
+		return out, nil
+	}
+
+	if auth := opts.Authenticator(registry); auth != nil {
+		out = append(out, remote.WithAuth(auth))
+	} else if opts.Keychain != nil {
+		out = append(out, remote.WithAuthFromKeychain(opts.Keychain))
+	} else {
+		out = append(out, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+
+	tlsConfig, err := opts.TLSConfig(registry)
+	if err != nil {
+		return nil, err
+	}
+	t := remote.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = tlsConfig
+	if !cfg.Proxy.IsZero() {
+		t.Proxy = cfg.Proxy.Func()
+	}
+	cfg.Bandwidth.Apply(t)
+	return append(out, remote.WithTransport(t)), nil
+}
@@ -0,0 +1,273 @@
+package attestation
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	openvex "github.com/openvex/go-vex/pkg/vex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/internal/dsse"
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/syft/source"
+)
+
+const baseVEX = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/base-1",
+  "author": "base image maintainers",
+  "timestamp": "2024-05-01T00:00:00Z",
+  "version": 1,
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2024-0001"},
+      "products": [
+        {
+          "@id": "pkg:oci/base@sha256:aaaa",
+          "subcomponents": [{"@id": "pkg:deb/debian/openssl@3.0.11-1"}]
+        }
+      ],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    },
+    {
+      "vulnerability": {"name": "CVE-2024-0002"},
+      "products": [{"@id": "pkg:oci/base@sha256:aaaa"}],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_present"
+    }
+  ]
+}`
+
+func TestBaseImage(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        *source.Description
+		wantRef    string
+		wantDigest string
+		wantOK     bool
+	}{
+		{
+			name: "annotations",
+			src: &source.Description{Metadata: source.ImageMetadata{Annotations: map[string]string{
+				BaseNameAnnotation:   "docker.io/library/debian:12",
+				BaseDigestAnnotation: "sha256:abcd",
+			}}},
+			wantRef:    "docker.io/library/debian:12",
+			wantDigest: "sha256:abcd",
+			wantOK:     true,
+		},
+		{
+			name: "labels",
+			src: &source.Description{Metadata: source.ImageMetadata{Labels: map[string]string{
+				BaseNameAnnotation: "debian:12",
+			}}},
+			wantRef: "debian:12",
+			wantOK:  true,
+		},
+		{
+			name: "no base image",
+			src:  &source.Description{Metadata: source.ImageMetadata{}},
+		},
+		{
+			name: "not an image",
+			src:  &source.Description{Metadata: source.DirectoryMetadata{Path: "."}},
+		},
+		{
+			name: "no source",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, digest, ok := BaseImage(tt.src)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantRef, ref)
+			assert.Equal(t, tt.wantDigest, digest)
+		})
+	}
+}
+
+func TestInherit(t *testing.T) {
+	doc, err := openvex.Parse([]byte(baseVEX))
+	require.NoError(t, err)
+
+	inherited, dropped := Inherit(doc)
+	assert.Equal(t, 1, inherited)
+	assert.Equal(t, 1, dropped)
+
+	assert.NotEmpty(t, doc.Matches("CVE-2024-0001", "pkg:deb/debian/openssl@3.0.11-1", nil))
+	assert.Empty(t, doc.Matches("CVE-2024-0001", "pkg:oci/base@sha256:aaaa", nil))
+	assert.Empty(t, doc.Matches("CVE-2024-0002", "pkg:oci/base@sha256:aaaa", nil))
+}
+
+func TestFromEnvelope(t *testing.T) {
+	signer, verifier := newKey(t)
+	_, other := newKey(t)
+
+	tests := []struct {
+		name          string
+		env           func(t *testing.T) dsse.Envelope
+		verifiers     []*dsse.Verifier
+		wantInherited int
+		wantNil       bool
+		wantErr       require.ErrorAssertionFunc
+	}{
+		{
+			name: "signed VEX attestation",
+			env: func(t *testing.T) dsse.Envelope {
+				return sign(t, signer, inTotoPayloadType, statementFor(t, "https://openvex.dev/ns/v0.2.0", "abcd"))
+			},
+			verifiers:     []*dsse.Verifier{other, verifier},
+			wantInherited: 1,
+		},
+		{
+			name: "signed by another key",
+			env: func(t *testing.T) dsse.Envelope {
+				return sign(t, signer, inTotoPayloadType, statementFor(t, "https://openvex.dev/ns/v0.2.0", "abcd"))
+			},
+			verifiers: []*dsse.Verifier{other},
+			wantErr:   require.Error,
+		},
+		{
+			name: "about another image",
+			env: func(t *testing.T) dsse.Envelope {
+				return sign(t, signer, inTotoPayloadType, statementFor(t, "https://openvex.dev/ns/v0.2.0", "ffff"))
+			},
+			verifiers: []*dsse.Verifier{verifier},
+			wantErr:   require.Error,
+		},
+		{
+			name: "other predicate type",
+			env: func(t *testing.T) dsse.Envelope {
+				return sign(t, signer, inTotoPayloadType, statementFor(t, "https://slsa.dev/provenance/v1", "abcd"))
+			},
+			verifiers: []*dsse.Verifier{other},
+			wantNil:   true,
+		},
+		{
+			name: "other payload type",
+			env: func(t *testing.T) dsse.Envelope {
+				return sign(t, signer, "application/json", []byte(baseVEX))
+			},
+			verifiers: []*dsse.Verifier{verifier},
+			wantNil:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			doc, err := fromEnvelope(tt.env(t), "sha256", "abcd", tt.verifiers)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			if tt.wantNil {
+				assert.Nil(t, doc)
+				return
+			}
+			require.NotNil(t, doc)
+			assert.Equal(t, verifier.KeyID(), doc.KeyID)
+			assert.Equal(t, tt.wantInherited, doc.Inherited)
+			assert.Equal(t, "https://example.com/vex/base-1", doc.VEX.ID)
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	base, err := random.Image(64, 1)
+	require.NoError(t, err)
+	baseRef, err := name.ParseReference(host+"/base:1", name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(baseRef, base))
+	digest, err := base.Digest()
+	require.NoError(t, err)
+
+	signer, verifier := newKey(t)
+	env := sign(t, signer, inTotoPayloadType, statementFor(t, "https://openvex.dev/ns/v0.2.0", digest.Hex))
+	by, err := json.Marshal(env)
+	require.NoError(t, err)
+	att, err := mutate.AppendLayers(empty.Image, static.NewLayer(by, dsseMediaType))
+	require.NoError(t, err)
+	attRef, err := name.ParseReference(host+"/base:sha256-"+digest.Hex+".att", name.Insecure)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(attRef, att))
+
+	cfg := Config{
+		Registry:  &image.RegistryOptions{InsecureUseHTTP: true},
+		Verifiers: []*dsse.Verifier{verifier},
+	}
+	derived := func(labels map[string]string) *source.Description {
+		return &source.Description{Metadata: source.ImageMetadata{Labels: labels}}
+	}
+
+	// the digest of the base image is resolved from its tag when not declared
+	docs, err := Discover(context.Background(), derived(map[string]string{BaseNameAnnotation: host + "/base:1"}), cfg)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, host+"/base:1", docs[0].Image)
+	assert.Equal(t, digest.String(), docs[0].Digest)
+	assert.Equal(t, verifier.KeyID(), docs[0].KeyID)
+	assert.Equal(t, 1, docs[0].Inherited)
+	assert.Equal(t, 1, docs[0].Dropped)
+
+	// a base image without attestations
+	other, err := random.Image(64, 1)
+	require.NoError(t, err)
+	otherDigest, err := other.Digest()
+	require.NoError(t, err)
+	docs, err = Discover(context.Background(), derived(map[string]string{
+		BaseNameAnnotation:   host + "/base:2",
+		BaseDigestAnnotation: otherDigest.String(),
+	}), cfg)
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
+
+func newKey(t *testing.T) (*dsse.Signer, *dsse.Verifier) {
+	t.Helper()
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := dsse.NewSigner(key)
+	require.NoError(t, err)
+	verifier, err := dsse.NewVerifier(pub)
+	require.NoError(t, err)
+	return signer, verifier
+}
+
+func statementFor(t *testing.T, predicateType, hex string) []byte {
+	t.Helper()
+	by, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"predicateType": predicateType,
+		"subject":       []subject{{Name: "base", Digest: map[string]string{"sha256": hex}}},
+		"predicate":     json.RawMessage(baseVEX),
+	})
+	require.NoError(t, err)
+	return by
+}
+
+func sign(t *testing.T, signer *dsse.Signer, payloadType string, payload []byte) dsse.Envelope {
+	t.Helper()
+	env, err := signer.Sign(payloadType, payload)
+	require.NoError(t, err)
+	return *env
+}
@@ -24,6 +24,9 @@ type Fix struct {
 	Versions  []string
 	State     FixState
 	Available []FixAvailable
+	// Entitlement is the subscription required to install the fix versions (e.g. "ubuntu-pro" for fixes only published
+	// in the Ubuntu ESM channel), empty when the fix is generally available.
+	Entitlement string
 }
 
 type FixAvailable struct {
@@ -32,6 +35,21 @@ type FixAvailable struct {
 	Kind    string
 }
 
+// EarliestAvailable returns the earliest date any fix became available, or nil if no fix dates are known.
+func (f Fix) EarliestAvailable() *time.Time {
+	var earliest *time.Time
+	for i := range f.Available {
+		d := f.Available[i].Date
+		if d.IsZero() {
+			continue
+		}
+		if earliest == nil || d.Before(*earliest) {
+			earliest = &d
+		}
+	}
+	return earliest
+}
+
 func (f FixState) String() string {
 	return string(f)
 }
@@ -0,0 +1,33 @@
+package vulnerability
+
+import "strings"
+
+// maliciousPackagePrefix is the ID prefix used by the OSV malicious-packages datasets (including the OpenSSF
+// malicious-packages repository) for records describing packages that are malicious by design.
+const maliciousPackagePrefix = "MAL-"
+
+// IsMaliciousPackage indicates if the given vulnerability record describes a known-malicious package rather than a
+// vulnerability within otherwise benign software. Records are recognized by their MAL- ID, so malware advisories that
+// are not published with a MAL- ID (or alias) are not recognized.
+func IsMaliciousPackage(ref Reference) bool {
+	return strings.HasPrefix(strings.ToUpper(ref.ID), maliciousPackagePrefix)
+}
+
+// MaliciousArtifact is a package artifact that a known-malicious package record applies to, identified either by its
+// package URL or by the digest of the artifact itself.
+type MaliciousArtifact struct {
+	// ID is the ID of the malicious package record (e.g. "MAL-2024-1234")
+	ID string
+	// PURL is the versioned package URL of the artifact, without qualifiers or subpath (empty when identified by digest)
+	PURL string
+	// Digest is the self describing digest of the artifact, e.g. "sha256:..." (empty when identified by package URL)
+	Digest string
+}
+
+// MaliciousArtifactProvider is an optional interface that vulnerability providers can implement to look up
+// known-malicious package artifacts by package URL and artifact digest, independent of matching packages by name and
+// version against the affected packages of the records.
+type MaliciousArtifactProvider interface {
+	// MaliciousArtifacts returns the malicious artifacts matching any of the given package URLs or digests.
+	MaliciousArtifacts(purls, digests []string) ([]MaliciousArtifact, error)
+}
@@ -13,9 +13,12 @@ type Metadata struct {
 	URLs           []string // secondary reference URLs a vulnerability may provide
 	Description    string
 	Cvss           []Cvss
+	Severities     []SeverityRecord // every severity reported for the vulnerability, by rank (the first one determines Severity)
 	KnownExploited []KnownExploited
 	EPSS           []EPSS
 	CWEs           []CWE
+	PublishedDate  *time.Time // when the vulnerability record was first published upstream
+	ModifiedDate   *time.Time // when the vulnerability record was last modified upstream
 
 	// calculated as-needed
 	risk float64
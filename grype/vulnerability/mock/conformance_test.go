@@ -0,0 +1,14 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/store/storetest"
+)
+
+func TestVulnerabilityProvider_Conformance(t *testing.T) {
+	storetest.TestProvider(t, func(_ *testing.T, vulns ...vulnerability.Vulnerability) vulnerability.Provider {
+		return VulnerabilityProvider(vulns...)
+	})
+}
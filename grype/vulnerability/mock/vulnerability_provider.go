@@ -38,6 +38,9 @@ func (s *mockProvider) VulnerabilityMetadata(ref vulnerability.Reference) (*vuln
 			if m, ok := vuln.Internal.(*vulnerability.Metadata); ok {
 				meta = m
 			}
+			if meta == nil && vuln.Metadata != nil {
+				meta = vuln.Metadata
+			}
 			if meta != nil {
 				if meta.ID != vuln.ID {
 					meta.ID = vuln.ID
@@ -60,6 +63,8 @@ func (s *mockProvider) FindVulnerabilities(criteria ...vulnerability.Criteria) (
 	var out []vulnerability.Vulnerability
 	out = append(out, s.Vulnerabilities...)
 	return filterE(out, func(v vulnerability.Vulnerability) (bool, error) {
+		// criteria combined with search.Or result in several rows, any of which may match
+	nextRow:
 		for _, row := range search.CriteriaIterator(criteria) {
 			// FIXME: searchForUnaffected is to emulate behavior in the v6 VulnerabilityProvider, which does not include
 			// unaffected results unless search.UnaffectedCriteria is present
@@ -69,15 +74,19 @@ func (s *mockProvider) FindVulnerabilities(criteria ...vulnerability.Criteria) (
 					searchForUnaffected = true
 				}
 				matches, _, err := c.MatchesVulnerability(v)
-				if !matches || err != nil {
+				if err != nil {
 					return false, err
 				}
+				if !matches {
+					continue nextRow
+				}
 			}
 			if !searchForUnaffected && v.Unaffected {
-				return false, nil
+				continue
 			}
+			return true, nil
 		}
-		return true, nil
+		return len(criteria) == 0, nil
 	})
 }
 
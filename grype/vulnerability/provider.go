@@ -26,6 +26,9 @@ type MetadataProvider interface {
 }
 
 // Provider is the common interface for vulnerability sources to provide searching and metadata, such as a database
+//
+// Embedders may back matching with their own datastore by implementing Provider, or the simpler store.Store wrapped
+// with store.NewProvider; implementations are verified with the conformance suites in store/storetest.
 type Provider interface {
 	PackageSearchNames(grypePkg.Package) []string
 	// FindVulnerabilities returns vulnerabilities matching all the provided criteria
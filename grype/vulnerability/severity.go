@@ -34,6 +34,15 @@ type Severity int
 
 type Severities []Severity
 
+// SeverityRecord is a severity reported for a vulnerability by a single source.
+type SeverityRecord struct {
+	Source   string   // who reported the severity (e.g. "nvd@nist.gov")
+	Scheme   string   // how the value is expressed (e.g. "CVSS" or "CHMLN")
+	Value    string   // the value as reported (e.g. a CVSS vector or "high")
+	Severity Severity // the value interpreted as a severity
+	Rank     int      // the priority of the record over the others (lowest first)
+}
+
 func (f Severity) String() string {
 	if int(f) >= len(matcherTypeStr) || f < 0 {
 		return matcherTypeStr[0]
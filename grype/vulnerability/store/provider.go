@@ -0,0 +1,165 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anchore/grype/grype/db/v6/name"
+	"github.com/anchore/grype/grype/distro"
+	grypePkg "github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+var _ interface {
+	vulnerability.Provider
+	vulnerability.StoreMetadataProvider
+	vulnerability.EOLChecker
+} = (*provider)(nil)
+
+// NewProvider returns a vulnerability.Provider which searches the given store. The optional
+// vulnerability.StoreMetadataProvider and vulnerability.EOLChecker interfaces are delegated to the store when it
+// implements them.
+func NewProvider(s Store) vulnerability.Provider {
+	return &provider{store: s}
+}
+
+type provider struct {
+	store Store
+}
+
+func (p *provider) PackageSearchNames(pkg grypePkg.Package) []string {
+	if namer, ok := p.store.(PackageSearchNamer); ok {
+		return namer.PackageSearchNames(pkg)
+	}
+	return name.PackageNames(pkg)
+}
+
+// FindVulnerabilities returns the vulnerabilities meeting all the provided criteria. Each distinct set of criteria
+// (criteria combined with search.Or result in several sets) is searched separately, and the results are combined.
+func (p *provider) FindVulnerabilities(criteria ...vulnerability.Criteria) ([]vulnerability.Vulnerability, error) {
+	if err := search.ValidateCriteria(criteria); err != nil {
+		return nil, err
+	}
+
+	var out []vulnerability.Vulnerability
+	seen := map[string]struct{}{}
+	for _, row := range search.CriteriaIterator(criteria) {
+		q := QueryFor(row)
+
+		candidates, err := p.store.SearchVulnerabilities(q)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search vulnerability store: %w", err)
+		}
+
+	nextCandidate:
+		for _, v := range candidates {
+			// stores may return more than asked for, so the affected-ness is enforced here as well
+			if v.Unaffected != q.Unaffected {
+				continue
+			}
+			for _, c := range row {
+				matches, _, err := c.MatchesVulnerability(v)
+				if err != nil {
+					return nil, err
+				}
+				if !matches {
+					continue nextCandidate
+				}
+			}
+
+			key := identity(v)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// Deprecated: vulnerability.Vulnerability objects now have metadata included
+func (p *provider) VulnerabilityMetadata(ref vulnerability.Reference) (*vulnerability.Metadata, error) {
+	return p.store.VulnerabilityMetadata(ref)
+}
+
+func (p *provider) DataProvenance() (map[string]vulnerability.DataProvenance, error) {
+	if dp, ok := p.store.(vulnerability.StoreMetadataProvider); ok {
+		return dp.DataProvenance()
+	}
+	return nil, nil
+}
+
+func (p *provider) GetOperatingSystemEOL(d *distro.Distro) (eolDate, eoasDate *time.Time, err error) {
+	if checker, ok := p.store.(vulnerability.EOLChecker); ok {
+		return checker.GetOperatingSystemEOL(d)
+	}
+	return nil, nil, nil
+}
+
+func (p *provider) Close() error {
+	return p.store.Close()
+}
+
+// QueryFor collects the indexed attributes of a flattened set of criteria (see search.CriteriaIterator).
+func QueryFor(row []vulnerability.Criteria) Query {
+	var q Query
+	for _, c := range row {
+		switch c := c.(type) {
+		case *search.IDCriteria:
+			q.ID = c.ID
+		case *search.PackageNameCriteria:
+			q.PackageName = c.PackageName
+		case *search.EcosystemCriteria:
+			q.Language = c.Language
+			q.PackageType = c.PackageType
+		case *search.DistroCriteria:
+			q.Distros = c.Distros
+			q.ExactDistros = c.Exact
+		case *search.CPECriteria:
+			q.CPE = &c.CPE
+		case *search.UnaffectedCriteria:
+			q.Unaffected = true
+		}
+	}
+	return q
+}
+
+// Criteria returns the search criteria equivalent to the query, which is the reverse of QueryFor (without any of the
+// criteria that are not indexed, such as version constraints).
+func (q Query) Criteria() []vulnerability.Criteria {
+	var criteria []vulnerability.Criteria
+	if q.ID != "" {
+		criteria = append(criteria, search.ByID(q.ID))
+	}
+	if q.PackageName != "" {
+		criteria = append(criteria, search.ByPackageName(q.PackageName))
+	}
+	if q.Language != "" || q.PackageType != "" {
+		criteria = append(criteria, search.ByEcosystem(q.Language, q.PackageType))
+	}
+	if len(q.Distros) > 0 {
+		if q.ExactDistros {
+			criteria = append(criteria, search.ByExactDistro(q.Distros...))
+		} else {
+			criteria = append(criteria, search.ByDistro(q.Distros...))
+		}
+	}
+	if q.CPE != nil {
+		criteria = append(criteria, search.ByCPE(*q.CPE))
+	}
+	if q.Unaffected {
+		criteria = append(criteria, search.ForUnaffected())
+	}
+	return criteria
+}
+
+// identity distinguishes records returned by several searches, which should only be reported once.
+func identity(v vulnerability.Vulnerability) string {
+	constraint := ""
+	if v.Constraint != nil {
+		constraint = v.Constraint.String()
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%t", v.ID, v.Namespace, v.PackageName, constraint, v.Unaffected)
+}
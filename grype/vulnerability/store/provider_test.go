@@ -0,0 +1,48 @@
+package store_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/store"
+	"github.com/anchore/grype/grype/vulnerability/store/storetest"
+)
+
+// memoryStore narrows down candidates by ID and package name only, relying on grype for the remaining criteria.
+type memoryStore struct {
+	vulns []vulnerability.Vulnerability
+}
+
+func (s *memoryStore) SearchVulnerabilities(q store.Query) ([]vulnerability.Vulnerability, error) {
+	var out []vulnerability.Vulnerability
+	for _, v := range s.vulns {
+		if q.ID != "" && v.ID != q.ID {
+			continue
+		}
+		if q.PackageName != "" && !strings.EqualFold(v.PackageName, q.PackageName) {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (s *memoryStore) VulnerabilityMetadata(ref vulnerability.Reference) (*vulnerability.Metadata, error) {
+	for _, v := range s.vulns {
+		if v.ID == ref.ID && v.Namespace == ref.Namespace {
+			return v.Metadata, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+func TestNewProvider(t *testing.T) {
+	storetest.TestStore(t, func(_ *testing.T, vulns ...vulnerability.Vulnerability) store.Store {
+		return &memoryStore{vulns: vulns}
+	})
+}
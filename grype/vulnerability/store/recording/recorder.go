@@ -0,0 +1,214 @@
+package recording
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/anchore/grype/grype/distro"
+	grypePkg "github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/store"
+)
+
+var _ interface {
+	vulnerability.Provider
+	vulnerability.StoreMetadataProvider
+	vulnerability.EOLChecker
+} = (*Recorder)(nil)
+
+// Recorder is a vulnerability.Provider recording all interactions with the provider it wraps.
+type Recorder struct {
+	provider vulnerability.Provider
+	status   status
+
+	lock       sync.Mutex
+	queries    map[string]queryRecording
+	metadata   map[reference]*vulnerability.Metadata
+	eol        map[string]eolRecording
+	provenance map[string]vulnerability.DataProvenance
+}
+
+// NewRecorder returns a Recorder wrapping the given provider, where the status describes the vulnerability DB of
+// the provider (which is reported by the replaying provider).
+func NewRecorder(p vulnerability.Provider, s vulnerability.ProviderStatus) *Recorder {
+	return &Recorder{
+		provider: p,
+		status: status{
+			SchemaVersion: s.SchemaVersion,
+			Built:         s.Built.UTC(),
+			From:          s.From,
+		},
+		queries:  make(map[string]queryRecording),
+		metadata: make(map[reference]*vulnerability.Metadata),
+		eol:      make(map[string]eolRecording),
+	}
+}
+
+func (r *Recorder) PackageSearchNames(p grypePkg.Package) []string {
+	return r.provider.PackageSearchNames(p)
+}
+
+// FindVulnerabilities returns the results of the wrapped provider, recording all candidate records for the indexed
+// attributes of each distinct set of criteria (see store.QueryFor).
+func (r *Recorder) FindVulnerabilities(criteria ...vulnerability.Criteria) ([]vulnerability.Vulnerability, error) {
+	if err := search.ValidateCriteria(criteria); err != nil {
+		return nil, err
+	}
+
+	for _, row := range search.CriteriaIterator(criteria) {
+		if err := r.record(store.QueryFor(row)); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.provider.FindVulnerabilities(criteria...)
+}
+
+func (r *Recorder) record(q store.Query) error {
+	rq := newQuery(q)
+	key := rq.key()
+
+	r.lock.Lock()
+	_, recorded := r.queries[key]
+	r.lock.Unlock()
+	if recorded {
+		return nil
+	}
+
+	candidates, err := r.provider.FindVulnerabilities(q.Criteria()...)
+	if err != nil {
+		return err
+	}
+
+	results := make([]record, 0, len(candidates))
+	for _, v := range candidates {
+		rec, err := newRecord(v)
+		if err != nil {
+			return err
+		}
+		results = append(results, rec)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.queries[key] = queryRecording{Query: rq, Results: results}
+	return nil
+}
+
+// Deprecated: vulnerability.Vulnerability objects now have metadata included
+func (r *Recorder) VulnerabilityMetadata(ref vulnerability.Reference) (*vulnerability.Metadata, error) {
+	m, err := r.provider.VulnerabilityMetadata(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.metadata[reference{ID: ref.ID, Namespace: ref.Namespace}] = m
+	return m, nil
+}
+
+func (r *Recorder) DataProvenance() (map[string]vulnerability.DataProvenance, error) {
+	dp, ok := r.provider.(vulnerability.StoreMetadataProvider)
+	if !ok {
+		return nil, nil
+	}
+	provenance, err := dp.DataProvenance()
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.provenance = provenance
+	return provenance, nil
+}
+
+func (r *Recorder) GetOperatingSystemEOL(d *distro.Distro) (eolDate, eoasDate *time.Time, err error) {
+	checker, ok := r.provider.(vulnerability.EOLChecker)
+	if !ok || d == nil {
+		return nil, nil, nil
+	}
+	eolDate, eoasDate, err = checker.GetOperatingSystemEOL(d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rec := eolRecording{Distro: newDistroRecord(*d), EOL: eolDate, EOAS: eoasDate}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.eol[eolKey(rec.Distro)] = rec
+	return eolDate, eoasDate, nil
+}
+
+// Close closes the wrapped provider.
+func (r *Recorder) Close() error {
+	return r.provider.Close()
+}
+
+// Count returns the number of distinct queries recorded.
+func (r *Recorder) Count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.queries)
+}
+
+// Write writes the recording as JSON.
+func (r *Recorder) Write(w io.Writer) error {
+	r.lock.Lock()
+	doc := document{
+		Schema:     schemaVersion,
+		Status:     r.status,
+		Provenance: r.provenance,
+	}
+	for _, q := range r.queries {
+		doc.Queries = append(doc.Queries, q)
+	}
+	for ref, m := range r.metadata {
+		doc.Metadata = append(doc.Metadata, metadataRecording{ID: ref.ID, Namespace: ref.Namespace, Metadata: m})
+	}
+	for _, e := range r.eol {
+		doc.EOL = append(doc.EOL, e)
+	}
+	r.lock.Unlock()
+
+	slices.SortFunc(doc.Queries, func(a, b queryRecording) int {
+		return cmp.Compare(a.Query.key(), b.Query.key())
+	})
+	slices.SortFunc(doc.Metadata, func(a, b metadataRecording) int {
+		return cmp.Or(cmp.Compare(a.ID, b.ID), cmp.Compare(a.Namespace, b.Namespace))
+	})
+	slices.SortFunc(doc.EOL, func(a, b eolRecording) int {
+		return cmp.Compare(eolKey(a.Distro), eolKey(b.Distro))
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", " ")
+	return enc.Encode(doc)
+}
+
+// WriteFile writes the recording as JSON to the given path.
+func (r *Recorder) WriteFile(path string) error {
+	fh, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create DB recording: %w", err)
+	}
+	if err := r.Write(fh); err != nil {
+		_ = fh.Close()
+		return fmt.Errorf("unable to write DB recording: %w", err)
+	}
+	return fh.Close()
+}
+
+func eolKey(d distroRecord) string {
+	by, _ := json.Marshal(d)
+	return string(by)
+}
@@ -0,0 +1,236 @@
+/*
+Package recording captures the interactions of a scan with a vulnerability provider (the queries made and the records
+returned) and serves them back, so that downstream projects can build deterministic integration tests without shipping
+a full vulnerability database.
+
+Use NewRecorder to wrap the provider of a scan (e.g. the vulnerability DB) and Recorder.Write the recording once the
+scan completes; Read (or Open) a recording to get a replaying vulnerability.Provider. Queries are recorded by their
+indexed attributes (see store.Query) along with every candidate record for them, so all search criteria (including
+version constraints) are re-evaluated on replay: scanning a different version of a recorded package is matched
+correctly, while scanning a package that was not recorded results in an error.
+*/
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/pkg/qualifier"
+	"github.com/anchore/grype/grype/pkg/qualifier/architecture"
+	"github.com/anchore/grype/grype/pkg/qualifier/gosymbols"
+	"github.com/anchore/grype/grype/pkg/qualifier/platformcpe"
+	"github.com/anchore/grype/grype/pkg/qualifier/rootio"
+	"github.com/anchore/grype/grype/pkg/qualifier/rpmmodularity"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/store"
+	"github.com/anchore/syft/syft/cpe"
+)
+
+// schemaVersion is the version of the recording document, incremented on breaking changes.
+const schemaVersion = 1
+
+// qualifierDecoders restore the qualifiers of recorded records, by qualifier.Spec kind.
+var qualifierDecoders = map[string]func(qualifier.Spec) (qualifier.Qualifier, error){
+	architecture.Kind:           architecture.FromSpec,
+	architecture.UnaffectedKind: architecture.FromSpec,
+	gosymbols.Kind:              gosymbols.FromSpec,
+	platformcpe.Kind:            platformcpe.FromSpec,
+	rootio.Kind:                 rootio.FromSpec,
+	rpmmodularity.Kind:          rpmmodularity.FromSpec,
+}
+
+// document is the serialized form of a recording. All collections are sorted, so recordings of the same scan are
+// identical (and diff well when checked in as test fixtures).
+type document struct {
+	Schema     int                                     `json:"schema"`
+	Status     status                                  `json:"status"`
+	Queries    []queryRecording                        `json:"queries"`
+	Metadata   []metadataRecording                     `json:"metadata,omitempty"`
+	EOL        []eolRecording                          `json:"eol,omitempty"`
+	Provenance map[string]vulnerability.DataProvenance `json:"provenance,omitempty"`
+}
+
+// status describes the vulnerability DB the recording was made against.
+type status struct {
+	SchemaVersion string    `json:"schemaVersion,omitempty"`
+	Built         time.Time `json:"built,omitempty"`
+	From          string    `json:"from,omitempty"`
+}
+
+type queryRecording struct {
+	Query   query    `json:"query"`
+	Results []record `json:"results"`
+}
+
+type metadataRecording struct {
+	ID        string                  `json:"id"`
+	Namespace string                  `json:"namespace"`
+	Metadata  *vulnerability.Metadata `json:"metadata"`
+}
+
+type eolRecording struct {
+	Distro distroRecord `json:"distro"`
+	EOL    *time.Time   `json:"eol,omitempty"`
+	EOAS   *time.Time   `json:"eoas,omitempty"`
+}
+
+// query is the serialized form of a store.Query.
+type query struct {
+	ID           string         `json:"id,omitempty"`
+	PackageName  string         `json:"packageName,omitempty"`
+	Language     string         `json:"language,omitempty"`
+	PackageType  string         `json:"packageType,omitempty"`
+	Distros      []distroRecord `json:"distros,omitempty"`
+	ExactDistros bool           `json:"exactDistros,omitempty"`
+	CPE          string         `json:"cpe,omitempty"`
+	Unaffected   bool           `json:"unaffected,omitempty"`
+}
+
+type distroRecord struct {
+	Type     string   `json:"type"`
+	Version  string   `json:"version,omitempty"`
+	Codename string   `json:"codename,omitempty"`
+	Channels []string `json:"channels,omitempty"`
+	IDLike   []string `json:"idLike,omitempty"`
+}
+
+// record is the serialized form of a vulnerability.Vulnerability.
+type record struct {
+	ID                     string                   `json:"id"`
+	Namespace              string                   `json:"namespace"`
+	Status                 string                   `json:"status,omitempty"`
+	PackageName            string                   `json:"packageName,omitempty"`
+	Constraint             string                   `json:"constraint,omitempty"`
+	ConstraintFormat       string                   `json:"constraintFormat,omitempty"`
+	Qualifiers             []qualifier.Spec         `json:"qualifiers,omitempty"`
+	CPEs                   []string                 `json:"cpes,omitempty"`
+	Fix                    vulnerability.Fix        `json:"fix"`
+	Advisories             []vulnerability.Advisory `json:"advisories,omitempty"`
+	RelatedVulnerabilities []reference              `json:"relatedVulnerabilities,omitempty"`
+	Metadata               *vulnerability.Metadata  `json:"metadata,omitempty"`
+	Unaffected             bool                     `json:"unaffected,omitempty"`
+}
+
+type reference struct {
+	ID        string `json:"id"`
+	Namespace string `json:"namespace"`
+}
+
+func newQuery(q store.Query) query {
+	out := query{
+		ID:           q.ID,
+		PackageName:  q.PackageName,
+		Language:     string(q.Language),
+		PackageType:  string(q.PackageType),
+		ExactDistros: q.ExactDistros,
+		Unaffected:   q.Unaffected,
+	}
+	for _, d := range q.Distros {
+		out.Distros = append(out.Distros, newDistroRecord(d))
+	}
+	if q.CPE != nil {
+		out.CPE = q.CPE.Attributes.BindToFmtString()
+	}
+	return out
+}
+
+// key identifies the query within the recording.
+func (q query) key() string {
+	by, _ := json.Marshal(q)
+	return string(by)
+}
+
+func newDistroRecord(d distro.Distro) distroRecord {
+	return distroRecord{
+		Type:     string(d.Type),
+		Version:  d.Version,
+		Codename: d.Codename,
+		Channels: d.Channels,
+		IDLike:   d.IDLike,
+	}
+}
+
+func (d distroRecord) toDistro() *distro.Distro {
+	out := distro.New(distro.Type(d.Type), d.Version, d.Codename, d.IDLike...)
+	out.Channels = d.Channels
+	return out
+}
+
+func newRecord(v vulnerability.Vulnerability) (record, error) {
+	out := record{
+		ID:          v.ID,
+		Namespace:   v.Namespace,
+		Status:      v.Status,
+		PackageName: v.PackageName,
+		Fix:         v.Fix,
+		Advisories:  v.Advisories,
+		Metadata:    v.Metadata,
+		Unaffected:  v.Unaffected,
+	}
+	if v.Constraint != nil {
+		out.Constraint = v.Constraint.Value()
+		out.ConstraintFormat = v.Constraint.Format().String()
+	}
+	for _, q := range v.PackageQualifiers {
+		specifier, ok := q.(qualifier.Specifier)
+		if !ok {
+			return record{}, fmt.Errorf("unable to record qualifier %T of %s", q, v.ID)
+		}
+		spec, err := specifier.Spec()
+		if err != nil {
+			return record{}, fmt.Errorf("unable to record qualifier %T of %s: %w", q, v.ID, err)
+		}
+		out.Qualifiers = append(out.Qualifiers, spec)
+	}
+	for _, c := range v.CPEs {
+		out.CPEs = append(out.CPEs, c.Attributes.BindToFmtString())
+	}
+	for _, r := range v.RelatedVulnerabilities {
+		out.RelatedVulnerabilities = append(out.RelatedVulnerabilities, reference{ID: r.ID, Namespace: r.Namespace})
+	}
+	return out, nil
+}
+
+func (r record) toVulnerability() (vulnerability.Vulnerability, error) {
+	out := vulnerability.Vulnerability{
+		Reference:   vulnerability.Reference{ID: r.ID, Namespace: r.Namespace},
+		Status:      r.Status,
+		PackageName: r.PackageName,
+		Fix:         r.Fix,
+		Advisories:  r.Advisories,
+		Metadata:    r.Metadata,
+		Unaffected:  r.Unaffected,
+	}
+	if r.ConstraintFormat != "" {
+		c, err := version.GetConstraint(r.Constraint, version.ParseFormat(r.ConstraintFormat))
+		if err != nil {
+			return out, fmt.Errorf("invalid constraint of %s: %w", r.ID, err)
+		}
+		out.Constraint = c
+	}
+	for _, spec := range r.Qualifiers {
+		decode, ok := qualifierDecoders[spec.Kind]
+		if !ok {
+			return out, fmt.Errorf("unsupported qualifier of %s: %q", r.ID, spec.Kind)
+		}
+		q, err := decode(spec)
+		if err != nil {
+			return out, err
+		}
+		out.PackageQualifiers = append(out.PackageQualifiers, q)
+	}
+	for _, c := range r.CPEs {
+		parsed, err := cpe.New(c, "")
+		if err != nil {
+			return out, fmt.Errorf("invalid CPE of %s: %w", r.ID, err)
+		}
+		out.CPEs = append(out.CPEs, parsed)
+	}
+	for _, rel := range r.RelatedVulnerabilities {
+		out.RelatedVulnerabilities = append(out.RelatedVulnerabilities, vulnerability.Reference{ID: rel.ID, Namespace: rel.Namespace})
+	}
+	return out, nil
+}
@@ -0,0 +1,100 @@
+package recording
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/pkg/qualifier"
+	"github.com/anchore/grype/grype/pkg/qualifier/rpmmodularity"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+	"github.com/anchore/grype/grype/vulnerability/store/storetest"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	vulns := append(storetest.Vulnerabilities(), vulnerability.Vulnerability{
+		Reference:              vulnerability.Reference{ID: "CVE-2024-0005", Namespace: "redhat:distro:redhat:8"},
+		PackageName:            "nodejs",
+		Constraint:             version.MustGetConstraint("< 0:18.1-1.module+el8", version.RpmFormat),
+		PackageQualifiers:      []qualifier.Qualifier{rpmmodularity.New("nodejs:18")},
+		Fix:                    vulnerability.Fix{Versions: []string{"0:18.1-1.module+el8"}, State: vulnerability.FixStateFixed},
+		RelatedVulnerabilities: []vulnerability.Reference{{ID: "CVE-2024-0005", Namespace: "nvd:cpe"}},
+	})
+
+	debian := distro.New(distro.Debian, "12", "")
+	searches := [][]vulnerability.Criteria{
+		{search.ByPackageName("requests"), search.ByEcosystem(syftPkg.Python, syftPkg.PythonPkg)},
+		{search.ByPackageName("openssl"), search.ByDistro(*debian), search.ByVersion(*version.New("3.0.0", version.DebFormat))},
+		{search.ByPackageName("nodejs"), search.ByDistro(*distro.New(distro.RedHat, "8", ""))},
+		{search.ByCPE(cpe.Must("cpe:2.3:a:haxx:curl:7.0:*:*:*:*:*:*:*", ""))},
+		{search.ByID("GHSA-aaaa-bbbb-cccc")},
+	}
+
+	built := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	recorder := NewRecorder(mock.VulnerabilityProvider(vulns...), vulnerability.ProviderStatus{SchemaVersion: "v6.0.2", Built: built})
+
+	var expected [][]vulnerability.Vulnerability
+	for _, criteria := range searches {
+		results, err := recorder.FindVulnerabilities(criteria...)
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		expected = append(expected, results)
+	}
+	_, err := recorder.VulnerabilityMetadata(vulnerability.Reference{ID: "CVE-2024-0001", Namespace: "github:language:python"})
+	require.NoError(t, err)
+	assert.Equal(t, len(searches), recorder.Count())
+
+	var buf bytes.Buffer
+	require.NoError(t, recorder.Write(&buf))
+
+	replayed, status, err := Read(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, "v6.0.2", status.SchemaVersion)
+	assert.True(t, built.Equal(status.Built))
+
+	for i, criteria := range searches {
+		results, err := replayed.FindVulnerabilities(criteria...)
+		require.NoError(t, err)
+		assert.Equal(t, ids(expected[i]), ids(results))
+		for j := range results {
+			assert.Equal(t, expected[i][j].Constraint.String(), results[j].Constraint.String())
+			assert.Equal(t, expected[i][j].PackageQualifiers, results[j].PackageQualifiers)
+			assert.Equal(t, expected[i][j].Fix, results[j].Fix)
+			assert.Equal(t, expected[i][j].RelatedVulnerabilities, results[j].RelatedVulnerabilities)
+		}
+	}
+
+	m, err := replayed.VulnerabilityMetadata(vulnerability.Reference{ID: "CVE-2024-0001", Namespace: "github:language:python"})
+	require.NoError(t, err)
+	require.NotNil(t, m)
+	assert.Equal(t, "High", m.Severity)
+
+	_, err = replayed.FindVulnerabilities(search.ByPackageName("lodash"))
+	assert.ErrorContains(t, err, "search was not recorded")
+
+	var again bytes.Buffer
+	require.NoError(t, recorder.Write(&again))
+	assert.Equal(t, buf.String(), again.String(), "recordings should be deterministic")
+}
+
+func TestRead_unsupportedSchema(t *testing.T) {
+	_, _, err := Read(bytes.NewReader([]byte(`{"schema": 99}`)))
+	assert.ErrorContains(t, err, "unsupported DB recording schema")
+}
+
+func ids(vulns []vulnerability.Vulnerability) []string {
+	var out []string
+	for _, v := range vulns {
+		out = append(out, v.ID+"@"+v.Namespace)
+	}
+	return out
+}
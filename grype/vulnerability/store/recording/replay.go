@@ -0,0 +1,111 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/store"
+)
+
+var _ interface {
+	store.Store
+	vulnerability.StoreMetadataProvider
+	vulnerability.EOLChecker
+} = (*replay)(nil)
+
+// replay is a store.Store serving the records of a recording.
+type replay struct {
+	queries    map[string][]vulnerability.Vulnerability
+	metadata   map[reference]*vulnerability.Metadata
+	eol        map[string]eolRecording
+	provenance map[string]vulnerability.DataProvenance
+}
+
+// Open reads the recording at the given path (see Read).
+func Open(path string) (vulnerability.Provider, vulnerability.ProviderStatus, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, vulnerability.ProviderStatus{}, fmt.Errorf("unable to open DB recording: %w", err)
+	}
+	defer fh.Close()
+
+	p, s, err := Read(fh)
+	s.Path = path
+	return p, s, err
+}
+
+// Read returns a vulnerability.Provider replaying the given recording, along with the status of the vulnerability DB
+// the recording was made against. Searches that were not recorded fail with an error.
+func Read(r io.Reader) (vulnerability.Provider, vulnerability.ProviderStatus, error) {
+	var doc document
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, vulnerability.ProviderStatus{}, fmt.Errorf("unable to read DB recording: %w", err)
+	}
+	if doc.Schema != schemaVersion {
+		return nil, vulnerability.ProviderStatus{}, fmt.Errorf("unsupported DB recording schema %d (expected %d)", doc.Schema, schemaVersion)
+	}
+
+	s := &replay{
+		queries:    make(map[string][]vulnerability.Vulnerability, len(doc.Queries)),
+		metadata:   make(map[reference]*vulnerability.Metadata, len(doc.Metadata)),
+		eol:        make(map[string]eolRecording, len(doc.EOL)),
+		provenance: doc.Provenance,
+	}
+	for _, q := range doc.Queries {
+		results := make([]vulnerability.Vulnerability, 0, len(q.Results))
+		for _, rec := range q.Results {
+			v, err := rec.toVulnerability()
+			if err != nil {
+				return nil, vulnerability.ProviderStatus{}, fmt.Errorf("invalid DB recording: %w", err)
+			}
+			results = append(results, v)
+		}
+		s.queries[q.Query.key()] = results
+	}
+	for _, m := range doc.Metadata {
+		s.metadata[reference{ID: m.ID, Namespace: m.Namespace}] = m.Metadata
+	}
+	for _, e := range doc.EOL {
+		s.eol[eolKey(e.Distro)] = e
+	}
+
+	return store.NewProvider(s), vulnerability.ProviderStatus{
+		SchemaVersion: doc.Status.SchemaVersion,
+		Built:         doc.Status.Built,
+		From:          doc.Status.From,
+	}, nil
+}
+
+func (s *replay) SearchVulnerabilities(q store.Query) ([]vulnerability.Vulnerability, error) {
+	key := newQuery(q).key()
+	results, ok := s.queries[key]
+	if !ok {
+		return nil, fmt.Errorf("search was not recorded: %s", key)
+	}
+	return results, nil
+}
+
+func (s *replay) VulnerabilityMetadata(ref vulnerability.Reference) (*vulnerability.Metadata, error) {
+	return s.metadata[reference{ID: ref.ID, Namespace: ref.Namespace}], nil
+}
+
+func (s *replay) DataProvenance() (map[string]vulnerability.DataProvenance, error) {
+	return s.provenance, nil
+}
+
+func (s *replay) GetOperatingSystemEOL(d *distro.Distro) (eolDate, eoasDate *time.Time, err error) {
+	if d == nil {
+		return nil, nil, nil
+	}
+	e := s.eol[eolKey(newDistroRecord(*d))]
+	return e.EOL, e.EOAS, nil
+}
+
+func (s *replay) Close() error {
+	return nil
+}
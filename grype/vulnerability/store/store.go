@@ -0,0 +1,76 @@
+/*
+Package store allows grype matching to be backed by a custom vulnerability datastore (e.g. a shared database service)
+instead of the sqlite database distributed by grype.
+
+Embedders may implement vulnerability.Provider directly, which requires evaluating every search criteria, or implement
+the smaller Store interface and wrap it with NewProvider. A Store only narrows down candidates using the indexed
+attributes of a Query; all criteria (version constraints, distro aliasing, CPE attribute matching, ...) are then
+evaluated by grype, so a Store stays correct as matchers and criteria evolve.
+
+Either way, implementations should be verified with the conformance suites in the storetest package.
+*/
+package store
+
+import (
+	"io"
+
+	"github.com/anchore/grype/grype/distro"
+	grypePkg "github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// Store is a source of vulnerability records which grype filters for matching.
+type Store interface {
+	// SearchVulnerabilities returns the candidate vulnerabilities for the query. A Store may return any superset of the
+	// vulnerabilities which satisfy the query (up to all vulnerabilities it holds): grype discards records which do
+	// not meet the search criteria, so unset or unsupported query fields may simply be ignored. Returning fewer records
+	// than satisfy the query results in missed matches.
+	SearchVulnerabilities(q Query) ([]vulnerability.Vulnerability, error)
+
+	// VulnerabilityMetadata returns the metadata for the referenced vulnerability. Unknown references are not an error:
+	// nil metadata (or metadata with an unknown severity) must be returned instead.
+	VulnerabilityMetadata(ref vulnerability.Reference) (*vulnerability.Metadata, error)
+
+	io.Closer
+}
+
+// Query holds the indexed attributes of a single search. Unset fields do not constrain the search.
+type Query struct {
+	// ID is the vulnerability ID to search for (e.g. CVE-2024-9143 or GHSA-g2x7-ar59-85z5).
+	ID string
+
+	// PackageName is the name of the affected package, compared case-insensitively.
+	PackageName string
+
+	// Language is the ecosystem of the affected package, for searches against language namespaces.
+	Language syftPkg.Language
+
+	// PackageType is the type of the affected package, accompanying Language (some ecosystems are only distinguished by
+	// package type).
+	PackageType syftPkg.Type
+
+	// Distros are the operating systems to search for; records for any of them satisfy the query. Distro aliases
+	// (e.g. AlmaLinux records being published as RHEL records) are resolved by grype when filtering, so a Store
+	// should not narrow its candidates by distro version or flavor.
+	Distros []distro.Distro
+
+	// ExactDistros is set when distro aliases must not be resolved (e.g. when searching for AlmaLinux-specific records
+	// rather than the RHEL records AlmaLinux is normally matched against).
+	ExactDistros bool
+
+	// CPE is the CPE to search for, matched by grype against the CPEs of each record. A Store typically only narrows
+	// down candidates by the product attribute.
+	CPE *cpe.CPE
+
+	// Unaffected is set when searching for records which declare packages as not affected (vulnerability.Vulnerability
+	// Unaffected is true). Otherwise, only records of affected packages are of interest.
+	Unaffected bool
+}
+
+// PackageSearchNamer may optionally be implemented by a Store to customize the names packages are searched by (e.g.
+// when the records of the store are not normalized the same way grype normalizes package names).
+type PackageSearchNamer interface {
+	PackageSearchNames(p grypePkg.Package) []string
+}
@@ -0,0 +1,305 @@
+// Package storetest implements conformance suites for custom vulnerability stores and providers. Embedders backing
+// grype with their own datastore should run the suite matching what they implement from their own tests, loading the
+// given vulnerabilities into a fresh instance of their store:
+//
+//	func TestMyStore(t *testing.T) {
+//		storetest.TestStore(t, func(t *testing.T, vulns ...vulnerability.Vulnerability) store.Store {
+//			return newMyStore(t, vulns...)
+//		})
+//	}
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/version"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/store"
+	"github.com/anchore/syft/syft/cpe"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+// NewStoreFunc creates a store holding the given vulnerabilities (and their metadata, from Vulnerability.Metadata).
+type NewStoreFunc func(t *testing.T, vulns ...vulnerability.Vulnerability) store.Store
+
+// NewProviderFunc creates a provider holding the given vulnerabilities (and their metadata, from
+// Vulnerability.Metadata).
+type NewProviderFunc func(t *testing.T, vulns ...vulnerability.Vulnerability) vulnerability.Provider
+
+// Vulnerabilities returns the records the suites load into the implementation under test.
+func Vulnerabilities() []vulnerability.Vulnerability {
+	return []vulnerability.Vulnerability{
+		{
+			Reference:   vulnerability.Reference{ID: "CVE-2024-0001", Namespace: "github:language:python"},
+			PackageName: "requests",
+			Constraint:  version.MustGetConstraint("< 2.0", version.PythonFormat),
+			Metadata: &vulnerability.Metadata{
+				ID:        "CVE-2024-0001",
+				Namespace: "github:language:python",
+				Severity:  "High",
+			},
+		},
+		{
+			Reference:   vulnerability.Reference{ID: "GHSA-aaaa-bbbb-cccc", Namespace: "github:language:javascript"},
+			PackageName: "lodash",
+			Constraint:  version.MustGetConstraint("< 4.17.21", version.SemanticFormat),
+		},
+		{
+			Reference:   vulnerability.Reference{ID: "CVE-2024-0002", Namespace: "debian:distro:debian:12"},
+			PackageName: "openssl",
+			Constraint:  version.MustGetConstraint("< 3.0.1", version.DebFormat),
+		},
+		{
+			Reference:   vulnerability.Reference{ID: "CVE-2024-0003", Namespace: "debian:distro:debian:12"},
+			PackageName: "openssl",
+			Constraint:  version.MustGetConstraint(">= 3.0.1", version.DebFormat),
+			Unaffected:  true,
+		},
+		{
+			Reference:   vulnerability.Reference{ID: "CVE-2024-0004", Namespace: "nvd:cpe"},
+			PackageName: "curl",
+			Constraint:  version.MustGetConstraint("< 8.0", version.UnknownFormat),
+			CPEs:        []cpe.CPE{cpe.Must("cpe:2.3:a:haxx:curl:*:*:*:*:*:*:*:*", "")},
+		},
+	}
+}
+
+// TestStore verifies that a store.Store meets the contract grype relies on when wrapping it with store.NewProvider:
+// every record satisfying a query is returned (more may be), metadata is available for held records, and unknown
+// references are not an error. The provider contract (see TestProvider) is verified with the store wrapped as well.
+func TestStore(t *testing.T, newStore NewStoreFunc) {
+	t.Helper()
+
+	t.Run("search", func(t *testing.T) {
+		s := newStore(t, Vulnerabilities()...)
+		t.Cleanup(func() { _ = s.Close() })
+
+		debian := distro.New(distro.Debian, "12", "")
+		curl := cpe.Must("cpe:2.3:a:haxx:curl:7.0:*:*:*:*:*:*:*", "")
+
+		tests := []struct {
+			name  string
+			query store.Query
+			want  []string
+		}{
+			{
+				name: "everything",
+				want: []string{"CVE-2024-0001", "GHSA-aaaa-bbbb-cccc", "CVE-2024-0002", "CVE-2024-0004"},
+			},
+			{
+				name:  "by id",
+				query: store.Query{ID: "CVE-2024-0001"},
+				want:  []string{"CVE-2024-0001"},
+			},
+			{
+				name:  "by package name ignoring case",
+				query: store.Query{PackageName: "Requests"},
+				want:  []string{"CVE-2024-0001"},
+			},
+			{
+				name:  "by ecosystem",
+				query: store.Query{PackageName: "lodash", Language: syftPkg.JavaScript},
+				want:  []string{"GHSA-aaaa-bbbb-cccc"},
+			},
+			{
+				name:  "by distro",
+				query: store.Query{PackageName: "openssl", Distros: []distro.Distro{*debian}},
+				want:  []string{"CVE-2024-0002"},
+			},
+			{
+				name:  "unaffected by distro",
+				query: store.Query{PackageName: "openssl", Distros: []distro.Distro{*debian}, Unaffected: true},
+				want:  []string{"CVE-2024-0003"},
+			},
+			{
+				name:  "by cpe",
+				query: store.Query{CPE: &curl},
+				want:  []string{"CVE-2024-0004"},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := s.SearchVulnerabilities(tt.query)
+				require.NoError(t, err)
+				ids := map[string]bool{}
+				for _, v := range got {
+					ids[v.ID] = true
+				}
+				for _, id := range tt.want {
+					assert.True(t, ids[id], "expected %s in results for %+v", id, tt.query)
+				}
+			})
+		}
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		s := newStore(t, Vulnerabilities()...)
+		t.Cleanup(func() { _ = s.Close() })
+		testMetadata(t, s)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		s := newStore(t, Vulnerabilities()...)
+		require.NoError(t, s.Close())
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		TestProvider(t, func(t *testing.T, vulns ...vulnerability.Vulnerability) vulnerability.Provider {
+			return store.NewProvider(newStore(t, vulns...))
+		})
+	})
+}
+
+// TestProvider verifies that a vulnerability.Provider meets the contract grype matchers rely on: results meet all the
+// criteria, criteria combined with search.Or are searched independently, unaffected records are only returned when
+// searching with search.ForUnaffected, and conflicting criteria are rejected.
+func TestProvider(t *testing.T, newProvider NewProviderFunc) {
+	t.Helper()
+
+	t.Run("find", func(t *testing.T) {
+		p := newProvider(t, Vulnerabilities()...)
+		t.Cleanup(func() { _ = p.Close() })
+
+		debian := distro.New(distro.Debian, "12", "")
+		curl := cpe.Must("cpe:2.3:a:haxx:curl:7.0:*:*:*:*:*:*:*", "")
+
+		tests := []struct {
+			name     string
+			criteria []vulnerability.Criteria
+			want     []string
+			wantErr  require.ErrorAssertionFunc
+		}{
+			{
+				name:     "by id",
+				criteria: []vulnerability.Criteria{search.ByID("CVE-2024-0001")},
+				want:     []string{"CVE-2024-0001"},
+			},
+			{
+				name:     "by package name ignoring case",
+				criteria: []vulnerability.Criteria{search.ByPackageName("Requests")},
+				want:     []string{"CVE-2024-0001"},
+			},
+			{
+				name: "by ecosystem",
+				criteria: []vulnerability.Criteria{
+					search.ByEcosystem(syftPkg.Python, syftPkg.PythonPkg),
+					search.ByPackageName("requests"),
+				},
+				want: []string{"CVE-2024-0001"},
+			},
+			{
+				name: "ecosystem mismatch",
+				criteria: []vulnerability.Criteria{
+					search.ByEcosystem(syftPkg.JavaScript, syftPkg.NpmPkg),
+					search.ByPackageName("requests"),
+				},
+			},
+			{
+				name: "by vulnerable version",
+				criteria: []vulnerability.Criteria{
+					search.ByPackageName("requests"),
+					search.ByVersion(*version.New("1.5", version.PythonFormat)),
+				},
+				want: []string{"CVE-2024-0001"},
+			},
+			{
+				name: "by fixed version",
+				criteria: []vulnerability.Criteria{
+					search.ByPackageName("requests"),
+					search.ByVersion(*version.New("2.1", version.PythonFormat)),
+				},
+			},
+			{
+				name: "affected by distro",
+				criteria: []vulnerability.Criteria{
+					search.ByDistro(*debian),
+					search.ByPackageName("openssl"),
+				},
+				want: []string{"CVE-2024-0002"},
+			},
+			{
+				name: "unaffected by distro",
+				criteria: []vulnerability.Criteria{
+					search.ByDistro(*debian),
+					search.ByPackageName("openssl"),
+					search.ForUnaffected(),
+				},
+				want: []string{"CVE-2024-0003"},
+			},
+			{
+				name:     "by cpe",
+				criteria: []vulnerability.Criteria{search.ByCPE(curl)},
+				want:     []string{"CVE-2024-0004"},
+			},
+			{
+				name: "any of several package names",
+				criteria: []vulnerability.Criteria{
+					search.Or(search.ByPackageName("requests"), search.ByPackageName("lodash")),
+				},
+				want: []string{"CVE-2024-0001", "GHSA-aaaa-bbbb-cccc"},
+			},
+			{
+				name:     "unknown package",
+				criteria: []vulnerability.Criteria{search.ByPackageName("does-not-exist")},
+			},
+			{
+				name: "conflicting criteria",
+				criteria: []vulnerability.Criteria{
+					search.ByPackageName("requests"),
+					search.ByPackageName("lodash"),
+				},
+				wantErr: require.Error,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.wantErr == nil {
+					tt.wantErr = require.NoError
+				}
+				got, err := p.FindVulnerabilities(tt.criteria...)
+				tt.wantErr(t, err)
+				if err != nil {
+					return
+				}
+				var ids []string
+				for _, v := range got {
+					ids = append(ids, v.ID)
+				}
+				assert.ElementsMatch(t, tt.want, ids)
+			})
+		}
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		p := newProvider(t, Vulnerabilities()...)
+		t.Cleanup(func() { _ = p.Close() })
+		testMetadata(t, p)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		p := newProvider(t, Vulnerabilities()...)
+		require.NoError(t, p.Close())
+	})
+}
+
+//nolint:staticcheck // MetadataProvider is deprecated but still part of the contract
+func testMetadata(t *testing.T, p vulnerability.MetadataProvider) {
+	t.Helper()
+
+	m, err := p.VulnerabilityMetadata(vulnerability.Reference{ID: "CVE-2024-0001", Namespace: "github:language:python"}) //nolint:staticcheck // deprecated API still used internally
+	require.NoError(t, err)
+	require.NotNil(t, m)
+	assert.Equal(t, "CVE-2024-0001", m.ID)
+	assert.Equal(t, "High", m.Severity)
+
+	m, err = p.VulnerabilityMetadata(vulnerability.Reference{ID: "CVE-1999-0000", Namespace: "nvd:cpe"}) //nolint:staticcheck // deprecated API still used internally
+	require.NoError(t, err)
+	if m != nil {
+		assert.Equal(t, vulnerability.UnknownSeverity, vulnerability.ParseSeverity(m.Severity))
+	}
+}
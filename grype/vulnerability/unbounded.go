@@ -0,0 +1,19 @@
+package vulnerability
+
+import "github.com/anchore/grype/grype/version"
+
+// IsUnbounded indicates if the given vulnerability describes an "affected, fix unknown" advisory: the affected
+// range has no upper bound and no fix version is known. A vulnerability explicitly marked as won't-fix is not
+// considered unbounded since the vendor has made a statement about the future of the affected versions.
+func IsUnbounded(v Vulnerability) bool {
+	if len(v.Fix.Versions) > 0 {
+		return false
+	}
+
+	switch v.Fix.State {
+	case FixStateFixed, FixStateWontFix:
+		return false
+	}
+
+	return !version.HasUpperBound(v.Constraint)
+}
@@ -0,0 +1,14 @@
+package vulnerability
+
+import "strings"
+
+// IsWithdrawn indicates if the record of the given vulnerability was withdrawn or rejected by its provider, in which
+// case the record should not be acted upon.
+func IsWithdrawn(v Vulnerability) bool {
+	// we should be using enumerations from all supported schema versions, but constants should not be imported here
+	switch strings.ToLower(v.Status) {
+	case "withdrawn", "rejected":
+		return true
+	}
+	return false
+}
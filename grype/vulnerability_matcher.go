@@ -18,9 +18,11 @@ import (
 	"github.com/anchore/grype/grype/match"
 	"github.com/anchore/grype/grype/matcher/stock"
 	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/sla"
 	"github.com/anchore/grype/grype/vex"
 	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/cvss"
 	"github.com/anchore/grype/internal/log"
 	syftPkg "github.com/anchore/syft/syft/pkg"
 )
@@ -42,13 +44,77 @@ type VulnerabilityMatcher struct {
 	Matchers              []match.Matcher
 	IgnoreRules           []match.IgnoreRule
 	FailSeverity          *vulnerability.Severity
+	FailSLA               *sla.Policy
 	NormalizeByCVE        bool
 	VexProcessor          *vex.Processor
 	Alerts                AlertsConfig
+	// StreamMatches, when set, is called with the matches of each package as soon as they are final, allowing results
+	// to be shown before the entire matching phase completes. The matches are post-processed as the results of
+	// FindMatches are (ignore rules, VEX documents, vulnerabilities reported within the SBOM and the policies below);
+	// the matches of packages owned by other packages are held back until the owners have been matched
+	StreamMatches func(matches []match.Match)
+	// Unbounded controls how matches against "affected, fix unknown" advisories (no upper bound, no known fix) are
+	// handled; the zero value reports them as regular matches
+	Unbounded match.UnboundedPolicy
+	// UnknownVersions controls how packages with an unknown version are handled
+	UnknownVersions UnknownVersionConfig
+	// UpstreamMatching controls whether packages are matched via their source (upstream) packages per distro
+	UpstreamMatching UpstreamMatchingConfig
+	// Reconciliation controls how disagreements between a distro and NVD about the same vulnerability are resolved;
+	// the zero value prefers the distro
+	Reconciliation match.ReconciliationPolicy
+	// IncludeWithdrawn reports matches against withdrawn or rejected vulnerability records, which are otherwise moved to
+	// the ignored matches (or not searched for at all)
+	IncludeWithdrawn bool
+	// ProviderPriority moves matches of lower priority providers for a vulnerability and package already reported by a
+	// higher priority provider to the ignored matches (the zero value keeps all matches)
+	ProviderPriority match.ProviderPriority
+	// MinConfidence moves matches with a confidence below this ratio to the ignored matches (0 keeps all matches)
+	MinConfidence float64
+	// OnlyDirectDeps moves matches on transitive dependencies to the ignored matches
+	OnlyDirectDeps bool
+	// ExcludeDevDependencies moves matches on packages only needed for development or testing (see
+	// pkg.DevDependencyAnnotation) to the ignored matches
+	ExcludeDevDependencies bool
+	// SeparateIntermediateLayers moves matches on packages only present in intermediate image layers (see
+	// pkg.IntermediateLayerAnnotation) to the ignored matches
+	SeparateIntermediateLayers bool
+	// DetectMaliciousPackages additionally looks up the package URLs and artifact digests of the packages against the
+	// known-malicious package artifacts of the VulnerabilityProvider (see vulnerability.MaliciousArtifactProvider), and
+	// tracks the matches against known-malicious package records (see MaliciousMatches). Since the package itself should
+	// be removed, these matches meet FailSeverity regardless of the severity of the record
+	DetectMaliciousPackages bool
+	// TimeBudget bounds the time spent matching packages outside the priority ecosystems
+	TimeBudget TimeBudgetConfig
+	// CVSSModifiers are the CVSS temporal and environmental modifiers of the scanned asset: when set, the adjusted
+	// severity is used to evaluate FailSeverity
+	CVSSModifiers cvss.Modifiers
+	// SeverityPolicy raises or caps the severity of findings by ecosystem: the resulting severity is used to evaluate
+	// FailSeverity
+	SeverityPolicy match.SeverityPolicy
+	// Baseline holds the findings of a previous scan: matches in the baseline are still reported, but only new
+	// matches are evaluated against FailSeverity and FailSLA
+	Baseline *match.Baseline
 
 	// tracked packages with distro issues (populated during FindMatches)
 	eolDistroPackages     []pkg.Package
 	distroDetectionFailed bool
+
+	// matches that exceeded the FailSLA grace period (populated during FindMatches)
+	slaBreaches []sla.Breach
+
+	// matches against unbounded advisories reported as warnings (populated during FindMatches)
+	unboundedMatches []match.Match
+
+	// matches against known-malicious package records (populated during FindMatches)
+	maliciousMatches []match.Match
+
+	// matches found in the Baseline, which are not considered for failure conditions (populated during FindMatches)
+	baselineMatches []match.Match
+
+	// packages with an unknown version that could not be matched, or that were not matched within the time budget
+	// (populated during FindMatches)
+	skippedPackages []SkippedPackage
 }
 
 func (m *VulnerabilityMatcher) FailAtOrAboveSeverity(severity *vulnerability.Severity) *VulnerabilityMatcher {
@@ -72,6 +138,40 @@ func (m *VulnerabilityMatcher) DistroDetectionFailed() bool {
 	return m.distroDetectionFailed
 }
 
+// FailOnSLA sets the SLA policy that remaining matches are evaluated against, in place of the FailSeverity threshold.
+func (m *VulnerabilityMatcher) FailOnSLA(policy *sla.Policy) *VulnerabilityMatcher {
+	m.FailSLA = policy
+	return m
+}
+
+// SLABreaches returns the matches that exceeded the grace period of the FailSLA policy.
+func (m *VulnerabilityMatcher) SLABreaches() []sla.Breach {
+	return m.slaBreaches
+}
+
+// UnboundedMatches returns the matches against "affected, fix unknown" advisories that were reported as warnings
+// (only populated with the match.UnboundedAsWarning policy).
+func (m *VulnerabilityMatcher) UnboundedMatches() []match.Match {
+	return m.unboundedMatches
+}
+
+// MaliciousMatches returns the remaining matches against known-malicious package records (only populated when
+// DetectMaliciousPackages is set).
+func (m *VulnerabilityMatcher) MaliciousMatches() []match.Match {
+	return m.maliciousMatches
+}
+
+// BaselineMatches returns the matches that were found in the Baseline (only populated when a Baseline is set).
+func (m *VulnerabilityMatcher) BaselineMatches() []match.Match {
+	return m.baselineMatches
+}
+
+// SkippedPackages returns the packages with an unknown version that could not be matched (only populated when
+// UnknownVersions.ReportSkipped is set), and the packages that were not matched because the TimeBudget was exhausted.
+func (m *VulnerabilityMatcher) SkippedPackages() []SkippedPackage {
+	return m.skippedPackages
+}
+
 // EOLDistroPackages returns packages from distros that have reached end-of-life.
 func (m *VulnerabilityMatcher) EOLDistroPackages() []pkg.Package {
 	return m.eolDistroPackages
@@ -110,7 +210,7 @@ func (m *VulnerabilityMatcher) FindMatchesContext(
 		}
 	}()
 
-	remainingMatches, ignoredMatches, err = m.findDBMatches(ctx, pkgs, progressMonitor)
+	remainingMatches, ignoredMatches, err = m.findDBMatches(ctx, pkgs, pkgContext, newMatchStream(m, pkgs, pkgContext), progressMonitor)
 	if err != nil {
 		err = fmt.Errorf("unable to find matches against vulnerability database: %w", err)
 		return remainingMatches, ignoredMatches, err
@@ -122,11 +222,32 @@ func (m *VulnerabilityMatcher) FindMatchesContext(
 		return remainingMatches, ignoredMatches, err
 	}
 
-	if m.FailSeverity != nil && hasSeverityAtOrAbove(m.VulnerabilityProvider, *m.FailSeverity, *remainingMatches) {
+	remainingMatches, ignoredMatches = m.applyExternalVulnerabilities(pkgs, pkgContext, remainingMatches, ignoredMatches, progressMonitor)
+
+	remainingMatches, ignoredMatches = m.applyPolicies(remainingMatches, ignoredMatches)
+
+	m.trackMaliciousPackages(remainingMatches)
+
+	remainingMatches, ignoredMatches, gatedMatches := m.applyUnboundedPolicy(remainingMatches, ignoredMatches)
+
+	gatedMatches = m.applyBaseline(gatedMatches)
+
+	if m.FailSLA != nil {
+		m.slaBreaches = m.FailSLA.Evaluate(m.VulnerabilityProvider, gatedMatches.Sorted())
+	}
+
+	// an SLA policy replaces the severity threshold: findings only fail the scan once they exceed the grace period of
+	// their severity (malicious packages still fail the scan)
+	if m.FailSeverity != nil && (len(m.maliciousMatches) > 0 || (m.FailSLA == nil && hasSeverityAtOrAbove(m.VulnerabilityProvider, *m.FailSeverity, *gatedMatches, m.CVSSModifiers, m.SeverityPolicy))) {
 		err = grypeerr.ErrAboveSeverityThreshold
 		return remainingMatches, ignoredMatches, err
 	}
 
+	if len(m.slaBreaches) > 0 {
+		err = grypeerr.ErrSLAGracePeriodExceeded
+		return remainingMatches, ignoredMatches, err
+	}
+
 	logListSummary(progressMonitor)
 
 	logIgnoredMatches(ignoredMatches)
@@ -134,11 +255,179 @@ func (m *VulnerabilityMatcher) FindMatchesContext(
 	return remainingMatches, ignoredMatches, nil
 }
 
-func (m *VulnerabilityMatcher) findDBMatches(ctx context.Context, pkgs []pkg.Package, progressMonitor *monitorWriter) (*match.Matches, []match.IgnoredMatch, error) {
-	var ignoredMatches []match.IgnoredMatch
+// applyPolicies moves the matches excluded by the configured policies to the ignored matches.
+func (m *VulnerabilityMatcher) applyPolicies(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch) {
+	remainingMatches, ignoredMatches = m.applyWithdrawn(remainingMatches, ignoredMatches)
+
+	remainingMatches, ignoredMatches = m.applyProviderPriority(remainingMatches, ignoredMatches)
+
+	remainingMatches, ignoredMatches = m.applyMinConfidence(remainingMatches, ignoredMatches)
+
+	remainingMatches, ignoredMatches = m.applyOnlyDirectDeps(remainingMatches, ignoredMatches)
+
+	remainingMatches, ignoredMatches = m.applyExcludeDevDependencies(remainingMatches, ignoredMatches)
+
+	return m.applySeparateIntermediateLayers(remainingMatches, ignoredMatches)
+}
+
+// applyWithdrawn moves matches against withdrawn or rejected vulnerability records to the ignored matches, unless
+// IncludeWithdrawn is set.
+func (m *VulnerabilityMatcher) applyWithdrawn(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch) {
+	if m.IncludeWithdrawn {
+		return remainingMatches, ignoredMatches
+	}
+
+	active, withdrawn := match.SplitWithdrawn(*remainingMatches)
+	if len(withdrawn) > 0 {
+		log.WithFields("count", len(withdrawn)).Info("ignoring matches against withdrawn or rejected vulnerabilities")
+	}
+	for _, w := range withdrawn {
+		ignoredMatches = append(ignoredMatches, match.NewWithdrawnIgnoredMatch(w))
+	}
+	return &active, ignoredMatches
+}
+
+// applyProviderPriority moves matches of lower priority providers already reported by a higher priority provider to
+// the ignored matches.
+func (m *VulnerabilityMatcher) applyProviderPriority(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch) {
+	if !m.ProviderPriority.Enabled() {
+		return remainingMatches, ignoredMatches
+	}
+
+	kept, dropped := match.SplitByProviderPriority(*remainingMatches, m.ProviderPriority)
+	if len(dropped) > 0 {
+		log.WithFields("count", len(dropped), "providers", m.ProviderPriority.Providers).Info("ignoring matches already reported by a higher priority provider")
+	}
+	for _, d := range dropped {
+		ignoredMatches = append(ignoredMatches, match.NewProviderPriorityIgnoredMatch(d))
+	}
+	return &kept, ignoredMatches
+}
+
+// applyMinConfidence moves matches below the MinConfidence threshold to the ignored matches.
+func (m *VulnerabilityMatcher) applyMinConfidence(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch) {
+	if m.MinConfidence <= 0 {
+		return remainingMatches, ignoredMatches
+	}
+
+	kept, dropped := match.SplitByConfidence(*remainingMatches, m.MinConfidence)
+	if len(dropped) > 0 {
+		log.WithFields("count", len(dropped), "min-confidence", m.MinConfidence).Info("ignoring matches below the minimum confidence")
+	}
+	for _, d := range dropped {
+		ignoredMatches = append(ignoredMatches, match.NewLowConfidenceIgnoredMatch(d))
+	}
+	return &kept, ignoredMatches
+}
+
+// applyOnlyDirectDeps moves matches on transitive dependencies to the ignored matches.
+func (m *VulnerabilityMatcher) applyOnlyDirectDeps(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch) {
+	if !m.OnlyDirectDeps {
+		return remainingMatches, ignoredMatches
+	}
+
+	direct, transitive := match.SplitTransitive(*remainingMatches)
+	if len(transitive) > 0 {
+		log.WithFields("count", len(transitive)).Info("ignoring matches on transitive dependencies")
+	}
+	for _, t := range transitive {
+		ignoredMatches = append(ignoredMatches, match.NewTransitiveIgnoredMatch(t))
+	}
+	return &direct, ignoredMatches
+}
+
+// applyExcludeDevDependencies moves matches on development or test dependencies to the ignored matches.
+func (m *VulnerabilityMatcher) applyExcludeDevDependencies(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch) {
+	if !m.ExcludeDevDependencies {
+		return remainingMatches, ignoredMatches
+	}
+
+	kept, dev := match.SplitDevDependencies(*remainingMatches)
+	if len(dev) > 0 {
+		log.WithFields("count", len(dev)).Info("ignoring matches on development or test dependencies")
+	}
+	for _, d := range dev {
+		ignoredMatches = append(ignoredMatches, match.NewDevDependencyIgnoredMatch(d))
+	}
+	return &kept, ignoredMatches
+}
 
+// applySeparateIntermediateLayers moves matches on packages only present in intermediate image layers to the ignored
+// matches.
+func (m *VulnerabilityMatcher) applySeparateIntermediateLayers(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch) {
+	if !m.SeparateIntermediateLayers {
+		return remainingMatches, ignoredMatches
+	}
+
+	final, intermediate := match.SplitIntermediateLayer(*remainingMatches)
+	if len(intermediate) > 0 {
+		log.WithFields("count", len(intermediate)).Info("reporting matches on packages only present in intermediate layers separately")
+	}
+	for _, i := range intermediate {
+		ignoredMatches = append(ignoredMatches, match.NewIntermediateLayerIgnoredMatch(i))
+	}
+	return &final, ignoredMatches
+}
+
+// trackMaliciousPackages records the remaining matches against known-malicious package records, which are reported
+// along with the other matches (see match.IsMaliciousPackage).
+func (m *VulnerabilityMatcher) trackMaliciousPackages(remainingMatches *match.Matches) {
+	m.maliciousMatches = nil
+	if !m.DetectMaliciousPackages {
+		return
+	}
+
+	for _, mt := range remainingMatches.Sorted() {
+		if match.IsMaliciousPackage(mt) {
+			m.maliciousMatches = append(m.maliciousMatches, mt)
+		}
+	}
+	if len(m.maliciousMatches) > 0 {
+		log.WithFields("count", len(m.maliciousMatches)).Warn("matches against known-malicious packages found")
+	}
+}
+
+// applyUnboundedPolicy handles matches against "affected, fix unknown" advisories according to the Unbounded policy,
+// returning the remaining and ignored matches along with the matches that fail-on severity and SLA gates should be
+// evaluated against.
+func (m *VulnerabilityMatcher) applyUnboundedPolicy(remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch) (*match.Matches, []match.IgnoredMatch, *match.Matches) {
+	m.unboundedMatches = nil
+
+	switch m.Unbounded {
+	case match.UnboundedAsWarning:
+		bounded, unbounded := match.SplitUnbounded(*remainingMatches)
+		if len(unbounded) > 0 {
+			log.WithFields("count", len(unbounded)).Warn("matches against advisories with no upper bound and no known fix are not considered for failure conditions")
+		}
+		m.unboundedMatches = unbounded
+		return remainingMatches, ignoredMatches, &bounded
+	case match.UnboundedSkip:
+		bounded, unbounded := match.SplitUnbounded(*remainingMatches)
+		for _, um := range unbounded {
+			ignoredMatches = append(ignoredMatches, match.NewUnboundedIgnoredMatch(um))
+		}
+		return &bounded, ignoredMatches, &bounded
+	}
+
+	return remainingMatches, ignoredMatches, remainingMatches
+}
+
+// applyBaseline returns the matches considered for failure conditions that are not in the Baseline.
+func (m *VulnerabilityMatcher) applyBaseline(gatedMatches *match.Matches) *match.Matches {
+	m.baselineMatches = nil
+	if m.Baseline == nil {
+		return gatedMatches
+	}
+
+	added, known := match.SplitBaseline(*gatedMatches, m.Baseline)
+	m.baselineMatches = known
+	log.WithFields("new", added.Count(), "baseline", len(known)).Debug("matches evaluated against failure conditions relative to the baseline")
+	return &added
+}
+
+func (m *VulnerabilityMatcher) findDBMatches(ctx context.Context, pkgs []pkg.Package, pkgContext pkg.Context, stream *matchStream, progressMonitor *monitorWriter) (*match.Matches, []match.IgnoredMatch, error) {
 	log.Trace("finding matches against DB")
-	matches, err := m.searchDBForMatches(ctx, pkgs, progressMonitor)
+	matches, reconciledMatches, err := m.searchDBForMatches(ctx, pkgs, pkgContext, stream, progressMonitor)
 	if err != nil {
 		if match.IsFatalError(err) {
 			return nil, nil, err
@@ -149,7 +438,15 @@ func (m *VulnerabilityMatcher) findDBMatches(ctx context.Context, pkgs []pkg.Pac
 		log.WithFields("error", err).Debug("error(s) returned from searchDBForMatches")
 	}
 
-	matches, ignoredMatches = m.applyIgnoreRules(matches)
+	matches, ignoredMatches := m.applyIgnoreRulesByCVE(matches, reconciledMatches)
+	return &matches, ignoredMatches, nil
+}
+
+// applyIgnoreRulesByCVE applies the user-provided ignore rules, normalizing the remaining matches to CVEs when
+// NormalizeByCVE is set.
+func (m *VulnerabilityMatcher) applyIgnoreRulesByCVE(matches match.Matches, reconciledMatches []match.IgnoredMatch) (match.Matches, []match.IgnoredMatch) {
+	matches, ignoredMatches := m.applyIgnoreRules(matches)
+	ignoredMatches = append(reconciledMatches, ignoredMatches...)
 
 	if m.NormalizeByCVE {
 		normalizedMatches := match.NewMatches()
@@ -166,7 +463,7 @@ func (m *VulnerabilityMatcher) findDBMatches(ctx context.Context, pkgs []pkg.Pac
 		ignoredMatches = m.mergeIgnoredMatches(originalIgnoredMatches, ignoredMatches)
 	}
 
-	return &matches, ignoredMatches, nil
+	return matches, ignoredMatches
 }
 
 func (m *VulnerabilityMatcher) mergeIgnoredMatches(allIgnoredMatches ...[]match.IgnoredMatch) []match.IgnoredMatch {
@@ -186,8 +483,10 @@ func (m *VulnerabilityMatcher) mergeIgnoredMatches(allIgnoredMatches ...[]match.
 func (m *VulnerabilityMatcher) searchDBForMatches(
 	ctx context.Context,
 	packages []pkg.Package,
+	pkgContext pkg.Context,
+	stream *matchStream,
 	progressMonitor *monitorWriter,
-) (match.Matches, error) {
+) (match.Matches, []match.IgnoredMatch, error) {
 	var allMatches []match.Match
 	var allIgnorers []match.IgnoreFilter
 	matcherIndex, defaultMatcher := newMatcherIndex(m.Matchers)
@@ -196,14 +495,31 @@ func (m *VulnerabilityMatcher) searchDBForMatches(
 		defaultMatcher = stock.NewStockMatcher(stock.MatcherConfig{UseCPEs: true})
 	}
 
-	// reset tracked distro packages
+	// reset tracked distro and skipped packages
 	m.eolDistroPackages = nil
+	m.skippedPackages = nil
 
 	// setup EOL tracking if enabled
 	eolTracker := newEOLTracker(m.Alerts.EnableEOLDistroWarnings, m.VulnerabilityProvider)
 
+	// matchers exclude withdrawn and rejected vulnerability records when searching, unless asked to include them
+	var vp vulnerability.Provider = m.VulnerabilityProvider
+	if m.IncludeWithdrawn {
+		vp = includeWithdrawnProvider{Provider: vp}
+	}
+
+	maliciousArtifactMatches := m.findMaliciousArtifactMatches(packages, pkgContext)
+
+	budgetStart := time.Now()
+	packages, prioritized := m.TimeBudget.order(packages)
+
 	var matcherErrs []error
-	for _, p := range packages {
+	for i, p := range packages {
+		if i >= prioritized && m.TimeBudget.exhausted(budgetStart) {
+			m.skipOverBudget(packages[i:], progressMonitor)
+			break
+		}
+
 		progressMonitor.PackagesProcessed.Increment()
 		log.WithFields("package", displayPackage(p)).Trace("searching for vulnerability matches")
 
@@ -212,19 +528,31 @@ func (m *VulnerabilityMatcher) searchDBForMatches(
 			m.eolDistroPackages = append(m.eolDistroPackages, p)
 		}
 
+		searchPkg, versionUnknown := m.UnknownVersions.searchPackage(p)
+		if len(searchPkg.Upstreams) > 0 && !m.UpstreamMatching.enabled(searchPkg) {
+			log.WithFields("package", displayPackage(p)).Trace("upstream matching is disabled for the package distro")
+			searchPkg.Upstreams = nil
+		}
+		restore := searchPkg.Version != p.Version || len(searchPkg.Upstreams) != len(p.Upstreams)
+
 		matchAgainst, ok := matcherIndex[p.Type]
 		if !ok {
 			matchAgainst = []match.Matcher{defaultMatcher}
 		}
+		var packageMatches []match.Match
+		var packageIgnorers []match.IgnoreFilter
 		for _, theMatcher := range matchAgainst {
 			if err := ctx.Err(); err != nil {
-				return match.Matches{}, err
+				return match.Matches{}, nil, err
 			}
 
-			matches, ignorers, err := callMatcherSafely(theMatcher, m.VulnerabilityProvider, p)
+			matches, ignorers, err := callMatcherSafely(theMatcher, vp, searchPkg)
+			if restore {
+				matches = restorePackage(matches, p)
+			}
 			if err != nil {
 				if match.IsFatalError(err) {
-					return match.Matches{}, err
+					return match.Matches{}, nil, err
 				}
 
 				log.WithFields("error", err, "package", displayPackage(p)).Warn("matcher returned error")
@@ -232,6 +560,7 @@ func (m *VulnerabilityMatcher) searchDBForMatches(
 			}
 
 			allIgnorers = append(allIgnorers, ignorers...)
+			packageIgnorers = append(packageIgnorers, ignorers...)
 
 			// Filter out matches based on records in the database exclusion table and hard-coded rules
 			filtered, dropped := match.ApplyExplicitIgnoreRules(m.ExclusionProvider, match.NewMatches(matches...))
@@ -240,6 +569,7 @@ func (m *VulnerabilityMatcher) searchDBForMatches(
 			logPackageMatches(p, additionalMatches)
 			logExplicitDroppedPackageMatches(p, dropped)
 			allMatches = append(allMatches, additionalMatches...)
+			packageMatches = append(packageMatches, additionalMatches...)
 
 			progressMonitor.MatchesDiscovered.Add(int64(len(additionalMatches)))
 
@@ -248,21 +578,82 @@ func (m *VulnerabilityMatcher) searchDBForMatches(
 			// dropped: matches that are filtered out due to hard-coded rules
 			updateVulnerabilityList(progressMonitor, additionalMatches, nil, dropped, m.VulnerabilityProvider)
 		}
+
+		if versionUnknown && len(packageMatches) == 0 {
+			matches, skipped := m.matchUnknownVersion(p)
+			if skipped {
+				m.skippedPackages = append(m.skippedPackages, SkippedPackage{Package: p, Reason: skippedUnknownVersionReason})
+			}
+			if len(matches) > 0 {
+				logPackageMatches(p, matches)
+				allMatches = append(allMatches, matches...)
+				packageMatches = append(packageMatches, matches...)
+				progressMonitor.MatchesDiscovered.Add(int64(len(matches)))
+				updateVulnerabilityList(progressMonitor, matches, nil, nil, m.VulnerabilityProvider)
+			}
+		}
+
+		if matches := newMaliciousArtifactMatches(packageMatches, maliciousArtifactMatches[p.ID]); len(matches) > 0 {
+			logPackageMatches(p, matches)
+			allMatches = append(allMatches, matches...)
+			packageMatches = append(packageMatches, matches...)
+			progressMonitor.MatchesDiscovered.Add(int64(len(matches)))
+			updateVulnerabilityList(progressMonitor, matches, nil, nil, m.VulnerabilityProvider)
+		}
+
+		if stream != nil {
+			stream.add(p, packageMatches, packageIgnorers)
+		}
+	}
+
+	if stream != nil {
+		// packages owned by packages that were not matched (within the time budget) are no longer held back
+		stream.flush()
 	}
 
 	// apply ignores based on matchers returning ignore rules
 	startTime := time.Now()
-	filtered, dropped := match.ApplyIgnoreFilters(allMatches, ignoredMatchFilter(allIgnorers))
+	ignoreFilter := ignoredMatchFilter(allIgnorers)
+	var dropped, reconciled []match.IgnoredMatch
+	// get deduplicated set of matches
+	res := match.NewMatches()
+	for _, mt := range allMatches {
+		mt = m.Reconciliation.Reconcile(mt, ignoreFilter.vulnerabilityFilters(mt))
+		filtered, ignored := match.ApplyIgnoreFilters([]match.Match{mt}, ignoreFilter)
+		dropped = append(dropped, ignored...)
+		res.Add(filtered...)
+		// matches ignored in favor of another source are reported, recording the reconciliation decision
+		for _, im := range ignored {
+			if im.Reconciliation != nil {
+				reconciled = append(reconciled, im)
+			}
+		}
+	}
 	logIgnoredMatches(dropped)
 	log.Debugf("took %v to process %v vulns with %v ignores", time.Since(startTime), len(allMatches), len(allIgnorers))
 
-	// get deduplicated set of matches
-	res := match.NewMatches(filtered...)
-
 	// update the total discovered matches after removing all duplicates and ignores
 	progressMonitor.MatchesDiscovered.Set(int64(res.Count()))
 
-	return res, errors.Join(matcherErrs...)
+	return res, reconciled, errors.Join(matcherErrs...)
+}
+
+// skipOverBudget records the packages left unmatched once the time budget is exhausted as skipped.
+func (m *VulnerabilityMatcher) skipOverBudget(packages []pkg.Package, progressMonitor *monitorWriter) {
+	log.WithFields("limit", m.TimeBudget.Limit, "packages", len(packages)).Warn("time budget exceeded, skipping matching of the remaining packages")
+	for _, p := range packages {
+		m.skippedPackages = append(m.skippedPackages, SkippedPackage{Package: p, Reason: skippedTimeBudgetReason})
+	}
+	progressMonitor.PackagesProcessed.Add(int64(len(packages)))
+}
+
+// restorePackage replaces the package handed to matchers (e.g. with a normalized version) on the given matches with
+// the original package.
+func restorePackage(matches []match.Match, p pkg.Package) []match.Match {
+	for i := range matches {
+		matches[i].Package = p
+	}
+	return matches
 }
 
 func callMatcherSafely(m match.Matcher, vp vulnerability.Provider, p pkg.Package) (matches []match.Match, ignoredMatches []match.IgnoreFilter, err error) {
@@ -296,6 +687,27 @@ func (m *VulnerabilityMatcher) findVEXMatches(pkgContext pkg.Context, remainingM
 	return matchesAfterVex, ignoredMatchesAfterVex, nil
 }
 
+// applyExternalVulnerabilities merges the vulnerabilities (and analysis) reported within the input SBOM into the
+// results, suppressing matches the SBOM producer analyzed as resolved, false positive, or not affected.
+func (m *VulnerabilityMatcher) applyExternalVulnerabilities(pkgs []pkg.Package, pkgContext pkg.Context, remainingMatches *match.Matches, ignoredMatches []match.IgnoredMatch, progressMonitor *monitorWriter) (*match.Matches, []match.IgnoredMatch) {
+	if len(pkgContext.ExternalVulnerabilities) == 0 {
+		return remainingMatches, ignoredMatches
+	}
+
+	log.WithFields("count", len(pkgContext.ExternalVulnerabilities)).Debug("applying vulnerabilities reported within the SBOM")
+	matchesAfter, ignoredMatchesAfter := match.ApplyExternalVulnerabilities(pkgContext.ExternalVulnerabilities, pkgs, *remainingMatches, ignoredMatches)
+
+	diffMatches := matchesAfter.Diff(*remainingMatches)
+	diffIgnoredMatches := ignoredMatchesDiff(ignoredMatchesAfter, ignoredMatches)
+	if count := len(diffIgnoredMatches); count > 0 {
+		log.Infof("ignoring %d matches due to the analysis within the SBOM", count)
+	}
+
+	updateVulnerabilityList(progressMonitor, diffMatches.Sorted(), diffIgnoredMatches, nil, m.VulnerabilityProvider)
+
+	return &matchesAfter, ignoredMatchesAfter
+}
+
 // applyIgnoreRules applies the user-provided ignore rules, splitting ignored matches into a separate set
 func (m *VulnerabilityMatcher) applyIgnoreRules(matches match.Matches) (match.Matches, []match.IgnoredMatch) {
 	var ignoredMatches []match.IgnoredMatch
@@ -395,6 +807,15 @@ func (i ignoreRulesByIndex) IgnoreMatch(m match.Match) []match.IgnoreRule {
 	return matchIndexed(m, i.remainingFilters)
 }
 
+// vulnerabilityFilters returns the filters indexed by the vulnerability ID (or related vulnerability IDs) of the match.
+func (i ignoreRulesByIndex) vulnerabilityFilters(m match.Match) []match.IgnoreFilter {
+	filters := i.vulnIDFilters[m.Vulnerability.ID]
+	for _, related := range m.Vulnerability.RelatedVulnerabilities {
+		filters = append(slices.Clip(filters), i.vulnIDFilters[related.ID]...)
+	}
+	return filters
+}
+
 func matchIndexed[E match.IgnoreFilter, F ~[]E](m match.Match, vulnFilters F) []match.IgnoreRule {
 	for _, rule := range vulnFilters {
 		if matched := rule.IgnoreMatch(m); matched != nil {
@@ -406,7 +827,7 @@ func matchIndexed[E match.IgnoreFilter, F ~[]E](m match.Match, vulnFilters F) []
 
 // ignoredMatchFilter creates an ignore filter based on location-based IgnoredMatches to filter out "the same"
 // vulnerabilities reported by other matchers based on overlapping file locations
-func ignoredMatchFilter(ignores []match.IgnoreFilter) match.IgnoreFilter {
+func ignoredMatchFilter(ignores []match.IgnoreFilter) ignoreRulesByIndex {
 	out := ignoreRulesByIndex{
 		locationIgnoreRules:    map[string][]match.IgnoreRule{},
 		packageNameIgnoreRules: map[string][]match.IgnoreRule{},
@@ -499,7 +920,7 @@ func isCVE(id string) bool {
 }
 
 //nolint:staticcheck // MetadataProvider is deprecated but still used internally
-func hasSeverityAtOrAbove(store vulnerability.MetadataProvider, severity vulnerability.Severity, matches match.Matches) bool {
+func hasSeverityAtOrAbove(store vulnerability.MetadataProvider, severity vulnerability.Severity, matches match.Matches, modifiers cvss.Modifiers, policy match.SeverityPolicy) bool {
 	if severity == vulnerability.UnknownSeverity {
 		return false
 	}
@@ -509,7 +930,12 @@ func hasSeverityAtOrAbove(store vulnerability.MetadataProvider, severity vulnera
 			continue
 		}
 
-		if vulnerability.ParseSeverity(metadata.Severity) >= severity {
+		matchSeverity := vulnerability.ParseSeverity(metadata.Severity)
+		if adjusted := modifiers.AdjustScores(metadata.Cvss); adjusted != nil {
+			matchSeverity = adjusted.Severity
+		}
+		matchSeverity, _ = policy.Apply(m.Package, matchSeverity)
+		if matchSeverity >= severity {
 			return true
 		}
 	}
@@ -2,6 +2,7 @@ package grype
 
 import (
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -22,11 +23,13 @@ import (
 	"github.com/anchore/grype/grype/matcher/ruby"
 	"github.com/anchore/grype/grype/pkg"
 	"github.com/anchore/grype/grype/pkg/qualifier"
+	"github.com/anchore/grype/grype/sla"
 	"github.com/anchore/grype/grype/version"
 	"github.com/anchore/grype/grype/vex"
 	"github.com/anchore/grype/grype/vulnerability"
 	"github.com/anchore/grype/grype/vulnerability/mock"
 	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/cvss"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/cpe"
 	"github.com/anchore/syft/syft/file"
@@ -191,7 +194,7 @@ func Test_HasSeverityAtOrAbove(t *testing.T) {
 				failOnSeverity = sev
 			}
 
-			actual := hasSeverityAtOrAbove(metadataProvider, failOnSeverity, test.matches)
+			actual := hasSeverityAtOrAbove(metadataProvider, failOnSeverity, test.matches, cvss.Modifiers{}, match.SeverityPolicy{})
 
 			if test.expectedResult != actual {
 				t.Errorf("expected: %v got : %v", test.expectedResult, actual)
@@ -250,6 +253,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 		FailSeverity   *vulnerability.Severity
 		NormalizeByCVE bool
 		VexProcessor   *vex.Processor
+		Baseline       *match.Baseline
 	}
 	type args struct {
 		pkgs    []pkg.Package
@@ -377,6 +381,61 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 			wantIgnoredMatches: nil,
 			wantErr:            grypeerr.ErrAboveSeverityThreshold,
 		},
+		{
+			name: "pass on severity threshold with baseline",
+			fields: fields{
+				Matchers: matcher.NewDefaultMatchers(matcher.Config{}),
+				FailSeverity: func() *vulnerability.Severity {
+					x := vulnerability.LowSeverity
+					return &x
+				}(),
+				Baseline: match.NewBaseline(match.BaselineFinding{
+					VulnerabilityIDs: []string{"CVE-2014-FAKE-1"},
+					PackageName:      "neutron",
+					PackageType:      neutron2013Pkg.Type,
+				}),
+			},
+			args: args{
+				pkgs: []pkg.Package{
+					neutron2013Pkg,
+				},
+				context: pkg.Context{},
+			},
+			wantMatches: match.NewMatches(
+				match.Match{
+					Vulnerability: vulnerability.Vulnerability{
+						PackageName: "neutron",
+						Constraint:  version.MustGetConstraint("< 2014.1.3-6", version.DebFormat),
+						Reference: vulnerability.Reference{
+							ID:        "CVE-2014-fake-1",
+							Namespace: "debian:distro:debian:8",
+						},
+						PackageQualifiers: []qualifier.Qualifier{},
+						CPEs:              []cpe.CPE{},
+						Advisories:        []vulnerability.Advisory{},
+					},
+					Package: neutron2013Pkg,
+					Details: match.Details{
+						{
+							Type: match.ExactDirectMatch,
+							SearchedBy: match.DistroParameters{
+								Distro:    match.DistroIdentification{Type: "debian", Version: "8"},
+								Namespace: "debian:distro:debian:8",
+								Package:   match.PackageParameter{Name: "neutron", Version: "2013.1.1-1"},
+							},
+							Found: match.DistroResult{
+								VulnerabilityID:   "CVE-2014-fake-1",
+								VersionConstraint: "< 2014.1.3-6 (deb)",
+							},
+							Matcher:    "dpkg-matcher",
+							Confidence: 1,
+						},
+					},
+				},
+			),
+			wantIgnoredMatches: nil,
+			wantErr:            nil,
+		},
 		{
 			name: "pass on severity threshold with VEX",
 			fields: fields{
@@ -498,7 +557,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 								},
 							},
 							Matcher:    "ruby-gem-matcher",
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 						},
 					},
 				},
@@ -617,7 +676,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 								},
 							},
 							Matcher:    "ruby-gem-matcher",
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 						},
 					},
 				},
@@ -685,7 +744,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 								},
 							},
 							Matcher:    "ruby-gem-matcher",
-							Confidence: 0.9,
+							Confidence: match.CPEConfidence,
 						},
 					},
 				},
@@ -800,7 +859,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 									},
 								},
 								Matcher:    "ruby-gem-matcher",
-								Confidence: 0.9,
+								Confidence: match.CPEConfidence,
 							},
 						},
 					},
@@ -956,7 +1015,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 									},
 								},
 								Matcher:    "ruby-gem-matcher",
-								Confidence: 0.9,
+								Confidence: match.CPEConfidence,
 							},
 						},
 					},
@@ -975,6 +1034,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 				FailSeverity:          tt.fields.FailSeverity,
 				NormalizeByCVE:        tt.fields.NormalizeByCVE,
 				VexProcessor:          tt.fields.VexProcessor,
+				Baseline:              tt.fields.Baseline,
 			}
 
 			listener := &busListener{}
@@ -997,6 +1057,7 @@ func TestVulnerabilityMatcher_FindMatches(t *testing.T) {
 				cmpopts.IgnoreFields(vulnerability.Reference{}, "Internal"),
 				cmpopts.IgnoreFields(pkg.Package{}, "Locations", "Distro"),
 				cmpopts.IgnoreUnexported(match.IgnoredMatch{}),
+				cmpopts.IgnoreFields(match.CPEResult{}, "Evidence"),
 			}
 
 			if d := cmp.Diff(tt.wantMatches.Sorted(), actualMatches.Sorted(), opts...); d != "" {
@@ -1203,6 +1264,103 @@ func TestVulnerabilityMatcher_APKNakByOwnership(t *testing.T) {
 	}
 }
 
+func TestVulnerabilityMatcher_Reconciliation(t *testing.T) {
+	// the apk openssl package owns the files of a binary openssl package: the distro reports openssl as fixed, while
+	// NVD reports the binary package as affected (by CPE)
+	apkPkg := pkg.Package{
+		ID:      "apk-openssl-pkg",
+		Name:    "openssl",
+		Version: "3.1.4-r5",
+		Type:    syftPkg.ApkPkg,
+		Distro:  distro.New(distro.Alpine, "3.18", ""),
+	}
+	binaryPkg := pkg.Package{
+		ID:      "binary-openssl-pkg",
+		Name:    "openssl",
+		Version: "3.1.4",
+		Type:    syftPkg.BinaryPkg,
+		RelatedPackages: map[artifact.RelationshipType][]*pkg.Package{
+			artifact.OwnershipByFileOverlapRelationship: {&apkPkg},
+		},
+	}
+	nvdVuln := vulnerability.Vulnerability{
+		Reference:   vulnerability.Reference{ID: "CVE-2024-0727", Namespace: "nvd:cpe"},
+		PackageName: "openssl",
+		Constraint:  version.MustGetConstraint("< 3.1.5", version.UnknownFormat),
+	}
+
+	apkMatcher := matcherMock.New(syftPkg.ApkPkg, func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		return nil, []match.IgnoreFilter{
+			match.IgnoreRelatedPackage{
+				Reason:           "DistroPackageFixed",
+				RelationshipType: artifact.OwnershipByFileOverlapRelationship,
+				VulnerabilityID:  nvdVuln.ID,
+				RelatedPackageID: p.ID,
+				Namespace:        "alpine:distro:alpine:3.18",
+			},
+		}, nil
+	})
+	binaryMatcher := matcherMock.New(syftPkg.BinaryPkg, func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		return []match.Match{
+			{
+				Vulnerability: nvdVuln,
+				Package:       p,
+				Details:       match.Details{{Type: match.CPEMatch, Matcher: match.StockMatcher}},
+			},
+		}, nil, nil
+	})
+
+	tests := []struct {
+		name        string
+		policy      match.ReconciliationPolicy
+		wantMatches int
+		wantIgnored int
+		wantResult  match.Reconciliation
+	}{
+		{
+			name:        "distro preferred by default",
+			wantIgnored: 1,
+			wantResult: match.Reconciliation{
+				Policy:     match.ReconcilePreferDistro,
+				DisputedBy: []string{"alpine:distro:alpine:3.18"},
+			},
+		},
+		{
+			name:        "NVD preferred",
+			policy:      match.ReconcilePreferNVD,
+			wantMatches: 1,
+			wantResult: match.Reconciliation{
+				Policy:     match.ReconcilePreferNVD,
+				Reported:   true,
+				DisputedBy: []string{"alpine:distro:alpine:3.18"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &VulnerabilityMatcher{
+				VulnerabilityProvider: mock.VulnerabilityProvider(nvdVuln),
+				Matchers:              []match.Matcher{apkMatcher, binaryMatcher},
+				Reconciliation:        tt.policy,
+			}
+
+			matches, ignored, err := m.FindMatches([]pkg.Package{apkPkg, binaryPkg}, pkg.Context{})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantMatches, matches.Count())
+			require.Len(t, ignored, tt.wantIgnored)
+
+			var got *match.Reconciliation
+			if len(ignored) > 0 {
+				got = ignored[0].Reconciliation
+			} else {
+				got = matches.Sorted()[0].Reconciliation
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantResult, *got)
+		})
+	}
+}
+
 func Test_fatalErrors(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1874,3 +2032,327 @@ func TestVulnerabilityMatcher_EOLDistroPackages(t *testing.T) {
 		})
 	}
 }
+
+func TestVulnerabilityMatcher_applyUnboundedPolicy(t *testing.T) {
+	p := pkg.Package{
+		ID:      pkg.ID(uuid.NewString()),
+		Name:    "neutron",
+		Version: "2014.1.3-6",
+		Type:    syftPkg.DebPkg,
+	}
+
+	bounded := match.Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference:  vulnerability.Reference{ID: "CVE-2014-fake-1", Namespace: "debian:distro:debian:8"},
+			Constraint: version.MustGetConstraint("< 2014.1.5-6", version.DebFormat),
+			Fix:        vulnerability.Fix{Versions: []string{"2014.1.5-6"}, State: vulnerability.FixStateFixed},
+		},
+		Package: p,
+	}
+	unbounded := match.Match{
+		Vulnerability: vulnerability.Vulnerability{
+			Reference:  vulnerability.Reference{ID: "CVE-2014-fake-2", Namespace: "debian:distro:debian:8"},
+			Constraint: version.MustGetConstraint("", version.DebFormat),
+			Fix:        vulnerability.Fix{State: vulnerability.FixStateNotFixed},
+		},
+		Package: p,
+	}
+
+	tests := []struct {
+		name          string
+		policy        match.UnboundedPolicy
+		wantRemaining []match.Match
+		wantIgnored   []match.Match
+		wantGated     []match.Match
+		wantWarnings  []match.Match
+	}{
+		{
+			name:          "default policy reports unbounded advisories as matches",
+			wantRemaining: []match.Match{bounded, unbounded},
+			wantGated:     []match.Match{bounded, unbounded},
+		},
+		{
+			name:          "match",
+			policy:        match.UnboundedAsMatch,
+			wantRemaining: []match.Match{bounded, unbounded},
+			wantGated:     []match.Match{bounded, unbounded},
+		},
+		{
+			name:          "warn keeps unbounded advisories out of failure conditions",
+			policy:        match.UnboundedAsWarning,
+			wantRemaining: []match.Match{bounded, unbounded},
+			wantGated:     []match.Match{bounded},
+			wantWarnings:  []match.Match{unbounded},
+		},
+		{
+			name:          "skip ignores unbounded advisories",
+			policy:        match.UnboundedSkip,
+			wantRemaining: []match.Match{bounded},
+			wantIgnored:   []match.Match{unbounded},
+			wantGated:     []match.Match{bounded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &VulnerabilityMatcher{Unbounded: tt.policy}
+			matches := match.NewMatches(bounded, unbounded)
+
+			remaining, ignored, gated := m.applyUnboundedPolicy(&matches, nil)
+
+			assert.ElementsMatch(t, tt.wantRemaining, remaining.Sorted())
+			assert.ElementsMatch(t, tt.wantGated, gated.Sorted())
+			assert.ElementsMatch(t, tt.wantWarnings, m.UnboundedMatches())
+
+			var ignoredMatches []match.Match
+			for _, im := range ignored {
+				require.NotEmpty(t, im.AppliedIgnoreRules[0].Reason)
+				ignoredMatches = append(ignoredMatches, im.Match)
+			}
+			assert.ElementsMatch(t, tt.wantIgnored, ignoredMatches)
+		})
+	}
+}
+
+// maliciousArtifactProvider is a vulnerability provider with known-malicious package artifacts.
+type maliciousArtifactProvider struct {
+	vulnerability.Provider
+	artifacts []vulnerability.MaliciousArtifact
+}
+
+func (p maliciousArtifactProvider) MaliciousArtifacts(purls, digests []string) ([]vulnerability.MaliciousArtifact, error) {
+	var out []vulnerability.MaliciousArtifact
+	for _, a := range p.artifacts {
+		if (a.PURL != "" && slices.Contains(purls, a.PURL)) || (a.Digest != "" && slices.Contains(digests, a.Digest)) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func TestVulnerabilityMatcher_DetectMaliciousPackages(t *testing.T) {
+	malicious := vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{
+			ID:        "MAL-2014-fake-4",
+			Namespace: "github:language:ruby",
+			Internal: vulnerability.Metadata{
+				Severity: "low",
+			},
+		},
+		PackageName: "activerecord",
+		Constraint:  version.MustGetConstraint("< 3.7.6", version.UnknownFormat),
+	}
+	renamed := vulnerability.Vulnerability{
+		Reference: vulnerability.Reference{
+			ID:        "MAL-2014-fake-5",
+			Namespace: "github:language:ruby",
+		},
+		PackageName: "activerecord-evil",
+		Constraint:  version.MustGetConstraint("= 1.0.0", version.UnknownFormat),
+	}
+	vp := maliciousArtifactProvider{
+		Provider: mock.VulnerabilityProvider(append(testVulnerabilities(), malicious, renamed)...),
+		artifacts: []vulnerability.MaliciousArtifact{
+			// the same record as matched by name and version
+			{ID: "MAL-2014-fake-4", PURL: "pkg:gem/activerecord@3.7.5"},
+			// a record of another package name, found by the digest of the artifact
+			{ID: "MAL-2014-fake-5", Digest: "sha256:aaaa"},
+		},
+	}
+
+	p := pkg.Package{
+		ID:       pkg.ID(uuid.NewString()),
+		Name:     "activerecord",
+		Version:  "3.7.5",
+		Type:     syftPkg.GemPkg,
+		Language: syftPkg.Ruby,
+		PURL:     "pkg:gem/activerecord@3.7.5?platform=ruby",
+	}
+	pkgContext := pkg.Context{
+		ArtifactDigests: map[pkg.ID][]string{p.ID: {"sha256:aaaa"}},
+	}
+
+	tests := []struct {
+		name          string
+		detect        bool
+		wantRemaining []string
+		wantMalicious []string
+		wantErr       error
+	}{
+		{
+			name:          "malicious package records are only matched by name and version by default",
+			wantRemaining: []string{"GHSA-2014-fake-3", "MAL-2014-fake-4"},
+		},
+		{
+			name:          "malicious artifacts are looked up and fail the scan regardless of severity",
+			detect:        true,
+			wantRemaining: []string{"GHSA-2014-fake-3", "MAL-2014-fake-4", "MAL-2014-fake-5"},
+			wantMalicious: []string{"MAL-2014-fake-4", "MAL-2014-fake-5"},
+			wantErr:       grypeerr.ErrAboveSeverityThreshold,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failSeverity := vulnerability.HighSeverity
+			m := &VulnerabilityMatcher{
+				VulnerabilityProvider:   vp,
+				Matchers:                []match.Matcher{ruby.NewRubyMatcher(ruby.MatcherConfig{})},
+				FailSeverity:            &failSeverity,
+				DetectMaliciousPackages: tt.detect,
+			}
+
+			remaining, _, err := m.FindMatches([]pkg.Package{p}, pkgContext)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var remainingIDs, maliciousIDs []string
+			for _, mt := range remaining.Sorted() {
+				remainingIDs = append(remainingIDs, mt.Vulnerability.ID)
+			}
+			for _, mt := range m.MaliciousMatches() {
+				maliciousIDs = append(maliciousIDs, mt.Vulnerability.ID)
+			}
+			assert.ElementsMatch(t, tt.wantRemaining, remainingIDs)
+			assert.ElementsMatch(t, tt.wantMalicious, maliciousIDs)
+		})
+	}
+}
+
+func TestVulnerabilityMatcher_FailSLA(t *testing.T) {
+	current := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	vulnPublishedAt := func(published time.Time) vulnerability.Vulnerability {
+		return vulnerability.Vulnerability{
+			Reference: vulnerability.Reference{
+				ID:        "GHSA-2014-fake-sla",
+				Namespace: "github:language:ruby",
+				Internal: vulnerability.Metadata{
+					Severity:      "high",
+					PublishedDate: &published,
+				},
+			},
+			PackageName: "activerecord",
+			Constraint:  version.MustGetConstraint("< 3.7.6", version.UnknownFormat),
+		}
+	}
+
+	p := pkg.Package{
+		ID:       pkg.ID(uuid.NewString()),
+		Name:     "activerecord",
+		Version:  "3.7.5",
+		Type:     syftPkg.GemPkg,
+		Language: syftPkg.Ruby,
+	}
+
+	tests := []struct {
+		name      string
+		published time.Time
+		wantErr   error
+	}{
+		{
+			// the SLA policy replaces the severity threshold, which the finding is above
+			name:      "fresh finding within the grace period does not fail the scan",
+			published: current.AddDate(0, 0, -3),
+		},
+		{
+			name:      "finding beyond the grace period fails the scan",
+			published: current.AddDate(0, 0, -45),
+			wantErr:   grypeerr.ErrSLAGracePeriodExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failSeverity := vulnerability.LowSeverity
+			m := &VulnerabilityMatcher{
+				VulnerabilityProvider: mock.VulnerabilityProvider(vulnPublishedAt(tt.published)),
+				Matchers:              []match.Matcher{ruby.NewRubyMatcher(ruby.MatcherConfig{})},
+				FailSeverity:          &failSeverity,
+				FailSLA: &sla.Policy{
+					GracePeriods: map[vulnerability.Severity]time.Duration{vulnerability.HighSeverity: 30 * 24 * time.Hour},
+					Now:          func() time.Time { return current },
+				},
+			}
+
+			remaining, _, err := m.FindMatches([]pkg.Package{p}, pkg.Context{})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, 1, remaining.Count())
+		})
+	}
+}
+
+func TestVulnerabilityMatcher_StreamMatches(t *testing.T) {
+	apkPkg := pkg.Package{
+		ID:      pkg.ID("apk-httpie-pkg"),
+		Name:    "httpie",
+		Version: "1.0.2-r1",
+		Type:    syftPkg.ApkPkg,
+		Distro:  distro.New(distro.Wolfi, "", ""),
+	}
+	// the python package is owned by the APK package, but is matched first
+	pythonPkg := pkg.Package{
+		ID:       pkg.ID("python-httpie-pkg"),
+		Name:     "httpie",
+		Version:  "1.0.2",
+		Type:     syftPkg.PythonPkg,
+		Language: syftPkg.Python,
+		RelatedPackages: map[artifact.RelationshipType][]*pkg.Package{
+			artifact.OwnershipByFileOverlapRelationship: {
+				{ID: apkPkg.ID, Name: "httpie"},
+			},
+		},
+	}
+
+	pythonVuln := func(id string) match.Match {
+		return match.Match{
+			Vulnerability: vulnerability.Vulnerability{
+				Reference:   vulnerability.Reference{ID: id, Namespace: "github:language:python"},
+				PackageName: "httpie",
+			},
+			Package: pythonPkg,
+			Details: match.Details{
+				{Type: match.ExactDirectMatch, Confidence: 1.0, Matcher: "python-matcher"},
+			},
+		}
+	}
+
+	apkMatcher := matcherMock.New(syftPkg.ApkPkg, func(_ vulnerability.Provider, p pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		return nil, []match.IgnoreFilter{
+			match.IgnoreRelatedPackage{
+				Reason:           "Explicit APK NAK",
+				RelationshipType: artifact.OwnershipByFileOverlapRelationship,
+				VulnerabilityID:  "GHSA-nak-fake",
+				RelatedPackageID: p.ID,
+			},
+		}, nil
+	})
+	pythonMatcher := matcherMock.New(syftPkg.PythonPkg, func(_ vulnerability.Provider, _ pkg.Package) ([]match.Match, []match.IgnoreFilter, error) {
+		return []match.Match{pythonVuln("GHSA-nak-fake"), pythonVuln("GHSA-ignored-fake"), pythonVuln("GHSA-reported-fake")}, nil, nil
+	})
+
+	var streamed [][]string
+	m := &VulnerabilityMatcher{
+		VulnerabilityProvider: mock.VulnerabilityProvider(),
+		Matchers:              []match.Matcher{apkMatcher, pythonMatcher},
+		IgnoreRules:           []match.IgnoreRule{{Vulnerability: "GHSA-ignored-fake"}},
+		StreamMatches: func(matches []match.Match) {
+			var ids []string
+			for _, mt := range matches {
+				ids = append(ids, mt.Vulnerability.ID)
+			}
+			streamed = append(streamed, ids)
+		},
+	}
+
+	remaining, _, err := m.FindMatches([]pkg.Package{pythonPkg, apkPkg}, pkg.Context{})
+	require.NoError(t, err)
+
+	// the python matches are held back until the APK package is matched, so the NAK is honored
+	assert.Equal(t, [][]string{{"GHSA-reported-fake"}}, streamed)
+	require.Equal(t, 1, remaining.Count())
+	assert.Equal(t, "GHSA-reported-fake", remaining.Sorted()[0].Vulnerability.ID)
+}
@@ -0,0 +1,39 @@
+package grype
+
+import (
+	"time"
+
+	"github.com/anchore/grype/grype/distro"
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+var _ interface {
+	vulnerability.Provider
+	vulnerability.EOLChecker
+} = (*includeWithdrawnProvider)(nil)
+
+// includeWithdrawnProvider is a vulnerability.Provider which ignores the criteria excluding withdrawn and rejected
+// vulnerability records (see search.ByNonWithdrawn), such that matchers find matches against these records.
+type includeWithdrawnProvider struct {
+	vulnerability.Provider
+}
+
+func (p includeWithdrawnProvider) FindVulnerabilities(criteria ...vulnerability.Criteria) ([]vulnerability.Vulnerability, error) {
+	var kept []vulnerability.Criteria
+	for _, c := range criteria {
+		if _, ok := c.(*search.NonWithdrawnCriteria); ok {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return p.Provider.FindVulnerabilities(kept...)
+}
+
+func (p includeWithdrawnProvider) GetOperatingSystemEOL(d *distro.Distro) (eolDate, eoasDate *time.Time, err error) {
+	checker, ok := p.Provider.(vulnerability.EOLChecker)
+	if !ok {
+		return nil, nil, nil
+	}
+	return checker.GetOperatingSystemEOL(d)
+}
@@ -0,0 +1,32 @@
+package grype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/search"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/grype/vulnerability/mock"
+)
+
+func TestIncludeWithdrawnProvider(t *testing.T) {
+	vp := mock.VulnerabilityProvider(
+		vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2024-0001", Namespace: "nvd:cpe"}, PackageName: "libfoo"},
+		vulnerability.Vulnerability{Reference: vulnerability.Reference{ID: "CVE-2024-0002", Namespace: "nvd:cpe"}, PackageName: "libfoo", Status: "rejected"},
+	)
+
+	ids := func(p vulnerability.Provider) []string {
+		vulns, err := p.FindVulnerabilities(search.ByPackageName("libfoo"), search.ByNonWithdrawn())
+		require.NoError(t, err)
+		var out []string
+		for _, v := range vulns {
+			out = append(out, v.ID)
+		}
+		return out
+	}
+
+	assert.ElementsMatch(t, []string{"CVE-2024-0001"}, ids(vp))
+	assert.ElementsMatch(t, []string{"CVE-2024-0001", "CVE-2024-0002"}, ids(includeWithdrawnProvider{Provider: vp}))
+}
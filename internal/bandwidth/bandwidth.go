@@ -0,0 +1,134 @@
+// Package bandwidth limits the rate at which data is received from the network, so that downloads (e.g. of the
+// vulnerability database or of image layers) do not saturate constrained links.
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/time/rate"
+)
+
+// maxChunk bounds the data read from a connection at once, so that the limit is applied smoothly.
+const maxChunk = 32 * 1024
+
+// ParseLimit returns the number of bytes per second of the given limit (e.g. "10MB/s", "512KiB/s" or "1GB"), or zero
+// for an empty limit (unlimited).
+func ParseLimit(limit string) (int64, error) {
+	value := strings.TrimSpace(limit)
+	if value == "" {
+		return 0, nil
+	}
+	if strings.HasSuffix(strings.ToLower(value), "/s") {
+		value = value[:len(value)-len("/s")]
+	}
+	n, err := humanize.ParseBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth limit %q: %w", limit, err)
+	}
+	if n == 0 || n > 1<<62 {
+		return 0, fmt.Errorf("invalid bandwidth limit %q: must be a positive rate (e.g. 10MB/s)", limit)
+	}
+	return int64(n), nil
+}
+
+// Limiter limits the rate at which data is read from network connections. The limit is shared by all connections of
+// the transports the limiter is applied to. A nil limiter does not limit anything.
+type Limiter struct {
+	limiter *rate.Limiter
+	chunk   int
+	// applied are the transports limited in place, which are not limited twice
+	applied sync.Map
+}
+
+// NewLimiter returns a limiter of the given number of bytes per second (nil when not positive).
+func NewLimiter(bytesPerSecond int64) *Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	chunk := int(min(bytesPerSecond, maxChunk))
+	return &Limiter{
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), chunk),
+		chunk:   chunk,
+	}
+}
+
+// Transport returns a copy of the given transport whose connections are limited (or the given transport as-is when the
+// limiter is nil).
+func (l *Limiter) Transport(base *http.Transport) *http.Transport {
+	if l == nil {
+		return base
+	}
+	t := base.Clone()
+	l.Apply(t)
+	return t
+}
+
+// Apply limits the connections the given transport dials from now on (once, however many times it is applied).
+func (l *Limiter) Apply(t *http.Transport) {
+	if l == nil || t == nil {
+		return
+	}
+	if _, applied := l.applied.LoadOrStore(t, true); applied {
+		return
+	}
+	dial := t.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	t.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return l.ConnContext(ctx, conn), nil
+	}
+}
+
+// Conn returns the given connection with reads limited (or the connection as-is when the limiter is nil).
+func (l *Limiter) Conn(conn net.Conn) net.Conn {
+	return l.ConnContext(context.Background(), conn)
+}
+
+// ConnContext returns the given connection with reads limited (or the connection as-is when the limiter is nil), whose
+// reads stop waiting on the limiter when the given context is done or the connection is closed. The HTTP transport
+// closes the connection of a canceled request, so waits end with the request (the dial context given by the transport
+// does not carry the cancellation of the request).
+func (l *Limiter) ConnContext(ctx context.Context, conn net.Conn) net.Conn {
+	if l == nil {
+		return conn
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &limitedConn{Conn: conn, limiter: l, ctx: ctx, cancel: cancel}
+}
+
+type limitedConn struct {
+	net.Conn
+	limiter *Limiter
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func (c *limitedConn) Read(p []byte) (int, error) {
+	if len(p) > c.limiter.chunk {
+		p = p[:c.limiter.chunk]
+	}
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		// waiting after the read delays the next one, which in turn throttles the sender through TCP flow control
+		if waitErr := c.limiter.limiter.WaitN(c.ctx, n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+	return n, err
+}
+
+func (c *limitedConn) Close() error {
+	c.cancel()
+	return c.Conn.Close()
+}
@@ -0,0 +1,142 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		limit   string
+		want    int64
+		wantErr string
+	}{
+		{limit: "", want: 0},
+		{limit: "10MB/s", want: 10_000_000},
+		{limit: "10mb/S", want: 10_000_000},
+		{limit: "512KiB/s", want: 512 * 1024},
+		{limit: "1GB", want: 1_000_000_000},
+		{limit: " 2048 ", want: 2048},
+		{limit: "0", wantErr: "must be a positive rate"},
+		{limit: "fast", wantErr: `invalid bandwidth limit "fast"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.limit, func(t *testing.T) {
+			got, err := ParseLimit(tt.limit)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewLimiter_Unlimited(t *testing.T) {
+	l := NewLimiter(0)
+	assert.Nil(t, l)
+
+	base := &http.Transport{}
+	assert.Same(t, base, l.Transport(base))
+
+	client, server := net.Pipe()
+	defer server.Close()
+	assert.Same(t, client, l.Conn(client))
+}
+
+func TestLimiter_Conn(t *testing.T) {
+	// a burst of 1KiB, then 1KiB per 100ms
+	l := NewLimiter(10 * 1024)
+	l.chunk = 1024
+	l.limiter.SetBurst(1024)
+
+	client, server := net.Pipe()
+	go func() {
+		_, _ = server.Write(bytes.Repeat([]byte("x"), 3*1024))
+		_ = server.Close()
+	}()
+
+	start := time.Now()
+	data, err := io.ReadAll(l.Conn(client))
+	require.NoError(t, err)
+	assert.Len(t, data, 3*1024)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+}
+
+func TestLimiter_ConnContext(t *testing.T) {
+	// the first read takes the burst, the second waits for a second
+	l := NewLimiter(1024)
+
+	tests := []struct {
+		name string
+		stop func(cancel context.CancelFunc, conn net.Conn)
+	}{
+		{name: "context canceled", stop: func(cancel context.CancelFunc, _ net.Conn) { cancel() }},
+		{name: "connection closed", stop: func(_ context.CancelFunc, conn net.Conn) { _ = conn.Close() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l.limiter = rate.NewLimiter(rate.Limit(1024), 1024)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client, server := net.Pipe()
+			defer server.Close()
+			go func() {
+				_, _ = server.Write(bytes.Repeat([]byte("x"), 2*1024))
+			}()
+			conn := l.ConnContext(ctx, client)
+
+			buf := make([]byte, 1024)
+			_, err := io.ReadFull(conn, buf)
+			require.NoError(t, err)
+
+			time.AfterFunc(50*time.Millisecond, func() { tt.stop(cancel, conn) })
+			start := time.Now()
+			_, err = conn.Read(buf)
+			require.ErrorIs(t, err, context.Canceled)
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+		})
+	}
+}
+
+func TestLimiter_Transport(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 64*1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(payload)
+	}))
+	defer server.Close()
+
+	base := &http.Transport{}
+	l := NewLimiter(256 * 1024)
+	transport := l.Transport(base)
+	require.NotSame(t, base, transport)
+	assert.Nil(t, base.DialContext, "the given transport is not modified")
+
+	// applying the limiter again does not limit the connections twice
+	dial := reflect.ValueOf(transport.DialContext).Pointer()
+	l.Apply(transport)
+	assert.Equal(t, dial, reflect.ValueOf(transport.DialContext).Pointer())
+
+	start := time.Now()
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, payload, body)
+	// the first 32KiB are the burst, the rest take at least ~125ms
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
@@ -0,0 +1,270 @@
+// Package cfb reads the streams of Compound File Binary files (also known as OLE2 or structured storage files), the
+// container format of Windows installer (MSI) packages. See [MS-CFB] for the specification.
+//
+// [MS-CFB]: https://learn.microsoft.com/en-us/openspecs/windows_protocols/ms-cfb
+package cfb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+)
+
+const (
+	headerSize     = 512
+	dirEntrySize   = 128
+	headerDIFATLen = 109
+
+	endOfChain = 0xFFFFFFFE
+	maxSector  = 0xFFFFFFFA
+
+	typeStream = 2
+	typeRoot   = 5
+
+	// maxStreamSize bounds the size of the streams read, guarding against malformed files
+	maxStreamSize = 256 << 20
+)
+
+var signature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// ErrNotCompoundFile is returned when the content is not a compound file.
+var ErrNotCompoundFile = errors.New("not a compound file")
+
+// File is a compound file whose streams can be read.
+type File struct {
+	r              io.ReaderAt
+	sectors        int64
+	sectorSize     int64
+	miniSectorSize int64
+	miniCutoff     uint64
+	fat            []uint32
+	miniFAT        []uint32
+	miniStream     []byte
+	entries        []entry
+}
+
+type entry struct {
+	name        string
+	objectType  byte
+	startSector uint32
+	size        uint64
+}
+
+// IsCompoundFile indicates whether the given header bytes start a compound file.
+func IsCompoundFile(header []byte) bool {
+	return bytes.HasPrefix(header, signature)
+}
+
+// Open reads the structure of the compound file of the given content and size (in bytes).
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	header := make([]byte, headerSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("unable to read compound file header: %w", err)
+	}
+	if !IsCompoundFile(header) {
+		return nil, ErrNotCompoundFile
+	}
+
+	le := binary.LittleEndian
+	sectorShift := le.Uint16(header[0x1E:])
+	miniSectorShift := le.Uint16(header[0x20:])
+	if sectorShift != 9 && sectorShift != 12 || miniSectorShift != 6 {
+		return nil, fmt.Errorf("unsupported compound file sector sizes (shift %d, mini shift %d)", sectorShift, miniSectorShift)
+	}
+
+	f := &File{
+		r:              r,
+		sectorSize:     1 << sectorShift,
+		miniSectorSize: 1 << miniSectorShift,
+		miniCutoff:     uint64(le.Uint32(header[0x38:])),
+	}
+	// the header takes the place of the first sector, the last sector may be truncated
+	f.sectors = max(0, (size+f.sectorSize-1)/f.sectorSize-1)
+
+	if err := f.readFAT(header); err != nil {
+		return nil, err
+	}
+
+	dir, err := f.readChain(le.Uint32(header[0x30:]), f.fat, f.readSector, f.sectorSize, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read compound file directory: %w", err)
+	}
+	for off := 0; off+dirEntrySize <= len(dir); off += dirEntrySize {
+		f.entries = append(f.entries, parseEntry(dir[off:off+dirEntrySize]))
+	}
+	if len(f.entries) == 0 || f.entries[0].objectType != typeRoot {
+		return nil, errors.New("compound file has no root entry")
+	}
+
+	if miniFATStart := le.Uint32(header[0x3C:]); miniFATStart <= maxSector {
+		data, err := f.readChain(miniFATStart, f.fat, f.readSector, f.sectorSize, 0)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read compound file mini FAT: %w", err)
+		}
+		f.miniFAT = toUint32s(data)
+	}
+
+	root := f.entries[0]
+	if root.size > 0 {
+		f.miniStream, err = f.readChain(root.startSector, f.fat, f.readSector, f.sectorSize, root.size)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read compound file mini stream: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// Streams returns the names of the streams of the file (of all storages).
+func (f *File) Streams() []string {
+	var names []string
+	for _, e := range f.entries {
+		if e.objectType == typeStream {
+			names = append(names, e.name)
+		}
+	}
+	return names
+}
+
+// ReadStream returns the content of the first stream with the given name.
+func (f *File) ReadStream(name string) ([]byte, error) {
+	for _, e := range f.entries {
+		if e.objectType != typeStream || e.name != name {
+			continue
+		}
+		if e.size > maxStreamSize {
+			return nil, fmt.Errorf("stream %q is too large (%d bytes)", name, e.size)
+		}
+		if e.size < f.miniCutoff {
+			return f.readChain(e.startSector, f.miniFAT, f.readMiniSector, f.miniSectorSize, e.size)
+		}
+		return f.readChain(e.startSector, f.fat, f.readSector, f.sectorSize, e.size)
+	}
+	return nil, fmt.Errorf("stream %q not found", name)
+}
+
+// readFAT reads the sector allocation table from the sectors listed by the DIFAT (of the header and DIFAT sectors).
+// The counts of the header are not trusted: there cannot be more FAT and DIFAT sectors than sectors in the file.
+func (f *File) readFAT(header []byte) error {
+	le := binary.LittleEndian
+	var fatSectors []uint32
+	for i := 0; i < headerDIFATLen; i++ {
+		if s := le.Uint32(header[0x4C+4*i:]); s <= maxSector {
+			fatSectors = append(fatSectors, s)
+		}
+	}
+
+	perSector := int(f.sectorSize/4) - 1
+	next := le.Uint32(header[0x44:])
+	count := min(int64(le.Uint32(header[0x48:])), f.sectors)
+	visited := make(map[uint32]bool)
+	for ; count > 0 && next <= maxSector; count-- {
+		if visited[next] {
+			return fmt.Errorf("invalid compound file DIFAT chain at sector %d", next)
+		}
+		visited[next] = true
+		data, err := f.readSector(next)
+		if err != nil {
+			return fmt.Errorf("unable to read compound file DIFAT: %w", err)
+		}
+		values := toUint32s(data)
+		for _, s := range values[:perSector] {
+			if s <= maxSector {
+				fatSectors = append(fatSectors, s)
+			}
+		}
+		next = values[perSector]
+	}
+
+	if int64(len(fatSectors)) > f.sectors {
+		return fmt.Errorf("compound file lists more FAT sectors than it holds (%d > %d)", len(fatSectors), f.sectors)
+	}
+	for _, s := range fatSectors {
+		data, err := f.readSector(s)
+		if err != nil {
+			return fmt.Errorf("unable to read compound file FAT: %w", err)
+		}
+		f.fat = append(f.fat, toUint32s(data)...)
+	}
+	return nil
+}
+
+// readChain reads the sectors of the chain starting at the given sector, truncated to the given size (when not 0).
+func (f *File) readChain(start uint32, table []uint32, read func(uint32) ([]byte, error), sectorSize int64, size uint64) ([]byte, error) {
+	out := make([]byte, 0, min(size, maxStreamSize))
+	visited := make(map[uint32]bool)
+	for s := start; s != endOfChain; {
+		if s > maxSector || int(s) >= len(table) || visited[s] {
+			return nil, fmt.Errorf("invalid sector chain at sector %d", s)
+		}
+		visited[s] = true
+		data, err := read(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, data...)
+		if size > 0 && uint64(len(out)) >= size {
+			return out[:size], nil
+		}
+		if int64(len(out)) > maxStreamSize+sectorSize {
+			return nil, errors.New("sector chain is too long")
+		}
+		s = table[s]
+	}
+	if uint64(len(out)) < size {
+		return nil, fmt.Errorf("sector chain is shorter than the stream (%d < %d bytes)", len(out), size)
+	}
+	return out, nil
+}
+
+func (f *File) readSector(s uint32) ([]byte, error) {
+	if int64(s) >= f.sectors {
+		return nil, fmt.Errorf("sector %d is beyond the end of the file (%d sectors)", s, f.sectors)
+	}
+	data := make([]byte, f.sectorSize)
+	n, err := f.r.ReadAt(data, (int64(s)+1)*f.sectorSize)
+	if err != nil && (!errors.Is(err, io.EOF) || n == 0 || int64(s) != f.sectors-1) {
+		return nil, fmt.Errorf("unable to read sector %d: %w", s, err)
+	}
+	return data, nil
+}
+
+func (f *File) readMiniSector(s uint32) ([]byte, error) {
+	start := int64(s) * f.miniSectorSize
+	if start+f.miniSectorSize > int64(len(f.miniStream)) {
+		return nil, fmt.Errorf("mini sector %d is outside of the mini stream", s)
+	}
+	return f.miniStream[start : start+f.miniSectorSize], nil
+}
+
+func parseEntry(data []byte) entry {
+	le := binary.LittleEndian
+	nameLen := int(le.Uint16(data[0x40:]))
+	if nameLen > 64 {
+		nameLen = 64
+	}
+	var name []uint16
+	for i := 0; i+1 < nameLen; i += 2 {
+		c := le.Uint16(data[i:])
+		if c == 0 {
+			break
+		}
+		name = append(name, c)
+	}
+	return entry{
+		name:        string(utf16.Decode(name)),
+		objectType:  data[0x42],
+		startSector: le.Uint32(data[0x74:]),
+		size:        le.Uint64(data[0x78:]),
+	}
+}
+
+func toUint32s(data []byte) []uint32 {
+	out := make([]uint32, len(data)/4)
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint32(data[4*i:])
+	}
+	return out
+}
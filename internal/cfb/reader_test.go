@@ -0,0 +1,275 @@
+package cfb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	large := bytes.Repeat([]byte("0123456789abcdef"), 1000) // above the mini stream cutoff, spanning several sectors
+	streams := map[string][]byte{
+		"small":   []byte("hello"),
+		"smaller": []byte("a"),
+		"large":   large,
+		"empty":   {},
+	}
+
+	content := newCompoundFile(t, streams)
+	f, err := Open(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	names := f.Streams()
+	sort.Strings(names)
+	assert.Equal(t, []string{"empty", "large", "small", "smaller"}, names)
+
+	for name, want := range streams {
+		got, err := f.ReadStream(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err = f.ReadStream("missing")
+	assert.ErrorContains(t, err, `stream "missing" not found`)
+}
+
+func TestOpen_NotCompoundFile(t *testing.T) {
+	_, err := Open(bytes.NewReader(bytes.Repeat([]byte{'x'}, headerSize)), headerSize)
+	assert.ErrorIs(t, err, ErrNotCompoundFile)
+}
+
+func TestOpen_InvalidChain(t *testing.T) {
+	content := newCompoundFile(t, map[string][]byte{"stream": []byte("content")})
+	// point the directory to a sector beyond the FAT
+	binary.LittleEndian.PutUint32(content[0x30:], 1000)
+
+	_, err := Open(bytes.NewReader(content), int64(len(content)))
+	assert.ErrorContains(t, err, "invalid sector chain")
+}
+
+func TestOpen_Malformed(t *testing.T) {
+	le := binary.LittleEndian
+	tests := []struct {
+		name    string
+		modify  func(content []byte) []byte
+		wantErr string
+	}{
+		{
+			name: "DIFAT chain cycle",
+			modify: func(content []byte) []byte {
+				// a DIFAT sector pointing back to itself, with a count as large as the header allows
+				le.PutUint32(content[0x44:], 0)
+				le.PutUint32(content[0x48:], 0xFFFFFFFF)
+				le.PutUint32(content[headerSize+headerSize-4:], 0)
+				return content
+			},
+			wantErr: "invalid compound file DIFAT chain",
+		},
+		{
+			name: "DIFAT sector beyond the end of the file",
+			modify: func(content []byte) []byte {
+				le.PutUint32(content[0x44:], 1000)
+				le.PutUint32(content[0x48:], 0xFFFFFFFF)
+				return content
+			},
+			wantErr: "beyond the end of the file",
+		},
+		{
+			name: "more FAT sectors than sectors in the file",
+			modify: func(content []byte) []byte {
+				for i := 0; i < headerDIFATLen; i++ {
+					le.PutUint32(content[0x4C+4*i:], 0)
+				}
+				return content
+			},
+			wantErr: "more FAT sectors than it holds",
+		},
+		{
+			name: "truncated file",
+			modify: func(content []byte) []byte {
+				return content[:headerSize+10]
+			},
+			wantErr: "unable to read compound file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := tt.modify(newCompoundFile(t, map[string][]byte{"stream": []byte("content")}))
+
+			_, err := Open(bytes.NewReader(content), int64(len(content)))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIsCompoundFile(t *testing.T) {
+	assert.True(t, IsCompoundFile(append(append([]byte{}, signature...), 0, 0)))
+	assert.False(t, IsCompoundFile([]byte("MZ")))
+}
+
+// newCompoundFile returns a compound file (version 3, 512-byte sectors) holding the given streams in its root storage.
+func newCompoundFile(t *testing.T, streams map[string][]byte) []byte {
+	t.Helper()
+	const (
+		sectorSize     = 512
+		miniSectorSize = 64
+		miniCutoff     = 4096
+		noStream       = 0xFFFFFFFF
+		fatSector      = 0xFFFFFFFD
+	)
+
+	var names []string
+	for name := range streams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sectorsOf := func(size, per int) int { return (size + per - 1) / per }
+
+	// lay out the small streams in the mini stream
+	var miniStream []byte
+	var miniFAT []uint32
+	start := map[string]uint32{}
+	for _, name := range names {
+		data := streams[name]
+		if len(data) >= miniCutoff {
+			continue
+		}
+		start[name] = endOfChain
+		n := sectorsOf(len(data), miniSectorSize)
+		if n == 0 {
+			continue
+		}
+		first := uint32(len(miniFAT))
+		start[name] = first
+		for i := 0; i < n; i++ {
+			next := first + uint32(i) + 1
+			if i == n-1 {
+				next = endOfChain
+			}
+			miniFAT = append(miniFAT, next)
+		}
+		padded := make([]byte, n*miniSectorSize)
+		copy(padded, data)
+		miniStream = append(miniStream, padded...)
+	}
+
+	// lay out the sectors: FAT, directory, mini FAT, mini stream, then the large streams
+	dirSectors := sectorsOf((len(names)+1)*dirEntrySize, sectorSize)
+	miniFATSectors := sectorsOf(len(miniFAT)*4, sectorSize)
+	miniStreamSectors := sectorsOf(len(miniStream), sectorSize)
+	dataSectors := dirSectors + miniFATSectors + miniStreamSectors
+	for _, name := range names {
+		if len(streams[name]) >= miniCutoff {
+			dataSectors += sectorsOf(len(streams[name]), sectorSize)
+		}
+	}
+	fatSectors := 1
+	for (dataSectors+fatSectors)*4 > fatSectors*sectorSize {
+		fatSectors++
+	}
+	require.LessOrEqual(t, fatSectors, headerDIFATLen)
+
+	var fat []uint32
+	var body []byte
+	for i := 0; i < fatSectors; i++ {
+		fat = append(fat, fatSector)
+	}
+	addChain := func(data []byte) uint32 {
+		n := sectorsOf(len(data), sectorSize)
+		if n == 0 {
+			return endOfChain
+		}
+		first := uint32(len(fat))
+		for i := 0; i < n; i++ {
+			next := first + uint32(i) + 1
+			if i == n-1 {
+				next = endOfChain
+			}
+			fat = append(fat, next)
+		}
+		padded := make([]byte, n*sectorSize)
+		copy(padded, data)
+		body = append(body, padded...)
+		return first
+	}
+
+	dir := make([]byte, dirSectors*sectorSize)
+	dirStart := addChain(dir)
+	miniFATStart := addChain(uint32Bytes(miniFAT))
+	miniStreamStart := addChain(miniStream)
+	for _, name := range names {
+		if len(streams[name]) >= miniCutoff {
+			start[name] = addChain(streams[name])
+		}
+	}
+
+	// the root storage lists the streams as a chain of right siblings
+	writeEntry := func(i int, name string, objectType byte, start uint32, size int, right, child uint32) {
+		e := body[int(dirStart)*sectorSize-fatSectors*sectorSize+i*dirEntrySize:]
+		encoded := utf16.Encode([]rune(name))
+		for j, c := range encoded {
+			binary.LittleEndian.PutUint16(e[2*j:], c)
+		}
+		binary.LittleEndian.PutUint16(e[0x40:], uint16(2*(len(encoded)+1)))
+		e[0x42] = objectType
+		e[0x43] = 1 // black
+		binary.LittleEndian.PutUint32(e[0x44:], noStream)
+		binary.LittleEndian.PutUint32(e[0x48:], right)
+		binary.LittleEndian.PutUint32(e[0x4C:], child)
+		binary.LittleEndian.PutUint32(e[0x74:], start)
+		binary.LittleEndian.PutUint64(e[0x78:], uint64(size))
+	}
+	writeEntry(0, "Root Entry", typeRoot, miniStreamStart, len(miniStream), noStream, 1)
+	for i, name := range names {
+		right := uint32(i + 2)
+		if i == len(names)-1 {
+			right = noStream
+		}
+		writeEntry(i+1, name, typeStream, start[name], len(streams[name]), right, noStream)
+	}
+	for len(fat)%(sectorSize/4) != 0 {
+		fat = append(fat, 0xFFFFFFFF)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, signature)
+	le := binary.LittleEndian
+	le.PutUint16(header[0x18:], 0x3E)
+	le.PutUint16(header[0x1A:], 3)
+	le.PutUint16(header[0x1C:], 0xFFFE)
+	le.PutUint16(header[0x1E:], 9)
+	le.PutUint16(header[0x20:], 6)
+	le.PutUint32(header[0x2C:], uint32(fatSectors))
+	le.PutUint32(header[0x30:], dirStart)
+	le.PutUint32(header[0x38:], miniCutoff)
+	le.PutUint32(header[0x3C:], miniFATStart)
+	le.PutUint32(header[0x40:], uint32(miniFATSectors))
+	le.PutUint32(header[0x44:], endOfChain)
+	for i := 0; i < headerDIFATLen; i++ {
+		s := uint32(0xFFFFFFFF)
+		if i < fatSectors {
+			s = uint32(i)
+		}
+		le.PutUint32(header[0x4C+4*i:], s)
+	}
+
+	var out bytes.Buffer
+	out.Write(header)
+	out.Write(uint32Bytes(fat))
+	out.Write(body)
+	return out.Bytes()
+}
+
+func uint32Bytes(values []uint32) []byte {
+	out := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(out[4*i:], v)
+	}
+	return out
+}
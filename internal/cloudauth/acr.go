@@ -0,0 +1,197 @@
+package cloudauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+const (
+	// acrUsername is the username ACR accepts with a refresh token as the password
+	acrUsername = "00000000-0000-0000-0000-000000000000"
+
+	acrResource = "https://management.azure.com/"
+
+	imdsTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+)
+
+// acrAuthorityHosts maps the ACR registry DNS suffix of each Azure cloud to its Microsoft Entra ID authority host
+var acrAuthorityHosts = map[string]string{
+	".azurecr.io": "https://login.microsoftonline.com",
+	".azurecr.cn": "https://login.chinacloudapi.cn",
+	".azurecr.us": "https://login.microsoftonline.us",
+}
+
+type acr struct {
+	cfg    ACRConfig
+	client *http.Client
+
+	// imdsURL is the managed identity token endpoint of the instance metadata service
+	imdsURL string
+
+	// exchangeURL returns the endpoint exchanging an Entra ID access token for a registry refresh token
+	exchangeURL func(registry string) string
+}
+
+func newACR(cfg ACRConfig, client *http.Client) *acr {
+	return &acr{
+		cfg:     cfg,
+		client:  client,
+		imdsURL: imdsTokenURL,
+		exchangeURL: func(registry string) string {
+			return "https://" + registry + "/oauth2/exchange"
+		},
+	}
+}
+
+func (a *acr) name() string {
+	return "acr"
+}
+
+func (a *acr) matches(registry string) bool {
+	_, ok := acrAuthorityHost(registry)
+	return ok
+}
+
+// token gets an Entra ID access token for the workload or managed identity, which is exchanged for a refresh token
+// of the registry.
+func (a *acr) token(ctx context.Context, registry string) (*authn.AuthConfig, time.Time, error) {
+	accessToken, expires, err := a.accessToken(ctx, registry)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to get Entra ID access token: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {registry},
+		"access_token": {accessToken},
+	}
+	if tenant := a.tenantID(); tenant != "" {
+		form.Set("tenant", tenant)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.exchangeURL(registry), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var response struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := doJSON(a.client, req, &response); err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to exchange access token for registry refresh token: %w", err)
+	}
+	if response.RefreshToken == "" {
+		return nil, time.Time{}, fmt.Errorf("no registry refresh token returned")
+	}
+
+	// the refresh token outlives the access token, which bounds how long it is cached
+	return &authn.AuthConfig{Username: acrUsername, Password: response.RefreshToken}, expires, nil
+}
+
+// accessToken gets an access token with workload identity when a federated token is available
+// (AZURE_FEDERATED_TOKEN_FILE), otherwise with the managed identity of the host.
+func (a *acr) accessToken(ctx context.Context, registry string) (string, time.Time, error) {
+	if tokenFile := os.Getenv("AZURE_FEDERATED_TOKEN_FILE"); tokenFile != "" {
+		return a.workloadIdentityToken(ctx, registry, tokenFile)
+	}
+	return a.managedIdentityToken(ctx)
+}
+
+func (a *acr) workloadIdentityToken(ctx context.Context, registry, tokenFile string) (string, time.Time, error) {
+	clientID, tenantID := a.clientID(), a.tenantID()
+	if clientID == "" || tenantID == "" {
+		return "", time.Time{}, fmt.Errorf("a client ID and tenant ID are required for workload identity")
+	}
+
+	assertion, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("unable to read federated token: %w", err)
+	}
+
+	authorityHost, _ := acrAuthorityHost(registry)
+	if host := os.Getenv("AZURE_AUTHORITY_HOST"); host != "" {
+		authorityHost = strings.TrimSuffix(host, "/")
+	}
+
+	form := url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_id":             {clientID},
+		"scope":                 {acrResource + ".default"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {strings.TrimSpace(string(assertion))},
+	}
+	endpoint := fmt.Sprintf("%s/%s/oauth2/v2.0/token", authorityHost, url.PathEscape(tenantID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := doJSON(a.client, req, &response); err != nil {
+		return "", time.Time{}, err
+	}
+	return response.AccessToken, time.Now().Add(time.Duration(response.ExpiresIn) * time.Second), nil
+}
+
+func (a *acr) managedIdentityToken(ctx context.Context) (string, time.Time, error) {
+	query := url.Values{
+		"api-version": {"2018-02-01"},
+		"resource":    {acrResource},
+	}
+	if clientID := a.clientID(); clientID != "" {
+		query.Set("client_id", clientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.imdsURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+		ExpiresOn   string `json:"expires_on"`
+	}
+	if err := doJSON(a.client, req, &response); err != nil {
+		return "", time.Time{}, err
+	}
+	expiresOn, err := strconv.ParseInt(response.ExpiresOn, 10, 64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid managed identity token expiry %q: %w", response.ExpiresOn, err)
+	}
+	return response.AccessToken, time.Unix(expiresOn, 0), nil
+}
+
+func (a *acr) clientID() string {
+	if a.cfg.ClientID != "" {
+		return a.cfg.ClientID
+	}
+	return os.Getenv("AZURE_CLIENT_ID")
+}
+
+func (a *acr) tenantID() string {
+	if a.cfg.TenantID != "" {
+		return a.cfg.TenantID
+	}
+	return os.Getenv("AZURE_TENANT_ID")
+}
+
+func acrAuthorityHost(registry string) (string, bool) {
+	for suffix, host := range acrAuthorityHosts {
+		if strings.HasSuffix(registry, suffix) {
+			return host, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,96 @@
+package cloudauth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACR_matches(t *testing.T) {
+	a := newACR(ACRConfig{}, http.DefaultClient)
+	assert.True(t, a.matches("example.azurecr.io"))
+	assert.True(t, a.matches("example.azurecr.cn"))
+	assert.False(t, a.matches("azurecr.io.example.com"))
+	assert.False(t, a.matches("ghcr.io"))
+}
+
+// newACRServer returns a server acting as Entra ID, the instance metadata service and the registry token exchange.
+func newACRServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tenant-id/oauth2/v2.0/token":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "client-id", r.PostForm.Get("client_id"))
+			assert.Equal(t, "federated-token", r.PostForm.Get("client_assertion"))
+			_, _ = w.Write([]byte(`{"access_token":"workload-access-token","expires_in":3600}`))
+		case "/metadata/identity/oauth2/token":
+			assert.Equal(t, "true", r.Header.Get("Metadata"))
+			assert.Equal(t, acrResource, r.URL.Query().Get("resource"))
+			_, _ = w.Write([]byte(`{"access_token":"managed-access-token","expires_on":"1767268800"}`))
+		case "/oauth2/exchange":
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "example.azurecr.io", r.PostForm.Get("service"))
+			_, _ = w.Write([]byte(`{"refresh_token":"refresh-for-` + r.PostForm.Get("access_token") + `"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestACR(server *httptest.Server, cfg ACRConfig) *acr {
+	a := newACR(cfg, server.Client())
+	a.imdsURL = server.URL + "/metadata/identity/oauth2/token"
+	a.exchangeURL = func(string) string { return server.URL + "/oauth2/exchange" }
+	return a
+}
+
+func TestACR_token_workloadIdentity(t *testing.T) {
+	server := newACRServer(t)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("federated-token\n"), 0o600))
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", tokenFile)
+	t.Setenv("AZURE_AUTHORITY_HOST", server.URL+"/")
+	t.Setenv("AZURE_CLIENT_ID", "client-id")
+	t.Setenv("AZURE_TENANT_ID", "")
+
+	a := newTestACR(server, ACRConfig{Enabled: true, TenantID: "tenant-id"})
+	auth, _, err := a.token(context.Background(), "example.azurecr.io")
+	require.NoError(t, err)
+	assert.Equal(t, &authn.AuthConfig{Username: acrUsername, Password: "refresh-for-workload-access-token"}, auth)
+}
+
+func TestACR_token_workloadIdentityMissingTenant(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("federated-token"), 0o600))
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", tokenFile)
+	t.Setenv("AZURE_CLIENT_ID", "client-id")
+	t.Setenv("AZURE_TENANT_ID", "")
+
+	a := newACR(ACRConfig{Enabled: true}, http.DefaultClient)
+	_, _, err := a.token(context.Background(), "example.azurecr.io")
+	require.ErrorContains(t, err, "a client ID and tenant ID are required for workload identity")
+}
+
+func TestACR_token_managedIdentity(t *testing.T) {
+	server := newACRServer(t)
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", "")
+	t.Setenv("AZURE_CLIENT_ID", "")
+	t.Setenv("AZURE_TENANT_ID", "")
+
+	a := newTestACR(server, ACRConfig{Enabled: true})
+	auth, expires, err := a.token(context.Background(), "example.azurecr.io")
+	require.NoError(t, err)
+	assert.Equal(t, &authn.AuthConfig{Username: acrUsername, Password: "refresh-for-managed-access-token"}, auth)
+	assert.Equal(t, int64(1767268800), expires.Unix())
+}
@@ -0,0 +1,148 @@
+// Package cloudauth resolves short-lived registry credentials from the cloud environment grype is running in, such
+// that scanners running in-cloud can pull from Amazon ECR, Google Container/Artifact Registry and Azure Container
+// Registry without refreshing docker credentials out of band.
+package cloudauth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+const (
+	// expiryMargin is how long before expiring a cached token is refreshed, such that a token does not expire
+	// during a pull
+	expiryMargin = 5 * time.Minute
+
+	requestTimeout = 30 * time.Second
+)
+
+// Config selects the cloud registry authentication flows used.
+type Config struct {
+	ECR ECRConfig
+	GCR GCRConfig
+	ACR ACRConfig
+}
+
+// ECRConfig configures authentication with Amazon ECR registries using the default AWS credential chain
+// (environment, shared config, web identity, container and instance roles).
+type ECRConfig struct {
+	Enabled bool
+
+	// RoleARN is the role assumed to get the authorization token (optional).
+	RoleARN string
+
+	// ExternalID is passed when assuming the role (optional).
+	ExternalID string
+
+	// SessionName is the name of the assumed role session (optional).
+	SessionName string
+}
+
+// GCRConfig configures authentication with Google Container Registry and Artifact Registry using application
+// default credentials (including GKE workload identity and the compute metadata server).
+type GCRConfig struct {
+	Enabled bool
+}
+
+// ACRConfig configures authentication with Azure Container Registry using workload identity (a federated token) or,
+// barring that, a managed identity.
+type ACRConfig struct {
+	Enabled bool
+
+	// ClientID is the client ID of the identity, defaulting to AZURE_CLIENT_ID (optional for system-assigned
+	// managed identities).
+	ClientID string
+
+	// TenantID is the tenant of the identity, defaulting to AZURE_TENANT_ID.
+	TenantID string
+}
+
+// IsZero returns true when no flows are enabled.
+func (c Config) IsZero() bool {
+	return !c.ECR.Enabled && !c.GCR.Enabled && !c.ACR.Enabled
+}
+
+// provider exchanges cloud credentials for registry credentials.
+type provider interface {
+	name() string
+	matches(registry string) bool
+	token(ctx context.Context, registry string) (*authn.AuthConfig, time.Time, error)
+}
+
+// Keychain returns a keychain resolving credentials for the registries of the enabled cloud providers, which
+// resolves to anonymous for other registries or when the provider credentials are not available.
+func Keychain(cfg Config) authn.Keychain {
+	client := &http.Client{Timeout: requestTimeout}
+
+	var providers []provider
+	if cfg.ECR.Enabled {
+		providers = append(providers, newECR(cfg.ECR, client))
+	}
+	if cfg.GCR.Enabled {
+		providers = append(providers, newGCR())
+	}
+	if cfg.ACR.Enabled {
+		providers = append(providers, newACR(cfg.ACR, client))
+	}
+	return newKeychain(providers...)
+}
+
+type cachedToken struct {
+	auth    authn.AuthConfig
+	expires time.Time
+}
+
+type keychain struct {
+	providers []provider
+	now       func() time.Time
+
+	lock  sync.Mutex
+	cache map[string]cachedToken
+}
+
+func newKeychain(providers ...provider) *keychain {
+	return &keychain{
+		providers: providers,
+		now:       time.Now,
+		cache:     map[string]cachedToken{},
+	}
+}
+
+func (k *keychain) Resolve(r authn.Resource) (authn.Authenticator, error) {
+	return k.ResolveContext(context.Background(), r)
+}
+
+func (k *keychain) ResolveContext(ctx context.Context, r authn.Resource) (authn.Authenticator, error) {
+	registry := r.RegistryStr()
+	for _, p := range k.providers {
+		if !p.matches(registry) {
+			continue
+		}
+
+		k.lock.Lock()
+		defer k.lock.Unlock()
+
+		if cached, ok := k.cache[registry]; ok && k.now().Add(expiryMargin).Before(cached.expires) {
+			return authn.FromConfig(cached.auth), nil
+		}
+
+		fields := []any{"registry", registry, "provider", p.name()}
+		auth, expires, err := p.token(ctx, registry)
+		if err != nil {
+			// as with missing credentials, an anonymous pull may still succeed for public images
+			log.WithFields(append(fields, "error", err)...).Warn("unable to get cloud registry credentials")
+			return authn.Anonymous, nil
+		}
+		log.WithFields(append(fields, "expires", expires)...).Debug("using cloud registry credentials")
+
+		k.cache[registry] = cachedToken{auth: *auth, expires: expires}
+		return authn.FromConfig(*auth), nil
+	}
+	return authn.Anonymous, nil
+}
@@ -0,0 +1,93 @@
+package cloudauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	registry string
+	auth     *authn.AuthConfig
+	expires  time.Time
+	err      error
+	calls    int
+}
+
+func (f *fakeProvider) name() string {
+	return "fake"
+}
+
+func (f *fakeProvider) matches(registry string) bool {
+	return registry == f.registry
+}
+
+func (f *fakeProvider) token(context.Context, string) (*authn.AuthConfig, time.Time, error) {
+	f.calls++
+	return f.auth, f.expires, f.err
+}
+
+func resolve(t *testing.T, k authn.Keychain, registry string) authn.Authenticator {
+	t.Helper()
+	reg, err := name.NewRegistry(registry)
+	require.NoError(t, err)
+	auth, err := k.Resolve(reg)
+	require.NoError(t, err)
+	return auth
+}
+
+func TestConfig_IsZero(t *testing.T) {
+	assert.True(t, Config{}.IsZero())
+	assert.True(t, Config{ECR: ECRConfig{RoleARN: "arn:aws:iam::123456789012:role/scanner"}}.IsZero())
+	assert.False(t, Config{GCR: GCRConfig{Enabled: true}}.IsZero())
+}
+
+func TestKeychain_Resolve(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &fakeProvider{
+		registry: "registry.example.com",
+		auth:     &authn.AuthConfig{Username: "user", Password: "token"},
+		expires:  now.Add(time.Hour),
+	}
+	k := newKeychain(p)
+	k.now = func() time.Time { return now }
+
+	// other registries are left to the following keychains
+	assert.Equal(t, authn.Anonymous, resolve(t, k, "ghcr.io"))
+	assert.Equal(t, 0, p.calls)
+
+	auth := resolve(t, k, "registry.example.com")
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, &authn.AuthConfig{Username: "user", Password: "token"}, cfg)
+
+	// tokens are cached until shortly before expiring
+	resolve(t, k, "registry.example.com")
+	assert.Equal(t, 1, p.calls)
+
+	now = now.Add(time.Hour - expiryMargin)
+	resolve(t, k, "registry.example.com")
+	assert.Equal(t, 2, p.calls)
+}
+
+func TestKeychain_Resolve_error(t *testing.T) {
+	p := &fakeProvider{registry: "registry.example.com", err: errors.New("no credentials")}
+	k := newKeychain(p)
+
+	assert.Equal(t, authn.Anonymous, resolve(t, k, "registry.example.com"))
+	assert.Equal(t, authn.Anonymous, resolve(t, k, "registry.example.com"))
+	assert.Equal(t, 2, p.calls)
+}
+
+func TestKeychain_providers(t *testing.T) {
+	k := Keychain(Config{ECR: ECRConfig{Enabled: true}, ACR: ACRConfig{Enabled: true}}).(*keychain)
+	require.Len(t, k.providers, 2)
+	assert.Equal(t, "ecr", k.providers[0].name())
+	assert.Equal(t, "acr", k.providers[1].name())
+}
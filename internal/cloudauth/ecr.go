@@ -0,0 +1,147 @@
+package cloudauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+// ecrRegistryPattern matches private ECR registries, capturing whether the registry is a FIPS endpoint, the region
+// and the partition DNS suffix (e.g. 123456789012.dkr.ecr.us-east-1.amazonaws.com)
+var ecrRegistryPattern = regexp.MustCompile(`^\d{12}\.dkr\.ecr(-fips)?\.([a-z0-9-]+)\.(amazonaws\.com(?:\.cn)?)$`)
+
+const ecrGetAuthorizationTokenTarget = "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken"
+
+type ecr struct {
+	cfg    ECRConfig
+	client *http.Client
+
+	// credentials returns the AWS credentials used to sign requests for the region
+	credentials func(ctx context.Context, region string) (aws.Credentials, error)
+
+	// endpoint returns the ECR API endpoint for the registry
+	endpoint func(registry string) string
+}
+
+func newECR(cfg ECRConfig, client *http.Client) *ecr {
+	e := &ecr{cfg: cfg, client: client, endpoint: ecrEndpoint}
+	e.credentials = e.awsCredentials
+	return e
+}
+
+func (e *ecr) name() string {
+	return "ecr"
+}
+
+func (e *ecr) matches(registry string) bool {
+	return ecrRegistryPattern.MatchString(registry)
+}
+
+// token gets an authorization token with the ECR GetAuthorizationToken API, signing the request with the AWS
+// credentials rather than depending on the ECR client.
+func (e *ecr) token(ctx context.Context, registry string) (*authn.AuthConfig, time.Time, error) {
+	region := ecrRegistryPattern.FindStringSubmatch(registry)[2]
+
+	creds, err := e.credentials(ctx, region)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to get AWS credentials: %w", err)
+	}
+
+	body := []byte("{}")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint(registry), bytes.NewReader(body))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", ecrGetAuthorizationTokenTarget)
+
+	hash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "ecr", region, time.Now()); err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to sign ECR request: %w", err)
+	}
+
+	var response struct {
+		AuthorizationData []struct {
+			AuthorizationToken string  `json:"authorizationToken"`
+			ExpiresAt          float64 `json:"expiresAt"`
+		} `json:"authorizationData"`
+	}
+	if err := doJSON(e.client, req, &response); err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to get ECR authorization token: %w", err)
+	}
+	if len(response.AuthorizationData) == 0 {
+		return nil, time.Time{}, fmt.Errorf("no ECR authorization token returned")
+	}
+
+	data := response.AuthorizationData[0]
+	decoded, err := base64.StdEncoding.DecodeString(data.AuthorizationToken)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to decode ECR authorization token: %w", err)
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf("malformed ECR authorization token")
+	}
+	return &authn.AuthConfig{Username: username, Password: password}, time.Unix(int64(data.ExpiresAt), 0), nil
+}
+
+// awsCredentials loads the credentials from the default AWS credential chain, assuming the configured role.
+func (e *ecr) awsCredentials(ctx context.Context, region string) (aws.Credentials, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region), config.WithHTTPClient(e.client))
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	if e.cfg.RoleARN != "" {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsCfg), e.cfg.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			if e.cfg.ExternalID != "" {
+				o.ExternalID = aws.String(e.cfg.ExternalID)
+			}
+			if e.cfg.SessionName != "" {
+				o.RoleSessionName = e.cfg.SessionName
+			}
+		})
+		awsCfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	return awsCfg.Credentials.Retrieve(ctx)
+}
+
+func ecrEndpoint(registry string) string {
+	m := ecrRegistryPattern.FindStringSubmatch(registry)
+	return fmt.Sprintf("https://api.ecr%s.%s.%s/", m[1], m[2], m[3])
+}
+
+// doJSON sends the request, decoding a successful JSON response into v.
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s: %s", req.Method, req.URL.Redacted(), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
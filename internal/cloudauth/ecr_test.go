@@ -0,0 +1,76 @@
+package cloudauth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestECR_matches(t *testing.T) {
+	e := newECR(ECRConfig{}, http.DefaultClient)
+	assert.True(t, e.matches("123456789012.dkr.ecr.us-east-1.amazonaws.com"))
+	assert.True(t, e.matches("123456789012.dkr.ecr-fips.us-gov-west-1.amazonaws.com"))
+	assert.True(t, e.matches("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn"))
+	assert.False(t, e.matches("public.ecr.aws"))
+	assert.False(t, e.matches("dkr.ecr.us-east-1.amazonaws.com"))
+	assert.False(t, e.matches("ghcr.io"))
+}
+
+func Test_ecrEndpoint(t *testing.T) {
+	assert.Equal(t, "https://api.ecr.us-east-1.amazonaws.com/", ecrEndpoint("123456789012.dkr.ecr.us-east-1.amazonaws.com"))
+	assert.Equal(t, "https://api.ecr-fips.us-gov-west-1.amazonaws.com/", ecrEndpoint("123456789012.dkr.ecr-fips.us-gov-west-1.amazonaws.com"))
+	assert.Equal(t, "https://api.ecr.cn-north-1.amazonaws.com.cn/", ecrEndpoint("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn"))
+}
+
+func TestECR_token(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ecrGetAuthorizationTokenTarget, r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/ecr/aws4_request")
+
+		token := base64.StdEncoding.EncodeToString([]byte("AWS:ecr-password"))
+		_, _ = fmt.Fprintf(w, `{"authorizationData":[{"authorizationToken":%q,"expiresAt":%d,"proxyEndpoint":"https://123456789012.dkr.ecr.us-east-1.amazonaws.com"}]}`, token, expiresAt.Unix())
+	}))
+	defer server.Close()
+
+	e := newECR(ECRConfig{Enabled: true}, server.Client())
+	e.endpoint = func(string) string { return server.URL }
+	e.credentials = func(_ context.Context, region string) (aws.Credentials, error) {
+		assert.Equal(t, "us-east-1", region)
+		return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+	}
+
+	auth, expires, err := e.token(context.Background(), "123456789012.dkr.ecr.us-east-1.amazonaws.com")
+	require.NoError(t, err)
+	assert.Equal(t, &authn.AuthConfig{Username: "AWS", Password: "ecr-password"}, auth)
+	assert.True(t, expiresAt.Equal(expires))
+}
+
+func TestECR_token_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"AccessDeniedException"}`))
+	}))
+	defer server.Close()
+
+	e := newECR(ECRConfig{Enabled: true}, server.Client())
+	e.endpoint = func(string) string { return server.URL }
+	e.credentials = func(context.Context, string) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+	}
+
+	_, _, err := e.token(context.Background(), "123456789012.dkr.ecr.us-east-1.amazonaws.com")
+	require.ErrorContains(t, err, "400 Bad Request")
+	require.ErrorContains(t, err, "AccessDeniedException")
+}
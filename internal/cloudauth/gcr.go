@@ -0,0 +1,54 @@
+package cloudauth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcrScope = "https://www.googleapis.com/auth/cloud-platform"
+
+	// gcrUsername is the username registries accept with an OAuth2 access token as the password
+	gcrUsername = "oauth2accesstoken"
+)
+
+type gcr struct {
+	// tokenSource returns the source of the access tokens used as registry passwords
+	tokenSource func(ctx context.Context) (oauth2.TokenSource, error)
+}
+
+func newGCR() *gcr {
+	return &gcr{
+		tokenSource: func(ctx context.Context) (oauth2.TokenSource, error) {
+			return google.DefaultTokenSource(ctx, gcrScope)
+		},
+	}
+}
+
+func (g *gcr) name() string {
+	return "gcr"
+}
+
+// matches returns true for Container Registry (gcr.io, us.gcr.io, ...) and Artifact Registry (us-docker.pkg.dev, ...)
+// registries.
+func (g *gcr) matches(registry string) bool {
+	return registry == "gcr.io" || strings.HasSuffix(registry, ".gcr.io") || strings.HasSuffix(registry, "-docker.pkg.dev")
+}
+
+func (g *gcr) token(ctx context.Context, _ string) (*authn.AuthConfig, time.Time, error) {
+	ts, err := g.tokenSource(ctx)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to find Google application default credentials: %w", err)
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to get Google access token: %w", err)
+	}
+	return &authn.AuthConfig{Username: gcrUsername, Password: tok.AccessToken}, tok.Expiry, nil
+}
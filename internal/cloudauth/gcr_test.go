@@ -0,0 +1,41 @@
+package cloudauth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestGCR_matches(t *testing.T) {
+	g := newGCR()
+	assert.True(t, g.matches("gcr.io"))
+	assert.True(t, g.matches("us.gcr.io"))
+	assert.True(t, g.matches("us-central1-docker.pkg.dev"))
+	assert.False(t, g.matches("notgcr.io"))
+	assert.False(t, g.matches("ghcr.io"))
+}
+
+func TestGCR_token(t *testing.T) {
+	expiry := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	g := newGCR()
+	g.tokenSource = func(context.Context) (oauth2.TokenSource, error) {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access-token", Expiry: expiry}), nil
+	}
+
+	auth, expires, err := g.token(context.Background(), "gcr.io")
+	require.NoError(t, err)
+	assert.Equal(t, &authn.AuthConfig{Username: gcrUsername, Password: "access-token"}, auth)
+	assert.Equal(t, expiry, expires)
+
+	g.tokenSource = func(context.Context) (oauth2.TokenSource, error) {
+		return nil, errors.New("could not find default credentials")
+	}
+	_, _, err = g.token(context.Background(), "gcr.io")
+	require.ErrorContains(t, err, "unable to find Google application default credentials")
+}
@@ -0,0 +1,171 @@
+// Package credhelper resolves registry credentials with docker credential helpers (docker-credential-<name>
+// executables), such as OS keychains (osxkeychain, wincred, secretservice, pass) and cloud-provider token exchanges
+// (ecr-login, gcr, acr-env).
+//
+// See https://docs.docker.com/reference/cli/docker/login/#credential-helper-protocol for the protocol.
+package credhelper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+const (
+	executablePrefix = "docker-credential-"
+
+	// notFoundMessage is returned by helpers without credentials for a registry
+	notFoundMessage = "credentials not found in native keychain"
+
+	// dockerHubServerURL is the server URL credentials for Docker Hub are stored under by the docker CLI
+	dockerHubServerURL = "https://index.docker.io/v1/"
+)
+
+// errNotFound is returned when a helper has no credentials for a registry.
+var errNotFound = errors.New(notFoundMessage)
+
+// Rule selects the credential helper used for registries matching the authority.
+type Rule struct {
+	// Authority is the registry host (e.g. "ghcr.io" or "localhost:5000") or a glob matching registry hosts (e.g.
+	// "*.dkr.ecr.*.amazonaws.com"). The rule applies to all registries when empty.
+	Authority string
+
+	// Helper is the name of the credential helper (e.g. "osxkeychain" runs docker-credential-osxkeychain).
+	Helper string
+}
+
+// Validate returns an error when the rule does not name a helper or has an invalid authority glob.
+func (r Rule) Validate() error {
+	if r.Helper == "" {
+		return fmt.Errorf("a credential helper name is required")
+	}
+	if strings.ContainsAny(r.Helper, `/\`) || strings.HasPrefix(r.Helper, executablePrefix) {
+		return fmt.Errorf("invalid credential helper %q: must be the name of the helper without the %q prefix", r.Helper, executablePrefix)
+	}
+	if _, err := path.Match(r.Authority, ""); err != nil {
+		return fmt.Errorf("invalid credential helper authority %q: %w", r.Authority, err)
+	}
+	return nil
+}
+
+// Executable returns the name of the executable run for the helper.
+func (r Rule) Executable() string {
+	return executablePrefix + r.Helper
+}
+
+func (r Rule) matches(registry string) bool {
+	if r.Authority == "" {
+		return true
+	}
+	if isDockerHub(r.Authority) && isDockerHub(registry) {
+		return true
+	}
+	matched, err := path.Match(strings.ToLower(r.Authority), strings.ToLower(registry))
+	return err == nil && matched
+}
+
+// Keychain returns a keychain resolving credentials with the helper of the first rule matching each registry, falling
+// back to the given keychains when no rule matches or the helper has no credentials for the registry.
+func Keychain(rules []Rule, fallback ...authn.Keychain) authn.Keychain {
+	return authn.NewMultiKeychain(append([]authn.Keychain{keychain{rules: rules, run: run}}, fallback...)...)
+}
+
+type runner func(ctx context.Context, executable, serverURL string) ([]byte, error)
+
+type keychain struct {
+	rules []Rule
+	run   runner
+}
+
+func (k keychain) Resolve(r authn.Resource) (authn.Authenticator, error) {
+	return k.ResolveContext(context.Background(), r)
+}
+
+func (k keychain) ResolveContext(ctx context.Context, r authn.Resource) (authn.Authenticator, error) {
+	registry := r.RegistryStr()
+	for _, rule := range k.rules {
+		if !rule.matches(registry) {
+			continue
+		}
+
+		fields := []any{"registry", registry, "helper", rule.Helper}
+		cfg, err := k.get(ctx, rule, registry)
+		switch {
+		case errors.Is(err, errNotFound):
+			log.WithFields(fields...).Debug("credential helper has no credentials for registry")
+		case err != nil:
+			// unlike failing to authenticate, an anonymous pull may still succeed for public images
+			log.WithFields(append(fields, "error", err)...).Warn("unable to get registry credentials from credential helper")
+		default:
+			log.WithFields(fields...).Debug("using registry credentials from credential helper")
+			return authn.FromConfig(*cfg), nil
+		}
+		return authn.Anonymous, nil
+	}
+	return authn.Anonymous, nil
+}
+
+type credentials struct {
+	Username string `json:"Username"`
+	Secret   string `json:"Secret"`
+}
+
+func (k keychain) get(ctx context.Context, rule Rule, registry string) (*authn.AuthConfig, error) {
+	serverURL := registry
+	if isDockerHub(registry) {
+		serverURL = dockerHubServerURL
+	}
+
+	out, err := k.run(ctx, rule.Executable(), serverURL)
+	if err != nil {
+		if strings.Contains(string(out), notFoundMessage) {
+			return nil, errNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(string(out)), err)
+	}
+
+	var creds credentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return nil, fmt.Errorf("unable to parse credentials: %w", err)
+	}
+	if creds.Secret == "" {
+		return nil, errNotFound
+	}
+
+	// identity tokens are stored with a username of "<token>"
+	if creds.Username == "<token>" {
+		return &authn.AuthConfig{IdentityToken: creds.Secret}, nil
+	}
+	return &authn.AuthConfig{Username: creds.Username, Password: creds.Secret}, nil
+}
+
+// run runs the helper, returning its output (along with the error output when it fails).
+func run(ctx context.Context, executable, serverURL string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, executable, "get")
+	cmd.Stdin = strings.NewReader(serverURL)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return append(stdout.Bytes(), stderr.Bytes()...), err
+	}
+	return stdout.Bytes(), nil
+}
+
+func isDockerHub(registry string) bool {
+	switch strings.ToLower(registry) {
+	case name.DefaultRegistry, "docker.io", "registry-1.docker.io":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package credhelper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr string
+	}{
+		{name: "default helper", rule: Rule{Helper: "osxkeychain"}},
+		{name: "authority glob", rule: Rule{Authority: "*.dkr.ecr.*.amazonaws.com", Helper: "ecr-login"}},
+		{name: "missing helper", rule: Rule{Authority: "ghcr.io"}, wantErr: "a credential helper name is required"},
+		{name: "helper path", rule: Rule{Helper: "/usr/bin/docker-credential-pass"}, wantErr: "invalid credential helper"},
+		{name: "helper with prefix", rule: Rule{Helper: "docker-credential-pass"}, wantErr: "invalid credential helper"},
+		{name: "bad glob", rule: Rule{Authority: "[ghcr.io", Helper: "pass"}, wantErr: "invalid credential helper authority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRule_matches(t *testing.T) {
+	tests := []struct {
+		rule     Rule
+		registry string
+		want     bool
+	}{
+		{rule: Rule{}, registry: "ghcr.io", want: true},
+		{rule: Rule{Authority: "ghcr.io"}, registry: "ghcr.io", want: true},
+		{rule: Rule{Authority: "ghcr.io"}, registry: "quay.io", want: false},
+		{rule: Rule{Authority: "*.dkr.ecr.*.amazonaws.com"}, registry: "123456789012.dkr.ecr.us-east-1.amazonaws.com", want: true},
+		{rule: Rule{Authority: "*.azurecr.io"}, registry: "example.azurecr.io", want: true},
+		{rule: Rule{Authority: "docker.io"}, registry: name.DefaultRegistry, want: true},
+		{rule: Rule{Authority: "localhost:5000"}, registry: "localhost:5000", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule.Authority+"->"+tt.registry, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.matches(tt.registry))
+		})
+	}
+}
+
+func TestKeychain_Resolve(t *testing.T) {
+	var gotExecutable, gotServerURL string
+	k := keychain{
+		rules: []Rule{
+			{Authority: "*.dkr.ecr.*.amazonaws.com", Helper: "ecr-login"},
+			{Authority: "ghcr.io", Helper: "failing"},
+			{Authority: "quay.io", Helper: "empty"},
+			{Helper: "osxkeychain"},
+		},
+		run: func(_ context.Context, executable, serverURL string) ([]byte, error) {
+			gotExecutable, gotServerURL = executable, serverURL
+			switch executable {
+			case "docker-credential-ecr-login":
+				return []byte(`{"ServerURL":"` + serverURL + `","Username":"AWS","Secret":"ecr-token"}`), nil
+			case "docker-credential-osxkeychain":
+				return []byte(`{"Username":"<token>","Secret":"identity-token"}`), nil
+			case "docker-credential-empty":
+				return []byte(notFoundMessage + "\n"), errors.New("exit status 1")
+			}
+			return []byte("helper crashed"), errors.New("exit status 2")
+		},
+	}
+
+	tests := []struct {
+		name          string
+		registry      string
+		want          authn.AuthConfig
+		wantAnonymous bool
+		executable    string
+		serverURL     string
+	}{
+		{
+			name:       "cloud provider helper",
+			registry:   "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+			want:       authn.AuthConfig{Username: "AWS", Password: "ecr-token"},
+			executable: "docker-credential-ecr-login",
+			serverURL:  "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+		},
+		{
+			name:       "default helper with identity token",
+			registry:   name.DefaultRegistry,
+			want:       authn.AuthConfig{IdentityToken: "identity-token"},
+			executable: "docker-credential-osxkeychain",
+			serverURL:  dockerHubServerURL,
+		},
+		{
+			name:          "failing helper",
+			registry:      "ghcr.io",
+			wantAnonymous: true,
+			executable:    "docker-credential-failing",
+			serverURL:     "ghcr.io",
+		},
+		{
+			name:          "helper without credentials",
+			registry:      "quay.io",
+			wantAnonymous: true,
+			executable:    "docker-credential-empty",
+			serverURL:     "quay.io",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg, err := name.NewRegistry(tt.registry)
+			require.NoError(t, err)
+
+			auth, err := k.Resolve(reg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.executable, gotExecutable)
+			assert.Equal(t, tt.serverURL, gotServerURL)
+			if tt.wantAnonymous {
+				assert.Equal(t, authn.Anonymous, auth)
+				return
+			}
+			cfg, err := auth.Authorization()
+			require.NoError(t, err)
+			assert.Equal(t, &tt.want, cfg)
+		})
+	}
+}
+
+func TestKeychain_Executable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential helper scripts are not supported on windows")
+	}
+
+	// a credential helper on the PATH, echoing the server URL as the username
+	dir := t.TempDir()
+	script := "#!/bin/sh\nread url\necho '{\"Username\":\"'\"$url\"'\",\"Secret\":\"s3cr3t\"}'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker-credential-test"), []byte(script), 0o700)) //nolint:gosec
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	reg, err := name.NewRegistry("registry.example.com")
+	require.NoError(t, err)
+
+	auth, err := Keychain([]Rule{{Helper: "test"}}).Resolve(reg)
+	require.NoError(t, err)
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, &authn.AuthConfig{Username: "registry.example.com", Password: "s3cr3t"}, cfg)
+}
+
+func TestKeychain_Fallback(t *testing.T) {
+	reg, err := name.NewRegistry("registry.example.com")
+	require.NoError(t, err)
+
+	fallback := authn.NewKeychainFromHelper(staticHelper{username: "user", password: "pass"})
+	auth, err := Keychain([]Rule{{Authority: "ghcr.io", Helper: "not-used"}}, fallback).Resolve(reg)
+	require.NoError(t, err)
+	cfg, err := auth.Authorization()
+	require.NoError(t, err)
+	assert.Equal(t, "user", cfg.Username)
+}
+
+type staticHelper struct {
+	username, password string
+}
+
+func (h staticHelper) Get(string) (string, string, error) {
+	return h.username, h.password, nil
+}
@@ -0,0 +1,210 @@
+package cvss
+
+import (
+	"fmt"
+	"strings"
+
+	gocvss20 "github.com/pandatix/go-cvss/20"
+	gocvss30 "github.com/pandatix/go-cvss/30"
+	gocvss31 "github.com/pandatix/go-cvss/31"
+	gocvss40 "github.com/pandatix/go-cvss/40"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+// Modifiers are CVSS temporal (threat) and environmental metrics, such as "CR:H/MAV:L/E:P", that are applied to the
+// vectors of vulnerabilities to compute scores adjusted to the environment an asset runs in. A single set of modifiers
+// may mix the metrics of different CVSS versions: metrics that a version does not define are not applied to vectors of
+// that version.
+type Modifiers struct {
+	metrics [][2]string
+}
+
+// Adjustment is a CVSS score recomputed with modifiers applied.
+type Adjustment struct {
+	Version   string                 // the CVSS version of the adjusted vector
+	Vector    string                 // the vector with the modifiers applied
+	BaseScore float64                // the score of the vector as published
+	Score     float64                // the environmental score (the overall score for CVSS v4.0)
+	Severity  vulnerability.Severity // the severity rating of the adjusted score
+}
+
+// modifiable is the common interface of the CVSS vectors of every version.
+type modifiable interface {
+	Set(abv, value string) error
+	Vector() string
+}
+
+// sampleVectors are used to validate modifiers against every CVSS version.
+var sampleVectors = []string{
+	"AV:N/AC:L/Au:N/C:P/I:P/A:P",
+	"CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+	"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+	"CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N",
+}
+
+// ParseModifiers parses modifiers in CVSS vector notation ("metric:value" pairs separated by "/"). Every metric must be
+// a valid modifier for at least one CVSS version.
+func ParseModifiers(s string) (Modifiers, error) {
+	var m Modifiers
+	for _, field := range strings.Split(strings.Trim(strings.TrimSpace(s), "/"), "/") {
+		if field == "" {
+			continue
+		}
+		abv, value, ok := strings.Cut(field, ":")
+		if !ok || abv == "" || value == "" {
+			return Modifiers{}, fmt.Errorf("invalid CVSS modifier %q: expected metric:value", field)
+		}
+		if abv == "CVSS" {
+			return Modifiers{}, fmt.Errorf("invalid CVSS modifier %q: modifiers must not include the CVSS version", field)
+		}
+		m.metrics = append(m.metrics, [2]string{abv, value})
+	}
+
+	for _, metric := range m.metrics {
+		if !validModifier(metric) {
+			return Modifiers{}, fmt.Errorf("invalid CVSS modifier %q: not a temporal or environmental metric of any CVSS version", metric[0]+":"+metric[1])
+		}
+	}
+	return m, nil
+}
+
+func validModifier(metric [2]string) bool {
+	for _, vector := range sampleVectors {
+		v, _, err := parseModifiable(vector)
+		if err != nil {
+			continue
+		}
+		base := v.Vector()
+		if v.Set(metric[0], metric[1]) == nil && isModifier(base, metric[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isModifier indicates whether the metric is not a base metric of the given (base) vector.
+func isModifier(baseVector, abv string) bool {
+	for _, field := range strings.Split(baseVector, "/") {
+		if strings.HasPrefix(field, abv+":") {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty indicates that no modifiers are set (scores are not adjusted).
+func (m Modifiers) IsEmpty() bool {
+	return len(m.metrics) == 0
+}
+
+// String returns the modifiers in CVSS vector notation.
+func (m Modifiers) String() string {
+	fields := make([]string, len(m.metrics))
+	for i, metric := range m.metrics {
+		fields[i] = metric[0] + ":" + metric[1]
+	}
+	return strings.Join(fields, "/")
+}
+
+// Adjust applies the modifiers to the given vector and recomputes its score.
+func (m Modifiers) Adjust(vector string) (*Adjustment, error) {
+	v, score, err := parseModifiable(vector)
+	if err != nil {
+		return nil, err
+	}
+	base := v.Vector()
+	baseScore := roundScore(score())
+
+	for _, metric := range m.metrics {
+		if !isModifier(base, metric[0]) {
+			continue
+		}
+		// metrics (or values) that are not defined for the version of the vector are not applicable
+		_ = v.Set(metric[0], metric[1])
+	}
+
+	adjusted := roundScore(score())
+	return &Adjustment{
+		Version:   vectorVersion(vector),
+		Vector:    v.Vector(),
+		BaseScore: baseScore,
+		Score:     adjusted,
+		Severity:  severityFromScore(adjusted),
+	}, nil
+}
+
+// AdjustScores adjusts the preferred score among those given: primary scores are preferred over secondary scores, then
+// the newest CVSS version. Nil is returned when no score can be adjusted.
+func (m Modifiers) AdjustScores(scores []vulnerability.Cvss) *Adjustment {
+	if m.IsEmpty() {
+		return nil
+	}
+
+	var best *Adjustment
+	bestPrimary := false
+	for _, s := range scores {
+		adjusted, err := m.Adjust(s.Vector)
+		if err != nil {
+			continue
+		}
+		primary := strings.EqualFold(s.Type, "primary")
+		switch {
+		case best == nil,
+			primary && !bestPrimary,
+			primary == bestPrimary && adjusted.Version > best.Version:
+			best, bestPrimary = adjusted, primary
+		}
+	}
+	return best
+}
+
+// parseModifiable parses the vector along with a function computing its (environmental) score. Note that the score
+// functions are closures: method values would be bound to a copy of the vectors that have value receivers.
+func parseModifiable(vector string) (modifiable, func() float64, error) {
+	switch {
+	case strings.HasPrefix(vector, "CVSS:3.0"):
+		v, err := gocvss30.ParseVector(vector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse CVSS v3 vector: %w", err)
+		}
+		return v, func() float64 { return v.EnvironmentalScore() }, nil
+	case strings.HasPrefix(vector, "CVSS:3.1"):
+		v, err := gocvss31.ParseVector(vector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse CVSS v3.1 vector: %w", err)
+		}
+		return v, func() float64 { return v.EnvironmentalScore() }, nil
+	case strings.HasPrefix(vector, "CVSS:4.0"):
+		v, err := gocvss40.ParseVector(vector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse CVSS v4.0 vector: %w", err)
+		}
+		return v, v.Score, nil
+	default:
+		v, err := gocvss20.ParseVector(vector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse CVSS v2 vector: %w", err)
+		}
+		return v, func() float64 { return v.EnvironmentalScore() }, nil
+	}
+}
+
+func vectorVersion(vector string) string {
+	if strings.HasPrefix(vector, "CVSS:") {
+		version, _, _ := strings.Cut(strings.TrimPrefix(vector, "CVSS:"), "/")
+		return version
+	}
+	return "2.0"
+}
+
+// severityFromScore rates a score per the CVSS qualitative severity rating scale (where a score of zero rates "none").
+func severityFromScore(score float64) vulnerability.Severity {
+	switch {
+	case score >= 9.0:
+		return vulnerability.CriticalSeverity
+	case score <= 0:
+		return vulnerability.NegligibleSeverity
+	}
+	return SeverityFromBaseScore(score)
+}
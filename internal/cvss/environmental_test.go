@@ -0,0 +1,127 @@
+package cvss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/vulnerability"
+)
+
+func TestParseModifiers(t *testing.T) {
+	tests := []struct {
+		modifiers string
+		want      string
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{modifiers: "", want: ""},
+		{modifiers: "CR:H/IR:H/AR:H/E:P", want: "CR:H/IR:H/AR:H/E:P"},
+		{modifiers: " /MAV:L/CDP:N/ ", want: "MAV:L/CDP:N"},
+		{modifiers: "AV:L", wantErr: require.Error},
+		{modifiers: "CR", wantErr: require.Error},
+		{modifiers: "FOO:H", wantErr: require.Error},
+		{modifiers: "CVSS:3.1/CR:H", wantErr: require.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modifiers, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			m, err := ParseModifiers(tt.modifiers)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, m.String())
+			assert.Equal(t, tt.want == "", m.IsEmpty())
+		})
+	}
+}
+
+func TestModifiers_Adjust(t *testing.T) {
+	modifiers, err := ParseModifiers("CR:L/IR:L/AR:L/MAV:L/E:U/CDP:N")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		vector string
+		want   *Adjustment
+	}{
+		{
+			name:   "CVSS 2.0",
+			vector: "AV:N/AC:L/Au:N/C:P/I:P/A:P",
+			want: &Adjustment{
+				Version:   "2.0",
+				Vector:    "AV:N/AC:L/Au:N/C:P/I:P/A:P/E:U/RL:ND/RC:ND/CDP:N/TD:ND/CR:L/IR:L/AR:L",
+				BaseScore: 7.5,
+				Score:     4.8,
+				Severity:  vulnerability.MediumSeverity,
+			},
+		},
+		{
+			name:   "CVSS 3.1",
+			vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			want: &Adjustment{
+				Version:   "3.1",
+				Vector:    "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:U/CR:L/IR:L/AR:L/MAV:L",
+				BaseScore: 9.8,
+				Score:     6.1,
+				Severity:  vulnerability.MediumSeverity,
+			},
+		},
+		{
+			name:   "CVSS 4.0",
+			vector: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N",
+			want: &Adjustment{
+				Version:   "4.0",
+				Vector:    "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N/E:U/CR:L/IR:L/AR:L/MAV:L",
+				BaseScore: 9.3,
+				Score:     4.3,
+				Severity:  vulnerability.MediumSeverity,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := modifiers.Adjust(tt.vector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err = modifiers.Adjust("not a vector")
+	require.Error(t, err)
+}
+
+func TestModifiers_Adjust_raisesScore(t *testing.T) {
+	modifiers, err := ParseModifiers("CR:H/IR:H/AR:H")
+	require.NoError(t, err)
+
+	got, err := modifiers.Adjust("CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:L/A:N")
+	require.NoError(t, err)
+	assert.Equal(t, 6.5, got.BaseScore)
+	assert.Greater(t, got.Score, got.BaseScore)
+}
+
+func TestModifiers_AdjustScores(t *testing.T) {
+	modifiers, err := ParseModifiers("E:U")
+	require.NoError(t, err)
+
+	scores := []vulnerability.Cvss{
+		{Type: "Secondary", Version: "4.0", Vector: "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N"},
+		{Type: "Primary", Version: "2.0", Vector: "AV:N/AC:L/Au:N/C:P/I:P/A:P"},
+		{Type: "Primary", Version: "3.1", Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		{Type: "Primary", Version: "3.1", Vector: "invalid"},
+	}
+
+	// primary scores are preferred, then the newest version
+	got := modifiers.AdjustScores(scores)
+	require.NotNil(t, got)
+	assert.Equal(t, "3.1", got.Version)
+
+	assert.Nil(t, Modifiers{}.AdjustScores(scores))
+	assert.Nil(t, modifiers.AdjustScores(nil))
+}
@@ -728,6 +728,14 @@ func (s *SingleFindingAssertion) HasFix(state vulnerability.FixState, versions .
 	return s
 }
 
+// HasFixEntitlement asserts the entitlement required to install the fix of the match's vulnerability (empty when the
+// fix is generally available).
+func (s *SingleFindingAssertion) HasFixEntitlement(entitlement string) *SingleFindingAssertion {
+	s.t.Helper()
+	assert.Equal(s.t, entitlement, s.match.Vulnerability.Fix.Entitlement, "unexpected fix entitlement")
+	return s
+}
+
 // HasAdvisories asserts the match's vulnerability has exactly the given
 // advisory IDs (order doesn't matter, but the count must match).
 func (s *SingleFindingAssertion) HasAdvisories(ids ...string) *SingleFindingAssertion {
@@ -1138,6 +1146,18 @@ func (d *DistroDetailAssertion) HasMatchType(matchType match.Type) *DistroDetail
 	return d
 }
 
+// HasEpochAssumption asserts that the detail explains the epoch assumed for the package version, with an explanation
+// containing the given text (or that there is no explanation, when the given text is empty).
+func (d *DistroDetailAssertion) HasEpochAssumption(contains string) *DistroDetailAssertion {
+	d.t.Helper()
+	if contains == "" {
+		assert.Empty(d.t, d.found.EpochAssumption, "unexpected epoch assumption in Found")
+		return d
+	}
+	assert.Contains(d.t, d.found.EpochAssumption, contains, "unexpected epoch assumption in Found")
+	return d
+}
+
 // CPEDetailAssertion provides assertions for CPE-based matches.
 // SearchedBy is CPEParameters, Found is CPEResult.
 type CPEDetailAssertion struct {
@@ -0,0 +1,240 @@
+// Package dsse signs and verifies documents using Dead Simple Signing Envelopes
+// (https://github.com/secure-systems-lab/dsse) with PEM-encoded ECDSA, Ed25519 or RSA keys.
+package dsse
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Envelope is a DSSE envelope holding a payload along with its signatures.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a single signature of a DSSE envelope.
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Signer produces DSSE envelopes with a private key.
+type Signer struct {
+	key   crypto.Signer
+	keyID string
+}
+
+// Verifier validates DSSE envelopes with a public key.
+type Verifier struct {
+	key   crypto.PublicKey
+	keyID string
+}
+
+// LoadSigner reads a PEM-encoded (PKCS#8, SEC 1 or PKCS#1) private key from the given path.
+func LoadSigner(path string) (*Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key %q: %w", path, err)
+	}
+	return NewSigner(key)
+}
+
+// NewSigner creates a signer for the given ECDSA, Ed25519 or RSA private key.
+func NewSigner(key crypto.Signer) (*Signer, error) {
+	keyID, err := keyIDOf(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key, keyID: keyID}, nil
+}
+
+// LoadVerifier reads a PEM-encoded (PKIX) public key from the given path.
+func LoadVerifier(path string) (*Verifier, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unable to parse public key %q: unsupported PEM block type %q", path, block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse public key %q: %w", path, err)
+	}
+	return NewVerifier(key)
+}
+
+// NewVerifier creates a verifier for the given ECDSA, Ed25519 or RSA public key.
+func NewVerifier(key crypto.PublicKey) (*Verifier, error) {
+	keyID, err := keyIDOf(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{key: key, keyID: keyID}, nil
+}
+
+// KeyID returns the identifier of the public key, the hex-encoded SHA256 digest of its PKIX encoding.
+func (v *Verifier) KeyID() string {
+	return v.keyID
+}
+
+// Sign returns an envelope holding the given payload signed with the private key.
+func (s *Signer) Sign(payloadType string, payload []byte) (*Envelope, error) {
+	message := pae(payloadType, payload)
+
+	var sig []byte
+	var err error
+	switch s.key.(type) {
+	case ed25519.PrivateKey:
+		sig, err = s.key.Sign(rand.Reader, message, crypto.Hash(0))
+	default:
+		digest := sha256.Sum256(message)
+		sig, err = s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign payload: %w", err)
+	}
+
+	return &Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{
+			{KeyID: s.keyID, Sig: base64.StdEncoding.EncodeToString(sig)},
+		},
+	}, nil
+}
+
+// Verify checks that the envelope has a valid signature made by the public key, returning the signed payload.
+func (v *Verifier) Verify(env Envelope) ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode envelope payload: %w", err)
+	}
+	message := pae(env.PayloadType, payload)
+
+	for _, s := range env.Signatures {
+		if s.KeyID != "" && s.KeyID != v.keyID {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if v.verify(message, sig) {
+			return payload, nil
+		}
+	}
+	return nil, fmt.Errorf("no valid signature found for key %s", v.keyID)
+}
+
+func (v *Verifier) verify(message, sig []byte) bool {
+	digest := sha256.Sum256(message)
+	switch key := v.key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	default:
+		return false
+	}
+}
+
+// WriteEnvelope writes the envelope as JSON.
+func WriteEnvelope(w io.Writer, env *Envelope) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+	return enc.Encode(env)
+}
+
+// ReadEnvelope reads a JSON envelope from the given path.
+func ReadEnvelope(path string) (*Envelope, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read signature: %w", err)
+	}
+	var env Envelope
+	if err := json.Unmarshal(by, &env); err != nil {
+		return nil, fmt.Errorf("unable to parse signature %q (expected a DSSE envelope): %w", path, err)
+	}
+	return &env, nil
+}
+
+// pae returns the DSSE pre-authentication encoding of the payload, which is the message that is actually signed.
+func pae(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// keyIDOf returns the hex-encoded SHA256 digest of the PKIX encoding of the public key.
+func keyIDOf(key crypto.PublicKey) (string, error) {
+	switch key.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey, *rsa.PublicKey:
+	default:
+		return "", fmt.Errorf("unsupported key type %T (must be ECDSA, Ed25519 or RSA)", key)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode public key: %w", err)
+	}
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	by, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key: %w", err)
+	}
+	block, _ := pem.Decode(by)
+	if block == nil {
+		return nil, fmt.Errorf("unable to read key %q: no PEM data found", path)
+	}
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q (encrypted keys are not supported)", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
@@ -0,0 +1,174 @@
+package dsse
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKeys(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return map[string]crypto.Signer{
+		"ecdsa":   ecKey,
+		"ed25519": edKey,
+		"rsa":     rsaKey,
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	payload := []byte(`{"matches":[]}`)
+
+	for name, key := range generateKeys(t) {
+		t.Run(name, func(t *testing.T) {
+			signer, err := NewSigner(key)
+			require.NoError(t, err)
+			verifier, err := NewVerifier(key.Public())
+			require.NoError(t, err)
+
+			env, err := signer.Sign("application/json", payload)
+			require.NoError(t, err)
+			assert.Equal(t, "application/json", env.PayloadType)
+			require.Len(t, env.Signatures, 1)
+			assert.Equal(t, verifier.keyID, env.Signatures[0].KeyID)
+
+			got, err := verifier.Verify(*env)
+			require.NoError(t, err)
+			assert.Equal(t, payload, got)
+
+			// a modified payload does not verify
+			tampered := *env
+			tampered.Payload = base64.StdEncoding.EncodeToString([]byte(`{"matches":null}`))
+			_, err = verifier.Verify(tampered)
+			require.Error(t, err)
+
+			// the payload type is covered by the signature
+			tampered = *env
+			tampered.PayloadType = "text/plain"
+			_, err = verifier.Verify(tampered)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestVerify_wrongKey(t *testing.T) {
+	keys := generateKeys(t)
+	signer, err := NewSigner(keys["ecdsa"])
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	verifier, err := NewVerifier(other.Public())
+	require.NoError(t, err)
+
+	env, err := signer.Sign("application/json", []byte("{}"))
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(*env)
+	require.ErrorContains(t, err, "no valid signature found")
+}
+
+func Test_pae(t *testing.T) {
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", string(pae("http://example.com/HelloWorld", []byte("hello world"))))
+}
+
+func TestLoadSignerAndVerifier(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		block   *pem.Block
+		public  crypto.PublicKey
+		wantErr string
+	}{
+		{
+			name:   "pkcs8",
+			block:  &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8},
+			public: ecKey.Public(),
+		},
+		{
+			name:   "sec1",
+			block:  &pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1},
+			public: ecKey.Public(),
+		},
+		{
+			name:   "pkcs1",
+			block:  &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+			public: rsaKey.Public(),
+		},
+		{
+			name:    "encrypted",
+			block:   &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("secret")},
+			wantErr: `unsupported PEM block type "ENCRYPTED PRIVATE KEY"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			keyPath := filepath.Join(dir, "key.pem")
+			require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(tt.block), 0o600))
+
+			signer, err := LoadSigner(keyPath)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			der, err := x509.MarshalPKIXPublicKey(tt.public)
+			require.NoError(t, err)
+			pubPath := filepath.Join(dir, "key.pub")
+			require.NoError(t, os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+			verifier, err := LoadVerifier(pubPath)
+			require.NoError(t, err)
+
+			env, err := signer.Sign("application/json", []byte("{}"))
+			require.NoError(t, err)
+			_, err = verifier.Verify(*env)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReadEnvelope(t *testing.T) {
+	keys := generateKeys(t)
+	signer, err := NewSigner(keys["ed25519"])
+	require.NoError(t, err)
+	env, err := signer.Sign("application/json", []byte("{}"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "report.json.dsse.json")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, WriteEnvelope(f, env))
+	require.NoError(t, f.Close())
+
+	got, err := ReadEnvelope(path)
+	require.NoError(t, err)
+	assert.Equal(t, env, got)
+}
@@ -13,6 +13,9 @@ const (
 	CycloneDXXML    Format = "cyclonedx-xml"
 	SarifFormat     Format = "sarif"
 	TemplateFormat  Format = "template"
+	SPDX3JSON       Format = "spdx3-json"
+	BadgeFormat     Format = "badge"
+	BadgeJSON       Format = "badge-json"
 
 	// DEPRECATED <-- TODO: remove in v1.0
 	EmbeddedVEXJSON Format = "embedded-cyclonedx-vex-json"
@@ -45,6 +48,12 @@ func Parse(userInput string) Format {
 		return CycloneDXJSON
 	case strings.ToLower(CycloneDXXML.String()):
 		return CycloneDXXML
+	case strings.ToLower(SPDX3JSON.String()):
+		return SPDX3JSON
+	case strings.ToLower(BadgeFormat.String()):
+		return BadgeFormat
+	case strings.ToLower(BadgeJSON.String()):
+		return BadgeJSON
 	case strings.ToLower(EmbeddedVEXJSON.String()):
 		return CycloneDXJSON
 	case strings.ToLower(EmbeddedVEXXML.String()):
@@ -62,6 +71,9 @@ var AvailableFormats = []Format{
 	CycloneDXJSON,
 	SarifFormat,
 	TemplateFormat,
+	SPDX3JSON,
+	BadgeFormat,
+	BadgeJSON,
 }
 
 // DeprecatedFormats TODO: remove in v1.0
@@ -23,6 +23,14 @@ func TestParse(t *testing.T) {
 			"jSOn",
 			JSONFormat,
 		},
+		{
+			"badge",
+			BadgeFormat,
+		},
+		{
+			"badge-json",
+			BadgeJSON,
+		},
 		{
 			"booboodepoopoo",
 			UnknownFormat,
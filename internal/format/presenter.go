@@ -3,12 +3,15 @@ package format
 import (
 	"github.com/wagoodman/go-presenter"
 
+	"github.com/anchore/grype/grype/presenter/badge"
 	"github.com/anchore/grype/grype/presenter/cyclonedx"
 	"github.com/anchore/grype/grype/presenter/json"
 	"github.com/anchore/grype/grype/presenter/models"
 	"github.com/anchore/grype/grype/presenter/sarif"
+	"github.com/anchore/grype/grype/presenter/spdx"
 	"github.com/anchore/grype/grype/presenter/table"
 	"github.com/anchore/grype/grype/presenter/template"
+	"github.com/anchore/grype/internal/dsse"
 	"github.com/anchore/grype/internal/log"
 )
 
@@ -16,6 +19,8 @@ type PresentationConfig struct {
 	TemplateFilePath string
 	ShowSuppressed   bool
 	Pretty           bool
+	// Signer, when set, signs every report written to a file, writing a DSSE envelope alongside it (see SignaturePath)
+	Signer *dsse.Signer
 }
 
 // GetPresenter retrieves a Presenter that matches a CLI option
@@ -37,6 +42,12 @@ func GetPresenter(format Format, c PresentationConfig, pb models.PresenterConfig
 		return cyclonedx.NewXMLPresenter(pb)
 	case SarifFormat:
 		return sarif.NewPresenter(pb)
+	case SPDX3JSON:
+		return spdx.NewJSONPresenter(pb)
+	case BadgeFormat:
+		return badge.NewSVGPresenter(pb)
+	case BadgeJSON:
+		return badge.NewJSONPresenter(pb)
 	case TemplateFormat:
 		return template.NewPresenter(pb, c.TemplateFilePath)
 	// DEPRECATED TODO: remove in v1.0
@@ -13,9 +13,23 @@ import (
 	"github.com/anchore/go-homedir"
 	"github.com/anchore/grype/grype/presenter/models"
 	"github.com/anchore/grype/internal/bus"
+	"github.com/anchore/grype/internal/dsse"
 	"github.com/anchore/grype/internal/log"
 )
 
+// signatureSuffix is appended to the path of a signed report to name its DSSE envelope.
+const signatureSuffix = ".dsse.json"
+
+// SignaturePath returns the path of the DSSE envelope written for a signed report at the given path.
+func SignaturePath(reportPath string) string {
+	return reportPath + signatureSuffix
+}
+
+// PayloadType returns the DSSE payload type of a signed report in the given format.
+func PayloadType(f Format) string {
+	return "application/vnd.grype.report+" + f.String()
+}
+
 type ScanResultWriter interface {
 	Write(result models.PresenterConfig) error
 }
@@ -132,6 +146,9 @@ func newMultiWriter(options ...scanResultWriterDescription) (_ *scanResultMultiW
 	for _, option := range options {
 		switch len(option.Path) {
 		case 0:
+			if option.Cfg.Signer != nil {
+				return nil, fmt.Errorf("unable to sign %s output written to stdout: signed reports must be written to a file (e.g. --file or -o %s=<file>)", option.Format, option.Format)
+			}
 			out.writers = append(out.writers, &scanResultPublisher{
 				format: option.Format,
 				cfg:    option.Cfg,
@@ -157,6 +174,7 @@ func newMultiWriter(options ...scanResultWriterDescription) (_ *scanResultMultiW
 			out.writers = append(out.writers, &scanResultStreamWriter{
 				format: option.Format,
 				out:    fileOut,
+				path:   option.Path,
 				cfg:    option.Cfg,
 			})
 		}
@@ -181,14 +199,45 @@ type scanResultStreamWriter struct {
 	format Format
 	cfg    PresentationConfig
 	out    io.Writer
+	// path is the file being written to, used to name the signature of signed reports
+	path string
 }
 
 // Write the provided result to the data stream
 func (w *scanResultStreamWriter) Write(s models.PresenterConfig) error {
 	pres := GetPresenter(w.format, w.cfg, s)
-	if err := pres.Present(w.out); err != nil {
+	if w.cfg.Signer == nil {
+		if err := pres.Present(w.out); err != nil {
+			return fmt.Errorf("unable to encode result: %w", err)
+		}
+		return nil
+	}
+
+	// the report must be captured to be signed
+	buf := &bytes.Buffer{}
+	if err := pres.Present(buf); err != nil {
 		return fmt.Errorf("unable to encode result: %w", err)
 	}
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("unable to write result: %w", err)
+	}
+	return w.sign(buf.Bytes())
+}
+
+func (w *scanResultStreamWriter) sign(report []byte) error {
+	env, err := w.cfg.Signer.Sign(PayloadType(w.format), report)
+	if err != nil {
+		return err
+	}
+	sigPath := SignaturePath(w.path)
+	f, err := os.Create(sigPath)
+	if err != nil {
+		return fmt.Errorf("unable to create signature file: %w", err)
+	}
+	defer log.CloseAndLogError(f, sigPath)
+	if err := dsse.WriteEnvelope(f, env); err != nil {
+		return fmt.Errorf("unable to write signature: %w", err)
+	}
 	return nil
 }
 
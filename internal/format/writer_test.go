@@ -1,12 +1,20 @@
 package format
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/docker/docker/pkg/homedir"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/internal/dsse"
 )
 
 func Test_MakeScanResultWriter(t *testing.T) {
@@ -216,3 +224,36 @@ func Test_newSBOMWriterDescription(t *testing.T) {
 		})
 	}
 }
+
+func Test_signedScanResultWriter(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := dsse.NewSigner(key)
+	require.NoError(t, err)
+	verifier, err := dsse.NewVerifier(key.Public())
+	require.NoError(t, err)
+
+	cfg := PresentationConfig{Signer: signer}
+
+	t.Run("signs reports written to files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "report.json")
+		writer, err := MakeScanResultWriter([]string{"json"}, path, cfg)
+		require.NoError(t, err)
+		require.NoError(t, writer.Write(models.PresenterConfig{}))
+
+		env, err := dsse.ReadEnvelope(SignaturePath(path))
+		require.NoError(t, err)
+		assert.Equal(t, PayloadType(JSONFormat), env.PayloadType)
+
+		payload, err := verifier.Verify(*env)
+		require.NoError(t, err)
+		report, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, report, payload)
+	})
+
+	t.Run("cannot sign stdout", func(t *testing.T) {
+		_, err := MakeScanResultWriter([]string{"json"}, "", cfg)
+		require.ErrorContains(t, err, "signed reports must be written to a file")
+	})
+}
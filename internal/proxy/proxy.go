@@ -0,0 +1,83 @@
+// Package proxy selects the proxy of outbound requests for destinations which may need a different egress path than
+// the proxy configured by the environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Direct is the proxy URL of a rule connecting directly, ignoring any proxy configured by the environment.
+const Direct = "direct"
+
+// Rule describes the proxy used for the requests of a destination.
+type Rule struct {
+	// URL of the proxy (http, https or socks5), or Direct to bypass proxies. When empty the proxy configured by the
+	// environment is used.
+	URL string
+
+	// NoProxy are the hosts connected to directly (in the NO_PROXY format), in addition to those of NO_PROXY.
+	NoProxy string
+}
+
+// IsZero returns true when the rule uses the proxy configured by the environment as-is.
+func (r Rule) IsZero() bool {
+	return r == Rule{}
+}
+
+// Validate returns an error when the proxy URL is not a supported proxy URL.
+func (r Rule) Validate() error {
+	if r.URL == "" || r.URL == Direct {
+		return nil
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy URL %q: must be an http, https or socks5 URL (or %q)", r.URL, Direct)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: missing host", r.URL)
+	}
+	return nil
+}
+
+// Func returns the proxy function of the rule (see http.Transport.Proxy), honoring NO_PROXY and the hosts of the rule
+// to connect to directly.
+func (r Rule) Func() func(*http.Request) (*url.URL, error) {
+	if r.URL == Direct {
+		return nil
+	}
+
+	cfg := *httpproxy.FromEnvironment()
+	if r.URL != "" {
+		cfg.HTTPProxy = r.URL
+		cfg.HTTPSProxy = r.URL
+	}
+	if r.NoProxy != "" {
+		cfg.NoProxy = strings.Trim(cfg.NoProxy+","+r.NoProxy, ",")
+	}
+
+	proxyFunc := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// Transport returns a copy of the given transport using the proxy of the rule (or the given transport as-is when the
+// rule is empty).
+func (r Rule) Transport(base *http.Transport) *http.Transport {
+	if r.IsZero() {
+		return base
+	}
+	t := base.Clone()
+	t.Proxy = r.Func()
+	return t
+}
@@ -0,0 +1,118 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRule_Func(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		rule    Rule
+		url     string
+		wantURL string
+	}{
+		{
+			name:    "environment proxy",
+			env:     map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			url:     "https://grype.anchore.io/databases",
+			wantURL: "http://env-proxy:3128",
+		},
+		{
+			name:    "rule proxy takes precedence over the environment",
+			env:     map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			rule:    Rule{URL: "http://db-proxy:8080"},
+			url:     "https://grype.anchore.io/databases",
+			wantURL: "http://db-proxy:8080",
+		},
+		{
+			name:    "rule proxy applies to http requests",
+			rule:    Rule{URL: "http://db-proxy:8080"},
+			url:     "http://mirror.example.com/databases",
+			wantURL: "http://db-proxy:8080",
+		},
+		{
+			name: "direct ignores the environment",
+			env:  map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			rule: Rule{URL: Direct},
+			url:  "https://grype.anchore.io/databases",
+		},
+		{
+			name: "NO_PROXY is honored by the rule proxy",
+			env:  map[string]string{"NO_PROXY": ".internal.example.com"},
+			rule: Rule{URL: "http://db-proxy:8080"},
+			url:  "https://mirror.internal.example.com/databases",
+		},
+		{
+			name: "hosts of the rule are connected to directly",
+			env:  map[string]string{"HTTPS_PROXY": "http://env-proxy:3128", "NO_PROXY": "other.example.com"},
+			rule: Rule{NoProxy: "registry.example.com"},
+			url:  "https://registry.example.com/v2/",
+		},
+		{
+			name:    "other hosts still use the proxy",
+			env:     map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			rule:    Rule{NoProxy: "registry.example.com"},
+			url:     "https://index.docker.io/v2/",
+			wantURL: "http://env-proxy:3128",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy", "REQUEST_METHOD"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			proxyFunc := tt.rule.Func()
+			if tt.rule.URL == Direct {
+				assert.Nil(t, proxyFunc)
+				return
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			got, err := proxyFunc(req)
+			require.NoError(t, err)
+			if tt.wantURL == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.wantURL, got.String())
+		})
+	}
+}
+
+func TestRule_Validate(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr require.ErrorAssertionFunc
+	}{
+		{url: "", wantErr: require.NoError},
+		{url: Direct, wantErr: require.NoError},
+		{url: "http://proxy:3128", wantErr: require.NoError},
+		{url: "socks5://proxy:1080", wantErr: require.NoError},
+		{url: "ftp://proxy:21", wantErr: require.Error},
+		{url: "proxy:3128", wantErr: require.Error},
+		{url: "http://", wantErr: require.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			tt.wantErr(t, Rule{URL: tt.url}.Validate())
+		})
+	}
+}
+
+func TestRule_Transport(t *testing.T) {
+	base := &http.Transport{}
+	assert.Same(t, base, Rule{}.Transport(base))
+
+	got := Rule{URL: "http://proxy:3128"}.Transport(base)
+	assert.NotSame(t, base, got)
+	assert.NotNil(t, got.Proxy)
+	assert.Nil(t, base.Proxy)
+}
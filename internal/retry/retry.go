@@ -0,0 +1,295 @@
+// Package retry retries network operations which failed with a transient error (e.g. a connection reset, a timeout,
+// or a 429 or 5xx response), waiting with exponential backoff between attempts.
+package retry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+// Config describes how failed operations are retried.
+type Config struct {
+	// MaxRetries is the number of times a failed operation is retried (retries are disabled when zero).
+	MaxRetries int
+
+	// Backoff is the wait before the first retry, doubled on every following retry.
+	Backoff time.Duration
+
+	// MaxBackoff caps the wait between retries, including waits requested by the server (uncapped when zero).
+	MaxBackoff time.Duration
+
+	// Timeout bounds the total time of an operation across all attempts: no retry is started once it would be
+	// exceeded (unbounded when zero).
+	Timeout time.Duration
+}
+
+// DefaultConfig returns the retry configuration used for network operations unless configured otherwise.
+func DefaultConfig() Config {
+	return Config{
+		MaxRetries: 3,
+		Backoff:    time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// StatusError is the error of an HTTP response with an unsuccessful status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+
+	// RetryAfter is the wait requested by the server with the Retry-After header (zero when not requested).
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected response status: %s", e.Status)
+}
+
+// NewStatusError returns the error of the given unsuccessful response.
+func NewStatusError(resp *http.Response) *StatusError {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RetryAfter: retryAfter(resp),
+	}
+}
+
+// RetriableStatus returns true when a request failing with the given response status may succeed when retried.
+func RetriableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// transientMessages identify transient network errors which have been flattened into a message by a dependency (so
+// cannot be classified by their type).
+var transientMessages = []string{
+	"connection reset by peer",
+	"connection refused",
+	"broken pipe",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"Client.Timeout exceeded",
+	"server closed idle connection",
+	"429 Too Many Requests",
+	"500 Internal Server Error",
+	"502 Bad Gateway",
+	"503 Service Unavailable",
+	"504 Gateway Timeout",
+}
+
+// Retriable returns true when an operation failing with the given error may succeed when retried. Cancellation, bad
+// certificates, unknown hosts and unsuccessful responses other than 408, 425, 429 and 5xx are not retried.
+func Retriable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return RetriableStatus(statusErr.StatusCode)
+	}
+
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &certErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	for _, transient := range []error{syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ECONNABORTED, syscall.EPIPE, io.ErrUnexpectedEOF} {
+		if errors.Is(err, transient) {
+			return true
+		}
+	}
+
+	msg := err.Error()
+	for _, m := range transientMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Do runs the operation until it succeeds, fails with an error which is not retriable, or the retries are exhausted.
+func Do(ctx context.Context, cfg Config, operation string, fn func() error) error {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !Retriable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		wait, ok := cfg.wait(attempt, start, requestedWait(err))
+		if !ok {
+			if attempt > 1 {
+				return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+			}
+			return err
+		}
+
+		logRetry(operation, attempt, cfg, wait, err)
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Transport returns a round tripper retrying idempotent requests (which can be replayed) on retriable errors and
+// response statuses, or the given round tripper as-is when retries are disabled.
+func Transport(base http.RoundTripper, cfg Config) http.RoundTripper {
+	if cfg.MaxRetries <= 0 {
+		return base
+	}
+	return transport{base: base, cfg: cfg}
+}
+
+type transport struct {
+	base http.RoundTripper
+	cfg  Config
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !replayable(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	start := time.Now()
+	operation := fmt.Sprintf("%s %s", req.Method, req.URL.Redacted())
+	for attempt := 1; ; attempt++ {
+		r := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		var failure error
+		switch {
+		case err != nil:
+			if !Retriable(err) {
+				return nil, err
+			}
+			failure = err
+		case RetriableStatus(resp.StatusCode):
+			failure = NewStatusError(resp)
+		default:
+			return resp, nil
+		}
+
+		wait, ok := t.cfg.wait(attempt, start, requestedWait(failure))
+		if !ok || ctx.Err() != nil {
+			// the last response is returned as-is, leaving the caller to handle the status
+			return resp, err
+		}
+		if resp != nil {
+			// drain (a bounded amount of) the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			_ = resp.Body.Close()
+		}
+
+		logRetry(operation, attempt, t.cfg, wait, failure)
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func replayable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// wait returns how long to wait before retrying after the given (failed) attempt, or false when no retry is left.
+func (cfg Config) wait(attempt int, start time.Time, requested time.Duration) (time.Duration, bool) {
+	if attempt > cfg.MaxRetries {
+		return 0, false
+	}
+
+	wait := cfg.Backoff
+	for i := 1; i < attempt && (cfg.MaxBackoff <= 0 || wait < cfg.MaxBackoff); i++ {
+		wait *= 2
+	}
+	wait = max(wait, requested)
+	if cfg.MaxBackoff > 0 {
+		wait = min(wait, cfg.MaxBackoff)
+	}
+
+	if cfg.Timeout > 0 && time.Since(start)+wait > cfg.Timeout {
+		return 0, false
+	}
+	return wait, true
+}
+
+func requestedWait(err error) time.Duration {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.RetryAfter
+	}
+	return 0
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+func logRetry(operation string, attempt int, cfg Config, wait time.Duration, err error) {
+	log.WithFields("operation", operation, "attempt", attempt, "max-retries", cfg.MaxRetries, "wait", wait, "error", err).
+		Debug("retrying after transient network error")
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
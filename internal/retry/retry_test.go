@@ -0,0 +1,193 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetriable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "canceled", err: fmt.Errorf("fetch: %w", context.Canceled), want: false},
+		{name: "connection reset", err: &url.Error{Op: "Get", URL: "https://example.com", Err: syscall.ECONNRESET}, want: true},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, want: true},
+		{name: "unexpected EOF", err: fmt.Errorf("read body: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "timeout", err: &url.Error{Op: "Get", URL: "https://example.com", Err: timeoutError{}}, want: true},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}, want: false},
+		{name: "temporary DNS failure", err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}, want: true},
+		{name: "service unavailable", err: &StatusError{StatusCode: http.StatusServiceUnavailable}, want: true},
+		{name: "too many requests", err: &StatusError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "not found", err: &StatusError{StatusCode: http.StatusNotFound}, want: false},
+		{name: "unauthorized", err: &StatusError{StatusCode: http.StatusUnauthorized}, want: false},
+		{name: "flattened connection reset", err: errors.New("failed to get image descriptor from registry: read tcp: connection reset by peer"), want: true},
+		{name: "flattened 503", err: errors.New("GET https://registry.example.com/v2/: unexpected status code 503 Service Unavailable"), want: true},
+		{name: "other error", err: errors.New("invalid reference format"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Retriable(tt.err))
+		})
+	}
+}
+
+func TestConfig_wait(t *testing.T) {
+	cfg := Config{MaxRetries: 5, Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	now := time.Now()
+
+	var waits []time.Duration
+	for attempt := 1; ; attempt++ {
+		wait, ok := cfg.wait(attempt, now, 0)
+		if !ok {
+			break
+		}
+		waits = append(waits, wait)
+	}
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, waits)
+
+	// the server may ask for a longer wait, which is still capped
+	wait, ok := cfg.wait(1, now, 3*time.Second)
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+	wait, ok = cfg.wait(1, now, time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, wait)
+
+	// no retry is started past the total timeout
+	cfg.Timeout = 10 * time.Second
+	_, ok = cfg.wait(1, now.Add(-9*time.Second), 0)
+	assert.False(t, ok)
+}
+
+func TestDo(t *testing.T) {
+	cfg := Config{MaxRetries: 2, Backoff: time.Millisecond}
+
+	t.Run("succeeds after transient errors", func(t *testing.T) {
+		calls := 0
+		err := Do(context.Background(), cfg, "test", func() error {
+			calls++
+			if calls < 3 {
+				return syscall.ECONNRESET
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up once retries are exhausted", func(t *testing.T) {
+		calls := 0
+		err := Do(context.Background(), cfg, "test", func() error {
+			calls++
+			return syscall.ECONNRESET
+		})
+		require.ErrorContains(t, err, "giving up after 3 attempts")
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		calls := 0
+		err := Do(context.Background(), cfg, "test", func() error {
+			calls++
+			return &StatusError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+		})
+		require.EqualError(t, err, "unexpected response status: 404 Not Found")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("does not retry when disabled", func(t *testing.T) {
+		calls := 0
+		err := Do(context.Background(), Config{}, "test", func() error {
+			calls++
+			return syscall.ECONNRESET
+		})
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestTransport(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/unavailable"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case n < 3:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport(http.DefaultTransport, Config{MaxRetries: 2, Backoff: time.Millisecond})}
+
+	t.Run("retries retriable statuses", func(t *testing.T) {
+		calls.Store(0)
+		resp, err := client.Get(server.URL + "/listing.json")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(body))
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("returns the last response once retries are exhausted", func(t *testing.T) {
+		calls.Store(0)
+		resp, err := client.Get(server.URL + "/unavailable")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("does not retry other statuses", func(t *testing.T) {
+		calls.Store(0)
+		resp, err := client.Get(server.URL + "/missing")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("does not retry requests which are not idempotent", func(t *testing.T) {
+		calls.Store(0)
+		resp, err := client.Post(server.URL+"/unavailable", "application/json", strings.NewReader("{}"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestTransport_Disabled(t *testing.T) {
+	assert.Same(t, http.DefaultTransport, Transport(http.DefaultTransport, Config{}))
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
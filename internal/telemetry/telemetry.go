@@ -0,0 +1,314 @@
+/*
+Package telemetry aggregates anonymous statistics about scans, to help platform teams plan capacity for grype.
+
+Telemetry is strictly opt-in. When enabled, every scan is added to a summary kept in a local JSON file:
+
+	{
+	  "schema":     {"version": "1.0.0"},
+	  "firstScan":  "2024-01-01T00:00:00Z",
+	  "lastScan":   "2024-01-02T00:00:00Z",
+	  "scans":      42,
+	  "sources":    {"image": 40, "directory": 2},
+	  "ecosystems": {"deb": 8400, "java-archive": 1200},
+	  "severities": {"Critical": 12, "High": 140},
+	  "versions":   {"0.80.0": 42},
+	  "durationSeconds": {"count": 42, "min": 3.1, "max": 61.8, "total": 512.4},
+	  "dbAgeHours":      {"count": 42, "min": 2.5, "max": 30.1, "total": 420.0}
+	}
+
+Only counts, ecosystems (package types), durations, and the age of the vulnerability DB are recorded: never the names
+of scanned sources, packages, vulnerabilities, paths, or any identifier of the host or user. The summary can optionally
+be sent to a configured endpoint after every scan, as a JSON POST request.
+*/
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	"github.com/anchore/grype/internal/log"
+)
+
+// SchemaVersion is the version of the summary schema. The major version is incremented on breaking changes.
+const SchemaVersion = "1.0.0"
+
+const defaultTimeout = 10 * time.Second
+
+const (
+	// lockTimeout is how long Record waits for other scans to release the lock of the summary
+	lockTimeout = 5 * time.Second
+	// staleLockAge is the age past which a lock is assumed to be left behind (recording takes milliseconds)
+	staleLockAge = 30 * time.Second
+	// lockRetryInterval is how often Record checks whether the lock of the summary was released
+	lockRetryInterval = 10 * time.Millisecond
+)
+
+// Scan holds the anonymous statistics of a single scan.
+type Scan struct {
+	Time       time.Time
+	Duration   time.Duration
+	SourceType string
+	Version    string
+	// Packages is the number of packages per ecosystem (package type)
+	Packages map[string]int
+	// Matches is the number of vulnerability matches per severity
+	Matches map[string]int
+	// DBBuilt is when the vulnerability DB used was built (zero when unknown)
+	DBBuilt time.Time
+}
+
+// NewScan returns the statistics of a scan that started at the given time and produced the given document.
+func NewScan(started time.Time, version string, doc models.Document, packages []pkg.Package, status *vulnerability.ProviderStatus) Scan {
+	now := time.Now()
+	scan := Scan{
+		Time:       now,
+		Duration:   now.Sub(started),
+		SourceType: "unknown",
+		Version:    version,
+		Packages:   make(map[string]int),
+		Matches:    make(map[string]int),
+	}
+	if doc.Source != nil && doc.Source.Type != "" {
+		scan.SourceType = doc.Source.Type
+	}
+	for _, p := range packages {
+		scan.Packages[string(p.Type)]++
+	}
+	for _, m := range doc.Matches {
+		scan.Matches[m.Vulnerability.Severity]++
+	}
+	if status != nil {
+		scan.DBBuilt = status.Built
+	}
+	return scan
+}
+
+// Schema describes the version of the summary schema.
+type Schema struct {
+	Version string `json:"version"`
+}
+
+// Stats aggregates a series of values (the mean is total / count).
+type Stats struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Total float64 `json:"total"`
+}
+
+func (s *Stats) add(v float64) {
+	if s.Count == 0 || v < s.Min {
+		s.Min = v
+	}
+	if s.Count == 0 || v > s.Max {
+		s.Max = v
+	}
+	s.Count++
+	s.Total += v
+}
+
+// Summary aggregates the statistics of all recorded scans.
+type Summary struct {
+	Schema     Schema         `json:"schema"`
+	FirstScan  time.Time      `json:"firstScan"`
+	LastScan   time.Time      `json:"lastScan"`
+	Scans      int            `json:"scans"`
+	Sources    map[string]int `json:"sources"`
+	Ecosystems map[string]int `json:"ecosystems"`
+	Severities map[string]int `json:"severities"`
+	Versions   map[string]int `json:"versions"`
+	Duration   Stats          `json:"durationSeconds"`
+	DBAge      Stats          `json:"dbAgeHours"`
+}
+
+func newSummary() Summary {
+	return Summary{
+		Schema:     Schema{Version: SchemaVersion},
+		Sources:    make(map[string]int),
+		Ecosystems: make(map[string]int),
+		Severities: make(map[string]int),
+		Versions:   make(map[string]int),
+	}
+}
+
+// Add aggregates the statistics of the given scan into the summary.
+func (s *Summary) Add(scan Scan) {
+	if s.Scans == 0 || scan.Time.Before(s.FirstScan) {
+		s.FirstScan = scan.Time
+	}
+	if scan.Time.After(s.LastScan) {
+		s.LastScan = scan.Time
+	}
+	s.Scans++
+	s.Sources[scan.SourceType]++
+	if scan.Version != "" {
+		s.Versions[scan.Version]++
+	}
+	for ecosystem, count := range scan.Packages {
+		s.Ecosystems[ecosystem] += count
+	}
+	for severity, count := range scan.Matches {
+		s.Severities[severity] += count
+	}
+	s.Duration.add(scan.Duration.Seconds())
+	if !scan.DBBuilt.IsZero() {
+		s.DBAge.add(scan.Time.Sub(scan.DBBuilt).Hours())
+	}
+}
+
+// Load reads the summary from the given file, returning an empty summary if the file does not exist.
+func Load(path string) (Summary, error) {
+	summary := newSummary()
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return summary, nil
+		}
+		return summary, fmt.Errorf("unable to read telemetry summary: %w", err)
+	}
+	if err := json.Unmarshal(contents, &summary); err != nil {
+		return newSummary(), fmt.Errorf("unable to parse telemetry summary %q: %w", path, err)
+	}
+	// tolerate summaries with sections missing (e.g. written by hand)
+	for _, m := range []*map[string]int{&summary.Sources, &summary.Ecosystems, &summary.Severities, &summary.Versions} {
+		if *m == nil {
+			*m = make(map[string]int)
+		}
+	}
+	return summary, nil
+}
+
+// Record adds the scan to the summary kept in the given file, returning the updated summary. Concurrent scans
+// recording to the same file are serialized with a lock file next to the summary, so that no scan is lost.
+func Record(path string, scan Scan) (Summary, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return newSummary(), fmt.Errorf("unable to create telemetry directory: %w", err)
+	}
+	unlock, err := lock(path)
+	if err != nil {
+		return newSummary(), err
+	}
+	defer unlock()
+
+	summary, err := Load(path)
+	if err != nil {
+		return summary, err
+	}
+	summary.Add(scan)
+
+	contents, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return summary, fmt.Errorf("unable to encode telemetry summary: %w", err)
+	}
+
+	// write to a temporary file first so that concurrent scans never observe (or leave behind) a partial summary
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return summary, fmt.Errorf("unable to write telemetry summary: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(contents); err != nil {
+		_ = tmp.Close()
+		return summary, fmt.Errorf("unable to write telemetry summary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return summary, fmt.Errorf("unable to write telemetry summary: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return summary, fmt.Errorf("unable to write telemetry summary: %w", err)
+	}
+
+	log.WithFields("path", path, "scans", summary.Scans).Debug("recorded scan telemetry")
+	return summary, nil
+}
+
+// lock acquires the lock file of the summary at the given path, waiting for other scans to release it. Locks older
+// than staleLockAge are assumed to be left behind by a scan that did not finish and are broken.
+func lock(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("unable to lock telemetry summary: %w", err)
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			log.WithFields("path", lockPath).Debug("removing stale telemetry lock")
+			_ = os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("unable to lock telemetry summary: timed out waiting for %q", lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// ValidateEndpoint checks that the given endpoint is an http or https URL.
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid telemetry endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid telemetry endpoint %q: must be an http or https URL", endpoint)
+	}
+	return nil
+}
+
+// Send POSTs the summary as JSON to the given endpoint.
+func Send(ctx context.Context, endpoint, userAgent string, timeout time.Duration, summary Summary) error {
+	if err := ValidateEndpoint(endpoint); err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("unable to encode telemetry summary: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send telemetry: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unable to send telemetry: unexpected response status %s", resp.Status)
+	}
+	log.WithFields("endpoint", endpoint).Debug("sent scan telemetry")
+	return nil
+}
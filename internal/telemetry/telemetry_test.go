@@ -0,0 +1,174 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/grype/grype/pkg"
+	"github.com/anchore/grype/grype/presenter/models"
+	"github.com/anchore/grype/grype/vulnerability"
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+func TestNewScan(t *testing.T) {
+	built := time.Now().Add(-48 * time.Hour)
+	doc := models.Document{
+		Matches: []models.Match{
+			{Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2021-44228", Severity: "Critical"}}},
+			{Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2021-45046", Severity: "Critical"}}},
+			{Vulnerability: models.Vulnerability{VulnerabilityMetadata: models.VulnerabilityMetadata{ID: "CVE-2023-0001", Severity: "Low"}}},
+		},
+	}
+	packages := []pkg.Package{
+		{Name: "log4j-core", Type: syftPkg.JavaPkg},
+		{Name: "libc6", Type: syftPkg.DebPkg},
+		{Name: "openssl", Type: syftPkg.DebPkg},
+	}
+
+	scan := NewScan(time.Now().Add(-time.Minute), "0.80.0", doc, packages, &vulnerability.ProviderStatus{Built: built})
+
+	assert.Equal(t, "unknown", scan.SourceType)
+	assert.Equal(t, "0.80.0", scan.Version)
+	assert.Equal(t, map[string]int{"java-archive": 1, "deb": 2}, scan.Packages)
+	assert.Equal(t, map[string]int{"Critical": 2, "Low": 1}, scan.Matches)
+	assert.Equal(t, built, scan.DBBuilt)
+	assert.GreaterOrEqual(t, scan.Duration, time.Minute)
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "telemetry.json")
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := Record(path, Scan{
+		Time:       first,
+		Duration:   10 * time.Second,
+		SourceType: "image",
+		Version:    "0.80.0",
+		Packages:   map[string]int{"deb": 100},
+		Matches:    map[string]int{"High": 3},
+		DBBuilt:    first.Add(-12 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	summary, err := Record(path, Scan{
+		Time:       first.Add(24 * time.Hour),
+		Duration:   30 * time.Second,
+		SourceType: "directory",
+		Version:    "0.80.0",
+		Packages:   map[string]int{"deb": 50, "npm": 200},
+	})
+	require.NoError(t, err)
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, summary, loaded)
+
+	assert.Equal(t, SchemaVersion, loaded.Schema.Version)
+	assert.Equal(t, 2, loaded.Scans)
+	assert.True(t, first.Equal(loaded.FirstScan))
+	assert.True(t, first.Add(24*time.Hour).Equal(loaded.LastScan))
+	assert.Equal(t, map[string]int{"image": 1, "directory": 1}, loaded.Sources)
+	assert.Equal(t, map[string]int{"deb": 150, "npm": 200}, loaded.Ecosystems)
+	assert.Equal(t, map[string]int{"High": 3}, loaded.Severities)
+	assert.Equal(t, map[string]int{"0.80.0": 2}, loaded.Versions)
+	assert.Equal(t, Stats{Count: 2, Min: 10, Max: 30, Total: 40}, loaded.Duration)
+	// the DB age is only known for the first scan
+	assert.Equal(t, Stats{Count: 1, Min: 12, Max: 12, Total: 12}, loaded.DBAge)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRecord_concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+
+	const scans = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, scans)
+	for range scans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Record(path, Scan{Time: time.Now(), SourceType: "image", Packages: map[string]int{"deb": 1}})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// no scan is lost to a concurrent read-modify-write of the summary
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, scans, loaded.Scans)
+	assert.Equal(t, map[string]int{"deb": scans}, loaded.Ecosystems)
+	assert.NoFileExists(t, path+".lock")
+}
+
+func TestRecord_staleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	lockPath := path + ".lock"
+	require.NoError(t, os.WriteFile(lockPath, nil, 0o600))
+	old := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(lockPath, old, old))
+
+	summary, err := Record(path, Scan{Time: time.Now(), SourceType: "image"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Scans)
+	assert.NoFileExists(t, lockPath)
+}
+
+func TestLoad(t *testing.T) {
+	summary, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Zero(t, summary.Scans)
+	assert.NotNil(t, summary.Sources)
+
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte("{"), 0o600))
+	_, err = Load(invalid)
+	require.Error(t, err)
+}
+
+func TestSend(t *testing.T) {
+	var got Summary
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		userAgent = r.Header.Get("User-Agent")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	summary := newSummary()
+	summary.Add(Scan{Time: time.Now(), SourceType: "image", Packages: map[string]int{"apk": 20}})
+
+	require.NoError(t, Send(context.Background(), server.URL, "grype 0.80.0", 0, summary))
+	assert.Equal(t, "grype 0.80.0", userAgent)
+	assert.Equal(t, 1, got.Scans)
+	assert.Equal(t, map[string]int{"apk": 20}, got.Ecosystems)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	require.Error(t, Send(context.Background(), failing.URL, "", 0, summary))
+
+	require.Error(t, Send(context.Background(), "ftp://example.com", "", 0, summary))
+}
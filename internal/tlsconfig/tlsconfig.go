@@ -0,0 +1,238 @@
+// Package tlsconfig describes how TLS connections are established (which CAs are trusted, whether certificates are
+// verified, and which client certificate is presented), globally and for specific hosts.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/anchore/grype/internal/log"
+)
+
+// Settings are the TLS settings for connecting to a host.
+type Settings struct {
+	// CACert is a PEM file (or a directory of *.crt, *.cert and *.pem files) with CA certificates trusted in addition
+	// to the system certificate pool.
+	CACert string
+
+	// InsecureSkipVerify disables verification of the certificate presented by the server.
+	InsecureSkipVerify bool
+
+	// ClientCert and ClientKey are the PEM files of the certificate (and its key) presented for mutual TLS.
+	ClientCert string
+	ClientKey  string
+}
+
+// Host are the TLS settings for a specific host, overriding the global settings.
+type Host struct {
+	// Host is the host name, optionally with a port (e.g. "registry.example.com:5000"). Without a port the settings
+	// apply to all ports of the host.
+	Host string
+
+	Settings
+}
+
+// Config are the global TLS settings along with overrides for specific hosts.
+type Config struct {
+	Settings
+
+	Hosts []Host
+}
+
+// IsZero returns true when the settings do not change how TLS connections are established.
+func (s Settings) IsZero() bool {
+	return s == Settings{}
+}
+
+// IsZero returns true when the configuration does not change how TLS connections are established.
+func (c Config) IsZero() bool {
+	return c.Settings.IsZero() && len(c.Hosts) == 0
+}
+
+// ForHost returns the settings for connecting to the given host (with an optional port): the settings of the most
+// specific matching host entry (by host and port, then host alone) layered over the global settings. Certificate
+// verification is skipped when disabled either globally or for the host.
+func (c Config) ForHost(host string) Settings {
+	s := c.Settings
+	h, ok := c.host(host)
+	if !ok {
+		return s
+	}
+	if h.CACert != "" {
+		s.CACert = h.CACert
+	}
+	if h.ClientCert != "" || h.ClientKey != "" {
+		s.ClientCert = h.ClientCert
+		s.ClientKey = h.ClientKey
+	}
+	s.InsecureSkipVerify = s.InsecureSkipVerify || h.InsecureSkipVerify
+	return s
+}
+
+func (c Config) host(host string) (Host, bool) {
+	host = strings.ToLower(host)
+	hostname := host
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		hostname = name
+	}
+
+	var fallback *Host
+	for i, h := range c.Hosts {
+		candidate := strings.ToLower(h.Host)
+		if candidate == host {
+			return h, true
+		}
+		if fallback == nil && candidate == hostname {
+			fallback = &c.Hosts[i]
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return Host{}, false
+}
+
+// ClientConfig returns the tls.Config for connecting with the given settings.
+func (s Settings) ClientConfig(fs afero.Fs) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: s.InsecureSkipVerify, //nolint:gosec // explicitly requested by the user
+	}
+
+	if s.CACert != "" {
+		rootCAs, err := loadCACerts(fs, s.CACert)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = rootCAs
+	}
+
+	if s.ClientCert != "" || s.ClientKey != "" {
+		if s.ClientCert == "" || s.ClientKey == "" {
+			return nil, fmt.Errorf("both a client certificate and key are required for TLS client authentication")
+		}
+		cert, err := loadClientCert(fs, s.ClientCert, s.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// Transport returns a round tripper applying the settings for the host of each request, based on the given transport
+// (which is returned as-is when the configuration is empty).
+func (c Config) Transport(fs afero.Fs, base *http.Transport) (http.RoundTripper, error) {
+	if c.IsZero() {
+		return base, nil
+	}
+
+	newTransport := func(s Settings) (*http.Transport, error) {
+		cfg, err := s.ClientConfig(fs)
+		if err != nil {
+			return nil, err
+		}
+		t := base.Clone()
+		t.TLSClientConfig = cfg
+		return t, nil
+	}
+
+	def, err := newTransport(c.Settings)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.Hosts) == 0 {
+		return def, nil
+	}
+
+	rt := hostTransport{
+		config:     c,
+		fallback:   def,
+		transports: make(map[string]*http.Transport),
+	}
+	for _, h := range c.Hosts {
+		if h.Host == "" {
+			return nil, fmt.Errorf("TLS settings for a host require the host name")
+		}
+		t, err := newTransport(c.ForHost(h.Host))
+		if err != nil {
+			return nil, fmt.Errorf("unable to configure TLS for host %q: %w", h.Host, err)
+		}
+		rt.transports[strings.ToLower(h.Host)] = t
+	}
+	return rt, nil
+}
+
+// hostTransport routes each request through the transport configured for its host.
+type hostTransport struct {
+	config     Config
+	fallback   *http.Transport
+	transports map[string]*http.Transport
+}
+
+func (t hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if h, ok := t.config.host(req.URL.Host); ok {
+		return t.transports[strings.ToLower(h.Host)].RoundTrip(req)
+	}
+	return t.fallback.RoundTrip(req)
+}
+
+func loadCACerts(fs afero.Fs, fileOrDir string) (*x509.CertPool, error) {
+	fi, err := fs.Stat(fileOrDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA certificates: %w", err)
+	}
+
+	files := []string{fileOrDir}
+	if fi.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.crt", "*.cert", "*.pem"} {
+			matches, err := afero.Glob(fs, filepath.Join(fileOrDir, pattern))
+			if err != nil {
+				return nil, fmt.Errorf("unable to find CA certificates in %q: %w", fileOrDir, err)
+			}
+			files = append(files, matches...)
+		}
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		log.WithFields("error", err).Warn("unable to load system cert pool")
+		rootCAs = x509.NewCertPool()
+	}
+	for _, f := range files {
+		log.Tracef("loading CA certificate from %q", f)
+		pemBytes, err := afero.ReadFile(fs, f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificate %q: %w", f, err)
+		}
+		if !rootCAs.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("no certificates found in CA certificate file %q", f)
+		}
+	}
+	return rootCAs, nil
+}
+
+func loadClientCert(fs afero.Fs, certFile, keyFile string) (tls.Certificate, error) {
+	certPEM, err := afero.ReadFile(fs, certFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to read client certificate: %w", err)
+	}
+	keyPEM, err := afero.ReadFile(fs, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to read client key: %w", err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("invalid client certificate %q: %w", certFile, err)
+	}
+	return cert, nil
+}
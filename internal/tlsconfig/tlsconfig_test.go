@@ -0,0 +1,203 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ForHost(t *testing.T) {
+	cfg := Config{
+		Settings: Settings{CACert: "ca.crt", ClientCert: "client.crt", ClientKey: "client.key"},
+		Hosts: []Host{
+			{Host: "registry.example.com", Settings: Settings{InsecureSkipVerify: true}},
+			{Host: "registry.example.com:5000", Settings: Settings{CACert: "registry-5000.crt"}},
+			{Host: "DB.example.com", Settings: Settings{ClientCert: "db.crt", ClientKey: "db.key"}},
+		},
+	}
+
+	tests := []struct {
+		host string
+		want Settings
+	}{
+		{
+			host: "other.example.com",
+			want: Settings{CACert: "ca.crt", ClientCert: "client.crt", ClientKey: "client.key"},
+		},
+		{
+			host: "registry.example.com",
+			want: Settings{CACert: "ca.crt", InsecureSkipVerify: true, ClientCert: "client.crt", ClientKey: "client.key"},
+		},
+		{
+			// the entry with the port is the most specific
+			host: "registry.example.com:5000",
+			want: Settings{CACert: "registry-5000.crt", ClientCert: "client.crt", ClientKey: "client.key"},
+		},
+		{
+			// entries without a port apply to all ports
+			host: "registry.example.com:8443",
+			want: Settings{CACert: "ca.crt", InsecureSkipVerify: true, ClientCert: "client.crt", ClientKey: "client.key"},
+		},
+		{
+			host: "db.example.com",
+			want: Settings{CACert: "ca.crt", ClientCert: "db.crt", ClientKey: "db.key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.ForHost(tt.host))
+		})
+	}
+}
+
+func TestSettings_ClientConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	certPEM, keyPEM := newTestCertificate(t)
+	require.NoError(t, afero.WriteFile(fs, "/certs/ca.pem", certPEM, 0600))
+	require.NoError(t, afero.WriteFile(fs, "/certs/client.key", keyPEM, 0600))
+	require.NoError(t, afero.WriteFile(fs, "/certs/readme.txt", []byte("not a cert"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/invalid.pem", []byte("not a cert"), 0600))
+
+	t.Run("empty settings", func(t *testing.T) {
+		cfg, err := Settings{}.ClientConfig(fs)
+		require.NoError(t, err)
+		assert.Nil(t, cfg.RootCAs)
+		assert.Empty(t, cfg.Certificates)
+		assert.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	})
+
+	t.Run("CA certificates from a directory", func(t *testing.T) {
+		cfg, err := Settings{CACert: "/certs"}.ClientConfig(fs)
+		require.NoError(t, err)
+		require.NotNil(t, cfg.RootCAs)
+	})
+
+	t.Run("CA certificate file without certificates", func(t *testing.T) {
+		_, err := Settings{CACert: "/invalid.pem"}.ClientConfig(fs)
+		require.ErrorContains(t, err, "no certificates found")
+	})
+
+	t.Run("missing CA certificate", func(t *testing.T) {
+		_, err := Settings{CACert: "/missing.pem"}.ClientConfig(fs)
+		require.Error(t, err)
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		cfg, err := Settings{ClientCert: "/certs/ca.pem", ClientKey: "/certs/client.key"}.ClientConfig(fs)
+		require.NoError(t, err)
+		assert.Len(t, cfg.Certificates, 1)
+	})
+
+	t.Run("client certificate without key", func(t *testing.T) {
+		_, err := Settings{ClientCert: "/certs/ca.pem"}.ClientConfig(fs)
+		require.ErrorContains(t, err, "both a client certificate and key are required")
+	})
+}
+
+func TestConfig_Transport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/server.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	base := http.DefaultTransport.(*http.Transport)
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "untrusted server certificate",
+			cfg:     Config{},
+			wantErr: require.Error,
+		},
+		{
+			name:    "trusted CA",
+			cfg:     Config{Settings: Settings{CACert: "/server.pem"}},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "trusted CA for the host",
+			cfg:     Config{Hosts: []Host{{Host: serverURL.Hostname(), Settings: Settings{CACert: "/server.pem"}}}},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "verification skipped for the host",
+			cfg:     Config{Hosts: []Host{{Host: serverURL.Host, Settings: Settings{InsecureSkipVerify: true}}}},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "verification skipped for another host",
+			cfg:     Config{Hosts: []Host{{Host: "other.example.com", Settings: Settings{InsecureSkipVerify: true}}}},
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt, err := tt.cfg.Transport(fs, base)
+			require.NoError(t, err)
+
+			resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestConfig_Transport_Empty(t *testing.T) {
+	base := &http.Transport{}
+	rt, err := Config{}.Transport(afero.NewMemMapFs(), base)
+	require.NoError(t, err)
+	assert.Same(t, base, rt)
+}
+
+func TestConfig_Transport_InvalidHost(t *testing.T) {
+	_, err := Config{Hosts: []Host{{Settings: Settings{InsecureSkipVerify: true}}}}.Transport(afero.NewMemMapFs(), &http.Transport{})
+	require.ErrorContains(t, err, "require the host name")
+}
+
+func newTestCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -1,6 +1,6 @@
 -- Generated by grype/db/v6/schema
 -- DO NOT EDIT: This file is auto-generated. Run 'task generate-db-schema' to update.
--- Schema version: 6.1.9
+-- Schema version: 6.1.11
 
 CREATE TABLE `affected_cpe_handles` (`id` integer PRIMARY KEY AUTOINCREMENT,`vulnerability_id` integer NOT NULL,`cpe_id` integer,`blob_id` integer,CONSTRAINT `fk_affected_cpe_handles_cpe` FOREIGN KEY (`cpe_id`) REFERENCES `cpes`(`id`,CONSTRAINT `fk_affected_cpe_handles_vulnerability` FOREIGN KEY (`vulnerability_id`) REFERENCES `vulnerability_handles`(`id`);
 
@@ -20,8 +20,12 @@ CREATE TABLE `epss_handles` (`id` integer PRIMARY KEY AUTOINCREMENT,`cve` text N
 
 CREATE TABLE `epss_metadata` (`date` datetime NOT NULL);
 
+CREATE TABLE `java_class_fingerprints` (`id` integer PRIMARY KEY AUTOINCREMENT,`digest` text NOT NULL,`class_name` text NOT NULL,`group_id` text NOT NULL,`artifact_id` text NOT NULL,`version` text NOT NULL);
+
 CREATE TABLE `known_exploited_vulnerability_handles` (`id` integer PRIMARY KEY AUTOINCREMENT,`cve` text NOT NULL,`blob_id` integer);
 
+CREATE TABLE `malicious_artifact_handles` (`id` integer PRIMARY KEY AUTOINCREMENT,`vulnerability_id` integer NOT NULL,`purl` text,`digest` text,CONSTRAINT `fk_malicious_artifact_handles_vulnerability` FOREIGN KEY (`vulnerability_id`) REFERENCES `vulnerability_handles`(`id`);
+
 CREATE TABLE `operating_system_specifier_overrides` (`alias` text,`version` text,`version_pattern` text,`codename` text,`channel` text,`replacement` text,`replacement_major_version` text,`replacement_minor_version` text,`replacement_label_version` text,`replacement_channel` text,`rolling` numeric,`applicable_client_db_schemas` text,PRIMARY KEY (`alias`,`version`,`version_pattern`,`replacement`,`replacement_major_version`,`replacement_minor_version`,`replacement_label_version`,`replacement_channel`,`rolling`));
 
 CREATE TABLE `operating_systems` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text,`release_id` text,`major_version` text,`minor_version` text,`label_version` text,`codename` text,`channel` text,`eol_date` datetime,`eoas_date` datetime);
@@ -85,8 +89,14 @@ CREATE INDEX `idx_vulnerability_handles_published_date` ON `vulnerability_handle
 
 CREATE INDEX `idx_vulnerability_handles_withdrawn_date` ON `vulnerability_handles`(`withdrawn_date`);
 
+CREATE INDEX `java_class_fingerprints_digest_idx` ON `java_class_fingerprints`(`digest`);
+
 CREATE INDEX `kev_cve_idx` ON `known_exploited_vulnerability_handles`(`cve` COLLATE NOCASE);
 
+CREATE INDEX `malicious_artifact_handles_digest_idx` ON `malicious_artifact_handles`(`digest`);
+
+CREATE INDEX `malicious_artifact_handles_purl_idx` ON `malicious_artifact_handles`(`purl`);
+
 CREATE INDEX `os_alias_idx` ON `operating_system_specifier_overrides`(`alias` COLLATE NOCASE);
 
 CREATE INDEX `pkg_ecosystem_idx` ON `package_specifier_overrides`(`ecosystem` COLLATE NOCASE);